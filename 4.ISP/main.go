@@ -2,6 +2,8 @@ package main
 
 import "fmt"
 
+//go:generate go run ../cmd/rolematrix -o ROLES.md .
+
 //---------------------------------------------//Bad Practice//--------------------------------------------------------///
 
 //// Employee Fat interface – mixes many responsibilities
@@ -1,6 +1,12 @@
 package main
 
-import "fmt"
+import (
+	"fmt"
+	"time"
+
+	"go-solid/clock"
+	"go-solid/id"
+)
 
 //////////--------------------Bad Practice--------------------/////////////////////////
 
@@ -31,8 +37,10 @@ import "fmt"
 //////////////-----------------------------Good Practice-------------------/////////////////////////////////////////////////////////
 
 type Employee struct {
-	Name   string
-	Salary int
+	ID      string
+	Name    string
+	Salary  int
+	HiredAt time.Time
 }
 
 // EmployeeRepository Abstraction (interface) - both high and low level modules depend on this
@@ -45,7 +53,7 @@ type EmployeeRepository interface {
 type MySQLRepository struct{}
 
 func (db MySQLRepository) Save(emp Employee) error {
-	fmt.Printf("💾 Saving employee '%s' to MySQL database\n", emp.Name)
+	fmt.Printf("💾 Saving employee '%s' (%s) to MySQL database\n", emp.Name, emp.ID)
 	return nil
 }
 
@@ -58,7 +66,7 @@ func (db MySQLRepository) GetByName(name string) (Employee, error) {
 type PostgresRepository struct{}
 
 func (db PostgresRepository) Save(emp Employee) error {
-	fmt.Printf("💾 Saving employee '%s' to PostgreSQL database\n", emp.Name)
+	fmt.Printf("💾 Saving employee '%s' (%s) to PostgreSQL database\n", emp.Name, emp.ID)
 	return nil
 }
 
@@ -71,7 +79,7 @@ func (db PostgresRepository) GetByName(name string) (Employee, error) {
 type MongoRepository struct{}
 
 func (db MongoRepository) Save(emp Employee) error {
-	fmt.Printf("💾 Saving employee '%s' to MongoDB database\n", emp.Name)
+	fmt.Printf("💾 Saving employee '%s' (%s) to MongoDB database\n", emp.Name, emp.ID)
 	return nil
 }
 
@@ -83,9 +91,13 @@ func (db MongoRepository) GetByName(name string) (Employee, error) {
 // EmployeeManager High-level module - depends on abstraction (EmployeeRepository), not concrete types
 type EmployeeManager struct {
 	repository EmployeeRepository // ✅ Depends on abstraction, not concrete implementation
+	ids        id.Generator       // ✅ Even "ambient" dependencies like IDs and time are injected
+	clock      clock.Clock
 }
 
 func (em EmployeeManager) AddEmployee(emp Employee) {
+	emp.ID = em.ids.NewID()
+	emp.HiredAt = em.clock.Now()
 	err := em.repository.Save(emp)
 	if err != nil {
 		fmt.Println("Error saving employee:", err)
@@ -110,9 +122,12 @@ func main() {
 	ahmed := Employee{Name: "Ahmed", Salary: 6000}
 	ali := Employee{Name: "Ali", Salary: 4500}
 
+	// A sequential generator keeps the output predictable; use id.UUID{} in production
+	ids := id.NewSequence("emp-")
+
 	// Using MySQL
 	mysqlRepo := MySQLRepository{}
-	manager1 := EmployeeManager{repository: mysqlRepo}
+	manager1 := EmployeeManager{repository: mysqlRepo, ids: ids, clock: clock.Real{}}
 	manager1.AddEmployee(mohamed)
 	manager1.FindEmployee("Mohamed")
 
@@ -120,7 +135,7 @@ func main() {
 
 	// Using PostgreSQL
 	postgresRepo := PostgresRepository{}
-	manager2 := EmployeeManager{repository: postgresRepo}
+	manager2 := EmployeeManager{repository: postgresRepo, ids: ids, clock: clock.Real{}}
 	manager2.AddEmployee(ahmed)
 	manager2.FindEmployee("Ahmed")
 
@@ -128,7 +143,7 @@ func main() {
 
 	// Using MongoDB
 	mongoRepo := MongoRepository{}
-	manager3 := EmployeeManager{repository: mongoRepo}
+	manager3 := EmployeeManager{repository: mongoRepo, ids: ids, clock: clock.Real{}}
 	manager3.AddEmployee(ali)
 	manager3.FindEmployee("Ali")
 
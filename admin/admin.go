@@ -0,0 +1,210 @@
+// Package admin serves the reference application's operational endpoints:
+// which implementation is wired behind each abstraction, and the settings
+// that can be changed while the application runs.
+//
+// The application is wired by hand in main, not by a container; Wiring is
+// where main records what it wired, so the choices made there - and the
+// decorators stacked on each one - can be inspected at runtime.
+package admin
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"sync"
+)
+
+// Wrapper Optional capability - decorators and proxies that can say what
+// they wrap. Declared here, where it is consumed; implementations need no
+// import of this package.
+type Wrapper interface {
+	Wrapped() any
+}
+
+// Chain describes v and everything it wraps, outermost first, e.g.
+// ["*chaos.Repository", "hotswap.employees", "*memory.Repository"].
+func Chain(v any) []string {
+	var chain []string
+	// a bound keeps a wrapper that wraps itself from looping forever
+	for range 16 {
+		chain = append(chain, fmt.Sprintf("%T", v))
+		w, ok := v.(Wrapper)
+		if !ok {
+			break
+		}
+		if v = w.Wrapped(); v == nil {
+			break
+		}
+	}
+	return chain
+}
+
+// Setting Something an operator can change at runtime. Get returns a value
+// that marshals to JSON; Set receives the JSON of the new value.
+type Setting struct {
+	Name        string
+	Description string
+	Get         func() any
+	Set         func(value json.RawMessage) error
+}
+
+// LogLevel makes the level of every logger built on v a Setting, e.g.
+// slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: v})).
+func LogLevel(v *slog.LevelVar) Setting {
+	return Setting{
+		Name:        "log-level",
+		Description: "DEBUG, INFO, WARN or ERROR",
+		Get:         func() any { return v.Level().String() },
+		Set: func(value json.RawMessage) error {
+			var s string
+			if err := json.Unmarshal(value, &s); err != nil {
+				return err
+			}
+			var level slog.Level
+			if err := level.UnmarshalText([]byte(s)); err != nil {
+				return err
+			}
+			v.Set(level)
+			return nil
+		},
+	}
+}
+
+// Toggle An on/off Setting
+func Toggle(name, description string, get func() bool, set func(bool)) Setting {
+	return Setting{
+		Name:        name,
+		Description: description,
+		Get:         func() any { return get() },
+		Set: func(value json.RawMessage) error {
+			var on bool
+			if err := json.Unmarshal(value, &on); err != nil {
+				return err
+			}
+			set(on)
+			return nil
+		},
+	}
+}
+
+// ErrUnknownSetting returned when no setting has the name
+var ErrUnknownSetting = errors.New("unknown setting")
+
+// Binding What currently implements one abstraction
+type Binding struct {
+	Interface      string   `json:"interface"`
+	Implementation []string `json:"implementation"` // Chain of the bound value
+}
+
+type bound struct {
+	iface string
+	impl  any
+}
+
+// Wiring Registry of what main wired, and of the settings it exposes
+type Wiring struct {
+	mu       sync.Mutex
+	bindings []bound
+	settings []Setting
+}
+
+// Bind records impl as what the application uses for iface, a name like
+// "employee.Repository". Bound values are described when asked, so a
+// proxy that swaps its backend shows the current one.
+func (w *Wiring) Bind(iface string, impl any) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.bindings = append(w.bindings, bound{iface, impl})
+}
+
+// Expose makes s readable and changeable through the admin endpoint.
+func (w *Wiring) Expose(s Setting) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.settings = append(w.settings, s)
+}
+
+// Bindings describes every binding, in the order they were made.
+func (w *Wiring) Bindings() []Binding {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	out := make([]Binding, len(w.bindings))
+	for i, b := range w.bindings {
+		out[i] = Binding{Interface: b.iface, Implementation: Chain(b.impl)}
+	}
+	return out
+}
+
+// Settings returns the current value of every setting, by name.
+func (w *Wiring) Settings() map[string]any {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	values := make(map[string]any, len(w.settings))
+	for _, s := range w.settings {
+		values[s.Name] = s.Get()
+	}
+	return values
+}
+
+// Set changes the named setting and returns its new value.
+func (w *Wiring) Set(name string, value json.RawMessage) (any, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	for _, s := range w.settings {
+		if s.Name == name {
+			if err := s.Set(value); err != nil {
+				return nil, fmt.Errorf("%s: %w", name, err)
+			}
+			return s.Get(), nil
+		}
+	}
+	return nil, fmt.Errorf("%w %q", ErrUnknownSetting, name)
+}
+
+type wiringResponse struct {
+	Bindings []Binding      `json:"bindings"`
+	Settings map[string]any `json:"settings"`
+}
+
+// Handler serves
+//
+//	GET /admin/wiring              bindings and settings
+//	PUT /admin/settings/{name}     a setting's new value as JSON, e.g. "DEBUG" or true
+//
+// Mount it, and nothing else public, on the admin address.
+func Handler(w *Wiring) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /admin/wiring", func(rw http.ResponseWriter, r *http.Request) {
+		writeJSON(rw, http.StatusOK, wiringResponse{Bindings: w.Bindings(), Settings: w.Settings()})
+	})
+	mux.HandleFunc("PUT /admin/settings/{name}", func(rw http.ResponseWriter, r *http.Request) {
+		var value json.RawMessage
+		if err := json.NewDecoder(http.MaxBytesReader(rw, r.Body, 1<<16)).Decode(&value); err != nil {
+			writeJSON(rw, http.StatusBadRequest, errorBody{"invalid JSON body: " + err.Error()})
+			return
+		}
+		name := r.PathValue("name")
+		now, err := w.Set(name, value)
+		switch {
+		case errors.Is(err, ErrUnknownSetting):
+			writeJSON(rw, http.StatusNotFound, errorBody{err.Error()})
+		case err != nil:
+			writeJSON(rw, http.StatusBadRequest, errorBody{err.Error()})
+		default:
+			writeJSON(rw, http.StatusOK, map[string]any{name: now})
+		}
+	})
+	return mux
+}
+
+type errorBody struct {
+	Error string `json:"error"`
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
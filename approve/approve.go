@@ -0,0 +1,164 @@
+// Package approve checks multi-line output - reports, tables, rendered
+// text - against an approved copy kept next to the tests.
+//
+// The first run has nothing to compare with: the output is written to
+// testdata/<Test>.received.txt and the test fails. Once a person has read it
+// and renamed it to .approved.txt (or run the tests with APPROVE=1), later
+// runs compare against it, failing with a line diff when the output
+// changes. Reporters can also open the two files in a diff tool.
+package approve
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+
+	"go-solid/textdiff"
+)
+
+// Reporter Abstraction - shows a failed approval to whoever runs the tests
+type Reporter interface {
+	Report(approved, received string) error
+}
+
+// Command Reporter running a diff tool with the approved and received files
+// as its last two arguments, e.g. Command{"code", "--diff"}
+type Command []string
+
+func (c Command) Report(approved, received string) error {
+	if len(c) == 0 {
+		return nil
+	}
+	cmd := exec.Command(c[0], append(c[1:], approved, received)...)
+	cmd.Stdout, cmd.Stderr = os.Stderr, os.Stderr
+	return cmd.Start() // the tool outlives the test; don't wait for it
+}
+
+// FromEnv returns the Command in APPROVE_REPORTER ("meld", "code --diff"),
+// or nil when it is unset and the diff in the failure message is the report.
+func FromEnv() Reporter {
+	fields := strings.Fields(os.Getenv("APPROVE_REPORTER"))
+	if len(fields) == 0 {
+		return nil
+	}
+	return Command(fields)
+}
+
+type options struct {
+	name     string
+	ext      string
+	reporter Reporter
+	scrub    []func(string) string
+}
+
+// Option customises a Verify call
+type Option func(*options)
+
+// WithName distinguishes several verifications in one test.
+func WithName(name string) Option { return func(o *options) { o.name = name } }
+
+// WithReporter reports failures with r instead of the APPROVE_REPORTER one.
+func WithReporter(r Reporter) Option { return func(o *options) { o.reporter = r } }
+
+// WithScrubber replaces matches of re with repl before comparing, for parts
+// of the output that change on every run: timestamps, durations, IDs.
+func WithScrubber(re *regexp.Regexp, repl string) Option {
+	return func(o *options) {
+		o.scrub = append(o.scrub, func(s string) string { return re.ReplaceAllString(s, repl) })
+	}
+}
+
+// Verify compares got with the approved output of the test.
+func Verify(t testing.TB, got string, opts ...Option) {
+	t.Helper()
+	o := options{ext: ".txt", reporter: FromEnv()}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	verify(t, got, o)
+}
+
+// VerifyJSON compares v, as indented JSON, with the approved output of the test.
+func VerifyJSON(t testing.TB, v any, opts ...Option) {
+	t.Helper()
+	b, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		t.Fatalf("approve: %v", err)
+	}
+	o := options{ext: ".json", reporter: FromEnv()}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	verify(t, string(b), o)
+}
+
+var unsafeChars = regexp.MustCompile(`[^\w.-]+`)
+
+func verify(t testing.TB, got string, o options) {
+	t.Helper()
+	for _, scrub := range o.scrub {
+		got = scrub(got)
+	}
+	got = strings.ReplaceAll(got, "\r\n", "\n")
+	if !strings.HasSuffix(got, "\n") {
+		got += "\n"
+	}
+	base := unsafeChars.ReplaceAllString(t.Name(), "_")
+	if o.name != "" {
+		base += "." + unsafeChars.ReplaceAllString(o.name, "_")
+	}
+	approved := filepath.Join("testdata", base+".approved"+o.ext)
+	received := filepath.Join("testdata", base+".received"+o.ext)
+
+	if os.Getenv("APPROVE") == "1" {
+		if err := write(approved, got); err != nil {
+			t.Fatalf("approve: %v", err)
+		}
+		_ = os.Remove(received)
+		t.Logf("approve: wrote %s", approved)
+		return
+	}
+	want, err := os.ReadFile(approved)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		t.Fatalf("approve: %v", err)
+	}
+	if string(want) == got {
+		_ = os.Remove(received) // left over from an earlier failure
+		return
+	}
+	if err := write(received, got); err != nil {
+		t.Fatalf("approve: %v", err)
+	}
+
+	var msg bytes.Buffer
+	if want == nil {
+		fmt.Fprintf(&msg, "approve: nothing approved yet; check %s\n", received)
+	} else {
+		fmt.Fprintf(&msg, "approve: output differs from %s\n", approved)
+		_ = textdiff.Unified(&msg, approved, received, string(want), got)
+	}
+	fmt.Fprintf(&msg, "to approve: mv %s %s, or run with APPROVE=1", received, approved)
+	if o.reporter != nil && want != nil {
+		if err := o.reporter.Report(approved, received); err != nil {
+			fmt.Fprintf(&msg, "\n(reporter failed: %v)", err)
+		}
+	}
+	t.Error(msg.String())
+}
+
+func write(path, content string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, []byte(content), 0o644)
+}
+
+var _ Reporter = Command(nil)
@@ -0,0 +1,137 @@
+// Package assertlsp checks that two implementations of an abstraction
+// behave the same: a script of calls runs against each, and every result,
+// error and panic is compared.
+//
+// Substitutability (LSP) is about behaviour, not method sets - the compiler
+// already checks those. A decorator that wraps ErrNotFound with %v instead
+// of %w, or a cache that returns a stale copy, still compiles:
+//
+//	assertlsp.SameBehavior[employee.Repository](t, memory.New(), cached,
+//		assertlsp.Exec("save Ali", func(r employee.Repository) error { return r.Save(ctx, ali) }),
+//		assertlsp.Try("get Ali", func(r employee.Repository) (any, error) { return r.GetByName(ctx, "Ali") }),
+//		assertlsp.Try("get Bob", func(r employee.Repository) (any, error) { return r.GetByName(ctx, "Bob") }),
+//	)
+//
+// Steps are closures over the shared interface, so they are type-checked
+// and can call unexported methods.
+package assertlsp
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+// Step One call of a script, written against the interface both
+// implementations share
+type Step[T any] struct {
+	Name string
+	Do   func(impl T) (any, error)
+}
+
+// Call is a step for a method returning a value and no error.
+func Call[T any](name string, do func(T) any) Step[T] {
+	return Step[T]{Name: name, Do: func(impl T) (any, error) { return do(impl), nil }}
+}
+
+// Try is a step for a method returning a value and an error.
+func Try[T any](name string, do func(T) (any, error)) Step[T] {
+	return Step[T]{Name: name, Do: do}
+}
+
+// Exec is a step for a method returning only an error.
+func Exec[T any](name string, do func(T) error) Step[T] {
+	return Step[T]{Name: name, Do: func(impl T) (any, error) { return nil, do(impl) }}
+}
+
+// Outcome What one step did on one implementation
+type Outcome struct {
+	Value any
+	Err   error
+	Panic any
+}
+
+func (o Outcome) String() string {
+	switch {
+	case o.Panic != nil:
+		return fmt.Sprintf("panic: %v", o.Panic)
+	case o.Err != nil:
+		return fmt.Sprintf("error: %v", o.Err)
+	case o.Value == nil:
+		return "ok"
+	}
+	return fmt.Sprintf("%+v", o.Value)
+}
+
+// Difference A step whose outcomes differed
+type Difference struct {
+	Step int // 1-based
+	Name string
+	A, B Outcome
+}
+
+func (d Difference) String() string {
+	return fmt.Sprintf("step %d (%s):\n   a: %s\n   b: %s", d.Step, d.Name, d.A, d.B)
+}
+
+// Compare runs script against a and then b, each from its first step to its
+// last, and returns the steps whose outcomes differ. Values are compared
+// with reflect.DeepEqual. Errors match when either wraps the other (so
+// errors.Is holds for the sentinel) or their messages are equal; callers
+// rely on which error they get, not on its wording beyond that.
+func Compare[T any](a, b T, script ...Step[T]) []Difference {
+	outA, outB := run(a, script), run(b, script)
+	var diffs []Difference
+	for i, s := range script {
+		if !same(outA[i], outB[i]) {
+			diffs = append(diffs, Difference{Step: i + 1, Name: s.Name, A: outA[i], B: outB[i]})
+		}
+	}
+	return diffs
+}
+
+// SameBehavior fails t for every step where a and b behave differently.
+// T is usually given explicitly, since a and b have different concrete types.
+func SameBehavior[T any](t testing.TB, a, b T, script ...Step[T]) {
+	t.Helper()
+	for _, d := range Compare(a, b, script...) {
+		t.Errorf("%T and %T differ at %s", a, b, d)
+	}
+}
+
+func run[T any](impl T, script []Step[T]) []Outcome {
+	outcomes := make([]Outcome, len(script))
+	for i, s := range script {
+		outcomes[i] = step(impl, s)
+	}
+	return outcomes
+}
+
+// step runs one step, turning a panic into an outcome: one implementation
+// panicking where the other returns is a difference like any other.
+func step[T any](impl T, s Step[T]) (o Outcome) {
+	defer func() {
+		if p := recover(); p != nil {
+			o = Outcome{Panic: p}
+		}
+	}()
+	v, err := s.Do(impl)
+	return Outcome{Value: v, Err: err}
+}
+
+func same(a, b Outcome) bool {
+	if (a.Panic != nil) != (b.Panic != nil) {
+		return false
+	}
+	if a.Panic != nil {
+		return fmt.Sprint(a.Panic) == fmt.Sprint(b.Panic)
+	}
+	if (a.Err != nil) != (b.Err != nil) {
+		return false
+	}
+	if a.Err != nil && !errors.Is(a.Err, b.Err) && !errors.Is(b.Err, a.Err) && a.Err.Error() != b.Err.Error() {
+		return false
+	}
+	return reflect.DeepEqual(a.Value, b.Value)
+}
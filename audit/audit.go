@@ -0,0 +1,83 @@
+// Package audit records who did what, and when, to the employee domain.
+//
+// Auditing is a separate responsibility from the business rules it observes
+// (SRP): the employee.Manager only builds a Record and hands it to a Sink. Where
+// the record ends up - stdout, a file, a SQL table - and whether it is hash
+// chained is decided by whoever wires the application together.
+package audit
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+type Outcome string
+
+const (
+	Success Outcome = "success"
+	Failure Outcome = "failure"
+)
+
+// Record Structured description of one operation
+type Record struct {
+	Seq      uint64         `json:"seq,omitempty"`
+	Time     time.Time      `json:"time"`
+	Actor    string         `json:"actor,omitempty"`
+	Action   string         `json:"action"`
+	Entity   string         `json:"entity"`
+	EntityID string         `json:"entity_id,omitempty"`
+	Details  map[string]any `json:"details,omitempty"`
+	Outcome  Outcome        `json:"outcome"`
+	Error    string         `json:"error,omitempty"`
+	PrevHash string         `json:"prev_hash,omitempty"`
+	Hash     string         `json:"hash,omitempty"`
+}
+
+// Sink Abstraction - destination for audit records
+type Sink interface {
+	Write(ctx context.Context, rec Record) error
+}
+
+// Memory Sink that keeps records in memory, useful in tests and demos
+type Memory struct {
+	mu      sync.Mutex
+	records []Record
+}
+
+func (m *Memory) Write(_ context.Context, rec Record) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.records = append(m.records, rec)
+	return nil
+}
+
+func (m *Memory) Tail(context.Context) (uint64, string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if len(m.records) == 0 {
+		return 0, "", nil
+	}
+	last := m.records[len(m.records)-1]
+	return last.Seq, last.Hash, nil
+}
+
+// Records returns a copy of everything written so far.
+func (m *Memory) Records() []Record {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]Record(nil), m.records...)
+}
+
+type actorKey struct{}
+
+// WithActor attaches the acting user to ctx so records can name them.
+func WithActor(ctx context.Context, actor string) context.Context {
+	return context.WithValue(ctx, actorKey{}, actor)
+}
+
+// ActorFrom returns the actor stored by WithActor, or "" if there is none.
+func ActorFrom(ctx context.Context) string {
+	actor, _ := ctx.Value(actorKey{}).(string)
+	return actor
+}
@@ -0,0 +1,106 @@
+package audit
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sync"
+)
+
+// Chain Decorator - makes any Sink tamper-evident by numbering records and
+// linking each one to the hash of its predecessor. Editing, removing or
+// reordering a stored record breaks every hash that follows it.
+type Chain struct {
+	mu   sync.Mutex
+	next Sink
+	seq  uint64
+	prev string
+}
+
+// NewChain starts a new chain in next, at sequence 1.
+func NewChain(next Sink) *Chain { return &Chain{next: next} }
+
+// Tail Optional capability - sinks that can read back where the chain they
+// store ends, so a Chain over them resumes it after a restart
+type Tail interface {
+	// Tail returns the Seq and Hash of the last record stored, or 0 and ""
+	// when there is none.
+	Tail(ctx context.Context) (seq uint64, hash string, err error)
+}
+
+// ResumeChain continues the chain next already holds: after its last record
+// when next is a Tail, at sequence 1 otherwise. An append-only sink outlives
+// the process writing to it, and a chain started afresh over it would no
+// longer Verify.
+func ResumeChain(ctx context.Context, next Sink) (*Chain, error) {
+	c := NewChain(next)
+	t, ok := next.(Tail)
+	if !ok {
+		return c, nil
+	}
+	seq, hash, err := t.Tail(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("audit: resume chain: %w", err)
+	}
+	c.seq, c.prev = seq, hash
+	return c, nil
+}
+
+func (c *Chain) Write(ctx context.Context, rec Record) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	rec.Seq = c.seq + 1
+	rec.PrevHash = c.prev
+	hash, err := hashRecord(rec)
+	if err != nil {
+		return err
+	}
+	rec.Hash = hash
+
+	if err := c.next.Write(ctx, rec); err != nil {
+		return err
+	}
+	c.seq, c.prev = rec.Seq, rec.Hash
+	return nil
+}
+
+// Verify checks that records form an unbroken chain starting at sequence 1.
+func Verify(records []Record) error {
+	prev := ""
+	for i, rec := range records {
+		if rec.Seq != uint64(i+1) {
+			return fmt.Errorf("audit: record %d: expected seq %d, got %d", i, i+1, rec.Seq)
+		}
+		if rec.PrevHash != prev {
+			return fmt.Errorf("audit: record %d: broken link to previous record", rec.Seq)
+		}
+		want, err := hashRecord(rec)
+		if err != nil {
+			return err
+		}
+		if rec.Hash != want {
+			return fmt.Errorf("audit: record %d: hash mismatch, record was modified", rec.Seq)
+		}
+		prev = rec.Hash
+	}
+	return nil
+}
+
+func hashRecord(rec Record) (string, error) {
+	rec.Hash = ""
+	b, err := json.Marshal(rec)
+	if err != nil {
+		return "", fmt.Errorf("audit: hash record: %w", err)
+	}
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+var (
+	_ Tail = (*Memory)(nil)
+	_ Tail = (*FileSink)(nil)
+	_ Tail = (*SQLSink)(nil)
+)
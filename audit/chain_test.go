@@ -0,0 +1,116 @@
+package audit_test
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"go-solid/audit"
+)
+
+func record(action string) audit.Record {
+	return audit.Record{Time: time.Date(2026, time.March, 2, 9, 0, 0, 0, time.UTC), Action: action, Entity: "employee", Outcome: audit.Success}
+}
+
+func TestResumeChain_AcrossRestarts(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+	for run := range 3 {
+		sink, err := audit.NewFileSink(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		chain, err := audit.ResumeChain(t.Context(), sink)
+		if err != nil {
+			t.Fatalf("run %d: ResumeChain() error = %v", run+1, err)
+		}
+		for _, action := range []string{"employee.added", "employee.promoted"} {
+			if err := chain.Write(t.Context(), record(action)); err != nil {
+				t.Fatalf("run %d: Write() error = %v", run+1, err)
+			}
+		}
+		if err := sink.Close(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	records := read(t, path)
+	if len(records) != 6 {
+		t.Fatalf("%d records stored, want 6", len(records))
+	}
+	if err := audit.Verify(records); err != nil {
+		t.Errorf("Verify() after restarts = %v", err)
+	}
+}
+
+func TestNewChain_RestartedBreaksTheChain(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+	for range 2 {
+		sink, _ := audit.NewFileSink(path)
+		_ = audit.NewChain(sink).Write(t.Context(), record("employee.added"))
+		sink.Close()
+	}
+	if err := audit.Verify(read(t, path)); err == nil {
+		t.Error("Verify() = nil, want two chains starting at 1 to be refused")
+	}
+}
+
+func TestResumeChain_Memory(t *testing.T) {
+	sink := &audit.Memory{}
+	first := audit.NewChain(sink)
+	_ = first.Write(t.Context(), record("employee.added"))
+	resumed, err := audit.ResumeChain(t.Context(), sink)
+	if err != nil {
+		t.Fatalf("ResumeChain() error = %v", err)
+	}
+	_ = resumed.Write(t.Context(), record("employee.removed"))
+	if err := audit.Verify(sink.Records()); err != nil {
+		t.Errorf("Verify() = %v", err)
+	}
+}
+
+func TestVerify_Tampering(t *testing.T) {
+	sink := &audit.Memory{}
+	chain := audit.NewChain(sink)
+	for _, action := range []string{"employee.added", "employee.promoted", "employee.removed"} {
+		_ = chain.Write(t.Context(), record(action))
+	}
+	tests := []struct {
+		name   string
+		tamper func([]audit.Record) []audit.Record
+	}{
+		{"edited", func(r []audit.Record) []audit.Record { r[1].Action = "employee.demoted"; return r }},
+		{"removed", func(r []audit.Record) []audit.Record { return append(r[:1], r[2:]...) }},
+		{"reordered", func(r []audit.Record) []audit.Record { r[1], r[2] = r[2], r[1]; return r }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := audit.Verify(tt.tamper(sink.Records())); err == nil {
+				t.Error("Verify() = nil, want the tampering caught")
+			}
+		})
+	}
+	if err := audit.Verify(sink.Records()); err != nil {
+		t.Errorf("Verify() of the untouched chain = %v", err)
+	}
+}
+
+func read(t *testing.T, path string) []audit.Record {
+	t.Helper()
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	var records []audit.Record
+	for sc := bufio.NewScanner(f); sc.Scan(); {
+		var rec audit.Record
+		if err := json.Unmarshal(sc.Bytes(), &rec); err != nil {
+			t.Fatal(err)
+		}
+		records = append(records, rec)
+	}
+	return records
+}
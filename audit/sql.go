@@ -0,0 +1,68 @@
+package audit
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"go-solid/sqldialect"
+)
+
+// SQLSink Low-level module - inserts records into a SQL table:
+//
+//	CREATE TABLE audit_log (
+//	    seq       BIGINT,
+//	    time      TIMESTAMP NOT NULL,
+//	    actor     TEXT,
+//	    action    TEXT NOT NULL,
+//	    entity    TEXT NOT NULL,
+//	    entity_id TEXT,
+//	    details   TEXT,
+//	    outcome   TEXT NOT NULL,
+//	    error     TEXT,
+//	    prev_hash TEXT,
+//	    hash      TEXT
+//	);
+type SQLSink struct {
+	db     *sql.DB
+	insert string
+	tail   string
+}
+
+func NewSQLSink(db *sql.DB, dialect sqldialect.Dialect, table string) *SQLSink {
+	query := fmt.Sprintf(`INSERT INTO %s
+		(seq, time, actor, action, entity, entity_id, details, outcome, error, prev_hash, hash)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`, table)
+	tail := fmt.Sprintf(`SELECT seq, hash FROM %s WHERE seq IS NOT NULL ORDER BY seq DESC LIMIT 1`, table)
+	return &SQLSink{db: db, insert: dialect.Rebind(query), tail: tail}
+}
+
+func (s *SQLSink) Write(ctx context.Context, rec Record) error {
+	details, err := json.Marshal(rec.Details)
+	if err != nil {
+		return fmt.Errorf("audit: encode details: %w", err)
+	}
+	_, err = s.db.ExecContext(ctx, s.insert,
+		rec.Seq, rec.Time, rec.Actor, rec.Action, rec.Entity, rec.EntityID,
+		string(details), string(rec.Outcome), rec.Error, rec.PrevHash, rec.Hash)
+	if err != nil {
+		return fmt.Errorf("audit: insert record: %w", err)
+	}
+	return nil
+}
+
+// Tail reads the highest seq in the table, and its hash.
+func (s *SQLSink) Tail(ctx context.Context) (uint64, string, error) {
+	var seq uint64
+	var hash sql.NullString
+	err := s.db.QueryRowContext(ctx, s.tail).Scan(&seq, &hash)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, "", nil
+	}
+	if err != nil {
+		return 0, "", fmt.Errorf("audit: read tail: %w", err)
+	}
+	return seq, hash.String, nil
+}
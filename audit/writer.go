@@ -0,0 +1,58 @@
+package audit
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"go-solid/jsonl"
+)
+
+// WriterSink Low-level module - writes records as JSON lines to any io.Writer
+type WriterSink struct {
+	w *jsonl.Writer
+}
+
+func NewWriterSink(w io.Writer) *WriterSink { return &WriterSink{w: jsonl.NewWriter(w)} }
+
+// NewStdoutSink writes JSON lines to standard output.
+func NewStdoutSink() *WriterSink { return NewWriterSink(os.Stdout) }
+
+func (s *WriterSink) Write(_ context.Context, rec Record) error { return s.w.Write(rec) }
+
+// FileSink Low-level module - appends JSON lines to a file
+type FileSink struct {
+	f *jsonl.File
+}
+
+// NewFileSink opens (or creates) path for appending.
+func NewFileSink(path string) (*FileSink, error) {
+	f, err := jsonl.Append(path)
+	if err != nil {
+		return nil, err
+	}
+	return &FileSink{f: f}, nil
+}
+
+func (s *FileSink) Write(_ context.Context, rec Record) error { return s.f.Write(rec) }
+
+func (s *FileSink) Close() error { return s.f.Close() }
+
+// Tail reads the file's last record back. It reads what is on disk, so call
+// it before writing, as ResumeChain does.
+func (s *FileSink) Tail(context.Context) (uint64, string, error) {
+	f, err := os.Open(s.f.Name())
+	if err != nil {
+		return 0, "", err
+	}
+	defer f.Close()
+	var last Record
+	for rec, err := range jsonl.Read[Record](f) {
+		if err != nil {
+			return 0, "", fmt.Errorf("%s: %w", s.f.Name(), err)
+		}
+		last = rec
+	}
+	return last.Seq, last.Hash, nil
+}
@@ -0,0 +1,211 @@
+package bench
+
+import (
+	"cmp"
+	"go/parser"
+	"go/token"
+	"os"
+	"reflect"
+	"runtime"
+	"slices"
+	"strings"
+	"testing"
+
+	"go-solid/metrics"
+)
+
+// Site Where some of a benchmark's allocations happened: the innermost frame
+// in this module's own code, so an allocation inside fmt.Errorf is charged
+// to the line that called it
+type Site struct {
+	Function string
+	File     string
+	Line     int
+	Bytes    int64 // allocated there over the whole profile
+	Objects  int64
+	// Complexity of the function declaration holding Line; zero when the
+	// source can't be read or Line is in a function literal outside one
+	Cyclomatic, Cognitive int
+	// Source is Line with up to two lines either side, from SourceLine on
+	Source     []string
+	SourceLine int
+}
+
+// Allocations One side of a pair, profiled
+type Allocations struct {
+	Pair   Pair
+	Side   string // "bad" or "good"
+	Result testing.BenchmarkResult
+	// Bytes and Objects count everything allocated while the side ran,
+	// in this module's code or not
+	Bytes, Objects int64
+	Sites          []Site // the top sites, most bytes first
+}
+
+// Share is the fraction of the side's allocated bytes that s accounts for.
+func (a Allocations) Share(s Site) float64 {
+	if a.Bytes == 0 {
+		return 0
+	}
+	return float64(s.Bytes) / float64(a.Bytes)
+}
+
+// module is this module's path, "go-solid", for telling its frames apart
+var module = strings.TrimSuffix(reflect.TypeFor[Pair]().PkgPath(), "/bench")
+
+// ProfileAllocs benchmarks both sides of every pair opts selects with every
+// allocation sampled, the way go test -memprofile -memprofilerate=1 would,
+// and returns each side's top allocation sites, annotated with the source
+// line and its function's complexity.
+func ProfileAllocs(opts Options, top int) ([]Allocations, error) {
+	if err := setBenchtime(opts.Benchtime); err != nil {
+		return nil, err
+	}
+	pairs, err := selected(opts.Principle)
+	if err != nil {
+		return nil, err
+	}
+	defer func(rate int) { runtime.MemProfileRate = rate }(runtime.MemProfileRate)
+	runtime.MemProfileRate = 1
+
+	src := sources{}
+	var all []Allocations
+	for _, p := range pairs {
+		if opts.Progress != nil {
+			opts.Progress(p)
+		}
+		for _, side := range []struct {
+			name string
+			fn   func(*testing.B)
+		}{{"bad", p.Bad}, {"good", p.Good}} {
+			a := profile(side.fn, top)
+			a.Pair, a.Side = p, side.name
+			for i := range a.Sites {
+				src.annotate(&a.Sites[i])
+			}
+			all = append(all, a)
+		}
+	}
+	return all, nil
+}
+
+// profile runs fn and charges what it allocated to sites, from the
+// difference between the memory profile before and after.
+func profile(fn func(*testing.B), top int) Allocations {
+	before := memProfile()
+	result := testing.Benchmark(fn)
+	after := memProfile()
+
+	a := Allocations{Result: result}
+	type at struct {
+		file string
+		line int
+	}
+	bySite := map[at]*Site{}
+	for stack, now := range after {
+		was := before[stack]
+		bytes, objects := now.AllocBytes-was.AllocBytes, now.AllocObjects-was.AllocObjects
+		if bytes <= 0 {
+			continue
+		}
+		site, ok := moduleFrame(now.Stack())
+		if ok && (site.Function == "bench.profile" || site.Function == "bench.memProfile") {
+			continue // the profiler's own snapshots
+		}
+		a.Bytes += bytes
+		a.Objects += objects
+		if !ok {
+			continue
+		}
+		key := at{site.File, site.Line}
+		if s, ok := bySite[key]; ok {
+			s.Bytes += bytes
+			s.Objects += objects
+			continue
+		}
+		site.Bytes, site.Objects = bytes, objects
+		bySite[key] = &site
+	}
+	for _, s := range bySite {
+		a.Sites = append(a.Sites, *s)
+	}
+	slices.SortFunc(a.Sites, func(x, y Site) int {
+		return cmp.Or(cmp.Compare(y.Bytes, x.Bytes), cmp.Compare(x.File, y.File), cmp.Compare(x.Line, y.Line))
+	})
+	if top > 0 && len(a.Sites) > top {
+		a.Sites = a.Sites[:top]
+	}
+	return a
+}
+
+// memProfile returns the allocation profile by stack. The runtime publishes
+// allocations at the end of a garbage collection, so it forces two.
+func memProfile() map[[32]uintptr]runtime.MemProfileRecord {
+	runtime.GC()
+	runtime.GC()
+	var records []runtime.MemProfileRecord
+	n, _ := runtime.MemProfile(nil, true)
+	for {
+		records = make([]runtime.MemProfileRecord, n+50)
+		var ok bool
+		if n, ok = runtime.MemProfile(records, true); ok {
+			break
+		}
+	}
+	byStack := make(map[[32]uintptr]runtime.MemProfileRecord, n)
+	for _, r := range records[:n] {
+		byStack[r.Stack0] = r
+	}
+	return byStack
+}
+
+// moduleFrame returns the innermost frame of stack in this module.
+func moduleFrame(stack []uintptr) (Site, bool) {
+	frames := runtime.CallersFrames(stack)
+	for {
+		f, more := frames.Next()
+		if strings.HasPrefix(f.Function, module+"/") {
+			return Site{Function: strings.TrimPrefix(f.Function, module+"/"), File: f.File, Line: f.Line}, true
+		}
+		if !more {
+			return Site{}, false
+		}
+	}
+}
+
+// sources Parsed source files, for the lines and complexity of sites
+type sources map[string]*source
+
+type source struct {
+	lines []string
+	funcs []metrics.Function
+}
+
+// annotate fills in s's source and its function's complexity; a file that
+// can't be read - a binary built with -trimpath - leaves them empty.
+func (src sources) annotate(s *Site) {
+	f, ok := src[s.File]
+	if !ok {
+		f = &source{}
+		src[s.File] = f
+		data, err := os.ReadFile(s.File)
+		if err != nil {
+			return
+		}
+		f.lines = strings.Split(string(data), "\n")
+		fset := token.NewFileSet()
+		if file, err := parser.ParseFile(fset, s.File, data, parser.SkipObjectResolution); err == nil {
+			f.funcs = metrics.Analyze(fset, file)
+		}
+	}
+	if s.Line < 1 || s.Line > len(f.lines) {
+		return
+	}
+	first, last := max(s.Line-2, 1), min(s.Line+2, len(f.lines))
+	s.Source, s.SourceLine = f.lines[first-1:last], first
+	for _, fn := range f.funcs {
+		if fn.Line <= s.Line && s.Line < fn.Line+fn.Lines {
+			s.Cyclomatic, s.Cognitive = fn.Cyclomatic, fn.Cognitive
+		}
+	}
+}
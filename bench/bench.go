@@ -0,0 +1,130 @@
+// Package bench pairs the bad and the good version of each principle's code
+// as benchmarks, so the cost of a refactoring is measured rather than
+// assumed. Some refactorings are free, some are faster, and some cost an
+// indirect call or an allocation; the table shows which.
+//
+// The benchmarks are ordinary func(*testing.B) run with testing.Benchmark,
+// so they work from the solid command without go test. Sample measures them
+// repeatedly for a History keyed by commit, and Compare tells a regression
+// between two commits from noise.
+package bench
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+	"text/tabwriter"
+	"time"
+)
+
+// Pair The same work done by the code before and after a refactoring
+type Pair struct {
+	Principle string // "srp", "ocp", ... as in the lessons
+	Name      string
+	Note      string // what differs, and why it might cost
+	Bad, Good func(b *testing.B)
+}
+
+// Pairs One or more per principle, in lesson order
+var Pairs = []Pair{srpPair, ocpPair, payslipPair, lspPair, payrollPair, ispPair, dipPair}
+
+// Result A pair and how each side performed
+type Result struct {
+	Pair
+	Bad, Good testing.BenchmarkResult
+}
+
+// Options How Run measures
+type Options struct {
+	// Principle limits the run to one principle; empty runs every pair
+	Principle string
+	// Benchtime per benchmark; 0 keeps testing's default of one second
+	Benchtime time.Duration
+	// Progress, when set, is called before each pair runs
+	Progress func(Pair)
+}
+
+// Run benchmarks both sides of every pair opts selects.
+func Run(opts Options) ([]Result, error) {
+	if err := setBenchtime(opts.Benchtime); err != nil {
+		return nil, err
+	}
+	pairs, err := selected(opts.Principle)
+	if err != nil {
+		return nil, err
+	}
+	var results []Result
+	for _, p := range pairs {
+		if opts.Progress != nil {
+			opts.Progress(p)
+		}
+		results = append(results, Result{Pair: p, Bad: testing.Benchmark(p.Bad), Good: testing.Benchmark(p.Good)})
+	}
+	return results, nil
+}
+
+func setBenchtime(d time.Duration) error {
+	if d <= 0 {
+		return nil
+	}
+	// testing.Benchmark only reads its duration from the test flags
+	testing.Init()
+	return flag.Set("test.benchtime", d.String())
+}
+
+// selected returns the pairs of principle, or all of them when it is empty.
+func selected(principle string) ([]Pair, error) {
+	var pairs []Pair
+	for _, p := range Pairs {
+		if principle == "" || strings.EqualFold(principle, p.Principle) {
+			pairs = append(pairs, p)
+		}
+	}
+	if len(pairs) == 0 {
+		return nil, fmt.Errorf("no benchmarks for principle %q", principle)
+	}
+	return pairs, nil
+}
+
+// WriteTable renders one row per pair: time, bytes and allocations per
+// operation, bad → good, with the change in percent.
+func WriteTable(w io.Writer, results []Result) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "principle\tbenchmark\tns/op\tB/op\tallocs/op")
+	for _, r := range results {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n", r.Principle, r.Name,
+			delta(nsPerOp(r.Bad), nsPerOp(r.Good), "%.1f"),
+			delta(float64(r.Bad.AllocedBytesPerOp()), float64(r.Good.AllocedBytesPerOp()), "%.0f"),
+			delta(float64(r.Bad.AllocsPerOp()), float64(r.Good.AllocsPerOp()), "%.0f"))
+	}
+	return tw.Flush()
+}
+
+// WriteNotes lists what each pair measures.
+func WriteNotes(w io.Writer, results []Result) {
+	for _, r := range results {
+		fmt.Fprintf(w, "%s %s: %s\n", r.Principle, r.Name, r.Note)
+	}
+}
+
+// nsPerOp is NsPerOp without rounding to whole nanoseconds, which hides
+// most of what a single call costs.
+func nsPerOp(r testing.BenchmarkResult) float64 {
+	if r.N == 0 {
+		return 0
+	}
+	return float64(r.T.Nanoseconds()) / float64(r.N)
+}
+
+func delta(bad, good float64, format string) string {
+	s := fmt.Sprintf(format+" → "+format, bad, good)
+	switch {
+	case bad == good:
+		return s + " (~)"
+	case bad == 0:
+		return s + " (new)"
+	}
+	return s + fmt.Sprintf(" (%+.0f%%)", (good-bad)/bad*100)
+}
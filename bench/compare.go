@@ -0,0 +1,217 @@
+package bench
+
+import (
+	"fmt"
+	"io"
+	"maps"
+	"math"
+	"slices"
+	"text/tabwriter"
+)
+
+// Significance When a difference between two commits counts, in the manner
+// of benchstat: the samples must differ by a Mann-Whitney U test at Alpha,
+// and the medians by at least MinChange
+type Significance struct {
+	Alpha     float64
+	MinChange float64 // a fraction: 0.05 is 5%
+}
+
+// DefaultSignificance p < 0.05, and a change of 5% or more
+var DefaultSignificance = Significance{Alpha: 0.05, MinChange: 0.05}
+
+// Summary The median of some samples and their spread: the largest
+// deviation from the median, as a fraction of it
+type Summary struct {
+	Median float64
+	Spread float64
+	N      int
+}
+
+func summarise(xs []float64) Summary {
+	if len(xs) == 0 {
+		return Summary{}
+	}
+	s := slices.Sorted(slices.Values(xs))
+	med := s[len(s)/2]
+	if len(s)%2 == 0 {
+		med = (s[len(s)/2-1] + s[len(s)/2]) / 2
+	}
+	spread := 0.0
+	if med != 0 {
+		spread = max(med-s[0], s[len(s)-1]-med) / med
+	}
+	return Summary{Median: med, Spread: spread, N: len(s)}
+}
+
+// Change One metric of one benchmark, from a base commit to a head commit
+type Change struct {
+	Name        string
+	Metric      string // "ns/op", "B/op" or "allocs/op"
+	Base, Head  Summary
+	Delta       float64 // (head - base) / base, of the medians
+	P           float64
+	Significant bool
+}
+
+// Regression reports a significant change for the worse; for every metric
+// recorded, more is worse.
+func (c Change) Regression() bool { return c.Significant && c.Delta > 0 }
+
+// Compare sets every benchmark recorded at both commits side by side, metric
+// by metric, sorted by name. Benchmarks recorded at only one are left out.
+func Compare(base, head Record, sig Significance) []Change {
+	var changes []Change
+	for _, name := range slices.Sorted(maps.Keys(head.Benchmarks)) {
+		b, ok := base.Benchmarks[name]
+		if !ok {
+			continue
+		}
+		h := head.Benchmarks[name]
+		for _, m := range []struct {
+			metric     string
+			base, head []float64
+		}{
+			{"ns/op", b.NsPerOp, h.NsPerOp},
+			{"B/op", b.BytesPerOp, h.BytesPerOp},
+			{"allocs/op", b.AllocsPerOp, h.AllocsPerOp},
+		} {
+			c := Change{Name: name, Metric: m.metric, Base: summarise(m.base), Head: summarise(m.head), P: MannWhitney(m.base, m.head)}
+			switch {
+			case c.Base.Median != 0:
+				c.Delta = (c.Head.Median - c.Base.Median) / c.Base.Median
+			case c.Head.Median != 0:
+				c.Delta = math.Inf(1)
+			}
+			c.Significant = c.P < sig.Alpha && math.Abs(c.Delta) >= sig.MinChange
+			changes = append(changes, c)
+		}
+	}
+	return changes
+}
+
+// MannWhitney returns the two-sided p-value of a Mann-Whitney U test: how
+// likely samples this far apart would be if x and y came from the same
+// distribution. Without ties it is exact; with ties it uses the normal
+// approximation, corrected for them. Identical samples give 1.
+func MannWhitney(x, y []float64) float64 {
+	n1, n2 := len(x), len(y)
+	if n1 == 0 || n2 == 0 {
+		return 1
+	}
+	type obs struct {
+		v     float64
+		fromX bool
+	}
+	all := make([]obs, 0, n1+n2)
+	for _, v := range x {
+		all = append(all, obs{v, true})
+	}
+	for _, v := range y {
+		all = append(all, obs{v, false})
+	}
+	slices.SortFunc(all, func(a, b obs) int {
+		switch {
+		case a.v < b.v:
+			return -1
+		case a.v > b.v:
+			return 1
+		}
+		return 0
+	})
+	// midranks for ties; ties also shrink the variance of U
+	rankX, tieTerm, ties := 0.0, 0.0, false
+	for i := 0; i < len(all); {
+		j := i
+		for j < len(all) && all[j].v == all[i].v {
+			j++
+		}
+		rank := float64(i+j+1) / 2
+		for k := i; k < j; k++ {
+			if all[k].fromX {
+				rankX += rank
+			}
+		}
+		if t := float64(j - i); t > 1 {
+			ties = true
+			tieTerm += t*t*t - t
+		}
+		i = j
+	}
+	u := rankX - float64(n1*(n1+1))/2
+	if !ties {
+		return exactU(n1, n2, u)
+	}
+	n := float64(n1 + n2)
+	mean := float64(n1*n2) / 2
+	variance := float64(n1*n2) / 12 * ((n + 1) - tieTerm/(n*(n-1)))
+	if variance <= 0 {
+		return 1
+	}
+	z := (math.Abs(u-mean) - 0.5) / math.Sqrt(variance)
+	return min(1, math.Erfc(max(z, 0)/math.Sqrt2))
+}
+
+// exactU is the two-sided p-value of u from the exact distribution of U for
+// samples of n1 and n2 without ties.
+func exactU(n1, n2 int, u float64) float64 {
+	// ways[i][j][k]: orderings of i x's and j y's with U = k
+	ways := make([][][]float64, n1+1)
+	for i := range ways {
+		ways[i] = make([][]float64, n2+1)
+		for j := range ways[i] {
+			ways[i][j] = make([]float64, i*j+1)
+			if i == 0 || j == 0 {
+				ways[i][j][0] = 1
+				continue
+			}
+			for k := range ways[i][j] {
+				// the largest value is either an x, above all j y's, or a y
+				if k >= j {
+					ways[i][j][k] += ways[i-1][j][k-j]
+				}
+				if k <= i*(j-1) {
+					ways[i][j][k] += ways[i][j-1][k]
+				}
+			}
+		}
+	}
+	dist := ways[n1][n2]
+	total, below, above := 0.0, 0.0, 0.0
+	for k, w := range dist {
+		total += w
+		if float64(k) <= u {
+			below += w
+		}
+		if float64(k) >= u {
+			above += w
+		}
+	}
+	return min(1, 2*min(below, above)/total)
+}
+
+// WriteComparison renders the changes benchstat-style: base and head
+// medians with their spread, then the change, or ~ when it isn't
+// significant.
+func WriteComparison(w io.Writer, base, head string, changes []Change) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(tw, "benchmark\tmetric\t%s\t%s\tchange\n", base, head)
+	for _, c := range changes {
+		change := "~"
+		if c.Significant {
+			change = fmt.Sprintf("%+.1f%%", c.Delta*100)
+			if c.Regression() {
+				change += " ⚠️"
+			}
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s (p=%.3f n=%d+%d)\n", c.Name, c.Metric, summary(c.Base), summary(c.Head), change, c.P, c.Base.N, c.Head.N)
+	}
+	return tw.Flush()
+}
+
+func summary(s Summary) string {
+	if s.Median >= 100 || s.Median == math.Trunc(s.Median) {
+		return fmt.Sprintf("%.0f ±%.0f%%", s.Median, s.Spread*100)
+	}
+	return fmt.Sprintf("%.2f ±%.0f%%", s.Median, s.Spread*100)
+}
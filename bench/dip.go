@@ -0,0 +1,72 @@
+package bench
+
+import "testing"
+
+type dipEmployee struct {
+	ID     string
+	Name   string
+	Salary int
+}
+
+// ❌ The manager holds the concrete database
+
+type dipMySQLDatabase struct {
+	rows [64]dipEmployee
+	n    int
+}
+
+func (db *dipMySQLDatabase) saveToMySQL(emp dipEmployee) {
+	db.rows[db.n%len(db.rows)] = emp
+	db.n++
+}
+
+type dipConcreteManager struct {
+	database *dipMySQLDatabase
+}
+
+func (m dipConcreteManager) saveEmployee(emp dipEmployee) { m.database.saveToMySQL(emp) }
+
+// ✅ The manager holds an abstraction
+
+type dipRepository interface {
+	save(emp dipEmployee) error
+}
+
+type dipMySQLRepository struct {
+	rows [64]dipEmployee
+	n    int
+}
+
+func (r *dipMySQLRepository) save(emp dipEmployee) error {
+	r.rows[r.n%len(r.rows)] = emp
+	r.n++
+	return nil
+}
+
+type dipManager struct {
+	repository dipRepository
+}
+
+func (m dipManager) saveEmployee(emp dipEmployee) error { return m.repository.save(emp) }
+
+var dipPair = Pair{
+	Principle: "dip",
+	Name:      "save through the manager",
+	Note:      "the interface costs an indirect call the compiler can't inline, and an error to check",
+	Bad: func(b *testing.B) {
+		m := dipConcreteManager{database: &dipMySQLDatabase{}}
+		emp := dipEmployee{ID: "emp-1", Name: "Mohamed", Salary: 5000}
+		for b.Loop() {
+			m.saveEmployee(emp)
+		}
+	},
+	Good: func(b *testing.B) {
+		m := dipManager{repository: &dipMySQLRepository{}}
+		emp := dipEmployee{ID: "emp-1", Name: "Mohamed", Salary: 5000}
+		for b.Loop() {
+			if err := m.saveEmployee(emp); err != nil {
+				b.Fatal(err)
+			}
+		}
+	},
+}
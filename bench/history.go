@@ -0,0 +1,146 @@
+package bench
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+// Samples Repeated measurements of one benchmark, one value per run
+type Samples struct {
+	NsPerOp     []float64 `json:"ns_per_op"`
+	BytesPerOp  []float64 `json:"bytes_per_op"`
+	AllocsPerOp []float64 `json:"allocs_per_op"`
+}
+
+func (s *Samples) add(r testing.BenchmarkResult) {
+	s.NsPerOp = append(s.NsPerOp, nsPerOp(r))
+	s.BytesPerOp = append(s.BytesPerOp, float64(r.AllocedBytesPerOp()))
+	s.AllocsPerOp = append(s.AllocsPerOp, float64(r.AllocsPerOp()))
+}
+
+// Record The benchmarks measured at one commit
+type Record struct {
+	Commit     string             `json:"commit"`
+	Time       time.Time          `json:"time"`
+	Go         string             `json:"go"`
+	Benchmarks map[string]Samples `json:"benchmarks"` // by Name
+}
+
+// History Records by commit, oldest first, kept in a JSON file
+type History struct {
+	Records []Record `json:"records"`
+}
+
+// ErrNoRecord returned when a commit has no recorded benchmarks
+var ErrNoRecord = errors.New("no benchmarks recorded for commit")
+
+// Name identifies one side of a pair across records: "dip/save through the
+// manager/good".
+func Name(p Pair, side string) string { return p.Principle + "/" + p.Name + "/" + side }
+
+// Sample runs both sides of every pair opts selects count times and returns
+// the measurements by Name, ready to record at commit.
+func Sample(opts Options, commit string, count int) (Record, error) {
+	if err := setBenchtime(opts.Benchtime); err != nil {
+		return Record{}, err
+	}
+	pairs, err := selected(opts.Principle)
+	if err != nil {
+		return Record{}, err
+	}
+	rec := Record{Commit: commit, Go: runtime.Version(), Benchmarks: map[string]Samples{}}
+	for _, p := range pairs {
+		if opts.Progress != nil {
+			opts.Progress(p)
+		}
+		var bad, good Samples
+		// interleaved, so a machine slowing down mid-run hits both sides alike
+		for range max(count, 1) {
+			bad.add(testing.Benchmark(p.Bad))
+			good.add(testing.Benchmark(p.Good))
+		}
+		rec.Benchmarks[Name(p, "bad")], rec.Benchmarks[Name(p, "good")] = bad, good
+	}
+	rec.Time = time.Now().UTC()
+	return rec, nil
+}
+
+// LoadHistory reads the history at path; a missing file is an empty history.
+func LoadHistory(path string) (*History, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return &History{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var h History
+	if err := json.Unmarshal(data, &h); err != nil {
+		return nil, fmt.Errorf("bench history %s: %w", path, err)
+	}
+	return &h, nil
+}
+
+// Save writes the history to path, creating its directory, through a
+// temporary file so an interrupted write leaves the old history intact.
+func (h *History) Save(path string) error {
+	data, err := json.MarshalIndent(h, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, append(data, '\n'), 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// Add records rec, replacing the benchmarks of the same name already
+// recorded at its commit and keeping the others.
+func (h *History) Add(rec Record) {
+	i := slices.IndexFunc(h.Records, func(r Record) bool { return r.Commit == rec.Commit })
+	if i < 0 {
+		h.Records = append(h.Records, rec)
+		return
+	}
+	old := h.Records[i]
+	for name, s := range old.Benchmarks {
+		if _, ok := rec.Benchmarks[name]; !ok {
+			rec.Benchmarks[name] = s
+		}
+	}
+	h.Records = append(slices.Delete(h.Records, i, i+1), rec)
+}
+
+// Find returns the record of commit, which may be abbreviated to a prefix
+// of at least four characters.
+func (h *History) Find(commit string) (Record, error) {
+	var found []Record
+	for _, r := range h.Records {
+		if r.Commit == commit {
+			return r, nil
+		}
+		if len(commit) >= 4 && strings.HasPrefix(r.Commit, commit) {
+			found = append(found, r)
+		}
+	}
+	switch len(found) {
+	case 0:
+		return Record{}, fmt.Errorf("%w %s", ErrNoRecord, commit)
+	case 1:
+		return found[0], nil
+	}
+	return Record{}, fmt.Errorf("commit %s is ambiguous: %d records match", commit, len(found))
+}
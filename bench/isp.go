@@ -0,0 +1,85 @@
+package bench
+
+import (
+	"errors"
+	"testing"
+)
+
+// ❌ One interface for everything anyone might do
+
+type ispFatEmployee interface {
+	getName() string
+	assignTask(task string, assignee ispFatEmployee) error
+}
+
+type ispFatDeveloper struct{ name string }
+
+func (d ispFatDeveloper) getName() string { return d.name }
+
+// assignTask ❌ forced to exist, can only fail
+func (d ispFatDeveloper) assignTask(task string, assignee ispFatEmployee) error {
+	return errors.New("developer cannot assign tasks")
+}
+
+type ispFatManager struct {
+	name     string
+	assigned int
+}
+
+func (m *ispFatManager) getName() string { return m.name }
+
+func (m *ispFatManager) assignTask(task string, assignee ispFatEmployee) error {
+	m.assigned++
+	return nil
+}
+
+// ✅ Only those who assign tasks implement taskAssigner
+
+type ispNamed interface {
+	getName() string
+}
+
+type ispTaskAssigner interface {
+	assignTask(task string, assignee ispNamed)
+}
+
+type ispDeveloper struct{ name string }
+
+func (d ispDeveloper) getName() string { return d.name }
+
+type ispManager struct {
+	name     string
+	assigned int
+}
+
+func (m *ispManager) getName() string { return m.name }
+
+func (m *ispManager) assignTask(task string, assignee ispNamed) { m.assigned++ }
+
+var ispPair = Pair{
+	Principle: "isp",
+	Name:      "assign work",
+	Note:      "with the fat interface every employee is asked, and each refusal allocates an error",
+	Bad: func(b *testing.B) {
+		dev := ispFatDeveloper{"Alice"}
+		staff := []ispFatEmployee{dev, &ispFatManager{name: "Bob"}, ispFatDeveloper{"Carol"}}
+		failed := 0
+		for b.Loop() {
+			for _, e := range staff {
+				if err := e.assignTask("review", dev); err != nil {
+					failed++
+				}
+			}
+		}
+		_ = failed
+	},
+	Good: func(b *testing.B) {
+		dev := ispDeveloper{"Alice"}
+		assigners := []ispTaskAssigner{&ispManager{name: "Bob"}}
+		for b.Loop() {
+			for _, a := range assigners {
+				a.assignTask("review", dev)
+			}
+		}
+	},
+}
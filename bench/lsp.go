@@ -0,0 +1,91 @@
+package bench
+
+import (
+	"strconv"
+	"testing"
+)
+
+type lspEmployee interface {
+	getName() string
+	getSalary() int
+}
+
+type lspFullTime struct {
+	name   string
+	salary int
+}
+
+func (em lspFullTime) getName() string { return em.name }
+func (em lspFullTime) getSalary() int  { return em.salary }
+
+// ❌ -1 means "not invoiced yet", a meaning only contractors have
+
+type lspSentinelContractor struct {
+	name              string
+	hourlyRate, hours int
+}
+
+func (c lspSentinelContractor) getName() string { return c.name }
+
+func (c lspSentinelContractor) getSalary() int {
+	if c.hours == 0 {
+		return -1
+	}
+	return c.hourlyRate * c.hours
+}
+
+func lspSpecialCasedInfo(buf []byte, em lspEmployee) []byte {
+	buf = append(buf, em.getName()...)
+	// ❌ the caller has to know about one particular subtype
+	if _, ok := em.(lspSentinelContractor); ok && em.getSalary() < 0 {
+		return append(buf, ": not invoiced"...)
+	}
+	return strconv.AppendInt(append(buf, ": "...), int64(em.getSalary()), 10)
+}
+
+// ✅ A contractor who hasn't invoiced earns 0, like anyone else who earned nothing
+
+type lspContractor struct {
+	name              string
+	hourlyRate, hours int
+}
+
+func (c lspContractor) getName() string { return c.name }
+func (c lspContractor) getSalary() int  { return c.hourlyRate * c.hours }
+
+func lspInfo(buf []byte, em lspEmployee) []byte {
+	buf = append(buf, em.getName()...)
+	return strconv.AppendInt(append(buf, ": "...), int64(em.getSalary()), 10)
+}
+
+var lspPair = Pair{
+	Principle: "lsp",
+	Name:      "print employee info",
+	Note:      "dropping the type check on the subtype removes a branch from every call",
+	Bad: func(b *testing.B) {
+		staff := []lspEmployee{
+			lspFullTime{"Mohamed", 5000},
+			lspSentinelContractor{"Ahmed", 120, 10},
+			lspSentinelContractor{"Ali", 120, 0},
+		}
+		buf := make([]byte, 0, 64)
+		for b.Loop() {
+			for _, em := range staff {
+				buf = lspSpecialCasedInfo(buf[:0], em)
+			}
+		}
+	},
+	Good: func(b *testing.B) {
+		staff := []lspEmployee{
+			lspFullTime{"Mohamed", 5000},
+			lspContractor{"Ahmed", 120, 10},
+			lspContractor{"Ali", 120, 0},
+		}
+		buf := make([]byte, 0, 64)
+		for b.Loop() {
+			for _, em := range staff {
+				buf = lspInfo(buf[:0], em)
+			}
+		}
+	},
+}
@@ -0,0 +1,64 @@
+package bench
+
+import "testing"
+
+// ❌ A string comparison per role, edited for every new role
+
+type ocpRoleName struct {
+	role string
+}
+
+func (em ocpRoleName) getSalary() int {
+	if em.role == "SWE" {
+		return 3000
+	} else if em.role == "SSWE" {
+		return 5000
+	}
+	return 0
+}
+
+// ✅ Each role knows its salary
+
+type ocpRole interface {
+	getSalary() int
+}
+
+type ocpSWE struct{}
+
+func (ocpSWE) getSalary() int { return 3000 }
+
+type ocpSSWE struct{}
+
+func (ocpSSWE) getSalary() int { return 5000 }
+
+type ocpEmployee struct {
+	role ocpRole
+}
+
+func (em ocpEmployee) getSalary() int { return em.role.getSalary() }
+
+var ocpPair = Pair{
+	Principle: "ocp",
+	Name:      "salary by role",
+	Note:      "string comparisons become one interface call, which the compiler can no longer inline",
+	Bad: func(b *testing.B) {
+		staff := []ocpRoleName{{"SWE"}, {"SSWE"}, {"SWE"}, {"SSWE"}}
+		total := 0
+		for b.Loop() {
+			for _, em := range staff {
+				total += em.getSalary()
+			}
+		}
+		_ = total
+	},
+	Good: func(b *testing.B) {
+		staff := []ocpEmployee{{ocpSWE{}}, {ocpSSWE{}}, {ocpSWE{}}, {ocpSSWE{}}}
+		total := 0
+		for b.Loop() {
+			for _, em := range staff {
+				total += em.getSalary()
+			}
+		}
+		_ = total
+	},
+}
@@ -0,0 +1,119 @@
+package bench
+
+import (
+	"context"
+	"fmt"
+	"iter"
+	"testing"
+	"time"
+
+	"go-solid/money"
+	"go-solid/payroll"
+)
+
+type payrollMember struct {
+	id  string
+	pay money.Money
+}
+
+func (m payrollMember) EmployeeID() string      { return m.id }
+func (m payrollMember) EmployeeName() string    { return m.id }
+func (m payrollMember) Country() string         { return "US" }
+func (m payrollMember) MonthlyPay() money.Money { return m.pay }
+
+type payrollRoster []payroll.PaidEmployee
+
+func (r payrollRoster) PaidEmployees(ctx context.Context) iter.Seq2[payroll.PaidEmployee, error] {
+	return func(yield func(payroll.PaidEmployee, error) bool) {
+		for _, emp := range r {
+			if !yield(emp, nil) {
+				return
+			}
+		}
+	}
+}
+
+// payrollLookup A step that waits on something outside the process, as a
+// tax service or an exchange rate would
+type payrollLookup struct{ wait time.Duration }
+
+func (payrollLookup) Name() string { return "lookup" }
+
+func (l payrollLookup) Apply(ctx context.Context, emp payroll.PaidEmployee, slip *payroll.Payslip) error {
+	time.Sleep(l.wait)
+	return nil
+}
+
+func payrollBench(runner func(*payroll.Engine) payroll.Runner) func(b *testing.B) {
+	return func(b *testing.B) {
+		engine := payroll.New(payroll.Config{"US": {
+			payrollLookup{wait: 50 * time.Microsecond},
+			payroll.Pension{Rate: "0.05"},
+			payroll.IncomeTax{Brackets: []payroll.Bracket{{UpTo: money.Of(4000, money.USD), Rate: "0.12"}, {Rate: "0.22"}}},
+		}})
+		roster := make(payrollRoster, 64)
+		for i := range roster {
+			roster[i] = payrollMember{id: fmt.Sprintf("emp-%d", i), pay: money.Of(3000+int64(i)*50, money.USD)}
+		}
+		r := runner(engine)
+		period := payroll.Period{Year: 2025, Month: time.March}
+		for b.Loop() {
+			if _, err := r.Run(b.Context(), period, roster); err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+}
+
+// ✅ Both sides are a payroll.Runner and return the same Run; only the
+// scheduling differs, so callers can swap one for the other (LSP)
+var payrollPair = Pair{
+	Principle: "lsp",
+	Name:      "payroll run, 64 employees",
+	Note:      "sequential Engine vs Concurrent with 8 workers: steps that wait on I/O wait side by side; goroutines cost allocations",
+	Bad:       payrollBench(func(e *payroll.Engine) payroll.Runner { return e }),
+	Good:      payrollBench(func(e *payroll.Engine) payroll.Runner { return payroll.NewConcurrent(e, 8) }),
+}
+
+// unbound Hides a step's Binder, so the Engine applies it as before binding
+// existed: rates parsed, and big.Rats allocated, for every employee
+type unbound struct{ payroll.Step }
+
+func payslipBench(wrap func(payroll.Step) payroll.Step) func(b *testing.B) {
+	return func(b *testing.B) {
+		steps := []payroll.Step{
+			payroll.Bonus{Label: "performance", Rate: "0.08"},
+			payroll.Pension{Rate: "0.05", Cap: money.Of(400, money.USD)},
+			payroll.IncomeTax{Brackets: []payroll.Bracket{{UpTo: money.Of(4000, money.USD), Rate: "0.12"}, {Rate: "0.22"}}},
+			payroll.Garnishment{Orders: map[string][]payroll.Order{"emp-7": {{Label: "child support", Rate: "0.15"}}}},
+		}
+		for i, step := range steps {
+			steps[i] = wrap(step)
+		}
+		engine := payroll.New(payroll.Config{"US": steps})
+		roster := make(payrollRoster, 64)
+		for i := range roster {
+			roster[i] = payrollMember{id: fmt.Sprintf("emp-%d", i), pay: money.Of(3000+int64(i)*50, money.USD)}
+		}
+		ctx := b.Context()
+		period := payroll.Period{Year: 2025, Month: time.March}
+		for b.Loop() {
+			for _, emp := range roster {
+				if _, err := engine.Payslip(ctx, period, emp); err != nil {
+					b.Fatal(err)
+				}
+			}
+		}
+	}
+}
+
+// ✅ The same steps, the same payslips: a step that can do its set-up once
+// says so through an optional interface, and steps that can't still plug in
+// unchanged (OCP)
+var payslipPair = Pair{
+	Principle: "ocp",
+	Name:      "payslips, 64 employees",
+	Note:      "steps applied through Step.Apply vs pre-bound by the Engine: rates parsed once and scaled without big.Rat",
+	Bad:       payslipBench(func(s payroll.Step) payroll.Step { return unbound{s} }),
+	Good:      payslipBench(func(s payroll.Step) payroll.Step { return s }),
+}
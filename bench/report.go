@@ -0,0 +1,103 @@
+package bench
+
+import (
+	"fmt"
+	"html/template"
+	"io"
+	"os"
+	"path/filepath"
+	"text/tabwriter"
+)
+
+// WriteAllocations renders each side's top allocation sites as text: share
+// of the bytes, location and the function's complexity.
+func WriteAllocations(w io.Writer, allocs []Allocations) error {
+	for _, a := range allocs {
+		fmt.Fprintf(w, "%s %s, %s: %d B/op, %d allocs/op\n", a.Pair.Principle, a.Pair.Name, a.Side, a.Result.AllocedBytesPerOp(), a.Result.AllocsPerOp())
+		if len(a.Sites) == 0 {
+			fmt.Fprintln(w, "   no allocations in this module's code")
+			continue
+		}
+		tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+		for _, s := range a.Sites {
+			fmt.Fprintf(tw, "  %5.1f%%\t%s:%d\t%s\t%s\n", a.Share(s)*100, relative(s.File), s.Line, s.Function, complexity(s))
+		}
+		if err := tw.Flush(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// WriteAllocationsHTML renders a standalone page: per pair, each side's top
+// allocation sites with the source around them and the complexity of the
+// function they are in, so the cost of a design shows next to its shape.
+func WriteAllocationsHTML(w io.Writer, title string, allocs []Allocations) error {
+	return allocPage.Execute(w, struct {
+		Title  string
+		Allocs []Allocations
+	}{title, allocs})
+}
+
+var allocPage = template.Must(template.New("allocs").Funcs(template.FuncMap{
+	"percent":    func(a Allocations, s Site) string { return fmt.Sprintf("%.1f%%", a.Share(s)*100) },
+	"relative":   relative,
+	"complexity": complexity,
+	"add":        func(a, b int) int { return a + b },
+}).Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>{{.Title}}</title>
+<style>
+body { font-family: sans-serif; max-width: 64em; margin: auto; }
+table { border-collapse: collapse; margin-bottom: 1em; }
+th, td { border: 1px solid #ccc; padding: 4px 8px; vertical-align: top; text-align: left; }
+td.num { text-align: right; }
+.bad h3 { color: #b00; }
+.good h3 { color: #070; }
+pre { margin: 0; font-size: 0.9em; }
+pre .hot { background: #fdd; font-weight: bold; }
+</style>
+</head>
+<body>
+<h1>{{.Title}}</h1>
+<p>Every allocation sampled while each benchmark ran, charged to the innermost line of this module's code. Complexity is that of the function the line is in.</p>
+{{range .Allocs}}{{$a := .}}<section class="{{.Side}}">
+<h3>{{.Pair.Principle}}: {{.Pair.Name}}, {{.Side}}</h3>
+<p>{{.Result.AllocedBytesPerOp}} B/op, {{.Result.AllocsPerOp}} allocs/op. {{.Pair.Note}}</p>
+{{if .Sites}}<table>
+<tr><th>Bytes</th><th>Where</th><th>Complexity</th><th>Source</th></tr>
+{{range .Sites}}<tr>
+<td class="num">{{percent $a .}}</td>
+<td><code>{{relative .File}}:{{.Line}}</code><br><code>{{.Function}}</code></td>
+<td>{{complexity .}}</td>
+<td><pre>{{$s := .}}{{range $i, $l := .Source}}{{$n := add $s.SourceLine $i}}{{if eq $n $s.Line}}<span class="hot">{{$n}}  {{$l}}</span>{{else}}{{$n}}  {{$l}}{{end}}
+{{end}}</pre></td>
+</tr>
+{{end}}</table>
+{{else}}<p>No allocations in this module's code.</p>
+{{end}}</section>
+{{end}}</body>
+</html>
+`))
+
+func complexity(s Site) string {
+	if s.Cyclomatic == 0 {
+		return "-"
+	}
+	return fmt.Sprintf("cyclomatic %d, cognitive %d", s.Cyclomatic, s.Cognitive)
+}
+
+// relative shortens path to be relative to the working directory, when it
+// is inside it.
+func relative(path string) string {
+	wd, err := os.Getwd()
+	if err != nil {
+		return path
+	}
+	if rel, err := filepath.Rel(wd, path); err == nil && filepath.IsLocal(rel) {
+		return rel
+	}
+	return path
+}
@@ -0,0 +1,51 @@
+package bench
+
+import "testing"
+
+// ❌ The employee formats itself and keeps its own storage
+
+type srpFatEmployee struct {
+	firstName, lastName, email string
+	rows                       []string
+}
+
+func (em *srpFatEmployee) getFullName() string { return em.firstName + " " + em.lastName }
+
+func (em *srpFatEmployee) saveEmployee() {
+	em.rows = append(em.rows[:0], em.getFullName()+" <"+em.email+">")
+}
+
+// ✅ Saving is the repository's job
+
+type srpEmployee struct {
+	firstName, lastName, email string
+}
+
+func (em *srpEmployee) getFullName() string { return em.firstName + " " + em.lastName }
+
+type srpRepository struct {
+	rows []string
+}
+
+func (r *srpRepository) saveEmployee(em *srpEmployee) {
+	r.rows = append(r.rows[:0], em.getFullName()+" <"+em.email+">")
+}
+
+var srpPair = Pair{
+	Principle: "srp",
+	Name:      "save employee",
+	Note:      "moving persistence into a repository is a different receiver for the same work",
+	Bad: func(b *testing.B) {
+		em := &srpFatEmployee{firstName: "Mohamed", lastName: "Habib", email: "mohamed@gmail.com"}
+		for b.Loop() {
+			em.saveEmployee()
+		}
+	},
+	Good: func(b *testing.B) {
+		em := &srpEmployee{firstName: "Mohamed", lastName: "Habib", email: "mohamed@gmail.com"}
+		repo := &srpRepository{}
+		for b.Loop() {
+			repo.saveEmployee(em)
+		}
+	},
+}
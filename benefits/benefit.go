@@ -0,0 +1,126 @@
+package benefits
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"go-solid/money"
+)
+
+// ErrNotEligible returned when enrolling a member in a benefit they don't
+// qualify for, wrapped with why
+var ErrNotEligible = errors.New("not eligible")
+
+// Enrollment A member enrolled in a benefit, and what it costs the company
+type Enrollment struct {
+	Member      string
+	Benefit     string
+	MonthlyCost money.Money // zero for benefits that aren't paid monthly
+	Note        string
+}
+
+// Benefit Something the company offers. A benefit asks the member for the
+// one capability it needs and refuses members without it.
+type Benefit interface {
+	Name() string
+	Enroll(m Member, now time.Time) (Enrollment, error)
+}
+
+var (
+	_ Benefit = Health{}
+	_ Benefit = Pension{}
+	_ Benefit = Equity{}
+	_ Benefit = Commuter{}
+)
+
+func notEligible(b Benefit, m Member, why string) error {
+	return fmt.Errorf("%s for %s: %w: %s", b.Name(), m.MemberID(), ErrNotEligible, why)
+}
+
+// Health Insurance for Insurable members, priced per person covered
+type Health struct {
+	PerPerson money.Money
+}
+
+func (Health) Name() string { return "health" }
+
+func (h Health) Enroll(m Member, _ time.Time) (Enrollment, error) {
+	ins, ok := m.(Insurable)
+	if !ok {
+		return Enrollment{}, notEligible(h, m, "not insurable")
+	}
+	n := ins.Covered()
+	return Enrollment{
+		Member:      m.MemberID(),
+		Benefit:     h.Name(),
+		MonthlyCost: h.PerPerson.Mul(big.NewRat(int64(n), 1)),
+		Note:        fmt.Sprintf("%d covered", n),
+	}, nil
+}
+
+// Pension Employer contributions of Match of a Pensionable member's salary.
+// Match is a decimal fraction kept as a string, like payroll rates ("0.05"
+// is 5%).
+type Pension struct {
+	Match string
+}
+
+func (Pension) Name() string { return "pension" }
+
+func (p Pension) Enroll(m Member, _ time.Time) (Enrollment, error) {
+	pen, ok := m.(Pensionable)
+	if !ok {
+		return Enrollment{}, notEligible(p, m, "no pensionable salary")
+	}
+	match, ok := new(big.Rat).SetString(p.Match)
+	if !ok || match.Sign() < 0 {
+		return Enrollment{}, fmt.Errorf("pension: invalid match %q", p.Match)
+	}
+	return Enrollment{
+		Member:      m.MemberID(),
+		Benefit:     p.Name(),
+		MonthlyCost: pen.PensionableSalary().Mul(match),
+		Note:        strings.TrimSuffix(new(big.Rat).Mul(match, big.NewRat(100, 1)).FloatString(1), ".0") + "% matched",
+	}, nil
+}
+
+// Equity Stock options for Tenured members, once they have been with the
+// company for the cliff
+type Equity struct {
+	CliffMonths int
+	Units       int
+}
+
+func (Equity) Name() string { return "equity" }
+
+func (e Equity) Enroll(m Member, now time.Time) (Enrollment, error) {
+	t, ok := m.(Tenured)
+	if !ok {
+		return Enrollment{}, notEligible(e, m, "no start date to vest from")
+	}
+	if from := t.Started().AddDate(0, e.CliffMonths, 0); now.Before(from) {
+		return Enrollment{}, notEligible(e, m, "eligible from "+from.Format(time.DateOnly))
+	}
+	return Enrollment{Member: m.MemberID(), Benefit: e.Name(), Note: fmt.Sprintf("%d units", e.Units)}, nil
+}
+
+// Commuter A monthly travel allowance for OnSite members with an office
+type Commuter struct {
+	Allowance money.Money
+}
+
+func (Commuter) Name() string { return "commuter" }
+
+func (c Commuter) Enroll(m Member, _ time.Time) (Enrollment, error) {
+	site, ok := m.(OnSite)
+	if !ok {
+		return Enrollment{}, notEligible(c, m, "no office")
+	}
+	if site.Site() == "" {
+		return Enrollment{}, notEligible(c, m, "works remotely")
+	}
+	return Enrollment{Member: m.MemberID(), Benefit: c.Name(), MonthlyCost: c.Allowance, Note: "to " + site.Site()}, nil
+}
@@ -0,0 +1,102 @@
+// Package benefits enrols people in the benefits they qualify for.
+//
+// Who qualifies for what is not a set of flags on one struct. Each benefit
+// needs one small capability - being insurable, having a pensionable
+// salary, a start date to vest from, an office to commute to - and asks the
+// member for it with a type assertion. Each kind of member implements only
+// the capabilities it has, so a contractor has no pension method returning
+// zero and no health method returning an error (ISP, LSP).
+package benefits
+
+import (
+	"time"
+
+	"go-solid/employee"
+	"go-solid/money"
+)
+
+// Member Anyone who can be offered benefits: the minimal identity
+type Member interface {
+	MemberID() string
+}
+
+// Insurable Members who can be covered by health insurance
+type Insurable interface {
+	Member
+	Covered() int // people covered: the member and their dependants
+}
+
+// Pensionable Members with a salary that pension contributions are based on
+type Pensionable interface {
+	Member
+	PensionableSalary() money.Money
+}
+
+// Tenured Members with a start date that equity vests from
+type Tenured interface {
+	Member
+	Started() time.Time
+}
+
+// OnSite Members with an office they may commute to
+type OnSite interface {
+	Member
+	Site() string // empty for someone working remotely
+}
+
+var (
+	_ Insurable   = FullTime{}
+	_ Pensionable = FullTime{}
+	_ Tenured     = FullTime{}
+	_ OnSite      = FullTime{}
+	_ Insurable   = PartTime{}
+	_ Pensionable = PartTime{}
+	_ OnSite      = PartTime{}
+	_ Member      = Contractor{}
+	_ OnSite      = Intern{}
+)
+
+// FullTime An employee on a permanent, full-time contract
+type FullTime struct {
+	employee.Employee
+	Dependants int
+	Office     string // empty when remote
+}
+
+func (f FullTime) MemberID() string               { return string(f.ID) }
+func (f FullTime) Covered() int                   { return 1 + f.Dependants }
+func (f FullTime) PensionableSalary() money.Money { return f.Salary }
+func (f FullTime) Started() time.Time             { return f.HiredAt }
+func (f FullTime) Site() string                   { return f.Office }
+
+// PartTime An employee working part of the week: insured and pensioned, but
+// not granted equity
+type PartTime struct {
+	employee.Employee
+	Dependants int
+	Office     string
+}
+
+func (p PartTime) MemberID() string               { return string(p.ID) }
+func (p PartTime) Covered() int                   { return 1 + p.Dependants }
+func (p PartTime) PensionableSalary() money.Money { return p.Salary }
+func (p PartTime) Site() string                   { return p.Office }
+
+// Contractor Paid by the hour through their own company, so none of the
+// capabilities apply
+type Contractor struct {
+	ID   string
+	Name string
+}
+
+func (c Contractor) MemberID() string { return c.ID }
+
+// Intern On a fixed-term placement: no salary, but an office
+type Intern struct {
+	ID     string
+	Name   string
+	Office string
+}
+
+func (i Intern) MemberID() string { return i.ID }
+func (i Intern) Site() string     { return i.Office }
@@ -0,0 +1,85 @@
+package benefits
+
+import (
+	"slices"
+
+	"go-solid/clock"
+	"go-solid/money"
+)
+
+// Statement What one member was enrolled in, and what they were refused
+type Statement struct {
+	Member   string
+	Enrolled []Enrollment
+	Refused  map[string]error // by benefit: why not
+}
+
+// Has reports whether the member was enrolled in the named benefit.
+func (s Statement) Has(benefit string) bool {
+	return slices.ContainsFunc(s.Enrolled, func(e Enrollment) bool { return e.Benefit == benefit })
+}
+
+// MonthlyCost adds up the enrollments' monthly costs. They must all be in
+// one currency.
+func (s Statement) MonthlyCost() (money.Money, error) {
+	var total money.Money
+	for _, e := range s.Enrolled {
+		if e.MonthlyCost.IsZero() {
+			continue
+		}
+		if total.IsZero() {
+			total = e.MonthlyCost
+			continue
+		}
+		var err error
+		if total, err = total.Add(e.MonthlyCost); err != nil {
+			return money.Money{}, err
+		}
+	}
+	return total, nil
+}
+
+// Plan The benefits on offer. A new benefit is one more Benefit; neither
+// the Plan nor the kinds of member change (OCP).
+type Plan struct {
+	benefits []Benefit
+	clock    clock.Clock
+}
+
+// Option customises a Plan created by NewPlan
+type Option func(*Plan)
+
+func WithClock(c clock.Clock) Option { return func(p *Plan) { p.clock = c } }
+
+func NewPlan(benefits []Benefit, opts ...Option) *Plan {
+	p := &Plan{benefits: slices.Clone(benefits), clock: clock.Real{}}
+	for _, opt := range opts {
+		opt(p)
+	}
+	return p
+}
+
+// Benefits returns the names of the benefits on offer, in order.
+func (p *Plan) Benefits() []string {
+	names := make([]string, len(p.benefits))
+	for i, b := range p.benefits {
+		names[i] = b.Name()
+	}
+	return names
+}
+
+// Enroll enrols m in every benefit they qualify for today, and says why
+// they were refused the others.
+func (p *Plan) Enroll(m Member) Statement {
+	now := p.clock.Now()
+	s := Statement{Member: m.MemberID(), Refused: map[string]error{}}
+	for _, b := range p.benefits {
+		e, err := b.Enroll(m, now)
+		if err != nil {
+			s.Refused[b.Name()] = err
+			continue
+		}
+		s.Enrolled = append(s.Enrolled, e)
+	}
+	return s
+}
@@ -0,0 +1,99 @@
+// Package blob abstracts object storage: named byte streams in a bucket-like
+// namespace.
+//
+// An export job depends on Store and never on a cloud SDK or the filesystem.
+// Multipart uploads are an optional capability (ISP) - stores that have them
+// let large objects be written in chunks and resumed after a crash; stores
+// that don't still take a single streamed Put. Dir (file://) and S3 (s3://)
+// have them; Memory doesn't.
+package blob
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"sort"
+	"sync"
+)
+
+// ErrNotFound returned by Get for keys that don't exist
+var ErrNotFound = errors.New("blob not found")
+
+// Store Abstraction - the minimum every object store provides
+type Store interface {
+	Put(ctx context.Context, key string, r io.Reader) error
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	// Delete removes key; deleting a missing key is not an error.
+	Delete(ctx context.Context, key string) error
+}
+
+// Multipart Optional capability - an object is uploaded as numbered parts
+// (from 1) and only becomes visible at Complete. An upload survives the
+// process that started it: a later process can ask which parts arrived and
+// carry on. Modelled on S3 multipart uploads.
+type Multipart interface {
+	CreateUpload(ctx context.Context, key string) (uploadID string, err error)
+	// UploadPart stores part n, replacing any earlier part n.
+	UploadPart(ctx context.Context, key, uploadID string, n int, data []byte) error
+	ListParts(ctx context.Context, key, uploadID string) ([]int, error)
+	// CompleteUpload assembles parts 1..parts into the object.
+	CompleteUpload(ctx context.Context, key, uploadID string, parts int) error
+	AbortUpload(ctx context.Context, key, uploadID string) error
+}
+
+// ErrUnknownUpload returned for upload IDs a store doesn't know (completed,
+// aborted or expired)
+var ErrUnknownUpload = errors.New("unknown upload")
+
+// Opener builds a store from a destination URL and returns the object key within it
+type Opener func(u *url.URL) (store Store, key string, err error)
+
+var (
+	mu      sync.RWMutex
+	openers = map[string]Opener{}
+)
+
+// Register makes a URL scheme available to Open; adapters for cloud stores
+// register themselves the same way.
+func Register(scheme string, open Opener) {
+	mu.Lock()
+	defer mu.Unlock()
+	if _, dup := openers[scheme]; dup {
+		panic("blob: Register called twice for scheme " + scheme)
+	}
+	openers[scheme] = open
+}
+
+func Schemes() []string {
+	mu.RLock()
+	defer mu.RUnlock()
+	names := make([]string, 0, len(openers))
+	for name := range openers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Open resolves a destination such as file:///var/backups/employees.jsonl
+// into a store and a key.
+func Open(dest string) (Store, string, error) {
+	u, err := url.Parse(dest)
+	if err != nil {
+		return nil, "", fmt.Errorf("blob: %w", err)
+	}
+	mu.RLock()
+	open, ok := openers[u.Scheme]
+	mu.RUnlock()
+	if !ok {
+		return nil, "", fmt.Errorf("blob: no store registered for scheme %q (registered: %v)", u.Scheme, Schemes())
+	}
+	return open(u)
+}
+
+func init() {
+	Register("file", openDir)
+	Register("s3", openS3)
+}
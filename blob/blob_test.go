@@ -0,0 +1,162 @@
+package blob_test
+
+import (
+	"errors"
+	"io"
+	"slices"
+	"strings"
+	"testing"
+
+	"go-solid/blob"
+	"go-solid/blob/blobtest"
+)
+
+type store interface {
+	blob.Store
+	blob.Multipart
+}
+
+// stores The stores with multipart uploads, each new and empty
+var stores = []struct {
+	name string
+	open func(t *testing.T) store
+}{
+	{"dir", func(t *testing.T) store { return blob.NewDir(t.TempDir()) }},
+	{"s3", func(t *testing.T) store {
+		srv := blobtest.NewS3(t)
+		srv.PartsPerPage, srv.Signed = 2, true
+		return &blob.S3{Bucket: "backups", Endpoint: srv.URL, Credentials: blob.Credentials{AccessKeyID: "test", SecretAccessKey: "test"}}
+	}},
+}
+
+func read(t *testing.T, s blob.Store, key string) string {
+	t.Helper()
+	rc, err := s.Get(t.Context(), key)
+	if err != nil {
+		t.Fatalf("Get(%s) error = %v", key, err)
+	}
+	defer rc.Close()
+	b, err := io.ReadAll(rc)
+	if err != nil {
+		t.Fatalf("Get(%s) read error = %v", key, err)
+	}
+	return string(b)
+}
+
+func TestStore_Objects(t *testing.T) {
+	for _, tt := range stores {
+		t.Run(tt.name, func(t *testing.T) {
+			s := tt.open(t)
+			if _, err := s.Get(t.Context(), "employees.jsonl"); !errors.Is(err, blob.ErrNotFound) {
+				t.Errorf("Get(missing) error = %v, want %v", err, blob.ErrNotFound)
+			}
+			for _, body := range []string{"first", "second"} {
+				if err := s.Put(t.Context(), "employees.jsonl", strings.NewReader(body)); err != nil {
+					t.Fatalf("Put() error = %v", err)
+				}
+			}
+			if got := read(t, s, "employees.jsonl"); got != "second" {
+				t.Errorf("Get() = %q, want the last Put", got)
+			}
+			for range 2 { // the second time the key is missing, which is fine
+				if err := s.Delete(t.Context(), "employees.jsonl"); err != nil {
+					t.Fatalf("Delete() error = %v", err)
+				}
+			}
+			if _, err := s.Get(t.Context(), "employees.jsonl"); !errors.Is(err, blob.ErrNotFound) {
+				t.Errorf("Get() after Delete error = %v, want %v", err, blob.ErrNotFound)
+			}
+		})
+	}
+}
+
+func TestStore_Multipart(t *testing.T) {
+	for _, tt := range stores {
+		t.Run(tt.name, func(t *testing.T) {
+			s := tt.open(t)
+			ctx := t.Context()
+			id, err := s.CreateUpload(ctx, "employees.jsonl")
+			if err != nil {
+				t.Fatalf("CreateUpload() error = %v", err)
+			}
+			// out of order, and part 2 twice: the second replaces the first
+			for n, data := range map[int]string{3: "Sara\n", 1: "Ali\n", 2: "Omar?\n"} {
+				if err := s.UploadPart(ctx, "employees.jsonl", id, n, []byte(data)); err != nil {
+					t.Fatalf("UploadPart(%d) error = %v", n, err)
+				}
+			}
+			if err := s.UploadPart(ctx, "employees.jsonl", id, 2, []byte("Omar\n")); err != nil {
+				t.Fatalf("UploadPart(2) again error = %v", err)
+			}
+			if parts, err := s.ListParts(ctx, "employees.jsonl", id); err != nil || !slices.Equal(parts, []int{1, 2, 3}) {
+				t.Errorf("ListParts() = %v, %v, want [1 2 3]", parts, err)
+			}
+			if _, err := s.Get(ctx, "employees.jsonl"); !errors.Is(err, blob.ErrNotFound) {
+				t.Errorf("Get() before CompleteUpload error = %v, want %v", err, blob.ErrNotFound)
+			}
+			if err := s.CompleteUpload(ctx, "employees.jsonl", id, 3); err != nil {
+				t.Fatalf("CompleteUpload() error = %v", err)
+			}
+			if got := read(t, s, "employees.jsonl"); got != "Ali\nOmar\nSara\n" {
+				t.Errorf("Get() = %q, want the parts in order", got)
+			}
+			if _, err := s.ListParts(ctx, "employees.jsonl", id); !errors.Is(err, blob.ErrUnknownUpload) {
+				t.Errorf("ListParts() after CompleteUpload error = %v, want %v", err, blob.ErrUnknownUpload)
+			}
+		})
+	}
+}
+
+func TestStore_AbortUpload(t *testing.T) {
+	for _, tt := range stores {
+		t.Run(tt.name, func(t *testing.T) {
+			s := tt.open(t)
+			ctx := t.Context()
+			id, err := s.CreateUpload(ctx, "employees.jsonl")
+			if err != nil {
+				t.Fatalf("CreateUpload() error = %v", err)
+			}
+			if err := s.UploadPart(ctx, "employees.jsonl", id, 1, []byte("Ali\n")); err != nil {
+				t.Fatalf("UploadPart() error = %v", err)
+			}
+			if err := s.AbortUpload(ctx, "employees.jsonl", id); err != nil {
+				t.Fatalf("AbortUpload() error = %v", err)
+			}
+			if err := s.UploadPart(ctx, "employees.jsonl", id, 2, []byte("Sara\n")); !errors.Is(err, blob.ErrUnknownUpload) {
+				t.Errorf("UploadPart() after AbortUpload error = %v, want %v", err, blob.ErrUnknownUpload)
+			}
+			if err := s.CompleteUpload(ctx, "employees.jsonl", "no-such-upload", 1); !errors.Is(err, blob.ErrUnknownUpload) {
+				t.Errorf("CompleteUpload(unknown) error = %v, want %v", err, blob.ErrUnknownUpload)
+			}
+		})
+	}
+}
+
+func TestOpen(t *testing.T) {
+	srv := blobtest.NewS3(t)
+	t.Setenv("AWS_ENDPOINT_URL", srv.URL)
+	tests := []struct {
+		dest    string
+		wantKey string
+		wantErr bool
+	}{
+		{dest: "file:///var/backups/employees.jsonl", wantKey: "employees.jsonl"},
+		{dest: "s3://backups/2026/employees.jsonl", wantKey: "2026/employees.jsonl"},
+		{dest: "s3://backups/", wantErr: true},
+		{dest: "gs://backups/employees.jsonl", wantErr: true},
+	}
+	for _, tt := range tests {
+		s, key, err := blob.Open(tt.dest)
+		if (err != nil) != tt.wantErr || key != tt.wantKey {
+			t.Errorf("Open(%s) = %q, %v, want %q", tt.dest, key, err, tt.wantKey)
+		}
+		if s3, ok := s.(*blob.S3); ok {
+			if err := s3.Put(t.Context(), key, strings.NewReader("Ali")); err != nil {
+				t.Fatalf("Put() error = %v", err)
+			}
+			if got, _ := srv.Object("backups", key); string(got) != "Ali" {
+				t.Errorf("the server holds %q, want what was Put through Open's store", got)
+			}
+		}
+	}
+}
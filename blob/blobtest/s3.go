@@ -0,0 +1,202 @@
+// Package blobtest is an S3-compatible server in memory, so what talks to
+// blob.S3 can be tested without a bucket:
+//
+//	srv := blobtest.NewS3(t)
+//	store := &blob.S3{Bucket: "backups", Endpoint: srv.URL}
+//
+// It speaks the part of the REST API blob.S3 uses: objects, and multipart
+// uploads with their ETags and paged part listings. It doesn't check
+// signatures, only that a request carries one when Signed is set.
+package blobtest
+
+import (
+	"cmp"
+	"crypto/md5"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// S3 A running server; objects and uploads are keyed by bucket and key.
+type S3 struct {
+	*httptest.Server
+	// PartsPerPage is how many parts a listing returns before it is
+	// truncated; defaults to 1000, as S3's does.
+	PartsPerPage int
+	// Signed rejects requests without a Signature Version 4 Authorization header.
+	Signed bool
+
+	mu      sync.Mutex
+	objects map[string][]byte
+	uploads map[string]map[int][]byte // upload ID -> part number -> data
+	seq     int
+}
+
+// NewS3 starts a server that is closed when the test ends.
+func NewS3(t *testing.T) *S3 {
+	s := &S3{objects: make(map[string][]byte), uploads: make(map[string]map[int][]byte)}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serve))
+	t.Cleanup(s.Close)
+	return s
+}
+
+// Object returns what is stored under bucket and key.
+func (s *S3) Object(bucket, key string) ([]byte, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	b, ok := s.objects[bucket+"/"+key]
+	return b, ok
+}
+
+// Uploads returns how many multipart uploads are neither completed nor aborted.
+func (s *S3) Uploads() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.uploads)
+}
+
+func etag(data []byte) string {
+	sum := md5.Sum(data)
+	return `"` + hex.EncodeToString(sum[:]) + `"`
+}
+
+type part struct {
+	PartNumber int
+	ETag       string
+}
+
+func (s *S3) serve(w http.ResponseWriter, r *http.Request) {
+	if s.Signed && !strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 ") {
+		fail(w, http.StatusForbidden, "AccessDenied")
+		return
+	}
+	name := strings.TrimPrefix(r.URL.Path, "/")
+	if !strings.Contains(name, "/") {
+		fail(w, http.StatusBadRequest, "InvalidRequest")
+		return
+	}
+	body, _ := io.ReadAll(r.Body)
+	q := r.URL.Query()
+	uploadID := q.Get("uploadId")
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	parts, known := s.uploads[uploadID]
+	if uploadID != "" && !known {
+		fail(w, http.StatusNotFound, "NoSuchUpload")
+		return
+	}
+	switch {
+	case r.Method == http.MethodPost && q.Has("uploads"):
+		s.seq++
+		id := fmt.Sprintf("upload-%d", s.seq)
+		s.uploads[id] = make(map[int][]byte)
+		reply(w, struct {
+			XMLName  xml.Name `xml:"InitiateMultipartUploadResult"`
+			UploadID string   `xml:"UploadId"`
+		}{UploadID: id})
+
+	case r.Method == http.MethodPut && uploadID != "":
+		n, err := strconv.Atoi(q.Get("partNumber"))
+		if err != nil || n < 1 {
+			fail(w, http.StatusBadRequest, "InvalidArgument")
+			return
+		}
+		parts[n] = body
+		w.Header().Set("ETag", etag(body))
+
+	case r.Method == http.MethodGet && uploadID != "":
+		marker, _ := strconv.Atoi(q.Get("part-number-marker"))
+		var res struct {
+			XMLName              xml.Name `xml:"ListPartsResult"`
+			IsTruncated          bool
+			NextPartNumberMarker int
+			Part                 []part
+		}
+		for _, n := range slices.Sorted(maps.Keys(parts)) {
+			if n <= marker {
+				continue
+			}
+			if len(res.Part) == cmp.Or(s.PartsPerPage, 1000) {
+				res.IsTruncated = true
+				break
+			}
+			res.Part = append(res.Part, part{PartNumber: n, ETag: etag(parts[n])})
+			res.NextPartNumberMarker = n
+		}
+		reply(w, res)
+
+	case r.Method == http.MethodPost && uploadID != "":
+		var req struct{ Part []part }
+		if err := xml.Unmarshal(body, &req); err != nil || len(req.Part) == 0 {
+			fail(w, http.StatusBadRequest, "MalformedXML")
+			return
+		}
+		var object []byte
+		for i, p := range req.Part {
+			data, ok := parts[p.PartNumber]
+			if !ok || p.PartNumber != i+1 || p.ETag != etag(data) {
+				// S3 reports this with a 200, which clients must look into
+				reply(w, struct {
+					XMLName xml.Name `xml:"Error"`
+					Code    string
+				}{Code: "InvalidPart"})
+				return
+			}
+			object = append(object, data...)
+		}
+		s.objects[name] = object
+		delete(s.uploads, uploadID)
+		reply(w, struct {
+			XMLName xml.Name `xml:"CompleteMultipartUploadResult"`
+			Key     string
+		}{Key: name})
+
+	case r.Method == http.MethodDelete && uploadID != "":
+		delete(s.uploads, uploadID)
+		w.WriteHeader(http.StatusNoContent)
+
+	case r.Method == http.MethodPut:
+		s.objects[name] = body
+		w.Header().Set("ETag", etag(body))
+
+	case r.Method == http.MethodGet:
+		b, ok := s.objects[name]
+		if !ok {
+			fail(w, http.StatusNotFound, "NoSuchKey")
+			return
+		}
+		_, _ = w.Write(b)
+
+	case r.Method == http.MethodDelete:
+		delete(s.objects, name)
+		w.WriteHeader(http.StatusNoContent)
+
+	default:
+		fail(w, http.StatusMethodNotAllowed, "MethodNotAllowed")
+	}
+}
+
+func reply(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/xml")
+	_ = xml.NewEncoder(w).Encode(v)
+}
+
+func fail(w http.ResponseWriter, status int, code string) {
+	w.Header().Set("Content-Type", "application/xml")
+	w.WriteHeader(status)
+	_ = xml.NewEncoder(w).Encode(struct {
+		XMLName xml.Name `xml:"Error"`
+		Code    string
+		Message string
+	}{Code: code, Message: http.StatusText(status)})
+}
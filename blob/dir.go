@@ -0,0 +1,195 @@
+package blob
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+
+	"go-solid/id"
+)
+
+// Dir Low-level module - a directory as a Store with multipart uploads.
+// Parts are kept under .uploads/<upload id>/ until the upload is completed,
+// so an interrupted upload can be resumed by a new process.
+type Dir struct {
+	root string
+	ids  id.Generator
+}
+
+func NewDir(root string) *Dir { return &Dir{root: root, ids: id.UUID{}} }
+
+// openDir handles file:///path/to/object - the store is the directory, the key the file name.
+func openDir(u *url.URL) (Store, string, error) {
+	p := u.Path
+	if u.Opaque != "" { // file:relative/path
+		p = u.Opaque
+	}
+	if p == "" || filepath.Base(p) == "/" {
+		return nil, "", fmt.Errorf("blob: %s has no file name", u)
+	}
+	return NewDir(filepath.Dir(p)), filepath.Base(p), nil
+}
+
+func (d *Dir) path(key string) (string, error) {
+	if !filepath.IsLocal(key) {
+		return "", fmt.Errorf("blob: invalid key %q", key)
+	}
+	return filepath.Join(d.root, key), nil
+}
+
+// Put writes to a temporary file and renames it, so readers never see half an object.
+func (d *Dir) Put(ctx context.Context, key string, r io.Reader) error {
+	p, err := d.path(key)
+	if err != nil {
+		return err
+	}
+	return writeAtomic(p, func(w io.Writer) error {
+		_, err := io.Copy(w, r)
+		return err
+	})
+}
+
+func (d *Dir) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	p, err := d.path(key)
+	if err != nil {
+		return nil, err
+	}
+	f, err := os.Open(p)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, ErrNotFound
+	}
+	return f, err
+}
+
+func (d *Dir) Delete(ctx context.Context, key string) error {
+	p, err := d.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(p); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+func (d *Dir) uploadDir(uploadID string) (string, error) {
+	dir := filepath.Join(d.root, ".uploads", uploadID)
+	if !filepath.IsLocal(uploadID) {
+		return "", ErrUnknownUpload
+	}
+	if _, err := os.Stat(dir); err != nil {
+		return "", fmt.Errorf("%w: %s", ErrUnknownUpload, uploadID)
+	}
+	return dir, nil
+}
+
+func (d *Dir) CreateUpload(ctx context.Context, key string) (string, error) {
+	if _, err := d.path(key); err != nil {
+		return "", err
+	}
+	uploadID := d.ids.NewID()
+	if err := os.MkdirAll(filepath.Join(d.root, ".uploads", uploadID), 0o755); err != nil {
+		return "", err
+	}
+	return uploadID, nil
+}
+
+func (d *Dir) UploadPart(ctx context.Context, key, uploadID string, n int, data []byte) error {
+	dir, err := d.uploadDir(uploadID)
+	if err != nil {
+		return err
+	}
+	return writeAtomic(filepath.Join(dir, partName(n)), func(w io.Writer) error {
+		_, err := w.Write(data)
+		return err
+	})
+}
+
+func (d *Dir) ListParts(ctx context.Context, key, uploadID string) ([]int, error) {
+	dir, err := d.uploadDir(uploadID)
+	if err != nil {
+		return nil, err
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	var parts []int
+	for _, e := range entries {
+		if n, err := strconv.Atoi(e.Name()); err == nil {
+			parts = append(parts, n)
+		}
+	}
+	sort.Ints(parts)
+	return parts, nil
+}
+
+func (d *Dir) CompleteUpload(ctx context.Context, key, uploadID string, parts int) error {
+	dir, err := d.uploadDir(uploadID)
+	if err != nil {
+		return err
+	}
+	p, err := d.path(key)
+	if err != nil {
+		return err
+	}
+	err = writeAtomic(p, func(w io.Writer) error {
+		for n := 1; n <= parts; n++ {
+			f, err := os.Open(filepath.Join(dir, partName(n)))
+			if err != nil {
+				return fmt.Errorf("blob: part %d: %w", n, err)
+			}
+			_, err = io.Copy(w, f)
+			f.Close()
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	return os.RemoveAll(dir)
+}
+
+func (d *Dir) AbortUpload(ctx context.Context, key, uploadID string) error {
+	dir, err := d.uploadDir(uploadID)
+	if err != nil {
+		return err
+	}
+	return os.RemoveAll(dir)
+}
+
+func partName(n int) string { return fmt.Sprintf("%05d", n) }
+
+func writeAtomic(path string, write func(io.Writer) error) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if err := write(tmp); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+var (
+	_ Store     = (*Dir)(nil)
+	_ Multipart = (*Dir)(nil)
+)
@@ -0,0 +1,362 @@
+package blob
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"net/url"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+
+	"go-solid/clock"
+)
+
+// S3 Low-level module - a bucket in Amazon S3, or in any store that speaks
+// its REST API (MinIO, Ceph, R2), over net/http with Signature Version 4.
+// Uploads are S3's own multipart uploads, so one started by a process that
+// died is resumed by the next: the parts and their ETags live in the bucket.
+type S3 struct {
+	Bucket string
+	// Endpoint defaults to https://s3.<region>.amazonaws.com with the bucket
+	// in the host name. When it is set, the bucket is the first path segment
+	// instead, which is what S3-compatible stores expect.
+	Endpoint    string
+	Region      string // defaults to us-east-1
+	Credentials Credentials
+	Client      *http.Client
+	Clock       clock.Clock
+}
+
+// Credentials An access key; the zero value sends requests unsigned.
+type Credentials struct {
+	AccessKeyID     string
+	SecretAccessKey string
+	SessionToken    string
+}
+
+// openS3 handles s3://bucket/path/to/object. The endpoint, region and
+// credentials come from the variables the AWS tools read.
+func openS3(u *url.URL) (Store, string, error) {
+	key := strings.TrimPrefix(u.Path, "/")
+	if u.Host == "" || key == "" {
+		return nil, "", fmt.Errorf("blob: %s needs a bucket and a key", u)
+	}
+	return &S3{
+		Bucket:   u.Host,
+		Endpoint: cmp.Or(os.Getenv("AWS_ENDPOINT_URL_S3"), os.Getenv("AWS_ENDPOINT_URL")),
+		Region:   cmp.Or(os.Getenv("AWS_REGION"), os.Getenv("AWS_DEFAULT_REGION")),
+		Credentials: Credentials{
+			AccessKeyID:     os.Getenv("AWS_ACCESS_KEY_ID"),
+			SecretAccessKey: os.Getenv("AWS_SECRET_ACCESS_KEY"),
+			SessionToken:    os.Getenv("AWS_SESSION_TOKEN"),
+		},
+	}, key, nil
+}
+
+// Put reads r whole before sending it, because S3 wants the length and the
+// hash of the body up front. Large objects go through multipart uploads.
+func (s *S3) Put(ctx context.Context, key string, r io.Reader) error {
+	body, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	resp, err := s.do(ctx, http.MethodPut, key, nil, body)
+	if err != nil {
+		return err
+	}
+	return resp.Body.Close()
+}
+
+func (s *S3) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	resp, err := s.do(ctx, http.MethodGet, key, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Body, nil
+}
+
+// Delete succeeds for a missing key, as S3's DELETE does.
+func (s *S3) Delete(ctx context.Context, key string) error {
+	resp, err := s.do(ctx, http.MethodDelete, key, nil, nil)
+	if errors.Is(err, ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	return resp.Body.Close()
+}
+
+func (s *S3) CreateUpload(ctx context.Context, key string) (string, error) {
+	var res struct {
+		UploadID string `xml:"UploadId"`
+	}
+	if err := s.call(ctx, http.MethodPost, key, url.Values{"uploads": {""}}, nil, &res); err != nil {
+		return "", err
+	}
+	return res.UploadID, nil
+}
+
+func (s *S3) UploadPart(ctx context.Context, key, uploadID string, n int, data []byte) error {
+	q := url.Values{"partNumber": {strconv.Itoa(n)}, "uploadId": {uploadID}}
+	resp, err := s.do(ctx, http.MethodPut, key, q, data)
+	if err != nil {
+		return err
+	}
+	return resp.Body.Close()
+}
+
+// s3Part One part of an upload as ListParts reports it and
+// CompleteMultipartUpload wants it back
+type s3Part struct {
+	PartNumber int
+	ETag       string
+}
+
+func (s *S3) ListParts(ctx context.Context, key, uploadID string) ([]int, error) {
+	parts, err := s.parts(ctx, key, uploadID)
+	if err != nil {
+		return nil, err
+	}
+	numbers := make([]int, len(parts))
+	for i, p := range parts {
+		numbers[i] = p.PartNumber
+	}
+	return numbers, nil
+}
+
+// parts follows ListParts' pages, a thousand parts at a time.
+func (s *S3) parts(ctx context.Context, key, uploadID string) ([]s3Part, error) {
+	var parts []s3Part
+	marker := ""
+	for {
+		var res struct {
+			IsTruncated          bool
+			NextPartNumberMarker string
+			Part                 []s3Part
+		}
+		q := url.Values{"uploadId": {uploadID}}
+		if marker != "" {
+			q.Set("part-number-marker", marker)
+		}
+		if err := s.call(ctx, http.MethodGet, key, q, nil, &res); err != nil {
+			return nil, err
+		}
+		parts = append(parts, res.Part...)
+		if !res.IsTruncated {
+			slices.SortFunc(parts, func(a, b s3Part) int { return a.PartNumber - b.PartNumber })
+			return parts, nil
+		}
+		marker = res.NextPartNumberMarker
+	}
+}
+
+// CompleteUpload asks the bucket for the parts' ETags rather than keeping
+// them, so a process that didn't upload the parts can complete the upload.
+func (s *S3) CompleteUpload(ctx context.Context, key, uploadID string, parts int) error {
+	uploaded, err := s.parts(ctx, key, uploadID)
+	if err != nil {
+		return err
+	}
+	var complete struct {
+		XMLName xml.Name `xml:"CompleteMultipartUpload"`
+		Part    []s3Part
+	}
+	for n := 1; n <= parts; n++ {
+		i := slices.IndexFunc(uploaded, func(p s3Part) bool { return p.PartNumber == n })
+		if i < 0 {
+			return fmt.Errorf("blob: part %d of upload %s: %w", n, uploadID, ErrNotFound)
+		}
+		complete.Part = append(complete.Part, uploaded[i])
+	}
+	body, _ := xml.Marshal(complete)
+	// S3 can answer 200 and still fail, with an <Error> for a body
+	var res struct {
+		XMLName xml.Name
+		Code    string
+		Message string
+	}
+	if err := s.call(ctx, http.MethodPost, key, url.Values{"uploadId": {uploadID}}, body, &res); err != nil {
+		return err
+	}
+	if res.XMLName.Local == "Error" {
+		return fmt.Errorf("blob: complete upload %s: %s: %s", uploadID, res.Code, res.Message)
+	}
+	return nil
+}
+
+func (s *S3) AbortUpload(ctx context.Context, key, uploadID string) error {
+	resp, err := s.do(ctx, http.MethodDelete, key, url.Values{"uploadId": {uploadID}}, nil)
+	if err != nil {
+		return err
+	}
+	return resp.Body.Close()
+}
+
+// call sends a request and decodes its XML answer into out.
+func (s *S3) call(ctx context.Context, method, key string, q url.Values, body []byte, out any) error {
+	resp, err := s.do(ctx, method, key, q, body)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if err := xml.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("blob: s3 %s %s: %w", method, key, err)
+	}
+	return nil
+}
+
+// do sends a signed request and turns S3's errors into the package's: a
+// missing key is ErrNotFound and a missing upload ErrUnknownUpload.
+func (s *S3) do(ctx context.Context, method, key string, q url.Values, body []byte) (*http.Response, error) {
+	if key == "" {
+		return nil, fmt.Errorf("blob: invalid key %q", key)
+	}
+	u, err := s.url(key, q)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, method, u, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.ContentLength = int64(len(body))
+	sum := sha256.Sum256(body)
+	s.sign(req, hex.EncodeToString(sum[:]))
+
+	resp, err := cmp.Or(s.Client, http.DefaultClient).Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("blob: s3: %w", err)
+	}
+	if resp.StatusCode/100 == 2 {
+		return resp, nil
+	}
+	defer resp.Body.Close()
+	var e struct {
+		Code    string
+		Message string
+	}
+	_ = xml.NewDecoder(io.LimitReader(resp.Body, 4096)).Decode(&e)
+	switch {
+	case e.Code == "NoSuchUpload":
+		return nil, fmt.Errorf("%w: %s", ErrUnknownUpload, q.Get("uploadId"))
+	case e.Code == "NoSuchKey", resp.StatusCode == http.StatusNotFound && e.Code == "":
+		return nil, ErrNotFound
+	}
+	return nil, fmt.Errorf("blob: s3 %s %s: %s: %s %s", method, key, resp.Status, e.Code, e.Message)
+}
+
+func (s *S3) region() string { return cmp.Or(s.Region, "us-east-1") }
+
+func (s *S3) url(key string, q url.Values) (string, error) {
+	var u *url.URL
+	if s.Endpoint == "" {
+		u = &url.URL{Scheme: "https", Host: s.Bucket + ".s3." + s.region() + ".amazonaws.com", Path: "/" + key}
+	} else {
+		var err error
+		if u, err = url.Parse(s.Endpoint); err != nil {
+			return "", fmt.Errorf("blob: s3 endpoint: %w", err)
+		}
+		u.Path = strings.TrimSuffix(u.Path, "/") + "/" + s.Bucket + "/" + key
+	}
+	u.RawPath = uriEncode(u.Path, false)
+	u.RawQuery = canonicalQuery(q)
+	return u.String(), nil
+}
+
+// sign adds Signature Version 4 headers to req, signing the host and every
+// header req already has.
+func (s *S3) sign(req *http.Request, payloadHash string) {
+	c := s.Credentials
+	if c.AccessKeyID == "" {
+		return
+	}
+	now := cmp.Or[clock.Clock](s.Clock, clock.Real{}).Now().UTC()
+	req.Header.Set("X-Amz-Date", now.Format("20060102T150405Z"))
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+	if c.SessionToken != "" {
+		req.Header.Set("X-Amz-Security-Token", c.SessionToken)
+	}
+
+	headers := map[string]string{"host": req.URL.Host}
+	for name, values := range req.Header {
+		headers[strings.ToLower(name)] = strings.TrimSpace(strings.Join(values, ","))
+	}
+	names := slices.Sorted(maps.Keys(headers))
+	var canonical strings.Builder
+	for _, name := range names {
+		canonical.WriteString(name + ":" + headers[name] + "\n")
+	}
+	signed := strings.Join(names, ";")
+
+	request := strings.Join([]string{
+		req.Method,
+		uriEncode(req.URL.Path, false),
+		req.URL.RawQuery, // already canonical, see url
+		canonical.String(),
+		signed,
+		payloadHash,
+	}, "\n")
+	date := now.Format("20060102")
+	scope := date + "/" + s.region() + "/s3/aws4_request"
+	hash := sha256.Sum256([]byte(request))
+	toSign := "AWS4-HMAC-SHA256\n" + now.Format("20060102T150405Z") + "\n" + scope + "\n" + hex.EncodeToString(hash[:])
+
+	key := []byte("AWS4" + c.SecretAccessKey)
+	for _, part := range []string{date, s.region(), "s3", "aws4_request"} {
+		key = hmacSHA256(key, part)
+	}
+	signature := hex.EncodeToString(hmacSHA256(key, toSign))
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		c.AccessKeyID, scope, signed, signature))
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(data))
+	return h.Sum(nil)
+}
+
+// canonicalQuery sorts the query by name and encodes it the way both the
+// URL and the signature need it: "uploads=" for a flag without a value.
+func canonicalQuery(q url.Values) string {
+	var pairs []string
+	for _, name := range slices.Sorted(maps.Keys(q)) {
+		for _, v := range slices.Sorted(slices.Values(q[name])) {
+			pairs = append(pairs, uriEncode(name, true)+"="+uriEncode(v, true))
+		}
+	}
+	return strings.Join(pairs, "&")
+}
+
+// uriEncode escapes everything but RFC 3986's unreserved characters, and
+// '/' too when encodeSlash is set, as Signature Version 4 requires.
+func uriEncode(s string, encodeSlash bool) string {
+	var b strings.Builder
+	for _, c := range []byte(s) {
+		switch {
+		case 'A' <= c && c <= 'Z', 'a' <= c && c <= 'z', '0' <= c && c <= '9',
+			c == '-', c == '_', c == '.', c == '~', c == '/' && !encodeSlash:
+			b.WriteByte(c)
+		default:
+			fmt.Fprintf(&b, "%%%02X", c)
+		}
+	}
+	return b.String()
+}
+
+var (
+	_ Store     = (*S3)(nil)
+	_ Multipart = (*S3)(nil)
+)
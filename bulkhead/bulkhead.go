@@ -0,0 +1,104 @@
+// Package bulkhead caps how many calls a dependency can have in flight, so
+// one slow dependency can't take every goroutine, connection or worker the
+// application has.
+//
+// Like the compartments of a ship's hull, each dependency gets its own
+// Bulkhead: when one fills up, its callers queue for a while and are then
+// turned away at once, and the others carry on. The Repository decorator
+// applies it to an employee.Repository; nothing else changes.
+package bulkhead
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// ErrRejected returned when every slot is taken and the queue is full
+var ErrRejected = errors.New("bulkhead: too many calls in flight")
+
+// Stats What a Bulkhead is doing now, and what it has done since it was created
+type Stats struct {
+	InFlight  int `json:"in_flight"`
+	Queued    int `json:"queued"`
+	Admitted  int `json:"admitted"`
+	Rejected  int `json:"rejected"`  // turned away because the queue was full
+	Abandoned int `json:"abandoned"` // gave up in the queue when their context ended
+}
+
+// Bulkhead Admits at most MaxConcurrent calls at a time and lets up to
+// MaxQueue more wait for a slot. Safe for concurrent use.
+type Bulkhead struct {
+	slots    chan struct{}
+	maxQueue int
+
+	mu    sync.Mutex
+	stats Stats
+}
+
+// New creates a Bulkhead with maxConcurrent slots and room for maxQueue
+// waiting calls. With a queue of 0, a call finding every slot taken is
+// rejected at once.
+func New(maxConcurrent, maxQueue int) *Bulkhead {
+	return &Bulkhead{slots: make(chan struct{}, max(maxConcurrent, 1)), maxQueue: max(maxQueue, 0)}
+}
+
+// Acquire takes a slot, waiting in the queue if there is room, and returns
+// the function that gives it back. It fails with ErrRejected when the queue
+// is full, or with the context's error when ctx ends while waiting.
+func (b *Bulkhead) Acquire(ctx context.Context) (release func(), err error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	select {
+	case b.slots <- struct{}{}:
+		b.update(func(s *Stats) { s.Admitted++; s.InFlight++ })
+		return sync.OnceFunc(b.release), nil
+	default:
+	}
+
+	b.mu.Lock()
+	if b.stats.Queued >= b.maxQueue {
+		b.stats.Rejected++
+		b.mu.Unlock()
+		return nil, ErrRejected
+	}
+	b.stats.Queued++
+	b.mu.Unlock()
+
+	select {
+	case b.slots <- struct{}{}:
+		b.update(func(s *Stats) { s.Queued--; s.Admitted++; s.InFlight++ })
+		return sync.OnceFunc(b.release), nil
+	case <-ctx.Done():
+		b.update(func(s *Stats) { s.Queued--; s.Abandoned++ })
+		return nil, ctx.Err()
+	}
+}
+
+func (b *Bulkhead) release() {
+	b.update(func(s *Stats) { s.InFlight-- })
+	<-b.slots
+}
+
+// Do runs f in a slot.
+func (b *Bulkhead) Do(ctx context.Context, f func(context.Context) error) error {
+	release, err := b.Acquire(ctx)
+	if err != nil {
+		return err
+	}
+	defer release()
+	return f(ctx)
+}
+
+func (b *Bulkhead) Stats() Stats {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.stats
+}
+
+func (b *Bulkhead) update(f func(*Stats)) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	f(&b.stats)
+}
@@ -0,0 +1,153 @@
+package bulkhead
+
+import (
+	"context"
+	"errors"
+	"iter"
+
+	"go-solid/employee"
+	"go-solid/outbox"
+	"go-solid/spec"
+)
+
+// Repository Decorator running every call to an employee.Repository through a
+// Bulkhead, reads and writes alike. Optional capabilities are forwarded, and
+// share the same slots.
+type Repository struct {
+	next     employee.Repository
+	bulkhead *Bulkhead
+}
+
+func NewRepository(next employee.Repository, b *Bulkhead) *Repository {
+	return &Repository{next: next, bulkhead: b}
+}
+
+// Wrapped returns the repository behind the bulkhead.
+func (r *Repository) Wrapped() any { return r.next }
+
+func (r *Repository) Save(ctx context.Context, emp employee.Employee) error {
+	return r.bulkhead.Do(ctx, func(ctx context.Context) error { return r.next.Save(ctx, emp) })
+}
+
+func (r *Repository) GetByName(ctx context.Context, name string) (emp employee.Employee, err error) {
+	err = r.bulkhead.Do(ctx, func(ctx context.Context) error {
+		emp, err = r.next.GetByName(ctx, name)
+		return err
+	})
+	return emp, err
+}
+
+func (r *Repository) GetByID(ctx context.Context, id employee.ID) (emp employee.Employee, err error) {
+	err = r.bulkhead.Do(ctx, func(ctx context.Context) error {
+		emp, err = employee.GetByID(ctx, r.next, id)
+		return err
+	})
+	return emp, err
+}
+
+// All holds one slot while the loop runs, as the backend holds its cursor.
+func (r *Repository) All(ctx context.Context) iter.Seq2[employee.Employee, error] {
+	return func(yield func(employee.Employee, error) bool) {
+		err := r.bulkhead.Do(ctx, func(ctx context.Context) error {
+			for emp, err := range employee.All(ctx, r.next) {
+				if !yield(emp, err) {
+					return nil
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			yield(employee.Employee{}, err)
+		}
+	}
+}
+
+// SaveAll takes one slot for the whole batch, as it is one call to the backend.
+func (r *Repository) SaveAll(ctx context.Context, emps iter.Seq[employee.Employee]) error {
+	return r.bulkhead.Do(ctx, func(ctx context.Context) error { return employee.SaveAll(ctx, r.next, emps) })
+}
+
+func (r *Repository) Update(ctx context.Context, emp employee.Employee, expectedVersion int) (stored employee.Employee, err error) {
+	u, ok := r.next.(employee.Updater)
+	if !ok {
+		return employee.Employee{}, errors.ErrUnsupported
+	}
+	err = r.bulkhead.Do(ctx, func(ctx context.Context) error {
+		stored, err = u.Update(ctx, emp, expectedVersion)
+		return err
+	})
+	return stored, err
+}
+
+func (r *Repository) SoftDelete(ctx context.Context, name string) error {
+	d, ok := r.next.(employee.SoftDeleter)
+	if !ok {
+		return errors.ErrUnsupported
+	}
+	return r.bulkhead.Do(ctx, func(ctx context.Context) error { return d.SoftDelete(ctx, name) })
+}
+
+func (r *Repository) Restore(ctx context.Context, name string) error {
+	d, ok := r.next.(employee.SoftDeleter)
+	if !ok {
+		return errors.ErrUnsupported
+	}
+	return r.bulkhead.Do(ctx, func(ctx context.Context) error { return d.Restore(ctx, name) })
+}
+
+func (r *Repository) History(ctx context.Context, name string) (history []employee.Employee, err error) {
+	v, ok := r.next.(employee.Versioned)
+	if !ok {
+		return nil, errors.ErrUnsupported
+	}
+	err = r.bulkhead.Do(ctx, func(ctx context.Context) error {
+		history, err = v.History(ctx, name)
+		return err
+	})
+	return history, err
+}
+
+func (r *Repository) List(ctx context.Context, filter employee.Filter, page employee.Page) (res employee.PageResult, err error) {
+	q, ok := r.next.(employee.QueryRepository)
+	if !ok {
+		return employee.PageResult{}, errors.ErrUnsupported
+	}
+	err = r.bulkhead.Do(ctx, func(ctx context.Context) error {
+		res, err = q.List(ctx, filter, page)
+		return err
+	})
+	return res, err
+}
+
+func (r *Repository) Matching(ctx context.Context, s spec.Specification[employee.Employee]) (emps []employee.Employee, err error) {
+	m, ok := r.next.(employee.SpecificationRepository)
+	if !ok {
+		return nil, errors.ErrUnsupported
+	}
+	err = r.bulkhead.Do(ctx, func(ctx context.Context) error {
+		emps, err = m.Matching(ctx, s)
+		return err
+	})
+	return emps, err
+}
+
+func (r *Repository) SaveWithOutbox(ctx context.Context, emp employee.Employee, msgs []outbox.Message) error {
+	o, ok := r.next.(employee.OutboxRepository)
+	if !ok {
+		return errors.ErrUnsupported
+	}
+	return r.bulkhead.Do(ctx, func(ctx context.Context) error { return o.SaveWithOutbox(ctx, emp, msgs) })
+}
+
+var (
+	_ employee.Repository              = (*Repository)(nil)
+	_ employee.BulkSaver               = (*Repository)(nil)
+	_ employee.SoftDeleter             = (*Repository)(nil)
+	_ employee.Updater                 = (*Repository)(nil)
+	_ employee.Iterable                = (*Repository)(nil)
+	_ employee.IDRepository            = (*Repository)(nil)
+	_ employee.Versioned               = (*Repository)(nil)
+	_ employee.QueryRepository         = (*Repository)(nil)
+	_ employee.SpecificationRepository = (*Repository)(nil)
+	_ employee.OutboxRepository        = (*Repository)(nil)
+)
@@ -0,0 +1,16 @@
+package bulkhead_test
+
+import (
+	"testing"
+
+	"go-solid/bulkhead"
+	"go-solid/employee"
+	"go-solid/employee/employeetest"
+	"go-solid/employee/memory"
+)
+
+func TestRepository(t *testing.T) {
+	employeetest.TestRepository(t, func(*testing.T) employee.Repository {
+		return bulkhead.NewRepository(memory.New(), bulkhead.New(4, 4))
+	})
+}
@@ -0,0 +1,286 @@
+// Package bundle packs a workshop into one archive that runs without a
+// network: the solid binary, the course content, the module's source with
+// its dependencies vendored, and a module cache go reads as a proxy
+// (GOPROXY=file://...).
+//
+// A bundle is a list of Parts, each a file system placed under its own
+// directory; Write doesn't know where any of them came from, so a new kind
+// of part never changes it (OCP). MANIFEST.json records every file with its
+// hash, and Open refuses an archive that doesn't match it: a bundle passed
+// around on a USB stick is checked before a room full of learners relies
+// on it.
+package bundle
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"maps"
+	"os"
+	"path"
+	"slices"
+	"strings"
+	"testing/fstest"
+	"time"
+)
+
+// ErrCorrupt returned for a bundle whose files don't match its manifest
+var ErrCorrupt = errors.New("bundle doesn't match its manifest")
+
+const (
+	// ManifestFile Lists a bundle's files, at its root
+	ManifestFile = "MANIFEST.json"
+	// ContentDir Holds the course content, laid out like lessons/
+	ContentDir = "content"
+)
+
+// Part One directory of a bundle
+type Part struct {
+	Dir string // where the files go in the bundle; "." for its root
+	FS  fs.FS
+	// Skip leaves out a file, or a whole directory, by its path in FS
+	Skip func(name string, d fs.DirEntry) bool
+}
+
+// File One file of a bundle
+type File struct {
+	Path   string      `json:"path"`
+	Mode   fs.FileMode `json:"mode"`
+	Size   int64       `json:"size"`
+	SHA256 string      `json:"sha256"`
+}
+
+// Manifest What a bundle holds
+type Manifest struct {
+	// Name is the directory the archive unpacks into
+	Name     string    `json:"name"`
+	Created  time.Time `json:"created"`
+	Go       string    `json:"go"`       // the toolchain that built it; the room needs this one or newer
+	Platform string    `json:"platform"` // GOOS/GOARCH of the binaries in bin
+	Parts    []string  `json:"parts"`
+	Files    []File    `json:"files"`
+}
+
+// Size is the total size of the bundle's files, uncompressed.
+func (m Manifest) Size() int64 {
+	var n int64
+	for _, f := range m.Files {
+		n += f.Size
+	}
+	return n
+}
+
+// Has reports whether the bundle has a part in dir.
+func (m Manifest) Has(dir string) bool { return slices.Contains(m.Parts, dir) }
+
+// Write archives parts as a gzipped tar under m.Name, with the manifest
+// last, and returns the manifest. Files are stamped with m.Created, so the
+// same parts make the same archive.
+func Write(ctx context.Context, w io.Writer, m Manifest, parts ...Part) (Manifest, error) {
+	if m.Name == "" || strings.ContainsAny(m.Name, `/\`) {
+		return Manifest{}, fmt.Errorf("bundle: name %q must be a plain directory name", m.Name)
+	}
+	zw := gzip.NewWriter(w)
+	tw := tar.NewWriter(zw)
+	seen := map[string]bool{ManifestFile: true}
+	for _, p := range parts {
+		m.Parts = append(m.Parts, p.Dir)
+		err := fs.WalkDir(p.FS, ".", func(name string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			if name != "." && p.Skip != nil && p.Skip(name, d) {
+				if d.IsDir() {
+					return fs.SkipDir
+				}
+				return nil
+			}
+			if !d.Type().IsRegular() {
+				return nil
+			}
+			dest := path.Join(p.Dir, name)
+			if seen[dest] {
+				return fmt.Errorf("%s is in two parts", dest)
+			}
+			seen[dest] = true
+			info, err := d.Info()
+			if err != nil {
+				return err
+			}
+			f, err := add(tw, p.FS, name, dest, info, m)
+			m.Files = append(m.Files, f)
+			return err
+		})
+		if err != nil {
+			return Manifest{}, fmt.Errorf("bundle: part %s: %w", p.Dir, err)
+		}
+	}
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return Manifest{}, err
+	}
+	hdr := &tar.Header{Name: path.Join(m.Name, ManifestFile), Mode: 0o644, Size: int64(len(data)), ModTime: m.Created, Format: tar.FormatPAX}
+	if err := tw.WriteHeader(hdr); err != nil {
+		return Manifest{}, fmt.Errorf("bundle: %w", err)
+	}
+	if _, err := tw.Write(data); err != nil {
+		return Manifest{}, fmt.Errorf("bundle: %w", err)
+	}
+	if err := errors.Join(tw.Close(), zw.Close()); err != nil {
+		return Manifest{}, fmt.Errorf("bundle: %w", err)
+	}
+	return m, nil
+}
+
+// add copies one file into the archive and describes it.
+func add(tw *tar.Writer, fsys fs.FS, name, dest string, info fs.FileInfo, m Manifest) (File, error) {
+	mode := fs.FileMode(0o644)
+	if info.Mode()&0o111 != 0 {
+		mode = 0o755
+	}
+	hdr := &tar.Header{Name: path.Join(m.Name, dest), Mode: int64(mode), Size: info.Size(), ModTime: m.Created, Format: tar.FormatPAX}
+	if err := tw.WriteHeader(hdr); err != nil {
+		return File{}, err
+	}
+	src, err := fsys.Open(name)
+	if err != nil {
+		return File{}, err
+	}
+	defer src.Close()
+	h := sha256.New()
+	n, err := io.Copy(io.MultiWriter(tw, h), src)
+	if err != nil {
+		return File{}, err
+	}
+	if n != info.Size() {
+		return File{}, fmt.Errorf("%s changed while it was archived", name)
+	}
+	return File{Path: dest, Mode: mode, Size: n, SHA256: hex.EncodeToString(h.Sum(nil))}, nil
+}
+
+// Bundle An archive read into memory, checked against its manifest. It is
+// an fs.FS rooted where the archive unpacks, so fs.Sub(b, ContentDir) is
+// the course.
+type Bundle struct {
+	fstest.MapFS
+	Manifest Manifest
+}
+
+// Open reads an archive Write wrote and checks every file against the
+// manifest.
+func Open(r io.Reader) (*Bundle, error) {
+	zr, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("bundle: %w", err)
+	}
+	files := fstest.MapFS{}
+	tr := tar.NewReader(zr)
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("bundle: %w", err)
+		}
+		if hdr.Typeflag != tar.TypeReg {
+			continue
+		}
+		_, name, ok := strings.Cut(hdr.Name, "/")
+		if !ok || !fs.ValidPath(name) {
+			return nil, fmt.Errorf("%w: unexpected file %s", ErrCorrupt, hdr.Name)
+		}
+		data, err := io.ReadAll(tr)
+		if err != nil {
+			return nil, fmt.Errorf("bundle: %w", err)
+		}
+		files[name] = &fstest.MapFile{Data: data, Mode: fs.FileMode(hdr.Mode).Perm(), ModTime: hdr.ModTime}
+	}
+	m, err := Verify(files)
+	if err != nil {
+		return nil, err
+	}
+	// unpacked, a bundle gains files as it is used; archived, it has
+	// only those it was built with
+	listed := map[string]bool{ManifestFile: true}
+	for _, f := range m.Files {
+		listed[f.Path] = true
+	}
+	var extra []string
+	for _, name := range slices.Sorted(maps.Keys(files)) {
+		if !listed[name] {
+			extra = append(extra, name)
+		}
+	}
+	if len(extra) > 0 {
+		return nil, fmt.Errorf("%w: %s isn't listed", ErrCorrupt, strings.Join(extra, ", "))
+	}
+	return &Bundle{MapFS: files, Manifest: m}, nil
+}
+
+// Verify checks a bundle, archived or unpacked, against its manifest: every
+// file listed is there, with the hash recorded.
+func Verify(fsys fs.FS) (Manifest, error) {
+	m, err := ReadManifest(fsys)
+	if err != nil {
+		return Manifest{}, err
+	}
+	for _, f := range m.Files {
+		data, err := fs.ReadFile(fsys, f.Path)
+		if err != nil {
+			return Manifest{}, fmt.Errorf("%w: %s is missing", ErrCorrupt, f.Path)
+		}
+		if sum := sha256.Sum256(data); hex.EncodeToString(sum[:]) != f.SHA256 || int64(len(data)) != f.Size {
+			return Manifest{}, fmt.Errorf("%w: %s was changed", ErrCorrupt, f.Path)
+		}
+	}
+	return m, nil
+}
+
+// ReadManifest reads the manifest of a bundle without checking its files.
+func ReadManifest(fsys fs.FS) (Manifest, error) {
+	data, err := fs.ReadFile(fsys, ManifestFile)
+	if err != nil {
+		return Manifest{}, fmt.Errorf("%w: %s: %w", ErrCorrupt, ManifestFile, err)
+	}
+	var m Manifest
+	if err := json.Unmarshal(data, &m); err != nil {
+		return Manifest{}, fmt.Errorf("%w: %s: %w", ErrCorrupt, ManifestFile, err)
+	}
+	return m, nil
+}
+
+// Load opens the bundle at path: an archive, read and checked whole, or the
+// directory it was unpacked into, whose files are read as they are asked
+// for. solid bundle verify checks an unpacked one.
+func Load(path string) (fs.FS, Manifest, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, Manifest{}, fmt.Errorf("bundle: %w", err)
+	}
+	if info.IsDir() {
+		fsys := os.DirFS(path)
+		m, err := ReadManifest(fsys)
+		return fsys, m, err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, Manifest{}, fmt.Errorf("bundle: %w", err)
+	}
+	b, err := Open(bytes.NewReader(data))
+	if err != nil {
+		return nil, Manifest{}, err
+	}
+	return b, b.Manifest, nil
+}
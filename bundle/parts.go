@@ -0,0 +1,192 @@
+package bundle
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing/fstest"
+)
+
+// Content is the course, as content.FS reads it.
+func Content(fsys fs.FS) Part { return Part{Dir: ContentDir, FS: fsys} }
+
+// Binary builds pkg from the module in dir for goos/goarch into stage, and
+// is the part holding it, in bin. A binary built without cgo runs on any
+// machine of that platform, installed Go or not.
+func Binary(ctx context.Context, dir, pkg, goos, goarch, stage string) (Part, error) {
+	name := binaryName(pkg)
+	if goos == "windows" {
+		name += ".exe"
+	}
+	cmd := exec.CommandContext(ctx, "go", "build", "-trimpath", "-o", filepath.Join(stage, name), pkg)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), "GOOS="+goos, "GOARCH="+goarch, "CGO_ENABLED=0")
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return Part{}, fmt.Errorf("bundle: go build %s: %w: %s", pkg, err, strings.TrimSpace(string(out)))
+	}
+	return Part{Dir: "bin", FS: os.DirFS(stage)}, nil
+}
+
+func binaryName(pkg string) string { return filepath.Base(strings.TrimSuffix(pkg, "/")) }
+
+// Module is the source of the module in dir, in module, without hidden
+// files, a vendor directory (Vendor makes a fresh one) or the paths
+// exclude names. In a git checkout, what git ignores is left out too.
+func Module(ctx context.Context, dir string, exclude ...string) Part {
+	skip := map[string]bool{"vendor": true}
+	for _, p := range exclude {
+		if rel, err := filepath.Rel(dir, p); err == nil && filepath.IsLocal(rel) {
+			skip[filepath.ToSlash(rel)] = true
+		}
+	}
+	tracked := gitFiles(ctx, dir)
+	return Part{Dir: "module", FS: os.DirFS(dir), Skip: func(name string, d fs.DirEntry) bool {
+		switch {
+		case strings.HasPrefix(d.Name(), ".") || skip[name]:
+			return true
+		case tracked == nil:
+			return false
+		case d.IsDir():
+			return !kept(tracked, name)
+		}
+		return !tracked[name]
+	}}
+}
+
+// gitFiles lists the files of the checkout in dir git doesn't ignore,
+// committed or not, or returns nil when dir isn't one.
+func gitFiles(ctx context.Context, dir string) map[string]bool {
+	out, err := exec.CommandContext(ctx, "git", "-C", dir, "ls-files", "-z", "--cached", "--others", "--exclude-standard").Output()
+	if err != nil {
+		return nil
+	}
+	files := map[string]bool{}
+	for name := range strings.SplitSeq(strings.TrimSuffix(string(out), "\x00"), "\x00") {
+		files[name] = true
+	}
+	return files
+}
+
+// Vendor vendors the dependencies of the module in dir into stage, with go
+// mod vendor, and is the part putting them in module/vendor, where go
+// builds from them without asking a proxy. A module without dependencies
+// has nothing to vendor, and the part is empty.
+func Vendor(ctx context.Context, dir, stage string) (Part, error) {
+	out := filepath.Join(stage, "vendor")
+	cmd := exec.CommandContext(ctx, "go", "mod", "vendor", "-o", out)
+	cmd.Dir = dir
+	if msg, err := cmd.CombinedOutput(); err != nil {
+		return Part{}, fmt.Errorf("bundle: go mod vendor: %w: %s", err, strings.TrimSpace(string(msg)))
+	}
+	if _, err := os.Stat(out); err != nil {
+		return Part{Dir: "module/vendor", FS: fstest.MapFS{}}, nil
+	}
+	return Part{Dir: "module/vendor", FS: os.DirFS(out)}, nil
+}
+
+// download One module go mod download fetched, with its files in the cache
+type download struct {
+	Path, Version    string
+	Info, GoMod, Zip string
+	Error            string
+}
+
+// ModCache downloads every module the module in dir needs, with go mod
+// download, and is the part holding their files from the module cache, in
+// modcache. The cache's download directory is laid out as a module proxy
+// is, so GOPROXY=file://<bundle>/modcache serves them to go offline, to
+// learners starting modules of their own.
+func ModCache(ctx context.Context, dir string) (Part, error) {
+	cmd := exec.CommandContext(ctx, "go", "mod", "download", "-json", "all")
+	cmd.Dir = dir
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return Part{}, fmt.Errorf("bundle: go mod download: %w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+	cache, err := exec.CommandContext(ctx, "go", "env", "GOMODCACHE").Output()
+	if err != nil {
+		return Part{}, fmt.Errorf("bundle: go env: %w", err)
+	}
+	root := filepath.Join(strings.TrimSpace(string(cache)), "cache", "download")
+	keep := map[string]bool{}
+	dec := json.NewDecoder(bytes.NewReader(out))
+	for dec.More() {
+		var d download
+		if err := dec.Decode(&d); err != nil {
+			return Part{}, fmt.Errorf("bundle: go mod download: %w", err)
+		}
+		if d.Error != "" {
+			return Part{}, fmt.Errorf("bundle: %s@%s: %s", d.Path, d.Version, d.Error)
+		}
+		for _, file := range []string{d.Info, d.GoMod, d.Zip} {
+			rel, err := filepath.Rel(root, file)
+			if err != nil || !filepath.IsLocal(rel) {
+				continue
+			}
+			rel = filepath.ToSlash(rel)
+			keep[rel] = true
+			// a proxy answers "which versions?" from the list beside them
+			keep[rel[:strings.LastIndex(rel, "/")]+"/list"] = true
+		}
+	}
+	if len(keep) == 0 {
+		return Part{Dir: "modcache", FS: fstest.MapFS{}}, nil
+	}
+	return Part{Dir: "modcache", FS: os.DirFS(root), Skip: func(name string, d fs.DirEntry) bool {
+		if d.IsDir() {
+			return !kept(keep, name)
+		}
+		return !keep[name]
+	}}, nil
+}
+
+// kept reports whether any file to keep is under dir.
+func kept(keep map[string]bool, dir string) bool {
+	for name := range keep {
+		if strings.HasPrefix(name, dir+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// SetupScript Sourced from an unpacked bundle, it points the shell at the
+// bundle's binary, content and module cache
+const SetupScript = `# . ./env.sh from the directory the bundle unpacked into
+BUNDLE="$(pwd)"
+export PATH="$BUNDLE/bin:$PATH"
+export SOLID_BUNDLE="$BUNDLE"
+export GOPROXY="file://$BUNDLE/modcache"
+export GOSUMDB=off
+export GOTOOLCHAIN=local
+`
+
+// Setup is the part at the bundle's root: env.sh and a README saying what
+// to do with it.
+func Setup(m Manifest) Part {
+	readme := fmt.Sprintf(`%s: the SOLID workshop, offline
+
+Built %s with %s for %s.
+
+    . ./env.sh              # solid on PATH, go reading modules from modcache/
+    solid bundle verify .   # every file as it was built
+    solid lesson list
+    cd module && go run ./1.SRP
+
+The course (solid lesson, quiz, slides) is read from content/, the code from
+module/, whose dependencies are in module/vendor. Go itself isn't included:
+install %s or newer beforehand.
+`, m.Name, m.Created.Format("2 January 2006"), m.Go, m.Platform, m.Go)
+	return Part{Dir: ".", FS: fstest.MapFS{
+		"env.sh":     {Data: []byte(SetupScript), Mode: 0o755},
+		"README.txt": {Data: []byte(readme), Mode: 0o644},
+	}}
+}
@@ -0,0 +1,274 @@
+// Package chaos injects faults - latency, errors and timeouts - into calls
+// on a dependency, to show how the rest of the application copes when it
+// misbehaves.
+//
+// Faults are added by decorators (Repository, Notifier) around the real
+// implementation, so neither the implementation nor its callers change.
+// One Injector decides what every decorator sharing it injects, and can be
+// reconfigured while the application runs, over HTTP or from code.
+package chaos
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"net/http"
+	"sync"
+	"time"
+)
+
+var (
+	// ErrInjected returned by a call chosen to fail
+	ErrInjected = errors.New("chaos: injected failure")
+	// ErrTimeout returned by a call chosen to hang, once it gives up. It
+	// is a context.DeadlineExceeded, so callers treat it like a real one.
+	ErrTimeout = fmt.Errorf("chaos: injected timeout: %w", context.DeadlineExceeded)
+	// ErrInvalidConfig returned by Set for rates outside 0..1
+	ErrInvalidConfig = errors.New("chaos: invalid config")
+)
+
+// Config What to inject. Each rate is the probability, 0..1, that a call
+// gets that fault; a call can be delayed and then still fail.
+type Config struct {
+	Enabled bool
+	// Latency is added to LatencyRate of calls, plus up to Jitter more
+	Latency     time.Duration
+	Jitter      time.Duration
+	LatencyRate float64
+	// ErrorRate of calls fail at once with ErrInjected
+	ErrorRate float64
+	// TimeoutRate of calls hang until their context is done, or for
+	// Timeout when it has no deadline, then fail with ErrTimeout
+	TimeoutRate float64
+	Timeout     time.Duration
+}
+
+// wire is Config as JSON, durations as strings like "250ms"
+type wire struct {
+	Enabled     bool    `json:"enabled"`
+	Latency     string  `json:"latency,omitempty"`
+	Jitter      string  `json:"jitter,omitempty"`
+	LatencyRate float64 `json:"latency_rate,omitempty"`
+	ErrorRate   float64 `json:"error_rate,omitempty"`
+	TimeoutRate float64 `json:"timeout_rate,omitempty"`
+	Timeout     string  `json:"timeout,omitempty"`
+}
+
+func (c Config) MarshalJSON() ([]byte, error) {
+	str := func(d time.Duration) string {
+		if d == 0 {
+			return ""
+		}
+		return d.String()
+	}
+	return json.Marshal(wire{c.Enabled, str(c.Latency), str(c.Jitter), c.LatencyRate, c.ErrorRate, c.TimeoutRate, str(c.Timeout)})
+}
+
+func (c *Config) UnmarshalJSON(b []byte) error {
+	var w wire
+	if err := json.Unmarshal(b, &w); err != nil {
+		return err
+	}
+	parsed := Config{Enabled: w.Enabled, LatencyRate: w.LatencyRate, ErrorRate: w.ErrorRate, TimeoutRate: w.TimeoutRate}
+	for _, d := range []struct {
+		in  string
+		out *time.Duration
+	}{{w.Latency, &parsed.Latency}, {w.Jitter, &parsed.Jitter}, {w.Timeout, &parsed.Timeout}} {
+		if d.in == "" {
+			continue
+		}
+		v, err := time.ParseDuration(d.in)
+		if err != nil {
+			return fmt.Errorf("%w: %v", ErrInvalidConfig, err)
+		}
+		*d.out = v
+	}
+	*c = parsed
+	return nil
+}
+
+func (c Config) validate() error {
+	for _, rate := range []float64{c.LatencyRate, c.ErrorRate, c.TimeoutRate} {
+		if rate < 0 || rate > 1 {
+			return fmt.Errorf("%w: rate %v is not between 0 and 1", ErrInvalidConfig, rate)
+		}
+	}
+	if c.Latency < 0 || c.Jitter < 0 || c.Timeout < 0 {
+		return fmt.Errorf("%w: negative duration", ErrInvalidConfig)
+	}
+	return nil
+}
+
+// Stats How many calls went through an Injector, and what they got
+type Stats struct {
+	Calls    int `json:"calls"`
+	Delayed  int `json:"delayed"`
+	Failed   int `json:"failed"`
+	TimedOut int `json:"timed_out"`
+}
+
+// Injector Decides, call by call, which faults to inject. Safe for
+// concurrent use; its Config can change at any time.
+type Injector struct {
+	mu    sync.Mutex
+	cfg   Config
+	rng   *rand.Rand
+	stats Stats
+}
+
+// Option customises an Injector created by New
+type Option func(*Injector)
+
+// WithSeed makes the sequence of faults repeatable, for demos.
+func WithSeed(seed uint64) Option {
+	return func(i *Injector) { i.rng = rand.New(rand.NewPCG(seed, seed)) }
+}
+
+func New(cfg Config, opts ...Option) (*Injector, error) {
+	if err := cfg.validate(); err != nil {
+		return nil, err
+	}
+	i := &Injector{cfg: cfg, rng: rand.New(rand.NewPCG(rand.Uint64(), rand.Uint64()))}
+	for _, opt := range opts {
+		opt(i)
+	}
+	return i, nil
+}
+
+func (i *Injector) Config() Config {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	return i.cfg
+}
+
+// Set replaces the configuration; calls already waiting keep their fault.
+func (i *Injector) Set(cfg Config) error {
+	if err := cfg.validate(); err != nil {
+		return err
+	}
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.cfg = cfg
+	return nil
+}
+
+// Enable switches injection on or off, keeping the rest of the Config.
+func (i *Injector) Enable(on bool) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.cfg.Enabled = on
+}
+
+func (i *Injector) Stats() Stats {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	return i.stats
+}
+
+// fault What one call gets
+type fault struct {
+	delay   time.Duration
+	fail    bool
+	hang    bool
+	timeout time.Duration
+}
+
+func (i *Injector) roll() fault {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.stats.Calls++
+	c := i.cfg
+	if !c.Enabled {
+		return fault{}
+	}
+	var f fault
+	if i.rng.Float64() < c.LatencyRate {
+		f.delay = c.Latency
+		if c.Jitter > 0 {
+			f.delay += time.Duration(i.rng.Int64N(int64(c.Jitter)))
+		}
+		i.stats.Delayed++
+	}
+	switch r := i.rng.Float64(); {
+	case r < c.TimeoutRate:
+		f.hang, f.timeout = true, c.Timeout
+		i.stats.TimedOut++
+	case r < c.TimeoutRate+c.ErrorRate:
+		f.fail = true
+		i.stats.Failed++
+	}
+	return f
+}
+
+// Inject is called by a decorator before it calls the real implementation,
+// which it skips when Inject returns an error.
+func (i *Injector) Inject(ctx context.Context) error {
+	f := i.roll()
+	if f.delay > 0 {
+		if err := sleep(ctx, f.delay); err != nil {
+			return err
+		}
+	}
+	switch {
+	case f.hang:
+		if _, ok := ctx.Deadline(); !ok && f.timeout > 0 {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, f.timeout)
+			defer cancel()
+		}
+		<-ctx.Done()
+		return ErrTimeout
+	case f.fail:
+		return ErrInjected
+	}
+	return nil
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
+
+// status is what the HTTP handler shows
+type status struct {
+	Config Config `json:"config"`
+	Stats  Stats  `json:"stats"`
+}
+
+// ServeHTTP makes the Injector controllable at runtime: GET shows its
+// Config and Stats, PUT replaces the Config with the JSON body.
+//
+//	curl -X PUT localhost:8081/admin/chaos -d '{"enabled":true,"error_rate":0.2}'
+func (i *Injector) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+	case http.MethodPut:
+		var cfg Config
+		dec := json.NewDecoder(http.MaxBytesReader(w, r.Body, 1<<16))
+		dec.DisallowUnknownFields()
+		err := dec.Decode(&cfg)
+		if err == nil {
+			err = i.Set(cfg)
+		}
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	default:
+		w.Header().Set("Allow", "GET, PUT")
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(status{Config: i.Config(), Stats: i.Stats()})
+}
+
+var _ http.Handler = (*Injector)(nil)
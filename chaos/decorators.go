@@ -0,0 +1,175 @@
+package chaos
+
+import (
+	"context"
+	"errors"
+	"iter"
+
+	"go-solid/employee"
+	"go-solid/notify"
+	"go-solid/outbox"
+	"go-solid/spec"
+)
+
+// Repository Decorator injecting faults before calls reach the wrapped
+// employee.Repository. Optional capabilities are forwarded, and get faults
+// too.
+type Repository struct {
+	next employee.Repository
+	inj  *Injector
+}
+
+func NewRepository(next employee.Repository, inj *Injector) *Repository {
+	return &Repository{next: next, inj: inj}
+}
+
+// Wrapped returns the repository the faults are injected in front of.
+func (r *Repository) Wrapped() any { return r.next }
+
+func (r *Repository) Save(ctx context.Context, emp employee.Employee) error {
+	if err := r.inj.Inject(ctx); err != nil {
+		return err
+	}
+	return r.next.Save(ctx, emp)
+}
+
+func (r *Repository) GetByName(ctx context.Context, name string) (employee.Employee, error) {
+	if err := r.inj.Inject(ctx); err != nil {
+		return employee.Employee{}, err
+	}
+	return r.next.GetByName(ctx, name)
+}
+
+func (r *Repository) GetByID(ctx context.Context, id employee.ID) (employee.Employee, error) {
+	if err := r.inj.Inject(ctx); err != nil {
+		return employee.Employee{}, err
+	}
+	return employee.GetByID(ctx, r.next, id)
+}
+
+// All injects once for the whole stream, like one query would.
+func (r *Repository) All(ctx context.Context) iter.Seq2[employee.Employee, error] {
+	return func(yield func(employee.Employee, error) bool) {
+		if err := r.inj.Inject(ctx); err != nil {
+			yield(employee.Employee{}, err)
+			return
+		}
+		for emp, err := range employee.All(ctx, r.next) {
+			if !yield(emp, err) {
+				return
+			}
+		}
+	}
+}
+
+// SaveAll injects once for the whole batch, like one round trip would.
+func (r *Repository) SaveAll(ctx context.Context, emps iter.Seq[employee.Employee]) error {
+	if err := r.inj.Inject(ctx); err != nil {
+		return err
+	}
+	return employee.SaveAll(ctx, r.next, emps)
+}
+
+func (r *Repository) Update(ctx context.Context, emp employee.Employee, expectedVersion int) (employee.Employee, error) {
+	u, err := capability[employee.Updater](ctx, r)
+	if err != nil {
+		return employee.Employee{}, err
+	}
+	return u.Update(ctx, emp, expectedVersion)
+}
+
+func (r *Repository) SoftDelete(ctx context.Context, name string) error {
+	d, err := capability[employee.SoftDeleter](ctx, r)
+	if err != nil {
+		return err
+	}
+	return d.SoftDelete(ctx, name)
+}
+
+func (r *Repository) Restore(ctx context.Context, name string) error {
+	d, err := capability[employee.SoftDeleter](ctx, r)
+	if err != nil {
+		return err
+	}
+	return d.Restore(ctx, name)
+}
+
+func (r *Repository) History(ctx context.Context, name string) ([]employee.Employee, error) {
+	v, err := capability[employee.Versioned](ctx, r)
+	if err != nil {
+		return nil, err
+	}
+	return v.History(ctx, name)
+}
+
+func (r *Repository) List(ctx context.Context, filter employee.Filter, page employee.Page) (employee.PageResult, error) {
+	q, err := capability[employee.QueryRepository](ctx, r)
+	if err != nil {
+		return employee.PageResult{}, err
+	}
+	return q.List(ctx, filter, page)
+}
+
+func (r *Repository) Matching(ctx context.Context, s spec.Specification[employee.Employee]) ([]employee.Employee, error) {
+	m, err := capability[employee.SpecificationRepository](ctx, r)
+	if err != nil {
+		return nil, err
+	}
+	return m.Matching(ctx, s)
+}
+
+func (r *Repository) SaveWithOutbox(ctx context.Context, emp employee.Employee, msgs []outbox.Message) error {
+	o, err := capability[employee.OutboxRepository](ctx, r)
+	if err != nil {
+		return err
+	}
+	return o.SaveWithOutbox(ctx, emp, msgs)
+}
+
+// capability returns the wrapped repository as C, or errors.ErrUnsupported
+// without injecting anything; otherwise the call gets its fault first.
+func capability[C any](ctx context.Context, r *Repository) (C, error) {
+	var zero C
+	c, ok := r.next.(C)
+	if !ok {
+		return zero, errors.ErrUnsupported
+	}
+	if err := r.inj.Inject(ctx); err != nil {
+		return zero, err
+	}
+	return c, nil
+}
+
+// Notifier Decorator injecting faults before messages reach the wrapped
+// notify.Notifier
+type Notifier struct {
+	next notify.Notifier
+	inj  *Injector
+}
+
+func NewNotifier(next notify.Notifier, inj *Injector) *Notifier {
+	return &Notifier{next: next, inj: inj}
+}
+
+func (n *Notifier) Wrapped() any { return n.next }
+
+func (n *Notifier) Notify(ctx context.Context, msg notify.Message) error {
+	if err := n.inj.Inject(ctx); err != nil {
+		return err
+	}
+	return n.next.Notify(ctx, msg)
+}
+
+var (
+	_ employee.Repository              = (*Repository)(nil)
+	_ employee.BulkSaver               = (*Repository)(nil)
+	_ employee.SoftDeleter             = (*Repository)(nil)
+	_ employee.Updater                 = (*Repository)(nil)
+	_ employee.Iterable                = (*Repository)(nil)
+	_ employee.IDRepository            = (*Repository)(nil)
+	_ employee.Versioned               = (*Repository)(nil)
+	_ employee.QueryRepository         = (*Repository)(nil)
+	_ employee.SpecificationRepository = (*Repository)(nil)
+	_ employee.OutboxRepository        = (*Repository)(nil)
+	_ notify.Notifier                  = (*Notifier)(nil)
+)
@@ -0,0 +1,20 @@
+package chaos_test
+
+import (
+	"testing"
+
+	"go-solid/chaos"
+	"go-solid/employee"
+	"go-solid/employee/employeetest"
+	"go-solid/employee/memory"
+)
+
+func TestRepository(t *testing.T) {
+	employeetest.TestRepository(t, func(t *testing.T) employee.Repository {
+		inj, err := chaos.New(chaos.Config{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return chaos.NewRepository(memory.New(), inj)
+	})
+}
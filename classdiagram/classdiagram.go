@@ -0,0 +1,186 @@
+// Package classdiagram draws the types of Go packages and how they relate.
+//
+// Build reads type-checked packages: every named type becomes a Class, with
+// its fields and methods, and an Edge joins a type to each interface it
+// implements and to each type it embeds. A Renderer writes the result: as an
+// SVG laid out here, or as Graphviz dot or Mermaid text for tools that lay
+// out better.
+package classdiagram
+
+import (
+	"go/types"
+	"slices"
+	"strings"
+)
+
+// Kind What sort of type a Class is
+type Kind int
+
+const (
+	Struct Kind = iota
+	Interface
+	Other // a named slice, func, basic type, ...
+)
+
+// Class A named type, with the members worth drawing
+type Class struct {
+	Name    string // qualified with its package when several are drawn
+	Kind    Kind
+	Fields  []string // "name type", embedded fields as just the type
+	Methods []string // "Name(params) results", interfaces' own and embedded
+}
+
+// Relation What an Edge says about two classes
+type Relation int
+
+const (
+	// Implements From is a concrete type satisfying the interface To, by
+	// value or through its pointer
+	Implements Relation = iota
+	// Embeds From is a struct or interface embedding To
+	Embeds
+)
+
+// Edge One relationship, drawn from the dependent type to the one it depends on
+type Edge struct {
+	From, To string
+	Relation Relation
+	Pointer  bool // Implements only through *From
+}
+
+// Diagram Classes in declaration-name order, and the edges between them
+type Diagram struct {
+	Title   string
+	Classes []Class
+	Edges   []Edge
+}
+
+// Build draws every named type declared in pkgs. An implementation edge is
+// left out when the interface is embedded in another one the type is already
+// drawn implementing, and interfaces without methods get none, since every
+// type would point at them.
+func Build(title string, pkgs ...*types.Package) Diagram {
+	qualify := len(pkgs) > 1
+	qualifier := func(p *types.Package) string {
+		if !qualify && p == pkgs[0] {
+			return ""
+		}
+		return p.Name()
+	}
+	name := func(obj types.Object) string {
+		if qualify {
+			return obj.Pkg().Name() + "." + obj.Name()
+		}
+		return obj.Name()
+	}
+
+	d := Diagram{Title: title}
+	var named []*types.Named
+	drawn := map[*types.TypeName]bool{}
+	for _, pkg := range pkgs {
+		scope := pkg.Scope()
+		for _, n := range scope.Names() {
+			tn, ok := scope.Lookup(n).(*types.TypeName)
+			if !ok || tn.IsAlias() {
+				continue
+			}
+			if t, ok := tn.Type().(*types.Named); ok {
+				named = append(named, t)
+				drawn[tn] = true
+			}
+		}
+	}
+	embedded := func(t types.Type) (*types.Named, bool) {
+		if p, ok := t.(*types.Pointer); ok {
+			t = p.Elem()
+		}
+		n, ok := t.(*types.Named)
+		return n, ok && drawn[n.Obj()]
+	}
+
+	for _, t := range named {
+		c := Class{Name: name(t.Obj())}
+		switch u := t.Underlying().(type) {
+		case *types.Struct:
+			c.Kind = Struct
+			for i := range u.NumFields() {
+				f := u.Field(i)
+				if f.Embedded() {
+					c.Fields = append(c.Fields, types.TypeString(f.Type(), qualifier))
+					if e, ok := embedded(f.Type()); ok {
+						d.Edges = append(d.Edges, Edge{From: c.Name, To: name(e.Obj()), Relation: Embeds})
+					}
+					continue
+				}
+				c.Fields = append(c.Fields, f.Name()+" "+types.TypeString(f.Type(), qualifier))
+			}
+		case *types.Interface:
+			c.Kind = Interface
+			for i := range u.NumMethods() {
+				c.Methods = append(c.Methods, signature(u.Method(i), qualifier))
+			}
+			for i := range u.NumEmbeddeds() {
+				if e, ok := embedded(u.EmbeddedType(i)); ok {
+					d.Edges = append(d.Edges, Edge{From: c.Name, To: name(e.Obj()), Relation: Embeds})
+				}
+			}
+		default:
+			c.Kind = Other
+		}
+		if c.Kind != Interface {
+			for i := range t.NumMethods() {
+				c.Methods = append(c.Methods, signature(t.Method(i), qualifier))
+			}
+		}
+		d.Classes = append(d.Classes, c)
+	}
+
+	for _, t := range named {
+		if _, ok := t.Underlying().(*types.Interface); ok || t.TypeParams().Len() > 0 {
+			continue
+		}
+		var impl []Edge
+		for _, i := range named {
+			iface, ok := i.Underlying().(*types.Interface)
+			if !ok || iface.NumMethods() == 0 || i.TypeParams().Len() > 0 {
+				continue
+			}
+			e := Edge{From: name(t.Obj()), To: name(i.Obj()), Relation: Implements}
+			switch {
+			case types.Implements(t, iface):
+			case types.Implements(types.NewPointer(t), iface):
+				e.Pointer = true
+			default:
+				continue
+			}
+			impl = append(impl, e)
+		}
+		for _, e := range impl {
+			implied := slices.ContainsFunc(impl, func(other Edge) bool {
+				return other.To != e.To && d.embeds(other.To, e.To)
+			})
+			if !implied {
+				d.Edges = append(d.Edges, e)
+			}
+		}
+	}
+	return d
+}
+
+// embeds reports whether class from embeds to, directly or through other
+// embedded types.
+func (d Diagram) embeds(from, to string) bool {
+	for _, e := range d.Edges {
+		if e.Relation == Embeds && e.From == from && (e.To == to || d.embeds(e.To, to)) {
+			return true
+		}
+	}
+	return false
+}
+
+// signature writes a method as Name(params) results.
+func signature(m *types.Func, q types.Qualifier) string {
+	sig := m.Type().(*types.Signature)
+	s := types.TypeString(sig, q) // func(params) results
+	return m.Name() + strings.TrimPrefix(s, "func")
+}
@@ -0,0 +1,108 @@
+package classdiagram
+
+import (
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// Renderer Strategy - writes a Diagram in some format
+type Renderer interface {
+	Render(w io.Writer, d Diagram) error
+}
+
+// Formats The renderers solid diagram can pick by name
+var Formats = map[string]Renderer{
+	"svg":     SVG{},
+	"dot":     Dot{},
+	"mermaid": Mermaid{},
+}
+
+// FormatNames returns the names of Formats, sorted.
+func FormatNames() []string { return slices.Sorted(maps.Keys(Formats)) }
+
+// Dot Graphviz input, one record node per class, interfaces on top:
+//
+//	solid diagram isp -format dot | dot -Tpng -o isp.png
+type Dot struct{}
+
+func (Dot) Render(w io.Writer, d Diagram) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "digraph %q {\n", d.Title)
+	b.WriteString("\trankdir=BT;\n\tnode [shape=record, fontname=\"monospace\", fontsize=10];\n")
+	for _, c := range d.Classes {
+		title := recordEscape(c.Name)
+		if c.Kind == Interface {
+			title = "«interface»\\n" + title
+		}
+		fmt.Fprintf(&b, "\t%q [label=\"{%s|%s|%s}\"];\n", c.Name, title, recordLines(c.Fields), recordLines(c.Methods))
+	}
+	for _, e := range d.Edges {
+		switch e.Relation {
+		case Implements:
+			label := ""
+			if e.Pointer {
+				label = ", label=\"*\""
+			}
+			fmt.Fprintf(&b, "\t%q -> %q [style=dashed, arrowhead=empty%s];\n", e.From, e.To, label)
+		case Embeds:
+			fmt.Fprintf(&b, "\t%q -> %q [dir=back, arrowtail=diamond];\n", e.From, e.To)
+		}
+	}
+	b.WriteString("}\n")
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// recordLines left-aligns lines inside a record field.
+func recordLines(lines []string) string {
+	var b strings.Builder
+	for _, l := range lines {
+		b.WriteString(recordEscape(l) + `\l`)
+	}
+	return b.String()
+}
+
+var recordEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "{", `\{`, "}", `\}`, "|", `\|`, "<", `\<`, ">", `\>`)
+
+func recordEscape(s string) string { return recordEscaper.Replace(s) }
+
+// Mermaid A classDiagram block, which GitHub renders inside a mermaid fence
+type Mermaid struct{}
+
+func (Mermaid) Render(w io.Writer, d Diagram) error {
+	var b strings.Builder
+	b.WriteString("classDiagram\n")
+	for _, c := range d.Classes {
+		fmt.Fprintf(&b, "\tclass %s {\n", mermaidName(c.Name))
+		if c.Kind == Interface {
+			b.WriteString("\t\t<<interface>>\n")
+		}
+		for _, f := range c.Fields {
+			fmt.Fprintf(&b, "\t\t%s\n", mermaidMember(f))
+		}
+		for _, m := range c.Methods {
+			fmt.Fprintf(&b, "\t\t%s\n", mermaidMember(m))
+		}
+		b.WriteString("\t}\n")
+	}
+	for _, e := range d.Edges {
+		switch e.Relation {
+		case Implements:
+			fmt.Fprintf(&b, "\t%s <|.. %s\n", mermaidName(e.To), mermaidName(e.From))
+		case Embeds:
+			fmt.Fprintf(&b, "\t%s *-- %s\n", mermaidName(e.From), mermaidName(e.To))
+		}
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// mermaidName makes a qualified name a valid Mermaid identifier.
+func mermaidName(s string) string { return strings.ReplaceAll(s, ".", "_") }
+
+// mermaidMember keeps Mermaid from reading the braces of struct{} or
+// interface{ ... } as the end of the class.
+var mermaidMember = strings.NewReplacer("{", "#123;", "}", "#125;").Replace
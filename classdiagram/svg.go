@@ -0,0 +1,231 @@
+package classdiagram
+
+import (
+	"fmt"
+	"html"
+	"io"
+	"slices"
+	"strings"
+	"unicode/utf8"
+)
+
+// SVG A standalone picture, laid out in layers: interfaces and embedded types
+// on top, the types depending on them below. Within a layer, a box sits under
+// the boxes it points at as far as the others allow. It is no match for
+// Graphviz on a large package, and isn't meant to be - a lesson has a handful
+// of types.
+type SVG struct{}
+
+const (
+	charWidth  = 7.2 // of 12px monospace
+	lineHeight = 16
+	padding    = 8
+	layerGap   = 70
+	boxGap     = 30
+)
+
+// box A class placed on the picture
+type box struct {
+	class      Class
+	layer      int
+	x, y, w, h float64
+	in, out    []int // edge indexes arriving at the bottom, leaving at the top
+}
+
+func (b *box) lines() (header []string, sections [][]string) {
+	if b.class.Kind == Interface {
+		header = append(header, "«interface»")
+	}
+	header = append(header, b.class.Name)
+	return header, [][]string{b.class.Fields, b.class.Methods}
+}
+
+// layout places every class and returns the picture's size.
+func layout(d Diagram) ([]*box, float64, float64) {
+	index := map[string]int{}
+	boxes := make([]*box, len(d.Classes))
+	for i, c := range d.Classes {
+		index[c.Name] = i
+		boxes[i] = &box{class: c, layer: -1}
+	}
+	for i, e := range d.Edges {
+		boxes[index[e.From]].out = append(boxes[index[e.From]].out, i)
+		boxes[index[e.To]].in = append(boxes[index[e.To]].in, i)
+	}
+
+	// A box's layer is one below the lowest box it points at; boxes pointing
+	// nowhere are on top. Interfaces and embedding never form a cycle, but a
+	// depth limit keeps a malformed diagram from recursing forever.
+	var layerOf func(i, depth int) int
+	layerOf = func(i, depth int) int {
+		b := boxes[i]
+		if b.layer >= 0 || depth > len(boxes) {
+			return max(b.layer, 0)
+		}
+		b.layer = 0
+		for _, e := range b.out {
+			b.layer = max(b.layer, layerOf(index[d.Edges[e].To], depth+1)+1)
+		}
+		return b.layer
+	}
+	var layers [][]*box
+	for i, b := range boxes {
+		l := layerOf(i, 0)
+		for len(layers) <= l {
+			layers = append(layers, nil)
+		}
+		layers[l] = append(layers[l], b)
+	}
+
+	// Order each layer under what it points at: the mean position of its
+	// targets above, boxes without edges last.
+	position := map[string]float64{}
+	for l, row := range layers {
+		if l > 0 {
+			mean := func(b *box) float64 {
+				if len(b.out) == 0 {
+					return float64(len(boxes))
+				}
+				sum := 0.0
+				for _, e := range b.out {
+					sum += position[d.Edges[e].To]
+				}
+				return sum / float64(len(b.out))
+			}
+			slices.SortStableFunc(row, func(a, b *box) int {
+				ma, mb := mean(a), mean(b)
+				switch {
+				case ma < mb:
+					return -1
+				case ma > mb:
+					return 1
+				}
+				return 0
+			})
+		} else {
+			slices.SortStableFunc(row, func(a, b *box) int { return len(b.in) - len(a.in) })
+		}
+		for i, b := range row {
+			position[b.class.Name] = float64(i)
+		}
+	}
+
+	// Size the boxes, then the layers, centring each on the widest one.
+	width, y := 0.0, float64(boxGap)
+	rowWidth := make([]float64, len(layers))
+	for l, row := range layers {
+		height := 0.0
+		for _, b := range row {
+			header, sections := b.lines()
+			longest, count := 0, len(header)
+			for _, s := range append([][]string{header}, sections...) {
+				for _, line := range s {
+					longest = max(longest, utf8.RuneCountInString(line))
+				}
+			}
+			for _, s := range sections {
+				count += max(len(s), 1)
+			}
+			b.w = float64(longest)*charWidth + 2*padding
+			b.h = float64(count)*lineHeight + 2*padding*float64(len(sections)+1)
+			b.y = y
+			height = max(height, b.h)
+			rowWidth[l] += b.w + boxGap
+		}
+		width = max(width, rowWidth[l]+boxGap)
+		y += height + layerGap
+	}
+	for l, row := range layers {
+		x := (width-rowWidth[l])/2 + boxGap/2
+		for _, b := range row {
+			b.x = x
+			x += b.w + boxGap
+		}
+	}
+	return boxes, width, y - layerGap + boxGap
+}
+
+// anchor returns where edge e meets b: spread along its top for edges
+// leaving, along its bottom for edges arriving, in the order of the boxes at
+// their other ends so that they don't cross there.
+func anchor(b *box, e int, leaving bool, ends func(e int) *box) (float64, float64) {
+	edges, y := b.in, b.y+b.h
+	if leaving {
+		edges, y = b.out, b.y
+	}
+	order := slices.Clone(edges)
+	slices.SortStableFunc(order, func(i, j int) int {
+		xi, xj := ends(i).x, ends(j).x
+		switch {
+		case xi < xj:
+			return -1
+		case xi > xj:
+			return 1
+		}
+		return 0
+	})
+	k := slices.Index(order, e)
+	return b.x + b.w*float64(k+1)/float64(len(order)+1), y
+}
+
+func (SVG) Render(w io.Writer, d Diagram) error {
+	boxes, width, height := layout(d)
+	byName := map[string]*box{}
+	for _, b := range boxes {
+		byName[b.class.Name] = b
+	}
+
+	var s strings.Builder
+	fmt.Fprintf(&s, `<svg xmlns="http://www.w3.org/2000/svg" width="%.0f" height="%.0f" viewBox="0 0 %.0f %.0f" font-family="monospace" font-size="12">`+"\n", width, height, width, height)
+	fmt.Fprintf(&s, "<title>%s</title>\n", html.EscapeString(d.Title))
+	s.WriteString(`<defs>
+<marker id="realizes" viewBox="0 0 12 12" refX="12" refY="6" markerWidth="12" markerHeight="12" orient="auto"><path d="M0,0 L12,6 L0,12 z" fill="white" stroke="black"/></marker>
+<marker id="embeds" viewBox="0 0 16 10" refX="0" refY="5" markerWidth="16" markerHeight="10" orient="auto"><path d="M0,5 L8,0 L16,5 L8,10 z" fill="black"/></marker>
+</defs>
+<rect width="100%" height="100%" fill="white"/>
+`)
+
+	for i, e := range d.Edges {
+		from, to := byName[e.From], byName[e.To]
+		x1, y1 := anchor(from, i, true, func(e int) *box { return byName[d.Edges[e].To] })
+		x2, y2 := anchor(to, i, false, func(e int) *box { return byName[d.Edges[e].From] })
+		switch e.Relation {
+		case Implements:
+			fmt.Fprintf(&s, `<line x1="%.1f" y1="%.1f" x2="%.1f" y2="%.1f" stroke="black" stroke-dasharray="6,4" marker-end="url(#realizes)"/>`+"\n", x1, y1, x2, y2)
+			if e.Pointer {
+				fmt.Fprintf(&s, `<text x="%.1f" y="%.1f">*</text>`+"\n", x1+4, y1-4)
+			}
+		case Embeds:
+			fmt.Fprintf(&s, `<line x1="%.1f" y1="%.1f" x2="%.1f" y2="%.1f" stroke="black" marker-start="url(#embeds)"/>`+"\n", x1, y1, x2, y2)
+		}
+	}
+
+	for _, b := range boxes {
+		fill := "#fffbe6"
+		if b.class.Kind == Interface {
+			fill = "#e8f1ff"
+		}
+		fmt.Fprintf(&s, `<rect x="%.1f" y="%.1f" width="%.1f" height="%.1f" fill="%s" stroke="black"/>`+"\n", b.x, b.y, b.w, b.h, fill)
+		header, sections := b.lines()
+		y := b.y + padding
+		for _, line := range header {
+			y += lineHeight
+			fmt.Fprintf(&s, `<text x="%.1f" y="%.1f" text-anchor="middle" font-weight="bold">%s</text>`+"\n", b.x+b.w/2, y-4, html.EscapeString(line))
+		}
+		for _, section := range sections {
+			y += padding
+			fmt.Fprintf(&s, `<line x1="%.1f" y1="%.1f" x2="%.1f" y2="%.1f" stroke="black"/>`+"\n", b.x, y, b.x+b.w, y)
+			y += padding
+			for _, line := range section {
+				y += lineHeight
+				fmt.Fprintf(&s, `<text x="%.1f" y="%.1f">%s</text>`+"\n", b.x+padding, y-4, html.EscapeString(line))
+			}
+			if len(section) == 0 {
+				y += lineHeight
+			}
+		}
+	}
+	s.WriteString("</svg>\n")
+	_, err := io.WriteString(w, s.String())
+	return err
+}
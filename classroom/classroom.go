@@ -0,0 +1,162 @@
+// Package classroom collects exercise results from a cohort of learners and
+// ranks them.
+//
+// The server only stores results and the ranking is computed from them, so
+// how results are graded (the learner's own CLI, a sandboxed grader) and
+// where they are kept (memory, SQL) vary independently of the leaderboard.
+package classroom
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// ErrInvalidResult returned for results the leaderboard can't rank
+var ErrInvalidResult = errors.New("invalid result")
+
+// Result One graded attempt at an exercise. Score is between 0 and 1.
+type Result struct {
+	Cohort      string    `json:"cohort"`
+	Student     string    `json:"student"`
+	Exercise    string    `json:"exercise"`
+	Score       float64   `json:"score"`
+	Passed      bool      `json:"passed"`
+	SubmittedAt time.Time `json:"submitted_at"`
+}
+
+func (r Result) Validate() error {
+	switch {
+	case strings.TrimSpace(r.Cohort) == "":
+		return fmt.Errorf("%w: missing cohort", ErrInvalidResult)
+	case strings.TrimSpace(r.Student) == "":
+		return fmt.Errorf("%w: missing student", ErrInvalidResult)
+	case strings.TrimSpace(r.Exercise) == "":
+		return fmt.Errorf("%w: missing exercise", ErrInvalidResult)
+	case r.Score < 0 || r.Score > 1:
+		return fmt.Errorf("%w: score %g is not between 0 and 1", ErrInvalidResult, r.Score)
+	}
+	return nil
+}
+
+// Store Abstraction - where a cohort's results are kept. Every attempt is
+// kept; ranking decides which ones count.
+type Store interface {
+	Submit(ctx context.Context, r Result) error
+	Results(ctx context.Context, cohort string) ([]Result, error)
+}
+
+// Standing A student's place on the leaderboard
+type Standing struct {
+	Rank     int       `json:"rank"`
+	Student  string    `json:"student"`
+	Points   float64   `json:"points"` // best score per exercise, summed
+	Passed   int       `json:"passed"` // exercises passed at least once
+	Attempts int       `json:"attempts"`
+	Last     time.Time `json:"last_submission"`
+}
+
+// Leaderboard ranks students by points. Retrying an exercise never costs
+// points, but on equal points fewer attempts rank higher, then the earlier
+// finisher.
+func Leaderboard(results []Result) []Standing {
+	type key struct{ student, exercise string }
+	best := map[key]Result{}
+	byStudent := map[string]*Standing{}
+	for _, r := range results {
+		s := byStudent[r.Student]
+		if s == nil {
+			s = &Standing{Student: r.Student}
+			byStudent[r.Student] = s
+		}
+		s.Attempts++
+		if r.SubmittedAt.After(s.Last) {
+			s.Last = r.SubmittedAt
+		}
+		k := key{r.Student, r.Exercise}
+		b, seen := best[k]
+		if !seen || r.Score > b.Score {
+			b.Score = r.Score
+		}
+		b.Passed = b.Passed || r.Passed
+		best[k] = b
+	}
+	for k, b := range best {
+		s := byStudent[k.student]
+		s.Points += b.Score
+		if b.Passed {
+			s.Passed++
+		}
+	}
+
+	board := make([]Standing, 0, len(byStudent))
+	for _, s := range byStudent {
+		board = append(board, *s)
+	}
+	slices.SortFunc(board, func(a, b Standing) int {
+		return cmp.Or(
+			cmp.Compare(b.Points, a.Points),
+			cmp.Compare(a.Attempts, b.Attempts),
+			a.Last.Compare(b.Last),
+			strings.Compare(a.Student, b.Student),
+		)
+	})
+	for i := range board {
+		board[i].Rank = i + 1
+		if i > 0 && board[i].Points == board[i-1].Points && board[i].Attempts == board[i-1].Attempts {
+			board[i].Rank = board[i-1].Rank
+		}
+	}
+	return board
+}
+
+// ExerciseStats How the cohort is doing on one exercise
+type ExerciseStats struct {
+	Exercise  string  `json:"exercise"`
+	Attempts  int     `json:"attempts"`
+	Students  int     `json:"students"`
+	Passed    int     `json:"passed"`     // students who passed
+	MeanScore float64 `json:"mean_score"` // of each student's best
+}
+
+// Stats summarizes results per exercise, in exercise order.
+func Stats(results []Result) []ExerciseStats {
+	type acc struct {
+		attempts int
+		best     map[string]Result
+	}
+	byExercise := map[string]*acc{}
+	for _, r := range results {
+		a := byExercise[r.Exercise]
+		if a == nil {
+			a = &acc{best: map[string]Result{}}
+			byExercise[r.Exercise] = a
+		}
+		a.attempts++
+		b, seen := a.best[r.Student]
+		if !seen || r.Score > b.Score {
+			b.Score = r.Score
+		}
+		b.Passed = b.Passed || r.Passed
+		a.best[r.Student] = b
+	}
+
+	stats := make([]ExerciseStats, 0, len(byExercise))
+	for name, a := range byExercise {
+		s := ExerciseStats{Exercise: name, Attempts: a.attempts, Students: len(a.best)}
+		for _, b := range a.best {
+			s.MeanScore += b.Score
+			if b.Passed {
+				s.Passed++
+			}
+		}
+		s.MeanScore /= float64(s.Students)
+		stats = append(stats, s)
+	}
+	slices.SortFunc(stats, func(a, b ExerciseStats) int { return strings.Compare(a.Exercise, b.Exercise) })
+	return stats
+}
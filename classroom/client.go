@@ -0,0 +1,80 @@
+package classroom
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// Client What a learner's CLI uses to talk to a Server
+type Client struct {
+	URL   string // e.g. http://teacher.local:8090
+	Token string
+	HTTP  *http.Client // http.DefaultClient when nil
+}
+
+// Submit sends results for cohort; the server stamps cohort and time.
+func (c *Client) Submit(ctx context.Context, cohort string, results []Result) error {
+	body, err := json.Marshal(results)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.endpoint(cohort, "results"), bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if c.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.Token)
+	}
+	return c.do(req, nil)
+}
+
+// Leaderboard fetches cohort's standings.
+func (c *Client) Leaderboard(ctx context.Context, cohort string) ([]Standing, error) {
+	var board []Standing
+	return board, c.get(ctx, c.endpoint(cohort, "leaderboard"), &board)
+}
+
+// Stats fetches cohort's per-exercise statistics.
+func (c *Client) Stats(ctx context.Context, cohort string) ([]ExerciseStats, error) {
+	var stats []ExerciseStats
+	return stats, c.get(ctx, c.endpoint(cohort, "exercises"), &stats)
+}
+
+func (c *Client) endpoint(cohort, what string) string {
+	return strings.TrimSuffix(c.URL, "/") + "/cohorts/" + url.PathEscape(cohort) + "/" + what
+}
+
+func (c *Client) get(ctx context.Context, endpoint string, dst any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return err
+	}
+	return c.do(req, dst)
+}
+
+func (c *Client) do(req *http.Request, dst any) error {
+	hc := c.HTTP
+	if hc == nil {
+		hc = http.DefaultClient
+	}
+	resp, err := hc.Do(req)
+	if err != nil {
+		return fmt.Errorf("classroom: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		var e errorBody
+		_ = json.NewDecoder(resp.Body).Decode(&e)
+		return fmt.Errorf("classroom: %s: %s", resp.Status, e.Error)
+	}
+	if dst == nil {
+		return nil
+	}
+	return json.NewDecoder(resp.Body).Decode(dst)
+}
@@ -0,0 +1,35 @@
+// Package memory is an in-process classroom.Store.
+package memory
+
+import (
+	"context"
+	"slices"
+	"sync"
+
+	"go-solid/classroom"
+)
+
+// Store Low-level module - results kept per cohort, in submission order
+type Store struct {
+	mu       sync.RWMutex
+	byCohort map[string][]classroom.Result
+}
+
+func New() *Store {
+	return &Store{byCohort: make(map[string][]classroom.Result)}
+}
+
+func (s *Store) Submit(ctx context.Context, r classroom.Result) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.byCohort[r.Cohort] = append(s.byCohort[r.Cohort], r)
+	return nil
+}
+
+func (s *Store) Results(ctx context.Context, cohort string) ([]classroom.Result, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return slices.Clone(s.byCohort[cohort]), nil
+}
+
+var _ classroom.Store = (*Store)(nil)
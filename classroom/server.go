@@ -0,0 +1,112 @@
+package classroom
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"go-solid/clock"
+)
+
+// Server HTTP front of a Store:
+//
+//	POST /cohorts/{cohort}/results     [{"student", "exercise", "score", "passed"}, ...]
+//	GET  /cohorts/{cohort}/leaderboard
+//	GET  /cohorts/{cohort}/exercises
+type Server struct {
+	store Store
+	clock clock.Clock
+	token string
+	mux   *http.ServeMux
+}
+
+// Option customises a Server created by NewServer
+type Option func(*Server)
+
+// WithToken requires submissions to carry "Authorization: Bearer <token>",
+// a secret the instructor hands to the cohort. Reading stays open.
+func WithToken(token string) Option { return func(s *Server) { s.token = token } }
+
+// WithClock stamps submissions with c instead of the real time.
+func WithClock(c clock.Clock) Option { return func(s *Server) { s.clock = c } }
+
+func NewServer(store Store, opts ...Option) *Server {
+	s := &Server{store: store, clock: clock.Real{}, mux: http.NewServeMux()}
+	for _, opt := range opts {
+		opt(s)
+	}
+	s.mux.HandleFunc("POST /cohorts/{cohort}/results", s.submit)
+	s.mux.HandleFunc("GET /cohorts/{cohort}/leaderboard", s.leaderboard)
+	s.mux.HandleFunc("GET /cohorts/{cohort}/exercises", s.exercises)
+	return s
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) { s.mux.ServeHTTP(w, r) }
+
+func (s *Server) submit(w http.ResponseWriter, r *http.Request) {
+	if s.token != "" && subtle.ConstantTimeCompare([]byte(r.Header.Get("Authorization")), []byte("Bearer "+s.token)) != 1 {
+		writeJSON(w, http.StatusUnauthorized, errorBody{"missing or wrong classroom token"})
+		return
+	}
+	var results []Result
+	dec := json.NewDecoder(http.MaxBytesReader(w, r.Body, 1<<20))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&results); err != nil {
+		writeJSON(w, http.StatusBadRequest, errorBody{"invalid JSON body: " + err.Error()})
+		return
+	}
+	// the server, not the learner's machine, decides cohort and time
+	now := s.clock.Now()
+	for i := range results {
+		results[i].Cohort = r.PathValue("cohort")
+		results[i].SubmittedAt = now
+		if err := results[i].Validate(); err != nil {
+			writeError(w, err)
+			return
+		}
+	}
+	for _, res := range results {
+		if err := s.store.Submit(r.Context(), res); err != nil {
+			writeError(w, err)
+			return
+		}
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) leaderboard(w http.ResponseWriter, r *http.Request) {
+	results, err := s.store.Results(r.Context(), r.PathValue("cohort"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, Leaderboard(results))
+}
+
+func (s *Server) exercises(w http.ResponseWriter, r *http.Request) {
+	results, err := s.store.Results(r.Context(), r.PathValue("cohort"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, Stats(results))
+}
+
+type errorBody struct {
+	Error string `json:"error"`
+}
+
+func writeError(w http.ResponseWriter, err error) {
+	status := http.StatusInternalServerError
+	if errors.Is(err, ErrInvalidResult) {
+		status = http.StatusBadRequest
+	}
+	writeJSON(w, status, errorBody{err.Error()})
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
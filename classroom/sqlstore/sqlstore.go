@@ -0,0 +1,68 @@
+// Package sqlstore is a classroom.Store on top of database/sql.
+package sqlstore
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"go-solid/classroom"
+	"go-solid/sqldialect"
+)
+
+// Schema Table layout expected by the store. It is safe to run on every
+// start, which is what `solid serve classroom` does.
+const Schema = `CREATE TABLE IF NOT EXISTS classroom_results (
+    cohort       VARCHAR(64)  NOT NULL,
+    student      VARCHAR(255) NOT NULL,
+    exercise     VARCHAR(255) NOT NULL,
+    score        REAL         NOT NULL,
+    passed       BOOLEAN      NOT NULL,
+    submitted_at TIMESTAMP    NOT NULL
+)`
+
+// Store Low-level module - one row per submitted result
+type Store struct {
+	db      *sql.DB
+	dialect sqldialect.Dialect
+}
+
+func New(db *sql.DB, dialect sqldialect.Dialect) *Store {
+	return &Store{db: db, dialect: dialect}
+}
+
+func (s *Store) Submit(ctx context.Context, r classroom.Result) error {
+	_, err := s.db.ExecContext(ctx, s.dialect.Rebind(`INSERT INTO classroom_results
+		(cohort, student, exercise, score, passed, submitted_at) VALUES (?, ?, ?, ?, ?, ?)`),
+		r.Cohort, r.Student, r.Exercise, r.Score, r.Passed, r.SubmittedAt.UTC())
+	if err != nil {
+		return fmt.Errorf("sqlstore: submit %s/%s: %w", r.Student, r.Exercise, err)
+	}
+	return nil
+}
+
+func (s *Store) Results(ctx context.Context, cohort string) ([]classroom.Result, error) {
+	rows, err := s.db.QueryContext(ctx, s.dialect.Rebind(`SELECT student, exercise, score, passed, submitted_at
+		FROM classroom_results WHERE cohort = ? ORDER BY submitted_at`), cohort)
+	if err != nil {
+		return nil, fmt.Errorf("sqlstore: results %q: %w", cohort, err)
+	}
+	defer rows.Close()
+
+	var results []classroom.Result
+	for rows.Next() {
+		r := classroom.Result{Cohort: cohort}
+		if err := rows.Scan(&r.Student, &r.Exercise, &r.Score, &r.Passed, &r.SubmittedAt); err != nil {
+			return nil, fmt.Errorf("sqlstore: results %q: %w", cohort, err)
+		}
+		results = append(results, r)
+	}
+	return results, rows.Err()
+}
+
+// CheckHealth pings the database (health.Checker).
+func (s *Store) CheckHealth(ctx context.Context) error {
+	return s.db.PingContext(ctx)
+}
+
+var _ classroom.Store = (*Store)(nil)
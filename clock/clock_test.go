@@ -0,0 +1,88 @@
+package clock_test
+
+import (
+	"testing"
+	"time"
+
+	"go-solid/clock"
+)
+
+var start = time.Date(2026, time.March, 2, 9, 0, 0, 0, time.UTC)
+
+// fired reports whether ch has fired, and at what time.
+func fired(ch <-chan time.Time) (time.Time, bool) {
+	select {
+	case at := <-ch:
+		return at, true
+	default:
+		return time.Time{}, false
+	}
+}
+
+func TestFake_Advance(t *testing.T) {
+	clk := clock.NewFake(start)
+	if got := clk.Now(); !got.Equal(start) {
+		t.Fatalf("Now() = %s, want %s", got, start)
+	}
+	clk.Advance(90 * time.Second)
+	if got, want := clk.Now(), start.Add(90*time.Second); !got.Equal(want) {
+		t.Errorf("Now() after Advance(90s) = %s, want %s", got, want)
+	}
+}
+
+func TestFake_After(t *testing.T) {
+	clk := clock.NewFake(start)
+	minute, hour := clk.After(time.Minute), clk.After(time.Hour)
+
+	clk.Advance(59 * time.Second)
+	if _, ok := fired(minute); ok {
+		t.Fatal("After(1m) fired 59s in")
+	}
+	clk.Advance(time.Second)
+	if at, ok := fired(minute); !ok || !at.Equal(start.Add(time.Minute)) {
+		t.Errorf("After(1m) at 1m = %s, %t, want fired at %s", at, ok, start.Add(time.Minute))
+	}
+	if _, ok := fired(hour); ok {
+		t.Error("After(1h) fired 1m in")
+	}
+
+	// a jump past the deadline fires it with the time jumped to
+	clk.Advance(2 * time.Hour)
+	if at, ok := fired(hour); !ok || !at.Equal(start.Add(2*time.Hour+time.Minute)) {
+		t.Errorf("After(1h) after a 2h jump = %s, %t, want fired at %s", at, ok, start.Add(2*time.Hour+time.Minute))
+	}
+	clk.Advance(time.Hour)
+	if _, ok := fired(minute); ok {
+		t.Error("After(1m) fired twice, want once")
+	}
+}
+
+func TestFake_AfterNothing(t *testing.T) {
+	clk := clock.NewFake(start)
+	for _, d := range []time.Duration{0, -time.Second} {
+		if at, ok := fired(clk.After(d)); !ok || !at.Equal(start) {
+			t.Errorf("After(%s) = %s, %t, want fired at once at %s", d, at, ok, start)
+		}
+	}
+}
+
+func TestFake_BlockUntil(t *testing.T) {
+	clk := clock.NewFake(start)
+	woke := make(chan time.Time)
+	for range 2 {
+		go func() { woke <- <-clk.After(time.Second) }()
+	}
+	// returns only once both goroutines wait, so the Advance reaches them
+	clk.BlockUntil(2)
+	clk.Advance(time.Second)
+	for range 2 {
+		select {
+		case at := <-woke:
+			if !at.Equal(start.Add(time.Second)) {
+				t.Errorf("woke at %s, want %s", at, start.Add(time.Second))
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatal("a waiter didn't wake up after Advance")
+		}
+	}
+}
@@ -0,0 +1,217 @@
+// Command employee-api is the reference application: the employee use cases
+// served over HTTP, with every collaborator wired in one place.
+//
+// The storage backend is read from the config file and can be changed while
+// the server runs - edit the file and the new backend takes over after the
+// in-flight requests on the old one have drained.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"io/fs"
+	"log/slog"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"go-solid/admin"
+	"go-solid/chaos"
+	"go-solid/clock"
+	"go-solid/config"
+	"go-solid/employee"
+	"go-solid/events"
+	"go-solid/graphqlapi"
+	"go-solid/health"
+	"go-solid/httpapi"
+	"go-solid/idempotency"
+	"go-solid/idempotency/redis"
+	"go-solid/lifecycle"
+	"go-solid/live"
+	"go-solid/money"
+	"go-solid/payroll"
+	"go-solid/payrollapi"
+	"go-solid/storage"
+	"go-solid/storage/hotswap"
+)
+
+func main() {
+	configPath := flag.String("config", "config.json", "path to the JSON config file")
+	flag.Parse()
+
+	// the level can be changed at runtime through the admin endpoint
+	level := new(slog.LevelVar)
+	logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: level}))
+	if err := run(*configPath, logger, level); err != nil {
+		logger.Error("employee-api stopped", "err", err)
+		os.Exit(1)
+	}
+}
+
+func run(configPath string, logger *slog.Logger, level *slog.LevelVar) error {
+	cfg, err := config.Load(configPath)
+	if errors.Is(err, fs.ErrNotExist) {
+		logger.Warn("config file not found, using defaults", "path", configPath)
+		cfg, err = config.Default, nil
+	}
+	if err != nil {
+		return err
+	}
+
+	initial, err := storage.Open(cfg.Storage)
+	if err != nil {
+		return err
+	}
+	// ✅ Everything below depends on the RepositoryFactory abstraction; hotswap decorates it
+	repos := hotswap.New(cfg.Storage.Backend, initial)
+
+	// ✅ Faults are injected by a decorator; with chaos disabled it only forwards
+	injector, err := chaos.New(cfg.Chaos)
+	if err != nil {
+		return err
+	}
+	employees := chaos.NewRepository(repos.Employees(), injector)
+	bus := events.NewBus()
+	// ✅ Browsers watching /live/ get the events as they are raised; the feed is one more handler
+	feed := live.NewSubscriber(live.WithOrigins(cfg.Live.Origins...))
+	bus.SubscribeAll(feed)
+	manager := employee.NewManager(employees,
+		employee.WithAudit(repos.Audit()),
+		employee.WithEvents(bus),
+		employee.WithLogger(logger),
+	)
+
+	// ✅ Only dependencies that implement health.Checker are probed; the memory
+	// backend doesn't, so hotswap reports it healthy without a check of its own.
+	checks := &health.Aggregator{Timeout: 2 * time.Second}
+	checks.AddIfSupported("storage", repos)
+
+	// ✅ Retried POSTs with the same Idempotency-Key create one employee, not two
+	idem := idempotency.Middleware{Store: idempotency.NewMemory(clock.Real{})}
+	if addr := cfg.Idempotency.Redis; addr != "" {
+		store := &redis.Store{Addr: addr}
+		idem.Store = store
+		checks.Add("idempotency", store)
+	}
+	api := httpapi.New(manager, httpapi.WithMiddleware("POST /employees", idem.Wrap))
+	// ✅ v2 beside v1, over the same manager: v1 clients are not broken by what v2 changed
+	api.Handle("/v2/", httpapi.NewV2(manager, httpapi.WithMiddleware("POST /v2/employees", idem.Wrap)))
+	api.Handle("GET /healthz", health.Liveness())
+	api.Handle("GET /readyz", checks.Readiness())
+	// ✅ A second delivery adapter over the same manager; the REST routes are unaffected
+	api.Handle("/graphql", graphqlapi.New(manager))
+	api.Handle("GET /live/", http.StripPrefix("/live", live.Page()))
+	api.Handle("GET /live/events", feed)
+	// ✅ Runs report progress to their event stream; paid employees also reach the live feed
+	payrunner := payroll.NewPublisher(payroll.NewConcurrent(payroll.New(pipelines), 0), bus, clock.Real{})
+	roster := payroll.Staff{
+		Repo:      employees,
+		CountryOf: payroll.ByCurrency(map[money.Currency]string{money.USD: "US", money.EUR: "DE", money.EGP: "EG"}),
+	}
+	payrolls := payrollapi.New(payrunner, roster)
+	api.Handle("/payroll/", payrolls)
+
+	reloader := &reloader{repos: repos, chaos: injector, active: cfg, logger: logger}
+	watcher := &config.Watcher{
+		Path:     configPath,
+		Interval: 2 * time.Second,
+		Clock:    clock.Real{},
+		OnChange: reloader.apply,
+		OnError:  func(err error) { logger.Warn("config reload failed", "err", err) },
+	}
+
+	// Started in this order, stopped in reverse: HTTP stops accepting requests
+	// before the storage it depends on is closed.
+	app := &lifecycle.Group{Logger: logger}
+	app.Add("storage", lifecycle.Closer(repos))
+	app.Add("config-watcher", lifecycle.RunFunc(watcher.Run))
+	app.Add("http", lifecycle.HTTPServer{Server: &http.Server{Addr: cfg.Addr, Handler: api}})
+	app.Add("live-feed", feed) // stopped first: the server's shutdown waits for no websocket
+	app.Add("payroll-runs", payrolls)
+	if cfg.Admin.Addr != "" {
+		// ✅ What main wired above, recorded so it can be seen - and partly changed - at runtime
+		wiring := &admin.Wiring{}
+		wiring.Bind("storage.RepositoryFactory", repos)
+		wiring.Bind("httpapi.EmployeeService", manager)
+		wiring.Bind("httpapi.EmployeeServiceV2", manager)
+		wiring.Bind("graphqlapi.EmployeeService", manager)
+		wiring.Bind("employee.Repository", employees)
+		wiring.Bind("audit.Sink", repos.Audit())
+		wiring.Bind("events.Dispatcher", bus)
+		wiring.Bind("payroll.Runner", payrunner)
+		wiring.Bind("payroll.Roster", roster)
+		wiring.Bind("idempotency.Store", idem.Store)
+		wiring.Bind("slog.Handler", logger.Handler())
+		wiring.Bind("clock.Clock", clock.Real{})
+		wiring.Expose(admin.LogLevel(level))
+		wiring.Expose(admin.Toggle("chaos", "inject the faults configured at /admin/chaos", func() bool { return injector.Config().Enabled }, injector.Enable))
+
+		mux := http.NewServeMux()
+		mux.Handle("/admin/", admin.Handler(wiring))
+		mux.Handle("/admin/chaos", injector)
+		app.Add("admin", lifecycle.HTTPServer{Server: &http.Server{Addr: cfg.Admin.Addr, Handler: mux}})
+	}
+
+	logger.Info("starting", "addr", cfg.Addr, "backend", repos.Active())
+	return app.Run(context.Background())
+}
+
+// pipelines The same simple pipeline per country as solid repl's payroll
+var pipelines = payroll.Config{
+	"US": {
+		payroll.Pension{Rate: "0.05", Cap: money.Of(400, money.USD)},
+		payroll.IncomeTax{Brackets: []payroll.Bracket{{UpTo: money.Of(1000, money.USD), Rate: "0"}, {Rate: "0.22"}}},
+	},
+	"DE": {
+		payroll.Pension{Rate: "0.093"},
+		payroll.IncomeTax{Brackets: []payroll.Bracket{{UpTo: money.Of(1000, money.EUR), Rate: "0"}, {Rate: "0.30"}}},
+	},
+	"EG": {
+		payroll.Pension{Rate: "0.11", Cap: money.Of(1500, money.EGP)},
+		payroll.IncomeTax{Brackets: []payroll.Bracket{{UpTo: money.Of(2500, money.EGP), Rate: "0"}, {Rate: "0.20"}}},
+	},
+}
+
+// reloader Applies configuration changes that can take effect without a restart
+type reloader struct {
+	mu     sync.Mutex
+	repos  *hotswap.Factory
+	chaos  *chaos.Injector
+	active config.Config
+	logger *slog.Logger
+}
+
+func (r *reloader) apply(next config.Config) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if next.Addr != r.active.Addr || next.Admin != r.active.Admin {
+		r.logger.Warn("addr changes need a restart", "addr", next.Addr, "admin", next.Admin.Addr)
+	}
+	if next.Chaos != r.active.Chaos {
+		if err := r.chaos.Set(next.Chaos); err != nil {
+			r.logger.Error("keeping current chaos config", "err", err)
+		} else {
+			r.active.Chaos = next.Chaos
+			r.logger.Info("chaos config changed", "enabled", next.Chaos.Enabled)
+		}
+	}
+	if next.Storage == r.active.Storage {
+		return
+	}
+
+	factory, err := storage.Open(next.Storage)
+	if err != nil {
+		r.logger.Error("keeping current backend", "backend", r.repos.Active(), "err", err)
+		return
+	}
+	drainCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if err := r.repos.Swap(drainCtx, next.Storage.Backend, factory); err != nil {
+		r.logger.Warn("previous backend did not drain cleanly", "err", err)
+	}
+	r.active.Storage = next.Storage
+	r.logger.Info("storage backend switched", "backend", next.Storage.Backend)
+}
@@ -0,0 +1,19 @@
+// Command employee-cli is a client of the employee API, over REST or GraphQL
+// as -transport says. The commands are in employeecli; grpcapi/cmd/employee-cli
+// is the same command with gRPC as a third transport.
+//
+//	employee-cli add -name Alice -title Engineer -salary 5000 -currency USD
+//	employee-cli -transport graphql get Alice
+//	employee-cli list -prefix A -sort salary -desc
+//	employee-cli payroll -month 2026-03
+package main
+
+import (
+	"os"
+
+	"go-solid/employeecli"
+)
+
+func main() {
+	os.Exit(employeecli.Main(os.Args[1:], employeecli.Transports))
+}
@@ -0,0 +1,67 @@
+// Command rolematrix prints which concrete types implement which interfaces
+// in the given packages, as a Markdown or HTML table.
+//
+//	rolematrix ./4.ISP
+//	rolematrix -roles 'employee.*' -format html -o roles.html ./employee ./employee/memory ./employee/sqlrepo
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"go-solid/rolematrix"
+)
+
+var renderers = map[string]rolematrix.Renderer{
+	"markdown": rolematrix.Markdown{},
+	"html":     rolematrix.HTML{},
+}
+
+func main() {
+	format := flag.String("format", "markdown", "output format: markdown or html")
+	roles := flag.String("roles", "", "comma-separated interfaces to include: Name, pkg.Name or pkg.* (default all)")
+	out := flag.String("o", "", "output file (default stdout)")
+	flag.Usage = func() {
+		fmt.Fprintln(os.Stderr, "usage: rolematrix [flags] package...")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if flag.NArg() == 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+	if err := run(*format, *roles, *out, flag.Args()); err != nil {
+		fmt.Fprintln(os.Stderr, "rolematrix:", err)
+		os.Exit(1)
+	}
+}
+
+func run(format, roles, out string, patterns []string) error {
+	r, ok := renderers[format]
+	if !ok {
+		return fmt.Errorf("unknown format %q", format)
+	}
+	pkgs, err := rolematrix.Load(".", patterns...)
+	if err != nil {
+		return err
+	}
+	var filter []string
+	if roles != "" {
+		filter = strings.Split(roles, ",")
+	}
+	m := rolematrix.Build(pkgs, filter...)
+
+	var w io.Writer = os.Stdout
+	if out != "" {
+		f, err := os.Create(out)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		w = f
+	}
+	return r.Render(w, m)
+}
@@ -0,0 +1,235 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"runtime/pprof"
+	"strings"
+
+	"go-solid/bench"
+)
+
+const benchUsage = "usage: solid bench list | compare [-principle srp] [-benchtime 1s] [-base SHA [-head SHA]] | record [-count 10] | history | allocs [-top 5] [-html FILE] [-pprof FILE]"
+
+// runBench measures what each principle's refactoring costs at runtime, and
+// tracks it across commits:
+//
+//	solid bench compare
+//	solid bench compare -principle dip -benchtime 3s
+//	solid bench record -count 10
+//	solid bench compare -base 3bd655a
+//	solid bench allocs -principle isp -html allocs.html
+func runBench(ctx context.Context, args []string) error {
+	if len(args) == 0 {
+		return errors.New(benchUsage)
+	}
+	verb := args[0]
+	fs := flag.NewFlagSet("solid bench "+verb, flag.ContinueOnError)
+	principle := fs.String("principle", "", "only the pairs of one principle")
+	benchtime := fs.Duration("benchtime", 0, "time per benchmark (default 1s)")
+	history := fs.String("history", ".bench/history.json", "where recorded results are kept")
+	count := fs.Int("count", 10, "record: runs of each benchmark, for the statistics")
+	commit := fs.String("commit", "", "record: the commit to record under (default: git HEAD, -dirty with local changes)")
+	base := fs.String("base", "", "compare: a recorded commit to compare against, instead of bad against good")
+	head := fs.String("head", "", "compare: the recorded commit to compare with -base (default: git HEAD)")
+	alpha := fs.Float64("alpha", bench.DefaultSignificance.Alpha, "compare: significance level of the U test")
+	threshold := fs.Float64("threshold", bench.DefaultSignificance.MinChange, "compare: smallest change that counts, as a fraction")
+	top := fs.Int("top", 5, "allocs: sites listed per benchmark")
+	htmlOut := fs.String("html", "", "allocs: also write an HTML report to this file")
+	pprofOut := fs.String("pprof", "", "allocs: also write the allocation profile, for go tool pprof")
+	if err := fs.Parse(args[1:]); err != nil {
+		return err
+	}
+	opts := bench.Options{
+		Principle: *principle,
+		Benchtime: *benchtime,
+		Progress: func(p bench.Pair) {
+			fmt.Fprintf(os.Stderr, "⏱️  %s: %s\n", p.Principle, p.Name)
+		},
+	}
+	switch verb {
+	case "list":
+		for _, p := range bench.Pairs {
+			fmt.Printf("%-4s %-26s %s\n", p.Principle, p.Name, p.Note)
+		}
+		return nil
+	case "record":
+		return benchRecord(ctx, opts, *history, *commit, *count)
+	case "history":
+		return benchHistory(*history)
+	case "allocs":
+		return benchAllocs(opts, *top, *htmlOut, *pprofOut)
+	case "compare":
+		if *base != "" {
+			return benchRegressions(ctx, *history, *base, *head, bench.Significance{Alpha: *alpha, MinChange: *threshold})
+		}
+	default:
+		return errors.New(benchUsage)
+	}
+
+	results, err := bench.Run(opts)
+	if err != nil {
+		return err
+	}
+	fmt.Println("📊 Bad → good, per operation")
+	if err := bench.WriteTable(os.Stdout, results); err != nil {
+		return err
+	}
+	fmt.Println()
+	bench.WriteNotes(os.Stdout, results)
+	return nil
+}
+
+// benchRecord runs the benchmarks count times each and adds them to the
+// history under commit.
+func benchRecord(ctx context.Context, opts bench.Options, path, commit string, count int) error {
+	if commit == "" {
+		var err error
+		if commit, err = gitHead(ctx); err != nil {
+			return err
+		}
+	}
+	h, err := bench.LoadHistory(path)
+	if err != nil {
+		return err
+	}
+	rec, err := bench.Sample(opts, commit, count)
+	if err != nil {
+		return err
+	}
+	h.Add(rec)
+	if err := h.Save(path); err != nil {
+		return err
+	}
+	fmt.Printf("💾 %d benchmarks × %d runs recorded for %s in %s\n", len(rec.Benchmarks), count, commit, path)
+	return nil
+}
+
+func benchHistory(path string) error {
+	h, err := bench.LoadHistory(path)
+	if err != nil {
+		return err
+	}
+	if len(h.Records) == 0 {
+		fmt.Printf("nothing recorded in %s yet: run solid bench record\n", path)
+		return nil
+	}
+	for _, r := range h.Records {
+		fmt.Printf("%-48s %s  %-10s %d benchmarks\n", r.Commit, r.Time.Format("2006-01-02 15:04"), r.Go, len(r.Benchmarks))
+	}
+	return nil
+}
+
+// benchRegressions compares two recorded commits and fails if anything got
+// significantly worse, so CI can run it after solid bench record.
+func benchRegressions(ctx context.Context, path, base, head string, sig bench.Significance) error {
+	if head == "" {
+		var err error
+		if head, err = gitHead(ctx); err != nil {
+			return err
+		}
+	}
+	h, err := bench.LoadHistory(path)
+	if err != nil {
+		return err
+	}
+	from, err := h.Find(base)
+	if err != nil {
+		return err
+	}
+	to, err := h.Find(head)
+	if err != nil {
+		return err
+	}
+	changes := bench.Compare(from, to, sig)
+	fmt.Printf("📈 %s → %s\n", short(from.Commit), short(to.Commit))
+	if err := bench.WriteComparison(os.Stdout, short(from.Commit), short(to.Commit), changes); err != nil {
+		return err
+	}
+	regressions := 0
+	for _, c := range changes {
+		if c.Regression() {
+			regressions++
+		}
+	}
+	if regressions > 0 {
+		return fmt.Errorf("%d significant regressions", regressions)
+	}
+	fmt.Println("\n✅ No significant regressions")
+	return nil
+}
+
+// benchAllocs profiles every allocation the benchmarks make and lists the
+// lines responsible, with the complexity of the functions they are in.
+func benchAllocs(opts bench.Options, top int, htmlOut, pprofOut string) error {
+	allocs, err := bench.ProfileAllocs(opts, top)
+	if err != nil {
+		return err
+	}
+	fmt.Println("🔥 Allocation sites, by share of the bytes allocated")
+	if err := bench.WriteAllocations(os.Stdout, allocs); err != nil {
+		return err
+	}
+	if pprofOut != "" {
+		if err := writeFile(pprofOut, func(w io.Writer) error { return pprof.Lookup("allocs").WriteTo(w, 0) }); err != nil {
+			return err
+		}
+		fmt.Printf("\n💾 go tool pprof -sample_index=alloc_space %s\n", pprofOut)
+	}
+	if htmlOut != "" {
+		if err := writeFile(htmlOut, func(w io.Writer) error {
+			return bench.WriteAllocationsHTML(w, "Where the bad and the good code allocate", allocs)
+		}); err != nil {
+			return err
+		}
+		fmt.Printf("\n💾 %s\n", htmlOut)
+	}
+	return nil
+}
+
+// writeFile creates path and writes it with write.
+func writeFile(path string, write func(io.Writer) error) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := write(f); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// gitHead returns the commit checked out, marked -dirty when the tree has
+// uncommitted changes, since those are measured too.
+func gitHead(ctx context.Context) (string, error) {
+	out, err := exec.CommandContext(ctx, "git", "rev-parse", "HEAD").Output()
+	if err != nil {
+		return "", fmt.Errorf("git rev-parse HEAD: %w (pass -commit or -head)", err)
+	}
+	sha := strings.TrimSpace(string(out))
+	status, err := exec.CommandContext(ctx, "git", "status", "--porcelain", "--untracked-files=no").Output()
+	if err != nil {
+		return "", fmt.Errorf("git status: %w", err)
+	}
+	if len(strings.TrimSpace(string(status))) > 0 {
+		sha += "-dirty"
+	}
+	return sha, nil
+}
+
+func short(commit string) string {
+	sha, dirty := strings.CutSuffix(commit, "-dirty")
+	if len(sha) > 7 {
+		sha = sha[:7]
+	}
+	if dirty {
+		sha += "-dirty"
+	}
+	return sha
+}
@@ -0,0 +1,108 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strings"
+	"time"
+
+	"go-solid/bundle"
+)
+
+const bundleUsage = "usage: solid bundle [-out workshop.tar.gz] [-module dir] [-os goos] [-arch goarch] | verify <bundle>"
+
+// runBundle packs the workshop into one archive for a room without
+// internet - solid, the course, the module with its dependencies, and a
+// module cache - or checks one:
+//
+//	solid bundle -out workshop.tar.gz
+//	solid bundle -os windows -arch amd64 -out workshop-windows.tar.gz
+//	solid bundle verify workshop.tar.gz
+func runBundle(ctx context.Context, args []string) error {
+	if len(args) > 0 && args[0] == "verify" {
+		if len(args) != 2 {
+			return errors.New(bundleUsage)
+		}
+		return verifyBundle(args[1])
+	}
+	fs := flag.NewFlagSet("solid bundle", flag.ContinueOnError)
+	out := fs.String("out", "workshop.tar.gz", "archive to write")
+	module := fs.String("module", ".", "the go-solid module to pack; solid is built from its cmd/solid")
+	goos := fs.String("os", runtime.GOOS, "operating system the room runs")
+	goarch := fs.String("arch", runtime.GOARCH, "architecture the room runs")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 0 {
+		return errors.New(bundleUsage)
+	}
+
+	c, err := course()
+	if err != nil {
+		return err
+	}
+	stage, err := os.MkdirTemp("", "solid-bundle")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(stage)
+	bin, err := bundle.Binary(ctx, *module, "./cmd/solid", *goos, *goarch, filepath.Join(stage, "bin"))
+	if err != nil {
+		return err
+	}
+	vendor, err := bundle.Vendor(ctx, *module, stage)
+	if err != nil {
+		return err
+	}
+	cache, err := bundle.ModCache(ctx, *module)
+	if err != nil {
+		return err
+	}
+
+	name := filepath.Base(*out)
+	for _, ext := range []string{".gz", ".tgz", ".tar"} {
+		name = strings.TrimSuffix(name, ext)
+	}
+	m := bundle.Manifest{Name: name, Created: time.Now().UTC().Truncate(time.Second), Go: runtime.Version(), Platform: *goos + "/" + *goarch}
+	// write then rename, so a failed build never leaves half an archive
+	tmp := *out + ".tmp"
+	f, err := os.Create(tmp)
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp)
+	m, err = bundle.Write(ctx, f, m,
+		bin, bundle.Content(c.FS), bundle.Module(ctx, *module, *out, tmp), vendor, cache, bundle.Setup(m))
+	if err := errors.Join(err, f.Close()); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp, *out); err != nil {
+		return err
+	}
+	fmt.Printf("📦 %s: %d files, %.1f MB unpacked, for %s\n", *out, len(m.Files), float64(m.Size())/(1<<20), m.Platform)
+	fmt.Printf("   %s, env.sh and README.txt\n", strings.Join(slices.DeleteFunc(m.Parts, func(p string) bool { return p == "." }), ", "))
+	fmt.Println("   unpack it, . ./env.sh, and solid runs offline")
+	return nil
+}
+
+// verifyBundle checks an archive, or the directory it was unpacked into,
+// against its manifest.
+func verifyBundle(path string) error {
+	fsys, _, err := bundle.Load(path)
+	if err != nil {
+		return err
+	}
+	m, err := bundle.Verify(fsys)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("✅ %s: %d files match the manifest (built %s with %s for %s)\n",
+		path, len(m.Files), m.Created.Format(time.DateOnly), m.Go, m.Platform)
+	return nil
+}
@@ -0,0 +1,127 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"go-solid/classdiagram"
+	"go-solid/lesson"
+	"go-solid/rolematrix"
+)
+
+var diagramUsage = "usage: solid diagram <lesson> | <package>... [-checkpoint n] [-format " + strings.Join(classdiagram.FormatNames(), "|") + "] [-out file]"
+
+// runDiagram draws the types of a lesson's checkpoint, or of packages, and
+// how they relate:
+//
+//	solid diagram isp --out=isp.svg
+//	solid diagram isp -checkpoint 1 -format mermaid
+//	solid diagram ./employee ./employee/memory -format dot | dot -Tpng -o employee.png
+//
+// A lesson is read from the lessons directory of the source tree, or the
+// one SOLID_CONTENT names, because types are checked from source. Without
+// -checkpoint it is the last one: the design the lesson arrives at.
+func runDiagram(ctx context.Context, args []string) error {
+	var targets []string
+	for len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		targets, args = append(targets, args[0]), args[1:]
+	}
+	fs := flag.NewFlagSet("solid diagram", flag.ContinueOnError)
+	checkpoint := fs.Int("checkpoint", 0, "the lesson's checkpoint to draw (default the last)")
+	format := fs.String("format", "", "diagram format: "+strings.Join(classdiagram.FormatNames(), ", ")+" (default from -out, else svg)")
+	var out string
+	fs.StringVar(&out, "out", "", "output file (default stdout)")
+	fs.StringVar(&out, "o", "", "short for -out")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	targets = append(targets, fs.Args()...)
+	if len(targets) == 0 {
+		return errors.New(diagramUsage)
+	}
+
+	if *format == "" {
+		*format = "svg"
+		switch filepath.Ext(out) {
+		case ".dot", ".gv":
+			*format = "dot"
+		case ".mmd", ".mermaid":
+			*format = "mermaid"
+		}
+	}
+	renderer, ok := classdiagram.Formats[*format]
+	if !ok {
+		return fmt.Errorf("unknown format %q - %s", *format, diagramUsage)
+	}
+
+	title := strings.Join(targets, " ")
+	if len(targets) == 1 && !strings.ContainsAny(targets[0], "./") {
+		dir, name, err := checkpointDir(targets[0], *checkpoint)
+		if err != nil {
+			return err
+		}
+		targets, title = []string{"./" + filepath.ToSlash(dir)}, name
+	}
+	pkgs, err := rolematrix.Load(".", targets...)
+	if err != nil {
+		return err
+	}
+	d := classdiagram.Build(title, pkgs...)
+
+	var w io.Writer = os.Stdout
+	if out != "" {
+		f, err := os.Create(out)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		w = f
+	}
+	if err := renderer.Render(w, d); err != nil {
+		return err
+	}
+	if out != "" {
+		fmt.Fprintf(os.Stderr, "🗺️  %d types, %d relationships of %s written to %s\n", len(d.Classes), len(d.Edges), title, out)
+	}
+	return nil
+}
+
+// checkpointDir returns the source directory of a lesson's checkpoint n, the
+// last one for 0, and a title for it.
+func checkpointDir(name string, n int) (string, string, error) {
+	root := "lessons"
+	if dir := os.Getenv("SOLID_CONTENT"); dir != "" {
+		root = dir
+	}
+	store := lesson.FSStore{FS: os.DirFS(root)}
+	cps, err := store.Checkpoints(name)
+	if err != nil {
+		return "", "", err
+	}
+	if len(cps) == 0 {
+		return "", "", fmt.Errorf("lesson %q has no checkpoints", name)
+	}
+	cp := cps[len(cps)-1]
+	if n != 0 {
+		i := 0
+		for i < len(cps) && cps[i].Number != n {
+			i++
+		}
+		if i == len(cps) {
+			return "", "", fmt.Errorf("lesson %q has no checkpoint %d", name, n)
+		}
+		cp = cps[i]
+	}
+	dir, err := store.LessonDir(name)
+	if err != nil {
+		return "", "", err
+	}
+	return filepath.Join(root, filepath.FromSlash(path.Join(dir, cp.Name))), cp.String(), nil
+}
@@ -0,0 +1,9 @@
+package main
+
+// The database/sql drivers of the SQL backends. storage and migrate open
+// them by name and import none, so the binary that opens one links it.
+import (
+	_ "github.com/go-sql-driver/mysql"
+	_ "github.com/lib/pq"
+	_ "modernc.org/sqlite"
+)
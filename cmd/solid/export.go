@@ -0,0 +1,61 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
+
+	"go-solid/blob"
+	"go-solid/codec"
+	"go-solid/export"
+	"go-solid/storage"
+)
+
+func runExport(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("solid export", flag.ContinueOnError)
+	configPath := fs.String("config", "config.json", "path to the JSON config file")
+	format := fs.String("format", "jsonl", "output format: "+strings.Join(codec.Names(), ", "))
+	dest := fs.String("dest", "", "destination URL, schemes: "+strings.Join(blob.Schemes(), ", "))
+	chunk := fs.Int("chunk-size", export.DefaultChunkSize, "bytes per uploaded part")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *dest == "" {
+		return fmt.Errorf("-dest is required")
+	}
+
+	logger := slog.New(slog.NewTextHandler(os.Stderr, nil))
+	cfg, err := loadConfig(*configPath, logger)
+	if err != nil {
+		return err
+	}
+	c, err := codec.Lookup(*format)
+	if err != nil {
+		return err
+	}
+	store, key, err := blob.Open(*dest)
+	if err != nil {
+		return err
+	}
+	repos, err := storage.Open(cfg.Storage)
+	if err != nil {
+		return err
+	}
+	defer repos.Close()
+
+	exporter := &export.Exporter{Source: repos.Employees(), Codec: c, Store: store, ChunkSize: *chunk}
+	res, err := exporter.Export(ctx, key)
+	if err != nil && res.Parts > 0 {
+		logger.Error("export interrupted; run the same command again to resume",
+			"employees", res.Employees, "parts", res.Parts)
+	}
+	if err != nil {
+		return err
+	}
+	logger.Info("export complete", "dest", *dest, "format", c.Name(),
+		"employees", res.Employees, "parts", res.Parts, "resumed", res.Resumed)
+	return nil
+}
@@ -0,0 +1,119 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"go-solid/gen"
+)
+
+const genUsage = "usage: solid gen stub | tests | client | proto -iface <[pkg.]Interface> [-transport rest|grpc] [-pb importpath] [-pkg name] [-o file [-check]]"
+
+// runGen writes Go source from an interface definition:
+//
+//	solid gen stub -iface Notifier
+//	solid gen stub -iface employee.Repository -o employee/employeestub/repository.go
+//	solid gen tests -iface employee.Repository -o employee/repository_test.go
+//	solid gen client -iface httpapi.EmployeeService -transport rest -o sdk/rest.go
+//	solid gen proto -iface httpapi.EmployeeService -pb go-solid/grpcapi/employeepb -o grpcapi/employeepb/employee.proto
+//	solid gen client -iface httpapi.EmployeeService -transport grpc -pb go-solid/grpcapi/employeepb -o grpcapi/employee.go
+//
+// gRPC takes two steps around protoc: proto writes the service's .proto,
+// and client -transport grpc both ends of it over the Go protoc writes from
+// that, at the -pb import path.
+//
+// With -check, nothing is written: it fails if the -o file isn't what would
+// be, so CI catches generated code left behind by its interface.
+func runGen(ctx context.Context, args []string) error {
+	if len(args) == 0 {
+		return errors.New(genUsage)
+	}
+	verb := args[0]
+	fs := flag.NewFlagSet("solid gen "+verb, flag.ContinueOnError)
+	ifaceName := fs.String("iface", "", "interface to generate from, e.g. Notifier or notify.Notifier")
+	pkg := fs.String("pkg", "", "package of the generated file (default: stubs go in the -o directory's package or stubs, tests in the interface's <pkg>_test; a .proto's is the -pb package's name, less its pb suffix)")
+	out := fs.String("o", "", "output file (default stdout)")
+	transport := fs.String("transport", "rest", "client: rest, over the routes the interface's //api:rest annotations give, or grpc, both ends over the service solid gen proto writes")
+	pb := fs.String("pb", "", "proto and client -transport grpc: import path of the Go protoc writes from the .proto, e.g. go-solid/grpcapi/employeepb")
+	check := fs.Bool("check", false, "fail if the -o file differs from what would be written, instead of writing it")
+	if err := fs.Parse(args[1:]); err != nil {
+		return err
+	}
+	if *ifaceName == "" || fs.NArg() > 0 || (*check && *out == "") || (*pb == "" && (verb == "proto" || *transport == "grpc")) {
+		return errors.New(genUsage)
+	}
+
+	svc, err := gen.FindService(ctx, ".", *ifaceName)
+	if err != nil {
+		return err
+	}
+	iface := svc.Iface
+	var src []byte
+	switch verb {
+	case "stub":
+		if *pkg == "" {
+			*pkg = "stubs"
+			if *out != "" {
+				*pkg = filepath.Base(filepath.Dir(*out))
+			}
+		}
+		src, err = gen.Stub(iface, *pkg)
+	case "tests":
+		if *pkg == "" {
+			*pkg = iface.Obj().Pkg().Name() + "_test"
+		}
+		src, err = gen.Tests(iface, *pkg)
+	case "client":
+		if *pkg == "" {
+			*pkg = "client"
+			if *out != "" {
+				*pkg = filepath.Base(filepath.Dir(*out))
+			}
+		}
+		switch *transport {
+		case "rest":
+			src, err = gen.RESTClient(svc, *pkg)
+		case "grpc":
+			src, err = gen.GRPC(iface, *pkg, *pb)
+		default:
+			return fmt.Errorf("solid gen client: unknown transport %q (rest, grpc)", *transport)
+		}
+	case "proto":
+		if *pkg == "" {
+			*pkg = strings.TrimSuffix(path.Base(*pb), "pb")
+		}
+		src, err = gen.Proto(iface, *pkg, *pb)
+	default:
+		return errors.New(genUsage)
+	}
+	if err != nil {
+		return err
+	}
+	if *out == "" {
+		_, err = os.Stdout.Write(src)
+		return err
+	}
+	if *check {
+		current, err := os.ReadFile(*out)
+		if err != nil || !bytes.Equal(current, src) {
+			return fmt.Errorf("%s is not what solid gen %s writes: run go generate", *out, verb)
+		}
+		fmt.Fprintf(os.Stderr, "✅ %s is up to date\n", *out)
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Dir(*out), 0o755); err != nil {
+		return err
+	}
+	if err := os.WriteFile(*out, src, 0o644); err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "✍️  %s written\n", *out)
+	return nil
+}
@@ -0,0 +1,116 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"go-solid/classroom"
+	"go-solid/progress"
+	"go-solid/sandbox"
+	"go-solid/telemetry"
+)
+
+const gradeUsage = "usage: solid grade [-sandbox process|docker|gvisor] [-image golang:1.25] [-timeout 2m] [-format text|github-classroom|junit] [-o file] [-exercise id] [-server url -cohort name -name learner [-token secret]] <dir>"
+
+// runGrade runs a submission's tests in a sandbox and scores them. With
+// -exercise the score goes to the progress store, and with -server to a
+// classroom as well; a failure is a telemetry event, for learners who
+// opted in:
+//
+//	solid grade -exercise srp-1 ./workspace
+//	solid grade -format junit -o report.xml ./submission
+//	solid grade -sandbox gvisor -exercise srp-1 -server http://teacher:8090 -cohort spring -name Ada ./submissions/ada
+func runGrade(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("solid grade", flag.ContinueOnError)
+	kind := fs.String("sandbox", "process", "process, docker or gvisor (docker with the runsc runtime)")
+	image := fs.String("image", "golang:1.25", "image for the container sandboxes")
+	timeout := fs.Duration("timeout", 2*time.Minute, "wall-clock limit for the whole run")
+	exercise := fs.String("exercise", "", "exercise the submission answers; records the score")
+	server := fs.String("server", "", "classroom server URL")
+	cohort := fs.String("cohort", "", "classroom cohort")
+	name := fs.String("name", "", "learner the score belongs to")
+	token := fs.String("token", "", "classroom token, if the server requires one")
+	format := fs.String("format", "text", "text, github-classroom (autograding result JSON) or junit (XML)")
+	out := fs.String("o", "", "write the report to a file instead of stdout")
+	maxScore := fs.Int("max-score", 0, "points the github-classroom format shares between the tests (default one per test)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 || (*server != "" && (*exercise == "" || *cohort == "" || *name == "")) {
+		return errors.New(gradeUsage)
+	}
+
+	var sb sandbox.Sandbox
+	switch *kind {
+	case "process":
+		cache, err := os.UserCacheDir()
+		if err != nil {
+			return err
+		}
+		sb = sandbox.Process{GoCache: filepath.Join(cache, "solid", "sandbox-gocache")}
+	case "docker":
+		sb = sandbox.Container{Image: *image}
+	case "gvisor":
+		sb = sandbox.Container{Image: *image, Runtime: "runsc"}
+	default:
+		return errors.New(gradeUsage)
+	}
+	reporters := map[string]sandbox.ResultReporter{
+		"text":             sandbox.Text{},
+		"github-classroom": sandbox.GitHubClassroom{MaxScore: *maxScore},
+		"junit":            sandbox.JUnit{},
+	}
+	reporter, ok := reporters[*format]
+	if !ok {
+		return fmt.Errorf("unknown format %q: text, github-classroom or junit", *format)
+	}
+	job := sandbox.Job{
+		Dir:     fs.Arg(0),
+		Timeout: *timeout,
+		Limits:  sandbox.Limits{CPU: 30 * time.Second, Memory: 2 << 30, FileSize: 64 << 20, Output: 1 << 20},
+	}
+	report, err := sandbox.Grade(ctx, sb, job)
+	if err != nil {
+		return err
+	}
+	w := os.Stdout
+	if *out != "" {
+		if w, err = os.Create(*out); err != nil {
+			return err
+		}
+	}
+	if err := reporter.Report(w, report); err != nil {
+		return err
+	}
+	if w != os.Stdout {
+		if err := w.Close(); err != nil {
+			return err
+		}
+	}
+
+	if *exercise == "" {
+		return nil
+	}
+	score := report.Score()
+	if report.OK() {
+		p, err := progressStore()
+		if err != nil {
+			return err
+		}
+		if err := p.Record(ctx, progress.Entry{Kind: progress.Exercise, ID: *exercise, Score: &score, CompletedAt: time.Now()}); err != nil {
+			return err
+		}
+	} else {
+		emit(ctx, telemetry.ExerciseFailed(*exercise, len(report.Failed()), len(report.Tests), report.BuildOutput == "", report.Outcome.TimedOut))
+	}
+	if *server == "" {
+		return nil
+	}
+	client := &classroom.Client{URL: *server, Token: *token}
+	return client.Submit(ctx, *cohort, []classroom.Result{{Student: *name, Exercise: *exercise, Score: score, Passed: report.OK()}})
+}
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"strings"
+
+	"go-solid/lesson"
+)
+
+const hintUsage = "usage: solid hint list | <exercise> [-level n]"
+
+// runHint reveals an exercise's hints one at a time, from a nudge to nearly
+// the solution. The progress store remembers how far each exercise went:
+//
+//	solid hint srp-1            # the next hint
+//	solid hint srp-1 -level=2   # everything up to hint 2
+func runHint(ctx context.Context, args []string) error {
+	if len(args) == 0 || strings.HasPrefix(args[0], "-") {
+		return errors.New(hintUsage)
+	}
+	id := args[0]
+	fs := flag.NewFlagSet("solid hint", flag.ContinueOnError)
+	level := fs.Int("level", 0, "reveal the hints up to this level (default: the next one)")
+	if err := fs.Parse(args[1:]); err != nil {
+		return err
+	}
+	c, err := course()
+	if err != nil {
+		return err
+	}
+	store := lesson.FSStore{FS: c.FS}
+	p, err := progressStore()
+	if err != nil {
+		return err
+	}
+	used, err := p.Hints(ctx)
+	if err != nil {
+		return err
+	}
+	if id == "list" {
+		return listExercises(store, used)
+	}
+
+	cp, err := lesson.FindExercise(store, id)
+	if err != nil {
+		return fmt.Errorf("%w - see solid hint list", err)
+	}
+	hints := cp.Exercise.Hints
+	want := *level
+	if want == 0 {
+		want = used[id] + 1
+	}
+	if want < 1 || len(hints) == 0 {
+		return errors.New(hintUsage)
+	}
+	if want > len(hints) {
+		if used[id] >= len(hints) {
+			fmt.Println("that was the last hint - solid lesson diff compares your code with the solution")
+		}
+		want = len(hints)
+	}
+	fmt.Printf("📖 %s\n", cp)
+	for i, h := range hints[:want] {
+		fmt.Printf("💡 %d/%d %s\n", i+1, len(hints), h)
+	}
+	if want <= used[id] {
+		return nil
+	}
+	return p.RecordHint(ctx, id, want)
+}
+
+func listExercises(store lesson.Store, used map[string]int) error {
+	names, err := store.Lessons()
+	if err != nil {
+		return err
+	}
+	for _, name := range names {
+		cps, err := store.Checkpoints(name)
+		if err != nil {
+			return err
+		}
+		for _, cp := range cps {
+			if ex := cp.Exercise; ex != nil {
+				fmt.Printf("%-6s %-28s %d/%d hints used\n", ex.ID, ex.Title, used[ex.ID], len(ex.Hints))
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,123 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go/types"
+	"strings"
+
+	"go-solid/satisfy"
+	"go-solid/typeload"
+)
+
+const (
+	implementsUsage = "usage: solid implements [package...] <Interface>"
+	satisfiesUsage  = "usage: solid satisfies [package...] <Type>"
+)
+
+// runImplements lists the types implementing an interface, and the types
+// that nearly do with what they lack:
+//
+//	solid implements ./... EmployeeRepository
+//	solid implements ./employee/... employee.Repository
+//
+// Packages default to ./..., and are loaded even when they don't compile:
+// "why doesn't my type satisfy the interface?" is asked of code that doesn't.
+func runImplements(ctx context.Context, args []string) error {
+	return explore(ctx, args, implementsUsage, func(pkgs []*types.Package, iface *types.Named) error {
+		if _, ok := iface.Underlying().(*types.Interface); !ok {
+			return fmt.Errorf("%s is not an interface - try solid satisfies", iface.Obj().Name())
+		}
+		fmt.Printf("🔌 %s (%s)\n", iface.Obj().Name(), iface.Obj().Pkg().Path())
+		matches := satisfy.Implementers(pkgs, iface)
+		if len(matches) == 0 {
+			fmt.Println("   ⚪ Nothing in these packages has any of its methods")
+		}
+		for _, m := range matches {
+			printMatch(m, qualifiedName(m.Type, iface.Obj().Pkg()))
+		}
+		return nil
+	})
+}
+
+// runSatisfies is the reverse: the interfaces a type implements, and those
+// it nearly does:
+//
+//	solid satisfies MySQLRepository
+//	solid satisfies ./... memory.Repo
+func runSatisfies(ctx context.Context, args []string) error {
+	return explore(ctx, args, satisfiesUsage, func(pkgs []*types.Package, t *types.Named) error {
+		fmt.Printf("🧩 %s (%s)\n", t.Obj().Name(), t.Obj().Pkg().Path())
+		matches := satisfy.Satisfied(pkgs, t)
+		if len(matches) == 0 {
+			fmt.Println("   ⚪ It has none of the methods of any interface in these packages")
+		}
+		for _, m := range matches {
+			printMatch(m, qualifiedName(m.Interface, t.Obj().Pkg()))
+		}
+		return nil
+	})
+}
+
+// explore loads the packages of args, finds the type named last and runs
+// report on every type of that name.
+func explore(ctx context.Context, args []string, usage string, report func([]*types.Package, *types.Named) error) error {
+	if len(args) == 0 || strings.HasPrefix(args[len(args)-1], "-") {
+		return errors.New(usage)
+	}
+	name, patterns := args[len(args)-1], args[:len(args)-1]
+	if len(patterns) == 0 {
+		patterns = []string{"./..."}
+	}
+	loaded, err := typeload.Load(ctx, ".", patterns...)
+	if err != nil {
+		return err
+	}
+	var pkgs []*types.Package
+	for _, p := range loaded {
+		pkgs = append(pkgs, p.Types)
+	}
+	found := satisfy.Find(pkgs, name)
+	if len(found) == 0 {
+		return fmt.Errorf("no type %s in %s", name, strings.Join(patterns, " "))
+	}
+	for _, p := range loaded {
+		for _, t := range found {
+			if p.Types == t.Obj().Pkg() && len(p.Errors) > 0 {
+				fmt.Printf("⚠️  %s doesn't compile, the answer may be partial: %v\n", p.Types.Path(), p.Errors[0])
+			}
+		}
+	}
+	for i, t := range found {
+		if i > 0 {
+			fmt.Println()
+		}
+		if err := report(pkgs, t); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func printMatch(m satisfy.Match, name string) {
+	switch {
+	case m.Value:
+		fmt.Printf("   ✅ %s\n", name)
+	case m.Pointer:
+		fmt.Printf("   ✅ *%s - only through a pointer\n", name)
+	default:
+		fmt.Printf("   ❌ %s\n", name)
+	}
+	for _, p := range m.Problems {
+		fmt.Printf("      • %s\n", p)
+	}
+}
+
+// qualifiedName writes tn with its package name unless it is in home.
+func qualifiedName(tn *types.TypeName, home *types.Package) string {
+	if tn.Pkg() == home {
+		return tn.Name()
+	}
+	return tn.Pkg().Name() + "." + tn.Name()
+}
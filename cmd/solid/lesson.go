@@ -0,0 +1,172 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"go-solid/content"
+	"go-solid/lesson"
+	"go-solid/progress"
+	"go-solid/telemetry"
+)
+
+const lessonUsage = "usage: solid lesson list | read <lesson> | start <lesson> | next | prev | reset | status | diff [-dir workspace] [-force]"
+
+// runLesson moves a workspace directory between the checkpoints of a lesson:
+//
+//	solid lesson read srp
+//	solid lesson start srp
+//	solid lesson diff
+//	solid lesson next
+func runLesson(ctx context.Context, args []string) error {
+	if len(args) == 0 {
+		return errors.New(lessonUsage)
+	}
+	verb := args[0]
+	fs := flag.NewFlagSet("solid lesson "+verb, flag.ContinueOnError)
+	dir := fs.String("dir", "workspace", "directory the checkpoints are materialized in")
+	force := fs.Bool("force", false, "discard changes made in the workspace")
+	if err := fs.Parse(args[1:]); err != nil {
+		return err
+	}
+	c, err := course()
+	if err != nil {
+		return err
+	}
+	ws := &lesson.Workspace{Dir: *dir, Store: lesson.FSStore{FS: c.FS}}
+
+	var cp lesson.Checkpoint
+	switch verb {
+	case "list":
+		return listLessons(ws.Store)
+	case "read":
+		if fs.NArg() != 1 {
+			return errors.New(lessonUsage)
+		}
+		return readLesson(c, fs.Arg(0))
+	case "start":
+		if fs.NArg() != 1 {
+			return errors.New(lessonUsage)
+		}
+		if err := os.MkdirAll(*dir, 0o755); err != nil {
+			return err
+		}
+		cp, err = ws.Start(fs.Arg(0), *force)
+	case "next":
+		cp, err = ws.Next(*force)
+	case "prev":
+		cp, err = ws.Prev(*force)
+	case "reset":
+		cp, err = ws.Reset()
+	case "status":
+		return lessonStatus(ws)
+	case "diff":
+		return ws.Diff(os.Stdout)
+	default:
+		return errors.New(lessonUsage)
+	}
+	if errors.Is(err, lesson.ErrNoLesson) {
+		return fmt.Errorf("%w in %s; run solid lesson start <lesson>", err, *dir)
+	}
+	if err != nil {
+		return err
+	}
+	fmt.Printf("📖 %s\n   %s is ready in %s - read TASK.md\n", cp, cp.Name, *dir)
+	if cp.Exercise != nil {
+		fmt.Printf("   stuck? solid hint %s\n", cp.Exercise.ID)
+	}
+	if verb == "next" {
+		return recordCheckpoint(ctx, ws.Store, cp)
+	}
+	return nil
+}
+
+// recordCheckpoint notes in the progress store that the checkpoint before
+// cp is done; reaching the last checkpoint completes the lesson.
+func recordCheckpoint(ctx context.Context, store lesson.Store, cp lesson.Checkpoint) error {
+	cps, err := store.Checkpoints(cp.Lesson)
+	if err != nil {
+		return err
+	}
+	p, err := progressStore()
+	if err != nil {
+		return err
+	}
+	i := slices.IndexFunc(cps, func(c lesson.Checkpoint) bool { return c.Name == cp.Name })
+	if i < 1 {
+		return nil
+	}
+	now := time.Now()
+	done := cps[i-1]
+	if err := p.Record(ctx, progress.Entry{Kind: progress.Checkpoint, ID: done.Lesson + "/" + done.Name, CompletedAt: now}); err != nil {
+		return err
+	}
+	if i < len(cps)-1 {
+		return nil
+	}
+	fmt.Printf("🎓 lesson %s complete - see solid progress\n", cp.Lesson)
+	emit(ctx, telemetry.LessonCompleted(cp.Lesson, len(cps)))
+	return p.Record(ctx, progress.Entry{Kind: progress.Lesson, ID: cp.Lesson, CompletedAt: now})
+}
+
+func listLessons(store lesson.Store) error {
+	names, err := store.Lessons()
+	if err != nil {
+		return err
+	}
+	for _, name := range names {
+		cps, err := store.Checkpoints(name)
+		if err != nil {
+			return err
+		}
+		titles := make([]string, len(cps))
+		for i, cp := range cps {
+			titles[i] = fmt.Sprintf("%d. %s", cp.Number, cp.Title)
+		}
+		fmt.Printf("%-4s %s\n", name, strings.Join(titles, " → "))
+	}
+	return nil
+}
+
+// readLesson prints a lesson's text, with its code excerpts, and its diagrams.
+func readLesson(provider content.Provider, name string) error {
+	l, err := provider.Lesson(name)
+	if err != nil {
+		return err
+	}
+	text, err := content.Expand(provider, name, l.Text)
+	if err != nil {
+		return err
+	}
+	diagrams, err := provider.Diagrams(name)
+	if err != nil {
+		return err
+	}
+	fmt.Println(strings.TrimSpace(text))
+	for _, d := range diagrams {
+		fmt.Printf("\n%s:\n\n%s", d.Name, d.Text)
+	}
+	fmt.Printf("\nsolid lesson start %s to write the code, solid quiz %s to check what stuck\n", name, name)
+	return nil
+}
+
+func lessonStatus(ws *lesson.Workspace) error {
+	st, err := ws.Status()
+	if err != nil {
+		return err
+	}
+	fmt.Printf("%s (checkpoint %d of %d)\n", st.Checkpoint, st.Checkpoint.Number, st.Of)
+	if len(st.Modified) == 0 {
+		fmt.Println("no changes")
+	}
+	for _, file := range st.Modified {
+		fmt.Println("modified:", file)
+	}
+	return nil
+}
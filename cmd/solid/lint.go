@@ -0,0 +1,86 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"go-solid/lint"
+)
+
+// runLint checks packages against the idioms the lessons teach:
+//
+//	solid lint
+//	solid lint -rules returnstructs ./employee/...
+//	solid lint -list
+//	solid lint -fix ./examples/...
+func runLint(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("solid lint", flag.ContinueOnError)
+	only := fs.String("rules", "", "comma-separated rules to run (default all)")
+	list := fs.Bool("list", false, "list the rules and stop")
+	fix := fs.Bool("fix", false, "apply the fixes the rules suggest")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *list {
+		for _, r := range lint.Rules {
+			fmt.Printf("%-16s %s\n", r.Name(), r.Doc())
+		}
+		return nil
+	}
+	var names []string
+	if *only != "" {
+		names = strings.Split(*only, ",")
+	}
+	rules, err := lint.Lookup(names...)
+	if err != nil {
+		return err
+	}
+	patterns := fs.Args()
+	if len(patterns) == 0 {
+		patterns = []string{"./..."}
+	}
+	findings, err := lint.Run(ctx, ".", patterns, rules...)
+	if err != nil {
+		return err
+	}
+	wd, _ := os.Getwd()
+	rel := func(file string) string {
+		r, err := filepath.Rel(wd, file)
+		if err != nil || strings.HasPrefix(r, "..") {
+			return file
+		}
+		return r
+	}
+	if *fix {
+		changed, err := lint.Apply(findings)
+		for _, file := range changed {
+			fmt.Println("🔧 Fixed", rel(file))
+		}
+		if err != nil {
+			return err
+		}
+		var left []lint.Finding
+		for _, f := range findings {
+			if f.Fix == nil {
+				left = append(left, f)
+			}
+		}
+		findings = left
+	}
+	for _, f := range findings {
+		mark := "❌"
+		if f.Fix != nil {
+			mark = "🔧" // -fix can make this change
+		}
+		fmt.Printf("%s %s:%d: %s: %s\n", mark, rel(f.Pos.Filename), f.Pos.Line, f.Rule, f.Message)
+	}
+	if len(findings) > 0 {
+		return fmt.Errorf("%d findings", len(findings))
+	}
+	fmt.Println("✅ No findings in", strings.Join(patterns, " "))
+	return nil
+}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"net/http/httptest"
+	"os"
+	"time"
+
+	"go-solid/employee"
+	"go-solid/employee/memory"
+	"go-solid/fakes"
+	"go-solid/httpapi"
+	"go-solid/load"
+)
+
+// runLoad drives traffic at the employee API and prints latencies per
+// route. Without -url it starts the API in-process over the memory
+// repository:
+//
+//	solid load -rps 500 -duration 60s
+//	solid load -url http://localhost:8080 -mix get=6,create=4
+func runLoad(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("solid load", flag.ContinueOnError)
+	url := fs.String("url", "", "employee API to load (default: an in-process server)")
+	rps := fs.Int("rps", 100, "requests per second")
+	duration := fs.Duration("duration", 10*time.Second, "how long to send requests")
+	mixSpec := fs.String("mix", "read-heavy", "read-heavy, balanced, write-heavy, or weights like get=8,create=2")
+	population := fs.Int("population", 100, "employees created before the run, for reads and updates")
+	inFlight := fs.Int("max-in-flight", 256, "concurrent requests before new ones are dropped")
+	seed := fs.Uint64("seed", 1, "seed for the employees created and the requests sent")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	mix, err := load.ParseMix(*mixSpec, *population)
+	if err != nil {
+		return err
+	}
+
+	if *url == "" {
+		server := httptest.NewServer(httpapi.New(employee.NewManager(memory.New())))
+		defer server.Close()
+		*url = server.URL
+		fmt.Println("🧪 No -url: loading an in-process API over the memory repository")
+	}
+	if err := load.Seed(ctx, nil, *url, fakes.New(*seed), *population); err != nil {
+		return err
+	}
+	fmt.Printf("🚀 %d req/s for %s against %s (%s)\n\n", *rps, *duration, *url, *mixSpec)
+	runner := &load.Runner{URL: *url, Pattern: mix, RPS: *rps, Duration: *duration, MaxInFlight: *inFlight, Seed: *seed}
+	report, err := runner.Run(ctx)
+	if err != nil {
+		return err
+	}
+	return report.Write(os.Stdout)
+}
@@ -0,0 +1,104 @@
+// Command solid is the operator's tool for the employee domain. Each
+// subcommand is a small function registered by name, so adding one never
+// touches the others.
+//
+//	solid export -format=jsonl -dest=file:///var/backups/employees.jsonl
+//	solid export -format=csv -dest=s3://backups/employees.csv
+//	solid repl
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"os"
+	"os/signal"
+	"sort"
+	"syscall"
+
+	"go-solid/config"
+)
+
+// command One subcommand: parses its own flags from args
+type command struct {
+	summary string
+	run     func(ctx context.Context, args []string) error
+}
+
+var commands = map[string]command{
+	"bundle":        {"pack the workshop into one archive that runs offline", runBundle},
+	"bench":         {"benchmark each principle's bad and good code side by side", runBench},
+	"diagram":       {"draw a lesson's types, the interfaces they implement and embed", runDiagram},
+	"export":        {"stream all employees to a blob store", runExport},
+	"gen":           {"generate stubs from interface definitions", runGen},
+	"grade":         {"run a submission's tests in a sandbox and score them", runGrade},
+	"hint":          {"reveal an exercise's hints, one at a time", runHint},
+	"implements":    {"the types implementing an interface, and what near misses lack", runImplements},
+	"lesson":        {"step through a principle's checkpoints", runLesson},
+	"lint":          {"check code against the idioms the lessons teach", runLint},
+	"load":          {"send traffic at the employee API and report latencies", runLoad},
+	"metrics":       {"measure complexity, before and after refactoring", runMetrics},
+	"migrate":       {"apply or revert the storage backend's schema migrations", runMigrate},
+	"mutate":        {"mutate code and report what the tests miss", runMutate},
+	"progress":      {"what you completed, and signed certificates", runProgress},
+	"quiz":          {"answer a lesson's quiz questions", runQuiz},
+	"repl":          {"interactive shell over the domain", runRepl},
+	"scenario":      {"run scripted demos and check their output", runScenario},
+	"satisfies":     {"the interfaces a type implements, and what it lacks for others", runSatisfies},
+	"serve":         {"run a server: classroom collects a cohort's results, telemetry its usage", runServe},
+	"simulate":      {"pay a made-up org month after month, with stats and timing", runSimulate},
+	"slides":        {"a lesson's slide deck, code excerpts included", runSlides},
+	"snippets":      {"list marked code regions, check lessons still quote real code", runSnippets},
+	"telemetry":     {"whether anonymous usage events are sent, and what they hold", runTelemetry},
+	"verify-wiring": {"check the wiring main records against the code", runVerifyWiring},
+}
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+		os.Exit(2)
+	}
+	cmd, ok := commands[os.Args[1]]
+	if !ok {
+		fmt.Fprintf(os.Stderr, "solid: unknown command %q\n\n", os.Args[1])
+		usage()
+		os.Exit(2)
+	}
+
+	// Interrupting a command cancels its context; long-running ones stop
+	// cleanly and, where they can, resume on the next run.
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	if err := cmd.run(ctx, os.Args[2:]); err != nil {
+		if !errors.Is(err, flag.ErrHelp) {
+			fmt.Fprintf(os.Stderr, "solid %s: %v\n", os.Args[1], err)
+		}
+		os.Exit(1)
+	}
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "usage: solid <command> [flags]\n\ncommands:")
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintf(os.Stderr, "  %-10s %s\n", name, commands[name].summary)
+	}
+}
+
+// loadConfig reads the same config file as employee-api, falling back to
+// the defaults when it doesn't exist.
+func loadConfig(path string, logger *slog.Logger) (config.Config, error) {
+	cfg, err := config.Load(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		logger.Warn("config file not found, using defaults", "path", path)
+		return config.Default, nil
+	}
+	return cfg, err
+}
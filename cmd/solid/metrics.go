@@ -0,0 +1,129 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+
+	"go-solid/lesson"
+	"go-solid/metrics"
+)
+
+const metricsUsage = "usage: solid metrics -complexity [-v] [dir... | -compare <before> <after>]"
+
+// runMetrics measures code. Without directories it compares each lesson's
+// first checkpoint - the code with the problem - with its last, the
+// refactored code:
+//
+//	solid metrics -complexity
+//	solid metrics -complexity ./payroll
+//	solid metrics -complexity -compare ./workspace lessons/2-ocp/03-add-a-role
+func runMetrics(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("solid metrics", flag.ContinueOnError)
+	complexity := fs.Bool("complexity", false, "cyclomatic and cognitive complexity per function")
+	verbose := fs.Bool("v", false, "with comparisons, also list every function")
+	compare := fs.Bool("compare", false, "compare two directories instead of listing them")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	// complexity is the only metric so far; the flag keeps room for others
+	if !*complexity || (*compare && fs.NArg() != 2) {
+		return errors.New(metricsUsage)
+	}
+
+	switch {
+	case *compare:
+		before, err := metrics.Dir(os.DirFS(fs.Arg(0)), ".")
+		if err != nil {
+			return err
+		}
+		after, err := metrics.Dir(os.DirFS(fs.Arg(1)), ".")
+		if err != nil {
+			return err
+		}
+		return printComparisons([]metrics.Comparison{{Name: fs.Arg(0) + " → " + fs.Arg(1), Before: metrics.Sum(before), After: metrics.Sum(after)}},
+			*verbose, [][2][]metrics.Function{{before, after}})
+	case fs.NArg() > 0:
+		for _, dir := range fs.Args() {
+			fns, err := metrics.Dir(os.DirFS(dir), ".")
+			if err != nil {
+				return err
+			}
+			fmt.Printf("📏 %s\n", dir)
+			if err := metrics.WriteFunctions(os.Stdout, fns); err != nil {
+				return err
+			}
+			fmt.Println()
+		}
+		return nil
+	}
+	return lessonComplexity(*verbose)
+}
+
+func lessonComplexity(verbose bool) error {
+	c, err := course()
+	if err != nil {
+		return err
+	}
+	store := lesson.FSStore{FS: c.FS}
+	names, err := store.Lessons()
+	if err != nil {
+		return err
+	}
+	var cs []metrics.Comparison
+	var pairs [][2][]metrics.Function
+	for _, name := range names {
+		cps, err := store.Checkpoints(name)
+		if err != nil {
+			return err
+		}
+		if len(cps) < 2 {
+			continue
+		}
+		first, last := cps[0], cps[len(cps)-1]
+		before, err := checkpointComplexity(store, first)
+		if err != nil {
+			return err
+		}
+		after, err := checkpointComplexity(store, last)
+		if err != nil {
+			return err
+		}
+		cs = append(cs, metrics.Comparison{Name: fmt.Sprintf("%s %d → %d", name, first.Number, last.Number), Before: metrics.Sum(before), After: metrics.Sum(after)})
+		pairs = append(pairs, [2][]metrics.Function{before, after})
+	}
+	return printComparisons(cs, verbose, pairs)
+}
+
+func checkpointComplexity(store lesson.Store, cp lesson.Checkpoint) ([]metrics.Function, error) {
+	tree, err := store.Tree(cp)
+	if err != nil {
+		return nil, err
+	}
+	return metrics.Files(tree)
+}
+
+// printComparisons writes the comparison table and, verbose, the functions
+// behind each row.
+func printComparisons(cs []metrics.Comparison, verbose bool, pairs [][2][]metrics.Function) error {
+	fmt.Println("📏 Complexity, before → after")
+	if err := metrics.WriteComparisons(os.Stdout, cs); err != nil {
+		return err
+	}
+	if !verbose {
+		return nil
+	}
+	for i, c := range cs {
+		fmt.Printf("\n❌ %s, before\n", c.Name)
+		if err := metrics.WriteFunctions(os.Stdout, pairs[i][0]); err != nil {
+			return err
+		}
+		fmt.Printf("\n✅ %s, after\n", c.Name)
+		if err := metrics.WriteFunctions(os.Stdout, pairs[i][1]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+
+	"go-solid/migrate"
+)
+
+const migrateUsage = "usage: solid migrate up | down [-config config.json] [-steps 1]"
+
+// runMigrate brings the configured storage backend's schema up to date, or
+// reverts the latest migrations:
+//
+//	solid migrate up
+//	solid migrate down -steps 2
+func runMigrate(ctx context.Context, args []string) error {
+	if len(args) == 0 {
+		return errors.New(migrateUsage)
+	}
+	verb := args[0]
+	fs := flag.NewFlagSet("solid migrate "+verb, flag.ContinueOnError)
+	configPath := fs.String("config", "config.json", "path to the JSON config file")
+	steps := fs.Int("steps", 1, "how many migrations down reverts")
+	if err := fs.Parse(args[1:]); err != nil {
+		return err
+	}
+	if verb != "up" && verb != "down" {
+		return errors.New(migrateUsage)
+	}
+
+	logger := slog.New(slog.NewTextHandler(os.Stderr, nil))
+	cfg, err := loadConfig(*configPath, logger)
+	if err != nil {
+		return err
+	}
+	m, err := migrate.Open(cfg.Storage)
+	if err != nil {
+		return err
+	}
+	defer m.Close()
+
+	var done []migrate.Migration
+	mark := "⬆️ "
+	if verb == "up" {
+		done, err = m.Up(ctx)
+	} else {
+		done, err = m.Down(ctx, *steps)
+		mark = "⬇️ "
+	}
+	for _, mig := range done {
+		fmt.Println(mark, mig)
+	}
+	if err != nil {
+		return err
+	}
+	if len(done) == 0 {
+		fmt.Printf("✅ %s: nothing to do\n", cfg.Storage.Backend)
+	}
+	return nil
+}
@@ -0,0 +1,43 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"go-solid/employee"
+	"go-solid/migrate"
+	"go-solid/storage"
+)
+
+// TestMigrate_SQLite runs solid migrate up as the README does, on a SQLite
+// file, with only the drivers this binary links.
+func TestMigrate_SQLite(t *testing.T) {
+	cfg := storage.Config{Backend: "sqlite", DSN: filepath.Join(t.TempDir(), "hr.db")}
+	data, _ := json.Marshal(map[string]storage.Config{"storage": cfg})
+	path := filepath.Join(t.TempDir(), "config.json")
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := runMigrate(t.Context(), []string{"up", "-config", path}); err != nil {
+		t.Fatalf("solid migrate up error = %v", err)
+	}
+
+	m, err := migrate.Open(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer m.Close()
+	if done, err := m.Up(t.Context()); err != nil || len(done) != 0 {
+		t.Errorf("Up() after solid migrate up = %v, %v, want nothing left to apply", done, err)
+	}
+	repos, err := storage.Open(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer repos.Close()
+	if err := repos.Employees().Save(t.Context(), employee.Employee{Name: "Ali"}); err != nil {
+		t.Errorf("Save() on the migrated schema error = %v", err)
+	}
+}
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"strings"
+
+	"go-solid/mutate"
+)
+
+const mutateUsage = "usage: solid mutate [-workers n] [-mutators flip-condition,change-constant,delete-statement] [-v] <packages>"
+
+// runMutate mutates the code of the given packages and reports which
+// mutants the tests let through:
+//
+//	solid mutate ./workspace/...
+func runMutate(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("solid mutate", flag.ContinueOnError)
+	workers := fs.Int("workers", 0, "mutants tested at once (default half the CPUs)")
+	names := fs.String("mutators", "", "comma-separated mutators to apply (default all)")
+	verbose := fs.Bool("v", false, "list every mutant, not only the survivors")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() == 0 {
+		return errors.New(mutateUsage)
+	}
+	opts := mutate.Options{Workers: *workers}
+	if *names != "" {
+		byName := map[string]mutate.Mutator{}
+		for _, m := range mutate.All {
+			byName[m.Name()] = m
+		}
+		for _, name := range strings.Split(*names, ",") {
+			m, ok := byName[strings.TrimSpace(name)]
+			if !ok {
+				return fmt.Errorf("unknown mutator %q", name)
+			}
+			opts.Mutators = append(opts.Mutators, m)
+		}
+	}
+	report, err := mutate.Run(ctx, opts, fs.Args()...)
+	if err != nil {
+		return err
+	}
+
+	for _, pkg := range report.Untested {
+		fmt.Printf("⚪ %s has no tests - every mutant would survive\n", pkg)
+	}
+	for _, r := range report.Results {
+		switch {
+		case r.Status == mutate.Survived:
+			fmt.Println("🧟", r)
+		case *verbose:
+			fmt.Println("  ", r)
+		}
+	}
+	fmt.Printf("%d killed, %d timed out, %d survived, %d didn't compile - mutation score %.0f%%\n",
+		report.Count(mutate.Killed), report.Count(mutate.TimedOut), report.Count(mutate.Survived), report.Count(mutate.Invalid), 100*report.Score())
+	return nil
+}
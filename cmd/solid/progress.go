@@ -0,0 +1,274 @@
+package main
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"go-solid/classroom"
+	"go-solid/lesson"
+	"go-solid/progress"
+)
+
+const progressUsage = "usage: solid progress [summary] | certificate -name <learner> -key <file> [-o cert.json] [-pdf cert.pdf] | verify -pub <file> <cert.json> | keygen [-o file] | submit -server <url> -cohort <name> -name <learner> [-token secret] | leaderboard -server <url> -cohort <name>"
+
+// progressStore is the learner's progress file; SOLID_PROGRESS moves it,
+// e.g. to keep a classroom machine's learners apart.
+func progressStore() (progress.Store, error) {
+	if path := os.Getenv("SOLID_PROGRESS"); path != "" {
+		return &progress.File{Path: path}, nil
+	}
+	path, err := progress.DefaultPath()
+	if err != nil {
+		return nil, err
+	}
+	return &progress.File{Path: path}, nil
+}
+
+// runProgress reports and certifies what the learner has completed:
+//
+//	solid progress
+//	solid progress keygen -o instructor.key
+//	solid progress certificate -name "Ada Lovelace" -key instructor.key -pdf ada.pdf
+//	solid progress verify -pub instructor.key.pub certificate.json
+//	solid progress submit -server http://teacher:8090 -cohort spring -name "Ada Lovelace"
+func runProgress(ctx context.Context, args []string) error {
+	verb := "summary"
+	if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		verb, args = args[0], args[1:]
+	}
+	fs := flag.NewFlagSet("solid progress "+verb, flag.ContinueOnError)
+	name := fs.String("name", "", "learner named on the certificate")
+	keyPath := fs.String("key", "", "private key signing the certificate")
+	pubPath := fs.String("pub", "", "public key the certificate must verify against")
+	out := fs.String("o", "", "output file (certificate.json, or solid.key for keygen)")
+	pdf := fs.String("pdf", "", "also render the certificate as a PDF")
+	server := fs.String("server", "", "classroom server URL")
+	cohort := fs.String("cohort", "", "classroom cohort")
+	token := fs.String("token", "", "classroom token, if the server requires one")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	switch verb {
+	case "summary":
+		return progressSummary(ctx)
+	case "certificate":
+		if *name == "" || *keyPath == "" {
+			return errors.New(progressUsage)
+		}
+		return issueCertificate(ctx, *name, *keyPath, cmp.Or(*out, "certificate.json"), *pdf)
+	case "verify":
+		if *pubPath == "" || fs.NArg() != 1 {
+			return errors.New(progressUsage)
+		}
+		return verifyCertificate(*pubPath, fs.Arg(0))
+	case "submit":
+		if *server == "" || *cohort == "" || *name == "" {
+			return errors.New(progressUsage)
+		}
+		return submitProgress(ctx, &classroom.Client{URL: *server, Token: *token}, *cohort, *name)
+	case "leaderboard":
+		if *server == "" || *cohort == "" {
+			return errors.New(progressUsage)
+		}
+		return printLeaderboard(ctx, &classroom.Client{URL: *server}, *cohort)
+	case "keygen":
+		path := cmp.Or(*out, "solid.key")
+		pub, err := progress.GenerateKey(path)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("🔑 key %s written to %s (share %s.pub with whoever verifies)\n", progress.KeyID(pub), path, path)
+		return nil
+	}
+	return errors.New(progressUsage)
+}
+
+func progressSummary(ctx context.Context) error {
+	p, err := progressStore()
+	if err != nil {
+		return err
+	}
+	entries, err := p.Entries(ctx)
+	if err != nil {
+		return err
+	}
+	hints, err := p.Hints(ctx)
+	if err != nil {
+		return err
+	}
+	c, err := course()
+	if err != nil {
+		return err
+	}
+	store := lesson.FSStore{FS: c.FS}
+	names, err := store.Lessons()
+	if err != nil {
+		return err
+	}
+	quizzes := map[string]float64{}
+	for _, e := range entries {
+		if e.Kind == progress.Quiz && e.Score != nil {
+			quizzes[e.ID] = *e.Score
+		}
+	}
+	for _, name := range names {
+		cps, err := store.Checkpoints(name)
+		if err != nil {
+			return err
+		}
+		// the last checkpoint is the finished code, there is nothing to do in it
+		done, used := 0, 0
+		for _, cp := range cps[:len(cps)-1] {
+			if progress.Completed(entries, progress.Checkpoint, name+"/"+cp.Name) {
+				done++
+			}
+			if cp.Exercise != nil {
+				used += hints[cp.Exercise.ID]
+			}
+		}
+		mark := "  "
+		if progress.Completed(entries, progress.Lesson, name) {
+			mark = "✅"
+		}
+		fmt.Printf("%s %-4s %d/%d checkpoints", mark, name, done, len(cps)-1)
+		if used > 0 {
+			fmt.Printf(", %d hint(s) used", used)
+		}
+		if score, ok := quizzes[name]; ok {
+			fmt.Printf(", quiz %.0f%%", 100*score)
+		}
+		fmt.Println()
+	}
+	// kinds without a catalog here are counted, not listed
+	counts := map[progress.Kind]int{}
+	for _, e := range entries {
+		counts[e.Kind]++
+	}
+	for _, k := range []progress.Kind{progress.Exercise} {
+		if counts[k] > 0 {
+			fmt.Printf("   %d %s(s) completed\n", counts[k], k)
+		}
+	}
+	return nil
+}
+
+func issueCertificate(ctx context.Context, name, keyPath, out, pdf string) error {
+	key, err := progress.LoadPrivateKey(keyPath)
+	if err != nil {
+		return err
+	}
+	p, err := progressStore()
+	if err != nil {
+		return err
+	}
+	entries, err := p.Entries(ctx)
+	if err != nil {
+		return err
+	}
+	entries = slices.DeleteFunc(entries, func(e progress.Entry) bool { return e.Kind == progress.Checkpoint })
+	cert, err := progress.Issue(name, entries, key, time.Now())
+	if errors.Is(err, progress.ErrNothingCompleted) {
+		return fmt.Errorf("%w - finish a lesson first (solid lesson start srp)", err)
+	}
+	if err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(cert, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(out, append(data, '\n'), 0o644); err != nil {
+		return err
+	}
+	fmt.Printf("📜 certificate for %s (%s) written to %s\n", name, strings.Join(cert.Lessons, ", "), out)
+	if pdf == "" {
+		return nil
+	}
+	f, err := os.Create(pdf)
+	if err != nil {
+		return err
+	}
+	if err := progress.WritePDF(f, cert); err != nil {
+		f.Close()
+		return err
+	}
+	fmt.Printf("📜 printable copy written to %s\n", pdf)
+	return f.Close()
+}
+
+func verifyCertificate(pubPath, certPath string) error {
+	pub, err := progress.LoadPublicKey(pubPath)
+	if err != nil {
+		return err
+	}
+	data, err := os.ReadFile(certPath)
+	if err != nil {
+		return err
+	}
+	var cert progress.Certificate
+	if err := json.Unmarshal(data, &cert); err != nil {
+		return fmt.Errorf("%s: %w", certPath, err)
+	}
+	if err := progress.Verify(cert, pub); err != nil {
+		return err
+	}
+	fmt.Printf("✅ %s completed %s (issued %s)\n", cert.Learner, strings.Join(cert.Lessons, ", "), cert.IssuedAt.Format(time.DateOnly))
+	return nil
+}
+
+// submitProgress sends everything completed to a classroom server. Resending
+// is harmless: the leaderboard counts each exercise's best attempt.
+func submitProgress(ctx context.Context, client *classroom.Client, cohort, name string) error {
+	p, err := progressStore()
+	if err != nil {
+		return err
+	}
+	entries, err := p.Entries(ctx)
+	if err != nil {
+		return err
+	}
+	if len(entries) == 0 {
+		return progress.ErrNothingCompleted
+	}
+	results := make([]classroom.Result, len(entries))
+	for i, e := range entries {
+		score := 1.0
+		if e.Score != nil {
+			score = *e.Score
+		}
+		results[i] = classroom.Result{Student: name, Exercise: string(e.Kind) + ":" + e.ID, Score: score, Passed: true}
+	}
+	if err := client.Submit(ctx, cohort, results); err != nil {
+		return err
+	}
+	fmt.Printf("📤 %d result(s) submitted to %s\n", len(results), cohort)
+	return nil
+}
+
+func printLeaderboard(ctx context.Context, client *classroom.Client, cohort string) error {
+	board, err := client.Leaderboard(ctx, cohort)
+	if err != nil {
+		return err
+	}
+	for _, s := range board {
+		fmt.Printf("%3d. %-20s %6.2f points  %d passed  %d attempts\n", s.Rank, s.Student, s.Points, s.Passed, s.Attempts)
+	}
+	stats, err := client.Stats(ctx, cohort)
+	if err != nil {
+		return err
+	}
+	fmt.Println()
+	for _, s := range stats {
+		fmt.Printf("     %-40s %d/%d passed  mean %.2f\n", s.Exercise, s.Passed, s.Students, s.MeanScore)
+	}
+	return nil
+}
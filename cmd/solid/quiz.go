@@ -0,0 +1,148 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"go-solid/content"
+	"go-solid/progress"
+	"go-solid/quiz"
+)
+
+const quizUsage = "usage: solid quiz list | <lesson>"
+
+// course is the course content compiled into solid; SOLID_CONTENT points
+// it at a directory laid out like lessons/ instead, for authors trying out
+// their edits, and SOLID_BUNDLE at a workshop bundle's, as the bundle's
+// env.sh does. Lessons, checkpoints and hints all come from it.
+func course() (content.FS, error) {
+	if dir := os.Getenv("SOLID_CONTENT"); dir != "" {
+		return content.Dir(dir), nil
+	}
+	if path := os.Getenv("SOLID_BUNDLE"); path != "" {
+		return content.Bundle(path)
+	}
+	return content.Embedded(), nil
+}
+
+// runQuiz asks a lesson's questions and records the score, keeping the best
+// one:
+//
+//	solid quiz list
+//	solid quiz ocp
+func runQuiz(ctx context.Context, args []string) error {
+	if len(args) != 1 || strings.HasPrefix(args[0], "-") {
+		return errors.New(quizUsage)
+	}
+	provider, err := course()
+	if err != nil {
+		return err
+	}
+	p, err := progressStore()
+	if err != nil {
+		return err
+	}
+	if args[0] == "list" {
+		return listQuizzes(ctx, provider, p)
+	}
+
+	l, err := provider.Lesson(args[0])
+	if err != nil {
+		return fmt.Errorf("%w - see solid quiz list", err)
+	}
+	questions, err := provider.Quiz(l.Name)
+	if err != nil {
+		return err
+	}
+	session, err := quiz.New(questions)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("📝 %s - %d questions, answer with the choice's number\n", l.Title, len(questions))
+	if err := askAll(session, bufio.NewReader(os.Stdin), os.Stdout); err != nil {
+		return err
+	}
+	right, of := session.Correct()
+	fmt.Printf("\n🏁 %d/%d correct\n", right, of)
+	score := session.Score()
+	return p.Record(ctx, progress.Entry{Kind: progress.Quiz, ID: l.Name, Score: &score, CompletedAt: time.Now()})
+}
+
+// askAll shows each question and reads answers until one is valid.
+func askAll(s *quiz.Session, in *bufio.Reader, out io.Writer) error {
+	for {
+		q, n, ok := s.Question()
+		if !ok {
+			return nil
+		}
+		fmt.Fprintf(out, "\n%d. %s\n", n, q.Prompt)
+		for i, c := range q.Choices {
+			fmt.Fprintf(out, "   %d) %s\n", i+1, c)
+		}
+		for {
+			fmt.Fprint(out, "> ")
+			line, err := in.ReadString('\n')
+			if err != nil && strings.TrimSpace(line) == "" {
+				return fmt.Errorf("quiz abandoned at question %d: %w", n, err)
+			}
+			choice, convErr := strconv.Atoi(strings.TrimSpace(line))
+			if convErr != nil {
+				fmt.Fprintf(out, "   a number from 1 to %d, please\n", len(q.Choices))
+				continue
+			}
+			fb, err := s.Answer(choice - 1)
+			if errors.Is(err, quiz.ErrChoice) {
+				fmt.Fprintf(out, "   %v\n", err)
+				continue
+			}
+			if err != nil {
+				return err
+			}
+			if fb.Correct {
+				fmt.Fprintf(out, "   ✅ %s\n", fb.Explanation)
+			} else {
+				fmt.Fprintf(out, "   ❌ the answer is %d) %s\n      %s\n", fb.Answer+1, q.Choices[fb.Answer], fb.Explanation)
+			}
+			break
+		}
+	}
+}
+
+func listQuizzes(ctx context.Context, provider content.Provider, p progress.Store) error {
+	all, err := provider.Lessons()
+	if err != nil {
+		return err
+	}
+	entries, err := p.Entries(ctx)
+	if err != nil {
+		return err
+	}
+	best := map[string]float64{}
+	for _, e := range entries {
+		if e.Kind == progress.Quiz && e.Score != nil {
+			best[e.ID] = *e.Score
+		}
+	}
+	for _, l := range all {
+		questions, err := provider.Quiz(l.Name)
+		if errors.Is(err, content.ErrNoContent) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		fmt.Printf("%-4s %-36s %d questions", l.Name, l.Title, len(questions))
+		if score, ok := best[l.Name]; ok {
+			fmt.Printf(", best %.0f%%", 100*score)
+		}
+		fmt.Println()
+	}
+	return nil
+}
@@ -0,0 +1,413 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"go-solid/employee"
+	"go-solid/migrate"
+	"go-solid/money"
+	"go-solid/payroll"
+	"go-solid/storage"
+	"go-solid/storage/hotswap"
+)
+
+// session State of one REPL: the Manager is built once over a hot-swappable
+// factory, so use-repo changes the backend underneath it.
+type session struct {
+	out     io.Writer
+	repos   *hotswap.Factory
+	manager *employee.Manager
+	payroll *payroll.Engine
+}
+
+// replCommand One REPL command: parses its own arguments
+type replCommand struct {
+	usage   string
+	summary string
+	run     func(s *session, ctx context.Context, args []string) error
+}
+
+var replCommands map[string]replCommand
+
+func init() {
+	// assigned in init: help lists replCommands itself
+	replCommands = map[string]replCommand{
+		"help":     {"help", "list the commands", (*session).help},
+		"hire":     {"hire <name> <salary> [currency] [title...]", "add an employee (USD unless an ISO 4217 code follows)", (*session).hire},
+		"find":     {"find <name>", "show one employee", (*session).find},
+		"list":     {"list [prefix]", "list employees by name", (*session).list},
+		"salary":   {"salary <name> <amount> [currency]", "change an employee's salary", (*session).salary},
+		"promote":  {"promote <name> <raise> <title...>", "new title and a raise in the same currency", (*session).promote},
+		"fire":     {"fire <name>", "remove an employee", (*session).fire},
+		"restore":  {"restore <name>", "bring a removed employee back", (*session).restore},
+		"history":  {"history <name>", "every stored version (Versioned backends only)", (*session).history},
+		"use-repo": {"use-repo <backend> [dsn]", "switch storage backend: " + strings.Join(storage.Backends(), ", "), (*session).useRepo},
+		"payroll":  {"payroll run [YYYY-MM]", "run payroll for a month (default: this month)", (*session).runPayroll},
+	}
+}
+
+func runRepl(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("solid repl", flag.ContinueOnError)
+	configPath := fs.String("config", "config.json", "path to the JSON config file; its storage is the starting backend")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	logger := slog.New(slog.NewTextHandler(os.Stderr, nil))
+	cfg, err := loadConfig(*configPath, logger)
+	if err != nil {
+		return err
+	}
+	s, err := newSession(cfg.Storage, os.Stdout)
+	if err != nil {
+		return err
+	}
+	defer s.Close()
+
+	fmt.Fprintf(s.out, "solid repl - backend %s. Type help for commands, exit to quit.\n", s.repos.Active())
+	return s.loop(ctx, os.Stdin)
+}
+
+func newSession(cfg storage.Config, out io.Writer) (*session, error) {
+	repos, err := storage.Open(cfg)
+	if err != nil {
+		return nil, err
+	}
+	swap := hotswap.New(cfg.Backend, repos)
+	return &session{
+		out:   out,
+		repos: swap,
+		// the REPL prints its own results; the Manager's logging would interleave
+		manager: employee.NewManager(swap.Employees(), employee.WithLogger(slog.New(slog.DiscardHandler))),
+		payroll: payroll.New(replPayroll),
+	}, nil
+}
+
+func (s *session) Close() error { return s.repos.Close() }
+
+// loop reads commands until exit, end of input or cancellation. A failing
+// command is reported and the session carries on.
+func (s *session) loop(ctx context.Context, in io.Reader) error {
+	lines := make(chan string)
+	go func() {
+		defer close(lines)
+		sc := bufio.NewScanner(in)
+		for sc.Scan() {
+			lines <- sc.Text()
+		}
+	}()
+	for {
+		fmt.Fprintf(s.out, "%s> ", s.repos.Active())
+		var line string
+		var ok bool
+		select {
+		case <-ctx.Done():
+			fmt.Fprintln(s.out)
+			return nil
+		case line, ok = <-lines:
+		}
+		if !ok {
+			fmt.Fprintln(s.out)
+			return nil
+		}
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		if fields[0] == "exit" || fields[0] == "quit" {
+			return nil
+		}
+		if err := s.exec(ctx, fields); err != nil {
+			fmt.Fprintln(s.out, "error:", err)
+		}
+	}
+}
+
+func (s *session) exec(ctx context.Context, fields []string) error {
+	cmd, found := replCommands[fields[0]]
+	if !found {
+		return fmt.Errorf("unknown command %q - try help", fields[0])
+	}
+	return cmd.run(s, ctx, fields[1:])
+}
+
+// Exec runs one command and returns what it printed, making the session a
+// scenario.Executor.
+func (s *session) Exec(ctx context.Context, command string) (string, error) {
+	fields := strings.Fields(command)
+	if len(fields) == 0 {
+		return "", nil
+	}
+	out := s.out
+	defer func() { s.out = out }()
+	var buf strings.Builder
+	s.out = &buf
+	err := s.exec(ctx, fields)
+	return buf.String(), err
+}
+
+// errUsage makes a command print its usage line
+var errUsage = errors.New("usage")
+
+func (s *session) help(ctx context.Context, args []string) error {
+	names := make([]string, 0, len(replCommands))
+	for name := range replCommands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintf(s.out, "  %-44s %s\n", replCommands[name].usage, replCommands[name].summary)
+	}
+	fmt.Fprintf(s.out, "  %-44s %s\n", "exit", "leave the REPL")
+	return nil
+}
+
+// amount parses "<amount> [currency]" from args, returning what is left.
+// The word after the amount is its currency only if it is an ISO 4217 code:
+// in "hire Alice 3000 CEO", CEO is the title.
+func amount(args []string) (money.Money, []string, error) {
+	if len(args) == 0 {
+		return money.Money{}, nil, errUsage
+	}
+	cur, rest := money.USD, args[1:]
+	if len(rest) > 0 && money.Currency(rest[0]).Known() {
+		cur, rest = money.Currency(rest[0]), rest[1:]
+	}
+	m, err := money.Parse(args[0], cur)
+	return m, rest, err
+}
+
+func (s *session) hire(ctx context.Context, args []string) error {
+	if len(args) < 2 {
+		return usageOf("hire")
+	}
+	salary, rest, err := amount(args[1:])
+	if err != nil {
+		return err
+	}
+	emp, err := s.manager.AddEmployee(ctx, employee.Employee{Name: args[0], Title: strings.Join(rest, " "), Salary: salary})
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(s.out, "hired %s\n", describe(emp))
+	return nil
+}
+
+func (s *session) find(ctx context.Context, args []string) error {
+	if len(args) != 1 {
+		return usageOf("find")
+	}
+	emp, err := s.manager.FindEmployee(ctx, args[0])
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(s.out, describe(emp))
+	return nil
+}
+
+func (s *session) list(ctx context.Context, args []string) error {
+	filter := employee.Filter{Sort: employee.SortByName}
+	if len(args) > 0 {
+		filter.NamePrefix = args[0]
+	}
+	page := employee.Page{Limit: employee.MaxPageSize}
+	n := 0
+	for {
+		res, err := s.manager.ListEmployees(ctx, filter, page)
+		if err != nil {
+			return err
+		}
+		for _, emp := range res.Items {
+			fmt.Fprintln(s.out, " ", describe(emp))
+			n++
+		}
+		if res.NextCursor == "" {
+			break
+		}
+		page.Cursor = res.NextCursor
+	}
+	fmt.Fprintf(s.out, "%d employee(s)\n", n)
+	return nil
+}
+
+func (s *session) salary(ctx context.Context, args []string) error {
+	if len(args) < 2 {
+		return usageOf("salary")
+	}
+	salary, rest, err := amount(args[1:])
+	if err != nil {
+		return err
+	}
+	if len(rest) > 0 {
+		return fmt.Errorf("%q is not an ISO 4217 currency", rest[0])
+	}
+	emp, err := s.manager.ChangeSalary(ctx, args[0], salary)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(s.out, describe(emp))
+	return nil
+}
+
+func (s *session) promote(ctx context.Context, args []string) error {
+	if len(args) < 3 {
+		return usageOf("promote")
+	}
+	emp, err := s.manager.FindEmployee(ctx, args[0])
+	if err != nil {
+		return err
+	}
+	raise, err := money.Parse(args[1], emp.Salary.Currency())
+	if err != nil {
+		return err
+	}
+	emp, err = s.manager.Promote(ctx, args[0], strings.Join(args[2:], " "), raise)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(s.out, describe(emp))
+	return nil
+}
+
+func (s *session) fire(ctx context.Context, args []string) error {
+	if len(args) != 1 {
+		return usageOf("fire")
+	}
+	if err := s.manager.RemoveEmployee(ctx, args[0]); err != nil {
+		return err
+	}
+	fmt.Fprintf(s.out, "removed %s\n", args[0])
+	return nil
+}
+
+func (s *session) restore(ctx context.Context, args []string) error {
+	if len(args) != 1 {
+		return usageOf("restore")
+	}
+	if err := s.manager.RestoreEmployee(ctx, args[0]); err != nil {
+		return err
+	}
+	fmt.Fprintf(s.out, "restored %s\n", args[0])
+	return nil
+}
+
+func (s *session) history(ctx context.Context, args []string) error {
+	if len(args) != 1 {
+		return usageOf("history")
+	}
+	versions, err := s.manager.EmployeeHistory(ctx, args[0])
+	if errors.Is(err, errors.ErrUnsupported) {
+		return fmt.Errorf("the %s backend keeps no history", s.repos.Active())
+	}
+	if err != nil {
+		return err
+	}
+	for _, v := range versions {
+		fmt.Fprintf(s.out, "  v%d %s\n", v.Version, describe(v))
+	}
+	return nil
+}
+
+// useRepo swaps the backend under the running Manager. The new backend's
+// schema is brought up to date first, as solid migrate up would, so a new
+// SQLite file is ready to hire into. If either step fails the current
+// backend stays.
+func (s *session) useRepo(ctx context.Context, args []string) error {
+	if len(args) == 0 || len(args) > 2 {
+		return usageOf("use-repo")
+	}
+	cfg := storage.Config{Backend: args[0]}
+	if len(args) == 2 {
+		cfg.DSN = args[1]
+	}
+	if err := migrateUp(ctx, cfg); err != nil {
+		return err
+	}
+	next, err := storage.Open(cfg)
+	if err != nil {
+		return err
+	}
+	if err := s.repos.Swap(ctx, cfg.Backend, next); err != nil {
+		return err
+	}
+	fmt.Fprintf(s.out, "now using %s; the Manager didn't notice\n", cfg.Backend)
+	return nil
+}
+
+func migrateUp(ctx context.Context, cfg storage.Config) error {
+	m, err := migrate.Open(cfg)
+	if err != nil {
+		return err
+	}
+	defer m.Close()
+	_, err = m.Up(ctx)
+	return err
+}
+
+// replPayroll One simple pipeline per country, chosen by salary currency
+var replPayroll = payroll.Config{
+	"US": {
+		payroll.Pension{Rate: "0.05", Cap: money.Of(400, money.USD)},
+		payroll.IncomeTax{Brackets: []payroll.Bracket{{UpTo: money.Of(1000, money.USD), Rate: "0"}, {Rate: "0.22"}}},
+	},
+	"DE": {
+		payroll.Pension{Rate: "0.093"},
+		payroll.IncomeTax{Brackets: []payroll.Bracket{{UpTo: money.Of(1000, money.EUR), Rate: "0"}, {Rate: "0.30"}}},
+	},
+	"EG": {
+		payroll.Pension{Rate: "0.11", Cap: money.Of(1500, money.EGP)},
+		payroll.IncomeTax{Brackets: []payroll.Bracket{{UpTo: money.Of(2500, money.EGP), Rate: "0"}, {Rate: "0.20"}}},
+	},
+}
+
+func (s *session) runPayroll(ctx context.Context, args []string) error {
+	if len(args) == 0 || args[0] != "run" || len(args) > 2 {
+		return usageOf("payroll")
+	}
+	now := time.Now()
+	period := payroll.Period{Year: now.Year(), Month: now.Month()}
+	if len(args) == 2 {
+		t, err := time.Parse("2006-01", args[1])
+		if err != nil {
+			return usageOf("payroll")
+		}
+		period = payroll.Period{Year: t.Year(), Month: t.Month()}
+	}
+	staff := payroll.Staff{
+		Repo:      s.repos.Employees(),
+		CountryOf: payroll.ByCurrency(map[money.Currency]string{money.USD: "US", money.EUR: "DE", money.EGP: "EG"}),
+	}
+	run, err := s.payroll.Run(ctx, period, staff)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(s.out, "payroll %s\n", run.Period)
+	for _, slip := range run.Payslips {
+		fmt.Fprintf(s.out, "  %-12s %s  gross %s  net %s\n", slip.Name, slip.Country, slip.Gross(), slip.Net())
+	}
+	for _, e := range run.Errors {
+		fmt.Fprintf(s.out, "  %-12s failed: %v\n", e.Name, e.Err)
+	}
+	return nil
+}
+
+func usageOf(name string) error {
+	return fmt.Errorf("%w: %s", errUsage, replCommands[name].usage)
+}
+
+func describe(emp employee.Employee) string {
+	s := fmt.Sprintf("%s earns %s", emp.Name, emp.Salary)
+	if emp.Title != "" {
+		s += " as " + emp.Title
+	}
+	return s
+}
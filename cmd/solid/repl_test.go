@@ -0,0 +1,85 @@
+package main
+
+import (
+	"io"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"go-solid/scenario"
+	"go-solid/storage"
+)
+
+func newTestSession(t *testing.T) *session {
+	t.Helper()
+	s, err := newSession(storage.Config{Backend: "memory"}, io.Discard)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = s.Close() })
+	return s
+}
+
+func TestRepl_Currency(t *testing.T) {
+	s := newTestSession(t)
+	tests := []struct {
+		command, want string
+	}{
+		{"hire Alice 3000 CEO", "hired Alice earns USD 3000.00 as CEO\n"},
+		{"hire Bob 5000 EUR Engineering Manager", "hired Bob earns EUR 5000.00 as Engineering Manager\n"},
+		{"hire Yuki 400000 JPY", "hired Yuki earns JPY 400000\n"},
+		{"salary Alice 3500 USD", "Alice earns USD 3500.00 as CEO\n"},
+	}
+	for _, tt := range tests {
+		if got, err := s.Exec(t.Context(), tt.command); err != nil || got != tt.want {
+			t.Errorf("%s = %q, %v, want %q", tt.command, got, err, tt.want)
+		}
+	}
+	if _, err := s.Exec(t.Context(), "salary Alice 4000 CEO"); err == nil || !strings.Contains(err.Error(), "ISO 4217") {
+		t.Errorf("salary Alice 4000 CEO error = %v, want CEO refused as a currency", err)
+	}
+}
+
+// TestRepl_UseRepo swaps to a SQLite file that doesn't exist yet, with the
+// drivers this binary links, and hires into it.
+func TestRepl_UseRepo(t *testing.T) {
+	s := newTestSession(t)
+	if _, err := s.Exec(t.Context(), "hire Alice 3000"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.Exec(t.Context(), "use-repo sqlite "+filepath.Join(t.TempDir(), "hr.db")); err != nil {
+		t.Fatalf("use-repo sqlite error = %v", err)
+	}
+	if s.repos.Active() != "sqlite" {
+		t.Errorf("Active() = %q, want sqlite", s.repos.Active())
+	}
+	if got, err := s.Exec(t.Context(), "hire Bob 5000"); err != nil || got != "hired Bob earns USD 5000.00\n" {
+		t.Errorf("hire Bob on sqlite = %q, %v", got, err)
+	}
+	if got, _ := s.Exec(t.Context(), "list"); !strings.Contains(got, "Bob") || strings.Contains(got, "Alice") {
+		t.Errorf("list on sqlite = %q, want Bob only: Alice stayed in memory", got)
+	}
+	if _, err := s.Exec(t.Context(), "use-repo nosuch"); err == nil || s.repos.Active() != "sqlite" {
+		t.Errorf("use-repo nosuch error = %v, backend %s, want an error and sqlite kept", err, s.repos.Active())
+	}
+}
+
+// TestScenario_Examples replays the README's scenario files, which double
+// as a regression test of the REPL.
+func TestScenario_Examples(t *testing.T) {
+	t.Setenv("SOLID_TELEMETRY", "") // play reports each run
+	paths, _ := filepath.Glob("../../examples/scenarios/*.json")
+	if len(paths) == 0 {
+		t.Fatal("no scenario files found")
+	}
+	for _, path := range paths {
+		sc, err := scenario.Load(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var out strings.Builder
+		if ok, err := play(t.Context(), &out, sc, false); err != nil || !ok {
+			t.Errorf("%s: %v\n%s", filepath.Base(path), err, out.String())
+		}
+	}
+}
@@ -0,0 +1,90 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"go-solid/scenario"
+	"go-solid/storage"
+	"go-solid/telemetry"
+)
+
+// runScenario runs scenario files through fresh REPL sessions:
+//
+//	solid scenario run examples/scenarios/*.json
+func runScenario(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("solid scenario run", flag.ContinueOnError)
+	verbose := fs.Bool("v", false, "print every step's output")
+	if len(args) == 0 || args[0] != "run" {
+		return fmt.Errorf("usage: solid scenario run [-v] file...")
+	}
+	if err := fs.Parse(args[1:]); err != nil {
+		return err
+	}
+	if fs.NArg() == 0 {
+		return fmt.Errorf("no scenario files given")
+	}
+
+	failed := 0
+	for _, path := range fs.Args() {
+		sc, err := scenario.Load(path)
+		if err != nil {
+			return err
+		}
+		ok, err := play(ctx, os.Stdout, sc, *verbose)
+		if err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+		if !ok {
+			failed++
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d scenario(s) failed", failed, fs.NArg())
+	}
+	return nil
+}
+
+// play runs sc on its own backend, memory unless it names another, so
+// scenarios never see each other's data.
+func play(ctx context.Context, w io.Writer, sc scenario.Scenario, verbose bool) (bool, error) {
+	backend := sc.Backend
+	if backend == "" {
+		backend = "memory"
+	}
+	s, err := newSession(storage.Config{Backend: backend}, io.Discard)
+	if err != nil {
+		return false, err
+	}
+	defer s.Close()
+
+	fmt.Fprintf(w, "▶ %s\n", sc.Name)
+	passed := 0
+	results := scenario.Run(ctx, sc, s)
+	for _, r := range results {
+		if r.Passed() {
+			passed++
+			fmt.Fprintf(w, "  ✅ %s\n", r.Step.Run)
+		} else {
+			fmt.Fprintf(w, "  ❌ %s\n     %s\n", r.Step.Run, r.Failure)
+		}
+		if verbose && r.Output != "" {
+			fmt.Fprintf(w, "%s", indent(r.Output, "     │ "))
+		}
+	}
+	fmt.Fprintf(w, "  %d/%d steps passed\n", passed, len(results))
+	emit(ctx, telemetry.DemoRun(sc.Name, len(results), passed))
+	return passed == len(results), nil
+}
+
+func indent(s, prefix string) string {
+	var out string
+	for line := range strings.Lines(s) {
+		out += prefix + line
+	}
+	return out
+}
@@ -0,0 +1,124 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"flag"
+	"fmt"
+	"net/http"
+	"time"
+
+	"go-solid/classroom"
+	classroommem "go-solid/classroom/memory"
+	"go-solid/classroom/sqlstore"
+	"go-solid/sqldialect"
+	"go-solid/telemetry"
+)
+
+const serveUsage = "usage: solid serve classroom [-addr :8090] [-store memory|sqlite|postgres|mysql] [-dsn dsn] [-token secret] | telemetry [-addr :8091] [-o events.jsonl]"
+
+// runServe runs a long-lived server until interrupted:
+//
+//	solid serve classroom -store sqlite -dsn file:class.db -token s3cret
+//	solid serve telemetry -o workshop.jsonl
+func runServe(ctx context.Context, args []string) error {
+	if len(args) == 0 {
+		return errors.New(serveUsage)
+	}
+	switch args[0] {
+	case "classroom":
+		return serveClassroom(ctx, args[1:])
+	case "telemetry":
+		return serveTelemetry(ctx, args[1:])
+	}
+	return errors.New(serveUsage)
+}
+
+func serveClassroom(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("solid serve classroom", flag.ContinueOnError)
+	addr := fs.String("addr", ":8090", "address to listen on")
+	backend := fs.String("store", "memory", "where results are kept: memory, sqlite, postgres or mysql")
+	dsn := fs.String("dsn", "", "data source name for the SQL stores")
+	token := fs.String("token", "", "secret students must send with their results")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	store, closeStore, err := openClassroomStore(ctx, *backend, *dsn)
+	if err != nil {
+		return err
+	}
+	defer closeStore()
+
+	srv := &http.Server{Addr: *addr, Handler: classroom.NewServer(store, classroom.WithToken(*token))}
+	return listen(ctx, srv, fmt.Sprintf("🏫 classroom server on %s (%s store)", *addr, *backend))
+}
+
+// serveTelemetry collects a workshop's events, and prints how often each
+// happened when it stops. Learners opt in with
+// SOLID_TELEMETRY=http://<this host>:8091/events.
+func serveTelemetry(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("solid serve telemetry", flag.ContinueOnError)
+	addr := fs.String("addr", ":8091", "address to listen on")
+	out := fs.String("o", "", "also append the events to this file, as JSON lines")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	var sink telemetry.Sink
+	if *out != "" {
+		f, err := telemetry.NewFileSink(*out)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		sink = f
+	}
+	collector := telemetry.NewCollector(telemetry.Default, sink)
+	srv := &http.Server{Addr: *addr, Handler: collector}
+	err := listen(ctx, srv, fmt.Sprintf("📡 telemetry collector on %s, events at /events, counts at /summary", *addr))
+	for _, c := range telemetry.Summarize(telemetry.Default, collector.Events()) {
+		fmt.Println("  ", c)
+	}
+	return err
+}
+
+// listen serves srv until it fails or ctx is done, then shuts it down.
+func listen(ctx context.Context, srv *http.Server, banner string) error {
+	errc := make(chan error, 1)
+	go func() { errc <- srv.ListenAndServe() }()
+	fmt.Println(banner)
+	select {
+	case err := <-errc:
+		return err
+	case <-ctx.Done():
+	}
+	shutdown, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	return srv.Shutdown(shutdown)
+}
+
+// openClassroomStore opens the named store. As with storage.Open, the SQL
+// drivers must be linked into the binary.
+func openClassroomStore(ctx context.Context, backend, dsn string) (classroom.Store, func() error, error) {
+	dialects := map[string]sqldialect.Dialect{
+		"sqlite":   sqldialect.SQLite{},
+		"postgres": sqldialect.Postgres{},
+		"mysql":    sqldialect.MySQL{},
+	}
+	if backend == "memory" {
+		return classroommem.New(), func() error { return nil }, nil
+	}
+	dialect, ok := dialects[backend]
+	if !ok {
+		return nil, nil, fmt.Errorf("unknown classroom store %q", backend)
+	}
+	db, err := sql.Open(backend, dsn)
+	if err != nil {
+		return nil, nil, err
+	}
+	if _, err := db.ExecContext(ctx, sqlstore.Schema); err != nil {
+		db.Close()
+		return nil, nil, fmt.Errorf("create classroom schema: %w", err)
+	}
+	return sqlstore.New(db, dialect), db.Close, nil
+}
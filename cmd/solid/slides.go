@@ -0,0 +1,61 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"go-solid/slides"
+)
+
+var slidesUsage = "usage: solid slides <lesson> [-format " + strings.Join(slides.FormatNames(), "|") + "] [-o file]"
+
+// runSlides writes a lesson's slide deck, code excerpts included:
+//
+//	solid slides ocp -format=revealjs -o ocp.html
+//	solid slides ocp -format=markdown
+func runSlides(ctx context.Context, args []string) error {
+	if len(args) == 0 || strings.HasPrefix(args[0], "-") {
+		return errors.New(slidesUsage)
+	}
+	lesson := args[0]
+	fs := flag.NewFlagSet("solid slides", flag.ContinueOnError)
+	format := fs.String("format", "revealjs", "deck format: "+strings.Join(slides.FormatNames(), ", "))
+	out := fs.String("o", "", "output file (default stdout)")
+	if err := fs.Parse(args[1:]); err != nil {
+		return err
+	}
+	renderer, ok := slides.Formats[*format]
+	if !ok {
+		return fmt.Errorf("unknown format %q - %s", *format, slidesUsage)
+	}
+	provider, err := course()
+	if err != nil {
+		return err
+	}
+	deck, err := slides.Build(provider, lesson)
+	if err != nil {
+		return err
+	}
+
+	var w io.Writer = os.Stdout
+	if *out != "" {
+		f, err := os.Create(*out)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		w = f
+	}
+	if err := renderer.Render(w, deck); err != nil {
+		return err
+	}
+	if *out != "" {
+		fmt.Fprintf(os.Stderr, "🎞️  %d slides of %s written to %s\n", len(deck.Slides), deck.Title, *out)
+	}
+	return nil
+}
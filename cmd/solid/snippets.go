@@ -0,0 +1,82 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+
+	"go-solid/content"
+	"go-solid/snippets"
+)
+
+const snippetsUsage = "usage: solid snippets list|check [-C dir]"
+
+// runSnippets lists the marked regions of the code, or checks that lessons
+// and slides can still quote what they quote:
+//
+//	solid snippets list
+//	solid snippets check
+//
+// check fails on a marker left open or closed twice, and on an excerpt whose
+// file, declaration or region is gone, so running it in CI stops the text
+// drifting from the code.
+func runSnippets(ctx context.Context, args []string) error {
+	if len(args) == 0 || (args[0] != "list" && args[0] != "check") {
+		return errors.New(snippetsUsage)
+	}
+	fs := flag.NewFlagSet("solid snippets", flag.ContinueOnError)
+	dir := fs.String("C", ".", "scan the .go files under this directory")
+	if err := fs.Parse(args[1:]); err != nil {
+		return err
+	}
+	regions, scanErr := snippets.Scan(os.DirFS(*dir))
+	if args[0] == "list" {
+		for _, r := range regions {
+			fmt.Printf("✂️  %-24s %s:%d\n", r.Name, r.File, r.Line)
+		}
+		return scanErr
+	}
+
+	problems := 0
+	if scanErr != nil {
+		for _, err := range unjoin(scanErr) {
+			fmt.Println("❌", err)
+			problems++
+		}
+	}
+	provider, err := course()
+	if err != nil {
+		return err
+	}
+	lessons, err := provider.Lessons()
+	if err != nil {
+		return err
+	}
+	for _, l := range lessons {
+		if _, err := content.Expand(provider, l.Name, l.Text); err != nil {
+			fmt.Printf("❌ %s: %v\n", l.Name, err)
+			problems++
+			continue
+		}
+		fmt.Printf("✅ %s\n", l.Name)
+	}
+	if problems > 0 {
+		return fmt.Errorf("%d snippet problems", problems)
+	}
+	fmt.Printf("✅ %d marked regions, every lesson excerpt found\n", len(regions))
+	return nil
+}
+
+// unjoin returns the errors errors.Join put together, or err alone.
+func unjoin(err error) []error {
+	if j, ok := err.(interface{ Unwrap() []error }); ok {
+		var all []error
+		for _, e := range j.Unwrap() {
+			all = append(all, unjoin(e)...)
+		}
+		return all
+	}
+	return []error{err}
+}
@@ -0,0 +1,95 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"go-solid/telemetry"
+)
+
+const telemetryUsage = "usage: solid telemetry [status] | schemas"
+
+// emit sends ev where SOLID_TELEMETRY says, when the learner has opted in,
+// and otherwise only checks it against its schema. Telemetry never fails a
+// command: a destination that can't be reached is reported and ignored.
+func emit(ctx context.Context, ev telemetry.Event) {
+	if err := send(ctx, os.Getenv("SOLID_TELEMETRY"), ev); err != nil {
+		fmt.Fprintf(os.Stderr, "solid: telemetry: %v\n", err)
+	}
+}
+
+func send(ctx context.Context, dest string, ev telemetry.Event) error {
+	sink, err := telemetry.Open(dest)
+	if err != nil {
+		return err
+	}
+	if c, ok := sink.(io.Closer); ok {
+		defer c.Close()
+	}
+	var opts []telemetry.Option
+	if _, off := sink.(telemetry.Nop); !off {
+		path, err := telemetrySessionPath()
+		if err != nil {
+			return err
+		}
+		session, err := telemetry.LoadSession(path)
+		if err != nil {
+			return err
+		}
+		opts = append(opts, telemetry.WithSession(session))
+	}
+	return telemetry.NewEmitter(sink, opts...).Emit(ctx, ev)
+}
+
+// telemetrySessionPath keeps the learner's random session beside their
+// progress, so each machine counts once.
+func telemetrySessionPath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("telemetry: %w", err)
+	}
+	return filepath.Join(dir, "solid", "telemetry-session"), nil
+}
+
+// runTelemetry says whether events are sent, and where, and what they hold:
+//
+//	solid telemetry
+//	solid telemetry schemas
+//	SOLID_TELEMETRY=http://teacher:8091/events solid lesson next
+func runTelemetry(ctx context.Context, args []string) error {
+	verb := "status"
+	if len(args) > 0 {
+		verb = args[0]
+	}
+	switch verb {
+	case "status":
+		dest := os.Getenv("SOLID_TELEMETRY")
+		if dest == "" || dest == "off" {
+			fmt.Println("📡 telemetry is off; set SOLID_TELEMETRY to stdout, file:///path or a collector's URL to opt in")
+			return nil
+		}
+		sink, err := telemetry.Open(dest)
+		if err != nil {
+			return err
+		}
+		if c, ok := sink.(io.Closer); ok {
+			c.Close()
+		}
+		fmt.Printf("📡 telemetry goes to %s; solid telemetry schemas lists all it sends\n", dest)
+		return nil
+	case "schemas":
+		for _, s := range telemetry.Default.Schemas() {
+			fmt.Printf("%s  %s\n", s, s.Doc)
+			for _, f := range s.Fields {
+				fmt.Printf("   %-12s %-7s %s\n", f.Name, f.Type, f.Doc)
+			}
+		}
+		fmt.Println("\nplus the time, and a random session ID that names no one")
+		return nil
+	}
+	return errors.New(telemetryUsage)
+}
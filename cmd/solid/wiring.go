@@ -0,0 +1,52 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"path/filepath"
+
+	"go-solid/wirecheck"
+)
+
+// runVerifyWiring checks the wiring main records for the admin endpoint
+// against the code, so a stale or wrong record fails the build step
+// instead of misleading an operator:
+//
+//	solid verify-wiring
+//	solid verify-wiring ./cmd/...
+func runVerifyWiring(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("solid verify-wiring", flag.ContinueOnError)
+	dir := fs.String("C", ".", "run go list in this directory")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	patterns := fs.Args()
+	if len(patterns) == 0 {
+		patterns = []string{"./cmd/employee-api"}
+	}
+	reports, err := wirecheck.Check(ctx, *dir, patterns...)
+	if err != nil {
+		return err
+	}
+	if len(reports) == 0 {
+		fmt.Println("⚪ No admin.Wiring.Bind calls in", patterns)
+		return nil
+	}
+	problems := 0
+	for _, r := range reports {
+		fmt.Printf("🔌 %s\n", r.Package)
+		for _, b := range r.Bindings {
+			fmt.Printf("   %-28s ← %s  (%s:%d)\n", b.Interface, b.Implementation, filepath.Base(b.Pos.Filename), b.Pos.Line)
+		}
+		for _, p := range r.Problems {
+			fmt.Printf("   ❌ %s:%d: %s: %s\n", filepath.Base(p.Pos.Filename), p.Pos.Line, p.Kind, p.Message)
+		}
+		problems += len(r.Problems)
+	}
+	if problems > 0 {
+		return fmt.Errorf("%d wiring problems", problems)
+	}
+	fmt.Println("✅ Wiring verified")
+	return nil
+}
@@ -0,0 +1,335 @@
+// Package coalesce batches writes. Its Repository decorator holds Save calls
+// for a moment and hands them to the backend as one SaveAll: a hundred
+// callers saving at once cost one round trip, one transaction, one lock,
+// instead of a hundred.
+//
+// Save keeps its meaning (LSP): it returns once the employee is stored, with
+// that employee's own error - the decorator fans each result of the batch
+// back to the caller who asked. What changes is latency, which now includes
+// the wait for the batch to fill.
+//
+// So does what the backend sees in the context: decorators below this one
+// route on context values (tenant partitions, audit actors), so saves are
+// only batched with saves whose contexts carry the same ones. Each batch is
+// saved under its first caller's values, without its cancellation.
+package coalesce
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"iter"
+	"sync"
+	"time"
+
+	"go-solid/audit"
+	"go-solid/clock"
+	"go-solid/employee"
+	"go-solid/tenant"
+)
+
+// ErrClosed returned by Save after Close
+var ErrClosed = errors.New("coalesce: repository closed")
+
+// Repository Decorator coalescing Save calls into SaveAll batches. Reads and
+// the other capabilities, Update included, go straight to the backend.
+type Repository struct {
+	next      employee.Repository
+	size      int
+	wait      time.Duration
+	clock     clock.Clock
+	partition func(ctx context.Context) any
+
+	requests chan request
+	done     chan struct{}
+	mu       sync.RWMutex // held for reading while a Save hands over its request
+	closed   bool
+
+	statsMu sync.Mutex
+	stats   Stats
+}
+
+// request One Save waiting for its batch. reply is buffered: the flushing
+// goroutine never blocks on a caller that gave up.
+type request struct {
+	ctx   context.Context
+	emp   employee.Employee
+	reply chan error
+}
+
+// Stats How well saves coalesced so far
+type Stats struct {
+	Saves   int
+	Batches int
+	Largest int
+	// Waiting is the number of saves in the batch being collected
+	Waiting int
+}
+
+// Option customises a Repository created by New
+type Option func(*Repository)
+
+// WithSize flushes as soon as n saves are waiting; 100 by default.
+func WithSize(n int) Option { return func(r *Repository) { r.size = n } }
+
+// WithWait flushes d after the first save of a batch arrived, however few
+// joined it; 5ms by default. It is the latency every Save pays at most.
+func WithWait(d time.Duration) Option { return func(r *Repository) { r.wait = d } }
+
+func WithClock(c clock.Clock) Option { return func(r *Repository) { r.clock = c } }
+
+// WithPartition batches together only saves whose contexts give the same
+// key, a comparable value; by default the tenant and the audit actor
+// (Partition). A backend routing on other context values needs them in it.
+func WithPartition(key func(ctx context.Context) any) Option {
+	return func(r *Repository) { r.partition = key }
+}
+
+// partitionKey What Partition tells apart
+type partitionKey struct {
+	tenant tenant.ID
+	actor  string
+}
+
+// Partition is the default key of WithPartition: the context's tenant and
+// audit actor, the values the repository's own decorators read.
+func Partition(ctx context.Context) any {
+	id, _ := tenant.FromContext(ctx)
+	return partitionKey{tenant: id, actor: audit.ActorFrom(ctx)}
+}
+
+// New starts the goroutine that batches saves for next. Close stops it.
+func New(next employee.Repository, opts ...Option) *Repository {
+	r := &Repository{next: next, clock: clock.Real{}, partition: Partition, done: make(chan struct{})}
+	for _, opt := range opts {
+		opt(r)
+	}
+	r.size = cmp.Or(r.size, 100)
+	r.wait = cmp.Or(r.wait, 5*time.Millisecond)
+	r.requests = make(chan request, r.size)
+	go r.run()
+	return r
+}
+
+// Wrapped returns the repository the batches are saved to.
+func (r *Repository) Wrapped() any { return r.next }
+
+// Save queues emp for the next batch and waits for its result. A caller whose
+// context ends stops waiting, but a save already flushing may still happen -
+// as with any write whose answer nobody waits for. One still queued when its
+// context ends is dropped.
+func (r *Repository) Save(ctx context.Context, emp employee.Employee) error {
+	req := request{ctx: ctx, emp: emp, reply: make(chan error, 1)}
+	r.mu.RLock()
+	if r.closed {
+		r.mu.RUnlock()
+		return ErrClosed
+	}
+	select {
+	case r.requests <- req:
+	case <-ctx.Done():
+		r.mu.RUnlock()
+		return ctx.Err()
+	}
+	r.mu.RUnlock()
+	select {
+	case err := <-req.reply:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (r *Repository) GetByName(ctx context.Context, name string) (employee.Employee, error) {
+	return r.next.GetByName(ctx, name)
+}
+
+// Update goes straight to the backend's employee.Updater: a conditional
+// write has its own answer, which a batch can't give. It is not ordered with
+// saves still waiting for their batch.
+func (r *Repository) Update(ctx context.Context, emp employee.Employee, expectedVersion int) (employee.Employee, error) {
+	u, ok := r.next.(employee.Updater)
+	if !ok {
+		return employee.Employee{}, errors.ErrUnsupported
+	}
+	return u.Update(ctx, emp, expectedVersion)
+}
+
+func (r *Repository) GetByID(ctx context.Context, id employee.ID) (employee.Employee, error) {
+	return employee.GetByID(ctx, r.next, id)
+}
+
+func (r *Repository) All(ctx context.Context) iter.Seq2[employee.Employee, error] {
+	return employee.All(ctx, r.next)
+}
+
+func (r *Repository) List(ctx context.Context, filter employee.Filter, page employee.Page) (employee.PageResult, error) {
+	q, ok := r.next.(employee.QueryRepository)
+	if !ok {
+		return employee.PageResult{}, errors.ErrUnsupported
+	}
+	return q.List(ctx, filter, page)
+}
+
+// Stats returns how many saves went through in how many batches.
+func (r *Repository) Stats() Stats {
+	r.statsMu.Lock()
+	defer r.statsMu.Unlock()
+	return r.stats
+}
+
+// Close stops taking saves, flushes those already queued, and returns once
+// every caller has its answer. Later saves fail with ErrClosed.
+func (r *Repository) Close() error {
+	r.mu.Lock()
+	if !r.closed {
+		r.closed = true
+		close(r.requests) // no Save is sending: they hold mu for reading while they do
+	}
+	r.mu.Unlock()
+	<-r.done
+	return nil
+}
+
+// run collects requests into a batch until it is full, its wait is over, or
+// the repository closes.
+func (r *Repository) run() {
+	defer close(r.done)
+	var (
+		batch []request
+		timer <-chan time.Time
+	)
+	for {
+		select {
+		case req, ok := <-r.requests:
+			if !ok {
+				r.flush(batch)
+				return
+			}
+			batch = append(batch, req)
+			r.statsMu.Lock()
+			r.stats.Waiting = len(batch)
+			r.statsMu.Unlock()
+			if len(batch) == 1 {
+				timer = r.clock.After(r.wait)
+			}
+			if len(batch) < r.size {
+				continue
+			}
+		case <-timer:
+		}
+		r.flush(batch)
+		batch, timer = nil, nil
+	}
+}
+
+// flush saves batch with one SaveAll per partition and answers every
+// request in it. A request whose caller gave up before the flush is left out.
+func (r *Repository) flush(batch []request) {
+	r.statsMu.Lock()
+	r.stats.Waiting = 0
+	r.statsMu.Unlock()
+	var keys []any
+	parts := map[any][]request{}
+	for _, req := range batch {
+		if err := req.ctx.Err(); err != nil {
+			req.reply <- err
+			continue
+		}
+		key := r.partition(req.ctx)
+		if _, ok := parts[key]; !ok {
+			keys = append(keys, key)
+		}
+		parts[key] = append(parts[key], req)
+	}
+	for _, key := range keys {
+		r.save(parts[key])
+	}
+}
+
+// save stores the requests of one partition with one SaveAll.
+func (r *Repository) save(live []request) {
+	emps := func(yield func(employee.Employee) bool) {
+		for _, req := range live {
+			if !yield(req.emp) {
+				return
+			}
+		}
+	}
+	ctx, cancel := batchContext(live)
+	defer cancel()
+	errs := fanOut(len(live), employee.SaveAll(ctx, r.next, emps))
+	for i, req := range live {
+		req.reply <- errs[i]
+	}
+
+	r.statsMu.Lock()
+	r.stats.Saves += len(live)
+	r.stats.Batches++
+	r.stats.Largest = max(r.stats.Largest, len(live))
+	r.statsMu.Unlock()
+}
+
+// batchContext is the context a batch is saved with. The requests share
+// their values, so it has the first one's; no caller's cancellation fits the
+// whole batch, so it has none. When every caller has a deadline, it has the
+// latest: nobody waits longer than that.
+func batchContext(live []request) (context.Context, context.CancelFunc) {
+	ctx := context.WithoutCancel(live[0].ctx)
+	var latest time.Time
+	for _, req := range live {
+		deadline, ok := req.ctx.Deadline()
+		if !ok {
+			return ctx, func() {}
+		}
+		if deadline.After(latest) {
+			latest = deadline
+		}
+	}
+	return context.WithDeadline(ctx, latest)
+}
+
+// fanOut splits the error of a SaveAll over n employees into each one's own.
+// A *employee.BulkError names the failed ones by index; of the rest, backends
+// save in sequence order, so the first Saved were stored and any after them
+// were stopped. Any other error is everybody's.
+func fanOut(n int, err error) []error {
+	errs := make([]error, n)
+	if err == nil {
+		return errs
+	}
+	var report *employee.BulkError
+	if !errors.As(err, &report) {
+		for i := range errs {
+			errs[i] = err
+		}
+		return errs
+	}
+	failed := make([]bool, n)
+	for _, f := range report.Failed {
+		if f.Index >= 0 && f.Index < n {
+			errs[f.Index], failed[f.Index] = f.Err, true
+		}
+	}
+	saved := 0
+	for i := range errs {
+		if failed[i] {
+			continue
+		}
+		if saved < report.Saved {
+			saved++
+			continue
+		}
+		errs[i] = fmt.Errorf("coalesce: batch stopped: %w", cmp.Or(report.Stopped, error(report)))
+	}
+	return errs
+}
+
+var (
+	_ employee.Repository      = (*Repository)(nil)
+	_ employee.Updater         = (*Repository)(nil)
+	_ employee.Iterable        = (*Repository)(nil)
+	_ employee.IDRepository    = (*Repository)(nil)
+	_ employee.QueryRepository = (*Repository)(nil)
+)
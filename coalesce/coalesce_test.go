@@ -0,0 +1,308 @@
+package coalesce_test
+
+import (
+	"context"
+	"errors"
+	"iter"
+	"sync"
+	"testing"
+	"time"
+
+	"go-solid/clock"
+	"go-solid/coalesce"
+	"go-solid/employee"
+	"go-solid/employee/employeetest"
+	"go-solid/employee/memory"
+	"go-solid/tenant"
+)
+
+func TestRepository(t *testing.T) {
+	employeetest.TestRepository(t, func(t *testing.T) employee.Repository {
+		repo := coalesce.New(memory.New(), coalesce.WithWait(time.Millisecond))
+		t.Cleanup(func() { repo.Close() })
+		return repo
+	})
+}
+
+func TestRepository_SaveKeepsTheTenant(t *testing.T) {
+	tenants := map[tenant.ID]*memory.Repository{"acme": memory.New(), "globex": memory.New()}
+	repo := coalesce.New(tenant.NewEmployees(func(id tenant.ID) (employee.Repository, error) {
+		return tenants[id], nil
+	}), coalesce.WithWait(20*time.Millisecond))
+	defer repo.Close()
+
+	// both tenants save within one wait, so their saves are flushed together
+	saves := []struct {
+		tenant tenant.ID
+		name   string
+	}{{"acme", "Ali"}, {"globex", "Sara"}, {"acme", "Omar"}, {"globex", "Lina"}}
+	var wg sync.WaitGroup
+	errs := make([]error, len(saves))
+	for i, s := range saves {
+		wg.Go(func() {
+			errs[i] = repo.Save(tenant.WithTenant(t.Context(), s.tenant), employee.Employee{Name: s.name})
+		})
+	}
+	wg.Wait()
+	for i, s := range saves {
+		if errs[i] != nil {
+			t.Fatalf("Save(%s, %s) error = %v", s.tenant, s.name, errs[i])
+		}
+		if _, err := tenants[s.tenant].GetByName(t.Context(), s.name); err != nil {
+			t.Errorf("%s isn't in %s's partition: %v", s.name, s.tenant, err)
+		}
+		for other, r := range tenants {
+			if _, err := r.GetByName(t.Context(), s.name); other != s.tenant && err == nil {
+				t.Errorf("%s of %s leaked into %s's partition", s.name, s.tenant, other)
+			}
+		}
+	}
+	if got := repo.Stats(); got.Saves != 4 || got.Batches != 2 {
+		t.Errorf("Stats() = %+v, want 4 saves in 2 batches, one per tenant", got)
+	}
+}
+
+func TestRepository_SaveWithoutTenant(t *testing.T) {
+	repo := coalesce.New(tenant.NewEmployees(func(id tenant.ID) (employee.Repository, error) {
+		return memory.New(), nil
+	}))
+	defer repo.Close()
+	if err := repo.Save(t.Context(), employee.Employee{Name: "Ali"}); !errors.Is(err, tenant.ErrNoTenant) {
+		t.Errorf("Save() error = %v, want %v", err, tenant.ErrNoTenant)
+	}
+}
+
+// deadlines A backend recording the deadline of each SaveAll
+type deadlines struct {
+	employee.Repository
+	mu   sync.Mutex
+	seen []time.Time
+}
+
+func (d *deadlines) SaveAll(ctx context.Context, emps iter.Seq[employee.Employee]) error {
+	deadline, _ := ctx.Deadline()
+	d.mu.Lock()
+	d.seen = append(d.seen, deadline)
+	d.mu.Unlock()
+	return employee.SaveAll(ctx, d.Repository, emps)
+}
+
+func TestRepository_SaveKeepsTheLatestDeadline(t *testing.T) {
+	backend := &deadlines{Repository: memory.New()}
+	repo := coalesce.New(backend, coalesce.WithSize(2), coalesce.WithWait(time.Minute))
+	defer repo.Close()
+
+	latest := time.Now().Add(time.Hour)
+	var wg sync.WaitGroup
+	for i, d := range []time.Time{latest.Add(-time.Minute), latest} {
+		wg.Go(func() {
+			ctx, cancel := context.WithDeadline(t.Context(), d)
+			defer cancel()
+			if err := repo.Save(ctx, employee.Employee{Name: []string{"Ali", "Sara"}[i]}); err != nil {
+				t.Errorf("Save() error = %v", err)
+			}
+		})
+	}
+	wg.Wait()
+	if len(backend.seen) != 1 || !backend.seen[0].Equal(latest) {
+		t.Errorf("SaveAll deadlines = %v, want one batch with %v", backend.seen, latest)
+	}
+}
+
+var errRejected = errors.New("rejected")
+
+// picky A backend whose bulk saves reject Mallory and, after stopAfter
+// employees, stop
+type picky struct {
+	employee.Repository
+	stopAfter int
+}
+
+func (p *picky) SaveAll(ctx context.Context, emps iter.Seq[employee.Employee]) error {
+	report := &employee.BulkError{}
+	i := 0
+	for emp := range emps {
+		if p.stopAfter > 0 && i == p.stopAfter {
+			report.Stopped = context.DeadlineExceeded
+			break
+		}
+		if emp.Name == "Mallory" {
+			report.Add(i, emp, errRejected)
+		} else {
+			report.Add(i, emp, p.Repository.Save(ctx, emp))
+		}
+		i++
+	}
+	return report.Err()
+}
+
+// saveTogether saves names in one batch, one goroutine each.
+func saveTogether(t *testing.T, backend employee.Repository, names []string) map[string]error {
+	t.Helper()
+	repo := coalesce.New(backend, coalesce.WithSize(len(names)), coalesce.WithWait(time.Minute))
+	defer repo.Close()
+	var (
+		wg   sync.WaitGroup
+		mu   sync.Mutex
+		errs = map[string]error{}
+	)
+	for _, name := range names {
+		wg.Go(func() {
+			err := repo.Save(t.Context(), employee.Employee{Name: name})
+			mu.Lock()
+			errs[name] = err
+			mu.Unlock()
+		})
+	}
+	wg.Wait()
+	if got := repo.Stats(); got.Batches != 1 {
+		t.Fatalf("Stats() = %+v, want the saves in one batch", got)
+	}
+	return errs
+}
+
+func TestRepository_SaveGetsItsOwnFailure(t *testing.T) {
+	backend := memory.New()
+	errs := saveTogether(t, &picky{Repository: backend}, []string{"Ali", "Mallory", "Sara", "Omar"})
+	for name, err := range errs {
+		if name == "Mallory" {
+			if !errors.Is(err, errRejected) {
+				t.Errorf("Save(Mallory) error = %v, want %v", err, errRejected)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Save(%s) error = %v, want nil: only Mallory was rejected", name, err)
+		}
+		if _, err := backend.GetByName(t.Context(), name); err != nil {
+			t.Errorf("%s wasn't saved: %v", name, err)
+		}
+	}
+}
+
+func TestRepository_SaveAfterTheBatchStopped(t *testing.T) {
+	backend := memory.New()
+	errs := saveTogether(t, &picky{Repository: backend, stopAfter: 2}, []string{"Ali", "Sara", "Omar", "Lina"})
+	saved := 0
+	for name, err := range errs {
+		_, lookup := backend.GetByName(t.Context(), name)
+		stored := lookup == nil
+		switch {
+		case err == nil && stored:
+			saved++
+		case errors.Is(err, context.DeadlineExceeded) && !stored:
+		default:
+			t.Errorf("Save(%s) error = %v, stored %v; want saved, or told the batch stopped", name, err, stored)
+		}
+	}
+	if saved != 2 {
+		t.Errorf("%d saves succeeded, want the 2 the backend stored", saved)
+	}
+}
+
+// waiting polls until n saves wait for their batch.
+func waiting(t *testing.T, repo *coalesce.Repository, n int) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for repo.Stats().Waiting != n {
+		if time.Now().After(deadline) {
+			t.Fatalf("Stats() = %+v, want %d saves waiting", repo.Stats(), n)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestRepository_FlushesAfterTheWait(t *testing.T) {
+	clk := clock.NewFake(time.Date(2026, time.March, 2, 9, 0, 0, 0, time.UTC))
+	backend := memory.New()
+	repo := coalesce.New(backend, coalesce.WithClock(clk), coalesce.WithWait(5*time.Millisecond))
+	defer repo.Close()
+
+	done := make(chan error)
+	go func() { done <- repo.Save(t.Context(), employee.Employee{Name: "Ali"}) }()
+	clk.BlockUntil(1)
+	clk.Advance(4 * time.Millisecond)
+	select {
+	case err := <-done:
+		t.Fatalf("Save() = %v before the wait was over", err)
+	case <-time.After(20 * time.Millisecond):
+	}
+	clk.Advance(time.Millisecond)
+	if err := <-done; err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	if got := repo.Stats(); got != (coalesce.Stats{Saves: 1, Batches: 1, Largest: 1}) {
+		t.Errorf("Stats() = %+v, want one batch of one", got)
+	}
+}
+
+func TestRepository_FlushesWhenFull(t *testing.T) {
+	repo := coalesce.New(memory.New(), coalesce.WithSize(3), coalesce.WithWait(time.Hour))
+	defer repo.Close()
+	var wg sync.WaitGroup
+	for _, name := range []string{"Ali", "Sara", "Omar"} {
+		wg.Go(func() {
+			if err := repo.Save(t.Context(), employee.Employee{Name: name}); err != nil {
+				t.Errorf("Save(%s) error = %v", name, err)
+			}
+		})
+	}
+	wg.Wait() // long before the hour is up
+	if got := repo.Stats(); got.Batches != 1 || got.Largest != 3 {
+		t.Errorf("Stats() = %+v, want one batch of three", got)
+	}
+}
+
+func TestRepository_CloseFlushesWhatIsQueued(t *testing.T) {
+	backend := memory.New()
+	repo := coalesce.New(backend, coalesce.WithWait(time.Hour))
+	names := []string{"Ali", "Sara", "Omar"}
+	errs := make(chan error, len(names))
+	for _, name := range names {
+		go func() { errs <- repo.Save(t.Context(), employee.Employee{Name: name}) }()
+	}
+	waiting(t, repo, len(names))
+
+	if err := repo.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	// the wait is an hour: only Close can have flushed them
+	for range names {
+		if err := <-errs; err != nil {
+			t.Errorf("Save() error = %v, want it flushed by Close", err)
+		}
+	}
+	for _, name := range names {
+		if _, err := backend.GetByName(t.Context(), name); err != nil {
+			t.Errorf("%s wasn't saved: %v", name, err)
+		}
+	}
+
+	if err := repo.Save(t.Context(), employee.Employee{Name: "Lina"}); !errors.Is(err, coalesce.ErrClosed) {
+		t.Errorf("Save() after Close() error = %v, want %v", err, coalesce.ErrClosed)
+	}
+	if err := repo.Close(); err != nil {
+		t.Errorf("Close() twice error = %v", err)
+	}
+}
+
+func TestRepository_SaveGivenUpIsDropped(t *testing.T) {
+	backend := memory.New()
+	repo := coalesce.New(backend, coalesce.WithWait(time.Hour))
+	ctx, cancel := context.WithCancel(t.Context())
+	done := make(chan error)
+	go func() { done <- repo.Save(ctx, employee.Employee{Name: "Ali"}) }()
+	waiting(t, repo, 1)
+	cancel()
+	if err := <-done; !errors.Is(err, context.Canceled) {
+		t.Errorf("Save() error = %v, want %v", err, context.Canceled)
+	}
+
+	repo.Close()
+	if _, err := backend.GetByName(t.Context(), "Ali"); !errors.Is(err, employee.ErrNotFound) {
+		t.Errorf("GetByName(Ali) error = %v, want the dropped save not stored", err)
+	}
+	if got := repo.Stats(); got.Saves != 0 || got.Batches != 0 {
+		t.Errorf("Stats() = %+v, want no batch for a save nobody waits for", got)
+	}
+}
@@ -0,0 +1,81 @@
+// Package codec turns streams of values into bytes in a named format.
+//
+// Code that writes "an export" depends on Codec, not on encoding/json or
+// encoding/csv; a new format is a new implementation registered by name, with
+// no change to the code producing the values (OCP).
+package codec
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+)
+
+// Codec A named output format
+type Codec interface {
+	Name() string
+	ContentType() string
+	// NewEncoder starts a new stream on w.
+	NewEncoder(w io.Writer) Encoder
+}
+
+// Encoder Writes one value at a time. Encode must have written the value to
+// the underlying writer when it returns, so callers can measure or cut the
+// output between values.
+type Encoder interface {
+	Encode(v any) error
+	// Close writes whatever ends the stream (a closing bracket, ...).
+	Close() error
+}
+
+// Continuer Optional capability - formats whose output can be carried on by a
+// fresh encoder, with no state shared across the cut other than what it
+// needs up front (a CSV header is not repeated). Streams in such formats can
+// be resumed after an interruption.
+type Continuer interface {
+	ContinueEncoder(w io.Writer) Encoder
+}
+
+var (
+	mu     sync.RWMutex
+	codecs = map[string]Codec{}
+)
+
+// Register makes a codec available to Lookup under its name.
+func Register(c Codec) {
+	mu.Lock()
+	defer mu.Unlock()
+	if _, dup := codecs[c.Name()]; dup {
+		panic("codec: Register called twice for " + c.Name())
+	}
+	codecs[c.Name()] = c
+}
+
+// Names lists the registered codecs.
+func Names() []string {
+	mu.RLock()
+	defer mu.RUnlock()
+	names := make([]string, 0, len(codecs))
+	for name := range codecs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func Lookup(name string) (Codec, error) {
+	mu.RLock()
+	c, ok := codecs[name]
+	mu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("codec: unknown format %q (registered: %v)", name, Names())
+	}
+	return c, nil
+}
+
+func init() {
+	Register(JSONL{})
+	Register(JSON{})
+	Register(CSV{})
+}
@@ -0,0 +1,103 @@
+package codec
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// JSONL One JSON document per line
+type JSONL struct{}
+
+func (JSONL) Name() string                          { return "jsonl" }
+func (JSONL) ContentType() string                   { return "application/x-ndjson" }
+func (JSONL) NewEncoder(w io.Writer) Encoder        { return jsonlEncoder{json.NewEncoder(w)} }
+func (c JSONL) ContinueEncoder(w io.Writer) Encoder { return c.NewEncoder(w) }
+
+type jsonlEncoder struct{ enc *json.Encoder }
+
+func (e jsonlEncoder) Encode(v any) error { return e.enc.Encode(v) }
+func (e jsonlEncoder) Close() error       { return nil }
+
+// JSON A single JSON array. The closing bracket depends on everything before
+// it, so JSON is not a Continuer.
+type JSON struct{}
+
+func (JSON) Name() string                   { return "json" }
+func (JSON) ContentType() string            { return "application/json" }
+func (JSON) NewEncoder(w io.Writer) Encoder { return &jsonEncoder{w: w} }
+
+type jsonEncoder struct {
+	w     io.Writer
+	count int
+}
+
+func (e *jsonEncoder) Encode(v any) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	sep := ",\n"
+	if e.count == 0 {
+		sep = "[\n"
+	}
+	e.count++
+	_, err = fmt.Fprintf(e.w, "%s%s", sep, b)
+	return err
+}
+
+func (e *jsonEncoder) Close() error {
+	end := "\n]\n"
+	if e.count == 0 {
+		end = "[]\n"
+	}
+	_, err := io.WriteString(e.w, end)
+	return err
+}
+
+// CSVRecord What a value must implement to be written by the CSV codec
+type CSVRecord interface {
+	CSVHeader() []string
+	CSVRecord() []string
+}
+
+// CSV Comma-separated values with a header row taken from the first value
+type CSV struct{}
+
+func (CSV) Name() string                   { return "csv" }
+func (CSV) ContentType() string            { return "text/csv" }
+func (CSV) NewEncoder(w io.Writer) Encoder { return &csvEncoder{w: csv.NewWriter(w)} }
+func (CSV) ContinueEncoder(w io.Writer) Encoder {
+	return &csvEncoder{w: csv.NewWriter(w), headerDone: true}
+}
+
+type csvEncoder struct {
+	w          *csv.Writer
+	headerDone bool
+}
+
+func (e *csvEncoder) Encode(v any) error {
+	rec, ok := v.(CSVRecord)
+	if !ok {
+		return fmt.Errorf("codec: csv cannot encode %T: it does not implement CSVRecord", v)
+	}
+	if !e.headerDone {
+		if err := e.w.Write(rec.CSVHeader()); err != nil {
+			return err
+		}
+		e.headerDone = true
+	}
+	if err := e.w.Write(rec.CSVRecord()); err != nil {
+		return err
+	}
+	e.w.Flush()
+	return e.w.Error()
+}
+
+func (e *csvEncoder) Close() error { return nil }
+
+var (
+	_ Continuer = JSONL{}
+	_ Continuer = CSV{}
+)
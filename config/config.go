@@ -0,0 +1,123 @@
+// Package config loads the reference application's JSON configuration and
+// watches the file for changes.
+package config
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"go-solid/chaos"
+	"go-solid/clock"
+	"go-solid/storage"
+)
+
+type Config struct {
+	Addr        string            `json:"addr"`
+	Storage     storage.Config    `json:"storage"`
+	Idempotency IdempotencyConfig `json:"idempotency"`
+	Admin       AdminConfig       `json:"admin"`
+	Live        LiveConfig        `json:"live"`
+	// Chaos faults injected into the employee repository; off by default
+	Chaos chaos.Config `json:"chaos"`
+}
+
+// IdempotencyConfig Where Idempotency-Key responses are kept: in process
+// memory unless a Redis address is given (needed with several instances)
+type IdempotencyConfig struct {
+	Redis string `json:"redis,omitempty"`
+}
+
+// AdminConfig Where the admin endpoints listen; none are served without an
+// address. They change how the application behaves, so keep them off the
+// public interface, e.g. on "127.0.0.1:8081".
+type AdminConfig struct {
+	Addr string `json:"addr,omitempty"`
+}
+
+// LiveConfig Origins of pages, besides the API's own, whose scripts may
+// open the live event feed, e.g. "https://hr.example.com"
+type LiveConfig struct {
+	Origins []string `json:"origins,omitempty"`
+}
+
+// Default is used for any field the file leaves empty
+var Default = Config{
+	Addr:    ":8080",
+	Storage: storage.Config{Backend: "memory"},
+}
+
+// Load reads and parses the file at path.
+func Load(path string) (Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Config{}, fmt.Errorf("config: %w", err)
+	}
+	cfg := Default
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return Config{}, fmt.Errorf("config: parse %s: %w", path, err)
+	}
+	if cfg.Storage.Backend == "" {
+		return Config{}, fmt.Errorf("config: %s: storage.backend must not be empty", path)
+	}
+	return cfg, nil
+}
+
+// Watcher polls a config file and reports every successfully parsed change.
+// Polling keeps it dependency-free and portable; a broken edit is reported
+// through OnError and the previous configuration stays in effect.
+type Watcher struct {
+	Path     string
+	Interval time.Duration
+	Clock    clock.Clock
+	OnChange func(Config)
+	OnError  func(error)
+
+	modTime time.Time
+	size    int64
+}
+
+// Run polls until ctx is cancelled. The file's state when Run starts is
+// treated as already applied.
+func (w *Watcher) Run(ctx context.Context) error {
+	if info, err := os.Stat(w.Path); err == nil {
+		w.modTime, w.size = info.ModTime(), info.Size()
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-w.Clock.After(w.Interval):
+		}
+		w.poll()
+	}
+}
+
+func (w *Watcher) poll() {
+	info, err := os.Stat(w.Path)
+	if err != nil {
+		w.report(fmt.Errorf("config: %w", err))
+		return
+	}
+	if info.ModTime().Equal(w.modTime) && info.Size() == w.size {
+		return
+	}
+	w.modTime, w.size = info.ModTime(), info.Size()
+
+	cfg, err := Load(w.Path)
+	if err != nil {
+		w.report(err)
+		return
+	}
+	if w.OnChange != nil {
+		w.OnChange(cfg)
+	}
+}
+
+func (w *Watcher) report(err error) {
+	if w.OnError != nil {
+		w.OnError(err)
+	}
+}
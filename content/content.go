@@ -0,0 +1,198 @@
+// Package content serves the course's words: the text of each lesson, its
+// quiz bank and its diagrams.
+//
+// Commands that teach - solid lesson, solid quiz - ask a Provider for them
+// instead of carrying strings of their own (SRP: wording changes in one
+// place). The Provider doesn't say where content lives. Embedded reads what
+// was compiled into the binary; Dir reads a directory, so an author sees an
+// edit without rebuilding; Bundle reads a workshop bundle, so a room without
+// internet gets the course it was packed with.
+package content
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"strings"
+
+	"go-solid/bundle"
+	"go-solid/lesson"
+	"go-solid/lessons"
+)
+
+// Lesson A lesson's text
+type Lesson struct {
+	Name  string // as in solid lesson start, e.g. "ocp"
+	Title string // the first heading of the text
+	Text  string // markdown
+}
+
+// Question One multiple-choice question of a quiz bank
+type Question struct {
+	Prompt      string   `json:"prompt"`
+	Choices     []string `json:"choices"`
+	Answer      int      `json:"answer"` // index into Choices
+	Explanation string   `json:"explanation"`
+}
+
+// Diagram A drawing of a lesson's types, in plain text so a terminal can
+// show it
+type Diagram struct {
+	Name string // file name without number or extension: "1-before.txt" is "before"
+	Text string
+}
+
+// Provider Abstraction - where lesson texts, quiz banks and diagrams come from
+type Provider interface {
+	// Lessons returns every lesson, in course order.
+	Lessons() ([]Lesson, error)
+	Lesson(name string) (Lesson, error)
+	Quiz(lesson string) ([]Question, error)
+	// Diagrams returns a lesson's diagrams in order; none is not an error.
+	Diagrams(lesson string) ([]Diagram, error)
+	// Code returns a file of one of the lesson's checkpoints, such as
+	// "01-switch-on-role/main.go", for excerpts to quote.
+	Code(lesson, file string) ([]byte, error)
+}
+
+const (
+	// TextFile A lesson's text; its first line is the title
+	TextFile = "LESSON.md"
+	// QuizFile A lesson's quiz bank: {"questions": [...]}
+	QuizFile = "quiz.json"
+	// DiagramDir Holds a lesson's diagrams, one .txt file each, numbered in
+	// the order they are shown
+	DiagramDir = "diagrams"
+)
+
+// ErrNoContent returned when a lesson has no text, no quiz or no such file
+var ErrNoContent = errors.New("no such content")
+
+// FS Provider over a file system laid out like lessons.FS: one numbered
+// directory per lesson, next to the checkpoints lesson.FSStore reads
+type FS struct {
+	FS fs.FS
+}
+
+// Embedded returns the content compiled into the binary.
+func Embedded() FS { return FS{FS: lessons.FS} }
+
+// Dir returns the content of a directory laid out like lessons/. Files are
+// read on every call.
+func Dir(path string) FS { return FS{FS: os.DirFS(path)} }
+
+// Bundle returns the content of a workshop bundle, archived or unpacked, as
+// solid bundle wrote it. An archive is checked against its manifest first.
+func Bundle(path string) (FS, error) {
+	fsys, m, err := bundle.Load(path)
+	if err != nil {
+		return FS{}, err
+	}
+	if !m.Has(bundle.ContentDir) {
+		return FS{}, fmt.Errorf("%w: bundle %s has no %s", ErrNoContent, m.Name, bundle.ContentDir)
+	}
+	sub, err := fs.Sub(fsys, bundle.ContentDir)
+	return FS{FS: sub}, err
+}
+
+func (p FS) Lessons() ([]Lesson, error) {
+	names, err := p.store().Lessons()
+	if err != nil {
+		return nil, err
+	}
+	all := make([]Lesson, 0, len(names))
+	for _, name := range names {
+		l, err := p.Lesson(name)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, l)
+	}
+	return all, nil
+}
+
+func (p FS) Lesson(name string) (Lesson, error) {
+	data, err := p.read(name, TextFile)
+	if err != nil {
+		return Lesson{}, err
+	}
+	first, _, _ := strings.Cut(string(data), "\n")
+	return Lesson{Name: name, Title: strings.TrimSpace(strings.TrimLeft(first, "# ")), Text: string(data)}, nil
+}
+
+// Quiz reads a lesson's quiz bank. A bank that doesn't parse, or has a
+// question whose answer isn't one of its choices, is an error.
+func (p FS) Quiz(lesson string) ([]Question, error) {
+	data, err := p.read(lesson, QuizFile)
+	if err != nil {
+		return nil, err
+	}
+	var bank struct {
+		Questions []Question `json:"questions"`
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&bank); err != nil {
+		return nil, fmt.Errorf("%s/%s: %w", lesson, QuizFile, err)
+	}
+	for i, q := range bank.Questions {
+		if q.Prompt == "" || len(q.Choices) < 2 || q.Answer < 0 || q.Answer >= len(q.Choices) {
+			return nil, fmt.Errorf("%s/%s: question %d needs a prompt, two choices or more, and an answer among them", lesson, QuizFile, i+1)
+		}
+	}
+	return bank.Questions, nil
+}
+
+func (p FS) Diagrams(lesson string) ([]Diagram, error) {
+	dir, err := p.store().LessonDir(lesson)
+	if err != nil {
+		return nil, err
+	}
+	entries, err := fs.ReadDir(p.FS, path.Join(dir, DiagramDir))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var diagrams []Diagram
+	for _, e := range entries { // ReadDir sorts by file name
+		name, ok := strings.CutSuffix(e.Name(), ".txt")
+		if !ok || e.IsDir() {
+			continue
+		}
+		data, err := fs.ReadFile(p.FS, path.Join(dir, DiagramDir, e.Name()))
+		if err != nil {
+			return nil, err
+		}
+		if num, rest, ok := strings.Cut(name, "-"); ok && strings.Trim(num, "0123456789") == "" {
+			name = rest
+		}
+		diagrams = append(diagrams, Diagram{Name: name, Text: string(data)})
+	}
+	return diagrams, nil
+}
+
+func (p FS) Code(lesson, file string) ([]byte, error) { return p.read(lesson, file) }
+
+// read returns file from lesson's directory. Lessons are found the way
+// solid lesson finds them, so both always agree on the names.
+func (p FS) read(lesson, file string) ([]byte, error) {
+	dir, err := p.store().LessonDir(lesson)
+	if err != nil {
+		return nil, err
+	}
+	data, err := fs.ReadFile(p.FS, path.Join(dir, file))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("%w: %s has no %s", ErrNoContent, lesson, file)
+	}
+	return data, err
+}
+
+func (p FS) store() lesson.FSStore { return lesson.FSStore{FS: p.FS} }
+
+var _ Provider = FS{}
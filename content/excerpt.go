@@ -0,0 +1,55 @@
+package content
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"go-solid/snippets"
+)
+
+// Excerpts are how lessons and slides show code without copying it. A fenced
+// block naming a checkpoint file and what to take from it is filled in with
+// the code as it is now, so the text can't drift from the program:
+//
+//	```go 02-role-interface/main.go#role,employee.getSalary
+//	```
+//
+// After the # comes a snippets selector: a comma-separated list of
+// declarations - a type, a function, or a method as type.method - marked
+// regions such as snippet:special-case, or line ranges such as L11-18.
+// Declarations keep their doc comments. The block's own lines are replaced.
+
+// ErrExcerpt returned when an excerpt names a file or piece of code that isn't
+// there
+var ErrExcerpt = errors.New("bad excerpt")
+
+var excerptFence = regexp.MustCompile("^```(\\w+) +([^\\s#]+)#(\\S+)\\s*$")
+
+// Expand fills in every excerpt of a lesson's markdown from the lesson's code.
+func Expand(p Provider, lesson, markdown string) (string, error) {
+	var out strings.Builder
+	lines := bufio.NewScanner(strings.NewReader(markdown))
+	for lines.Scan() {
+		m := excerptFence.FindStringSubmatch(lines.Text())
+		if m == nil {
+			out.WriteString(lines.Text() + "\n")
+			continue
+		}
+		for lines.Scan() && !strings.HasPrefix(lines.Text(), "```") {
+			// whatever was between the fences is replaced
+		}
+		src, err := p.Code(lesson, m[2])
+		if err != nil {
+			return "", fmt.Errorf("%w: %s: %w", ErrExcerpt, m[2], err)
+		}
+		code, err := snippets.Select(src, m[3])
+		if err != nil {
+			return "", fmt.Errorf("%w: %s#%s: %w", ErrExcerpt, m[2], m[3], err)
+		}
+		fmt.Fprintf(&out, "```%s\n%s\n```\n", m[1], code)
+	}
+	return out.String(), lines.Err()
+}
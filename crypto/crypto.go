@@ -0,0 +1,76 @@
+// Package crypto encrypts sensitive employee fields at rest.
+//
+// A FieldEncrypter turns plaintext into ciphertext and back; AESGCM does it
+// with a local key, Envelope with data keys issued by a key management
+// service. Repository is a decorator that seals salary and email before
+// they reach any employee.Repository and opens them again on the way out,
+// so neither the Manager nor the storage backends change to get encryption.
+package crypto
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"errors"
+	"fmt"
+)
+
+// FieldEncrypter Abstraction - authenticated encryption of one field value.
+// aad (additional authenticated data) is not encrypted but must match on
+// Decrypt, which binds a ciphertext to its record: a salary copied onto
+// another employee fails to decrypt instead of being accepted.
+type FieldEncrypter interface {
+	Encrypt(ctx context.Context, plaintext, aad []byte) ([]byte, error)
+	Decrypt(ctx context.Context, ciphertext, aad []byte) ([]byte, error)
+}
+
+// ErrDecrypt returned when a ciphertext was tampered with, belongs to another
+// record or was encrypted under another key
+var ErrDecrypt = errors.New("crypto: message authentication failed")
+
+// AESGCM Low-level module - AES-256-GCM with one local key. A random nonce is
+// prepended to every ciphertext.
+type AESGCM struct {
+	aead cipher.AEAD
+}
+
+// NewAESGCM accepts a 16, 24 or 32 byte key (AES-128, -192, -256).
+func NewAESGCM(key []byte) (*AESGCM, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("crypto: %w", err)
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("crypto: %w", err)
+	}
+	return &AESGCM{aead: aead}, nil
+}
+
+// NewKey returns a random 32 byte key.
+func NewKey() []byte {
+	key := make([]byte, 32)
+	rand.Read(key)
+	return key
+}
+
+func (a *AESGCM) Encrypt(ctx context.Context, plaintext, aad []byte) ([]byte, error) {
+	nonce := make([]byte, a.aead.NonceSize(), a.aead.NonceSize()+len(plaintext)+a.aead.Overhead())
+	rand.Read(nonce)
+	return a.aead.Seal(nonce, nonce, plaintext, aad), nil
+}
+
+func (a *AESGCM) Decrypt(ctx context.Context, ciphertext, aad []byte) ([]byte, error) {
+	n := a.aead.NonceSize()
+	if len(ciphertext) < n {
+		return nil, ErrDecrypt
+	}
+	plaintext, err := a.aead.Open(nil, ciphertext[:n], ciphertext[n:], aad)
+	if err != nil {
+		return nil, ErrDecrypt
+	}
+	return plaintext, nil
+}
+
+var _ FieldEncrypter = (*AESGCM)(nil)
@@ -0,0 +1,329 @@
+package crypto
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"iter"
+	"strings"
+
+	"go-solid/employee"
+	"go-solid/id"
+	"go-solid/money"
+	"go-solid/outbox"
+	"go-solid/spec"
+)
+
+// Repository Decorator encrypting salary and email before they reach the
+// wrapped employee.Repository. The stored copy has a zero Salary and, in
+// place of the Email, the ciphertext of both behind a "sealed:" prefix; reads
+// decrypt it again. The backend needs no column of its own for it, only an
+// email column wide enough. Employees stored before encryption was switched
+// on have a plain Email and are returned as they are, and are sealed on
+// their next save.
+//
+// The backend can no longer see salaries, so it can't filter or sort by
+// them: List refuses salary filters, and Matching evaluates specifications
+// here, after decrypting every employee. Events (outbox payloads, audit
+// records) still carry salaries and need their own protection.
+//
+// The ID is sealed with the employee, so an employee saved without one is
+// given its ID here, before sealing, rather than by the backend: the ID of
+// the employee stored under its name, or a new one.
+type Repository struct {
+	next employee.Repository
+	enc  FieldEncrypter
+	ids  id.Generator
+}
+
+// Option customises a Repository created by NewRepository
+type Option func(*Repository)
+
+// WithIDs gives new employees saved without an ID one from g; id.UUIDv7 by
+// default.
+func WithIDs(g id.Generator) Option { return func(r *Repository) { r.ids = g } }
+
+func NewRepository(next employee.Repository, enc FieldEncrypter, opts ...Option) *Repository {
+	r := &Repository{next: next, enc: enc, ids: id.UUIDv7{}}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
+}
+
+// Wrapped returns the repository the ciphertext is stored in.
+func (r *Repository) Wrapped() any { return r.next }
+
+// sealedPrefix marks an Email holding ciphertext. No address starts with it:
+// a colon is not allowed in the unquoted local part.
+const sealedPrefix = "sealed:"
+
+// sensitive is what gets encrypted, as one value
+type sensitive struct {
+	Salary money.Money `json:"salary"`
+	Email  string      `json:"email,omitempty"`
+}
+
+// identify gives emp the ID it is stored under when it has none, as the
+// backend would: the ID of the employee with its name, or a new one.
+func (r *Repository) identify(ctx context.Context, emp *employee.Employee) error {
+	if emp.ID != "" {
+		return nil
+	}
+	stored, err := r.next.GetByName(ctx, emp.Name)
+	switch {
+	case err == nil:
+		emp.ID = stored.ID
+	case errors.Is(err, employee.ErrNotFound):
+		emp.ID = employee.ID(r.ids.NewID())
+	default:
+		return fmt.Errorf("seal %q: %w", emp.Name, err)
+	}
+	return nil
+}
+
+// seal returns the copy of emp that is stored. The employee ID is the
+// additional data, so a sealed value only opens on its own employee; one
+// without an ID is identified first, or it would be sealed under an ID it
+// is never stored with.
+func (r *Repository) seal(ctx context.Context, emp employee.Employee) (employee.Employee, error) {
+	if err := r.identify(ctx, &emp); err != nil {
+		return employee.Employee{}, err
+	}
+	plain, err := json.Marshal(sensitive{Salary: emp.Salary, Email: emp.Email})
+	if err != nil {
+		return employee.Employee{}, err
+	}
+	ct, err := r.enc.Encrypt(ctx, plain, []byte(emp.ID))
+	if err != nil {
+		return employee.Employee{}, fmt.Errorf("seal %q: %w", emp.Name, err)
+	}
+	emp.Salary, emp.Email = money.Money{}, sealedPrefix+base64.StdEncoding.EncodeToString(ct)
+	return emp, nil
+}
+
+func (r *Repository) open(ctx context.Context, emp employee.Employee) (employee.Employee, error) {
+	sealed, ok := strings.CutPrefix(emp.Email, sealedPrefix)
+	if !ok {
+		return emp, nil
+	}
+	ct, err := base64.StdEncoding.DecodeString(sealed)
+	if err != nil {
+		return employee.Employee{}, fmt.Errorf("open %q: %w", emp.Name, ErrDecrypt)
+	}
+	plain, err := r.enc.Decrypt(ctx, ct, []byte(emp.ID))
+	if err != nil {
+		return employee.Employee{}, fmt.Errorf("open %q: %w", emp.Name, err)
+	}
+	var s sensitive
+	if err := json.Unmarshal(plain, &s); err != nil {
+		return employee.Employee{}, fmt.Errorf("open %q: %w", emp.Name, err)
+	}
+	emp.Salary, emp.Email = s.Salary, s.Email
+	return emp, nil
+}
+
+func (r *Repository) openAll(ctx context.Context, emps []employee.Employee) ([]employee.Employee, error) {
+	for i, emp := range emps {
+		opened, err := r.open(ctx, emp)
+		if err != nil {
+			return nil, err
+		}
+		emps[i] = opened
+	}
+	return emps, nil
+}
+
+func (r *Repository) Save(ctx context.Context, emp employee.Employee) error {
+	sealed, err := r.seal(ctx, emp)
+	if err != nil {
+		return err
+	}
+	return r.next.Save(ctx, sealed)
+}
+
+func (r *Repository) GetByName(ctx context.Context, name string) (employee.Employee, error) {
+	emp, err := r.next.GetByName(ctx, name)
+	if err != nil {
+		return employee.Employee{}, err
+	}
+	return r.open(ctx, emp)
+}
+
+func (r *Repository) GetByID(ctx context.Context, id employee.ID) (employee.Employee, error) {
+	emp, err := employee.GetByID(ctx, r.next, id)
+	if err != nil {
+		return employee.Employee{}, err
+	}
+	return r.open(ctx, emp)
+}
+
+// All opens every employee of the backend's stream; one that can't be opened
+// ends it.
+func (r *Repository) All(ctx context.Context) iter.Seq2[employee.Employee, error] {
+	return func(yield func(employee.Employee, error) bool) {
+		for emp, err := range employee.All(ctx, r.next) {
+			if err == nil {
+				emp, err = r.open(ctx, emp)
+			}
+			if !yield(emp, err) || err != nil {
+				return
+			}
+		}
+	}
+}
+
+// SaveAll seals the sequence as the backend consumes it; an employee that
+// can't be sealed stops the batch.
+func (r *Repository) SaveAll(ctx context.Context, emps iter.Seq[employee.Employee]) error {
+	var stopped error
+	yielded := 0
+	sealed := func(yield func(employee.Employee) bool) {
+		for emp := range emps {
+			s, err := r.seal(ctx, emp)
+			if err != nil {
+				stopped = err
+				return
+			}
+			if !yield(s) {
+				return
+			}
+			yielded++
+		}
+	}
+	err := employee.SaveAll(ctx, r.next, sealed)
+	if stopped == nil {
+		return err
+	}
+	var report *employee.BulkError
+	if !errors.As(err, &report) {
+		report = &employee.BulkError{Saved: yielded}
+	}
+	if report.Stopped == nil {
+		report.Stopped = stopped
+	}
+	return report
+}
+
+// Update seals emp and stores it through the backend's employee.Updater; the
+// version is not encrypted, so the backend compares it as it would any other.
+func (r *Repository) Update(ctx context.Context, emp employee.Employee, expectedVersion int) (employee.Employee, error) {
+	u, ok := r.next.(employee.Updater)
+	if !ok {
+		return employee.Employee{}, errors.ErrUnsupported
+	}
+	sealed, err := r.seal(ctx, emp)
+	if err != nil {
+		return employee.Employee{}, err
+	}
+	stored, err := u.Update(ctx, sealed, expectedVersion)
+	if err != nil {
+		return employee.Employee{}, err
+	}
+	return r.open(ctx, stored)
+}
+
+func (r *Repository) SaveWithOutbox(ctx context.Context, emp employee.Employee, msgs []outbox.Message) error {
+	o, ok := r.next.(employee.OutboxRepository)
+	if !ok {
+		return errors.ErrUnsupported
+	}
+	sealed, err := r.seal(ctx, emp)
+	if err != nil {
+		return err
+	}
+	return o.SaveWithOutbox(ctx, sealed, msgs)
+}
+
+func (r *Repository) SoftDelete(ctx context.Context, name string) error {
+	d, ok := r.next.(employee.SoftDeleter)
+	if !ok {
+		return errors.ErrUnsupported
+	}
+	return d.SoftDelete(ctx, name)
+}
+
+func (r *Repository) Restore(ctx context.Context, name string) error {
+	d, ok := r.next.(employee.SoftDeleter)
+	if !ok {
+		return errors.ErrUnsupported
+	}
+	return d.Restore(ctx, name)
+}
+
+func (r *Repository) History(ctx context.Context, name string) ([]employee.Employee, error) {
+	v, ok := r.next.(employee.Versioned)
+	if !ok {
+		return nil, errors.ErrUnsupported
+	}
+	versions, err := v.History(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	return r.openAll(ctx, versions)
+}
+
+// ErrEncryptedField returned for a query the backend can't answer because the
+// field it needs is encrypted
+var ErrEncryptedField = fmt.Errorf("crypto: query on an encrypted field: %w", errors.ErrUnsupported)
+
+func (r *Repository) List(ctx context.Context, filter employee.Filter, page employee.Page) (employee.PageResult, error) {
+	q, ok := r.next.(employee.QueryRepository)
+	if !ok {
+		return employee.PageResult{}, errors.ErrUnsupported
+	}
+	if !filter.MinSalary.IsZero() || !filter.MaxSalary.IsZero() || filter.Sort == employee.SortBySalary {
+		return employee.PageResult{}, ErrEncryptedField
+	}
+	res, err := q.List(ctx, filter, page)
+	if err != nil {
+		return employee.PageResult{}, err
+	}
+	res.Items, err = r.openAll(ctx, res.Items)
+	return res, err
+}
+
+// Matching decrypts every employee and evaluates s in memory: a specification
+// translated to SQL would compare against the zero values the backend holds.
+func (r *Repository) Matching(ctx context.Context, s spec.Specification[employee.Employee]) ([]employee.Employee, error) {
+	q, ok := r.next.(employee.QueryRepository)
+	if !ok {
+		return nil, errors.ErrUnsupported
+	}
+	var matched []employee.Employee
+	page := employee.Page{Limit: employee.MaxPageSize}
+	for {
+		res, err := q.List(ctx, employee.Filter{}, page)
+		if err != nil {
+			return nil, err
+		}
+		for _, emp := range res.Items {
+			emp, err := r.open(ctx, emp)
+			if err != nil {
+				return nil, err
+			}
+			if s.IsSatisfiedBy(emp) {
+				matched = append(matched, emp)
+			}
+		}
+		if res.NextCursor == "" {
+			return matched, nil
+		}
+		page.Cursor = res.NextCursor
+	}
+}
+
+var (
+	_ employee.Repository              = (*Repository)(nil)
+	_ employee.SoftDeleter             = (*Repository)(nil)
+	_ employee.Updater                 = (*Repository)(nil)
+	_ employee.Iterable                = (*Repository)(nil)
+	_ employee.IDRepository            = (*Repository)(nil)
+	_ employee.Versioned               = (*Repository)(nil)
+	_ employee.QueryRepository         = (*Repository)(nil)
+	_ employee.SpecificationRepository = (*Repository)(nil)
+	_ employee.BulkSaver               = (*Repository)(nil)
+	_ employee.OutboxRepository        = (*Repository)(nil)
+)
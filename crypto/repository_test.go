@@ -0,0 +1,118 @@
+package crypto_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"go-solid/crypto"
+	"go-solid/employee"
+	"go-solid/employee/employeetest"
+	"go-solid/employee/memory"
+	"go-solid/id"
+	"go-solid/money"
+)
+
+func newRepository(t *testing.T, backend employee.Repository) *crypto.Repository {
+	t.Helper()
+	aes, err := crypto.NewAESGCM(crypto.NewKey())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return crypto.NewRepository(backend, aes, crypto.WithIDs(id.NewSequence("emp-")))
+}
+
+// TestRepository_Update checks a conditional update is sealed like a save,
+// and a stale one still conflicts: the version is stored in the clear.
+func TestRepository_Update(t *testing.T) {
+	ctx := t.Context()
+	backend := memory.New()
+	repo := newRepository(t, backend)
+	m := employee.NewManager(repo, employee.WithConflictRetries(1))
+	if _, err := m.AddEmployee(ctx, employee.Employee{Name: "Ali", Title: "Engineer", Salary: money.Of(5000, money.EUR)}); err != nil {
+		t.Fatalf("AddEmployee() error = %v", err)
+	}
+	emp, err := m.ChangeSalary(ctx, "Ali", money.Of(5500, money.EUR))
+	if err != nil || emp.Version != 2 || emp.Salary != money.Of(5500, money.EUR) {
+		t.Fatalf("ChangeSalary() = version %d %v, %v, want version 2 at 5500", emp.Version, emp.Salary, err)
+	}
+	if stored, _ := backend.GetByName(ctx, "Ali"); !strings.HasPrefix(stored.Email, "sealed:") || !stored.Salary.IsZero() {
+		t.Errorf("backend holds %+v after Update, want only the sealed value", stored)
+	}
+	_, err = m.UpdateEmployee(ctx, "Ali", func(emp *employee.Employee) error {
+		if _, err := m.ChangeSalary(ctx, "Ali", money.Of(6000, money.EUR)); err != nil {
+			t.Fatalf("ChangeSalary() error = %v", err)
+		}
+		emp.Title = "Lead"
+		return nil
+	})
+	if !errors.Is(err, employee.ErrConflict) {
+		t.Errorf("UpdateEmployee() error = %v, want %v", err, employee.ErrConflict)
+	}
+}
+
+func TestRepository_SaveWithoutID(t *testing.T) {
+	ctx := t.Context()
+	backend := memory.New()
+	repo := newRepository(t, backend)
+
+	ali := employee.Employee{Name: "Ali", Title: "Engineer", Email: "ali@example.com", Salary: money.Of(5000, money.EUR)}
+	if err := repo.Save(ctx, ali); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	got, err := repo.GetByName(ctx, "Ali")
+	if err != nil {
+		t.Fatalf("GetByName() error = %v", err)
+	}
+	if got.ID != "emp-1" || got.Salary != ali.Salary || got.Email != ali.Email {
+		t.Errorf("GetByName() = %+v, want ID emp-1 with salary and email opened", got)
+	}
+	stored, _ := backend.GetByName(ctx, "Ali")
+	if !strings.HasPrefix(stored.Email, "sealed:") || strings.Contains(stored.Email, "ali@") || !stored.Salary.IsZero() {
+		t.Errorf("backend holds %+v, want only the sealed value", stored)
+	}
+
+	// saved again without an ID, Ali is the employee stored under the name
+	ali.Salary = money.Of(6000, money.EUR)
+	if err := repo.Save(ctx, ali); err != nil {
+		t.Fatalf("Save() again error = %v", err)
+	}
+	got, err = repo.GetByName(ctx, "Ali")
+	if err != nil {
+		t.Fatalf("GetByName() after update error = %v", err)
+	}
+	if got.ID != "emp-1" || got.Salary != ali.Salary || got.Version != 2 {
+		t.Errorf("GetByName() after update = %+v, want emp-1 at version 2 earning %v", got, ali.Salary)
+	}
+}
+
+func TestRepository_SaveAllWithoutIDs(t *testing.T) {
+	ctx := t.Context()
+	repo := newRepository(t, memory.New())
+	emps := []employee.Employee{
+		{Name: "Ali", Salary: money.Of(5000, money.EUR)},
+		{Name: "Sara", Salary: money.Of(5200, money.EUR)},
+	}
+	if err := repo.SaveAll(ctx, func(yield func(employee.Employee) bool) {
+		for _, emp := range emps {
+			if !yield(emp) {
+				return
+			}
+		}
+	}); err != nil {
+		t.Fatalf("SaveAll() error = %v", err)
+	}
+	for _, want := range emps {
+		got, err := repo.GetByName(ctx, want.Name)
+		if err != nil {
+			t.Fatalf("GetByName(%q) error = %v", want.Name, err)
+		}
+		if got.Salary != want.Salary {
+			t.Errorf("GetByName(%q) salary = %v, want %v", want.Name, got.Salary, want.Salary)
+		}
+	}
+}
+
+func TestRepository(t *testing.T) {
+	employeetest.TestRepository(t, func(t *testing.T) employee.Repository { return newRepository(t, memory.New()) })
+}
@@ -0,0 +1,256 @@
+// Package differential runs the same randomized sequence of operations
+// against two employee repositories and reports the first place they
+// disagree.
+//
+// Two backends behind employee.Repository should be interchangeable (LSP),
+// but hand-written tests only check the cases someone thought of. A seeded
+// random sequence of saves, reads, soft deletes and listings over a small
+// set of names finds the others: a cache that misses an invalidation, a
+// database that rounds timestamps, a listing that orders ties differently.
+// The seed makes a failure repeatable, and the steps up to it are the
+// reproduction.
+package differential
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"strings"
+	"testing"
+	"time"
+
+	"go-solid/employee"
+	"go-solid/fakes"
+	"go-solid/money"
+)
+
+// Options How Run picks operations
+type Options struct {
+	Seed uint64
+	// Steps is the number of operations; 200 by default
+	Steps int
+	// Names is how many distinct employees the operations touch; 8 by
+	// default. Few names mean operations keep meeting earlier ones.
+	Names int
+}
+
+// Step One operation, as run against both repositories
+type Step struct {
+	N    int
+	Op   string // save, get, soft-delete, restore, list
+	Args string
+}
+
+func (s Step) String() string { return fmt.Sprintf("%3d. %s %s", s.N, s.Op, s.Args) }
+
+// Divergence Where the repositories disagreed, and how
+type Divergence struct {
+	Step Step
+	A, B string
+}
+
+func (d Divergence) String() string {
+	return fmt.Sprintf("step %d, %s %s:\n   a: %s\n   b: %s", d.Step.N, d.Step.Op, d.Step.Args, d.A, d.B)
+}
+
+// Report What Run did
+type Report struct {
+	Seed  uint64
+	Steps []Step
+	// Capabilities lists the optional capabilities exercised; one only
+	// implemented by one side is left out and named in Skipped
+	Capabilities []string
+	Skipped      []string
+	// Divergence is the first disagreement, nil if there was none
+	Divergence *Divergence
+}
+
+// Run drives a and b through the same operations, comparing every result,
+// then compares what each holds for every name. It stops at the first
+// divergence; an error is returned only when the context ends. A listing
+// one side answers with errors.ErrUnsupported is not compared.
+func Run(ctx context.Context, a, b employee.Repository, opts Options) (Report, error) {
+	opts.Steps = cmp.Or(opts.Steps, 200)
+	opts.Names = cmp.Or(opts.Names, 8)
+	rng := rand.New(rand.NewPCG(opts.Seed, opts.Seed))
+	faker := fakes.New(opts.Seed)
+	r := &runner{a: side{repo: a}, b: side{repo: b}, rng: rng, faker: faker, report: Report{Seed: opts.Seed}}
+	r.a.soft, r.b.soft = capability[employee.SoftDeleter](a), capability[employee.SoftDeleter](b)
+	r.a.query, r.b.query = capability[employee.QueryRepository](a), capability[employee.QueryRepository](b)
+	r.note("SoftDeleter", r.a.soft != nil, r.b.soft != nil)
+	r.note("QueryRepository", r.a.query != nil, r.b.query != nil)
+	for range opts.Names {
+		r.names = append(r.names, faker.Employee().Name)
+	}
+
+	for i := range opts.Steps {
+		if err := ctx.Err(); err != nil {
+			return r.report, err
+		}
+		if d := r.step(ctx, i+1); d != nil {
+			r.report.Divergence = d
+			return r.report, nil
+		}
+	}
+	// the final state, name by name
+	n := len(r.report.Steps)
+	for _, name := range r.names {
+		step := Step{N: n + 1, Op: "get", Args: name + " (final state)"}
+		if d := r.compare(step, get(ctx, r.a, name), get(ctx, r.b, name)); d != nil {
+			r.report.Divergence = d
+			return r.report, nil
+		}
+	}
+	return r.report, nil
+}
+
+// Check runs Run and fails t with the steps up to the divergence, if any.
+func Check(t testing.TB, a, b employee.Repository, opts Options) {
+	t.Helper()
+	report, err := Run(t.Context(), a, b, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.Divergence != nil {
+		t.Fatalf("repositories diverged (seed %d)\n%s\n%s", report.Seed, report.Replay(), report.Divergence)
+	}
+}
+
+// Replay lists the steps that were run, one per line.
+func (r Report) Replay() string {
+	var b strings.Builder
+	for _, s := range r.Steps {
+		fmt.Fprintln(&b, s)
+	}
+	return b.String()
+}
+
+type side struct {
+	repo  employee.Repository
+	soft  employee.SoftDeleter
+	query employee.QueryRepository
+}
+
+type runner struct {
+	a, b   side
+	rng    *rand.Rand
+	faker  fakes.Faker
+	names  []string
+	report Report
+}
+
+func (r *runner) note(name string, a, b bool) {
+	switch {
+	case a && b:
+		r.report.Capabilities = append(r.report.Capabilities, name)
+	case a != b:
+		r.report.Skipped = append(r.report.Skipped, name)
+	}
+}
+
+// step runs one random operation on both sides.
+func (r *runner) step(ctx context.Context, n int) *Divergence {
+	name := r.names[r.rng.IntN(len(r.names))]
+	ops := []string{"save", "save", "get", "get"}
+	if r.a.soft != nil && r.b.soft != nil {
+		ops = append(ops, "soft-delete", "restore")
+	}
+	if r.a.query != nil && r.b.query != nil {
+		ops = append(ops, "list")
+	}
+
+	step := Step{N: n, Op: ops[r.rng.IntN(len(ops))], Args: name}
+	var ra, rb string
+	switch step.Op {
+	case "save":
+		emp := r.faker.Employee()
+		emp.Name = name
+		step.Args = fmt.Sprintf("%s %s %s", name, emp.Title, emp.Salary)
+		ra, rb = describe(nil, r.a.repo.Save(ctx, emp)), describe(nil, r.b.repo.Save(ctx, emp))
+	case "get":
+		ra, rb = get(ctx, r.a, name), get(ctx, r.b, name)
+	case "soft-delete":
+		ra, rb = describe(nil, r.a.soft.SoftDelete(ctx, name)), describe(nil, r.b.soft.SoftDelete(ctx, name))
+	case "restore":
+		ra, rb = describe(nil, r.a.soft.Restore(ctx, name)), describe(nil, r.b.soft.Restore(ctx, name))
+	case "list":
+		filter, page := r.filter(), employee.Page{Limit: 1 + r.rng.IntN(len(r.names))}
+		step.Args = fmt.Sprintf("%+v limit %d", filter, page.Limit)
+		ra, rb = list(ctx, r.a, filter, page), list(ctx, r.b, filter, page)
+		if (ra == unsupported) != (rb == unsupported) {
+			// one side can't answer this listing, e.g. by an encrypted field
+			step.Args += " (unsupported by one side)"
+			ra, rb = unsupported, unsupported
+		}
+	}
+	r.report.Steps = append(r.report.Steps, step)
+	return r.compare(step, ra, rb)
+}
+
+func (r *runner) compare(step Step, a, b string) *Divergence {
+	if a == b {
+		return nil
+	}
+	return &Divergence{Step: step, A: a, B: b}
+}
+
+// filter picks a listing: by name or salary, either way, maybe from a prefix
+// or salary floor.
+func (r *runner) filter() employee.Filter {
+	f := employee.Filter{Sort: []employee.SortField{employee.SortByName, employee.SortBySalary}[r.rng.IntN(2)], Descending: r.rng.IntN(2) == 0}
+	switch r.rng.IntN(3) {
+	case 0:
+		f.NamePrefix = r.names[r.rng.IntN(len(r.names))][:1]
+	case 1:
+		f.MinSalary = money.Of(4000+r.rng.Int64N(4000), money.USD)
+	}
+	return f
+}
+
+func get(ctx context.Context, s side, name string) string {
+	emp, err := s.repo.GetByName(ctx, name)
+	return describe(&emp, err)
+}
+
+// list reads the first page; the names in it, in order, are the result.
+func list(ctx context.Context, s side, filter employee.Filter, page employee.Page) string {
+	res, err := s.query.List(ctx, filter, page)
+	if err != nil {
+		return describe(nil, err)
+	}
+	names := make([]string, len(res.Items))
+	for i, emp := range res.Items {
+		names[i] = emp.Name
+	}
+	return fmt.Sprintf("[%s] more: %v", strings.Join(names, ", "), res.NextCursor != "")
+}
+
+// unsupported Describes an operation a side doesn't support
+const unsupported = "ErrUnsupported"
+
+// describe renders a result so that equal strings mean equal behaviour.
+// Errors are compared by the sentinel they wrap: backends word their
+// errors differently, but must agree on what went wrong.
+func describe(emp *employee.Employee, err error) string {
+	switch {
+	case errors.Is(err, employee.ErrNotFound):
+		return "ErrNotFound"
+	case errors.Is(err, errors.ErrUnsupported):
+		return unsupported
+	case err != nil:
+		return "error"
+	case emp == nil:
+		return "ok"
+	}
+	// databases keep microseconds and may hand back another location
+	hired := emp.HiredAt.UTC().Truncate(time.Microsecond).Format(time.RFC3339Nano)
+	return fmt.Sprintf("{ID:%s Name:%s Title:%s Email:%s Salary:%s HiredAt:%s Version:%d}",
+		emp.ID, emp.Name, emp.Title, emp.Email, emp.Salary, hired, emp.Version)
+}
+
+func capability[C any](repo employee.Repository) C {
+	c, _ := repo.(C)
+	return c
+}
@@ -0,0 +1,230 @@
+// Package actor serves the employee use cases with one goroutine per
+// employee: every command for an employee goes to that employee's mailbox,
+// and its goroutine runs them one at a time.
+//
+// Manager has the same methods, and must keep the same semantics, as the
+// synchronous employee.Manager it wraps - callers written against
+// httpapi.EmployeeService can't tell them apart (LSP). What changes is
+// concurrency: two raises of one employee can no longer both read the old
+// salary, because they never run at the same time.
+package actor
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"go-solid/employee"
+	"go-solid/money"
+	"go-solid/normalize"
+)
+
+// ErrClosed returned for commands sent after Close
+var ErrClosed = errors.New("actor: manager closed")
+
+// Manager Routes each command to the actor owning the employee it names
+type Manager struct {
+	manager  *employee.Manager
+	idle     time.Duration
+	mu       sync.Mutex
+	actors   map[string]*actor // by normalized name
+	names    normalize.Normalizer
+	closed   bool
+	stopping sync.WaitGroup
+}
+
+// Option customises a Manager created by New
+type Option func(*Manager)
+
+// WithIdle stops an actor that received no command for d; the next command
+// for its employee starts a new one. One minute by default.
+func WithIdle(d time.Duration) Option { return func(m *Manager) { m.idle = d } }
+
+// WithNormalizer gives names with the same key under n one actor, so that
+// commands for "Mohamed" and "mohamed" run one at a time when the repository
+// takes them for the same employee. normalize.Name by default.
+func WithNormalizer(n normalize.Normalizer) Option { return func(m *Manager) { m.names = n } }
+
+// New creates a Manager running the use cases of m, which keeps its
+// repository, audit sink, events and logger.
+func New(m *employee.Manager, opts ...Option) *Manager {
+	a := &Manager{manager: m, actors: map[string]*actor{}, names: normalize.Name}
+	for _, opt := range opts {
+		opt(a)
+	}
+	a.idle = cmp.Or(a.idle, time.Minute)
+	return a
+}
+
+// actor Owns one employee: its goroutine is the only one running commands for it
+type actor struct {
+	name    string // normalized
+	mailbox chan func()
+	pending int // commands sent or about to be; guarded by Manager.mu
+}
+
+func (m *Manager) AddEmployee(ctx context.Context, emp employee.Employee) (employee.Employee, error) {
+	return ask(ctx, m, emp.Name, func() (employee.Employee, error) { return m.manager.AddEmployee(ctx, emp) })
+}
+
+func (m *Manager) FindEmployee(ctx context.Context, name string) (employee.Employee, error) {
+	return ask(ctx, m, name, func() (employee.Employee, error) { return m.manager.FindEmployee(ctx, name) })
+}
+
+func (m *Manager) ChangeSalary(ctx context.Context, name string, salary money.Money) (employee.Employee, error) {
+	return ask(ctx, m, name, func() (employee.Employee, error) { return m.manager.ChangeSalary(ctx, name, salary) })
+}
+
+func (m *Manager) Promote(ctx context.Context, name, title string, raise money.Money) (employee.Employee, error) {
+	return ask(ctx, m, name, func() (employee.Employee, error) { return m.manager.Promote(ctx, name, title, raise) })
+}
+
+func (m *Manager) RemoveEmployee(ctx context.Context, name string) error {
+	_, err := ask(ctx, m, name, func() (struct{}, error) { return struct{}{}, m.manager.RemoveEmployee(ctx, name) })
+	return err
+}
+
+func (m *Manager) RestoreEmployee(ctx context.Context, name string) error {
+	_, err := ask(ctx, m, name, func() (struct{}, error) { return struct{}{}, m.manager.RestoreEmployee(ctx, name) })
+	return err
+}
+
+// FindEmployeeByID changes nothing, and until the employee is found there
+// is no name to tell which actor owns it: it runs on the caller's goroutine.
+func (m *Manager) FindEmployeeByID(ctx context.Context, id employee.ID) (employee.Employee, error) {
+	return m.manager.FindEmployeeByID(ctx, id)
+}
+
+// ListEmployees spans many employees and changes none, so no actor owns it:
+// it runs on the caller's goroutine, as it would on the synchronous Manager.
+func (m *Manager) ListEmployees(ctx context.Context, filter employee.Filter, page employee.Page) (employee.PageResult, error) {
+	return m.manager.ListEmployees(ctx, filter, page)
+}
+
+// Close lets every actor finish the commands already in its mailbox, then
+// stops them. Later commands fail with ErrClosed.
+func (m *Manager) Close() error {
+	m.mu.Lock()
+	m.closed = true
+	for _, a := range m.actors {
+		if a.pending == 0 {
+			close(a.mailbox)
+			delete(m.actors, a.name)
+		}
+	}
+	m.mu.Unlock()
+	m.stopping.Wait()
+	return nil
+}
+
+// ask sends do to the actor owning name and waits for its result. A command
+// whose context ends while it waits in the mailbox is dropped unrun, and the
+// caller gets the context's error.
+func ask[R any](ctx context.Context, m *Manager, name string, do func() (R, error)) (R, error) {
+	type result struct {
+		value R
+		err   error
+	}
+	var zero R
+	a, err := m.acquire(name)
+	if err != nil {
+		return zero, err
+	}
+	reply := make(chan result, 1) // the actor never blocks on a caller that gave up
+	cmd := func() {
+		if err := ctx.Err(); err != nil {
+			reply <- result{err: err}
+			return
+		}
+		v, err := do()
+		reply <- result{v, err}
+	}
+	select {
+	case a.mailbox <- cmd:
+	case <-ctx.Done():
+		m.done(a) // never sent
+		return zero, ctx.Err()
+	}
+	select {
+	case r := <-reply:
+		return r.value, r.err
+	case <-ctx.Done():
+		return zero, ctx.Err()
+	}
+}
+
+// acquire returns the actor owning name, starting it if there is none, and
+// counts the command about to be sent so the actor doesn't stop before it
+// arrives.
+func (m *Manager) acquire(name string) (*actor, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.closed {
+		return nil, ErrClosed
+	}
+	key := m.names.Normalize(name)
+	a, ok := m.actors[key]
+	if !ok {
+		a = &actor{name: key, mailbox: make(chan func(), 16)}
+		m.actors[key] = a
+		m.stopping.Add(1)
+		go m.run(a)
+	}
+	a.pending++
+	return a, nil
+}
+
+// run is the actor's goroutine: it processes its mailbox in order until the
+// actor stops.
+func (m *Manager) run(a *actor) {
+	defer m.stopping.Done()
+	timer := time.NewTimer(m.idle)
+	defer timer.Stop()
+	for {
+		select {
+		case cmd, ok := <-a.mailbox:
+			if !ok {
+				return
+			}
+			cmd()
+			m.done(a)
+			timer.Reset(m.idle)
+		case <-timer.C:
+			if m.passivate(a) {
+				return
+			}
+			timer.Reset(m.idle)
+		}
+	}
+}
+
+// done counts a processed command; the last one in after Close stops the actor.
+func (m *Manager) done(a *actor) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	a.pending--
+	if m.closed && a.pending == 0 {
+		close(a.mailbox)
+		delete(m.actors, a.name)
+	}
+}
+
+// passivate stops an idle actor, unless a command is on its way.
+func (m *Manager) passivate(a *actor) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if a.pending > 0 || m.closed {
+		return false
+	}
+	delete(m.actors, a.name)
+	return true
+}
+
+// Actors returns how many employees currently have a running actor.
+func (m *Manager) Actors() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return len(m.actors)
+}
@@ -0,0 +1,109 @@
+package employee
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"go-solid/events"
+	"go-solid/money"
+)
+
+var (
+	ErrInvalidName      = errors.New("employee name must not be empty")
+	ErrInvalidSalary    = errors.New("salary must be positive")
+	ErrInvalidPromotion = errors.New("invalid promotion")
+)
+
+// Domain events raised by the Employee aggregate
+
+type Hired struct {
+	EmployeeID ID
+	Name       string
+	Salary     money.Money
+	At         time.Time
+}
+
+type SalaryChanged struct {
+	EmployeeID ID
+	Name       string
+	From, To   money.Money
+	At         time.Time
+}
+
+type Promoted struct {
+	EmployeeID ID
+	Name       string
+	FromTitle  string
+	ToTitle    string
+	Raise      money.Money
+	Salary     money.Money
+	At         time.Time
+}
+
+func (Hired) EventName() string         { return "employee.hired" }
+func (SalaryChanged) EventName() string { return "employee.salary_changed" }
+func (Promoted) EventName() string      { return "employee.promoted" }
+
+// AggregateID keys the events by employee (outbox.Keyed).
+func (e Hired) AggregateID() string         { return string(e.EmployeeID) }
+func (e SalaryChanged) AggregateID() string { return string(e.EmployeeID) }
+func (e Promoted) AggregateID() string      { return string(e.EmployeeID) }
+
+// Hire creates a new Employee aggregate, checking its invariants and
+// recording a Hired event.
+func Hire(id ID, name, title string, salary money.Money, at time.Time) (Employee, error) {
+	if strings.TrimSpace(name) == "" {
+		return Employee{}, ErrInvalidName
+	}
+	if !salary.IsPositive() {
+		return Employee{}, ErrInvalidSalary
+	}
+	emp := Employee{ID: id, Name: name, Title: title, Salary: salary, HiredAt: at}
+	emp.raise(Hired{EmployeeID: id, Name: name, Salary: salary, At: at})
+	return emp, nil
+}
+
+// ChangeSalary sets a new salary. The salary must stay positive; setting the
+// same value is a no-op and raises no event. The currency may change (a
+// relocation), which is why this is not expressed as a raise.
+func (e *Employee) ChangeSalary(salary money.Money, at time.Time) error {
+	if !salary.IsPositive() {
+		return ErrInvalidSalary
+	}
+	if salary == e.Salary {
+		return nil
+	}
+	e.raise(SalaryChanged{EmployeeID: e.ID, Name: e.Name, From: e.Salary, To: salary, At: at})
+	e.Salary = salary
+	return nil
+}
+
+// Promote gives the employee a new title together with a raise - a promotion
+// never lowers pay.
+func (e *Employee) Promote(title string, raise money.Money, at time.Time) error {
+	if strings.TrimSpace(title) == "" || title == e.Title {
+		return fmt.Errorf("%w: new title must differ from %q", ErrInvalidPromotion, e.Title)
+	}
+	if !raise.IsPositive() {
+		return fmt.Errorf("%w: raise must be positive", ErrInvalidPromotion)
+	}
+	salary, err := e.Salary.Add(raise)
+	if err != nil {
+		return fmt.Errorf("%w: raise: %w", ErrInvalidPromotion, err)
+	}
+	e.raise(Promoted{EmployeeID: e.ID, Name: e.Name, FromTitle: e.Title, ToTitle: title, Raise: raise, Salary: salary, At: at})
+	e.Title = title
+	e.Salary = salary
+	return nil
+}
+
+// PullEvents returns the events recorded since the last call and clears them.
+func (e *Employee) PullEvents() []events.Event {
+	evts := e.pending
+	e.pending = nil
+	return evts
+}
+
+func (e *Employee) raise(evt events.Event) { e.pending = append(e.pending, evt) }
@@ -0,0 +1,122 @@
+package employee
+
+import (
+	"context"
+	"fmt"
+	"iter"
+	"strings"
+)
+
+// BulkSaver Optional capability - backends that can save a stream of employees
+// more cheaply than one Save call at a time (batched transactions, one lock...).
+//
+// SaveAll is not atomic. It consumes emps to the end, and if anything went
+// wrong it returns a *BulkError naming exactly the employees that were not
+// saved; every other employee was. Callers that don't care about the
+// capability use the package-level SaveAll, which falls back to Save.
+type BulkSaver interface {
+	SaveAll(ctx context.Context, emps iter.Seq[Employee]) error
+}
+
+// BulkFailure One employee a bulk save could not store
+type BulkFailure struct {
+	// Index is the position of the employee in the input sequence, from 0.
+	Index int
+	Name  string
+	Err   error
+}
+
+// BulkError Partial-failure report of a bulk save
+type BulkError struct {
+	Saved  int
+	Failed []BulkFailure
+	// Stopped is set when the save ended before the sequence did (usually a
+	// cancelled context); employees neither saved nor listed in Failed were
+	// not attempted.
+	Stopped error
+}
+
+func (e *BulkError) Error() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "bulk save: %d saved, %d failed", e.Saved, len(e.Failed))
+	for i, f := range e.Failed {
+		if i == 3 {
+			fmt.Fprintf(&sb, "; and %d more", len(e.Failed)-i)
+			break
+		}
+		fmt.Fprintf(&sb, "; #%d %q: %v", f.Index, f.Name, f.Err)
+	}
+	if e.Stopped != nil {
+		fmt.Fprintf(&sb, "; stopped: %v", e.Stopped)
+	}
+	return sb.String()
+}
+
+// Unwrap exposes the individual errors to errors.Is and errors.As.
+func (e *BulkError) Unwrap() []error {
+	errs := make([]error, 0, len(e.Failed)+1)
+	for _, f := range e.Failed {
+		errs = append(errs, f.Err)
+	}
+	if e.Stopped != nil {
+		errs = append(errs, e.Stopped)
+	}
+	return errs
+}
+
+// Add records the outcome of saving emp, the employee at index in the
+// input sequence.
+func (e *BulkError) Add(index int, emp Employee, err error) {
+	if err != nil {
+		e.Failed = append(e.Failed, BulkFailure{Index: index, Name: emp.Name, Err: err})
+	} else {
+		e.Saved++
+	}
+}
+
+// Err returns e as an error, or nil if nothing failed.
+func (e *BulkError) Err() error {
+	if len(e.Failed) == 0 && e.Stopped == nil {
+		return nil
+	}
+	return e
+}
+
+// SaveAll saves emps through repo's BulkSaver capability when it has one, and
+// one Save at a time otherwise - with the same partial-failure semantics.
+func SaveAll(ctx context.Context, repo Repository, emps iter.Seq[Employee]) error {
+	if b, ok := repo.(BulkSaver); ok {
+		return b.SaveAll(ctx, emps)
+	}
+	report := &BulkError{}
+	i := 0
+	for emp := range emps {
+		if err := ctx.Err(); err != nil {
+			report.Stopped = err
+			break
+		}
+		report.Add(i, emp, repo.Save(ctx, emp))
+		i++
+	}
+	return report.Err()
+}
+
+// Batches groups seq into slices of up to size elements. Each slice is freshly
+// allocated, so consumers may keep it.
+func Batches[T any](seq iter.Seq[T], size int) iter.Seq[[]T] {
+	return func(yield func([]T) bool) {
+		batch := make([]T, 0, size)
+		for v := range seq {
+			batch = append(batch, v)
+			if len(batch) == size {
+				if !yield(batch) {
+					return
+				}
+				batch = make([]T, 0, size)
+			}
+		}
+		if len(batch) > 0 {
+			yield(batch)
+		}
+	}
+}
@@ -0,0 +1,112 @@
+package employee_test
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+
+	"go-solid/employee"
+)
+
+var errRejected = errors.New("rejected")
+
+// picky A Repository without BulkSaver that rejects names starting with M
+type picky struct {
+	saved []string
+}
+
+func (p *picky) Save(_ context.Context, emp employee.Employee) error {
+	if strings.HasPrefix(emp.Name, "M") {
+		return errRejected
+	}
+	p.saved = append(p.saved, emp.Name)
+	return nil
+}
+
+func (p *picky) GetByName(context.Context, string) (employee.Employee, error) {
+	return employee.Employee{}, employee.ErrNotFound
+}
+
+func staff(names ...string) func(yield func(employee.Employee) bool) {
+	return func(yield func(employee.Employee) bool) {
+		for _, name := range names {
+			if !yield(employee.Employee{Name: name}) {
+				return
+			}
+		}
+	}
+}
+
+func TestSaveAll_FallsBackToSave(t *testing.T) {
+	repo := &picky{}
+	err := employee.SaveAll(t.Context(), repo, staff("Ali", "Mallory", "Sara", "Mona", "Omar"))
+	var bulk *employee.BulkError
+	if !errors.As(err, &bulk) {
+		t.Fatalf("SaveAll() error = %v, want a *BulkError", err)
+	}
+	if bulk.Saved != 3 || bulk.Stopped != nil {
+		t.Errorf("SaveAll() = %d saved, stopped %v; want 3 saved to the end", bulk.Saved, bulk.Stopped)
+	}
+	want := []employee.BulkFailure{{Index: 1, Name: "Mallory", Err: errRejected}, {Index: 3, Name: "Mona", Err: errRejected}}
+	if !slices.Equal(bulk.Failed, want) {
+		t.Errorf("Failed = %+v, want %+v", bulk.Failed, want)
+	}
+	if !slices.Equal(repo.saved, []string{"Ali", "Sara", "Omar"}) {
+		t.Errorf("saved %v, want everyone else, in order", repo.saved)
+	}
+	if !errors.Is(err, errRejected) {
+		t.Error("errors.Is(err, errRejected) = false, want the failures unwrapped")
+	}
+}
+
+func TestSaveAll_NothingFailed(t *testing.T) {
+	if err := employee.SaveAll(t.Context(), &picky{}, staff("Ali", "Sara")); err != nil {
+		t.Errorf("SaveAll() error = %v, want nil", err)
+	}
+}
+
+func TestSaveAll_Stopped(t *testing.T) {
+	ctx, cancel := context.WithCancel(t.Context())
+	repo := &picky{}
+	err := employee.SaveAll(ctx, repo, func(yield func(employee.Employee) bool) {
+		for _, name := range []string{"Ali", "Sara", "Omar"} {
+			if name == "Omar" {
+				cancel()
+			}
+			if !yield(employee.Employee{Name: name}) {
+				return
+			}
+		}
+	})
+	var bulk *employee.BulkError
+	if !errors.As(err, &bulk) || bulk.Saved != 2 || len(bulk.Failed) != 0 || !errors.Is(err, context.Canceled) {
+		t.Fatalf("SaveAll() = %v, want 2 saved and the rest stopped by the cancellation", err)
+	}
+}
+
+func TestBulkError_Error(t *testing.T) {
+	report := &employee.BulkError{Saved: 1}
+	for i, name := range []string{"Mallory", "Mona", "Max", "Milo", "Mia"} {
+		report.Add(i+1, employee.Employee{Name: name}, errRejected)
+	}
+	report.Stopped = context.Canceled
+	want := `bulk save: 1 saved, 5 failed; #1 "Mallory": rejected; #2 "Mona": rejected; #3 "Max": rejected; and 2 more; stopped: context canceled`
+	if got := report.Error(); got != want {
+		t.Errorf("Error() = %q, want %q", got, want)
+	}
+	if (&employee.BulkError{Saved: 3}).Err() != nil {
+		t.Error("Err() with nothing failed or stopped != nil")
+	}
+}
+
+func TestBatches(t *testing.T) {
+	var got [][]int
+	for batch := range employee.Batches(slices.Values([]int{1, 2, 3, 4, 5}), 2) {
+		got = append(got, batch)
+	}
+	if !slices.EqualFunc(got, [][]int{{1, 2}, {3, 4}, {5}}, slices.Equal) {
+		t.Errorf("Batches() = %v, want [[1 2] [3 4] [5]]", got)
+	}
+}
@@ -0,0 +1,46 @@
+package employee
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// ErrConflict returned by Update when the employee was saved by someone else
+// since the caller read it
+var ErrConflict = errors.New("employee was modified concurrently")
+
+// Updater Optional capability - backends that store an employee only if it is
+// still at the version the caller read (optimistic concurrency). Save stays
+// unconditional: the last write wins.
+//
+// The contract, which every implementation must keep for callers to rely on
+// it (LSP):
+//
+//   - expectedVersion is the Version of the employee as the caller read it.
+//     emp.Version is ignored.
+//   - expectedVersion 0 means the caller expects no employee by that name: a
+//     soft-deleted one counts as none, and is brought back.
+//   - When the stored employee is at expectedVersion, emp is stored, and
+//     returned at its new version: expectedVersion+1 for an update.
+//   - When it is not, nothing is stored and the error matches ErrConflict. It
+//     is a *ConflictError telling the version found.
+//   - When expectedVersion > 0 and there is no employee, or a soft-deleted
+//     one, the error is ErrNotFound: there is nothing to conflict with.
+type Updater interface {
+	Update(ctx context.Context, emp Employee, expectedVersion int) (Employee, error)
+}
+
+// ConflictError The version Update expected and the one it found; Actual is
+// 0 when there was no employee
+type ConflictError struct {
+	Name     string
+	Expected int
+	Actual   int
+}
+
+func (e *ConflictError) Error() string {
+	return fmt.Sprintf("%v: %q is at version %d, not %d", ErrConflict, e.Name, e.Actual, e.Expected)
+}
+
+func (e *ConflictError) Is(target error) bool { return target == ErrConflict }
@@ -0,0 +1,61 @@
+// Package employee is the domain the SOLID examples grow into: the Employee
+// entity, the Repository abstraction and the Manager use cases that depend on it.
+//
+// Storage backends live in sub-packages (memory, ...) and are injected into the
+// Manager - the same Dependency Inversion as in 5.DIP, but reusable.
+package employee
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go-solid/events"
+	"go-solid/money"
+	"go-solid/outbox"
+)
+
+// Employee Aggregate root of the domain. Fields are readable by everyone, but
+// changes should go through Hire, ChangeSalary and Promote so invariants are
+// checked and domain events recorded.
+type Employee struct {
+	ID         ID
+	Name       string
+	Title      string
+	Department string // empty for employees stored before it existed (see package evolve)
+	Email      string
+	Salary     money.Money
+	HiredAt    time.Time
+	// Version is maintained by the repository: 1 on first save, +1 on every update
+	Version int
+
+	pending []events.Event
+}
+
+// ErrNotFound returned by repositories when no employee matches
+var ErrNotFound = errors.New("employee not found")
+
+// Repository Abstraction - both the Manager and the storage backends depend on this
+type Repository interface {
+	Save(ctx context.Context, emp Employee) error
+	GetByName(ctx context.Context, name string) (Employee, error)
+}
+
+// SoftDeleter Optional capability - backends that can hide an employee instead of erasing it.
+// A soft-deleted employee must behave exactly like a missing one (ErrNotFound) until restored.
+type SoftDeleter interface {
+	SoftDelete(ctx context.Context, name string) error
+	Restore(ctx context.Context, name string) error
+}
+
+// OutboxRepository Optional capability - backends that store an employee and
+// the outbox messages announcing the change in one transaction
+type OutboxRepository interface {
+	SaveWithOutbox(ctx context.Context, emp Employee, msgs []outbox.Message) error
+}
+
+// Versioned Optional capability - backends that keep every saved revision of an employee
+type Versioned interface {
+	// History returns all revisions of the employee, oldest first.
+	History(ctx context.Context, name string) ([]Employee, error)
+}
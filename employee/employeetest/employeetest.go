@@ -0,0 +1,487 @@
+// Package employeetest is the contract every employee.Repository keeps,
+// as a test suite any backend runs against itself:
+//
+//	func TestRepository(t *testing.T) {
+//		employeetest.TestRepository(t, func(t *testing.T) employee.Repository { return memory.New() })
+//	}
+//
+// The suite checks behaviour callers rely on rather than how it is stored:
+// IDs and versions, renames, names taken, ErrNotFound and bulk saves that
+// fail in part. Listings filtered, sorted and paged by cursor, soft
+// deletes, conditional updates and the outbox are checked when the backend
+// has them. A
+// decorator over a backend without them answers errors.ErrUnsupported, and
+// their cases are skipped, as are listings a backend answers with it, such
+// as crypto's by an encrypted salary. It finishes with a differential run
+// against the memory backend. open is called once per case and must return
+// an empty repository, so a shared database is emptied by open.
+package employeetest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"testing"
+	"time"
+
+	"go-solid/differential"
+	"go-solid/employee"
+	"go-solid/employee/memory"
+	"go-solid/money"
+	"go-solid/outbox"
+	"go-solid/spec"
+)
+
+// hired A fixed time in the precision databases keep
+var hired = time.Date(2026, time.March, 2, 9, 0, 0, 0, time.UTC)
+
+func ali() employee.Employee {
+	return employee.Employee{Name: "Ali", Title: "Engineer", Department: "Platform", Email: "ali@example.com", Salary: money.Of(5000, money.USD), HiredAt: hired}
+}
+
+// TestRepository runs the contract against the repositories open returns.
+func TestRepository(t *testing.T, open func(t *testing.T) employee.Repository) {
+	t.Run("save then get", func(t *testing.T) {
+		repo := open(t)
+		want := ali()
+		save(t, repo, want)
+		got := get(t, repo, want.Name)
+		if got.ID == "" || got.Version != 1 {
+			t.Errorf("GetByName() = ID %q version %d, want an ID at version 1", got.ID, got.Version)
+		}
+		if got.Title != want.Title || got.Department != want.Department || got.Email != want.Email ||
+			got.Salary != want.Salary || !got.HiredAt.Equal(want.HiredAt) {
+			t.Errorf("GetByName() = %+v, want what was saved: %+v", got, want)
+		}
+	})
+
+	t.Run("missing", func(t *testing.T) {
+		if _, err := open(t).GetByName(t.Context(), "Nobody"); !errors.Is(err, employee.ErrNotFound) {
+			t.Errorf("GetByName() error = %v, want %v", err, employee.ErrNotFound)
+		}
+	})
+
+	t.Run("save again", func(t *testing.T) {
+		repo := open(t)
+		emp := ali()
+		save(t, repo, emp)
+		first := get(t, repo, emp.Name)
+		emp.Title = "Lead"
+		save(t, repo, emp) // without an ID: the employee stored under the name
+		got := get(t, repo, emp.Name)
+		if got.ID != first.ID || got.Version != 2 || got.Title != "Lead" {
+			t.Errorf("GetByName() = ID %q version %d title %q, want %q at version 2 as Lead", got.ID, got.Version, got.Title, first.ID)
+		}
+	})
+
+	t.Run("rename", func(t *testing.T) {
+		repo := open(t)
+		save(t, repo, ali())
+		emp := get(t, repo, "Ali")
+		emp.Name = "Ali Hassan"
+		save(t, repo, emp)
+		if got := get(t, repo, "Ali Hassan"); got.ID != emp.ID {
+			t.Errorf("GetByName(new name) ID = %q, want %q", got.ID, emp.ID)
+		}
+		if _, err := repo.GetByName(t.Context(), "Ali"); !errors.Is(err, employee.ErrNotFound) {
+			t.Errorf("GetByName(old name) error = %v, want %v", err, employee.ErrNotFound)
+		}
+	})
+
+	t.Run("rename again and again", func(t *testing.T) {
+		// enough names that a backend placing employees by name moves this one
+		repo := open(t)
+		save(t, repo, ali())
+		emp := get(t, repo, "Ali")
+		for _, name := range []string{"Ali Hassan", "Hassan", "A. Hassan", "Ali H.", "Ali"} {
+			emp.Name = name
+			save(t, repo, emp)
+			if got := get(t, repo, name); got.ID != emp.ID {
+				t.Errorf("GetByName(%q) ID = %q, want %q", name, got.ID, emp.ID)
+			}
+			if got, err := employee.GetByID(t.Context(), repo, emp.ID); err != nil || got.Name != name {
+				t.Errorf("GetByID() = %q, %v, want %q", got.Name, err, name)
+			}
+		}
+		sara := ali()
+		sara.Name = "Hassan" // given up by Ali
+		save(t, repo, sara)
+		if got := get(t, repo, "Hassan"); got.ID == emp.ID {
+			t.Errorf("GetByName(Hassan) ID = %q, want a new employee, not Ali", got.ID)
+		}
+		var names []string
+		for emp, err := range employee.All(t.Context(), repo) {
+			if err != nil {
+				t.Fatalf("All() error = %v", err)
+			}
+			names = append(names, emp.Name)
+		}
+		if want := []string{"Ali", "Hassan"}; !slices.Equal(names, want) {
+			t.Errorf("All() = %v, want %v: each employee once, under its last name", names, want)
+		}
+		if _, err := repo.GetByName(t.Context(), "Ali Hassan"); !errors.Is(err, employee.ErrNotFound) {
+			t.Errorf("GetByName(old name) error = %v, want %v", err, employee.ErrNotFound)
+		}
+	})
+
+	t.Run("name taken", func(t *testing.T) {
+		repo := open(t)
+		save(t, repo, ali())
+		sara := ali()
+		sara.Name = "Sara"
+		save(t, repo, sara)
+		emp := get(t, repo, "Sara")
+		emp.Name = "Ali"
+		if err := repo.Save(t.Context(), emp); !errors.Is(err, employee.ErrNameTaken) {
+			t.Errorf("Save() under Ali's name error = %v, want %v", err, employee.ErrNameTaken)
+		}
+	})
+
+	t.Run("soft delete", func(t *testing.T) {
+		repo := open(t)
+		soft, ok := repo.(employee.SoftDeleter)
+		if !ok {
+			t.Skip("not a SoftDeleter")
+		}
+		save(t, repo, ali())
+		if err := soft.SoftDelete(t.Context(), "Ali"); err != nil {
+			t.Fatalf("SoftDelete() error = %v", err)
+		}
+		if _, err := repo.GetByName(t.Context(), "Ali"); !errors.Is(err, employee.ErrNotFound) {
+			t.Errorf("GetByName() after SoftDelete error = %v, want %v", err, employee.ErrNotFound)
+		}
+		if err := soft.SoftDelete(t.Context(), "Nobody"); !errors.Is(err, employee.ErrNotFound) {
+			t.Errorf("SoftDelete(missing) error = %v, want %v", err, employee.ErrNotFound)
+		}
+		if err := soft.Restore(t.Context(), "Ali"); err != nil {
+			t.Fatalf("Restore() error = %v", err)
+		}
+		get(t, repo, "Ali")
+	})
+
+	t.Run("get by id", func(t *testing.T) {
+		repo := open(t)
+		save(t, repo, ali())
+		want := get(t, repo, "Ali")
+		if got, err := employee.GetByID(t.Context(), repo, want.ID); err != nil || got.Name != "Ali" || got.Salary != want.Salary {
+			t.Errorf("GetByID(%s) = %q, %v, want Ali", want.ID, got.Name, err)
+		}
+		if _, err := employee.GetByID(t.Context(), repo, "no-such-id"); !errors.Is(err, employee.ErrNotFound) {
+			t.Errorf("GetByID(missing) error = %v, want %v", err, employee.ErrNotFound)
+		}
+	})
+
+	t.Run("all", func(t *testing.T) {
+		repo := open(t)
+		for _, name := range []string{"Sara", "Ali", "Omar"} {
+			emp := ali()
+			emp.Name = name
+			save(t, repo, emp)
+		}
+		var names []string
+		for emp, err := range employee.All(t.Context(), repo) {
+			if err != nil {
+				t.Fatalf("All() error = %v", err)
+			}
+			names = append(names, emp.Name)
+		}
+		if want := []string{"Ali", "Omar", "Sara"}; !slices.Equal(names, want) {
+			t.Errorf("All() = %v, want %v, by name", names, want)
+		}
+	})
+
+	t.Run("list", func(t *testing.T) {
+		usd := func(amount int64) money.Money { return money.Of(amount, money.USD) }
+		tests := []struct {
+			name   string
+			filter employee.Filter
+			want   []string
+		}{
+			{name: "by name", want: []string{"Ali", "Amal", "Amir", "Bea", "Omar", "Sara", "Zoe"}},
+			{name: "prefix", filter: employee.Filter{NamePrefix: "Am"}, want: []string{"Amal", "Amir"}},
+			{name: "prefix in another case", filter: employee.Filter{NamePrefix: "aM"}, want: []string{"Amal", "Amir"}},
+			{name: "prefix with a wildcard", filter: employee.Filter{NamePrefix: "A%"}},
+			{name: "salary range", filter: employee.Filter{MinSalary: usd(4000), MaxSalary: usd(5000)}, want: []string{"Ali", "Amir", "Bea", "Sara"}},
+			{name: "descending", filter: employee.Filter{Descending: true}, want: []string{"Zoe", "Sara", "Omar", "Bea", "Amir", "Amal", "Ali"}},
+			// by currency first, then amount, then name
+			{name: "salary sort", filter: employee.Filter{Sort: employee.SortBySalary}, want: []string{"Zoe", "Omar", "Amir", "Sara", "Ali", "Bea", "Amal"}},
+			{name: "salary sort, descending", filter: employee.Filter{Sort: employee.SortBySalary, Descending: true}, want: []string{"Amal", "Bea", "Ali", "Sara", "Amir", "Omar", "Zoe"}},
+			{name: "everything", filter: employee.Filter{NamePrefix: "A", MinSalary: usd(4500), Sort: employee.SortBySalary, Descending: true}, want: []string{"Amal", "Ali"}},
+		}
+		repo := open(t)
+		q, ok := repo.(employee.QueryRepository)
+		if !ok {
+			t.Skip("not a QueryRepository")
+		}
+		for name, salary := range map[string]money.Money{
+			"Ali": usd(5000), "Amal": usd(6000), "Amir": usd(4000), "Bea": usd(5000),
+			"Omar": usd(3000), "Sara": usd(4500), "Zoe": money.Of(9000, money.EUR),
+		} {
+			emp := ali()
+			emp.Name, emp.Salary = name, salary
+			save(t, repo, emp)
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				for _, limit := range []int{0, 1, 2, 3} {
+					if got := list(t, q, tt.filter, limit); !slices.Equal(got, tt.want) {
+						t.Errorf("List() %d at a time = %v, want %v", limit, got, tt.want)
+					}
+				}
+			})
+		}
+		t.Run("matching", func(t *testing.T) {
+			m, ok := repo.(employee.SpecificationRepository)
+			if !ok {
+				t.Skip("not a SpecificationRepository")
+			}
+			for _, tt := range []struct {
+				spec spec.Specification[employee.Employee]
+				want []string
+			}{
+				{employee.NameStartsWith("aM"), []string{"Amal", "Amir"}},
+				{spec.And(employee.NameStartsWith("a"), employee.SalaryAtLeast(usd(5000))), []string{"Ali", "Amal"}},
+				{employee.NameStartsWith("A_"), nil},
+			} {
+				matched, err := m.Matching(t.Context(), tt.spec)
+				var got []string
+				for _, emp := range matched {
+					got = append(got, emp.Name)
+				}
+				if err != nil || !slices.Equal(got, tt.want) {
+					t.Errorf("Matching() = %v, %v, want %v", got, err, tt.want)
+				}
+			}
+		})
+		t.Run("cursor of another order", func(t *testing.T) {
+			res, err := q.List(t.Context(), employee.Filter{}, employee.Page{Limit: 1})
+			if err != nil || res.NextCursor == "" {
+				t.Fatalf("List() = %v, %v, want a next page", res.NextCursor, err)
+			}
+			page := employee.Page{Cursor: res.NextCursor, Limit: 1}
+			_, err = q.List(t.Context(), employee.Filter{Sort: employee.SortBySalary}, page)
+			if errors.Is(err, errors.ErrUnsupported) {
+				t.Skip("List() by salary is unsupported")
+			}
+			if !errors.Is(err, employee.ErrInvalidCursor) {
+				t.Errorf("List() by salary with a cursor by name error = %v, want %v", err, employee.ErrInvalidCursor)
+			}
+		})
+	})
+
+	t.Run("update", func(t *testing.T) {
+		repo := open(t)
+		u := updater(t, repo)
+		emp := get(t, repo, "Ali")
+		emp.Title = "Lead"
+		stored, err := u.Update(t.Context(), emp, emp.Version)
+		if err != nil || stored.Version != emp.Version+1 || stored.Title != "Lead" {
+			t.Fatalf("Update() = version %d title %q, %v, want Lead at version %d", stored.Version, stored.Title, err, emp.Version+1)
+		}
+		emp.Title = "Manager" // still at the version read before the update
+		_, err = u.Update(t.Context(), emp, emp.Version)
+		var conflict *employee.ConflictError
+		if !errors.As(err, &conflict) || !errors.Is(err, employee.ErrConflict) || conflict.Actual != stored.Version {
+			t.Errorf("Update() at a stale version error = %v, want a conflict at version %d", err, stored.Version)
+		}
+		if got := get(t, repo, "Ali"); got.Title != "Lead" {
+			t.Errorf("GetByName() title = %q after a conflict, want Lead", got.Title)
+		}
+	})
+
+	t.Run("update through a manager", func(t *testing.T) {
+		repo := open(t)
+		updater(t, repo)
+		m := employee.NewManager(repo, employee.WithConflictRetries(1))
+		_, err := m.UpdateEmployee(t.Context(), "Ali", func(emp *employee.Employee) error {
+			// someone else saves Ali between this read and the write
+			other := *emp
+			other.Title = "Lead"
+			save(t, repo, other)
+			emp.Title = "Manager"
+			return nil
+		})
+		if !errors.Is(err, employee.ErrConflict) {
+			t.Errorf("UpdateEmployee() error = %v, want %v: the Manager's update must reach the backend's Update", err, employee.ErrConflict)
+		}
+	})
+
+	t.Run("save all", func(t *testing.T) {
+		repo := open(t)
+		save(t, repo, ali())
+		// two employees with IDs of their own take Ali's name: one early,
+		// one past the first batch of any backend batching by up to 100
+		emps := make([]employee.Employee, 300)
+		for i := range emps {
+			emps[i] = ali()
+			emps[i].Name = fmt.Sprintf("Staff %03d", i)
+		}
+		emps[3].ID, emps[3].Name = "impostor-1", "Ali"
+		emps[257].ID, emps[257].Name = "impostor-2", "Ali"
+		err := employee.SaveAll(t.Context(), repo, slices.Values(emps))
+		var bulk *employee.BulkError
+		if !errors.As(err, &bulk) {
+			t.Fatalf("SaveAll() error = %v, want a *BulkError", err)
+		}
+		if bulk.Saved != 298 || bulk.Stopped != nil || len(bulk.Failed) != 2 {
+			t.Fatalf("SaveAll() = %v, want 298 saved and 2 failed", err)
+		}
+		for i, index := range []int{3, 257} {
+			if f := bulk.Failed[i]; f.Index != index || f.Name != "Ali" || !errors.Is(f.Err, employee.ErrNameTaken) {
+				t.Errorf("Failed[%d] = #%d %q %v, want #%d \"Ali\" %v", i, f.Index, f.Name, f.Err, index, employee.ErrNameTaken)
+			}
+		}
+		get(t, repo, "Staff 002")
+		get(t, repo, "Staff 299")
+		if got := get(t, repo, "Ali"); got.ID == "impostor-1" || got.ID == "impostor-2" {
+			t.Errorf("Ali's ID = %q, want the original kept", got.ID)
+		}
+	})
+
+	t.Run("save all stopped", func(t *testing.T) {
+		repo := open(t)
+		ctx, cancel := context.WithCancel(t.Context())
+		defer cancel()
+		err := employee.SaveAll(ctx, repo, func(yield func(employee.Employee) bool) {
+			for i := range 300 {
+				if i == 150 {
+					cancel()
+				}
+				emp := ali()
+				emp.Name = fmt.Sprintf("Staff %03d", i)
+				if !yield(emp) {
+					return
+				}
+			}
+		})
+		var bulk *employee.BulkError
+		if !errors.As(err, &bulk) || !errors.Is(err, context.Canceled) || len(bulk.Failed) != 0 {
+			t.Fatalf("SaveAll() error = %v, want it stopped by the cancellation", err)
+		}
+		if bulk.Saved == 300 {
+			t.Errorf("SaveAll() saved all 300, want the save stopped")
+		}
+		// whatever was reported saved was saved, in sequence order
+		for i := range bulk.Saved {
+			get(t, repo, fmt.Sprintf("Staff %03d", i))
+		}
+	})
+
+	t.Run("outbox", func(t *testing.T) {
+		repo := open(t)
+		o, ok := repo.(employee.OutboxRepository)
+		store, isStore := repo.(outbox.Store)
+		if !ok || !isStore {
+			t.Skip("backend has no outbox")
+		}
+		var msgs []outbox.Message
+		for _, id := range []string{"msg-1", "msg-2", "msg-3"} {
+			msgs = append(msgs, outbox.Message{ID: id, Topic: "employee.hired", Key: "Ali", Payload: []byte(`{}`), CreatedAt: hired})
+		}
+		if err := o.SaveWithOutbox(t.Context(), ali(), msgs); errors.Is(err, errors.ErrUnsupported) {
+			t.Skip("backend has no outbox")
+		} else if err != nil {
+			t.Fatalf("SaveWithOutbox() error = %v", err)
+		}
+		for _, limit := range []int{0, -1} {
+			if got, err := store.Pending(t.Context(), limit); err != nil || len(got) != 0 {
+				t.Errorf("Pending(%d) = %d messages, %v; want none", limit, len(got), err)
+			}
+		}
+		if got := pending(t, store, 2); !slices.Equal(got, []string{"msg-1", "msg-2"}) {
+			t.Errorf("Pending(2) = %q, want the oldest two", got)
+		}
+		if err := store.MarkPublished(t.Context(), "msg-1"); err != nil {
+			t.Fatalf("MarkPublished() error = %v", err)
+		}
+		if got := pending(t, store, 10); !slices.Equal(got, []string{"msg-2", "msg-3"}) {
+			t.Errorf("Pending(10) after msg-1 published = %q, want msg-2 and msg-3", got)
+		}
+	})
+
+	t.Run("same as memory", func(t *testing.T) {
+		differential.Check(t, memory.New(), open(t), differential.Options{Seed: 1})
+	})
+}
+
+// pending is the IDs of store's first limit unpublished messages.
+func pending(t *testing.T, store outbox.Store, limit int) []string {
+	t.Helper()
+	msgs, err := store.Pending(t.Context(), limit)
+	if err != nil {
+		t.Fatalf("Pending(%d) error = %v", limit, err)
+	}
+	var ids []string
+	for _, m := range msgs {
+		ids = append(ids, m.ID)
+	}
+	return ids
+}
+
+func save(t *testing.T, repo employee.Repository, emp employee.Employee) {
+	t.Helper()
+	if err := repo.Save(t.Context(), emp); err != nil {
+		t.Fatalf("Save(%q) error = %v", emp.Name, err)
+	}
+}
+
+// list pages through everything filter matches, limit at a time, and
+// returns the names in the order they came. A name seen twice fails the
+// test: a cursor must resume just past the last item.
+func list(t *testing.T, q employee.QueryRepository, filter employee.Filter, limit int) []string {
+	t.Helper()
+	var names []string
+	seen := make(map[string]bool)
+	page := employee.Page{Limit: limit}
+	for {
+		res, err := q.List(t.Context(), filter, page)
+		if errors.Is(err, errors.ErrUnsupported) {
+			t.Skipf("List(%+v) is unsupported", filter)
+		}
+		if err != nil {
+			t.Fatalf("List() error = %v", err)
+		}
+		if limit > 0 && len(res.Items) > limit {
+			t.Fatalf("List() = %d items, want at most %d", len(res.Items), limit)
+		}
+		for _, emp := range res.Items {
+			if seen[emp.Name] {
+				t.Fatalf("List() %d at a time returned %s twice", limit, emp.Name)
+			}
+			seen[emp.Name] = true
+			names = append(names, emp.Name)
+		}
+		if res.NextCursor == "" {
+			return names
+		}
+		page.Cursor = res.NextCursor
+	}
+}
+
+// updater saves Ali in repo and returns repo's employee.Updater, skipping
+// the case when it has none or its backend hasn't.
+func updater(t *testing.T, repo employee.Repository) employee.Updater {
+	t.Helper()
+	u, ok := repo.(employee.Updater)
+	if !ok {
+		t.Skip("not an Updater")
+	}
+	save(t, repo, ali())
+	emp := get(t, repo, "Ali")
+	if _, err := u.Update(t.Context(), emp, emp.Version); errors.Is(err, errors.ErrUnsupported) {
+		t.Skip("Update is unsupported by the backend")
+	} else if err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	return u
+}
+
+func get(t *testing.T, repo employee.Repository, name string) employee.Employee {
+	t.Helper()
+	emp, err := repo.GetByName(t.Context(), name)
+	if err != nil {
+		t.Fatalf("GetByName(%q) error = %v", name, err)
+	}
+	return emp
+}
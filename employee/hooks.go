@@ -0,0 +1,107 @@
+package employee
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+)
+
+// Hook A plugin reacting to the Manager's saves. It implements BeforeSaver,
+// AfterSaver or both; Name identifies it in errors and logs.
+type Hook interface {
+	Name() string
+}
+
+// BeforeSaver Hook validating an employee before it is stored. An error
+// vetoes the save: nothing is stored, and the Manager returns the errors of
+// every BeforeSaver that objected, wrapped in ErrVetoed.
+type BeforeSaver interface {
+	BeforeSave(ctx context.Context, emp Employee) error
+}
+
+// AfterSaver Hook reacting to an employee once it is stored - indexing,
+// notifying, recording. It can't undo the save, so its error is logged and
+// the save still succeeds.
+type AfterSaver interface {
+	AfterSave(ctx context.Context, emp Employee) error
+}
+
+// ErrVetoed returned when a BeforeSaver rejects a save
+var ErrVetoed = errors.New("save vetoed")
+
+// ErrNotAHook returned by Register for a Hook that is neither a BeforeSaver
+// nor an AfterSaver, and would never run
+var ErrNotAHook = errors.New("hook implements neither BeforeSave nor AfterSave")
+
+// Hooks Registry of the Manager's save hooks, run in registration order. Hooks
+// may be registered while the Manager runs.
+type Hooks struct {
+	mu    sync.RWMutex
+	hooks []Hook
+}
+
+// Register adds hooks after those already registered.
+func (h *Hooks) Register(hooks ...Hook) error {
+	for _, hook := range hooks {
+		_, before := hook.(BeforeSaver)
+		_, after := hook.(AfterSaver)
+		if !before && !after {
+			return fmt.Errorf("register %s: %w", hook.Name(), ErrNotAHook)
+		}
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.hooks = append(h.hooks, hooks...)
+	return nil
+}
+
+// Names returns the registered hooks' names, in the order they run.
+func (h *Hooks) Names() []string {
+	var names []string
+	for _, hook := range h.snapshot() {
+		names = append(names, hook.Name())
+	}
+	return names
+}
+
+func (h *Hooks) snapshot() []Hook {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return slices.Clone(h.hooks)
+}
+
+// before is the first phase of a save: every BeforeSaver sees emp, so the
+// caller learns of every objection at once.
+func (h *Hooks) before(ctx context.Context, emp Employee) error {
+	var errs []error
+	for _, hook := range h.snapshot() {
+		if b, ok := hook.(BeforeSaver); ok {
+			if err := b.BeforeSave(ctx, emp); err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", hook.Name(), err))
+			}
+		}
+	}
+	if len(errs) == 0 {
+		return nil
+	}
+	return fmt.Errorf("%w: %w", ErrVetoed, errors.Join(errs...))
+}
+
+// after is the second phase, once emp is stored. It returns the errors of the
+// AfterSavers that failed, for the Manager to log.
+func (h *Hooks) after(ctx context.Context, emp Employee) map[string]error {
+	var failed map[string]error
+	for _, hook := range h.snapshot() {
+		if a, ok := hook.(AfterSaver); ok {
+			if err := a.AfterSave(ctx, emp); err != nil {
+				if failed == nil {
+					failed = map[string]error{}
+				}
+				failed[hook.Name()] = err
+			}
+		}
+	}
+	return failed
+}
@@ -0,0 +1,49 @@
+package employee
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// ID Identifies an employee for life. A name can be misspelt, changed or
+// shared; the ID can't, so repositories key employees by it. Its format is
+// the Manager's id.Generator's to choose (WithIDs): UUIDv7 by default.
+type ID string
+
+func (id ID) String() string { return string(id) }
+
+// ErrNameTaken returned by repositories saving an employee under a name
+// another employee already has
+var ErrNameTaken = errors.New("name belongs to another employee")
+
+// IDRepository Optional capability - backends keyed by ID, which find an
+// employee by it directly. Names stay unique through an index, so GetByName
+// keeps working for callers that only know the name.
+//
+// An employee saved without an ID is the employee stored under its name, if
+// any, and is given a new ID otherwise. Saving an employee under another
+// name renames it; the new name must be free (ErrNameTaken).
+//
+// Decorators forward it by calling GetByID on what they wrap: without it,
+// every lookup by ID would look through every employee.
+type IDRepository interface {
+	GetByID(ctx context.Context, id ID) (Employee, error)
+}
+
+// GetByID finds the employee with id through repo's IDRepository capability,
+// or by looking through every employee when it has none.
+func GetByID(ctx context.Context, repo Repository, id ID) (Employee, error) {
+	if r, ok := repo.(IDRepository); ok {
+		return r.GetByID(ctx, id)
+	}
+	for emp, err := range All(ctx, repo) {
+		if err != nil {
+			return Employee{}, fmt.Errorf("get employee %s: %w", id, err)
+		}
+		if emp.ID == id {
+			return emp, nil
+		}
+	}
+	return Employee{}, ErrNotFound
+}
@@ -0,0 +1,59 @@
+package employee
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"iter"
+)
+
+// Iterable Optional capability - backends that can stream every employee, by
+// name, without the caller paging. The backend holds its cursor, rows or
+// lock only while the loop runs: breaking out of it releases them.
+//
+// Decorators forward it by calling All on what they wrap, so a backend's
+// stream is not turned back into pages by the decorator in front of it.
+type Iterable interface {
+	All(ctx context.Context) iter.Seq2[Employee, error]
+}
+
+// All yields every employee of repo, by name: through its Iterable
+// capability when it has one, a page at a time through QueryRepository
+// otherwise. An error is yielded once, with a zero Employee, and ends the
+// sequence; a loop that stops early stops the backend too.
+//
+//	for emp, err := range employee.All(ctx, repo) {
+//		if err != nil {
+//			return err
+//		}
+//		...
+//	}
+func All(ctx context.Context, repo Repository) iter.Seq2[Employee, error] {
+	if it, ok := repo.(Iterable); ok {
+		return it.All(ctx)
+	}
+	return func(yield func(Employee, error) bool) {
+		q, ok := repo.(QueryRepository)
+		if !ok {
+			yield(Employee{}, fmt.Errorf("repository cannot list employees: %w", errors.ErrUnsupported))
+			return
+		}
+		cursor := ""
+		for {
+			res, err := q.List(ctx, Filter{}, Page{Cursor: cursor, Limit: MaxPageSize})
+			if err != nil {
+				yield(Employee{}, err)
+				return
+			}
+			for _, emp := range res.Items {
+				if !yield(emp, nil) {
+					return
+				}
+			}
+			if res.NextCursor == "" {
+				return
+			}
+			cursor = res.NextCursor
+		}
+	}
+}
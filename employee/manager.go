@@ -0,0 +1,328 @@
+package employee
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"go-solid/audit"
+	"go-solid/clock"
+	"go-solid/events"
+	"go-solid/id"
+	"go-solid/money"
+	"go-solid/nullobj"
+	"go-solid/outbox"
+	"go-solid/spec"
+)
+
+// Manager High-level module - employee use cases, depends only on abstractions
+type Manager struct {
+	repository Repository
+	ids        id.Generator
+	clock      clock.Clock
+	audit      audit.Sink
+	events     events.Dispatcher
+	logger     *slog.Logger
+	outbox     bool
+	attempts   int
+	hooks      *Hooks
+}
+
+// Option customises a Manager created by NewManager
+type Option func(*Manager)
+
+func WithIDs(g id.Generator) Option         { return func(m *Manager) { m.ids = g } }
+func WithClock(c clock.Clock) Option        { return func(m *Manager) { m.clock = c } }
+func WithAudit(s audit.Sink) Option         { return func(m *Manager) { m.audit = s } }
+func WithEvents(d events.Dispatcher) Option { return func(m *Manager) { m.events = d } }
+func WithLogger(l *slog.Logger) Option      { return func(m *Manager) { m.logger = l } }
+
+// WithOutbox writes domain events to the repository's outbox, in the same
+// transaction as the change, instead of dispatching them; an outbox.Relay
+// publishes them. The repository must be an OutboxRepository.
+func WithOutbox() Option { return func(m *Manager) { m.outbox = true } }
+
+// WithHooks runs the save hooks registered in h; more can be registered
+// later through Manager.Hooks.
+func WithHooks(h *Hooks) Option { return func(m *Manager) { m.hooks = h } }
+
+// WithConflictRetries makes UpdateEmployee, ChangeSalary and Promote try up
+// to n times in all when another writer got there first; 3 by default.
+func WithConflictRetries(n int) Option { return func(m *Manager) { m.attempts = n } }
+
+// NewManager creates a Manager on top of the given repository. Without options
+// it uses random UUIDs and the real clock; audit records, events and logs go
+// to null objects, so no collaborator is ever nil. A nil repository is
+// replaced by NopRepository.
+func NewManager(repo Repository, opts ...Option) *Manager {
+	if repo == nil {
+		repo = NopRepository{}
+	}
+	m := &Manager{
+		repository: repo,
+		ids:        id.UUIDv7{},
+		clock:      clock.Real{},
+		audit:      nullobj.NopAuditSink{},
+		events:     nullobj.NopDispatcher{},
+		logger:     nullobj.NopLogger(),
+		attempts:   3,
+		hooks:      &Hooks{},
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	if m.hooks == nil {
+		m.hooks = &Hooks{}
+	}
+	return m
+}
+
+// Hooks returns the registry of save hooks, for plugins to register with.
+func (m *Manager) Hooks() *Hooks { return m.hooks }
+
+// AddEmployee hires emp: it assigns an ID and hire date, checks the
+// aggregate's invariants and stores it.
+func (m *Manager) AddEmployee(ctx context.Context, emp Employee) (Employee, error) {
+	hired, err := Hire(ID(m.ids.NewID()), emp.Name, emp.Title, emp.Salary, m.clock.Now())
+	if err == nil {
+		hired.Email, hired.Department = emp.Email, emp.Department
+		err = m.save(ctx, &hired)
+	}
+	m.record(ctx, "employee.added", string(hired.ID), map[string]any{"name": emp.Name, "salary": emp.Salary}, err)
+	if err != nil {
+		return Employee{}, fmt.Errorf("add employee %q: %w", emp.Name, err)
+	}
+	return hired, nil
+}
+
+// ChangeSalary loads the employee, applies the new salary and stores it.
+func (m *Manager) ChangeSalary(ctx context.Context, name string, salary money.Money) (Employee, error) {
+	emp, err := m.update(ctx, name, func(emp *Employee) error { return emp.ChangeSalary(salary, m.clock.Now()) })
+	m.record(ctx, "employee.salary_changed", string(emp.ID), map[string]any{"name": name, "salary": salary}, err)
+	if err != nil {
+		return Employee{}, fmt.Errorf("change salary of %q: %w", name, err)
+	}
+	return emp, nil
+}
+
+// Promote loads the employee, promotes them and stores the result.
+func (m *Manager) Promote(ctx context.Context, name, title string, raise money.Money) (Employee, error) {
+	emp, err := m.update(ctx, name, func(emp *Employee) error { return emp.Promote(title, raise, m.clock.Now()) })
+	m.record(ctx, "employee.promoted", string(emp.ID), map[string]any{"name": name, "title": title, "raise": raise}, err)
+	if err != nil {
+		return Employee{}, fmt.Errorf("promote %q: %w", name, err)
+	}
+	return emp, nil
+}
+
+// UpdateEmployee loads the employee, applies change and stores the result.
+// On an Updater backend the store only happens if nobody saved the employee
+// in between; if somebody did, the employee is loaded again and change runs
+// again on what they saved, up to WithConflictRetries attempts in all. change
+// may thus run more than once, and must only modify emp. Other backends store
+// unconditionally.
+func (m *Manager) UpdateEmployee(ctx context.Context, name string, change func(emp *Employee) error) (Employee, error) {
+	emp, err := m.update(ctx, name, change)
+	m.record(ctx, "employee.updated", string(emp.ID), map[string]any{"name": name}, err)
+	if err != nil {
+		return Employee{}, fmt.Errorf("update %q: %w", name, err)
+	}
+	return emp, nil
+}
+
+// update is the read-modify-write loop behind UpdateEmployee.
+func (m *Manager) update(ctx context.Context, name string, change func(emp *Employee) error) (Employee, error) {
+	for attempt := 1; ; attempt++ {
+		emp, err := m.repository.GetByName(ctx, name)
+		if err == nil {
+			err = change(&emp)
+		}
+		if err == nil {
+			err = m.save(ctx, &emp)
+		}
+		if errors.Is(err, ErrConflict) && attempt < m.attempts && ctx.Err() == nil {
+			m.logger.DebugContext(ctx, "retrying after a conflict", "name", name, "attempt", attempt)
+			continue
+		}
+		return emp, err
+	}
+}
+
+// save persists the aggregate in two phases: the BeforeSave hooks may veto
+// it, then it is stored and the AfterSave hooks run. Events are only
+// published once the change is stored - or, with an outbox, stored along
+// with it. An employee that was read from an Updater (Version > 0) is stored
+// only if it is still at that version; SaveWithOutbox has no such condition.
+func (m *Manager) save(ctx context.Context, emp *Employee) error {
+	if err := m.hooks.before(ctx, *emp); err != nil {
+		return err
+	}
+	evts := emp.PullEvents()
+	if m.outbox {
+		if err := m.saveWithOutbox(ctx, emp, evts); err != nil {
+			return err
+		}
+		m.afterSave(ctx, *emp)
+		return nil
+	}
+	if err := m.store(ctx, emp); err != nil {
+		return err
+	}
+	defer m.afterSave(ctx, *emp)
+	if err := m.events.Dispatch(ctx, evts...); err != nil {
+		return fmt.Errorf("saved, but event delivery failed: %w", err)
+	}
+	m.logger.InfoContext(ctx, "employee saved", "id", emp.ID, "name", emp.Name, "events", len(evts))
+	return nil
+}
+
+// afterSave runs the AfterSave hooks. The employee is stored whatever they
+// do, so their failures are logged rather than returned, as with auditing.
+func (m *Manager) afterSave(ctx context.Context, emp Employee) {
+	for name, err := range m.hooks.after(ctx, emp) {
+		m.logger.WarnContext(ctx, "after-save hook failed", "hook", name, "name", emp.Name, "err", err)
+	}
+}
+
+func (m *Manager) store(ctx context.Context, emp *Employee) error {
+	u, ok := m.repository.(Updater)
+	if !ok || emp.Version == 0 {
+		return m.repository.Save(ctx, *emp)
+	}
+	stored, err := u.Update(ctx, *emp, emp.Version)
+	if errors.Is(err, errors.ErrUnsupported) { // a decorator whose backend can't
+		return m.repository.Save(ctx, *emp)
+	}
+	if err != nil {
+		return err
+	}
+	emp.Version = stored.Version
+	return nil
+}
+
+func (m *Manager) saveWithOutbox(ctx context.Context, emp *Employee, evts []events.Event) error {
+	repo, ok := m.repository.(OutboxRepository)
+	if !ok {
+		return fmt.Errorf("outbox: %w", errors.ErrUnsupported)
+	}
+	msgs, err := outbox.Encode(m.ids, m.clock.Now(), evts...)
+	if err != nil {
+		return err
+	}
+	if err := repo.SaveWithOutbox(ctx, *emp, msgs); err != nil {
+		return err
+	}
+	m.logger.InfoContext(ctx, "employee saved", "id", emp.ID, "name", emp.Name, "outbox", len(msgs))
+	return nil
+}
+
+// FindEmployee looks an employee up by name.
+func (m *Manager) FindEmployee(ctx context.Context, name string) (Employee, error) {
+	emp, err := m.repository.GetByName(ctx, name)
+	m.record(ctx, "employee.viewed", string(emp.ID), map[string]any{"name": name}, err)
+	if err != nil {
+		return Employee{}, fmt.Errorf("find employee %q: %w", name, err)
+	}
+	return emp, nil
+}
+
+// FindEmployeeByID finds an employee by the ID they were hired with, which
+// stays the same when they are renamed.
+func (m *Manager) FindEmployeeByID(ctx context.Context, id ID) (Employee, error) {
+	emp, err := GetByID(ctx, m.repository, id)
+	m.record(ctx, "employee.viewed", string(id), map[string]any{"name": emp.Name}, err)
+	if err != nil {
+		return Employee{}, fmt.Errorf("find employee %s: %w", id, err)
+	}
+	return emp, nil
+}
+
+// RemoveEmployee soft-deletes an employee. Not every backend can do that, so
+// the capability is detected at runtime instead of being forced on all of them.
+func (m *Manager) RemoveEmployee(ctx context.Context, name string) error {
+	deleter, ok := m.repository.(SoftDeleter)
+	if !ok {
+		return fmt.Errorf("remove employee %q: %w", name, errors.ErrUnsupported)
+	}
+	err := deleter.SoftDelete(ctx, name)
+	m.record(ctx, "employee.removed", "", map[string]any{"name": name}, err)
+	if err != nil {
+		return fmt.Errorf("remove employee %q: %w", name, err)
+	}
+	return nil
+}
+
+// RestoreEmployee brings back a soft-deleted employee.
+func (m *Manager) RestoreEmployee(ctx context.Context, name string) error {
+	deleter, ok := m.repository.(SoftDeleter)
+	if !ok {
+		return fmt.Errorf("restore employee %q: %w", name, errors.ErrUnsupported)
+	}
+	err := deleter.Restore(ctx, name)
+	m.record(ctx, "employee.restored", "", map[string]any{"name": name}, err)
+	if err != nil {
+		return fmt.Errorf("restore employee %q: %w", name, err)
+	}
+	return nil
+}
+
+// EmployeeHistory returns every stored revision, if the backend keeps them.
+func (m *Manager) EmployeeHistory(ctx context.Context, name string) ([]Employee, error) {
+	versioned, ok := m.repository.(Versioned)
+	if !ok {
+		return nil, fmt.Errorf("history of %q: %w", name, errors.ErrUnsupported)
+	}
+	history, err := versioned.History(ctx, name)
+	if err != nil {
+		return nil, fmt.Errorf("history of %q: %w", name, err)
+	}
+	return history, nil
+}
+
+// ListEmployees returns one page of employees matching the filter.
+func (m *Manager) ListEmployees(ctx context.Context, filter Filter, page Page) (PageResult, error) {
+	querier, ok := m.repository.(QueryRepository)
+	if !ok {
+		return PageResult{}, fmt.Errorf("list employees: %w", errors.ErrUnsupported)
+	}
+	result, err := querier.List(ctx, filter, page)
+	if err != nil {
+		return PageResult{}, fmt.Errorf("list employees: %w", err)
+	}
+	return result, nil
+}
+
+// FindEmployees returns every employee satisfying the specification, ordered by name.
+func (m *Manager) FindEmployees(ctx context.Context, s spec.Specification[Employee]) ([]Employee, error) {
+	finder, ok := m.repository.(SpecificationRepository)
+	if !ok {
+		return nil, fmt.Errorf("find employees: %w", errors.ErrUnsupported)
+	}
+	emps, err := finder.Matching(ctx, s)
+	if err != nil {
+		return nil, fmt.Errorf("find employees: %w", err)
+	}
+	return emps, nil
+}
+
+// record hands an audit record to the sink. Auditing must never break the
+// business operation, so sink failures are logged rather than returned.
+func (m *Manager) record(ctx context.Context, action, entityID string, details map[string]any, opErr error) {
+	rec := audit.Record{
+		Time:     m.clock.Now(),
+		Actor:    audit.ActorFrom(ctx),
+		Action:   action,
+		Entity:   "employee",
+		EntityID: entityID,
+		Details:  details,
+		Outcome:  audit.Success,
+	}
+	if opErr != nil {
+		rec.Outcome = audit.Failure
+		rec.Error = opErr.Error()
+	}
+	if err := m.audit.Write(ctx, rec); err != nil {
+		m.logger.WarnContext(ctx, "audit write failed", "action", action, "err", err)
+	}
+}
@@ -0,0 +1,326 @@
+// Package memory is an in-process employee.Repository, handy for demos and tests.
+package memory
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"iter"
+	"slices"
+	"sort"
+	"sync"
+
+	"go-solid/employee"
+	"go-solid/id"
+	"go-solid/normalize"
+	"go-solid/outbox"
+	"go-solid/spec"
+)
+
+type row struct {
+	current employee.Employee
+	history []employee.Employee
+	deleted bool
+}
+
+// Repository Low-level module - map-backed employee.Repository, keyed by ID
+// with an index of names.
+// Also implements the employee.IDRepository, employee.SoftDeleter,
+// employee.Versioned, employee.Updater, employee.QueryRepository,
+// employee.Iterable, employee.SpecificationRepository, employee.BulkSaver and
+// employee.OutboxRepository capabilities, and is the outbox.Store for its own
+// outbox.
+type Repository struct {
+	mu     sync.RWMutex
+	byID   map[employee.ID]*row
+	byName map[string]*row  // by normalized name
+	outbox []outbox.Message // unpublished, oldest first
+	names  normalize.Normalizer
+	ids    id.Generator
+}
+
+// Option customises a Repository created by New
+type Option func(*Repository)
+
+// WithNormalizer compares names through n; normalize.Name by default, so
+// "mohamed" finds Mohamed. normalize.Exact compares them as written.
+func WithNormalizer(n normalize.Normalizer) Option {
+	return func(r *Repository) { r.names = n }
+}
+
+// WithIDs gives employees saved without an ID one from g; id.UUIDv7 by
+// default.
+func WithIDs(g id.Generator) Option { return func(r *Repository) { r.ids = g } }
+
+func New(opts ...Option) *Repository {
+	r := &Repository{byID: make(map[employee.ID]*row), byName: make(map[string]*row), names: normalize.Name, ids: id.UUIDv7{}}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
+}
+
+// row returns the row stored under name, if any.
+func (r *Repository) row(name string) (*row, bool) {
+	rw, ok := r.byName[r.names.Normalize(name)]
+	return rw, ok
+}
+
+// find returns the row of emp: by ID, or by name when it has none.
+func (r *Repository) find(emp employee.Employee) (*row, bool) {
+	if emp.ID == "" {
+		return r.row(emp.Name)
+	}
+	rw, ok := r.byID[emp.ID]
+	return rw, ok
+}
+
+func (r *Repository) Save(ctx context.Context, emp employee.Employee) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	_, err := r.save(emp)
+	return err
+}
+
+// SaveAll takes the lock once per batch rather than once per employee, and
+// never while the caller's sequence is producing the next one.
+func (r *Repository) SaveAll(ctx context.Context, emps iter.Seq[employee.Employee]) error {
+	report := &employee.BulkError{}
+	offset := 0
+	for batch := range employee.Batches(emps, batchSize) {
+		if err := ctx.Err(); err != nil {
+			report.Stopped = err
+			break
+		}
+		r.mu.Lock()
+		for i, emp := range batch {
+			_, err := r.save(emp)
+			report.Add(offset+i, emp, err)
+		}
+		r.mu.Unlock()
+		offset += len(batch)
+	}
+	return report.Err()
+}
+
+const batchSize = 256
+
+// save stores emp under its ID, indexed by its normalized name. Without an
+// ID it is the employee of that name, or a new one with a new ID; under a
+// new name, the employee is renamed.
+func (r *Repository) save(emp employee.Employee) (*row, error) {
+	key := r.names.Normalize(emp.Name)
+	named := r.byName[key]
+	rw, ok := r.find(emp)
+	if named != nil && named != rw {
+		return nil, fmt.Errorf("save %q: %w", emp.Name, employee.ErrNameTaken)
+	}
+	if !ok {
+		if emp.ID == "" {
+			emp.ID = employee.ID(r.ids.NewID())
+		}
+		rw = &row{}
+		r.byID[emp.ID] = rw
+	}
+	emp.ID = cmp.Or(emp.ID, rw.current.ID)
+	if len(rw.history) > 0 {
+		delete(r.byName, r.names.Normalize(rw.current.Name))
+	}
+	r.byName[key] = rw
+	emp.Version = len(rw.history) + 1
+	rw.current = emp
+	rw.history = append(rw.history, emp)
+	rw.deleted = false
+	return rw, nil
+}
+
+// SaveWithOutbox stores emp and queues msgs under the same lock: either both
+// are visible or neither is.
+func (r *Repository) SaveWithOutbox(ctx context.Context, emp employee.Employee, msgs []outbox.Message) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, err := r.save(emp); err != nil {
+		return err
+	}
+	r.outbox = append(r.outbox, msgs...)
+	return nil
+}
+
+func (r *Repository) Pending(ctx context.Context, limit int) ([]outbox.Message, error) {
+	if limit <= 0 {
+		return nil, nil
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return slices.Clone(r.outbox[:min(limit, len(r.outbox))]), nil
+}
+
+func (r *Repository) MarkPublished(ctx context.Context, ids ...string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.outbox = slices.DeleteFunc(r.outbox, func(m outbox.Message) bool { return slices.Contains(ids, m.ID) })
+	return nil
+}
+
+// Update stores emp if the employee is at expectedVersion, under the same
+// lock as the check.
+func (r *Repository) Update(ctx context.Context, emp employee.Employee, expectedVersion int) (employee.Employee, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	actual := 0
+	if rw, ok := r.find(emp); ok && !rw.deleted {
+		actual = rw.current.Version
+	}
+	switch {
+	case expectedVersion > 0 && actual == 0:
+		return employee.Employee{}, employee.ErrNotFound
+	case actual != expectedVersion:
+		return employee.Employee{}, &employee.ConflictError{Name: emp.Name, Expected: expectedVersion, Actual: actual}
+	}
+	rw, err := r.save(emp)
+	if err != nil {
+		return employee.Employee{}, err
+	}
+	return rw.current, nil
+}
+
+func (r *Repository) GetByName(ctx context.Context, name string) (employee.Employee, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	rw, ok := r.row(name)
+	if !ok || rw.deleted {
+		return employee.Employee{}, employee.ErrNotFound
+	}
+	return rw.current, nil
+}
+
+func (r *Repository) GetByID(ctx context.Context, id employee.ID) (employee.Employee, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	rw, ok := r.byID[id]
+	if !ok || rw.deleted {
+		return employee.Employee{}, employee.ErrNotFound
+	}
+	return rw.current, nil
+}
+
+func (r *Repository) SoftDelete(ctx context.Context, name string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	rw, ok := r.row(name)
+	if !ok || rw.deleted {
+		return employee.ErrNotFound
+	}
+	rw.deleted = true
+	return nil
+}
+
+func (r *Repository) Restore(ctx context.Context, name string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	rw, ok := r.row(name)
+	if !ok || !rw.deleted {
+		return employee.ErrNotFound
+	}
+	rw.deleted = false
+	return nil
+}
+
+func (r *Repository) History(ctx context.Context, name string) ([]employee.Employee, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	rw, ok := r.row(name)
+	if !ok {
+		return nil, employee.ErrNotFound
+	}
+	return append([]employee.Employee(nil), rw.history...), nil
+}
+
+func (r *Repository) List(ctx context.Context, filter employee.Filter, page employee.Page) (employee.PageResult, error) {
+	var cursor *employee.Cursor
+	if page.Cursor != "" {
+		c, err := employee.DecodeCursor(filter, page.Cursor)
+		if err != nil {
+			return employee.PageResult{}, err
+		}
+		cursor = &c
+	}
+
+	r.mu.RLock()
+	var matches []employee.Employee
+	for _, rw := range r.byID {
+		if rw.deleted || !filter.Matches(r.names, rw.current) {
+			continue
+		}
+		if cursor != nil && !cursor.After(filter, rw.current) {
+			continue
+		}
+		matches = append(matches, rw.current)
+	}
+	r.mu.RUnlock()
+
+	sort.Slice(matches, func(i, j int) bool { return filter.Before(matches[i], matches[j]) })
+
+	var result employee.PageResult
+	if size := page.Size(); len(matches) > size {
+		matches = matches[:size]
+		result.NextCursor = employee.CursorAfter(filter, matches[size-1])
+	}
+	result.Items = matches
+	return result, nil
+}
+
+// All yields a snapshot taken when the loop starts. The lock is not held
+// while the loop body runs, so the body may Save.
+func (r *Repository) All(ctx context.Context) iter.Seq2[employee.Employee, error] {
+	return func(yield func(employee.Employee, error) bool) {
+		r.mu.RLock()
+		var all []employee.Employee
+		for _, rw := range r.byID {
+			if !rw.deleted {
+				all = append(all, rw.current)
+			}
+		}
+		r.mu.RUnlock()
+
+		sort.Slice(all, func(i, j int) bool { return all[i].Name < all[j].Name })
+		for _, emp := range all {
+			if err := ctx.Err(); err != nil {
+				yield(employee.Employee{}, err)
+				return
+			}
+			if !yield(emp, nil) {
+				return
+			}
+		}
+	}
+}
+
+func (r *Repository) Matching(ctx context.Context, s spec.Specification[employee.Employee]) ([]employee.Employee, error) {
+	s = employee.BindNames(s, r.names)
+	r.mu.RLock()
+	var matched []employee.Employee
+	for _, rw := range r.byID {
+		if !rw.deleted && s.IsSatisfiedBy(rw.current) {
+			matched = append(matched, rw.current)
+		}
+	}
+	r.mu.RUnlock()
+
+	sort.Slice(matched, func(i, j int) bool { return matched[i].Name < matched[j].Name })
+	return matched, nil
+}
+
+var (
+	_ employee.Repository              = (*Repository)(nil)
+	_ employee.IDRepository            = (*Repository)(nil)
+	_ employee.SoftDeleter             = (*Repository)(nil)
+	_ employee.Updater                 = (*Repository)(nil)
+	_ employee.Versioned               = (*Repository)(nil)
+	_ employee.QueryRepository         = (*Repository)(nil)
+	_ employee.Iterable                = (*Repository)(nil)
+	_ employee.SpecificationRepository = (*Repository)(nil)
+	_ employee.BulkSaver               = (*Repository)(nil)
+	_ employee.OutboxRepository        = (*Repository)(nil)
+	_ outbox.Store                     = (*Repository)(nil)
+)
@@ -0,0 +1,13 @@
+package memory_test
+
+import (
+	"testing"
+
+	"go-solid/employee"
+	"go-solid/employee/employeetest"
+	"go-solid/employee/memory"
+)
+
+func TestRepository(t *testing.T) {
+	employeetest.TestRepository(t, func(*testing.T) employee.Repository { return memory.New() })
+}
@@ -0,0 +1,14 @@
+package employee
+
+import "context"
+
+// NopRepository Null Object - stores nothing, so it never finds anything.
+// It lives next to Repository rather than in package nullobj because the
+// Manager uses it as a default, and nullobj cannot import this package back.
+type NopRepository struct{}
+
+func (NopRepository) Save(context.Context, Employee) error { return nil }
+
+func (NopRepository) GetByName(context.Context, string) (Employee, error) {
+	return Employee{}, ErrNotFound
+}
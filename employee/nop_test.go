@@ -0,0 +1,34 @@
+package employee_test
+
+import (
+	"errors"
+	"testing"
+
+	"go-solid/employee"
+	"go-solid/money"
+)
+
+func TestNopRepository_StoresNothing(t *testing.T) {
+	repo := employee.NopRepository{}
+	if err := repo.Save(t.Context(), employee.Employee{Name: "Ali"}); err != nil {
+		t.Fatalf("Save() error = %v, want nil", err)
+	}
+	if _, err := repo.GetByName(t.Context(), "Ali"); !errors.Is(err, employee.ErrNotFound) {
+		t.Errorf("GetByName() error = %v, want %v", err, employee.ErrNotFound)
+	}
+}
+
+func TestNewManager_NullObjectDefaults(t *testing.T) {
+	// no repository and no options: every collaborator is a null object
+	m := employee.NewManager(nil)
+	emp, err := m.AddEmployee(t.Context(), employee.Employee{Name: "Ali", Title: "Engineer", Salary: money.Of(5000, money.USD)})
+	if err != nil || emp.ID == "" {
+		t.Fatalf("AddEmployee() = %+v, %v; want an ID and no error", emp, err)
+	}
+	if _, err := m.FindEmployee(t.Context(), "Ali"); !errors.Is(err, employee.ErrNotFound) {
+		t.Errorf("FindEmployee() error = %v, want %v", err, employee.ErrNotFound)
+	}
+	if _, err := m.ChangeSalary(t.Context(), "Ali", money.Of(5500, money.USD)); !errors.Is(err, employee.ErrNotFound) {
+		t.Errorf("ChangeSalary() error = %v, want %v", err, employee.ErrNotFound)
+	}
+}
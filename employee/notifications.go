@@ -0,0 +1,25 @@
+package employee
+
+import (
+	"context"
+	"fmt"
+
+	"go-solid/events"
+	"go-solid/notify"
+)
+
+// Notifications returns an event handler that tells employees about changes
+// that concern them. Subscribe it to a bus; the Manager never calls it directly.
+func Notifications(n notify.Notifier) events.Handler {
+	return events.HandlerFunc(func(ctx context.Context, e events.Event) error {
+		switch e := e.(type) {
+		case Hired:
+			return n.Notify(ctx, notify.Message{To: e.Name, Subject: "Welcome aboard",
+				Body: fmt.Sprintf("your monthly salary is %s", e.Salary)})
+		case Promoted:
+			return n.Notify(ctx, notify.Message{To: e.Name, Subject: "Congratulations",
+				Body: fmt.Sprintf("you are now %s", e.ToTitle)})
+		}
+		return nil
+	})
+}
@@ -0,0 +1,152 @@
+package employee
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strings"
+
+	"go-solid/money"
+	"go-solid/normalize"
+)
+
+// QueryRepository Optional capability - backends that can filter, sort and page through employees.
+// Kept apart from Repository so simple backends aren't forced to implement querying (ISP).
+type QueryRepository interface {
+	List(ctx context.Context, filter Filter, page Page) (PageResult, error)
+}
+
+// Filter narrows a listing; zero values mean "no restriction". A salary bound
+// only matches employees paid in the bound's currency - amounts in different
+// currencies can't be compared without an exchange rate, and choosing one is
+// not the repository's job. NamePrefix is compared as names are, under the
+// repository's Normalizer, so "am" finds Amal.
+type Filter struct {
+	NamePrefix string
+	MinSalary  money.Money
+	MaxSalary  money.Money
+	Sort       SortField
+	Descending bool
+}
+
+type SortField string
+
+const (
+	SortByName   SortField = "name"
+	SortBySalary SortField = "salary"
+)
+
+// Page Cursor-based page request. Cursor is empty for the first page and
+// otherwise the NextCursor of the previous result.
+type Page struct {
+	Cursor string
+	Limit  int
+}
+
+const (
+	DefaultPageSize = 20
+	MaxPageSize     = 500
+)
+
+// Size returns the effective page size.
+func (p Page) Size() int {
+	switch {
+	case p.Limit <= 0:
+		return DefaultPageSize
+	case p.Limit > MaxPageSize:
+		return MaxPageSize
+	}
+	return p.Limit
+}
+
+type PageResult struct {
+	Items []Employee
+	// NextCursor is empty when there are no further pages
+	NextCursor string
+}
+
+// ErrInvalidCursor returned when a cursor is malformed or belongs to a different sort order
+var ErrInvalidCursor = errors.New("invalid page cursor")
+
+// Matches reports whether emp passes the filter, names being compared under
+// n. Backends that filter in memory use it directly; SQL backends translate
+// the same rules into WHERE clauses, matching the prefix against name_key.
+func (f Filter) Matches(n normalize.Normalizer, emp Employee) bool {
+	if f.NamePrefix != "" && !strings.HasPrefix(n.Normalize(emp.Name), n.Normalize(f.NamePrefix)) {
+		return false
+	}
+	if !f.MinSalary.IsZero() {
+		if c, err := emp.Salary.Cmp(f.MinSalary); err != nil || c < 0 {
+			return false
+		}
+	}
+	if !f.MaxSalary.IsZero() {
+		if c, err := emp.Salary.Cmp(f.MaxSalary); err != nil || c > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// SortKey returns the effective sort field.
+func (f Filter) SortKey() SortField {
+	if f.Sort == "" {
+		return SortByName
+	}
+	return f.Sort
+}
+
+// Cursor Position after the last item of a page (keyset pagination). Name
+// breaks ties because it is unique.
+type Cursor struct {
+	Sort       SortField      `json:"s"`
+	Descending bool           `json:"d,omitempty"`
+	Currency   money.Currency `json:"c,omitempty"`
+	Salary     int64          `json:"v,omitempty"` // minor units
+	Name       string         `json:"n"`
+}
+
+// CursorAfter builds the cursor pointing just past emp for the given filter.
+func CursorAfter(f Filter, emp Employee) string {
+	c := Cursor{Sort: f.SortKey(), Descending: f.Descending, Name: emp.Name}
+	if c.Sort == SortBySalary {
+		c.Currency, c.Salary = emp.Salary.Currency(), emp.Salary.Minor()
+	}
+	b, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// DecodeCursor parses a cursor and checks it was issued for the same ordering.
+func DecodeCursor(f Filter, s string) (Cursor, error) {
+	var c Cursor
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return Cursor{}, ErrInvalidCursor
+	}
+	if err := json.Unmarshal(b, &c); err != nil {
+		return Cursor{}, ErrInvalidCursor
+	}
+	if c.Sort != f.SortKey() || c.Descending != f.Descending {
+		return Cursor{}, ErrInvalidCursor
+	}
+	return c, nil
+}
+
+// Before reports whether a sorts before b under the filter's ordering. Sorting
+// by salary groups by currency first (see money.Money.Less).
+func (f Filter) Before(a, b Employee) bool {
+	less := a.Name < b.Name
+	if f.SortKey() == SortBySalary && a.Salary != b.Salary {
+		less = a.Salary.Less(b.Salary)
+	}
+	if f.Descending {
+		return !less && a.Name != b.Name
+	}
+	return less
+}
+
+// After reports whether emp comes after the cursor position.
+func (c Cursor) After(f Filter, emp Employee) bool {
+	return f.Before(Employee{Name: c.Name, Salary: money.FromMinor(c.Salary, c.Currency)}, emp)
+}
@@ -0,0 +1,91 @@
+package employee
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"go-solid/money"
+	"go-solid/normalize"
+	"go-solid/spec"
+)
+
+// SpecificationRepository Optional capability - backends that can find employees matching a specification
+type SpecificationRepository interface {
+	Matching(ctx context.Context, s spec.Specification[Employee]) ([]Employee, error)
+}
+
+// Each rule below is evaluated in memory by IsSatisfiedBy and rendered for SQL
+// backends by SQL. Combine them with spec.And, spec.Or and spec.Not.
+
+type nameStartsWith struct {
+	prefix string
+	names  normalize.Normalizer
+}
+
+// NameStartsWith matches employees whose name begins with prefix, compared
+// under the Normalizer the repository keys names with: "am" matches Amal.
+// In SQL it is matched against the name_key column. Outside a repository's
+// Matching it compares under normalize.Name; see BindNames.
+func NameStartsWith(prefix string) spec.Specification[Employee] {
+	return nameStartsWith{prefix: prefix, names: normalize.Name}
+}
+
+func (s nameStartsWith) IsSatisfiedBy(e Employee) bool {
+	return strings.HasPrefix(s.names.Normalize(e.Name), s.names.Normalize(s.prefix))
+}
+
+func (s nameStartsWith) SQL() (string, []any, error) {
+	return "name_key LIKE ? ESCAPE '!'", []any{spec.LikePrefix(s.names.Normalize(s.prefix))}, nil
+}
+
+// BindNames has the name rules in s compare names under n, the Normalizer a
+// repository keys names with, so a match in memory agrees with one against
+// name_key. Repositories call it at the top of Matching.
+func BindNames(s spec.Specification[Employee], n normalize.Normalizer) spec.Specification[Employee] {
+	return spec.Map(s, func(s spec.Specification[Employee]) spec.Specification[Employee] {
+		if name, ok := s.(nameStartsWith); ok {
+			name.names = n
+			return name
+		}
+		return s
+	})
+}
+
+type salaryAtLeast money.Money
+
+// SalaryAtLeast matches employees paid in min's currency earning min or more.
+func SalaryAtLeast(min money.Money) spec.Specification[Employee] { return salaryAtLeast(min) }
+
+func (s salaryAtLeast) IsSatisfiedBy(e Employee) bool {
+	c, err := e.Salary.Cmp(money.Money(s))
+	return err == nil && c >= 0
+}
+
+func (s salaryAtLeast) SQL() (string, []any, error) {
+	m := money.Money(s)
+	return "(currency = ? AND salary >= ?)", []any{string(m.Currency()), m.Minor()}, nil
+}
+
+type salaryAtMost money.Money
+
+// SalaryAtMost matches employees paid in max's currency earning max or less.
+func SalaryAtMost(max money.Money) spec.Specification[Employee] { return salaryAtMost(max) }
+
+func (s salaryAtMost) IsSatisfiedBy(e Employee) bool {
+	c, err := e.Salary.Cmp(money.Money(s))
+	return err == nil && c <= 0
+}
+
+func (s salaryAtMost) SQL() (string, []any, error) {
+	m := money.Money(s)
+	return "(currency = ? AND salary <= ?)", []any{string(m.Currency()), m.Minor()}, nil
+}
+
+type hiredBefore time.Time
+
+// HiredBefore matches employees hired strictly before t.
+func HiredBefore(t time.Time) spec.Specification[Employee] { return hiredBefore(t) }
+
+func (s hiredBefore) IsSatisfiedBy(e Employee) bool { return e.HiredAt.Before(time.Time(s)) }
+func (s hiredBefore) SQL() (string, []any, error)   { return "hired_at < ?", []any{time.Time(s)}, nil }
@@ -0,0 +1,628 @@
+// Package sqlrepo is an employee.Repository on top of database/sql.
+//
+// It is written once against the standard library and works with any driver
+// (MySQL, PostgreSQL, SQLite); placeholder differences are handled by a
+// sqldialect.Dialect. The caller opens the *sql.DB and registers the driver.
+package sqlrepo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"iter"
+	"strings"
+
+	"go-solid/employee"
+	"go-solid/id"
+	"go-solid/money"
+	"go-solid/normalize"
+	"go-solid/outbox"
+	"go-solid/spec"
+	"go-solid/sqldialect"
+)
+
+// Schema Table layout expected by the repository
+const Schema = `CREATE TABLE employees (
+    id         VARCHAR(64)  NOT NULL PRIMARY KEY,
+    name       VARCHAR(255) NOT NULL,
+    name_key   VARCHAR(255) NOT NULL UNIQUE, -- name as compared (WithNormalizer)
+    title      VARCHAR(255) NOT NULL DEFAULT '',
+    department VARCHAR(255) NOT NULL DEFAULT '', -- empty for rows from before it existed
+    email      VARCHAR(1024) NOT NULL DEFAULT '', -- or the salary and email sealed by package crypto
+    salary     BIGINT       NOT NULL, -- minor units (cents)
+    currency   CHAR(3)      NOT NULL,
+    hired_at   TIMESTAMP    NOT NULL,
+    version    INTEGER      NOT NULL,
+    deleted_at TIMESTAMP    NULL
+)`
+
+// TableSchema is Schema for a table created under another name (WithTable).
+func TableSchema(table string) string {
+	return strings.Replace(Schema, "employees", table, 1)
+}
+
+// OutboxSchema Table written by SaveWithOutbox and read by the relay
+const OutboxSchema = `CREATE TABLE outbox (
+    id           VARCHAR(64)  NOT NULL PRIMARY KEY,
+    topic        VARCHAR(255) NOT NULL,
+    msg_key      VARCHAR(255) NOT NULL DEFAULT '',
+    payload      TEXT         NOT NULL,
+    created_at   TIMESTAMP    NOT NULL,
+    seq          INTEGER      NOT NULL, -- insertion order; created_at can tie
+    published_at TIMESTAMP    NULL
+)`
+
+// OutboxTableSchema is OutboxSchema for the outbox of a repository using
+// WithTable(table).
+func OutboxTableSchema(table string) string {
+	return strings.Replace(OutboxSchema, "outbox", table+"_outbox", 1)
+}
+
+// Repository Low-level module - SQL-backed employee.Repository, keyed by ID
+// with a unique index of names (name_key).
+// Implements employee.IDRepository, employee.SoftDeleter through the deleted_at column,
+// employee.Updater through the version column, employee.QueryRepository, employee.Iterable, employee.SpecificationRepository, employee.BulkSaver
+// and employee.OutboxRepository (with outbox.Store over the same table),
+// but keeps no history table, so it deliberately does not implement employee.Versioned.
+type Repository struct {
+	db      *sql.DB
+	dialect sqldialect.Dialect
+	table   string
+	outbox  string
+	stmts   *stmtCache // nil unless WithStatementCache
+	names   normalize.Normalizer
+	ids     id.Generator
+}
+
+// Option customises a Repository created by New
+type Option func(*Repository)
+
+// WithTable uses a table other than "employees", e.g. one per tenant (see
+// package tenant), and name_outbox instead of "outbox", so that one tenant's
+// relay never publishes another's messages. The name is trusted: it is
+// written into the SQL as is.
+func WithTable(name string) Option {
+	return func(r *Repository) { r.table, r.outbox = name, name+"_outbox" }
+}
+
+// WithNormalizer compares names through n, kept in the name_key column;
+// normalize.Name by default. Names are then found the same way whatever the
+// database's collation. Changing it needs a Rekey.
+func WithNormalizer(n normalize.Normalizer) Option { return func(r *Repository) { r.names = n } }
+
+// WithIDs gives employees saved without an ID one from g; id.UUIDv7 by
+// default, which keeps inserts at the end of the primary key's index.
+func WithIDs(g id.Generator) Option { return func(r *Repository) { r.ids = g } }
+
+func New(db *sql.DB, dialect sqldialect.Dialect, opts ...Option) *Repository {
+	r := &Repository{db: db, dialect: dialect, table: "employees", outbox: "outbox", names: normalize.Name, ids: id.UUIDv7{}}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
+}
+
+func (r *Repository) q(query string) string { return r.dialect.Rebind(query) }
+
+// key is name as the name_key column has it.
+func (r *Repository) key(name string) string { return r.names.Normalize(name) }
+
+// columns selected by every read, in the order scan expects them
+const columns = "id, name, title, department, email, salary, currency, hired_at, version"
+
+type scanner interface{ Scan(dest ...any) error }
+
+func scan(row scanner) (employee.Employee, error) {
+	var emp employee.Employee
+	var minor int64
+	var currency string
+	if err := row.Scan(&emp.ID, &emp.Name, &emp.Title, &emp.Department, &emp.Email, &minor, &currency, &emp.HiredAt, &emp.Version); err != nil {
+		return employee.Employee{}, err
+	}
+	emp.Salary = money.FromMinor(minor, money.Currency(currency))
+	return emp, nil
+}
+
+// Save inserts a new employee or updates an existing one, bumping its version.
+// Saving a soft-deleted employee brings it back.
+func (r *Repository) Save(ctx context.Context, emp employee.Employee) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("sqlrepo: begin: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := r.upsert(ctx, tx, emp); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// SaveWithOutbox upserts emp and inserts msgs into the outbox table in one
+// transaction.
+func (r *Repository) SaveWithOutbox(ctx context.Context, emp employee.Employee, msgs []outbox.Message) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("sqlrepo: begin: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := r.upsert(ctx, tx, emp); err != nil {
+		return err
+	}
+	for _, msg := range msgs {
+		_, err := r.execContext(ctx, tx, fmt.Sprintf(`INSERT INTO %[1]s (id, topic, msg_key, payload, created_at, seq)
+			VALUES (?, ?, ?, ?, ?, (SELECT COALESCE(MAX(seq), 0) + 1 FROM %[1]s))`, r.outbox),
+			msg.ID, msg.Topic, msg.Key, string(msg.Payload), msg.CreatedAt)
+		if err != nil {
+			return fmt.Errorf("sqlrepo: outbox %s: %w", msg.ID, err)
+		}
+	}
+	return tx.Commit()
+}
+
+// Pending returns the oldest unpublished outbox messages (outbox.Store).
+func (r *Repository) Pending(ctx context.Context, limit int) ([]outbox.Message, error) {
+	// SQLite reads LIMIT -1 as no limit, and Postgres and MySQL refuse it
+	if limit <= 0 {
+		return nil, nil
+	}
+	rows, err := r.queryContext(ctx, fmt.Sprintf(`SELECT id, topic, msg_key, payload, created_at
+		FROM %s WHERE published_at IS NULL ORDER BY seq LIMIT %d`, r.outbox, limit))
+	if err != nil {
+		return nil, fmt.Errorf("sqlrepo: outbox: %w", err)
+	}
+	defer rows.Close()
+
+	var msgs []outbox.Message
+	for rows.Next() {
+		var msg outbox.Message
+		var payload string
+		if err := rows.Scan(&msg.ID, &msg.Topic, &msg.Key, &payload, &msg.CreatedAt); err != nil {
+			return nil, fmt.Errorf("sqlrepo: outbox: %w", err)
+		}
+		msg.Payload = []byte(payload)
+		msgs = append(msgs, msg)
+	}
+	return msgs, rows.Err()
+}
+
+func (r *Repository) MarkPublished(ctx context.Context, ids ...string) error {
+	for _, id := range ids {
+		_, err := r.execContext(ctx, nil, `UPDATE `+r.outbox+` SET published_at = CURRENT_TIMESTAMP WHERE id = ?`, id)
+		if err != nil {
+			return fmt.Errorf("sqlrepo: outbox %s: %w", id, err)
+		}
+	}
+	return nil
+}
+
+// BatchSize Employees written per transaction by SaveAll
+const BatchSize = 100
+
+// SaveAll writes each batch of BatchSize employees in one transaction. If a
+// batch fails it is rolled back and retried one employee per transaction, so
+// a single bad row costs its batch some speed but never its neighbours.
+func (r *Repository) SaveAll(ctx context.Context, emps iter.Seq[employee.Employee]) error {
+	report := &employee.BulkError{}
+	offset := 0
+	for batch := range employee.Batches(emps, BatchSize) {
+		if err := ctx.Err(); err != nil {
+			report.Stopped = err
+			break
+		}
+		err := r.saveBatch(ctx, batch)
+		switch {
+		case err == nil:
+			report.Saved += len(batch)
+		case ctx.Err() != nil:
+			report.Stopped = ctx.Err()
+			return report
+		default:
+			for i, emp := range batch {
+				report.Add(offset+i, emp, r.Save(ctx, emp))
+			}
+		}
+		offset += len(batch)
+	}
+	return report.Err()
+}
+
+func (r *Repository) saveBatch(ctx context.Context, batch []employee.Employee) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("sqlrepo: begin: %w", err)
+	}
+	defer tx.Rollback()
+
+	for _, emp := range batch {
+		if err := r.upsert(ctx, tx, emp); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// assignments written by every update, and their arguments in order. The
+// row is found by id, so a new name renames the employee.
+const assignments = `name = ?, name_key = ?, title = ?, department = ?, email = ?, salary = ?, currency = ?, hired_at = ?, version = version + 1`
+
+func (r *Repository) assigned(emp employee.Employee) []any {
+	return []any{emp.Name, r.key(emp.Name), emp.Title, emp.Department, emp.Email, emp.Salary.Minor(), string(emp.Salary.Currency()), emp.HiredAt}
+}
+
+// identify gives emp the ID of the employee stored under its name when it
+// has none, or a new one if there is no such employee. An employee with an
+// ID can't take a name another one has.
+func (r *Repository) identify(ctx context.Context, tx *sql.Tx, emp *employee.Employee) error {
+	var owner employee.ID
+	err := tx.QueryRowContext(ctx, r.q(`SELECT id FROM `+r.table+` WHERE name_key = ?`), r.key(emp.Name)).Scan(&owner)
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+	case err != nil:
+		return fmt.Errorf("sqlrepo: save %q: %w", emp.Name, err)
+	case emp.ID == "":
+		emp.ID = owner
+	case emp.ID != owner:
+		return fmt.Errorf("sqlrepo: save %q: %w", emp.Name, employee.ErrNameTaken)
+	}
+	if emp.ID == "" {
+		emp.ID = employee.ID(r.ids.NewID())
+	}
+	return nil
+}
+
+func (r *Repository) upsert(ctx context.Context, tx *sql.Tx, emp employee.Employee) error {
+	if err := r.identify(ctx, tx, &emp); err != nil {
+		return err
+	}
+	res, err := r.execContext(ctx, tx, `UPDATE `+r.table+` SET `+assignments+`, deleted_at = NULL WHERE id = ?`,
+		append(r.assigned(emp), emp.ID)...)
+	if err != nil {
+		return fmt.Errorf("sqlrepo: update %q: %w", emp.Name, err)
+	}
+	if n, err := res.RowsAffected(); err != nil {
+		return fmt.Errorf("sqlrepo: update %q: %w", emp.Name, err)
+	} else if n == 0 {
+		return r.insert(ctx, tx, emp)
+	}
+	return nil
+}
+
+func (r *Repository) insert(ctx context.Context, tx *sql.Tx, emp employee.Employee) error {
+	_, err := r.execContext(ctx, tx, `INSERT INTO `+r.table+` (id, name, name_key, title, department, email, salary, currency, hired_at, version)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, 1)`,
+		emp.ID, emp.Name, r.key(emp.Name), emp.Title, emp.Department, emp.Email, emp.Salary.Minor(), string(emp.Salary.Currency()), emp.HiredAt)
+	if err != nil {
+		return fmt.Errorf("sqlrepo: insert %q: %w", emp.Name, err)
+	}
+	return nil
+}
+
+// Update puts the version check in the UPDATE's WHERE clause, so the
+// database settles races: of two updates from the same version, one matches
+// the row and the other matches nothing. With expectedVersion 0 it brings
+// back a soft-deleted employee or inserts; the unique ID and name settle a
+// race between two inserts.
+func (r *Repository) Update(ctx context.Context, emp employee.Employee, expectedVersion int) (employee.Employee, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return employee.Employee{}, fmt.Errorf("sqlrepo: begin: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := r.identify(ctx, tx, &emp); err != nil {
+		return employee.Employee{}, err
+	}
+	var res sql.Result
+	if expectedVersion > 0 {
+		res, err = r.execContext(ctx, tx, `UPDATE `+r.table+` SET `+assignments+`
+			WHERE id = ? AND version = ? AND deleted_at IS NULL`,
+			append(r.assigned(emp), emp.ID, expectedVersion)...)
+	} else {
+		res, err = r.execContext(ctx, tx, `UPDATE `+r.table+` SET `+assignments+`, deleted_at = NULL
+			WHERE id = ? AND deleted_at IS NOT NULL`,
+			append(r.assigned(emp), emp.ID)...)
+	}
+	if err != nil {
+		return employee.Employee{}, fmt.Errorf("sqlrepo: update %q: %w", emp.Name, err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return employee.Employee{}, fmt.Errorf("sqlrepo: update %q: %w", emp.Name, err)
+	}
+	if n == 0 && expectedVersion == 0 {
+		if err := r.insert(ctx, tx, emp); err != nil {
+			_ = tx.Rollback()
+			if c := r.conflict(ctx, emp, 0); errors.Is(c, employee.ErrConflict) {
+				return employee.Employee{}, c // another writer inserted it first
+			}
+			return employee.Employee{}, err
+		}
+		n = 1
+	}
+	if n == 0 {
+		return employee.Employee{}, r.conflict(ctx, emp, expectedVersion)
+	}
+	if err := tx.QueryRowContext(ctx, r.q(`SELECT version FROM `+r.table+` WHERE id = ?`), emp.ID).Scan(&emp.Version); err != nil {
+		return employee.Employee{}, fmt.Errorf("sqlrepo: update %q: %w", emp.Name, err)
+	}
+	if err := tx.Commit(); err != nil {
+		return employee.Employee{}, fmt.Errorf("sqlrepo: update %q: %w", emp.Name, err)
+	}
+	return emp, nil
+}
+
+// conflict explains why a write from expectedVersion failed, or returns nil
+// when the employee is where the caller expected: absent, for version 0.
+func (r *Repository) conflict(ctx context.Context, emp employee.Employee, expectedVersion int) error {
+	name := emp.Name
+	var actual int
+	err := r.queryRowContext(ctx, `SELECT version FROM `+r.table+` WHERE id = ? AND deleted_at IS NULL`, emp.ID).Scan(&actual)
+	switch {
+	case errors.Is(err, sql.ErrNoRows) && expectedVersion > 0:
+		return employee.ErrNotFound
+	case errors.Is(err, sql.ErrNoRows):
+		return nil
+	case err != nil:
+		return fmt.Errorf("sqlrepo: update %q: %w", name, err)
+	}
+	return &employee.ConflictError{Name: name, Expected: expectedVersion, Actual: actual}
+}
+
+func (r *Repository) GetByName(ctx context.Context, name string) (employee.Employee, error) {
+	emp, err := scan(r.queryRowContext(ctx, `SELECT `+columns+`
+		FROM `+r.table+` WHERE name_key = ? AND deleted_at IS NULL`, r.key(name)))
+	if errors.Is(err, sql.ErrNoRows) {
+		return employee.Employee{}, employee.ErrNotFound
+	}
+	if err != nil {
+		return employee.Employee{}, fmt.Errorf("sqlrepo: get %q: %w", name, err)
+	}
+	return emp, nil
+}
+
+func (r *Repository) GetByID(ctx context.Context, id employee.ID) (employee.Employee, error) {
+	emp, err := scan(r.queryRowContext(ctx, `SELECT `+columns+`
+		FROM `+r.table+` WHERE id = ? AND deleted_at IS NULL`, id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return employee.Employee{}, employee.ErrNotFound
+	}
+	if err != nil {
+		return employee.Employee{}, fmt.Errorf("sqlrepo: get %s: %w", id, err)
+	}
+	return emp, nil
+}
+
+func (r *Repository) SoftDelete(ctx context.Context, name string) error {
+	return r.execOne(ctx, name, `UPDATE `+r.table+` SET deleted_at = CURRENT_TIMESTAMP
+		WHERE name_key = ? AND deleted_at IS NULL`)
+}
+
+func (r *Repository) Restore(ctx context.Context, name string) error {
+	return r.execOne(ctx, name, `UPDATE `+r.table+` SET deleted_at = NULL
+		WHERE name_key = ? AND deleted_at IS NOT NULL`)
+}
+
+// execOne runs a statement that must touch exactly the named employee.
+func (r *Repository) execOne(ctx context.Context, name, query string) error {
+	res, err := r.execContext(ctx, nil, query, r.key(name))
+	if err != nil {
+		return fmt.Errorf("sqlrepo: %q: %w", name, err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("sqlrepo: %q: %w", name, err)
+	}
+	if n == 0 {
+		return employee.ErrNotFound
+	}
+	return nil
+}
+
+// ErrSameKey returned by Rekey when two stored names normalize to the same key
+var ErrSameKey = errors.New("names normalize to the same key")
+
+// Rekey writes name_key again from every name, deleted employees included.
+// Run it after the migration adding the column, which can only approximate
+// the keys in SQL, and after changing the Normalizer. It changes nothing if
+// two names would share a key, and returns how many keys it changed.
+func (r *Repository) Rekey(ctx context.Context) (int, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("sqlrepo: begin: %w", err)
+	}
+	defer tx.Rollback()
+
+	rows, err := tx.QueryContext(ctx, `SELECT id, name, name_key FROM `+r.table+` ORDER BY name`)
+	if err != nil {
+		return 0, fmt.Errorf("sqlrepo: rekey: %w", err)
+	}
+	owner := map[string]string{} // name by new key
+	var stale []employee.Employee
+	for rows.Next() {
+		var emp employee.Employee
+		var key string
+		if err := rows.Scan(&emp.ID, &emp.Name, &key); err != nil {
+			rows.Close()
+			return 0, fmt.Errorf("sqlrepo: rekey: %w", err)
+		}
+		k := r.key(emp.Name)
+		if other, dup := owner[k]; dup {
+			rows.Close()
+			return 0, fmt.Errorf("sqlrepo: rekey: %q and %q are both %q: %w", other, emp.Name, k, ErrSameKey)
+		}
+		owner[k] = emp.Name
+		if k != key {
+			stale = append(stale, emp)
+		}
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, fmt.Errorf("sqlrepo: rekey: %w", err)
+	}
+	for _, emp := range stale {
+		if _, err := r.execContext(ctx, tx, `UPDATE `+r.table+` SET name_key = ? WHERE id = ?`, r.key(emp.Name), emp.ID); err != nil {
+			return 0, fmt.Errorf("sqlrepo: rekey %q: %w", emp.Name, err)
+		}
+	}
+	return len(stale), tx.Commit()
+}
+
+// List translates the filter into a WHERE clause and pages with a keyset
+// condition, so deep pages cost the same as the first one.
+func (r *Repository) List(ctx context.Context, filter employee.Filter, page employee.Page) (employee.PageResult, error) {
+	where := []string{"deleted_at IS NULL"}
+	var args []any
+
+	if filter.NamePrefix != "" {
+		where = append(where, "name_key LIKE ? ESCAPE '!'")
+		args = append(args, spec.LikePrefix(r.key(filter.NamePrefix)))
+	}
+	if m := filter.MinSalary; !m.IsZero() {
+		where = append(where, "currency = ? AND salary >= ?")
+		args = append(args, string(m.Currency()), m.Minor())
+	}
+	if m := filter.MaxSalary; !m.IsZero() {
+		where = append(where, "currency = ? AND salary <= ?")
+		args = append(args, string(m.Currency()), m.Minor())
+	}
+
+	cmp, dir := ">", "ASC"
+	if filter.Descending {
+		cmp, dir = "<", "DESC"
+	}
+	order := "name " + dir
+	if page.Cursor != "" {
+		c, err := employee.DecodeCursor(filter, page.Cursor)
+		if err != nil {
+			return employee.PageResult{}, err
+		}
+		if filter.SortKey() == employee.SortBySalary {
+			where = append(where, fmt.Sprintf(
+				"(currency %s ? OR (currency = ? AND (salary %s ? OR (salary = ? AND name %s ?))))", cmp, cmp, cmp))
+			args = append(args, string(c.Currency), string(c.Currency), c.Salary, c.Salary, c.Name)
+		} else {
+			where = append(where, "name "+cmp+" ?")
+			args = append(args, c.Name)
+		}
+	}
+	if filter.SortKey() == employee.SortBySalary {
+		order = "currency " + dir + ", salary " + dir + ", " + order
+	}
+
+	size := page.Size()
+	query := fmt.Sprintf(`SELECT %s FROM %s
+		WHERE %s ORDER BY %s LIMIT %d`, columns, r.table, strings.Join(where, " AND "), order, size+1)
+
+	items, err := r.query(ctx, query, args...)
+	if err != nil {
+		return employee.PageResult{}, fmt.Errorf("sqlrepo: list: %w", err)
+	}
+	result := employee.PageResult{Items: items}
+
+	// one extra row was requested to learn whether another page exists
+	if len(result.Items) > size {
+		result.Items = result.Items[:size]
+		result.NextCursor = employee.CursorAfter(filter, result.Items[size-1])
+	}
+	return result, nil
+}
+
+// Matching pushes the specification down as a WHERE clause. Specifications
+// without a SQL form (spec.Func, custom rules) still work: they are evaluated
+// in memory over all rows - correct, just slower.
+func (r *Repository) Matching(ctx context.Context, s spec.Specification[employee.Employee]) ([]employee.Employee, error) {
+	s = employee.BindNames(s, r.names)
+	where, args, err := spec.ToSQL(s)
+	if errors.Is(err, spec.ErrNotTranslatable) {
+		all, err := r.query(ctx, `SELECT `+columns+` FROM `+r.table+`
+			WHERE deleted_at IS NULL ORDER BY name`)
+		if err != nil {
+			return nil, fmt.Errorf("sqlrepo: matching: %w", err)
+		}
+		var matched []employee.Employee
+		for _, emp := range all {
+			if s.IsSatisfiedBy(emp) {
+				matched = append(matched, emp)
+			}
+		}
+		return matched, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("sqlrepo: matching: %w", err)
+	}
+
+	matched, err := r.query(ctx, `SELECT `+columns+` FROM `+r.table+`
+		WHERE deleted_at IS NULL AND (`+where+`) ORDER BY name`, args...)
+	if err != nil {
+		return nil, fmt.Errorf("sqlrepo: matching: %w", err)
+	}
+	return matched, nil
+}
+
+// All streams the rows of one query. Breaking out of the loop closes them,
+// giving the connection back to the pool.
+func (r *Repository) All(ctx context.Context) iter.Seq2[employee.Employee, error] {
+	return func(yield func(employee.Employee, error) bool) {
+		rows, err := r.queryContext(ctx, fmt.Sprintf(`SELECT %s FROM %s
+			WHERE deleted_at IS NULL ORDER BY name`, columns, r.table))
+		if err != nil {
+			yield(employee.Employee{}, fmt.Errorf("sqlrepo: all: %w", err))
+			return
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			emp, err := scan(rows)
+			if err != nil {
+				yield(employee.Employee{}, fmt.Errorf("sqlrepo: all: %w", err))
+				return
+			}
+			if !yield(emp, nil) {
+				return
+			}
+		}
+		if err := rows.Err(); err != nil {
+			yield(employee.Employee{}, fmt.Errorf("sqlrepo: all: %w", err))
+		}
+	}
+}
+
+// query runs a SELECT of the employee columns and scans every row.
+func (r *Repository) query(ctx context.Context, query string, args ...any) ([]employee.Employee, error) {
+	rows, err := r.queryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var emps []employee.Employee
+	for rows.Next() {
+		emp, err := scan(rows)
+		if err != nil {
+			return nil, err
+		}
+		emps = append(emps, emp)
+	}
+	return emps, rows.Err()
+}
+
+// CheckHealth pings the database (health.Checker).
+func (r *Repository) CheckHealth(ctx context.Context) error {
+	return r.db.PingContext(ctx)
+}
+
+var (
+	_ employee.Repository              = (*Repository)(nil)
+	_ employee.IDRepository            = (*Repository)(nil)
+	_ employee.SoftDeleter             = (*Repository)(nil)
+	_ employee.Updater                 = (*Repository)(nil)
+	_ employee.QueryRepository         = (*Repository)(nil)
+	_ employee.Iterable                = (*Repository)(nil)
+	_ employee.SpecificationRepository = (*Repository)(nil)
+	_ employee.BulkSaver               = (*Repository)(nil)
+	_ employee.OutboxRepository        = (*Repository)(nil)
+	_ outbox.Store                     = (*Repository)(nil)
+)
@@ -0,0 +1,187 @@
+package sqlrepo
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Pool Connection pool settings for the *sql.DB behind a Repository. Zero
+// fields keep database/sql's defaults: unlimited open connections, two idle,
+// connections kept forever.
+type Pool struct {
+	MaxOpen     int
+	MaxIdle     int
+	MaxLifetime time.Duration
+	MaxIdleTime time.Duration
+}
+
+// poolWire is Pool as JSON, durations as strings like "5m"
+type poolWire struct {
+	MaxOpen     int    `json:"max_open,omitempty"`
+	MaxIdle     int    `json:"max_idle,omitempty"`
+	MaxLifetime string `json:"max_lifetime,omitempty"`
+	MaxIdleTime string `json:"max_idle_time,omitempty"`
+}
+
+func (p Pool) MarshalJSON() ([]byte, error) {
+	str := func(d time.Duration) string {
+		if d == 0 {
+			return ""
+		}
+		return d.String()
+	}
+	return json.Marshal(poolWire{p.MaxOpen, p.MaxIdle, str(p.MaxLifetime), str(p.MaxIdleTime)})
+}
+
+func (p *Pool) UnmarshalJSON(b []byte) error {
+	var w poolWire
+	if err := json.Unmarshal(b, &w); err != nil {
+		return err
+	}
+	parsed := Pool{MaxOpen: w.MaxOpen, MaxIdle: w.MaxIdle}
+	for _, d := range []struct {
+		in  string
+		out *time.Duration
+	}{{w.MaxLifetime, &parsed.MaxLifetime}, {w.MaxIdleTime, &parsed.MaxIdleTime}} {
+		if d.in == "" {
+			continue
+		}
+		v, err := time.ParseDuration(d.in)
+		if err != nil {
+			return fmt.Errorf("sqlrepo: pool: %w", err)
+		}
+		*d.out = v
+	}
+	*p = parsed
+	return nil
+}
+
+// Apply sets the pool of db. The pool belongs to db, so every repository
+// sharing it sees the change.
+func (p Pool) Apply(db *sql.DB) {
+	if p.MaxOpen > 0 {
+		db.SetMaxOpenConns(p.MaxOpen)
+	}
+	if p.MaxIdle > 0 {
+		db.SetMaxIdleConns(p.MaxIdle)
+	}
+	if p.MaxLifetime > 0 {
+		db.SetConnMaxLifetime(p.MaxLifetime)
+	}
+	if p.MaxIdleTime > 0 {
+		db.SetConnMaxIdleTime(p.MaxIdleTime)
+	}
+}
+
+// WithPool applies p to the *sql.DB given to New.
+func WithPool(p Pool) Option { return func(r *Repository) { p.Apply(r.db) } }
+
+// WithStatementCache prepares each query once and reuses the statement,
+// keeping up to size of them; 0 means 64. Drivers that prepare on the server
+// then parse and plan a query once per connection instead of on every call.
+// Queries past the limit run unprepared. Close releases the statements.
+func WithStatementCache(size int) Option {
+	return func(r *Repository) {
+		if size <= 0 {
+			size = 64
+		}
+		r.stmts = &stmtCache{size: size, byQuery: map[string]*sql.Stmt{}}
+	}
+}
+
+// stmtCache Prepared statements by query text, prepared on the *sql.DB so
+// database/sql re-prepares them on whichever connection runs them
+type stmtCache struct {
+	size    int
+	mu      sync.Mutex
+	byQuery map[string]*sql.Stmt
+}
+
+// get returns the statement for query, nil when the cache is full.
+func (c *stmtCache) get(ctx context.Context, db *sql.DB, query string) (*sql.Stmt, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if s, ok := c.byQuery[query]; ok {
+		return s, nil
+	}
+	if len(c.byQuery) >= c.size {
+		return nil, nil
+	}
+	s, err := db.PrepareContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	c.byQuery[query] = s
+	return s, nil
+}
+
+func (c *stmtCache) close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var first error
+	for q, s := range c.byQuery {
+		if err := s.Close(); err != nil && first == nil {
+			first = err
+		}
+		delete(c.byQuery, q)
+	}
+	return first
+}
+
+// Close releases the cached statements. It leaves the *sql.DB open: it
+// belongs to the caller.
+func (r *Repository) Close() error {
+	if r.stmts == nil {
+		return nil
+	}
+	return r.stmts.close()
+}
+
+// stmt returns the cached statement for query, moved into tx when there is
+// one, or nil when query runs unprepared.
+func (r *Repository) stmt(ctx context.Context, tx *sql.Tx, query string) *sql.Stmt {
+	if r.stmts == nil {
+		return nil
+	}
+	s, err := r.stmts.get(ctx, r.db, query)
+	if err != nil || s == nil {
+		return nil // running it unprepared reports the same error
+	}
+	if tx != nil {
+		return tx.StmtContext(ctx, s)
+	}
+	return s
+}
+
+// execContext runs query, written with ? placeholders, in tx or, when tx is
+// nil, on the pool.
+func (r *Repository) execContext(ctx context.Context, tx *sql.Tx, query string, args ...any) (sql.Result, error) {
+	query = r.q(query)
+	if s := r.stmt(ctx, tx, query); s != nil {
+		return s.ExecContext(ctx, args...)
+	}
+	if tx != nil {
+		return tx.ExecContext(ctx, query, args...)
+	}
+	return r.db.ExecContext(ctx, query, args...)
+}
+
+func (r *Repository) queryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+	query = r.q(query)
+	if s := r.stmt(ctx, nil, query); s != nil {
+		return s.QueryContext(ctx, args...)
+	}
+	return r.db.QueryContext(ctx, query, args...)
+}
+
+func (r *Repository) queryRowContext(ctx context.Context, query string, args ...any) *sql.Row {
+	query = r.q(query)
+	if s := r.stmt(ctx, nil, query); s != nil {
+		return s.QueryRowContext(ctx, args...)
+	}
+	return r.db.QueryRowContext(ctx, query, args...)
+}
@@ -0,0 +1,49 @@
+package employeecli
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"go-solid/employee"
+)
+
+// Client What the commands need from the employee API. Declared here, where
+// it is consumed: the commands don't know which protocol is behind it, and
+// each transport is one implementation chosen by the -transport flag (DIP).
+// Clients of other modules satisfy it too: grpcapi adds one over gRPC.
+type Client interface {
+	Add(ctx context.Context, emp employee.Employee) (employee.Employee, error)
+	Get(ctx context.Context, name string) (employee.Employee, error)
+	List(ctx context.Context, filter employee.Filter, page employee.Page) (employee.PageResult, error)
+}
+
+// Transport Makes the Client of one protocol, for the API at addr, giving
+// up on each request after timeout
+type Transport func(addr string, timeout time.Duration) (Client, error)
+
+// Transports The transports of the standard library: REST and GraphQL, both
+// over HTTP
+var Transports = map[string]Transport{
+	"rest": func(addr string, timeout time.Duration) (Client, error) {
+		return &restClient{base: addr, http: &http.Client{Timeout: timeout}}, nil
+	},
+	"graphql": func(addr string, timeout time.Duration) (Client, error) {
+		return &graphqlClient{url: addr + "/graphql", http: &http.Client{Timeout: timeout}}, nil
+	},
+}
+
+// errInvalid stands for whatever the server rejected as invalid input
+var errInvalid = errors.New("rejected by the server")
+
+// remoteError An error reported by the server. It unwraps to the domain
+// error it stands for, so the commands check errors.Is(err,
+// employee.ErrNotFound) the same way over either transport.
+type remoteError struct {
+	msg  string
+	kind error
+}
+
+func (e *remoteError) Error() string { return e.msg }
+func (e *remoteError) Unwrap() error { return e.kind }
@@ -0,0 +1,185 @@
+// Package employeecli is the employee-cli command: subcommands that talk to
+// the employee API through a Client, whatever the protocol behind it. The
+// mains pick the transports -transport chooses from: cmd/employee-cli offers
+// REST and GraphQL, and grpcapi's employee-cli adds gRPC, which the root
+// module can't import.
+//
+//	employee-cli add -name Alice -title Engineer -salary 5000 -currency USD
+//	employee-cli -transport graphql get Alice
+//	employee-cli -transport grpc -addr localhost:9090 list -prefix A -sort salary -desc
+//	employee-cli payroll -month 2026-03
+package employeecli
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"maps"
+	"os"
+	"os/signal"
+	"slices"
+	"sort"
+	"strings"
+	"syscall"
+	"time"
+
+	"go-solid/employee"
+	"go-solid/money"
+)
+
+// command One subcommand: parses its own flags and calls the Client
+type command struct {
+	summary string
+	run     func(ctx context.Context, c Client, args []string) error
+}
+
+var commands = map[string]command{
+	"add":     {"hire an employee", runAdd},
+	"get":     {"show one employee", runGet},
+	"list":    {"list employees, every page or one", runList},
+	"payroll": {"run payroll over the employees the API lists", runPayroll},
+}
+
+// Main runs employee-cli with args, the command line without the program
+// name, over the Client of the transport -transport names. It returns the
+// exit code: 2 for a bad command line, 1 when the command fails.
+func Main(args []string, transports map[string]Transport) int {
+	names := slices.Sorted(maps.Keys(transports))
+	fs := flag.NewFlagSet("employee-cli", flag.ContinueOnError)
+	addr := fs.String("addr", "http://localhost:8080", "employee API address: a base URL over HTTP, host:port over gRPC")
+	transport := fs.String("transport", "rest", "protocol to the API: "+strings.Join(names, ", "))
+	timeout := fs.Duration("timeout", 10*time.Second, "timeout of each request")
+	fs.Usage = func() { usage(fs, names) }
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	newClient, ok := transports[*transport]
+	if !ok {
+		fmt.Fprintf(os.Stderr, "employee-cli: unknown transport %q\n\n", *transport)
+		fs.Usage()
+		return 2
+	}
+	if fs.NArg() == 0 {
+		fs.Usage()
+		return 2
+	}
+	cmd, ok := commands[fs.Arg(0)]
+	if !ok {
+		fmt.Fprintf(os.Stderr, "employee-cli: unknown command %q\n\n", fs.Arg(0))
+		fs.Usage()
+		return 2
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	client, err := newClient(strings.TrimSuffix(*addr, "/"), *timeout)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "employee-cli: %v\n", err)
+		return 1
+	}
+	if err := cmd.run(ctx, client, fs.Args()[1:]); err != nil {
+		if !errors.Is(err, flag.ErrHelp) {
+			fmt.Fprintf(os.Stderr, "employee-cli %s: %v\n", fs.Arg(0), err)
+		}
+		return 1
+	}
+	return 0
+}
+
+func usage(fs *flag.FlagSet, transports []string) {
+	fmt.Fprintf(os.Stderr, "usage: employee-cli [-addr address] [-transport %s] <command> [flags]\n\ncommands:\n", strings.Join(transports, "|"))
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintf(os.Stderr, "  %-8s %s\n", name, commands[name].summary)
+	}
+	fmt.Fprintln(os.Stderr, "\nflags:")
+	fs.PrintDefaults()
+}
+
+func runAdd(ctx context.Context, c Client, args []string) error {
+	fs := flag.NewFlagSet("employee-cli add", flag.ContinueOnError)
+	name := fs.String("name", "", "name (required)")
+	title := fs.String("title", "", "job title")
+	email := fs.String("email", "", "email address")
+	salary := fs.String("salary", "", "monthly salary, a decimal amount (required)")
+	currency := fs.String("currency", "USD", "ISO 4217 code of the salary")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	pay, err := money.Parse(*salary, money.Currency(*currency))
+	if err != nil {
+		return fmt.Errorf("-salary: %w", err)
+	}
+	emp, err := c.Add(ctx, employee.Employee{Name: *name, Title: *title, Email: *email, Salary: pay})
+	if err != nil {
+		return err
+	}
+	fmt.Printf("✅ hired %s (%s)\n", emp.Name, emp.ID)
+	return nil
+}
+
+func runGet(ctx context.Context, c Client, args []string) error {
+	if len(args) != 1 {
+		return errors.New("usage: employee-cli get <name>")
+	}
+	emp, err := c.Get(ctx, args[0])
+	if errors.Is(err, employee.ErrNotFound) {
+		return fmt.Errorf("no employee named %q", args[0])
+	}
+	if err != nil {
+		return err
+	}
+	fmt.Printf("%-8s %s\n", "id", emp.ID)
+	fmt.Printf("%-8s %s\n", "name", emp.Name)
+	fmt.Printf("%-8s %s\n", "title", emp.Title)
+	fmt.Printf("%-8s %s\n", "email", emp.Email)
+	fmt.Printf("%-8s %s\n", "salary", emp.Salary)
+	if !emp.HiredAt.IsZero() {
+		fmt.Printf("%-8s %s\n", "hired", emp.HiredAt.Format(time.DateOnly))
+	}
+	return nil
+}
+
+func runList(ctx context.Context, c Client, args []string) error {
+	fs := flag.NewFlagSet("employee-cli list", flag.ContinueOnError)
+	prefix := fs.String("prefix", "", "only names starting with this")
+	sortBy := fs.String("sort", "", "name or salary")
+	desc := fs.Bool("desc", false, "sort descending")
+	limit := fs.Int("limit", 0, "one page of this many; all pages when 0")
+	cursor := fs.String("cursor", "", "start after this cursor, from a previous -limit listing")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	filter := employee.Filter{NamePrefix: *prefix, Sort: employee.SortField(*sortBy), Descending: *desc}
+	page := employee.Page{Cursor: *cursor, Limit: *limit}
+	if *limit == 0 {
+		page.Limit = employee.MaxPageSize
+	}
+	count := 0
+	for {
+		res, err := c.List(ctx, filter, page)
+		if err != nil {
+			return err
+		}
+		for _, emp := range res.Items {
+			fmt.Printf("%-20s %-24s %s\n", emp.Name, emp.Title, emp.Salary)
+		}
+		count += len(res.Items)
+		if res.NextCursor == "" {
+			break
+		}
+		if *limit > 0 {
+			fmt.Printf("... more with -cursor %s\n", res.NextCursor)
+			break
+		}
+		page.Cursor = res.NextCursor
+	}
+	fmt.Printf("%d employees\n", count)
+	return nil
+}
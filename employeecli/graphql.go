@@ -0,0 +1,171 @@
+package employeecli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"go-solid/employee"
+	"go-solid/money"
+)
+
+// graphqlClient Client over graphqlapi
+type graphqlClient struct {
+	url  string
+	http *http.Client
+}
+
+const employeeFields = `fragment employee on Employee { id name title email salary { amount currency } hiredAt }`
+
+// gqlEmployee An Employee as the GraphQL API returns it
+type gqlEmployee struct {
+	ID     string `json:"id"`
+	Name   string `json:"name"`
+	Title  string `json:"title"`
+	Email  string `json:"email"`
+	Salary struct {
+		Amount   string `json:"amount"`
+		Currency string `json:"currency"`
+	} `json:"salary"`
+	HiredAt string `json:"hiredAt"`
+}
+
+func (g gqlEmployee) employee() (employee.Employee, error) {
+	salary, err := money.Parse(g.Salary.Amount, money.Currency(g.Salary.Currency))
+	if err != nil {
+		return employee.Employee{}, fmt.Errorf("salary of %s: %w", g.Name, err)
+	}
+	emp := employee.Employee{ID: employee.ID(g.ID), Name: g.Name, Title: g.Title, Email: g.Email, Salary: salary}
+	emp.HiredAt, _ = time.Parse(time.RFC3339, g.HiredAt)
+	return emp, nil
+}
+
+// moneyInput The MoneyInput variable for m, or null for the zero value
+func moneyInput(m money.Money) any {
+	if m.IsZero() {
+		return nil
+	}
+	return map[string]string{"amount": m.Amount(), "currency": string(m.Currency())}
+}
+
+func (c *graphqlClient) Add(ctx context.Context, emp employee.Employee) (employee.Employee, error) {
+	var data struct {
+		Hire gqlEmployee `json:"hire"`
+	}
+	err := c.do(ctx, `mutation($in: HireInput!) { hire(input: $in) { ...employee } } `+employeeFields, map[string]any{
+		"in": map[string]any{"name": emp.Name, "title": emp.Title, "email": emp.Email, "salary": moneyInput(emp.Salary)},
+	}, &data)
+	if err != nil {
+		return employee.Employee{}, err
+	}
+	return data.Hire.employee()
+}
+
+func (c *graphqlClient) Get(ctx context.Context, name string) (employee.Employee, error) {
+	var data struct {
+		Employee gqlEmployee `json:"employee"`
+	}
+	err := c.do(ctx, `query($name: String!) { employee(name: $name) { ...employee } } `+employeeFields, map[string]any{"name": name}, &data)
+	if err != nil {
+		return employee.Employee{}, err
+	}
+	return data.Employee.employee()
+}
+
+func (c *graphqlClient) List(ctx context.Context, filter employee.Filter, page employee.Page) (employee.PageResult, error) {
+	vars := map[string]any{
+		"prefix":    filter.NamePrefix,
+		"minSalary": moneyInput(filter.MinSalary),
+		"maxSalary": moneyInput(filter.MaxSalary),
+		"desc":      filter.Descending,
+		"limit":     page.Limit,
+		"cursor":    page.Cursor,
+	}
+	if filter.Sort != "" {
+		vars["sort"] = strings.ToUpper(string(filter.Sort))
+	}
+	var data struct {
+		Employees struct {
+			Items      []gqlEmployee `json:"items"`
+			NextCursor string        `json:"nextCursor"`
+		} `json:"employees"`
+	}
+	err := c.do(ctx, `query($prefix: String, $minSalary: MoneyInput, $maxSalary: MoneyInput, $sort: SortField, $desc: Boolean, $limit: Int, $cursor: String) {
+		employees(prefix: $prefix, minSalary: $minSalary, maxSalary: $maxSalary, sort: $sort, desc: $desc, limit: $limit, cursor: $cursor) {
+			items { ...employee }
+			nextCursor
+		}
+	} `+employeeFields, vars, &data)
+	if err != nil {
+		return employee.PageResult{}, err
+	}
+	res := employee.PageResult{NextCursor: data.Employees.NextCursor}
+	for _, item := range data.Employees.Items {
+		emp, err := item.employee()
+		if err != nil {
+			return employee.PageResult{}, err
+		}
+		res.Items = append(res.Items, emp)
+	}
+	return res, nil
+}
+
+// do sends one operation and decodes its data into out. Each operation here
+// asks for a single field, so any error in the response means it failed.
+func (c *graphqlClient) do(ctx context.Context, query string, vars map[string]any, out any) error {
+	body, err := json.Marshal(map[string]any{"query": query, "variables": vars})
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	var result struct {
+		Data   json.RawMessage `json:"data"`
+		Errors []struct {
+			Message    string `json:"message"`
+			Extensions struct {
+				Code string `json:"code"`
+			} `json:"extensions"`
+		} `json:"errors"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return fmt.Errorf("graphql: %s: decoding response: %w", resp.Status, err)
+	}
+	if len(result.Errors) > 0 {
+		e := result.Errors[0]
+		return &remoteError{msg: e.Message, kind: codeError(e.Extensions.Code)}
+	}
+	return json.Unmarshal(result.Data, out)
+}
+
+// codeError The error a GraphQL error code stands for - the inverse of
+// graphqlapi's code
+func codeError(code string) error {
+	switch code {
+	case "NOT_FOUND":
+		return employee.ErrNotFound
+	case "BAD_USER_INPUT", "GRAPHQL_VALIDATION_FAILED":
+		return errInvalid
+	case "CONFLICT":
+		return employee.ErrConflict
+	case "NAME_TAKEN":
+		return employee.ErrNameTaken
+	case "NOT_IMPLEMENTED":
+		return errors.ErrUnsupported
+	}
+	return nil
+}
@@ -0,0 +1,99 @@
+package employeecli
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"iter"
+	"os"
+	"time"
+
+	"go-solid/employee"
+	"go-solid/money"
+	"go-solid/payroll"
+)
+
+// pipelines The same simple pipeline per country as solid repl's payroll
+var pipelines = payroll.Config{
+	"US": {
+		payroll.Pension{Rate: "0.05", Cap: money.Of(400, money.USD)},
+		payroll.IncomeTax{Brackets: []payroll.Bracket{{UpTo: money.Of(1000, money.USD), Rate: "0"}, {Rate: "0.22"}}},
+	},
+	"DE": {
+		payroll.Pension{Rate: "0.093"},
+		payroll.IncomeTax{Brackets: []payroll.Bracket{{UpTo: money.Of(1000, money.EUR), Rate: "0"}, {Rate: "0.30"}}},
+	},
+	"EG": {
+		payroll.Pension{Rate: "0.11", Cap: money.Of(1500, money.EGP)},
+		payroll.IncomeTax{Brackets: []payroll.Bracket{{UpTo: money.Of(2500, money.EGP), Rate: "0"}, {Rate: "0.20"}}},
+	},
+}
+
+// remoteRoster Adapts a Client to a payroll.Roster: the engine pays the
+// employees the API lists, page by page, without knowing they came over the
+// network - or over which protocol.
+type remoteRoster struct {
+	client Client
+	staff  payroll.Staff
+}
+
+func (r remoteRoster) PaidEmployees(ctx context.Context) iter.Seq2[payroll.PaidEmployee, error] {
+	return func(yield func(payroll.PaidEmployee, error) bool) {
+		page := employee.Page{Limit: employee.MaxPageSize}
+		for {
+			res, err := r.client.List(ctx, employee.Filter{}, page)
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+			for _, emp := range res.Items {
+				if !yield(r.staff.Member(emp), nil) {
+					return
+				}
+			}
+			if res.NextCursor == "" {
+				return
+			}
+			page.Cursor = res.NextCursor
+		}
+	}
+}
+
+func runPayroll(ctx context.Context, c Client, args []string) error {
+	fs := flag.NewFlagSet("employee-cli payroll", flag.ContinueOnError)
+	month := fs.String("month", time.Now().Format("2006-01"), "the month to pay, YYYY-MM")
+	progress := fs.Bool("progress", terminal(os.Stderr), "draw a progress bar on stderr")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	t, err := time.Parse("2006-01", *month)
+	if err != nil {
+		return fmt.Errorf("-month: %w", err)
+	}
+
+	roster := remoteRoster{client: c, staff: payroll.Staff{
+		CountryOf: payroll.ByCurrency(map[money.Currency]string{money.USD: "US", money.EUR: "DE", money.EGP: "EG"}),
+	}}
+	// ✅ Listed first, so the bar has a total to fill
+	employees, err := payroll.Collect(ctx, roster)
+	if err != nil {
+		return err
+	}
+	if *progress {
+		ctx = payroll.WithProgress(ctx, bar{w: os.Stderr, width: 30})
+	}
+	run, err := payroll.New(pipelines).Run(ctx, payroll.Period{Year: t.Year(), Month: t.Month()}, employees)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("💰 payroll %s\n", run.Period)
+	for _, slip := range run.Payslips {
+		fmt.Printf("   %-20s %s  gross %s  net %s\n", slip.Name, slip.Country, slip.Gross(), slip.Net())
+	}
+	for _, e := range run.Errors {
+		fmt.Printf("   ❌ %-17s %v\n", e.Name, e.Err)
+	}
+	return nil
+}
+
+var _ payroll.Roster = remoteRoster{}
@@ -0,0 +1,38 @@
+package employeecli
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"go-solid/payroll"
+)
+
+// bar A payroll.ProgressReporter drawing a progress bar on a terminal - the
+// same reports payrollapi streams to browsers
+type bar struct {
+	w     io.Writer
+	width int
+}
+
+func (b bar) Report(p payroll.Progress) {
+	if p.Total <= 0 {
+		fmt.Fprintf(b.w, "\r   %d paid so far", p.Done)
+		return
+	}
+	filled := b.width * p.Done / p.Total
+	fmt.Fprintf(b.w, "\r   [%s%s] %d/%d", strings.Repeat("█", filled), strings.Repeat("░", b.width-filled), p.Done, p.Total)
+	if p.Done == p.Total {
+		fmt.Fprintln(b.w)
+	}
+}
+
+// terminal reports whether f is a terminal rather than a file or a pipe,
+// where a redrawn bar would only be noise.
+func terminal(f *os.File) bool {
+	fi, err := f.Stat()
+	return err == nil && fi.Mode()&os.ModeCharDevice != 0
+}
+
+var _ payroll.ProgressReporter = bar{}
@@ -0,0 +1,147 @@
+package employeecli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"go-solid/employee"
+	"go-solid/money"
+)
+
+// restClient Client over the REST endpoints of httpapi
+type restClient struct {
+	base string
+	http *http.Client
+}
+
+// employeeDTO The REST representation of an employee; the CLI keeps its own
+// copy rather than importing the server's package.
+type employeeDTO struct {
+	ID      string      `json:"id"`
+	Name    string      `json:"name"`
+	Title   string      `json:"title,omitempty"`
+	Email   string      `json:"email,omitempty"`
+	Salary  money.Money `json:"salary"`
+	HiredAt string      `json:"hired_at,omitempty"`
+}
+
+func (d employeeDTO) employee() employee.Employee {
+	emp := employee.Employee{ID: employee.ID(d.ID), Name: d.Name, Title: d.Title, Email: d.Email, Salary: d.Salary}
+	emp.HiredAt, _ = time.Parse(time.RFC3339, d.HiredAt)
+	return emp
+}
+
+func (c *restClient) Add(ctx context.Context, emp employee.Employee) (employee.Employee, error) {
+	body := map[string]any{"name": emp.Name, "title": emp.Title, "email": emp.Email, "salary": emp.Salary}
+	var dto employeeDTO
+	if err := c.do(ctx, http.MethodPost, "/employees", body, &dto); err != nil {
+		return employee.Employee{}, err
+	}
+	return dto.employee(), nil
+}
+
+func (c *restClient) Get(ctx context.Context, name string) (employee.Employee, error) {
+	var dto employeeDTO
+	if err := c.do(ctx, http.MethodGet, "/employees/"+url.PathEscape(name), nil, &dto); err != nil {
+		return employee.Employee{}, err
+	}
+	return dto.employee(), nil
+}
+
+func (c *restClient) List(ctx context.Context, filter employee.Filter, page employee.Page) (employee.PageResult, error) {
+	q := url.Values{}
+	set := func(key, value string) {
+		if value != "" {
+			q.Set(key, value)
+		}
+	}
+	set("prefix", filter.NamePrefix)
+	set("sort", string(filter.Sort))
+	set("cursor", page.Cursor)
+	if filter.Descending {
+		q.Set("desc", "true")
+	}
+	if page.Limit > 0 {
+		q.Set("limit", strconv.Itoa(page.Limit))
+	}
+	// the REST API takes one currency for both bounds
+	for key, bound := range map[string]money.Money{"min_salary": filter.MinSalary, "max_salary": filter.MaxSalary} {
+		if !bound.IsZero() {
+			q.Set(key, bound.Amount())
+			q.Set("currency", string(bound.Currency()))
+		}
+	}
+
+	var resp struct {
+		Items      []employeeDTO `json:"items"`
+		NextCursor string        `json:"next_cursor"`
+	}
+	if err := c.do(ctx, http.MethodGet, "/employees?"+q.Encode(), nil, &resp); err != nil {
+		return employee.PageResult{}, err
+	}
+	res := employee.PageResult{NextCursor: resp.NextCursor}
+	for _, dto := range resp.Items {
+		res.Items = append(res.Items, dto.employee())
+	}
+	return res, nil
+}
+
+func (c *restClient) do(ctx context.Context, method, path string, body, out any) error {
+	var payload bytes.Buffer
+	if body != nil {
+		if err := json.NewEncoder(&payload).Encode(body); err != nil {
+			return err
+		}
+	}
+	req, err := http.NewRequestWithContext(ctx, method, c.base+path, &payload)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		var e struct {
+			Error string `json:"error"`
+		}
+		if json.NewDecoder(resp.Body).Decode(&e) != nil || e.Error == "" {
+			e.Error = resp.Status
+		}
+		return &remoteError{msg: e.Error, kind: statusError(resp.StatusCode, e.Error)}
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("%s %s: decoding response: %w", method, path, err)
+	}
+	return nil
+}
+
+// statusError The error a status code stands for - the inverse of httpapi's
+// writeError. A 409 stands for two, told apart by the message.
+func statusError(status int, msg string) error {
+	switch status {
+	case http.StatusNotFound:
+		return employee.ErrNotFound
+	case http.StatusBadRequest, http.StatusUnprocessableEntity:
+		return errInvalid
+	case http.StatusConflict:
+		if strings.HasSuffix(msg, employee.ErrNameTaken.Error()) {
+			return employee.ErrNameTaken
+		}
+		return employee.ErrConflict
+	case http.StatusNotImplemented:
+		return errors.ErrUnsupported
+	}
+	return nil
+}
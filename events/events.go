@@ -0,0 +1,71 @@
+// Package events delivers domain events to the handlers interested in them.
+//
+// The code raising an event never knows who reacts to it: notifications,
+// projections or integrations subscribe on the side, so adding a reaction
+// never means editing the code that raised the event (OCP).
+package events
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// Event Something that happened in the domain
+type Event interface {
+	EventName() string
+}
+
+// Handler Reaction to an event
+type Handler interface {
+	Handle(ctx context.Context, e Event) error
+}
+
+// HandlerFunc Adapter so plain functions can be used as handlers
+type HandlerFunc func(ctx context.Context, e Event) error
+
+func (f HandlerFunc) Handle(ctx context.Context, e Event) error { return f(ctx, e) }
+
+// Dispatcher Abstraction - delivers events to their handlers
+type Dispatcher interface {
+	Dispatch(ctx context.Context, evts ...Event) error
+}
+
+// Bus Synchronous in-process Dispatcher. Handlers run in subscription order;
+// a failing handler doesn't stop the others, all errors are joined.
+type Bus struct {
+	mu       sync.RWMutex
+	handlers map[string][]Handler
+	all      []Handler
+}
+
+func NewBus() *Bus { return &Bus{handlers: make(map[string][]Handler)} }
+
+// Subscribe registers h for events with the given name.
+func (b *Bus) Subscribe(name string, h Handler) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.handlers[name] = append(b.handlers[name], h)
+}
+
+// SubscribeAll registers h for every event.
+func (b *Bus) SubscribeAll(h Handler) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.all = append(b.all, h)
+}
+
+func (b *Bus) Dispatch(ctx context.Context, evts ...Event) error {
+	var errs []error
+	for _, e := range evts {
+		b.mu.RLock()
+		hs := append(append([]Handler(nil), b.handlers[e.EventName()]...), b.all...)
+		b.mu.RUnlock()
+		for _, h := range hs {
+			if err := h.Handle(ctx, e); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
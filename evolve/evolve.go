@@ -0,0 +1,87 @@
+// Package evolve adds fields to the Employee entity without breaking what
+// was stored before they existed.
+//
+// A new field is added, never required: every backend and codec must read
+// old data with the field empty, and write it without losing anything else.
+// What an empty field means is then one decision, a Filler, made in one
+// place rather than in every reader. It can be applied three ways:
+//
+//   - on read: a Repository decorating the backend fills employees as they
+//     are read, and stores them filled on their next save. Nothing else
+//     changes, and the stored data catches up by itself.
+//   - in bulk: Backfill fills and saves every employee that needs it, once,
+//     after which readers no longer need the decorator.
+//   - in the schema: a column added with a default (migration
+//     0007_department) is the same for every row, and can't depend on the
+//     rest of the employee as a Filler can.
+//
+// A new field is then a new Filler, and the backends, codecs and callers stay
+// closed for modification (OCP).
+package evolve
+
+import (
+	"go-solid/employee"
+)
+
+// Filler Abstraction - gives an employee stored before a field existed a
+// value for it. Fill reports whether it changed emp. It only fills fields
+// that are empty, so filling twice changes nothing the second time, and a
+// value someone chose is never overwritten.
+type Filler interface {
+	Fill(emp *employee.Employee) bool
+}
+
+// Func Adapter turning a function into a Filler
+type Func func(emp *employee.Employee) bool
+
+func (f Func) Fill(emp *employee.Employee) bool { return f(emp) }
+
+// Chain Tries each Filler in turn; a later one only sees what the earlier
+// ones left empty
+type Chain []Filler
+
+func (c Chain) Fill(emp *employee.Employee) bool {
+	changed := false
+	for _, f := range c {
+		changed = f.Fill(emp) || changed
+	}
+	return changed
+}
+
+// DepartmentByTitle Fills an empty department from the title, for titles
+// that say where someone works: "Engineer" is in "Engineering"
+type DepartmentByTitle map[string]string
+
+func (d DepartmentByTitle) Fill(emp *employee.Employee) bool {
+	if emp.Department != "" {
+		return false
+	}
+	dept, ok := d[emp.Title]
+	if !ok {
+		return false
+	}
+	emp.Department = dept
+	return true
+}
+
+// DepartmentDefault Fills an empty department with one value, as a column
+// default would: the last resort of a Chain
+type DepartmentDefault string
+
+func (d DepartmentDefault) Fill(emp *employee.Employee) bool {
+	if emp.Department != "" || d == "" {
+		return false
+	}
+	emp.Department = string(d)
+	return true
+}
+
+// Unassigned is the department of employees nothing else places.
+const Unassigned DepartmentDefault = "Unassigned"
+
+var (
+	_ Filler = Func(nil)
+	_ Filler = Chain{}
+	_ Filler = DepartmentByTitle{}
+	_ Filler = Unassigned
+)
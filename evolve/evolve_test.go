@@ -0,0 +1,297 @@
+package evolve_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"go-solid/blob"
+	"go-solid/clock"
+	"go-solid/codec"
+	"go-solid/crypto"
+	"go-solid/employee"
+	"go-solid/employee/memory"
+	"go-solid/evolve"
+	"go-solid/export"
+	"go-solid/id"
+	"go-solid/importer"
+	"go-solid/migrate"
+	"go-solid/money"
+	"go-solid/replica"
+	"go-solid/shard"
+)
+
+// contract checks what a backend must do for Department to be added
+// without breaking it, failing t for every clause repo breaks.
+func contract(t testing.TB, repo employee.Repository) {
+	t.Helper()
+	ctx := context.Background()
+	// saved as the code before Department did: without one
+	old := employee.Employee{ID: "emp-1", Name: "Olga", Title: "Engineer", Email: "olga@example.com", Salary: money.Of(5000, money.USD)}
+	if err := repo.Save(ctx, old); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	got, err := repo.GetByName(ctx, "Olga")
+	if err != nil || got.Department != "" {
+		t.Errorf("GetByName() = %q, %v; want an employee saved without a department to read back with none", got.Department, err)
+	}
+	got.Department = "Engineering"
+	if err := repo.Save(ctx, got); err != nil {
+		t.Fatalf("Save() with a department error = %v", err)
+	}
+	got, err = repo.GetByName(ctx, "Olga")
+	if err != nil || got.Department != "Engineering" {
+		t.Errorf("GetByName() department = %q, %v; want the one saved, Engineering", got.Department, err)
+	}
+	if got.ID != old.ID || got.Title != old.Title || got.Email != old.Email || got.Salary != old.Salary {
+		t.Errorf("GetByName() = %+v, want nothing but the department changed from %+v", got, old)
+	}
+	listed := false
+	for emp, err := range employee.All(ctx, repo) {
+		listed = listed || err == nil && emp.Name == "Olga" && emp.Department == "Engineering"
+	}
+	if !listed {
+		t.Error("employee.All() doesn't list Olga in Engineering")
+	}
+	if v, ok := repo.(employee.Versioned); ok {
+		history, err := v.History(ctx, "Olga")
+		if err != nil || len(history) != 2 || history[0].Department != "" {
+			t.Errorf("History() = %+v, %v; want the version from before without a department", history, err)
+		}
+	}
+}
+
+func TestContract_Backends(t *testing.T) {
+	aes, err := crypto.NewAESGCM(crypto.NewKey())
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name string
+		repo employee.Repository
+	}{
+		{"memory", memory.New()},
+		{"shard over memory", shard.New([]employee.Repository{memory.New(), memory.New()})},
+		{"replica over memory", replica.New(memory.New(), nil)},
+		{"crypto over memory", crypto.NewRepository(memory.New(), aes)},
+		{"evolve over memory", evolve.NewRepository(memory.New(), evolve.Func(func(*employee.Employee) bool { return false }))},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) { contract(t, tt.repo) })
+	}
+}
+
+// v1 An adapter written before Department: it stores the fields it knew
+// about, one by one, and drops any added since
+type v1 struct{ *memory.Repository }
+
+func (r v1) Save(ctx context.Context, emp employee.Employee) error {
+	return r.Repository.Save(ctx, employee.Employee{ID: emp.ID, Name: emp.Name, Title: emp.Title, Email: emp.Email, Salary: emp.Salary, HiredAt: emp.HiredAt})
+}
+
+// recorder Collects the failures of a contract run instead of failing
+type recorder struct {
+	testing.TB
+	failures []string
+}
+
+func (r *recorder) Helper() {}
+func (r *recorder) Errorf(format string, a ...any) {
+	r.failures = append(r.failures, fmt.Sprintf(format, a...))
+}
+func (r *recorder) Error(a ...any) { r.failures = append(r.failures, fmt.Sprint(a...)) }
+
+func TestContract_CatchesAnAdapterDroppingTheField(t *testing.T) {
+	rec := &recorder{TB: t}
+	contract(rec, v1{memory.New()})
+	if len(rec.failures) == 0 {
+		t.Fatal("the contract passed an adapter that drops Department")
+	}
+	for _, f := range rec.failures {
+		t.Logf("v1 breaks: %s", f)
+	}
+}
+
+func TestMigrations_AddTheColumnWithADefault(t *testing.T) {
+	for _, backend := range []string{"mysql", "postgres", "sqlite"} {
+		t.Run(backend, func(t *testing.T) {
+			migrations, err := migrate.SQL(backend)
+			if err != nil || len(migrations) == 0 {
+				t.Fatalf("migrate.SQL() = %v, %v", migrations, err)
+			}
+			last := migrations[len(migrations)-1]
+			if !strings.Contains(last.Up, "ADD COLUMN department") || !strings.Contains(last.Up, "DEFAULT ''") || !strings.Contains(last.Down, "DROP COLUMN department") {
+				t.Errorf("%s doesn't add department with a default and drop it on the way down:\n%s\n%s", last, last.Up, last.Down)
+			}
+		})
+	}
+}
+
+// seed stores employees the way the code before Department did.
+func seed(t *testing.T, repo employee.Repository) {
+	t.Helper()
+	for i, e := range []struct{ name, title string }{{"Olga", "Engineer"}, {"Pavel", "Accountant"}, {"Rana", "Chief of Staff"}} {
+		emp := employee.Employee{ID: employee.ID(fmt.Sprintf("emp-%d", i+1)), Name: e.name, Title: e.title, Salary: money.Of(5000, money.USD)}
+		if err := repo.Save(t.Context(), emp); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestCodecs_OldFilesStillRead(t *testing.T) {
+	ctx := t.Context()
+	var row export.Row
+	if err := json.Unmarshal([]byte(`{"id":"emp-1","name":"Olga","title":"Engineer","salary":{"amount":"5000.00","currency":"USD"}}`), &row); err != nil || row.Name != "Olga" || row.Department != "" {
+		t.Errorf("an old JSONL line decodes to %+v, %v; want Olga without a department", row, err)
+	}
+
+	repo := memory.New()
+	seed(t, repo)
+	olga, _ := repo.GetByName(ctx, "Olga")
+	olga.Department = "Engineering"
+	if err := repo.Save(ctx, olga); err != nil {
+		t.Fatal(err)
+	}
+	exports := blob.NewMemory()
+	exported := func(format, key string) string {
+		t.Helper()
+		c, err := codec.Lookup(format)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := (&export.Exporter{Source: repo, Codec: c, Store: exports}).Export(ctx, key); err != nil {
+			t.Fatalf("Export(%s) error = %v", key, err)
+		}
+		rc, err := exports.Get(ctx, key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer rc.Close()
+		b, err := io.ReadAll(rc)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return string(b)
+	}
+	if out := exported("jsonl", "staff.jsonl"); strings.Count(out, `"department":"Engineering"`) != 1 || strings.Count(out, `"department"`) != 1 {
+		t.Errorf("JSONL export = %s, want the department when there is one and nothing when not", out)
+	}
+	out := exported("csv", "staff.csv")
+	if header, _, _ := strings.Cut(out, "\n"); header != "id,name,title,salary,currency,hired_at,department" {
+		t.Errorf("CSV header = %q, want department last so the old columns keep their places", header)
+	}
+
+	rules := importer.Rules{IDs: id.NewSequence("imp-"), Clock: clock.NewFake(time.Date(2026, time.March, 2, 9, 0, 0, 0, time.UTC)), Currency: money.USD}
+	imported := memory.New()
+	report, err := importer.New(imported, rules).Import(ctx, importer.NewCSV(strings.NewReader("name,title,salary\nSam,Engineer,4000\n")))
+	if err != nil || report.Imported != 1 {
+		t.Errorf("importing an old CSV = %+v, %v; want Sam imported", report, err)
+	}
+	if _, err := importer.New(imported, rules).Import(ctx, importer.NewCSV(strings.NewReader(out))); err != nil {
+		t.Fatalf("importing the new CSV error = %v", err)
+	}
+	if olga, err := imported.GetByName(ctx, "Olga"); err != nil || olga.Department != "Engineering" {
+		t.Errorf("Olga imported in %q, %v; want Engineering", olga.Department, err)
+	}
+}
+
+var fill = evolve.Chain{evolve.DepartmentByTitle{"Engineer": "Engineering", "Accountant": "Finance"}, evolve.Unassigned}
+
+func TestFiller(t *testing.T) {
+	tests := []struct {
+		name        string
+		emp         employee.Employee
+		want        string
+		wantChanged bool
+	}{
+		{"by title", employee.Employee{Title: "Accountant"}, "Finance", true},
+		{"unmapped title falls through to the default", employee.Employee{Title: "Chief of Staff"}, "Unassigned", true},
+		{"a chosen department is kept", employee.Employee{Title: "Engineer", Department: "Platform"}, "Platform", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			emp := tt.emp
+			if changed := fill.Fill(&emp); changed != tt.wantChanged || emp.Department != tt.want {
+				t.Errorf("Fill() = %v, department %q; want %v, %q", changed, emp.Department, tt.wantChanged, tt.want)
+			}
+			if fill.Fill(&emp) {
+				t.Error("Fill() again changed the employee, want nothing left to fill")
+			}
+		})
+	}
+}
+
+func TestRepository_FillsOnReadAndStoresOnSave(t *testing.T) {
+	ctx := t.Context()
+	repo := memory.New()
+	seed(t, repo)
+	onRead := evolve.NewRepository(repo, fill)
+
+	pavel, err := onRead.GetByName(ctx, "Pavel")
+	if err != nil || pavel.Department != "Finance" {
+		t.Fatalf("GetByName() = %q, %v; want Pavel filled in as Finance", pavel.Department, err)
+	}
+	if stored, _ := repo.GetByName(ctx, "Pavel"); stored.Department != "" {
+		t.Errorf("the stored copy is in %q, want it untouched by a read", stored.Department)
+	}
+	if _, err := employee.NewManager(onRead).ChangeSalary(ctx, "Pavel", money.Of(5500, money.USD)); err != nil {
+		t.Fatalf("ChangeSalary() error = %v", err)
+	}
+	if stored, _ := repo.GetByName(ctx, "Pavel"); stored.Department != "Finance" {
+		t.Errorf("after a raise the stored copy is in %q, want it saved filled", stored.Department)
+	}
+}
+
+func TestBackfill(t *testing.T) {
+	ctx := t.Context()
+	repo := memory.New()
+	seed(t, repo)
+	olga, _ := repo.GetByName(ctx, "Olga")
+	olga.Department = "Platform"
+	if err := repo.Save(ctx, olga); err != nil {
+		t.Fatal(err)
+	}
+	if n, err := evolve.Backfill(ctx, repo, fill); err != nil || n != 2 {
+		t.Errorf("Backfill() = %d, %v; want 2 saved", n, err)
+	}
+	if olga, _ := repo.GetByName(ctx, "Olga"); olga.Department != "Platform" {
+		t.Errorf("Olga is in %q, want the department someone chose kept", olga.Department)
+	}
+	if n, err := evolve.Backfill(ctx, repo, fill); err != nil || n != 0 {
+		t.Errorf("Backfill() again = %d, %v; want nothing left to do", n, err)
+	}
+}
+
+// meddling Saves a new email for whoever is updated first, just before the
+// update lands, as a colleague might while a backfill runs
+type meddling struct {
+	*memory.Repository
+	done bool
+}
+
+func (m *meddling) Update(ctx context.Context, emp employee.Employee, expectedVersion int) (employee.Employee, error) {
+	if !m.done {
+		m.done = true
+		current, _ := m.GetByName(ctx, emp.Name)
+		current.Email = strings.ToLower(current.Name) + "@example.com"
+		_ = m.Save(ctx, current)
+	}
+	return m.Repository.Update(ctx, emp, expectedVersion)
+}
+
+func TestBackfill_ReadsAConcurrentChangeAgain(t *testing.T) {
+	busy := &meddling{Repository: memory.New()}
+	seed(t, busy)
+	n, err := evolve.Backfill(t.Context(), busy, fill)
+	if err != nil || n != 3 {
+		t.Fatalf("Backfill() = %d, %v; want 3 saved", n, err)
+	}
+	olga, _ := busy.GetByName(t.Context(), "Olga")
+	if olga.Email != "olga@example.com" || olga.Department != "Engineering" {
+		t.Errorf("Olga = %s in %q, want the colleague's email kept and the department filled", olga.Email, olga.Department)
+	}
+}
@@ -0,0 +1,212 @@
+package evolve
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"iter"
+
+	"go-solid/employee"
+	"go-solid/outbox"
+)
+
+// Repository Decorator filling employees through a Filler as they are read
+// from the wrapped employee.Repository, and before they are saved, so an
+// employee read once is stored filled on its next save. Optional
+// capabilities are forwarded; employee.SpecificationRepository is not, as
+// the backend would match the values stored rather than the ones filled.
+type Repository struct {
+	next employee.Repository
+	fill Filler
+}
+
+func NewRepository(next employee.Repository, f Filler) *Repository {
+	return &Repository{next: next, fill: f}
+}
+
+// Wrapped returns the repository the employees are stored in, as stored.
+func (r *Repository) Wrapped() any { return r.next }
+
+func (r *Repository) filled(emp employee.Employee, err error) (employee.Employee, error) {
+	if err != nil {
+		return employee.Employee{}, err
+	}
+	r.fill.Fill(&emp)
+	return emp, nil
+}
+
+func (r *Repository) fillAll(emps []employee.Employee) []employee.Employee {
+	for i := range emps {
+		r.fill.Fill(&emps[i])
+	}
+	return emps
+}
+
+func (r *Repository) Save(ctx context.Context, emp employee.Employee) error {
+	r.fill.Fill(&emp)
+	return r.next.Save(ctx, emp)
+}
+
+func (r *Repository) GetByName(ctx context.Context, name string) (employee.Employee, error) {
+	return r.filled(r.next.GetByName(ctx, name))
+}
+
+// GetByID finds the employee through the backend's employee.IDRepository
+// capability, or by looking through every employee when it has none.
+func (r *Repository) GetByID(ctx context.Context, id employee.ID) (employee.Employee, error) {
+	return r.filled(employee.GetByID(ctx, r.next, id))
+}
+
+func (r *Repository) SaveAll(ctx context.Context, emps iter.Seq[employee.Employee]) error {
+	return employee.SaveAll(ctx, r.next, func(yield func(employee.Employee) bool) {
+		for emp := range emps {
+			r.fill.Fill(&emp)
+			if !yield(emp) {
+				return
+			}
+		}
+	})
+}
+
+func (r *Repository) Update(ctx context.Context, emp employee.Employee, expectedVersion int) (employee.Employee, error) {
+	u, ok := r.next.(employee.Updater)
+	if !ok {
+		return employee.Employee{}, errors.ErrUnsupported
+	}
+	r.fill.Fill(&emp)
+	return r.filled(u.Update(ctx, emp, expectedVersion))
+}
+
+func (r *Repository) SaveWithOutbox(ctx context.Context, emp employee.Employee, msgs []outbox.Message) error {
+	o, ok := r.next.(employee.OutboxRepository)
+	if !ok {
+		return errors.ErrUnsupported
+	}
+	r.fill.Fill(&emp)
+	return o.SaveWithOutbox(ctx, emp, msgs)
+}
+
+func (r *Repository) SoftDelete(ctx context.Context, name string) error {
+	d, ok := r.next.(employee.SoftDeleter)
+	if !ok {
+		return errors.ErrUnsupported
+	}
+	return d.SoftDelete(ctx, name)
+}
+
+func (r *Repository) Restore(ctx context.Context, name string) error {
+	d, ok := r.next.(employee.SoftDeleter)
+	if !ok {
+		return errors.ErrUnsupported
+	}
+	return d.Restore(ctx, name)
+}
+
+// History fills every version: old versions are as old as the data gets.
+func (r *Repository) History(ctx context.Context, name string) ([]employee.Employee, error) {
+	v, ok := r.next.(employee.Versioned)
+	if !ok {
+		return nil, errors.ErrUnsupported
+	}
+	versions, err := v.History(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	return r.fillAll(versions), nil
+}
+
+func (r *Repository) List(ctx context.Context, filter employee.Filter, page employee.Page) (employee.PageResult, error) {
+	q, ok := r.next.(employee.QueryRepository)
+	if !ok {
+		return employee.PageResult{}, errors.ErrUnsupported
+	}
+	res, err := q.List(ctx, filter, page)
+	if err != nil {
+		return employee.PageResult{}, err
+	}
+	res.Items = r.fillAll(res.Items)
+	return res, nil
+}
+
+// All streams through the backend's employee.Iterable capability, or pages
+// through List when it has none.
+func (r *Repository) All(ctx context.Context) iter.Seq2[employee.Employee, error] {
+	return func(yield func(employee.Employee, error) bool) {
+		for emp, err := range employee.All(ctx, r.next) {
+			if err == nil {
+				r.fill.Fill(&emp)
+			}
+			if !yield(emp, err) {
+				return
+			}
+		}
+	}
+}
+
+// attempts is how many times Backfill tries to store one employee when
+// others keep saving it first.
+const attempts = 3
+
+// Backfill fills every employee of repo that f changes, saves them and
+// returns how many it saved. Every employee is read before any is saved, so
+// no backend is written to while it streams.
+//
+// Through an employee.Updater, an employee saved by someone else since it
+// was read is read again and filled again, so the backfill never overwrites
+// their change; other backends are saved to, the last write winning.
+func Backfill(ctx context.Context, repo employee.Repository, f Filler) (int, error) {
+	var stale []employee.Employee
+	for emp, err := range employee.All(ctx, repo) {
+		if err != nil {
+			return 0, fmt.Errorf("backfill: %w", err)
+		}
+		if f.Fill(&emp) {
+			stale = append(stale, emp)
+		}
+	}
+	saved := 0
+	for _, emp := range stale {
+		ok, err := store(ctx, repo, f, emp)
+		if err != nil {
+			return saved, fmt.Errorf("backfill %q: %w", emp.Name, err)
+		}
+		if ok {
+			saved++
+		}
+	}
+	return saved, nil
+}
+
+// store saves the filled emp, and reports false if there was nothing left
+// to fill by the time it could.
+func store(ctx context.Context, repo employee.Repository, f Filler, emp employee.Employee) (bool, error) {
+	u, ok := repo.(employee.Updater)
+	if !ok {
+		return true, repo.Save(ctx, emp)
+	}
+	var err error
+	for range attempts {
+		if _, err = u.Update(ctx, emp, emp.Version); !errors.Is(err, employee.ErrConflict) {
+			return err == nil, err
+		}
+		if emp, err = employee.GetByID(ctx, repo, emp.ID); err != nil {
+			return false, err
+		}
+		if !f.Fill(&emp) {
+			return false, nil
+		}
+	}
+	return false, fmt.Errorf("%w %d times", employee.ErrConflict, attempts)
+}
+
+var (
+	_ employee.Repository       = (*Repository)(nil)
+	_ employee.IDRepository     = (*Repository)(nil)
+	_ employee.BulkSaver        = (*Repository)(nil)
+	_ employee.Updater          = (*Repository)(nil)
+	_ employee.OutboxRepository = (*Repository)(nil)
+	_ employee.SoftDeleter      = (*Repository)(nil)
+	_ employee.Versioned        = (*Repository)(nil)
+	_ employee.QueryRepository  = (*Repository)(nil)
+	_ employee.Iterable         = (*Repository)(nil)
+)
@@ -0,0 +1,90 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"go-solid/assertlsp"
+	"go-solid/employee"
+	"go-solid/employee/actor"
+	"go-solid/employee/memory"
+	"go-solid/httpapi"
+	"go-solid/money"
+)
+
+// slowRepository Adds a little latency to every read, as a database would,
+// widening the gap between loading an employee and saving them
+type slowRepository struct{ *memory.Repository }
+
+func (r slowRepository) GetByName(ctx context.Context, name string) (employee.Employee, error) {
+	emp, err := r.Repository.GetByName(ctx, name)
+	time.Sleep(time.Millisecond) // the row is read; the reply is on its way
+	return emp, err
+}
+
+// raiseConcurrently Gives Alice twenty promotions with a $100 raise at once,
+// through any service
+func raiseConcurrently(ctx context.Context, svc httpapi.EmployeeService) money.Money {
+	_, _ = svc.AddEmployee(ctx, employee.Employee{Name: "Alice", Title: "Engineer", Salary: money.Of(5000, money.USD)})
+	var wg sync.WaitGroup
+	for level := range 20 {
+		title := fmt.Sprintf("Engineer L%d", level+2)
+		wg.Go(func() { _, _ = svc.Promote(ctx, "Alice", title, money.Of(100, money.USD)) })
+	}
+	wg.Wait()
+	alice, _ := svc.FindEmployee(ctx, "Alice")
+	return alice.Salary
+}
+
+// script The sequence both managers must agree on
+func script(ctx context.Context) []assertlsp.Step[httpapi.EmployeeService] {
+	salary := func(emp employee.Employee, err error) (any, error) { return emp.Salary.String(), err }
+	return []assertlsp.Step[httpapi.EmployeeService]{
+		assertlsp.Try("hire Bob", func(s httpapi.EmployeeService) (any, error) {
+			return salary(s.AddEmployee(ctx, employee.Employee{Name: "Bob", Title: "Analyst", Salary: money.Of(4000, money.USD)}))
+		}),
+		assertlsp.Try("hire Bob at no salary", func(s httpapi.EmployeeService) (any, error) {
+			return salary(s.AddEmployee(ctx, employee.Employee{Name: "Bob", Title: "Analyst"}))
+		}),
+		assertlsp.Try("promote Bob", func(s httpapi.EmployeeService) (any, error) {
+			return salary(s.Promote(ctx, "Bob", "Senior Analyst", money.Of(500, money.USD)))
+		}),
+		assertlsp.Try("cut Bob's salary to zero", func(s httpapi.EmployeeService) (any, error) {
+			return salary(s.ChangeSalary(ctx, "Bob", money.Money{}))
+		}),
+		assertlsp.Exec("remove Bob", func(s httpapi.EmployeeService) error { return s.RemoveEmployee(ctx, "Bob") }),
+		assertlsp.Try("find Bob", func(s httpapi.EmployeeService) (any, error) { return salary(s.FindEmployee(ctx, "Bob")) }),
+		assertlsp.Try("promote Carol", func(s httpapi.EmployeeService) (any, error) {
+			return salary(s.Promote(ctx, "Carol", "Lead", money.Of(500, money.USD)))
+		}),
+	}
+}
+
+func main() {
+	ctx := context.Background()
+
+	fmt.Println("❌ Synchronous Manager: twenty promotions with a $100 raise at once, from USD 5000.00")
+	fmt.Printf("   Alice earns %s\n", raiseConcurrently(ctx, employee.NewManager(slowRepository{memory.New()})))
+
+	fmt.Println("\n✅ Actor Manager: Alice's commands run one at a time on her goroutine")
+	actors := actor.New(employee.NewManager(slowRepository{memory.New()}), actor.WithIdle(50*time.Millisecond))
+	defer actors.Close()
+	fmt.Printf("   Alice earns %s\n", raiseConcurrently(ctx, actors))
+	fmt.Printf("   running actors: %d", actors.Actors())
+	time.Sleep(100 * time.Millisecond)
+	fmt.Printf(", %d once idle\n", actors.Actors())
+
+	fmt.Println("\n⚖️  Same script, synchronous vs actor Manager")
+	direct := employee.NewManager(memory.New())
+	async := actor.New(employee.NewManager(memory.New()))
+	defer async.Close()
+	diffs := assertlsp.Compare[httpapi.EmployeeService](direct, async, script(ctx)...)
+	for _, d := range diffs {
+		fmt.Println("   ❌", d)
+	}
+	if len(diffs) == 0 {
+		fmt.Printf("   ✅ %d steps, same results and errors\n", len(script(ctx)))
+	}
+}
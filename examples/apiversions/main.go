@@ -0,0 +1,150 @@
+// Command apiversions serves v1 and v2 of the employee HTTP API side by side,
+// each an adapter over the same Manager. v2 shows what it changed, and both
+// versions see the same employees. What v1 answers is pinned by a contract
+// in httpapi's tests (TestV1_Contract), so nothing added since can have
+// broken a v1 client. main_test.go checks every claim.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"time"
+
+	"go-solid/clock"
+	"go-solid/employee"
+	"go-solid/employee/memory"
+	"go-solid/httpapi"
+	"go-solid/id"
+)
+
+// newManager is a Manager whose IDs and clock are the same on every run, so
+// responses can be compared byte for byte.
+func newManager(repo employee.Repository) *employee.Manager {
+	return employee.NewManager(repo,
+		employee.WithClock(clock.NewFake(time.Date(2026, time.March, 2, 9, 0, 0, 0, time.UTC))),
+		employee.WithIDs(id.NewSequence("emp-")))
+}
+
+// serve mounts v1 at the root and v2 under /v2.
+func serve(svc httpapi.EmployeeServiceV2) *httptest.Server {
+	api := httpapi.New(svc)
+	api.Handle("/v2/", httpapi.NewV2(svc))
+	return httptest.NewServer(api)
+}
+
+// request One request the walkthrough sends, and what it shows
+type request struct {
+	what         string
+	method, path string
+	body         string
+}
+
+// response What came back: status, content type and body
+type response struct {
+	status int
+	ctype  string
+	body   string
+}
+
+// do sends one request.
+func do(srv *httptest.Server, r request) response {
+	req, _ := http.NewRequest(r.method, srv.URL+r.path, strings.NewReader(r.body))
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return response{body: err.Error()}
+	}
+	defer resp.Body.Close()
+	b, _ := io.ReadAll(resp.Body)
+	return response{resp.StatusCode, resp.Header.Get("Content-Type"), strings.TrimSpace(string(b))}
+}
+
+// openAPI fetches the document srv serves at path.
+func openAPI(srv *httptest.Server, path string) httpapi.Document {
+	var doc httpapi.Document
+	resp := do(srv, request{method: "GET", path: path})
+	_ = json.Unmarshal([]byte(resp.body), &doc)
+	return doc
+}
+
+// The requests main sends
+const (
+	omarNoDepartment = `{"name":"Omar","title":"Engineer","salary":{"amount":"5000.00","currency":"USD"}}`
+	omar             = `{"name":"Omar","title":"Engineer","department":"Platform","salary":{"amount":"5000.00","currency":"USD"}}`
+	lina             = `{"name":"Lina","title":"Analyst","salary":{"amount":"4000.00","currency":"USD"}}`
+	linaPromotion    = `{"title":"Senior Analyst","raise":{"amount":"400.00","currency":"USD"}}`
+)
+
+// rename renames an employee straight in storage, behind both APIs.
+func rename(repo employee.Repository, from, to string) error {
+	ctx := context.Background()
+	emp, err := repo.GetByName(ctx, from)
+	if err != nil {
+		return err
+	}
+	emp.Name = to
+	return repo.Save(ctx, emp)
+}
+
+// whatV2Changed sends the requests showing v2's changes to srv, renaming
+// Omar in repo, behind both APIs, half-way, and calls show with each.
+func whatV2Changed(srv *httptest.Server, repo employee.Repository, show func(request, response)) error {
+	send := func(reqs ...request) {
+		for _, r := range reqs {
+			show(r, do(srv, r))
+		}
+	}
+	send(
+		request{"hiring requires a department, and errors are problem details", "POST", "/v2/employees", omarNoDepartment},
+		request{"every field is always there, even an empty email", "POST", "/v2/employees", omar},
+		request{"employees are addressed by ID", "GET", "/v2/employees/emp-1", ""},
+	)
+	if err := rename(repo, "Omar", "Omar Haddad"); err != nil {
+		return err
+	}
+	send(
+		request{"so renaming them doesn't move them", "GET", "/v2/employees/emp-1", ""},
+		request{"where v1, addressed by name, has to follow the new name", "GET", "/employees/Omar", ""},
+		request{"an unknown ID is a 404 problem", "GET", "/v2/employees/emp-9", ""},
+	)
+	return nil
+}
+
+// oneService sends srv requests through v1 and v2 in turn, about the same
+// employee, the second one hired, and calls show with each.
+func oneService(srv *httptest.Server, show func(request, response)) {
+	for _, r := range []request{
+		{"hired through v1", "POST", "/employees", lina},
+		{"found through v2, with no department yet", "GET", "/v2/employees/emp-2", ""},
+		{"promoted through v2", "POST", "/v2/employees/emp-2/promotion", linaPromotion},
+		{"seen through v1", "GET", "/employees/Lina", ""},
+		{"removed through v2", "DELETE", "/v2/employees/emp-2", ""},
+		{"gone from v1", "GET", "/employees/Lina", ""},
+	} {
+		show(r, do(srv, r))
+	}
+}
+
+func main() {
+	repo := memory.New()
+	srv := serve(newManager(repo))
+	defer srv.Close()
+	show := func(r request, resp response) {
+		fmt.Printf("   %s\n      %s %s → %d %s\n", r.what, r.method, r.path, resp.status, resp.body)
+	}
+
+	fmt.Println("🆕 What v2 changed")
+	if err := whatV2Changed(srv, repo, show); err != nil {
+		fmt.Println("   ❌", err)
+		return
+	}
+	doc := openAPI(srv, "/v2/openapi.json")
+	fmt.Printf("   v2 has its own OpenAPI document, version %s, declaring problem details\n", doc.Info.Version)
+
+	fmt.Println("🔗 One service behind both")
+	oneService(srv, show)
+}
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"go-solid/employee/memory"
+)
+
+func TestWalkthrough(t *testing.T) {
+	repo := memory.New()
+	srv := serve(newManager(repo))
+	defer srv.Close()
+	var got []response
+	record := func(_ request, resp response) { got = append(got, resp) }
+	if err := whatV2Changed(srv, repo, record); err != nil {
+		t.Fatal(err)
+	}
+	doc := openAPI(srv, "/v2/openapi.json")
+	oneService(srv, record)
+
+	// what each response must hold, in the order main sends the requests
+	want := []struct {
+		status   int
+		ctype    string
+		contains []string
+	}{
+		// what v2 changed
+		{400, "application/problem+json", []string{`"detail":"department is required"`}},
+		{201, "", []string{`"email":""`, `"department":"Platform"`}},
+		{200, "", []string{`"name":"Omar"`}},
+		{200, "", []string{`"name":"Omar Haddad"`}},
+		{404, "", nil},
+		{404, "application/problem+json", []string{`"status":404`}},
+		// one service behind both
+		{201, "", nil},
+		{200, "", []string{`"name":"Lina"`, `"department":""`}},
+		{200, "", nil},
+		{200, "", []string{`"title":"Senior Analyst"`, `"amount":"4400.00"`}},
+		{204, "", nil},
+		{404, "", nil},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("%d requests sent, want %d", len(got), len(want))
+	}
+	for i, w := range want {
+		resp := got[i]
+		if resp.status != w.status || w.ctype != "" && resp.ctype != w.ctype {
+			t.Errorf("request %d = %d %s, want %d %s", i+1, resp.status, resp.ctype, w.status, w.ctype)
+		}
+		for _, c := range w.contains {
+			if !strings.Contains(resp.body, c) {
+				t.Errorf("request %d = %s, want it to contain %s", i+1, resp.body, c)
+			}
+		}
+	}
+
+	if doc.Info.Version != "2.0.0" {
+		t.Errorf("v2 OpenAPI version = %q, want 2.0.0", doc.Info.Version)
+	}
+	if _, ok := doc.Paths["/v2/employees/{id}"]["get"].Responses["404"].Content["application/problem+json"]; !ok {
+		t.Error("v2 OpenAPI declares no problem details for a 404 on /v2/employees/{id}")
+	}
+}
@@ -0,0 +1,90 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"go-solid/assertlsp"
+	"go-solid/employee"
+	"go-solid/employee/memory"
+	"go-solid/money"
+)
+
+// validatingRepository Rejects employees without a title before saving,
+// otherwise passing calls through to the memory repository
+type validatingRepository struct {
+	*memory.Repository
+	wrap bool
+}
+
+func (r validatingRepository) Save(ctx context.Context, emp employee.Employee) error {
+	if strings.TrimSpace(emp.Title) == "" {
+		return fmt.Errorf("employee %q has no title", emp.Name)
+	}
+	return r.Repository.Save(ctx, emp)
+}
+
+// ❌ Wrapping with %v keeps the message but loses ErrNotFound: callers
+// checking errors.Is(err, employee.ErrNotFound) now treat a missing
+// employee as a failure
+//
+// ✅ The fixed version wraps with %w
+func (r validatingRepository) GetByName(ctx context.Context, name string) (employee.Employee, error) {
+	emp, err := r.Repository.GetByName(ctx, name)
+	if err != nil && !r.wrap {
+		return emp, fmt.Errorf("get %s: %v", name, err)
+	}
+	if err != nil {
+		return emp, fmt.Errorf("get %s: %w", name, err)
+	}
+	return emp, nil
+}
+
+// script The calls a caller of employee.Repository makes; the implementations
+// must agree on all of them
+func script(ctx context.Context) []assertlsp.Step[employee.Repository] {
+	ali := employee.Employee{Name: "Ali", Title: "Engineer", Salary: money.Of(5000, money.USD)}
+	return []assertlsp.Step[employee.Repository]{
+		assertlsp.Exec("save Ali", func(r employee.Repository) error { return r.Save(ctx, ali) }),
+		assertlsp.Try("get Ali", func(r employee.Repository) (any, error) {
+			emp, err := r.GetByName(ctx, "Ali")
+			return emp.Title, err
+		}),
+		assertlsp.Try("get Bob", func(r employee.Repository) (any, error) {
+			_, err := r.GetByName(ctx, "Bob")
+			return nil, err
+		}),
+		assertlsp.Call("is Bob missing?", func(r employee.Repository) any {
+			_, err := r.GetByName(ctx, "Bob")
+			return errors.Is(err, employee.ErrNotFound)
+		}),
+	}
+}
+
+func main() {
+	ctx := context.Background()
+
+	fmt.Printf("⚖️  memory vs validating decorator wrapping with %%v\n")
+	bad := validatingRepository{Repository: memory.New()}
+	for _, d := range assertlsp.Compare[employee.Repository](memory.New(), bad, script(ctx)...) {
+		fmt.Println("   ❌", d)
+	}
+
+	fmt.Printf("\n⚖️  memory vs validating decorator wrapping with %%w\n")
+	good := validatingRepository{Repository: memory.New(), wrap: true}
+	if diffs := assertlsp.Compare[employee.Repository](memory.New(), good, script(ctx)...); len(diffs) == 0 {
+		fmt.Printf("   ✅ %d steps, same behaviour\n", len(script(ctx)))
+	}
+
+	// A decorator may be stricter than what it wraps - that's a stronger
+	// precondition, and LSP forbids it. The script shows it as a difference too.
+	fmt.Println("\n⚖️  saving an employee without a title")
+	untitled := assertlsp.Exec("save Bob, no title", func(r employee.Repository) error {
+		return r.Save(ctx, employee.Employee{Name: "Bob", Salary: money.Of(4000, money.USD)})
+	})
+	for _, d := range assertlsp.Compare[employee.Repository](memory.New(), good, untitled) {
+		fmt.Println("   ❌", d)
+	}
+}
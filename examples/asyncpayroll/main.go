@@ -0,0 +1,145 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"go-solid/employee"
+	"go-solid/employee/memory"
+	"go-solid/money"
+	"go-solid/payroll"
+	"go-solid/queue"
+	queuemem "go-solid/queue/memory"
+)
+
+const jobs = "payroll.jobs"
+
+type job struct {
+	Employee string `json:"employee"`
+	Year     int    `json:"year"`
+	Month    int    `json:"month"`
+}
+
+// ledger Where payslips end up. ✅ Idempotent: recording the same payslip
+// twice is a no-op, which is what at-least-once delivery requires.
+type ledger struct {
+	mu    sync.Mutex
+	slips map[string]payroll.Payslip
+	dups  int
+}
+
+func (l *ledger) record(slip payroll.Payslip) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	key := slip.EmployeeID + "/" + slip.Period.String()
+	if _, seen := l.slips[key]; seen {
+		l.dups++
+		return
+	}
+	l.slips[key] = slip
+}
+
+func main() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	repo := memory.New()
+	for i, e := range []struct {
+		name   string
+		salary money.Money
+	}{
+		{"Alice", money.Of(9000, money.USD)},
+		{"Bob", money.Of(4500, money.USD)},
+		{"Carol", money.Of(7000, money.USD)},
+		{"Karim", money.Of(60000, money.EGP)},
+		{"Nour", money.Of(42000, money.EGP)},
+		{"Oliver", money.Of(4000, money.GBP)},
+	} {
+		_ = repo.Save(ctx, employee.Employee{ID: employee.ID(fmt.Sprintf("emp-%d", i+1)), Name: e.name, Salary: e.salary})
+	}
+
+	engine := payroll.New(payroll.Config{
+		"US": {payroll.Pension{Rate: "0.05"}, payroll.IncomeTax{Brackets: []payroll.Bracket{{UpTo: money.Of(4000, money.USD), Rate: "0.12"}, {Rate: "0.22"}}}},
+		"EG": {payroll.IncomeTax{Brackets: []payroll.Bracket{{UpTo: money.Of(15000, money.EGP), Rate: "0.10"}, {Rate: "0.25"}}}},
+	})
+	staff := payroll.Staff{Repo: repo, CountryOf: payroll.ByCurrency(map[money.Currency]string{money.USD: "US", money.EGP: "EG", money.GBP: "GB"})}
+
+	// ✅ The demo only depends on queue.Producer and queue.Consumer; Kafka or
+	// RabbitMQ would replace the memory broker without touching the handlers
+	broker := queuemem.New(64)
+	broker.Backoff = 20 * time.Millisecond
+	var producer queue.Producer = broker
+	var consumer queue.Consumer = broker
+
+	books := &ledger{slips: make(map[string]payroll.Payslip)}
+	var done sync.WaitGroup // one per job: acknowledged or dead-lettered
+	var flaky sync.Map
+
+	worker := func(name string) queue.Handler {
+		return queue.HandlerFunc(func(ctx context.Context, msg queue.Message) error {
+			var j job
+			if err := json.Unmarshal(msg.Body, &j); err != nil {
+				return queue.Permanent(err)
+			}
+			emp, err := repo.GetByName(ctx, j.Employee)
+			if err != nil {
+				return err
+			}
+			slip, err := engine.Payslip(ctx, payroll.Period{Year: j.Year, Month: time.Month(j.Month)}, staff.Member(emp))
+			if errors.Is(err, payroll.ErrNoPipeline) {
+				return queue.Permanent(err) // ❌ retrying won't create a pipeline
+			}
+			if err != nil {
+				return err
+			}
+			books.record(slip)
+
+			// Carol's first delivery "crashes" after the ledger write but before
+			// the acknowledgement: the broker redelivers, the ledger shrugs
+			if _, crashed := flaky.LoadOrStore(j.Employee, true); !crashed && j.Employee == "Carol" {
+				fmt.Printf("   💥 %s crashed after recording %s (attempt %d)\n", name, j.Employee, msg.Attempt)
+				return errors.New("worker crashed before ack")
+			}
+			fmt.Printf("   ✅ %s paid %-6s net %s (attempt %d)\n", name, j.Employee, slip.Net(), msg.Attempt)
+			done.Done()
+			return nil
+		})
+	}
+
+	for _, name := range []string{"worker-1", "worker-2", "worker-3"} {
+		go consumer.Consume(ctx, jobs, worker(name))
+	}
+	go consumer.Consume(ctx, queue.DeadLetter(jobs), queue.HandlerFunc(func(ctx context.Context, msg queue.Message) error {
+		fmt.Printf("   ☠️  dead letter %s after %s attempt(s): %s\n", msg.Key, msg.Headers["x-attempts"], msg.Headers["x-error"])
+		done.Done()
+		return nil
+	}))
+
+	fmt.Println("📮 Publishing one payroll job per employee")
+	for emp, err := range staff.PaidEmployees(ctx) {
+		if err != nil {
+			fmt.Println("❌", err)
+			return
+		}
+		body, _ := json.Marshal(job{Employee: emp.EmployeeName(), Year: 2025, Month: int(time.March)})
+		done.Add(1)
+		if err := producer.Publish(ctx, jobs, queue.Message{Key: emp.EmployeeName(), Body: body}); err != nil {
+			fmt.Println("❌", err)
+			return
+		}
+	}
+	done.Wait()
+
+	fmt.Printf("\n📒 Ledger: %d payslips, %d duplicate deliveries ignored\n", len(books.slips), books.dups)
+	var names []string
+	for _, slip := range books.slips {
+		names = append(names, slip.Name)
+	}
+	sort.Strings(names)
+	fmt.Println("  ", names)
+}
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"go-solid/audit"
+	"go-solid/clock"
+	"go-solid/employee"
+	"go-solid/employee/memory"
+	"go-solid/id"
+	"go-solid/money"
+)
+
+// teeSink Fans a record out to several sinks - composed, not hard-coded in the manager
+type teeSink []audit.Sink
+
+func (t teeSink) Write(ctx context.Context, rec audit.Record) error {
+	for _, s := range t {
+		if err := s.Write(ctx, rec); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func main() {
+	ctx := audit.WithActor(context.Background(), "hr-admin")
+
+	// ✅ The manager only knows audit.Sink; stdout, file or SQL sinks are wiring decisions
+	kept := &audit.Memory{}
+	sink := audit.NewChain(teeSink{audit.NewWriterSink(os.Stdout), kept})
+
+	manager := employee.NewManager(memory.New(),
+		employee.WithIDs(id.NewSequence("emp-")),
+		employee.WithClock(clock.NewFake(time.Date(2026, time.March, 2, 9, 0, 0, 0, time.UTC))),
+		employee.WithAudit(sink),
+	)
+
+	fmt.Println("📝 Audit trail:")
+	_, _ = manager.AddEmployee(ctx, employee.Employee{Name: "Mohamed", Salary: money.Of(5000, money.USD)})
+	_, _ = manager.AddEmployee(ctx, employee.Employee{Name: "Ahmed", Salary: money.Of(6000, money.USD)})
+	_, _ = manager.FindEmployee(ctx, "Mohamed")
+	_, _ = manager.FindEmployee(ctx, "Nobody")
+
+	records := kept.Records()
+	fmt.Println()
+	fmt.Println("🔗 Verify untouched chain:", verdict(audit.Verify(records)))
+
+	// someone quietly "fixes" a salary in the stored log...
+	records[0].Details["salary"] = 9000
+	fmt.Println("🔗 Verify tampered chain: ", verdict(audit.Verify(records)))
+
+	// Auditing lives outside the business logic (SRP): the manager builds a record,
+	// the sink decides where it goes and the chain decorator makes it tamper-evident.
+}
+
+func verdict(err error) string {
+	if err != nil {
+		return "❌ " + err.Error()
+	}
+	return "✅ intact"
+}
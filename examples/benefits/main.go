@@ -0,0 +1,142 @@
+// Command benefits enrols full-timers, a part-timer, a contractor, an intern
+// and an apprentice - a kind written here - in health insurance, a pension,
+// equity and a commuter allowance. Who qualifies comes from the small
+// capability interfaces each kind implements, not from flags. main_test.go
+// checks every claim.
+package main
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	"go-solid/benefits"
+	"go-solid/clock"
+	"go-solid/employee"
+	"go-solid/money"
+)
+
+//////////--------------------Bad Practice--------------------/////////////////////////
+
+// ❌ One struct for everyone, and a flag for every difference
+//type Staff struct {
+//	Name         string
+//	Salary       float64 // ❌ 0 for contractors and interns - or just not filled in?
+//	IsFullTime   bool
+//	IsPartTime   bool
+//	IsContractor bool // ❌ nothing stops IsFullTime && IsContractor
+//	IsIntern     bool
+//	IsRemote     bool
+//	Dependants   int
+//	HiredAt      time.Time // ❌ zero for contractors: vested since year 1?
+//}
+//
+//func eligibleForPension(s Staff) bool {
+//	return (s.IsFullTime || s.IsPartTime) && !s.IsContractor // ❌ every benefit repeats the rules, each a little differently
+//}
+//
+//func eligibleForEquity(s Staff, now time.Time) bool {
+//	return s.IsFullTime && !s.IsIntern && now.Sub(s.HiredAt) > 365*24*time.Hour // ❌ a new kind of worker means auditing all of these
+//}
+
+//////////////-----------------------------Good Practice-------------------/////////////////////////////////////////////////////////
+
+// apprentice A kind of member written here: salaried and on site, so
+// pensioned and given a commuter allowance, but not insured through the
+// company or granted equity. The Plan takes it without a change (OCP).
+type apprentice struct {
+	id     string
+	wage   money.Money
+	office string
+}
+
+func (a apprentice) MemberID() string               { return a.id }
+func (a apprentice) PensionableSalary() money.Money { return a.wage }
+func (a apprentice) Site() string                   { return a.office }
+
+// show prints a statement: what the member got, and why not the rest.
+func show(s benefits.Statement) {
+	for _, e := range s.Enrolled {
+		cost := "-"
+		if !e.MonthlyCost.IsZero() {
+			cost = e.MonthlyCost.String()
+		}
+		fmt.Printf("      %-6s ✔ %-8s %-12s %s\n", s.Member, e.Benefit, cost, e.Note)
+	}
+	for _, name := range slices.Sorted(maps.Keys(s.Refused)) {
+		fmt.Printf("      %-6s ✘ %-8s %v\n", s.Member, name, s.Refused[name])
+	}
+}
+
+// enrolled returns the names of the benefits in s, in order.
+func enrolled(s benefits.Statement) string {
+	var names []string
+	for _, e := range s.Enrolled {
+		names = append(names, e.Benefit)
+	}
+	return strings.Join(names, ", ")
+}
+
+var today = time.Date(2025, time.June, 1, 0, 0, 0, 0, time.UTC)
+
+// newPlan offers health insurance, a 5% pension match, equity after a
+// year and a commuter allowance.
+func newPlan() *benefits.Plan {
+	return benefits.NewPlan([]benefits.Benefit{
+		benefits.Health{PerPerson: money.Of(200, money.EUR)},
+		benefits.Pension{Match: "0.05"},
+		benefits.Equity{CliffMonths: 12, Units: 500},
+		benefits.Commuter{Allowance: money.Of(80, money.EUR)},
+	}, benefits.WithClock(clock.NewFake(today)))
+}
+
+func salaried(id, name string, salary int64, hired time.Time) employee.Employee {
+	return employee.Employee{ID: employee.ID(id), Name: name, Title: "Engineer", Salary: money.Of(salary, money.EUR), HiredAt: hired}
+}
+
+var (
+	// alice is full-time in the office for three years, with two dependants
+	alice = benefits.FullTime{Employee: salaried("alice", "Alice", 6000, today.AddDate(-3, 0, 0)), Dependants: 2, Office: "Berlin"}
+	// bob is full-time too, but hired two months ago, and remote
+	bob = benefits.FullTime{Employee: salaried("bob", "Bob", 5000, today.AddDate(0, -2, 0))}
+	// carol is part-time, for five years
+	carol = benefits.PartTime{Employee: salaried("carol", "Carol", 3000, today.AddDate(-5, 0, 0)), Office: "Lisbon"}
+	dan   = benefits.Contractor{ID: "dan", Name: "Dan"}
+	erin  = benefits.Intern{ID: "erin", Name: "Erin", Office: "Berlin"}
+	frank = apprentice{id: "frank", wage: money.Of(1800, money.EUR), office: "Lisbon"}
+)
+
+func main() {
+	plan := newPlan()
+
+	fmt.Println("🧑‍💼 Employees")
+	s := plan.Enroll(alice)
+	show(s)
+	cost, _ := s.MonthlyCost()
+	fmt.Println("   Alice, full-time in the office for three years, gets all four:", enrolled(s))
+	fmt.Println("   costing 3 × 200 + 5% of 6000 + 80 a month:", cost)
+	s = plan.Enroll(bob)
+	show(s)
+	fmt.Println("   Bob is full-time too, but hired two months ago, and remote:", enrolled(s))
+	fmt.Println("   the same capabilities, refused on their values")
+	s = plan.Enroll(carol)
+	show(s)
+	fmt.Println("   Carol, part-time for five years, has no equity however long they stay:", enrolled(s))
+
+	fmt.Println("🧰 Not employees")
+	show(plan.Enroll(dan))
+	_, pensionable := any(dan).(benefits.Pensionable)
+	fmt.Println("   Dan, a contractor, has none of the capabilities, so none of the benefits")
+	fmt.Println("   and no PensionableSalary method to return a made-up zero (ISP) - Pensionable:", pensionable)
+	s = plan.Enroll(erin)
+	show(s)
+	fmt.Println("   Erin, an intern, has an office and nothing else:", enrolled(s))
+
+	fmt.Println("➕ A new kind of member, and no change to the Plan or the benefits (OCP)")
+	s = plan.Enroll(frank)
+	show(s)
+	cost, _ = s.MonthlyCost()
+	fmt.Printf("   Frank, an apprentice, is pensioned and commutes: %s, %v\n", enrolled(s), cost)
+}
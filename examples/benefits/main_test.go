@@ -0,0 +1,56 @@
+package main
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"go-solid/benefits"
+	"go-solid/money"
+)
+
+func TestPlan_Enroll(t *testing.T) {
+	tests := []struct {
+		name     string
+		member   benefits.Member
+		want     string
+		wantCost money.Money
+		refused  map[string]string
+	}{
+		{name: "full-time in the office", member: alice, want: "health, pension, equity, commuter", wantCost: money.Of(980, money.EUR)},
+		{name: "full-time, new and remote", member: bob, want: "health, pension", wantCost: money.Of(450, money.EUR),
+			refused: map[string]string{"equity": "eligible from 2026-04-01", "commuter": "works remotely"}},
+		{name: "part-time", member: carol, want: "health, pension, commuter", wantCost: money.Of(430, money.EUR),
+			refused: map[string]string{"equity": "no start date to vest from"}},
+		{name: "contractor", member: dan, want: "", wantCost: money.Money{},
+			refused: map[string]string{"health": "not insurable", "pension": "no pensionable salary", "equity": "no start date", "commuter": "no office"}},
+		{name: "intern", member: erin, want: "commuter", wantCost: money.Of(80, money.EUR)},
+		{name: "apprentice, written here", member: frank, want: "pension, commuter", wantCost: money.Of(170, money.EUR)},
+	}
+	plan := newPlan()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := plan.Enroll(tt.member)
+			if got := enrolled(s); got != tt.want {
+				t.Errorf("Enroll() = %q, want %q", got, tt.want)
+			}
+			if cost, err := s.MonthlyCost(); err != nil || cost != tt.wantCost {
+				t.Errorf("MonthlyCost() = %v, %v, want %v", cost, err, tt.wantCost)
+			}
+			for name, reason := range tt.refused {
+				if err := s.Refused[name]; !errors.Is(err, benefits.ErrNotEligible) || !strings.Contains(err.Error(), reason) {
+					t.Errorf("Refused[%s] = %v, want %v: %s", name, err, benefits.ErrNotEligible, reason)
+				}
+			}
+			if len(s.Enrolled)+len(s.Refused) != 4 {
+				t.Errorf("Enroll() = %d enrolled and %d refused, want each of the 4 benefits in one", len(s.Enrolled), len(s.Refused))
+			}
+		})
+	}
+}
+
+func TestContractor_IsNotPensionable(t *testing.T) {
+	if _, ok := any(dan).(benefits.Pensionable); ok {
+		t.Error("Contractor implements Pensionable, want no PensionableSalary to return a made-up zero")
+	}
+}
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"iter"
+	"time"
+
+	"go-solid/employee"
+	"go-solid/employee/memory"
+	"go-solid/money"
+)
+
+// generated Streams n employees without ever holding them in a slice; with
+// broken set, a few of them carry an invalid salary, as in any real import.
+func generated(n int, broken bool) iter.Seq[employee.Employee] {
+	return func(yield func(employee.Employee) bool) {
+		for i := range n {
+			salary := money.Of(int64(4000+i%50*100), money.USD)
+			if broken && i%2500 == 1234 {
+				salary = money.Of(-1, money.USD)
+			}
+			if !yield(employee.Employee{ID: employee.ID(fmt.Sprintf("emp-%d", i)), Name: fmt.Sprintf("Employee %05d", i), Salary: salary}) {
+				return
+			}
+		}
+	}
+}
+
+// validatingRepository Plain employee.Repository with a rule of its own and no bulk capability
+type validatingRepository struct {
+	employee.Repository
+}
+
+func (r validatingRepository) Save(ctx context.Context, emp employee.Employee) error {
+	if !emp.Salary.IsPositive() {
+		return employee.ErrInvalidSalary
+	}
+	return r.Repository.Save(ctx, emp)
+}
+
+func main() {
+	ctx := context.Background()
+
+	// ✅ The caller writes a stream; whether it is batched is the backend's business
+	fmt.Println("📦 10,000 employees into the memory backend (BulkSaver)")
+	repo := memory.New()
+	began := time.Now()
+	err := employee.SaveAll(ctx, repo, generated(10_000, false))
+	fmt.Printf("   err=%v in %s\n", err, time.Since(began).Round(time.Millisecond))
+
+	fmt.Println()
+	fmt.Println("🐢 Same stream into a backend without the capability (falls back to Save)")
+	err = employee.SaveAll(ctx, validatingRepository{memory.New()}, generated(10_000, true))
+	report(err)
+
+	fmt.Println()
+	fmt.Println("✋ Cancelled halfway")
+	ctx, cancel := context.WithCancel(ctx)
+	half := func(yield func(employee.Employee) bool) {
+		i := 0
+		for emp := range generated(10_000, false) {
+			if i++; i == 5_000 {
+				cancel()
+			}
+			if !yield(emp) {
+				return
+			}
+		}
+	}
+	report(employee.SaveAll(ctx, memory.New(), half))
+
+	// SaveAll is never all-or-nothing: the BulkError says exactly what was not
+	// saved, so an import can be fixed and re-run for just those rows.
+}
+
+func report(err error) {
+	var bulk *employee.BulkError
+	if !errors.As(err, &bulk) {
+		fmt.Println("   ✅ all saved")
+		return
+	}
+	fmt.Printf("   saved %d, failed %d\n", bulk.Saved, len(bulk.Failed))
+	for _, f := range bulk.Failed {
+		fmt.Printf("   ❌ row %d %s: %v\n", f.Index, f.Name, f.Err)
+	}
+	if bulk.Stopped != nil {
+		fmt.Println("   ⏹️  stopped:", bulk.Stopped)
+	}
+	if len(bulk.Failed) > 0 {
+		fmt.Println("   errors.Is(err, ErrInvalidSalary):", errors.Is(err, employee.ErrInvalidSalary))
+	}
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"go-solid/bulkhead"
+	"go-solid/chaos"
+	"go-solid/employee"
+	"go-solid/employee/memory"
+	"go-solid/money"
+)
+
+// repositories Two backends sharing one pool of ten database connections.
+// The reporting one has become slow; the employee one is as fast as ever.
+func repositories(ctx context.Context, partition bool) (reports, employees employee.Repository, slow *bulkhead.Bulkhead) {
+	injector, _ := chaos.New(chaos.Config{Enabled: true, Latency: 200 * time.Millisecond, LatencyRate: 1}, chaos.WithSeed(3))
+	pool := bulkhead.New(10, 1000) // ten connections; everyone else waits for one
+	reportsDB, employeesDB := memory.New(), memory.New()
+	for _, repo := range []*memory.Repository{reportsDB, employeesDB} {
+		_ = repo.Save(ctx, employee.Employee{Name: "Alice", Salary: money.Of(5000, money.USD)})
+	}
+
+	reports = bulkhead.NewRepository(chaos.NewRepository(reportsDB, injector), pool)
+	employees = bulkhead.NewRepository(employeesDB, pool)
+	if partition {
+		// ✅ The reports get four of the connections at most, and four more
+		// callers may wait for them; the rest are turned away at once
+		slow = bulkhead.New(4, 4)
+		reports = bulkhead.NewRepository(reports, slow)
+	}
+	return reports, employees, slow
+}
+
+// load Forty report lookups, and ten employee lookups right behind them
+func load(ctx context.Context, reports, employees employee.Repository) {
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		rejected int
+		slowest  time.Duration
+	)
+	for range 40 {
+		wg.Go(func() {
+			if _, err := reports.GetByName(ctx, "Alice"); errors.Is(err, bulkhead.ErrRejected) {
+				mu.Lock()
+				rejected++
+				mu.Unlock()
+			}
+		})
+	}
+	time.Sleep(10 * time.Millisecond) // the reports got there first
+	for range 10 {
+		wg.Go(func() {
+			start := time.Now()
+			_, _ = employees.GetByName(ctx, "Alice")
+			mu.Lock()
+			slowest = max(slowest, time.Since(start))
+			mu.Unlock()
+		})
+	}
+	wg.Wait()
+	fmt.Printf("   reports: %d of 40 rejected at once\n", rejected)
+	fmt.Printf("   employees: slowest of 10 lookups took %s\n", slowest.Round(10*time.Millisecond))
+}
+
+func main() {
+	ctx := context.Background()
+
+	// ❌ Every connection goes to the slow reports; employee lookups queue
+	// behind them though nothing is wrong with their own backend
+	fmt.Println("🚢 One pool, no partition: reports take 200ms each")
+	reports, employees, _ := repositories(ctx, false)
+	load(ctx, reports, employees)
+
+	fmt.Println("\n🧱 Reports behind a bulkhead of 4 slots and 4 queued")
+	reports, employees, slow := repositories(ctx, true)
+	load(ctx, reports, employees)
+	s := slow.Stats()
+	fmt.Printf("   📊 admitted %d, rejected %d, abandoned %d, in flight %d\n", s.Admitted, s.Rejected, s.Abandoned, s.InFlight)
+}
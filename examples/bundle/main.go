@@ -0,0 +1,215 @@
+// Command bundle packs a workshop the way solid bundle does, and then uses
+// it as a room without internet would: the archive is checked against its
+// manifest, the course is read out of it, and go resolves a dependency from
+// the module cache it carries, with no proxy to ask. The binary is stood in
+// for by a script, so the example doesn't wait on a build. Run it from the
+// module root. main_test.go checks every claim.
+package main
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing/fstest"
+	"time"
+
+	"go-solid/bundle"
+	"go-solid/content"
+)
+
+// greet A dependency the room can't download: a module as a proxy serves
+// it, under the download directory's layout
+func greet() fstest.MapFS {
+	var zipped bytes.Buffer
+	zw := zip.NewWriter(&zipped)
+	for name, src := range map[string]string{
+		"go.mod":   "module example.com/greet\n\ngo 1.21\n",
+		"greet.go": "package greet\n\nfunc Hello(name string) string { return \"Hello, \" + name + \", offline\" }\n",
+	} {
+		w, _ := zw.Create("example.com/greet@v1.0.0/" + name)
+		_, _ = w.Write([]byte(src))
+	}
+	_ = zw.Close()
+	return fstest.MapFS{
+		"example.com/greet/@v/list":        {Data: []byte("v1.0.0\n")},
+		"example.com/greet/@v/v1.0.0.info": {Data: []byte(`{"Version":"v1.0.0","Time":"2026-03-02T09:00:00Z"}`)},
+		"example.com/greet/@v/v1.0.0.mod":  {Data: []byte("module example.com/greet\n\ngo 1.21\n")},
+		"example.com/greet/@v/v1.0.0.zip":  {Data: zipped.Bytes()},
+	}
+}
+
+var manifest = bundle.Manifest{Name: "workshop", Created: time.Date(2026, time.March, 2, 9, 0, 0, 0, time.UTC), Go: "go1.25", Platform: "linux/amd64"}
+
+// workshop returns what the workshop packs: a stand-in binary, the
+// course, the module under root without this example, a module cache and
+// the setup scripts.
+func workshop(ctx context.Context, root string) []bundle.Part {
+	return []bundle.Part{
+		{Dir: "bin", FS: fstest.MapFS{"solid": {Data: []byte("#!/bin/sh\necho solid, standing in\n"), Mode: 0o755}}},
+		bundle.Content(content.Embedded().FS),
+		bundle.Module(ctx, root, filepath.Join(root, "examples", "bundle")),
+		{Dir: "modcache", FS: greet()},
+		bundle.Setup(manifest),
+	}
+}
+
+// offline builds and runs a learner's module needing example.com/greet in
+// tmp, with modcache as its only proxy, and returns what it printed.
+func offline(ctx context.Context, modcache, tmp string) (string, error) {
+	app := filepath.Join(tmp, "app")
+	_ = os.MkdirAll(app, 0o755)
+	_ = os.WriteFile(filepath.Join(app, "go.mod"), []byte("module app\n\ngo 1.21\n\nrequire example.com/greet v1.0.0\n"), 0o644)
+	_ = os.WriteFile(filepath.Join(app, "main.go"), []byte("package main\n\nimport (\n\t\"fmt\"\n\n\t\"example.com/greet\"\n)\n\nfunc main() { fmt.Println(greet.Hello(\"workshop\")) }\n"), 0o644)
+	env := append(os.Environ(), "GOPROXY=file://"+filepath.ToSlash(modcache), "GOSUMDB=off",
+		"GOTOOLCHAIN=local", "GOFLAGS=-mod=mod", "GOMODCACHE="+filepath.Join(tmp, "gomodcache"))
+	// the module cache is read-only, so it needs go to remove it
+	defer func() {
+		cleaner := exec.Command("go", "clean", "-modcache")
+		cleaner.Env = env
+		_ = cleaner.Run()
+	}()
+	var (
+		out []byte
+		err error
+	)
+	for _, args := range [][]string{{"mod", "tidy"}, {"run", "."}} {
+		cmd := exec.CommandContext(ctx, "go", args...)
+		cmd.Dir, cmd.Env = app, env
+		if out, err = cmd.CombinedOutput(); err != nil {
+			break
+		}
+	}
+	return strings.TrimSpace(string(out)), err
+}
+
+func main() {
+	ctx := context.Background()
+	tmp, _ := os.MkdirTemp("", "bundle")
+	defer os.RemoveAll(tmp)
+
+	fmt.Println("📦 Packing the workshop")
+	parts := workshop(ctx, ".")
+	var archive bytes.Buffer
+	written, err := bundle.Write(ctx, &archive, manifest, parts...)
+	if err != nil {
+		fmt.Println("   ❌", err)
+		return
+	}
+	fmt.Printf("   %d files, %.1f MB unpacked, %.1f MB archived, in %s\n", len(written.Files),
+		float64(written.Size())/(1<<20), float64(archive.Len())/(1<<20), strings.Join(written.Parts, ", "))
+	var again bytes.Buffer
+	_, _ = bundle.Write(ctx, &again, manifest, parts...)
+	fmt.Println("   the same parts make the same archive, byte for byte:", bytes.Equal(archive.Bytes(), again.Bytes()))
+	_, err = bundle.Write(ctx, &again, manifest, parts[0], parts[0])
+	fmt.Println("   a file two parts both claim is refused:", err)
+
+	fmt.Println("🔒 Checked before the room relies on it")
+	b, err := bundle.Open(bytes.NewReader(archive.Bytes()))
+	if err != nil {
+		fmt.Println("   ❌", err)
+		return
+	}
+	fmt.Println("   the archive matches its manifest")
+	info, _ := fs.Stat(b, "bin/solid")
+	fmt.Println("   solid stays executable:", info.Mode())
+	fmt.Println("   the module's source is there, without what git ignores")
+	changed := clone(b.MapFS)
+	changed["content/2-ocp/quiz.json"] = &fstest.MapFile{Data: []byte(`{"questions": []}`)}
+	_, err = bundle.Open(repack(changed))
+	fmt.Println("   a file changed on the way is caught:", err)
+	added := clone(b.MapFS)
+	added["bin/helper"] = &fstest.MapFile{Data: []byte("#!/bin/sh\n"), Mode: 0o755}
+	_, err = bundle.Open(repack(added))
+	fmt.Println("   so is one slipped in:", err)
+	dir := filepath.Join(tmp, "unpacked")
+	if err := unpack(b, dir); err != nil {
+		fmt.Println("   ❌", err)
+		return
+	}
+	_, err = bundle.Verify(os.DirFS(dir))
+	fmt.Println("   unpacked, it verifies too:", err == nil)
+
+	fmt.Println("📚 The course, read from the bundle")
+	path := filepath.Join(tmp, "workshop.tar.gz")
+	_ = os.WriteFile(path, archive.Bytes(), 0o644)
+	for _, from := range []string{path, dir} {
+		c, err := content.Bundle(from)
+		if err != nil {
+			fmt.Println("   ❌", err)
+			continue
+		}
+		lessons, _ := c.Lessons()
+		quiz, _ := c.Quiz("lsp")
+		fmt.Printf("   %d lessons and the lsp quiz (%d questions) from %s, as solid compiled them\n", len(lessons), len(quiz), filepath.Base(from))
+	}
+	var bare bytes.Buffer
+	_, _ = bundle.Write(ctx, &bare, manifest, parts[0])
+	_ = os.WriteFile(path, bare.Bytes(), 0o644)
+	_, err = content.Bundle(path)
+	fmt.Println("   a bundle packed without the course says so:", err)
+
+	fmt.Println("🌐 go, with no proxy but the bundle")
+	out, err := offline(ctx, filepath.Join(dir, "modcache"), tmp)
+	if err != nil {
+		fmt.Printf("   ❌ %v: %s\n", err, out)
+		return
+	}
+	fmt.Println("   a learner's module gets example.com/greet from modcache/:", out)
+}
+
+func exists(fsys fs.FS, name string) bool {
+	_, err := fs.Stat(fsys, name)
+	return err == nil
+}
+
+func clone(files fstest.MapFS) fstest.MapFS {
+	out := fstest.MapFS{}
+	for name, f := range files {
+		copied := *f
+		out[name] = &copied
+	}
+	return out
+}
+
+// repack archives files again without a new manifest, as someone editing a
+// bundle by hand would.
+func repack(files fstest.MapFS) *bytes.Reader {
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(zw)
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	for _, name := range names {
+		f := files[name]
+		_ = tw.WriteHeader(&tar.Header{Name: "workshop/" + name, Mode: int64(f.Mode.Perm() | 0o644), Size: int64(len(f.Data))})
+		_, _ = tw.Write(f.Data)
+	}
+	_ = tw.Close()
+	_ = zw.Close()
+	return bytes.NewReader(buf.Bytes())
+}
+
+func unpack(b *bundle.Bundle, dir string) error {
+	for name, f := range b.MapFS {
+		target := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+			return err
+		}
+		if err := os.WriteFile(target, f.Data, f.Mode.Perm()|0o600); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,126 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"go-solid/bundle"
+	"go-solid/content"
+)
+
+// pack writes the workshop from the module root, two directories up.
+func pack(t *testing.T) ([]bundle.Part, *bytes.Buffer) {
+	t.Helper()
+	parts := workshop(t.Context(), filepath.Join("..", ".."))
+	var archive bytes.Buffer
+	if _, err := bundle.Write(t.Context(), &archive, manifest, parts...); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	return parts, &archive
+}
+
+func TestWrite(t *testing.T) {
+	parts, archive := pack(t)
+	var again bytes.Buffer
+	if _, err := bundle.Write(t.Context(), &again, manifest, parts...); err != nil || !bytes.Equal(archive.Bytes(), again.Bytes()) {
+		t.Errorf("Write() again = %v, want the same archive byte for byte", err)
+	}
+	if _, err := bundle.Write(t.Context(), &again, manifest, parts[0], parts[0]); err == nil {
+		t.Error("Write() with a file in two parts = nil, want it refused")
+	}
+}
+
+func TestOpen(t *testing.T) {
+	_, archive := pack(t)
+	b, err := bundle.Open(bytes.NewReader(archive.Bytes()))
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	if info, err := fs.Stat(b, "bin/solid"); err != nil || info.Mode()&0o100 == 0 {
+		t.Errorf("Stat(bin/solid) = %v, %v, want it executable", info.Mode(), err)
+	}
+	for name, want := range map[string]bool{"module/go.mod": true, "module/employee/employee.go": true, "module/requests.jsonl": false} {
+		if got := exists(b, name); got != want {
+			t.Errorf("exists(%s) = %v, want %v", name, got, want)
+		}
+	}
+
+	changed := clone(b.MapFS)
+	changed["content/2-ocp/quiz.json"] = &fstest.MapFile{Data: []byte(`{"questions": []}`)}
+	if _, err := bundle.Open(repack(changed)); !errors.Is(err, bundle.ErrCorrupt) {
+		t.Errorf("Open(changed) error = %v, want %v", err, bundle.ErrCorrupt)
+	}
+	added := clone(b.MapFS)
+	added["bin/helper"] = &fstest.MapFile{Data: []byte("#!/bin/sh\n"), Mode: 0o755}
+	if _, err := bundle.Open(repack(added)); !errors.Is(err, bundle.ErrCorrupt) {
+		t.Errorf("Open(added) error = %v, want %v", err, bundle.ErrCorrupt)
+	}
+
+	dir := t.TempDir()
+	if err := unpack(b, dir); err != nil {
+		t.Fatalf("unpack() error = %v", err)
+	}
+	if _, err := bundle.Verify(os.DirFS(dir)); err != nil {
+		t.Errorf("Verify(unpacked) error = %v", err)
+	}
+}
+
+func TestContentBundle(t *testing.T) {
+	parts, archive := pack(t)
+	b, err := bundle.Open(bytes.NewReader(archive.Bytes()))
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	dir, path := t.TempDir(), filepath.Join(t.TempDir(), "workshop.tar.gz")
+	if err := unpack(b, dir); err != nil {
+		t.Fatalf("unpack() error = %v", err)
+	}
+	if err := os.WriteFile(path, archive.Bytes(), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	embedded, _ := content.Embedded().Lessons()
+	for _, from := range []string{path, dir} {
+		c, err := content.Bundle(from)
+		if err != nil {
+			t.Fatalf("Bundle(%s) error = %v", from, err)
+		}
+		if lessons, err := c.Lessons(); err != nil || !slices.Equal(lessons, embedded) {
+			t.Errorf("Lessons() from %s = %v, %v, want the embedded lessons", filepath.Base(from), lessons, err)
+		}
+		if quiz, err := c.Quiz("lsp"); err != nil || len(quiz) == 0 {
+			t.Errorf("Quiz(lsp) from %s = %d questions, %v, want some", filepath.Base(from), len(quiz), err)
+		}
+	}
+
+	var bare bytes.Buffer
+	if _, err := bundle.Write(t.Context(), &bare, manifest, parts[0]); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, bare.Bytes(), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := content.Bundle(path); !errors.Is(err, content.ErrNoContent) {
+		t.Errorf("Bundle(without content) error = %v, want %v", err, content.ErrNoContent)
+	}
+}
+
+func TestOffline(t *testing.T) {
+	if testing.Short() {
+		t.Skip("runs go")
+	}
+	modcache := t.TempDir()
+	if err := os.CopyFS(modcache, greet()); err != nil {
+		t.Fatal(err)
+	}
+	out, err := offline(t.Context(), modcache, t.TempDir())
+	if err != nil || !strings.Contains(out, "offline") {
+		t.Errorf("offline() = %q, %v, want example.com/greet resolved from the module cache", out, err)
+	}
+}
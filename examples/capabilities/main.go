@@ -0,0 +1,63 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"go-solid/employee"
+	"go-solid/employee/memory"
+	"go-solid/money"
+)
+
+// loggingRepository Decorator that only embeds the base interface.
+// ⚠️ Its method set is exactly employee.Repository, so the optional capabilities
+// of the wrapped backend become invisible to type assertions.
+type loggingRepository struct {
+	employee.Repository
+}
+
+func (r loggingRepository) Save(ctx context.Context, emp employee.Employee) error {
+	fmt.Printf("   [log] saving %s\n", emp.Name)
+	return r.Repository.Save(ctx, emp)
+}
+
+func main() {
+	ctx := context.Background()
+
+	// ✅ Backends implement the small core interface plus whichever capabilities they can honour.
+	// The memory backend supports both soft deletes and history; the SQL backend (employee/sqlrepo)
+	// only supports soft deletes.
+	repo := memory.New()
+	manager := employee.NewManager(repo)
+
+	fmt.Println("🧠 Memory repository (SoftDeleter + Versioned)")
+	_, _ = manager.AddEmployee(ctx, employee.Employee{Name: "Mohamed", Salary: money.Of(5000, money.USD)})
+	emp, _ := manager.FindEmployee(ctx, "Mohamed")
+	emp.Salary = money.Of(5500, money.USD)
+	_ = repo.Save(ctx, emp) // salary correction creates a new revision
+
+	history, _ := manager.EmployeeHistory(ctx, "Mohamed")
+	for _, rev := range history {
+		fmt.Printf("   v%d: salary %s\n", rev.Version, rev.Salary)
+	}
+
+	_ = manager.RemoveEmployee(ctx, "Mohamed")
+	_, err := manager.FindEmployee(ctx, "Mohamed")
+	fmt.Println("   after soft delete:", err)
+	_ = manager.RestoreEmployee(ctx, "Mohamed")
+	emp, _ = manager.FindEmployee(ctx, "Mohamed")
+	fmt.Printf("   after restore: %s, salary %s\n", emp.Name, emp.Salary)
+
+	fmt.Println()
+	fmt.Println("🪞 Same backend behind a decorator that only exposes employee.Repository")
+	wrapped := employee.NewManager(loggingRepository{repo})
+	_, _ = wrapped.AddEmployee(ctx, employee.Employee{Name: "Ahmed", Salary: money.Of(6000, money.USD)})
+	if err := wrapped.RemoveEmployee(ctx, "Ahmed"); errors.Is(err, errors.ErrUnsupported) {
+		fmt.Println("   ❌", err)
+	}
+
+	// Capabilities are detected with type assertions instead of being forced on every backend (ISP).
+	// The flip side: anything wrapping a repository must forward the capabilities it wants to keep,
+	// otherwise substituting the decorator silently changes behaviour (LSP).
+}
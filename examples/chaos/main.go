@@ -0,0 +1,91 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"time"
+
+	"go-solid/chaos"
+	"go-solid/employee"
+	"go-solid/employee/memory"
+	"go-solid/money"
+	"go-solid/notify"
+)
+
+func main() {
+	ctx := context.Background()
+
+	// ✅ The decorator sits between the Manager and the real repository; neither changes
+	injector, _ := chaos.New(chaos.Config{}, chaos.WithSeed(7))
+	manager := employee.NewManager(chaos.NewRepository(memory.New(), injector))
+	for _, name := range []string{"Mohamed", "Ahmed", "Ali"} {
+		_, _ = manager.AddEmployee(ctx, employee.Employee{Name: name, Salary: money.Of(5000, money.USD)})
+	}
+
+	fmt.Println("😇 Chaos disabled")
+	lookups(ctx, manager, 5)
+
+	fmt.Println("\n💥 30% of calls fail")
+	_ = injector.Set(chaos.Config{Enabled: true, ErrorRate: 0.3})
+	lookups(ctx, manager, 10)
+
+	fmt.Println("\n🐢 Half the calls take 50-70ms more; the caller allows 60ms")
+	_ = injector.Set(chaos.Config{Enabled: true, Latency: 50 * time.Millisecond, Jitter: 20 * time.Millisecond, LatencyRate: 0.5})
+	lookupsWithin(ctx, manager, 6, 60*time.Millisecond)
+
+	fmt.Println("\n⏳ A call that hangs gives up when its context does")
+	_ = injector.Set(chaos.Config{Enabled: true, TimeoutRate: 1})
+	timeoutCtx, cancel := context.WithTimeout(ctx, 100*time.Millisecond)
+	start := time.Now()
+	_, err := manager.FindEmployee(timeoutCtx, "Mohamed")
+	cancel()
+	fmt.Printf("   after %s: %v (deadline exceeded: %t)\n", time.Since(start).Round(10*time.Millisecond), err, errors.Is(err, context.DeadlineExceeded))
+
+	// ✅ Notifiers get the same decorator
+	fmt.Println("\n✉️  Notifications while 100% of them fail")
+	_ = injector.Set(chaos.Config{Enabled: true, ErrorRate: 1})
+	notifier := chaos.NewNotifier(notify.NewConsole(nil), injector)
+	err = notifier.Notify(ctx, notify.Message{To: "mohamed@example.com", Subject: "Payslip", Body: "ready"})
+	fmt.Println("   ❌", err)
+
+	// ✅ Reconfigured at runtime over HTTP, as the employee-api admin endpoint does
+	admin := httptest.NewServer(injector)
+	defer admin.Close()
+	fmt.Println("\n🎛️  PUT /admin/chaos")
+	req, _ := http.NewRequest(http.MethodPut, admin.URL, strings.NewReader(`{"enabled": false}`))
+	if resp, err := http.DefaultClient.Do(req); err == nil {
+		resp.Body.Close()
+		fmt.Println("   ->", resp.Status)
+	}
+	_ = notifier.Notify(ctx, notify.Message{To: "mohamed@example.com", Subject: "Payslip", Body: "ready"})
+
+	s := injector.Stats()
+	fmt.Printf("\n📊 %d calls: %d delayed, %d failed, %d timed out\n", s.Calls, s.Delayed, s.Failed, s.TimedOut)
+}
+
+func lookups(ctx context.Context, manager *employee.Manager, n int) {
+	lookupsWithin(ctx, manager, n, 0)
+}
+
+func lookupsWithin(ctx context.Context, manager *employee.Manager, n int, budget time.Duration) {
+	names := []string{"Mohamed", "Ahmed", "Ali"}
+	for i := range n {
+		callCtx, cancel := ctx, context.CancelFunc(func() {})
+		if budget > 0 {
+			callCtx, cancel = context.WithTimeout(ctx, budget)
+		}
+		start := time.Now()
+		emp, err := manager.FindEmployee(callCtx, names[i%len(names)])
+		cancel()
+		took := time.Since(start).Round(time.Millisecond)
+		if err != nil {
+			fmt.Printf("   ❌ %-8s %6s  %v\n", names[i%len(names)], took, err)
+			continue
+		}
+		fmt.Printf("   ✅ %-8s %6s\n", emp.Name, took)
+	}
+}
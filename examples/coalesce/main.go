@@ -0,0 +1,139 @@
+// Command coalesce saves two hundred employees from two hundred goroutines,
+// first straight to a backend that pays a round trip per call, then through
+// coalesce.Repository. It also shows each caller getting its own error out
+// of a shared batch, and Close flushing what is still queued. main_test.go
+// checks every claim.
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"iter"
+	"sync"
+	"time"
+
+	"go-solid/coalesce"
+	"go-solid/employee"
+	"go-solid/employee/memory"
+	"go-solid/money"
+)
+
+// remote A backend a network away, over one connection: every call costs a
+// round trip, however many employees it carries, and calls queue for the
+// connection. It rejects Mallory.
+type remote struct {
+	*memory.Repository
+	rtt        time.Duration
+	mu         sync.Mutex
+	roundTrips int
+}
+
+var errRejected = errors.New("rejected by the backend")
+
+func (r *remote) trip() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.roundTrips++
+	time.Sleep(r.rtt)
+}
+
+func (r *remote) Save(ctx context.Context, emp employee.Employee) error {
+	r.trip()
+	if emp.Name == "Mallory" {
+		return errRejected
+	}
+	return r.Repository.Save(ctx, emp)
+}
+
+func (r *remote) SaveAll(ctx context.Context, emps iter.Seq[employee.Employee]) error {
+	r.trip()
+	report := &employee.BulkError{}
+	i := 0
+	for emp := range emps {
+		if emp.Name == "Mallory" {
+			report.Add(i, emp, errRejected)
+		} else {
+			report.Add(i, emp, r.Repository.Save(ctx, emp))
+		}
+		i++
+	}
+	return report.Err()
+}
+
+// saveConcurrently saves n employees, one goroutine each, and returns how
+// long it took and the errors by name.
+func saveConcurrently(repo employee.Repository, names []string) (time.Duration, map[string]error) {
+	var (
+		wg   sync.WaitGroup
+		mu   sync.Mutex
+		errs = map[string]error{}
+	)
+	start := time.Now()
+	for _, name := range names {
+		wg.Go(func() {
+			err := repo.Save(context.Background(), employee.Employee{Name: name, Salary: money.Of(4000, money.USD)})
+			mu.Lock()
+			errs[name] = err
+			mu.Unlock()
+		})
+	}
+	wg.Wait()
+	return time.Since(start), errs
+}
+
+// staff are the two hundred employees to save
+var staff = func() []string {
+	var names []string
+	for i := range 200 {
+		names = append(names, fmt.Sprintf("employee-%03d", i))
+	}
+	return names
+}()
+
+// saveWhileWaiting saves Carol through a repository that only Close will
+// flush, closes it once she has joined the batch, and returns her error.
+func saveWhileWaiting(repo *coalesce.Repository) error {
+	done := make(chan error, 1)
+	go func() {
+		done <- repo.Save(context.Background(), employee.Employee{Name: "Carol", Salary: money.Of(4000, money.USD)})
+	}()
+	for repo.Stats().Waiting == 0 { // Carol has joined the batch
+		time.Sleep(time.Millisecond)
+	}
+	_ = repo.Close()
+	return <-done
+}
+
+func main() {
+	fmt.Println("🐢 Straight to the backend")
+	direct := &remote{Repository: memory.New(), rtt: 2 * time.Millisecond}
+	straight, _ := saveConcurrently(direct, staff)
+	fmt.Printf("   200 saves: %d round trips, %v\n", direct.roundTrips, straight.Round(time.Millisecond))
+
+	fmt.Println("🚚 Through coalesce.Repository")
+	backend := &remote{Repository: memory.New(), rtt: 2 * time.Millisecond}
+	repo := coalesce.New(backend, coalesce.WithSize(50), coalesce.WithWait(5*time.Millisecond))
+	defer repo.Close()
+	took, errs := saveConcurrently(repo, staff)
+	stats := repo.Stats()
+	fmt.Printf("   200 saves: %d round trips in %d batches (largest %d), %v\n", backend.roundTrips, stats.Batches, stats.Largest, took.Round(time.Millisecond))
+	failed := 0
+	for _, err := range errs {
+		if err != nil {
+			failed++
+		}
+	}
+	fmt.Printf("   %d saves answered, %d failed; a Save that returned is in the backend\n", stats.Saves, failed)
+
+	fmt.Println("📬 Errors fan back to their own callers")
+	_, errs = saveConcurrently(repo, []string{"Alice", "Mallory", "Bob"})
+	for _, name := range []string{"Alice", "Mallory", "Bob"} {
+		fmt.Printf("   %s: %v\n", name, errs[name])
+	}
+
+	fmt.Println("🧹 Close flushes what is queued")
+	slow := coalesce.New(backend, coalesce.WithWait(time.Hour))
+	fmt.Println("   Carol, waiting for an hour-long batch, is saved by Close:", saveWhileWaiting(slow))
+	fmt.Println("   a Save after Close fails:", slow.Save(context.Background(), employee.Employee{Name: "Dave"}))
+}
@@ -0,0 +1,70 @@
+package main
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"go-solid/coalesce"
+	"go-solid/employee"
+	"go-solid/employee/memory"
+)
+
+func TestRepository_FewerRoundTrips(t *testing.T) {
+	direct := &remote{Repository: memory.New(), rtt: 2 * time.Millisecond}
+	straight, _ := saveConcurrently(direct, staff)
+
+	backend := &remote{Repository: memory.New(), rtt: 2 * time.Millisecond}
+	repo := coalesce.New(backend, coalesce.WithSize(50), coalesce.WithWait(5*time.Millisecond))
+	defer repo.Close()
+	took, errs := saveConcurrently(repo, staff)
+	for name, err := range errs {
+		if err != nil {
+			t.Errorf("Save(%s) error = %v", name, err)
+		}
+	}
+	if got := repo.Stats().Saves; got != 200 {
+		t.Errorf("Stats().Saves = %d, want 200", got)
+	}
+	if direct.roundTrips != 200 || backend.roundTrips >= 200/10 {
+		t.Errorf("round trips = %d coalesced, %d direct, want fewer than 20 against 200", backend.roundTrips, direct.roundTrips)
+	}
+	if took >= straight {
+		t.Errorf("coalesced saves took %v, want less than the %v straight to the backend", took, straight)
+	}
+	// read-your-writes: a Save that returned is in the backend
+	for _, name := range staff {
+		if _, err := backend.GetByName(t.Context(), name); err != nil {
+			t.Errorf("GetByName(%s) error = %v", name, err)
+		}
+	}
+}
+
+func TestRepository_ErrorsFanBack(t *testing.T) {
+	repo := coalesce.New(&remote{Repository: memory.New()}, coalesce.WithSize(3), coalesce.WithWait(time.Minute))
+	defer repo.Close()
+	_, errs := saveConcurrently(repo, []string{"Alice", "Mallory", "Bob"})
+	if got := repo.Stats().Batches; got != 1 {
+		t.Fatalf("Stats().Batches = %d, want the saves in one batch", got)
+	}
+	if !errors.Is(errs["Mallory"], errRejected) {
+		t.Errorf("Save(Mallory) error = %v, want %v", errs["Mallory"], errRejected)
+	}
+	if errs["Alice"] != nil || errs["Bob"] != nil {
+		t.Errorf("Save(Alice), Save(Bob) = %v, %v, want nil", errs["Alice"], errs["Bob"])
+	}
+}
+
+func TestRepository_CloseFlushes(t *testing.T) {
+	backend := &remote{Repository: memory.New()}
+	repo := coalesce.New(backend, coalesce.WithWait(time.Hour))
+	if err := saveWhileWaiting(repo); err != nil {
+		t.Fatalf("Save(Carol) error = %v, want it flushed by Close", err)
+	}
+	if _, err := backend.GetByName(t.Context(), "Carol"); err != nil {
+		t.Errorf("GetByName(Carol) error = %v", err)
+	}
+	if err := repo.Save(t.Context(), employee.Employee{Name: "Dave"}); !errors.Is(err, coalesce.ErrClosed) {
+		t.Errorf("Save() after Close error = %v, want %v", err, coalesce.ErrClosed)
+	}
+}
@@ -0,0 +1,330 @@
+// Command contracts checks the employee HTTP API against what its consumers
+// rely on. Two consumers - payroll and a staff directory - record their
+// expectations against a mock provider, as JSON contracts in a directory;
+// the API then replays every contract, so a change that breaks a consumer is
+// caught before it ships, along with who it breaks. main_test.go checks
+// every claim.
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"go-solid/employee"
+	"go-solid/employee/actor"
+	"go-solid/employee/memory"
+	"go-solid/httpapi"
+	"go-solid/money"
+	"go-solid/pact"
+)
+
+const provider = "employee-api"
+
+// payroll A consumer: it reads salaries and changes them, and decodes no
+// more of an employee than the salary
+type payroll struct{ base string }
+
+func (p payroll) salary(name string) (money.Money, error) {
+	var emp struct {
+		Salary money.Money `json:"salary"`
+	}
+	err := call(http.MethodGet, p.base+"/employees/"+name, nil, &emp)
+	return emp.Salary, err
+}
+
+func (p payroll) pay(name string, salary money.Money) (money.Money, error) {
+	req := map[string]money.Money{"salary": salary}
+	var emp struct {
+		Salary money.Money `json:"salary"`
+	}
+	err := call(http.MethodPut, p.base+"/employees/"+name+"/salary", req, &emp)
+	return emp.Salary, err
+}
+
+// directory A consumer: it lists who works where, and looks up email
+// addresses
+type directory struct{ base string }
+
+func (d directory) titles() (map[string]string, error) {
+	var page struct {
+		Items []struct {
+			Name  string `json:"name"`
+			Title string `json:"title"`
+		} `json:"items"`
+	}
+	if err := call(http.MethodGet, d.base+"/employees?limit=10", nil, &page); err != nil {
+		return nil, err
+	}
+	titles := map[string]string{}
+	for _, e := range page.Items {
+		titles[e.Name] = e.Title
+	}
+	return titles, nil
+}
+
+// errUnlisted The directory has no entry for the name
+var errUnlisted = errors.New("not in the directory")
+
+func (d directory) email(name string) (string, error) {
+	var emp struct {
+		Email string `json:"email"`
+	}
+	err := call(http.MethodGet, d.base+"/employees/"+name, nil, &emp)
+	return emp.Email, err
+}
+
+// call sends body as JSON and decodes the response into out. A 404 is
+// errUnlisted, whatever its body says.
+func call(method, url string, body, out any) error {
+	var r io.Reader = http.NoBody
+	if body != nil {
+		b, _ := json.Marshal(body)
+		r = bytes.NewReader(b)
+	}
+	req, _ := http.NewRequest(method, url, r)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return errUnlisted
+	case resp.StatusCode >= 300:
+		return fmt.Errorf("%s %s: %s", method, url, resp.Status)
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+// interaction builds a pact.Interaction; bodies are written as JSON.
+func interaction(description, given, method, path, body string, status int, want string) pact.Interaction {
+	return pact.Interaction{
+		Description: description,
+		Given:       given,
+		Request:     pact.Request{Method: method, Path: path, Body: json.RawMessage(body)},
+		Response:    pact.Response{Status: status, ContentType: "application/json", Body: json.RawMessage(want)},
+	}
+}
+
+// seeded is a Manager in the provider state named.
+func seeded(ctx context.Context, state string) (*employee.Manager, error) {
+	m := employee.NewManager(memory.New())
+	hire := func(name, title, dept string) error {
+		_, err := m.AddEmployee(ctx, employee.Employee{Name: name, Title: title, Department: dept,
+			Email: strings.ToLower(name) + "@example.com", Salary: money.Of(5000, money.USD)})
+		return err
+	}
+	switch state {
+	case "", "no employees":
+		return m, nil
+	case "Mona is employed":
+		return m, hire("Mona", "Engineer", "Engineering")
+	case "Mona and Omar are employed":
+		return m, errors.Join(hire("Mona", "Engineer", "Engineering"), hire("Omar", "Accountant", "Finance"))
+	}
+	return nil, fmt.Errorf("unknown provider state")
+}
+
+// api is the provider: handler serves a Manager seeded for each interaction.
+func api(handler func(m *employee.Manager) http.Handler) pact.Provider {
+	return func(ctx context.Context, state string) (http.Handler, error) {
+		m, err := seeded(ctx, state)
+		if err != nil {
+			return nil, err
+		}
+		return handler(m), nil
+	}
+}
+
+// renamed ❌ A change to the provider: "title" is now "job_title"
+func renamed(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		rec := httptest.NewRecorder()
+		next.ServeHTTP(rec, r)
+		maps.Copy(w.Header(), rec.Header())
+		w.WriteHeader(rec.Code)
+		_, _ = w.Write(bytes.ReplaceAll(rec.Body.Bytes(), []byte(`"title":`), []byte(`"job_title":`)))
+	})
+}
+
+// payrollMock is the provider as payroll expects it
+func payrollMock() *pact.MockProvider {
+	return pact.NewMockProvider("payroll", provider).
+		Expect(interaction("reads a salary", "Mona is employed", "GET", "/employees/Mona", "",
+			200, `{"salary":{"amount":"5000.00","currency":"USD"}}`)).
+		Expect(interaction("changes a salary", "Mona is employed", "PUT", "/employees/Mona/salary", `{"salary":{"amount":"5500.00","currency":"USD"}}`,
+			200, `{"salary":{"amount":"5500.00","currency":"USD"}}`))
+}
+
+// directoryMock is the provider as the directory expects it. For someone
+// not employed it pins the status alone, as that is all it reads.
+func directoryMock() *pact.MockProvider {
+	return pact.NewMockProvider("directory", provider).
+		Expect(interaction("lists titles", "Mona and Omar are employed", "GET", "/employees?limit=10", "",
+			200, `{"items":[{"name":"Mona","title":"Engineer"},{"name":"Omar","title":"Accountant"}]}`)).
+		Expect(interaction("looks up an email", "Mona is employed", "GET", "/employees/Mona", "",
+			200, `{"email":"mona@example.com"}`)).
+		Expect(pact.Interaction{Description: "looks up someone not employed", Given: "Mona is employed",
+			Request: pact.Request{Method: "GET", Path: "/employees/Nobody"}, Response: pact.Response{Status: 404}})
+}
+
+// carelessMock ❌ declares a read, for a consumer that then pays
+func carelessMock() *pact.MockProvider {
+	return pact.NewMockProvider("careless", provider).
+		Expect(interaction("reads a salary", "Mona is employed", "GET", "/employees/Mona", "", 200, `{}`))
+}
+
+// publish publishes the contract mock recorded, and returns how many
+// interactions it holds.
+func publish(ctx context.Context, store pact.ContractStore, mock *pact.MockProvider) (int, error) {
+	c, err := mock.Contract()
+	if err != nil {
+		return 0, err
+	}
+	return len(c.Interactions), store.Publish(ctx, c)
+}
+
+// against runs use with the base URL of a server for mock.
+func against(mock *pact.MockProvider, use func(base string)) {
+	srv := httptest.NewServer(mock)
+	defer srv.Close()
+	use(srv.URL)
+}
+
+var (
+	v1 = api(func(m *employee.Manager) http.Handler { return httpapi.New(m) })
+
+	// providers are the ways the API is served, each replaying every contract
+	providers = []struct {
+		name string
+		api  pact.Provider
+	}{
+		{"httpapi.New", v1},
+		{"httpapi.New over actor.Manager", api(func(m *employee.Manager) http.Handler { return httpapi.New(actor.New(m)) })},
+		{"httpapi.New with v2 mounted", api(func(m *employee.Manager) http.Handler {
+			h := httpapi.New(m)
+			h.Handle("/v2/", httpapi.NewV2(m))
+			return h
+		})},
+	}
+
+	// onboarding relies on a state the provider can't set up
+	onboarding = pact.Contract{Consumer: "onboarding", Provider: provider, Interactions: []pact.Interaction{
+		interaction("reads a new starter", "Lina starts on Monday", "GET", "/employees/Lina", "", 200, `{}`)}}
+)
+
+func main() {
+	ctx := context.Background()
+	root, _ := os.MkdirTemp("", "contracts")
+	defer os.RemoveAll(root)
+	store := pact.NewDir(root)
+
+	fmt.Println("🧪 Each consumer records what it relies on, against a mock")
+	mock := payrollMock()
+	against(mock, func(base string) {
+		p := payroll{base: base}
+		before, _ := p.salary("Mona")
+		after, _ := p.pay("Mona", money.Of(5500, money.USD))
+		fmt.Printf("   payroll reads %s and pays %s, against the mock\n", before, after)
+	})
+	n, err := publish(ctx, store, mock)
+	if err != nil {
+		fmt.Println("   ❌", err)
+		return
+	}
+	fmt.Printf("   payroll publishes %d interactions, pinning only the salary\n", n)
+	mock = directoryMock()
+	against(mock, func(base string) {
+		d := directory{base: base}
+		titles, _ := d.titles()
+		email, _ := d.email("Mona")
+		_, err := d.email("Nobody")
+		fmt.Printf("   directory lists %v and finds %s, against the mock\n", titles, email)
+		fmt.Println("   and knows Nobody isn't listed from the status alone, so that's all it pins:", err)
+	})
+	if n, err = publish(ctx, store, mock); err != nil {
+		fmt.Println("   ❌", err)
+		return
+	}
+	fmt.Printf("   directory publishes %d interactions\n", n)
+	entries, _ := filepath.Glob(filepath.Join(root, provider, "*.json"))
+	fmt.Println("   contracts are files, one per consumer:", names(entries))
+
+	mock = carelessMock()
+	against(mock, func(base string) {
+		_, err := payroll{base: base}.pay("Mona", money.Of(1, money.USD))
+		fmt.Println("   a request nobody declared is answered:", err)
+	})
+	_, err = mock.Contract()
+	fmt.Println("   and the contract is refused: it must hold what the consumer did, no more and no less")
+	for line := range strings.Lines(fmt.Sprint(err)) {
+		fmt.Printf("      %s\n", strings.TrimSpace(line))
+	}
+
+	fmt.Println("✅ The provider replays them (LSP)")
+	for _, run := range providers {
+		results, err := pact.VerifyAll(ctx, store, provider, run.api)
+		if err != nil {
+			fmt.Println("   ❌", err)
+			continue
+		}
+		fmt.Printf("   %s keeps %d of %d interactions\n", run.name, passed(results), len(results))
+		for _, r := range results {
+			if !r.Passed() {
+				fmt.Printf("      %s\n", r)
+			}
+		}
+	}
+	results, _ := pact.VerifyAll(ctx, store, provider, v1)
+	fmt.Println("   though the list carries fields the directory didn't pin:", strings.Join(unpinned(results[0]), ", "))
+	results = pact.Verify(ctx, v1, onboarding)
+	fmt.Println("   a state the provider can't set up fails:", results[0].Failure)
+
+	fmt.Println("🔍 Who a change breaks")
+	results, _ = pact.VerifyAll(ctx, store, provider, api(func(m *employee.Manager) http.Handler { return renamed(httpapi.New(m)) }))
+	for _, r := range results {
+		if !r.Passed() {
+			fmt.Printf("   ❌ renaming title breaks %s\n", r)
+		}
+	}
+	fmt.Println("   only directory, which reads the title; payroll never does")
+}
+
+// unpinned returns the fields of the first listed employee in r's body
+// other than the name and title the directory reads.
+func unpinned(r pact.Result) []string {
+	var listed struct{ Items []map[string]any }
+	if json.Unmarshal([]byte(r.Body), &listed) != nil || len(listed.Items) == 0 {
+		return nil
+	}
+	return slices.DeleteFunc(slices.Sorted(maps.Keys(listed.Items[0])), func(f string) bool { return f == "name" || f == "title" })
+}
+
+func passed(results []pact.Result) int {
+	n := 0
+	for _, r := range results {
+		if r.Passed() {
+			n++
+		}
+	}
+	return n
+}
+
+func names(paths []string) string {
+	n := make([]string, 0, len(paths))
+	for _, p := range paths {
+		n = append(n, filepath.Base(p))
+	}
+	return strings.Join(n, ", ")
+}
@@ -0,0 +1,115 @@
+package main
+
+import (
+	"errors"
+	"net/http"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"go-solid/employee"
+	"go-solid/httpapi"
+	"go-solid/money"
+	"go-solid/pact"
+)
+
+// record runs both consumers against their mocks and publishes their
+// contracts to a directory.
+func record(t *testing.T) (pact.ContractStore, string) {
+	t.Helper()
+	root := t.TempDir()
+	store := pact.NewDir(root)
+
+	mock := payrollMock()
+	against(mock, func(base string) {
+		p := payroll{base: base}
+		if got, err := p.salary("Mona"); err != nil || got != money.Of(5000, money.USD) {
+			t.Errorf("salary(Mona) = %v, %v, want USD 5000.00", got, err)
+		}
+		if got, err := p.pay("Mona", money.Of(5500, money.USD)); err != nil || got != money.Of(5500, money.USD) {
+			t.Errorf("pay(Mona) = %v, %v, want USD 5500.00", got, err)
+		}
+	})
+	if n, err := publish(t.Context(), store, mock); err != nil || n != 2 {
+		t.Fatalf("publish(payroll) = %d, %v, want 2 interactions", n, err)
+	}
+
+	mock = directoryMock()
+	against(mock, func(base string) {
+		d := directory{base: base}
+		if titles, err := d.titles(); err != nil || titles["Omar"] != "Accountant" {
+			t.Errorf("titles() = %v, %v, want Omar the accountant", titles, err)
+		}
+		if email, err := d.email("Mona"); err != nil || email != "mona@example.com" {
+			t.Errorf("email(Mona) = %q, %v, want mona@example.com", email, err)
+		}
+		if _, err := d.email("Nobody"); !errors.Is(err, errUnlisted) {
+			t.Errorf("email(Nobody) error = %v, want %v", err, errUnlisted)
+		}
+	})
+	if n, err := publish(t.Context(), store, mock); err != nil || n != 3 {
+		t.Fatalf("publish(directory) = %d, %v, want 3 interactions", n, err)
+	}
+	return store, root
+}
+
+func TestConsumers_Publish(t *testing.T) {
+	_, root := record(t)
+	entries, _ := filepath.Glob(filepath.Join(root, provider, "*.json"))
+	if got := names(entries); got != "directory.json, payroll.json" {
+		t.Errorf("contract files = %s, want one per consumer", got)
+	}
+}
+
+func TestMockProvider_RefusesAnUndeclaredRequest(t *testing.T) {
+	mock := carelessMock()
+	against(mock, func(base string) {
+		if _, err := (payroll{base: base}).pay("Mona", money.Of(1, money.USD)); err == nil {
+			t.Error("pay() = nil, want the undeclared request answered 500")
+		}
+	})
+	if _, err := mock.Contract(); err == nil {
+		t.Error("Contract() error = nil, want it refused")
+	}
+}
+
+func TestProviders_KeepEveryContract(t *testing.T) {
+	store, _ := record(t)
+	for _, run := range providers {
+		t.Run(run.name, func(t *testing.T) {
+			pact.VerifyT(t, store, provider, run.api)
+		})
+	}
+	results, err := pact.VerifyAll(t.Context(), store, provider, v1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fields := unpinned(results[0]); !slices.Contains(fields, "department") || !slices.Contains(fields, "hired_at") {
+		t.Errorf("unpinned() = %v, want fields the directory didn't pin", fields)
+	}
+}
+
+func TestVerify_UnknownState(t *testing.T) {
+	results := pact.Verify(t.Context(), v1, onboarding)
+	if len(results) != 1 || results[0].Passed() || !strings.Contains(results[0].Failure, "Lina starts on Monday") {
+		t.Errorf("Verify() = %v, want the unknown state to fail", results)
+	}
+}
+
+func TestVerify_RenamedFieldBreaksOnlyItsReader(t *testing.T) {
+	store, _ := record(t)
+	results, err := pact.VerifyAll(t.Context(), store, provider, api(func(m *employee.Manager) http.Handler { return renamed(httpapi.New(m)) }))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var broken []string
+	for _, r := range results {
+		if !r.Passed() && !slices.Contains(broken, r.Consumer) {
+			broken = append(broken, r.Consumer)
+		}
+	}
+	if !slices.Equal(broken, []string{"directory"}) {
+		t.Errorf("broken consumers = %v, want only directory, which reads the title", broken)
+	}
+}
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"go-solid/differential"
+	"go-solid/employee"
+	"go-solid/employee/memory"
+)
+
+// cachingRepository Caches reads in front of the memory repository. It
+// looks substitutable, and its own tests - save, then read - pass.
+type cachingRepository struct {
+	*memory.Repository
+	mu         sync.Mutex
+	cache      map[string]employee.Employee
+	invalidate bool
+}
+
+func newCaching(invalidate bool) *cachingRepository {
+	return &cachingRepository{Repository: memory.New(), cache: map[string]employee.Employee{}, invalidate: invalidate}
+}
+
+func (r *cachingRepository) Save(ctx context.Context, emp employee.Employee) error {
+	r.mu.Lock()
+	delete(r.cache, emp.Name)
+	r.mu.Unlock()
+	return r.Repository.Save(ctx, emp)
+}
+
+func (r *cachingRepository) GetByName(ctx context.Context, name string) (employee.Employee, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if emp, ok := r.cache[name]; ok {
+		return emp, nil
+	}
+	emp, err := r.Repository.GetByName(ctx, name)
+	if err == nil {
+		r.cache[name] = emp
+	}
+	return emp, err
+}
+
+// ❌ SoftDelete is promoted from the embedded repository: the cache keeps
+// serving an employee who should now be missing
+//
+// ✅ The fixed version drops the cached entry first
+func (r *cachingRepository) SoftDelete(ctx context.Context, name string) error {
+	if r.invalidate {
+		r.mu.Lock()
+		delete(r.cache, name)
+		r.mu.Unlock()
+	}
+	return r.Repository.SoftDelete(ctx, name)
+}
+
+func main() {
+	ctx := context.Background()
+
+	fmt.Println("🔀 memory vs cached memory, 200 random steps")
+	report, _ := differential.Run(ctx, memory.New(), newCaching(false), differential.Options{Seed: 3})
+	fmt.Printf("   capabilities exercised: %v\n", report.Capabilities)
+	if d := report.Divergence; d != nil {
+		fmt.Printf("   ❌ diverged after %d steps; the last few:\n", len(report.Steps))
+		for _, s := range report.Steps[max(len(report.Steps)-4, 0):] {
+			fmt.Println("     ", s)
+		}
+		fmt.Println("   ", d)
+	}
+
+	fmt.Println("\n🔀 memory vs cached memory that invalidates on soft delete, 5 seeds")
+	for seed := range uint64(5) {
+		report, _ := differential.Run(ctx, memory.New(), newCaching(true), differential.Options{Seed: seed, Steps: 500})
+		if report.Divergence != nil {
+			fmt.Printf("   ❌ seed %d: %s\n", seed, report.Divergence)
+			continue
+		}
+		fmt.Printf("   ✅ seed %d: %d steps, same results\n", seed, len(report.Steps))
+	}
+}
@@ -0,0 +1,154 @@
+// Command embedding composes interfaces and structs by embedding, as 4.ISP
+// does with PaidEmployee, and shows where embedding stops helping: two
+// embedded types with the same method, an embedded interface left nil, and a
+// constructor returning an interface instead of its struct.
+package main
+
+import "fmt"
+
+// Employee, PaidEmployee and TaskAssigner as in 4.ISP: small roles, the
+// bigger ones embedding the smaller
+type Employee interface {
+	GetName() string
+}
+
+type PaidEmployee interface {
+	Employee // ✅ everything an Employee does, plus pay
+	CalculateMonthlyPay() float64
+}
+
+type TaskAssigner interface {
+	AssignTask(task string, assignee Employee) error
+}
+
+// Reviewer embeds Employee too. PaidEmployee and Reviewer meet again in
+// PaidReviewer - a diamond. Since Go 1.14 the same method reaching an
+// interface twice is fine, as long as both copies have the same signature.
+type Reviewer interface {
+	Employee
+	Review(doc string) string
+}
+
+type PaidReviewer interface {
+	PaidEmployee
+	Reviewer // ✅ GetName arrives twice, identical: one method
+}
+
+// Manager plays every role above
+type Manager struct {
+	Name   string
+	Salary float64
+}
+
+func (m *Manager) GetName() string              { return m.Name }
+func (m *Manager) CalculateMonthlyPay() float64 { return m.Salary }
+func (m *Manager) Review(doc string) string     { return m.Name + " approves " + doc }
+func (m *Manager) AssignTask(task string, assignee Employee) error {
+	fmt.Printf("   📌 %s assigns %q to %s\n", m.Name, task, assignee.GetName())
+	return nil
+}
+
+// NewManager ✅ returns the struct: callers use it as any role they need
+func NewManager(name string, salary float64) *Manager {
+	return &Manager{Name: name, Salary: salary}
+}
+
+// NewPaidManager ❌ returns one of the roles, hiding the others
+//
+//lint:ignore returnstructs the bad practice this example shows; remove this line to see solid lint object
+func NewPaidManager(name string, salary float64) PaidEmployee {
+	return &Manager{Name: name, Salary: salary}
+}
+
+// Struct embedding: a Contractor is a Person and a Vendor, and both have a
+// Describe method at the same depth
+type Person struct{ Name string }
+
+func (p Person) Describe() string { return "person " + p.Name }
+func (p Person) GetName() string  { return p.Name }
+
+type Vendor struct{ Company string }
+
+func (v Vendor) Describe() string { return "vendor " + v.Company }
+
+type Contractor struct {
+	Person
+	Vendor
+}
+
+type Describer interface{ Describe() string }
+
+// Consultant is the fix: a method of its own beats the embedded ones
+type Consultant struct {
+	Person
+	Vendor
+}
+
+func (c Consultant) Describe() string { return c.Person.Describe() + " of " + c.Vendor.Company }
+
+// Repository and auditedRepository: embedding an interface in a struct
+// forwards every method to it, and a decorator overrides only what it adds to
+type Repository interface {
+	Save(name string) error
+	Delete(name string) error
+}
+
+type auditedRepository struct {
+	Repository // ✅ Delete is forwarded as is
+	log        []string
+}
+
+func (a *auditedRepository) Save(name string) error {
+	a.log = append(a.log, "save "+name)
+	return a.Repository.Save(name)
+}
+
+type memoryRepository struct{ names map[string]bool }
+
+func (m memoryRepository) Save(name string) error   { m.names[name] = true; return nil }
+func (m memoryRepository) Delete(name string) error { delete(m.names, name); return nil }
+
+func main() {
+	fmt.Println("🧱 Interface embedding")
+	alice := NewManager("Alice", 9000)
+	var paid PaidEmployee = alice
+	var employee Employee = paid // ✅ a PaidEmployee is an Employee, no conversion
+	var both PaidReviewer = alice
+	fmt.Printf("   %s is paid %.0f; as a plain Employee: %s\n", paid.GetName(), paid.CalculateMonthlyPay(), employee.GetName())
+	fmt.Println("  ", both.Review("the Q3 budget"))
+
+	fmt.Println("🎁 Accept interfaces, return structs")
+	bob := NewManager("Bob", 8000)
+	_ = bob.AssignTask("write the roadmap", alice) // ✅ *Manager has every method
+	hidden := NewPaidManager("Carol", 8500)
+	// hidden.AssignTask(...)  ❌ does not compile: PaidEmployee has no AssignTask
+	if assigner, ok := hidden.(TaskAssigner); ok { // ❌ only a type assertion gets it back
+		_ = assigner.AssignTask("hire a designer", alice)
+	}
+
+	fmt.Println("💥 Struct embedding conflicts")
+	c := Contractor{Person{"Dave"}, Vendor{"Acme"}}
+	// c.Describe()  ❌ does not compile: ambiguous selector c.Describe
+	fmt.Println("  ", c.Person.Describe(), "/", c.Vendor.Describe()) // ✅ name the one you mean
+	_, ok := any(c).(Describer)
+	fmt.Printf("   Contractor is a Describer: %v - an ambiguous method is in no method set\n", ok)
+	fmt.Printf("   Contractor still has GetName from Person: %s\n", c.GetName())
+	var d Describer = Consultant{Person{"Erin"}, Vendor{"Initech"}}
+	fmt.Println("   ✅ Consultant resolves it itself:", d.Describe())
+
+	fmt.Println("🪆 Embedding an interface in a struct")
+	repo := &auditedRepository{Repository: memoryRepository{names: map[string]bool{}}}
+	_ = repo.Save("Frank")
+	_ = repo.Delete("Frank") // forwarded, not audited
+	fmt.Println("   audit log:", repo.log)
+	err := func() (err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				err = fmt.Errorf("%v", r)
+			}
+		}()
+		empty := &auditedRepository{} // ❌ nothing embedded: the interface field is nil
+		return empty.Delete("Grace")
+	}()
+	fmt.Println("   ❌ a forwarded method of a nil embedded interface panics:", err)
+}
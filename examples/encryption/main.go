@@ -0,0 +1,65 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"go-solid/crypto"
+	"go-solid/employee"
+	"go-solid/employee/memory"
+	"go-solid/money"
+)
+
+func main() {
+	ctx := context.Background()
+	kms := &crypto.FakeKMS{}
+
+	// ✅ Encryption is a decorator: the Manager and the memory repository are unchanged
+	raw := memory.New()
+	repo := crypto.NewRepository(raw, crypto.Envelope{KMS: kms, KeyID: "hr-pii"})
+	manager := employee.NewManager(repo)
+
+	_, _ = manager.AddEmployee(ctx, employee.Employee{Name: "Mohamed", Email: "mohamed@example.com", Salary: money.Of(5000, money.USD)})
+	_, _ = manager.AddEmployee(ctx, employee.Employee{Name: "Ahmed", Email: "ahmed@example.com", Salary: money.Of(6000, money.USD)})
+
+	stored, _ := raw.GetByName(ctx, "Mohamed")
+	fmt.Printf("💾 Stored:  salary=%q email=%s...\n", stored.Salary.Amount(), stored.Email[:31])
+
+	emp, _ := manager.FindEmployee(ctx, "Mohamed")
+	fmt.Printf("🔓 Read:    salary=%s email=%s\n", emp.Salary, emp.Email)
+	fmt.Printf("🔑 KMS calls so far: %d\n", kms.Calls())
+
+	// ⚠️ The backend only holds ciphertext, so it can't filter by salary...
+	if _, err := repo.List(ctx, employee.Filter{MinSalary: money.Of(4000, money.USD)}, employee.Page{}); errors.Is(err, errors.ErrUnsupported) {
+		fmt.Println("🚫 List by salary:", err)
+	}
+	// ✅ ...but specifications still work, evaluated after decryption
+	matched, err := repo.Matching(ctx, employee.SalaryAtLeast(money.Of(5500, money.USD)))
+	if err != nil {
+		fmt.Println("❌", err)
+		return
+	}
+	for _, m := range matched {
+		fmt.Printf("🔍 Earning at least 5500: %s (%s)\n", m.Name, m.Salary)
+	}
+
+	// ❌ A sealed salary copied onto another employee doesn't open: the employee ID is authenticated
+	ahmed, _ := raw.GetByName(ctx, "Ahmed")
+	ahmed.Email = stored.Email
+	_ = raw.Save(ctx, ahmed)
+	if _, err := manager.FindEmployee(ctx, "Ahmed"); errors.Is(err, crypto.ErrDecrypt) {
+		fmt.Println("🚨 Tampered record rejected:", err)
+	}
+
+	// ✅ Same decorator, local AES-GCM key instead of a KMS
+	aes, err := crypto.NewAESGCM(crypto.NewKey())
+	if err != nil {
+		fmt.Println("❌", err)
+		return
+	}
+	local := employee.NewManager(crypto.NewRepository(memory.New(), aes))
+	_, _ = local.AddEmployee(ctx, employee.Employee{Name: "Ali", Salary: money.Of(4500, money.USD)})
+	ali, _ := local.FindEmployee(ctx, "Ali")
+	fmt.Printf("🔐 AES-GCM: %s earns %s\n", ali.Name, ali.Salary)
+}
@@ -0,0 +1,230 @@
+// Command errorflow follows repository errors up through the layers of a
+// hire: storage, service, and the HTTP handler and CLI on top. Each layer
+// wraps with %w and adds what it knows; one function classifies an error,
+// and the handlers only map classes to status codes and exit codes - SRP for
+// errors. The bad variant matches on error text, and breaks when a driver
+// rewords a message. main_test.go checks every claim.
+package main
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// Sentinel errors: the domain's vocabulary, compared with errors.Is
+var (
+	ErrNotFound    = errors.New("employee not found")
+	ErrConflict    = errors.New("employee already exists")
+	ErrUnavailable = errors.New("storage unavailable")
+)
+
+// ValidationError carries which field is wrong, found with errors.As
+type ValidationError struct {
+	Field  string
+	Reason string
+}
+
+func (e *ValidationError) Error() string { return e.Field + " " + e.Reason }
+
+// DBError is what the SQL driver returns: a SQLSTATE code and the driver's
+// own wording. Is and As translate it into the domain's terms, so no layer
+// above storage needs to know a SQLSTATE.
+type DBError struct {
+	Code       string
+	Constraint string
+	Message    string
+}
+
+func (e *DBError) Error() string { return "pq: " + e.Message }
+
+// Is ✅ a unique violation is an ErrConflict, a connection failure (class 08)
+// an ErrUnavailable
+func (e *DBError) Is(target error) bool {
+	switch target {
+	case ErrConflict:
+		return e.Code == "23505"
+	case ErrUnavailable:
+		return strings.HasPrefix(e.Code, "08")
+	}
+	return false
+}
+
+// As ✅ a check violation is a *ValidationError on the column the
+// constraint guards
+func (e *DBError) As(target any) bool {
+	v, ok := target.(**ValidationError)
+	if !ok || e.Code != "23514" {
+		return false
+	}
+	field, _ := strings.CutPrefix(e.Constraint, "employees_")
+	field, _ = strings.CutSuffix(field, "_check")
+	*v = &ValidationError{Field: field, Reason: "is out of range"}
+	return true
+}
+
+// sqlRepository Storage layer - fails the way the script below says
+type sqlRepository struct {
+	fail map[string]*DBError
+}
+
+func (r *sqlRepository) Insert(name string, salary int) error {
+	if err := r.fail[name]; err != nil {
+		return fmt.Errorf("insert employee %q: %w", name, err) // ✅ adds the query, keeps the cause
+	}
+	return nil
+}
+
+func (r *sqlRepository) Get(name string) (int, error) {
+	if name != "Alice" {
+		return 0, fmt.Errorf("select employee %q: %w", name, ErrNotFound)
+	}
+	return 5000, nil
+}
+
+// Manager Service layer - adds the operation, knows nothing of HTTP or SQL
+type Manager struct{ repo *sqlRepository }
+
+func (m *Manager) Hire(name string, salary int) error {
+	if name == "" {
+		return &ValidationError{Field: "name", Reason: "must not be empty"}
+	}
+	if err := m.repo.Insert(name, salary); err != nil {
+		return fmt.Errorf("hire %s: %w", name, err)
+	}
+	return nil
+}
+
+func (m *Manager) Raise(name string, amount int) error {
+	salary, err := m.repo.Get(name)
+	if err != nil {
+		return fmt.Errorf("raise %s: %w", name, err)
+	}
+	return m.repo.Insert(name, salary+amount)
+}
+
+// Class What a caller can do about an error. Deciding it is one job;
+// turning it into a status code, an exit code or a retry is another.
+type Class int
+
+const (
+	Internal Class = iota
+	NotFound
+	Conflict
+	Invalid
+	Unavailable
+)
+
+func (c Class) String() string {
+	return [...]string{"internal", "not found", "conflict", "invalid", "unavailable"}[c]
+}
+
+// classify ✅ the one place that knows the error vocabulary
+func classify(err error) Class {
+	var invalid *ValidationError
+	switch {
+	case errors.Is(err, ErrNotFound):
+		return NotFound
+	case errors.Is(err, ErrConflict):
+		return Conflict
+	case errors.As(err, &invalid):
+		return Invalid
+	case errors.Is(err, ErrUnavailable):
+		return Unavailable
+	}
+	return Internal
+}
+
+// httpStatus and exitCode ✅ handle a Class; neither inspects an error
+func httpStatus(c Class) int {
+	switch c {
+	case NotFound:
+		return http.StatusNotFound
+	case Conflict:
+		return http.StatusConflict
+	case Invalid:
+		return http.StatusBadRequest
+	case Unavailable:
+		return http.StatusServiceUnavailable
+	}
+	return http.StatusInternalServerError
+}
+
+func exitCode(c Class) int { return int(c) + 1 }
+
+// badStatus ❌ classifies and handles in one go, by reading the message. It
+// depends on the driver's wording and on every layer's wording above it.
+func badStatus(err error) int {
+	msg := err.Error()
+	switch {
+	case strings.Contains(msg, "not found"):
+		return http.StatusNotFound
+	case strings.Contains(msg, "duplicate key"):
+		return http.StatusConflict
+	case strings.Contains(msg, "must not be empty"):
+		return http.StatusBadRequest
+	}
+	return http.StatusInternalServerError
+}
+
+// script is a Manager whose storage rejects Bob as a duplicate, Carol's
+// salary as out of range and Dave as the connection drops. It returns the
+// duplicate too, for a driver upgrade to reword.
+func script() (*Manager, *DBError) {
+	duplicate := &DBError{Code: "23505", Constraint: "employees_name_key", Message: `duplicate key value violates unique constraint "employees_name_key"`}
+	return &Manager{repo: &sqlRepository{fail: map[string]*DBError{
+		"Bob":   duplicate,
+		"Carol": {Code: "23514", Constraint: "employees_salary_check", Message: `new row violates check constraint "employees_salary_check"`},
+		"Dave":  {Code: "08006", Message: "connection reset by peer"},
+	}}}, duplicate
+}
+
+func main() {
+	m, duplicate := script()
+
+	fmt.Println("🧵 One error, every layer's context")
+	err := m.Hire("Bob", 4000)
+	fmt.Println("  ", err)
+	fmt.Println("   errors.Is finds ErrConflict through two wraps and DBError.Is:", errors.Is(err, ErrConflict))
+	var db *DBError
+	if errors.As(err, &db) {
+		fmt.Println("   errors.As still reaches the driver's *DBError: code", db.Code)
+	}
+	var invalid *ValidationError
+	if errors.As(m.Hire("Carol", -1), &invalid) {
+		fmt.Println("   DBError.As turns Carol's check violation into a *ValidationError:", invalid)
+	}
+
+	fmt.Println("🏷️  Classify once, handle anywhere")
+	for _, c := range []struct {
+		what string
+		err  error
+	}{
+		{"raise of a stranger", m.Raise("Erin", 100)},
+		{"hire of Bob again", m.Hire("Bob", 4000)},
+		{"hire with no name", m.Hire("", 4000)},
+		{"hire with a negative salary", m.Hire("Carol", -1)},
+		{"hire while the database is down", m.Hire("Dave", 4000)},
+	} {
+		class := classify(c.err)
+		fmt.Printf("   %s: %s → HTTP %d, exit %d\n", c.what, class, httpStatus(class), exitCode(class))
+	}
+
+	fmt.Println("❌ Matching on error text")
+	fmt.Println("   badStatus gets Bob's conflict right while the driver says \"duplicate key\":", badStatus(m.Hire("Bob", 4000)))
+	duplicate.Message = `unique constraint "employees_name_key" violated` // a driver upgrade rewords it
+	err = m.Hire("Bob", 4000)
+	fmt.Printf("   after a driver upgrade badStatus says %d, classify still %s\n", badStatus(err), classify(err))
+	fmt.Printf("   badStatus sends the check violation to %d: its text matches nothing\n", badStatus(m.Hire("Carol", -1)))
+
+	fmt.Printf("🔗 Wrapping with %%v cuts the chain\n")
+	flattened := fmt.Errorf("hire Bob: %v", duplicate) // ❌ keeps the text, loses the error
+	fmt.Println("   errors.Is(flattened, ErrConflict) is", errors.Is(flattened, ErrConflict))
+
+	fmt.Println("📏 Rules of thumb")
+	fmt.Printf("   • wrap with %%w and add what this layer knows: the query, the operation\n")
+	fmt.Println("   • compare with errors.Is and errors.As, never err.Error()")
+	fmt.Println("   • let the storage error speak the domain's language with Is and As")
+	fmt.Println("   • classify in one function; handlers map classes, not errors")
+}
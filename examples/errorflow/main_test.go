@@ -0,0 +1,79 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestWrapping_KeepsTheChain(t *testing.T) {
+	m, duplicate := script()
+	err := m.Hire("Bob", 4000)
+	if !errors.Is(err, ErrConflict) {
+		t.Errorf("Hire(Bob) error = %v, want %v through two wraps", err, ErrConflict)
+	}
+	var db *DBError
+	if !errors.As(err, &db) || db != duplicate {
+		t.Errorf("errors.As(*DBError) = %v, want the driver's error", db)
+	}
+	var invalid *ValidationError
+	if err := m.Hire("Carol", -1); !errors.As(err, &invalid) || invalid.Field != "salary" {
+		t.Errorf("Hire(Carol) error = %v, want a *ValidationError on salary", err)
+	}
+	if flattened := fmt.Errorf("hire Bob: %v", duplicate); errors.Is(flattened, ErrConflict) {
+		t.Errorf("errors.Is(wrapped with %%v, ErrConflict) = true, want the chain cut")
+	}
+}
+
+func TestClassify(t *testing.T) {
+	m, _ := script()
+	tests := []struct {
+		name       string
+		err        error
+		want       Class
+		wantStatus int
+		wantExit   int
+	}{
+		{"raise of a stranger", m.Raise("Erin", 100), NotFound, http.StatusNotFound, 2},
+		{"hire of Bob again", m.Hire("Bob", 4000), Conflict, http.StatusConflict, 3},
+		{"hire with no name", m.Hire("", 4000), Invalid, http.StatusBadRequest, 4},
+		{"hire with a negative salary", m.Hire("Carol", -1), Invalid, http.StatusBadRequest, 4},
+		{"hire while the database is down", m.Hire("Dave", 4000), Unavailable, http.StatusServiceUnavailable, 5},
+		{"something unforeseen", errors.New("boom"), Internal, http.StatusInternalServerError, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := classify(tt.err)
+			if got != tt.want {
+				t.Fatalf("classify(%v) = %s, want %s", tt.err, got, tt.want)
+			}
+			if status := httpStatus(got); status != tt.wantStatus {
+				t.Errorf("httpStatus(%s) = %d, want %d", got, status, tt.wantStatus)
+			}
+			if code := exitCode(got); code != tt.wantExit {
+				t.Errorf("exitCode(%s) = %d, want %d", got, code, tt.wantExit)
+			}
+		})
+	}
+}
+
+// TestBadStatus pins down why matching on text is the bad variant: a
+// reworded driver message changes its answer, and classify's not.
+func TestBadStatus(t *testing.T) {
+	m, duplicate := script()
+	if got := badStatus(m.Hire("Bob", 4000)); got != http.StatusConflict {
+		t.Errorf("badStatus(Bob) = %d, want %d while the driver says \"duplicate key\"", got, http.StatusConflict)
+	}
+	if got := badStatus(m.Hire("Carol", -1)); got != http.StatusInternalServerError {
+		t.Errorf("badStatus(Carol) = %d, want %d: its text matches nothing", got, http.StatusInternalServerError)
+	}
+	duplicate.Message = `unique constraint "employees_name_key" violated`
+	err := m.Hire("Bob", 4000)
+	if got := badStatus(err); got != http.StatusInternalServerError {
+		t.Errorf("badStatus(Bob) after the rewording = %d, want %d", got, http.StatusInternalServerError)
+	}
+	if got := classify(err); got != Conflict {
+		t.Errorf("classify(Bob) after the rewording = %s, want %s", got, Conflict)
+	}
+}
@@ -0,0 +1,103 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go-solid/clock"
+	"go-solid/employee"
+	"go-solid/employee/memory"
+	"go-solid/events"
+	"go-solid/id"
+	"go-solid/money"
+)
+
+//////////--------------------Bad Practice--------------------/////////////////////////
+
+// ❌ The use case knows about every reaction and changes whenever a new one is added
+//func (m *Manager) Promote(name, title string, raise int) {
+//	emp := m.repo.Get(name)
+//	emp.Title = title           // ❌ no invariant checks - a "promotion" could cut pay
+//	emp.Salary += raise
+//	m.repo.Save(emp)
+//	m.mailer.SendCongrats(emp)  // ❌ notification concern
+//	m.payroll.Recalculate(emp)  // ❌ payroll concern
+//	m.orgChart.Refresh()        // ❌ org chart concern
+//}
+
+//////////////-----------------------------Good Practice-------------------/////////////////////////////////////////////////////////
+
+// payrollProjection Read model kept up to date purely from events. Salaries
+// stay in their own currency; the total is normalized through whichever
+// money.ExchangeRateProvider is injected.
+type payrollProjection struct {
+	salaries map[employee.ID]money.Money
+	rates    money.ExchangeRateProvider
+}
+
+func (p *payrollProjection) Handle(ctx context.Context, e events.Event) error {
+	switch e := e.(type) {
+	case employee.Hired:
+		p.salaries[e.EmployeeID] = e.Salary
+	case employee.SalaryChanged:
+		p.salaries[e.EmployeeID] = e.To
+	case employee.Promoted:
+		p.salaries[e.EmployeeID] = e.Salary
+	}
+	return nil
+}
+
+func (p *payrollProjection) Total(ctx context.Context, in money.Currency) (money.Money, error) {
+	amounts := make([]money.Money, 0, len(p.salaries))
+	for _, s := range p.salaries {
+		amounts = append(amounts, s)
+	}
+	return money.Sum(ctx, p.rates, in, amounts...)
+}
+
+func main() {
+	ctx := context.Background()
+
+	// ✅ Reactions subscribe to events; the manager and the aggregate never know about them
+	bus := events.NewBus()
+	bus.SubscribeAll(events.HandlerFunc(func(ctx context.Context, e events.Event) error {
+		fmt.Printf("📣 %-24s %+v\n", e.EventName(), e)
+		return nil
+	}))
+	bus.Subscribe("employee.promoted", events.HandlerFunc(func(ctx context.Context, e events.Event) error {
+		p := e.(employee.Promoted)
+		fmt.Printf("🎉 Congratulations %s, you are now %s!\n", p.Name, p.ToTitle)
+		return nil
+	}))
+
+	payroll := &payrollProjection{
+		salaries: map[employee.ID]money.Money{},
+		rates:    money.Rates{Base: money.USD, Rates: map[money.Currency]string{"EGP": "48.50", "EUR": "0.92"}},
+	}
+	bus.SubscribeAll(payroll)
+
+	manager := employee.NewManager(memory.New(),
+		employee.WithEvents(bus),
+		employee.WithIDs(id.NewSequence("emp-")),
+		employee.WithClock(clock.NewFake(time.Date(2026, time.March, 2, 9, 0, 0, 0, time.UTC))),
+	)
+
+	_, _ = manager.AddEmployee(ctx, employee.Employee{Name: "Mohamed", Title: "Engineer", Salary: money.Of(5000, money.USD)})
+	_, _ = manager.ChangeSalary(ctx, "Mohamed", money.Of(5200, money.USD))
+	_, _ = manager.Promote(ctx, "Mohamed", "Senior Engineer", money.Of(800, money.USD))
+	_, _ = manager.AddEmployee(ctx, employee.Employee{Name: "Ali", Title: "Designer", Salary: money.Of(90000, money.EGP)})
+	total, err := payroll.Total(ctx, money.USD)
+	fmt.Printf("💰 Monthly payroll total from projection: %s (err=%v)\n\n", total, err)
+
+	// ✅ The aggregate protects its invariants - no event is raised for a rejected change
+	_, err = manager.Promote(ctx, "Mohamed", "Staff Engineer", money.Of(-100, money.USD))
+	if errors.Is(err, employee.ErrInvalidPromotion) {
+		fmt.Println("❌", err)
+	}
+	_, err = manager.AddEmployee(ctx, employee.Employee{Name: "Ahmed", Salary: money.Of(0, money.USD)})
+	if errors.Is(err, employee.ErrInvalidSalary) {
+		fmt.Println("❌", err)
+	}
+}
@@ -0,0 +1,209 @@
+// Command evolve adds a Department to employees that were stored before it
+// existed. It runs one contract - old data reads with the field empty, new
+// data keeps it, nothing else is lost - against every repository and codec,
+// and against an adapter written before the field, then fills the old data
+// in: on read, in bulk, and in the SQL schema. The evolve package's tests
+// check the claims, and main_test.go the contract and schema here.
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"go-solid/blob"
+	"go-solid/clock"
+	"go-solid/codec"
+	"go-solid/crypto"
+	"go-solid/employee"
+	"go-solid/employee/memory"
+	"go-solid/employee/sqlrepo"
+	"go-solid/evolve"
+	"go-solid/export"
+	"go-solid/id"
+	"go-solid/importer"
+	"go-solid/migrate"
+	"go-solid/money"
+	"go-solid/replica"
+	"go-solid/shard"
+)
+
+// v1 ❌ An adapter written before Department: it stores the fields it knew
+// about, one by one, and drops any added since
+type v1 struct{ *memory.Repository }
+
+func (r v1) Save(ctx context.Context, emp employee.Employee) error {
+	return r.Repository.Save(ctx, employee.Employee{ID: emp.ID, Name: emp.Name, Title: emp.Title, Email: emp.Email, Salary: emp.Salary, HiredAt: emp.HiredAt})
+}
+
+// contract checks what a backend must do for a field to be added without
+// breaking it, and returns the clauses repo breaks.
+func contract(repo employee.Repository) []string {
+	ctx := context.Background()
+	var broken []string
+	clause := func(what string, ok bool) {
+		if !ok {
+			broken = append(broken, what)
+		}
+	}
+	// Saved as the code before Department did: without one
+	old := employee.Employee{ID: "emp-1", Name: "Olga", Title: "Engineer", Email: "olga@example.com", Salary: money.Of(5000, money.USD)}
+	err := repo.Save(ctx, old)
+	got, lookup := repo.GetByName(ctx, "Olga")
+	clause("an employee saved without a department reads back with none", err == nil && lookup == nil && got.Department == "")
+	got.Department = "Engineering"
+	err = repo.Save(ctx, got)
+	got, lookup = repo.GetByName(ctx, "Olga")
+	clause("a department saved is read back", err == nil && lookup == nil && got.Department == "Engineering")
+	clause("and nothing else changed", got.ID == old.ID && got.Title == old.Title && got.Email == old.Email && got.Salary == old.Salary)
+	listed := employee.Employee{}
+	for emp, err := range employee.All(ctx, repo) {
+		if err == nil && emp.Name == "Olga" {
+			listed = emp
+		}
+	}
+	clause("listing returns it too", listed.Department == "Engineering")
+	if v, ok := repo.(employee.Versioned); ok {
+		history, err := v.History(ctx, "Olga")
+		clause("the version from before it still has none", err == nil && len(history) == 2 && history[0].Department == "")
+	}
+	return broken
+}
+
+// seed stores employees the way the code before Department did.
+func seed(repo employee.Repository) {
+	for i, e := range []struct{ name, title string }{{"Olga", "Engineer"}, {"Pavel", "Accountant"}, {"Rana", "Chief of Staff"}} {
+		_ = repo.Save(context.Background(), employee.Employee{ID: employee.ID(fmt.Sprintf("emp-%d", i+1)), Name: e.name, Title: e.title, Salary: money.Of(5000, money.USD)})
+	}
+}
+
+// meddling Saves a new email for whoever is updated first, just before the
+// update lands, as a colleague might while a backfill runs
+type meddling struct {
+	*memory.Repository
+	done bool
+}
+
+func (m *meddling) Update(ctx context.Context, emp employee.Employee, expectedVersion int) (employee.Employee, error) {
+	if !m.done {
+		m.done = true
+		current, _ := m.GetByName(ctx, emp.Name)
+		current.Email = strings.ToLower(current.Name) + "@example.com"
+		_ = m.Save(ctx, current)
+	}
+	return m.Repository.Update(ctx, emp, expectedVersion)
+}
+
+func main() {
+	ctx := context.Background()
+
+	fmt.Println("📜 One contract, every backend (OCP, LSP)")
+	aes, _ := crypto.NewAESGCM(crypto.NewKey())
+	for _, b := range []struct {
+		name string
+		repo employee.Repository
+	}{
+		{"memory.Repository", memory.New()},
+		{"shard.ShardedRepository over memory", shard.New([]employee.Repository{memory.New(), memory.New()})},
+		{"replica.ReadWriteSplitter over memory", replica.New(memory.New(), nil)},
+		{"crypto.Repository over memory", crypto.NewRepository(memory.New(), aes)},
+		{"v1", v1{memory.New()}},
+	} {
+		broken := contract(b.repo)
+		if len(broken) == 0 {
+			fmt.Printf("   %s keeps every clause\n", b.name)
+		}
+		for _, c := range broken {
+			fmt.Printf("   ❌ %s breaks: %s\n", b.name, c)
+		}
+	}
+
+	fmt.Println("🗄️  The SQL backends: a column with a default")
+	for line := range strings.Lines(sqlrepo.Schema) {
+		if strings.Contains(line, "department") {
+			fmt.Println("   sqlrepo.Schema:", strings.TrimSpace(line))
+		}
+	}
+	for _, dialect := range []string{"mysql", "postgres", "sqlite"} {
+		if migrations, err := migrate.SQL(dialect); err == nil {
+			last := migrations[len(migrations)-1]
+			fmt.Printf("   %s: %s adds it with a default, so no row needs a value\n", dialect, last)
+		}
+	}
+
+	fmt.Println("🔁 Codecs: files from before it still read")
+	var row export.Row
+	_ = json.Unmarshal([]byte(`{"id":"emp-1","name":"Olga","title":"Engineer","salary":{"amount":"5000.00","currency":"USD"}}`), &row)
+	fmt.Printf("   an old JSONL line decodes: %s, department %q\n", row.Name, row.Department)
+	repo := memory.New()
+	seed(repo)
+	olga, _ := repo.GetByName(ctx, "Olga")
+	olga.Department = "Engineering"
+	_ = repo.Save(ctx, olga)
+	exports := blob.NewMemory()
+	jsonl, _ := codec.Lookup("jsonl")
+	_, _ = (&export.Exporter{Source: repo, Codec: jsonl, Store: exports}).Export(ctx, "staff.jsonl")
+	fmt.Println("   new JSONL: the department when there is one, nothing when not")
+	for line := range strings.Lines(read(exports, "staff.jsonl")) {
+		fmt.Print("      ", line)
+	}
+	csv, _ := codec.Lookup("csv")
+	_, _ = (&export.Exporter{Source: repo, Codec: csv, Store: exports}).Export(ctx, "staff.csv")
+	out := read(exports, "staff.csv")
+	header, _, _ := strings.Cut(out, "\n")
+	fmt.Println("   new CSV: the column goes last, so the old columns keep their places:", header)
+	rules := importer.Rules{IDs: id.NewSequence("imp-"), Clock: clock.NewFake(time.Date(2026, time.March, 2, 9, 0, 0, 0, time.UTC)), Currency: money.USD}
+	imported := memory.New()
+	report, err := importer.New(imported, rules).Import(ctx, importer.NewCSV(strings.NewReader("name,title,salary\nSam,Engineer,4000\n")))
+	fmt.Printf("   an old CSV, without the column, imports: %d imported, %v\n", report.Imported, err)
+	_, _ = importer.New(imported, rules).Import(ctx, importer.NewCSV(strings.NewReader(out)))
+	olga, _ = imported.GetByName(ctx, "Olga")
+	fmt.Println("   the new CSV imports with it:", olga.Department)
+
+	fmt.Println("🧩 Filling the old data in")
+	fill := evolve.Chain{evolve.DepartmentByTitle{"Engineer": "Engineering", "Accountant": "Finance"}, evolve.Unassigned}
+	repo = memory.New()
+	seed(repo)
+	onRead := evolve.NewRepository(repo, fill)
+	pavel, _ := onRead.GetByName(ctx, "Pavel")
+	stored, _ := repo.GetByName(ctx, "Pavel")
+	fmt.Printf("   on read: Pavel, an Accountant, is in %s; the stored copy in %q\n", pavel.Department, stored.Department)
+	rana, _ := onRead.GetByName(ctx, "Rana")
+	fmt.Println("   a title nothing maps falls through to the default:", rana.Department)
+	_, _ = employee.NewManager(onRead).ChangeSalary(ctx, "Pavel", money.Of(5500, money.USD))
+	stored, _ = repo.GetByName(ctx, "Pavel")
+	fmt.Println("   and stored filled on the next save, here a raise:", stored.Department)
+
+	repo = memory.New()
+	seed(repo)
+	olga, _ = repo.GetByName(ctx, "Olga")
+	olga.Department = "Platform"
+	_ = repo.Save(ctx, olga)
+	n, _ := evolve.Backfill(ctx, repo, fill)
+	fmt.Printf("   in bulk: Backfill saved %d employees\n", n)
+	olga, _ = repo.GetByName(ctx, "Olga")
+	fmt.Println("   a department someone chose is never overwritten:", olga.Department)
+	n, _ = evolve.Backfill(ctx, repo, fill)
+	fmt.Printf("   run again, it saves %d\n", n)
+
+	busy := &meddling{Repository: memory.New()}
+	seed(busy)
+	_, _ = evolve.Backfill(ctx, busy, fill)
+	olga, _ = busy.GetByName(ctx, "Olga")
+	fmt.Printf("   a colleague's change during the backfill is read again, not overwritten: %s, %s\n", olga.Email, olga.Department)
+}
+
+// read returns the object stored under key.
+func read(store *blob.Memory, key string) string {
+	rc, err := store.Get(context.Background(), key)
+	if err != nil {
+		return ""
+	}
+	defer rc.Close()
+	var b bytes.Buffer
+	_, _ = b.ReadFrom(rc)
+	return b.String()
+}
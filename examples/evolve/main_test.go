@@ -0,0 +1,39 @@
+package main
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"go-solid/employee"
+	"go-solid/employee/memory"
+	"go-solid/employee/sqlrepo"
+	"go-solid/replica"
+	"go-solid/shard"
+)
+
+func TestContract(t *testing.T) {
+	tests := []struct {
+		name string
+		repo employee.Repository
+		want []string
+	}{
+		{"memory", memory.New(), nil},
+		{"shard over memory", shard.New([]employee.Repository{memory.New(), memory.New()}), nil},
+		{"replica over memory", replica.New(memory.New(), nil), nil},
+		{"v1", v1{memory.New()}, []string{"a department saved is read back", "listing returns it too"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := contract(tt.repo); !slices.Equal(got, tt.want) {
+				t.Errorf("contract() broken = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSchema_DepartmentHasADefault(t *testing.T) {
+	if !strings.Contains(sqlrepo.Schema, "department VARCHAR(255) NOT NULL DEFAULT ''") {
+		t.Errorf("sqlrepo.Schema has no department column defaulting to '':\n%s", sqlrepo.Schema)
+	}
+}
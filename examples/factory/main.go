@@ -0,0 +1,67 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go-solid/employee"
+	"go-solid/leave"
+	"go-solid/money"
+	"go-solid/storage"
+)
+
+//////////--------------------Bad Practice--------------------/////////////////////////
+
+// ❌ Each repository is picked on its own - nothing stops a mismatched family
+//employees := mysqlrepo.NewEmployees(db)
+//leaves := memory.NewLeaves()         // ❌ oops, leave requests vanish on restart
+//auditSink := postgres.NewAudit(pgdb) // ❌ and audit goes to a different database
+
+//////////////-----------------------------Good Practice-------------------/////////////////////////////////////////////////////////
+
+// run High-level code - only knows the RepositoryFactory abstraction
+func run(ctx context.Context, repos storage.RepositoryFactory) error {
+	manager := employee.NewManager(repos.Employees(), employee.WithAudit(repos.Audit()))
+	emp, err := manager.AddEmployee(ctx, employee.Employee{Name: "Mohamed", Salary: money.Of(5000, money.USD)})
+	if err != nil {
+		return err
+	}
+
+	req := leave.Request{ID: "leave-1", Employee: emp.Name, Days: 5, Status: leave.Pending, RequestedAt: time.Now()}
+	if err := repos.Leaves().Save(ctx, req); err != nil {
+		return err
+	}
+	reqs, err := repos.Leaves().ListByEmployee(ctx, emp.Name)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("   ✅ %s hired with %d pending leave request(s)\n", emp.Name, len(reqs))
+	return nil
+}
+
+func main() {
+	ctx := context.Background()
+
+	// ✅ One configuration value swaps the whole family of repositories at once
+	for _, cfg := range []storage.Config{
+		{Backend: "memory"},
+		{Backend: "postgres", DSN: "postgres://localhost/hr"},
+		{Backend: "mongo"},
+	} {
+		fmt.Printf("🏭 backend %q\n", cfg.Backend)
+		repos, err := storage.Open(cfg)
+		if err != nil {
+			// SQL backends need their driver linked in (blank import), as usual with database/sql
+			fmt.Println("   ❌", err)
+			continue
+		}
+		if err := run(ctx, repos); err != nil {
+			fmt.Println("   ❌", err)
+		}
+		_ = repos.Close()
+	}
+
+	// A new backend family is added with storage.Register("mongo", ...) from its own
+	// package - neither storage.Open nor run() change (OCP + DIP).
+}
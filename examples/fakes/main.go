@@ -0,0 +1,56 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"go-solid/employee"
+	"go-solid/employee/memory"
+	"go-solid/fakes"
+	"go-solid/money"
+)
+
+func main() {
+	ctx := context.Background()
+
+	// ❌ Hand-written fixtures: three people, all "Employee N", all paid the same
+	//
+	//	for i := range 3 {
+	//		repo.Save(ctx, employee.Employee{Name: fmt.Sprintf("Employee %d", i), Salary: money.Of(5000, money.USD)})
+	//	}
+
+	// ✅ The same seed makes the same people, so a surprising run can be repeated
+	fmt.Println("🎲 Two fakers with seed 42")
+	a, b := fakes.New(42), fakes.New(42)
+	for range 3 {
+		x, y := a.Employee(), b.Employee()
+		fmt.Printf("   %-22s %-20s %-10s same: %v\n", x.Name, x.Title, x.Salary, x.Name == y.Name && x.Salary == y.Salary)
+	}
+
+	fmt.Println("\n👥 A team")
+	team := fakes.New(7).Team(4)
+	fmt.Printf("   %s, led by %s (%s, %s)\n", team.Name, team.Manager.Name, team.Manager.Title, team.Manager.Salary)
+	for _, m := range team.Members {
+		fmt.Printf("   - %-22s %-20s %s\n", m.Name, m.Title, m.Salary)
+	}
+
+	fmt.Println("\n🧾 Payroll history of the manager, last 18 months")
+	faker := fakes.New(7, fakes.WithCurrency(money.EGP))
+	manager := faker.Manager()
+	fmt.Printf("   %s, hired %s\n", manager.Name, manager.HiredAt.Format("2006-01-02"))
+	for _, slip := range faker.PayrollHistory(manager, 18) {
+		fmt.Printf("   %s %s\n", slip.Period, slip.Base)
+	}
+
+	// ✅ Seed fills any employee.Repository; queries then have something to find
+	fmt.Println("\n🌱 Seeding a repository with 500 employees")
+	repo := memory.New()
+	if _, err := fakes.Seed(ctx, repo, fakes.New(1), 500); err != nil {
+		fmt.Println("   ❌", err)
+		return
+	}
+	top, _ := repo.List(ctx, employee.Filter{Sort: employee.SortBySalary, Descending: true}, employee.Page{Limit: 3})
+	for _, emp := range top.Items {
+		fmt.Printf("   %-22s %-24s %s\n", emp.Name, emp.Title, emp.Salary)
+	}
+}
@@ -0,0 +1,87 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"go-solid/featureflag"
+)
+
+// BonusStrategy Existing extension point for bonus calculation
+type BonusStrategy interface {
+	Bonus(salary float64, rating int) float64
+}
+
+// flatBonus The strategy in production today - untouched by the rollout
+type flatBonus struct{}
+
+func (flatBonus) Bonus(salary float64, rating int) float64 { return salary * 0.05 }
+
+// performanceBonus The new calculation being rolled out
+type performanceBonus struct{}
+
+func (performanceBonus) Bonus(salary float64, rating int) float64 {
+	return salary * 0.02 * float64(rating)
+}
+
+// rollout Picks the strategy for the caller in ctx - neither strategy knows about flags
+type rollout struct {
+	flags featureflag.Flags
+	flag  string
+	on    BonusStrategy
+	off   BonusStrategy
+}
+
+func (r rollout) For(ctx context.Context) BonusStrategy {
+	if r.flags.Enabled(ctx, r.flag) {
+		return r.on
+	}
+	return r.off
+}
+
+type employee struct {
+	name   string
+	salary float64
+	rating int
+}
+
+func payBonuses(flags featureflag.Flags, staff []employee) {
+	bonuses := rollout{flags: flags, flag: "new-bonus", on: performanceBonus{}, off: flatBonus{}}
+	for _, e := range staff {
+		ctx := featureflag.WithSubject(context.Background(), e.name)
+		strategy := bonuses.For(ctx)
+		marker := "  "
+		if _, isNew := strategy.(performanceBonus); isNew {
+			marker = "🆕"
+		}
+		fmt.Printf("   %s %-8s bonus %7.2f\n", marker, e.name, strategy.Bonus(e.salary, e.rating))
+	}
+}
+
+func main() {
+	staff := []employee{
+		{"Mohamed", 5000, 4}, {"Ahmed", 6000, 3}, {"Ali", 4500, 5},
+		{"Amira", 7000, 2}, {"Omar", 3000, 4}, {"Aya", 5200, 3},
+	}
+
+	// ✅ The same rollout logic works with any Flags implementation
+	for _, rollout := range []string{"off", "50%", "on"} {
+		fmt.Printf("🚩 new-bonus = %s\n", rollout)
+		payBonuses(featureflag.Static{"new-bonus": rollout}, staff)
+	}
+
+	// Remote flag service (stubbed with an in-process HTTP server)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"new-bonus": "on"}`)
+	}))
+	defer srv.Close()
+
+	fmt.Println("🌐 new-bonus from remote flag service")
+	payBonuses(&featureflag.Remote{URL: srv.URL, TTL: time.Minute}, staff[:2])
+
+	// The new bonus shipped dark, was rolled out to half of the staff and then to everyone -
+	// without editing flatBonus or performanceBonus (OCP).
+}
@@ -0,0 +1,109 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	"go-solid/assertlsp"
+	"go-solid/employee"
+	"go-solid/employee/actor"
+	"go-solid/employee/memory"
+	"go-solid/graphqlapi"
+	"go-solid/httpapi"
+)
+
+// post sends a GraphQL request and prints the answer.
+func post(url, query string, vars map[string]any) {
+	body, _ := json.Marshal(graphqlapi.Request{Query: query, Variables: vars})
+	resp, err := http.Post(url+"/graphql", "application/json", bytes.NewReader(body))
+	if err != nil {
+		fmt.Println("❌", err)
+		return
+	}
+	defer resp.Body.Close()
+	var out bytes.Buffer
+	_, _ = out.ReadFrom(resp.Body)
+	fmt.Printf("   %s %s", strings.TrimSpace(resp.Status), out.String())
+}
+
+// rest sends a REST request and prints the answer.
+func rest(method, url, body string) {
+	req, _ := http.NewRequest(method, url, strings.NewReader(body))
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		fmt.Println("❌", err)
+		return
+	}
+	defer resp.Body.Close()
+	var out bytes.Buffer
+	_, _ = out.ReadFrom(resp.Body)
+	fmt.Printf("   %s %s", strings.TrimSpace(resp.Status), out.String())
+}
+
+const fields = `fragment card on Employee { name title salary { formatted } }`
+
+func main() {
+	// ✅ One use-case layer, two delivery adapters. Neither adapter knows the
+	// other exists, and the Manager knows neither.
+	manager := employee.NewManager(memory.New())
+	api := httpapi.New(manager)
+	api.Handle("/graphql", graphqlapi.New(manager))
+	server := httptest.NewServer(api)
+	defer server.Close()
+
+	fmt.Println("🕸️  Hire over GraphQL")
+	post(server.URL, `mutation Hire($in: HireInput!) { hire(input: $in) { ...card } } `+fields, map[string]any{
+		"in": map[string]any{"name": "Alice", "title": "Engineer", "salary": map[string]any{"amount": "5000", "currency": "USD"}},
+	})
+
+	fmt.Println("\n🌐 Promote over REST")
+	rest(http.MethodPost, server.URL+"/employees/Alice/promotion", `{"title": "Senior Engineer", "raise": {"amount": "1000.00", "currency": "USD"}}`)
+
+	fmt.Println("\n🕸️  Read it back over GraphQL, only the fields asked for")
+	post(server.URL, `{ alice: employee(name: "Alice") { ...card } } `+fields, nil)
+
+	// The same domain error, in each protocol's terms: a status code for REST,
+	// a code next to the data that could still be read for GraphQL
+	fmt.Println("\n🔎 Someone who isn't there")
+	rest(http.MethodGet, server.URL+"/employees/Bob", "")
+	post(server.URL, `{ alice: employee(name: "Alice") { name } bob: employee(name: "Bob") { name } }`, nil)
+
+	// ❌ Queries are validated against the schema before anything is resolved
+	post(server.URL, `{ employee(name: "Alice") { name password } }`, nil)
+
+	// ✅ The resolvers depend on graphqlapi.EmployeeService, not on a Manager:
+	// the same requests give the same answers whichever implementation serves
+	// them. Ids and hire dates differ between runs, so they aren't asked for.
+	fmt.Println("\n🔁 The same requests against employee.Manager and actor.Manager")
+	ask := func(name, query string) assertlsp.Step[*graphqlapi.Handler] {
+		return assertlsp.Call(name, func(h *graphqlapi.Handler) any {
+			out, _ := json.Marshal(h.Execute(context.Background(), graphqlapi.Request{Query: query}))
+			return string(out)
+		})
+	}
+	script := []assertlsp.Step[*graphqlapi.Handler]{
+		ask("hire", `mutation { hire(input: {name: "Alice", salary: {amount: 5000, currency: "USD"}}) { name salary { amount } } }`),
+		ask("hire again", `mutation { hire(input: {name: "Bea", title: "Designer", salary: {amount: "4200.50", currency: "EUR"}}) { name } }`),
+		ask("invalid salary", `mutation { hire(input: {name: "Carl", salary: {amount: "-1", currency: "USD"}}) { name } }`),
+		ask("raise", `mutation { changeSalary(name: "Alice", salary: {amount: "5500", currency: "USD"}) { salary { formatted } } }`),
+		ask("promote", `mutation { promote(name: "Bea", title: "Lead Designer", raise: {amount: "300", currency: "EUR"}) { title } }`),
+		ask("list", `{ employees(sort: SALARY, desc: true) { items { name salary { formatted } } nextCursor } }`),
+		ask("remove", `mutation { remove(name: "Bea") }`),
+		ask("find removed", `{ employee(name: "Bea") { name } }`),
+	}
+	sync := graphqlapi.New(employee.NewManager(memory.New()))
+	actors := actor.New(employee.NewManager(memory.New()))
+	defer actors.Close()
+	if diffs := assertlsp.Compare(sync, graphqlapi.New(actors), script...); len(diffs) > 0 {
+		for _, d := range diffs {
+			fmt.Printf("   ❌ %s\n", d)
+		}
+		return
+	}
+	fmt.Printf("   ✅ %d requests, identical responses\n", len(script))
+}
@@ -0,0 +1,159 @@
+// Command hiring takes candidates through a recruitment pipeline - screening,
+// a technical interview, an offer, onboarding - built as a chain of
+// hiring.Stages. It shows the chain stopping at the first stage that rejects
+// or holds a candidate, picking a held candidate up where they stopped, a
+// stage written here slotted in without a change to the Pipeline, and the
+// trail of every decision. main_test.go checks every claim.
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"go-solid/audit"
+	"go-solid/clock"
+	"go-solid/employee"
+	"go-solid/employee/memory"
+	"go-solid/hiring"
+	"go-solid/money"
+	"go-solid/savehook"
+)
+
+// references Stage written here: it passes candidates whose referees have
+// answered, and counts how often it was asked
+type references struct {
+	answered map[string]bool
+	asked    *int
+}
+
+func (references) Name() string { return "references" }
+
+func (r references) Handle(_ context.Context, app *hiring.Application) (hiring.Decision, error) {
+	*r.asked++
+	if !r.answered[app.Candidate.Name] {
+		return hiring.Hold("waiting for referees"), nil
+	}
+	return hiring.Advance("referees answered"), nil
+}
+
+// trail prints an application's trail, one decision per line.
+func trail(app *hiring.Application) {
+	for _, e := range app.Trail {
+		fmt.Printf("      %s %-14s %-8s %s\n", e.At.Format("Jan 2"), e.Stage, e.Verdict, e.Reason)
+	}
+}
+
+// desk The pipeline and everything its stages read or write
+type desk struct {
+	pipeline *hiring.Pipeline
+	people   *memory.Repository
+	scores   hiring.Scores
+	refs     references
+	sink     *audit.Memory
+}
+
+// open builds the chain: screening, a two-person interview, references, an
+// offer of at least 5000, and onboarding through a Manager whose salary
+// band tops out at 8000.
+func open(clk clock.Clock) *desk {
+	d := &desk{
+		people: memory.New(),
+		scores: hiring.Scores{},
+		refs:   references{answered: map[string]bool{}, asked: new(int)},
+		sink:   &audit.Memory{},
+	}
+	hooks := &employee.Hooks{}
+	_ = hooks.Register(savehook.SalaryBand{Min: money.Of(3000, money.USD), Max: money.Of(8000, money.USD)})
+	manager := employee.NewManager(d.people, employee.WithHooks(hooks))
+	d.pipeline = hiring.NewPipeline([]hiring.Stage{
+		hiring.Screening{MinYears: 3, Skills: []string{"go", "sql"}, Budget: money.Of(9000, money.USD)},
+		hiring.TechInterview{Panel: d.scores, Interviewers: 2, PassMark: 3.5},
+		d.refs,
+		hiring.Offer{Floor: money.Of(5000, money.USD)},
+		hiring.Onboard{Hirer: manager},
+	}, hiring.WithClock(clk), hiring.WithAudit(d.sink))
+	return d
+}
+
+// apply opens an application for one of the candidates.
+func apply(name string) *hiring.Application {
+	return &hiring.Application{ID: "app-" + strings.ToLower(name), Candidate: candidates[name]}
+}
+
+var (
+	start  = time.Date(2025, time.April, 1, 9, 0, 0, 0, time.UTC)
+	skills = []string{"go", "sql", "kubernetes"}
+
+	candidates = map[string]hiring.Candidate{
+		"Alice": {Name: "Alice", Email: "alice@example.com", Role: "Engineer", Years: 6, Skills: skills, Expected: money.Of(4500, money.USD)},
+		// rejected at screening: too junior, missing sql, over budget
+		"Bob":   {Name: "Bob", Role: "Engineer", Years: 2, Skills: skills, Expected: money.Of(4000, money.USD)},
+		"Carol": {Name: "Carol", Role: "Engineer", Years: 5, Skills: []string{"go"}, Expected: money.Of(6000, money.USD)},
+		"Dan":   {Name: "Dan", Role: "Engineer", Years: 8, Skills: skills, Expected: money.Of(12000, money.USD)},
+		"Erin":  {Name: "Erin", Role: "Engineer", Years: 4, Skills: skills, Expected: money.Of(5500, money.USD)},
+		"Frank": {Name: "Frank", Email: "frank@example.com", Role: "SRE", Years: 5, Skills: skills, Expected: money.Of(6000, money.USD)},
+		// expects more than the salary band allows
+		"Grace": {Name: "Grace", Role: "Architect", Years: 12, Skills: skills, Expected: money.Of(8500, money.USD)},
+	}
+)
+
+func main() {
+	ctx := audit.WithActor(context.Background(), "recruiter@example.com")
+	clk := clock.NewFake(start)
+	d := open(clk)
+
+	fmt.Println("⛓️  All the way down the chain")
+	alice := apply("Alice")
+	d.scores["Alice"] = []int{4, 5}
+	d.refs.answered["Alice"] = true
+	_ = d.pipeline.Process(ctx, alice)
+	trail(alice)
+	fmt.Printf("   Alice is %s, through %d stages, offered the floor, above what they asked: %v\n", alice.Status, len(alice.Passed), alice.Offer)
+	emp, _ := d.people.GetByName(ctx, "Alice")
+	fmt.Println("   and an employee now, at", emp.Salary)
+	fmt.Println("   their application is closed:", d.pipeline.Process(ctx, alice))
+
+	fmt.Println("✂️  A rejection ends the chain")
+	for _, name := range []string{"Bob", "Carol", "Dan"} {
+		app := apply(name)
+		_ = d.pipeline.Process(ctx, app)
+		last := app.Trail[len(app.Trail)-1]
+		fmt.Printf("   %s is %s at %s: %s\n", name, app.Status, last.Stage, last.Reason)
+	}
+	erin := apply("Erin")
+	d.scores["Erin"] = []int{3, 2}
+	_ = d.pipeline.Process(ctx, erin)
+	trail(erin)
+	fmt.Println("   Erin passes screening but not the interview; no stage after it was asked")
+
+	fmt.Println("⏸️  A hold, and picking up where it stopped")
+	frank := apply("Frank")
+	d.scores["Frank"] = []int{4}
+	_ = d.pipeline.Process(ctx, frank)
+	fmt.Println("   Frank waits for a second interview:", frank.Trail[len(frank.Trail)-1].Reason)
+	clk.Advance(48 * time.Hour)
+	d.scores["Frank"] = append(d.scores["Frank"], 4)
+	_ = d.pipeline.Process(ctx, frank)
+	fmt.Println("   then for their referees, a stage written here, slotted in without a change (OCP):", frank.Trail[len(frank.Trail)-1].Stage)
+	clk.Advance(24 * time.Hour)
+	d.refs.answered["Frank"] = true
+	_ = d.pipeline.Process(ctx, frank)
+	trail(frank)
+	fmt.Printf("   and is %s: screening isn't run twice\n", frank.Status)
+
+	fmt.Println("⚠️  A stage fails: the application waits there")
+	grace := apply("Grace")
+	d.scores["Grace"] = []int{5, 5}
+	d.refs.answered["Grace"] = true
+	err := d.pipeline.Process(ctx, grace)
+	fmt.Printf("      %v\n", err)
+	fmt.Printf("   the salary band hook vetoes their hire at onboarding, so they are %s after the %s\n", grace.Status, grace.Passed[len(grace.Passed)-1])
+
+	fmt.Println("📜 The audit trail")
+	records := d.sink.Records()
+	fmt.Printf("   %d decisions audited, by %s\n", len(records), records[0].Actor)
+	failed := records[len(records)-1]
+	fmt.Printf("   the failure as a failure: %s, %s\n", failed.Action, failed.Outcome)
+}
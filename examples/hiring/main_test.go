@@ -0,0 +1,128 @@
+package main
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"go-solid/audit"
+	"go-solid/clock"
+	"go-solid/employee"
+	"go-solid/hiring"
+	"go-solid/money"
+)
+
+func TestPipeline_HiresThroughEveryStage(t *testing.T) {
+	d := open(clock.NewFake(start))
+	app := apply("Alice")
+	d.scores["Alice"] = []int{4, 5}
+	d.refs.answered["Alice"] = true
+	if err := d.pipeline.Process(t.Context(), app); err != nil {
+		t.Fatalf("Process() error = %v", err)
+	}
+	if app.Status != hiring.Hired || len(app.Passed) != 5 {
+		t.Errorf("Process() = %s after %v, want hired through all 5 stages", app.Status, app.Passed)
+	}
+	if want := money.Of(5000, money.USD); app.Offer != want {
+		t.Errorf("Offer = %v, want the floor %v", app.Offer, want)
+	}
+	if emp, err := d.people.GetByName(t.Context(), "Alice"); err != nil || emp.Salary != app.Offer {
+		t.Errorf("GetByName(Alice) = %v, %v, want an employee paid the offer", emp.Salary, err)
+	}
+	if err := d.pipeline.Process(t.Context(), app); !errors.Is(err, hiring.ErrClosed) {
+		t.Errorf("Process() again error = %v, want %v", err, hiring.ErrClosed)
+	}
+}
+
+func TestPipeline_RejectionEndsTheChain(t *testing.T) {
+	d := open(clock.NewFake(start))
+	for _, name := range []string{"Bob", "Carol", "Dan"} {
+		app := apply(name)
+		_ = d.pipeline.Process(t.Context(), app)
+		if app.Status != hiring.Rejected || len(app.Trail) != 1 || app.Trail[0].Stage != "screening" {
+			t.Errorf("Process(%s) = %s after %v, want rejected at screening", name, app.Status, app.Trail)
+		}
+	}
+	app := apply("Erin")
+	d.scores["Erin"] = []int{3, 2}
+	_ = d.pipeline.Process(t.Context(), app)
+	if app.Status != hiring.Rejected || len(app.Trail) != 2 {
+		t.Errorf("Process(Erin) = %s after %v, want rejected at the interview", app.Status, app.Trail)
+	}
+	if *d.refs.asked != 0 {
+		t.Errorf("references asked %d times, want no stage after a rejection asked", *d.refs.asked)
+	}
+}
+
+func TestPipeline_PicksUpWhereItHeld(t *testing.T) {
+	clk := clock.NewFake(start)
+	d := open(clk)
+	app := apply("Frank")
+	d.scores["Frank"] = []int{4}
+	_ = d.pipeline.Process(t.Context(), app)
+	if app.Status != hiring.Held || app.Trail[len(app.Trail)-1].Stage != "tech-interview" {
+		t.Fatalf("Process() = %s at %v, want held for a second interview", app.Status, app.Trail)
+	}
+	clk.Advance(48 * time.Hour)
+	d.scores["Frank"] = append(d.scores["Frank"], 4)
+	_ = d.pipeline.Process(t.Context(), app)
+	if app.Status != hiring.Held || app.Trail[len(app.Trail)-1].Stage != "references" {
+		t.Fatalf("Process() = %s at %v, want held for referees", app.Status, app.Trail)
+	}
+	clk.Advance(24 * time.Hour)
+	d.refs.answered["Frank"] = true
+	if err := d.pipeline.Process(t.Context(), app); err != nil || app.Status != hiring.Hired {
+		t.Fatalf("Process() = %s, %v, want hired", app.Status, err)
+	}
+	screenings := 0
+	for _, e := range app.Trail {
+		if e.Stage == "screening" {
+			screenings++
+		}
+	}
+	if screenings != 1 {
+		t.Errorf("screening ran %d times, want once", screenings)
+	}
+}
+
+func TestPipeline_FailingStageHolds(t *testing.T) {
+	d := open(clock.NewFake(start))
+	app := apply("Grace")
+	d.scores["Grace"] = []int{5, 5}
+	d.refs.answered["Grace"] = true
+	if err := d.pipeline.Process(t.Context(), app); !errors.Is(err, employee.ErrVetoed) {
+		t.Errorf("Process() error = %v, want %v", err, employee.ErrVetoed)
+	}
+	if app.Status != hiring.Held || app.Passed[len(app.Passed)-1] != "offer" {
+		t.Errorf("Process() = %s after %v, want held after the offer", app.Status, app.Passed)
+	}
+}
+
+func TestPipeline_Audit(t *testing.T) {
+	ctx := audit.WithActor(t.Context(), "recruiter@example.com")
+	d := open(clock.NewFake(start))
+	var apps []*hiring.Application
+	for _, name := range []string{"Alice", "Bob", "Grace"} {
+		d.scores[name] = []int{5, 5}
+		d.refs.answered[name] = true
+		app := apply(name)
+		_ = d.pipeline.Process(ctx, app)
+		apps = append(apps, app)
+	}
+	decisions := 0
+	for _, app := range apps {
+		decisions += len(app.Trail)
+	}
+	records := d.sink.Records()
+	if len(records) != decisions {
+		t.Errorf("%d records audited, want one per decision on the trails: %d", len(records), decisions)
+	}
+	for _, r := range records {
+		if r.Actor != "recruiter@example.com" {
+			t.Errorf("record %s by %q, want the recruiter", r.Action, r.Actor)
+		}
+	}
+	if failed := records[len(records)-1]; failed.Outcome != audit.Failure || failed.EntityID != "app-grace" || failed.Action != "hiring.onboard" {
+		t.Errorf("last record = %+v, want Grace's onboarding audited as a failure", failed)
+	}
+}
@@ -0,0 +1,148 @@
+// Command hooks plugs search indexing, notifications, auditing and a salary
+// band into the employee.Manager through its save hooks, without the Manager
+// knowing any of them. It shows a save going through both phases, a save
+// vetoed by two hooks at once, an after-save hook failing without failing
+// the save, and a hook registered while the Manager runs. main_test.go
+// checks every claim.
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"go-solid/audit"
+	"go-solid/employee"
+	"go-solid/employee/memory"
+	"go-solid/money"
+	"go-solid/notify"
+	"go-solid/savehook"
+	"go-solid/search"
+	searchmemory "go-solid/search/memory"
+)
+
+// down ❌ A notifier whose mail server is unreachable
+type down struct{}
+
+func (down) Notify(context.Context, notify.Message) error {
+	return errors.New("smtp: connection refused")
+}
+
+// nameCase BeforeSaver rejecting names that don't start with a capital
+type nameCase struct{}
+
+func (nameCase) Name() string { return "name-case" }
+
+func (nameCase) BeforeSave(_ context.Context, emp employee.Employee) error {
+	if emp.Name == "" || emp.Name[:1] != strings.ToUpper(emp.Name[:1]) {
+		return fmt.Errorf("name %q must start with a capital", emp.Name)
+	}
+	return nil
+}
+
+// silent A Hook that implements neither phase
+type silent struct{}
+
+func (silent) Name() string { return "silent" }
+
+// plant The Manager with its plugins, and what they write to
+type plant struct {
+	repo       *memory.Repository
+	index      *searchmemory.Index
+	sink       *audit.Memory
+	mail, logs *bytes.Buffer
+	manager    *employee.Manager
+}
+
+// wire plugs a salary band, notifications, indexing and auditing into a
+// Manager that knows none of them.
+func wire() (*plant, error) {
+	p := &plant{repo: memory.New(), index: searchmemory.New(), sink: &audit.Memory{}, mail: &bytes.Buffer{}, logs: &bytes.Buffer{}}
+	hooks := &employee.Hooks{}
+	err := hooks.Register(
+		savehook.SalaryBand{Min: money.Of(3000, money.USD), Max: money.Of(10000, money.USD)},
+		savehook.Notify{Notifier: notify.NewConsole(p.mail)},
+		savehook.Index{Searcher: p.index},
+		savehook.Audit{Sink: p.sink},
+	)
+	p.manager = employee.NewManager(p.repo,
+		employee.WithHooks(hooks),
+		employee.WithLogger(slog.New(slog.NewTextHandler(p.logs, nil))),
+	)
+	return p, err
+}
+
+// unreachable is a second Manager over the same storage, told to notify
+// through a mail server that is down.
+func (p *plant) unreachable() *employee.Manager {
+	m := employee.NewManager(p.repo,
+		employee.WithHooks(&employee.Hooks{}),
+		employee.WithLogger(slog.New(slog.NewTextHandler(p.logs, nil))),
+	)
+	_ = m.Hooks().Register(savehook.Notify{Notifier: down{}})
+	return m
+}
+
+var (
+	alice = employee.Employee{Name: "Alice", Title: "Engineer", Email: "alice@example.com", Salary: money.Of(5000, money.USD)}
+	// bob is paid above the band and has no valid email
+	bob   = employee.Employee{Name: "Bob", Title: "Engineer", Email: "bob at example", Salary: money.Of(25000, money.USD)}
+	carol = employee.Employee{Name: "Carol", Title: "Designer", Email: "carol@example.com", Salary: money.Of(4000, money.USD)}
+	dave  = employee.Employee{Name: "dave", Title: "Engineer", Salary: money.Of(4000, money.USD)}
+)
+
+func main() {
+	ctx := audit.WithActor(context.Background(), "hr@example.com")
+	p, err := wire()
+	if err != nil {
+		fmt.Println("❌", err)
+		return
+	}
+
+	fmt.Println("🔌 Plugins registered, the Manager unchanged (OCP)")
+	fmt.Println("   hooks:", strings.Join(p.manager.Hooks().Names(), ", "))
+	fmt.Println("   a hook with neither phase is refused:", p.manager.Hooks().Register(silent{}))
+
+	fmt.Println("💾 Two phases: validate, store, react")
+	if _, err := p.manager.AddEmployee(ctx, alice); err != nil {
+		fmt.Println("   ❌", err)
+		return
+	}
+	hits, _ := p.index.Search(ctx, search.Query{Text: "alice"})
+	fmt.Printf("   Alice is hired, and the search index finds %d of her\n", len(hits))
+	fmt.Println("   she is told:", strings.TrimSpace(p.mail.String()))
+	if records := p.sink.Records(); len(records) > 0 {
+		last := records[len(records)-1]
+		fmt.Printf("   the stored revision is audited: %s by %s\n", last.Action, last.Actor)
+	}
+	_, _ = p.manager.Promote(ctx, "Alice", "Senior Engineer", money.Of(1000, money.USD))
+	hits, _ = p.index.Search(ctx, search.Query{Text: "senior"})
+	fmt.Printf("   a promotion goes through the same hooks: %d hit for the new title\n", len(hits))
+
+	fmt.Println("🚫 A veto, with every objection at once")
+	p.mail.Reset()
+	_, err = p.manager.AddEmployee(ctx, bob)
+	fmt.Println("   Bob's hire is vetoed by both the salary band and the email check:")
+	for line := range strings.SplitSeq(fmt.Sprint(err), "\n") {
+		fmt.Printf("      %s\n", line)
+	}
+	_, err = p.repo.GetByName(ctx, "Bob")
+	fmt.Println("   nothing is stored:", err)
+
+	fmt.Println("⚠️  An after-save hook fails: the save stands")
+	_, err = p.unreachable().AddEmployee(ctx, carol)
+	fmt.Println("   Carol is hired although she couldn't be told:", err == nil)
+	for line := range strings.Lines(p.logs.String()) {
+		if strings.Contains(line, "level=WARN") {
+			fmt.Print("   the failure is logged, by hook: ", line)
+		}
+	}
+
+	fmt.Println("➕ A hook registered while the Manager runs")
+	_ = p.manager.Hooks().Register(nameCase{})
+	_, err = p.manager.AddEmployee(ctx, dave)
+	fmt.Println("   name-case runs on the next save:", err)
+}
@@ -0,0 +1,104 @@
+package main
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"go-solid/audit"
+	"go-solid/employee"
+	"go-solid/money"
+	"go-solid/search"
+)
+
+func TestHooks_Register(t *testing.T) {
+	p, err := wire()
+	if err != nil {
+		t.Fatalf("wire() error = %v", err)
+	}
+	if got := p.manager.Hooks().Names(); len(got) != 4 {
+		t.Errorf("Names() = %v, want the 4 plugins", got)
+	}
+	if err := p.manager.Hooks().Register(silent{}); !errors.Is(err, employee.ErrNotAHook) {
+		t.Errorf("Register(silent) error = %v, want %v", err, employee.ErrNotAHook)
+	}
+}
+
+func TestHooks_BothPhases(t *testing.T) {
+	ctx := audit.WithActor(t.Context(), "hr@example.com")
+	p, err := wire()
+	if err != nil {
+		t.Fatalf("wire() error = %v", err)
+	}
+	if _, err := p.manager.AddEmployee(ctx, alice); err != nil {
+		t.Fatalf("AddEmployee(Alice) error = %v", err)
+	}
+	if hits, _ := p.index.Search(ctx, search.Query{Text: "alice"}); len(hits) != 1 || hits[0].Title != "Engineer" {
+		t.Errorf("Search(alice) = %v, want Alice the engineer", hits)
+	}
+	if !strings.Contains(p.mail.String(), "to alice@example.com") {
+		t.Errorf("mail = %q, want Alice told", p.mail.String())
+	}
+	records := p.sink.Records()
+	if len(records) == 0 || records[len(records)-1].Action != "employee.stored" || records[len(records)-1].Actor != "hr@example.com" {
+		t.Errorf("audit records = %v, want the stored revision audited by hr@example.com", records)
+	}
+	if _, err := p.manager.Promote(ctx, "Alice", "Senior Engineer", money.Of(1000, money.USD)); err != nil {
+		t.Fatalf("Promote() error = %v", err)
+	}
+	if hits, _ := p.index.Search(ctx, search.Query{Text: "senior"}); len(hits) != 1 {
+		t.Errorf("Search(senior) = %v, want the promotion indexed", hits)
+	}
+}
+
+func TestHooks_Veto(t *testing.T) {
+	p, err := wire()
+	if err != nil {
+		t.Fatalf("wire() error = %v", err)
+	}
+	_, err = p.manager.AddEmployee(t.Context(), bob)
+	if !errors.Is(err, employee.ErrVetoed) {
+		t.Fatalf("AddEmployee(Bob) error = %v, want %v", err, employee.ErrVetoed)
+	}
+	for _, hook := range []string{"salary-band:", "notify:"} {
+		if !strings.Contains(err.Error(), hook) {
+			t.Errorf("AddEmployee(Bob) error = %v, want an objection from %s", err, hook)
+		}
+	}
+	if _, err := p.repo.GetByName(t.Context(), "Bob"); !errors.Is(err, employee.ErrNotFound) {
+		t.Errorf("GetByName(Bob) error = %v, want %v", err, employee.ErrNotFound)
+	}
+	hits, _ := p.index.Search(t.Context(), search.Query{Text: "bob"})
+	if len(hits) != 0 || p.mail.Len() != 0 || len(p.sink.Records()) != 0 {
+		t.Errorf("after the veto: %d hits, %d bytes of mail, %d audit records, want none", len(hits), p.mail.Len(), len(p.sink.Records()))
+	}
+}
+
+func TestHooks_AfterSaveFailureKeepsTheSave(t *testing.T) {
+	p, err := wire()
+	if err != nil {
+		t.Fatalf("wire() error = %v", err)
+	}
+	if _, err := p.unreachable().AddEmployee(t.Context(), carol); err != nil {
+		t.Fatalf("AddEmployee(Carol) error = %v, want the save to stand", err)
+	}
+	if _, err := p.repo.GetByName(t.Context(), "Carol"); err != nil {
+		t.Errorf("GetByName(Carol) error = %v", err)
+	}
+	if logs := p.logs.String(); !strings.Contains(logs, "after-save hook failed") || !strings.Contains(logs, "hook=notify") {
+		t.Errorf("logs = %q, want the failing hook logged", logs)
+	}
+}
+
+func TestHooks_RegisteredWhileRunning(t *testing.T) {
+	p, err := wire()
+	if err != nil {
+		t.Fatalf("wire() error = %v", err)
+	}
+	if err := p.manager.Hooks().Register(nameCase{}); err != nil {
+		t.Fatalf("Register(nameCase) error = %v", err)
+	}
+	if _, err := p.manager.AddEmployee(t.Context(), dave); !errors.Is(err, employee.ErrVetoed) {
+		t.Errorf("AddEmployee(dave) error = %v, want %v", err, employee.ErrVetoed)
+	}
+}
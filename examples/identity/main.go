@@ -0,0 +1,97 @@
+// Command identity gives employees IDs from each id.Generator - a sequence,
+// UUIDv7 and ULID - and shows the repository keeping them by that ID: a
+// rename is the same employee, a name held by someone else is refused, and
+// GetByName still finds them for callers that only know the name.
+// main_test.go checks every claim.
+package main
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"go-solid/clock"
+	"go-solid/employee"
+	"go-solid/employee/memory"
+	"go-solid/id"
+	"go-solid/money"
+)
+
+// listOnly hides every capability of the repository it wraps but saving,
+// finding by name and listing.
+type listOnly struct {
+	employee.Repository
+	employee.QueryRepository
+}
+
+func main() {
+	ctx := context.Background()
+
+	fmt.Println("🆔 One Generator, three strategies (OCP)")
+	clk := clock.NewFake(time.Date(2025, time.March, 3, 9, 0, 0, 0, time.UTC))
+	seq := id.NewSequence("emp-")
+	fmt.Printf("   a sequence, for tests and demos: %s, %s, %s\n", seq.NewID(), seq.NewID(), seq.NewID())
+	v7 := id.UUIDv7{Clock: clk}
+	a := v7.NewID()
+	clk.Advance(time.Millisecond)
+	b := v7.NewID()
+	fmt.Printf("      UUIDv7 %s\n      UUIDv7 %s\n", a, b)
+	fmt.Printf("   UUIDv7: version %c, and a millisecond later sorts later: %v\n", a[14], a < b)
+	fmt.Printf("   the Unix milliseconds are the first 48 bits: %s is %012x\n", a[:13], clk.Now().Add(-time.Millisecond).UnixMilli())
+	ulid := id.ULID{Clock: clk}
+	c, same := ulid.NewID(), ulid.NewID()
+	clk.Advance(time.Millisecond)
+	d := ulid.NewID()
+	fmt.Printf("      ULID   %s\n      ULID   %s\n      ULID   %s\n", c, same, d)
+	fmt.Printf("   ULID: %d characters of Crockford base 32, sorted by time too: %v\n", len(c), c < d)
+	fmt.Println("   the same millisecond is the same first 10 characters:", c[:10], same[:10])
+
+	fmt.Println("🗄️  Keyed by ID, not by name")
+	repo := memory.New()
+	manager := employee.NewManager(repo, employee.WithIDs(id.NewSequence("emp-")))
+	ali, err := manager.AddEmployee(ctx, employee.Employee{Name: "Ali Khan", Title: "Engineer", Salary: money.Of(5000, money.USD)})
+	fmt.Println("   Ali is hired as " + ali.ID.String() + failure(err))
+	found, err := repo.GetByID(ctx, "emp-1")
+	fmt.Println("   GetByID finds " + found.Name + failure(err))
+	renamed := found
+	renamed.Name = "Ali Rahman"
+	err = repo.Save(ctx, renamed)
+	found, _ = repo.GetByID(ctx, "emp-1")
+	fmt.Println("   a new name is a rename, not a new employee: emp-1 is " + found.Name + failure(err))
+	_, err = repo.GetByName(ctx, "Ali Khan")
+	fmt.Println("   the old name is free again:", err)
+	found, err = repo.GetByName(ctx, "ali rahman")
+	fmt.Println("   GetByName still works, through the name index: " + found.ID.String() + failure(err))
+	history, _ := repo.History(ctx, "Ali Rahman")
+	fmt.Printf("   and the history followed them: %d versions, from %s\n", len(history), history[0].Name)
+
+	fmt.Println("🚫 Names stay unique")
+	sara, _ := manager.AddEmployee(ctx, employee.Employee{Name: "Sara", Title: "Analyst", Salary: money.Of(4500, money.USD)})
+	sara.Name = "Ali Rahman"
+	err = repo.Save(ctx, sara)
+	fmt.Printf("   %s can't take Ali's name: %v\n", sara.ID, err)
+	err = repo.Save(ctx, employee.Employee{Name: "Sara", Title: "Lead analyst", Salary: money.Of(5000, money.USD)})
+	found, _ = repo.GetByName(ctx, "Sara")
+	fmt.Printf("   saved without an ID, Sara is the Sara already there: %s, now %s%s\n", found.ID, found.Title, failure(err))
+	var ids []employee.ID
+	for emp := range employee.All(ctx, repo) {
+		ids = append(ids, emp.ID)
+	}
+	slices.Sort(ids)
+	fmt.Println("   two employees, two IDs:", ids)
+
+	fmt.Println("🔌 A backend without GetByID (LSP)")
+	found, err = employee.GetByID(ctx, listOnly{repo, repo}, "emp-2")
+	fmt.Println("   employee.GetByID looks through every employee instead: " + found.Name + failure(err))
+	_, err = employee.GetByID(ctx, listOnly{repo, repo}, "emp-9")
+	fmt.Println("   and an ID nobody has is not found:", err)
+}
+
+// failure shows err, if any, after a result.
+func failure(err error) string {
+	if err == nil {
+		return ""
+	}
+	return " - " + err.Error()
+}
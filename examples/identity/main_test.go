@@ -0,0 +1,113 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"go-solid/clock"
+	"go-solid/employee"
+	"go-solid/employee/memory"
+	"go-solid/id"
+	"go-solid/money"
+)
+
+var start = time.Date(2025, time.March, 3, 9, 0, 0, 0, time.UTC)
+
+func TestSequence(t *testing.T) {
+	seq := id.NewSequence("emp-")
+	for _, want := range []string{"emp-1", "emp-2", "emp-3"} {
+		if got := seq.NewID(); got != want {
+			t.Errorf("NewID() = %s, want %s", got, want)
+		}
+	}
+}
+
+func TestUUIDv7(t *testing.T) {
+	clk := clock.NewFake(start)
+	v7 := id.UUIDv7{Clock: clk}
+	a := v7.NewID()
+	clk.Advance(time.Millisecond)
+	b := v7.NewID()
+	if a[14] != '7' || a >= b {
+		t.Errorf("UUIDv7 %s then %s, want version 7 and the later one sorting later", a, b)
+	}
+	if millis := fmt.Sprintf("%012x", start.UnixMilli()); strings.ReplaceAll(a[:13], "-", "") != millis {
+		t.Errorf("UUIDv7 %s doesn't start with the Unix milliseconds %s", a, millis)
+	}
+}
+
+func TestULID(t *testing.T) {
+	clk := clock.NewFake(start)
+	ulid := id.ULID{Clock: clk}
+	c, same := ulid.NewID(), ulid.NewID()
+	clk.Advance(time.Millisecond)
+	d := ulid.NewID()
+	if len(c) != 26 || c >= d || strings.ContainsAny(c+d, "ILOU") {
+		t.Errorf("ULIDs %s then %s, want 26 characters of Crockford base 32 sorted by time", c, d)
+	}
+	if c[:10] != same[:10] || c[10:] == same[10:] {
+		t.Errorf("ULIDs %s and %s in one millisecond, want the same time and different randomness", c, same)
+	}
+}
+
+func TestRepository_KeyedByID(t *testing.T) {
+	ctx := t.Context()
+	repo := memory.New()
+	manager := employee.NewManager(repo, employee.WithIDs(id.NewSequence("emp-")))
+	ali, err := manager.AddEmployee(ctx, employee.Employee{Name: "Ali Khan", Title: "Engineer", Salary: money.Of(5000, money.USD)})
+	if err != nil || ali.ID != "emp-1" {
+		t.Fatalf("AddEmployee() = %s, %v; want emp-1", ali.ID, err)
+	}
+	found, err := repo.GetByID(ctx, "emp-1")
+	if err != nil || found.Name != "Ali Khan" {
+		t.Fatalf("GetByID() = %q, %v; want Ali Khan", found.Name, err)
+	}
+	found.Name = "Ali Rahman"
+	if err := repo.Save(ctx, found); err != nil {
+		t.Fatalf("Save() renamed error = %v", err)
+	}
+	if found, err := repo.GetByID(ctx, "emp-1"); err != nil || found.Name != "Ali Rahman" {
+		t.Errorf("GetByID() after the rename = %q, %v; want Ali Rahman", found.Name, err)
+	}
+	if _, err := repo.GetByName(ctx, "Ali Khan"); !errors.Is(err, employee.ErrNotFound) {
+		t.Errorf("GetByName(old name) error = %v, want %v", err, employee.ErrNotFound)
+	}
+	if found, err := repo.GetByName(ctx, "ali rahman"); err != nil || found.ID != "emp-1" {
+		t.Errorf("GetByName(new name) = %s, %v; want emp-1", found.ID, err)
+	}
+	if history, err := repo.History(ctx, "Ali Rahman"); err != nil || len(history) != 2 || history[0].Name != "Ali Khan" {
+		t.Errorf("History() = %+v, %v; want two versions, from Ali Khan", history, err)
+	}
+
+	sara, _ := manager.AddEmployee(ctx, employee.Employee{Name: "Sara", Title: "Analyst", Salary: money.Of(4500, money.USD)})
+	sara.Name = "Ali Rahman"
+	if err := repo.Save(ctx, sara); !errors.Is(err, employee.ErrNameTaken) {
+		t.Errorf("Save() under Ali's name error = %v, want %v", err, employee.ErrNameTaken)
+	}
+	if err := repo.Save(ctx, employee.Employee{Name: "Sara", Title: "Lead analyst"}); err != nil {
+		t.Fatal(err)
+	}
+	if found, _ := repo.GetByName(ctx, "Sara"); found.ID != "emp-2" || found.Title != "Lead analyst" {
+		t.Errorf("Sara saved without an ID = %s %s, want emp-2 as Lead analyst", found.ID, found.Title)
+	}
+	var ids []employee.ID
+	for emp := range employee.All(ctx, repo) {
+		ids = append(ids, emp.ID)
+	}
+	slices.Sort(ids)
+	if !slices.Equal(ids, []employee.ID{"emp-1", "emp-2"}) {
+		t.Errorf("IDs = %v, want emp-1 and emp-2", ids)
+	}
+
+	lists := listOnly{repo, repo}
+	if found, err := employee.GetByID(ctx, lists, "emp-2"); err != nil || found.Name != "Sara" {
+		t.Errorf("employee.GetByID() without the capability = %q, %v; want Sara", found.Name, err)
+	}
+	if _, err := employee.GetByID(ctx, lists, "emp-9"); !errors.Is(err, employee.ErrNotFound) {
+		t.Errorf("employee.GetByID(emp-9) error = %v, want %v", err, employee.ErrNotFound)
+	}
+}
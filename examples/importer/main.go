@@ -0,0 +1,107 @@
+package main
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"go-solid/clock"
+	"go-solid/employee/memory"
+	"go-solid/id"
+	"go-solid/importer"
+	"go-solid/money"
+)
+
+const staffCSV = `Name,Title,Salary,Hired_At
+Mohamed,Engineer,5000,2024-03-01
+Ahmed,,6000,
+,Designer,4500,2023-01-10
+Sara,Manager,lots,2022-06-15
+Omar,Engineer,-100,2021-09-01
+Laila,Analyst,5200,01/02/2020
+"Youssef,Engineer,5100,2020-02-02
+`
+
+func main() {
+	ctx := context.Background()
+	repo := memory.New()
+
+	// ✅ Parsing, validating and persisting are three collaborators
+	rules := importer.Rules{
+		IDs:      id.NewSequence("emp-"),
+		Clock:    clock.NewFake(time.Date(2026, time.March, 2, 9, 0, 0, 0, time.UTC)),
+		Currency: money.USD,
+	}
+	imp := importer.New(repo, rules)
+
+	fmt.Println("📄 CSV import")
+	report, err := imp.Import(ctx, importer.NewCSV(strings.NewReader(staffCSV)))
+	show(report, err)
+
+	fmt.Println()
+	fmt.Println("📊 XLSX import - same importer, different RecordSource")
+	book := workbook([][]string{
+		{"name", "salary", "currency", "hired_at"},
+		{"Hana", "7000", "EUR", "45292"}, // dates are serial numbers in a spreadsheet
+		{"Karim", "", "EUR", "45292"},
+	})
+	report, err = imp.Import(ctx, importer.NewXLSX(bytes.NewReader(book), int64(len(book))))
+	show(report, err)
+
+	fmt.Println()
+	for _, name := range []string{"Mohamed", "Ahmed", "Hana"} {
+		emp, _ := repo.GetByName(ctx, name)
+		fmt.Printf("   💾 %s %s salary %s hired %s\n", emp.ID, emp.Name, emp.Salary, emp.HiredAt.Format(time.DateOnly))
+	}
+	_, err = repo.GetByName(ctx, "Sara")
+	fmt.Println("   💾 Sara:", err, "(rejected rows never reach the repository)")
+}
+
+func show(report importer.Report, err error) {
+	fmt.Printf("   read %d, imported %d, rejected %d\n", report.Read, report.Imported, len(report.Errors))
+	for _, re := range report.Errors {
+		fmt.Println("   ❌", re)
+	}
+	if err != nil {
+		fmt.Println("   💥", err)
+	}
+}
+
+// workbook builds a minimal .xlsx in memory; a real one comes from a file.
+func workbook(rows [][]string) []byte {
+	var sheet strings.Builder
+	sheet.WriteString(`<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>`)
+	for i, row := range rows {
+		fmt.Fprintf(&sheet, `<row r="%d">`, i+1)
+		for j, v := range row {
+			ref := fmt.Sprintf("%c%d", 'A'+j, i+1)
+			switch {
+			case v == "":
+			case strings.Trim(v, "0123456789") == "":
+				fmt.Fprintf(&sheet, `<c r="%s"><v>%s</v></c>`, ref, v)
+			default:
+				fmt.Fprintf(&sheet, `<c r="%s" t="inlineStr"><is><t>%s</t></is></c>`, ref, v)
+			}
+		}
+		sheet.WriteString(`</row>`)
+	}
+	sheet.WriteString(`</sheetData></worksheet>`)
+
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for name, body := range map[string]string{
+		"xl/workbook.xml": `<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">` +
+			`<sheets><sheet name="Staff" sheetId="1" r:id="rId1"/></sheets></workbook>`,
+		"xl/_rels/workbook.xml.rels": `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+			`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/></Relationships>`,
+		"xl/worksheets/sheet1.xml": sheet.String(),
+	} {
+		w, _ := zw.Create(name)
+		_, _ = w.Write([]byte(body))
+	}
+	_ = zw.Close()
+	return buf.Bytes()
+}
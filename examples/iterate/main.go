@@ -0,0 +1,171 @@
+// Command iterate consumes employee.All, an iter.Seq2[Employee, error], with
+// Go 1.23's range-over-func: a plain loop, an early break and the cleanup it
+// triggers, an error partway through, the fallback for backends that can only
+// page, and iter.Pull2 for two sequences at once. main_test.go checks every
+// claim.
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"iter"
+
+	"go-solid/employee"
+	"go-solid/employee/memory"
+	"go-solid/money"
+)
+
+// cursor An Iterable backend that reports what a database cursor would do:
+// open, yield rows, and close - on every way out of the loop
+type cursor struct {
+	employee.Repository
+	emps   []employee.Employee
+	failAt int // yield an error instead of this row; -1 never
+	opened int
+	closed int
+}
+
+func (c *cursor) All(ctx context.Context) iter.Seq2[employee.Employee, error] {
+	return func(yield func(employee.Employee, error) bool) {
+		c.opened++
+		defer func() { c.closed++ }() // ✅ runs on break, return, panic and the end of the rows
+		for i, emp := range c.emps {
+			if i == c.failAt {
+				yield(employee.Employee{}, errors.New("connection lost"))
+				return
+			}
+			if !yield(emp, nil) {
+				return // ✅ the loop body broke out: stop, the defer closes
+			}
+		}
+	}
+}
+
+// careless ❌ ignores what yield returns and carries on after a break
+func careless(yield func(employee.Employee, error) bool) {
+	for _, name := range []string{"Alice", "Bob"} {
+		yield(employee.Employee{Name: name}, nil)
+	}
+}
+
+// pager Only the QueryRepository capability: employee.All pages through it
+type pager struct {
+	employee.Repository
+	q     employee.QueryRepository
+	pages int
+}
+
+func (p *pager) List(ctx context.Context, f employee.Filter, page employee.Page) (employee.PageResult, error) {
+	p.pages++
+	page.Limit = 2
+	return p.q.List(ctx, f, page)
+}
+
+func main() {
+	ctx := context.Background()
+	repo := memory.New()
+	var staff []employee.Employee
+	for i, name := range []string{"Alice", "Bob", "Carol", "Dave", "Erin"} {
+		emp := employee.Employee{Name: name, Salary: money.Of(int64(4000+500*i), money.USD)}
+		staff = append(staff, emp)
+		_ = repo.Save(ctx, emp)
+	}
+
+	fmt.Println("🔁 range over a function")
+	var names []string
+	for emp, err := range employee.All(ctx, repo) {
+		if err != nil { // ✅ the error comes first: emp is zero when it is set
+			fmt.Println("   ❌", err)
+			break
+		}
+		names = append(names, emp.Name)
+	}
+	fmt.Println("   memory yields every employee, by name:", names)
+
+	fmt.Println("🛑 Early termination and cleanup")
+	c := &cursor{Repository: repo, emps: staff, failAt: -1}
+	seen := 0
+	for emp := range employee.All(ctx, c) {
+		seen++
+		if emp.Name == "Bob" {
+			break // yield returns false; All returns; its defer closes the cursor
+		}
+	}
+	fmt.Printf("   break after Bob: %d rows read, cursor opened %d, closed %d\n", seen, c.opened, c.closed)
+
+	func() {
+		defer func() { _ = recover() }()
+		for range employee.All(ctx, c) {
+			panic("the loop body panics")
+		}
+	}()
+	fmt.Printf("   a panic in the loop body still closes the cursor: opened %d, closed %d\n", c.opened, c.closed)
+
+	first := func() string {
+		for emp := range employee.All(ctx, c) {
+			return emp.Name // returning from inside the loop stops it too
+		}
+		return ""
+	}()
+	fmt.Printf("   returning %s from inside the loop closes it as well: opened %d, closed %d\n", first, c.opened, c.closed)
+
+	fmt.Println("💥 An error partway through")
+	c = &cursor{Repository: repo, emps: staff, failAt: 3}
+	var read []string
+	var err error
+	for emp, e := range employee.All(ctx, c) {
+		if e != nil {
+			err = e
+			break
+		}
+		read = append(read, emp.Name)
+	}
+	fmt.Printf("   %d employees, then %q; the cursor is closed: %v\n", len(read), err, c.closed == 1)
+
+	fmt.Println("📄 Backends that can only page")
+	p := &pager{Repository: repo, q: repo}
+	count := 0
+	for _, err := range employee.All(ctx, p) {
+		if err == nil {
+			count++
+		}
+	}
+	fmt.Printf("   All falls back to List: %d employees in %d pages\n", count, p.pages)
+	for _, err := range employee.All(ctx, employee.NopRepository{}) {
+		fmt.Println("   a backend with neither capability yields", err)
+	}
+
+	fmt.Println("🤝 Two sequences at once with iter.Pull2")
+	next, stop := iter.Pull2(employee.All(ctx, repo))
+	defer stop() // ✅ a pulled iterator is cleaned up by stop, not by a loop ending
+	var pairs []string
+	for emp, err := range employee.All(ctx, c) {
+		if err != nil {
+			break
+		}
+		other, _, ok := next()
+		if !ok {
+			break
+		}
+		pairs = append(pairs, emp.Name+"="+other.Name)
+	}
+	fmt.Println("   the failing cursor and memory zipped until the first error:", pairs)
+
+	fmt.Println("⚠️  An iterator must stop when yield says so")
+	fmt.Println("   ❌ careless keeps yielding after a break, and Go panics:", breakOut(careless))
+}
+
+// breakOut breaks out of a loop over seq at once, and returns the panic
+// that ends in, if any, as an error.
+func breakOut(seq iter.Seq2[employee.Employee, error]) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("%v", r)
+		}
+	}()
+	for range seq {
+		break
+	}
+	return nil
+}
@@ -0,0 +1,134 @@
+package main
+
+import (
+	"errors"
+	"iter"
+	"slices"
+	"testing"
+
+	"go-solid/employee"
+	"go-solid/employee/memory"
+	"go-solid/money"
+)
+
+func staff(t *testing.T) (*memory.Repository, []employee.Employee) {
+	t.Helper()
+	repo := memory.New()
+	var emps []employee.Employee
+	for i, name := range []string{"Alice", "Bob", "Carol", "Dave", "Erin"} {
+		emp := employee.Employee{Name: name, Salary: money.Of(int64(4000+500*i), money.USD)}
+		emps = append(emps, emp)
+		if err := repo.Save(t.Context(), emp); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return repo, emps
+}
+
+func names(t *testing.T, seq iter.Seq2[employee.Employee, error]) ([]string, error) {
+	t.Helper()
+	var out []string
+	for emp, err := range seq {
+		if err != nil {
+			return out, err
+		}
+		out = append(out, emp.Name)
+	}
+	return out, nil
+}
+
+func TestAll_Memory(t *testing.T) {
+	repo, _ := staff(t)
+	got, err := names(t, employee.All(t.Context(), repo))
+	if err != nil || !slices.Equal(got, []string{"Alice", "Bob", "Carol", "Dave", "Erin"}) {
+		t.Errorf("All() = %v, %v; want everyone by name", got, err)
+	}
+}
+
+func TestAll_ClosesTheCursorOnEveryWayOut(t *testing.T) {
+	repo, emps := staff(t)
+	c := &cursor{Repository: repo, emps: emps, failAt: -1}
+	ctx := t.Context()
+
+	seen := 0
+	for emp := range employee.All(ctx, c) {
+		seen++
+		if emp.Name == "Bob" {
+			break
+		}
+	}
+	if seen != 2 || c.closed != 1 {
+		t.Errorf("break after Bob: %d rows read, closed %d; want 2 read and the cursor closed", seen, c.closed)
+	}
+
+	func() {
+		defer func() { _ = recover() }()
+		for range employee.All(ctx, c) {
+			panic("the loop body panics")
+		}
+	}()
+	if c.closed != 2 {
+		t.Errorf("after a panic in the loop body closed %d of %d", c.closed, c.opened)
+	}
+
+	func() {
+		for range employee.All(ctx, c) {
+			return
+		}
+	}()
+	if c.closed != 3 || c.opened != 3 {
+		t.Errorf("after returning from the loop closed %d of %d", c.closed, c.opened)
+	}
+}
+
+func TestAll_ErrorPartway(t *testing.T) {
+	repo, emps := staff(t)
+	c := &cursor{Repository: repo, emps: emps, failAt: 3}
+	got, err := names(t, employee.All(t.Context(), c))
+	if err == nil || len(got) != 3 || c.closed != 1 {
+		t.Errorf("All() = %v, %v, closed %d; want 3 employees, then the error, and the cursor closed", got, err, c.closed)
+	}
+}
+
+func TestAll_FallsBackToList(t *testing.T) {
+	repo, _ := staff(t)
+	p := &pager{Repository: repo, q: repo}
+	got, err := names(t, employee.All(t.Context(), p))
+	if err != nil || len(got) != 5 || p.pages != 3 {
+		t.Errorf("All() = %v, %v in %d pages; want 5 employees in 3 pages of 2", got, err, p.pages)
+	}
+	if _, err := names(t, employee.All(t.Context(), employee.NopRepository{})); !errors.Is(err, errors.ErrUnsupported) {
+		t.Errorf("All() of a backend without either capability error = %v, want %v", err, errors.ErrUnsupported)
+	}
+}
+
+func TestPull2_Zips(t *testing.T) {
+	repo, emps := staff(t)
+	c := &cursor{Repository: repo, emps: emps, failAt: 3}
+	next, stop := iter.Pull2(employee.All(t.Context(), repo))
+	defer stop()
+	var pairs []string
+	for emp, err := range employee.All(t.Context(), c) {
+		if err != nil {
+			break
+		}
+		other, _, ok := next()
+		if !ok {
+			break
+		}
+		pairs = append(pairs, emp.Name+"="+other.Name)
+	}
+	if !slices.Equal(pairs, []string{"Alice=Alice", "Bob=Bob", "Carol=Carol"}) {
+		t.Errorf("zipped %v, want the first three until the cursor failed", pairs)
+	}
+}
+
+func TestCareless_PanicsAfterABreak(t *testing.T) {
+	if err := breakOut(careless); err == nil {
+		t.Error("breakOut(careless) = nil, want Go's panic for yielding after a break")
+	}
+	repo, _ := staff(t)
+	if err := breakOut(employee.All(t.Context(), repo)); err != nil {
+		t.Errorf("breakOut(All) = %v, want a well-behaved iterator stopping", err)
+	}
+}
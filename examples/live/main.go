@@ -0,0 +1,158 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"time"
+
+	"go-solid/clock"
+	"go-solid/employee"
+	"go-solid/employee/memory"
+	"go-solid/events"
+	"go-solid/live"
+	"go-solid/money"
+	"go-solid/payroll"
+)
+
+// watcher A bare websocket client, standing in for the browser page
+type watcher struct {
+	conn net.Conn
+	r    *bufio.Reader
+}
+
+func watch(server *httptest.Server, query string) (*watcher, error) {
+	conn, err := net.Dial("tcp", strings.TrimPrefix(server.URL, "http://"))
+	if err != nil {
+		return nil, err
+	}
+	fmt.Fprintf(conn, "GET /live/events%s HTTP/1.1\r\nHost: example\r\nUpgrade: websocket\r\nConnection: Upgrade\r\n"+
+		"Sec-WebSocket-Key: dGhlIHNhbXBsZSBub25jZQ==\r\nSec-WebSocket-Version: 13\r\n\r\n", query)
+	r := bufio.NewReader(conn)
+	resp, err := http.ReadResponse(r, nil)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusSwitchingProtocols {
+		return nil, fmt.Errorf("handshake: %s", resp.Status)
+	}
+	return &watcher{conn: conn, r: r}, nil
+}
+
+// next reads one frame: a message, or the reason the server closed.
+func (w *watcher) next() (live.Message, string, error) {
+	var header [2]byte
+	if _, err := io.ReadFull(w.r, header[:]); err != nil {
+		return live.Message{}, "", err
+	}
+	length := int(header[1] & 0x7F)
+	if length == 126 {
+		var ext [2]byte
+		_, _ = io.ReadFull(w.r, ext[:])
+		length = int(binary.BigEndian.Uint16(ext[:]))
+	}
+	payload := make([]byte, length)
+	if _, err := io.ReadFull(w.r, payload); err != nil {
+		return live.Message{}, "", err
+	}
+	if header[0]&0x0F == 0x8 {
+		return live.Message{}, fmt.Sprintf("closed %d %s", binary.BigEndian.Uint16(payload), payload[2:]), nil
+	}
+	var m struct {
+		Event string          `json:"event"`
+		Data  json.RawMessage `json:"data"`
+	}
+	_ = json.Unmarshal(payload, &m)
+	return live.Message{Event: m.Event}, string(m.Data), nil
+}
+
+// changes How many salary changes the burst makes
+const changes = 1000
+
+func main() {
+	ctx := context.Background()
+	clk := clock.NewFake(time.Date(2026, 3, 31, 17, 0, 0, 0, time.UTC))
+
+	// ✅ The feed is one more handler on the bus; nothing raising events changes
+	bus := events.NewBus()
+	feed := live.NewSubscriber(live.WithBuffer(4))
+	bus.SubscribeAll(feed)
+
+	repo := memory.New()
+	manager := employee.NewManager(repo, employee.WithEvents(bus), employee.WithClock(clk))
+	mux := http.NewServeMux()
+	mux.Handle("GET /live/", http.StripPrefix("/live", live.Page()))
+	mux.Handle("GET /live/events", feed)
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	everything, err := watch(server, "")
+	if err != nil {
+		fmt.Println("❌", err)
+		return
+	}
+	payday, _ := watch(server, "?event=payroll.paid")
+	defer everything.conn.Close()
+	defer payday.conn.Close()
+	for feed.Clients() < 2 {
+		time.Sleep(time.Millisecond)
+	}
+	fmt.Printf("📡 %d clients connected; the page is at %s/live/\n", feed.Clients(), server.URL)
+
+	_, _ = manager.AddEmployee(ctx, employee.Employee{Name: "Alice", Title: "Engineer", Salary: money.Of(5000, money.USD)})
+	_, _ = manager.AddEmployee(ctx, employee.Employee{Name: "Bob", Title: "Analyst", Salary: money.Of(4000, money.USD)})
+	_, _ = manager.Promote(ctx, "Alice", "Senior Engineer", money.Of(1000, money.USD))
+
+	// ✅ Payroll raises "paid" through a decorator; the engine doesn't know either
+	runner := payroll.NewPublisher(payroll.New(payroll.Config{"US": {payroll.Pension{Rate: "0.05"}}}), bus, clk)
+	staff := payroll.Staff{Repo: repo, CountryOf: func(employee.Employee) string { return "US" }}
+	if _, err := runner.Run(ctx, payroll.Period{Year: 2026, Month: time.March}, staff); err != nil {
+		fmt.Println("❌", err)
+	}
+
+	fmt.Println("\n👀 Watching everything")
+	for range 5 {
+		m, data, _ := everything.next()
+		fmt.Printf("   %-18s %s\n", m.Event, data)
+	}
+	fmt.Println("\n💸 Watching payroll.paid only")
+	for range 2 {
+		m, data, _ := payday.next()
+		fmt.Printf("   %-18s %s\n", m.Event, data)
+	}
+
+	// ❌ A client that can't keep up would hold up every use case if Handle
+	// waited for it. A client more than four messages behind is disconnected
+	// instead - here the one watching salaries, and the one watching
+	// everything, which has stopped reading - and the manager never slows down.
+	fmt.Println("\n🐢 A burst of salary changes, faster than the feed can write them")
+	salaries, _ := watch(server, "?event=employee.salary_changed")
+	defer salaries.conn.Close()
+	for feed.Clients() < 3 {
+		time.Sleep(time.Millisecond)
+	}
+	start := time.Now()
+	for i := range changes {
+		_, _ = manager.ChangeSalary(ctx, "Bob", money.Of(int64(4001+i), money.USD))
+	}
+	fmt.Printf("   %d salary changes took %s\n", changes, time.Since(start).Round(time.Millisecond))
+	for {
+		_, closed, err := salaries.next()
+		if err != nil {
+			break
+		}
+		if strings.HasPrefix(closed, "closed") {
+			fmt.Printf("   the salary watcher got the first few, then the server %s\n", closed)
+			break
+		}
+	}
+	fmt.Printf("   %d client still connected, the payroll watcher\n", feed.Clients())
+	_ = feed.Stop(ctx)
+}
@@ -0,0 +1,96 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"go-solid/mutate"
+)
+
+const goMod = "module raises\n\ngo 1.25\n"
+
+// The code under test: a raise policy with a boundary and a cap
+const raise = `package raises
+
+// Raise is 10% for ratings of 4 and up, 5% for 3, nothing below;
+// never more than 1000.
+func Raise(salary, rating int) int {
+	raise := 0
+	if rating >= 4 {
+		raise = salary / 10
+	} else if rating == 3 {
+		raise = salary / 20
+	}
+	if raise > 1000 {
+		raise = 1000
+	}
+	return raise
+}
+`
+
+// ❌ Only the happy path: every line runs, little is pinned down
+const weakTest = `package raises
+
+import "testing"
+
+func TestRaise(t *testing.T) {
+	if Raise(5000, 5) <= 0 {
+		t.Fatal("a top rating earns a raise")
+	}
+}
+`
+
+// ✅ Exact values at each boundary and at the cap
+const strongTest = `package raises
+
+import "testing"
+
+func TestRaise(t *testing.T) {
+	cases := []struct{ salary, rating, want int }{
+		{5000, 5, 500},
+		{5000, 4, 500},
+		{5000, 3, 250},
+		{5000, 2, 0},
+		{20000, 4, 1000},
+		{10000, 4, 1000},
+		{10010, 4, 1000},
+		{9990, 4, 999},
+	}
+	for _, c := range cases {
+		if got := Raise(c.salary, c.rating); got != c.want {
+			t.Errorf("Raise(%d, %d) = %d, want %d", c.salary, c.rating, got, c.want)
+		}
+	}
+}
+`
+
+func main() {
+	for _, suite := range []struct{ name, test string }{{"weak tests", weakTest}, {"strong tests", strongTest}} {
+		dir, err := os.MkdirTemp("", "mutate-")
+		if err != nil {
+			fmt.Println("❌", err)
+			return
+		}
+		defer os.RemoveAll(dir)
+		for name, content := range map[string]string{"go.mod": goMod, "raise.go": raise, "raise_test.go": suite.test} {
+			if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+				fmt.Println("❌", err)
+				return
+			}
+		}
+
+		report, err := mutate.Run(context.Background(), mutate.Options{Dir: dir}, "./...")
+		if err != nil {
+			fmt.Println("❌", err)
+			return
+		}
+		fmt.Printf("🧪 %s: mutation score %.0f%%\n", suite.name, 100*report.Score())
+		for _, r := range report.Results {
+			if r.Status == mutate.Survived {
+				fmt.Printf("   🧟 line %d: %s (%s)\n", r.Position.Line, r.Description, r.Mutator)
+			}
+		}
+	}
+}
@@ -0,0 +1,77 @@
+// Command names looks employees up by names written the way people type
+// them - another case, stray spaces, an accent typed as a separate mark -
+// and shows every layer keyed by name agreeing on who they mean, because
+// each compares names through the same normalize.Normalizer. main_test.go
+// holds every layer to it.
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"go-solid/employee"
+	"go-solid/employee/memory"
+	"go-solid/money"
+	"go-solid/normalize"
+	"go-solid/shard"
+)
+
+// spellings pairs of names and whether normalize.Name takes them for the
+// same person
+var spellings = []struct{ a, b string }{
+	{"Mohamed", "  MOHAMED "}, // case and spaces don't matter
+	{"José", "Jose\u0301"},    // é typed as e and a combining acute is é (NFC)
+	{"Jose", "José"},          // but an accent is not dropped
+	{"\u212aim", "kim"},       // the Kelvin sign K folds with k
+	{"Zoë  Ng", "zoë ng"},     // runs of spaces collapse
+	{"Mohamed", "Mohammed"},   // and a different spelling is someone else
+}
+
+func main() {
+	ctx := context.Background()
+
+	fmt.Println("🔤 One key per name")
+	for _, name := range []string{"Mohamed", "  MOHAMED ", "José", "Jose\u0301", "Zoë  Ng"} {
+		fmt.Printf("      %-16s → %q\n", fmt.Sprintf("%+q", name), normalize.Name.Normalize(name))
+	}
+	for _, s := range spellings {
+		fmt.Printf("   %+q and %+q are the same name: %v\n", s.a, s.b, normalize.Equal(normalize.Name, s.a, s.b))
+	}
+
+	fmt.Println("🗄️  The memory repository")
+	repo := memory.New()
+	manager := employee.NewManager(repo)
+	_, _ = manager.AddEmployee(ctx, employee.Employee{Name: "Mohamed", Title: "Engineer", Salary: money.Of(5000, money.USD)})
+	for _, name := range []string{"mohamed", "MOHAMED", " Mohamed"} {
+		emp, err := repo.GetByName(ctx, name)
+		fmt.Printf("   GetByName(%q) finds %s%s\n", name, emp.Name, failure(err))
+	}
+	_, _ = manager.ChangeSalary(ctx, "mohamed", money.Of(5500, money.USD))
+	history, _ := repo.History(ctx, "Mohamed")
+	fmt.Printf("   a raise for mohamed is a version of Mohamed, not a new employee: %d versions\n", len(history))
+	_, _ = manager.AddEmployee(ctx, employee.Employee{Name: "Jose\u0301", Title: "Analyst", Salary: money.Of(4500, money.USD)})
+	emp, err := repo.GetByName(ctx, "josé")
+	fmt.Printf("   José, hired with a combining accent, is found typed with é: the %s%s\n", emp.Title, failure(err))
+
+	fmt.Println("📏 As written, for a backend that compared names that way")
+	exact := memory.New(memory.WithNormalizer(normalize.Exact))
+	_ = exact.Save(ctx, employee.Employee{Name: "Mohamed", Salary: money.Of(5000, money.USD)})
+	_, err = exact.GetByName(ctx, "mohamed")
+	fmt.Println("   normalize.Exact: mohamed is someone else -", err)
+
+	fmt.Println("🧩 Sharded: the same key, the same shard")
+	sharded := shard.New([]employee.Repository{memory.New(), memory.New(), memory.New(), memory.New()})
+	_ = sharded.Save(ctx, employee.Employee{Name: "Mohamed", Salary: money.Of(5000, money.USD)})
+	for _, name := range []string{"Mohamed", "mohamed", "MOHAMED", " Mohamed "} {
+		_, err := sharded.GetByName(ctx, name)
+		fmt.Printf("   %-11q routes to shard %d%s\n", name, sharded.ShardOf(name), failure(err))
+	}
+}
+
+// failure shows err, if any, after a result.
+func failure(err error) string {
+	if err == nil {
+		return ""
+	}
+	return " - " + err.Error()
+}
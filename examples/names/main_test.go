@@ -0,0 +1,117 @@
+package main
+
+import (
+	"database/sql"
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"go-solid/employee"
+	"go-solid/employee/memory"
+	"go-solid/employee/sqlrepo"
+	"go-solid/money"
+	"go-solid/normalize"
+	"go-solid/shard"
+	"go-solid/sqldialect"
+
+	_ "modernc.org/sqlite"
+)
+
+func TestSpellings(t *testing.T) {
+	want := []bool{true, true, false, true, true, false}
+	for i, s := range spellings {
+		if got := normalize.Equal(normalize.Name, s.a, s.b); got != want[i] {
+			t.Errorf("Equal(%+q, %+q) = %v, want %v", s.a, s.b, got, want[i])
+		}
+	}
+}
+
+func TestNormalize_AllocatesNothingForANormalizedName(t *testing.T) {
+	if allocs := testing.AllocsPerRun(100, func() { normalize.Name.Normalize("mohamed") }); allocs != 0 {
+		t.Errorf("Normalize() of a normalized name = %.0f allocations, want 0", allocs)
+	}
+}
+
+func TestMemory_FindsEverySpelling(t *testing.T) {
+	ctx := t.Context()
+	repo := memory.New()
+	manager := employee.NewManager(repo)
+	if _, err := manager.AddEmployee(ctx, employee.Employee{Name: "Mohamed", Title: "Engineer", Salary: money.Of(5000, money.USD)}); err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"mohamed", "MOHAMED", " Mohamed"} {
+		if emp, err := repo.GetByName(ctx, name); err != nil || emp.Name != "Mohamed" {
+			t.Errorf("GetByName(%q) = %q, %v; want Mohamed", name, emp.Name, err)
+		}
+	}
+	if _, err := manager.ChangeSalary(ctx, "mohamed", money.Of(5500, money.USD)); err != nil {
+		t.Fatal(err)
+	}
+	history, err := repo.History(ctx, "Mohamed")
+	if err != nil || len(history) != 2 || history[1].Salary != money.Of(5500, money.USD) {
+		t.Errorf("History() = %+v, %v; want the raise as Mohamed's second version", history, err)
+	}
+	if _, err := manager.AddEmployee(ctx, employee.Employee{Name: "Jose\u0301", Title: "Analyst", Salary: money.Of(4500, money.USD)}); err != nil {
+		t.Fatal(err)
+	}
+	if emp, err := repo.GetByName(ctx, "josé"); err != nil || emp.Title != "Analyst" {
+		t.Errorf("GetByName(josé) = %+v, %v; want the Analyst hired with a combining accent", emp, err)
+	}
+}
+
+func TestMemory_Exact(t *testing.T) {
+	exact := memory.New(memory.WithNormalizer(normalize.Exact))
+	if err := exact.Save(t.Context(), employee.Employee{Name: "Mohamed"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := exact.GetByName(t.Context(), "mohamed"); !errors.Is(err, employee.ErrNotFound) {
+		t.Errorf("GetByName(mohamed) error = %v, want %v", err, employee.ErrNotFound)
+	}
+}
+
+func TestShard_RoutesEverySpellingTogether(t *testing.T) {
+	sharded := shard.New([]employee.Repository{memory.New(), memory.New(), memory.New(), memory.New()})
+	if err := sharded.Save(t.Context(), employee.Employee{Name: "Mohamed"}); err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"mohamed", "MOHAMED", " Mohamed "} {
+		if got, want := sharded.ShardOf(name), sharded.ShardOf("Mohamed"); got != want {
+			t.Errorf("ShardOf(%q) = %d, want %d", name, got, want)
+		}
+		if _, err := sharded.GetByName(t.Context(), name); err != nil {
+			t.Errorf("GetByName(%q) error = %v", name, err)
+		}
+	}
+}
+
+// a specification compares names the way the repository it runs in keys them,
+// in memory and in SQL alike
+func TestMatching_UsesTheRepositoryNormalizer(t *testing.T) {
+	db, err := sql.Open("sqlite", filepath.Join(t.TempDir(), "names.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	if _, err := db.ExecContext(t.Context(), sqlrepo.Schema); err != nil {
+		t.Fatal(err)
+	}
+	// hyphens don't count: Al-Amin is keyed alamin
+	dashless := normalize.Chain{normalize.Name, normalize.Func(func(s string) string { return strings.ReplaceAll(s, "-", "") })}
+	repos := map[string]employee.Repository{
+		"memory":  memory.New(memory.WithNormalizer(dashless)),
+		"sqlrepo": sqlrepo.New(db, sqldialect.SQLite{}, sqlrepo.WithNormalizer(dashless)),
+	}
+	for name, repo := range repos {
+		if err := repo.Save(t.Context(), employee.Employee{Name: "Al-Amin", Salary: money.Of(5000, money.USD)}); err != nil {
+			t.Fatal(err)
+		}
+		m := repo.(employee.SpecificationRepository)
+		for _, prefix := range []string{"alam", "Al-A", "ala"} {
+			got, err := m.Matching(t.Context(), employee.NameStartsWith(prefix))
+			if err != nil || len(got) != 1 {
+				t.Errorf("%s: Matching(NameStartsWith(%q)) = %d employees, %v; want Al-Amin", name, prefix, len(got), err)
+			}
+		}
+	}
+}
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+
+	"go-solid/employee"
+	"go-solid/employee/memory"
+	"go-solid/events"
+	"go-solid/money"
+	"go-solid/notify"
+	"go-solid/nullobj"
+)
+
+//////////--------------------Bad Practice--------------------/////////////////////////
+
+// ❌ Optional collaborators are nil, so every call site has to remember a nil check
+//func (m *Manager) AddEmployee(emp Employee) error {
+//	if err := m.repo.Save(emp); err != nil {
+//		return err
+//	}
+//	if m.audit != nil { // ❌ forget this once and it panics in production
+//		m.audit.Write(...)
+//	}
+//	if m.notifier != nil {
+//		m.notifier.Notify(...)
+//	}
+//	if m.logger != nil {
+//		m.logger.Info(...)
+//	}
+//	return nil
+//}
+
+//////////////-----------------------------Good Practice-------------------/////////////////////////////////////////////////////////
+
+func hireAlice(manager *employee.Manager) {
+	_, err := manager.AddEmployee(context.Background(), employee.Employee{Name: "Alice", Salary: money.Of(3000, money.USD)})
+	fmt.Println("   hire Alice, err =", err)
+}
+
+func main() {
+	// ✅ Nothing configured: audit, events and logging default to null objects - no nil checks anywhere
+	fmt.Println("🔇 Defaults only")
+	hireAlice(employee.NewManager(memory.New()))
+
+	// ✅ Real collaborators replace the null objects without any code change in the manager
+	fmt.Println("🔊 Real notifier and logger")
+	bus := events.NewBus()
+	bus.SubscribeAll(employee.Notifications(notify.NewConsole(os.Stdout)))
+	hireAlice(employee.NewManager(memory.New(),
+		employee.WithEvents(bus),
+		employee.WithLogger(slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{
+			ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+				if a.Key == slog.TimeKey {
+					return slog.Attr{}
+				}
+				return a
+			},
+		}))),
+	))
+
+	// ✅ ...and switching a notification off is just another substitution
+	fmt.Println("🔕 Notifications muted with NopNotifier")
+	muted := events.NewBus()
+	muted.SubscribeAll(employee.Notifications(nullobj.NopNotifier{}))
+	hireAlice(employee.NewManager(memory.New(), employee.WithEvents(muted)))
+
+	// ⚠️ A null object must still honour the contract. NopRepository "successfully" saves
+	// nothing, so a later lookup reports ErrNotFound - fine for a dry run, surprising if you
+	// expected persistence. Null Objects fit *optional* collaborators best.
+	fmt.Println("🕳️  NopRepository (nil repository)")
+	manager := employee.NewManager(nil)
+	hireAlice(manager)
+	_, err := manager.FindEmployee(context.Background(), "Alice")
+	fmt.Println("   find Alice, not found =", errors.Is(err, employee.ErrNotFound))
+}
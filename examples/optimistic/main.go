@@ -0,0 +1,169 @@
+// Command optimistic shows the lost update that Save allows, and how
+// employee.Updater prevents it: a write that only lands if nobody wrote
+// since the read. It runs the Updater contract against every implementation
+// in the tree, and against one that bends it, and has concurrent raises go
+// through the Manager's retry loop. main_test.go checks every claim.
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"go-solid/employee"
+	"go-solid/employee/memory"
+	"go-solid/money"
+	"go-solid/replica"
+	"go-solid/shard"
+)
+
+// lenient ❌ An Updater that stores whatever it is given: it compiles, and
+// breaks the contract callers rely on
+type lenient struct{ *memory.Repository }
+
+func (l lenient) Update(ctx context.Context, emp employee.Employee, _ int) (employee.Employee, error) {
+	if err := l.Save(ctx, emp); err != nil {
+		return employee.Employee{}, err
+	}
+	return l.GetByName(ctx, emp.Name)
+}
+
+// slow Reads take a moment, so concurrent read-modify-writes interleave
+type slow struct{ *memory.Repository }
+
+func (s slow) GetByName(ctx context.Context, name string) (employee.Employee, error) {
+	emp, err := s.Repository.GetByName(ctx, name)
+	time.Sleep(time.Millisecond)
+	return emp, err
+}
+
+// updater is what the contract is run against.
+type updater interface {
+	employee.Repository
+	employee.Updater
+}
+
+// contract checks each clause of employee.Updater's contract and returns the
+// clauses repo breaks.
+func contract(repo updater) []string {
+	ctx := context.Background()
+	var broken []string
+	clause := func(what string, ok bool) {
+		if !ok {
+			broken = append(broken, what)
+		}
+	}
+	carol := employee.Employee{Name: "Carol", Salary: money.Of(4000, money.USD)}
+	got, err := repo.Update(ctx, carol, 0)
+	clause("version 0 creates an absent employee at version 1", err == nil && got.Version == 1)
+	_, err = repo.Update(ctx, carol, 0)
+	clause("version 0 conflicts with an existing employee", errors.Is(err, employee.ErrConflict))
+	carol.Salary = money.Of(4500, money.USD)
+	got, err = repo.Update(ctx, carol, 1)
+	clause("the expected version updates, to the next version", err == nil && got.Version == 2)
+	carol.Salary = money.Of(9999, money.USD)
+	carol.Version = 2 // ignored: the expected version decides
+	_, err = repo.Update(ctx, carol, 1)
+	var conflict *employee.ConflictError
+	clause("a stale version conflicts, telling the version found", errors.As(err, &conflict) && conflict.Actual == 2)
+	stored, _ := repo.GetByName(ctx, "Carol")
+	clause("a conflict stores nothing", stored.Salary == money.Of(4500, money.USD))
+	_, err = repo.Update(ctx, employee.Employee{Name: "Nobody"}, 3)
+	clause("a version > 0 for an absent employee is ErrNotFound, not a conflict", errors.Is(err, employee.ErrNotFound))
+	return broken
+}
+
+var alice = employee.Employee{Name: "Alice", Title: "Engineer", Salary: money.Of(4000, money.USD)}
+
+// implementations are the Updaters the contract is run against
+var implementations = []struct {
+	name string
+	repo func() updater
+}{
+	{"memory.Repository", func() updater { return memory.New() }},
+	{"shard.ShardedRepository over memory", func() updater { return shard.New([]employee.Repository{memory.New(), memory.New()}) }},
+	{"replica.ReadWriteSplitter over memory", func() updater { return replica.New(memory.New(), nil) }},
+	{"lenient", func() updater { return lenient{memory.New()} }},
+}
+
+// raise has 20 goroutines each give Alice a raise of 100 through a
+// Manager trying attempts times, and returns how many were stored, how many
+// conflicted, and her salary after.
+func raise(ctx context.Context, attempts int) (ok, conflicts int, salary money.Money) {
+	backend := slow{memory.New()}
+	_ = backend.Save(ctx, alice)
+	manager := employee.NewManager(backend, employee.WithConflictRetries(attempts))
+	var (
+		wg sync.WaitGroup
+		mu sync.Mutex
+	)
+	for range 20 {
+		wg.Go(func() {
+			_, err := manager.UpdateEmployee(ctx, "Alice", func(emp *employee.Employee) error {
+				emp.Salary, _ = emp.Salary.Add(money.Of(100, money.USD))
+				return nil
+			})
+			mu.Lock()
+			defer mu.Unlock()
+			if errors.Is(err, employee.ErrConflict) {
+				conflicts++
+			} else if err == nil {
+				ok++
+			}
+		})
+	}
+	wg.Wait()
+	now, _ := backend.GetByName(ctx, "Alice")
+	return ok, conflicts, now.Salary
+}
+
+func main() {
+	ctx := context.Background()
+
+	fmt.Println("💥 The lost update: Save, last write wins")
+	repo := memory.New()
+	_ = repo.Save(ctx, alice)
+	hr, _ := repo.GetByName(ctx, "Alice")  // HR reads version 1...
+	mgr, _ := repo.GetByName(ctx, "Alice") // ...and so does her manager
+	hr.Salary = money.Of(4500, money.USD)
+	_ = repo.Save(ctx, hr)
+	mgr.Title = "Senior Engineer"
+	_ = repo.Save(ctx, mgr)
+	now, _ := repo.GetByName(ctx, "Alice")
+	fmt.Printf("   ❌ the title is saved, and the raise is silently gone: %s, %v\n", now.Title, now.Salary)
+
+	fmt.Println("🔒 Update: only from the version read")
+	repo = memory.New()
+	_ = repo.Save(ctx, alice)
+	hr, _ = repo.GetByName(ctx, "Alice")
+	mgr, _ = repo.GetByName(ctx, "Alice")
+	hr.Salary = money.Of(4500, money.USD)
+	_, err := repo.Update(ctx, hr, hr.Version)
+	fmt.Println("   HR's raise, from version 1, is stored:", err == nil)
+	mgr.Title = "Senior Engineer"
+	_, err = repo.Update(ctx, mgr, mgr.Version)
+	fmt.Println("   the manager's title change, also from version 1, conflicts:", err)
+	mgr, _ = repo.GetByName(ctx, "Alice")
+	mgr.Title = "Senior Engineer"
+	now, _ = repo.Update(ctx, mgr, mgr.Version)
+	fmt.Printf("   read again, changed again: version %d has both, %s and %v\n", now.Version, now.Title, now.Salary)
+
+	fmt.Println("📜 One contract, every implementation (LSP)")
+	for _, impl := range implementations {
+		broken := contract(impl.repo())
+		if len(broken) == 0 {
+			fmt.Printf("   %s keeps every clause\n", impl.name)
+		}
+		for _, b := range broken {
+			fmt.Printf("   ❌ %s breaks: %s\n", impl.name, b)
+		}
+	}
+
+	fmt.Println("🔁 The Manager retries on conflict")
+	ok, conflicts, salary := raise(ctx, 1)
+	fmt.Printf("   without retries, 20 concurrent raises of 100: %d stored, %d conflicted, salary %v - none lost\n", ok, conflicts, salary)
+	ok, _, salary = raise(ctx, 100)
+	fmt.Printf("   WithConflictRetries(100): %d of 20 stored, salary %v\n", ok, salary)
+}
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"errors"
+	"testing"
+
+	"go-solid/employee"
+	"go-solid/employee/memory"
+	"go-solid/money"
+)
+
+func TestSave_LosesAConcurrentUpdate(t *testing.T) {
+	repo := memory.New()
+	if err := repo.Save(t.Context(), alice); err != nil {
+		t.Fatal(err)
+	}
+	hr, _ := repo.GetByName(t.Context(), "Alice")
+	mgr, _ := repo.GetByName(t.Context(), "Alice")
+	hr.Salary = money.Of(4500, money.USD)
+	_ = repo.Save(t.Context(), hr)
+	mgr.Title = "Senior Engineer"
+	_ = repo.Save(t.Context(), mgr)
+	if now, _ := repo.GetByName(t.Context(), "Alice"); now.Salary != alice.Salary {
+		t.Errorf("salary after two Saves = %v, want the raise lost: that is what Update prevents", now.Salary)
+	}
+}
+
+func TestUpdate_KeepsBothChanges(t *testing.T) {
+	repo := memory.New()
+	if err := repo.Save(t.Context(), alice); err != nil {
+		t.Fatal(err)
+	}
+	hr, _ := repo.GetByName(t.Context(), "Alice")
+	mgr, _ := repo.GetByName(t.Context(), "Alice")
+	hr.Salary = money.Of(4500, money.USD)
+	if _, err := repo.Update(t.Context(), hr, hr.Version); err != nil {
+		t.Fatalf("Update(raise) error = %v", err)
+	}
+	mgr.Title = "Senior Engineer"
+	if _, err := repo.Update(t.Context(), mgr, mgr.Version); !errors.Is(err, employee.ErrConflict) {
+		t.Fatalf("Update(title) from the same version error = %v, want %v", err, employee.ErrConflict)
+	}
+	mgr, _ = repo.GetByName(t.Context(), "Alice")
+	mgr.Title = "Senior Engineer"
+	now, err := repo.Update(t.Context(), mgr, mgr.Version)
+	if err != nil || now.Salary != money.Of(4500, money.USD) || now.Title != "Senior Engineer" {
+		t.Errorf("Update() read again = %s, %v, %v, want both changes", now.Title, now.Salary, err)
+	}
+}
+
+func TestContract(t *testing.T) {
+	for _, impl := range implementations {
+		t.Run(impl.name, func(t *testing.T) {
+			broken := contract(impl.repo())
+			if impl.name == "lenient" {
+				if len(broken) == 0 {
+					t.Error("contract() passed an Updater that ignores the version")
+				}
+				return
+			}
+			for _, b := range broken {
+				t.Errorf("breaks: %s", b)
+			}
+		})
+	}
+}
+
+func TestManager_RetriesOnConflict(t *testing.T) {
+	ok, conflicts, salary := raise(t.Context(), 1)
+	if conflicts == 0 {
+		t.Errorf("raise() without retries = %d conflicts, want some", conflicts)
+	}
+	if want := money.Of(4000+100*int64(ok), money.USD); salary != want {
+		t.Errorf("salary = %v after %d stored raises, want %v: none lost", salary, ok, want)
+	}
+	if ok, _, salary := raise(t.Context(), 100); ok != 20 || salary != money.Of(6000, money.USD) {
+		t.Errorf("raise() with retries = %d stored, salary %v, want all 20 and USD 6000.00", ok, salary)
+	}
+}
@@ -0,0 +1,130 @@
+// Command orgchart builds an org chart of nested teams over the employees a
+// Manager hired, asks it who reports to whom, has the Directory refuse the
+// changes that would break the hierarchy, and reorganises a team.
+// main_test.go checks every claim.
+package main
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"strings"
+
+	"go-solid/employee"
+	employeememory "go-solid/employee/memory"
+	"go-solid/id"
+	"go-solid/money"
+	"go-solid/org"
+	"go-solid/org/memory"
+)
+
+// draw prints the chart, one team per line, indented by depth.
+func draw(chart *org.Chart) {
+	for depth, t := range chart.Walk("") {
+		fmt.Printf("   %s%s (%s): %s\n", strings.Repeat("   ", depth), t.Name, t.Manager, strings.Join(t.Members, ", "))
+	}
+}
+
+// teams The teams build creates
+type teams struct{ eng, platform, mobile, sales org.Team }
+
+// build hires eight engineers and sales staff and puts them in teams:
+// Engineering (Alice) over Platform (Bob) and Mobile (Carol), and Sales
+// (Grace) on its own.
+func build(ctx context.Context) (*org.Directory, teams, error) {
+	people := employeememory.New()
+	manager := employee.NewManager(people)
+	for _, name := range []string{"Alice", "Bob", "Carol", "Dan", "Erin", "Frank", "Grace", "Heidi"} {
+		if _, err := manager.AddEmployee(ctx, employee.Employee{Name: name, Title: "Engineer", Salary: money.Of(5000, money.USD)}); err != nil {
+			return nil, teams{}, err
+		}
+	}
+
+	// The Directory needs only GetByName from employee storage (ISP); teams
+	// go to their own repository.
+	dir := org.NewDirectory(memory.New(), people, org.WithTeamIDs(id.NewSequence("team-")))
+	var (
+		t   teams
+		err error
+	)
+	create := func(name, manager, parent string) org.Team {
+		team, e := dir.CreateTeam(ctx, name, manager, parent)
+		err = cmp.Or(err, e)
+		return team
+	}
+	t.eng = create("Engineering", "Alice", "")
+	t.platform = create("Platform", "Bob", t.eng.ID)
+	t.mobile = create("Mobile", "Carol", t.eng.ID)
+	t.sales = create("Sales", "Grace", "")
+	for _, m := range []struct{ team, name string }{
+		{t.eng.ID, "Bob"}, {t.eng.ID, "Carol"},
+		{t.platform.ID, "Dan"}, {t.platform.ID, "Erin"},
+		{t.mobile.ID, "Frank"}, {t.sales.ID, "Heidi"},
+	} {
+		_, e := dir.AddMember(ctx, m.team, m.name)
+		err = cmp.Or(err, e)
+	}
+	return dir, t, err
+}
+
+// reorganise moves Mobile under Sales, and Carol, its manager, with it.
+func reorganise(ctx context.Context, dir *org.Directory, t teams) error {
+	if _, err := dir.RemoveMember(ctx, t.eng.ID, "Carol"); err != nil {
+		return err
+	}
+	if _, err := dir.AddMember(ctx, t.sales.ID, "Carol"); err != nil {
+		return err
+	}
+	_, err := dir.Move(ctx, t.mobile.ID, t.sales.ID)
+	return err
+}
+
+func main() {
+	ctx := context.Background()
+	dir, t, err := build(ctx)
+	if err != nil {
+		fmt.Println("❌", err)
+		return
+	}
+
+	fmt.Println("🌳 The org chart")
+	chart, err := dir.Chart(ctx)
+	if err != nil {
+		fmt.Println("❌", err)
+		return
+	}
+	draw(chart)
+
+	fmt.Println("🧭 Traversals")
+	fmt.Println("   Dan's chain of command:", strings.Join(chart.ChainOfCommand("Dan"), " → "))
+	fmt.Println("   everyone under Alice:", strings.Join(chart.Reports("Alice"), ", "))
+	boss, _ := chart.CommonManager("Dan", "Frank")
+	fmt.Println("   Dan and Frank first meet at", boss)
+	boss, _ = chart.CommonManager("Dan", "Bob")
+	fmt.Println("   Dan and their own manager Bob meet at", boss)
+	_, ok := chart.CommonManager("Dan", "Heidi")
+	fmt.Println("   Dan and Heidi, in separate top-level teams, have a manager in common:", ok)
+	team, _ := chart.TeamOf("Erin")
+	fmt.Printf("   Erin is on %s, one of Engineering's %d subteams\n", team.Name, len(chart.Subteams(t.eng.ID)))
+
+	fmt.Println("🚧 Changes that would break the hierarchy")
+	_, err = dir.AddMember(ctx, t.mobile.ID, "Dan")
+	fmt.Println("   Dan can't be on Mobile too:", err)
+	_, err = dir.AddMember(ctx, t.mobile.ID, "Mallory")
+	fmt.Println("   nor can someone who was never hired:", err)
+	_, err = dir.Move(ctx, t.eng.ID, t.platform.ID)
+	fmt.Println("   Engineering can't become part of its own subteam:", err)
+	_, err = dir.AssignManager(ctx, t.platform.ID, "Dan")
+	fmt.Println("   Dan can't manage the team they are on:", err)
+	_, err = dir.AssignManager(ctx, t.eng.ID, "Dan")
+	fmt.Println("   nor the team above it, and report to themselves:", err)
+
+	fmt.Println("🔀 Mobile moves to Sales, and Carol with it")
+	if err := reorganise(ctx, dir, t); err != nil {
+		fmt.Println("❌", err)
+		return
+	}
+	chart, _ = dir.Chart(ctx)
+	draw(chart)
+	fmt.Println("   Frank now reports to", strings.Join(chart.ChainOfCommand("Frank"), " → "))
+}
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"go-solid/employee"
+	"go-solid/org"
+)
+
+func TestChart_Traversals(t *testing.T) {
+	dir, teams, err := build(t.Context())
+	if err != nil {
+		t.Fatalf("build() error = %v", err)
+	}
+	chart, err := dir.Chart(t.Context())
+	if err != nil {
+		t.Fatalf("Chart() error = %v", err)
+	}
+	if got, want := chart.ChainOfCommand("Dan"), []string{"Bob", "Alice"}; !slices.Equal(got, want) {
+		t.Errorf("ChainOfCommand(Dan) = %v, want %v", got, want)
+	}
+	if got, want := chart.Reports("Alice"), []string{"Bob", "Carol", "Dan", "Erin", "Frank"}; !slices.Equal(got, want) {
+		t.Errorf("Reports(Alice) = %v, want %v", got, want)
+	}
+	tests := []struct {
+		a, b   string
+		want   string
+		wantOK bool
+	}{
+		{"Dan", "Frank", "Alice", true},
+		{"Dan", "Bob", "Bob", true},
+		{"Dan", "Heidi", "", false},
+	}
+	for _, tt := range tests {
+		if got, ok := chart.CommonManager(tt.a, tt.b); got != tt.want || ok != tt.wantOK {
+			t.Errorf("CommonManager(%s, %s) = %q, %v, want %q, %v", tt.a, tt.b, got, ok, tt.want, tt.wantOK)
+		}
+	}
+	if team, _ := chart.TeamOf("Erin"); team.ID != teams.platform.ID {
+		t.Errorf("TeamOf(Erin) = %s, want Platform", team.Name)
+	}
+	if got := len(chart.Subteams(teams.eng.ID)); got != 2 {
+		t.Errorf("len(Subteams(Engineering)) = %d, want 2", got)
+	}
+}
+
+func TestDirectory_RefusesBrokenHierarchies(t *testing.T) {
+	dir, teams, err := build(t.Context())
+	if err != nil {
+		t.Fatalf("build() error = %v", err)
+	}
+	before, _ := dir.Chart(t.Context())
+	tests := []struct {
+		name    string
+		change  func() (org.Team, error)
+		wantErr error
+	}{
+		{"on two teams", func() (org.Team, error) { return dir.AddMember(t.Context(), teams.mobile.ID, "Dan") }, org.ErrOnAnotherTeam},
+		{"never hired", func() (org.Team, error) { return dir.AddMember(t.Context(), teams.mobile.ID, "Mallory") }, employee.ErrNotFound},
+		{"under its own subteam", func() (org.Team, error) { return dir.Move(t.Context(), teams.eng.ID, teams.platform.ID) }, org.ErrCycle},
+		{"managing their own team", func() (org.Team, error) { return dir.AssignManager(t.Context(), teams.platform.ID, "Dan") }, org.ErrCycle},
+		{"managing the team above", func() (org.Team, error) { return dir.AssignManager(t.Context(), teams.eng.ID, "Dan") }, org.ErrCycle},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := tt.change(); !errors.Is(err, tt.wantErr) {
+				t.Errorf("error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+	after, _ := dir.Chart(t.Context())
+	if !slices.Equal(after.Reports("Alice"), before.Reports("Alice")) {
+		t.Errorf("Reports(Alice) = %v after refused changes, want %v", after.Reports("Alice"), before.Reports("Alice"))
+	}
+}
+
+func TestReorganise(t *testing.T) {
+	dir, teams, err := build(t.Context())
+	if err != nil {
+		t.Fatalf("build() error = %v", err)
+	}
+	if err := reorganise(t.Context(), dir, teams); err != nil {
+		t.Fatalf("reorganise() error = %v", err)
+	}
+	chart, _ := dir.Chart(t.Context())
+	if got, want := chart.ChainOfCommand("Frank"), []string{"Carol", "Grace"}; !slices.Equal(got, want) {
+		t.Errorf("ChainOfCommand(Frank) = %v, want %v", got, want)
+	}
+	if slices.Contains(chart.Reports("Alice"), "Frank") {
+		t.Errorf("Reports(Alice) = %v, want Mobile gone", chart.Reports("Alice"))
+	}
+}
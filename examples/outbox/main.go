@@ -0,0 +1,85 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"go-solid/employee"
+	"go-solid/employee/memory"
+	"go-solid/id"
+	"go-solid/money"
+	"go-solid/outbox"
+	"go-solid/queue"
+	queuemem "go-solid/queue/memory"
+)
+
+// lossyStore Loses the first "published" mark, as if the relay died between
+// publishing a message and recording that it did
+type lossyStore struct {
+	outbox.Store
+	once sync.Once
+}
+
+func (s *lossyStore) MarkPublished(ctx context.Context, ids ...string) error {
+	var err error
+	s.once.Do(func() { err = errors.New("relay killed") })
+	if err != nil {
+		return err
+	}
+	return s.Store.MarkPublished(ctx, ids...)
+}
+
+func main() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	repo := memory.New()
+	// ✅ Events go to the outbox in the same "transaction" as the employee row
+	manager := employee.NewManager(repo, employee.WithOutbox(), employee.WithIDs(id.NewSequence("id")))
+	broker := queuemem.New(16)
+
+	fmt.Println("📝 Hiring and promoting - nothing is published yet")
+	_, _ = manager.AddEmployee(ctx, employee.Employee{Name: "Alice", Title: "Engineer", Salary: money.Of(5000, money.USD)})
+	_, _ = manager.AddEmployee(ctx, employee.Employee{Name: "Bob", Title: "Engineer", Salary: money.Of(4800, money.USD)})
+	_, _ = manager.Promote(ctx, "Alice", "Senior Engineer", money.Of(800, money.USD))
+	pending, _ := repo.Pending(ctx, 100)
+	fmt.Printf("   outbox: %d pending, queue: %d message(s)\n", len(pending), broker.Len("employee.hired"))
+
+	// ✅ The consumer deduplicates on the outbox message ID
+	var mu sync.Mutex
+	deliveries, handled := 0, 0
+	var delivered sync.WaitGroup
+	delivered.Add(len(pending) + 1) // one message will be published twice
+	welcome := outbox.Idempotent(queue.HandlerFunc(func(ctx context.Context, msg queue.Message) error {
+		mu.Lock()
+		defer mu.Unlock()
+		handled++
+		fmt.Printf("   📨 handled %s %s (employee %s)\n", msg.Headers["event"], msg.ID, msg.Key)
+		return nil
+	}))
+	counted := queue.HandlerFunc(func(ctx context.Context, msg queue.Message) error {
+		defer delivered.Done()
+		mu.Lock()
+		deliveries++
+		mu.Unlock()
+		return welcome.Handle(ctx, msg)
+	})
+	for _, topic := range []string{"employee.hired", "employee.promoted"} {
+		go broker.Consume(ctx, topic, counted)
+	}
+
+	relay := &outbox.Relay{Store: &lossyStore{Store: repo}, Producer: broker}
+	fmt.Println("\n🚚 Relay, first run: dies after publishing one message")
+	n, err := relay.Flush(ctx)
+	fmt.Printf("   published %d, err: %v\n", n, err)
+
+	fmt.Println("\n🚚 Relay, second run: publishes that message again, then the rest")
+	n, err = relay.Flush(ctx)
+	fmt.Printf("   published %d, err: %v\n", n, err)
+
+	delivered.Wait()
+	pending, _ = repo.Pending(ctx, 100)
+	fmt.Printf("\n✅ %d deliveries, %d handled; outbox: %d pending\n", deliveries, handled, len(pending))
+}
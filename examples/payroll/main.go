@@ -0,0 +1,116 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"time"
+
+	"go-solid/employee"
+	"go-solid/employee/memory"
+	"go-solid/money"
+	"go-solid/payroll"
+)
+
+// mealAllowance A rule the payroll package has never heard of. ✅ It plugs into
+// a pipeline without touching the engine (OCP).
+type mealAllowance struct{ perMonth money.Money }
+
+func (mealAllowance) Name() string { return "meal-allowance" }
+
+func (m mealAllowance) Apply(ctx context.Context, emp payroll.PaidEmployee, slip *payroll.Payslip) error {
+	return slip.Add(m.Name(), "meal vouchers", payroll.Earning, m.perMonth)
+}
+
+func main() {
+	ctx := context.Background()
+	repo := memory.New()
+	for _, emp := range []employee.Employee{
+		{ID: "emp-1", Name: "Alice", Title: "Engineer", Salary: money.Of(9000, money.USD)},
+		{ID: "emp-2", Name: "Bob", Title: "Manager", Salary: money.Of(4500, money.USD)},
+		{ID: "emp-3", Name: "Karim", Title: "Engineer", Salary: money.Of(60000, money.EGP)},
+		{ID: "emp-4", Name: "Lena", Title: "Designer", Salary: money.Of(5200, money.EUR)},
+		{ID: "emp-5", Name: "Oliver", Title: "Analyst", Salary: money.Of(4000, money.GBP)},
+	} {
+		_ = repo.Save(ctx, emp)
+	}
+
+	// ✅ Each country is just an ordered list of steps
+	engine := payroll.New(payroll.Config{
+		"US": {
+			payroll.Bonus{Label: "engineering bonus", Rate: "0.10", Eligible: isEngineer},
+			payroll.Pension{Rate: "0.05", Cap: money.Of(400, money.USD)},
+			payroll.IncomeTax{Brackets: []payroll.Bracket{
+				{UpTo: money.Of(1000, money.USD), Rate: "0.10"},
+				{UpTo: money.Of(4000, money.USD), Rate: "0.12"},
+				{Rate: "0.22"},
+			}},
+			payroll.Garnishment{
+				Orders:    map[string][]payroll.Order{"emp-2": {{Label: "child support", Rate: "0.25"}, {Label: "tax levy", Amount: money.Of(900, money.USD)}}},
+				Protected: money.Of(2000, money.USD),
+			},
+		},
+		"EG": {
+			payroll.Pension{Rate: "0.11", Cap: money.Of(1500, money.EGP)},
+			payroll.IncomeTax{Brackets: []payroll.Bracket{
+				{UpTo: money.Of(3333, money.EGP), Rate: "0"},
+				{UpTo: money.Of(15000, money.EGP), Rate: "0.15"},
+				{Rate: "0.25"},
+			}},
+		},
+		"DE": {
+			mealAllowance{perMonth: money.Of(100, money.EUR)},
+			payroll.Pension{Rate: "0.093"},
+			payroll.IncomeTax{Brackets: []payroll.Bracket{{UpTo: money.Of(1000, money.EUR), Rate: "0"}, {Rate: "0.30"}}},
+		},
+	})
+
+	staff := payroll.Staff{
+		Repo:      repo,
+		CountryOf: payroll.ByCurrency(map[money.Currency]string{money.USD: "US", money.EGP: "EG", money.EUR: "DE", money.GBP: "GB"}),
+	}
+	run, err := engine.Run(ctx, payroll.Period{Year: 2025, Month: time.March}, staff)
+	if err != nil {
+		fmt.Println("❌", err)
+		return
+	}
+
+	fmt.Printf("💰 Payroll %s\n", run.Period)
+	for _, slip := range run.Payslips {
+		fmt.Printf("\n🧾 %s (%s)\n", slip.Name, slip.Country)
+		fmt.Printf("   %-32s %15s\n", "base pay", slip.Base)
+		for _, l := range slip.Lines {
+			fmt.Printf("   %-32s %15s\n", l.Step+": "+l.Label, l.Amount)
+		}
+		fmt.Printf("   %-32s %15s\n", "net", slip.Net())
+	}
+
+	// ❌ No pipeline for GB - Oliver is reported, everyone else is still paid
+	fmt.Println()
+	for _, e := range run.Errors {
+		fmt.Println("⚠️ ", e)
+	}
+
+	rates := money.Rates{Base: money.USD, Rates: map[money.Currency]string{"EGP": "48.50", "EUR": "0.92"}}
+	gross, net, err := run.Totals(ctx, rates, money.USD)
+	if err != nil {
+		fmt.Println("❌", err)
+		return
+	}
+	fmt.Printf("\n📊 Totals: gross %s, net %s\n", gross, net)
+
+	// ✅ Same Runner interface, payslips computed four at a time - and the same Run
+	var concurrent payroll.Runner = payroll.NewConcurrent(engine, 4)
+	again, err := concurrent.Run(ctx, run.Period, staff)
+	if err != nil {
+		fmt.Println("❌", err)
+		return
+	}
+	fmt.Printf("\n🔀 Concurrent run: same payslips and errors: %v\n", reflect.DeepEqual(run, again))
+	fmt.Println("   unpaid:", again.Err())
+}
+
+// isEngineer PaidEmployee has no title (ISP), so eligibility comes from HR's list
+func isEngineer(emp payroll.PaidEmployee) bool {
+	return emp.EmployeeID() == "emp-1" || emp.EmployeeID() == "emp-3"
+}
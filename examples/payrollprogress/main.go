@@ -0,0 +1,92 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"time"
+
+	"go-solid/employee"
+	"go-solid/employee/memory"
+	"go-solid/id"
+	"go-solid/money"
+	"go-solid/payroll"
+	"go-solid/payrollapi"
+)
+
+// slowLookup A step that waits, as a tax service would, so the run takes long
+// enough to watch
+type slowLookup struct{ delay time.Duration }
+
+func (slowLookup) Name() string { return "slow-lookup" }
+
+func (s slowLookup) Apply(ctx context.Context, emp payroll.PaidEmployee, slip *payroll.Payslip) error {
+	time.Sleep(s.delay)
+	return nil
+}
+
+func main() {
+	ctx := context.Background()
+	repo := memory.New()
+	for i, name := range []string{"Alice", "Bob", "Carol", "Dan", "Erin", "Frank"} {
+		salary := money.Of(int64(4000+500*i), money.USD)
+		if name == "Dan" {
+			salary = money.Of(3000, money.GBP) // no pipeline: reported, and left unpaid
+		}
+		_ = repo.Save(ctx, employee.Employee{ID: employee.ID(fmt.Sprintf("emp-%d", i+1)), Name: name, Title: "Engineer", Salary: salary})
+	}
+	staff := payroll.Staff{Repo: repo, CountryOf: payroll.ByCurrency(map[money.Currency]string{money.USD: "US"})}
+	engine := payroll.New(payroll.Config{"US": {slowLookup{20 * time.Millisecond}, payroll.Pension{Rate: "0.05"}}})
+	period := payroll.Period{Year: 2026, Month: time.March}
+
+	// ✅ A reporter is any Report method; the engine doesn't know where reports go
+	fmt.Println("🖥️  Engine, reporting to the terminal")
+	log := payroll.ReportFunc(func(p payroll.Progress) {
+		switch {
+		case p.Done == 0:
+			fmt.Printf("   starting, total %d (a roster that reads as it goes can't tell)\n", p.Total)
+		case p.Err != nil:
+			fmt.Printf("   %d  ❌ %s: %v\n", p.Done, p.Name, p.Err)
+		default:
+			fmt.Printf("   %d  ✅ %s\n", p.Done, p.Name)
+		}
+	})
+	_, _ = engine.Run(payroll.WithProgress(ctx, log), period, staff)
+
+	// ✅ Read into memory first, the roster has a length to report against
+	fmt.Println("\n📊 Concurrent, against a total: completion order, but Done only grows")
+	employees, _ := payroll.Collect(ctx, staff)
+	bar := payroll.ReportFunc(func(p payroll.Progress) {
+		fmt.Printf("   [%-6s] %d/%d\n", strings.Repeat("#", p.Done), p.Done, p.Total)
+	})
+	_, _ = payroll.NewConcurrent(engine, 3).Run(payroll.WithProgress(ctx, bar), period, employees)
+
+	// ✅ The same reports as server-sent events: here the reporter is the run's stream
+	fmt.Println("\n🌐 payrollapi, streaming to a browser (or anything reading text/event-stream)")
+	server := httptest.NewServer(payrollapi.New(payroll.NewConcurrent(engine, 3), staff, payrollapi.WithIDs(id.NewSequence("run-"))))
+	defer server.Close()
+	resp, err := http.Post(server.URL+"/payroll/runs", "application/json", strings.NewReader(`{"month": "2026-03"}`))
+	if err != nil {
+		fmt.Println("❌", err)
+		return
+	}
+	resp.Body.Close()
+	fmt.Printf("   POST /payroll/runs: %s, follow %s\n", resp.Status, resp.Header.Get("Location"))
+
+	stream, err := http.Get(server.URL + resp.Header.Get("Location"))
+	if err != nil {
+		fmt.Println("❌", err)
+		return
+	}
+	defer stream.Body.Close()
+	lines := bufio.NewScanner(stream.Body)
+	for lines.Scan() {
+		if data, ok := strings.CutPrefix(lines.Text(), "data: "); ok {
+			fmt.Println("  ", data)
+		}
+	}
+	fmt.Println("   the stream ends after \"done\"; the page's EventSource closes then, rather than reconnect")
+}
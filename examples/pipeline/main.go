@@ -0,0 +1,152 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"os"
+	"sync/atomic"
+	"text/tabwriter"
+	"time"
+
+	"go-solid/employee"
+	"go-solid/employee/memory"
+	"go-solid/fakes"
+	"go-solid/money"
+	"go-solid/pipeline"
+)
+
+// employees Source - every employee of a repository, page by page, so only
+// one page is ever held in memory
+type employees struct {
+	repo employee.QueryRepository
+}
+
+func (s employees) Emit(ctx context.Context, out chan<- employee.Employee) error {
+	page := employee.Page{Limit: 50}
+	for {
+		res, err := s.repo.List(ctx, employee.Filter{Sort: employee.SortByName}, page)
+		if err != nil {
+			return err
+		}
+		for _, emp := range res.Items {
+			if err := pipeline.Send(ctx, out, emp); err != nil {
+				return err
+			}
+		}
+		if res.NextCursor == "" {
+			return nil
+		}
+		page.Cursor = res.NextCursor
+	}
+}
+
+// adjustment One line of the report
+type adjustment struct {
+	Name     string
+	From, To money.Money
+}
+
+// costOfLiving Transform - raises everyone paid below Ceiling by Rate; the
+// others are skipped, and left out of the report
+type costOfLiving struct {
+	Rate    *big.Rat
+	Ceiling money.Money
+}
+
+func (c costOfLiving) Apply(ctx context.Context, emp employee.Employee) (adjustment, error) {
+	if !emp.Salary.Less(c.Ceiling) {
+		return adjustment{}, pipeline.Skip
+	}
+	raised, err := emp.Salary.Add(emp.Salary.Mul(c.Rate))
+	return adjustment{Name: emp.Name, From: emp.Salary, To: raised}, err
+}
+
+// report Sink - a table of adjustments, with the total written by Flush
+type report struct {
+	w     *tabwriter.Writer
+	lines int
+	total money.Money
+}
+
+func newReport(w io.Writer) *report {
+	r := &report{w: tabwriter.NewWriter(w, 0, 0, 2, ' ', 0), total: money.Of(0, money.USD)}
+	fmt.Fprintln(r.w, "   Employee\tFrom\tTo\t")
+	return r
+}
+
+func (r *report) Write(ctx context.Context, a adjustment) error {
+	cost, err := a.To.Sub(a.From)
+	if err == nil {
+		r.total, err = r.total.Add(cost)
+	}
+	if err != nil {
+		return err
+	}
+	r.lines++
+	if r.lines <= 5 {
+		fmt.Fprintf(r.w, "   %s\t%s\t%s\t\n", a.Name, a.From, a.To)
+	}
+	return nil
+}
+
+func (r *report) Flush(ctx context.Context) error {
+	fmt.Fprintf(r.w, "   ... %d more\t\t\t\n", r.lines-5)
+	fmt.Fprintf(r.w, "   %d raised\t\t+%s a month\t\n", r.lines, r.total)
+	return r.w.Flush()
+}
+
+var (
+	_ pipeline.Source[employee.Employee]                = employees{}
+	_ pipeline.Transform[employee.Employee, adjustment] = costOfLiving{}
+	_ pipeline.Sink[adjustment]                         = (*report)(nil)
+	_ pipeline.Flusher                                  = (*report)(nil)
+)
+
+// counted Source - wraps another and counts what it has emitted so far
+func counted[T any](src pipeline.Source[T], n *atomic.Int64) pipeline.Source[T] {
+	return pipeline.Through(src, pipeline.TransformFunc[T, T](func(ctx context.Context, v T) (T, error) {
+		n.Add(1)
+		return v, nil
+	}))
+}
+
+func main() {
+	ctx := context.Background()
+	repo := memory.New()
+	_, _ = fakes.Seed(ctx, repo, fakes.New(7), 400)
+	raise := costOfLiving{Rate: big.NewRat(3, 100), Ceiling: money.Of(8000, money.USD)}
+
+	fmt.Println("🚰 400 employees -> 3% cost-of-living raise below USD 8000 -> report")
+	src := pipeline.Through[employee.Employee, adjustment](employees{repo}, raise, pipeline.WithWorkers(4))
+	if err := pipeline.Run(ctx, src, newReport(os.Stdout)); err != nil {
+		fmt.Println("   ❌", err)
+	}
+
+	// ✅ Backpressure: a sink taking 1ms per line holds the source back; it
+	// never runs more than a few employees ahead, whatever the total
+	fmt.Println("\n🐢 Same pipeline, slow sink, buffer of 8")
+	var read, written atomic.Int64 // adjustments made, and written
+	var ahead int64
+	slow := pipeline.SinkFunc[adjustment](func(ctx context.Context, a adjustment) error {
+		time.Sleep(time.Millisecond)
+		written.Add(1)
+		ahead = max(ahead, read.Load()-written.Load())
+		return nil
+	})
+	src = counted(pipeline.Through[employee.Employee, adjustment](employees{repo}, raise), &read)
+	_ = pipeline.Run(ctx, src, slow, pipeline.WithBuffer(8))
+	fmt.Printf("   %d adjusted, %d written; adjusting was at most %d ahead of writing\n", read.Load(), written.Load(), ahead)
+
+	// ✅ Cancellation: the deadline stops every stage, and Flush is not called
+	fmt.Println("\n⏱️  Same pipeline, slow sink, 30ms deadline")
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Millisecond)
+	defer cancel()
+	read.Store(0)
+	written.Store(0)
+	src = counted(pipeline.Through[employee.Employee, adjustment](employees{repo}, raise), &read)
+	err := pipeline.Run(ctx, src, slow)
+	fmt.Printf("   stopped after %d adjusted, %d written: %v (deadline: %v)\n", read.Load(), written.Load(), err, errors.Is(err, context.DeadlineExceeded))
+}
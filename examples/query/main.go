@@ -0,0 +1,50 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"go-solid/employee"
+	"go-solid/employee/memory"
+	"go-solid/money"
+)
+
+func main() {
+	ctx := context.Background()
+	manager := employee.NewManager(memory.New())
+
+	for _, e := range []employee.Employee{
+		{Name: "Mohamed", Salary: money.Of(5000, money.USD)},
+		{Name: "Ahmed", Salary: money.Of(6000, money.USD)},
+		{Name: "Ali", Salary: money.Of(4500, money.USD)},
+		{Name: "Amira", Salary: money.Of(7000, money.USD)},
+		{Name: "Aya", Salary: money.Of(4500, money.USD)},
+		{Name: "Omar", Salary: money.Of(3000, money.USD)},
+	} {
+		_, _ = manager.AddEmployee(ctx, e)
+	}
+
+	// ✅ The same Filter/Page API works for every backend implementing employee.QueryRepository
+	filter := employee.Filter{NamePrefix: "A", MinSalary: money.Of(4000, money.USD), Sort: employee.SortBySalary, Descending: true}
+	page := employee.Page{Limit: 2}
+
+	for n := 1; ; n++ {
+		result, err := manager.ListEmployees(ctx, filter, page)
+		if err != nil {
+			fmt.Println("Error listing employees:", err)
+			return
+		}
+		fmt.Printf("📄 Page %d\n", n)
+		for _, emp := range result.Items {
+			fmt.Printf("   %-6s %s\n", emp.Name, emp.Salary)
+		}
+		if result.NextCursor == "" {
+			break
+		}
+		page.Cursor = result.NextCursor
+	}
+
+	// Cursors remember the ordering they were issued for; reusing one with another sort is rejected.
+	_, err := manager.ListEmployees(ctx, employee.Filter{}, page)
+	fmt.Println("❌", err)
+}
@@ -0,0 +1,162 @@
+package main
+
+import (
+	"fmt"
+	"maps"
+	"sync"
+	"time"
+
+	"go-solid/money"
+)
+
+// SalaryCache Abstraction - what the payroll workers below depend on. Both
+// implementations satisfy it; only one is safe to share between goroutines.
+type SalaryCache interface {
+	Raise(name string, by money.Money) error
+	Salary(name string) money.Money
+}
+
+// bonusPolicy stands in for the slow call a real raise makes between reading
+// the salary and writing it back: a rate lookup, a rule engine, a database
+func bonusPolicy(by money.Money) money.Money {
+	time.Sleep(time.Microsecond)
+	return by
+}
+
+//////////--------------------Bad Practice--------------------/////////////////////////
+
+// sharedCache ❌ Every goroutine reads and writes the same salaries with no
+// synchronisation. Two raises of one employee both read the old salary, and
+// the second write undoes the first. `go run -race` reports the data race.
+type sharedCache struct {
+	salaries map[string]*money.Money
+}
+
+func newSharedCache(salaries map[string]money.Money) *sharedCache {
+	c := &sharedCache{salaries: map[string]*money.Money{}}
+	for name, s := range salaries {
+		c.salaries[name] = &s
+	}
+	return c
+}
+
+func (c *sharedCache) Raise(name string, by money.Money) error {
+	salary := c.salaries[name]
+	current := *salary // ❌ read ...
+	raised, err := current.Add(bonusPolicy(by))
+	if err != nil {
+		return err
+	}
+	*salary = raised // ❌ ... and write back, undoing whatever happened in between
+	return nil
+}
+
+func (c *sharedCache) Salary(name string) money.Money { return *c.salaries[name] }
+
+//////////////-----------------------------Good Practice-------------------/////////////////////////////////////////////////////////
+
+// ownedCache ✅ The salaries belong to one goroutine. Others send it requests
+// over a channel and wait for the answer, so reads and writes never overlap -
+// and callers see the same SalaryCache as before.
+type ownedCache struct {
+	requests chan func(map[string]money.Money)
+	done     chan struct{}
+}
+
+func newOwnedCache(salaries map[string]money.Money) *ownedCache {
+	c := &ownedCache{requests: make(chan func(map[string]money.Money)), done: make(chan struct{})}
+	go c.own(maps.Clone(salaries)) // nobody else keeps a reference
+	return c
+}
+
+// own is the only code touching salaries.
+func (c *ownedCache) own(salaries map[string]money.Money) {
+	defer close(c.done)
+	for req := range c.requests {
+		req(salaries)
+	}
+}
+
+// do runs f on the owner goroutine and waits for it to finish.
+func (c *ownedCache) do(f func(map[string]money.Money)) {
+	finished := make(chan struct{})
+	c.requests <- func(salaries map[string]money.Money) {
+		f(salaries)
+		close(finished)
+	}
+	<-finished
+}
+
+func (c *ownedCache) Raise(name string, by money.Money) (err error) {
+	c.do(func(salaries map[string]money.Money) {
+		var raised money.Money
+		if raised, err = salaries[name].Add(bonusPolicy(by)); err == nil {
+			salaries[name] = raised
+		}
+	})
+	return err
+}
+
+func (c *ownedCache) Salary(name string) (s money.Money) {
+	c.do(func(salaries map[string]money.Money) { s = salaries[name] })
+	return s
+}
+
+// Close stops the owner goroutine.
+func (c *ownedCache) Close() {
+	close(c.requests)
+	<-c.done
+}
+
+var (
+	_ SalaryCache = (*sharedCache)(nil)
+	_ SalaryCache = (*ownedCache)(nil)
+)
+
+// payrollRun Raises every employee once per worker, all workers at the same time
+func payrollRun(cache SalaryCache, names []string, workers int) {
+	var wg sync.WaitGroup
+	for range workers {
+		wg.Go(func() {
+			for _, name := range names {
+				_ = cache.Raise(name, money.Of(10, money.USD))
+			}
+		})
+	}
+	wg.Wait()
+}
+
+func main() {
+	names := []string{"Alice", "Bob", "Carol"}
+	start := map[string]money.Money{}
+	for _, name := range names {
+		start[name] = money.Of(5000, money.USD)
+	}
+	const workers = 50
+	want := money.Of(5000+10*workers, money.USD)
+
+	fmt.Printf("🏃 %d workers each raising %v by $10 at once; everyone should end at %s\n", workers, names, want)
+
+	fmt.Println("\n❌ Shared cache")
+	shared := newSharedCache(start)
+	payrollRun(shared, names, workers)
+	for _, name := range names {
+		fmt.Printf("   %s: %s (%s)\n", name, shared.Salary(name), verdict(shared.Salary(name), want))
+	}
+
+	fmt.Println("\n✅ Cache owned by one goroutine")
+	owned := newOwnedCache(start)
+	defer owned.Close()
+	payrollRun(owned, names, workers)
+	for _, name := range names {
+		fmt.Printf("   %s: %s (%s)\n", name, owned.Salary(name), verdict(owned.Salary(name), want))
+	}
+}
+
+func verdict(got, want money.Money) string {
+	if got == want {
+		return "correct"
+	}
+	lost, _ := want.Sub(got)
+	return fmt.Sprintf("%s of raises lost", lost)
+}
@@ -0,0 +1,46 @@
+package main
+
+import (
+	"os"
+	"testing"
+
+	"go-solid/money"
+)
+
+func salaries(names ...string) map[string]money.Money {
+	s := map[string]money.Money{}
+	for _, name := range names {
+		s[name] = money.Of(5000, money.USD)
+	}
+	return s
+}
+
+// TestOwnedCache_PayrollRun passes under `go test -race`: every raise counts
+// and the race detector stays quiet.
+func TestOwnedCache_PayrollRun(t *testing.T) {
+	names := []string{"Alice", "Bob", "Carol"}
+	cache := newOwnedCache(salaries(names...))
+	defer cache.Close()
+
+	const workers = 50
+	payrollRun(cache, names, workers)
+	want := money.Of(5000+10*workers, money.USD)
+	for _, name := range names {
+		if got := cache.Salary(name); got != want {
+			t.Errorf("Salary(%q) = %s, want %s", name, got, want)
+		}
+	}
+}
+
+// TestSharedCache_PayrollRun is the data race itself, so `go test -race`
+// fails it. It is skipped unless asked for:
+//
+//	RACE_DEMO=1 go test -race -run SharedCache ./examples/race
+func TestSharedCache_PayrollRun(t *testing.T) {
+	if os.Getenv("RACE_DEMO") == "" {
+		t.Skip("races by design; set RACE_DEMO=1 and run with -race to see it reported")
+	}
+	names := []string{"Alice", "Bob", "Carol"}
+	cache := newSharedCache(salaries(names...))
+	payrollRun(cache, names, 50)
+}
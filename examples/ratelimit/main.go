@@ -0,0 +1,119 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"go-solid/clock"
+	"go-solid/employee"
+	"go-solid/employee/memory"
+	"go-solid/money"
+	"go-solid/ratelimit"
+)
+
+func main() {
+	// ✅ Same contract - 5 operations per second - two timing shapes
+	fmt.Println("⏱️  One request every 100ms for 2s, limit 5/s (✅ admitted, ❌ rejected)")
+	start := time.Date(2026, time.March, 2, 9, 0, 0, 0, time.UTC)
+	for _, c := range []struct {
+		name string
+		new  func(clock.Clock) ratelimit.Limiter
+	}{
+		{"token bucket  ", func(c clock.Clock) ratelimit.Limiter { return ratelimit.NewTokenBucket(200*time.Millisecond, 5, c) }},
+		{"sliding window", func(c clock.Clock) ratelimit.Limiter { return ratelimit.NewSlidingWindow(5, time.Second, c) }},
+	} {
+		fake := clock.NewFake(start)
+		fmt.Printf("   %s %s\n", c.name, timeline(c.new(fake), fake, 20, 100*time.Millisecond))
+	}
+	// Both sustain 5/s; the bucket spends its burst and then trickles, the window
+	// admits a full batch and then nothing until the first one ages out.
+	// A caller that assumed either shape would break when the other is injected.
+
+	fmt.Println()
+	fmt.Println("🏎️  Throughput of Allow (unlimited rate, 8 goroutines)")
+	for _, c := range []struct {
+		name string
+		l    ratelimit.Limiter
+	}{
+		{"token bucket  ", ratelimit.NewTokenBucket(time.Nanosecond, 1<<30, clock.Real{})},
+		{"sliding window", ratelimit.NewSlidingWindow(1<<10, time.Nanosecond, clock.Real{})},
+	} {
+		res := testing.Benchmark(func(b *testing.B) {
+			b.SetParallelism(8)
+			b.RunParallel(func(pb *testing.PB) {
+				for pb.Next() {
+					c.l.Allow()
+				}
+			})
+		})
+		fmt.Printf("   %s %s\n", c.name, res)
+	}
+
+	fmt.Println()
+	fmt.Println("⚖️  Fairness: 4 workers calling Wait for 500ms at 200 ops/s")
+	for _, c := range []struct {
+		name string
+		l    ratelimit.Limiter
+	}{
+		{"token bucket  ", ratelimit.NewTokenBucket(5*time.Millisecond, 1, clock.Real{})},
+		{"sliding window", ratelimit.NewSlidingWindow(20, 100*time.Millisecond, clock.Real{})},
+	} {
+		counts := fairness(c.l, 4, 500*time.Millisecond)
+		fmt.Printf("   %s per worker %v (spread %d)\n", c.name, counts, slices.Max(counts)-slices.Min(counts))
+	}
+	// Neither limiter queues waiters, so whoever is running when capacity frees
+	// up wins. A window that frees a whole batch at once lets one worker take it.
+
+	fmt.Println()
+	fmt.Println("🧱 Decorator: writes throttled to one per 50ms, reads untouched")
+	ctx := context.Background()
+	repo := ratelimit.NewRepository(memory.New(), ratelimit.NewTokenBucket(50*time.Millisecond, 1, clock.Real{}))
+	manager := employee.NewManager(repo)
+	began := time.Now()
+	for _, name := range []string{"Mohamed", "Ahmed", "Sara", "Omar"} {
+		_, _ = manager.AddEmployee(ctx, employee.Employee{Name: name, Salary: money.Of(5000, money.USD)})
+	}
+	fmt.Printf("   4 hires took ~%s\n", time.Since(began).Round(10*time.Millisecond))
+	began = time.Now()
+	for range 100 {
+		_, _ = manager.FindEmployee(ctx, "Sara")
+	}
+	fmt.Printf("   100 lookups took %s\n", time.Since(began).Round(time.Millisecond))
+}
+
+func timeline(l ratelimit.Limiter, c *clock.Fake, n int, step time.Duration) string {
+	var sb strings.Builder
+	for range n {
+		if l.Allow() {
+			sb.WriteString("✅")
+		} else {
+			sb.WriteString("❌")
+		}
+		c.Advance(step)
+	}
+	return sb.String()
+}
+
+func fairness(l ratelimit.Limiter, workers int, d time.Duration) []int {
+	ctx, cancel := context.WithTimeout(context.Background(), d)
+	defer cancel()
+
+	counts := make([]int, workers)
+	var wg sync.WaitGroup
+	for i := range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for l.Wait(ctx) == nil {
+				counts[i]++
+			}
+		}()
+	}
+	wg.Wait()
+	return counts
+}
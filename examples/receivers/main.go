@@ -0,0 +1,128 @@
+// Command receivers walks through value and pointer receivers: what each can
+// change, which one puts a method in a type's method set, and so which type
+// satisfies an interface. main_test.go holds Go to every claim made here.
+package main
+
+import "fmt"
+
+// employee as in 1.SRP, plus a salary to change
+type employee struct {
+	firstName string
+	lastName  string
+	salary    int
+}
+
+// getFullName changes nothing, yet has a pointer receiver, as in 1.SRP. Once
+// one method of a type needs a pointer (raise does), giving them all one
+// keeps the method set in one piece: *employee has every method, employee
+// has none, and nobody has to remember which is which. It also doesn't copy
+// the struct on every call.
+func (em *employee) getFullName() string { return em.firstName + " " + em.lastName }
+
+// raise has to change the employee, so it needs the pointer
+func (em *employee) raise(amount int) { em.salary += amount }
+
+// raiseCopy ❌ changes a copy, which is thrown away on return
+func (em employee) raiseCopy(amount int) { em.salary += amount }
+
+type fullNamer interface{ getFullName() string }
+
+// badge has a value receiver: a badge and a *badge both have describe
+type badge struct{ name string }
+
+func (b badge) describe() string { return "badge of " + b.name }
+
+type describer interface{ describe() string }
+
+// mutate raises alice through raiseCopy, then through raise, and returns her
+// salary after each.
+func mutate() (afterCopy, afterRaise int) {
+	alice := employee{firstName: "Alice", lastName: "Smith", salary: 3000}
+	alice.raiseCopy(500)
+	afterCopy = alice.salary
+	alice.raise(500) // Go takes &alice for us: alice is a variable, so addressable
+	return afterCopy, alice.salary
+}
+
+// methodSets reports whether an employee and an *employee are fullNamers,
+// and whether a badge and a *badge are describers.
+func methodSets() (value, pointer, badgeValue, badgePointer bool) {
+	alice := employee{firstName: "Alice", lastName: "Smith"}
+	_, value = any(alice).(fullNamer)
+	_, pointer = any(&alice).(fullNamer)
+	// var n fullNamer = alice  ❌ does not compile: employee does not
+	// implement fullNamer (method getFullName has pointer receiver)
+	_, badgeValue = any(badge{"Bob"}).(describer)
+	_, badgePointer = any(&badge{"Bob"}).(describer)
+	return value, pointer, badgeValue, badgePointer
+}
+
+// throughInterface stores &alice in a fullNamer, renames alice, and returns
+// the name the interface gives.
+func throughInterface() string {
+	alice := employee{firstName: "Alice", lastName: "Smith"}
+	var n fullNamer = &alice
+	alice.firstName = "Alicia"
+	return n.getFullName()
+}
+
+// interfaceCopy takes the badge out of a describer, renames it, and returns
+// what the describer describes.
+func interfaceCopy() string {
+	var d describer = badge{name: "Carol"}
+	b := d.(badge)
+	b.name = "Dave"
+	return d.describe()
+}
+
+// raiseStaff raises two employees of a slice ranging by value, then by
+// index, and returns the first one's salary after each.
+func raiseStaff() (byValue int, byIndex [2]int) {
+	staff := []employee{{firstName: "Erin", salary: 1000}, {firstName: "Frank", salary: 1000}}
+	for _, e := range staff {
+		e.raise(100) // ❌ e is a copy of the element
+	}
+	byValue = staff[0].salary
+	for i := range staff {
+		staff[i].raise(100) // ✅ the element itself, addressable through the slice
+	}
+	return byValue, [2]int{staff[0].salary, staff[1].salary}
+}
+
+// raiseInMap raises an employee kept by value in a map.
+func raiseInMap() int {
+	byName := map[string]employee{"Grace": {firstName: "Grace", salary: 2000}}
+	// byName["Grace"].raise(100)  ❌ does not compile: a map element is not
+	// addressable, so Go can't take the pointer raise needs
+	g := byName["Grace"]
+	g.raise(100)
+	byName["Grace"] = g // ✅ copy out, change, store back - or keep *employee in the map
+	return byName["Grace"].salary
+}
+
+func main() {
+	fmt.Println("✏️  Mutation")
+	afterCopy, afterRaise := mutate()
+	fmt.Printf("   ❌ a value receiver raises a copy: the salary is still %d\n", afterCopy)
+	fmt.Printf("   ✅ a pointer receiver raises alice herself: %d\n", afterRaise)
+
+	fmt.Println("🔌 Method sets and interfaces")
+	valueOK, pointerOK, badgeOK, badgePointerOK := methodSets()
+	fmt.Printf("   an employee value is a fullNamer: %v - getFullName belongs to *employee\n", valueOK)
+	fmt.Printf("   an *employee is a fullNamer: %v\n", pointerOK)
+	fmt.Println("   through the interface, the pointer still reaches alice, renamed:", throughInterface())
+	fmt.Printf("   a value receiver method is in both method sets: badge is a describer: %v, *badge: %v\n", badgeOK, badgePointerOK)
+
+	fmt.Println("📦 Copies you didn't ask for")
+	fmt.Println("   an interface holds a copy of a value: after renaming b, d is the", interfaceCopy())
+	byValue, byIndex := raiseStaff()
+	fmt.Printf("   ❌ ranging by value raises copies: the slice still pays %d\n", byValue)
+	fmt.Printf("   ✅ ranging by index raises the elements: %d and %d\n", byIndex[0], byIndex[1])
+	fmt.Printf("   ✅ a map element is raised by copying it out and back: %d\n", raiseInMap())
+
+	fmt.Println("📏 Rules of thumb")
+	fmt.Println("   • a method that changes the receiver needs a pointer")
+	fmt.Println("   • once one method has a pointer receiver, give them all one")
+	fmt.Println("   • small immutable values (money.Money, time.Time) take value receivers")
+	fmt.Println("   • with pointer receivers, only *T satisfies the interface: store &v")
+}
@@ -0,0 +1,34 @@
+package main
+
+import "testing"
+
+func TestMutation(t *testing.T) {
+	if afterCopy, afterRaise := mutate(); afterCopy != 3000 || afterRaise != 3500 {
+		t.Errorf("mutate() = %d, %d, want 3000 after raiseCopy, a copy raised, and 3500 after raise", afterCopy, afterRaise)
+	}
+}
+
+func TestMethodSets(t *testing.T) {
+	value, pointer, badgeValue, badgePointer := methodSets()
+	if value || !pointer {
+		t.Errorf("employee is a fullNamer: %v, *employee: %v; want getFullName in *employee's method set only", value, pointer)
+	}
+	if !badgeValue || !badgePointer {
+		t.Errorf("badge is a describer: %v, *badge: %v; want both", badgeValue, badgePointer)
+	}
+	if got := throughInterface(); got != "Alicia Smith" {
+		t.Errorf("throughInterface() = %q, want alice herself, renamed: Alicia Smith", got)
+	}
+}
+
+func TestCopies(t *testing.T) {
+	if got := interfaceCopy(); got != "badge of Carol" {
+		t.Errorf("interfaceCopy() = %q, want the interface's own copy: badge of Carol", got)
+	}
+	if byValue, byIndex := raiseStaff(); byValue != 1000 || byIndex != [2]int{1100, 1100} {
+		t.Errorf("raiseStaff() = %d, %v; want copies raised by value, then 1100 each by index", byValue, byIndex)
+	}
+	if got := raiseInMap(); got != 2100 {
+		t.Errorf("raiseInMap() = %d, want 2100 once stored back", got)
+	}
+}
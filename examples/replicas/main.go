@@ -0,0 +1,207 @@
+// Command replicas routes a Manager's reads to read replicas with
+// replica.ReadWriteSplitter: round-robin and latency-aware policies, a
+// staleness tolerance, reading your own writes, and falling back to the
+// primary when a replica fails. The replicas apply the primary's writes a
+// little behind it, on a fake clock, so main_test.go checks every claim
+// deterministically.
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go-solid/clock"
+	"go-solid/employee"
+	"go-solid/employee/memory"
+	"go-solid/money"
+	"go-solid/replica"
+)
+
+// cluster A primary and its replication log: every write, when it happened
+type cluster struct {
+	clk *clock.Fake
+	log []write
+}
+
+type write struct {
+	at  time.Time
+	emp employee.Employee
+}
+
+// primary Writes go to memory and into the replication log
+type primary struct {
+	*memory.Repository
+	c *cluster
+}
+
+func (p primary) Save(ctx context.Context, emp employee.Employee) error {
+	if err := p.Repository.Save(ctx, emp); err != nil {
+		return err
+	}
+	p.c.log = append(p.c.log, write{p.c.clk.Now(), emp})
+	return nil
+}
+
+// node A replica applying the log delay behind the primary. Each read takes
+// latency, on the fake clock.
+type node struct {
+	*memory.Repository
+	c       *cluster
+	delay   time.Duration
+	latency time.Duration
+	down    bool
+	applied int
+}
+
+func newNode(c *cluster, delay, latency time.Duration) *node {
+	return &node{Repository: memory.New(), c: c, delay: delay, latency: latency}
+}
+
+func (n *node) catchUp() {
+	for ; n.applied < len(n.c.log); n.applied++ {
+		w := n.c.log[n.applied]
+		if n.c.clk.Now().Sub(w.at) < n.delay {
+			return
+		}
+		_ = n.Repository.Save(context.Background(), w.emp)
+	}
+}
+
+func (n *node) GetByName(ctx context.Context, name string) (employee.Employee, error) {
+	n.c.clk.Advance(n.latency)
+	if n.down {
+		return employee.Employee{}, errors.New("connection refused")
+	}
+	n.catchUp()
+	return n.Repository.GetByName(ctx, name)
+}
+
+// Lag implements replica.LagReporter: the age of the oldest write not yet
+// applied.
+func (n *node) Lag(context.Context) (time.Duration, error) {
+	n.catchUp()
+	if n.applied == len(n.c.log) {
+		return 0, nil
+	}
+	return n.c.clk.Now().Sub(n.c.log[n.applied].at), nil
+}
+
+// topology A primary, three replicas and the cluster between them: a and b
+// a second behind, 2ms and 20ms away; far a minute behind, 5ms away
+type topology struct {
+	c        *cluster
+	prim     primary
+	a, b     *node
+	far      *node
+	replicas []replica.Replica
+}
+
+// build sets a topology up with Alice, Bob and Carol hired through a
+// Manager over the splitter, and two minutes passed for every replica to
+// apply them.
+func build(ctx context.Context) (*topology, *replica.ReadWriteSplitter) {
+	c := &cluster{clk: clock.NewFake(time.Date(2025, 3, 1, 9, 0, 0, 0, time.UTC))}
+	t := &topology{c: c, prim: primary{memory.New(), c},
+		a: newNode(c, time.Second, 2*time.Millisecond), b: newNode(c, time.Second, 20*time.Millisecond), far: newNode(c, time.Minute, 5*time.Millisecond)}
+	t.replicas = []replica.Replica{{Name: "a", Repo: t.a}, {Name: "b", Repo: t.b}, {Name: "far", Repo: t.far}}
+	rw := t.split(t.replicas)
+	manager := employee.NewManager(rw)
+	for _, name := range []string{"Alice", "Bob", "Carol"} {
+		_, _ = manager.AddEmployee(ctx, employee.Employee{Name: name, Salary: money.Of(4000, money.USD)})
+	}
+	c.clk.Advance(2 * time.Minute)
+	return t, rw
+}
+
+// split returns a splitter over the primary and replicas, on the cluster's
+// clock.
+func (t *topology) split(replicas []replica.Replica, opts ...replica.Option) *replica.ReadWriteSplitter {
+	return replica.New(t.prim, replicas, append([]replica.Option{replica.WithClock(t.c.clk)}, opts...)...)
+}
+
+// raise gives Alice 500 more through repo, and returns her salary before
+// and as read back after.
+func raise(ctx context.Context, repo *replica.ReadWriteSplitter) (before, after money.Money) {
+	emp, _ := repo.GetByName(ctx, "Alice")
+	before = emp.Salary
+	emp.Salary, _ = emp.Salary.Add(money.Of(500, money.USD))
+	_ = repo.Save(ctx, emp)
+	emp, _ = repo.GetByName(ctx, "Alice")
+	return before, emp.Salary
+}
+
+func main() {
+	ctx := context.Background()
+	t, rw := build(ctx)
+	clk := t.c.clk
+
+	fmt.Println("📖 Writes to the primary, reads to the replicas")
+	for range 3 {
+		for _, name := range []string{"Alice", "Bob", "Carol"} {
+			_, _ = rw.GetByName(ctx, name)
+		}
+	}
+	st := rw.Stats()
+	fmt.Printf("   the Manager hired through the splitter: the primary has %d employees in its log\n", len(t.c.log))
+	fmt.Printf("   round robin spread 9 reads: a %d, b %d, far %d, primary %d\n", st.Replicas["a"], st.Replicas["b"], st.Replicas["far"], st.Primary)
+
+	fmt.Println("⏳ Staleness tolerance")
+	_ = rw.Save(ctx, employee.Employee{Name: "Dave", Salary: money.Of(4500, money.USD)})
+	clk.Advance(1500 * time.Millisecond)
+	missed := 0
+	for range 3 {
+		if _, err := rw.GetByName(ctx, "Dave"); errors.Is(err, employee.ErrNotFound) {
+			missed++
+		}
+	}
+	fmt.Printf("   without a tolerance, Dave, hired 1.5s ago, is missing from %d read in 3: far hasn't applied him\n", missed)
+	fresh := t.split(t.replicas, replica.WithMaxStaleness(time.Second))
+	found := 0
+	for range 6 {
+		if _, err := fresh.GetByName(ctx, "Dave"); err == nil {
+			found++
+		}
+	}
+	st = fresh.Stats()
+	fmt.Printf("   WithMaxStaleness(1s) skips far, 1.5s behind: a %d, b %d, far %d; Dave is found %d times out of 6\n", st.Replicas["a"], st.Replicas["b"], st.Replicas["far"], found)
+	onlyFar := t.split(t.replicas[2:], replica.WithMaxStaleness(time.Second))
+	_, _ = onlyFar.GetByName(ctx, "Dave")
+	st = onlyFar.Stats()
+	fmt.Printf("   with no replica fresh enough, the primary serves the read (stale %d, primary %d)\n", st.Stale, st.Primary)
+
+	fmt.Println("✍️  Reading your own writes")
+	clk.Advance(2 * time.Minute)
+	before, after := raise(ctx, t.split(t.replicas[:2]))
+	fmt.Printf("   without it, a raise from %v reads back as %v: the replica hasn't seen it\n", before, after)
+	clk.Advance(2 * time.Second)
+	ryw := t.split(t.replicas[:2], replica.WithReadYourWrites(5*time.Second))
+	before, after = raise(ctx, ryw)
+	fmt.Printf("   with WithReadYourWrites(5s), a raise from %v reads back as %v, from the primary\n", before, after)
+	clk.Advance(5 * time.Second)
+	_, _ = ryw.GetByName(ctx, "Alice")
+	fmt.Printf("   5s later her reads are back on the replicas: %d read from the primary\n", ryw.Stats().Primary)
+
+	fmt.Println("🐇 Latency-aware routing")
+	latency := &replica.LatencyAware{}
+	fast := t.split(t.replicas, replica.WithPolicy(latency))
+	for range 100 {
+		_, _ = fast.GetByName(ctx, "Bob")
+	}
+	st = fast.Stats()
+	fmt.Printf("   a (2ms) serves %d of 100 reads, b (20ms) %d, far (5ms) %d\n", st.Replicas["a"], st.Replicas["b"], st.Replicas["far"])
+	t.a.down = true
+	for range 100 {
+		_, _ = fast.GetByName(ctx, "Bob")
+	}
+	down := fast.Stats()
+	fmt.Printf("   a goes down: %d reads fell back to the primary, far took over with %d\n", down.Fallbacks, down.Replicas["far"]-st.Replicas["far"])
+	t.a.down = false
+	for range 100 {
+		_, _ = fast.GetByName(ctx, "Bob")
+	}
+	back := fast.Stats()
+	d, _ := latency.Latency("a")
+	fmt.Printf("   a is back: the next exploring read finds it at %v, and it serves %d of the next 100\n", d, back.Replicas["a"]-down.Replicas["a"])
+}
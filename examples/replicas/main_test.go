@@ -0,0 +1,116 @@
+package main
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"go-solid/employee"
+	"go-solid/money"
+	"go-solid/replica"
+)
+
+func TestReadWriteSplitter_RoundRobin(t *testing.T) {
+	topo, rw := build(t.Context())
+	if len(topo.c.log) != 3 {
+		t.Fatalf("the primary's log has %d writes, want the 3 hires", len(topo.c.log))
+	}
+	for range 3 {
+		for _, name := range []string{"Alice", "Bob", "Carol"} {
+			if _, err := rw.GetByName(t.Context(), name); err != nil {
+				t.Fatalf("GetByName(%s) error = %v", name, err)
+			}
+		}
+	}
+	st := rw.Stats()
+	for _, name := range []string{"a", "b", "far"} {
+		if st.Replicas[name] != 3 {
+			t.Errorf("Stats().Replicas[%s] = %d, want 3 of the 9 reads", name, st.Replicas[name])
+		}
+	}
+	if st.Primary != 0 {
+		t.Errorf("Stats().Primary = %d, want no read on the primary", st.Primary)
+	}
+}
+
+func TestReadWriteSplitter_MaxStaleness(t *testing.T) {
+	topo, rw := build(t.Context())
+	_ = rw.Save(t.Context(), employee.Employee{Name: "Dave", Salary: money.Of(4500, money.USD)})
+	topo.c.clk.Advance(1500 * time.Millisecond)
+	missed := 0
+	for range 3 {
+		if _, err := rw.GetByName(t.Context(), "Dave"); errors.Is(err, employee.ErrNotFound) {
+			missed++
+		}
+	}
+	if missed != 1 {
+		t.Errorf("without a tolerance Dave was missing from %d reads in 3, want 1: far's", missed)
+	}
+
+	fresh := topo.split(topo.replicas, replica.WithMaxStaleness(time.Second))
+	for range 6 {
+		if _, err := fresh.GetByName(t.Context(), "Dave"); err != nil {
+			t.Errorf("GetByName(Dave) error = %v, want far skipped", err)
+		}
+	}
+	if got := fresh.Stats().Replicas["far"]; got != 0 {
+		t.Errorf("far served %d reads, want none: it is 1.5s behind", got)
+	}
+
+	onlyFar := topo.split(topo.replicas[2:], replica.WithMaxStaleness(time.Second))
+	if _, err := onlyFar.GetByName(t.Context(), "Dave"); err != nil {
+		t.Errorf("GetByName(Dave) error = %v, want the primary to serve it", err)
+	}
+	if st := onlyFar.Stats(); st.Stale != 1 || st.Primary != 1 {
+		t.Errorf("Stats() = stale %d, primary %d, want 1 and 1", st.Stale, st.Primary)
+	}
+}
+
+func TestReadWriteSplitter_ReadYourWrites(t *testing.T) {
+	topo, _ := build(t.Context())
+	if before, after := raise(t.Context(), topo.split(topo.replicas[:2])); after != before {
+		t.Errorf("without read-your-writes the raise read back as %v, want the replica's stale %v", after, before)
+	}
+	topo.c.clk.Advance(2 * time.Second)
+	ryw := topo.split(topo.replicas[:2], replica.WithReadYourWrites(5*time.Second))
+	if _, after := raise(t.Context(), ryw); after != money.Of(5000, money.USD) {
+		t.Errorf("with read-your-writes the raise read back as %v, want USD 5000.00", after)
+	}
+	if got := ryw.Stats().Primary; got != 1 {
+		t.Errorf("Stats().Primary = %d, want the read after the write on the primary", got)
+	}
+	// the repositories take " ALICE" for Alice, so the window does too
+	if emp, err := ryw.GetByName(t.Context(), " ALICE"); err != nil || emp.Salary != money.Of(5000, money.USD) {
+		t.Errorf("GetByName(\" ALICE\") = %v, %v, want the raise from the primary", emp.Salary, err)
+	}
+	topo.c.clk.Advance(5 * time.Second)
+	_, _ = ryw.GetByName(t.Context(), "Alice")
+	if got := ryw.Stats().Primary; got != 2 {
+		t.Errorf("Stats().Primary = %d 5s later, want reads back on the replicas", got)
+	}
+}
+
+func TestReadWriteSplitter_LatencyAware(t *testing.T) {
+	topo, _ := build(t.Context())
+	latency := &replica.LatencyAware{}
+	fast := topo.split(topo.replicas, replica.WithPolicy(latency))
+	read := func() replica.Stats {
+		for range 100 {
+			_, _ = fast.GetByName(t.Context(), "Bob")
+		}
+		return fast.Stats()
+	}
+	st := read()
+	if st.Replicas["a"] <= 80 {
+		t.Errorf("a, the fastest, served %d of 100 reads, want more than 80", st.Replicas["a"])
+	}
+	topo.a.down = true
+	down := read()
+	if down.Fallbacks > 10 || down.Replicas["far"]-st.Replicas["far"] <= 80 {
+		t.Errorf("with a down: %d fallbacks, far served %d, want few fallbacks and far taking over", down.Fallbacks, down.Replicas["far"]-st.Replicas["far"])
+	}
+	topo.a.down = false
+	if back := read(); back.Replicas["a"]-down.Replicas["a"] <= 60 {
+		t.Errorf("a back up served %d of the next 100, want more than 60", back.Replicas["a"]-down.Replicas["a"])
+	}
+}
@@ -0,0 +1,124 @@
+// Command result writes one use case - raise an employee's salary - twice:
+// with Go's (T, error), and with the experimental result.Result and
+// result.Option. Both are run against the same cases, and main_test.go
+// fails if they disagree; read the two functions to judge which you would
+// rather maintain.
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"go-solid/employee"
+	"go-solid/employee/memory"
+	"go-solid/money"
+	"go-solid/result"
+)
+
+// raise ✅ idiomatic Go: an if per step, the error wrapped with its context
+func raise(ctx context.Context, repo employee.Repository, name string, amount money.Money) (money.Money, error) {
+	emp, err := repo.GetByName(ctx, name)
+	if err != nil {
+		return money.Money{}, fmt.Errorf("raise %s: %w", name, err)
+	}
+	emp.Salary, err = emp.Salary.Add(amount)
+	if err != nil {
+		return money.Money{}, fmt.Errorf("raise %s: %w", name, err)
+	}
+	if err := repo.Save(ctx, emp); err != nil {
+		return money.Money{}, fmt.Errorf("raise %s: %w", name, err)
+	}
+	return emp.Salary, nil
+}
+
+// raiseResult 🧪 the same steps chained with Then. No if err != nil, but
+// every step is a closure, the type of each stage is spelt out, and Save and
+// Add still speak (T, error).
+func raiseResult(ctx context.Context, repo result.Repository, name string, amount money.Money) result.Result[money.Money] {
+	found := result.Then(repo.GetByName(ctx, name), func(o result.Option[employee.Employee]) result.Result[employee.Employee] {
+		return result.OkOr(o, employee.ErrNotFound)
+	})
+	raised := result.Then(found, func(emp employee.Employee) result.Result[employee.Employee] {
+		return result.Map(result.Of(emp.Salary.Add(amount)), func(s money.Money) employee.Employee {
+			emp.Salary = s
+			return emp
+		})
+	})
+	saved := result.Then(raised, func(emp employee.Employee) result.Result[money.Money] {
+		if err := repo.Save(ctx, emp); err != nil {
+			return result.Fail[money.Money](err)
+		}
+		return result.Ok(emp.Salary)
+	})
+	if err := saved.Err(); err != nil {
+		return result.Fail[money.Money](fmt.Errorf("raise %s: %w", name, err)) // wrapping is still by hand
+	}
+	return saved
+}
+
+// title 🧪 where Option reads well: absence is a normal answer with a default
+func title(ctx context.Context, repo result.Repository, name string) (string, error) {
+	return result.Map(repo.GetByName(ctx, name), func(o result.Option[employee.Employee]) string {
+		return o.Or(employee.Employee{Title: "(not on staff)"}).Title
+	}).Get()
+}
+
+// down A backend that fails every read
+type down struct{ employee.Repository }
+
+func (down) GetByName(context.Context, string) (employee.Employee, error) {
+	return employee.Employee{}, errors.New("connection refused")
+}
+
+// cases Raises both styles must agree on
+var cases = []struct {
+	what   string
+	name   string
+	amount money.Money
+}{
+	{"a raise in dollars", "Alice", money.Of(500, money.USD)},
+	{"a stranger", "Bob", money.Of(500, money.USD)},
+	{"a raise in euros", "Alice", money.Of(500, money.EUR)},
+}
+
+func main() {
+	ctx := context.Background()
+	repo := memory.New()
+	hireAlice := func() {
+		_ = repo.Save(ctx, employee.Employee{Name: "Alice", Title: "Engineer", Salary: money.Of(5000, money.USD)})
+	}
+
+	fmt.Println("⚖️  One use case, two styles")
+	for _, c := range cases {
+		hireAlice() // both styles start from the same salary
+		want, wantErr := raise(ctx, repo, c.name, c.amount)
+		hireAlice()
+		got, gotErr := raiseResult(ctx, result.Adapt(repo), c.name, c.amount).Get()
+		fmt.Printf("   %s\n      (T, error): %v\n      Result:     %v\n", c.what, outcome(want, wantErr), outcome(got, gotErr))
+	}
+
+	fmt.Println("🔍 Absence is not failure")
+	hireAlice()
+	t, _ := title(ctx, result.Adapt(repo), "Alice")
+	fmt.Println("   Alice's title:", t)
+	t, _ = title(ctx, result.Adapt(repo), "Bob")
+	fmt.Println("   Bob is Ok(None), shown as", t)
+	_, err := title(ctx, result.Adapt(down{repo}), "Alice")
+	fmt.Println("   a backend failure stays an error:", err)
+	// ❌ with (Employee, error) the same split is an errors.Is(err,
+	// employee.ErrNotFound) that the signature doesn't mention
+
+	fmt.Println("📏 Verdict")
+	fmt.Println("   • Option says in the signature what ErrNotFound says in a doc comment")
+	fmt.Println("   • Result restates (T, error) with closures, and converts at every boundary")
+	fmt.Println("   • the rest of this module keeps (T, error): it is what every Go reader expects")
+}
+
+// outcome shows a salary, or the error instead of one.
+func outcome(salary money.Money, err error) string {
+	if err != nil {
+		return err.Error()
+	}
+	return salary.String()
+}
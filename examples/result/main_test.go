@@ -0,0 +1,63 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"go-solid/employee"
+	"go-solid/employee/memory"
+	"go-solid/money"
+	"go-solid/result"
+)
+
+func TestBothStylesAgree(t *testing.T) {
+	for _, c := range cases {
+		t.Run(c.what, func(t *testing.T) {
+			alice := employee.Employee{Name: "Alice", Title: "Engineer", Salary: money.Of(5000, money.USD)}
+			repo := memory.New()
+			_ = repo.Save(t.Context(), alice)
+			want, wantErr := raise(t.Context(), repo, c.name, c.amount)
+			_ = repo.Save(t.Context(), alice)
+			got, gotErr := raiseResult(t.Context(), result.Adapt(repo), c.name, c.amount).Get()
+			if got != want || fmt.Sprint(gotErr) != fmt.Sprint(wantErr) {
+				t.Errorf("raiseResult() = %v, %v; raise() = %v, %v", got, gotErr, want, wantErr)
+			}
+		})
+	}
+}
+
+func TestRaise(t *testing.T) {
+	repo := memory.New()
+	_ = repo.Save(t.Context(), employee.Employee{Name: "Alice", Salary: money.Of(5000, money.USD)})
+	if got, err := raise(t.Context(), repo, "Alice", money.Of(500, money.USD)); err != nil || got != money.Of(5500, money.USD) {
+		t.Errorf("raise() = %v, %v; want 5500 USD", got, err)
+	}
+	if _, err := raise(t.Context(), repo, "Bob", money.Of(500, money.USD)); !errors.Is(err, employee.ErrNotFound) {
+		t.Errorf("raise(Bob) error = %v, want %v", err, employee.ErrNotFound)
+	}
+}
+
+func TestTitle(t *testing.T) {
+	repo := memory.New()
+	_ = repo.Save(t.Context(), employee.Employee{Name: "Alice", Title: "Engineer", Salary: money.Of(5000, money.USD)})
+	tests := []struct {
+		name    string
+		repo    result.Repository
+		who     string
+		want    string
+		wantErr bool
+	}{
+		{"on staff", result.Adapt(repo), "Alice", "Engineer", false},
+		{"absent is Ok(None)", result.Adapt(repo), "Bob", "(not on staff)", false},
+		{"a backend failure stays an error", result.Adapt(down{repo}), "Alice", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := title(t.Context(), tt.repo, tt.who)
+			if (err != nil) != tt.wantErr || got != tt.want {
+				t.Errorf("title(%s) = %q, %v; want %q, error %v", tt.who, got, err, tt.want, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,91 @@
+// Command review scores the same review cycle with each review.Scorer - a
+// manager's rating, OKRs, 360-degree feedback and a blend of two - puts the
+// scores on another scale, and writes the results through the codecs the
+// export uses, masked by a redaction policy. main_test.go checks every
+// score.
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"go-solid/codec"
+	"go-solid/crypto"
+	"go-solid/redact"
+	"go-solid/review"
+)
+
+var cycle = []review.Review{
+	{Employee: "Alice", Cycle: "2025-H1", Evidence: review.Evidence{
+		Rating: 4,
+		Objectives: []review.Objective{
+			{Title: "Ship the new payroll engine", Weight: 2, KeyResults: []review.KeyResult{
+				{Title: "All countries migrated", Progress: 0.8},
+				{Title: "p99 run under a minute", Progress: 0.6},
+			}},
+			{Title: "Grow the team", Weight: 1, KeyResults: []review.KeyResult{{Title: "Two hires", Progress: 1}}},
+		},
+		Feedback: []review.Feedback{
+			{From: "Bob", Relation: review.Manager, Rating: 4},
+			{From: "Carol", Relation: review.Peer, Rating: 5},
+			{From: "Dan", Relation: review.Peer, Rating: 4},
+			{From: "Erin", Relation: review.Report, Rating: 3},
+			{From: "Alice", Relation: review.Self, Rating: 5},
+		},
+	}},
+	{Employee: "Dan", Cycle: "2025-H1", Evidence: review.Evidence{
+		Rating: 3,
+		Feedback: []review.Feedback{
+			{From: "Bob", Relation: review.Manager, Rating: 3},
+			{From: "Alice", Relation: review.Peer, Rating: 3},
+			{From: "Carol", Relation: review.Peer, Rating: 2},
+		},
+	}},
+}
+
+func main() {
+	fmt.Println("🧮 One cycle, each strategy")
+	for _, scorer := range []review.Scorer{review.SimpleRating{}, review.OKR{}, review.ThreeSixty{}} {
+		results, err := review.NewEvaluator(scorer).EvaluateAll(cycle)
+		for _, r := range results {
+			fmt.Printf("      %-6s %-28s %s\n", scorer.Name(), r, r.Notes)
+		}
+		if err != nil {
+			fmt.Printf("      %-6s %v\n", scorer.Name(), err)
+		}
+	}
+	_, err := review.NewEvaluator(review.SimpleRating{}).Evaluate(review.Review{Employee: "Erin", Evidence: review.Evidence{Rating: 7}})
+	fmt.Println("   rating: a 7 on a five-point scale is refused:", err)
+
+	fmt.Println("🧩 A blend is a Scorer too (OCP)")
+	blend := review.Blend{{Scorer: review.OKR{}, Weight: 0.5}, {Scorer: review.ThreeSixty{}, Weight: 0.5}}
+	blended, _ := review.NewEvaluator(blend).EvaluateAll(cycle)
+	for _, r := range blended {
+		fmt.Printf("      %-10s %-28s %s\n", r.Method, r, r.Notes)
+	}
+	fmt.Println("   Alice is half OKR, half 360; Dan had no OKRs, so is all 360")
+
+	fmt.Println("📏 Another scale, the same scores (SRP)")
+	threePoint := review.Bands{{Min: 4, Label: "above"}, {Min: 2.5, Label: "on track"}, {Min: 0, Label: "needs support"}}
+	results, _ := review.NewEvaluator(review.ThreeSixty{}, review.WithBands(threePoint)).EvaluateAll(cycle)
+	for _, r := range results {
+		fmt.Printf("      %-10s %s\n", r.Method, r)
+	}
+
+	fmt.Println("📄 The report, in any codec")
+	csv, _ := codec.Lookup("csv")
+	var out bytes.Buffer
+	_ = review.WriteReport(&out, csv, blended)
+	for line := range strings.Lines(out.String()) {
+		fmt.Printf("      %s", line)
+	}
+	jsonl, _ := codec.Lookup("jsonl")
+	out.Reset()
+	fmt.Println("   JSONL through redact.Strict: scores, but not whose")
+	strict, _ := redact.Strict(crypto.NewKey())
+	_ = review.WriteReport(&out, redact.NewCodec(jsonl, strict), blended)
+	for line := range strings.Lines(out.String()) {
+		fmt.Printf("      %s", line)
+	}
+}
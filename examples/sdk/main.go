@@ -0,0 +1,199 @@
+// Command sdk uses the REST client `solid gen client` writes from
+// httpapi.EmployeeService over the routes its annotations give, committed in
+// client/ and regenerated with go generate. It checks the client, and the
+// gRPC service the grpcapi module generates from the same interface, are
+// still what the generator writes, that the annotations agree with the
+// routes the server has, and runs one script in process and over the client,
+// expecting the same answers. The gRPC client runs it in grpcapi's tests,
+// which need gRPC itself. Run it from the module root. main_test.go checks
+// every claim.
+package main
+
+//go:generate go run ../../cmd/solid gen client -iface httpapi.EmployeeService -transport rest -o client/rest.go
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"go-solid/clock"
+	"go-solid/employee"
+	"go-solid/employee/memory"
+	"go-solid/examples/sdk/client"
+	"go-solid/gen"
+	"go-solid/httpapi"
+	"go-solid/id"
+	"go-solid/money"
+)
+
+// newManager is a Manager whose IDs and clock are the same on every run, so
+// answers can be compared across transports.
+func newManager() *employee.Manager {
+	return employee.NewManager(memory.New(),
+		employee.WithClock(clock.NewFake(time.Date(2026, time.March, 2, 9, 0, 0, 0, time.UTC))),
+		employee.WithIDs(id.NewSequence("emp-")))
+}
+
+// script runs the same use cases against svc and writes down every answer.
+func script(ctx context.Context, svc httpapi.EmployeeService) []string {
+	var answers []string
+	show := func(what string, emp employee.Employee, err error) {
+		answers = append(answers, fmt.Sprintf("%s: %s %s %s %s %s %s %s, %v", what,
+			emp.ID, emp.Name, emp.Title, emp.Department, emp.Email, emp.Salary, emp.HiredAt.Format(time.RFC3339), err))
+	}
+	emp, err := svc.AddEmployee(ctx, employee.Employee{Name: "Mona", Title: "Engineer", Department: "Platform", Email: "mona@example.com", Salary: money.Of(5000, money.USD)})
+	show("hire", emp, err)
+	_, _ = svc.AddEmployee(ctx, employee.Employee{Name: "Omar", Title: "Analyst", Salary: money.Of(4000, money.USD)})
+	emp, err = svc.FindEmployee(ctx, "Mona")
+	show("find", emp, err)
+	emp, err = svc.ChangeSalary(ctx, "Mona", money.Of(5500, money.USD))
+	show("pay", emp, err)
+	emp, err = svc.Promote(ctx, "Mona", "Senior Engineer", money.Of(500, money.USD))
+	show("promote", emp, err)
+	page, err := svc.ListEmployees(ctx, employee.Filter{MinSalary: money.Of(4500, money.USD), Sort: employee.SortBySalary}, employee.Page{Limit: 10})
+	var names []string
+	for _, e := range page.Items {
+		names = append(names, e.Name)
+	}
+	answers = append(answers, fmt.Sprintf("earning 4500 or more: %v, %v", names, err))
+	err = svc.RemoveEmployee(ctx, "Omar")
+	_, found := svc.FindEmployee(ctx, "Omar")
+	answers = append(answers, fmt.Sprintf("remove: %v, then not found: %v", err, errors.Is(found, employee.ErrNotFound)))
+	return answers
+}
+
+// annotated One //api:rest annotation, as the server should have it
+type annotated struct {
+	method, path string
+	status       string
+}
+
+func parse(line string) annotated {
+	fields := strings.Fields(strings.TrimPrefix(line, "rest"))
+	a := annotated{method: strings.ToLower(fields[0]), path: fields[1], status: "200"}
+	if !slices.Contains(fields, "->") {
+		a.status = "204"
+	}
+	if last := fields[len(fields)-1]; last[0] >= '0' && last[0] <= '9' {
+		a.status = last
+	}
+	return a
+}
+
+// fresh reports whether the REST client, and the .proto and Go of the gRPC
+// service, committed under root are what the generator writes from svc
+// today.
+func fresh(svc gen.Service, root string) (rest, grpc bool, err error) {
+	restSrc, err := gen.RESTClient(svc, "client")
+	if err != nil {
+		return false, false, err
+	}
+	protoSrc, err := gen.Proto(svc.Iface, "employee", "go-solid/grpcapi/employeepb")
+	if err != nil {
+		return false, false, err
+	}
+	grpcSrc, err := gen.GRPC(svc.Iface, "grpcapi", "go-solid/grpcapi/employeepb")
+	if err != nil {
+		return false, false, err
+	}
+	committedREST, _ := os.ReadFile(filepath.Join(root, "examples", "sdk", "client", "rest.go"))
+	committedProto, _ := os.ReadFile(filepath.Join(root, "grpcapi", "employeepb", "employee.proto"))
+	committedGRPC, _ := os.ReadFile(filepath.Join(root, "grpcapi", "employee.go"))
+	return bytes.Equal(restSrc, committedREST), bytes.Equal(protoSrc, committedProto) && bytes.Equal(grpcSrc, committedGRPC), nil
+}
+
+// unserved returns the methods of svc whose annotation names a route, or a
+// status, the server's OpenAPI document doesn't have.
+func unserved(svc gen.Service) []string {
+	api := httpapi.New(newManager())
+	rec := httptest.NewRecorder()
+	api.ServeHTTP(rec, httptest.NewRequest("GET", "/openapi.json", nil))
+	var doc httpapi.Document
+	_ = json.Unmarshal(rec.Body.Bytes(), &doc)
+	var missing []string
+	for method, lines := range svc.Annotations {
+		a := parse(lines[0])
+		if _, ok := doc.Paths[a.path][a.method].Responses[a.status]; !ok {
+			missing = append(missing, fmt.Sprintf("%s: %s %s answering %s", method, strings.ToUpper(a.method), a.path, a.status))
+		}
+	}
+	slices.Sort(missing)
+	return missing
+}
+
+// misannotated generates a REST client from svc with ChangeSalary annotated
+// as annotation instead.
+func misannotated(svc gen.Service, annotation string) error {
+	bad := gen.Service{Iface: svc.Iface, Annotations: maps.Clone(svc.Annotations)}
+	bad.Annotations["ChangeSalary"] = []string{annotation}
+	_, err := gen.RESTClient(bad, "client")
+	return err
+}
+
+// A nameless hire, refused by the Manager wherever it is sent from
+var nameless = employee.Employee{Salary: money.Of(1, money.USD)}
+
+func main() {
+	ctx := context.Background()
+
+	fmt.Println("🛠️  One interface, a REST client and a gRPC service generated from it")
+	svc, err := gen.FindService(ctx, ".", "httpapi.EmployeeService")
+	if err != nil {
+		fmt.Println("❌", err)
+		return
+	}
+	fmt.Printf("   httpapi.EmployeeService found, with %d annotations\n", len(svc.Annotations))
+	rest, grpc, err := fresh(svc, ".")
+	if err != nil {
+		fmt.Println("❌", err)
+		return
+	}
+	fmt.Println("   client/rest.go is what the generator writes today:", rest)
+	fmt.Println("   grpcapi's .proto and both ends of it too:", grpc)
+	missing := unserved(svc)
+	fmt.Printf("   %d of %d annotations are routes the server serves, with that status\n", len(svc.Annotations)-len(missing), len(svc.Annotations))
+	for _, m := range missing {
+		fmt.Println("      the server has no", m)
+	}
+	fmt.Println("   an annotation the method can't fill is refused:",
+		misannotated(svc, "rest PUT /employees/{name}/salary PromotionRequest -> EmployeeDTO"))
+	fmt.Println("   so is a path naming no parameter:",
+		misannotated(svc, "rest PUT /employees/{id}/salary SalaryRequest -> EmployeeDTO"))
+
+	fmt.Println("🌐 The same script, in process and over the client (LSP)")
+	want := script(ctx, newManager())
+	for _, line := range want {
+		fmt.Printf("      %s\n", line)
+	}
+	web := httptest.NewServer(httpapi.New(newManager()))
+	defer web.Close()
+	viaREST := client.NewEmployeeServiceRESTClient(web.URL, nil)
+	got := script(ctx, viaREST)
+	fmt.Println("   over REST, every answer is the same:", slices.Equal(got, want))
+	diff(got, want)
+	fmt.Println("   over gRPC it runs in grpcapi's tests: cd grpcapi && go test ./...")
+
+	fmt.Println("🧯 Errors keep their meaning, as far as a status can carry it")
+	_, err = viaREST.AddEmployee(ctx, nameless)
+	fmt.Println("   a nameless hire is a 400, which several errors share, so only the status is kept:", err)
+	_, err = viaREST.FindEmployee(ctx, "Nobody")
+	fmt.Println("   a missing employee is employee.ErrNotFound:", err)
+	fmt.Println("   over gRPC, every sentinel is kept: the status names it")
+}
+
+func diff(got, want []string) {
+	for i := range min(len(got), len(want)) {
+		if got[i] != want[i] {
+			fmt.Printf("      got  %s\n      want %s\n", got[i], want[i])
+		}
+	}
+}
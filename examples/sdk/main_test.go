@@ -0,0 +1,81 @@
+package main
+
+import (
+	"errors"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+
+	"go-solid/employee"
+	"go-solid/examples/sdk/client"
+	"go-solid/gen"
+	"go-solid/httpapi"
+)
+
+// root The module root, two directories up from this example
+const root = "../.."
+
+func service(t *testing.T) gen.Service {
+	t.Helper()
+	svc, err := gen.FindService(t.Context(), root, "httpapi.EmployeeService")
+	if err != nil {
+		t.Fatalf("FindService() error = %v", err)
+	}
+	if len(svc.Annotations) != 6 {
+		t.Fatalf("FindService() = %d annotations, want 6", len(svc.Annotations))
+	}
+	return svc
+}
+
+func TestClients_AreFresh(t *testing.T) {
+	rest, grpc, err := fresh(service(t), root)
+	if err != nil || !rest || !grpc {
+		t.Errorf("fresh() = %v, %v, %v, want the REST client and the gRPC service what the generator writes; run go generate here and in grpcapi", rest, grpc, err)
+	}
+}
+
+func TestAnnotations_AreServed(t *testing.T) {
+	if missing := unserved(service(t)); len(missing) > 0 {
+		t.Errorf("the server has no %s", strings.Join(missing, "; "))
+	}
+}
+
+func TestRESTClient_RefusesMisannotations(t *testing.T) {
+	svc := service(t)
+	tests := []struct {
+		annotation string
+		want       string
+	}{
+		{"rest PUT /employees/{name}/salary PromotionRequest -> EmployeeDTO", "no parameter for PromotionRequest.Title"},
+		{"rest PUT /employees/{id}/salary SalaryRequest -> EmployeeDTO", "no parameter id"},
+	}
+	for _, tt := range tests {
+		if err := misannotated(svc, tt.annotation); err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("RESTClient(%q) error = %v, want %q", tt.annotation, err, tt.want)
+		}
+	}
+}
+
+func TestClient_AnswersAsInProcess(t *testing.T) {
+	want := script(t.Context(), newManager())
+	web := httptest.NewServer(httpapi.New(newManager()))
+	defer web.Close()
+	if got := script(t.Context(), client.NewEmployeeServiceRESTClient(web.URL, nil)); !slices.Equal(got, want) {
+		t.Errorf("script() over rest = %q, want %q", got, want)
+	}
+}
+
+func TestClient_KeepsErrors(t *testing.T) {
+	web := httptest.NewServer(httpapi.New(newManager()))
+	defer web.Close()
+	viaREST := client.NewEmployeeServiceRESTClient(web.URL, nil)
+
+	var status *httpapi.Error
+	if _, err := viaREST.AddEmployee(t.Context(), nameless); !errors.As(err, &status) || status.Status != 400 || errors.Is(err, employee.ErrInvalidName) {
+		t.Errorf("AddEmployee() error = %v, want a 400 and nothing more", err)
+	}
+	if _, err := viaREST.FindEmployee(t.Context(), "Nobody"); !errors.Is(err, employee.ErrNotFound) {
+		t.Errorf("FindEmployee() error = %v, want %v", err, employee.ErrNotFound)
+	}
+}
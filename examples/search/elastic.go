@@ -0,0 +1,19 @@
+//go:build elasticsearch
+
+package main
+
+import (
+	"cmp"
+	"os"
+
+	"go-solid/search"
+	"go-solid/search/elastic"
+)
+
+// newSearcher With -tags elasticsearch the example runs against a real cluster:
+//
+//	ELASTICSEARCH_URL=http://localhost:9200 go run -tags elasticsearch ./examples/search
+func newSearcher() (search.EmployeeSearcher, string) {
+	url := cmp.Or(os.Getenv("ELASTICSEARCH_URL"), "http://localhost:9200")
+	return &elastic.Searcher{URL: url, IndexName: "employees"}, "Elasticsearch at " + url
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"go-solid/employee"
+	"go-solid/employee/memory"
+	"go-solid/money"
+	"go-solid/search"
+)
+
+func main() {
+	ctx := context.Background()
+	repo := memory.New()
+	for i, e := range []struct{ name, title string }{
+		{"Alice Martin", "Engineering Manager"},
+		{"Bob Engel", "Sales Engineer"},
+		{"Carol Manning", "Senior Software Engineer"},
+		{"Dave Marsh", "Product Manager"},
+		{"Eve Engstrom", "Recruiter"},
+	} {
+		_ = repo.Save(ctx, employee.Employee{ID: employee.ID(fmt.Sprintf("emp-%d", i+1)), Name: e.name, Title: e.title, Salary: money.Of(5000, money.USD)})
+	}
+
+	// ✅ The rest of main only knows search.EmployeeSearcher; the build tag picks the engine
+	searcher, engine := newSearcher()
+	n, err := search.Reindex(ctx, repo, searcher)
+	if err != nil {
+		fmt.Println("❌", err)
+		return
+	}
+	fmt.Printf("🔎 %d employees indexed in %s\n", n, engine)
+
+	for _, text := range []string{"eng", "engineering man", "MAN", "mar", "software eng", "nobody"} {
+		hits, err := searcher.Search(ctx, search.Query{Text: text})
+		if err != nil {
+			fmt.Println("❌", err)
+			return
+		}
+		fmt.Printf("\n%q\n", text)
+		if len(hits) == 0 {
+			fmt.Println("   (no match)")
+		}
+		for _, h := range hits {
+			fmt.Printf("   %-14s %-26s %.2f\n", h.Name, h.Title, h.Score)
+		}
+	}
+
+	fmt.Println("\n🗑️  Dave leaves; \"man\" no longer finds him")
+	_ = searcher.Remove(ctx, "Dave Marsh")
+	hits, _ := searcher.Search(ctx, search.Query{Text: "man"})
+	for _, h := range hits {
+		fmt.Printf("   %s\n", h.Name)
+	}
+}
@@ -0,0 +1,10 @@
+//go:build !elasticsearch
+
+package main
+
+import (
+	"go-solid/search"
+	"go-solid/search/memory"
+)
+
+func newSearcher() (search.EmployeeSearcher, string) { return memory.New(), "memory" }
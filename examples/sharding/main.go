@@ -0,0 +1,193 @@
+// Command sharding spreads a thousand employees over four in-memory shards
+// with shard.ShardedRepository, and shows what a sharded store must
+// guarantee: every name routes to the same shard every time, listings merged
+// from all shards match a single store's, and growing to five shards moves as
+// few employees as the key function allows - Jump against Modulo.
+// main_test.go checks every claim.
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"go-solid/employee"
+	"go-solid/employee/memory"
+	"go-solid/money"
+	"go-solid/shard"
+)
+
+// picky A shard rejecting one employee, to follow a failure through SaveAll
+type picky struct{ *memory.Repository }
+
+func (p picky) Save(ctx context.Context, emp employee.Employee) error {
+	if emp.Name == "Mallory" {
+		return errors.New("rejected by the shard")
+	}
+	return p.Repository.Save(ctx, emp)
+}
+
+// SaveAll hides memory's, so employee.SaveAll calls Save for each.
+func (picky) SaveAll() {}
+
+func memories(n int) []employee.Repository {
+	var shards []employee.Repository
+	for range n {
+		shards = append(shards, memory.New())
+	}
+	return shards
+}
+
+// count returns how many employees each shard holds.
+func count(ctx context.Context, shards []employee.Repository) []int {
+	counts := make([]int, len(shards))
+	for i, s := range shards {
+		for range employee.All(ctx, s) {
+			counts[i]++
+		}
+	}
+	return counts
+}
+
+// moved returns how many of names change shard from n to n+1 shards.
+func moved(key shard.ShardKeyFunc, names []string, n int) int {
+	m := 0
+	for _, name := range names {
+		if key(name, n) != key(name, n+1) {
+			m++
+		}
+	}
+	return m
+}
+
+// staff returns a thousand employees, employee-0000 to employee-0999, paid
+// 3000 to 3999.
+func staff() []employee.Employee {
+	var emps []employee.Employee
+	for i := range 1000 {
+		emps = append(emps, employee.Employee{Name: fmt.Sprintf("employee-%04d", i), Salary: money.Of(int64(3000+i), money.USD)})
+	}
+	return emps
+}
+
+func names(emps []employee.Employee) []string {
+	var ns []string
+	for _, e := range emps {
+		ns = append(ns, e.Name)
+	}
+	return ns
+}
+
+// hire spreads staff over four in-memory shards through a Manager.
+func hire(ctx context.Context, staff []employee.Employee) (*shard.ShardedRepository, []employee.Repository) {
+	shards := memories(4)
+	repo := shard.New(shards)
+	manager := employee.NewManager(repo)
+	for _, emp := range staff {
+		_, _ = manager.AddEmployee(ctx, emp)
+	}
+	return repo, shards
+}
+
+// found returns how many of staff repo finds by name.
+func found(ctx context.Context, repo employee.Repository, staff []employee.Employee) int {
+	n := 0
+	for _, emp := range staff {
+		if _, err := repo.GetByName(ctx, emp.Name); err == nil {
+			n++
+		}
+	}
+	return n
+}
+
+// stable reports whether each of staff is on the shard it routes to, and a
+// new composite over the same shards routes it there too.
+func stable(ctx context.Context, repo *shard.ShardedRepository, shards []employee.Repository, staff []employee.Employee) bool {
+	again := shard.New(shards)
+	for _, emp := range staff {
+		n := repo.ShardOf(emp.Name)
+		if _, err := shards[n].GetByName(ctx, emp.Name); err != nil || again.ShardOf(emp.Name) != n {
+			return false
+		}
+	}
+	return true
+}
+
+// paged lists everyone in repo by salary, descending, 60 at a time.
+func paged(ctx context.Context, repo employee.QueryRepository) ([]string, error) {
+	filter := employee.Filter{Sort: employee.SortBySalary, Descending: true}
+	var all []string
+	for page := (employee.Page{Limit: 60}); ; {
+		res, err := repo.List(ctx, filter, page)
+		if err != nil {
+			return all, err
+		}
+		all = append(all, names(res.Items)...)
+		if res.NextCursor == "" {
+			return all, nil
+		}
+		page.Cursor = res.NextCursor
+	}
+}
+
+// streamed returns the names repo.All yields.
+func streamed(ctx context.Context, repo *shard.ShardedRepository) []string {
+	var all []string
+	for emp, err := range repo.All(ctx) {
+		if err == nil {
+			all = append(all, emp.Name)
+		}
+	}
+	return all
+}
+
+// saveWithMallory saves four employees over two shards rejecting Mallory,
+// the third.
+func saveWithMallory(ctx context.Context) error {
+	batch := shard.New([]employee.Repository{picky{memory.New()}, picky{memory.New()}})
+	return batch.SaveAll(ctx, slices.Values([]employee.Employee{{Name: "Alice"}, {Name: "Bob"}, {Name: "Mallory"}, {Name: "Carol"}}))
+}
+
+func main() {
+	ctx := context.Background()
+	staff := staff()
+
+	fmt.Println("🧭 Routing")
+	repo, shards := hire(ctx, staff)
+	fmt.Printf("   the Manager hired 1000 through the composite and finds %d\n", found(ctx, repo, staff))
+	fmt.Println("   spread over the shards:", count(ctx, shards))
+	fmt.Println("   each employee is on the shard it routes to, and a new composite over the same shards agrees:", stable(ctx, repo, shards, staff))
+
+	fmt.Println("🧩 One repository made of four")
+	single := memory.New()
+	_ = employee.SaveAll(ctx, single, slices.Values(staff))
+	pages, _ := paged(ctx, repo)
+	want, _ := paged(ctx, single)
+	fmt.Printf("   paging by salary, descending, 60 at a time: %d names, in the same order as one store: %v\n", len(pages), slices.Equal(pages, want))
+	all := streamed(ctx, repo)
+	fmt.Printf("   All merges four sorted streams into one: %d names, sorted: %v\n", len(all), slices.IsSorted(all))
+	rich, _ := repo.Matching(ctx, employee.SalaryAtLeast(money.Of(3990, money.USD)))
+	fmt.Println("   Matching asks every shard:", names(rich))
+	nested := shard.New([]employee.Repository{shard.New(memories(2)), shard.New(memories(2), shard.WithKey(shard.Modulo))})
+	_ = nested.Save(ctx, staff[0])
+	_, err := nested.GetByName(ctx, staff[0].Name)
+	fmt.Println("   a shard can be a ShardedRepository too, as it is an employee.Repository - found:", err == nil)
+
+	fmt.Println("📦 SaveAll splits by shard and reports by input position")
+	fmt.Println("   Mallory fails at index 2 of the input, the other 3 are saved:", saveWithMallory(ctx))
+
+	fmt.Println("📏 Growing from 4 to 5 shards")
+	byJump, byModulo := moved(shard.Jump, names(staff), 4), moved(shard.Modulo, names(staff), 4)
+	fmt.Printf("   Modulo would move %d of 1000 employees\n", byModulo)
+	fmt.Printf("   Jump moves %d: only those the new shard takes, about 1 in 5\n", byJump)
+	grown := append(slices.Clone(shards), memory.New())
+	rep, err := repo.Reshard(ctx, grown, nil)
+	if err != nil {
+		fmt.Println("❌", err)
+		return
+	}
+	fmt.Printf("   Reshard kept %d and copied %d, the same %d Jump predicted\n", rep.Kept, rep.Moved, byJump)
+	fmt.Printf("   %d still found, now spread %v\n", found(ctx, repo, staff), count(ctx, grown))
+	fmt.Printf("   the copies left behind are soft-deleted: All yields %d, not %d\n", len(streamed(ctx, repo)), len(streamed(ctx, repo))+byJump)
+}
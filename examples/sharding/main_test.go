@@ -0,0 +1,97 @@
+package main
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"go-solid/employee"
+	"go-solid/employee/memory"
+	"go-solid/money"
+	"go-solid/shard"
+)
+
+func TestRouting(t *testing.T) {
+	staff := staff()
+	repo, shards := hire(t.Context(), staff)
+	if got := found(t.Context(), repo, staff); got != 1000 {
+		t.Errorf("found() = %d, want 1000", got)
+	}
+	if counts := count(t.Context(), shards); slices.Min(counts) <= 200 || slices.Max(counts) >= 300 {
+		t.Errorf("count() = %v, want about 250 on each shard", counts)
+	}
+	if !stable(t.Context(), repo, shards, staff) {
+		t.Error("stable() = false, want each employee on the shard it routes to, by any composite over the shards")
+	}
+}
+
+func TestShardedRepository_ActsAsOne(t *testing.T) {
+	staff := staff()
+	repo, _ := hire(t.Context(), staff)
+	single := memory.New()
+	if err := employee.SaveAll(t.Context(), single, slices.Values(staff)); err != nil {
+		t.Fatal(err)
+	}
+	got, err := paged(t.Context(), repo)
+	want, _ := paged(t.Context(), single)
+	if err != nil || len(got) != 1000 || !slices.Equal(got, want) {
+		t.Errorf("paged() = %d names, %v, want the same 1000 in the same order as one store", len(got), err)
+	}
+	if all := streamed(t.Context(), repo); len(all) != 1000 || !slices.IsSorted(all) {
+		t.Errorf("All() = %d names, sorted: %v, want 1000, sorted", len(all), slices.IsSorted(all))
+	}
+	if rich, err := repo.Matching(t.Context(), employee.SalaryAtLeast(money.Of(3990, money.USD))); err != nil || len(rich) != 10 {
+		t.Errorf("Matching() = %v, %v, want the 10 paid 3990 or more", names(rich), err)
+	}
+}
+
+func TestShardedRepository_Nests(t *testing.T) {
+	nested := shard.New([]employee.Repository{shard.New(memories(2)), shard.New(memories(2), shard.WithKey(shard.Modulo))})
+	if err := nested.Save(t.Context(), employee.Employee{Name: "Alice"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := nested.GetByName(t.Context(), "Alice"); err != nil {
+		t.Errorf("GetByName() error = %v", err)
+	}
+}
+
+func TestSaveAll_ReportsByInputPosition(t *testing.T) {
+	var report *employee.BulkError
+	err := saveWithMallory(t.Context())
+	if !errors.As(err, &report) || report.Saved != 3 || len(report.Failed) != 1 || report.Failed[0].Index != 2 {
+		t.Errorf("SaveAll() error = %v, want Mallory failed at index 2 and 3 saved", err)
+	}
+}
+
+func TestReshard(t *testing.T) {
+	staff := staff()
+	byJump, byModulo := moved(shard.Jump, names(staff), 4), moved(shard.Modulo, names(staff), 4)
+	if byModulo <= 700 {
+		t.Errorf("moved(Modulo) = %d, want most of 1000", byModulo)
+	}
+	if byJump <= 150 || byJump >= 250 {
+		t.Errorf("moved(Jump) = %d, want about 1 in 5 of 1000", byJump)
+	}
+
+	repo, shards := hire(t.Context(), staff)
+	fifth := memory.New()
+	grown := append(slices.Clone(shards), fifth)
+	rep, err := repo.Reshard(t.Context(), grown, nil)
+	if err != nil || rep.Moved != byJump || rep.Kept != 1000-byJump {
+		t.Errorf("Reshard() = kept %d, moved %d, %v, want %d moved as Jump predicted", rep.Kept, rep.Moved, err, byJump)
+	}
+	if got := found(t.Context(), repo, staff); got != 1000 {
+		t.Errorf("found() = %d after resharding, want 1000", got)
+	}
+	if got := len(streamed(t.Context(), repo)); got != 1000 {
+		t.Errorf("All() = %d names, want 1000 with the copies left behind soft-deleted", got)
+	}
+	if got := count(t.Context(), grown)[4]; got != byJump {
+		t.Errorf("the new shard holds %d, want the %d Jump moved", got, byJump)
+	}
+	for emp := range employee.All(t.Context(), fifth) {
+		if n := repo.ShardOf(emp.Name); n != 4 {
+			t.Errorf("%s on the new shard routes to shard %d, want nothing moved between the old shards", emp.Name, n)
+		}
+	}
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"go-solid/employee"
+	"go-solid/employee/memory"
+	"go-solid/money"
+	"go-solid/spec"
+	"go-solid/sqldialect"
+)
+
+//////////--------------------Bad Practice--------------------/////////////////////////
+
+// ❌ Every new combination of criteria becomes a new repository method
+//type EmployeeRepository interface {
+//	FindByNamePrefix(prefix string) []Employee
+//	FindBySalaryRange(min, max int) []Employee
+//	FindByNamePrefixAndMinSalary(prefix string, min int) []Employee
+//	FindByNamePrefixOrSalaryAbove(prefix string, min int) []Employee
+//	// ...and so on, forever
+//}
+
+//////////////-----------------------------Good Practice-------------------/////////////////////////////////////////////////////////
+
+func main() {
+	ctx := context.Background()
+	manager := employee.NewManager(memory.New())
+	for _, e := range []employee.Employee{
+		{Name: "Mohamed", Salary: money.Of(5000, money.USD)},
+		{Name: "Ahmed", Salary: money.Of(6000, money.USD)},
+		{Name: "Ali", Salary: money.Of(4500, money.USD)},
+		{Name: "Amira", Salary: money.Of(7000, money.USD)},
+		{Name: "Omar", Salary: money.Of(3000, money.USD)},
+	} {
+		_, _ = manager.AddEmployee(ctx, e)
+	}
+
+	// ✅ Rules are small values combined on demand - no new repository method needed
+	wellPaidA := spec.And(employee.NameStartsWith("A"), employee.SalaryAtLeast(money.Of(5000, money.USD)))
+	rule := spec.Or(wellPaidA, spec.Not(employee.SalaryAtLeast(money.Of(4000, money.USD))))
+
+	emps, _ := manager.FindEmployees(ctx, rule)
+	fmt.Println("🔎 (name A* AND salary >= 5000) OR salary < 4000")
+	for _, emp := range emps {
+		fmt.Printf("   %-8s %s\n", emp.Name, emp.Salary)
+	}
+
+	// The same rule is pushed down to SQL backends as a WHERE clause
+	where, args, _ := spec.ToSQL(rule)
+	fmt.Println()
+	fmt.Println("🗄️  Postgres:", sqldialect.Postgres{}.Rebind(where), args)
+
+	// Ad-hoc predicates still work in memory; SQL backends fall back to filtering rows in Go
+	shortName := spec.Func[employee.Employee](func(e employee.Employee) bool { return len(e.Name) <= 3 })
+	_, _, err := spec.ToSQL(spec.And(shortName, employee.SalaryAtLeast(money.Of(1, money.USD))))
+	fmt.Println("⚠️ ", err)
+}
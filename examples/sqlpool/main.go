@@ -0,0 +1,172 @@
+// Command sqlpool measures what sqlrepo's adapter options buy: the same
+// concurrent reads through employee.Repository, against a simulated database
+// that charges for dialing, parsing and round trips, with one connection, a
+// pool, and a pool reusing prepared statements. The callers never change;
+// only the options given to sqlrepo.New do. main_test.go checks what each
+// option changes in the dials, prepares and queries the server counts.
+package main
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go-solid/employee"
+	"go-solid/employee/sqlrepo"
+	"go-solid/sqldialect"
+)
+
+// Costs of the simulated server
+const (
+	dialCost  = 3 * time.Millisecond   // TCP and TLS handshake, authentication
+	parseCost = 400 * time.Microsecond // parsing and planning a statement
+	roundTrip = 400 * time.Microsecond // every request to the server
+)
+
+// server A database a network away. It counts what it was asked to do.
+type server struct {
+	dials, prepares, queries atomic.Int64
+}
+
+func (s *server) Open(string) (driver.Conn, error) {
+	time.Sleep(dialCost)
+	s.dials.Add(1)
+	return &conn{s: s}, nil
+}
+
+// conn One connection. It implements neither driver.QueryerContext nor
+// driver.ExecerContext, as many drivers that prepare on the server do:
+// database/sql prepares every unprepared query, runs it, and closes it.
+type conn struct{ s *server }
+
+func (c *conn) Prepare(query string) (driver.Stmt, error) {
+	time.Sleep(roundTrip + parseCost)
+	c.s.prepares.Add(1)
+	return &stmt{s: c.s}, nil
+}
+
+func (c *conn) Close() error              { return nil }
+func (c *conn) Begin() (driver.Tx, error) { return nil, errors.New("read only") }
+
+type stmt struct{ s *server }
+
+func (s *stmt) Close() error  { return nil }
+func (s *stmt) NumInput() int { return -1 }
+
+func (s *stmt) Exec([]driver.Value) (driver.Result, error) {
+	return nil, errors.New("read only")
+}
+
+func (s *stmt) Query(args []driver.Value) (driver.Rows, error) {
+	time.Sleep(roundTrip)
+	s.s.queries.Add(1)
+	return &rows{name: fmt.Sprint(args[0])}, nil
+}
+
+// rows One employee row, in sqlrepo's column order
+type rows struct {
+	name string
+	done bool
+}
+
+func (r *rows) Columns() []string {
+	return []string{"id", "name", "title", "department", "email", "salary", "currency", "hired_at", "version"}
+}
+
+func (r *rows) Close() error { return nil }
+
+func (r *rows) Next(dest []driver.Value) error {
+	if r.done {
+		return io.EOF
+	}
+	r.done = true
+	copy(dest, []driver.Value{"id-" + r.name, r.name, "Engineer", "Platform", "", int64(500000), "USD", time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC), int64(1)})
+	return nil
+}
+
+// run reads employees through repo in 5 bursts of 16 goroutines reading 5
+// each, the way requests arrive, and returns the reads per second.
+func run(repo employee.Repository) (float64, error) {
+	const bursts, workers, reads = 5, 16, 5
+	var first atomic.Value
+	start := time.Now()
+	for b := range bursts {
+		var wg sync.WaitGroup
+		for w := range workers {
+			wg.Go(func() {
+				for i := range reads {
+					if _, err := repo.GetByName(context.Background(), fmt.Sprintf("employee-%d-%d-%d", b, w, i)); err != nil {
+						first.CompareAndSwap(nil, err)
+					}
+				}
+			})
+		}
+		wg.Wait() // between bursts the pool keeps only its idle connections
+	}
+	err, _ := first.Load().(error)
+	return bursts * workers * reads / time.Since(start).Seconds(), err
+}
+
+// setup One way to configure the adapter
+type setup struct {
+	name string
+	opts []sqlrepo.Option
+}
+
+type measured struct {
+	perSecond                float64
+	dials, prepares, queries int64
+}
+
+var setups = []setup{
+	{"one connection", []sqlrepo.Option{sqlrepo.WithPool(sqlrepo.Pool{MaxOpen: 1})}},
+	{"pool of 8, default idle", []sqlrepo.Option{sqlrepo.WithPool(sqlrepo.Pool{MaxOpen: 8})}},
+	{"pool of 8, 8 idle", []sqlrepo.Option{sqlrepo.WithPool(sqlrepo.Pool{MaxOpen: 8, MaxIdle: 8})}},
+	{"pool of 8, 8 idle, statements", []sqlrepo.Option{sqlrepo.WithPool(sqlrepo.Pool{MaxOpen: 8, MaxIdle: 8}), sqlrepo.WithStatementCache(0)}},
+}
+
+// measure runs the reads against a fresh server through an adapter set up
+// as s.
+func measure(s setup) (measured, error) {
+	srv := &server{}
+	db := sql.OpenDB(connector{srv})
+	defer db.Close()
+	repo := sqlrepo.New(db, sqldialect.MySQL{}, s.opts...)
+	defer repo.Close()
+	perSecond, err := run(repo)
+	return measured{perSecond, srv.dials.Load(), srv.prepares.Load(), srv.queries.Load()}, err
+}
+
+func main() {
+	fmt.Println("🏎️  400 reads in bursts of 16 goroutines, same callers, different adapter options")
+	results := make([]measured, len(setups))
+	for i, s := range setups {
+		m, err := measure(s)
+		if err != nil {
+			fmt.Println("   ❌", err)
+		}
+		results[i] = m
+		fmt.Printf("   %-30s %6.0f reads/s  %3d dials  %3d prepares  %3d queries\n", s.name, m.perSecond, m.dials, m.prepares, m.queries)
+	}
+
+	one, churn, pool, cached := results[0], results[1], results[2], results[3]
+	fmt.Println("🔌 Pool size")
+	fmt.Printf("   8 connections read %.1fx as fast as one\n", pool.perSecond/one.perSecond)
+	fmt.Printf("   with the default of 2 idle, returned connections are closed and redialed: %d dials against %d\n", churn.dials, pool.dials)
+	fmt.Println("📎 Prepared statements")
+	fmt.Printf("   without the cache every read prepares: %d prepares for %d queries\n", pool.prepares, pool.queries)
+	fmt.Printf("   with it, each connection prepares once: %d prepares over %d connections\n", cached.prepares, cached.dials)
+	fmt.Printf("   and reads go %.1fx as fast\n", cached.perSecond/pool.perSecond)
+}
+
+// connector Opens connections to a server, for sql.OpenDB
+type connector struct{ s *server }
+
+func (c connector) Connect(context.Context) (driver.Conn, error) { return c.s.Open("") }
+func (c connector) Driver() driver.Driver                        { return c.s }
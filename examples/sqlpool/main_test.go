@@ -0,0 +1,37 @@
+package main
+
+import "testing"
+
+// TestOptions checks what each option changes in what the server is asked
+// to do. Throughput depends on the machine, so main only prints it.
+func TestOptions(t *testing.T) {
+	results := make([]measured, len(setups))
+	for i, s := range setups {
+		m, err := measure(s)
+		if err != nil {
+			t.Fatalf("%s: %v", s.name, err)
+		}
+		if m.queries != 400 {
+			t.Errorf("%s: %d queries, want 400", s.name, m.queries)
+		}
+		results[i] = m
+	}
+	one, churn, pool, cached := results[0], results[1], results[2], results[3]
+	if one.dials != 1 {
+		t.Errorf("with one connection %d dials, want 1", one.dials)
+	}
+	if pool.dials < 2 || pool.dials > 8 {
+		t.Errorf("with a pool of 8 kept idle %d dials, want the bursts spread over up to 8 connections", pool.dials)
+	}
+	if churn.dials <= pool.dials {
+		t.Errorf("with 2 idle connections %d dials, with 8 %d; want the smaller idle pool redialing", churn.dials, pool.dials)
+	}
+	for _, m := range []measured{one, churn, pool} {
+		if m.prepares != m.queries {
+			t.Errorf("without the statement cache %d prepares for %d queries, want one each", m.prepares, m.queries)
+		}
+	}
+	if cached.prepares > cached.dials {
+		t.Errorf("with the statement cache %d prepares over %d connections, want one per connection at most", cached.prepares, cached.dials)
+	}
+}
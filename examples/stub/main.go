@@ -0,0 +1,58 @@
+package main
+
+//go:generate go run ../../cmd/solid gen stub -iface employee.Repository -o stubs/repository.go
+//go:generate go run ../../cmd/solid gen stub -iface notify.Notifier -o stubs/notifier.go
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"go-solid/employee"
+	"go-solid/examples/stub/stubs"
+	"go-solid/money"
+)
+
+func main() {
+	ctx := context.Background()
+
+	// ✅ A generated stub: plain function fields instead of a mocking DSL
+	repo := &stubs.RepositoryStub{
+		SaveFunc: func(ctx context.Context, emp employee.Employee) error {
+			if emp.Name == "Ali" {
+				return errors.New("duplicate key value violates unique constraint")
+			}
+			return nil
+		},
+	}
+	manager := employee.NewManager(repo)
+
+	fmt.Println("🧪 Hiring through a stubbed repository")
+	_, err := manager.AddEmployee(ctx, employee.Employee{Name: "Mohamed", Salary: money.Of(5000, money.USD)})
+	fmt.Println("   Mohamed:", errString(err))
+	_, err = manager.AddEmployee(ctx, employee.Employee{Name: "Ali", Salary: money.Of(4500, money.USD)})
+	fmt.Println("   Ali:", errString(err))
+
+	// GetByNameFunc is nil, so the stub returns the zero Employee and a nil error
+	emp, err := manager.FindEmployee(ctx, "Ahmed")
+	fmt.Printf("   Ahmed: %q, %s\n", emp.Name, errString(err))
+
+	// ✅ Every call was recorded; in a test, stub.AssertCalled and friends check them
+	fmt.Println("\n📼 Recorded calls")
+	for _, c := range repo.Calls("") {
+		switch arg := c.Args[1].(type) {
+		case employee.Employee:
+			fmt.Printf("   %s(ctx, Employee{Name: %q, Salary: %s})\n", c.Method, arg.Name, arg.Salary)
+		default:
+			fmt.Printf("   %s(ctx, %q)\n", c.Method, arg)
+		}
+	}
+	fmt.Printf("   Save called %d time(s), GetByName %d time(s)\n", repo.Count("Save"), repo.Count("GetByName"))
+}
+
+func errString(err error) string {
+	if err != nil {
+		return "❌ " + err.Error()
+	}
+	return "✅ ok"
+}
@@ -0,0 +1,29 @@
+// Code generated by solid gen stub; DO NOT EDIT.
+
+package stubs
+
+import (
+	"context"
+
+	"go-solid/notify"
+	"go-solid/stub"
+)
+
+// NotifierStub Stub of notify.Notifier: set a ...Func field to decide what a method
+// does; methods left nil return zero values. Every call is recorded.
+type NotifierStub struct {
+	stub.Recorder
+
+	NotifyFunc func(ctx context.Context, msg notify.Message) error
+}
+
+func (s *NotifierStub) Notify(ctx context.Context, msg notify.Message) error {
+	s.Record("Notify", ctx, msg)
+	if s.NotifyFunc != nil {
+		return s.NotifyFunc(ctx, msg)
+	}
+	var r0 error
+	return r0
+}
+
+var _ notify.Notifier = (*NotifierStub)(nil)
@@ -0,0 +1,40 @@
+// Code generated by solid gen stub; DO NOT EDIT.
+
+package stubs
+
+import (
+	"context"
+
+	"go-solid/employee"
+	"go-solid/stub"
+)
+
+// RepositoryStub Stub of employee.Repository: set a ...Func field to decide what a method
+// does; methods left nil return zero values. Every call is recorded.
+type RepositoryStub struct {
+	stub.Recorder
+
+	GetByNameFunc func(ctx context.Context, name string) (employee.Employee, error)
+	SaveFunc      func(ctx context.Context, emp employee.Employee) error
+}
+
+func (s *RepositoryStub) GetByName(ctx context.Context, name string) (employee.Employee, error) {
+	s.Record("GetByName", ctx, name)
+	if s.GetByNameFunc != nil {
+		return s.GetByNameFunc(ctx, name)
+	}
+	var r0 employee.Employee
+	var r1 error
+	return r0, r1
+}
+
+func (s *RepositoryStub) Save(ctx context.Context, emp employee.Employee) error {
+	s.Record("Save", ctx, emp)
+	if s.SaveFunc != nil {
+		return s.SaveFunc(ctx, emp)
+	}
+	var r0 error
+	return r0
+}
+
+var _ employee.Repository = (*RepositoryStub)(nil)
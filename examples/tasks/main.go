@@ -0,0 +1,177 @@
+// Command tasks hands out a team's work with each tasks.AssignmentPolicy -
+// round-robin, least loaded, by skill - and with one written here, which the
+// Service takes without a change (OCP). It then walks a task through its
+// statuses. main_test.go checks every claim.
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"go-solid/employee"
+	employeememory "go-solid/employee/memory"
+	"go-solid/id"
+	"go-solid/money"
+	"go-solid/org"
+	orgmemory "go-solid/org/memory"
+	"go-solid/tasks"
+	"go-solid/tasks/memory"
+)
+
+// generalist AssignmentPolicy keeping specialists free: the task goes to
+// the candidate with the fewest skills
+type generalist struct{}
+
+func (generalist) Choose(_ tasks.Task, candidates []tasks.Candidate) (int, error) {
+	best := 0
+	for i, c := range candidates {
+		if len(c.Skills) < len(candidates[best].Skills) {
+			best = i
+		}
+	}
+	return best, nil
+}
+
+var skills = tasks.SkillMap{
+	"Dan":  {"go", "sql"},
+	"Erin": {"go", "kubernetes"},
+	"Ivan": {"sql"},
+}
+
+// assignees creates a task for each of needs - the skills it takes, comma
+// separated - assigns it and returns who got each.
+func assignees(ctx context.Context, svc *tasks.Service, team string, needs ...string) ([]string, error) {
+	var names []string
+	for i, need := range needs {
+		var skills []string
+		if need != "" {
+			skills = strings.Split(need, ",")
+		}
+		t, err := svc.Create(ctx, fmt.Sprintf("task %d", i+1), team, skills...)
+		if err == nil {
+			t, err = svc.Assign(ctx, t.ID)
+		}
+		if err != nil {
+			return names, err
+		}
+		names = append(names, t.Assignee)
+	}
+	return names, nil
+}
+
+// team The Platform team build creates, and its employees' Directory
+type team struct {
+	dir *org.Directory
+	id  string
+}
+
+// build hires Bob, Dan, Erin and Ivan and puts them in Platform, with Bob
+// as its manager.
+func build(ctx context.Context) (team, error) {
+	people := employeememory.New()
+	manager := employee.NewManager(people)
+	for _, name := range []string{"Bob", "Dan", "Erin", "Ivan"} {
+		if _, err := manager.AddEmployee(ctx, employee.Employee{Name: name, Title: "Engineer", Salary: money.Of(5000, money.USD)}); err != nil {
+			return team{}, err
+		}
+	}
+	dir := org.NewDirectory(orgmemory.New(), people, org.WithTeamIDs(id.NewSequence("team-")))
+	platform, err := dir.CreateTeam(ctx, "Platform", "Bob", "")
+	if err != nil {
+		return team{}, err
+	}
+	for _, name := range []string{"Dan", "Erin", "Ivan"} {
+		if _, err := dir.AddMember(ctx, platform.ID, name); err != nil {
+			return team{}, err
+		}
+	}
+	return team{dir: dir, id: platform.ID}, nil
+}
+
+// service returns a Service handing out t's tasks by p.
+func (t team) service(p tasks.AssignmentPolicy) *tasks.Service {
+	return tasks.NewService(memory.New(), tasks.OrgRoster{Directory: t.dir}, tasks.WithPolicy(p), tasks.WithSkills(skills), tasks.WithIDs(id.NewSequence("task-")))
+}
+
+// loads returns how many open tasks each member of t has in svc.
+func (t team) loads(ctx context.Context, svc *tasks.Service) map[string]int {
+	candidates, _ := svc.Candidates(ctx, t.id)
+	loads := map[string]int{}
+	for _, c := range candidates {
+		loads[c.Name] = c.Load
+	}
+	return loads
+}
+
+// backlog assigns three tasks already in hand: two to Dan, one to Erin.
+func (t team) backlog(ctx context.Context, svc *tasks.Service) {
+	for _, name := range []string{"Dan", "Dan", "Erin"} {
+		task, _ := svc.Create(ctx, "backlog", t.id)
+		_, _ = svc.AssignTo(ctx, task.ID, name)
+	}
+}
+
+func main() {
+	ctx := context.Background()
+	platform, err := build(ctx)
+	if err != nil {
+		fmt.Println("❌", err)
+		return
+	}
+	show := func(what string, names []string, err error) {
+		if err != nil {
+			fmt.Printf("   %s: %v\n", what, err)
+			return
+		}
+		fmt.Printf("   %s: %s\n", what, strings.Join(names, ", "))
+	}
+
+	fmt.Println("🔁 RoundRobin")
+	svc := platform.service(&tasks.RoundRobin{})
+	got, err := assignees(ctx, svc, platform.id, "", "", "", "")
+	show("turns, by name", got, err)
+	_, _ = platform.dir.RemoveMember(ctx, platform.id, "Erin")
+	got, err = assignees(ctx, svc, platform.id, "", "")
+	show("Erin leaves; the turn carries on after Dan", got, err)
+	_, _ = platform.dir.AddMember(ctx, platform.id, "Erin")
+
+	fmt.Println("⚖️  LeastLoaded")
+	svc = platform.service(tasks.LeastLoaded{})
+	platform.backlog(ctx, svc)
+	got, err = assignees(ctx, svc, platform.id, "", "", "")
+	show("Dan has 2, Erin 1, Ivan 0", got, err)
+	fmt.Println("   and the loads even out:", platform.loads(ctx, svc))
+
+	fmt.Println("🧠 SkillBased")
+	svc = platform.service(tasks.SkillBased{})
+	got, err = assignees(ctx, svc, platform.id, "kubernetes", "go,sql", "sql")
+	show("kubernetes, go and sql, then sql", got, err)
+	_, err = assignees(ctx, svc, platform.id, "rust")
+	fmt.Println("   nobody knows rust:", err)
+	svc = platform.service(tasks.SkillBased{Then: &tasks.RoundRobin{}})
+	got, err = assignees(ctx, svc, platform.id, "sql", "sql", "sql")
+	show("SkillBased{Then: RoundRobin} takes turns among the sql people", got, err)
+
+	fmt.Println("➕ A new policy, and no change to the Service (OCP)")
+	svc = platform.service(tasks.SkillBased{Then: generalist{}})
+	got, err = assignees(ctx, svc, platform.id, "sql", "go")
+	show("sql goes to Ivan, who only knows sql; go to Dan, the first of two equals", got, err)
+
+	fmt.Println("🚦 A task's life")
+	svc = platform.service(tasks.LeastLoaded{})
+	t, _ := svc.Create(ctx, "Rotate the certificates", platform.id, "kubernetes")
+	_, err = svc.AssignTo(ctx, t.ID, "Grace")
+	fmt.Println("   it can't go to someone off the team:", err)
+	_, _ = svc.AssignTo(ctx, t.ID, "Erin")
+	t, _ = svc.AssignTo(ctx, t.ID, "Dan")
+	fmt.Printf("   it changes hands until it is started: %s, %s\n", t.Assignee, t.Status)
+	t, _ = svc.Start(ctx, t.ID)
+	_, err = svc.AssignTo(ctx, t.ID, "Erin")
+	fmt.Println("   but not after:", err)
+	t, _ = svc.Complete(ctx, t.ID)
+	fmt.Println("   it is", t.Status)
+	_, err = svc.Complete(ctx, t.ID)
+	fmt.Println("   once:", err)
+	fmt.Println("   and no longer counts towards Dan's load:", platform.loads(ctx, svc)["Dan"])
+}
@@ -0,0 +1,110 @@
+package main
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"go-solid/tasks"
+)
+
+func TestPolicies(t *testing.T) {
+	tests := []struct {
+		name    string
+		policy  tasks.AssignmentPolicy
+		backlog bool
+		needs   []string
+		want    []string
+	}{
+		{name: "round robin", policy: &tasks.RoundRobin{}, needs: []string{"", "", "", ""}, want: []string{"Dan", "Erin", "Ivan", "Dan"}},
+		{name: "least loaded", policy: tasks.LeastLoaded{}, backlog: true, needs: []string{"", "", ""}, want: []string{"Ivan", "Erin", "Ivan"}},
+		{name: "skill based", policy: tasks.SkillBased{}, needs: []string{"kubernetes", "go,sql", "sql"}, want: []string{"Erin", "Dan", "Ivan"}},
+		{name: "skill based, then round robin", policy: tasks.SkillBased{Then: &tasks.RoundRobin{}}, needs: []string{"sql", "sql", "sql"}, want: []string{"Dan", "Ivan", "Dan"}},
+		{name: "skill based, then generalist, written here", policy: tasks.SkillBased{Then: generalist{}}, needs: []string{"sql", "go"}, want: []string{"Ivan", "Dan"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			platform, err := build(t.Context())
+			if err != nil {
+				t.Fatalf("build() error = %v", err)
+			}
+			svc := platform.service(tt.policy)
+			if tt.backlog {
+				platform.backlog(t.Context(), svc)
+			}
+			if got, err := assignees(t.Context(), svc, platform.id, tt.needs...); err != nil || !slices.Equal(got, tt.want) {
+				t.Errorf("assignees(%q) = %v, %v, want %v", tt.needs, got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestRoundRobin_CarriesOnWhenSomeoneLeaves(t *testing.T) {
+	platform, err := build(t.Context())
+	if err != nil {
+		t.Fatalf("build() error = %v", err)
+	}
+	svc := platform.service(&tasks.RoundRobin{})
+	_, _ = assignees(t.Context(), svc, platform.id, "", "", "", "")
+	if _, err := platform.dir.RemoveMember(t.Context(), platform.id, "Erin"); err != nil {
+		t.Fatal(err)
+	}
+	if got, err := assignees(t.Context(), svc, platform.id, "", ""); err != nil || !slices.Equal(got, []string{"Ivan", "Dan"}) {
+		t.Errorf("assignees() = %v, %v, want [Ivan Dan]", got, err)
+	}
+}
+
+func TestLeastLoaded_EvensOutTheLoads(t *testing.T) {
+	platform, err := build(t.Context())
+	if err != nil {
+		t.Fatalf("build() error = %v", err)
+	}
+	svc := platform.service(tasks.LeastLoaded{})
+	platform.backlog(t.Context(), svc)
+	_, _ = assignees(t.Context(), svc, platform.id, "", "", "")
+	if got := platform.loads(t.Context(), svc); got["Dan"] != 2 || got["Erin"] != 2 || got["Ivan"] != 2 {
+		t.Errorf("loads() = %v, want 2 each", got)
+	}
+}
+
+func TestSkillBased_NobodyHasTheSkill(t *testing.T) {
+	platform, err := build(t.Context())
+	if err != nil {
+		t.Fatalf("build() error = %v", err)
+	}
+	if _, err := assignees(t.Context(), platform.service(tasks.SkillBased{}), platform.id, "rust"); !errors.Is(err, tasks.ErrNoCandidate) {
+		t.Errorf("assignees(rust) error = %v, want %v", err, tasks.ErrNoCandidate)
+	}
+}
+
+func TestTaskLife(t *testing.T) {
+	ctx := t.Context()
+	platform, err := build(ctx)
+	if err != nil {
+		t.Fatalf("build() error = %v", err)
+	}
+	svc := platform.service(tasks.LeastLoaded{})
+	task, _ := svc.Create(ctx, "Rotate the certificates", platform.id, "kubernetes")
+	if _, err := svc.AssignTo(ctx, task.ID, "Grace"); !errors.Is(err, tasks.ErrNotOnTeam) {
+		t.Errorf("AssignTo(Grace) error = %v, want %v", err, tasks.ErrNotOnTeam)
+	}
+	_, _ = svc.AssignTo(ctx, task.ID, "Erin")
+	if task, err = svc.AssignTo(ctx, task.ID, "Dan"); err != nil || task.Assignee != "Dan" || task.Status != tasks.Assigned {
+		t.Errorf("AssignTo(Dan) = %s, %s, %v, want it reassigned", task.Assignee, task.Status, err)
+	}
+	if _, err := svc.Start(ctx, task.ID); err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+	if _, err := svc.AssignTo(ctx, task.ID, "Erin"); !errors.Is(err, tasks.ErrTransition) {
+		t.Errorf("AssignTo() once started error = %v, want %v", err, tasks.ErrTransition)
+	}
+	if task, err = svc.Complete(ctx, task.ID); err != nil || task.Status != tasks.Done {
+		t.Errorf("Complete() = %s, %v, want %s", task.Status, err, tasks.Done)
+	}
+	if _, err := svc.Complete(ctx, task.ID); !errors.Is(err, tasks.ErrTransition) {
+		t.Errorf("Complete() twice error = %v, want %v", err, tasks.ErrTransition)
+	}
+	if got := platform.loads(ctx, svc)["Dan"]; got != 0 {
+		t.Errorf("Dan's load = %d once the task is done, want 0", got)
+	}
+}
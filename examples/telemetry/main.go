@@ -0,0 +1,210 @@
+// Command telemetry runs a workshop's worth of anonymous usage events
+// through the telemetry package: schemas that refuse anything they don't
+// declare, sinks that only differ in where events end up, and a collector
+// an instructor reads the counts from. main_test.go checks every claim.
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"go-solid/clock"
+	"go-solid/telemetry"
+)
+
+// The events the CLI builds, one of each
+var events = []telemetry.Event{
+	telemetry.LessonCompleted("srp", 4),
+	telemetry.ExerciseFailed("ocp-1", 2, 5, true, false),
+	telemetry.DemoRun("Per-country payroll", 6, 6),
+}
+
+// named returns an event carrying a learner's name, which no schema allows.
+func named() telemetry.Event {
+	e := telemetry.LessonCompleted("srp", 4)
+	e.Attrs["student"] = "Ada Lovelace"
+	return e
+}
+
+// mistyped returns an event with a count given as text.
+func mistyped() telemetry.Event {
+	e := telemetry.DemoRun("Per-country payroll", 6, 6)
+	e.Attrs["passed"] = "all of them"
+	return e
+}
+
+// extend registers a new event, hint_revealed, and lesson_completed again,
+// in a copy of the default registry.
+func extend() (added, again error) {
+	registry, err := telemetry.NewRegistry(telemetry.Default.Schemas()...)
+	if err != nil {
+		return err, nil
+	}
+	hinted := telemetry.Schema{Name: "hint_revealed", Version: 1, Key: "exercise", Fields: []telemetry.Field{
+		{Name: "exercise", Type: telemetry.String}, {Name: "level", Type: telemetry.Int}}}
+	return registry.Register(hinted), registry.Register(telemetry.LessonCompletedSchema)
+}
+
+var start = time.Date(2026, time.March, 2, 9, 0, 0, 0, time.UTC)
+
+// emit sends events and then a named one to sink as learner-1, at start,
+// and returns what the named one got.
+func emit(ctx context.Context, sink telemetry.Sink) error {
+	emitter := telemetry.NewEmitter(sink, telemetry.WithClock(clock.NewFake(start)), telemetry.WithSession("learner-1"))
+	for _, e := range events {
+		_ = emitter.Emit(ctx, e)
+	}
+	return emitter.Emit(ctx, named())
+}
+
+// appendRuns opens path as a file sink runs times, emitting events each
+// time, and returns how many lines it then holds.
+func appendRuns(ctx context.Context, path string, runs int) (int, error) {
+	for range runs {
+		sink, err := telemetry.Open("file://" + path)
+		if err != nil {
+			return 0, err
+		}
+		for _, e := range events {
+			_ = telemetry.NewEmitter(sink).Emit(ctx, e)
+		}
+		sink.(*telemetry.FileSink).Close()
+	}
+	return lines(path), nil
+}
+
+// A workshop: three learners and what each sends
+var cohort = []struct {
+	session string
+	events  []telemetry.Event
+}{
+	{"a", []telemetry.Event{telemetry.ExerciseFailed("ocp-1", 1, 4, true, false), telemetry.LessonCompleted("srp", 4)}},
+	{"b", []telemetry.Event{telemetry.ExerciseFailed("ocp-1", 4, 4, false, false), telemetry.ExerciseFailed("ocp-1", 1, 4, true, false)}},
+	{"c", []telemetry.Event{telemetry.LessonCompleted("srp", 4), telemetry.DemoRun("Per-country payroll", 6, 5)}},
+}
+
+// runWorkshop has the cohort send their events to the collector at url, and
+// returns how many it took.
+func runWorkshop(ctx context.Context, url string) int {
+	sent := 0
+	for _, learner := range cohort {
+		emitter := telemetry.NewEmitter(&telemetry.HTTPSink{URL: url + "/events"}, telemetry.WithSession(learner.session))
+		for _, e := range learner.events {
+			if emitter.Emit(ctx, e) == nil {
+				sent++
+			}
+		}
+	}
+	return sent
+}
+
+// postNamed posts a named event to the collector at url straight, as a CLI
+// that skipped validation would, and returns the status.
+func postNamed(url string) (int, error) {
+	body, _ := json.Marshal(named())
+	resp, err := http.Post(url+"/events", "application/json", bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	resp.Body.Close()
+	return resp.StatusCode, nil
+}
+
+// summary fetches the collector's counts from url.
+func summary(url string) ([]telemetry.Count, error) {
+	resp, err := http.Get(url + "/summary")
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	var counts []telemetry.Count
+	err = json.NewDecoder(resp.Body).Decode(&counts)
+	return counts, err
+}
+
+func main() {
+	ctx := context.Background()
+
+	fmt.Println("🗂️  Every event is declared by a schema")
+	for _, s := range telemetry.Default.Schemas() {
+		names := make([]string, len(s.Fields))
+		for i, f := range s.Fields {
+			names[i] = f.Name
+		}
+		fmt.Printf("      %s: %s\n", s, strings.Join(names, ", "))
+	}
+	valid := 0
+	for _, e := range events {
+		if telemetry.Default.Validate(e) == nil {
+			valid++
+		}
+	}
+	fmt.Printf("   %d of the %d events the CLI builds match their schemas\n", valid, len(events))
+	fmt.Println("   a name added to an event is refused:", telemetry.Default.Validate(named()))
+	fmt.Println("   so is a field of the wrong type:", telemetry.Default.Validate(mistyped()))
+	fmt.Println("   and an event nobody declared:", telemetry.Default.Validate(telemetry.Event{Name: "keystroke", Version: 1}))
+	added, again := extend()
+	fmt.Println("   a new event is one more schema, and no sink changes (OCP):", added)
+	fmt.Println("   one already declared is refused:", again)
+
+	fmt.Println("📤 Sinks differ only in where events end up (DIP)")
+	memory := &telemetry.Memory{}
+	err := emit(ctx, memory)
+	kept := memory.Events()
+	fmt.Printf("   the emitter stamps time and session: %s at %s, session %s\n", kept[0], kept[0].Time.Format(time.Kitchen), kept[0].Session)
+	fmt.Printf("   and a refused event never reaches the sink: %d kept, %v\n", len(kept), err)
+	fmt.Println("   a learner who hasn't opted in gets Nop: checked, then dropped:", emit(ctx, telemetry.Nop{}))
+	var out bytes.Buffer
+	_ = emit(ctx, telemetry.NewWriterSink(&out))
+	first, _, _ := strings.Cut(out.String(), "\n")
+	fmt.Println("   a writer gets a JSON line:", first)
+	dir, _ := os.MkdirTemp("", "telemetry")
+	defer os.RemoveAll(dir)
+	n, err := appendRuns(ctx, filepath.Join(dir, "events.jsonl"), 2)
+	if err != nil {
+		fmt.Println("❌", err)
+		return
+	}
+	fmt.Printf("   a file is appended to, run after run: %d lines\n", n)
+	_, err = telemetry.Open("ftp://teacher/events")
+	fmt.Println("   a destination no sink handles is an error:", err)
+
+	fmt.Println("🧑‍🏫 A workshop, counted by the instructor's collector")
+	srv := httptest.NewServer(telemetry.NewCollector(telemetry.Default, nil))
+	defer srv.Close()
+	fmt.Printf("   %d learners send %d events over HTTP\n", len(cohort), runWorkshop(ctx, srv.URL))
+	status, _ := postNamed(srv.URL)
+	fmt.Println("   the collector checks again, for CLIs that skipped it:", status, http.StatusText(status))
+	counts, _ := summary(srv.URL)
+	for _, c := range counts {
+		fmt.Printf("      %s\n", c)
+	}
+	fmt.Println("   ocp-1 failed three times for two learners, which is where to help")
+
+	dead := httptest.NewServer(http.NotFoundHandler())
+	dead.Close()
+	err = telemetry.NewEmitter(&telemetry.HTTPSink{URL: dead.URL + "/events"}).Emit(ctx, events[0])
+	fmt.Println("   a collector that's gone is an error, for the CLI to report and ignore:", err)
+}
+
+func lines(path string) int {
+	f, err := os.Open(path)
+	if err != nil {
+		return 0
+	}
+	defer f.Close()
+	n := 0
+	for sc := bufio.NewScanner(f); sc.Scan(); {
+		n++
+	}
+	return n
+}
@@ -0,0 +1,101 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"go-solid/telemetry"
+)
+
+func TestSchemas(t *testing.T) {
+	for _, e := range events {
+		if err := telemetry.Default.Validate(e); err != nil {
+			t.Errorf("Validate(%s) error = %v", e, err)
+		}
+	}
+	tests := []struct {
+		name    string
+		event   telemetry.Event
+		wantErr error
+	}{
+		{"a learner's name", named(), telemetry.ErrInvalidEvent},
+		{"a field of the wrong type", mistyped(), telemetry.ErrInvalidEvent},
+		{"an undeclared event", telemetry.Event{Name: "keystroke", Version: 1}, telemetry.ErrUnknownEvent},
+	}
+	for _, tt := range tests {
+		if err := telemetry.Default.Validate(tt.event); !errors.Is(err, tt.wantErr) {
+			t.Errorf("Validate(%s) error = %v, want %v", tt.name, err, tt.wantErr)
+		}
+	}
+	if added, again := extend(); added != nil || again == nil {
+		t.Errorf("extend() = %v, %v, want a new schema added and a declared one refused", added, again)
+	}
+}
+
+func TestEmitter(t *testing.T) {
+	memory := &telemetry.Memory{}
+	if err := emit(t.Context(), memory); err == nil {
+		t.Error("Emit(named) error = nil, want it refused")
+	}
+	kept := memory.Events()
+	if len(kept) != len(events) {
+		t.Fatalf("Memory kept %d events, want %d, without the refused one", len(kept), len(events))
+	}
+	if !kept[0].Time.Equal(start) || kept[0].Session != "learner-1" {
+		t.Errorf("Emit() stamped %s, session %q, want %s, learner-1", kept[0].Time, kept[0].Session, start)
+	}
+	if err := telemetry.NewEmitter(telemetry.Nop{}).Emit(t.Context(), events[0]); err != nil {
+		t.Errorf("Emit() to Nop error = %v, want it dropped", err)
+	}
+	if err := emit(t.Context(), telemetry.Nop{}); err == nil {
+		t.Error("Emit(named) to Nop error = nil, want it checked all the same")
+	}
+}
+
+func TestSinks(t *testing.T) {
+	var out bytes.Buffer
+	_ = emit(t.Context(), telemetry.NewWriterSink(&out))
+	var line telemetry.Event
+	if err := json.NewDecoder(&out).Decode(&line); err != nil || line.Name != "lesson_completed" {
+		t.Errorf("WriterSink wrote %s, %v, want a JSON line per event", line.Name, err)
+	}
+	if n, err := appendRuns(t.Context(), filepath.Join(t.TempDir(), "events.jsonl"), 2); err != nil || n != 2*len(events) {
+		t.Errorf("appendRuns() = %d lines, %v, want %d", n, err, 2*len(events))
+	}
+	if _, err := telemetry.Open("ftp://teacher/events"); err == nil {
+		t.Error("Open(ftp://) error = nil, want no sink for it")
+	}
+}
+
+func TestCollector(t *testing.T) {
+	collector := telemetry.NewCollector(telemetry.Default, nil)
+	srv := httptest.NewServer(collector)
+	defer srv.Close()
+	if sent := runWorkshop(t.Context(), srv.URL); sent != 6 || len(collector.Events()) != 6 {
+		t.Errorf("runWorkshop() = %d sent, %d collected, want 6", sent, len(collector.Events()))
+	}
+	if status, err := postNamed(srv.URL); err != nil || status != http.StatusUnprocessableEntity {
+		t.Errorf("POST named = %d, %v, want %d", status, err, http.StatusUnprocessableEntity)
+	}
+	counts, err := summary(srv.URL)
+	want := []telemetry.Count{
+		{Event: "demo_run", Key: "Per-country payroll", Events: 1, Sessions: 1},
+		{Event: "exercise_failed", Key: "ocp-1", Events: 3, Sessions: 2},
+		{Event: "lesson_completed", Key: "srp", Events: 2, Sessions: 2},
+	}
+	if err != nil || fmt.Sprint(counts) != fmt.Sprint(want) {
+		t.Errorf("summary() = %v, %v, want %v", counts, err, want)
+	}
+
+	dead := httptest.NewServer(http.NotFoundHandler())
+	dead.Close()
+	if err := telemetry.NewEmitter(&telemetry.HTTPSink{URL: dead.URL + "/events"}).Emit(t.Context(), events[0]); err == nil {
+		t.Error("Emit() to a collector that's gone error = nil")
+	}
+}
@@ -0,0 +1,70 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	"go-solid/employee"
+	"go-solid/employee/memory"
+	"go-solid/httpapi"
+	"go-solid/money"
+	"go-solid/tenant"
+)
+
+func main() {
+	// ✅ One repository per tenant, opened on first use; the Manager doesn't know tenants exist
+	repo := tenant.NewEmployees(func(id tenant.ID) (employee.Repository, error) {
+		fmt.Printf("📦 Opening partition for %s\n", id)
+		return memory.New(), nil
+	})
+	manager := employee.NewManager(repo)
+
+	acme := tenant.WithTenant(context.Background(), "acme")
+	globex := tenant.WithTenant(context.Background(), "globex")
+
+	_, _ = manager.AddEmployee(acme, employee.Employee{Name: "Mohamed", Salary: money.Of(5000, money.USD)})
+	_, _ = manager.AddEmployee(globex, employee.Employee{Name: "Ahmed", Salary: money.Of(6000, money.USD)})
+
+	if _, err := manager.FindEmployee(acme, "Mohamed"); err == nil {
+		fmt.Println("✅ acme sees Mohamed")
+	}
+	if _, err := manager.FindEmployee(globex, "Mohamed"); errors.Is(err, employee.ErrNotFound) {
+		fmt.Println("🔒 globex cannot see acme's Mohamed")
+	}
+
+	// ❌ Forgetting the tenant fails loudly instead of reading everyone's data
+	if _, err := manager.FindEmployee(context.Background(), "Mohamed"); errors.Is(err, tenant.ErrNoTenant) {
+		fmt.Println("🚫 No tenant in context:", err)
+	}
+
+	// ✅ Over HTTP the middleware resolves the tenant; the handlers are unchanged
+	resolver := tenant.Known{Resolver: tenant.Header("X-Tenant-ID"), Tenants: []tenant.ID{"acme", "globex"}}
+	server := httptest.NewServer(tenant.Middleware(resolver)(httpapi.New(manager)))
+	defer server.Close()
+
+	fmt.Println("\n🌐 HTTP")
+	get(server.URL, "acme", "Mohamed")
+	get(server.URL, "globex", "Mohamed")
+	get(server.URL, "globex", "Ahmed")
+	get(server.URL, "", "Ahmed")
+	get(server.URL, "initech", "Ahmed")
+	get(server.URL, "Robert'); DROP TABLE", "Ahmed")
+}
+
+func get(base, id, name string) {
+	req, _ := http.NewRequest(http.MethodGet, base+"/employees/"+name, nil)
+	if id != "" {
+		req.Header.Set("X-Tenant-ID", id)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		fmt.Println("❌", err)
+		return
+	}
+	resp.Body.Close()
+	fmt.Printf("   %-22q GET %-8s -> %s\n", id, name, strings.TrimSpace(resp.Status))
+}
@@ -0,0 +1,83 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go-solid/clock"
+	"go-solid/employee"
+	"go-solid/employee/memory"
+	"go-solid/money"
+	"go-solid/policy"
+)
+
+// slowRepository Takes as long as latency says to answer, on a fake clock,
+// so a run of a thousand calls takes no real time
+type slowRepository struct {
+	*memory.Repository
+	clock   *clock.Fake
+	latency func() time.Duration
+}
+
+func (r slowRepository) GetByName(ctx context.Context, name string) (employee.Employee, error) {
+	r.clock.Advance(r.latency())
+	return r.Repository.GetByName(ctx, name)
+}
+
+// phase Calls made while the database answers in a given way
+type phase struct {
+	name    string
+	calls   int
+	latency func(i int) time.Duration
+}
+
+var phases = []phase{
+	{"normal: 20-40ms, a 250ms spike every 50th call", 200, func(i int) time.Duration {
+		if i%50 == 49 {
+			return 250 * time.Millisecond
+		}
+		return time.Duration(20+i%3*10) * time.Millisecond
+	}},
+	{"after a migration: 150-180ms", 200, func(i int) time.Duration { return time.Duration(150+i%4*10) * time.Millisecond }},
+	{"recovered: 20-40ms", 200, func(i int) time.Duration { return time.Duration(20+i%3*10) * time.Millisecond }},
+}
+
+// run sends every phase's calls through a repository applying p.
+func run(p policy.TimeoutPolicy) {
+	ctx := context.Background()
+	clk := clock.NewFake(time.Date(2026, 1, 1, 9, 0, 0, 0, time.UTC))
+	mem := memory.New()
+	_ = mem.Save(ctx, employee.Employee{Name: "Alice", Salary: money.Of(5000, money.USD)})
+	var latency time.Duration
+	repo := policy.NewRepository(slowRepository{mem, clk, func() time.Duration { return latency }}, p, clk)
+
+	for _, ph := range phases {
+		timeouts := 0
+		for i := range ph.calls {
+			latency = ph.latency(i)
+			if _, err := repo.GetByName(ctx, "Alice"); errors.Is(err, policy.ErrTimeout) {
+				timeouts++
+			}
+		}
+		mark := "✅"
+		if timeouts > ph.calls/10 {
+			mark = "❌"
+		}
+		fmt.Printf("   %s %-48s %3d/%d timed out, budget now %s\n", mark, ph.name, timeouts, ph.calls, p.Timeout("GetByName"))
+	}
+}
+
+func main() {
+	// ❌ A budget tuned for the database as it was: it cuts off every call once
+	// the database is slower, until someone edits the configuration
+	fmt.Println("⏱️  Fixed 100ms")
+	run(policy.Fixed(100 * time.Millisecond))
+
+	// ✅ A new policy, not an edit to the decorator: twice the p99 of the last
+	// hundred calls. Only a spike before it has learned anything times out;
+	// the budget grows to cover the slowdown and shrinks once it is over.
+	fmt.Println("\n📈 Adaptive: 2 × p99 of the last 100 calls, between 50ms and 2s")
+	run(&policy.Adaptive{Initial: 100 * time.Millisecond, Min: 50 * time.Millisecond, Max: 2 * time.Second})
+}
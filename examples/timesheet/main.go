@@ -0,0 +1,116 @@
+// Command timesheet follows two contractors through a month: they log hours
+// through a TimesheetRecorder, their manager approves or rejects the
+// timesheets through the workflow engine, and payroll pays each for the
+// hours approved - the same engine, the same pipeline as salaried staff.
+// main_test.go checks every step.
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go-solid/clock"
+	"go-solid/id"
+	"go-solid/money"
+	"go-solid/payroll"
+	"go-solid/timesheet"
+	"go-solid/timesheet/memory"
+	"go-solid/workflow"
+	workflowmemory "go-solid/workflow/memory"
+)
+
+// logWeek is contractor-facing code: all it can do is record hours (ISP).
+func logWeek(ctx context.Context, r timesheet.TimesheetRecorder, who string, monday time.Time, hours ...time.Duration) error {
+	for i, h := range hours {
+		if _, err := r.Record(ctx, who, monday.AddDate(0, 0, i), h, "client project"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+var (
+	march  = payroll.Period{Year: 2025, Month: time.March}
+	monday = time.Date(2025, time.March, 3, 0, 0, 0, 0, time.UTC)
+
+	contractors = []timesheet.Contractor{
+		{ID: "ctr-1", Name: "Ali", Country: "US", Rate: money.Of(120, money.USD)},
+		{ID: "ctr-2", Name: "Sara", Country: "US", Rate: money.Of(95, money.USD)},
+	}
+	pay = payroll.New(payroll.Config{"US": {
+		payroll.IncomeTax{Brackets: []payroll.Bracket{{UpTo: money.Of(1000, money.USD), Rate: "0.10"}, {Rate: "0.20"}}},
+	}})
+)
+
+// setup wires timesheets to a workflow capping the month at 200h, then
+// asking Bob, the contractors' manager.
+func setup(clk clock.Clock) (*timesheet.Service, *workflow.Engine, *memory.Repository) {
+	sheets := memory.New()
+	engine := workflow.NewEngine(workflowmemory.New(),
+		workflow.WithClock(clk),
+		workflow.WithIDs(id.NewSequence("wf-")),
+		workflow.WithListener(timesheet.StatusSync{Repo: sheets}),
+	)
+	engine.Define(workflow.Definition{Name: timesheet.SubjectKind, Steps: []workflow.Step{
+		workflow.Check{Label: "monthly cap", Allow: func(_ context.Context, subj workflow.Subject) error {
+			if timesheet.Hours(subj) > 200*time.Hour {
+				return fmt.Errorf("%v is over the monthly cap of 200h", timesheet.Hours(subj))
+			}
+			return nil
+		}},
+		workflow.Approval{
+			Label:     "manager",
+			Approvers: workflow.Manager{Org: workflow.Reports{"Ali": "Bob", "Sara": "Bob"}},
+			Rule:      workflow.AnyOne{},
+		},
+	}})
+	return timesheet.NewService(sheets, engine), engine, sheets
+}
+
+func main() {
+	ctx := context.Background()
+	svc, engine, sheets := setup(clock.NewFake(monday))
+
+	fmt.Println("⏱️  Logging hours")
+	_ = logWeek(ctx, svc, "Ali", monday, 8*time.Hour, 8*time.Hour, 8*time.Hour+30*time.Minute)
+	sheet, _ := svc.Get(ctx, "Ali", march)
+	fmt.Printf("   Ali logs three days: %v on %s\n", sheet.Total(), sheet.ID)
+	for _, hours := range []time.Duration{0, 90 * time.Second, 17 * time.Hour} {
+		_, err := svc.Record(ctx, "Ali", monday, hours, "")
+		fmt.Printf("   ❌ %v more on Monday: %v\n", hours, err)
+	}
+	_ = logWeek(ctx, svc, "Sara", monday, 6*time.Hour, 4*time.Hour)
+	sheet, _ = svc.Get(ctx, "Sara", march)
+	fmt.Printf("   Sara logs two days: %v\n", sheet.Total())
+
+	fmt.Println("📨 Approval through the workflow engine")
+	_, err := svc.Submit(ctx, "Nadia", march)
+	fmt.Println("   an empty timesheet can't be submitted:", err)
+	ali, _ := svc.Submit(ctx, "Ali", march)
+	fmt.Printf("   Ali submits; the timesheet is %s, waiting for %v\n", ali.Status, ali.Approvers)
+	_, err = svc.Record(ctx, "Ali", monday.AddDate(0, 0, 3), 8*time.Hour, "")
+	fmt.Println("   a submitted timesheet is locked:", err)
+	_, _ = engine.Vote(ctx, ali.ID, "Bob", true, "")
+	sheet, _ = svc.Get(ctx, "Ali", march)
+	fmt.Println("   Bob approves, and StatusSync writes it back:", sheet.Status)
+	sara, _ := svc.Submit(ctx, "Sara", march)
+	_, _ = engine.Vote(ctx, sara.ID, "Bob", false, "wrong project code")
+	sheet, _ = svc.Get(ctx, "Sara", march)
+	fmt.Println("   Bob rejects Sara's:", sheet.Status)
+	sheet, _ = svc.Record(ctx, "Sara", monday.AddDate(0, 0, 2), 2*time.Hour, "client project, corrected")
+	fmt.Println("   she corrects it: it is a draft again, not yet resubmitted:", sheet.Status)
+
+	fmt.Println("💰 Payroll pays what was approved, through the same engine and pipeline as salaried staff")
+	run, err := pay.Run(ctx, march, timesheet.Roster{Sheets: sheets, Period: march, Contractors: contractors})
+	if err == nil {
+		err = run.Err()
+	}
+	if err != nil {
+		fmt.Println("   ❌", err)
+	}
+	for _, slip := range run.Payslips {
+		fmt.Printf("      🧾 %s: gross %v, net %v\n", slip.Name, slip.Gross(), slip.Net())
+	}
+	fmt.Println("   Ali is paid 24h30m at $120/h; Sara, with nothing approved, zero - not a sentinel (LSP)")
+}
@@ -0,0 +1,103 @@
+package main
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"go-solid/clock"
+	"go-solid/money"
+	"go-solid/timesheet"
+	"go-solid/workflow"
+)
+
+func TestRecord_RefusesInvalidHours(t *testing.T) {
+	svc, _, _ := setup(clock.NewFake(monday))
+	if err := logWeek(t.Context(), svc, "Ali", monday, 8*time.Hour, 8*time.Hour, 8*time.Hour+30*time.Minute); err != nil {
+		t.Fatalf("logWeek() error = %v", err)
+	}
+	sheet, err := svc.Get(t.Context(), "Ali", march)
+	if err != nil || sheet.Total() != 24*time.Hour+30*time.Minute {
+		t.Fatalf("Get() = %v, %v, want 24h30m logged", sheet.Total(), err)
+	}
+	for _, hours := range []time.Duration{0, 90 * time.Second, 17 * time.Hour} {
+		if _, err := svc.Record(t.Context(), "Ali", monday, hours, ""); !errors.Is(err, timesheet.ErrInvalidHours) {
+			t.Errorf("Record(%v) error = %v, want %v", hours, err, timesheet.ErrInvalidHours)
+		}
+	}
+}
+
+func TestSubmit(t *testing.T) {
+	svc, engine, _ := setup(clock.NewFake(monday))
+	if _, err := svc.Submit(t.Context(), "Nadia", march); !errors.Is(err, timesheet.ErrEmpty) {
+		t.Errorf("Submit(empty) error = %v, want %v", err, timesheet.ErrEmpty)
+	}
+	if err := logWeek(t.Context(), svc, "Ali", monday, 8*time.Hour); err != nil {
+		t.Fatalf("logWeek() error = %v", err)
+	}
+	inst, err := svc.Submit(t.Context(), "Ali", march)
+	if err != nil || inst.Status != workflow.Pending {
+		t.Fatalf("Submit() = %s, %v, want pending", inst.Status, err)
+	}
+	if _, err := svc.Record(t.Context(), "Ali", monday.AddDate(0, 0, 3), 8*time.Hour, ""); !errors.Is(err, timesheet.ErrLocked) {
+		t.Errorf("Record() after Submit error = %v, want %v", err, timesheet.ErrLocked)
+	}
+	if _, err := engine.Vote(t.Context(), inst.ID, "Bob", true, ""); err != nil {
+		t.Fatalf("Vote() error = %v", err)
+	}
+	if sheet, _ := svc.Get(t.Context(), "Ali", march); sheet.Status != timesheet.Approved {
+		t.Errorf("Status after approval = %s, want %s", sheet.Status, timesheet.Approved)
+	}
+}
+
+func TestRejectThenCorrect(t *testing.T) {
+	svc, engine, _ := setup(clock.NewFake(monday))
+	if err := logWeek(t.Context(), svc, "Sara", monday, 6*time.Hour, 4*time.Hour); err != nil {
+		t.Fatalf("logWeek() error = %v", err)
+	}
+	inst, err := svc.Submit(t.Context(), "Sara", march)
+	if err != nil {
+		t.Fatalf("Submit() error = %v", err)
+	}
+	if _, err := engine.Vote(t.Context(), inst.ID, "Bob", false, "wrong project code"); err != nil {
+		t.Fatalf("Vote() error = %v", err)
+	}
+	if sheet, _ := svc.Get(t.Context(), "Sara", march); sheet.Status != timesheet.Rejected {
+		t.Errorf("Status after rejection = %s, want %s", sheet.Status, timesheet.Rejected)
+	}
+	sheet, err := svc.Record(t.Context(), "Sara", monday.AddDate(0, 0, 2), 2*time.Hour, "corrected")
+	if err != nil || sheet.Status != timesheet.Draft {
+		t.Errorf("Record() after rejection = %s, %v, want a draft again", sheet.Status, err)
+	}
+}
+
+func TestPayroll_PaysApprovedHours(t *testing.T) {
+	svc, engine, sheets := setup(clock.NewFake(monday))
+	if err := logWeek(t.Context(), svc, "Ali", monday, 8*time.Hour, 8*time.Hour, 8*time.Hour+30*time.Minute); err != nil {
+		t.Fatalf("logWeek(Ali) error = %v", err)
+	}
+	if err := logWeek(t.Context(), svc, "Sara", monday, 6*time.Hour); err != nil {
+		t.Fatalf("logWeek(Sara) error = %v", err)
+	}
+	inst, err := svc.Submit(t.Context(), "Ali", march)
+	if err != nil {
+		t.Fatalf("Submit() error = %v", err)
+	}
+	if _, err := engine.Vote(t.Context(), inst.ID, "Bob", true, ""); err != nil {
+		t.Fatalf("Vote() error = %v", err)
+	}
+
+	run, err := pay.Run(t.Context(), march, timesheet.Roster{Sheets: sheets, Period: march, Contractors: contractors})
+	if err == nil {
+		err = run.Err()
+	}
+	if err != nil || len(run.Payslips) != 2 {
+		t.Fatalf("Run() = %d payslips, %v, want 2", len(run.Payslips), err)
+	}
+	want := map[string]money.Money{"Ali": money.Of(2940, money.USD), "Sara": money.FromMinor(0, money.USD)}
+	for _, slip := range run.Payslips {
+		if got := slip.Gross(); got != want[slip.Name] {
+			t.Errorf("%s's gross = %v, want %v", slip.Name, got, want[slip.Name])
+		}
+	}
+}
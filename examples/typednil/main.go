@@ -0,0 +1,133 @@
+// Command typednil reproduces the typed nil trap of interface-heavy code: a
+// nil *MySQLRepository stored in an EmployeeRepository is not a nil
+// EmployeeRepository. An interface value is a type and a pointer, and it is
+// == nil only when both are missing. main_test.go checks every claim.
+package main
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// EmployeeRepository and MySQLRepository as in 5.DIP, with a connection to
+// lose
+type EmployeeRepository interface {
+	Save(name string) error
+}
+
+type MySQLRepository struct{ dsn string }
+
+func (r *MySQLRepository) Save(name string) error {
+	fmt.Printf("   💾 saving %s to %s\n", name, r.dsn) // reads r.dsn: a nil r panics
+	return nil
+}
+
+// openRepository ❌ returns repo whether or not it was set. With no DSN the
+// caller gets an interface holding (*MySQLRepository, nil), which is not nil.
+func openRepository(dsn string) EmployeeRepository {
+	var repo *MySQLRepository
+	if dsn != "" {
+		repo = &MySQLRepository{dsn: dsn}
+	}
+	//lint:ignore typednil the bug this example shows; remove this line to see solid lint object
+	return repo
+}
+
+// openRepositoryFixed ✅ says nil itself on the path that has no repository
+func openRepositoryFixed(dsn string) EmployeeRepository {
+	if dsn == "" {
+		return nil
+	}
+	return &MySQLRepository{dsn: dsn}
+}
+
+// connect ✅ returns the struct, as solid lint's returnstructs asks, and
+// reports a missing DSN as an error rather than a nil to test for
+func connect(dsn string) (*MySQLRepository, error) {
+	if dsn == "" {
+		return nil, errors.New("no DSN")
+	}
+	return &MySQLRepository{dsn: dsn}, nil
+}
+
+// ValidationError is the same trap with error, the interface everybody
+// returns
+type ValidationError struct{ Field string }
+
+func (e *ValidationError) Error() string { return e.Field + " is required" }
+
+// validate ❌ returns a nil *ValidationError as a non-nil error
+func validate(name string) error {
+	var err *ValidationError
+	if name == "" {
+		err = &ValidationError{Field: "name"}
+	}
+	//lint:ignore typednil the bug this example shows
+	return err
+}
+
+// validateFixed ✅ keeps the concrete error local and returns nil literally
+func validateFixed(name string) error {
+	if name == "" {
+		return &ValidationError{Field: "name"}
+	}
+	return nil
+}
+
+// isNil ⚠️ sees through the interface with reflect. It works, but every
+// caller has to know to use it - fix the function returning the typed nil.
+func isNil(v any) bool {
+	if v == nil {
+		return true
+	}
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Pointer, reflect.Map, reflect.Slice, reflect.Func, reflect.Chan, reflect.Interface:
+		return rv.IsNil()
+	}
+	return false
+}
+
+func main() {
+	fmt.Println("🕳️  A nil pointer in an interface")
+	repo := openRepository("")
+	fmt.Printf("   repo holds type %T, value %v\n", repo, repo)
+	fmt.Println("   ❌ repo != nil, though the pointer inside is nil:", repo != nil)
+	fmt.Println("   so the guard every caller writes lets it through, and Save panics:", guardedSave(repo, "Alice"))
+
+	fmt.Println("🧯 The same trap with error")
+	err := validate("Bob")
+	fmt.Printf("   ❌ validate(\"Bob\") is a %T holding nil, so a valid name fails: err != nil is %v\n", err, err != nil)
+	fmt.Println("   ✅ validateFixed(\"Bob\") returns", validateFixed("Bob"))
+
+	fmt.Println("✅ Fixes")
+	fmt.Println("   openRepositoryFixed(\"\") == nil, returned literally:", openRepositoryFixed("") == nil)
+	if _, err := connect(""); err != nil {
+		fmt.Println("   connect(\"\") returns *MySQLRepository and an error:", err)
+	}
+	if r, err := connect("mysql://hr"); err == nil {
+		var saver EmployeeRepository = r // ✅ non-nil pointer, non-nil interface
+		_ = saver.Save("Carol")
+	}
+	fmt.Println("   isNil(repo) sees through the interface, with reflect:", isNil(repo))
+
+	fmt.Println("📏 Rules of thumb")
+	fmt.Println("   • a function returning an interface returns nil literally, not a nil pointer variable")
+	fmt.Println("   • keep concrete error variables out of error results: return &E{} or nil")
+	fmt.Println("   • solid lint's typednil rule flags a possibly nil variable reaching an interface")
+}
+
+// guardedSave saves name through repo behind the usual nil guard, and
+// returns the panic it ends in, if any, as an error.
+func guardedSave(repo EmployeeRepository, name string) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("%v", r)
+		}
+	}()
+	if repo != nil { // ❌ the guard every caller writes
+		return repo.Save(name)
+	}
+	return nil
+}
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestOpenRepository_TypedNil(t *testing.T) {
+	repo := openRepository("")
+	if repo == nil {
+		t.Fatal("openRepository(\"\") == nil, want the typed nil trap reproduced")
+	}
+	if !isNil(repo) {
+		t.Error("isNil(repo) = false, want it to see the nil pointer inside")
+	}
+	if err := guardedSave(repo, "Alice"); err == nil {
+		t.Error("guardedSave() = nil, want the guard passed and Save panicking")
+	}
+}
+
+func TestValidate_TypedNil(t *testing.T) {
+	err := validate("Bob")
+	if err == nil {
+		t.Fatal("validate(\"Bob\") == nil, want a non-nil error holding a nil *ValidationError")
+	}
+	var v *ValidationError
+	if !errors.As(err, &v) || v != nil {
+		t.Errorf("validate(\"Bob\") = %#v, want a nil *ValidationError", err)
+	}
+	if err := validateFixed("Bob"); err != nil {
+		t.Errorf("validateFixed(\"Bob\") = %v, want nil", err)
+	}
+	if err := validateFixed(""); !errors.As(err, &v) || v.Field != "name" {
+		t.Errorf("validateFixed(\"\") = %v, want name is required", err)
+	}
+}
+
+func TestFixes(t *testing.T) {
+	if repo := openRepositoryFixed(""); repo != nil {
+		t.Errorf("openRepositoryFixed(\"\") = %v, want nil", repo)
+	}
+	if r, err := connect(""); r != nil || err == nil {
+		t.Errorf("connect(\"\") = %v, %v; want no repository and an error", r, err)
+	}
+	r, err := connect("mysql://hr")
+	if err != nil {
+		t.Fatalf("connect() error = %v", err)
+	}
+	if err := guardedSave(r, "Carol"); err != nil {
+		t.Errorf("Save() through a real connection = %v, want nil", err)
+	}
+	if isNil(r) || !isNil(nil) {
+		t.Error("isNil() doesn't tell a connection from nothing")
+	}
+}
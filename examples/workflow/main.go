@@ -0,0 +1,104 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go-solid/clock"
+	"go-solid/id"
+	"go-solid/leave"
+	leavemem "go-solid/leave/memory"
+	"go-solid/notify"
+	"go-solid/workflow"
+	"go-solid/workflow/memory"
+)
+
+const yearlyAllowance = 21
+
+func main() {
+	ctx := context.Background()
+	clk := clock.NewFake(time.Date(2025, 3, 3, 9, 0, 0, 0, time.UTC))
+	leaves := leavemem.New()
+
+	engine := workflow.NewEngine(memory.New(),
+		workflow.WithClock(clk),
+		workflow.WithIDs(id.NewSequence("wf")),
+		workflow.WithListener(workflow.Notifications{Notifier: notify.NewConsole(nil)}),
+		workflow.WithListener(leave.StatusSync{Repo: leaves}),
+	)
+
+	// ✅ Leave approval is configuration: every step, selector, rule and policy
+	// is swappable without touching the engine
+	org := workflow.Reports{"alice": "carol", "bob": "carol", "carol": "dave"}
+	engine.Define(workflow.Definition{Name: "leave", Steps: []workflow.Step{
+		workflow.Check{Label: "allowance", Allow: func(ctx context.Context, subj workflow.Subject) error {
+			if leave.Days(subj) > yearlyAllowance {
+				return fmt.Errorf("%d days exceeds the yearly allowance of %d", leave.Days(subj), yearlyAllowance)
+			}
+			return nil
+		}},
+		workflow.Approval{
+			Label:      "manager",
+			Approvers:  workflow.Manager{Org: org},
+			Rule:       workflow.AnyOne{},
+			SkipWhen:   func(s workflow.Subject) bool { return leave.Days(s) <= 2 },
+			Escalation: workflow.EscalateTo{After: 48 * time.Hour, Approvers: workflow.Manager{Org: org, Levels: 2}},
+		},
+		workflow.Approval{
+			Label:      "hr",
+			Approvers:  workflow.Named{"hana", "hiro", "hugo"},
+			Rule:       workflow.Majority{},
+			SkipWhen:   func(s workflow.Subject) bool { return leave.Days(s) <= 10 },
+			Escalation: workflow.DecideAfter{After: 7 * 24 * time.Hour, Outcome: workflow.Approved},
+		},
+	}})
+
+	request := func(reqID, who string, days int) workflow.Instance {
+		req := leave.Request{ID: reqID, Employee: who, Days: days, Status: leave.Pending, RequestedAt: clk.Now()}
+		_ = leaves.Save(ctx, req)
+		inst, err := engine.Start(ctx, "leave", req.Subject())
+		if err != nil {
+			fmt.Println("❌", err)
+		}
+		return inst
+	}
+
+	fmt.Println("🏖️  Alice asks for 2 days - below the manager threshold")
+	request("leave-1", "alice", 2)
+
+	fmt.Println("\n🏖️  Bob asks for 30 days - the allowance check rejects it")
+	request("leave-2", "bob", 30)
+
+	fmt.Println("\n🏖️  Alice asks for 5 days; Carol is away, so it escalates to Dave after 48h")
+	five := request("leave-3", "alice", 5)
+	clk.Advance(49 * time.Hour)
+	n, _ := engine.Escalate(ctx)
+	fmt.Printf("   ⏰ escalated %d instance(s)\n", n)
+	if _, err := engine.Vote(ctx, five.ID, "dave", true, "enjoy"); err != nil {
+		fmt.Println("❌", err)
+	}
+
+	fmt.Println("\n🏖️  Bob asks for 15 days: manager, then an HR majority")
+	fifteen := request("leave-4", "bob", 15)
+	_, _ = engine.Vote(ctx, fifteen.ID, "carol", true, "")
+	_, _ = engine.Vote(ctx, fifteen.ID, "hana", false, "release week")
+	_, err := engine.Vote(ctx, fifteen.ID, "bob", true, "")
+	fmt.Println("   ❌ bob votes on his own request:", err)
+	_, _ = engine.Vote(ctx, fifteen.ID, "hiro", true, "")
+	_, _ = engine.Vote(ctx, fifteen.ID, "hugo", true, "")
+
+	fmt.Println("\n📋 Leave requests, as the leave repository now sees them")
+	for _, who := range []string{"alice", "bob"} {
+		reqs, _ := leaves.ListByEmployee(ctx, who)
+		for _, r := range reqs {
+			fmt.Printf("   %s %-6s %2d days  %s\n", r.ID, r.Employee, r.Days, r.Status)
+		}
+	}
+
+	fmt.Println("\n📜 History of leave-4")
+	inst, _ := engine.Get(ctx, fifteen.ID)
+	for _, e := range inst.History {
+		fmt.Printf("   %-9s %-9s %-6s %s\n", e.Step, e.Action, e.Actor, e.Note)
+	}
+}
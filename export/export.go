@@ -0,0 +1,251 @@
+// Package export streams every employee from a repository into a blob store
+// in any registered format.
+//
+// The Exporter depends on three abstractions - an employee.QueryRepository to
+// page through, a codec.Codec to encode with and a blob.Store to write to -
+// and on two optional capabilities: when the store supports multipart uploads
+// and the format can be continued, the export is uploaded in chunks and an
+// interrupted run picks up after the last uploaded chunk.
+package export
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"time"
+
+	"go-solid/blob"
+	"go-solid/codec"
+	"go-solid/employee"
+	"go-solid/money"
+)
+
+// Row Wire format of an exported employee. The redact tags let redact.NewCodec
+// mask the sensitive columns.
+//
+// Fields are only ever added, and optional: a new JSON field is omitted when
+// empty, and a new CSV column goes last, so files written before it existed
+// read the same, and readers that don't know it can skip it.
+type Row struct {
+	ID         string      `json:"id"`
+	Name       string      `json:"name" redact:"pii"`
+	Title      string      `json:"title,omitempty"`
+	Salary     money.Money `json:"salary" redact:"financial"`
+	HiredAt    time.Time   `json:"hired_at,omitzero"`
+	Department string      `json:"department,omitempty"`
+}
+
+func (Row) CSVHeader() []string {
+	return []string{"id", "name", "title", "salary", "currency", "hired_at", "department"}
+}
+
+func (r Row) CSVRecord() []string {
+	hired := ""
+	if !r.HiredAt.IsZero() {
+		hired = r.HiredAt.UTC().Format(time.DateOnly)
+	}
+	return []string{r.ID, r.Name, r.Title, r.Salary.Amount(), string(r.Salary.Currency()), hired, r.Department}
+}
+
+// DefaultChunkSize is the smallest part size S3 accepts for all but the last part.
+const DefaultChunkSize = 5 << 20
+
+// Exporter Streams employees -> codec -> blob store
+type Exporter struct {
+	Source employee.Repository
+	Codec  codec.Codec
+	Store  blob.Store
+	// ChunkSize is the size a part grows to before it is uploaded; defaults to DefaultChunkSize.
+	ChunkSize int
+}
+
+// Result What an export wrote
+type Result struct {
+	Employees int
+	Parts     int // 0 when the store took a single Put
+	Resumed   bool
+}
+
+// checkpoint is stored next to the object while a chunked export is in
+// progress and deleted once it completes.
+type checkpoint struct {
+	Format    string `json:"format"`
+	UploadID  string `json:"upload_id"`
+	Parts     int    `json:"parts"`
+	Cursor    string `json:"cursor"`
+	Employees int    `json:"employees"`
+}
+
+func checkpointKey(key string) string { return key + ".checkpoint" }
+
+// order is fixed so a cursor saved by one run is valid in the next.
+var order = employee.Filter{Sort: employee.SortByName}
+
+// Export writes all employees to key. A resumed export is not a snapshot:
+// employees changed after the last uploaded chunk appear in their new state.
+func (e *Exporter) Export(ctx context.Context, key string) (Result, error) {
+	q, ok := e.Source.(employee.QueryRepository)
+	if !ok {
+		return Result{}, fmt.Errorf("export: repository cannot list employees: %w", errors.ErrUnsupported)
+	}
+	mp, ok := e.Store.(blob.Multipart)
+	if !ok {
+		return e.put(ctx, q, key)
+	}
+	return e.multipart(ctx, q, mp, key)
+}
+
+// put streams the whole export into one Put; nothing to resume from.
+func (e *Exporter) put(ctx context.Context, q employee.QueryRepository, key string) (Result, error) {
+	var res Result
+	pr, pw := io.Pipe()
+	go func() {
+		enc := e.Codec.NewEncoder(pw)
+		for emp, err := range pages(ctx, q, "") {
+			if err == nil {
+				err = enc.Encode(toRow(emp))
+			}
+			if err != nil {
+				pw.CloseWithError(err)
+				return
+			}
+			res.Employees++
+		}
+		pw.CloseWithError(enc.Close())
+	}()
+	if err := e.Store.Put(ctx, key, pr); err != nil {
+		pr.CloseWithError(err) // unblock the encoder if the store gave up early
+		return Result{}, fmt.Errorf("export %q: %w", key, err)
+	}
+	return res, nil
+}
+
+func (e *Exporter) multipart(ctx context.Context, q employee.QueryRepository, mp blob.Multipart, key string) (Result, error) {
+	cp, resumed := e.resume(ctx, mp, key)
+	if !resumed {
+		uploadID, err := mp.CreateUpload(ctx, key)
+		if err != nil {
+			return Result{}, fmt.Errorf("export %q: %w", key, err)
+		}
+		cp = checkpoint{Format: e.Codec.Name(), UploadID: uploadID}
+	}
+
+	var buf bytes.Buffer
+	enc := e.Codec.NewEncoder(&buf)
+	if resumed {
+		enc = e.Codec.(codec.Continuer).ContinueEncoder(&buf)
+	}
+	chunk := e.ChunkSize
+	if chunk <= 0 {
+		chunk = DefaultChunkSize
+	}
+
+	encoded := cp.Employees // cp only counts what is safely uploaded
+	for emp, err := range pages(ctx, q, cp.Cursor) {
+		if err == nil {
+			err = enc.Encode(toRow(emp))
+		}
+		if err != nil {
+			return e.result(cp, resumed), fmt.Errorf("export %q: %w", key, err)
+		}
+		encoded++
+		if buf.Len() >= chunk {
+			if err := e.uploadPart(ctx, mp, key, &cp, &buf, employee.CursorAfter(order, emp), encoded); err != nil {
+				return e.result(cp, resumed), err
+			}
+		}
+	}
+	if err := enc.Close(); err != nil {
+		return e.result(cp, resumed), fmt.Errorf("export %q: %w", key, err)
+	}
+	if buf.Len() > 0 || cp.Parts == 0 {
+		if err := e.uploadPart(ctx, mp, key, &cp, &buf, cp.Cursor, encoded); err != nil {
+			return e.result(cp, resumed), err
+		}
+	}
+
+	if err := mp.CompleteUpload(ctx, key, cp.UploadID, cp.Parts); err != nil {
+		return e.result(cp, resumed), fmt.Errorf("export %q: %w", key, err)
+	}
+	_ = e.Store.Delete(ctx, checkpointKey(key))
+	return e.result(cp, resumed), nil
+}
+
+// uploadPart sends buf as the next part and then records how far the export
+// got. The part is uploaded before the checkpoint moves, so a crash in between
+// just re-uploads the same part number.
+func (e *Exporter) uploadPart(ctx context.Context, mp blob.Multipart, key string, cp *checkpoint, buf *bytes.Buffer, cursor string, employees int) error {
+	if err := mp.UploadPart(ctx, key, cp.UploadID, cp.Parts+1, buf.Bytes()); err != nil {
+		return fmt.Errorf("export %q: part %d: %w", key, cp.Parts+1, err)
+	}
+	buf.Reset()
+	cp.Parts++
+	cp.Cursor = cursor
+	cp.Employees = employees
+	if _, continuable := e.Codec.(codec.Continuer); !continuable {
+		return nil // nothing could resume it anyway
+	}
+	b, _ := json.Marshal(cp)
+	if err := e.Store.Put(ctx, checkpointKey(key), bytes.NewReader(b)); err != nil {
+		return fmt.Errorf("export %q: checkpoint: %w", key, err)
+	}
+	return nil
+}
+
+// resume loads the checkpoint of an interrupted export to key, if there is a
+// usable one. One it can't use (another format, upload gone) is discarded.
+func (e *Exporter) resume(ctx context.Context, mp blob.Multipart, key string) (checkpoint, bool) {
+	rc, err := e.Store.Get(ctx, checkpointKey(key))
+	if err != nil {
+		return checkpoint{}, false
+	}
+	var cp checkpoint
+	err = json.NewDecoder(rc).Decode(&cp)
+	rc.Close()
+
+	_, continuable := e.Codec.(codec.Continuer)
+	if err == nil && continuable && cp.Format == e.Codec.Name() {
+		if parts, err := mp.ListParts(ctx, key, cp.UploadID); err == nil && len(parts) >= cp.Parts {
+			return cp, true
+		}
+	}
+	if cp.UploadID != "" {
+		_ = mp.AbortUpload(ctx, key, cp.UploadID)
+	}
+	_ = e.Store.Delete(ctx, checkpointKey(key))
+	return checkpoint{}, false
+}
+
+func (e *Exporter) result(cp checkpoint, resumed bool) Result {
+	return Result{Employees: cp.Employees, Parts: cp.Parts, Resumed: resumed}
+}
+
+// pages yields every employee after cursor, one page at a time.
+func pages(ctx context.Context, q employee.QueryRepository, cursor string) iter.Seq2[employee.Employee, error] {
+	return func(yield func(employee.Employee, error) bool) {
+		for {
+			res, err := q.List(ctx, order, employee.Page{Cursor: cursor, Limit: employee.MaxPageSize})
+			if err != nil {
+				yield(employee.Employee{}, err)
+				return
+			}
+			for _, emp := range res.Items {
+				if !yield(emp, nil) {
+					return
+				}
+			}
+			if res.NextCursor == "" {
+				return
+			}
+			cursor = res.NextCursor
+		}
+	}
+}
+
+func toRow(emp employee.Employee) Row {
+	return Row{ID: string(emp.ID), Name: emp.Name, Title: emp.Title, Salary: emp.Salary, HiredAt: emp.HiredAt, Department: emp.Department}
+}
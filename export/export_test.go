@@ -0,0 +1,186 @@
+package export_test
+
+import (
+	"bufio"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"testing"
+
+	"go-solid/blob"
+	"go-solid/blob/blobtest"
+	"go-solid/codec"
+	"go-solid/employee"
+	"go-solid/employee/memory"
+	"go-solid/export"
+	"go-solid/money"
+)
+
+type store interface {
+	blob.Store
+	blob.Multipart
+}
+
+var stores = []struct {
+	name string
+	open func(t *testing.T) store
+}{
+	{"dir", func(t *testing.T) store { return blob.NewDir(t.TempDir()) }},
+	{"s3", func(t *testing.T) store {
+		return &blob.S3{Bucket: "backups", Endpoint: blobtest.NewS3(t).URL}
+	}},
+}
+
+// staff saves n employees and returns their names in the order an export writes them.
+func staff(t *testing.T, n int) (*memory.Repository, []string) {
+	t.Helper()
+	repo := memory.New()
+	var names []string
+	for i := range n {
+		name := fmt.Sprintf("Staff %03d", i)
+		if err := repo.Save(t.Context(), employee.Employee{Name: name, Title: "Engineer", Salary: money.Of(5000, money.USD)}); err != nil {
+			t.Fatal(err)
+		}
+		names = append(names, name)
+	}
+	return repo, names
+}
+
+// exported reads back the names of a JSONL export.
+func exported(t *testing.T, s blob.Store, key string) []string {
+	t.Helper()
+	rc, err := s.Get(t.Context(), key)
+	if err != nil {
+		t.Fatalf("Get(%s) error = %v", key, err)
+	}
+	defer rc.Close()
+	var names []string
+	lines := bufio.NewScanner(rc)
+	for lines.Scan() {
+		var row export.Row
+		if err := json.Unmarshal(lines.Bytes(), &row); err != nil {
+			t.Fatalf("line %q: %v", lines.Text(), err)
+		}
+		names = append(names, row.Name)
+	}
+	return names
+}
+
+// interrupted fails UploadPart for part failAt, as a dropped connection would.
+type interrupted struct {
+	store
+	failAt int
+}
+
+var errDropped = errors.New("connection reset by peer")
+
+func (s interrupted) UploadPart(ctx context.Context, key, uploadID string, n int, data []byte) error {
+	if n == s.failAt {
+		return errDropped
+	}
+	return s.store.UploadPart(ctx, key, uploadID, n, data)
+}
+
+func TestExporter_ResumesAnInterruptedUpload(t *testing.T) {
+	for _, tt := range stores {
+		t.Run(tt.name, func(t *testing.T) {
+			repo, names := staff(t, 100)
+			s := tt.open(t)
+			jsonl, _ := codec.Lookup("jsonl")
+			first := &export.Exporter{Source: repo, Codec: jsonl, Store: interrupted{s, 3}, ChunkSize: 500}
+			res, err := first.Export(t.Context(), "employees.jsonl")
+			if !errors.Is(err, errDropped) || res.Parts != 2 || res.Resumed {
+				t.Fatalf("Export() = %+v, %v, want it stopped after 2 parts by %v", res, err, errDropped)
+			}
+			if _, err := s.Get(t.Context(), "employees.jsonl"); !errors.Is(err, blob.ErrNotFound) {
+				t.Errorf("Get() after the interruption error = %v, want nothing visible yet", err)
+			}
+
+			again := &export.Exporter{Source: repo, Codec: jsonl, Store: s, ChunkSize: 500}
+			res, err = again.Export(t.Context(), "employees.jsonl")
+			if err != nil || !res.Resumed || res.Employees != 100 || res.Parts <= 2 {
+				t.Fatalf("Export() again = %+v, %v, want all 100 after resuming from part 3", res, err)
+			}
+			if got := exported(t, s, "employees.jsonl"); !slices.Equal(got, names) {
+				t.Errorf("exported %d names, want each of the %d once, in order", len(got), len(names))
+			}
+			if _, err := s.Get(t.Context(), "employees.jsonl.checkpoint"); !errors.Is(err, blob.ErrNotFound) {
+				t.Errorf("Get(checkpoint) error = %v, want it deleted once complete", err)
+			}
+		})
+	}
+}
+
+func TestExporter_ResumedCSVHasOneHeader(t *testing.T) {
+	repo, names := staff(t, 60)
+	s := blob.NewDir(t.TempDir())
+	csvCodec, _ := codec.Lookup("csv")
+	_, _ = (&export.Exporter{Source: repo, Codec: csvCodec, Store: interrupted{s, 2}, ChunkSize: 300}).Export(t.Context(), "employees.csv")
+	if res, err := (&export.Exporter{Source: repo, Codec: csvCodec, Store: s, ChunkSize: 300}).Export(t.Context(), "employees.csv"); err != nil || !res.Resumed {
+		t.Fatalf("Export() again = %+v, %v, want it resumed", res, err)
+	}
+	rc, err := s.Get(t.Context(), "employees.csv")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rc.Close()
+	records, err := csv.NewReader(rc).ReadAll()
+	if err != nil {
+		t.Fatalf("reading the CSV: %v", err)
+	}
+	if len(records) != len(names)+1 || !slices.Equal(records[0], export.Row{}.CSVHeader()) {
+		t.Fatalf("CSV = %d records starting %v, want a header and %d rows", len(records), records[0], len(names))
+	}
+	for i, rec := range records[1:] {
+		if rec[1] != names[i] {
+			t.Errorf("row %d = %s, want %s", i+1, rec[1], names[i])
+		}
+	}
+}
+
+func TestExporter_RestartsWhatCannotResume(t *testing.T) {
+	repo, names := staff(t, 60)
+	s := blob.NewDir(t.TempDir())
+	jsonl, _ := codec.Lookup("jsonl")
+	_, _ = (&export.Exporter{Source: repo, Codec: jsonl, Store: interrupted{s, 2}, ChunkSize: 300}).Export(t.Context(), "employees")
+
+	// the same key in a format a fresh encoder can't carry on
+	jsonCodec, _ := codec.Lookup("json")
+	res, err := (&export.Exporter{Source: repo, Codec: jsonCodec, Store: s, ChunkSize: 300}).Export(t.Context(), "employees")
+	if err != nil || res.Resumed || res.Employees != 60 {
+		t.Fatalf("Export() as JSON = %+v, %v, want all 60 from the start", res, err)
+	}
+	rc, _ := s.Get(t.Context(), "employees")
+	defer rc.Close()
+	var rows []export.Row
+	if err := json.NewDecoder(rc).Decode(&rows); err != nil || len(rows) != len(names) {
+		t.Errorf("exported %d rows, %v, want one JSON array of %d", len(rows), err, len(names))
+	}
+}
+
+func TestExporter_PutsWithoutMultipart(t *testing.T) {
+	repo, names := staff(t, 30)
+	s := blob.NewMemory()
+	jsonl, _ := codec.Lookup("jsonl")
+	res, err := (&export.Exporter{Source: repo, Codec: jsonl, Store: s}).Export(t.Context(), "employees.jsonl")
+	if err != nil || res.Parts != 0 || res.Employees != 30 {
+		t.Fatalf("Export() = %+v, %v, want 30 in one Put", res, err)
+	}
+	if got := exported(t, s, "employees.jsonl"); !slices.Equal(got, names) {
+		t.Errorf("exported %v, want %v", got, names)
+	}
+}
+
+func TestExporter_NeedsAListing(t *testing.T) {
+	jsonl, _ := codec.Lookup("jsonl")
+	e := &export.Exporter{Source: unlisted{memory.New()}, Codec: jsonl, Store: blob.NewMemory()}
+	if _, err := e.Export(t.Context(), "employees.jsonl"); !errors.Is(err, errors.ErrUnsupported) {
+		t.Errorf("Export() error = %v, want %v", err, errors.ErrUnsupported)
+	}
+}
+
+// unlisted hides the backend's List.
+type unlisted struct{ employee.Repository }
@@ -0,0 +1,203 @@
+// Package fakes makes up believable employee data - names, titles, salaries,
+// teams and payroll histories - to fill repositories in demos, benchmarks and
+// load tests.
+//
+// Everything is drawn from a seeded generator: the same seed gives the same
+// people, so a run that went wrong can be run again with the same data.
+package fakes
+
+import (
+	"context"
+	"fmt"
+	"math/rand/v2"
+	"strings"
+	"time"
+
+	"go-solid/employee"
+	"go-solid/money"
+	"go-solid/payroll"
+)
+
+// Faker Abstraction - a source of made-up domain data. Names are unique per
+// Faker, so everything it makes can be saved to the same repository.
+type Faker interface {
+	Employee() employee.Employee
+	// Manager is an employee with a management title and pay to match.
+	Manager() employee.Employee
+	// Team is a manager and size members.
+	Team(size int) Team
+	// PayrollHistory is one payslip per month, oldest first, for the months
+	// emp was employed; the last is the month before the Faker's now.
+	PayrollHistory(emp employee.Employee, months int) []payroll.Payslip
+}
+
+// Team A manager and the people reporting to them
+type Team struct {
+	Name    string
+	Manager employee.Employee
+	Members []employee.Employee
+}
+
+// Random Faker drawing from a PCG generator; not safe for concurrent use
+type Random struct {
+	rng      *rand.Rand
+	now      time.Time
+	currency money.Currency
+	employed int
+	names    map[string]int
+}
+
+// Option customises a Random created by New
+type Option func(*Random)
+
+// WithNow sets the date hires and payroll histories are counted back from;
+// it is fixed by default so data doesn't change with the day it is made.
+func WithNow(t time.Time) Option { return func(r *Random) { r.now = t } }
+
+// WithCurrency pays everyone in c instead of USD.
+func WithCurrency(c money.Currency) Option { return func(r *Random) { r.currency = c } }
+
+// New creates a Random Faker; the same seed and options make the same data.
+func New(seed uint64, opts ...Option) *Random {
+	r := &Random{
+		rng:      rand.New(rand.NewPCG(seed, seed)),
+		now:      time.Date(2026, time.January, 1, 0, 0, 0, 0, time.UTC),
+		currency: money.USD,
+		names:    map[string]int{},
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
+}
+
+// band A title and its monthly pay range, in USD
+type band struct {
+	title    string
+	min, max int64
+}
+
+var (
+	firstNames = []string{
+		"Mohamed", "Ahmed", "Ali", "Amira", "Aya", "Omar", "Sara", "Youssef", "Fatma", "Khaled",
+		"Nour", "Hassan", "Mona", "Karim", "Laila", "Alice", "Bob", "Carol", "Dave", "Eve",
+		"Hiro", "Yuki", "Priya", "Arjun", "Lucia", "Mateo", "Ingrid", "Lars", "Chloe", "Noah",
+	}
+	lastNames = []string{
+		"Habib", "Hassan", "Mahmoud", "Ibrahim", "Saleh", "Farouk", "Nasser", "Kamal", "Youssef", "Fahmy",
+		"Smith", "Jones", "Brown", "Garcia", "Martin", "Tanaka", "Sato", "Patel", "Sharma", "Rossi",
+		"Müller", "Schmidt", "Larsen", "Dubois", "Silva", "Kowalski", "Novak", "O'Brien", "Kim", "Chen",
+	}
+	staff = []band{
+		{"Junior Engineer", 3000, 4200},
+		{"Engineer", 4000, 6000},
+		{"Senior Engineer", 5500, 8000},
+		{"Staff Engineer", 7500, 10000},
+		{"Designer", 3500, 6500},
+		{"Product Analyst", 3500, 6000},
+		{"QA Engineer", 3200, 5200},
+		{"Support Specialist", 2800, 4000},
+	}
+	managers = []band{
+		{"Engineering Manager", 8000, 11000},
+		{"Product Manager", 7000, 10000},
+		{"Head of Design", 7500, 10500},
+		{"Director of Engineering", 10000, 14000},
+	}
+	teamNames = []string{
+		"Payroll", "Platform", "Payments", "Onboarding", "Search", "Mobile", "Reporting", "Identity",
+		"Billing", "Growth", "Infrastructure", "Support Tools",
+	}
+	// perUSD Roughly how many units of a currency a dollar buys, to keep
+	// salaries in other currencies believable
+	perUSD = map[money.Currency]int64{
+		money.USD: 1, money.EUR: 1, money.GBP: 1, money.SAR: 4, money.EGP: 50, money.JPY: 150,
+	}
+)
+
+func (r *Random) Employee() employee.Employee { return r.person(staff) }
+
+func (r *Random) Manager() employee.Employee { return r.person(managers) }
+
+func (r *Random) Team(size int) Team {
+	name := r.unique(pick(r.rng, teamNames))
+	t := Team{Name: name, Manager: r.Manager()}
+	for range size {
+		t.Members = append(t.Members, r.Employee())
+	}
+	return t
+}
+
+// PayrollHistory walks back from emp's current salary: every twelve months
+// of service, counted from the hire date, came with a raise of 3-10%.
+func (r *Random) PayrollHistory(emp employee.Employee, months int) []payroll.Payslip {
+	last := time.Date(r.now.Year(), r.now.Month(), 1, 0, 0, 0, 0, time.UTC).AddDate(0, -1, 0)
+	first := last.AddDate(0, 1-months, 0)
+	if hired := time.Date(emp.HiredAt.Year(), emp.HiredAt.Month(), 1, 0, 0, 0, 0, time.UTC); first.Before(hired) {
+		first = hired
+	}
+
+	var slips []payroll.Payslip
+	pay, unit := emp.Salary.Minor(), money.Of(1, emp.Salary.Currency()).Minor()
+	for month := last; !month.Before(first); month = month.AddDate(0, -1, 0) {
+		slips = append(slips, payroll.Payslip{
+			EmployeeID: string(emp.ID),
+			Name:       emp.Name,
+			Period:     payroll.Period{Year: month.Year(), Month: month.Month()},
+			Base:       money.FromMinor(pay, emp.Salary.Currency()),
+		})
+		// the month of a work anniversary is the first one paid at the raised salary
+		if month.Month() == emp.HiredAt.Month() && month.Year() > emp.HiredAt.Year() {
+			pay = pay * 100 / (103 + r.rng.Int64N(8))
+			pay -= pay % unit
+		}
+	}
+	for i, j := 0, len(slips)-1; i < j; i, j = i+1, j-1 {
+		slips[i], slips[j] = slips[j], slips[i]
+	}
+	return slips
+}
+
+// person makes an employee with a title from bands.
+func (r *Random) person(bands []band) employee.Employee {
+	first, last := pick(r.rng, firstNames), pick(r.rng, lastNames)
+	name := r.unique(first + " " + last)
+	b := pick(r.rng, bands)
+	units := (b.min + r.rng.Int64N(b.max-b.min+1)) * perUSD[r.currency]
+	units -= units % 50
+	r.employed++
+	return employee.Employee{
+		ID:      employee.ID(fmt.Sprintf("emp-%d", r.employed)),
+		Name:    name,
+		Title:   b.title,
+		Email:   strings.ToLower(strings.NewReplacer(" ", ".", "'", "").Replace(name)) + "@example.com",
+		Salary:  money.Of(max(units, 1), r.currency),
+		HiredAt: r.now.AddDate(0, 0, -1-r.rng.IntN(10*365)).Truncate(24 * time.Hour),
+	}
+}
+
+// unique numbers repeats of name: "Aya Kim", "Aya Kim 2", ...
+func (r *Random) unique(name string) string {
+	r.names[name]++
+	if n := r.names[name]; n > 1 {
+		return fmt.Sprintf("%s %d", name, n)
+	}
+	return name
+}
+
+func pick[T any](rng *rand.Rand, from []T) T { return from[rng.IntN(len(from))] }
+
+// Seed saves n employees from f to repo and returns them.
+func Seed(ctx context.Context, repo employee.Repository, f Faker, n int) ([]employee.Employee, error) {
+	emps := make([]employee.Employee, 0, n)
+	for range n {
+		emp := f.Employee()
+		if err := repo.Save(ctx, emp); err != nil {
+			return emps, fmt.Errorf("seeding %s: %w", emp.Name, err)
+		}
+		emps = append(emps, emp)
+	}
+	return emps, nil
+}
+
+var _ Faker = (*Random)(nil)
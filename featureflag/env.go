@@ -0,0 +1,28 @@
+package featureflag
+
+import (
+	"context"
+	"os"
+	"strings"
+)
+
+// Env Low-level module - reads FEATURE_<NAME> environment variables, where
+// "new-bonus" becomes FEATURE_NEW_BONUS
+type Env struct {
+	// Lookup defaults to os.LookupEnv; override it in tests.
+	Lookup func(key string) (string, bool)
+}
+
+func (e Env) Enabled(ctx context.Context, flag string) bool {
+	lookup := e.Lookup
+	if lookup == nil {
+		lookup = os.LookupEnv
+	}
+	value, _ := lookup(EnvKey(flag))
+	return Evaluate(ctx, flag, value)
+}
+
+// EnvKey returns the environment variable consulted for flag.
+func EnvKey(flag string) string {
+	return "FEATURE_" + strings.ToUpper(strings.NewReplacer("-", "_", ".", "_").Replace(flag))
+}
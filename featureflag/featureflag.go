@@ -0,0 +1,80 @@
+// Package featureflag decides at runtime whether a feature is switched on.
+//
+// New behaviour ships dark behind a flag and is rolled out gradually; the
+// code that chooses between old and new only depends on the Flags
+// abstraction, never on where the flag values come from.
+//
+// A flag value is one of:
+//
+//	"true" / "on"    enabled for everyone
+//	"false" / "off"  disabled (also the default for unknown flags)
+//	"25%"            enabled for a stable 25% of subjects (see WithSubject)
+package featureflag
+
+import (
+	"context"
+	"hash/fnv"
+	"strconv"
+	"strings"
+)
+
+// Flags Abstraction - answers "is this feature on for the current caller?"
+type Flags interface {
+	Enabled(ctx context.Context, flag string) bool
+}
+
+type subjectKey struct{}
+
+// WithSubject attaches the subject (user, employee, tenant...) percentage
+// rollouts are bucketed by.
+func WithSubject(ctx context.Context, subject string) context.Context {
+	return context.WithValue(ctx, subjectKey{}, subject)
+}
+
+func subjectFrom(ctx context.Context) string {
+	s, _ := ctx.Value(subjectKey{}).(string)
+	return s
+}
+
+// Evaluate applies a raw flag value to the subject stored in ctx. Every
+// implementation shares it so the same value means the same thing everywhere.
+func Evaluate(ctx context.Context, flag, value string) bool {
+	value = strings.ToLower(strings.TrimSpace(value))
+	switch value {
+	case "true", "on", "1", "100%":
+		return true
+	case "", "false", "off", "0", "0%":
+		return false
+	}
+
+	pct, ok := strings.CutSuffix(value, "%")
+	if !ok {
+		return false
+	}
+	n, err := strconv.ParseFloat(pct, 64)
+	if err != nil {
+		return false
+	}
+	subject := subjectFrom(ctx)
+	if subject == "" {
+		return false // no subject to bucket, stay on the safe side
+	}
+	return bucket(flag, subject) < n
+}
+
+// bucket maps a subject to a stable value in [0, 100) per flag, so raising a
+// rollout from 10% to 20% keeps the first 10% enabled.
+func bucket(flag, subject string) float64 {
+	h := fnv.New32a()
+	h.Write([]byte(flag))
+	h.Write([]byte{0})
+	h.Write([]byte(subject))
+	return float64(h.Sum32()%10000) / 100
+}
+
+// Static Flags from a fixed map - for tests and demos
+type Static map[string]string
+
+func (s Static) Enabled(ctx context.Context, flag string) bool {
+	return Evaluate(ctx, flag, s[flag])
+}
@@ -0,0 +1,48 @@
+package featureflag
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+)
+
+// File Low-level module - flags from a JSON object such as {"new-bonus": "25%"}.
+// The file is read on creation and again on every Reload.
+type File struct {
+	path   string
+	mu     sync.RWMutex
+	values map[string]string
+}
+
+func NewFile(path string) (*File, error) {
+	f := &File{path: path}
+	if err := f.Reload(); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// Reload re-reads the file; on error the previous values are kept.
+func (f *File) Reload() error {
+	data, err := os.ReadFile(f.path)
+	if err != nil {
+		return fmt.Errorf("featureflag: %w", err)
+	}
+	values := map[string]string{}
+	if err := json.Unmarshal(data, &values); err != nil {
+		return fmt.Errorf("featureflag: parse %s: %w", f.path, err)
+	}
+	f.mu.Lock()
+	f.values = values
+	f.mu.Unlock()
+	return nil
+}
+
+func (f *File) Enabled(ctx context.Context, flag string) bool {
+	f.mu.RLock()
+	value := f.values[flag]
+	f.mu.RUnlock()
+	return Evaluate(ctx, flag, value)
+}
@@ -0,0 +1,83 @@
+package featureflag
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"go-solid/clock"
+)
+
+// Remote Low-level module - fetches the same JSON document as File from a
+// flag service over HTTP and caches it for TTL. When a refresh fails the last
+// good values keep being used. This is a stub of a real flag provider: no
+// streaming updates, no auth.
+type Remote struct {
+	URL    string
+	TTL    time.Duration
+	Client *http.Client
+	Clock  clock.Clock
+
+	mu        sync.Mutex
+	values    map[string]string
+	fetchedAt time.Time
+}
+
+func (r *Remote) Enabled(ctx context.Context, flag string) bool {
+	values, err := r.snapshot(ctx)
+	if err != nil && values == nil {
+		return false // flag service down and nothing cached: fail closed, keep the old behaviour
+	}
+	return Evaluate(ctx, flag, values[flag])
+}
+
+func (r *Remote) snapshot(ctx context.Context) (map[string]string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := r.now()
+	if r.values != nil && now.Sub(r.fetchedAt) < r.TTL {
+		return r.values, nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, r.URL, nil)
+	if err != nil {
+		return r.values, err
+	}
+	client := r.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return r.values, fmt.Errorf("featureflag: fetch: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return r.values, fmt.Errorf("featureflag: fetch: %s", resp.Status)
+	}
+
+	values := map[string]string{}
+	if err := json.NewDecoder(resp.Body).Decode(&values); err != nil {
+		return r.values, fmt.Errorf("featureflag: decode: %w", err)
+	}
+	r.values, r.fetchedAt = values, now
+	return values, nil
+}
+
+func (r *Remote) now() time.Time {
+	if r.Clock == nil {
+		return time.Now()
+	}
+	return r.Clock.Now()
+}
+
+var (
+	_ Flags = Static(nil)
+	_ Flags = Env{}
+	_ Flags = (*File)(nil)
+	_ Flags = (*Remote)(nil)
+)
@@ -0,0 +1,346 @@
+package gen
+
+import (
+	"context"
+	"fmt"
+	"go/ast"
+	"go/types"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Service An interface, with the //api: annotations on its methods
+type Service struct {
+	Iface *types.Named
+	// Annotations are each method's //api: lines, without the prefix, by
+	// method name
+	Annotations map[string][]string
+}
+
+// FindService is Find, reading the annotations of the interface's methods
+// from its source.
+func FindService(ctx context.Context, dir, name string) (Service, error) {
+	named, pkg, err := find(ctx, dir, name)
+	if err != nil {
+		return Service{}, err
+	}
+	svc := Service{Iface: named, Annotations: map[string][]string{}}
+	for _, file := range pkg.Files {
+		ast.Inspect(file, func(n ast.Node) bool {
+			ts, ok := n.(*ast.TypeSpec)
+			if !ok || ts.Name.Name != named.Obj().Name() {
+				return true
+			}
+			for _, m := range ts.Type.(*ast.InterfaceType).Methods.List {
+				if len(m.Names) == 0 || m.Doc == nil {
+					continue // embedded, or not annotated
+				}
+				for _, c := range m.Doc.List {
+					if line, ok := strings.CutPrefix(c.Text, "//api:"); ok {
+						svc.Annotations[m.Names[0].Name] = append(svc.Annotations[m.Names[0].Name], line)
+					}
+				}
+			}
+			return false
+		})
+	}
+	return svc, nil
+}
+
+// restRoute What an //api:rest annotation says:
+//
+//	//api:rest METHOD PATH [IN] [-> OUT] [STATUS]
+//
+// {x} in PATH is the method's parameter x. IN, a name in the interface's
+// package, takes the other parameters: a struct is the JSON body, its
+// fields set from the parameters of the same name or from the fields of a
+// struct parameter; a function returning url.Values is the query. OUT is
+// the JSON response, converted to the method's result by a method of OUT
+// returning it. STATUS is that of success: 200, or 204 with no OUT.
+type restRoute struct {
+	method, path string
+	in, out      string
+	status       int
+}
+
+func parseREST(line string) (restRoute, error) {
+	fields := strings.Fields(strings.TrimPrefix(line, "rest"))
+	if len(fields) < 2 {
+		return restRoute{}, fmt.Errorf("%q: want METHOD PATH [IN] [-> OUT] [STATUS]", line)
+	}
+	r := restRoute{method: fields[0], path: fields[1]}
+	for i := 2; i < len(fields); i++ {
+		switch f := fields[i]; {
+		case f == "->" && i+1 < len(fields):
+			i++
+			r.out = fields[i]
+		case f[0] >= '0' && f[0] <= '9':
+			n, err := strconv.Atoi(f)
+			if err != nil {
+				return restRoute{}, fmt.Errorf("%q: status %q", line, f)
+			}
+			r.status = n
+		case r.in == "":
+			r.in = f
+		default:
+			return restRoute{}, fmt.Errorf("%q: unexpected %q", line, f)
+		}
+	}
+	if r.status == 0 {
+		r.status = 200
+		if r.out == "" {
+			r.status = 204
+		}
+	}
+	return r, nil
+}
+
+var pathParam = regexp.MustCompile(`\{(\w+)\}`)
+
+// RESTClient writes, into package pkg, a client of svc's interface over the
+// REST routes its //api:rest annotations give, named <Name>RESTClient. The
+// wire types are the ones the annotations name in the interface's package,
+// and error responses are read by that package's ReadError(*http.Response)
+// error, so the client carries no copy of the format to drift from the
+// server's.
+func RESTClient(svc Service, pkg string) ([]byte, error) {
+	f := newFile(pkg)
+	iface := svc.Iface
+	api := iface.Obj().Pkg()
+	name := iface.Obj().Name() + "RESTClient"
+	ifaceName := f.typ(iface)
+	readError, _ := api.Scope().Lookup("ReadError").(*types.Func)
+	if readError == nil || readError.Type().String() != "func(resp *net/http.Response) error" {
+		return nil, fmt.Errorf("gen: %s has no func ReadError(resp *http.Response) error to read error responses with", api.Path())
+	}
+	httpPkg := f.use("net/http", "http")
+
+	f.printf("// %s Client of %s over its annotated\n// REST routes\n", name, ifaceName)
+	f.printf("type %s struct {\n\tbase string\n\thttp *%s.Client\n}\n\n", name, httpPkg)
+	f.printf("// New%s calls the API at baseURL, e.g. http://localhost:8080, with hc\n", name)
+	f.printf("// (http.DefaultClient when nil).\n")
+	f.printf("func New%s(baseURL string, hc *%s.Client) *%s {\n", name, httpPkg, name)
+	f.printf("\tif hc == nil {\n\t\thc = %s.DefaultClient\n\t}\n\treturn &%s{base: baseURL, http: hc}\n}\n", httpPkg, name)
+
+	for _, m := range methods(iface) {
+		var route *restRoute
+		for _, line := range svc.Annotations[m.Name()] {
+			if strings.HasPrefix(line, "rest ") {
+				r, err := parseREST(line)
+				if err != nil {
+					return nil, fmt.Errorf("gen: %s.%s: %w", iface.Obj().Name(), m.Name(), err)
+				}
+				route = &r
+			}
+		}
+		if route == nil {
+			return nil, fmt.Errorf("gen: %s.%s has no //api:rest annotation", iface.Obj().Name(), m.Name())
+		}
+		if err := restMethod(f, name, api, m, *route); err != nil {
+			return nil, fmt.Errorf("gen: %s.%s: %w", iface.Obj().Name(), m.Name(), err)
+		}
+	}
+
+	f.printf("\nfunc (s *%s) do(ctx %s.Context, method, path string, in any, status int, out any) error {\n", name, f.use("context", "context"))
+	f.printf("\tvar body %s.Reader = %s.NoBody\n\tif in != nil {\n", f.use("io", "io"), httpPkg)
+	f.printf("\t\tb, err := %s.Marshal(in)\n\t\tif err != nil {\n\t\t\treturn err\n\t\t}\n", f.use("encoding/json", "json"))
+	f.printf("\t\tbody = %s.NewReader(b)\n\t}\n", f.use("bytes", "bytes"))
+	f.printf("\treq, err := %s.NewRequestWithContext(ctx, method, s.base+path, body)\n\tif err != nil {\n\t\treturn err\n\t}\n", httpPkg)
+	f.printf("\tif in != nil {\n\t\treq.Header.Set(\"Content-Type\", \"application/json\")\n\t}\n")
+	f.printf("\tresp, err := s.http.Do(req)\n\tif err != nil {\n\t\treturn err\n\t}\n\tdefer resp.Body.Close()\n")
+	f.printf("\tif resp.StatusCode != status {\n\t\treturn %s.ReadError(resp)\n\t}\n", f.qualifier(api))
+	f.printf("\tif out == nil {\n\t\treturn nil\n\t}\n")
+	f.printf("\tif err := %s.NewDecoder(resp.Body).Decode(out); err != nil {\n", f.use("encoding/json", "json"))
+	f.printf("\t\treturn %s.Errorf(\"%%s %%s: decoding response: %%w\", method, path, err)\n\t}\n\treturn nil\n}\n", f.use("fmt", "fmt"))
+
+	f.printf("\nvar _ %s = (*%s)(nil)\n", ifaceName, name)
+	return f.bytes("Code generated by solid gen client -transport rest; DO NOT EDIT.")
+}
+
+// restMethod writes the client method calling m's route.
+func restMethod(f *file, client string, api *types.Package, m *types.Func, r restRoute) error {
+	sig := m.Type().(*types.Signature)
+	in := f.params(sig.Params(), sig.Variadic(), "p")
+	out := f.params(sig.Results(), false, "r")
+	if len(in) == 0 || !isContext(sig.Params().At(0).Type()) {
+		return fmt.Errorf("the first parameter must be a context.Context")
+	}
+	if len(out) == 0 || len(out) > 2 || !isError(sig.Results().At(len(out)-1).Type()) {
+		return fmt.Errorf("want (T, error) or error results")
+	}
+
+	// the path, with its parameters escaped into it
+	used := map[string]bool{in[0].name: true}
+	var path []string
+	rest := r.path
+	for _, loc := range pathParam.FindAllStringSubmatchIndex(r.path, -1) {
+		pname := r.path[loc[2]:loc[3]]
+		i := paramIndex(sig.Params(), pname)
+		if i < 0 {
+			return fmt.Errorf("%s: no parameter %s", r.path, pname)
+		}
+		if b, ok := sig.Params().At(i).Type().Underlying().(*types.Basic); !ok || b.Kind() != types.String {
+			return fmt.Errorf("%s: parameter %s is not a string", r.path, pname)
+		}
+		if prefix := rest[:loc[0]-(len(r.path)-len(rest))]; prefix != "" {
+			path = append(path, strconv.Quote(prefix))
+		}
+		arg := in[i].name
+		if sig.Params().At(i).Type() != types.Typ[types.String] {
+			arg = "string(" + arg + ")"
+		}
+		path = append(path, f.use("net/url", "url")+".PathEscape("+arg+")")
+		used[in[i].name] = true
+		rest = r.path[loc[1]:]
+	}
+	if rest != "" {
+		path = append(path, strconv.Quote(rest))
+	}
+
+	// what the other parameters become
+	body := "nil"
+	var others []int
+	for i, p := range in {
+		if !used[p.name] {
+			others = append(others, i)
+		}
+	}
+	switch obj := api.Scope().Lookup(r.in).(type) {
+	case nil:
+		if r.in != "" {
+			return fmt.Errorf("%s.%s does not exist", api.Name(), r.in)
+		}
+		if len(others) > 0 {
+			return fmt.Errorf("parameter %s reaches no part of the request", in[others[0]].name)
+		}
+	case *types.Func:
+		q := obj.Type().(*types.Signature)
+		if q.Params().Len() != len(others) || q.Results().Len() != 1 || q.Results().At(0).Type().String() != "net/url.Values" {
+			return fmt.Errorf("%s must take the parameters after the path's and return url.Values", r.in)
+		}
+		args := make([]string, len(others))
+		for j, i := range others {
+			if !types.Identical(q.Params().At(j).Type(), sig.Params().At(i).Type()) {
+				return fmt.Errorf("%s takes %s, not %s", r.in, q.Params().At(j).Type(), sig.Params().At(i).Type())
+			}
+			args[j] = in[i].name
+		}
+		path = append(path, `"?"+`+f.qualifier(api)+"."+r.in+"("+strings.Join(args, ", ")+").Encode()")
+	case *types.TypeName:
+		st, ok := obj.Type().Underlying().(*types.Struct)
+		if !ok {
+			return fmt.Errorf("%s is not a struct", r.in)
+		}
+		lit, err := structLiteral(f, obj, st, sig.Params(), in, others)
+		if err != nil {
+			return err
+		}
+		body = lit
+	default:
+		return fmt.Errorf("%s is neither a type nor a function", r.in)
+	}
+
+	// the result, converted from the response
+	result, outVar := "", "nil"
+	if len(out) == 2 {
+		want := sig.Results().At(0).Type()
+		obj, _ := api.Scope().Lookup(r.out).(*types.TypeName)
+		if obj == nil {
+			return fmt.Errorf("returns %s, and the annotation names no response type to read it from", f.typ(want))
+		}
+		result = "resp"
+		if !types.Identical(obj.Type(), want) {
+			conv := converter(obj.Type(), want)
+			if conv == "" {
+				return fmt.Errorf("%s has no method returning %s", r.out, f.typ(want))
+			}
+			result = "resp." + conv + "()"
+		}
+		f.printf("\nfunc (s *%s) %s(%s)%s {\n", client, m.Name(), declare(in), results(out))
+		f.printf("\tvar resp %s\n", f.typ(obj.Type()))
+		outVar = "&resp"
+	} else {
+		if r.out != "" {
+			return fmt.Errorf("returns no value to read %s into", r.out)
+		}
+		f.printf("\nfunc (s *%s) %s(%s)%s {\n", client, m.Name(), declare(in), results(out))
+	}
+	call := fmt.Sprintf("s.do(%s, %q, %s, %s, %d, %s)", in[0].name, r.method, strings.Join(path, "+"), body, r.status, outVar)
+	if result == "" {
+		f.printf("\treturn %s\n}\n", call)
+		return nil
+	}
+	f.printf("\tif err := %s; err != nil {\n\t\tvar %s %s\n\t\treturn %s, err\n\t}\n", call, out[0].name, out[0].typ, out[0].name)
+	f.printf("\treturn %s, nil\n}\n", result)
+	return nil
+}
+
+// structLiteral builds the request body typ from the parameters others: a
+// field is set from the parameter of the same name or, failing that, from
+// the field of the same name of a struct parameter. Every field must be
+// set and every parameter used.
+func structLiteral(f *file, typ *types.TypeName, st *types.Struct, params *types.Tuple, in []param, others []int) (string, error) {
+	used := map[int]bool{}
+	var fields []string
+	for i := range st.NumFields() {
+		field := st.Field(i)
+		if !field.Exported() {
+			continue
+		}
+		value := ""
+		for _, j := range others {
+			if strings.EqualFold(params.At(j).Name(), field.Name()) && types.AssignableTo(params.At(j).Type(), field.Type()) {
+				value, used[j] = in[j].name, true
+				break
+			}
+		}
+		for _, j := range others {
+			if value != "" {
+				break
+			}
+			ps, ok := params.At(j).Type().Underlying().(*types.Struct)
+			if !ok {
+				continue
+			}
+			for k := range ps.NumFields() {
+				if pf := ps.Field(k); pf.Exported() && pf.Name() == field.Name() && types.AssignableTo(pf.Type(), field.Type()) {
+					value, used[j] = in[j].name+"."+pf.Name(), true
+					break
+				}
+			}
+		}
+		if value == "" {
+			return "", fmt.Errorf("no parameter for %s.%s", typ.Name(), field.Name())
+		}
+		fields = append(fields, field.Name()+": "+value)
+	}
+	for _, j := range others {
+		if !used[j] {
+			return "", fmt.Errorf("parameter %s reaches no field of %s", in[j].name, typ.Name())
+		}
+	}
+	return f.typ(typ.Type()) + "{" + strings.Join(fields, ", ") + "}", nil
+}
+
+// converter names the method of from, taking nothing, that returns to.
+func converter(from, to types.Type) string {
+	ms := types.NewMethodSet(from)
+	for i := range ms.Len() {
+		fn := ms.At(i).Obj().(*types.Func)
+		sig := fn.Type().(*types.Signature)
+		if fn.Exported() && sig.Params().Len() == 0 && sig.Results().Len() == 1 && types.Identical(sig.Results().At(0).Type(), to) {
+			return fn.Name()
+		}
+	}
+	return ""
+}
+
+func paramIndex(t *types.Tuple, name string) int {
+	for i := range t.Len() {
+		if t.At(i).Name() == name {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,282 @@
+// Package gen writes Go source from interface definitions: stubs for tests
+// (Stub), table-driven test skeletons (Tests), and clients over REST
+// (RESTClient) or gRPC (Proto, then GRPC). Interfaces are found by name,
+// type-checked, and rendered with their imports.
+package gen
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/build"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"go-solid/typeload"
+)
+
+var (
+	ErrNoInterface        = errors.New("no such interface")
+	ErrAmbiguousInterface = errors.New("ambiguous interface name")
+)
+
+// Find resolves name - "Notifier", or "notify.Notifier" to pick the
+// package - to the interface it names in the module containing dir.
+func Find(ctx context.Context, dir, name string) (*types.Named, error) {
+	named, _, err := find(ctx, dir, name)
+	return named, err
+}
+
+// find is Find, returning the package the interface was found in as well.
+func find(ctx context.Context, dir, name string) (*types.Named, typeload.Package, error) {
+	var none typeload.Package
+	pkgName, typeName, qualified := strings.Cut(name, ".")
+	if !qualified {
+		pkgName, typeName = "", name
+	}
+	module, err := goList(ctx, dir, "-m")
+	if err != nil {
+		return nil, none, err
+	}
+	out, err := goList(ctx, dir, "-f", "{{.ImportPath}}\t{{.Name}}\t{{.Dir}}\t{{join .GoFiles \",\"}}", strings.TrimSpace(module)+"/...")
+	if err != nil {
+		return nil, none, err
+	}
+
+	// a cheap syntactic pass first, to type-check only the package needed
+	var found []string
+	for line := range strings.Lines(out) {
+		fields := strings.Split(strings.TrimSpace(line), "\t")
+		if len(fields) < 4 || (pkgName != "" && fields[1] != pkgName) || fields[1] == "main" {
+			continue
+		}
+		if declaresInterface(fields[2], strings.Split(fields[3], ","), typeName) {
+			found = append(found, fields[0])
+		}
+	}
+	switch len(found) {
+	case 0:
+		return nil, none, fmt.Errorf("%w %s", ErrNoInterface, name)
+	case 1:
+	default:
+		return nil, none, fmt.Errorf("%w %s: in %s; qualify it, e.g. %s.%s", ErrAmbiguousInterface, name, strings.Join(found, ", "), path.Base(found[0]), typeName)
+	}
+
+	pkgs, err := typeload.Load(ctx, dir, found[0])
+	if err != nil {
+		return nil, none, err
+	}
+	pkg := pkgs[0]
+	if len(pkg.Errors) > 0 {
+		return nil, none, fmt.Errorf("gen: %w", pkg.Errors[0])
+	}
+	obj, _ := pkg.Types.Scope().Lookup(typeName).(*types.TypeName)
+	if obj == nil {
+		return nil, none, fmt.Errorf("%w %s", ErrNoInterface, name)
+	}
+	named, ok := obj.Type().(*types.Named)
+	if !ok || !types.IsInterface(named) || named.TypeParams().Len() > 0 {
+		return nil, none, fmt.Errorf("%w %s: not a plain interface", ErrNoInterface, name)
+	}
+	return named, pkg, nil
+}
+
+func goList(ctx context.Context, dir string, args ...string) (string, error) {
+	cmd := exec.CommandContext(ctx, "go", append([]string{"list"}, args...)...)
+	cmd.Dir = dir
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("gen: go list: %w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+	return string(out), nil
+}
+
+func declaresInterface(dir string, files []string, name string) bool {
+	fset := token.NewFileSet()
+	for _, file := range files {
+		f, err := parser.ParseFile(fset, filepath.Join(dir, file), nil, parser.SkipObjectResolution)
+		if err != nil {
+			continue
+		}
+		for _, decl := range f.Decls {
+			gd, ok := decl.(*ast.GenDecl)
+			if !ok || gd.Tok != token.TYPE {
+				continue
+			}
+			for _, spec := range gd.Specs {
+				ts := spec.(*ast.TypeSpec)
+				if _, isIface := ts.Type.(*ast.InterfaceType); isIface && ts.Name.Name == name {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// file Collects the imports of a generated file while its body is written
+type file struct {
+	pkg     string            // name of the generated package
+	imports map[string]string // path -> name used
+	body    bytes.Buffer
+}
+
+func newFile(pkg string) *file {
+	return &file{pkg: pkg, imports: map[string]string{}}
+}
+
+// qualifier imports p, renaming it when its name is taken.
+func (f *file) qualifier(p *types.Package) string {
+	if name, ok := f.imports[p.Path()]; ok {
+		return name
+	}
+	name := p.Name()
+	for i := 2; f.taken(name); i++ {
+		name = p.Name() + strconv.Itoa(i)
+	}
+	f.imports[p.Path()] = name
+	return name
+}
+
+// use imports path for code written by hand, like "go-solid/stub".
+func (f *file) use(path, name string) string {
+	return f.qualifier(types.NewPackage(path, name))
+}
+
+func (f *file) taken(name string) bool {
+	if name == f.pkg {
+		return true
+	}
+	for _, n := range f.imports {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
+
+func (f *file) typ(t types.Type) string { return types.TypeString(t, f.qualifier) }
+
+func (f *file) printf(format string, args ...any) { fmt.Fprintf(&f.body, format, args...) }
+
+// bytes renders the file and gofmts it.
+func (f *file) bytes(header string) ([]byte, error) {
+	var out bytes.Buffer
+	fmt.Fprintf(&out, "// %s\n\npackage %s\n\n", header, f.pkg)
+	paths := make([]string, 0, len(f.imports))
+	for p := range f.imports {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+	if len(paths) > 0 {
+		// the standard library first, as goimports groups them
+		sort.SliceStable(paths, func(i, j int) bool { return standard(paths[i]) && !standard(paths[j]) })
+		out.WriteString("import (\n")
+		for i, p := range paths {
+			if i > 0 && standard(paths[i-1]) != standard(p) {
+				out.WriteString("\n")
+			}
+			if f.imports[p] == path.Base(p) {
+				fmt.Fprintf(&out, "\t%q\n", p)
+			} else {
+				fmt.Fprintf(&out, "\t%s %q\n", f.imports[p], p)
+			}
+		}
+		out.WriteString(")\n\n")
+	}
+	out.Write(f.body.Bytes())
+	src, err := format.Source(out.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("gen: generated invalid code: %w\n%s", err, out.Bytes())
+	}
+	return src, nil
+}
+
+func standard(path string) bool {
+	pkg, err := build.Default.Import(path, "", build.FindOnly)
+	return err == nil && pkg.Goroot
+}
+
+// param One parameter or result of a method, named for generated code
+type param struct {
+	name     string
+	typ      string
+	variadic bool
+}
+
+// params names the tuple's entries after the interface's own names where
+// it has them, and p0, p1... (or r0, r1... for results) otherwise; "s" is
+// the receiver.
+func (f *file) params(t *types.Tuple, variadic bool, prefix string) []param {
+	ps := make([]param, t.Len())
+	used := map[string]bool{}
+	for i := range t.Len() {
+		v := t.At(i)
+		name := v.Name()
+		if name == "" || name == "_" || name == "s" || used[name] || prefix == "r" {
+			name = prefix + strconv.Itoa(i)
+		}
+		used[name] = true
+		ps[i] = param{name: name, typ: f.typ(v.Type())}
+		if variadic && i == t.Len()-1 {
+			ps[i].variadic = true
+			ps[i].typ = "..." + f.typ(v.Type().(*types.Slice).Elem())
+		}
+	}
+	return ps
+}
+
+func declare(ps []param) string {
+	parts := make([]string, len(ps))
+	for i, p := range ps {
+		parts[i] = p.name + " " + p.typ
+	}
+	return strings.Join(parts, ", ")
+}
+
+func pass(ps []param) string {
+	parts := make([]string, len(ps))
+	for i, p := range ps {
+		parts[i] = p.name
+		if p.variadic {
+			parts[i] += "..."
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+func results(ps []param) string {
+	switch len(ps) {
+	case 0:
+		return ""
+	case 1:
+		return " " + ps[0].typ
+	}
+	types := make([]string, len(ps))
+	for i, p := range ps {
+		types[i] = p.typ
+	}
+	return " (" + strings.Join(types, ", ") + ")"
+}
+
+// methods lists the interface's methods, embedded ones included, sorted.
+func methods(named *types.Named) []*types.Func {
+	iface := named.Underlying().(*types.Interface)
+	fns := make([]*types.Func, iface.NumMethods())
+	for i := range fns {
+		fns[i] = iface.Method(i)
+	}
+	return fns
+}
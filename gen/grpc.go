@@ -0,0 +1,690 @@
+package gen
+
+import (
+	"fmt"
+	"go/types"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// protoAPI An interface laid out as a gRPC service: the messages Proto
+// writes, and GRPC converts to and from
+type protoAPI struct {
+	iface    *types.Named
+	rpcs     []protoRPC
+	messages []protoMessage // the structs the signatures use, in order of first use
+	opaques  []*types.Named // types crossing as the JSON they marshal to
+	time     bool           // whether a time.Time crosses
+	names    map[string]types.Type
+}
+
+// protoRPC One method, and the messages it takes and answers
+type protoRPC struct {
+	m       *types.Func
+	ctx     int // index of the context parameter; -1 when it has none
+	request []protoField
+	results []protoField // the response's fields, bar the error
+}
+
+// protoMessage A struct crossing as a message of its exported fields
+type protoMessage struct {
+	named  *types.Named
+	fields []protoField
+}
+
+// protoField One field of a message
+type protoField struct {
+	name string // as the .proto has it, e.g. "hired_at"
+	from string // the struct field, parameter or result it carries
+	typ  types.Type
+}
+
+// pb is the field's name in the Go protoc-gen-go writes, e.g. "HiredAt".
+func (p protoField) pb() string { return goCamelCase(p.name) }
+
+// layout lays iface out as a service. Every method must return an error
+// last, and every type it uses must have a protobuf counterpart: strings,
+// booleans and numbers; time.Time, as a google.protobuf.Timestamp; a
+// struct with exported fields, as a message of them; any other type with
+// MarshalJSON and UnmarshalJSON, as a string of its JSON; slices of those.
+func layout(iface *types.Named) (*protoAPI, error) {
+	api := &protoAPI{iface: iface, names: map[string]types.Type{}}
+	base := iface.Obj().Name()
+	for _, m := range methods(iface) {
+		sig := m.Type().(*types.Signature)
+		results := sig.Results()
+		if results.Len() == 0 || !isError(results.At(results.Len()-1).Type()) {
+			return nil, fmt.Errorf("gen: %s.%s: an RPC must return an error", base, m.Name())
+		}
+		rpc := protoRPC{m: m, ctx: -1}
+		for _, msg := range []string{m.Name() + "Request", m.Name() + "Response"} {
+			if err := api.claim(msg, nil); err != nil {
+				return nil, err
+			}
+		}
+		for i := range sig.Params().Len() {
+			v := sig.Params().At(i)
+			if isContext(v.Type()) {
+				rpc.ctx = i
+				continue
+			}
+			name := v.Name()
+			if name == "" || name == "_" {
+				name = "p" + strconv.Itoa(i)
+			}
+			rpc.request = append(rpc.request, protoField{name: snake(name), from: name, typ: v.Type()})
+		}
+		for i := range results.Len() - 1 {
+			name := resultField(i, results.Len()-1)
+			rpc.results = append(rpc.results, protoField{name: snake(name), from: name, typ: results.At(i).Type()})
+		}
+		for _, field := range slices.Concat(rpc.request, rpc.results) {
+			if _, err := api.protoType(field.typ); err != nil {
+				return nil, fmt.Errorf("gen: %s.%s: %w", base, m.Name(), err)
+			}
+		}
+		api.rpcs = append(api.rpcs, rpc)
+	}
+	return api, nil
+}
+
+// claim reserves name for a message of t, refusing it when another type
+// has it.
+func (api *protoAPI) claim(name string, t types.Type) error {
+	if other, ok := api.names[name]; ok && (t == nil || other == nil || !types.Identical(t, other)) {
+		return fmt.Errorf("gen: two messages named %s", name)
+	}
+	api.names[name] = t
+	return nil
+}
+
+// protoType is t's type in the .proto, adding the messages it needs.
+func (api *protoAPI) protoType(t types.Type) (string, error) {
+	if s, ok := t.Underlying().(*types.Slice); ok {
+		if _, nested := s.Elem().Underlying().(*types.Slice); nested {
+			return "", fmt.Errorf("no protobuf type for %s", t)
+		}
+		elem, err := api.protoType(s.Elem())
+		return "repeated " + elem, err
+	}
+	if scalar := protoScalar(t); scalar != "" {
+		return scalar, nil
+	}
+	named, ok := t.(*types.Named)
+	if !ok {
+		return "", fmt.Errorf("no protobuf type for %s", t)
+	}
+	if isTime(named) {
+		api.time = true
+		return "google.protobuf.Timestamp", nil
+	}
+	if fields := exportedFields(named); len(fields) > 0 {
+		name := named.Obj().Name()
+		if _, known := api.names[name]; known {
+			return name, api.claim(name, named)
+		}
+		if err := api.claim(name, named); err != nil {
+			return "", err
+		}
+		msg := protoMessage{named: named}
+		api.messages = append(api.messages, msg)
+		for _, v := range fields {
+			if _, err := api.protoType(v.Type()); err != nil {
+				return "", fmt.Errorf("%s.%s: %w", named.Obj().Name(), v.Name(), err)
+			}
+			msg.fields = append(msg.fields, protoField{name: snake(v.Name()), from: v.Name(), typ: v.Type()})
+		}
+		api.messages[slices.IndexFunc(api.messages, func(m protoMessage) bool { return m.named == named })] = msg
+		return name, nil
+	}
+	if hasMethod(named, "MarshalJSON") && hasMethod(types.NewPointer(named), "UnmarshalJSON") {
+		if !slices.Contains(api.opaques, named) {
+			api.opaques = append(api.opaques, named)
+		}
+		return "string", nil
+	}
+	return "", fmt.Errorf("no protobuf type for %s", t)
+}
+
+// protoScalar is the scalar type of the .proto for t, or "" when it is
+// none.
+func protoScalar(t types.Type) string {
+	b, ok := t.Underlying().(*types.Basic)
+	if !ok {
+		return ""
+	}
+	info := b.Info()
+	switch {
+	case info&types.IsString != 0:
+		return "string"
+	case info&types.IsBoolean != 0:
+		return "bool"
+	case info&types.IsUnsigned != 0:
+		return "uint64"
+	case info&types.IsInteger != 0:
+		return "int64"
+	case info&types.IsFloat != 0:
+		return "double"
+	}
+	return ""
+}
+
+// goScalar is the Go type protoc-gen-go gives a scalar of the .proto.
+func goScalar(scalar string) string {
+	if scalar == "double" {
+		return "float64"
+	}
+	return scalar
+}
+
+func isTime(t types.Type) bool {
+	named, ok := t.(*types.Named)
+	return ok && named.Obj().Pkg() != nil && named.Obj().Pkg().Path() == "time" && named.Obj().Name() == "Time"
+}
+
+func exportedFields(named *types.Named) []*types.Var {
+	st, ok := named.Underlying().(*types.Struct)
+	if !ok {
+		return nil
+	}
+	var fields []*types.Var
+	for i := range st.NumFields() {
+		if st.Field(i).Exported() {
+			fields = append(fields, st.Field(i))
+		}
+	}
+	return fields
+}
+
+func hasMethod(t types.Type, name string) bool {
+	return types.NewMethodSet(t).Lookup(nil, name) != nil
+}
+
+// Proto writes the .proto of iface's service: protobuf package pkg, whose
+// Go package protoc-gen-go writes at goPackage. Each method is an rpc
+// taking its parameters, bar the context, as <Method>Request and answering
+// <Method>Response, whose result is the method's (r0, r1... when it has
+// several). See GRPC for the Go on either side of it.
+func Proto(iface *types.Named, pkg, goPackage string) ([]byte, error) {
+	api, err := layout(iface)
+	if err != nil {
+		return nil, err
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "// Code generated by solid gen proto; DO NOT EDIT.\n\nsyntax = \"proto3\";\n\npackage %s;\n\n", pkg)
+	if api.time {
+		b.WriteString("import \"google/protobuf/timestamp.proto\";\n\n")
+	}
+	fmt.Fprintf(&b, "option go_package = %q;\n\n", goPackage)
+	base := iface.Obj().Name()
+	fmt.Fprintf(&b, "// %s is %s.%s, one rpc per method.\nservice %s {\n", base, iface.Obj().Pkg().Name(), base, base)
+	for _, rpc := range api.rpcs {
+		fmt.Fprintf(&b, "  rpc %s(%sRequest) returns (%sResponse);\n", rpc.m.Name(), rpc.m.Name(), rpc.m.Name())
+	}
+	b.WriteString("}\n")
+	message := func(name, doc string, fields []protoField) {
+		fmt.Fprintf(&b, "\n%smessage %s {", doc, name)
+		if len(fields) == 0 {
+			b.WriteString("}\n")
+			return
+		}
+		b.WriteString("\n")
+		for i, field := range fields {
+			typ, _ := api.protoType(field.typ)
+			elem := field.typ
+			if s, ok := elem.Underlying().(*types.Slice); ok {
+				elem = s.Elem()
+			}
+			if strings.HasSuffix(typ, "string") && protoScalar(elem) == "" {
+				fmt.Fprintf(&b, "  // %s, as its MarshalJSON writes it\n", types.TypeString(elem, (*types.Package).Name))
+			}
+			fmt.Fprintf(&b, "  %s %s = %d;\n", typ, field.name, i+1)
+		}
+		b.WriteString("}\n")
+	}
+	for _, rpc := range api.rpcs {
+		message(rpc.m.Name()+"Request", "", rpc.request)
+		message(rpc.m.Name()+"Response", "", rpc.results)
+	}
+	for _, msg := range api.messages {
+		message(msg.named.Obj().Name(), fmt.Sprintf("// %s is %s.\n", msg.named.Obj().Name(), types.TypeString(msg.named, (*types.Package).Name)), msg.fields)
+	}
+	return []byte(b.String()), nil
+}
+
+// GRPC writes, into package pkg, both ends of iface over the gRPC service
+// Proto describes, whose Go protoc-gen-go and protoc-gen-go-grpc wrote at
+// pbPath: <Name>GRPCServer serves an implementation of iface, and
+// <Name>GRPCClient implements iface by calling it. An error keeps its
+// identity: a sentinel error of a package the signatures use (an exported
+// Err... var), or errors.ErrUnsupported, crosses as a status whose
+// ErrorInfo names it, and the client unwraps it back to it. Write one
+// service per package; RemoteError is shared.
+func GRPC(iface *types.Named, pkg, pbPath string) ([]byte, error) {
+	api, err := layout(iface)
+	if err != nil {
+		return nil, err
+	}
+	g := &grpcFile{file: newFile(pkg), api: api}
+	g.pb = g.use(pbPath, path.Base(pbPath))
+	base := iface.Obj().Name()
+	ifaceName := g.typ(iface)
+	server, client := base+"GRPCServer", base+"GRPCClient"
+	ctxPkg := g.use("context", "context")
+	codes := g.use("google.golang.org/grpc/codes", "codes")
+	status := g.use("google.golang.org/grpc/status", "status")
+
+	g.printf("// %s Serves an implementation of %s over gRPC:\n//\n", server, ifaceName)
+	g.printf("//\t%s.Register%sServer(s, New%s(svc))\n", g.pb, base, server)
+	g.printf("type %s struct {\n\t%s.Unimplemented%sServer\n\tsvc %s\n}\n\n", server, g.pb, base, ifaceName)
+	g.printf("func New%s(svc %s) *%s { return &%s{svc: svc} }\n", server, ifaceName, server, server)
+	for _, rpc := range api.rpcs {
+		sig := rpc.m.Type().(*types.Signature)
+		in := g.params(sig)
+		g.printf("\nfunc (s *%s) %s(ctx %s.Context, req *%s.%sRequest) (resp *%s.%sResponse, err error) {\n",
+			server, rpc.m.Name(), ctxPkg, g.pb, rpc.m.Name(), g.pb, rpc.m.Name())
+		var args []string
+		for i, p := range in {
+			if i == rpc.ctx {
+				args = append(args, "ctx")
+				continue
+			}
+			if p.variadic {
+				args = append(args, p.name+"...")
+			} else {
+				args = append(args, p.name)
+			}
+		}
+		if len(rpc.request) > 0 {
+			var vars []string
+			for i, p := range in {
+				if i != rpc.ctx {
+					vars = append(vars, p.name+" "+g.typ(sig.Params().At(i).Type()))
+				}
+			}
+			if len(vars) == 1 {
+				g.printf("\tvar %s\n", vars[0])
+			} else {
+				g.printf("\tvar (\n\t\t%s\n\t)\n", strings.Join(vars, "\n\t\t"))
+			}
+			for i, p := range nonContext(in, rpc.ctx) {
+				g.assign(p.name, rpc.request[i].typ, "req.Get"+rpc.request[i].pb()+"()", false,
+					"nil, "+status+".Error("+codes+".InvalidArgument, err.Error())")
+			}
+		}
+		rs := make([]string, len(rpc.results))
+		for i := range rs {
+			rs[i] = "r" + strconv.Itoa(i)
+		}
+		if len(rs) == 0 {
+			g.printf("\tif err = s.svc.%s(%s); err != nil {\n", rpc.m.Name(), strings.Join(args, ", "))
+		} else {
+			g.printf("\t%s, err := s.svc.%s(%s)\n\tif err != nil {\n", strings.Join(rs, ", "), rpc.m.Name(), strings.Join(args, ", "))
+		}
+		g.printf("\t\treturn nil, toStatus(err)\n\t}\n")
+		g.build("resp", rpc.m.Name()+"Response", rpc.results, rs, "nil, "+status+".Error("+codes+".Internal, err.Error())")
+		g.printf("\treturn resp, nil\n}\n")
+	}
+
+	grpcPkg := g.use("google.golang.org/grpc", "grpc")
+	g.printf("\n// %s Client of %s over gRPC, answered by %s\n", client, ifaceName, server)
+	g.printf("type %s struct{ c %s.%sClient }\n\n", client, g.pb, base)
+	g.printf("// New%s calls over conn, e.g. what grpc.NewClient returns.\n", client)
+	g.printf("func New%s(conn %s.ClientConnInterface) *%s {\n\treturn &%s{c: %s.New%sClient(conn)}\n}\n",
+		client, grpcPkg, client, client, g.pb, base)
+	for _, rpc := range api.rpcs {
+		sig := rpc.m.Type().(*types.Signature)
+		in := g.params(sig)
+		var out []string
+		for i, r := range rpc.results {
+			out = append(out, r.from+" "+g.typ(sig.Results().At(i).Type()))
+		}
+		g.printf("\nfunc (s *%s) %s(%s) (%s) {\n", client, rpc.m.Name(), declare(in), strings.Join(append(out, "err error"), ", "))
+		fail := rpc.returns("err")
+		var values []string
+		for _, p := range nonContext(in, rpc.ctx) {
+			values = append(values, p.name)
+		}
+		g.build("req", rpc.m.Name()+"Request", rpc.request, values, fail)
+		ctx := ctxPkg + ".Background()"
+		if rpc.ctx >= 0 {
+			ctx = in[rpc.ctx].name
+		}
+		if len(rpc.results) == 0 {
+			g.printf("\tif _, err = s.c.%s(%s, req); err != nil {\n\t\treturn fromStatus(err)\n\t}\n\treturn nil\n}\n", rpc.m.Name(), ctx)
+			continue
+		}
+		g.printf("\tresp, err := s.c.%s(%s, req)\n\tif err != nil {\n\t\treturn %s\n\t}\n", rpc.m.Name(), ctx, rpc.returns("fromStatus(err)"))
+		for _, r := range rpc.results {
+			g.assign(r.from, r.typ, "resp.Get"+r.pb()+"()", false, fail)
+		}
+		g.printf("\treturn %s\n}\n", rpc.returns("nil"))
+	}
+
+	g.converters()
+	g.errors(codes, status)
+	g.printf("\nvar (\n\t_ %s.%sServer = (*%s)(nil)\n\t_ %s = (*%s)(nil)\n)\n", g.pb, base, server, ifaceName, client)
+	return g.bytes("Code generated by solid gen client -transport grpc; DO NOT EDIT.")
+}
+
+// grpcFile A Go file converting between an interface's types and the ones
+// protoc-gen-go wrote for them
+type grpcFile struct {
+	*file
+	api *protoAPI
+	pb  string // what the protoc-generated package is imported as
+}
+
+// reserved The names the generated methods use for their own variables
+var reserved = []string{"ctx", "req", "resp", "err", "result", "e", "x", "s"}
+
+// params names sig's parameters as params does, avoiding reserved.
+func (g *grpcFile) params(sig *types.Signature) []param {
+	ps := g.file.params(sig.Params(), sig.Variadic(), "p")
+	for i := range ps {
+		if slices.Contains(reserved, ps[i].name) && !isContext(sig.Params().At(i).Type()) {
+			ps[i].name = "p" + strconv.Itoa(i)
+		}
+	}
+	return ps
+}
+
+func nonContext(ps []param, ctx int) []param {
+	if ctx < 0 {
+		return ps
+	}
+	return slices.Delete(slices.Clone(ps), ctx, ctx+1)
+}
+
+// returns is what the client's method returns with the error err.
+func (rpc protoRPC) returns(err string) string {
+	values := []string{}
+	for _, r := range rpc.results {
+		values = append(values, r.from)
+	}
+	return strings.Join(append(values, err), ", ")
+}
+
+// conv is expr, of type t (not a slice), converted to the wire or from it,
+// and whether the conversion also returns an error.
+func (g *grpcFile) conv(t types.Type, expr string, toPB bool) (string, bool) {
+	if scalar := protoScalar(t); scalar != "" {
+		goType := goScalar(scalar)
+		if b, ok := t.(*types.Basic); ok && b.Name() == goType {
+			return expr, false
+		}
+		if toPB {
+			return goType + "(" + expr + ")", false
+		}
+		return g.typ(t) + "(" + expr + ")", false
+	}
+	name := t.(*types.Named).Obj().Name()
+	if isTime(t) {
+		name = "Time"
+	}
+	if toPB {
+		return "toPB" + name + "(" + expr + ")", !isTime(t)
+	}
+	return "fromPB" + name + "(" + expr + ")", !isTime(t)
+}
+
+// fallible reports whether assign must write t's conversion as statements:
+// a slice's, or one that can fail.
+func (g *grpcFile) fallible(t types.Type) bool {
+	if _, isSlice := t.Underlying().(*types.Slice); isSlice {
+		return true
+	}
+	_, fallible := g.conv(t, "", true)
+	return fallible
+}
+
+// assign writes target = expr converted, returning fail (err among its
+// values) should the conversion fail.
+func (g *grpcFile) assign(target string, t types.Type, expr string, toPB bool, fail string) {
+	s, isSlice := t.Underlying().(*types.Slice)
+	if !isSlice {
+		value, fallible := g.conv(t, expr, toPB)
+		if !fallible {
+			g.printf("\t%s = %s\n", target, value)
+			return
+		}
+		g.printf("\tif %s, err = %s; err != nil {\n\t\treturn %s\n\t}\n", target, value, fail)
+		return
+	}
+	value, fallible := g.conv(s.Elem(), "e", toPB)
+	g.printf("\tfor _, e := range %s {\n", expr)
+	if fallible {
+		g.printf("\t\tx, err := %s\n\t\tif err != nil {\n\t\t\treturn %s\n\t\t}\n", value, fail)
+		value = "x"
+	}
+	g.printf("\t\t%s = append(%s, %s)\n\t}\n", target, target, value)
+}
+
+// build writes name = the message msg, its fields converted from the Go
+// values, returning fail should a conversion fail.
+func (g *grpcFile) build(name, msg string, fields []protoField, values []string, fail string) {
+	var literal, later []int
+	for i, field := range fields {
+		if g.fallible(field.typ) {
+			later = append(later, i)
+		} else {
+			literal = append(literal, i)
+		}
+	}
+	op := ":="
+	if name == "resp" || name == "m" { // named results
+		op = "="
+	}
+	if len(literal) == 0 {
+		g.printf("\t%s %s &%s.%s{}\n", name, op, g.pb, msg)
+	} else {
+		g.printf("\t%s %s &%s.%s{\n", name, op, g.pb, msg)
+		for _, i := range literal {
+			value, _ := g.conv(fields[i].typ, values[i], true)
+			g.printf("\t\t%s: %s,\n", fields[i].pb(), value)
+		}
+		g.printf("\t}\n")
+	}
+	for _, i := range later {
+		g.assign(name+"."+fields[i].pb(), fields[i].typ, values[i], true, fail)
+	}
+}
+
+// converters writes the functions conv calls: toPB<Name> and fromPB<Name>
+// for each message and each type crossing as JSON, and for time.Time.
+func (g *grpcFile) converters() {
+	for _, msg := range g.api.messages {
+		name, goType := msg.named.Obj().Name(), g.typ(msg.named)
+		g.printf("\nfunc toPB%s(v %s) (m *%s.%s, err error) {\n", name, goType, g.pb, name)
+		values := make([]string, len(msg.fields))
+		for i, field := range msg.fields {
+			values[i] = "v." + field.from
+		}
+		g.build("m", name, msg.fields, values, "nil, err")
+		g.printf("\treturn m, nil\n}\n")
+
+		g.printf("\nfunc fromPB%s(m *%s.%s) (v %s, err error) {\n", name, g.pb, name, goType)
+		var later []protoField
+		g.printf("\tv = %s{", goType)
+		for _, field := range msg.fields {
+			if g.fallible(field.typ) {
+				later = append(later, field)
+				continue
+			}
+			value, _ := g.conv(field.typ, "m.Get"+field.pb()+"()", false)
+			g.printf("\n\t\t%s: %s,", field.from, value)
+		}
+		if len(later) < len(msg.fields) {
+			g.printf("\n\t")
+		}
+		g.printf("}\n")
+		for _, field := range later {
+			g.assign("v."+field.from, field.typ, "m.Get"+field.pb()+"()", false, "v, err")
+		}
+		g.printf("\treturn v, nil\n}\n")
+	}
+	if len(g.api.opaques) > 0 {
+		json := g.use("encoding/json", "json")
+		for _, t := range g.api.opaques {
+			name, goType := t.Obj().Name(), g.typ(t)
+			g.printf("\n// toPB%s is v as its MarshalJSON writes it.\n", name)
+			g.printf("func toPB%s(v %s) (string, error) {\n\tb, err := %s.Marshal(v)\n\treturn string(b), err\n}\n", name, goType, json)
+			g.printf("\n// fromPB%s is the zero %s when s is empty.\n", name, goType)
+			g.printf("func fromPB%s(s string) (v %s, err error) {\n\tif s == \"\" {\n\t\treturn v, nil\n\t}\n", name, goType)
+			g.printf("\terr = %s.Unmarshal([]byte(s), &v)\n\treturn v, err\n}\n", json)
+		}
+	}
+	if g.api.time {
+		timePkg := g.use("time", "time")
+		ts := g.use("google.golang.org/protobuf/types/known/timestamppb", "timestamppb")
+		g.printf("\n// toPBTime leaves the zero time unset.\n")
+		g.printf("func toPBTime(t %s.Time) *%s.Timestamp {\n\tif t.IsZero() {\n\t\treturn nil\n\t}\n\treturn %s.New(t)\n}\n", timePkg, ts, ts)
+		g.printf("\nfunc fromPBTime(t *%s.Timestamp) %s.Time {\n\tif t == nil {\n\t\treturn %s.Time{}\n\t}\n\treturn t.AsTime()\n}\n", ts, timePkg, timePkg)
+	}
+}
+
+// errors writes RemoteError, and the statuses errors cross as.
+func (g *grpcFile) errors(codes, status string) {
+	errorsPkg := g.use("errors", "errors")
+	details := g.use("google.golang.org/genproto/googleapis/rpc/errdetails", "errdetails")
+	domain := g.api.iface.Obj().Pkg().Path() + "." + g.api.iface.Obj().Name()
+	g.printf("\n// errorDomain is the domain of the ErrorInfo naming a sentinel error.\n")
+	g.printf("const errorDomain = %q\n\n", domain)
+	g.printf("// RemoteError An error the service returned, as it crossed the wire. It\n")
+	g.printf("// unwraps to the sentinel error it wrapped, if any.\n")
+	g.printf("type RemoteError struct {\n\tStatus *%s.Status\n\t// Reason names the sentinel, e.g. \"employee.ErrNotFound\"\n\tReason string\n}\n\n", status)
+	g.printf("func (e *RemoteError) Error() string              { return e.Status.Message() }\n")
+	g.printf("func (e *RemoteError) GRPCStatus() *%s.Status { return e.Status }\n\n", status)
+	g.printf("func (e *RemoteError) Unwrap() error {\n\tfor _, s := range sentinels {\n\t\tif s.reason == e.Reason {\n\t\t\treturn s.err\n\t\t}\n\t}\n\treturn nil\n}\n\n")
+	g.printf("// toStatus is err as it crosses the wire: a status of the code its sentinel\n")
+	g.printf("// suggests, with an ErrorInfo naming the sentinel.\n")
+	g.printf("func toStatus(err error) error {\n\tfor _, s := range sentinels {\n\t\tif !%s.Is(err, s.err) {\n\t\t\tcontinue\n\t\t}\n", errorsPkg)
+	g.printf("\t\tst, detailed := %s.New(s.code, err.Error()).WithDetails(&%s.ErrorInfo{Reason: s.reason, Domain: errorDomain})\n", status, details)
+	g.printf("\t\tif detailed != nil {\n\t\t\treturn %s.Error(s.code, err.Error())\n\t\t}\n\t\treturn st.Err()\n\t}\n", status)
+	g.printf("\treturn %s.Error(%s.Unknown, err.Error())\n}\n\n", status, codes)
+	g.printf("// fromStatus is the error a call failed with: a *RemoteError when the\n")
+	g.printf("// service sent one, err itself otherwise.\n")
+	g.printf("func fromStatus(err error) error {\n\tst, ok := %s.FromError(err)\n\tif !ok {\n\t\treturn err\n\t}\n", status)
+	g.printf("\tfor _, d := range st.Details() {\n\t\tif info, ok := d.(*%s.ErrorInfo); ok && info.GetDomain() == errorDomain {\n", details)
+	g.printf("\t\t\treturn &RemoteError{Status: st, Reason: info.GetReason()}\n\t\t}\n\t}\n\treturn err\n}\n\n")
+	g.printf("// sentinels The errors that keep their identity across the wire, tried in\n")
+	g.printf("// order: an error wrapping several crosses as the first\n")
+	g.printf("var sentinels = []struct {\n\treason string\n\terr    error\n\tcode   %s.Code\n}{\n", codes)
+	for _, v := range sentinels(g.api.iface) {
+		g.printf("\t{%q, %s.%s, %s.%s},\n", code(v), g.qualifier(v.Pkg()), v.Name(), codes, statusCode(v.Name()))
+	}
+	g.printf("}\n")
+}
+
+// statusCodes The status code a sentinel's name suggests, the first match
+// winning; any other crosses as Unknown, its identity kept all the same
+var statusCodes = []struct{ word, code string }{
+	{"NotFound", "NotFound"},
+	{"Taken", "AlreadyExists"},
+	{"Exists", "AlreadyExists"},
+	{"Conflict", "Aborted"},
+	{"Invalid", "InvalidArgument"},
+	{"Mismatch", "InvalidArgument"},
+	{"Unsupported", "Unimplemented"},
+	{"Overflow", "OutOfRange"},
+}
+
+func statusCode(name string) string {
+	for _, c := range statusCodes {
+		if strings.Contains(name, c.word) {
+			return c.code
+		}
+	}
+	return "Unknown"
+}
+
+func resultField(i, n int) string {
+	if n == 1 {
+		return "result"
+	}
+	return "r" + strconv.Itoa(i)
+}
+
+// sentinels lists the sentinel errors of the packages iface's signatures
+// use, its own included, and errors.ErrUnsupported, ordered by code.
+func sentinels(iface *types.Named) []*types.Var {
+	pkgs := map[*types.Package]bool{iface.Obj().Pkg(): true}
+	var visit func(t types.Type)
+	visit = func(t types.Type) {
+		switch t := t.(type) {
+		case *types.Named:
+			if t.Obj().Pkg() != nil && !standard(t.Obj().Pkg().Path()) {
+				pkgs[t.Obj().Pkg()] = true
+			}
+		case interface{ Elem() types.Type }: // pointers, slices, arrays, maps, channels
+			visit(t.Elem())
+		}
+	}
+	for _, m := range methods(iface) {
+		sig := m.Type().(*types.Signature)
+		for _, tuple := range []*types.Tuple{sig.Params(), sig.Results()} {
+			for i := range tuple.Len() {
+				visit(tuple.At(i).Type())
+			}
+		}
+	}
+	errorsPkg := types.NewPackage("errors", "errors")
+	vars := []*types.Var{types.NewVar(0, errorsPkg, "ErrUnsupported", types.Universe.Lookup("error").Type())}
+	for pkg := range pkgs {
+		for _, name := range pkg.Scope().Names() {
+			if v, ok := pkg.Scope().Lookup(name).(*types.Var); ok && v.Exported() && strings.HasPrefix(name, "Err") && isError(v.Type()) {
+				vars = append(vars, v)
+			}
+		}
+	}
+	slices.SortFunc(vars, func(a, b *types.Var) int { return strings.Compare(code(a), code(b)) })
+	return vars
+}
+
+// code is what v crosses the wire as, e.g. "employee.ErrNotFound".
+func code(v *types.Var) string { return v.Pkg().Name() + "." + v.Name() }
+
+// snake is name as a .proto field is named: "HiredAt" is "hired_at", "ID"
+// is "id".
+func snake(name string) string {
+	r := []rune(name)
+	var b strings.Builder
+	for i, c := range r {
+		if i > 0 && unicode.IsUpper(c) && (!unicode.IsUpper(r[i-1]) || i+1 < len(r) && unicode.IsLower(r[i+1])) {
+			b.WriteByte('_')
+		}
+		b.WriteRune(unicode.ToLower(c))
+	}
+	return b.String()
+}
+
+// goCamelCase is the Go name protoc-gen-go gives the field name, after
+// protogen's GoCamelCase: "hired_at" is "HiredAt", "id" is "Id".
+func goCamelCase(name string) string {
+	var b []byte
+	for i := 0; i < len(name); i++ {
+		c := name[i]
+		switch {
+		case c == '_' && i == 0:
+			b = append(b, 'X')
+		case c == '_' && i+1 < len(name) && isASCIILower(name[i+1]):
+			// dropped: the letter after it is capitalized
+		case c >= '0' && c <= '9':
+			b = append(b, c)
+		default:
+			if isASCIILower(c) {
+				c -= 'a' - 'A'
+			}
+			b = append(b, c)
+			for ; i+1 < len(name) && isASCIILower(name[i+1]); i++ {
+				b = append(b, name[i+1])
+			}
+		}
+	}
+	return string(b)
+}
+
+func isASCIILower(c byte) bool { return c >= 'a' && c <= 'z' }
@@ -0,0 +1,50 @@
+package gen
+
+import (
+	"go/types"
+)
+
+// Stub writes, into package pkg, a stub of iface named <Name>Stub: a
+// function field per method, and a stub.Recorder recording every call.
+// Methods whose field is nil return zero values.
+func Stub(iface *types.Named, pkg string) ([]byte, error) {
+	f := newFile(pkg)
+	name := iface.Obj().Name() + "Stub"
+	ifaceName := f.typ(iface)
+	stubPkg := f.use("go-solid/stub", "stub")
+
+	f.printf("// %s Stub of %s: set a ...Func field to decide what a method\n", name, ifaceName)
+	f.printf("// does; methods left nil return zero values. Every call is recorded.\n")
+	f.printf("type %s struct {\n\t%s.Recorder\n\n", name, stubPkg)
+	for _, m := range methods(iface) {
+		sig := m.Type().(*types.Signature)
+		in := f.params(sig.Params(), sig.Variadic(), "p")
+		out := f.params(sig.Results(), false, "r")
+		f.printf("\t%sFunc func(%s)%s\n", m.Name(), declare(in), results(out))
+	}
+	f.printf("}\n")
+
+	for _, m := range methods(iface) {
+		sig := m.Type().(*types.Signature)
+		in := f.params(sig.Params(), sig.Variadic(), "p")
+		out := f.params(sig.Results(), false, "r")
+		f.printf("\nfunc (s *%s) %s(%s)%s {\n", name, m.Name(), declare(in), results(out))
+		record := ""
+		for _, p := range in {
+			record += ", " + p.name
+		}
+		f.printf("\ts.Record(%q%s)\n", m.Name(), record)
+		f.printf("\tif s.%sFunc != nil {\n", m.Name())
+		if len(out) > 0 {
+			f.printf("\t\treturn s.%sFunc(%s)\n\t}\n", m.Name(), pass(in))
+			for _, r := range out {
+				f.printf("\tvar %s %s\n", r.name, r.typ)
+			}
+			f.printf("\treturn %s\n}\n", pass(out))
+		} else {
+			f.printf("\t\ts.%sFunc(%s)\n\t}\n}\n", m.Name(), pass(in))
+		}
+	}
+	f.printf("\nvar _ %s = (*%s)(nil)\n", ifaceName, name)
+	return f.bytes("Code generated by solid gen stub; DO NOT EDIT.")
+}
@@ -0,0 +1,110 @@
+package gen
+
+import (
+	"go/types"
+	"strconv"
+	"strings"
+)
+
+// Tests writes, into package pkg, a table-driven test skeleton for
+// implementations of iface: a Test<Iface>_<Method> per method with a happy
+// path case and, for methods returning an error, an error case - all left
+// for the reader to fill in. A new<Iface> function, skipping until it is
+// written, supplies the implementation under test.
+func Tests(iface *types.Named, pkg string) ([]byte, error) {
+	f := newFile(pkg)
+	base := iface.Obj().Name()
+	ifaceName := f.typ(iface)
+	testingPkg := f.use("testing", "testing")
+	ctor := "new" + base
+
+	f.printf("// %s returns the %s implementation under test.\n", ctor, ifaceName)
+	f.printf("func %s(t *%s.T) %s {\n\tt.Helper()\n", ctor, testingPkg, ifaceName)
+	f.printf("\tt.Skip(%q)\n\treturn nil\n}\n", "TODO: return the "+base+" implementation to test")
+
+	todo := false
+	for _, m := range methods(iface) {
+		sig := m.Type().(*types.Signature)
+		// named on a scratch file: a context parameter becomes t.Context()
+		// and must not import context
+		in := newFile(pkg).params(sig.Params(), sig.Variadic(), "p")
+		out := f.params(sig.Results(), false, "r")
+		hasErr := out != nil && isError(sig.Results().At(len(out)-1).Type())
+		wants := out
+		if hasErr {
+			wants = out[:len(out)-1]
+			todo = true
+		}
+
+		f.printf("\nfunc Test%s_%s(t *%s.T) {\n\ttests := []struct {\n\t\tname string\n", base, m.Name(), testingPkg)
+		args := make([]string, len(in))
+		for i, p := range in {
+			if isContext(sig.Params().At(i).Type()) {
+				args[i] = "t.Context()"
+				continue
+			}
+			field := p.name
+			if field == "name" || field == "subject" || strings.HasPrefix(field, "want") {
+				field += "Arg"
+			}
+			typ := f.typ(sig.Params().At(i).Type())
+			if p.variadic {
+				args[i] = "tt." + field + "..."
+			} else {
+				args[i] = "tt." + field
+			}
+			f.printf("\t\t%s %s\n", field, typ)
+		}
+		gots := make([]string, len(out))
+		for i, r := range wants {
+			suffix := ""
+			if len(wants) > 1 {
+				suffix = strconv.Itoa(i)
+			}
+			gots[i] = "got" + suffix
+			f.printf("\t\twant%s %s\n", suffix, r.typ)
+		}
+		if hasErr {
+			gots[len(out)-1] = "err"
+			f.printf("\t\twantErr error\n")
+		}
+		f.printf("\t}{\n\t\t{name: \"happy path\"}, // TODO: arguments and what they should return\n")
+		if hasErr {
+			f.printf("\t\t{name: \"error\", wantErr: errTODO}, // TODO: arguments that fail, and the error\n")
+		}
+		f.printf("\t}\n\tfor _, tt := range tests {\n\t\tt.Run(tt.name, func(t *%s.T) {\n\t\t\tsubject := %s(t)\n", testingPkg, ctor)
+
+		call := "subject." + m.Name() + "(" + strings.Join(args, ", ") + ")"
+		if len(gots) > 0 {
+			call = strings.Join(gots, ", ") + " := " + call
+		}
+		f.printf("\t\t\t%s\n", call)
+		if hasErr {
+			f.printf("\t\t\tif !%s.Is(err, tt.wantErr) {\n", f.use("errors", "errors"))
+			f.printf("\t\t\t\tt.Fatalf(\"%s() error = %%v, want %%v\", err, tt.wantErr)\n\t\t\t}\n", m.Name())
+			if len(wants) > 0 {
+				f.printf("\t\t\tif tt.wantErr != nil {\n\t\t\t\treturn\n\t\t\t}\n")
+			}
+		}
+		for i := range wants {
+			want := "tt.want" + strings.TrimPrefix(gots[i], "got")
+			f.printf("\t\t\tif !%s.DeepEqual(%s, %s) {\n", f.use("reflect", "reflect"), gots[i], want)
+			f.printf("\t\t\t\tt.Errorf(\"%s() %s = %%v, want %%v\", %s, %s)\n\t\t\t}\n", m.Name(), gots[i], gots[i], want)
+		}
+		f.printf("\t\t})\n\t}\n}\n")
+	}
+	if todo {
+		f.printf("\n// errTODO stands in for the error a case expects until it is filled in\n")
+		f.printf("var errTODO = %s.New(\"TODO: the expected error\")\n", f.use("errors", "errors"))
+	}
+	return f.bytes("Test skeleton generated by solid gen tests: fill in the cases marked TODO.")
+}
+
+func isError(t types.Type) bool {
+	return types.Identical(t, types.Universe.Lookup("error").Type())
+}
+
+func isContext(t types.Type) bool {
+	named, ok := t.(*types.Named)
+	return ok && named.Obj().Pkg() != nil && named.Obj().Pkg().Path() == "context" && named.Obj().Name() == "Context"
+}
@@ -0,0 +1,412 @@
+package graphqlapi
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+
+	"go-solid/money"
+)
+
+// object A GraphQL object type: its name and how each of its fields is
+// resolved from the value it describes
+type object struct {
+	name   string
+	fields map[string]field
+}
+
+// field One field of an object. Resolvers for lists return []any; typ
+// describes the value, or each element of a list, and is nil for scalars.
+type field struct {
+	args    []string
+	typ     *object
+	resolve func(ctx context.Context, parent any, a args) (any, error)
+}
+
+// Error One entry in a response's errors, in the format the GraphQL spec gives
+type Error struct {
+	Message    string         `json:"message"`
+	Path       []any          `json:"path,omitempty"`
+	Extensions map[string]any `json:"extensions,omitempty"`
+}
+
+// Response The result of a request. Data is absent when the request couldn't
+// be run at all, and null fields in it are explained by Errors.
+type Response struct {
+	Data   *result `json:"data,omitempty"`
+	Errors []Error `json:"errors,omitempty"`
+}
+
+// result An object in the response; its fields keep the order they were
+// selected in, as the spec requires
+type result struct {
+	keys   []string
+	values map[string]any
+}
+
+func (r *result) set(key string, v any) {
+	if _, ok := r.values[key]; !ok {
+		r.keys = append(r.keys, key)
+	}
+	r.values[key] = v
+}
+
+func (r *result) MarshalJSON() ([]byte, error) {
+	var b bytes.Buffer
+	b.WriteByte('{')
+	for i, k := range r.keys {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		key, _ := json.Marshal(k)
+		v, err := json.Marshal(r.values[k])
+		if err != nil {
+			return nil, err
+		}
+		b.Write(key)
+		b.WriteByte(':')
+		b.Write(v)
+	}
+	b.WriteByte('}')
+	return b.Bytes(), nil
+}
+
+// executor Runs one operation, collecting the errors of the fields that failed
+type executor struct {
+	doc    *document
+	vars   map[string]any
+	errors []Error
+}
+
+func (e *executor) fail(path []any, err error) {
+	e.errors = append(e.errors, Error{
+		Message:    err.Error(),
+		Path:       slices.Clone(path),
+		Extensions: map[string]any{"code": code(err)},
+	})
+}
+
+// selectionSet resolves the selected fields of parent, an instance of typ.
+// A field that fails is null in the result; the others are unaffected.
+func (e *executor) selectionSet(ctx context.Context, typ *object, parent any, sel []selection, path []any) *result {
+	out := &result{values: map[string]any{}}
+	for _, s := range e.collect(typ, sel, map[string]bool{}) {
+		fieldPath := append(path, s.key())
+		if s.name == "__typename" {
+			out.set(s.key(), typ.name)
+			continue
+		}
+		out.set(s.key(), nil)
+		f := typ.fields[s.name]
+		a, err := e.arguments(s)
+		if err != nil {
+			e.fail(fieldPath, err)
+			continue
+		}
+		v, err := f.resolve(ctx, parent, a)
+		if err != nil {
+			e.fail(fieldPath, err)
+			continue
+		}
+		out.set(s.key(), e.complete(ctx, f.typ, v, s.sel, fieldPath))
+	}
+	return out
+}
+
+// complete turns a resolved value into its place in the response.
+func (e *executor) complete(ctx context.Context, typ *object, v any, sel []selection, path []any) any {
+	if list, ok := v.([]any); ok {
+		out := make([]any, len(list))
+		for i, item := range list {
+			out[i] = e.complete(ctx, typ, item, sel, append(path, i))
+		}
+		return out
+	}
+	if v == nil || typ == nil {
+		return v
+	}
+	return e.selectionSet(ctx, typ, v, sel, path)
+}
+
+// collect flattens the fragments in sel that apply to typ into their fields.
+// Fields selected twice under the same key are merged.
+func (e *executor) collect(typ *object, sel []selection, visited map[string]bool) []selection {
+	var fields []selection
+	index := map[string]int{}
+	add := func(s selection) {
+		if i, ok := index[s.key()]; ok {
+			fields[i].sel = append(slices.Clone(fields[i].sel), s.sel...)
+			return
+		}
+		index[s.key()] = len(fields)
+		fields = append(fields, s)
+	}
+	for _, s := range sel {
+		var inner []selection
+		switch {
+		case s.spread != "":
+			frag := e.doc.fragments[s.spread]
+			if visited[s.spread] {
+				continue
+			}
+			visited[s.spread] = true
+			inner = e.collect(typ, frag.sel, visited)
+		case s.inline != nil:
+			inner = e.collect(typ, s.inline, visited)
+		default:
+			add(s)
+			continue
+		}
+		for _, f := range inner {
+			add(f)
+		}
+	}
+	return fields
+}
+
+// validate checks sel against typ before anything is resolved, so a query
+// asking for what the schema doesn't have fails as a whole.
+func (e *executor) validate(typ *object, sel []selection, spreading map[string]bool) error {
+	for _, s := range sel {
+		switch {
+		case s.spread != "":
+			frag, ok := e.doc.fragments[s.spread]
+			if !ok {
+				return fmt.Errorf("%w: unknown fragment %q", errInvalidQuery, s.spread)
+			}
+			if frag.on != typ.name {
+				return fmt.Errorf("%w: fragment %q on %q can't be spread in %q", errInvalidQuery, s.spread, frag.on, typ.name)
+			}
+			if spreading[s.spread] {
+				return fmt.Errorf("%w: fragment %q spreads itself", errInvalidQuery, s.spread)
+			}
+			spreading[s.spread] = true
+			err := e.validate(typ, frag.sel, spreading)
+			delete(spreading, s.spread)
+			if err != nil {
+				return err
+			}
+			continue
+		case s.inline != nil:
+			if s.on != "" && s.on != typ.name {
+				return fmt.Errorf("%w: inline fragment on %q can't be spread in %q", errInvalidQuery, s.on, typ.name)
+			}
+			if err := e.validate(typ, s.inline, spreading); err != nil {
+				return err
+			}
+			continue
+		case s.name == "__typename":
+			continue
+		}
+		f, ok := typ.fields[s.name]
+		if !ok {
+			return fmt.Errorf("%w: cannot query field %q on type %q", errInvalidQuery, s.name, typ.name)
+		}
+		for name := range s.args {
+			if !slices.Contains(f.args, name) {
+				return fmt.Errorf("%w: unknown argument %q on field %q", errInvalidQuery, name, s.name)
+			}
+		}
+		switch {
+		case f.typ == nil && s.sel != nil:
+			return fmt.Errorf("%w: field %q is a scalar and takes no selection", errInvalidQuery, s.name)
+		case f.typ != nil && s.sel == nil:
+			return fmt.Errorf("%w: field %q of type %q needs a selection", errInvalidQuery, s.name, f.typ.name)
+		case f.typ != nil:
+			if err := e.validate(f.typ, s.sel, spreading); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// arguments resolves the variables in s's arguments.
+func (e *executor) arguments(s selection) (args, error) {
+	a := args{}
+	for _, name := range slices.Sorted(maps.Keys(s.args)) {
+		v, err := e.value(s.args[name])
+		if err != nil {
+			return nil, err
+		}
+		a[name] = v
+	}
+	return a, nil
+}
+
+func (e *executor) value(v any) (any, error) {
+	switch v := v.(type) {
+	case variable:
+		val, ok := e.vars[string(v)]
+		if !ok {
+			return nil, fmt.Errorf("%w: variable $%s is not defined", errInvalidQuery, v)
+		}
+		return val, nil
+	case enum:
+		return string(v), nil
+	case []any:
+		out := make([]any, len(v))
+		for i, item := range v {
+			var err error
+			if out[i], err = e.value(item); err != nil {
+				return nil, err
+			}
+		}
+		return out, nil
+	case map[string]any:
+		out := make(map[string]any, len(v))
+		for k, item := range v {
+			var err error
+			if out[k], err = e.value(item); err != nil {
+				return nil, err
+			}
+		}
+		return out, nil
+	}
+	return v, nil
+}
+
+// args The arguments of one field, with variables substituted
+type args map[string]any
+
+// reader Reads a field's arguments and keeps the first error, so a resolver
+// checks once after reading them all. An absent or null argument reads as
+// the zero value unless it is required.
+type reader struct {
+	args   args
+	prefix string // of the argument names in errors, for input objects
+	err    *error // shared with the readers of the input objects inside
+}
+
+func newReader(a args) *reader { return &reader{args: a, err: new(error)} }
+
+// Err returns the first error met reading.
+func (r *reader) Err() error { return *r.err }
+
+func (r *reader) fail(name, format string, a ...any) {
+	if *r.err == nil {
+		*r.err = fmt.Errorf("%w: %s%s "+format, append([]any{ErrInvalidArgument, r.prefix, name}, a...)...)
+	}
+}
+
+func (r *reader) require(names ...string) {
+	for _, name := range names {
+		if r.args[name] == nil {
+			r.fail(name, "is required")
+		}
+	}
+}
+
+func (r *reader) string(name string) string {
+	switch v := r.args[name].(type) {
+	case nil:
+	case string:
+		return v
+	default:
+		r.fail(name, "must be a string")
+	}
+	return ""
+}
+
+func (r *reader) int(name string) int {
+	if v, ok := r.args[name].(json.Number); ok {
+		if n, err := strconv.Atoi(v.String()); err == nil {
+			return n
+		}
+	}
+	if r.args[name] != nil {
+		r.fail(name, "must be an integer")
+	}
+	return 0
+}
+
+func (r *reader) bool(name string) bool {
+	switch v := r.args[name].(type) {
+	case nil:
+	case bool:
+		return v
+	default:
+		r.fail(name, "must be a boolean")
+	}
+	return false
+}
+
+// object returns the reader of an input object's fields.
+func (r *reader) object(name string) *reader {
+	in := &reader{prefix: r.prefix + name + ".", err: r.err}
+	switch v := r.args[name].(type) {
+	case nil:
+	case map[string]any:
+		in.args = v
+	default:
+		r.fail(name, "must be an input object")
+	}
+	return in
+}
+
+// money reads a MoneyInput, {amount, currency}. The amount may be a string or
+// a number, but is parsed as a decimal either way, never as a float.
+func (r *reader) money(name string) money.Money {
+	if r.args[name] == nil {
+		return money.Money{}
+	}
+	in := r.object(name)
+	in.require("amount", "currency")
+	amount := in.args["amount"]
+	if n, ok := amount.(json.Number); ok {
+		amount = n.String()
+	}
+	s, ok := amount.(string)
+	if !ok {
+		in.fail("amount", "must be a string or a number")
+	}
+	m, err := money.Parse(s, money.Currency(in.string("currency")))
+	if err != nil {
+		r.fail(name, "is invalid: %v", err)
+	}
+	return m
+}
+
+// variables applies the defaults of op's variable definitions to the values
+// sent with the request, and checks non-null ones were given.
+func variables(op *operation, given map[string]any) (map[string]any, error) {
+	vars := map[string]any{}
+	for _, def := range op.vars {
+		v, ok := given[def.name]
+		if !ok && def.hasValue {
+			v = def.fallback
+		}
+		if v == nil && def.nonNull {
+			return nil, fmt.Errorf("%w: variable $%s is required", ErrInvalidArgument, def.name)
+		}
+		vars[def.name] = v
+	}
+	return vars, nil
+}
+
+// pick returns the operation named name, or the only one when name is empty.
+func (d *document) pick(name string) (*operation, error) {
+	if name == "" {
+		if len(d.operations) != 1 {
+			return nil, fmt.Errorf("%w: operationName is required with %d operations", errInvalidQuery, len(d.operations))
+		}
+		return d.operations[0], nil
+	}
+	for _, op := range d.operations {
+		if op.name == name {
+			return op, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: no operation named %q", errInvalidQuery, name)
+}
+
+var (
+	// ErrInvalidArgument returned for an argument or variable of the wrong type, or a missing required one
+	ErrInvalidArgument = errors.New("invalid argument")
+	errInvalidQuery    = errors.New("invalid query")
+)
@@ -0,0 +1,175 @@
+// Package graphqlapi exposes the employee use cases over GraphQL.
+//
+// It is a second delivery adapter next to httpapi: the same use cases, the
+// same domain errors, a different protocol. Neither adapter knows about the
+// other, and the domain knows about neither - swapping or adding a delivery
+// mechanism doesn't touch the code it delivers (DIP).
+//
+// The schema is in schema.graphql and is served by GET without a query. Only
+// the part of GraphQL the schema needs is implemented; see parse.go.
+package graphqlapi
+
+import (
+	"context"
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"go-solid/employee"
+	"go-solid/employee/actor"
+	"go-solid/money"
+)
+
+// EmployeeService What the GraphQL layer needs from the domain. Declared here,
+// where it is consumed, like httpapi's; the two happen to have the same
+// methods, and *employee.Manager and *actor.Manager satisfy both.
+type EmployeeService interface {
+	AddEmployee(ctx context.Context, emp employee.Employee) (employee.Employee, error)
+	FindEmployee(ctx context.Context, name string) (employee.Employee, error)
+	ListEmployees(ctx context.Context, filter employee.Filter, page employee.Page) (employee.PageResult, error)
+	ChangeSalary(ctx context.Context, name string, salary money.Money) (employee.Employee, error)
+	Promote(ctx context.Context, name, title string, raise money.Money) (employee.Employee, error)
+	RemoveEmployee(ctx context.Context, name string) error
+}
+
+// Schema The schema the resolvers implement, in the GraphQL schema language
+//
+//go:embed schema.graphql
+var Schema string
+
+// Request A GraphQL request, as sent in a POST body
+type Request struct {
+	Query         string         `json:"query"`
+	OperationName string         `json:"operationName,omitempty"`
+	Variables     map[string]any `json:"variables,omitempty"`
+}
+
+// Handler GraphQL adapter over an EmployeeService
+type Handler struct {
+	query, mutation *object
+}
+
+func New(svc EmployeeService) *Handler {
+	r := resolvers{svc}
+	return &Handler{query: r.query(), mutation: r.mutation()}
+}
+
+// Execute runs req. Errors in fields are reported in the response next to
+// the data that could still be resolved; only a request that can't be run at
+// all comes back without data.
+func (h *Handler) Execute(ctx context.Context, req Request) Response {
+	doc, err := parse(req.Query)
+	if err != nil {
+		return Response{Errors: []Error{{Message: err.Error(), Extensions: map[string]any{"code": "GRAPHQL_PARSE_FAILED"}}}}
+	}
+	op, err := doc.pick(req.OperationName)
+	if err != nil {
+		return requestError(err)
+	}
+	vars, err := variables(op, req.Variables)
+	if err != nil {
+		return requestError(err)
+	}
+	root := h.query
+	if op.kind == "mutation" {
+		root = h.mutation
+	}
+	e := &executor{doc: doc, vars: vars}
+	if err := e.validate(root, op.sel, map[string]bool{}); err != nil {
+		return requestError(err)
+	}
+	// Fields are resolved one after the other, which the spec requires of
+	// mutations and allows of queries.
+	data := e.selectionSet(ctx, root, nil, op.sel, nil)
+	return Response{Data: data, Errors: e.errors}
+}
+
+func requestError(err error) Response {
+	return Response{Errors: []Error{{Message: err.Error(), Extensions: map[string]any{"code": code(err)}}}}
+}
+
+// ServeHTTP accepts queries and mutations by POST, and queries by GET with
+// ?query= as well. A GET without a query returns the schema.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var req Request
+	switch r.Method {
+	case http.MethodPost:
+		dec := json.NewDecoder(http.MaxBytesReader(w, r.Body, 1<<20))
+		dec.UseNumber()
+		if err := dec.Decode(&req); err != nil {
+			writeJSON(w, http.StatusBadRequest, requestError(errors.New("invalid JSON body: "+err.Error())))
+			return
+		}
+	case http.MethodGet:
+		q := r.URL.Query()
+		if !q.Has("query") {
+			w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+			_, _ = w.Write([]byte(Schema))
+			return
+		}
+		req = Request{Query: q.Get("query"), OperationName: q.Get("operationName")}
+		if v := q.Get("variables"); v != "" {
+			dec := json.NewDecoder(strings.NewReader(v))
+			dec.UseNumber()
+			if err := dec.Decode(&req.Variables); err != nil {
+				writeJSON(w, http.StatusBadRequest, requestError(errors.New("invalid variables: "+err.Error())))
+				return
+			}
+		}
+		// ✅ GET must be safe; a mutation sent by GET is refused, not run
+		if doc, err := parse(req.Query); err == nil {
+			if op, err := doc.pick(req.OperationName); err == nil && op.kind == "mutation" {
+				w.Header().Set("Allow", "POST")
+				writeJSON(w, http.StatusMethodNotAllowed, requestError(errors.New("mutations must be sent by POST")))
+				return
+			}
+		}
+	default:
+		w.Header().Set("Allow", "GET, POST")
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	resp := h.Execute(r.Context(), req)
+	status := http.StatusOK
+	if resp.Data == nil {
+		status = http.StatusBadRequest
+	}
+	writeJSON(w, status, resp)
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+// code maps domain errors to the error codes clients switch on - the
+// GraphQL counterpart of httpapi's writeError.
+func code(err error) string {
+	switch {
+	case errors.Is(err, employee.ErrNotFound):
+		return "NOT_FOUND"
+	case errors.Is(err, employee.ErrInvalidName), errors.Is(err, employee.ErrInvalidSalary),
+		errors.Is(err, employee.ErrInvalidPromotion), errors.Is(err, employee.ErrInvalidCursor),
+		errors.Is(err, employee.ErrVetoed), errors.Is(err, ErrInvalidArgument):
+		return "BAD_USER_INPUT"
+	case errors.Is(err, employee.ErrConflict):
+		return "CONFLICT"
+	case errors.Is(err, employee.ErrNameTaken):
+		return "NAME_TAKEN"
+	case errors.Is(err, errInvalidQuery):
+		return "GRAPHQL_VALIDATION_FAILED"
+	case errors.Is(err, errors.ErrUnsupported):
+		return "NOT_IMPLEMENTED"
+	}
+	return "INTERNAL_SERVER_ERROR"
+}
+
+var (
+	_ EmployeeService = (*employee.Manager)(nil)
+	_ EmployeeService = (*actor.Manager)(nil)
+	_ http.Handler    = (*Handler)(nil)
+)
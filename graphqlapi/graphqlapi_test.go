@@ -0,0 +1,350 @@
+package graphqlapi_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"slices"
+	"strings"
+	"testing"
+
+	"go-solid/employee"
+	"go-solid/employee/memory"
+	"go-solid/graphqlapi"
+	"go-solid/httpapi"
+)
+
+// api Both adapters over one Manager, mounted as employee-api mounts them
+type api struct {
+	t   *testing.T
+	srv *httptest.Server
+}
+
+func newAPI(t *testing.T) api {
+	manager := employee.NewManager(memory.New())
+	rest := httpapi.New(manager)
+	rest.Handle("/graphql", graphqlapi.New(manager))
+	srv := httptest.NewServer(rest)
+	t.Cleanup(srv.Close)
+	return api{t, srv}
+}
+
+func (a api) send(method, path, contentType, body string) (int, []byte) {
+	a.t.Helper()
+	req, err := http.NewRequestWithContext(a.t.Context(), method, a.srv.URL+path, strings.NewReader(body))
+	if err != nil {
+		a.t.Fatal(err)
+	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	resp, err := a.srv.Client().Do(req)
+	if err != nil {
+		a.t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	b, _ := io.ReadAll(resp.Body)
+	return resp.StatusCode, b
+}
+
+// rest sends a REST request and decodes a 2xx answer into out.
+func (a api) rest(method, path, body string, out any) int {
+	a.t.Helper()
+	status, b := a.send(method, path, "application/json", body)
+	if status/100 == 2 && out != nil {
+		if err := json.Unmarshal(b, out); err != nil {
+			a.t.Fatalf("%s %s: %v in %s", method, path, err, b)
+		}
+	}
+	return status
+}
+
+// response A GraphQL response with the data left to decode per test
+type response struct {
+	Data   json.RawMessage    `json:"data"`
+	Errors []graphqlapi.Error `json:"errors"`
+}
+
+func (r response) codes() []string {
+	var codes []string
+	for _, e := range r.Errors {
+		codes = append(codes, e.Extensions["code"].(string))
+	}
+	return codes
+}
+
+// graphql posts query with vars and decodes the data into out.
+func (a api) graphql(query string, vars map[string]any, out any) response {
+	a.t.Helper()
+	body, _ := json.Marshal(graphqlapi.Request{Query: query, Variables: vars})
+	status, b := a.send(http.MethodPost, "/graphql", "application/json", string(body))
+	var resp response
+	if err := json.Unmarshal(b, &resp); err != nil {
+		a.t.Fatalf("graphql: %v in %d %s", err, status, b)
+	}
+	if out != nil && resp.Data != nil {
+		if err := json.Unmarshal(resp.Data, out); err != nil {
+			a.t.Fatalf("graphql data: %v in %s", err, resp.Data)
+		}
+	}
+	return resp
+}
+
+// gqlEmployee An Employee as the schema has it
+type gqlEmployee struct {
+	ID     string  `json:"id"`
+	Name   string  `json:"name"`
+	Title  *string `json:"title"`
+	Email  *string `json:"email"`
+	Salary *struct {
+		Amount    string `json:"amount"`
+		Currency  string `json:"currency"`
+		Formatted string `json:"formatted"`
+	} `json:"salary"`
+	HiredAt *string `json:"hiredAt"`
+}
+
+const fields = `id name title email salary { amount currency formatted } hiredAt`
+
+// same reports how g differs from what REST returned for the same employee.
+func same(t *testing.T, g *gqlEmployee, r httpapi.EmployeeDTO) {
+	t.Helper()
+	if g == nil {
+		t.Fatalf("GraphQL employee is null, REST has %s", r.Name)
+	}
+	deref := func(s *string) string {
+		if s == nil {
+			return ""
+		}
+		return *s
+	}
+	if g.ID != r.ID || g.Name != r.Name || deref(g.Title) != r.Title || deref(g.Email) != r.Email || deref(g.HiredAt) != r.HiredAt {
+		t.Errorf("GraphQL = %s %q %q %q %q, REST = %s %q %q %q %q", g.ID, g.Name, deref(g.Title), deref(g.Email), deref(g.HiredAt),
+			r.ID, r.Name, r.Title, r.Email, r.HiredAt)
+	}
+	if g.Salary == nil || g.Salary.Amount != r.Salary.Amount() || g.Salary.Currency != string(r.Salary.Currency()) || g.Salary.Formatted != r.Salary.String() {
+		t.Errorf("GraphQL salary = %+v, REST = %s", g.Salary, r.Salary)
+	}
+}
+
+func (a api) hireREST(name, title, amount string) {
+	a.t.Helper()
+	body := `{"name": "` + name + `", "title": "` + title + `", "email": "` + strings.ToLower(name) + `@example.com", "salary": {"amount": "` + amount + `", "currency": "USD"}}`
+	if status := a.rest(http.MethodPost, "/employees", body, nil); status != http.StatusCreated {
+		a.t.Fatalf("POST /employees %s = %d, want 201", name, status)
+	}
+}
+
+func TestQuery_Employee(t *testing.T) {
+	a := newAPI(t)
+	a.hireREST("Mona", "Engineer", "5000.00")
+
+	var rest httpapi.EmployeeDTO
+	a.rest(http.MethodGet, "/employees/Mona", "", &rest)
+	var data struct{ Employee *gqlEmployee }
+	if resp := a.graphql(`query Find($name: String!) { employee(name: $name) { `+fields+` } }`, map[string]any{"name": "Mona"}, &data); len(resp.Errors) != 0 {
+		t.Fatalf("employee errors = %v", resp.Errors)
+	}
+	same(t, data.Employee, rest)
+}
+
+func TestQuery_EmployeeNotFound(t *testing.T) {
+	a := newAPI(t)
+	a.hireREST("Mona", "Engineer", "5000.00")
+	if status := a.rest(http.MethodGet, "/employees/Nobody", "", nil); status != http.StatusNotFound {
+		t.Errorf("GET /employees/Nobody = %d, want 404", status)
+	}
+	// the other field still resolves
+	var data struct{ Nobody, Mona *gqlEmployee }
+	resp := a.graphql(`{ nobody: employee(name: "Nobody") { name } mona: employee(name: "Mona") { name } }`, nil, &data)
+	if data.Nobody != nil || data.Mona == nil || data.Mona.Name != "Mona" {
+		t.Errorf("data = %+v, want nobody null and mona found", data)
+	}
+	if !slices.Equal(resp.codes(), []string{"NOT_FOUND"}) || len(resp.Errors[0].Path) != 1 || resp.Errors[0].Path[0] != "nobody" {
+		t.Errorf("errors = %+v, want NOT_FOUND at nobody", resp.Errors)
+	}
+}
+
+func TestQuery_EmployeesMatchesREST(t *testing.T) {
+	a := newAPI(t)
+	for _, e := range []struct{ name, amount string }{
+		{"Ali", "5000.00"}, {"Amal", "6000.00"}, {"Amir", "4000.00"}, {"Bea", "5000.00"}, {"Omar", "3000.00"}, {"Sara", "4500.00"},
+	} {
+		a.hireREST(e.name, "Engineer", e.amount)
+	}
+	tests := []struct {
+		name string
+		args string // GraphQL arguments, cursor aside
+		rest url.Values
+	}{
+		{name: "by name", args: `limit: 2`, rest: url.Values{"limit": {"2"}}},
+		{name: "prefix", args: `prefix: "Am", limit: 1`, rest: url.Values{"prefix": {"Am"}, "limit": {"1"}}},
+		{name: "salary range, by salary", args: `minSalary: {amount: "4000", currency: "USD"}, maxSalary: {amount: "5000.00", currency: "USD"}, sort: SALARY, limit: 3`,
+			rest: url.Values{"min_salary": {"4000"}, "max_salary": {"5000.00"}, "currency": {"USD"}, "sort": {"salary"}, "limit": {"3"}}},
+		{name: "descending", args: `desc: true, limit: 4`, rest: url.Values{"desc": {"true"}, "limit": {"4"}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cursor, pages := "", 0
+			for {
+				q := tt.rest
+				q.Set("cursor", cursor)
+				var rest httpapi.ListResponse
+				if status := a.rest(http.MethodGet, "/employees?"+q.Encode(), "", &rest); status != http.StatusOK {
+					t.Fatalf("GET /employees?%s = %d", q.Encode(), status)
+				}
+				var data struct {
+					Employees struct {
+						Items      []*gqlEmployee
+						NextCursor *string
+					}
+				}
+				resp := a.graphql(`query Page($cursor: String) { employees(`+tt.args+`, cursor: $cursor) { items { `+fields+` } nextCursor } }`,
+					map[string]any{"cursor": cursor}, &data)
+				if len(resp.Errors) != 0 {
+					t.Fatalf("employees errors = %v", resp.Errors)
+				}
+				if len(data.Employees.Items) != len(rest.Items) {
+					t.Fatalf("page %d: GraphQL has %d items, REST %d", pages, len(data.Employees.Items), len(rest.Items))
+				}
+				for i := range rest.Items {
+					same(t, data.Employees.Items[i], rest.Items[i])
+				}
+				next := ""
+				if data.Employees.NextCursor != nil {
+					next = *data.Employees.NextCursor
+				}
+				if next != rest.NextCursor {
+					t.Fatalf("page %d: GraphQL nextCursor %q, REST %q", pages, next, rest.NextCursor)
+				}
+				pages++
+				if next == "" {
+					break
+				}
+				cursor = next
+			}
+			if pages < 2 {
+				t.Errorf("%d page, want the case to follow a cursor", pages)
+			}
+		})
+	}
+}
+
+func TestQuery_EmployeesRejectsAnUnknownSort(t *testing.T) {
+	a := newAPI(t)
+	resp := a.graphql(`{ employees(sort: AGE) { nextCursor } }`, nil, nil)
+	if !slices.Equal(resp.codes(), []string{"BAD_USER_INPUT"}) {
+		t.Errorf("errors = %+v, want BAD_USER_INPUT", resp.Errors)
+	}
+}
+
+func TestMutations_MatchREST(t *testing.T) {
+	a := newAPI(t)
+	var hired struct{ Hire *gqlEmployee }
+	resp := a.graphql(`mutation Hire($in: HireInput!) { hire(input: $in) { `+fields+` } }`,
+		map[string]any{"in": map[string]any{"name": "Mona", "title": "Engineer", "email": "mona@example.com", "salary": map[string]any{"amount": "5000", "currency": "USD"}}}, &hired)
+	if len(resp.Errors) != 0 {
+		t.Fatalf("hire errors = %v", resp.Errors)
+	}
+	var rest httpapi.EmployeeDTO
+	if status := a.rest(http.MethodGet, "/employees/Mona", "", &rest); status != http.StatusOK {
+		t.Fatalf("GET /employees/Mona = %d after hire, want 200", status)
+	}
+	same(t, hired.Hire, rest)
+
+	var changed struct{ ChangeSalary *gqlEmployee }
+	a.graphql(`mutation { changeSalary(name: "Mona", salary: {amount: "5500.50", currency: "USD"}) { `+fields+` } }`, nil, &changed)
+	a.rest(http.MethodGet, "/employees/Mona", "", &rest)
+	same(t, changed.ChangeSalary, rest)
+	if rest.Salary.Amount() != "5500.50" {
+		t.Errorf("REST salary = %s after changeSalary, want 5500.50", rest.Salary)
+	}
+
+	// REST promotes, GraphQL reads: the other direction
+	if status := a.rest(http.MethodPost, "/employees/Mona/promotion", `{"title": "Lead", "raise": {"amount": "500.00", "currency": "USD"}}`, &rest); status != http.StatusOK {
+		t.Fatalf("POST promotion = %d", status)
+	}
+	var found struct{ Employee *gqlEmployee }
+	a.graphql(`{ employee(name: "Mona") { `+fields+` } }`, nil, &found)
+	same(t, found.Employee, rest)
+
+	var promoted struct{ Promote *gqlEmployee }
+	a.graphql(`mutation { promote(name: "Mona", title: "Manager", raise: {amount: "1000", currency: "USD"}) { `+fields+` } }`, nil, &promoted)
+	a.rest(http.MethodGet, "/employees/Mona", "", &rest)
+	same(t, promoted.Promote, rest)
+	if rest.Title != "Manager" || rest.Salary.Amount() != "7000.50" {
+		t.Errorf("REST = %s %s after promote, want Manager at 7000.50", rest.Title, rest.Salary)
+	}
+
+	var removed struct{ Remove *bool }
+	a.graphql(`mutation { remove(name: "Mona") }`, nil, &removed)
+	if removed.Remove == nil || !*removed.Remove {
+		t.Errorf("remove = %v, want true", removed.Remove)
+	}
+	if status := a.rest(http.MethodGet, "/employees/Mona", "", nil); status != http.StatusNotFound {
+		t.Errorf("GET /employees/Mona = %d after remove, want 404", status)
+	}
+}
+
+func TestMutations_ErrorsMatchREST(t *testing.T) {
+	tests := []struct {
+		name     string
+		mutation string
+		method   string
+		path     string
+		body     string
+		code     string
+		status   int
+	}{
+		{"name taken", `mutation { hire(input: {name: "Mona", salary: {amount: "1", currency: "USD"}}) { name } }`,
+			http.MethodPost, "/employees", `{"name": "Mona", "salary": {"amount": "1", "currency": "USD"}}`, "NAME_TAKEN", http.StatusConflict},
+		{"invalid salary", `mutation { changeSalary(name: "Mona", salary: {amount: "-5", currency: "USD"}) { name } }`,
+			http.MethodPut, "/employees/Mona/salary", `{"salary": {"amount": "-5", "currency": "USD"}}`, "BAD_USER_INPUT", http.StatusBadRequest},
+		{"promotion without a raise", `mutation { promote(name: "Mona", title: "Lead", raise: {amount: "0", currency: "USD"}) { name } }`,
+			http.MethodPost, "/employees/Mona/promotion", `{"title": "Lead", "raise": {"amount": "0", "currency": "USD"}}`, "BAD_USER_INPUT", http.StatusBadRequest},
+		{"missing", `mutation { remove(name: "Nobody") }`,
+			http.MethodDelete, "/employees/Nobody", "", "NOT_FOUND", http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := newAPI(t)
+			a.hireREST("Mona", "Engineer", "5000.00")
+			resp := a.graphql(tt.mutation, nil, nil)
+			if !slices.Equal(resp.codes(), []string{tt.code}) {
+				t.Errorf("GraphQL errors = %+v, want %s", resp.Errors, tt.code)
+			}
+			if status := a.rest(tt.method, tt.path, tt.body, nil); status != tt.status {
+				t.Errorf("%s %s = %d, want %d", tt.method, tt.path, status, tt.status)
+			}
+		})
+	}
+}
+
+func TestServeHTTP(t *testing.T) {
+	a := newAPI(t)
+	tests := []struct {
+		name     string
+		method   string
+		path     string
+		body     string
+		status   int
+		contains string
+	}{
+		{"schema", http.MethodGet, "/graphql", "", http.StatusOK, "type Query"},
+		{"query by GET", http.MethodGet, "/graphql?query=" + url.QueryEscape(`{ employees { nextCursor } }`), "", http.StatusOK, `"data"`},
+		{"mutation by GET", http.MethodGet, "/graphql?query=" + url.QueryEscape(`mutation { remove(name: "Mona") }`), "", http.StatusMethodNotAllowed, "POST"},
+		{"invalid JSON", http.MethodPost, "/graphql", "{", http.StatusBadRequest, "invalid JSON body"},
+		{"unknown field", http.MethodPost, "/graphql", `{"query": "{ salary }"}`, http.StatusBadRequest, "GRAPHQL_VALIDATION_FAILED"},
+		{"parse error", http.MethodPost, "/graphql", `{"query": "{ employee(name: "}`, http.StatusBadRequest, "GRAPHQL_PARSE_FAILED"},
+		{"other methods", http.MethodPut, "/graphql", "", http.StatusMethodNotAllowed, ""},
+	}
+	for _, tt := range tests {
+		status, body := a.send(tt.method, tt.path, "application/json", tt.body)
+		if status != tt.status || !bytes.Contains(body, []byte(tt.contains)) {
+			t.Errorf("%s: %s %s = %d %s, want %d containing %s", tt.name, tt.method, tt.path, status, body, tt.status, tt.contains)
+		}
+	}
+}
@@ -0,0 +1,357 @@
+package graphqlapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// The subset of the GraphQL query language the adapter understands:
+// operations with variables, fields with aliases and arguments, named and
+// inline fragments. Directives, block strings and subscriptions are not
+// supported, and are reported as syntax errors.
+
+// document A parsed request
+type document struct {
+	operations []*operation
+	fragments  map[string]*fragment
+}
+
+type operation struct {
+	kind string // "query" or "mutation"
+	name string
+	vars []variableDef
+	sel  []selection
+}
+
+type variableDef struct {
+	name     string
+	nonNull  bool
+	fallback any // the default value, if any
+	hasValue bool
+}
+
+type fragment struct {
+	on  string
+	sel []selection
+}
+
+// selection A field, or a fragment spread when spread is set, or an inline
+// fragment when inline is set
+type selection struct {
+	alias, name string
+	args        map[string]any
+	sel         []selection
+
+	spread string
+	on     string
+	inline []selection
+}
+
+func (s selection) key() string {
+	if s.alias != "" {
+		return s.alias
+	}
+	return s.name
+}
+
+// variable A reference to a variable inside an argument value
+type variable string
+
+// enum An enum value, such as SALARY
+type enum string
+
+// SyntaxError A query the parser couldn't read, with where it stopped
+type SyntaxError struct {
+	Offset int
+	Msg    string
+}
+
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("syntax error at offset %d: %s", e.Offset, e.Msg)
+}
+
+type token struct {
+	kind  byte // 'n' name, 's' string, '0' number, 'p' punctuator, 0 end
+	value string
+	pos   int
+}
+
+type parser struct {
+	src string
+	pos int
+	tok token
+}
+
+func parse(src string) (doc *document, err error) {
+	defer func() {
+		// fail panics with a *SyntaxError to unwind the descent; anything
+		// else is a bug in the parser and goes on up
+		switch r := recover().(type) {
+		case nil:
+		case *SyntaxError:
+			doc, err = nil, r
+		default:
+			panic(r)
+		}
+	}()
+	p := &parser{src: src}
+	p.next()
+	doc = &document{fragments: map[string]*fragment{}}
+	for p.tok.kind != 0 {
+		switch {
+		case p.is('p', "{"):
+			doc.operations = append(doc.operations, &operation{kind: "query", sel: p.selectionSet()})
+		case p.is('n', "query"), p.is('n', "mutation"):
+			doc.operations = append(doc.operations, p.operation())
+		case p.is('n', "fragment"):
+			p.next()
+			name := p.name()
+			if name == "on" {
+				p.fail("fragment can't be named on")
+			}
+			p.expectName("on")
+			doc.fragments[name] = &fragment{on: p.name(), sel: p.selectionSet()}
+		default:
+			p.fail("expected an operation or fragment, found %q", p.tok.value)
+		}
+	}
+	return doc, nil
+}
+
+func (p *parser) operation() *operation {
+	op := &operation{kind: p.name()}
+	if p.tok.kind == 'n' {
+		op.name = p.name()
+	}
+	if p.accept("(") {
+		for !p.accept(")") {
+			p.expect("$")
+			v := variableDef{name: p.name()}
+			p.expect(":")
+			v.nonNull = p.typeRef()
+			if p.accept("=") {
+				v.fallback, v.hasValue = p.value(true), true
+			}
+			op.vars = append(op.vars, v)
+		}
+	}
+	op.sel = p.selectionSet()
+	return op
+}
+
+// typeRef skips a type such as [String!]! and reports whether it is non-null.
+// Variables aren't checked against their declared types; the resolvers check
+// the values they receive.
+func (p *parser) typeRef() bool {
+	if p.accept("[") {
+		p.typeRef()
+		p.expect("]")
+	} else {
+		p.name()
+	}
+	return p.accept("!")
+}
+
+func (p *parser) selectionSet() []selection {
+	p.expect("{")
+	var sel []selection
+	for !p.accept("}") {
+		if p.accept("...") {
+			if p.is('n', "on") {
+				p.next()
+				on := p.name()
+				sel = append(sel, selection{on: on, inline: p.selectionSet()})
+			} else if p.is('p', "{") {
+				sel = append(sel, selection{inline: p.selectionSet()})
+			} else {
+				sel = append(sel, selection{spread: p.name()})
+			}
+			continue
+		}
+		s := selection{name: p.name()}
+		if p.accept(":") {
+			s.alias, s.name = s.name, p.name()
+		}
+		if p.accept("(") {
+			s.args = map[string]any{}
+			for !p.accept(")") {
+				name := p.name()
+				p.expect(":")
+				s.args[name] = p.value(false)
+			}
+		}
+		if p.is('p', "{") {
+			s.sel = p.selectionSet()
+		}
+		sel = append(sel, s)
+	}
+	if len(sel) == 0 {
+		p.fail("empty selection set")
+	}
+	return sel
+}
+
+// value reads an argument value. Numbers are json.Numbers, as they are in
+// variables decoded from the request body.
+func (p *parser) value(constant bool) any {
+	t := p.tok
+	switch {
+	case t.kind == 'p' && t.value == "$" && !constant:
+		p.next()
+		return variable(p.name())
+	case t.kind == 's':
+		p.next()
+		return t.value
+	case t.kind == '0':
+		p.next()
+		return json.Number(t.value)
+	case t.kind == 'n':
+		p.next()
+		switch t.value {
+		case "true":
+			return true
+		case "false":
+			return false
+		case "null":
+			return nil
+		}
+		return enum(t.value)
+	case p.accept("["):
+		list := []any{}
+		for !p.accept("]") {
+			list = append(list, p.value(constant))
+		}
+		return list
+	case p.accept("{"):
+		obj := map[string]any{}
+		for !p.accept("}") {
+			name := p.name()
+			p.expect(":")
+			obj[name] = p.value(constant)
+		}
+		return obj
+	}
+	p.fail("expected a value, found %q", t.value)
+	return nil
+}
+
+func (p *parser) is(kind byte, value string) bool {
+	return p.tok.kind == kind && p.tok.value == value
+}
+
+func (p *parser) accept(punct string) bool {
+	if p.is('p', punct) {
+		p.next()
+		return true
+	}
+	return false
+}
+
+func (p *parser) expect(punct string) {
+	if !p.accept(punct) {
+		p.fail("expected %q, found %q", punct, p.tok.value)
+	}
+}
+
+func (p *parser) expectName(name string) {
+	if !p.is('n', name) {
+		p.fail("expected %q, found %q", name, p.tok.value)
+	}
+	p.next()
+}
+
+func (p *parser) name() string {
+	if p.tok.kind != 'n' {
+		p.fail("expected a name, found %q", p.tok.value)
+	}
+	name := p.tok.value
+	p.next()
+	return name
+}
+
+func (p *parser) fail(format string, args ...any) {
+	panic(&SyntaxError{Offset: p.tok.pos, Msg: fmt.Sprintf(format, args...)})
+}
+
+// next reads the following token into p.tok. Commas, like whitespace and
+// comments, are insignificant.
+func (p *parser) next() {
+	for p.pos < len(p.src) {
+		c := p.src[p.pos]
+		if c == '#' {
+			for p.pos < len(p.src) && p.src[p.pos] != '\n' {
+				p.pos++
+			}
+			continue
+		}
+		if c != ' ' && c != '\t' && c != '\n' && c != '\r' && c != ',' {
+			break
+		}
+		p.pos++
+	}
+	start := p.pos
+	if p.pos == len(p.src) {
+		p.tok = token{value: "end of query", pos: start}
+		return
+	}
+	switch c := p.src[p.pos]; {
+	case strings.HasPrefix(p.src[p.pos:], "..."):
+		p.pos += 3
+		p.tok = token{'p', "...", start}
+	case strings.IndexByte("!$():=@[]{}|", c) >= 0:
+		p.pos++
+		p.tok = token{'p', string(c), start}
+	case c == '_' || 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z':
+		for p.pos < len(p.src) && isNameChar(p.src[p.pos]) {
+			p.pos++
+		}
+		p.tok = token{'n', p.src[start:p.pos], start}
+	case c == '-' || '0' <= c && c <= '9':
+		p.pos++
+		for p.pos < len(p.src) && strings.IndexByte("0123456789.eE+-", p.src[p.pos]) >= 0 {
+			p.pos++
+		}
+		if _, err := strconv.ParseFloat(p.src[start:p.pos], 64); err != nil {
+			p.tok.pos = start
+			p.fail("invalid number %q", p.src[start:p.pos])
+		}
+		p.tok = token{'0', p.src[start:p.pos], start}
+	case c == '"':
+		p.tok = token{'s', p.str(), start}
+	default:
+		r, _ := utf8.DecodeRuneInString(p.src[p.pos:])
+		p.tok.pos = start
+		p.fail("unexpected character %q", r)
+	}
+}
+
+// str reads a quoted string; its escapes are JSON's.
+func (p *parser) str() string {
+	start := p.pos
+	for p.pos++; p.pos < len(p.src); p.pos++ {
+		switch p.src[p.pos] {
+		case '\\':
+			p.pos++
+		case '\n':
+			p.pos = len(p.src)
+		case '"':
+			p.pos++
+			var s string
+			if err := json.Unmarshal([]byte(p.src[start:p.pos]), &s); err != nil {
+				p.tok.pos = start
+				p.fail("invalid string: %v", err)
+			}
+			return s
+		}
+	}
+	p.tok.pos = start
+	p.fail("unterminated string")
+	return ""
+}
+
+func isNameChar(c byte) bool {
+	return c == '_' || 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' || '0' <= c && c <= '9'
+}
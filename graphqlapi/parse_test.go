@@ -0,0 +1,74 @@
+package graphqlapi
+
+import (
+	"errors"
+	"testing"
+)
+
+var queries = []string{
+	`{ employee(name: "Mona") { name title } }`,
+	`query Page($after: String = null) { employees(first: 2, after: $after, sort: SALARY) { items { ...card } } } fragment card on Employee { name salary { amount currency } }`,
+	`mutation { promote(name: "Mona", title: "Lead", raise: {amount: "500.00", currency: "USD"}) { title } }`,
+	`{ employee(name: "Mona") { ... on Employee { hiredAt } } }`,
+	`{ employee(name: "Mona" { name } }`,
+	`fragment on on Employee { name }`,
+	`{ employee(name: "unterminated) }`,
+	`query @deprecated { x }`,
+	`{`,
+	``,
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		src     string
+		wantOps int
+		wantErr bool
+	}{
+		{src: queries[0], wantOps: 1},
+		{src: queries[1], wantOps: 1},
+		{src: queries[2], wantOps: 1},
+		{src: queries[3], wantOps: 1},
+		{src: queries[4], wantErr: true},
+		{src: queries[5], wantErr: true},
+		{src: queries[6], wantErr: true},
+		{src: queries[7], wantErr: true},
+		{src: queries[8], wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.src, func(t *testing.T) {
+			doc, err := parse(tt.src)
+			var syntax *SyntaxError
+			if tt.wantErr {
+				if !errors.As(err, &syntax) || doc != nil {
+					t.Fatalf("parse() = %v, %v, want a *SyntaxError and no document", doc, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parse() error = %v", err)
+			}
+			if len(doc.operations) != tt.wantOps {
+				t.Errorf("parse() has %d operations, want %d", len(doc.operations), tt.wantOps)
+			}
+		})
+	}
+}
+
+// FuzzParse checks that parse only ever fails with a *SyntaxError: a bug
+// that panics with anything else must reach the caller, not be returned as
+// half a document.
+func FuzzParse(f *testing.F) {
+	for _, q := range queries {
+		f.Add(q)
+	}
+	f.Fuzz(func(t *testing.T, src string) {
+		doc, err := parse(src)
+		var syntax *SyntaxError
+		if err != nil && !errors.As(err, &syntax) {
+			t.Fatalf("parse(%q) error = %v, want a *SyntaxError", src, err)
+		}
+		if (err == nil) == (doc == nil) {
+			t.Fatalf("parse(%q) = %v, %v, want a document or an error", src, doc, err)
+		}
+	})
+}
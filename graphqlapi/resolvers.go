@@ -0,0 +1,155 @@
+package graphqlapi
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go-solid/employee"
+	"go-solid/money"
+)
+
+// resolvers The schema's fields, each a call on the EmployeeService or a
+// read of the value its parent resolved to
+type resolvers struct {
+	svc EmployeeService
+}
+
+var (
+	moneyType = &object{name: "Money", fields: map[string]field{
+		"amount":    prop(func(m money.Money) any { return m.Amount() }),
+		"currency":  prop(func(m money.Money) any { return string(m.Currency()) }),
+		"formatted": prop(func(m money.Money) any { return m.String() }),
+	}}
+	employeeType = &object{name: "Employee", fields: map[string]field{
+		"id":     prop(func(e employee.Employee) any { return e.ID }),
+		"name":   prop(func(e employee.Employee) any { return e.Name }),
+		"title":  prop(func(e employee.Employee) any { return optional(e.Title) }),
+		"email":  prop(func(e employee.Employee) any { return optional(e.Email) }),
+		"salary": {typ: moneyType, resolve: prop(func(e employee.Employee) any { return e.Salary }).resolve},
+		"hiredAt": prop(func(e employee.Employee) any {
+			if e.HiredAt.IsZero() {
+				return nil
+			}
+			return e.HiredAt.UTC().Format(time.RFC3339)
+		}),
+	}}
+	pageType = &object{name: "EmployeePage", fields: map[string]field{
+		"items": {typ: employeeType, resolve: prop(func(p employee.PageResult) any {
+			items := make([]any, len(p.Items))
+			for i, e := range p.Items {
+				items[i] = e
+			}
+			return items
+		}).resolve},
+		"nextCursor": prop(func(p employee.PageResult) any { return optional(p.NextCursor) }),
+	}}
+)
+
+// prop A scalar field read from a parent of type T
+func prop[T any](get func(T) any) field {
+	return field{resolve: func(_ context.Context, parent any, _ args) (any, error) {
+		return get(parent.(T)), nil
+	}}
+}
+
+// optional makes an empty string null, as the REST API leaves it out.
+func optional(s string) any {
+	if s == "" {
+		return nil
+	}
+	return s
+}
+
+func (r resolvers) query() *object {
+	return &object{name: "Query", fields: map[string]field{
+		"employee": {args: []string{"name"}, typ: employeeType, resolve: func(ctx context.Context, _ any, a args) (any, error) {
+			in := newReader(a)
+			in.require("name")
+			name := in.string("name")
+			if err := in.Err(); err != nil {
+				return nil, err
+			}
+			return r.svc.FindEmployee(ctx, name)
+		}},
+		"employees": {
+			args: []string{"prefix", "minSalary", "maxSalary", "sort", "desc", "limit", "cursor"},
+			typ:  pageType, resolve: r.employees,
+		},
+	}}
+}
+
+func (r resolvers) employees(ctx context.Context, _ any, a args) (any, error) {
+	in := newReader(a)
+	filter := employee.Filter{
+		NamePrefix: in.string("prefix"),
+		MinSalary:  in.money("minSalary"),
+		MaxSalary:  in.money("maxSalary"),
+		Descending: in.bool("desc"),
+	}
+	switch sort := in.string("sort"); sort {
+	case "":
+	case "NAME":
+		filter.Sort = employee.SortByName
+	case "SALARY":
+		filter.Sort = employee.SortBySalary
+	default:
+		return nil, fmt.Errorf("%w: sort must be NAME or SALARY, not %s", ErrInvalidArgument, sort)
+	}
+	page := employee.Page{Limit: in.int("limit"), Cursor: in.string("cursor")}
+	if err := in.Err(); err != nil {
+		return nil, err
+	}
+	return r.svc.ListEmployees(ctx, filter, page)
+}
+
+func (r resolvers) mutation() *object {
+	return &object{name: "Mutation", fields: map[string]field{
+		"hire": {args: []string{"input"}, typ: employeeType, resolve: func(ctx context.Context, _ any, a args) (any, error) {
+			in := newReader(a)
+			in.require("input")
+			input := in.object("input")
+			input.require("name", "salary")
+			emp := employee.Employee{
+				Name:   input.string("name"),
+				Title:  input.string("title"),
+				Email:  input.string("email"),
+				Salary: input.money("salary"),
+			}
+			if err := in.Err(); err != nil {
+				return nil, err
+			}
+			return r.svc.AddEmployee(ctx, emp)
+		}},
+		"changeSalary": {args: []string{"name", "salary"}, typ: employeeType, resolve: func(ctx context.Context, _ any, a args) (any, error) {
+			in := newReader(a)
+			in.require("name", "salary")
+			name, salary := in.string("name"), in.money("salary")
+			if err := in.Err(); err != nil {
+				return nil, err
+			}
+			return r.svc.ChangeSalary(ctx, name, salary)
+		}},
+		"promote": {args: []string{"name", "title", "raise"}, typ: employeeType, resolve: func(ctx context.Context, _ any, a args) (any, error) {
+			in := newReader(a)
+			in.require("name", "title", "raise")
+			name, title, raise := in.string("name"), in.string("title"), in.money("raise")
+			if err := in.Err(); err != nil {
+				return nil, err
+			}
+			return r.svc.Promote(ctx, name, title, raise)
+		}},
+		"remove": {args: []string{"name"}, resolve: func(ctx context.Context, _ any, a args) (any, error) {
+			in := newReader(a)
+			in.require("name")
+			name := in.string("name")
+			if err := in.Err(); err != nil {
+				return nil, err
+			}
+			if err := r.svc.RemoveEmployee(ctx, name); err != nil {
+				return nil, err
+			}
+			return true, nil
+		}},
+	}}
+}
@@ -0,0 +1,53 @@
+package grpcapi
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+
+	"go-solid/employee"
+	"go-solid/employeecli"
+)
+
+// Transport is employee-cli's gRPC transport: a Client of the server at
+// addr, a host:port, giving up on each call after timeout. The connection
+// is plaintext, as employee-api's HTTP is.
+func Transport(addr string, timeout time.Duration) (employeecli.Client, error) {
+	conn, err := grpc.NewClient(addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, err
+	}
+	return cliClient{svc: NewEmployeeServiceGRPCClient(conn), timeout: timeout}, nil
+}
+
+// cliClient Adapts the generated client to the three calls the CLI's
+// commands make; its RemoteErrors unwrap to the domain errors they check
+type cliClient struct {
+	svc     *EmployeeServiceGRPCClient
+	timeout time.Duration
+}
+
+func (c cliClient) Add(ctx context.Context, emp employee.Employee) (employee.Employee, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+	return c.svc.AddEmployee(ctx, emp)
+}
+
+func (c cliClient) Get(ctx context.Context, name string) (employee.Employee, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+	return c.svc.FindEmployee(ctx, name)
+}
+
+func (c cliClient) List(ctx context.Context, filter employee.Filter, page employee.Page) (employee.PageResult, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+	return c.svc.ListEmployees(ctx, filter, page)
+}
+
+var (
+	_ employeecli.Transport = Transport
+	_ employeecli.Client    = cliClient{}
+)
@@ -0,0 +1,20 @@
+// Command employee-cli is go-solid's employee-cli with gRPC as a third
+// transport, beside REST and GraphQL; -transport grpc talks to employee-grpc:
+//
+//	employee-cli -transport grpc -addr localhost:9090 add -name Alice -salary 5000
+//	employee-cli -transport grpc -addr localhost:9090 get Alice
+package main
+
+import (
+	"maps"
+	"os"
+
+	"go-solid/employeecli"
+	"go-solid/grpcapi"
+)
+
+func main() {
+	transports := maps.Clone(employeecli.Transports)
+	transports["grpc"] = grpcapi.Transport
+	os.Exit(employeecli.Main(os.Args[1:], transports))
+}
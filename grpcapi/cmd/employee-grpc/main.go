@@ -0,0 +1,94 @@
+// Command employee-grpc serves the employee use cases over gRPC, on the
+// storage backend of employee-api's config file. It is employee-api without
+// the HTTP extras: the same Manager behind grpcapi.NewServer.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"io/fs"
+	"log/slog"
+	"net"
+	"os"
+
+	"google.golang.org/grpc"
+
+	"go-solid/config"
+	"go-solid/employee"
+	"go-solid/grpcapi"
+	"go-solid/lifecycle"
+	"go-solid/storage"
+)
+
+func main() {
+	configPath := flag.String("config", "config.json", "path to employee-api's JSON config file")
+	addr := flag.String("addr", ":9090", "address to serve gRPC on")
+	flag.Parse()
+
+	logger := slog.New(slog.NewTextHandler(os.Stderr, nil))
+	if err := run(*configPath, *addr, logger); err != nil {
+		logger.Error("employee-grpc stopped", "err", err)
+		os.Exit(1)
+	}
+}
+
+func run(configPath, addr string, logger *slog.Logger) error {
+	cfg, err := config.Load(configPath)
+	if errors.Is(err, fs.ErrNotExist) {
+		logger.Warn("config file not found, using defaults", "path", configPath)
+		cfg, err = config.Default, nil
+	}
+	if err != nil {
+		return err
+	}
+	repos, err := storage.Open(cfg.Storage)
+	if err != nil {
+		return err
+	}
+	manager := employee.NewManager(repos.Employees(),
+		employee.WithAudit(repos.Audit()),
+		employee.WithLogger(logger),
+	)
+
+	// Started in this order, stopped in reverse: gRPC stops taking calls
+	// before the storage it depends on is closed.
+	app := &lifecycle.Group{Logger: logger}
+	app.Add("storage", lifecycle.Closer(repos))
+	app.Add("grpc", grpcServer{server: grpcapi.NewServer(manager), addr: addr})
+
+	logger.Info("starting", "addr", addr, "backend", cfg.Storage.Backend)
+	return app.Run(context.Background())
+}
+
+// grpcServer Adapter - runs a *grpc.Server as lifecycle.HTTPServer runs an
+// *http.Server
+type grpcServer struct {
+	server *grpc.Server
+	addr   string
+}
+
+func (g grpcServer) Run(context.Context) error {
+	lis, err := net.Listen("tcp", g.addr)
+	if err != nil {
+		return err
+	}
+	return g.server.Serve(lis)
+}
+
+// Stop lets the calls in flight finish, and cuts them off when ctx ends
+// first.
+func (g grpcServer) Stop(ctx context.Context) error {
+	done := make(chan struct{})
+	go func() {
+		g.server.GracefulStop()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		g.server.Stop()
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,457 @@
+// Code generated by solid gen client -transport grpc; DO NOT EDIT.
+
+package grpcapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"go-solid/employee"
+	"go-solid/grpcapi/employeepb"
+	"go-solid/httpapi"
+	"go-solid/money"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// EmployeeServiceGRPCServer Serves an implementation of httpapi.EmployeeService over gRPC:
+//
+//	employeepb.RegisterEmployeeServiceServer(s, NewEmployeeServiceGRPCServer(svc))
+type EmployeeServiceGRPCServer struct {
+	employeepb.UnimplementedEmployeeServiceServer
+	svc httpapi.EmployeeService
+}
+
+func NewEmployeeServiceGRPCServer(svc httpapi.EmployeeService) *EmployeeServiceGRPCServer {
+	return &EmployeeServiceGRPCServer{svc: svc}
+}
+
+func (s *EmployeeServiceGRPCServer) AddEmployee(ctx context.Context, req *employeepb.AddEmployeeRequest) (resp *employeepb.AddEmployeeResponse, err error) {
+	var emp employee.Employee
+	if emp, err = fromPBEmployee(req.GetEmp()); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	r0, err := s.svc.AddEmployee(ctx, emp)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	resp = &employeepb.AddEmployeeResponse{}
+	if resp.Result, err = toPBEmployee(r0); err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return resp, nil
+}
+
+func (s *EmployeeServiceGRPCServer) ChangeSalary(ctx context.Context, req *employeepb.ChangeSalaryRequest) (resp *employeepb.ChangeSalaryResponse, err error) {
+	var (
+		name   string
+		salary money.Money
+	)
+	name = req.GetName()
+	if salary, err = fromPBMoney(req.GetSalary()); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	r0, err := s.svc.ChangeSalary(ctx, name, salary)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	resp = &employeepb.ChangeSalaryResponse{}
+	if resp.Result, err = toPBEmployee(r0); err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return resp, nil
+}
+
+func (s *EmployeeServiceGRPCServer) FindEmployee(ctx context.Context, req *employeepb.FindEmployeeRequest) (resp *employeepb.FindEmployeeResponse, err error) {
+	var name string
+	name = req.GetName()
+	r0, err := s.svc.FindEmployee(ctx, name)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	resp = &employeepb.FindEmployeeResponse{}
+	if resp.Result, err = toPBEmployee(r0); err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return resp, nil
+}
+
+func (s *EmployeeServiceGRPCServer) ListEmployees(ctx context.Context, req *employeepb.ListEmployeesRequest) (resp *employeepb.ListEmployeesResponse, err error) {
+	var (
+		filter employee.Filter
+		page   employee.Page
+	)
+	if filter, err = fromPBFilter(req.GetFilter()); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if page, err = fromPBPage(req.GetPage()); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	r0, err := s.svc.ListEmployees(ctx, filter, page)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	resp = &employeepb.ListEmployeesResponse{}
+	if resp.Result, err = toPBPageResult(r0); err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return resp, nil
+}
+
+func (s *EmployeeServiceGRPCServer) Promote(ctx context.Context, req *employeepb.PromoteRequest) (resp *employeepb.PromoteResponse, err error) {
+	var (
+		name  string
+		title string
+		raise money.Money
+	)
+	name = req.GetName()
+	title = req.GetTitle()
+	if raise, err = fromPBMoney(req.GetRaise()); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	r0, err := s.svc.Promote(ctx, name, title, raise)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	resp = &employeepb.PromoteResponse{}
+	if resp.Result, err = toPBEmployee(r0); err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return resp, nil
+}
+
+func (s *EmployeeServiceGRPCServer) RemoveEmployee(ctx context.Context, req *employeepb.RemoveEmployeeRequest) (resp *employeepb.RemoveEmployeeResponse, err error) {
+	var name string
+	name = req.GetName()
+	if err = s.svc.RemoveEmployee(ctx, name); err != nil {
+		return nil, toStatus(err)
+	}
+	resp = &employeepb.RemoveEmployeeResponse{}
+	return resp, nil
+}
+
+// EmployeeServiceGRPCClient Client of httpapi.EmployeeService over gRPC, answered by EmployeeServiceGRPCServer
+type EmployeeServiceGRPCClient struct {
+	c employeepb.EmployeeServiceClient
+}
+
+// NewEmployeeServiceGRPCClient calls over conn, e.g. what grpc.NewClient returns.
+func NewEmployeeServiceGRPCClient(conn grpc.ClientConnInterface) *EmployeeServiceGRPCClient {
+	return &EmployeeServiceGRPCClient{c: employeepb.NewEmployeeServiceClient(conn)}
+}
+
+func (s *EmployeeServiceGRPCClient) AddEmployee(ctx context.Context, emp employee.Employee) (result employee.Employee, err error) {
+	req := &employeepb.AddEmployeeRequest{}
+	if req.Emp, err = toPBEmployee(emp); err != nil {
+		return result, err
+	}
+	resp, err := s.c.AddEmployee(ctx, req)
+	if err != nil {
+		return result, fromStatus(err)
+	}
+	if result, err = fromPBEmployee(resp.GetResult()); err != nil {
+		return result, err
+	}
+	return result, nil
+}
+
+func (s *EmployeeServiceGRPCClient) ChangeSalary(ctx context.Context, name string, salary money.Money) (result employee.Employee, err error) {
+	req := &employeepb.ChangeSalaryRequest{
+		Name: name,
+	}
+	if req.Salary, err = toPBMoney(salary); err != nil {
+		return result, err
+	}
+	resp, err := s.c.ChangeSalary(ctx, req)
+	if err != nil {
+		return result, fromStatus(err)
+	}
+	if result, err = fromPBEmployee(resp.GetResult()); err != nil {
+		return result, err
+	}
+	return result, nil
+}
+
+func (s *EmployeeServiceGRPCClient) FindEmployee(ctx context.Context, name string) (result employee.Employee, err error) {
+	req := &employeepb.FindEmployeeRequest{
+		Name: name,
+	}
+	resp, err := s.c.FindEmployee(ctx, req)
+	if err != nil {
+		return result, fromStatus(err)
+	}
+	if result, err = fromPBEmployee(resp.GetResult()); err != nil {
+		return result, err
+	}
+	return result, nil
+}
+
+func (s *EmployeeServiceGRPCClient) ListEmployees(ctx context.Context, filter employee.Filter, page employee.Page) (result employee.PageResult, err error) {
+	req := &employeepb.ListEmployeesRequest{}
+	if req.Filter, err = toPBFilter(filter); err != nil {
+		return result, err
+	}
+	if req.Page, err = toPBPage(page); err != nil {
+		return result, err
+	}
+	resp, err := s.c.ListEmployees(ctx, req)
+	if err != nil {
+		return result, fromStatus(err)
+	}
+	if result, err = fromPBPageResult(resp.GetResult()); err != nil {
+		return result, err
+	}
+	return result, nil
+}
+
+func (s *EmployeeServiceGRPCClient) Promote(ctx context.Context, name string, title string, raise money.Money) (result employee.Employee, err error) {
+	req := &employeepb.PromoteRequest{
+		Name:  name,
+		Title: title,
+	}
+	if req.Raise, err = toPBMoney(raise); err != nil {
+		return result, err
+	}
+	resp, err := s.c.Promote(ctx, req)
+	if err != nil {
+		return result, fromStatus(err)
+	}
+	if result, err = fromPBEmployee(resp.GetResult()); err != nil {
+		return result, err
+	}
+	return result, nil
+}
+
+func (s *EmployeeServiceGRPCClient) RemoveEmployee(ctx context.Context, name string) (err error) {
+	req := &employeepb.RemoveEmployeeRequest{
+		Name: name,
+	}
+	if _, err = s.c.RemoveEmployee(ctx, req); err != nil {
+		return fromStatus(err)
+	}
+	return nil
+}
+
+func toPBEmployee(v employee.Employee) (m *employeepb.Employee, err error) {
+	m = &employeepb.Employee{
+		Id:         string(v.ID),
+		Name:       v.Name,
+		Title:      v.Title,
+		Department: v.Department,
+		Email:      v.Email,
+		HiredAt:    toPBTime(v.HiredAt),
+		Version:    int64(v.Version),
+	}
+	if m.Salary, err = toPBMoney(v.Salary); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func fromPBEmployee(m *employeepb.Employee) (v employee.Employee, err error) {
+	v = employee.Employee{
+		ID:         employee.ID(m.GetId()),
+		Name:       m.GetName(),
+		Title:      m.GetTitle(),
+		Department: m.GetDepartment(),
+		Email:      m.GetEmail(),
+		HiredAt:    fromPBTime(m.GetHiredAt()),
+		Version:    int(m.GetVersion()),
+	}
+	if v.Salary, err = fromPBMoney(m.GetSalary()); err != nil {
+		return v, err
+	}
+	return v, nil
+}
+
+func toPBFilter(v employee.Filter) (m *employeepb.Filter, err error) {
+	m = &employeepb.Filter{
+		NamePrefix: v.NamePrefix,
+		Sort:       string(v.Sort),
+		Descending: v.Descending,
+	}
+	if m.MinSalary, err = toPBMoney(v.MinSalary); err != nil {
+		return nil, err
+	}
+	if m.MaxSalary, err = toPBMoney(v.MaxSalary); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func fromPBFilter(m *employeepb.Filter) (v employee.Filter, err error) {
+	v = employee.Filter{
+		NamePrefix: m.GetNamePrefix(),
+		Sort:       employee.SortField(m.GetSort()),
+		Descending: m.GetDescending(),
+	}
+	if v.MinSalary, err = fromPBMoney(m.GetMinSalary()); err != nil {
+		return v, err
+	}
+	if v.MaxSalary, err = fromPBMoney(m.GetMaxSalary()); err != nil {
+		return v, err
+	}
+	return v, nil
+}
+
+func toPBPage(v employee.Page) (m *employeepb.Page, err error) {
+	m = &employeepb.Page{
+		Cursor: v.Cursor,
+		Limit:  int64(v.Limit),
+	}
+	return m, nil
+}
+
+func fromPBPage(m *employeepb.Page) (v employee.Page, err error) {
+	v = employee.Page{
+		Cursor: m.GetCursor(),
+		Limit:  int(m.GetLimit()),
+	}
+	return v, nil
+}
+
+func toPBPageResult(v employee.PageResult) (m *employeepb.PageResult, err error) {
+	m = &employeepb.PageResult{
+		NextCursor: v.NextCursor,
+	}
+	for _, e := range v.Items {
+		x, err := toPBEmployee(e)
+		if err != nil {
+			return nil, err
+		}
+		m.Items = append(m.Items, x)
+	}
+	return m, nil
+}
+
+func fromPBPageResult(m *employeepb.PageResult) (v employee.PageResult, err error) {
+	v = employee.PageResult{
+		NextCursor: m.GetNextCursor(),
+	}
+	for _, e := range m.GetItems() {
+		x, err := fromPBEmployee(e)
+		if err != nil {
+			return v, err
+		}
+		v.Items = append(v.Items, x)
+	}
+	return v, nil
+}
+
+// toPBMoney is v as its MarshalJSON writes it.
+func toPBMoney(v money.Money) (string, error) {
+	b, err := json.Marshal(v)
+	return string(b), err
+}
+
+// fromPBMoney is the zero money.Money when s is empty.
+func fromPBMoney(s string) (v money.Money, err error) {
+	if s == "" {
+		return v, nil
+	}
+	err = json.Unmarshal([]byte(s), &v)
+	return v, err
+}
+
+// toPBTime leaves the zero time unset.
+func toPBTime(t time.Time) *timestamppb.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+	return timestamppb.New(t)
+}
+
+func fromPBTime(t *timestamppb.Timestamp) time.Time {
+	if t == nil {
+		return time.Time{}
+	}
+	return t.AsTime()
+}
+
+// errorDomain is the domain of the ErrorInfo naming a sentinel error.
+const errorDomain = "go-solid/httpapi.EmployeeService"
+
+// RemoteError An error the service returned, as it crossed the wire. It
+// unwraps to the sentinel error it wrapped, if any.
+type RemoteError struct {
+	Status *status.Status
+	// Reason names the sentinel, e.g. "employee.ErrNotFound"
+	Reason string
+}
+
+func (e *RemoteError) Error() string              { return e.Status.Message() }
+func (e *RemoteError) GRPCStatus() *status.Status { return e.Status }
+
+func (e *RemoteError) Unwrap() error {
+	for _, s := range sentinels {
+		if s.reason == e.Reason {
+			return s.err
+		}
+	}
+	return nil
+}
+
+// toStatus is err as it crosses the wire: a status of the code its sentinel
+// suggests, with an ErrorInfo naming the sentinel.
+func toStatus(err error) error {
+	for _, s := range sentinels {
+		if !errors.Is(err, s.err) {
+			continue
+		}
+		st, detailed := status.New(s.code, err.Error()).WithDetails(&errdetails.ErrorInfo{Reason: s.reason, Domain: errorDomain})
+		if detailed != nil {
+			return status.Error(s.code, err.Error())
+		}
+		return st.Err()
+	}
+	return status.Error(codes.Unknown, err.Error())
+}
+
+// fromStatus is the error a call failed with: a *RemoteError when the
+// service sent one, err itself otherwise.
+func fromStatus(err error) error {
+	st, ok := status.FromError(err)
+	if !ok {
+		return err
+	}
+	for _, d := range st.Details() {
+		if info, ok := d.(*errdetails.ErrorInfo); ok && info.GetDomain() == errorDomain {
+			return &RemoteError{Status: st, Reason: info.GetReason()}
+		}
+	}
+	return err
+}
+
+// sentinels The errors that keep their identity across the wire, tried in
+// order: an error wrapping several crosses as the first
+var sentinels = []struct {
+	reason string
+	err    error
+	code   codes.Code
+}{
+	{"employee.ErrConflict", employee.ErrConflict, codes.Aborted},
+	{"employee.ErrInvalidCursor", employee.ErrInvalidCursor, codes.InvalidArgument},
+	{"employee.ErrInvalidName", employee.ErrInvalidName, codes.InvalidArgument},
+	{"employee.ErrInvalidPromotion", employee.ErrInvalidPromotion, codes.InvalidArgument},
+	{"employee.ErrInvalidSalary", employee.ErrInvalidSalary, codes.InvalidArgument},
+	{"employee.ErrNameTaken", employee.ErrNameTaken, codes.AlreadyExists},
+	{"employee.ErrNotAHook", employee.ErrNotAHook, codes.Unknown},
+	{"employee.ErrNotFound", employee.ErrNotFound, codes.NotFound},
+	{"employee.ErrVetoed", employee.ErrVetoed, codes.Unknown},
+	{"errors.ErrUnsupported", errors.ErrUnsupported, codes.Unimplemented},
+	{"money.ErrCurrencyMismatch", money.ErrCurrencyMismatch, codes.InvalidArgument},
+	{"money.ErrInvalidAmount", money.ErrInvalidAmount, codes.InvalidArgument},
+	{"money.ErrInvalidCurrency", money.ErrInvalidCurrency, codes.InvalidArgument},
+	{"money.ErrNoRate", money.ErrNoRate, codes.Unknown},
+	{"money.ErrOverflow", money.ErrOverflow, codes.OutOfRange},
+}
+
+var (
+	_ employeepb.EmployeeServiceServer = (*EmployeeServiceGRPCServer)(nil)
+	_ httpapi.EmployeeService          = (*EmployeeServiceGRPCClient)(nil)
+)
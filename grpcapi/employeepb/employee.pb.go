@@ -0,0 +1,1022 @@
+// Code generated by solid gen proto; DO NOT EDIT.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.12
+// 	protoc        (unknown)
+// source: employee.proto
+
+package employeepb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type AddEmployeeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Emp           *Employee              `protobuf:"bytes,1,opt,name=emp,proto3" json:"emp,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddEmployeeRequest) Reset() {
+	*x = AddEmployeeRequest{}
+	mi := &file_employee_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddEmployeeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddEmployeeRequest) ProtoMessage() {}
+
+func (x *AddEmployeeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_employee_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddEmployeeRequest.ProtoReflect.Descriptor instead.
+func (*AddEmployeeRequest) Descriptor() ([]byte, []int) {
+	return file_employee_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *AddEmployeeRequest) GetEmp() *Employee {
+	if x != nil {
+		return x.Emp
+	}
+	return nil
+}
+
+type AddEmployeeResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Result        *Employee              `protobuf:"bytes,1,opt,name=result,proto3" json:"result,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddEmployeeResponse) Reset() {
+	*x = AddEmployeeResponse{}
+	mi := &file_employee_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddEmployeeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddEmployeeResponse) ProtoMessage() {}
+
+func (x *AddEmployeeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_employee_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddEmployeeResponse.ProtoReflect.Descriptor instead.
+func (*AddEmployeeResponse) Descriptor() ([]byte, []int) {
+	return file_employee_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *AddEmployeeResponse) GetResult() *Employee {
+	if x != nil {
+		return x.Result
+	}
+	return nil
+}
+
+type ChangeSalaryRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Name  string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// money.Money, as its MarshalJSON writes it
+	Salary        string `protobuf:"bytes,2,opt,name=salary,proto3" json:"salary,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ChangeSalaryRequest) Reset() {
+	*x = ChangeSalaryRequest{}
+	mi := &file_employee_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChangeSalaryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChangeSalaryRequest) ProtoMessage() {}
+
+func (x *ChangeSalaryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_employee_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChangeSalaryRequest.ProtoReflect.Descriptor instead.
+func (*ChangeSalaryRequest) Descriptor() ([]byte, []int) {
+	return file_employee_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ChangeSalaryRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ChangeSalaryRequest) GetSalary() string {
+	if x != nil {
+		return x.Salary
+	}
+	return ""
+}
+
+type ChangeSalaryResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Result        *Employee              `protobuf:"bytes,1,opt,name=result,proto3" json:"result,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ChangeSalaryResponse) Reset() {
+	*x = ChangeSalaryResponse{}
+	mi := &file_employee_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChangeSalaryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChangeSalaryResponse) ProtoMessage() {}
+
+func (x *ChangeSalaryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_employee_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChangeSalaryResponse.ProtoReflect.Descriptor instead.
+func (*ChangeSalaryResponse) Descriptor() ([]byte, []int) {
+	return file_employee_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ChangeSalaryResponse) GetResult() *Employee {
+	if x != nil {
+		return x.Result
+	}
+	return nil
+}
+
+type FindEmployeeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FindEmployeeRequest) Reset() {
+	*x = FindEmployeeRequest{}
+	mi := &file_employee_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FindEmployeeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FindEmployeeRequest) ProtoMessage() {}
+
+func (x *FindEmployeeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_employee_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FindEmployeeRequest.ProtoReflect.Descriptor instead.
+func (*FindEmployeeRequest) Descriptor() ([]byte, []int) {
+	return file_employee_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *FindEmployeeRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type FindEmployeeResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Result        *Employee              `protobuf:"bytes,1,opt,name=result,proto3" json:"result,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FindEmployeeResponse) Reset() {
+	*x = FindEmployeeResponse{}
+	mi := &file_employee_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FindEmployeeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FindEmployeeResponse) ProtoMessage() {}
+
+func (x *FindEmployeeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_employee_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FindEmployeeResponse.ProtoReflect.Descriptor instead.
+func (*FindEmployeeResponse) Descriptor() ([]byte, []int) {
+	return file_employee_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *FindEmployeeResponse) GetResult() *Employee {
+	if x != nil {
+		return x.Result
+	}
+	return nil
+}
+
+type ListEmployeesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Filter        *Filter                `protobuf:"bytes,1,opt,name=filter,proto3" json:"filter,omitempty"`
+	Page          *Page                  `protobuf:"bytes,2,opt,name=page,proto3" json:"page,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListEmployeesRequest) Reset() {
+	*x = ListEmployeesRequest{}
+	mi := &file_employee_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListEmployeesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListEmployeesRequest) ProtoMessage() {}
+
+func (x *ListEmployeesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_employee_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListEmployeesRequest.ProtoReflect.Descriptor instead.
+func (*ListEmployeesRequest) Descriptor() ([]byte, []int) {
+	return file_employee_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ListEmployeesRequest) GetFilter() *Filter {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
+func (x *ListEmployeesRequest) GetPage() *Page {
+	if x != nil {
+		return x.Page
+	}
+	return nil
+}
+
+type ListEmployeesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Result        *PageResult            `protobuf:"bytes,1,opt,name=result,proto3" json:"result,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListEmployeesResponse) Reset() {
+	*x = ListEmployeesResponse{}
+	mi := &file_employee_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListEmployeesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListEmployeesResponse) ProtoMessage() {}
+
+func (x *ListEmployeesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_employee_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListEmployeesResponse.ProtoReflect.Descriptor instead.
+func (*ListEmployeesResponse) Descriptor() ([]byte, []int) {
+	return file_employee_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ListEmployeesResponse) GetResult() *PageResult {
+	if x != nil {
+		return x.Result
+	}
+	return nil
+}
+
+type PromoteRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Name  string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Title string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	// money.Money, as its MarshalJSON writes it
+	Raise         string `protobuf:"bytes,3,opt,name=raise,proto3" json:"raise,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PromoteRequest) Reset() {
+	*x = PromoteRequest{}
+	mi := &file_employee_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PromoteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PromoteRequest) ProtoMessage() {}
+
+func (x *PromoteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_employee_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PromoteRequest.ProtoReflect.Descriptor instead.
+func (*PromoteRequest) Descriptor() ([]byte, []int) {
+	return file_employee_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *PromoteRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *PromoteRequest) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *PromoteRequest) GetRaise() string {
+	if x != nil {
+		return x.Raise
+	}
+	return ""
+}
+
+type PromoteResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Result        *Employee              `protobuf:"bytes,1,opt,name=result,proto3" json:"result,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PromoteResponse) Reset() {
+	*x = PromoteResponse{}
+	mi := &file_employee_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PromoteResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PromoteResponse) ProtoMessage() {}
+
+func (x *PromoteResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_employee_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PromoteResponse.ProtoReflect.Descriptor instead.
+func (*PromoteResponse) Descriptor() ([]byte, []int) {
+	return file_employee_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *PromoteResponse) GetResult() *Employee {
+	if x != nil {
+		return x.Result
+	}
+	return nil
+}
+
+type RemoveEmployeeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveEmployeeRequest) Reset() {
+	*x = RemoveEmployeeRequest{}
+	mi := &file_employee_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveEmployeeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveEmployeeRequest) ProtoMessage() {}
+
+func (x *RemoveEmployeeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_employee_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveEmployeeRequest.ProtoReflect.Descriptor instead.
+func (*RemoveEmployeeRequest) Descriptor() ([]byte, []int) {
+	return file_employee_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *RemoveEmployeeRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type RemoveEmployeeResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveEmployeeResponse) Reset() {
+	*x = RemoveEmployeeResponse{}
+	mi := &file_employee_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveEmployeeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveEmployeeResponse) ProtoMessage() {}
+
+func (x *RemoveEmployeeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_employee_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveEmployeeResponse.ProtoReflect.Descriptor instead.
+func (*RemoveEmployeeResponse) Descriptor() ([]byte, []int) {
+	return file_employee_proto_rawDescGZIP(), []int{11}
+}
+
+// Employee is employee.Employee.
+type Employee struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Id         string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name       string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Title      string                 `protobuf:"bytes,3,opt,name=title,proto3" json:"title,omitempty"`
+	Department string                 `protobuf:"bytes,4,opt,name=department,proto3" json:"department,omitempty"`
+	Email      string                 `protobuf:"bytes,5,opt,name=email,proto3" json:"email,omitempty"`
+	// money.Money, as its MarshalJSON writes it
+	Salary        string                 `protobuf:"bytes,6,opt,name=salary,proto3" json:"salary,omitempty"`
+	HiredAt       *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=hired_at,json=hiredAt,proto3" json:"hired_at,omitempty"`
+	Version       int64                  `protobuf:"varint,8,opt,name=version,proto3" json:"version,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Employee) Reset() {
+	*x = Employee{}
+	mi := &file_employee_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Employee) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Employee) ProtoMessage() {}
+
+func (x *Employee) ProtoReflect() protoreflect.Message {
+	mi := &file_employee_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Employee.ProtoReflect.Descriptor instead.
+func (*Employee) Descriptor() ([]byte, []int) {
+	return file_employee_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *Employee) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Employee) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Employee) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *Employee) GetDepartment() string {
+	if x != nil {
+		return x.Department
+	}
+	return ""
+}
+
+func (x *Employee) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *Employee) GetSalary() string {
+	if x != nil {
+		return x.Salary
+	}
+	return ""
+}
+
+func (x *Employee) GetHiredAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.HiredAt
+	}
+	return nil
+}
+
+func (x *Employee) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+// Filter is employee.Filter.
+type Filter struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	NamePrefix string                 `protobuf:"bytes,1,opt,name=name_prefix,json=namePrefix,proto3" json:"name_prefix,omitempty"`
+	// money.Money, as its MarshalJSON writes it
+	MinSalary string `protobuf:"bytes,2,opt,name=min_salary,json=minSalary,proto3" json:"min_salary,omitempty"`
+	// money.Money, as its MarshalJSON writes it
+	MaxSalary     string `protobuf:"bytes,3,opt,name=max_salary,json=maxSalary,proto3" json:"max_salary,omitempty"`
+	Sort          string `protobuf:"bytes,4,opt,name=sort,proto3" json:"sort,omitempty"`
+	Descending    bool   `protobuf:"varint,5,opt,name=descending,proto3" json:"descending,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Filter) Reset() {
+	*x = Filter{}
+	mi := &file_employee_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Filter) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Filter) ProtoMessage() {}
+
+func (x *Filter) ProtoReflect() protoreflect.Message {
+	mi := &file_employee_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Filter.ProtoReflect.Descriptor instead.
+func (*Filter) Descriptor() ([]byte, []int) {
+	return file_employee_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *Filter) GetNamePrefix() string {
+	if x != nil {
+		return x.NamePrefix
+	}
+	return ""
+}
+
+func (x *Filter) GetMinSalary() string {
+	if x != nil {
+		return x.MinSalary
+	}
+	return ""
+}
+
+func (x *Filter) GetMaxSalary() string {
+	if x != nil {
+		return x.MaxSalary
+	}
+	return ""
+}
+
+func (x *Filter) GetSort() string {
+	if x != nil {
+		return x.Sort
+	}
+	return ""
+}
+
+func (x *Filter) GetDescending() bool {
+	if x != nil {
+		return x.Descending
+	}
+	return false
+}
+
+// Page is employee.Page.
+type Page struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Cursor        string                 `protobuf:"bytes,1,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	Limit         int64                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Page) Reset() {
+	*x = Page{}
+	mi := &file_employee_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Page) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Page) ProtoMessage() {}
+
+func (x *Page) ProtoReflect() protoreflect.Message {
+	mi := &file_employee_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Page.ProtoReflect.Descriptor instead.
+func (*Page) Descriptor() ([]byte, []int) {
+	return file_employee_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *Page) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+func (x *Page) GetLimit() int64 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+// PageResult is employee.PageResult.
+type PageResult struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Items         []*Employee            `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+	NextCursor    string                 `protobuf:"bytes,2,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PageResult) Reset() {
+	*x = PageResult{}
+	mi := &file_employee_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PageResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PageResult) ProtoMessage() {}
+
+func (x *PageResult) ProtoReflect() protoreflect.Message {
+	mi := &file_employee_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PageResult.ProtoReflect.Descriptor instead.
+func (*PageResult) Descriptor() ([]byte, []int) {
+	return file_employee_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *PageResult) GetItems() []*Employee {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *PageResult) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
+var File_employee_proto protoreflect.FileDescriptor
+
+const file_employee_proto_rawDesc = "" +
+	"\n" +
+	"\x0eemployee.proto\x12\bemployee\x1a\x1fgoogle/protobuf/timestamp.proto\":\n" +
+	"\x12AddEmployeeRequest\x12$\n" +
+	"\x03emp\x18\x01 \x01(\v2\x12.employee.EmployeeR\x03emp\"A\n" +
+	"\x13AddEmployeeResponse\x12*\n" +
+	"\x06result\x18\x01 \x01(\v2\x12.employee.EmployeeR\x06result\"A\n" +
+	"\x13ChangeSalaryRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x16\n" +
+	"\x06salary\x18\x02 \x01(\tR\x06salary\"B\n" +
+	"\x14ChangeSalaryResponse\x12*\n" +
+	"\x06result\x18\x01 \x01(\v2\x12.employee.EmployeeR\x06result\")\n" +
+	"\x13FindEmployeeRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\"B\n" +
+	"\x14FindEmployeeResponse\x12*\n" +
+	"\x06result\x18\x01 \x01(\v2\x12.employee.EmployeeR\x06result\"d\n" +
+	"\x14ListEmployeesRequest\x12(\n" +
+	"\x06filter\x18\x01 \x01(\v2\x10.employee.FilterR\x06filter\x12\"\n" +
+	"\x04page\x18\x02 \x01(\v2\x0e.employee.PageR\x04page\"E\n" +
+	"\x15ListEmployeesResponse\x12,\n" +
+	"\x06result\x18\x01 \x01(\v2\x14.employee.PageResultR\x06result\"P\n" +
+	"\x0ePromoteRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x14\n" +
+	"\x05title\x18\x02 \x01(\tR\x05title\x12\x14\n" +
+	"\x05raise\x18\x03 \x01(\tR\x05raise\"=\n" +
+	"\x0fPromoteResponse\x12*\n" +
+	"\x06result\x18\x01 \x01(\v2\x12.employee.EmployeeR\x06result\"+\n" +
+	"\x15RemoveEmployeeRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\"\x18\n" +
+	"\x16RemoveEmployeeResponse\"\xe3\x01\n" +
+	"\bEmployee\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x14\n" +
+	"\x05title\x18\x03 \x01(\tR\x05title\x12\x1e\n" +
+	"\n" +
+	"department\x18\x04 \x01(\tR\n" +
+	"department\x12\x14\n" +
+	"\x05email\x18\x05 \x01(\tR\x05email\x12\x16\n" +
+	"\x06salary\x18\x06 \x01(\tR\x06salary\x125\n" +
+	"\bhired_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\ahiredAt\x12\x18\n" +
+	"\aversion\x18\b \x01(\x03R\aversion\"\x9b\x01\n" +
+	"\x06Filter\x12\x1f\n" +
+	"\vname_prefix\x18\x01 \x01(\tR\n" +
+	"namePrefix\x12\x1d\n" +
+	"\n" +
+	"min_salary\x18\x02 \x01(\tR\tminSalary\x12\x1d\n" +
+	"\n" +
+	"max_salary\x18\x03 \x01(\tR\tmaxSalary\x12\x12\n" +
+	"\x04sort\x18\x04 \x01(\tR\x04sort\x12\x1e\n" +
+	"\n" +
+	"descending\x18\x05 \x01(\bR\n" +
+	"descending\"4\n" +
+	"\x04Page\x12\x16\n" +
+	"\x06cursor\x18\x01 \x01(\tR\x06cursor\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x03R\x05limit\"W\n" +
+	"\n" +
+	"PageResult\x12(\n" +
+	"\x05items\x18\x01 \x03(\v2\x12.employee.EmployeeR\x05items\x12\x1f\n" +
+	"\vnext_cursor\x18\x02 \x01(\tR\n" +
+	"nextCursor2\xe2\x03\n" +
+	"\x0fEmployeeService\x12J\n" +
+	"\vAddEmployee\x12\x1c.employee.AddEmployeeRequest\x1a\x1d.employee.AddEmployeeResponse\x12M\n" +
+	"\fChangeSalary\x12\x1d.employee.ChangeSalaryRequest\x1a\x1e.employee.ChangeSalaryResponse\x12M\n" +
+	"\fFindEmployee\x12\x1d.employee.FindEmployeeRequest\x1a\x1e.employee.FindEmployeeResponse\x12P\n" +
+	"\rListEmployees\x12\x1e.employee.ListEmployeesRequest\x1a\x1f.employee.ListEmployeesResponse\x12>\n" +
+	"\aPromote\x12\x18.employee.PromoteRequest\x1a\x19.employee.PromoteResponse\x12S\n" +
+	"\x0eRemoveEmployee\x12\x1f.employee.RemoveEmployeeRequest\x1a .employee.RemoveEmployeeResponseB\x1dZ\x1bgo-solid/grpcapi/employeepbb\x06proto3"
+
+var (
+	file_employee_proto_rawDescOnce sync.Once
+	file_employee_proto_rawDescData []byte
+)
+
+func file_employee_proto_rawDescGZIP() []byte {
+	file_employee_proto_rawDescOnce.Do(func() {
+		file_employee_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_employee_proto_rawDesc), len(file_employee_proto_rawDesc)))
+	})
+	return file_employee_proto_rawDescData
+}
+
+var file_employee_proto_msgTypes = make([]protoimpl.MessageInfo, 16)
+var file_employee_proto_goTypes = []any{
+	(*AddEmployeeRequest)(nil),     // 0: employee.AddEmployeeRequest
+	(*AddEmployeeResponse)(nil),    // 1: employee.AddEmployeeResponse
+	(*ChangeSalaryRequest)(nil),    // 2: employee.ChangeSalaryRequest
+	(*ChangeSalaryResponse)(nil),   // 3: employee.ChangeSalaryResponse
+	(*FindEmployeeRequest)(nil),    // 4: employee.FindEmployeeRequest
+	(*FindEmployeeResponse)(nil),   // 5: employee.FindEmployeeResponse
+	(*ListEmployeesRequest)(nil),   // 6: employee.ListEmployeesRequest
+	(*ListEmployeesResponse)(nil),  // 7: employee.ListEmployeesResponse
+	(*PromoteRequest)(nil),         // 8: employee.PromoteRequest
+	(*PromoteResponse)(nil),        // 9: employee.PromoteResponse
+	(*RemoveEmployeeRequest)(nil),  // 10: employee.RemoveEmployeeRequest
+	(*RemoveEmployeeResponse)(nil), // 11: employee.RemoveEmployeeResponse
+	(*Employee)(nil),               // 12: employee.Employee
+	(*Filter)(nil),                 // 13: employee.Filter
+	(*Page)(nil),                   // 14: employee.Page
+	(*PageResult)(nil),             // 15: employee.PageResult
+	(*timestamppb.Timestamp)(nil),  // 16: google.protobuf.Timestamp
+}
+var file_employee_proto_depIdxs = []int32{
+	12, // 0: employee.AddEmployeeRequest.emp:type_name -> employee.Employee
+	12, // 1: employee.AddEmployeeResponse.result:type_name -> employee.Employee
+	12, // 2: employee.ChangeSalaryResponse.result:type_name -> employee.Employee
+	12, // 3: employee.FindEmployeeResponse.result:type_name -> employee.Employee
+	13, // 4: employee.ListEmployeesRequest.filter:type_name -> employee.Filter
+	14, // 5: employee.ListEmployeesRequest.page:type_name -> employee.Page
+	15, // 6: employee.ListEmployeesResponse.result:type_name -> employee.PageResult
+	12, // 7: employee.PromoteResponse.result:type_name -> employee.Employee
+	16, // 8: employee.Employee.hired_at:type_name -> google.protobuf.Timestamp
+	12, // 9: employee.PageResult.items:type_name -> employee.Employee
+	0,  // 10: employee.EmployeeService.AddEmployee:input_type -> employee.AddEmployeeRequest
+	2,  // 11: employee.EmployeeService.ChangeSalary:input_type -> employee.ChangeSalaryRequest
+	4,  // 12: employee.EmployeeService.FindEmployee:input_type -> employee.FindEmployeeRequest
+	6,  // 13: employee.EmployeeService.ListEmployees:input_type -> employee.ListEmployeesRequest
+	8,  // 14: employee.EmployeeService.Promote:input_type -> employee.PromoteRequest
+	10, // 15: employee.EmployeeService.RemoveEmployee:input_type -> employee.RemoveEmployeeRequest
+	1,  // 16: employee.EmployeeService.AddEmployee:output_type -> employee.AddEmployeeResponse
+	3,  // 17: employee.EmployeeService.ChangeSalary:output_type -> employee.ChangeSalaryResponse
+	5,  // 18: employee.EmployeeService.FindEmployee:output_type -> employee.FindEmployeeResponse
+	7,  // 19: employee.EmployeeService.ListEmployees:output_type -> employee.ListEmployeesResponse
+	9,  // 20: employee.EmployeeService.Promote:output_type -> employee.PromoteResponse
+	11, // 21: employee.EmployeeService.RemoveEmployee:output_type -> employee.RemoveEmployeeResponse
+	16, // [16:22] is the sub-list for method output_type
+	10, // [10:16] is the sub-list for method input_type
+	10, // [10:10] is the sub-list for extension type_name
+	10, // [10:10] is the sub-list for extension extendee
+	0,  // [0:10] is the sub-list for field type_name
+}
+
+func init() { file_employee_proto_init() }
+func file_employee_proto_init() {
+	if File_employee_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_employee_proto_rawDesc), len(file_employee_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   16,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_employee_proto_goTypes,
+		DependencyIndexes: file_employee_proto_depIdxs,
+		MessageInfos:      file_employee_proto_msgTypes,
+	}.Build()
+	File_employee_proto = out.File
+	file_employee_proto_goTypes = nil
+	file_employee_proto_depIdxs = nil
+}
@@ -0,0 +1,33 @@
+// Package grpcapi serves httpapi.EmployeeService over gRPC, and calls it
+// back as one. Nothing in it is written by hand but NewServer and
+// Transport, employee-cli's grpc transport: the .proto and its Go come from
+// the interface, through solid gen and protoc, so the three can't drift
+// apart without TestGenerated_IsFresh noticing.
+//
+// It is a module of its own, so only a build that uses it downloads gRPC
+// and go-solid's own packages import nothing beyond the standard library:
+//
+//	cd grpcapi && go test ./...
+//
+// go generate needs protoc, protoc-gen-go and protoc-gen-go-grpc on the
+// PATH.
+package grpcapi
+
+//go:generate go run -C .. ./cmd/solid gen proto -iface httpapi.EmployeeService -pb go-solid/grpcapi/employeepb -o grpcapi/employeepb/employee.proto
+//go:generate protoc -I employeepb --go_out=employeepb --go_opt=paths=source_relative --go-grpc_out=employeepb --go-grpc_opt=paths=source_relative employee.proto
+//go:generate go run -C .. ./cmd/solid gen client -iface httpapi.EmployeeService -transport grpc -pb go-solid/grpcapi/employeepb -o grpcapi/employee.go
+
+import (
+	"google.golang.org/grpc"
+
+	"go-solid/grpcapi/employeepb"
+	"go-solid/httpapi"
+)
+
+// NewServer is a gRPC server of svc, e.g. an *employee.Manager, ready to
+// Serve a listener.
+func NewServer(svc httpapi.EmployeeService, opts ...grpc.ServerOption) *grpc.Server {
+	s := grpc.NewServer(opts...)
+	employeepb.RegisterEmployeeServiceServer(s, NewEmployeeServiceGRPCServer(svc))
+	return s
+}
@@ -0,0 +1,220 @@
+package grpcapi_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/bufbuild/protocompile"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+
+	"go-solid/clock"
+	"go-solid/employee"
+	"go-solid/employee/memory"
+	"go-solid/employeecli"
+	"go-solid/gen"
+	"go-solid/grpcapi"
+	"go-solid/grpcapi/employeepb"
+	"go-solid/httpapi"
+	"go-solid/id"
+	"go-solid/money"
+)
+
+// newManager is a Manager whose IDs and clock are the same on every run, so
+// answers can be compared across the wire.
+func newManager() *employee.Manager {
+	return employee.NewManager(memory.New(),
+		employee.WithClock(clock.NewFake(time.Date(2026, time.March, 2, 9, 0, 0, 0, time.UTC))),
+		employee.WithIDs(id.NewSequence("emp-")))
+}
+
+// connect serves svc over an in-memory listener and returns a connection
+// to it.
+func connect(t *testing.T, svc httpapi.EmployeeService) *grpc.ClientConn {
+	t.Helper()
+	lis := bufconn.Listen(1 << 20)
+	srv := grpcapi.NewServer(svc)
+	go func() { _ = srv.Serve(lis) }()
+	t.Cleanup(srv.Stop)
+	conn, err := grpc.NewClient("passthrough:///bufconn",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return conn
+}
+
+// script runs the same use cases against svc and writes down every answer.
+func script(ctx context.Context, svc httpapi.EmployeeService) []string {
+	var answers []string
+	show := func(what string, emp employee.Employee, err error) {
+		answers = append(answers, fmt.Sprintf("%s: %s %s %s %s %s %s %s v%d, %v", what,
+			emp.ID, emp.Name, emp.Title, emp.Department, emp.Email, emp.Salary, emp.HiredAt.Format(time.RFC3339), emp.Version, err))
+	}
+	emp, err := svc.AddEmployee(ctx, employee.Employee{Name: "Mona", Title: "Engineer", Department: "Platform", Email: "mona@example.com", Salary: money.Of(5000, money.USD)})
+	show("hire", emp, err)
+	for _, name := range []string{"Omar", "Nadia", "Karim"} {
+		_, _ = svc.AddEmployee(ctx, employee.Employee{Name: name, Title: "Analyst", Salary: money.Of(4000, money.USD)})
+	}
+	emp, err = svc.FindEmployee(ctx, "Mona")
+	show("find", emp, err)
+	emp, err = svc.ChangeSalary(ctx, "Mona", money.Of(5500, money.USD))
+	show("pay", emp, err)
+	emp, err = svc.Promote(ctx, "Mona", "Senior Engineer", money.Of(500, money.USD))
+	show("promote", emp, err)
+	page, err := svc.ListEmployees(ctx, employee.Filter{MinSalary: money.Of(4500, money.USD), Sort: employee.SortBySalary}, employee.Page{Limit: 10})
+	answers = append(answers, fmt.Sprintf("earning 4500 or more: %v, %v", names(page), err))
+	page, err = svc.ListEmployees(ctx, employee.Filter{Sort: employee.SortByName, Descending: true}, employee.Page{Limit: 2})
+	answers = append(answers, fmt.Sprintf("first page: %v, more: %t, %v", names(page), page.NextCursor != "", err))
+	page, err = svc.ListEmployees(ctx, employee.Filter{Sort: employee.SortByName, Descending: true}, employee.Page{Cursor: page.NextCursor, Limit: 2})
+	answers = append(answers, fmt.Sprintf("second page: %v, %v", names(page), err))
+	err = svc.RemoveEmployee(ctx, "Omar")
+	_, found := svc.FindEmployee(ctx, "Omar")
+	answers = append(answers, fmt.Sprintf("remove: %v, then not found: %v", err, errors.Is(found, employee.ErrNotFound)))
+	return answers
+}
+
+func names(page employee.PageResult) []string {
+	var names []string
+	for _, e := range page.Items {
+		names = append(names, e.Name)
+	}
+	return names
+}
+
+func TestGenerated_IsFresh(t *testing.T) {
+	svc, err := gen.FindService(t.Context(), "..", "httpapi.EmployeeService")
+	if err != nil {
+		t.Fatalf("FindService() error = %v", err)
+	}
+	def, err := gen.Proto(svc.Iface, "employee", "go-solid/grpcapi/employeepb")
+	if err != nil {
+		t.Fatalf("Proto() error = %v", err)
+	}
+	adapters, err := gen.GRPC(svc.Iface, "grpcapi", "go-solid/grpcapi/employeepb")
+	if err != nil {
+		t.Fatalf("GRPC() error = %v", err)
+	}
+	for file, want := range map[string][]byte{"employeepb/employee.proto": def, "employee.go": adapters} {
+		if got, _ := os.ReadFile(file); !bytes.Equal(got, want) {
+			t.Errorf("%s is not what solid gen writes from httpapi.EmployeeService; run go generate", file)
+		}
+	}
+
+	// protoc's output, checked against the .proto by compiling it again
+	compiler := protocompile.Compiler{Resolver: protocompile.WithStandardImports(&protocompile.SourceResolver{ImportPaths: []string{"employeepb"}})}
+	files, err := compiler.Compile(t.Context(), "employee.proto")
+	if err != nil {
+		t.Fatalf("compiling employee.proto: %v", err)
+	}
+	compiled := protodesc.ToFileDescriptorProto(files[0])
+	committed := protodesc.ToFileDescriptorProto(employeepb.File_employee_proto)
+	committed.SourceCodeInfo = nil
+	if !proto.Equal(compiled, committed) {
+		t.Error("employeepb's Go is not what protoc writes from employee.proto; run go generate")
+	}
+}
+
+func TestClient_AnswersAsInProcess(t *testing.T) {
+	want := script(t.Context(), newManager())
+	if got := script(t.Context(), grpcapi.NewEmployeeServiceGRPCClient(connect(t, newManager()))); !slices.Equal(got, want) {
+		t.Errorf("script() over gRPC = %q,\nwant %q", got, want)
+	}
+}
+
+func TestClient_KeepsErrors(t *testing.T) {
+	c := grpcapi.NewEmployeeServiceGRPCClient(connect(t, newManager()))
+	if _, err := c.AddEmployee(t.Context(), employee.Employee{Name: "Ali", Salary: money.Of(4000, money.USD)}); err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name string
+		call func() error
+		want error
+		code codes.Code
+	}{
+		{"nameless hire", func() error {
+			_, err := c.AddEmployee(t.Context(), employee.Employee{Salary: money.Of(1, money.USD)})
+			return err
+		}, employee.ErrInvalidName, codes.InvalidArgument},
+		{"hiring Ali twice", func() error {
+			_, err := c.AddEmployee(t.Context(), employee.Employee{Name: "Ali", Salary: money.Of(1, money.USD)})
+			return err
+		}, employee.ErrNameTaken, codes.AlreadyExists},
+		{"missing employee", func() error {
+			_, err := c.FindEmployee(t.Context(), "Nobody")
+			return err
+		}, employee.ErrNotFound, codes.NotFound},
+		{"removing a missing employee", func() error { return c.RemoveEmployee(t.Context(), "Nobody") }, employee.ErrNotFound, codes.NotFound},
+		{"a cursor of nothing", func() error {
+			_, err := c.ListEmployees(t.Context(), employee.Filter{}, employee.Page{Cursor: "garbage"})
+			return err
+		}, employee.ErrInvalidCursor, codes.InvalidArgument},
+	}
+	for _, tt := range tests {
+		err := tt.call()
+		var remote *grpcapi.RemoteError
+		if !errors.Is(err, tt.want) || !errors.As(err, &remote) || status.Code(err) != tt.code {
+			t.Errorf("%s: error = %v (%s), want %v as %s", tt.name, err, status.Code(err), tt.want, tt.code)
+		}
+	}
+}
+
+func TestServer_RefusesBadRequests(t *testing.T) {
+	// a client not written from the interface, sending what no Money marshals to
+	raw := employeepb.NewEmployeeServiceClient(connect(t, newManager()))
+	_, err := raw.ChangeSalary(t.Context(), &employeepb.ChangeSalaryRequest{Name: "Ali", Salary: "5000 dollars"})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("ChangeSalary() with a salary of %q error = %v, want InvalidArgument", "5000 dollars", err)
+	}
+}
+
+func TestTransport_RunsTheCLI(t *testing.T) {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	manager := newManager()
+	srv := grpcapi.NewServer(manager)
+	go func() { _ = srv.Serve(lis) }()
+	t.Cleanup(srv.Stop)
+
+	transports := map[string]employeecli.Transport{"grpc": grpcapi.Transport}
+	cli := func(args ...string) int {
+		return employeecli.Main(append([]string{"-transport", "grpc", "-addr", lis.Addr().String()}, args...), transports)
+	}
+	if code := cli("add", "-name", "Ali", "-salary", "4000"); code != 0 {
+		t.Fatalf("employee-cli add exited %d, want 0", code)
+	}
+	if _, err := manager.FindEmployee(t.Context(), "Ali"); err != nil {
+		t.Errorf("FindEmployee(Ali) after employee-cli add error = %v", err)
+	}
+	tests := []struct {
+		args []string
+		want int
+	}{
+		{[]string{"get", "Ali"}, 0},
+		{[]string{"list", "-prefix", "A"}, 0},
+		{[]string{"get", "Nobody"}, 1},
+		{[]string{"add", "-salary", "4000"}, 1}, // nameless
+	}
+	for _, tt := range tests {
+		if code := cli(tt.args...); code != tt.want {
+			t.Errorf("employee-cli %v exited %d, want %d", tt.args, code, tt.want)
+		}
+	}
+}
@@ -0,0 +1,151 @@
+// Package health aggregates per-dependency health probes into liveness and
+// readiness endpoints.
+//
+// Being checkable is an optional capability (ISP): a SQL repository can ping
+// its database, an in-memory one has nothing to check and simply doesn't
+// implement Checker. The aggregator discovers the capability with a type
+// assertion, and the HTTP handlers only depend on the aggregator (DIP).
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Checker Optional capability - a dependency that can report its own health
+type Checker interface {
+	CheckHealth(ctx context.Context) error
+}
+
+// CheckerFunc Adapter so plain functions can be used as checks
+type CheckerFunc func(ctx context.Context) error
+
+func (f CheckerFunc) CheckHealth(ctx context.Context) error { return f(ctx) }
+
+type Status string
+
+const (
+	Up   Status = "up"
+	Down Status = "down"
+)
+
+type Result struct {
+	Status   Status `json:"status"`
+	Error    string `json:"error,omitempty"`
+	Duration string `json:"duration"`
+}
+
+type Report struct {
+	Status Status            `json:"status"`
+	Checks map[string]Result `json:"checks"`
+}
+
+// Aggregator Runs every registered check concurrently, each with its own timeout
+type Aggregator struct {
+	// Timeout per check; defaults to 2s.
+	Timeout time.Duration
+
+	mu     sync.RWMutex
+	checks map[string]Checker
+}
+
+// Add registers a check under name.
+func (a *Aggregator) Add(name string, c Checker) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.checks == nil {
+		a.checks = make(map[string]Checker)
+	}
+	a.checks[name] = c
+}
+
+// AddIfSupported registers dep if it implements Checker and reports whether it did.
+func (a *Aggregator) AddIfSupported(name string, dep any) bool {
+	c, ok := dep.(Checker)
+	if ok {
+		a.Add(name, c)
+	}
+	return ok
+}
+
+// Names lists the registered checks.
+func (a *Aggregator) Names() []string {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	names := make([]string, 0, len(a.checks))
+	for name := range a.checks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Check runs all checks; the report is Down if any of them failed.
+func (a *Aggregator) Check(ctx context.Context) Report {
+	timeout := a.Timeout
+	if timeout <= 0 {
+		timeout = 2 * time.Second
+	}
+
+	a.mu.RLock()
+	checks := make(map[string]Checker, len(a.checks))
+	for name, c := range a.checks {
+		checks[name] = c
+	}
+	a.mu.RUnlock()
+
+	report := Report{Status: Up, Checks: make(map[string]Result, len(checks))}
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for name, c := range checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			checkCtx, cancel := context.WithTimeout(ctx, timeout)
+			defer cancel()
+
+			start := time.Now()
+			err := c.CheckHealth(checkCtx)
+			res := Result{Status: Up, Duration: time.Since(start).Round(time.Microsecond).String()}
+			if err != nil {
+				res.Status, res.Error = Down, err.Error()
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			report.Checks[name] = res
+			if err != nil {
+				report.Status = Down
+			}
+		}()
+	}
+	wg.Wait()
+	return report
+}
+
+// Liveness answers /healthz: the process is up and serving HTTP. It runs no
+// dependency checks - a database outage shouldn't get the process restarted.
+func Liveness() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		writeReport(w, Report{Status: Up, Checks: map[string]Result{}})
+	})
+}
+
+// Readiness answers /readyz: 200 when every dependency is healthy, 503 otherwise.
+func (a *Aggregator) Readiness() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		writeReport(w, a.Check(r.Context()))
+	})
+}
+
+func writeReport(w http.ResponseWriter, report Report) {
+	w.Header().Set("Content-Type", "application/json")
+	if report.Status != Up {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+	_ = json.NewEncoder(w).Encode(report)
+}
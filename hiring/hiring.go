@@ -0,0 +1,176 @@
+// Package hiring takes candidates through a recruitment pipeline.
+//
+// The pipeline is a chain of responsibility. Each Stage - screening, a
+// technical interview, an offer, onboarding - looks at an application and
+// decides: pass it on to the next stage, hold it until something happens,
+// or reject it, which ends the chain there. A stage knows nothing of the
+// others, so stages are added, removed or reordered where the Pipeline is
+// built, and nowhere else (OCP). The Pipeline keeps the trail of every
+// decision, so no stage has to (SRP).
+package hiring
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"go-solid/audit"
+	"go-solid/clock"
+	"go-solid/money"
+)
+
+// Candidate Someone applying for a role
+type Candidate struct {
+	Name     string
+	Email    string
+	Role     string
+	Years    int // of relevant experience
+	Skills   []string
+	Expected money.Money // monthly salary asked for
+}
+
+type Status string
+
+const (
+	Active   Status = "active" // going through the stages
+	Held     Status = "held"   // waiting at a stage, to be processed again
+	Rejected Status = "rejected"
+	Hired    Status = "hired" // passed every stage
+)
+
+type Verdict string
+
+const (
+	Advanced Verdict = "advanced"
+	Holding  Verdict = "held"
+	Declined Verdict = "rejected"
+)
+
+// Decision A stage's verdict on an application, and why
+type Decision struct {
+	Verdict Verdict
+	Reason  string
+}
+
+// Advance passes the application on to the next stage.
+func Advance(reason string) Decision { return Decision{Advanced, reason} }
+
+// Hold stops the chain until the application is processed again.
+func Hold(reason string) Decision { return Decision{Holding, reason} }
+
+// Reject ends the chain, and the application.
+func Reject(reason string) Decision { return Decision{Declined, reason} }
+
+// Entry One line of an application's trail
+type Entry struct {
+	At      time.Time
+	Stage   string
+	Verdict Verdict
+	Reason  string
+}
+
+// Application A candidate's progress through the pipeline
+type Application struct {
+	ID        string
+	Candidate Candidate
+	Status    Status
+	Passed    []string    // the stages passed, in order
+	Offer     money.Money // set by the Offer stage
+	Trail     []Entry
+}
+
+// Stage One link of the chain
+type Stage interface {
+	Name() string
+	// Handle decides on app. It may fill in what the stages after it need,
+	// like an offer. An error holds the application where it is.
+	Handle(ctx context.Context, app *Application) (Decision, error)
+}
+
+// ErrClosed returned when processing an application that was rejected or
+// hired
+var ErrClosed = errors.New("application closed")
+
+// Pipeline The chain of stages every application goes through, in order
+type Pipeline struct {
+	stages []Stage
+	clock  clock.Clock
+	audit  audit.Sink
+}
+
+// Option customises a Pipeline created by NewPipeline
+type Option func(*Pipeline)
+
+func WithClock(c clock.Clock) Option { return func(p *Pipeline) { p.clock = c } }
+
+// WithAudit writes every decision to s as well as to the application's
+// trail.
+func WithAudit(s audit.Sink) Option { return func(p *Pipeline) { p.audit = s } }
+
+func NewPipeline(stages []Stage, opts ...Option) *Pipeline {
+	p := &Pipeline{stages: slices.Clone(stages), clock: clock.Real{}}
+	for _, opt := range opts {
+		opt(p)
+	}
+	return p
+}
+
+// Process hands app along the chain, from the first stage it hasn't passed,
+// until a stage holds or rejects it or it has passed them all. Stages
+// after the one that stopped it are not asked. An application on hold is
+// processed again by calling Process again.
+func (p *Pipeline) Process(ctx context.Context, app *Application) error {
+	if app.Status == Rejected || app.Status == Hired {
+		return fmt.Errorf("application %s: %w (%s)", app.ID, ErrClosed, app.Status)
+	}
+	app.Status = Active
+	for _, stage := range p.stages {
+		if slices.Contains(app.Passed, stage.Name()) {
+			continue
+		}
+		d, err := stage.Handle(ctx, app)
+		if err != nil {
+			d = Hold(err.Error())
+		}
+		p.record(ctx, app, stage.Name(), d, err)
+		switch {
+		case err != nil:
+			app.Status = Held
+			return fmt.Errorf("application %s: %s: %w", app.ID, stage.Name(), err)
+		case d.Verdict == Holding:
+			app.Status = Held
+			return nil
+		case d.Verdict == Declined:
+			app.Status = Rejected
+			return nil
+		}
+		app.Passed = append(app.Passed, stage.Name())
+	}
+	app.Status = Hired
+	return nil
+}
+
+// record adds the decision to app's trail and to the audit sink. Audit
+// failures don't stop the pipeline: the trail has the decision.
+func (p *Pipeline) record(ctx context.Context, app *Application, stage string, d Decision, err error) {
+	e := Entry{At: p.clock.Now(), Stage: stage, Verdict: d.Verdict, Reason: d.Reason}
+	app.Trail = append(app.Trail, e)
+	if p.audit == nil {
+		return
+	}
+	rec := audit.Record{
+		Time:     e.At,
+		Actor:    audit.ActorFrom(ctx),
+		Action:   "hiring." + stage,
+		Entity:   "application",
+		EntityID: app.ID,
+		Details:  map[string]any{"candidate": app.Candidate.Name, "verdict": string(d.Verdict), "reason": d.Reason},
+		Outcome:  audit.Success,
+	}
+	if err != nil {
+		rec.Outcome, rec.Error = audit.Failure, err.Error()
+	}
+	_ = p.audit.Write(ctx, rec)
+}
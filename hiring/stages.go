@@ -0,0 +1,140 @@
+package hiring
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"go-solid/employee"
+	"go-solid/money"
+)
+
+var (
+	_ Stage = Screening{}
+	_ Stage = TechInterview{}
+	_ Stage = Offer{}
+	_ Stage = Onboard{}
+
+	_ Hirer = (*employee.Manager)(nil)
+)
+
+// Screening Stage rejecting candidates short of experience or skills, or
+// asking for more than the role's budget
+type Screening struct {
+	MinYears int
+	Skills   []string    // all required
+	Budget   money.Money // zero for no limit
+}
+
+func (Screening) Name() string { return "screening" }
+
+func (s Screening) Handle(_ context.Context, app *Application) (Decision, error) {
+	c := app.Candidate
+	if c.Years < s.MinYears {
+		return Reject(fmt.Sprintf("%d years of experience, %d required", c.Years, s.MinYears)), nil
+	}
+	var missing []string
+	for _, skill := range s.Skills {
+		if !slices.Contains(c.Skills, skill) {
+			missing = append(missing, skill)
+		}
+	}
+	if len(missing) > 0 {
+		return Reject("missing " + strings.Join(missing, ", ")), nil
+	}
+	if !s.Budget.IsZero() {
+		over, err := c.Expected.Cmp(s.Budget)
+		if err != nil {
+			return Decision{}, err
+		}
+		if over > 0 {
+			return Reject(fmt.Sprintf("expects %v, over the budget of %v", c.Expected, s.Budget)), nil
+		}
+	}
+	return Advance("meets the requirements"), nil
+}
+
+// Panel Where interview scores come from, out of 5
+type Panel interface {
+	Scores(ctx context.Context, candidate string) ([]int, error)
+}
+
+// Scores Panel kept in memory, by candidate
+type Scores map[string][]int
+
+func (s Scores) Scores(_ context.Context, candidate string) ([]int, error) {
+	return s[candidate], nil
+}
+
+// TechInterview Stage holding candidates until enough interviewers have
+// scored them, then passing those whose average reaches the pass mark
+type TechInterview struct {
+	Panel        Panel
+	Interviewers int     // scores needed; at least one
+	PassMark     float64 // out of 5
+}
+
+func (TechInterview) Name() string { return "tech-interview" }
+
+func (t TechInterview) Handle(ctx context.Context, app *Application) (Decision, error) {
+	scores, err := t.Panel.Scores(ctx, app.Candidate.Name)
+	if err != nil {
+		return Decision{}, err
+	}
+	if need := max(t.Interviewers, 1); len(scores) < need {
+		return Hold(fmt.Sprintf("%d of %d interviews scored", len(scores), need)), nil
+	}
+	sum := 0
+	for _, s := range scores {
+		sum += s
+	}
+	avg := float64(sum) / float64(len(scores))
+	if avg < t.PassMark {
+		return Reject(fmt.Sprintf("scored %.1f, %.1f to pass", avg, t.PassMark)), nil
+	}
+	return Advance(fmt.Sprintf("scored %.1f", avg)), nil
+}
+
+// Offer Stage setting the salary offered: what the candidate asked for, but
+// never below the floor
+type Offer struct {
+	Floor money.Money
+}
+
+func (Offer) Name() string { return "offer" }
+
+func (o Offer) Handle(_ context.Context, app *Application) (Decision, error) {
+	app.Offer = app.Candidate.Expected
+	if !o.Floor.IsZero() {
+		below, err := app.Offer.Cmp(o.Floor)
+		if err != nil {
+			return Decision{}, err
+		}
+		if below < 0 {
+			app.Offer = o.Floor
+		}
+	}
+	return Advance(fmt.Sprintf("offered %v", app.Offer)), nil
+}
+
+// Hirer The one thing onboarding needs from employee management (ISP)
+type Hirer interface {
+	AddEmployee(ctx context.Context, emp employee.Employee) (employee.Employee, error)
+}
+
+// Onboard Stage hiring the candidate, at the salary offered
+type Onboard struct {
+	Hirer Hirer
+}
+
+func (Onboard) Name() string { return "onboard" }
+
+func (o Onboard) Handle(ctx context.Context, app *Application) (Decision, error) {
+	c := app.Candidate
+	emp, err := o.Hirer.AddEmployee(ctx, employee.Employee{Name: c.Name, Title: c.Role, Email: c.Email, Salary: app.Offer})
+	if err != nil {
+		return Decision{}, err
+	}
+	return Advance("hired as " + emp.Title), nil
+}
@@ -0,0 +1,337 @@
+// Package httpapi exposes the employee use cases over HTTP/JSON.
+//
+// It is a delivery adapter: it translates HTTP into calls on EmployeeService
+// and errors back into status codes, and contains no business rules.
+//
+// Two versions of the API are served side by side, each an adapter of its
+// own over the same service: v1 (New) at the paths it has always had, and
+// v2 (NewV2) under /v2. v1 is frozen - clients written against it keep
+// working - and changes that would break them go into v2.
+package httpapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"go-solid/employee"
+	"go-solid/employee/actor"
+	"go-solid/money"
+)
+
+// EmployeeService What the HTTP layer needs from the domain. Defined here, where
+// it is consumed; *employee.Manager and *actor.Manager satisfy it.
+//
+// The //api:rest annotations say which v1 route serves each method, for the
+// clients `solid gen client` writes: the method and path, the wire types in
+// and out (see wire.go), and the status of success. New checks nothing
+// against them - examples/sdk does.
+type EmployeeService interface {
+	//api:rest POST /employees CreateRequest -> EmployeeDTO 201
+	AddEmployee(ctx context.Context, emp employee.Employee) (employee.Employee, error)
+	//api:rest GET /employees/{name} -> EmployeeDTO
+	FindEmployee(ctx context.Context, name string) (employee.Employee, error)
+	//api:rest GET /employees ListQuery -> ListResponse
+	ListEmployees(ctx context.Context, filter employee.Filter, page employee.Page) (employee.PageResult, error)
+	//api:rest PUT /employees/{name}/salary SalaryRequest -> EmployeeDTO
+	ChangeSalary(ctx context.Context, name string, salary money.Money) (employee.Employee, error)
+	//api:rest POST /employees/{name}/promotion PromotionRequest -> EmployeeDTO
+	Promote(ctx context.Context, name, title string, raise money.Money) (employee.Employee, error)
+	//api:rest DELETE /employees/{name} 204
+	RemoveEmployee(ctx context.Context, name string) error
+}
+
+// Handler HTTP adapter over an EmployeeService, for one version of the API
+type Handler struct {
+	svc        EmployeeService
+	mux        *http.ServeMux
+	middleware map[string][]Middleware
+	routes     []Route
+	version    string // of the API, as the OpenAPI document gives it
+	errors     errorFormat
+}
+
+// errorFormat How a version of the API writes errors, for the OpenAPI document
+type errorFormat struct {
+	body      any // zero value of the body type
+	mediaType string
+}
+
+// Middleware Decorator around one route (idempotency keys, auth...)
+type Middleware func(http.Handler) http.Handler
+
+// Option customises a Handler created by New
+type Option func(*Handler)
+
+// WithMiddleware wraps the route registered under pattern, e.g.
+// "POST /employees". The first middleware given is the outermost.
+func WithMiddleware(pattern string, mw ...Middleware) Option {
+	return func(h *Handler) { h.middleware[pattern] = append(h.middleware[pattern], mw...) }
+}
+
+// New serves v1 of the API.
+func New(svc EmployeeService, opts ...Option) *Handler {
+	h := newHandler(svc, "1.0.0", errorFormat{body: errorBody{}, mediaType: "application/json"}, opts)
+	h.route(Route{Pattern: "POST /employees", Summary: "Hire an employee",
+		Request: CreateRequest{}, Status: http.StatusCreated, Response: EmployeeDTO{}, Errors: []int{http.StatusBadRequest, http.StatusConflict, http.StatusUnprocessableEntity}}, h.create)
+	h.route(Route{Pattern: "GET /employees", Summary: "List employees, one page at a time", Query: listParams,
+		Response: ListResponse{}, Errors: []int{http.StatusBadRequest, http.StatusNotImplemented}}, h.list)
+	h.route(Route{Pattern: "GET /employees/{name}", Summary: "Find an employee by name",
+		Response: EmployeeDTO{}, Errors: []int{http.StatusNotFound}}, h.get)
+	h.route(Route{Pattern: "PUT /employees/{name}/salary", Summary: "Change an employee's salary",
+		Request: SalaryRequest{}, Response: EmployeeDTO{}, Errors: []int{http.StatusBadRequest, http.StatusNotFound, http.StatusConflict, http.StatusUnprocessableEntity}}, h.changeSalary)
+	h.route(Route{Pattern: "POST /employees/{name}/promotion", Summary: "Promote an employee with a raise",
+		Request: PromotionRequest{}, Response: EmployeeDTO{}, Errors: []int{http.StatusBadRequest, http.StatusNotFound, http.StatusConflict, http.StatusUnprocessableEntity}}, h.promote)
+	h.route(Route{Pattern: "DELETE /employees/{name}", Summary: "Remove an employee (soft delete)",
+		Status: http.StatusNoContent, Errors: []int{http.StatusNotFound, http.StatusNotImplemented}}, h.remove)
+	h.mux.Handle("GET /openapi.json", h.openAPIHandler())
+	h.mux.Handle("GET /docs", swaggerUI())
+	return h
+}
+
+func newHandler(svc EmployeeService, version string, errs errorFormat, opts []Option) *Handler {
+	h := &Handler{svc: svc, mux: http.NewServeMux(), middleware: make(map[string][]Middleware), version: version, errors: errs}
+	for _, opt := range opts {
+		opt(h)
+	}
+	return h
+}
+
+// route registers fn under r.Pattern, wrapped in the route's middleware, and
+// keeps r to describe it in the OpenAPI document.
+func (h *Handler) route(r Route, fn http.HandlerFunc) {
+	var handler http.Handler = fn
+	mws := h.middleware[r.Pattern]
+	for i := len(mws) - 1; i >= 0; i-- {
+		handler = mws[i](handler)
+	}
+	h.mux.Handle(r.Pattern, handler)
+	h.routes = append(h.routes, r)
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) { h.mux.ServeHTTP(w, r) }
+
+// Handle mounts an extra handler on the same mux (health checks, admin...).
+func (h *Handler) Handle(pattern string, handler http.Handler) { h.mux.Handle(pattern, handler) }
+
+// EmployeeDTO Wire format of an employee - decoupled from the domain struct.
+// Amounts are {"amount": "5000.00", "currency": "USD"}.
+type EmployeeDTO struct {
+	ID         string      `json:"id"`
+	Name       string      `json:"name"`
+	Title      string      `json:"title,omitempty"`
+	Department string      `json:"department,omitempty"`
+	Email      string      `json:"email,omitempty"`
+	Salary     money.Money `json:"salary"`
+	HiredAt    string      `json:"hired_at,omitempty"`
+}
+
+func toDTO(e employee.Employee) EmployeeDTO {
+	dto := EmployeeDTO{ID: string(e.ID), Name: e.Name, Title: e.Title, Department: e.Department, Email: e.Email, Salary: e.Salary}
+	if !e.HiredAt.IsZero() {
+		dto.HiredAt = e.HiredAt.UTC().Format("2006-01-02T15:04:05Z")
+	}
+	return dto
+}
+
+type CreateRequest struct {
+	Name       string      `json:"name"`
+	Title      string      `json:"title"`
+	Department string      `json:"department,omitempty"`
+	Email      string      `json:"email"`
+	Salary     money.Money `json:"salary"`
+}
+
+func (h *Handler) create(w http.ResponseWriter, r *http.Request) {
+	var req CreateRequest
+	if !decode(w, r, &req) {
+		return
+	}
+	emp, err := h.svc.AddEmployee(r.Context(), employee.Employee{Name: req.Name, Title: req.Title, Department: req.Department, Email: req.Email, Salary: req.Salary})
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	w.Header().Set("Location", "/employees/"+emp.Name)
+	writeJSON(w, http.StatusCreated, toDTO(emp))
+}
+
+func (h *Handler) get(w http.ResponseWriter, r *http.Request) {
+	emp, err := h.svc.FindEmployee(r.Context(), r.PathValue("name"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, toDTO(emp))
+}
+
+type ListResponse struct {
+	Items      []EmployeeDTO `json:"items"`
+	NextCursor string        `json:"next_cursor,omitempty"`
+}
+
+var listParams = []Param{
+	{Name: "prefix", Description: "only names starting with this"},
+	{Name: "min_salary", Description: "decimal amount in currency, e.g. 4000.00"},
+	{Name: "max_salary", Description: "decimal amount in currency"},
+	{Name: "currency", Description: "ISO 4217 code the salary bounds are in; required with them"},
+	{Name: "sort", Description: "name or salary", Enum: []string{"name", "salary"}},
+	{Name: "desc", Type: "boolean", Description: "sort descending"},
+	{Name: "limit", Type: "integer", Description: "page size, 20 by default and 500 at most"},
+	{Name: "cursor", Description: "next_cursor of the previous page"},
+}
+
+func (h *Handler) list(w http.ResponseWriter, r *http.Request) {
+	filter, page, err := listQuery(r.URL.Query())
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, errorBody{err.Error()})
+		return
+	}
+	result, err := h.svc.ListEmployees(r.Context(), filter, page)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	resp := ListResponse{Items: make([]EmployeeDTO, 0, len(result.Items)), NextCursor: result.NextCursor}
+	for _, e := range result.Items {
+		resp.Items = append(resp.Items, toDTO(e))
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// listQuery reads the query parameters in listParams. Salary bounds are
+// decimal amounts in currency, which they require.
+func listQuery(q url.Values) (employee.Filter, employee.Page, error) {
+	filter := employee.Filter{
+		NamePrefix: q.Get("prefix"),
+		Sort:       employee.SortField(q.Get("sort")),
+		Descending: q.Get("desc") == "true",
+	}
+	page := employee.Page{Cursor: q.Get("cursor")}
+	if v := q.Get("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return filter, page, errors.New("limit must be a number")
+		}
+		page.Limit = n
+	}
+	for _, p := range []struct {
+		key string
+		dst *money.Money
+	}{{"min_salary", &filter.MinSalary}, {"max_salary", &filter.MaxSalary}} {
+		if v := q.Get(p.key); v != "" {
+			m, err := money.Parse(v, money.Currency(q.Get("currency")))
+			if err != nil {
+				return filter, page, fmt.Errorf("%s: %w", p.key, err)
+			}
+			*p.dst = m
+		}
+	}
+	return filter, page, nil
+}
+
+type SalaryRequest struct {
+	Salary money.Money `json:"salary"`
+}
+
+func (h *Handler) changeSalary(w http.ResponseWriter, r *http.Request) {
+	var req SalaryRequest
+	if !decode(w, r, &req) {
+		return
+	}
+	emp, err := h.svc.ChangeSalary(r.Context(), r.PathValue("name"), req.Salary)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, toDTO(emp))
+}
+
+type PromotionRequest struct {
+	Title string      `json:"title"`
+	Raise money.Money `json:"raise"`
+}
+
+func (h *Handler) promote(w http.ResponseWriter, r *http.Request) {
+	var req PromotionRequest
+	if !decode(w, r, &req) {
+		return
+	}
+	emp, err := h.svc.Promote(r.Context(), r.PathValue("name"), req.Title, req.Raise)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, toDTO(emp))
+}
+
+func (h *Handler) remove(w http.ResponseWriter, r *http.Request) {
+	if err := h.svc.RemoveEmployee(r.Context(), r.PathValue("name")); err != nil {
+		writeError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+type errorBody struct {
+	Error string `json:"error"`
+}
+
+func decode(w http.ResponseWriter, r *http.Request, dst any) bool {
+	if err := readJSON(w, r, dst); err != nil {
+		writeJSON(w, http.StatusBadRequest, errorBody{err.Error()})
+		return false
+	}
+	return true
+}
+
+// readJSON decodes the request body into dst, refusing fields dst doesn't have.
+func readJSON(w http.ResponseWriter, r *http.Request, dst any) error {
+	dec := json.NewDecoder(http.MaxBytesReader(w, r.Body, 1<<20))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(dst); err != nil {
+		return fmt.Errorf("invalid JSON body: %w", err)
+	}
+	return nil
+}
+
+// writeError maps domain errors to HTTP status codes - the only place that knows both worlds.
+func writeError(w http.ResponseWriter, err error) {
+	writeJSON(w, statusOf(err), errorBody{err.Error()})
+}
+
+func statusOf(err error) int {
+	switch {
+	case errors.Is(err, employee.ErrNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, employee.ErrInvalidName), errors.Is(err, employee.ErrInvalidSalary),
+		errors.Is(err, employee.ErrInvalidPromotion), errors.Is(err, employee.ErrInvalidCursor):
+		return http.StatusBadRequest
+	case errors.Is(err, employee.ErrConflict):
+		// the Manager retried and lost every time; the client may try again
+		return http.StatusConflict
+	case errors.Is(err, employee.ErrNameTaken):
+		return http.StatusConflict
+	case errors.Is(err, employee.ErrVetoed):
+		// well-formed, but a save hook's rule refused it
+		return http.StatusUnprocessableEntity
+	case errors.Is(err, errors.ErrUnsupported):
+		return http.StatusNotImplemented
+	}
+	return http.StatusInternalServerError
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+var (
+	_ EmployeeService = (*employee.Manager)(nil)
+	_ EmployeeService = (*actor.Manager)(nil)
+)
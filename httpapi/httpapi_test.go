@@ -0,0 +1,130 @@
+package httpapi_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"go-solid/employee"
+	"go-solid/employee/memory"
+	"go-solid/httpapi"
+	"go-solid/money"
+)
+
+// failing An EmployeeService whose every call fails with err
+type failing struct{ err error }
+
+func (f failing) AddEmployee(ctx context.Context, emp employee.Employee) (employee.Employee, error) {
+	return employee.Employee{}, f.err
+}
+func (f failing) FindEmployee(ctx context.Context, name string) (employee.Employee, error) {
+	return employee.Employee{}, f.err
+}
+func (f failing) FindEmployeeByID(ctx context.Context, id employee.ID) (employee.Employee, error) {
+	return employee.Employee{}, f.err
+}
+func (f failing) ListEmployees(ctx context.Context, filter employee.Filter, page employee.Page) (employee.PageResult, error) {
+	return employee.PageResult{}, f.err
+}
+func (f failing) ChangeSalary(ctx context.Context, name string, salary money.Money) (employee.Employee, error) {
+	return employee.Employee{}, f.err
+}
+func (f failing) Promote(ctx context.Context, name, title string, raise money.Money) (employee.Employee, error) {
+	return employee.Employee{}, f.err
+}
+func (f failing) RemoveEmployee(ctx context.Context, name string) error { return f.err }
+
+func TestHandler_ErrorStatus(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{name: "not found", err: employee.ErrNotFound, want: http.StatusNotFound},
+		{name: "invalid salary", err: employee.ErrInvalidSalary, want: http.StatusBadRequest},
+		{name: "unsupported", err: errors.ErrUnsupported, want: http.StatusNotImplemented},
+		{name: "conflict after retries", err: fmt.Errorf("change salary of %q: %w 3 times", "Mona", employee.ErrConflict), want: http.StatusConflict},
+		{name: "name taken", err: fmt.Errorf("add employee %q: %w", "Mona", employee.ErrNameTaken), want: http.StatusConflict},
+		{name: "vetoed by a hook", err: fmt.Errorf("%w: %w", employee.ErrVetoed, errors.New("salary above the band")), want: http.StatusUnprocessableEntity},
+		{name: "anything else", err: errors.New("disk on fire"), want: http.StatusInternalServerError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, v := range []struct {
+				api  http.Handler
+				path string
+			}{{httpapi.New(failing{tt.err}), "/employees/Mona/salary"}, {httpapi.NewV2(failing{tt.err}), "/v2/employees/emp-1/salary"}} {
+				rec := httptest.NewRecorder()
+				req := httptest.NewRequest("PUT", v.path, strings.NewReader(`{"salary":{"amount":"5500.00","currency":"USD"}}`))
+				v.api.ServeHTTP(rec, req)
+				if rec.Code != tt.want {
+					t.Errorf("PUT %s status = %d, want %d (%s)", v.path, rec.Code, tt.want, rec.Body)
+				}
+			}
+		})
+	}
+}
+
+func TestError_Unwrap(t *testing.T) {
+	for _, want := range []error{employee.ErrNotFound, employee.ErrConflict, employee.ErrNameTaken, employee.ErrVetoed, errors.ErrUnsupported} {
+		rec := httptest.NewRecorder()
+		httpapi.New(failing{want}).ServeHTTP(rec, httptest.NewRequest("POST", "/employees/Mona/promotion",
+			strings.NewReader(`{"title":"Lead","raise":{"amount":"500.00","currency":"USD"}}`)))
+		if err := httpapi.ReadError(rec.Result()); !errors.Is(err, want) {
+			t.Errorf("ReadError() = %v, want it to match %v", err, want)
+		}
+	}
+}
+
+func TestHandler_HireUnderATakenName(t *testing.T) {
+	api := httpapi.New(employee.NewManager(memory.New()))
+	hire := `{"name":"Mona","title":"Engineer","salary":{"amount":"5000.00","currency":"USD"}}`
+	for _, want := range []int{http.StatusCreated, http.StatusConflict} {
+		rec := httptest.NewRecorder()
+		api.ServeHTTP(rec, httptest.NewRequest("POST", "/employees", strings.NewReader(hire)))
+		if rec.Code != want {
+			t.Fatalf("POST /employees status = %d, want %d (%s)", rec.Code, want, rec.Body)
+		}
+	}
+}
+
+func TestOpenAPI_DeclaresErrors(t *testing.T) {
+	tests := []struct {
+		api          http.Handler
+		doc          string
+		path, op     string
+		wantStatuses []int
+	}{
+		{httpapi.New(failing{}), "/openapi.json", "/employees", "post", []int{400, 409, 422}},
+		{httpapi.New(failing{}), "/openapi.json", "/employees/{name}/salary", "put", []int{400, 404, 409, 422}},
+		{httpapi.New(failing{}), "/openapi.json", "/employees/{name}/promotion", "post", []int{400, 404, 409, 422}},
+		{httpapi.NewV2(failing{}), "/v2/openapi.json", "/v2/employees", "post", []int{400, 409, 422}},
+		{httpapi.NewV2(failing{}), "/v2/openapi.json", "/v2/employees/{id}/salary", "put", []int{400, 404, 409, 422}},
+		{httpapi.NewV2(failing{}), "/v2/openapi.json", "/v2/employees/{id}/promotion", "post", []int{400, 404, 409, 422}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.op+" "+tt.path, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			tt.api.ServeHTTP(rec, httptest.NewRequest("GET", tt.doc, nil))
+			var doc httpapi.Document
+			if err := json.Unmarshal(rec.Body.Bytes(), &doc); err != nil {
+				t.Fatalf("GET %s: %v", tt.doc, err)
+			}
+			op, ok := doc.Paths[tt.path][tt.op]
+			if !ok {
+				t.Fatalf("%s has no %s %s", tt.doc, tt.op, tt.path)
+			}
+			for _, status := range tt.wantStatuses {
+				if _, ok := op.Responses[strconv.Itoa(status)]; !ok {
+					t.Errorf("%s %s doesn't declare %d", tt.op, tt.path, status)
+				}
+			}
+		})
+	}
+}
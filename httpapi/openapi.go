@@ -0,0 +1,270 @@
+package httpapi
+
+import (
+	_ "embed"
+	"encoding/json"
+	"go/token"
+	"net/http"
+	"reflect"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"go-solid/money"
+)
+
+// Route Metadata of one endpoint. Handlers are registered with theirs, so the
+// OpenAPI document is built from the same list that builds the mux and can't
+// drift from it.
+type Route struct {
+	Pattern string // "METHOD /path/{param}", as for http.ServeMux
+	Summary string
+	Query   []Param
+	// Request and Response are zero values of the body types; nil means no body
+	Request  any
+	Response any
+	// Status on success; 200 by default
+	Status int
+	// Errors lists the statuses writeError can answer with, each with an errorBody
+	Errors []int
+}
+
+// Param A query parameter
+type Param struct {
+	Name        string
+	Type        string // "string" by default; "integer", "boolean"
+	Description string
+	Enum        []string
+}
+
+// Document The parts of an OpenAPI 3.1 document the API uses
+type Document struct {
+	OpenAPI    string                          `json:"openapi"`
+	Info       Info                            `json:"info"`
+	Paths      map[string]map[string]Operation `json:"paths"`
+	Components Components                      `json:"components"`
+}
+
+type Info struct {
+	Title   string `json:"title"`
+	Version string `json:"version"`
+}
+
+type Operation struct {
+	Summary     string              `json:"summary,omitempty"`
+	OperationID string              `json:"operationId"`
+	Parameters  []Parameter         `json:"parameters,omitempty"`
+	RequestBody *Body               `json:"requestBody,omitempty"`
+	Responses   map[string]Response `json:"responses"`
+}
+
+type Parameter struct {
+	Name        string  `json:"name"`
+	In          string  `json:"in"`
+	Required    bool    `json:"required,omitempty"`
+	Description string  `json:"description,omitempty"`
+	Schema      *Schema `json:"schema"`
+}
+
+type Body struct {
+	Required bool                 `json:"required,omitempty"`
+	Content  map[string]MediaType `json:"content"`
+}
+
+type Response struct {
+	Description string               `json:"description"`
+	Content     map[string]MediaType `json:"content,omitempty"`
+}
+
+type MediaType struct {
+	Schema *Schema `json:"schema"`
+}
+
+type Components struct {
+	Schemas map[string]*Schema `json:"schemas"`
+}
+
+// Schema A JSON Schema, as far as the API's types need one
+type Schema struct {
+	Ref         string             `json:"$ref,omitempty"`
+	Type        string             `json:"type,omitempty"`
+	Format      string             `json:"format,omitempty"`
+	Description string             `json:"description,omitempty"`
+	Enum        []string           `json:"enum,omitempty"`
+	Properties  map[string]*Schema `json:"properties,omitempty"`
+	Required    []string           `json:"required,omitempty"`
+	Items       *Schema            `json:"items,omitempty"`
+	Example     any                `json:"example,omitempty"`
+}
+
+// OpenAPI describes every route registered by New. Handlers mounted later
+// with Handle (health checks, admin) carry no metadata and are left out.
+func (h *Handler) OpenAPI() Document {
+	doc := Document{
+		OpenAPI:    "3.1.0",
+		Info:       Info{Title: "Employee API", Version: h.version},
+		Paths:      map[string]map[string]Operation{},
+		Components: Components{Schemas: map[string]*Schema{}},
+	}
+	doc.Components.Schemas["Error"] = doc.schema(reflect.TypeOf(h.errors.body))
+	for _, r := range h.routes {
+		method, path, _ := strings.Cut(r.Pattern, " ")
+		op := Operation{Summary: r.Summary, OperationID: operationID(method, path), Responses: map[string]Response{}}
+		for _, m := range pathParam.FindAllStringSubmatch(path, -1) {
+			op.Parameters = append(op.Parameters, Parameter{Name: m[1], In: "path", Required: true, Schema: &Schema{Type: "string"}})
+		}
+		for _, q := range r.Query {
+			s := &Schema{Type: q.Type, Enum: q.Enum}
+			if s.Type == "" {
+				s.Type = "string"
+			}
+			op.Parameters = append(op.Parameters, Parameter{Name: q.Name, In: "query", Description: q.Description, Schema: s})
+		}
+		if r.Request != nil {
+			op.RequestBody = &Body{Required: true, Content: jsonContent(doc.schema(reflect.TypeOf(r.Request)))}
+		}
+		status := r.Status
+		if status == 0 {
+			status = http.StatusOK
+		}
+		ok := Response{Description: http.StatusText(status)}
+		if r.Response != nil {
+			ok.Content = jsonContent(doc.schema(reflect.TypeOf(r.Response)))
+		}
+		op.Responses[strconv.Itoa(status)] = ok
+		for _, code := range r.Errors {
+			op.Responses[strconv.Itoa(code)] = Response{Description: http.StatusText(code),
+				Content: map[string]MediaType{h.errors.mediaType: {Schema: &Schema{Ref: "#/components/schemas/Error"}}}}
+		}
+		if doc.Paths[path] == nil {
+			doc.Paths[path] = map[string]Operation{}
+		}
+		doc.Paths[path][strings.ToLower(method)] = op
+	}
+	return doc
+}
+
+var pathParam = regexp.MustCompile(`\{(\w+)\}`)
+
+// operationID names an operation after its method and the fixed parts of its
+// path: POST /employees/{name}/promotion is postEmployeesPromotion.
+func operationID(method, path string) string {
+	id := strings.ToLower(method)
+	for part := range strings.SplitSeq(path, "/") {
+		if part != "" && !strings.HasPrefix(part, "{") {
+			id += strings.ToUpper(part[:1]) + part[1:]
+		}
+	}
+	return id
+}
+
+func jsonContent(s *Schema) map[string]MediaType {
+	return map[string]MediaType{"application/json": {Schema: s}}
+}
+
+// schema describes t. Exported struct types become components, referenced by
+// name; unexported ones are inlined. Fields are named, and made optional, by
+// their json tags.
+func (doc *Document) schema(t reflect.Type) *Schema {
+	switch t {
+	case reflect.TypeFor[money.Money]():
+		if _, ok := doc.Components.Schemas["Money"]; !ok {
+			doc.Components.Schemas["Money"] = &Schema{
+				Type:        "object",
+				Description: "An amount in a currency, exact to the minor unit",
+				Properties: map[string]*Schema{
+					"amount":   {Type: "string", Description: "decimal amount", Example: "5000.00"},
+					"currency": {Type: "string", Description: "ISO 4217 code", Example: "USD"},
+				},
+				Required: []string{"amount", "currency"},
+			}
+		}
+		return &Schema{Ref: "#/components/schemas/Money"}
+	case reflect.TypeFor[time.Time]():
+		return &Schema{Type: "string", Format: "date-time"}
+	}
+	switch t.Kind() {
+	case reflect.Pointer:
+		return doc.schema(t.Elem())
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &Schema{Type: "integer"}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: "number"}
+	case reflect.Slice, reflect.Array:
+		return &Schema{Type: "array", Items: doc.schema(t.Elem())}
+	case reflect.Map:
+		return &Schema{Type: "object"}
+	case reflect.Struct:
+	default:
+		return &Schema{}
+	}
+
+	name := t.Name()
+	component := token.IsExported(name)
+	if _, ok := doc.Components.Schemas[name]; ok && component {
+		return &Schema{Ref: "#/components/schemas/" + name}
+	}
+	s := &Schema{Type: "object", Properties: map[string]*Schema{}}
+	if component {
+		doc.Components.Schemas[name] = s // before the fields, in case one refers back
+	}
+	for f := range fields(t) {
+		tag := f.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		field, opts, _ := strings.Cut(tag, ",")
+		if field == "" {
+			field = f.Name
+		}
+		s.Properties[field] = doc.schema(f.Type)
+		if o := strings.Split(opts, ","); !slices.Contains(o, "omitempty") && !slices.Contains(o, "omitzero") {
+			s.Required = append(s.Required, field)
+		}
+	}
+	if component {
+		return &Schema{Ref: "#/components/schemas/" + name}
+	}
+	return s
+}
+
+// fields yields the exported fields of struct type t.
+func fields(t reflect.Type) func(yield func(reflect.StructField) bool) {
+	return func(yield func(reflect.StructField) bool) {
+		for i := range t.NumField() {
+			if f := t.Field(i); f.IsExported() && !yield(f) {
+				return
+			}
+		}
+	}
+}
+
+func (h *Handler) openAPIHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		_ = enc.Encode(h.OpenAPI())
+	})
+}
+
+// swaggerHTML is a page loading Swagger UI, pointed at /openapi.json. The
+// page is embedded; Swagger UI's scripts and styles come from a CDN, so
+// /docs needs a browser with internet access while the API itself doesn't.
+//
+//go:embed swagger.html
+var swaggerHTML []byte
+
+func swaggerUI() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		_, _ = w.Write(swaggerHTML)
+	})
+}
@@ -0,0 +1,71 @@
+package httpapi_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"go-solid/employee"
+	"go-solid/employee/actor"
+	"go-solid/employee/memory"
+	"go-solid/httpapi"
+	"go-solid/money"
+	"go-solid/pact"
+)
+
+// contracts Where the API's consumers publish what they rely on
+var contracts = pact.NewDir("testdata/pacts")
+
+// seeded is a Manager in the provider state named.
+func seeded(ctx context.Context, state string) (*employee.Manager, error) {
+	m := employee.NewManager(memory.New())
+	hire := func(name, title, dept string) error {
+		_, err := m.AddEmployee(ctx, employee.Employee{Name: name, Title: title, Department: dept,
+			Email: strings.ToLower(name) + "@example.com", Salary: money.Of(5000, money.USD)})
+		return err
+	}
+	switch state {
+	case "", "no employees":
+		return m, nil
+	case "Mona is employed":
+		return m, hire("Mona", "Engineer", "Engineering")
+	case "Mona and Omar are employed":
+		return m, errors.Join(hire("Mona", "Engineer", "Engineering"), hire("Omar", "Accountant", "Finance"))
+	}
+	return nil, fmt.Errorf("unknown provider state")
+}
+
+// TestPact_Provider replays every contract a consumer published for
+// employee-api against the handlers, so a change that breaks a consumer
+// fails here, naming who it breaks.
+func TestPact_Provider(t *testing.T) {
+	if cs, err := contracts.Contracts(t.Context(), "employee-api"); err != nil || len(cs) == 0 {
+		t.Fatalf("Contracts() = %d, %v; want the consumers' contracts", len(cs), err)
+	}
+	tests := []struct {
+		name    string
+		handler func(m *employee.Manager) http.Handler
+	}{
+		{"v1", func(m *employee.Manager) http.Handler { return httpapi.New(m) }},
+		{"v1 over actor.Manager", func(m *employee.Manager) http.Handler { return httpapi.New(actor.New(m)) }},
+		{"v1 with v2 mounted", func(m *employee.Manager) http.Handler {
+			h := httpapi.New(m)
+			h.Handle("/v2/", httpapi.NewV2(m))
+			return h
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pact.VerifyT(t, contracts, "employee-api", func(ctx context.Context, state string) (http.Handler, error) {
+				m, err := seeded(ctx, state)
+				if err != nil {
+					return nil, err
+				}
+				return tt.handler(m), nil
+			})
+		})
+	}
+}
@@ -0,0 +1,109 @@
+package httpapi_test
+
+import (
+	"encoding/json"
+	"maps"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"go-solid/clock"
+	"go-solid/employee"
+	"go-solid/employee/actor"
+	"go-solid/employee/memory"
+	"go-solid/httpapi"
+	"go-solid/id"
+)
+
+// exchange One request of the contract and the response v1 is pinned to
+type exchange struct {
+	method, path, body string
+	status             int
+	want               string
+}
+
+// v1Contract What v1 answered when v2 was written. Changing any of it
+// breaks a v1 client: such changes go into v2.
+var v1Contract = []exchange{
+	{"POST", "/employees", `{"name":"Mona","title":"Engineer","email":"mona@example.com","salary":{"amount":"5000.00","currency":"USD"}}`,
+		201, `{"id":"emp-1","name":"Mona","title":"Engineer","email":"mona@example.com","salary":{"amount":"5000.00","currency":"USD"},"hired_at":"2026-03-02T09:00:00Z"}`},
+	{"POST", "/employees", `{"name":"","salary":{"amount":"5000.00","currency":"USD"}}`,
+		400, `{"error":"add employee \"\": employee name must not be empty"}`},
+	{"POST", "/employees", `{"name":"Mona","nickname":"Mo"}`,
+		400, `{"error":"invalid JSON body: json: unknown field \"nickname\""}`},
+	{"GET", "/employees/Mona", "",
+		200, `{"id":"emp-1","name":"Mona","title":"Engineer","email":"mona@example.com","salary":{"amount":"5000.00","currency":"USD"},"hired_at":"2026-03-02T09:00:00Z"}`},
+	{"GET", "/employees/Nobody", "",
+		404, `{"error":"find employee \"Nobody\": employee not found"}`},
+	{"PUT", "/employees/Mona/salary", `{"salary":{"amount":"5500.00","currency":"USD"}}`,
+		200, `{"id":"emp-1","name":"Mona","title":"Engineer","email":"mona@example.com","salary":{"amount":"5500.00","currency":"USD"},"hired_at":"2026-03-02T09:00:00Z"}`},
+	{"POST", "/employees/Mona/promotion", `{"title":"Senior Engineer","raise":{"amount":"500.00","currency":"USD"}}`,
+		200, `{"id":"emp-1","name":"Mona","title":"Senior Engineer","email":"mona@example.com","salary":{"amount":"6000.00","currency":"USD"},"hired_at":"2026-03-02T09:00:00Z"}`},
+	{"GET", "/employees?limit=x", "",
+		400, `{"error":"limit must be a number"}`},
+	{"GET", "/employees?limit=1", "",
+		200, `{"items":[{"id":"emp-1","name":"Mona","title":"Senior Engineer","email":"mona@example.com","salary":{"amount":"6000.00","currency":"USD"},"hired_at":"2026-03-02T09:00:00Z"}]}`},
+	{"DELETE", "/employees/Mona", "", 204, ""},
+	{"GET", "/employees/Mona", "",
+		404, `{"error":"find employee \"Mona\": employee not found"}`},
+}
+
+// v1Paths The operations v1's OpenAPI document listed when v2 was written
+var v1Paths = []string{"/employees", "/employees/{name}", "/employees/{name}/promotion", "/employees/{name}/salary"}
+
+// newManager is a Manager whose IDs and clock are the same on every run, so
+// responses can be compared byte for byte.
+func newManager() *employee.Manager {
+	return employee.NewManager(memory.New(),
+		employee.WithClock(clock.NewFake(time.Date(2026, time.March, 2, 9, 0, 0, 0, time.UTC))),
+		employee.WithIDs(id.NewSequence("emp-")))
+}
+
+// TestV1_Contract runs the pinned exchanges against v1 alone, with v2
+// mounted beside it, and over another service (LSP), so nothing added since
+// can have broken a v1 client.
+func TestV1_Contract(t *testing.T) {
+	tests := []struct {
+		name string
+		svc  httpapi.EmployeeServiceV2
+		v2   bool
+	}{
+		{"v1 alone", newManager(), false},
+		{"v1 with v2 mounted", newManager(), true},
+		{"v1 over actor.Manager with v2 mounted", actor.New(newManager()), true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			api := httpapi.New(tt.svc)
+			if tt.v2 {
+				api.Handle("/v2/", httpapi.NewV2(tt.svc))
+			}
+			for _, x := range v1Contract {
+				rec := httptest.NewRecorder()
+				api.ServeHTTP(rec, httptest.NewRequest(x.method, x.path, strings.NewReader(x.body)))
+				wantType := "application/json"
+				if x.status == http.StatusNoContent {
+					wantType = ""
+				}
+				got := strings.TrimSpace(rec.Body.String())
+				if rec.Code != x.status || rec.Header().Get("Content-Type") != wantType || got != x.want {
+					t.Errorf("%s %s = %d %s %s\nwant %d %s %s", x.method, x.path,
+						rec.Code, rec.Header().Get("Content-Type"), got, x.status, wantType, x.want)
+				}
+			}
+
+			rec := httptest.NewRecorder()
+			api.ServeHTTP(rec, httptest.NewRequest("GET", "/openapi.json", nil))
+			var doc httpapi.Document
+			if err := json.Unmarshal(rec.Body.Bytes(), &doc); err != nil {
+				t.Fatalf("GET /openapi.json: %v", err)
+			}
+			if paths := slices.Sorted(maps.Keys(doc.Paths)); doc.Info.Version != "1.0.0" || !slices.Equal(paths, v1Paths) {
+				t.Errorf("v1's OpenAPI document = version %s with %v, want 1.0.0 with %v", doc.Info.Version, paths, v1Paths)
+			}
+		})
+	}
+}
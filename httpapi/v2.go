@@ -0,0 +1,202 @@
+package httpapi
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"go-solid/employee"
+	"go-solid/employee/actor"
+	"go-solid/money"
+)
+
+// EmployeeServiceV2 What v2 needs from the domain: v1's use cases, and
+// finding an employee by ID. *employee.Manager and *actor.Manager satisfy it.
+type EmployeeServiceV2 interface {
+	EmployeeService
+	FindEmployeeByID(ctx context.Context, id employee.ID) (employee.Employee, error)
+}
+
+// EmployeeV2 Wire format of an employee in v2. Unlike v1's EmployeeDTO every
+// field is always there, and the hire time is given in full.
+type EmployeeV2 struct {
+	ID         string      `json:"id"`
+	Name       string      `json:"name"`
+	Title      string      `json:"title"`
+	Department string      `json:"department"`
+	Email      string      `json:"email"`
+	Salary     money.Money `json:"salary"`
+	HiredAt    time.Time   `json:"hired_at"`
+}
+
+func toV2(e employee.Employee) EmployeeV2 {
+	return EmployeeV2{ID: string(e.ID), Name: e.Name, Title: e.Title, Department: e.Department, Email: e.Email, Salary: e.Salary, HiredAt: e.HiredAt.UTC()}
+}
+
+// CreateRequestV2 Hires an employee; v2 requires the department
+type CreateRequestV2 struct {
+	Name       string      `json:"name"`
+	Title      string      `json:"title"`
+	Department string      `json:"department"`
+	Email      string      `json:"email,omitempty"`
+	Salary     money.Money `json:"salary"`
+}
+
+type ListResponseV2 struct {
+	Items      []EmployeeV2 `json:"items"`
+	NextCursor string       `json:"next_cursor,omitempty"`
+}
+
+// Problem RFC 9457 problem details, the error body of v2
+type Problem struct {
+	Type   string `json:"type"`
+	Title  string `json:"title"`
+	Status int    `json:"status"`
+	Detail string `json:"detail,omitempty"`
+}
+
+// v2 Handlers of v2, over the same service as v1. What changed from v1:
+//
+//   - Employees are addressed by ID, which a rename doesn't change, rather
+//     than by name: /v2/employees/{id}.
+//   - Hiring requires a department.
+//   - Employees are EmployeeV2, and errors are Problem details.
+type v2 struct{ svc EmployeeServiceV2 }
+
+// NewV2 serves v2 of the API under /v2. Mount it next to v1:
+//
+//	api := httpapi.New(svc)
+//	api.Handle("/v2/", httpapi.NewV2(svc))
+func NewV2(svc EmployeeServiceV2, opts ...Option) *Handler {
+	h := newHandler(svc, "2.0.0", errorFormat{body: Problem{}, mediaType: "application/problem+json"}, opts)
+	v := v2{svc: svc}
+	h.route(Route{Pattern: "POST /v2/employees", Summary: "Hire an employee into a department",
+		Request: CreateRequestV2{}, Status: http.StatusCreated, Response: EmployeeV2{}, Errors: []int{http.StatusBadRequest, http.StatusConflict, http.StatusUnprocessableEntity}}, v.create)
+	h.route(Route{Pattern: "GET /v2/employees", Summary: "List employees, one page at a time", Query: listParams,
+		Response: ListResponseV2{}, Errors: []int{http.StatusBadRequest, http.StatusNotImplemented}}, v.list)
+	h.route(Route{Pattern: "GET /v2/employees/{id}", Summary: "Find an employee by ID",
+		Response: EmployeeV2{}, Errors: []int{http.StatusNotFound}}, v.get)
+	h.route(Route{Pattern: "PUT /v2/employees/{id}/salary", Summary: "Change an employee's salary",
+		Request: SalaryRequest{}, Response: EmployeeV2{}, Errors: []int{http.StatusBadRequest, http.StatusNotFound, http.StatusConflict, http.StatusUnprocessableEntity}}, v.changeSalary)
+	h.route(Route{Pattern: "POST /v2/employees/{id}/promotion", Summary: "Promote an employee with a raise",
+		Request: PromotionRequest{}, Response: EmployeeV2{}, Errors: []int{http.StatusBadRequest, http.StatusNotFound, http.StatusConflict, http.StatusUnprocessableEntity}}, v.promote)
+	h.route(Route{Pattern: "DELETE /v2/employees/{id}", Summary: "Remove an employee (soft delete)",
+		Status: http.StatusNoContent, Errors: []int{http.StatusNotFound, http.StatusNotImplemented}}, v.remove)
+	h.mux.Handle("GET /v2/openapi.json", h.openAPIHandler())
+	return h
+}
+
+func (v v2) create(w http.ResponseWriter, r *http.Request) {
+	var req CreateRequestV2
+	if err := readJSON(w, r, &req); err != nil {
+		writeProblem(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if req.Department == "" {
+		writeProblem(w, http.StatusBadRequest, "department is required")
+		return
+	}
+	emp, err := v.svc.AddEmployee(r.Context(), employee.Employee{Name: req.Name, Title: req.Title, Department: req.Department, Email: req.Email, Salary: req.Salary})
+	if err != nil {
+		writeProblem(w, statusOf(err), err.Error())
+		return
+	}
+	w.Header().Set("Location", "/v2/employees/"+string(emp.ID))
+	writeJSON(w, http.StatusCreated, toV2(emp))
+}
+
+func (v v2) list(w http.ResponseWriter, r *http.Request) {
+	filter, page, err := listQuery(r.URL.Query())
+	if err != nil {
+		writeProblem(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	result, err := v.svc.ListEmployees(r.Context(), filter, page)
+	if err != nil {
+		writeProblem(w, statusOf(err), err.Error())
+		return
+	}
+	resp := ListResponseV2{Items: make([]EmployeeV2, 0, len(result.Items)), NextCursor: result.NextCursor}
+	for _, e := range result.Items {
+		resp.Items = append(resp.Items, toV2(e))
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func (v v2) get(w http.ResponseWriter, r *http.Request) {
+	if emp, ok := v.find(w, r); ok {
+		writeJSON(w, http.StatusOK, toV2(emp))
+	}
+}
+
+// find looks up the employee the path names, writing the problem if there is none.
+func (v v2) find(w http.ResponseWriter, r *http.Request) (employee.Employee, bool) {
+	emp, err := v.svc.FindEmployeeByID(r.Context(), employee.ID(r.PathValue("id")))
+	if err != nil {
+		writeProblem(w, statusOf(err), err.Error())
+		return employee.Employee{}, false
+	}
+	return emp, true
+}
+
+// The use cases below take a name: the ID is resolved to the employee's
+// current name first.
+
+func (v v2) changeSalary(w http.ResponseWriter, r *http.Request) {
+	var req SalaryRequest
+	if err := readJSON(w, r, &req); err != nil {
+		writeProblem(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	v.update(w, r, func(ctx context.Context, name string) (employee.Employee, error) {
+		return v.svc.ChangeSalary(ctx, name, req.Salary)
+	})
+}
+
+func (v v2) promote(w http.ResponseWriter, r *http.Request) {
+	var req PromotionRequest
+	if err := readJSON(w, r, &req); err != nil {
+		writeProblem(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	v.update(w, r, func(ctx context.Context, name string) (employee.Employee, error) {
+		return v.svc.Promote(ctx, name, req.Title, req.Raise)
+	})
+}
+
+func (v v2) update(w http.ResponseWriter, r *http.Request, change func(ctx context.Context, name string) (employee.Employee, error)) {
+	emp, ok := v.find(w, r)
+	if !ok {
+		return
+	}
+	emp, err := change(r.Context(), emp.Name)
+	if err != nil {
+		writeProblem(w, statusOf(err), err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, toV2(emp))
+}
+
+func (v v2) remove(w http.ResponseWriter, r *http.Request) {
+	emp, ok := v.find(w, r)
+	if !ok {
+		return
+	}
+	if err := v.svc.RemoveEmployee(r.Context(), emp.Name); err != nil {
+		writeProblem(w, statusOf(err), err.Error())
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func writeProblem(w http.ResponseWriter, status int, detail string) {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(Problem{Type: "about:blank", Title: http.StatusText(status), Status: status, Detail: detail})
+}
+
+var (
+	_ EmployeeServiceV2 = (*employee.Manager)(nil)
+	_ EmployeeServiceV2 = (*actor.Manager)(nil)
+)
@@ -0,0 +1,107 @@
+package httpapi
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"go-solid/employee"
+	"go-solid/money"
+)
+
+// The client side of v1's wire format: the inverse of each thing the
+// handlers read or write, kept next to them so the two change together.
+// Clients generated by `solid gen client` call these rather than carrying a
+// copy of the format.
+
+// Employee is the employee the DTO describes, as the service returned it.
+func (d EmployeeDTO) Employee() employee.Employee {
+	emp := employee.Employee{ID: employee.ID(d.ID), Name: d.Name, Title: d.Title, Department: d.Department, Email: d.Email, Salary: d.Salary}
+	emp.HiredAt, _ = time.Parse(time.RFC3339, d.HiredAt)
+	return emp
+}
+
+// PageResult is the page the response describes.
+func (r ListResponse) PageResult() employee.PageResult {
+	res := employee.PageResult{Items: make([]employee.Employee, 0, len(r.Items)), NextCursor: r.NextCursor}
+	for _, d := range r.Items {
+		res.Items = append(res.Items, d.Employee())
+	}
+	return res
+}
+
+// ListQuery writes filter and page as the query parameters listQuery reads.
+// The API takes one currency for both salary bounds: that of the maximum,
+// when both are set.
+func ListQuery(filter employee.Filter, page employee.Page) url.Values {
+	q := url.Values{}
+	set := func(key, value string) {
+		if value != "" {
+			q.Set(key, value)
+		}
+	}
+	set("prefix", filter.NamePrefix)
+	set("sort", string(filter.Sort))
+	set("cursor", page.Cursor)
+	if filter.Descending {
+		q.Set("desc", "true")
+	}
+	if page.Limit > 0 {
+		q.Set("limit", strconv.Itoa(page.Limit))
+	}
+	for _, b := range []struct {
+		key   string
+		bound money.Money
+	}{{"min_salary", filter.MinSalary}, {"max_salary", filter.MaxSalary}} {
+		if !b.bound.IsZero() {
+			q.Set(b.key, b.bound.Amount())
+			q.Set("currency", string(b.bound.Currency()))
+		}
+	}
+	return q
+}
+
+// Error An error response, as a client reads it. It unwraps to the domain
+// error its status stands for, where one status stands for one error, so
+// a client checks errors.Is(err, employee.ErrNotFound) as it would in
+// process. A 409 stands for two, told apart by the message, which ends
+// with the error wrapped.
+type Error struct {
+	Status  int
+	Message string
+}
+
+func (e *Error) Error() string { return e.Message }
+
+// Unwrap inverts statusOf.
+func (e *Error) Unwrap() error {
+	switch e.Status {
+	case http.StatusNotFound:
+		return employee.ErrNotFound
+	case http.StatusConflict:
+		if strings.HasSuffix(e.Message, employee.ErrNameTaken.Error()) {
+			return employee.ErrNameTaken
+		}
+		return employee.ErrConflict
+	case http.StatusUnprocessableEntity:
+		return employee.ErrVetoed
+	case http.StatusNotImplemented:
+		return errors.ErrUnsupported
+	}
+	return nil
+}
+
+// ReadError reads the error resp carries, as writeError wrote it.
+func ReadError(resp *http.Response) error {
+	var body errorBody
+	b, _ := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if json.Unmarshal(b, &body) != nil || body.Error == "" {
+		body.Error = resp.Status
+	}
+	return &Error{Status: resp.StatusCode, Message: body.Error}
+}
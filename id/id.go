@@ -0,0 +1,171 @@
+// Package id abstracts identifier generation.
+//
+// Like the clock, random IDs are an ambient dependency: code that generates
+// them inline produces output no test can predict. Inject a Generator instead.
+package id
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"fmt"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go-solid/clock"
+)
+
+// Generator Abstraction - produces unique identifiers
+type Generator interface {
+	NewID() string
+}
+
+// UUID Low-level module - random RFC 4122 version 4 UUIDs
+type UUID struct{}
+
+func (UUID) NewID() string {
+	var b [16]byte
+	_, _ = rand.Read(b[:])  // never returns an error
+	b[6] = b[6]&0x0f | 0x40 // version 4
+	b[8] = b[8]&0x3f | 0x80 // RFC 4122 variant
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+}
+
+// UUIDv7 Low-level module - RFC 9562 version 7 UUIDs: the Unix time in
+// milliseconds, then random bits. They sort by creation time, so a B-tree
+// index on them grows at one end rather than being written all over as with
+// version 4. Within one millisecond each is the previous one plus one, so
+// they sort in the order they were made there too. Clock is the real clock
+// when nil.
+type UUIDv7 struct {
+	Clock clock.Clock
+}
+
+var lastUUIDv7 monotonic
+
+func (u UUIDv7) NewID() string {
+	b := lastUUIDv7.next(now(u.Clock), func(b *[16]byte) {
+		b[6] = b[6]&0x0f | 0x70 // version 7
+		b[8] = b[8]&0x3f | 0x80 // RFC 4122 variant
+	}, incrementUUIDv7)
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+}
+
+// ULID Low-level module - Universally Unique Lexicographically Sortable
+// Identifiers: 48 bits of Unix milliseconds and 80 random bits, as 26
+// characters of Crockford's base 32. They sort like UUIDv7 and read better
+// in a URL. Within one millisecond they are monotonic, as the ULID spec
+// describes: the previous one plus one. Clock is the real clock when nil.
+type ULID struct {
+	Clock clock.Clock
+}
+
+var lastULID monotonic
+
+func (u ULID) NewID() string {
+	b := lastULID.next(now(u.Clock), func(*[16]byte) {}, incrementULID)
+	// 128 bits as 26 five-bit digits, the first one only 3 bits wide
+	var out [26]byte
+	hi := binary.BigEndian.Uint64(b[:8])
+	lo := binary.BigEndian.Uint64(b[8:])
+	for i := 25; i >= 0; i-- {
+		out[i] = crockford[lo&31]
+		lo = lo>>5 | hi<<59
+		hi >>= 5
+	}
+	return string(out[:])
+}
+
+const crockford = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+func now(c clock.Clock) time.Time {
+	if c == nil {
+		return time.Now()
+	}
+	return c.Now()
+}
+
+// putMillis writes t's Unix time in milliseconds into b, 48 bits big-endian.
+func putMillis(b []byte, t time.Time) {
+	ms := uint64(t.UnixMilli())
+	for i := 5; i >= 0; i-- {
+		b[i] = byte(ms)
+		ms >>= 8
+	}
+}
+
+// monotonic The latest ID of one kind, so the next one made in the same
+// millisecond can follow it
+type monotonic struct {
+	mu   sync.Mutex
+	ms   int64
+	last [16]byte
+}
+
+// next returns the bits of a new ID for t: its milliseconds, then random
+// bits, with mark setting any version bits among them. In the millisecond
+// of the latest ID it is that ID incremented instead.
+func (m *monotonic) next(t time.Time, mark, increment func(*[16]byte)) [16]byte {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var b [16]byte
+	if ms := t.UnixMilli(); ms == m.ms {
+		b = m.last
+		increment(&b)
+	} else {
+		putMillis(b[:6], t)
+		_, _ = rand.Read(b[6:])
+		mark(&b)
+		m.ms = ms
+	}
+	m.last = b
+	return b
+}
+
+// incrementULID adds one to the 80 random bits.
+func incrementULID(b *[16]byte) {
+	for i := 15; i >= 6; i-- {
+		if b[i]++; b[i] != 0 {
+			return
+		}
+	}
+}
+
+// incrementUUIDv7 adds one to the 74 random bits, carrying over the version
+// and variant bits between them.
+func incrementUUIDv7(b *[16]byte) {
+	for i := 15; i >= 9; i-- {
+		if b[i]++; b[i] != 0 {
+			return
+		}
+	}
+	if b[8]&0x3f != 0x3f {
+		b[8]++
+		return
+	}
+	b[8] &^= 0x3f
+	if b[7]++; b[7] != 0 {
+		return
+	}
+	b[6] = b[6]&0xf0 | (b[6]+1)&0x0f
+}
+
+// Sequence Deterministic generator for tests and demos: prefix-1, prefix-2, ...
+type Sequence struct {
+	prefix string
+	n      atomic.Uint64
+}
+
+func NewSequence(prefix string) *Sequence { return &Sequence{prefix: prefix} }
+
+func (s *Sequence) NewID() string {
+	return s.prefix + strconv.FormatUint(s.n.Add(1), 10)
+}
+
+var (
+	_ Generator = UUID{}
+	_ Generator = UUIDv7{}
+	_ Generator = ULID{}
+	_ Generator = (*Sequence)(nil)
+)
@@ -0,0 +1,102 @@
+package id_test
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"go-solid/clock"
+	"go-solid/id"
+)
+
+var start = time.Date(2026, time.March, 2, 9, 0, 0, 0, time.UTC)
+
+func TestSequence_Monotonic(t *testing.T) {
+	seq := id.NewSequence("emp-")
+	for i := 1; i <= 3; i++ {
+		if got, want := seq.NewID(), fmt.Sprintf("emp-%d", i); got != want {
+			t.Errorf("NewID() = %s, want %s", got, want)
+		}
+	}
+
+	// concurrent callers share one counter: no number twice, none skipped
+	seq = id.NewSequence("")
+	seen := make(map[string]bool)
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for range 8 {
+		wg.Go(func() {
+			for range 100 {
+				got := seq.NewID()
+				mu.Lock()
+				seen[got] = true
+				mu.Unlock()
+			}
+		})
+	}
+	wg.Wait()
+	for i := 1; i <= 800; i++ {
+		if !seen[fmt.Sprint(i)] {
+			t.Fatalf("%d never drawn by 8 goroutines drawing 100 each", i)
+		}
+	}
+}
+
+func TestUUID_Version4(t *testing.T) {
+	v4 := regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)
+	if a, b := (id.UUID{}).NewID(), (id.UUID{}).NewID(); !v4.MatchString(a) || a == b {
+		t.Errorf("NewID() = %s then %s, want two different version 4 UUIDs", a, b)
+	}
+}
+
+// sorted checks that gen's IDs sort in the order they were made, within one
+// millisecond and across a millisecond boundary, and returns the first.
+func sorted(t *testing.T, gen func() string, clk *clock.Fake) string {
+	t.Helper()
+	ids := make([]string, 0, 2001)
+	for range 1000 {
+		ids = append(ids, gen())
+	}
+	clk.Advance(time.Millisecond)
+	for range 1000 {
+		ids = append(ids, gen())
+	}
+	for i := 1; i < len(ids); i++ {
+		if ids[i-1] >= ids[i] {
+			t.Fatalf("ID %d %s is not after ID %d %s", i, ids[i], i-1, ids[i-1])
+		}
+	}
+	return ids[0]
+}
+
+func TestUUIDv7_Ordered(t *testing.T) {
+	clk := clock.NewFake(start)
+	v7 := id.UUIDv7{Clock: clk}
+	first := sorted(t, v7.NewID, clk)
+	if millis := fmt.Sprintf("%012x", start.UnixMilli()); strings.ReplaceAll(first[:13], "-", "") != millis {
+		t.Errorf("UUIDv7 %s doesn't start with the Unix milliseconds %s", first, millis)
+	}
+	v7s := regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-7[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)
+	for range 1000 {
+		if got := v7.NewID(); !v7s.MatchString(got) {
+			t.Fatalf("NewID() = %s, want the version and variant kept as it counts up", got)
+		}
+	}
+}
+
+func TestULID_Ordered(t *testing.T) {
+	clk := clock.NewFake(start)
+	ulid := id.ULID{Clock: clk}
+	first := sorted(t, ulid.NewID, clk)
+	if len(first) != 26 || strings.ContainsAny(first, "ILOU") {
+		t.Errorf("ULID %s, want 26 characters of Crockford base 32", first)
+	}
+	// 48 bits of milliseconds are the first 10 digits
+	later := id.ULID{Clock: clock.NewFake(start.Add(time.Hour))}
+	if a, b := ulid.NewID(), later.NewID(); a[:10] >= b[:10] {
+		t.Errorf("ULID of %s %s, of an hour later %s, want the time first", start, a, b)
+	}
+}
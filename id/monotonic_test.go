@@ -0,0 +1,21 @@
+package id
+
+import "testing"
+
+// TestIncrementUUIDv7_Carries adds one to random bits that are all ones up
+// to the version nibble, which must stay 7, as the variant stays 10.
+func TestIncrementUUIDv7_Carries(t *testing.T) {
+	b := [16]byte{6: 0x73, 7: 0xff, 8: 0xbf, 9: 0xff, 10: 0xff, 11: 0xff, 12: 0xff, 13: 0xff, 14: 0xff, 15: 0xff}
+	incrementUUIDv7(&b)
+	if want := [16]byte{6: 0x74, 8: 0x80}; b != want {
+		t.Errorf("incremented = % x, want % x", b, want)
+	}
+}
+
+func TestIncrementULID_Carries(t *testing.T) {
+	b := [16]byte{5: 0x01, 6: 0x02, 7: 0xff, 8: 0xff, 9: 0xff, 10: 0xff, 11: 0xff, 12: 0xff, 13: 0xff, 14: 0xff, 15: 0xff}
+	incrementULID(&b)
+	if want := [16]byte{5: 0x01, 6: 0x03}; b != want {
+		t.Errorf("incremented = % x, want % x", b, want)
+	}
+}
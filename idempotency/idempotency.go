@@ -0,0 +1,157 @@
+// Package idempotency makes unsafe HTTP requests safe to retry. A client
+// sends an Idempotency-Key header; the first request with a key runs and its
+// response is stored, and every retry with the same key gets that response
+// back instead of running again - one employee is created, not two.
+//
+// The middleware only deals with HTTP; where responses are kept is a Store
+// (memory, Redis), so each can change without the other (SRP).
+package idempotency
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/http"
+	"time"
+)
+
+// Record What is kept under a key: the request's fingerprint and, once the
+// request has finished, its response
+type Record struct {
+	Fingerprint string      `json:"fingerprint"`
+	Done        bool        `json:"done"`
+	Status      int         `json:"status,omitempty"`
+	Header      http.Header `json:"header,omitempty"`
+	Body        []byte      `json:"body,omitempty"`
+}
+
+// Store Abstraction over response storage. Reserve must be atomic: of two
+// concurrent requests with the same key, exactly one reserves it.
+type Store interface {
+	// Reserve stores rec under key unless the key exists. It returns true when
+	// rec was stored, and the existing record otherwise.
+	Reserve(ctx context.Context, key string, rec Record, ttl time.Duration) (Record, bool, error)
+	// Complete replaces the reservation with the finished record.
+	Complete(ctx context.Context, key string, rec Record, ttl time.Duration) error
+	// Release drops a reservation so the request can be retried.
+	Release(ctx context.Context, key string) error
+}
+
+// Header Request header carrying the key
+const Header = "Idempotency-Key"
+
+// ReplayedHeader Set on responses served from the store
+const ReplayedHeader = "Idempotent-Replayed"
+
+// DefaultTTL How long responses are kept when Middleware.TTL is zero
+const DefaultTTL = 24 * time.Hour
+
+// MaxBody Requests with larger bodies are rejected; the body is read to
+// fingerprint it.
+const MaxBody = 1 << 20
+
+// Middleware Decorator adding idempotency keys to a handler. Requests without
+// the header pass straight through.
+type Middleware struct {
+	Store Store
+	TTL   time.Duration
+}
+
+func (m Middleware) Wrap(next http.Handler) http.Handler {
+	ttl := m.TTL
+	if ttl <= 0 {
+		ttl = DefaultTTL
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key := r.Header.Get(Header)
+		if key == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+		body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, MaxBody))
+		var tooLarge *http.MaxBytesError
+		switch {
+		case errors.As(err, &tooLarge):
+			http.Error(w, "request body too large", http.StatusRequestEntityTooLarge)
+			return
+		case err != nil:
+			http.Error(w, "request body unreadable", http.StatusBadRequest)
+			return
+		}
+		r.Body = io.NopCloser(bytes.NewReader(body))
+		fp := fingerprint(r, body)
+
+		prev, reserved, err := m.Store.Reserve(r.Context(), key, Record{Fingerprint: fp}, ttl)
+		switch {
+		case err != nil:
+			http.Error(w, "idempotency store unavailable", http.StatusServiceUnavailable)
+			return
+		case !reserved && prev.Fingerprint != fp:
+			http.Error(w, "Idempotency-Key reused for a different request", http.StatusUnprocessableEntity)
+			return
+		case !reserved && !prev.Done:
+			http.Error(w, "a request with this Idempotency-Key is in progress", http.StatusConflict)
+			return
+		case !reserved:
+			replay(w, prev)
+			return
+		}
+
+		rec := &recorder{ResponseWriter: w, status: http.StatusOK}
+		defer func() {
+			// server errors and panics are not remembered: the client may retry them
+			if p := recover(); p != nil || rec.status >= 500 {
+				_ = m.Store.Release(context.WithoutCancel(r.Context()), key)
+				if p != nil {
+					panic(p)
+				}
+				return
+			}
+			done := Record{Fingerprint: fp, Done: true, Status: rec.status, Header: w.Header().Clone(), Body: rec.body.Bytes()}
+			_ = m.Store.Complete(context.WithoutCancel(r.Context()), key, done, ttl)
+		}()
+		next.ServeHTTP(rec, r)
+	})
+}
+
+// fingerprint identifies the request a key was first used for, so a key
+// reused for another request is caught instead of replaying the wrong answer.
+func fingerprint(r *http.Request, body []byte) string {
+	h := sha256.New()
+	io.WriteString(h, r.Method+" "+r.URL.RequestURI()+"\n")
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func replay(w http.ResponseWriter, rec Record) {
+	for k, v := range rec.Header {
+		w.Header()[k] = v
+	}
+	w.Header().Set(ReplayedHeader, "true")
+	w.WriteHeader(rec.Status)
+	_, _ = w.Write(rec.Body)
+}
+
+// recorder Writes the response through while keeping a copy
+type recorder struct {
+	http.ResponseWriter
+	status      int
+	wroteHeader bool
+	body        bytes.Buffer
+}
+
+func (r *recorder) WriteHeader(status int) {
+	if !r.wroteHeader {
+		r.status, r.wroteHeader = status, true
+	}
+	r.ResponseWriter.WriteHeader(status)
+}
+
+func (r *recorder) Write(b []byte) (int, error) {
+	r.wroteHeader = true
+	r.body.Write(b)
+	return r.ResponseWriter.Write(b)
+}
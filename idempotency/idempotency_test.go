@@ -0,0 +1,63 @@
+package idempotency_test
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"go-solid/idempotency"
+)
+
+// failingReader A request body whose connection dropped half-way
+type failingReader struct{}
+
+func (failingReader) Read([]byte) (int, error) { return 0, errors.New("connection reset by peer") }
+
+func TestMiddleware_BodyErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		body io.Reader
+		want int
+	}{
+		{"fits", strings.NewReader(`{"name":"Mona"}`), http.StatusCreated},
+		{"too large", strings.NewReader(strings.Repeat("x", idempotency.MaxBody+1)), http.StatusRequestEntityTooLarge},
+		{"unreadable", failingReader{}, http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mw := idempotency.Middleware{Store: idempotency.NewMemory(nil)}
+			h := mw.Wrap(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusCreated) }))
+			req := httptest.NewRequest("POST", "/employees", tt.body)
+			req.Header.Set(idempotency.Header, "key-1")
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, req)
+			if rec.Code != tt.want {
+				t.Errorf("status = %d, want %d (%s)", rec.Code, tt.want, strings.TrimSpace(rec.Body.String()))
+			}
+		})
+	}
+}
+
+func TestMiddleware_Replays(t *testing.T) {
+	runs := 0
+	mw := idempotency.Middleware{Store: idempotency.NewMemory(nil)}
+	h := mw.Wrap(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		runs++
+		w.WriteHeader(http.StatusCreated)
+	}))
+	for i := range 2 {
+		req := httptest.NewRequest("POST", "/employees", strings.NewReader(`{"name":"Mona"}`))
+		req.Header.Set(idempotency.Header, "key-1")
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		if rec.Code != http.StatusCreated || (i == 1) != (rec.Header().Get(idempotency.ReplayedHeader) == "true") {
+			t.Errorf("request %d: status %d, replayed %q", i+1, rec.Code, rec.Header().Get(idempotency.ReplayedHeader))
+		}
+	}
+	if runs != 1 {
+		t.Errorf("handler ran %d times, want once", runs)
+	}
+}
@@ -0,0 +1,73 @@
+package idempotency
+
+import (
+	"context"
+	"maps"
+	"sync"
+	"time"
+
+	"go-solid/clock"
+)
+
+// Memory Low-level module - in-process Store for single-instance deployments
+// and tests. Expired keys are dropped lazily: a key that comes back is
+// overwritten, and Reserve sweeps out the rest at most once every
+// sweepInterval, so keys never reused don't pile up.
+type Memory struct {
+	mu      sync.Mutex
+	clock   clock.Clock
+	records map[string]entry
+	swept   time.Time
+}
+
+// sweepInterval How often Reserve looks for expired keys; a sweep visits
+// every key, so it is amortised over the reservations in between
+const sweepInterval = time.Minute
+
+type entry struct {
+	rec     Record
+	expires time.Time
+}
+
+func NewMemory(c clock.Clock) *Memory {
+	if c == nil {
+		c = clock.Real{}
+	}
+	return &Memory{clock: c, records: make(map[string]entry), swept: c.Now()}
+}
+
+func (m *Memory) Reserve(ctx context.Context, key string, rec Record, ttl time.Duration) (Record, bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	now := m.clock.Now()
+	if now.Sub(m.swept) >= sweepInterval {
+		m.sweep(now)
+	}
+	if e, ok := m.records[key]; ok && now.Before(e.expires) {
+		return e.rec, false, nil
+	}
+	m.records[key] = entry{rec: rec, expires: now.Add(ttl)}
+	return rec, true, nil
+}
+
+// sweep drops every key expired at now.
+func (m *Memory) sweep(now time.Time) {
+	maps.DeleteFunc(m.records, func(_ string, e entry) bool { return !now.Before(e.expires) })
+	m.swept = now
+}
+
+func (m *Memory) Complete(ctx context.Context, key string, rec Record, ttl time.Duration) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.records[key] = entry{rec: rec, expires: m.clock.Now().Add(ttl)}
+	return nil
+}
+
+func (m *Memory) Release(ctx context.Context, key string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.records, key)
+	return nil
+}
+
+var _ Store = (*Memory)(nil)
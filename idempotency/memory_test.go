@@ -0,0 +1,38 @@
+package idempotency
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"go-solid/clock"
+)
+
+func TestMemory_SweepsKeysNeverReused(t *testing.T) {
+	c := clock.NewFake(time.Date(2026, time.March, 2, 9, 0, 0, 0, time.UTC))
+	m := NewMemory(c)
+	for i := range 1000 {
+		if _, ok, err := m.Reserve(t.Context(), fmt.Sprintf("key-%d", i), Record{Fingerprint: "fp"}, time.Second); !ok || err != nil {
+			t.Fatalf("Reserve(key-%d) = %v, %v, want it reserved", i, ok, err)
+		}
+	}
+	c.Advance(sweepInterval)
+	if _, _, err := m.Reserve(t.Context(), "fresh", Record{Fingerprint: "fp"}, time.Hour); err != nil {
+		t.Fatalf("Reserve(fresh) error = %v", err)
+	}
+	if got := len(m.records); got != 1 {
+		t.Errorf("%d keys kept after a sweep, want only the live one", got)
+	}
+}
+
+func TestMemory_SweepKeepsLiveKeys(t *testing.T) {
+	c := clock.NewFake(time.Date(2026, time.March, 2, 9, 0, 0, 0, time.UTC))
+	m := NewMemory(c)
+	_, _, _ = m.Reserve(t.Context(), "long", Record{Fingerprint: "fp"}, time.Hour)
+	c.Advance(sweepInterval)
+	_, _, _ = m.Reserve(t.Context(), "other", Record{Fingerprint: "fp"}, time.Hour)
+	prev, ok, err := m.Reserve(t.Context(), "long", Record{Fingerprint: "another"}, time.Hour)
+	if ok || err != nil || prev.Fingerprint != "fp" {
+		t.Errorf("Reserve(long) = %+v, %v, %v, want the first reservation kept", prev, ok, err)
+	}
+}
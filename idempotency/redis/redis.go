@@ -0,0 +1,152 @@
+// Package redis is an idempotency.Store on Redis, for deployments with more
+// than one API instance. It speaks the few RESP commands it needs over
+// net.Conn rather than pulling in a client library.
+package redis
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"go-solid/idempotency"
+)
+
+// Store Low-level module - one Redis string per key, expiring with the TTL.
+// Reserve is a single SET NX, which Redis runs atomically.
+type Store struct {
+	Addr     string
+	Password string
+	// Prefix namespaces the keys; defaults to "idempotency:".
+	Prefix string
+	Dialer net.Dialer
+}
+
+var errNil = errors.New("redis: nil")
+
+func (s *Store) key(k string) string {
+	if s.Prefix == "" {
+		return "idempotency:" + k
+	}
+	return s.Prefix + k
+}
+
+func (s *Store) Reserve(ctx context.Context, key string, rec idempotency.Record, ttl time.Duration) (idempotency.Record, bool, error) {
+	doc, err := json.Marshal(rec)
+	if err != nil {
+		return idempotency.Record{}, false, err
+	}
+	_, err = s.do(ctx, "SET", s.key(key), string(doc), "NX", "PX", strconv.FormatInt(ttl.Milliseconds(), 10))
+	if err == nil {
+		return rec, true, nil
+	}
+	if !errors.Is(err, errNil) {
+		return idempotency.Record{}, false, err
+	}
+	// NX refused: the key exists
+	existing, err := s.do(ctx, "GET", s.key(key))
+	if errors.Is(err, errNil) {
+		// expired in between; let the client retry rather than loop here
+		return idempotency.Record{}, false, fmt.Errorf("redis: %q expired during reserve", key)
+	}
+	if err != nil {
+		return idempotency.Record{}, false, err
+	}
+	var prev idempotency.Record
+	if err := json.Unmarshal([]byte(existing), &prev); err != nil {
+		return idempotency.Record{}, false, fmt.Errorf("redis: decode %q: %w", key, err)
+	}
+	return prev, false, nil
+}
+
+func (s *Store) Complete(ctx context.Context, key string, rec idempotency.Record, ttl time.Duration) error {
+	doc, err := json.Marshal(rec)
+	if err != nil {
+		return err
+	}
+	_, err = s.do(ctx, "SET", s.key(key), string(doc), "PX", strconv.FormatInt(ttl.Milliseconds(), 10))
+	return err
+}
+
+func (s *Store) Release(ctx context.Context, key string) error {
+	_, err := s.do(ctx, "DEL", s.key(key))
+	return err
+}
+
+// CheckHealth pings the server (health.Checker).
+func (s *Store) CheckHealth(ctx context.Context) error {
+	_, err := s.do(ctx, "PING")
+	return err
+}
+
+// do runs one command on a fresh connection. Idempotency checks are one
+// round trip per unsafe request, so pooling is not worth its complexity here.
+func (s *Store) do(ctx context.Context, args ...string) (string, error) {
+	conn, err := s.Dialer.DialContext(ctx, "tcp", s.Addr)
+	if err != nil {
+		return "", fmt.Errorf("redis: %w", err)
+	}
+	defer conn.Close()
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+	r := bufio.NewReader(conn)
+	if s.Password != "" {
+		if _, err := command(conn, r, "AUTH", s.Password); err != nil {
+			return "", err
+		}
+	}
+	return command(conn, r, args...)
+}
+
+func command(conn net.Conn, r *bufio.Reader, args ...string) (string, error) {
+	var b strings.Builder
+	fmt.Fprintf(&b, "*%d\r\n", len(args))
+	for _, a := range args {
+		fmt.Fprintf(&b, "$%d\r\n%s\r\n", len(a), a)
+	}
+	if _, err := conn.Write([]byte(b.String())); err != nil {
+		return "", fmt.Errorf("redis: %w", err)
+	}
+	return reply(r)
+}
+
+// reply reads one RESP reply of the kinds the commands above return.
+func reply(r *bufio.Reader) (string, error) {
+	line, err := r.ReadString('\n')
+	if err != nil {
+		return "", fmt.Errorf("redis: %w", err)
+	}
+	line = strings.TrimSuffix(line, "\r\n")
+	if line == "" {
+		return "", errors.New("redis: empty reply")
+	}
+	switch line[0] {
+	case '+', ':':
+		return line[1:], nil
+	case '-':
+		return "", fmt.Errorf("redis: %s", line[1:])
+	case '$':
+		n, err := strconv.Atoi(line[1:])
+		if err != nil {
+			return "", fmt.Errorf("redis: bad reply %q", line)
+		}
+		if n < 0 {
+			return "", errNil
+		}
+		buf := make([]byte, n+2)
+		if _, err := io.ReadFull(r, buf); err != nil {
+			return "", fmt.Errorf("redis: %w", err)
+		}
+		return string(buf[:n]), nil
+	}
+	return "", fmt.Errorf("redis: unexpected reply %q", line)
+}
+
+var _ idempotency.Store = (*Store)(nil)
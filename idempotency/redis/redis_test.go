@@ -0,0 +1,290 @@
+package redis_test
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"go-solid/idempotency"
+	"go-solid/idempotency/redis"
+)
+
+// server A Redis stand-in speaking the RESP commands the store sends:
+// AUTH, PING, SET [NX] [PX], GET and DEL. It never expires keys on its own.
+type server struct {
+	password string
+	// vanish deletes a key as soon as SET NX is refused, as if it had
+	// expired just then
+	vanish bool
+	// hang reads commands and never answers
+	hang bool
+
+	mu       sync.Mutex
+	data     map[string]string
+	commands []string
+}
+
+func start(t *testing.T, s *server) string {
+	t.Helper()
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { l.Close() })
+	s.data = make(map[string]string)
+	go func() {
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+			go s.serve(conn)
+		}
+	}()
+	return l.Addr().String()
+}
+
+func (s *server) serve(conn net.Conn) {
+	defer conn.Close()
+	r := bufio.NewReader(conn)
+	authed := s.password == ""
+	for {
+		args, err := readCommand(r)
+		if err != nil {
+			return
+		}
+		if s.hang {
+			continue
+		}
+		s.mu.Lock()
+		s.commands = append(s.commands, strings.Join(args, " "))
+		reply := s.run(args, &authed)
+		s.mu.Unlock()
+		if _, err := io.WriteString(conn, reply); err != nil {
+			return
+		}
+	}
+}
+
+func (s *server) run(args []string, authed *bool) string {
+	cmd := strings.ToUpper(args[0])
+	if cmd == "AUTH" {
+		if len(args) != 2 || args[1] != s.password {
+			return "-WRONGPASS invalid password\r\n"
+		}
+		*authed = true
+		return "+OK\r\n"
+	}
+	if !*authed {
+		return "-NOAUTH Authentication required.\r\n"
+	}
+	switch cmd {
+	case "PING":
+		return "+PONG\r\n"
+	case "SET":
+		if _, exists := s.data[args[1]]; exists && strings.Contains(strings.Join(args[3:], " "), "NX") {
+			if s.vanish {
+				delete(s.data, args[1])
+			}
+			return "$-1\r\n"
+		}
+		s.data[args[1]] = args[2]
+		return "+OK\r\n"
+	case "GET":
+		v, ok := s.data[args[1]]
+		if !ok {
+			return "$-1\r\n"
+		}
+		return fmt.Sprintf("$%d\r\n%s\r\n", len(v), v)
+	case "DEL":
+		_, ok := s.data[args[1]]
+		delete(s.data, args[1])
+		if ok {
+			return ":1\r\n"
+		}
+		return ":0\r\n"
+	}
+	return "-ERR unknown command '" + args[0] + "'\r\n"
+}
+
+func readCommand(r *bufio.Reader) ([]string, error) {
+	line, err := r.ReadString('\n')
+	if err != nil {
+		return nil, err
+	}
+	n, err := strconv.Atoi(strings.TrimSpace(strings.TrimPrefix(line, "*")))
+	if err != nil {
+		return nil, err
+	}
+	args := make([]string, n)
+	for i := range args {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return nil, err
+		}
+		size, err := strconv.Atoi(strings.TrimSpace(strings.TrimPrefix(line, "$")))
+		if err != nil {
+			return nil, err
+		}
+		buf := make([]byte, size+2)
+		if _, err := io.ReadFull(r, buf); err != nil {
+			return nil, err
+		}
+		args[i] = string(buf[:size])
+	}
+	return args, nil
+}
+
+func (s *server) sent() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return slices.Clone(s.commands)
+}
+
+func TestStore(t *testing.T) {
+	srv := &server{}
+	store := &redis.Store{Addr: start(t, srv)}
+	ctx := t.Context()
+
+	rec, ok, err := store.Reserve(ctx, "key-1", idempotency.Record{Fingerprint: "fp-1"}, 24*time.Hour)
+	if !ok || err != nil || rec.Fingerprint != "fp-1" {
+		t.Fatalf("Reserve() = %+v, %v, %v, want it reserved", rec, ok, err)
+	}
+	if sent := srv.sent()[0]; sent != `SET idempotency:key-1 {"fingerprint":"fp-1","done":false} NX PX 86400000` {
+		t.Errorf("Reserve() sent %q, want a SET NX under the prefix with the TTL in milliseconds", sent)
+	}
+	prev, ok, err := store.Reserve(ctx, "key-1", idempotency.Record{Fingerprint: "fp-2"}, time.Hour)
+	if ok || err != nil || prev.Fingerprint != "fp-1" || prev.Done {
+		t.Errorf("Reserve() again = %+v, %v, %v, want the pending reservation", prev, ok, err)
+	}
+
+	// a body with the bytes RESP uses as delimiters
+	done := idempotency.Record{Fingerprint: "fp-1", Done: true, Status: http.StatusCreated,
+		Header: http.Header{"Location": {"/employees/1"}}, Body: []byte("{\"name\":\"Ali\"}\r\n$-1\r\n")}
+	if err := store.Complete(ctx, "key-1", done, time.Hour); err != nil {
+		t.Fatalf("Complete() error = %v", err)
+	}
+	prev, ok, err = store.Reserve(ctx, "key-1", idempotency.Record{Fingerprint: "fp-1"}, time.Hour)
+	if ok || err != nil || !prev.Done || prev.Status != http.StatusCreated ||
+		prev.Header.Get("Location") != "/employees/1" || string(prev.Body) != string(done.Body) {
+		t.Errorf("Reserve() after Complete() = %+v, %v, %v, want the completed record", prev, ok, err)
+	}
+
+	if err := store.Release(ctx, "key-1"); err != nil {
+		t.Fatalf("Release() error = %v", err)
+	}
+	if _, ok, err := store.Reserve(ctx, "key-1", idempotency.Record{Fingerprint: "fp-3"}, time.Hour); !ok || err != nil {
+		t.Errorf("Reserve() after Release() = %v, %v, want it reserved again", ok, err)
+	}
+	if err := store.CheckHealth(ctx); err != nil {
+		t.Errorf("CheckHealth() error = %v", err)
+	}
+}
+
+func TestStore_Prefix(t *testing.T) {
+	srv := &server{}
+	store := &redis.Store{Addr: start(t, srv), Prefix: "acme:"}
+	if _, _, err := store.Reserve(t.Context(), "key-1", idempotency.Record{}, time.Second); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Release(t.Context(), "key-1"); err != nil {
+		t.Fatal(err)
+	}
+	sent := srv.sent()
+	if !strings.HasPrefix(sent[0], "SET acme:key-1 ") || sent[1] != "DEL acme:key-1" {
+		t.Errorf("sent %q, want the keys under acme:", sent)
+	}
+}
+
+func TestStore_ReserveIsAtomic(t *testing.T) {
+	store := &redis.Store{Addr: start(t, &server{})}
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	reserved := 0
+	for i := range 20 {
+		wg.Go(func() {
+			_, ok, err := store.Reserve(t.Context(), "key-1", idempotency.Record{Fingerprint: strconv.Itoa(i)}, time.Hour)
+			if err != nil {
+				t.Error(err)
+			}
+			if ok {
+				mu.Lock()
+				reserved++
+				mu.Unlock()
+			}
+		})
+	}
+	wg.Wait()
+	if reserved != 1 {
+		t.Errorf("%d of 20 concurrent Reserve() calls reserved the key, want exactly 1", reserved)
+	}
+}
+
+func TestStore_Auth(t *testing.T) {
+	srv := &server{password: "s3cret"}
+	addr := start(t, srv)
+	tests := []struct {
+		password string
+		wantErr  string
+	}{
+		{"s3cret", ""},
+		{"wrong", "WRONGPASS"},
+		{"", "NOAUTH"},
+	}
+	for _, tt := range tests {
+		err := (&redis.Store{Addr: addr, Password: tt.password}).CheckHealth(t.Context())
+		if (tt.wantErr == "" && err != nil) || (tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr))) {
+			t.Errorf("CheckHealth() with password %q error = %v, want %q", tt.password, err, tt.wantErr)
+		}
+	}
+}
+
+func TestStore_Errors(t *testing.T) {
+	t.Run("expired during reserve", func(t *testing.T) {
+		store := &redis.Store{Addr: start(t, &server{vanish: true})}
+		_, _, _ = store.Reserve(t.Context(), "key-1", idempotency.Record{}, time.Hour)
+		_, ok, err := store.Reserve(t.Context(), "key-1", idempotency.Record{}, time.Hour)
+		if ok || err == nil || !strings.Contains(err.Error(), "expired during reserve") {
+			t.Errorf("Reserve() = %v, %v, want the client told to retry", ok, err)
+		}
+	})
+
+	t.Run("not a record", func(t *testing.T) {
+		srv := &server{}
+		store := &redis.Store{Addr: start(t, srv)}
+		srv.data["idempotency:key-1"] = "<html>"
+		if _, ok, err := store.Reserve(t.Context(), "key-1", idempotency.Record{}, time.Hour); ok || err == nil {
+			t.Errorf("Reserve() = %v, %v, want a decode error", ok, err)
+		}
+	})
+
+	t.Run("unreachable", func(t *testing.T) {
+		l, err := net.Listen("tcp", "127.0.0.1:0")
+		if err != nil {
+			t.Fatal(err)
+		}
+		addr := l.Addr().String()
+		l.Close()
+		if err := (&redis.Store{Addr: addr}).CheckHealth(t.Context()); err == nil {
+			t.Error("CheckHealth() of a closed port error = nil")
+		}
+	})
+
+	t.Run("no answer", func(t *testing.T) {
+		store := &redis.Store{Addr: start(t, &server{hang: true})}
+		ctx, cancel := context.WithTimeout(t.Context(), 100*time.Millisecond)
+		defer cancel()
+		if _, _, err := store.Reserve(ctx, "key-1", idempotency.Record{}, time.Hour); err == nil {
+			t.Error("Reserve() from a server that never answers error = nil, want the deadline")
+		}
+	})
+}
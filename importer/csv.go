@@ -0,0 +1,96 @@
+package importer
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"strings"
+)
+
+// CSV RecordSource for comma-separated files with a header row
+type CSV struct {
+	r     io.Reader
+	Comma rune // defaults to ','
+}
+
+func NewCSV(r io.Reader) *CSV { return &CSV{r: r} }
+
+func (c *CSV) Records() iter.Seq2[Record, error] {
+	return records(func(yield func(line) bool) {
+		cr := csv.NewReader(c.r)
+		cr.FieldsPerRecord = -1 // ragged rows are the validator's problem, not the parser's
+		if c.Comma != 0 {
+			cr.Comma = c.Comma
+		}
+		for n := 1; ; n++ {
+			cells, err := cr.Read()
+			if err == io.EOF {
+				return
+			}
+			var perr *csv.ParseError
+			switch {
+			case errors.As(err, &perr):
+				err = perr.Err
+			case err != nil:
+				err = fmt.Errorf("%w: %w", ErrFatal, err)
+			}
+			if !yield(line{n: n, cells: cells, err: err}) || errors.Is(err, ErrFatal) {
+				return
+			}
+		}
+	})
+}
+
+// line One raw row as a format produces it
+type line struct {
+	n     int
+	cells []string
+	err   error
+}
+
+// records maps raw rows onto the header row's column names, shared by every
+// tabular format.
+func records(lines iter.Seq[line]) iter.Seq2[Record, error] {
+	return func(yield func(Record, error) bool) {
+		var header []string
+		for l := range lines {
+			if l.err != nil {
+				if !yield(Record{Row: l.n}, l.err) {
+					return
+				}
+				continue
+			}
+			if header == nil {
+				for _, h := range l.cells {
+					header = append(header, strings.ToLower(strings.TrimSpace(h)))
+				}
+				continue
+			}
+			if blank(l.cells) {
+				continue
+			}
+			rec := Record{Row: l.n, Fields: make(map[string]string, len(header))}
+			for i, v := range l.cells {
+				if i < len(header) && header[i] != "" {
+					rec.Fields[header[i]] = v
+				}
+			}
+			if !yield(rec, nil) {
+				return
+			}
+		}
+	}
+}
+
+func blank(cells []string) bool {
+	for _, c := range cells {
+		if strings.TrimSpace(c) != "" {
+			return false
+		}
+	}
+	return true
+}
+
+var _ RecordSource = (*CSV)(nil)
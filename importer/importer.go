@@ -0,0 +1,137 @@
+// Package importer loads employees from spreadsheet-like files.
+//
+// Each step has its own collaborator (SRP): a RecordSource parses a file
+// format into rows of named fields, a Validator turns a row into an Employee
+// or explains what is wrong with it, and an employee.Repository stores the
+// result. Adding a format means a new RecordSource; changing a rule means a
+// new Validator; neither touches the Importer.
+package importer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"iter"
+	"strings"
+
+	"go-solid/employee"
+)
+
+// Record One row of input: values by lower-cased column name
+type Record struct {
+	// Row is the 1-based row number in the file, header included, so it
+	// matches what a spreadsheet user sees.
+	Row    int
+	Fields map[string]string
+}
+
+// Get returns the trimmed value of a column, or "" if it is absent.
+func (r Record) Get(column string) string {
+	return strings.TrimSpace(r.Fields[column])
+}
+
+// RecordSource Parsing responsibility - yields rows, or a per-row error for
+// rows it could not parse. Errors wrapping ErrFatal end the import.
+type RecordSource interface {
+	Records() iter.Seq2[Record, error]
+}
+
+// ErrFatal marks source errors after which no further rows can be read.
+var ErrFatal = errors.New("import source unreadable")
+
+// Validator Validation responsibility - turns a row into an Employee
+type Validator interface {
+	Validate(rec Record) (employee.Employee, error)
+}
+
+// RowError One rejected row
+type RowError struct {
+	Row    int
+	Column string // empty when the problem isn't tied to one column
+	Err    error
+}
+
+func (e RowError) Error() string {
+	if e.Column == "" {
+		return fmt.Sprintf("row %d: %v", e.Row, e.Err)
+	}
+	return fmt.Sprintf("row %d, %s: %v", e.Row, e.Column, e.Err)
+}
+
+func (e RowError) Unwrap() error { return e.Err }
+
+// Report Outcome of an import: the rows are either imported or listed in Errors
+type Report struct {
+	Read     int
+	Imported int
+	Errors   []RowError
+}
+
+// Importer Orchestrates source -> validator -> repository; owns no format and no rules
+type Importer struct {
+	repo      employee.Repository
+	validator Validator
+}
+
+func New(repo employee.Repository, v Validator) *Importer {
+	return &Importer{repo: repo, validator: v}
+}
+
+// Import streams src into the repository. Invalid rows are collected in the
+// report and the rest are still imported; the error is non-nil only when the
+// import could not run to the end (unreadable source, cancelled context).
+//
+// Rows go to the repository directly, not through employee.Manager: this is
+// a data load, so no audit records or domain events are produced.
+func (im *Importer) Import(ctx context.Context, src RecordSource) (Report, error) {
+	var report Report
+	var rows []int // row number of each valid employee, to map save failures back
+	var fatal error
+
+	valid := func(yield func(employee.Employee) bool) {
+		for rec, err := range src.Records() {
+			if err != nil {
+				if errors.Is(err, ErrFatal) {
+					fatal = err
+					return
+				}
+				report.Read++
+				report.Errors = append(report.Errors, asRowError(rec.Row, err))
+				continue
+			}
+			report.Read++
+			emp, err := im.validator.Validate(rec)
+			if err != nil {
+				report.Errors = append(report.Errors, asRowError(rec.Row, err))
+				continue
+			}
+			rows = append(rows, rec.Row)
+			if !yield(emp) {
+				return
+			}
+		}
+	}
+
+	err := employee.SaveAll(ctx, im.repo, valid)
+	report.Imported = len(rows)
+	var bulk *employee.BulkError
+	if errors.As(err, &bulk) {
+		for _, f := range bulk.Failed {
+			report.Errors = append(report.Errors, RowError{Row: rows[f.Index], Err: f.Err})
+		}
+		report.Imported = bulk.Saved
+		err = bulk.Stopped
+	}
+	if fatal != nil {
+		return report, fatal
+	}
+	return report, err
+}
+
+func asRowError(row int, err error) RowError {
+	var re RowError
+	if errors.As(err, &re) {
+		return re
+	}
+	return RowError{Row: row, Err: err}
+}
@@ -0,0 +1,96 @@
+package importer
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"go-solid/clock"
+	"go-solid/employee"
+	"go-solid/id"
+	"go-solid/money"
+)
+
+// Columns understood by Rules; name and salary are required. Columns are
+// found by name, so a file without a column added later still imports.
+const (
+	ColumnName       = "name"
+	ColumnTitle      = "title"
+	ColumnSalary     = "salary"
+	ColumnCurrency   = "currency"
+	ColumnHiredAt    = "hired_at"
+	ColumnDepartment = "department"
+)
+
+var (
+	ErrMissingValue = errors.New("value is required")
+	ErrBadAmount    = errors.New("not an amount (want e.g. 5000 or 5000.50)")
+	ErrBadDate      = errors.New("not a date (want YYYY-MM-DD)")
+)
+
+// Rules Default Validator: parses the standard columns and checks the domain
+// invariants through employee.Hire, so a file can't contain an employee the
+// domain itself would refuse.
+type Rules struct {
+	IDs      id.Generator
+	Clock    clock.Clock    // hire date for rows without hired_at
+	Currency money.Currency // for rows without a currency column
+}
+
+func (v Rules) Validate(rec Record) (employee.Employee, error) {
+	name := rec.Get(ColumnName)
+	if name == "" {
+		return employee.Employee{}, RowError{Row: rec.Row, Column: ColumnName, Err: ErrMissingValue}
+	}
+
+	raw := rec.Get(ColumnSalary)
+	if raw == "" {
+		return employee.Employee{}, RowError{Row: rec.Row, Column: ColumnSalary, Err: ErrMissingValue}
+	}
+	currency := money.Currency(strings.ToUpper(rec.Get(ColumnCurrency)))
+	if currency == "" {
+		currency = v.Currency
+	}
+	if !currency.Valid() {
+		return employee.Employee{}, RowError{Row: rec.Row, Column: ColumnCurrency, Err: money.ErrInvalidCurrency}
+	}
+	salary, err := money.Parse(raw, currency)
+	if err != nil {
+		return employee.Employee{}, RowError{Row: rec.Row, Column: ColumnSalary, Err: ErrBadAmount}
+	}
+
+	hiredAt := v.Clock.Now()
+	if raw := rec.Get(ColumnHiredAt); raw != "" {
+		if hiredAt, err = parseDate(raw); err != nil {
+			return employee.Employee{}, RowError{Row: rec.Row, Column: ColumnHiredAt, Err: err}
+		}
+	}
+
+	emp, err := employee.Hire(employee.ID(v.IDs.NewID()), name, rec.Get(ColumnTitle), salary, hiredAt)
+	if errors.Is(err, employee.ErrInvalidSalary) {
+		return employee.Employee{}, RowError{Row: rec.Row, Column: ColumnSalary, Err: err}
+	}
+	if err != nil {
+		return employee.Employee{}, RowError{Row: rec.Row, Err: err}
+	}
+	emp.Department = rec.Get(ColumnDepartment)
+	emp.PullEvents() // a data load, not a hire: nobody is listening
+	return emp, nil
+}
+
+// excelEpoch is day 0 of spreadsheet serial dates, which is how XLSX stores a
+// date cell unless it is formatted as text.
+var excelEpoch = time.Date(1899, time.December, 30, 0, 0, 0, 0, time.UTC)
+
+func parseDate(s string) (time.Time, error) {
+	if t, err := time.Parse(time.DateOnly, s); err == nil {
+		return t, nil
+	}
+	if days, err := strconv.Atoi(s); err == nil && days > 0 {
+		return excelEpoch.AddDate(0, 0, days), nil
+	}
+	return time.Time{}, ErrBadDate
+}
+
+var _ Validator = Rules{}
@@ -0,0 +1,257 @@
+package importer
+
+import (
+	"archive/zip"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"path"
+	"strconv"
+	"strings"
+)
+
+// XLSX RecordSource for the first worksheet of an Office Open XML workbook.
+// It reads the zip/XML container with the standard library only; formulas are
+// read as their cached values and dates as serial numbers.
+type XLSX struct {
+	r    io.ReaderAt
+	size int64
+}
+
+func NewXLSX(r io.ReaderAt, size int64) *XLSX { return &XLSX{r: r, size: size} }
+
+func (x *XLSX) Records() iter.Seq2[Record, error] {
+	return records(func(yield func(line) bool) {
+		if err := x.readSheet(yield); err != nil {
+			yield(line{err: fmt.Errorf("%w: xlsx: %w", ErrFatal, err)})
+		}
+	})
+}
+
+func (x *XLSX) readSheet(yield func(line) bool) error {
+	zr, err := zip.NewReader(x.r, x.size)
+	if err != nil {
+		return err
+	}
+	files := make(map[string]*zip.File, len(zr.File))
+	for _, f := range zr.File {
+		files[f.Name] = f
+	}
+
+	sheet, err := firstSheet(files)
+	if err != nil {
+		return err
+	}
+	var shared []string
+	if f, ok := files["xl/sharedStrings.xml"]; ok {
+		if shared, err = sharedStrings(f); err != nil {
+			return err
+		}
+	}
+
+	rc, err := sheet.Open()
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+
+	// Rows are decoded one at a time, so large sheets are streamed too.
+	dec := xml.NewDecoder(rc)
+	for n := 1; ; n++ {
+		tok, err := dec.Token()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		start, ok := tok.(xml.StartElement)
+		if !ok || start.Name.Local != "row" {
+			continue
+		}
+		var row xlsxRow
+		if err := dec.DecodeElement(&row, &start); err != nil {
+			return err
+		}
+		if row.R == 0 { // the row number attribute is optional
+			row.R = n
+		}
+		n = row.R
+		// a row that can't be read is rejected on its own: the rest of an
+		// uploaded file is still imported
+		l, err := row.line(shared)
+		if err != nil {
+			l = line{n: row.R, err: err}
+		}
+		if !yield(l) {
+			return nil
+		}
+	}
+}
+
+// firstSheet resolves the first <sheet> of the workbook through its relationship.
+func firstSheet(files map[string]*zip.File) (*zip.File, error) {
+	var wb struct {
+		Sheets []struct {
+			RID string `xml:"http://schemas.openxmlformats.org/officeDocument/2006/relationships id,attr"`
+		} `xml:"sheets>sheet"`
+	}
+	if err := decodeFile(files, "xl/workbook.xml", &wb); err != nil {
+		return nil, err
+	}
+	if len(wb.Sheets) == 0 {
+		return nil, errors.New("workbook has no sheets")
+	}
+	var rels struct {
+		Rels []struct {
+			ID     string `xml:"Id,attr"`
+			Target string `xml:"Target,attr"`
+		} `xml:"Relationship"`
+	}
+	if err := decodeFile(files, "xl/_rels/workbook.xml.rels", &rels); err != nil {
+		return nil, err
+	}
+	for _, rel := range rels.Rels {
+		if rel.ID != wb.Sheets[0].RID {
+			continue
+		}
+		name := path.Join("xl", rel.Target)
+		if strings.HasPrefix(rel.Target, "/") {
+			name = strings.TrimPrefix(rel.Target, "/")
+		}
+		if f, ok := files[name]; ok {
+			return f, nil
+		}
+		return nil, fmt.Errorf("missing %s", name)
+	}
+	return nil, errors.New("first sheet has no relationship")
+}
+
+func sharedStrings(f *zip.File) ([]string, error) {
+	var sst struct {
+		Items []xlsxText `xml:"si"`
+	}
+	if err := decodeZip(f, &sst); err != nil {
+		return nil, err
+	}
+	out := make([]string, len(sst.Items))
+	for i, si := range sst.Items {
+		out[i] = si.String()
+	}
+	return out, nil
+}
+
+// xlsxText Plain (<t>) or rich (<r><t>) text
+type xlsxText struct {
+	T    string `xml:"t"`
+	Runs []struct {
+		T string `xml:"t"`
+	} `xml:"r"`
+}
+
+func (t xlsxText) String() string {
+	if len(t.Runs) == 0 {
+		return t.T
+	}
+	var sb strings.Builder
+	for _, r := range t.Runs {
+		sb.WriteString(r.T)
+	}
+	return sb.String()
+}
+
+type xlsxRow struct {
+	R     int `xml:"r,attr"`
+	Cells []struct {
+		Ref    string   `xml:"r,attr"`
+		Type   string   `xml:"t,attr"`
+		Value  string   `xml:"v"`
+		Inline xlsxText `xml:"is"`
+	} `xml:"c"`
+}
+
+// line places each cell by its column reference; empty cells are omitted in
+// the XML, so position in the element list is not the column.
+func (r xlsxRow) line(shared []string) (line, error) {
+	var cells []string
+	for i, c := range r.Cells {
+		col := i
+		if c.Ref != "" {
+			var err error
+			if col, err = columnIndex(c.Ref); err != nil {
+				return line{}, err
+			}
+		}
+		if col >= maxColumns {
+			return line{}, fmt.Errorf("cell %d: %w", i+1, ErrBadCellRef)
+		}
+		v := c.Value
+		switch c.Type {
+		case "s":
+			n, err := strconv.Atoi(v)
+			if err != nil || n < 0 || n >= len(shared) {
+				return line{}, fmt.Errorf("cell %s: bad shared string %q", c.Ref, v)
+			}
+			v = shared[n]
+		case "inlineStr":
+			v = c.Inline.String()
+		case "b":
+			v = map[string]string{"0": "false", "1": "true"}[v]
+		}
+		for len(cells) <= col {
+			cells = append(cells, "")
+		}
+		cells[col] = v
+	}
+	return line{n: r.R, cells: cells}, nil
+}
+
+// ErrBadCellRef returned for a cell whose reference isn't a column's
+// letters followed by a row number, within a worksheet's 16384 columns
+var ErrBadCellRef = errors.New("not a cell reference (want e.g. B7, at most column XFD)")
+
+// maxColumns A worksheet's columns, A to XFD
+const maxColumns = 16384
+
+// columnIndex turns the letters of a reference like "AB12" into 27.
+func columnIndex(ref string) (int, error) {
+	letters := strings.IndexFunc(ref, func(ch rune) bool { return ch < 'A' || ch > 'Z' })
+	if letters <= 0 || letters > 3 {
+		return 0, fmt.Errorf("cell %q: %w", ref, ErrBadCellRef)
+	}
+	if _, err := strconv.ParseUint(ref[letters:], 10, 32); err != nil {
+		return 0, fmt.Errorf("cell %q: %w", ref, ErrBadCellRef)
+	}
+	n := 0
+	for _, ch := range ref[:letters] {
+		n = n*26 + int(ch-'A'+1)
+	}
+	if n > maxColumns {
+		return 0, fmt.Errorf("cell %q: %w", ref, ErrBadCellRef)
+	}
+	return n - 1, nil
+}
+
+func decodeFile(files map[string]*zip.File, name string, v any) error {
+	f, ok := files[name]
+	if !ok {
+		return fmt.Errorf("missing %s", name)
+	}
+	return decodeZip(f, v)
+}
+
+func decodeZip(f *zip.File, v any) error {
+	rc, err := f.Open()
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+	if err := xml.NewDecoder(rc).Decode(v); err != nil {
+		return fmt.Errorf("%s: %w", f.Name, err)
+	}
+	return nil
+}
+
+var _ RecordSource = (*XLSX)(nil)
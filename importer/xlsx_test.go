@@ -0,0 +1,97 @@
+package importer
+
+import (
+	"archive/zip"
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"go-solid/clock"
+	"go-solid/employee/memory"
+	"go-solid/id"
+	"go-solid/money"
+)
+
+// workbook is a minimal .xlsx whose first sheet has the given rows.
+func workbook(t *testing.T, rows ...string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for name, body := range map[string]string{
+		"xl/workbook.xml": `<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">` +
+			`<sheets><sheet name="Staff" sheetId="1" r:id="rId1"/></sheets></workbook>`,
+		"xl/_rels/workbook.xml.rels": `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+			`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/></Relationships>`,
+		"xl/worksheets/sheet1.xml": `<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>` +
+			strings.Join(rows, "") + `</sheetData></worksheet>`,
+	} {
+		w, err := zw.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, _ = w.Write([]byte(body))
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestColumnIndex(t *testing.T) {
+	tests := []struct {
+		ref     string
+		want    int
+		wantErr error
+	}{
+		{ref: "A1", want: 0},
+		{ref: "AB12", want: 27},
+		{ref: "XFD1048576", want: 16383},
+		{ref: "1", wantErr: ErrBadCellRef},
+		{ref: "", wantErr: ErrBadCellRef},
+		{ref: "B", wantErr: ErrBadCellRef},
+		{ref: "b2", wantErr: ErrBadCellRef},
+		{ref: "B2x", wantErr: ErrBadCellRef},
+		{ref: "XFE1", wantErr: ErrBadCellRef},
+		{ref: "XFDXFD1", wantErr: ErrBadCellRef},
+		{ref: "A" + strings.Repeat("9", 40), wantErr: ErrBadCellRef},
+	}
+	for _, tt := range tests {
+		t.Run(tt.ref, func(t *testing.T) {
+			got, err := columnIndex(tt.ref)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("columnIndex(%q) error = %v, want %v", tt.ref, err, tt.wantErr)
+			}
+			if tt.wantErr == nil && got != tt.want {
+				t.Errorf("columnIndex(%q) = %d, want %d", tt.ref, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestXLSX_BadCellRefIsARowError(t *testing.T) {
+	book := workbook(t,
+		`<row r="1"><c r="A1" t="inlineStr"><is><t>name</t></is></c><c r="B1" t="inlineStr"><is><t>salary</t></is></c></row>`,
+		`<row r="2"><c r="1" t="inlineStr"><is><t>Hana</t></is></c></row>`,
+		`<row r="3"><c r="XFDXFD3" t="inlineStr"><is><t>Karim</t></is></c></row>`,
+		`<row r="4"><c r="A4" t="inlineStr"><is><t>Omar</t></is></c><c r="B4"><v>5000</v></c></row>`,
+	)
+	repo := memory.New()
+	imp := New(repo, Rules{IDs: id.NewSequence("emp-"), Clock: clock.NewFake(time.Date(2026, time.March, 2, 9, 0, 0, 0, time.UTC)), Currency: money.USD})
+	report, err := imp.Import(t.Context(), NewXLSX(bytes.NewReader(book), int64(len(book))))
+	if err != nil {
+		t.Fatalf("Import() error = %v, want the bad rows rejected on their own", err)
+	}
+	if report.Read != 3 || report.Imported != 1 || len(report.Errors) != 2 {
+		t.Fatalf("Import() = %+v, want 3 read, 1 imported, 2 rejected", report)
+	}
+	for i, row := range []int{2, 3} {
+		if got := report.Errors[i]; got.Row != row || !errors.Is(got, ErrBadCellRef) {
+			t.Errorf("Errors[%d] = %v, want row %d rejected with %v", i, got, row, ErrBadCellRef)
+		}
+	}
+	if _, err := repo.GetByName(t.Context(), "Omar"); err != nil {
+		t.Errorf("the valid row wasn't imported: %v", err)
+	}
+}
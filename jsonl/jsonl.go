@@ -0,0 +1,73 @@
+// Package jsonl keeps values as JSON lines, one value per line: the format
+// audit records and telemetry events are written in. Sinks adapt it to
+// their own abstraction, and only the format lives here.
+package jsonl
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"os"
+	"sync"
+)
+
+// Writer Encodes values as JSON lines to any io.Writer. It is safe for
+// concurrent use: lines are never interleaved.
+type Writer struct {
+	mu  sync.Mutex
+	enc *json.Encoder
+}
+
+func NewWriter(w io.Writer) *Writer { return &Writer{enc: json.NewEncoder(w)} }
+
+// Write encodes v as one line.
+func (w *Writer) Write(v any) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.enc.Encode(v)
+}
+
+// File A Writer appending to a file, created if it doesn't exist
+type File struct {
+	*Writer
+	f *os.File
+}
+
+// Append opens path for appending.
+func Append(path string) (*File, error) {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		return nil, err
+	}
+	return &File{Writer: NewWriter(f), f: f}, nil
+}
+
+// Name is the path the file was opened with.
+func (f *File) Name() string { return f.f.Name() }
+
+func (f *File) Close() error { return f.f.Close() }
+
+// Read decodes the values of r one line at a time, stopping at the first
+// error.
+func Read[T any](r io.Reader) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		dec := json.NewDecoder(r)
+		for {
+			var v T
+			err := dec.Decode(&v)
+			if errors.Is(err, io.EOF) {
+				return
+			}
+			if err != nil {
+				var zero T
+				yield(zero, fmt.Errorf("jsonl: %w", err))
+				return
+			}
+			if !yield(v, nil) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,86 @@
+package jsonl_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"go-solid/jsonl"
+)
+
+type event struct {
+	N    int    `json:"n"`
+	Name string `json:"name"`
+}
+
+func TestFile_AppendAndRead(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "events.jsonl")
+	// the file is appended to, not truncated, when it is opened again
+	for _, batch := range [][]event{{{1, "hire"}, {2, "promote"}}, {{3, "fire"}}} {
+		f, err := jsonl.Append(path)
+		if err != nil {
+			t.Fatalf("Append() error = %v", err)
+		}
+		for _, e := range batch {
+			if err := f.Write(e); err != nil {
+				t.Fatalf("Write() error = %v", err)
+			}
+		}
+		if err := f.Close(); err != nil {
+			t.Fatalf("Close() error = %v", err)
+		}
+	}
+
+	r, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+	var got []event
+	for e, err := range jsonl.Read[event](r) {
+		if err != nil {
+			t.Fatalf("Read() error = %v", err)
+		}
+		got = append(got, e)
+	}
+	if len(got) != 3 || got[0] != (event{1, "hire"}) || got[2] != (event{3, "fire"}) {
+		t.Errorf("Read() = %v, want the three events in order", got)
+	}
+}
+
+func TestWriter_ConcurrentLines(t *testing.T) {
+	var buf strings.Builder
+	w := jsonl.NewWriter(&buf)
+	var wg sync.WaitGroup
+	for i := range 50 {
+		wg.Go(func() { _ = w.Write(event{i, strings.Repeat("x", 100)}) })
+	}
+	wg.Wait()
+	n := 0
+	for _, err := range jsonl.Read[event](strings.NewReader(buf.String())) {
+		if err != nil {
+			t.Fatalf("Read() error = %v, want whole lines", err)
+		}
+		n++
+	}
+	if n != 50 {
+		t.Errorf("Read() = %d lines, want 50", n)
+	}
+}
+
+func TestRead_StopsAtABrokenLine(t *testing.T) {
+	var got []event
+	var err error
+	for e, e2 := range jsonl.Read[event](strings.NewReader("{\"n\":1}\n{\"n\":\n")) {
+		if e2 != nil {
+			err = e2
+			break
+		}
+		got = append(got, e)
+	}
+	if err == nil || len(got) != 1 {
+		t.Errorf("Read() = %v, %v; want one event, then an error", got, err)
+	}
+}
@@ -0,0 +1,48 @@
+package leave
+
+import (
+	"context"
+	"strconv"
+
+	"go-solid/workflow"
+)
+
+// SubjectKind identifies leave requests among workflow subjects
+const SubjectKind = "leave"
+
+// Subject describes the request to a workflow.Engine; the number of days is
+// the "days" attribute.
+func (r Request) Subject() workflow.Subject {
+	return workflow.Subject{
+		Kind:      SubjectKind,
+		ID:        r.ID,
+		Requester: r.Employee,
+		Attrs:     map[string]string{"days": strconv.Itoa(r.Days)},
+	}
+}
+
+// Days reads the "days" attribute back from a leave subject.
+func Days(subj workflow.Subject) int {
+	days, _ := strconv.Atoi(subj.Attrs["days"])
+	return days
+}
+
+// StatusSync workflow.Listener that writes the workflow's decision back to
+// the leave request, so the leave package never drives approvals itself
+type StatusSync struct {
+	Repo Repository
+}
+
+func (s StatusSync) OnEntry(ctx context.Context, inst workflow.Instance, e workflow.Entry) error {
+	if e.Action != workflow.Decided || inst.Subject.Kind != SubjectKind {
+		return nil
+	}
+	req, err := s.Repo.Get(ctx, inst.Subject.ID)
+	if err != nil {
+		return err
+	}
+	req.Status = Status(inst.Status)
+	return s.Repo.Save(ctx, req)
+}
+
+var _ workflow.Listener = StatusSync{}
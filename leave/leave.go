@@ -0,0 +1,35 @@
+// Package leave models employees' leave (vacation) requests.
+package leave
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+type Status string
+
+const (
+	Pending  Status = "pending"
+	Approved Status = "approved"
+	Rejected Status = "rejected"
+)
+
+type Request struct {
+	ID          string
+	Employee    string
+	Days        int
+	Status      Status
+	RequestedAt time.Time
+}
+
+// ErrNotFound returned by repositories when no request matches
+var ErrNotFound = errors.New("leave request not found")
+
+// Repository Abstraction over leave request storage
+type Repository interface {
+	Save(ctx context.Context, req Request) error
+	Get(ctx context.Context, id string) (Request, error)
+	// ListByEmployee returns the employee's requests, oldest first.
+	ListByEmployee(ctx context.Context, employee string) ([]Request, error)
+}
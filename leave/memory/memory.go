@@ -0,0 +1,52 @@
+// Package memory is an in-process leave.Repository.
+package memory
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"go-solid/leave"
+)
+
+// Repository Low-level module - map-backed leave.Repository
+type Repository struct {
+	mu   sync.RWMutex
+	byID map[string]leave.Request
+}
+
+func New() *Repository {
+	return &Repository{byID: make(map[string]leave.Request)}
+}
+
+func (r *Repository) Save(ctx context.Context, req leave.Request) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.byID[req.ID] = req
+	return nil
+}
+
+func (r *Repository) Get(ctx context.Context, id string) (leave.Request, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	req, ok := r.byID[id]
+	if !ok {
+		return leave.Request{}, leave.ErrNotFound
+	}
+	return req, nil
+}
+
+func (r *Repository) ListByEmployee(ctx context.Context, employee string) ([]leave.Request, error) {
+	r.mu.RLock()
+	var reqs []leave.Request
+	for _, req := range r.byID {
+		if req.Employee == employee {
+			reqs = append(reqs, req)
+		}
+	}
+	r.mu.RUnlock()
+	sort.Slice(reqs, func(i, j int) bool { return reqs[i].RequestedAt.Before(reqs[j].RequestedAt) })
+	return reqs, nil
+}
+
+var _ leave.Repository = (*Repository)(nil)
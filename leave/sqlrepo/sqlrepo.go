@@ -0,0 +1,116 @@
+// Package sqlrepo is a leave.Repository on top of database/sql.
+package sqlrepo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"go-solid/leave"
+	"go-solid/sqldialect"
+)
+
+// Schema Table layout expected by the repository
+const Schema = `CREATE TABLE leave_requests (
+    id           VARCHAR(64)  NOT NULL PRIMARY KEY,
+    employee     VARCHAR(255) NOT NULL,
+    days         INTEGER      NOT NULL,
+    status       VARCHAR(16)  NOT NULL,
+    requested_at TIMESTAMP    NOT NULL
+)`
+
+// Repository Low-level module - SQL-backed leave.Repository
+type Repository struct {
+	db      *sql.DB
+	dialect sqldialect.Dialect
+	table   string
+}
+
+// Option customises a Repository created by New
+type Option func(*Repository)
+
+// WithTable uses a table other than "leave_requests", e.g. one per tenant.
+// The name is trusted: it is written into the SQL as is.
+func WithTable(name string) Option { return func(r *Repository) { r.table = name } }
+
+func New(db *sql.DB, dialect sqldialect.Dialect, opts ...Option) *Repository {
+	r := &Repository{db: db, dialect: dialect, table: "leave_requests"}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
+}
+
+// TableSchema is Schema for a table created under another name (WithTable).
+func TableSchema(table string) string {
+	return strings.Replace(Schema, "leave_requests", table, 1)
+}
+
+func (r *Repository) Save(ctx context.Context, req leave.Request) error {
+	res, err := r.db.ExecContext(ctx, r.dialect.Rebind(`UPDATE `+r.table+`
+		SET employee = ?, days = ?, status = ?, requested_at = ? WHERE id = ?`),
+		req.Employee, req.Days, string(req.Status), req.RequestedAt, req.ID)
+	if err != nil {
+		return fmt.Errorf("sqlrepo: update leave %q: %w", req.ID, err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("sqlrepo: update leave %q: %w", req.ID, err)
+	}
+	if n > 0 {
+		return nil
+	}
+	_, err = r.db.ExecContext(ctx, r.dialect.Rebind(`INSERT INTO `+r.table+`
+		(id, employee, days, status, requested_at) VALUES (?, ?, ?, ?, ?)`),
+		req.ID, req.Employee, req.Days, string(req.Status), req.RequestedAt)
+	if err != nil {
+		return fmt.Errorf("sqlrepo: insert leave %q: %w", req.ID, err)
+	}
+	return nil
+}
+
+func (r *Repository) Get(ctx context.Context, id string) (leave.Request, error) {
+	var req leave.Request
+	var status string
+	err := r.db.QueryRowContext(ctx, r.dialect.Rebind(`SELECT id, employee, days, status, requested_at
+		FROM `+r.table+` WHERE id = ?`), id).
+		Scan(&req.ID, &req.Employee, &req.Days, &status, &req.RequestedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return leave.Request{}, leave.ErrNotFound
+	}
+	if err != nil {
+		return leave.Request{}, fmt.Errorf("sqlrepo: get leave %q: %w", id, err)
+	}
+	req.Status = leave.Status(status)
+	return req, nil
+}
+
+func (r *Repository) ListByEmployee(ctx context.Context, employee string) ([]leave.Request, error) {
+	rows, err := r.db.QueryContext(ctx, r.dialect.Rebind(`SELECT id, employee, days, status, requested_at
+		FROM `+r.table+` WHERE employee = ? ORDER BY requested_at`), employee)
+	if err != nil {
+		return nil, fmt.Errorf("sqlrepo: list leave of %q: %w", employee, err)
+	}
+	defer rows.Close()
+
+	var reqs []leave.Request
+	for rows.Next() {
+		var req leave.Request
+		var status string
+		if err := rows.Scan(&req.ID, &req.Employee, &req.Days, &status, &req.RequestedAt); err != nil {
+			return nil, fmt.Errorf("sqlrepo: list leave of %q: %w", employee, err)
+		}
+		req.Status = leave.Status(status)
+		reqs = append(reqs, req)
+	}
+	return reqs, rows.Err()
+}
+
+// CheckHealth pings the database (health.Checker).
+func (r *Repository) CheckHealth(ctx context.Context) error {
+	return r.db.PingContext(ctx)
+}
+
+var _ leave.Repository = (*Repository)(nil)
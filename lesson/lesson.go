@@ -0,0 +1,223 @@
+// Package lesson walks a learner through numbered checkpoints of a principle.
+//
+// A Store holds the checkpoints: complete code trees, one per step. A
+// Workspace materializes one of them in a directory and records what it
+// wrote in a small state file - just enough version control to tell the
+// learner's edits apart from the checkpoint, refuse to overwrite them by
+// accident, and diff them against the reference solution.
+package lesson
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Checkpoint One numbered step of a lesson
+type Checkpoint struct {
+	Lesson string
+	Number int
+	Name   string // directory name, e.g. "01-everything-in-employee"
+	Title  string // from the manifest, or the first heading of its TASK.md
+	// Exercise is what the learner has to do, nil for a checkpoint that
+	// only shows the last solution
+	Exercise *Exercise
+}
+
+// Exercise A checkpoint's manifest: the structured part of its task
+type Exercise struct {
+	ID    string   `json:"-"` // lesson and checkpoint number, e.g. "srp-1"
+	Title string   `json:"title"`
+	Hints []string `json:"hints"` // from a nudge to nearly the solution
+}
+
+func (c Checkpoint) String() string { return fmt.Sprintf("%s %d: %s", c.Lesson, c.Number, c.Title) }
+
+// Store Abstraction - where lessons and their code trees come from
+type Store interface {
+	Lessons() ([]string, error)
+	Checkpoints(lesson string) ([]Checkpoint, error)
+	// Tree returns every file of a checkpoint, by slash-separated path
+	Tree(cp Checkpoint) (map[string][]byte, error)
+}
+
+var (
+	ErrUnknownLesson   = errors.New("unknown lesson")
+	ErrUnknownExercise = errors.New("unknown exercise")
+	ErrNoLesson        = errors.New("no lesson started")
+	ErrLastStep        = errors.New("already at the last checkpoint")
+	ErrFirstStep       = errors.New("already at the first checkpoint")
+)
+
+const (
+	// TaskFile Describes a checkpoint's exercise; its first line is the title
+	TaskFile = "TASK.md"
+	// ManifestFile Makes a checkpoint an exercise. It holds the hints, so it
+	// is never copied into the workspace.
+	ManifestFile = "exercise.json"
+)
+
+// FindExercise looks an exercise up by ID across every lesson in store.
+func FindExercise(store Store, id string) (Checkpoint, error) {
+	lessons, err := store.Lessons()
+	if err != nil {
+		return Checkpoint{}, err
+	}
+	for _, name := range lessons {
+		cps, err := store.Checkpoints(name)
+		if err != nil {
+			return Checkpoint{}, err
+		}
+		for _, cp := range cps {
+			if cp.Exercise != nil && cp.Exercise.ID == id {
+				return cp, nil
+			}
+		}
+	}
+	return Checkpoint{}, fmt.Errorf("%w %q", ErrUnknownExercise, id)
+}
+
+// FSStore Store over a file system laid out as <n>-<lesson>/<nn>-<step>/...,
+// e.g. the embedded lessons.FS. The number prefixes fix the order and are
+// dropped from lesson names.
+type FSStore struct {
+	FS fs.FS
+}
+
+func (s FSStore) Lessons() ([]string, error) {
+	dirs, err := s.dirs(".")
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, len(dirs))
+	for i, d := range dirs {
+		_, names[i] = split(d)
+	}
+	return names, nil
+}
+
+func (s FSStore) Checkpoints(lesson string) ([]Checkpoint, error) {
+	dir, err := s.LessonDir(lesson)
+	if err != nil {
+		return nil, err
+	}
+	steps, err := s.dirs(dir)
+	if err != nil {
+		return nil, err
+	}
+	cps := make([]Checkpoint, len(steps))
+	for i, step := range steps {
+		n, _ := split(step)
+		cps[i] = Checkpoint{Lesson: lesson, Number: n, Name: step, Title: step}
+		if task, err := fs.ReadFile(s.FS, path.Join(dir, step, TaskFile)); err == nil {
+			first, _, _ := strings.Cut(string(task), "\n")
+			cps[i].Title = strings.TrimSpace(strings.TrimLeft(first, "# "))
+		}
+		ex, err := s.manifest(path.Join(dir, step))
+		if err != nil {
+			return nil, err
+		}
+		if ex != nil {
+			ex.ID = fmt.Sprintf("%s-%d", lesson, n)
+			cps[i].Exercise = ex
+			cps[i].Title = ex.Title
+		}
+	}
+	return cps, nil
+}
+
+// manifest reads the ManifestFile in dir, if there is one. A manifest that
+// doesn't parse is an error rather than a checkpoint without hints.
+func (s FSStore) manifest(dir string) (*Exercise, error) {
+	data, err := fs.ReadFile(s.FS, path.Join(dir, ManifestFile))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var ex Exercise
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&ex); err != nil {
+		return nil, fmt.Errorf("%s: %w", path.Join(dir, ManifestFile), err)
+	}
+	if ex.Title == "" {
+		return nil, fmt.Errorf("%s: missing title", path.Join(dir, ManifestFile))
+	}
+	return &ex, nil
+}
+
+func (s FSStore) Tree(cp Checkpoint) (map[string][]byte, error) {
+	dir, err := s.LessonDir(cp.Lesson)
+	if err != nil {
+		return nil, err
+	}
+	root := path.Join(dir, cp.Name)
+	tree := map[string][]byte{}
+	err = fs.WalkDir(s.FS, root, func(p string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() || p == path.Join(root, ManifestFile) {
+			return err
+		}
+		data, err := fs.ReadFile(s.FS, p)
+		if err != nil {
+			return err
+		}
+		tree[strings.TrimPrefix(p, root+"/")] = data
+		return nil
+	})
+	return tree, err
+}
+
+// LessonDir returns the directory holding lesson, e.g. "2-ocp" for "ocp".
+func (s FSStore) LessonDir(lesson string) (string, error) {
+	dirs, err := s.dirs(".")
+	if err != nil {
+		return "", err
+	}
+	for _, d := range dirs {
+		if _, name := split(d); name == lesson {
+			return d, nil
+		}
+	}
+	return "", fmt.Errorf("%w %q", ErrUnknownLesson, lesson)
+}
+
+// dirs lists the numbered subdirectories of dir in numeric order.
+func (s FSStore) dirs(dir string) ([]string, error) {
+	entries, err := fs.ReadDir(s.FS, dir)
+	if err != nil {
+		return nil, err
+	}
+	var dirs []string
+	for _, e := range entries {
+		if n, _ := split(e.Name()); e.IsDir() && n > 0 {
+			dirs = append(dirs, e.Name())
+		}
+	}
+	sort.Slice(dirs, func(i, j int) bool {
+		a, _ := split(dirs[i])
+		b, _ := split(dirs[j])
+		return a < b
+	})
+	return dirs, nil
+}
+
+// split turns "02-role-interface" into 2 and "role-interface"; names without
+// a number prefix give 0.
+func split(name string) (int, string) {
+	num, rest, ok := strings.Cut(name, "-")
+	n, err := strconv.Atoi(num)
+	if !ok || err != nil {
+		return 0, name
+	}
+	return n, rest
+}
+
+var _ Store = FSStore{}
@@ -0,0 +1,237 @@
+package lesson
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"go-solid/textdiff"
+)
+
+// StateFile Kept in the workspace: which checkpoint is materialized and the
+// hash of every file as written
+const StateFile = ".lesson.json"
+
+type state struct {
+	Lesson string            `json:"lesson"`
+	Step   int               `json:"step"` // index into the lesson's checkpoints
+	Files  map[string]string `json:"files"`
+}
+
+// ModifiedError returned instead of overwriting files the learner changed
+type ModifiedError struct {
+	Files []string
+}
+
+func (e *ModifiedError) Error() string {
+	return fmt.Sprintf("workspace has changes to %s; diff them against the reference, or force to discard them", strings.Join(e.Files, ", "))
+}
+
+// Workspace A directory holding one checkpoint at a time
+type Workspace struct {
+	Dir   string
+	Store Store
+}
+
+// Status A workspace's checkpoint and which of its files the learner changed
+type Status struct {
+	Checkpoint Checkpoint
+	Of         int // checkpoints in the lesson
+	Modified   []string
+}
+
+// Start materializes the first checkpoint of lesson. A workspace holding
+// changes is only overwritten with force.
+func (w *Workspace) Start(lesson string, force bool) (Checkpoint, error) {
+	cps, err := w.Store.Checkpoints(lesson)
+	if err != nil {
+		return Checkpoint{}, err
+	}
+	if len(cps) == 0 {
+		return Checkpoint{}, fmt.Errorf("%w %q: no checkpoints", ErrUnknownLesson, lesson)
+	}
+	st, err := w.load()
+	if err != nil && !errors.Is(err, ErrNoLesson) {
+		return Checkpoint{}, err
+	}
+	return w.move(st, cps, 0, force)
+}
+
+// Next materializes the following checkpoint, the reference solution for the
+// current one.
+func (w *Workspace) Next(force bool) (Checkpoint, error) { return w.step(+1, force) }
+
+// Prev goes back one checkpoint.
+func (w *Workspace) Prev(force bool) (Checkpoint, error) { return w.step(-1, force) }
+
+// Reset materializes the current checkpoint again, discarding changes.
+func (w *Workspace) Reset() (Checkpoint, error) { return w.step(0, true) }
+
+func (w *Workspace) step(delta int, force bool) (Checkpoint, error) {
+	st, err := w.load()
+	if err != nil {
+		return Checkpoint{}, err
+	}
+	cps, err := w.Store.Checkpoints(st.Lesson)
+	if err != nil {
+		return Checkpoint{}, err
+	}
+	to := st.Step + delta
+	switch {
+	case to >= len(cps):
+		return Checkpoint{}, ErrLastStep
+	case to < 0:
+		return Checkpoint{}, ErrFirstStep
+	}
+	return w.move(st, cps, to, force)
+}
+
+func (w *Workspace) move(st state, cps []Checkpoint, to int, force bool) (Checkpoint, error) {
+	if !force {
+		modified, err := w.modified(st)
+		if err != nil {
+			return Checkpoint{}, err
+		}
+		if len(modified) > 0 {
+			return Checkpoint{}, &ModifiedError{Files: modified}
+		}
+	}
+	tree, err := w.Store.Tree(cps[to])
+	if err != nil {
+		return Checkpoint{}, err
+	}
+	// files of the old checkpoint that the new one doesn't have; anything
+	// the learner added is theirs and stays
+	for file := range st.Files {
+		if _, keep := tree[file]; !keep {
+			if err := os.Remove(w.path(file)); err != nil && !errors.Is(err, fs.ErrNotExist) {
+				return Checkpoint{}, err
+			}
+		}
+	}
+	next := state{Lesson: cps[to].Lesson, Step: to, Files: map[string]string{}}
+	for file, data := range tree {
+		if err := os.MkdirAll(filepath.Dir(w.path(file)), 0o755); err != nil {
+			return Checkpoint{}, err
+		}
+		if err := os.WriteFile(w.path(file), data, 0o644); err != nil {
+			return Checkpoint{}, err
+		}
+		next.Files[file] = hash(data)
+	}
+	return cps[to], w.save(next)
+}
+
+// Status reports the current checkpoint and the learner's changes to it.
+func (w *Workspace) Status() (Status, error) {
+	st, err := w.load()
+	if err != nil {
+		return Status{}, err
+	}
+	cps, err := w.Store.Checkpoints(st.Lesson)
+	if err != nil {
+		return Status{}, err
+	}
+	if st.Step >= len(cps) {
+		return Status{}, fmt.Errorf("lesson: %s has no checkpoint %d", st.Lesson, st.Step+1)
+	}
+	modified, err := w.modified(st)
+	return Status{Checkpoint: cps[st.Step], Of: len(cps), Modified: modified}, err
+}
+
+// Diff writes a unified diff from the workspace to the reference: the next
+// checkpoint, or the current one at the end of a lesson.
+func (w *Workspace) Diff(out io.Writer) error {
+	st, err := w.load()
+	if err != nil {
+		return err
+	}
+	cps, err := w.Store.Checkpoints(st.Lesson)
+	if err != nil {
+		return err
+	}
+	ref := cps[min(st.Step+1, len(cps)-1)]
+	tree, err := w.Store.Tree(ref)
+	if err != nil {
+		return err
+	}
+	files := make([]string, 0, len(tree)+len(st.Files))
+	for file := range tree {
+		files = append(files, file)
+	}
+	for file := range st.Files {
+		if _, ok := tree[file]; !ok {
+			files = append(files, file)
+		}
+	}
+	slices.Sort(files)
+	for _, file := range files {
+		if file == TaskFile {
+			continue // the exercise text always differs
+		}
+		mine, err := os.ReadFile(w.path(file))
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return err
+		}
+		if err := textdiff.Unified(out, "workspace/"+file, ref.Name+"/"+file, string(mine), string(tree[file])); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (w *Workspace) modified(st state) ([]string, error) {
+	var changed []string
+	for file, sum := range st.Files {
+		data, err := os.ReadFile(w.path(file))
+		switch {
+		case errors.Is(err, fs.ErrNotExist):
+			changed = append(changed, file) // deleted
+		case err != nil:
+			return nil, err
+		case hash(data) != sum:
+			changed = append(changed, file)
+		}
+	}
+	slices.Sort(changed)
+	return changed, nil
+}
+
+func (w *Workspace) load() (state, error) {
+	data, err := os.ReadFile(w.path(StateFile))
+	if errors.Is(err, fs.ErrNotExist) {
+		return state{}, ErrNoLesson
+	}
+	if err != nil {
+		return state{}, err
+	}
+	var st state
+	if err := json.Unmarshal(data, &st); err != nil {
+		return state{}, fmt.Errorf("lesson: %s: %w", StateFile, err)
+	}
+	return st, nil
+}
+
+func (w *Workspace) save(st state) error {
+	data, err := json.MarshalIndent(st, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(w.path(StateFile), append(data, '\n'), 0o644)
+}
+
+func (w *Workspace) path(file string) string { return filepath.Join(w.Dir, filepath.FromSlash(file)) }
+
+func hash(data []byte) string {
+	sum := sha256.Sum256(bytes.ReplaceAll(data, []byte("\r\n"), []byte("\n")))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,28 @@
+package main
+
+import "fmt"
+
+type employee struct {
+	firstName string
+	lastName  string
+	email     string
+}
+
+func (em *employee) getFullName() string {
+	return fmt.Sprintf("%s %s", em.firstName, em.lastName)
+}
+
+func (em *employee) getEmail() string {
+	return em.email
+}
+
+// saveEmployee ❌ storage is a different responsibility
+func (em *employee) saveEmployee() {
+	fmt.Printf("Saving employee: %s (%s)\n", em.getFullName(), em.email)
+}
+
+func main() {
+	em := employee{firstName: "Mohamed", lastName: "Habib", email: "mohamed@gmail.com"}
+	fmt.Println(em.getFullName(), em.getEmail())
+	em.saveEmployee()
+}
@@ -0,0 +1,25 @@
+package main
+
+import "fmt"
+
+type employee struct {
+	name string
+	role string
+}
+
+// getSalary ❌ must be modified for every new role
+func (em employee) getSalary() int {
+	if em.role == "SWE" {
+		return 3000
+	} else if em.role == "SSWE" {
+		return 5000
+	}
+	return 0
+}
+
+func main() {
+	em1 := employee{name: "Mohamed", role: "SWE"}
+	em2 := employee{name: "Ahmed", role: "SSWE"}
+	fmt.Println("Salary", em1.getSalary())
+	fmt.Println("Salary", em2.getSalary())
+}
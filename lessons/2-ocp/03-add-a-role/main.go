@@ -0,0 +1,39 @@
+package main
+
+import "fmt"
+
+type role interface {
+	getSalary() int
+}
+
+type employee struct {
+	name string
+	role role
+}
+
+// getSalary ✅ closed for modification: new roles don't touch it
+func (em employee) getSalary() int {
+	return em.role.getSalary()
+}
+
+type swe struct{}
+
+func (swe) getSalary() int { return 3000 }
+
+type sswe struct{}
+
+func (sswe) getSalary() int { return 5000 }
+
+// lead ✅ extension: a new type, no existing line changed
+type lead struct{}
+
+func (lead) getSalary() int { return 7000 }
+
+func main() {
+	em1 := employee{name: "Mohamed", role: swe{}}
+	em2 := employee{name: "Ahmed", role: sswe{}}
+	em3 := employee{name: "Ali", role: lead{}}
+	fmt.Println("Salary", em1.getSalary())
+	fmt.Println("Salary", em2.getSalary())
+	fmt.Println("Salary", em3.getSalary())
+}
@@ -0,0 +1,50 @@
+package main
+
+import "fmt"
+
+type baseEmployee interface {
+	getName() string
+	getSalary() int
+}
+
+type fullTimeEmployee struct {
+	name   string
+	salary int
+}
+
+func (em fullTimeEmployee) getName() string { return em.name }
+
+func (em fullTimeEmployee) getSalary() int { return em.salary }
+
+type contractorEmployee struct {
+	name        string
+	hourlyRate  int
+	hoursWorked int
+}
+
+func (cem contractorEmployee) getName() string { return cem.name }
+
+// getSalary ❌ -1 means "not invoiced yet" - a meaning only contractors have
+func (cem contractorEmployee) getSalary() int {
+	if cem.hoursWorked == 0 {
+		return -1
+	}
+	return cem.hourlyRate * cem.hoursWorked
+}
+
+func printEmployeeInfo(em baseEmployee) {
+	// snippet:begin subtype-check
+	// ❌ the caller has to know about one particular subtype
+	if _, ok := em.(contractorEmployee); ok && em.getSalary() < 0 {
+		fmt.Printf("Name: %s, Salary: not invoiced\n", em.getName())
+		return
+	}
+	// snippet:end subtype-check
+	fmt.Printf("Name: %s, Salary: %d\n", em.getName(), em.getSalary())
+}
+
+func main() {
+	printEmployeeInfo(fullTimeEmployee{name: "Mohamed", salary: 5000})
+	printEmployeeInfo(contractorEmployee{name: "Ahmed", hourlyRate: 120, hoursWorked: 10})
+	printEmployeeInfo(contractorEmployee{name: "Ali", hourlyRate: 120})
+}
@@ -0,0 +1,30 @@
+package main
+
+import (
+	"testing"
+
+	"go-solid/assertlsp"
+)
+
+// script is what code written against baseEmployee may do with any of them
+var script = []assertlsp.Step[baseEmployee]{
+	assertlsp.Call("getName", func(em baseEmployee) any { return em.getName() }),
+	assertlsp.Call("getSalary", func(em baseEmployee) any { return em.getSalary() }),
+}
+
+// TestContractor_NotSubstitutable ❌ pins the violation this exercise is
+// about: before any hours, a contractor's salary is -1 where an employee
+// earning nothing says 0. Once the contractor is fixed this test fails -
+// turn it into assertlsp.SameBehavior, as the next checkpoint does.
+func TestContractor_NotSubstitutable(t *testing.T) {
+	diffs := assertlsp.Compare[baseEmployee](fullTimeEmployee{name: "Ali", salary: 0}, contractorEmployee{name: "Ali", hourlyRate: 120}, script...)
+	if len(diffs) != 1 || diffs[0].Name != "getSalary" {
+		t.Fatalf("Compare() = %v, want the contractor to differ on getSalary only", diffs)
+	}
+	t.Logf("contractor isn't substitutable: %s", diffs[0])
+}
+
+// TestContractor_HoursLogged ✅ with hours logged the two already agree.
+func TestContractor_HoursLogged(t *testing.T) {
+	assertlsp.SameBehavior[baseEmployee](t, fullTimeEmployee{name: "Ahmed", salary: 1200}, contractorEmployee{name: "Ahmed", hourlyRate: 120, hoursWorked: 10}, script...)
+}
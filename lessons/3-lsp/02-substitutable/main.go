@@ -0,0 +1,39 @@
+package main
+
+import "fmt"
+
+type baseEmployee interface {
+	getName() string
+	getSalary() int
+}
+
+type fullTimeEmployee struct {
+	name   string
+	salary int
+}
+
+func (em fullTimeEmployee) getName() string { return em.name }
+
+func (em fullTimeEmployee) getSalary() int { return em.salary }
+
+type contractorEmployee struct {
+	name        string
+	hourlyRate  int
+	hoursWorked int
+}
+
+func (cem contractorEmployee) getName() string { return cem.name }
+
+// getSalary ✅ zero hours is simply zero pay - no special value
+func (cem contractorEmployee) getSalary() int { return cem.hourlyRate * cem.hoursWorked }
+
+// printEmployeeInfo ✅ works for every baseEmployee without knowing which one
+func printEmployeeInfo(em baseEmployee) {
+	fmt.Printf("Name: %s, Salary: %d\n", em.getName(), em.getSalary())
+}
+
+func main() {
+	printEmployeeInfo(fullTimeEmployee{name: "Mohamed", salary: 5000})
+	printEmployeeInfo(contractorEmployee{name: "Ahmed", hourlyRate: 120, hoursWorked: 10})
+	printEmployeeInfo(contractorEmployee{name: "Ali", hourlyRate: 120})
+}
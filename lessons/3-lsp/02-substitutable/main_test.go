@@ -0,0 +1,31 @@
+package main
+
+import (
+	"testing"
+
+	"go-solid/assertlsp"
+)
+
+// script is what code written against baseEmployee may do with any of them
+var script = []assertlsp.Step[baseEmployee]{
+	assertlsp.Call("getName", func(em baseEmployee) any { return em.getName() }),
+	assertlsp.Call("getSalary", func(em baseEmployee) any { return em.getSalary() }),
+}
+
+// TestContractor_Substitutable ✅ a contractor behaves like an employee
+// earning what the contractor has invoiced - nothing, before any hours.
+func TestContractor_Substitutable(t *testing.T) {
+	tests := []struct {
+		name       string
+		fullTime   fullTimeEmployee
+		contractor contractorEmployee
+	}{
+		{"hours logged", fullTimeEmployee{name: "Ahmed", salary: 1200}, contractorEmployee{name: "Ahmed", hourlyRate: 120, hoursWorked: 10}},
+		{"no hours yet", fullTimeEmployee{name: "Ali", salary: 0}, contractorEmployee{name: "Ali", hourlyRate: 120}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertlsp.SameBehavior[baseEmployee](t, tt.fullTime, tt.contractor, script...)
+		})
+	}
+}
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Employee ❌ one interface for everything anyone might do
+type Employee interface {
+	GetName() string
+	CalculateMonthlyPay() float64
+	ApproveLeave(days int) error
+	AssignTask(task string, assignee Employee) error
+}
+
+type Developer struct {
+	Name   string
+	Salary float64
+}
+
+func (d Developer) GetName() string              { return d.Name }
+func (d Developer) CalculateMonthlyPay() float64 { return d.Salary }
+
+// ApproveLeave ❌ forced to exist, can only fail
+func (d Developer) ApproveLeave(days int) error { return errors.New("developer cannot approve leave") }
+func (d Developer) AssignTask(task string, assignee Employee) error {
+	return errors.New("developer cannot assign tasks")
+}
+
+type Manager struct {
+	Name   string
+	Salary float64
+}
+
+func (m Manager) GetName() string              { return m.Name }
+func (m Manager) CalculateMonthlyPay() float64 { return m.Salary }
+func (m Manager) ApproveLeave(days int) error  { return nil }
+func (m Manager) AssignTask(task string, assignee Employee) error {
+	fmt.Printf("Manager %s assigned '%s' to %s\n", m.Name, task, assignee.GetName())
+	return nil
+}
+
+func AssignWork(assigner Employee, assignee Employee, task string) {
+	if err := assigner.AssignTask(task, assignee); err != nil {
+		fmt.Println("❌ runtime error:", err) // the compiler could have caught this
+	}
+}
+
+func main() {
+	dev := Developer{Name: "Alice", Salary: 3000}
+	mgr := Manager{Name: "Bob", Salary: 5000}
+	AssignWork(mgr, dev, "Implement new feature")
+	AssignWork(dev, mgr, "Review code")
+}
@@ -0,0 +1,50 @@
+package main
+
+import "fmt"
+
+// Employee ✅ the minimum everyone shares
+type Employee interface {
+	GetName() string
+}
+
+// PaidEmployee Only for people on the payroll
+type PaidEmployee interface {
+	Employee
+	CalculateMonthlyPay() float64
+}
+
+// TaskAssigner Only for people who can assign work
+type TaskAssigner interface {
+	AssignTask(task string, assignee Employee) error
+}
+
+type Developer struct {
+	Name   string
+	Salary float64
+}
+
+func (d Developer) GetName() string              { return d.Name }
+func (d Developer) CalculateMonthlyPay() float64 { return d.Salary }
+
+type Manager struct {
+	Name   string
+	Salary float64
+}
+
+func (m Manager) GetName() string              { return m.Name }
+func (m Manager) CalculateMonthlyPay() float64 { return m.Salary }
+func (m Manager) AssignTask(task string, assignee Employee) error {
+	fmt.Printf("Manager %s assigned '%s' to %s\n", m.Name, task, assignee.GetName())
+	return nil
+}
+
+func AssignWork(assigner TaskAssigner, assignee Employee, task string) {
+	_ = assigner.AssignTask(task, assignee)
+}
+
+func main() {
+	dev := Developer{Name: "Alice", Salary: 3000}
+	mgr := Manager{Name: "Bob", Salary: 5000}
+	AssignWork(mgr, dev, "Implement new feature")
+	// AssignWork(dev, mgr, "Review code") // ✅ compile error: Developer is not a TaskAssigner
+}
@@ -0,0 +1,23 @@
+package main
+
+import "fmt"
+
+type MySQLDatabase struct{}
+
+func (db MySQLDatabase) SaveToMySQL(name string) {
+	fmt.Println("Saving employee to MySQL:", name)
+}
+
+// EmployeeManager ❌ high-level policy welded to one low-level module
+type EmployeeManager struct {
+	database MySQLDatabase
+}
+
+func (em EmployeeManager) SaveEmployee(name string) {
+	em.database.SaveToMySQL(name)
+}
+
+func main() {
+	manager := EmployeeManager{database: MySQLDatabase{}}
+	manager.SaveEmployee("Mohamed")
+}
@@ -0,0 +1,38 @@
+package main
+
+import "fmt"
+
+// EmployeeRepository ✅ the abstraction both sides depend on
+type EmployeeRepository interface {
+	Save(name string) error
+}
+
+type MySQLRepository struct{}
+
+func (MySQLRepository) Save(name string) error {
+	fmt.Println("Saving employee to MySQL:", name)
+	return nil
+}
+
+type PostgresRepository struct{}
+
+func (PostgresRepository) Save(name string) error {
+	fmt.Println("Saving employee to PostgreSQL:", name)
+	return nil
+}
+
+// EmployeeManager ✅ knows the abstraction only
+type EmployeeManager struct {
+	repository EmployeeRepository
+}
+
+func (em EmployeeManager) SaveEmployee(name string) {
+	if err := em.repository.Save(name); err != nil {
+		fmt.Println("Error saving employee:", err)
+	}
+}
+
+func main() {
+	EmployeeManager{repository: MySQLRepository{}}.SaveEmployee("Mohamed")
+	EmployeeManager{repository: PostgresRepository{}}.SaveEmployee("Ahmed")
+}
@@ -0,0 +1,16 @@
+// Package lessons holds the checkpoints materialized by solid lesson.
+//
+// Each principle is a directory of numbered checkpoints, and each checkpoint
+// is a complete code tree: a TASK.md describing the exercise and the code to
+// start from. The next checkpoint is the reference solution. Every tree is
+// a real program, so go vet keeps them all compiling. A checkpoint that is
+// an exercise also has an exercise.json manifest with its title and hints.
+//
+// Next to its checkpoints, each lesson has the content package's files: its
+// text (LESSON.md), its quiz bank (quiz.json) and its diagrams.
+package lessons
+
+import "embed"
+
+//go:embed 1-srp 2-ocp 3-lsp 4-isp 5-dip
+var FS embed.FS
@@ -0,0 +1,27 @@
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+)
+
+// HTTPServer Adapter - runs an *http.Server and shuts it down gracefully
+type HTTPServer struct {
+	Server *http.Server
+}
+
+func (h HTTPServer) Run(context.Context) error {
+	if err := h.Server.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+func (h HTTPServer) Stop(ctx context.Context) error { return h.Server.Shutdown(ctx) }
+
+// Closer Adapter - turns an io.Closer into a Stopper
+func Closer(c io.Closer) Stopper {
+	return StopFunc(func(context.Context) error { return c.Close() })
+}
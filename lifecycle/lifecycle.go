@@ -0,0 +1,166 @@
+// Package lifecycle starts an application's components in order and stops
+// them in reverse order, on a signal or when one of them fails.
+//
+// Components only implement the small interfaces they need (ISP): Starter for
+// one-off initialisation, Runner for something that blocks until told to
+// stop, Stopper for cleanup. The Group doesn't care what they are.
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+
+	"go-solid/nullobj"
+)
+
+// Starter Component with initialisation that must finish before the next one starts
+type Starter interface {
+	Start(ctx context.Context) error
+}
+
+// Runner Component that blocks until its context is cancelled
+type Runner interface {
+	Run(ctx context.Context) error
+}
+
+// Stopper Component needing cleanup, given ctx as a deadline
+type Stopper interface {
+	Stop(ctx context.Context) error
+}
+
+// StartFunc, RunFunc and StopFunc adapt plain functions
+type (
+	StartFunc func(ctx context.Context) error
+	RunFunc   func(ctx context.Context) error
+	StopFunc  func(ctx context.Context) error
+)
+
+func (f StartFunc) Start(ctx context.Context) error { return f(ctx) }
+func (f RunFunc) Run(ctx context.Context) error     { return f(ctx) }
+func (f StopFunc) Stop(ctx context.Context) error   { return f(ctx) }
+
+// Group Ordered set of components
+type Group struct {
+	// ShutdownTimeout bounds the whole shutdown; defaults to 10s.
+	ShutdownTimeout time.Duration
+	// Signals trigger a graceful shutdown; defaults to SIGINT and SIGTERM.
+	Signals []os.Signal
+	Logger  *slog.Logger
+
+	components []component
+}
+
+type component struct {
+	name string
+	impl any
+}
+
+// Add registers a component, which must implement at least one of Starter,
+// Runner or Stopper. Components start in the order they are added.
+func (g *Group) Add(name string, c any) {
+	switch c.(type) {
+	case Starter, Runner, Stopper:
+	default:
+		panic(fmt.Sprintf("lifecycle: %s (%T) implements none of Starter, Runner, Stopper", name, c))
+	}
+	g.components = append(g.components, component{name, c})
+}
+
+// Run starts every component, waits for ctx to end, a signal, or a Runner to
+// fail, then stops the started components in reverse order. It returns the
+// first failure joined with any shutdown errors.
+func (g *Group) Run(ctx context.Context) error {
+	logger := g.Logger
+	if logger == nil {
+		logger = nullobj.NopLogger()
+	}
+	signals := g.Signals
+	if signals == nil {
+		signals = []os.Signal{os.Interrupt, syscall.SIGTERM}
+	}
+	ctx, stopSignals := signal.NotifyContext(ctx, signals...)
+	defer stopSignals()
+
+	failed := make(chan error, len(g.components))
+	runners := make(map[int]*running)
+	var wg sync.WaitGroup
+
+	started := 0
+	var cause error
+	for i, c := range g.components {
+		if s, ok := c.impl.(Starter); ok {
+			if err := s.Start(ctx); err != nil {
+				cause = fmt.Errorf("start %s: %w", c.name, err)
+				break
+			}
+		}
+		if r, ok := c.impl.(Runner); ok {
+			runCtx, cancel := context.WithCancel(context.Background())
+			state := &running{cancel: cancel, done: make(chan struct{})}
+			runners[i] = state
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				defer close(state.done)
+				if err := r.Run(runCtx); err != nil && !errors.Is(err, context.Canceled) {
+					failed <- fmt.Errorf("%s: %w", c.name, err)
+				}
+			}()
+		}
+		started = i + 1
+		logger.Info("component started", "component", c.name)
+	}
+
+	if cause == nil {
+		select {
+		case <-ctx.Done():
+			logger.Info("shutting down", "reason", context.Cause(ctx))
+		case cause = <-failed:
+			logger.Error("component failed, shutting down", "err", cause)
+		}
+	}
+
+	timeout := g.ShutdownTimeout
+	if timeout <= 0 {
+		timeout = 10 * time.Second
+	}
+	stopCtx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	errs := []error{cause}
+	for i := started - 1; i >= 0; i-- {
+		c := g.components[i]
+		if s, ok := c.impl.(Stopper); ok {
+			if err := s.Stop(stopCtx); err != nil {
+				errs = append(errs, fmt.Errorf("stop %s: %w", c.name, err))
+			}
+		}
+		if r, ok := runners[i]; ok {
+			// a Runner's context is cancelled after its Stop (if any) returned
+			r.cancel()
+			select {
+			case <-r.done:
+			case <-stopCtx.Done():
+				errs = append(errs, fmt.Errorf("stop %s: %w", c.name, stopCtx.Err()))
+			}
+		}
+		logger.Info("component stopped", "component", c.name)
+	}
+	for _, r := range runners {
+		r.cancel()
+	}
+	wg.Wait()
+	return errors.Join(errs...)
+}
+
+type running struct {
+	cancel context.CancelFunc
+	done   chan struct{}
+}
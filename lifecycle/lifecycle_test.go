@@ -0,0 +1,169 @@
+package lifecycle_test
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"go-solid/lifecycle"
+)
+
+// journal What the components did, in order
+type journal struct {
+	mu      sync.Mutex
+	entries []string
+}
+
+func (j *journal) write(entry string) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.entries = append(j.entries, entry)
+}
+
+func (j *journal) read() []string {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return slices.Clone(j.entries)
+}
+
+// service A Starter and Stopper writing both down; Start fails with err
+type service struct {
+	name string
+	j    *journal
+	err  error
+}
+
+func (s service) Start(context.Context) error {
+	if s.err != nil {
+		s.j.write("start " + s.name + " failed")
+		return s.err
+	}
+	s.j.write("start " + s.name)
+	return nil
+}
+
+func (s service) Stop(context.Context) error {
+	s.j.write("stop " + s.name)
+	return nil
+}
+
+// server A Runner and Stopper: Run returns err at once, or waits to be
+// cancelled when err is nil
+type server struct {
+	name string
+	j    *journal
+	err  error
+}
+
+func (s server) Run(ctx context.Context) error {
+	if s.err != nil {
+		return s.err
+	}
+	<-ctx.Done()
+	s.j.write("run " + s.name + " cancelled")
+	return ctx.Err()
+}
+
+func (s server) Stop(context.Context) error {
+	s.j.write("stop " + s.name)
+	return nil
+}
+
+// cancelled is a context already done, so Run stops as soon as it has
+// started everything.
+func cancelled(t *testing.T) context.Context {
+	ctx, cancel := context.WithCancel(t.Context())
+	cancel()
+	return ctx
+}
+
+func TestGroup_StopsInReverseOrder(t *testing.T) {
+	j := &journal{}
+	g := &lifecycle.Group{}
+	g.Add("db", service{name: "db", j: j})
+	g.Add("http", server{name: "http", j: j})
+	g.Add("cache", service{name: "cache", j: j})
+	if err := g.Run(cancelled(t)); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	// a Runner's context is cancelled after its Stop, before the next component stops
+	want := []string{"start db", "start cache", "stop cache", "stop http", "run http cancelled", "stop db"}
+	if got := j.read(); !slices.Equal(got, want) {
+		t.Errorf("journal = %q, want %q", got, want)
+	}
+}
+
+func TestGroup_RunnerFailure(t *testing.T) {
+	j := &journal{}
+	crash := errors.New("address already in use")
+	g := &lifecycle.Group{}
+	g.Add("db", service{name: "db", j: j})
+	g.Add("http", server{name: "http", j: j, err: crash})
+	g.Add("cache", service{name: "cache", j: j})
+
+	// nothing ends ctx: the failure alone shuts the group down
+	err := g.Run(context.Background())
+	if !errors.Is(err, crash) || !strings.Contains(err.Error(), "http") {
+		t.Errorf("Run() error = %v, want %v named after http", err, crash)
+	}
+	want := []string{"start db", "start cache", "stop cache", "stop http", "stop db"}
+	if got := j.read(); !slices.Equal(got, want) {
+		t.Errorf("journal = %q, want %q", got, want)
+	}
+}
+
+func TestGroup_ShutdownTimeout(t *testing.T) {
+	j := &journal{}
+	g := &lifecycle.Group{ShutdownTimeout: 50 * time.Millisecond}
+	g.Add("db", service{name: "db", j: j})
+	g.Add("stuck", lifecycle.StopFunc(func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	}))
+
+	start := time.Now()
+	err := g.Run(cancelled(t))
+	if !errors.Is(err, context.DeadlineExceeded) || !strings.Contains(err.Error(), "stop stuck") {
+		t.Errorf("Run() error = %v, want stop stuck: %v", err, context.DeadlineExceeded)
+	}
+	if d := time.Since(start); d > 5*time.Second {
+		t.Errorf("Run() took %v, want it given up on after the 50ms timeout", d)
+	}
+	// the components below a stuck one are stopped all the same
+	if got := j.read(); !slices.Contains(got, "stop db") {
+		t.Errorf("journal = %q, want db stopped after stuck timed out", got)
+	}
+}
+
+func TestGroup_StartFailure(t *testing.T) {
+	j := &journal{}
+	refused := errors.New("connection refused")
+	g := &lifecycle.Group{}
+	g.Add("db", service{name: "db", j: j})
+	g.Add("http", server{name: "http", j: j})
+	g.Add("cache", service{name: "cache", j: j, err: refused})
+	g.Add("worker", service{name: "worker", j: j})
+
+	err := g.Run(context.Background())
+	if !errors.Is(err, refused) || !strings.Contains(err.Error(), "start cache") {
+		t.Errorf("Run() error = %v, want start cache: %v", err, refused)
+	}
+	// cache never started and worker was never reached: neither is stopped
+	want := []string{"start db", "start cache failed", "stop http", "run http cancelled", "stop db"}
+	if got := j.read(); !slices.Equal(got, want) {
+		t.Errorf("journal = %q, want %q", got, want)
+	}
+}
+
+func TestGroup_AddRejectsNonComponents(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("Add() of a string didn't panic, want it refused")
+		}
+	}()
+	(&lifecycle.Group{}).Add("nothing", "not a component")
+}
@@ -0,0 +1,171 @@
+package lint
+
+import (
+	"cmp"
+	"fmt"
+	"go/ast"
+	"go/types"
+	"slices"
+	"strings"
+
+	"go-solid/typeload"
+)
+
+// AcceptInterfaces The other half of the idiom: an exported function taking a
+// concrete type, though all it does with it is call methods an interface
+// already declares, should take the interface. Callers can then pass another
+// implementation, a decorator or a fake - DIP at the scale of one parameter.
+//
+// The interface has to exist already, in the function's package or one it
+// imports; the rule doesn't invent one. Of those that fit, it suggests the
+// smallest.
+type AcceptInterfaces struct{}
+
+func (AcceptInterfaces) Name() string { return "acceptinterfaces" }
+
+func (AcceptInterfaces) Doc() string {
+	return "exported functions taking a concrete type only to call methods of an existing interface take the interface"
+}
+
+func (r AcceptInterfaces) Check(p typeload.Package) []Finding {
+	var (
+		findings   []Finding
+		interfaces = reachable(p.Types)
+		q          = qualifier(p.Types)
+	)
+	for file, fn := range funcs(p) {
+		if !fn.Name.IsExported() {
+			continue
+		}
+		for _, field := range fn.Type.Params.List {
+			for _, name := range field.Names {
+				param, _ := p.Info.Defs[name].(*types.Var)
+				if param == nil || !concrete(param.Type()) {
+					continue
+				}
+				called, ok := methodsOnly(p.Info, fn.Body, param)
+				if !ok || len(called) == 0 {
+					continue
+				}
+				iface := smallest(interfaces, param.Type(), called)
+				if iface == nil {
+					continue
+				}
+				f := Finding{
+					Pos:  p.Fset.Position(name.Pos()),
+					Rule: r.Name(),
+					Message: fmt.Sprintf("%s takes %s %s only to call %s: take %s, and any implementation will do",
+						fn.Name.Name, name.Name, types.TypeString(param.Type(), q), strings.Join(called, ", "), types.TypeString(iface, q)),
+				}
+				if len(field.Names) == 1 {
+					f.Fix = replace(p, file, field.Type, iface)
+				}
+				findings = append(findings, f)
+			}
+		}
+	}
+	return findings
+}
+
+// concrete reports whether t is a named non-interface type, or a pointer to
+// one, without type parameters.
+func concrete(t types.Type) bool {
+	if ptr, ok := t.(*types.Pointer); ok {
+		t = ptr.Elem()
+	}
+	n, ok := types.Unalias(t).(*types.Named)
+	return ok && !types.IsInterface(n) && n.TypeParams().Len() == 0
+}
+
+// methodsOnly returns the methods body calls on v, sorted, and whether
+// calling methods is all it does with v. Reading a field, passing v on or
+// comparing it needs the concrete type.
+func methodsOnly(info *types.Info, body *ast.BlockStmt, v *types.Var) ([]string, bool) {
+	var (
+		called      []string
+		methodUses  int
+		allUses     int
+		methodIdent = map[*ast.Ident]bool{}
+	)
+	ast.Inspect(body, func(n ast.Node) bool {
+		sel, ok := n.(*ast.SelectorExpr)
+		if !ok {
+			return true
+		}
+		if id, ok := sel.X.(*ast.Ident); ok && info.Uses[id] == v {
+			if s := info.Selections[sel]; s != nil && s.Kind() == types.MethodVal {
+				methodIdent[id] = true
+				if !slices.Contains(called, sel.Sel.Name) {
+					called = append(called, sel.Sel.Name)
+				}
+			}
+		}
+		return true
+	})
+	ast.Inspect(body, func(n ast.Node) bool {
+		if id, ok := n.(*ast.Ident); ok && info.Uses[id] == v {
+			allUses++
+			if methodIdent[id] {
+				methodUses++
+			}
+		}
+		return true
+	})
+	slices.Sort(called)
+	return called, allUses == methodUses
+}
+
+// reachable returns the interfaces with methods pkg can name: all of its
+// own, and the exported ones of the packages it imports.
+func reachable(pkg *types.Package) []*types.Named {
+	var found []*types.Named
+	for _, p := range append([]*types.Package{pkg}, pkg.Imports()...) {
+		scope := p.Scope()
+		for _, n := range scope.Names() {
+			tn, ok := scope.Lookup(n).(*types.TypeName)
+			if !ok || (p != pkg && !tn.Exported()) {
+				continue
+			}
+			named, ok := tn.Type().(*types.Named)
+			if !ok || named.TypeParams().Len() > 0 {
+				continue
+			}
+			if it, ok := named.Underlying().(*types.Interface); ok && it.NumMethods() > 0 {
+				found = append(found, named)
+			}
+		}
+	}
+	return found
+}
+
+// smallest returns the interface with the fewest methods that t implements
+// and that declares every method called, nil when none does.
+func smallest(interfaces []*types.Named, t types.Type, called []string) *types.Named {
+	var fits []*types.Named
+	for _, n := range interfaces {
+		it := n.Underlying().(*types.Interface)
+		if !types.Implements(t, it) {
+			continue
+		}
+		declares := func(name string) bool {
+			for i := range it.NumMethods() {
+				if it.Method(i).Name() == name {
+					return true
+				}
+			}
+			return false
+		}
+		if !slices.ContainsFunc(called, func(m string) bool { return !declares(m) }) {
+			fits = append(fits, n)
+		}
+	}
+	if len(fits) == 0 {
+		return nil
+	}
+	return slices.MinFunc(fits, func(a, b *types.Named) int {
+		return cmp.Or(
+			cmp.Compare(a.Underlying().(*types.Interface).NumMethods(), b.Underlying().(*types.Interface).NumMethods()),
+			cmp.Compare(a.Obj().Pkg().Path()+"."+a.Obj().Name(), b.Obj().Pkg().Path()+"."+b.Obj().Name()),
+		)
+	})
+}
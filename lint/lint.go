@@ -0,0 +1,239 @@
+// Package lint checks Go code against the idioms the lessons teach.
+//
+// go vet catches mistakes; these rules catch designs that compile, work, and
+// make the next change harder - the kind a reviewer points out. Each Rule
+// reads type-checked packages and reports Findings. solid lint runs them.
+//
+// A finding the code means to keep is silenced with a comment on the line
+// above, or on the line itself, naming the rule:
+//
+//	//lint:ignore returnstructs the factory picks the backend at runtime
+package lint
+
+import (
+	"context"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/token"
+	"go/types"
+	"iter"
+	"maps"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+
+	"go-solid/typeload"
+)
+
+// Finding One place a rule objects to
+type Finding struct {
+	Pos     token.Position
+	Rule    string
+	Message string
+	// Fix is the change the rule suggests, applied by solid lint -fix; nil
+	// when it can't write one
+	Fix []Edit
+}
+
+// Edit Replaces the source between two offsets of a file
+type Edit struct {
+	File       string
+	Start, End int
+	New        string
+}
+
+func (f Finding) String() string { return fmt.Sprintf("%s: %s: %s", f.Pos, f.Rule, f.Message) }
+
+// Rule One idiom, checked package by package
+type Rule interface {
+	Name() string
+	// Doc is one line on what the rule wants and why
+	Doc() string
+	Check(p typeload.Package) []Finding
+}
+
+// Rules Every rule solid lint knows, in the order it runs them
+var Rules = []Rule{
+	ReturnStructs{},
+	AcceptInterfaces{},
+	TypedNil{},
+}
+
+// Lookup returns the rules called names, every rule when there are none.
+func Lookup(names ...string) ([]Rule, error) {
+	if len(names) == 0 {
+		return Rules, nil
+	}
+	var rules []Rule
+	for _, n := range names {
+		i := slices.IndexFunc(Rules, func(r Rule) bool { return r.Name() == n })
+		if i < 0 {
+			return nil, fmt.Errorf("lint: no rule %q", n)
+		}
+		rules = append(rules, Rules[i])
+	}
+	return rules, nil
+}
+
+// Run loads the packages matching patterns, as go list takes them, from dir
+// and checks them with rules. A package that doesn't type-check is an error:
+// the rules need its types.
+func Run(ctx context.Context, dir string, patterns []string, rules ...Rule) ([]Finding, error) {
+	pkgs, err := typeload.Load(ctx, dir, patterns...)
+	if err != nil {
+		return nil, err
+	}
+	var findings []Finding
+	for _, p := range pkgs {
+		if len(p.Errors) > 0 {
+			return nil, fmt.Errorf("lint: %w", p.Errors[0])
+		}
+		ignored := ignores(p)
+		for _, r := range rules {
+			for _, f := range r.Check(p) {
+				if !ignored[ignoreKey{f.Pos.Filename, f.Pos.Line, r.Name()}] {
+					findings = append(findings, f)
+				}
+			}
+		}
+	}
+	return findings, nil
+}
+
+type ignoreKey struct {
+	file string
+	line int
+	rule string
+}
+
+// ignores collects the //lint:ignore comments of p, each covering its own
+// line and the next.
+func ignores(p typeload.Package) map[ignoreKey]bool {
+	ignored := map[ignoreKey]bool{}
+	for _, f := range p.Files {
+		for _, group := range f.Comments {
+			for _, c := range group.List {
+				rest, ok := strings.CutPrefix(c.Text, "//lint:ignore ")
+				if !ok {
+					continue
+				}
+				rule, _, _ := strings.Cut(rest, " ")
+				pos := p.Fset.Position(c.Pos())
+				ignored[ignoreKey{pos.Filename, pos.Line, rule}] = true
+				ignored[ignoreKey{pos.Filename, pos.Line + 1, rule}] = true
+			}
+		}
+	}
+	return ignored
+}
+
+// funcs yields the top-level functions of p, without methods, and the file
+// declaring each.
+func funcs(p typeload.Package) iter.Seq2[*ast.File, *ast.FuncDecl] {
+	return func(yield func(*ast.File, *ast.FuncDecl) bool) {
+		for _, f := range p.Files {
+			for _, d := range f.Decls {
+				if fn, ok := d.(*ast.FuncDecl); ok && fn.Recv == nil && fn.Body != nil && !yield(f, fn) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// qualifier writes types as the code in pkg does: its own unqualified,
+// others by package name.
+func qualifier(pkg *types.Package) types.Qualifier {
+	return func(other *types.Package) string {
+		if other == pkg {
+			return ""
+		}
+		return other.Name()
+	}
+}
+
+// replace returns the Edit writing t in place of expr, spelt as file spells
+// it, or nil when t needs a package file doesn't import.
+func replace(p typeload.Package, file *ast.File, expr ast.Expr, t types.Type) []Edit {
+	names := map[string]string{}
+	for _, spec := range file.Imports {
+		path, _ := strconv.Unquote(spec.Path.Value)
+		if spec.Name != nil {
+			names[path] = spec.Name.Name
+		} else {
+			names[path] = "" // its package name, known once we meet it
+		}
+	}
+	missing := false
+	text := types.TypeString(t, func(pkg *types.Package) string {
+		if pkg == p.Types {
+			return ""
+		}
+		name, ok := names[pkg.Path()]
+		if !ok {
+			missing = true
+		}
+		if name == "" {
+			name = pkg.Name()
+		}
+		return name
+	})
+	if missing {
+		return nil
+	}
+	start, end := p.Fset.Position(expr.Pos()), p.Fset.Position(expr.End())
+	return []Edit{{File: start.Filename, Start: start.Offset, End: end.Offset, New: text}}
+}
+
+// Apply makes the edits of findings' fixes, file by file, and returns the
+// files it changed. Of two overlapping edits only one is made; the other
+// waits for the next run.
+func Apply(findings []Finding) ([]string, error) {
+	byFile := map[string][]Edit{}
+	for _, f := range findings {
+		for _, e := range f.Fix {
+			byFile[e.File] = append(byFile[e.File], e)
+		}
+	}
+	var changed []string
+	for _, file := range slices.Sorted(maps.Keys(byFile)) {
+		src, err := os.ReadFile(file)
+		if err != nil {
+			return changed, err
+		}
+		edits := byFile[file]
+		slices.SortStableFunc(edits, func(a, b Edit) int { return b.Start - a.Start })
+		last := len(src) + 1
+		for _, e := range edits {
+			if e.End > last {
+				continue
+			}
+			src = slices.Concat(src[:e.Start], []byte(e.New), src[e.End:])
+			last = e.Start
+		}
+		if formatted, err := format.Source(src); err == nil {
+			src = formatted
+		}
+		if err := os.WriteFile(file, src, 0o644); err != nil {
+			return changed, err
+		}
+		changed = append(changed, file)
+	}
+	return changed, nil
+}
+
+// returns calls visit with every return statement of fn's own body, leaving
+// out those of function literals inside it.
+func returns(fn *ast.FuncDecl, visit func(*ast.ReturnStmt)) {
+	ast.Inspect(fn.Body, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.FuncLit:
+			return false
+		case *ast.ReturnStmt:
+			visit(n)
+		}
+		return true
+	})
+}
@@ -0,0 +1,181 @@
+package lint_test
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"testing"
+
+	"go-solid/lint"
+)
+
+// fixture copies the Go files of testdata/name into a module of its own, so
+// fixes can be applied without touching testdata, and returns its directory.
+func fixture(t *testing.T, name string, extra map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	files := map[string]string{"go.mod": "module fixture\n\ngo 1.25\n"}
+	matches, _ := filepath.Glob(filepath.Join("testdata", name, "*.go"))
+	for _, m := range matches {
+		src, err := os.ReadFile(m)
+		if err != nil {
+			t.Fatal(err)
+		}
+		files[filepath.Base(m)] = string(src)
+	}
+	for file, src := range extra {
+		files[file] = src
+	}
+	for file, src := range files {
+		if err := os.WriteFile(filepath.Join(dir, file), []byte(src), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+// want One finding a fixture expects: a `// want` comment lists a pattern
+// per finding on its line.
+type want struct {
+	file    string
+	line    int
+	pattern *regexp.Regexp
+	found   bool
+}
+
+var quoted = regexp.MustCompile("`([^`]*)`")
+
+func wants(t *testing.T, dir string) []*want {
+	t.Helper()
+	var all []*want
+	matches, _ := filepath.Glob(filepath.Join(dir, "*.go"))
+	for _, m := range matches {
+		src, err := os.ReadFile(m)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for i, line := range strings.Split(string(src), "\n") {
+			_, patterns, ok := strings.Cut(line, "// want ")
+			if !ok {
+				continue
+			}
+			for _, q := range quoted.FindAllStringSubmatch(patterns, -1) {
+				all = append(all, &want{file: filepath.Base(m), line: i + 1, pattern: regexp.MustCompile(q[1])})
+			}
+		}
+	}
+	return all
+}
+
+// TestRules checks each rule against the fixture in testdata named after it.
+func TestRules(t *testing.T) {
+	entries, err := os.ReadDir("testdata")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, e := range entries {
+		rules, err := lint.Lookup(e.Name())
+		if err != nil {
+			t.Fatalf("testdata/%s: %v", e.Name(), err)
+		}
+		r := rules[0]
+		t.Run(r.Name(), func(t *testing.T) {
+			dir := fixture(t, r.Name(), nil)
+			findings, err := lint.Run(t.Context(), dir, []string{"./..."}, r)
+			if err != nil {
+				t.Fatalf("Run() error = %v", err)
+			}
+			expected := wants(t, dir)
+			for _, f := range findings {
+				i := slices.IndexFunc(expected, func(w *want) bool {
+					return !w.found && w.file == filepath.Base(f.Pos.Filename) && w.line == f.Pos.Line && w.pattern.MatchString(f.Message)
+				})
+				if i < 0 || f.Rule != r.Name() {
+					t.Errorf("unexpected finding %s", f)
+					continue
+				}
+				expected[i].found = true
+			}
+			for _, w := range expected {
+				if !w.found {
+					t.Errorf("%s:%d: no finding matching %s", w.file, w.line, w.pattern)
+				}
+			}
+
+			changed, err := lint.Apply(findings)
+			if err != nil {
+				t.Fatalf("Apply() error = %v", err)
+			}
+			goldens, _ := filepath.Glob(filepath.Join("testdata", r.Name(), "*.golden"))
+			var fixed []string
+			for _, g := range goldens {
+				file := strings.TrimSuffix(filepath.Base(g), ".golden")
+				got, _ := os.ReadFile(filepath.Join(dir, file))
+				if want, _ := os.ReadFile(g); string(got) != string(want) {
+					t.Errorf("%s after Apply() =\n%s\nwant\n%s", file, got, want)
+				}
+				if slices.Contains(changed, filepath.Join(dir, file)) {
+					fixed = append(fixed, file)
+				}
+			}
+			if len(fixed) != len(changed) {
+				t.Errorf("Apply() changed %v, want only files with a golden (%v)", changed, fixed)
+			}
+
+			// the fixed code still type-checks, and only the findings
+			// without a fix are left
+			left, err := lint.Run(t.Context(), dir, []string{"./..."}, r)
+			if err != nil {
+				t.Fatalf("Run() after Apply() error = %v", err)
+			}
+			unfixable := slices.DeleteFunc(slices.Clone(findings), func(f lint.Finding) bool { return f.Fix != nil })
+			if len(left) != len(unfixable) {
+				t.Errorf("Run() after Apply() = %v, want the %d findings without a fix", left, len(unfixable))
+			}
+		})
+	}
+}
+
+func TestRun_TypeErrors(t *testing.T) {
+	dir := fixture(t, "returnstructs", map[string]string{"broken.go": "package store\n\nvar n int = \"one\"\n"})
+	if _, err := lint.Run(t.Context(), dir, []string{"./..."}, lint.Rules...); err == nil || !strings.Contains(err.Error(), "broken.go") {
+		t.Errorf("Run() error = %v, want the type error of broken.go", err)
+	}
+}
+
+func TestLookup(t *testing.T) {
+	all, err := lint.Lookup()
+	if err != nil || len(all) != len(lint.Rules) {
+		t.Errorf("Lookup() = %v, %v, want every rule", all, err)
+	}
+	some, err := lint.Lookup("typednil", "returnstructs")
+	if err != nil || len(some) != 2 || some[0].Name() != "typednil" || some[1].Name() != "returnstructs" {
+		t.Errorf("Lookup(typednil, returnstructs) = %v, %v, want those two in order", some, err)
+	}
+	if _, err := lint.Lookup("returnstructs", "golint"); err == nil {
+		t.Error("Lookup(golint) error = nil, want no such rule")
+	}
+}
+
+func TestApply_OverlappingEdits(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "notes.txt")
+	if err := os.WriteFile(file, []byte("abcdef"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	findings := []lint.Finding{
+		{Fix: []lint.Edit{{File: file, Start: 1, End: 4, New: "X"}}},
+		{Fix: []lint.Edit{{File: file, Start: 2, End: 3, New: "Y"}}},
+		{Fix: []lint.Edit{{File: file, Start: 4, End: 5, New: "Z"}}},
+		{}, // no fix
+	}
+	changed, err := lint.Apply(findings)
+	if err != nil || !slices.Equal(changed, []string{file}) {
+		t.Fatalf("Apply() = %v, %v, want %s changed", changed, err, file)
+	}
+	// b-d overlaps c, made first from the end, and waits for the next run
+	if got, _ := os.ReadFile(file); string(got) != "abYdZf" {
+		t.Errorf("Apply() wrote %q, want %q", got, "abYdZf")
+	}
+}
@@ -0,0 +1,105 @@
+package lint
+
+import (
+	"fmt"
+	"go/ast"
+	"go/types"
+	"strings"
+
+	"go-solid/typeload"
+)
+
+// ReturnStructs "Accept interfaces, return structs": an exported constructor
+// whose every return is the same concrete type should say so. Returning the
+// interface hides the type's other methods - a caller holding a PaidEmployee
+// can't assign the Manager a task without a type assertion - and gains
+// nothing, since a *Manager can be passed as a PaidEmployee anyway.
+//
+// A constructor returning different types on different paths is a factory,
+// choosing the implementation at runtime, and is left alone, as is one
+// returning an unexported type: hiding the implementation is the point.
+type ReturnStructs struct{}
+
+func (ReturnStructs) Name() string { return "returnstructs" }
+
+func (ReturnStructs) Doc() string {
+	return "exported New functions always returning one concrete type return that type, not an interface"
+}
+
+func (r ReturnStructs) Check(p typeload.Package) []Finding {
+	var findings []Finding
+	for file, fn := range funcs(p) {
+		if !fn.Name.IsExported() || !strings.HasPrefix(fn.Name.Name, "New") {
+			continue
+		}
+		obj, _ := p.Info.Defs[fn.Name].(*types.Func)
+		if obj == nil {
+			continue
+		}
+		results := obj.Type().(*types.Signature).Results()
+		if results.Len() == 0 {
+			continue
+		}
+		iface, ok := types.Unalias(results.At(0).Type()).(*types.Named)
+		if !ok || !types.IsInterface(iface) || iface.Obj().Pkg() == nil { // error, any, ...
+			continue
+		}
+		concrete := onlyConcrete(p.Info, fn)
+		if concrete == nil || !exported(concrete) {
+			continue
+		}
+		q := qualifier(p.Types)
+		f := Finding{
+			Pos:  p.Fset.Position(fn.Name.Pos()),
+			Rule: r.Name(),
+			Message: fmt.Sprintf("%s returns the interface %s but always a %s: return %[3]s, callers can still pass it as %[2]s",
+				fn.Name.Name, types.TypeString(iface, q), types.TypeString(concrete, q)),
+		}
+		if first := fn.Type.Results.List[0]; len(first.Names) <= 1 {
+			f.Fix = replace(p, file, first.Type, concrete)
+		}
+		findings = append(findings, f)
+	}
+	return findings
+}
+
+// onlyConcrete returns the concrete type every return statement of fn gives
+// as its first result, nil returns aside, or nil when they differ, when one
+// of them is an interface already, or when fn uses bare returns.
+func onlyConcrete(info *types.Info, fn *ast.FuncDecl) types.Type {
+	var (
+		found types.Type
+		mixed bool
+	)
+	returns(fn, func(ret *ast.ReturnStmt) {
+		if len(ret.Results) == 0 {
+			mixed = true
+			return
+		}
+		t := info.Types[ret.Results[0]].Type
+		if _, ok := t.(*types.Tuple); ok { // return f(), one call giving every result
+			mixed = true
+			return
+		}
+		switch {
+		case t == nil || types.Identical(t, types.Typ[types.UntypedNil]):
+		case types.IsInterface(t), found != nil && !types.Identical(found, t):
+			mixed = true
+		default:
+			found = t
+		}
+	})
+	if mixed {
+		return nil
+	}
+	return found
+}
+
+// exported reports whether t, or what it points to, is an exported named type.
+func exported(t types.Type) bool {
+	if ptr, ok := t.(*types.Pointer); ok {
+		t = ptr.Elem()
+	}
+	n, ok := types.Unalias(t).(*types.Named)
+	return ok && n.Obj().Exported()
+}
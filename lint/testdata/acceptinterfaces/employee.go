@@ -0,0 +1,43 @@
+package employee
+
+import (
+	"bytes"
+	"io"
+)
+
+type Namer interface {
+	Name() string
+}
+
+type Employee struct{ name, title string }
+
+func (e *Employee) Name() string  { return e.name }
+func (e *Employee) Title() string { return e.title }
+
+func Greet(e *Employee) string { // want `Greet takes e \*Employee only to call Name: take Namer`
+	return "Hello, " + e.Name()
+}
+
+// Write picks io.StringWriter, the smallest of the io interfaces a
+// *bytes.Buffer has WriteString in.
+func Write(buf *bytes.Buffer, s string) { // want `Write takes buf \*bytes.Buffer only to call WriteString: take io.StringWriter`
+	buf.WriteString(s)
+}
+
+func Pair(a, b *Employee) string { // want `Pair takes a` `Pair takes b`
+	return a.Name() + " & " + b.Name()
+}
+
+func Title(e *Employee) string { return e.title }
+
+func Card(e *Employee) string { return e.Name() + ", " + e.Title() }
+
+func Forward(e *Employee) string { return Greet(e) }
+
+func Same(a, b *Employee) bool { return a == b }
+
+func Ignore(e *Employee) {}
+
+func greet(e *Employee) string { return e.Name() }
+
+var _ io.Writer = (*bytes.Buffer)(nil)
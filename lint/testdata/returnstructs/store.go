@@ -0,0 +1,54 @@
+package store
+
+import "errors"
+
+type Store interface {
+	Get(id string) (string, error)
+}
+
+type Memory struct{ m map[string]string }
+
+func (s *Memory) Get(id string) (string, error) { return s.m[id], nil }
+func (s *Memory) Len() int                      { return len(s.m) }
+
+type disk struct{}
+
+func (disk) Get(string) (string, error) { return "", nil }
+
+func NewMemory() Store { // want `NewMemory returns the interface Store but always a \*Memory: return \*Memory`
+	return &Memory{m: map[string]string{}}
+}
+
+func NewChecked(m map[string]string) (Store, error) { // want `NewChecked returns the interface Store but always a \*Memory`
+	if m == nil {
+		return nil, errors.New("no map")
+	}
+	return &Memory{m: m}, nil
+}
+
+// NewStore is a factory: which Store depends on kind.
+func NewStore(kind string) Store {
+	if kind == "disk" {
+		return disk{}
+	}
+	return &Memory{}
+}
+
+// NewDisk hides an unexported type on purpose.
+func NewDisk() Store { return disk{} }
+
+func NewFrom(other Store) Store { return other }
+
+func NewBare() (s Store) {
+	s = &Memory{}
+	return
+}
+
+func NewError() error { return errors.New("an error is an interface without a package") }
+
+func newMemory() Store { return &Memory{} }
+
+//lint:ignore returnstructs the caller is meant to see a Store only
+func NewHidden() Store { return &Memory{} }
+
+var _ = newMemory
@@ -0,0 +1,84 @@
+package repo
+
+import (
+	"errors"
+	"fmt"
+)
+
+type Repository interface {
+	Get(id string) (string, error)
+}
+
+type MySQL struct{}
+
+func (*MySQL) Get(string) (string, error) { return "", nil }
+
+func Open(dsn string) Repository {
+	var repo *MySQL
+	if dsn != "" {
+		repo = &MySQL{}
+	}
+	return repo // want `repo may be a nil \*MySQL, returned as Repository`
+}
+
+func OpenChecked(dsn string) Repository {
+	var repo *MySQL
+	if dsn != "" {
+		repo = &MySQL{}
+	}
+	if repo == nil {
+		return nil
+	}
+	return repo
+}
+
+func Assign() Repository {
+	m := (*MySQL)(nil)
+	var r Repository
+	m = nil
+	r = m // want `m may be a nil \*MySQL, assigned as Repository`
+	var again Repository = m // want `m may be a nil \*MySQL, assigned as Repository`
+	_ = again
+	return r
+}
+
+func use(Repository) {}
+
+func Pass() {
+	var m *MySQL
+	use(m) // want `m may be a nil \*MySQL, passed as Repository`
+	fmt.Println(m)
+}
+
+func Lazy() func() Repository {
+	var m *MySQL
+	return func() Repository {
+		return m // want `m may be a nil \*MySQL, returned as Repository`
+	}
+}
+
+type notFound struct{}
+
+func (*notFound) Error() string { return "not found" }
+
+func Find(err error) error {
+	var target *notFound
+	errors.As(err, &target)
+	return target
+}
+
+func Concrete() *MySQL {
+	var m *MySQL
+	return m
+}
+
+func Fresh() Repository {
+	m := &MySQL{}
+	return m
+}
+
+func Ignored() Repository {
+	var m *MySQL
+	//lint:ignore typednil callers check with IsNil
+	return m
+}
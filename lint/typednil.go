@@ -0,0 +1,195 @@
+package lint
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+
+	"go-solid/typeload"
+)
+
+// TypedNil A nil *MySQLRepository stored in an EmployeeRepository is not a
+// nil EmployeeRepository: the interface holds a type, so != nil holds and the
+// first method call panics. The classic case is a function declaring
+// var repo *MySQLRepository, setting it on some paths, and returning it.
+//
+// Without following every path the rule can only guess, so it guesses
+// narrowly: a local variable of pointer, map, slice, func or chan type that
+// starts or is set nil, is never compared with nil nor has its address
+// taken, and is returned as, assigned to, or passed as an interface.
+type TypedNil struct{}
+
+func (TypedNil) Name() string { return "typednil" }
+
+func (TypedNil) Doc() string {
+	return "a possibly nil pointer stored in an interface makes it non-nil; return or assign nil literally"
+}
+
+func (r TypedNil) Check(p typeload.Package) []Finding {
+	var findings []Finding
+	q := qualifier(p.Types)
+	report := func(id *ast.Ident, iface types.Type, how string) {
+		findings = append(findings, Finding{
+			Pos:  p.Fset.Position(id.Pos()),
+			Rule: r.Name(),
+			Message: fmt.Sprintf("%s may be a nil %s, %s as %s, which is then never == nil: use a literal nil on that path",
+				id.Name, types.TypeString(p.Info.Uses[id].Type(), q), how, types.TypeString(iface, q)),
+		})
+	}
+	for _, f := range p.Files {
+		for _, d := range f.Decls {
+			fn, ok := d.(*ast.FuncDecl)
+			if !ok || fn.Body == nil {
+				continue
+			}
+			obj, _ := p.Info.Defs[fn.Name].(*types.Func)
+			if obj == nil {
+				continue
+			}
+			suspects := maybeNil(p.Info, fn.Body)
+			if len(suspects) == 0 {
+				continue
+			}
+			suspect := func(e ast.Expr) *ast.Ident {
+				id, ok := ast.Unparen(e).(*ast.Ident)
+				if ok && suspects[p.Info.Uses[id]] {
+					return id
+				}
+				return nil
+			}
+			var visit func(sig *types.Signature, body *ast.BlockStmt)
+			visit = func(sig *types.Signature, body *ast.BlockStmt) {
+				ast.Inspect(body, func(n ast.Node) bool {
+					switch n := n.(type) {
+					case *ast.FuncLit:
+						if lit, ok := p.Info.Types[n].Type.(*types.Signature); ok {
+							visit(lit, n.Body)
+						}
+						return false
+					case *ast.ReturnStmt:
+						if len(n.Results) != sig.Results().Len() {
+							return true
+						}
+						for i, e := range n.Results {
+							if t := sig.Results().At(i).Type(); types.IsInterface(t) {
+								if id := suspect(e); id != nil {
+									report(id, t, "returned")
+								}
+							}
+						}
+					case *ast.AssignStmt:
+						if n.Tok != token.ASSIGN || len(n.Lhs) != len(n.Rhs) {
+							return true
+						}
+						for i, e := range n.Rhs {
+							if t := p.Info.Types[n.Lhs[i]].Type; t != nil && types.IsInterface(t) {
+								if id := suspect(e); id != nil {
+									report(id, t, "assigned")
+								}
+							}
+						}
+					case *ast.ValueSpec:
+						if n.Type == nil || len(n.Values) != len(n.Names) {
+							return true
+						}
+						if t := p.Info.Types[n.Type].Type; types.IsInterface(t) {
+							for _, e := range n.Values {
+								if id := suspect(e); id != nil {
+									report(id, t, "assigned")
+								}
+							}
+						}
+					case *ast.CallExpr:
+						call, ok := p.Info.Types[n.Fun].Type.(*types.Signature)
+						if !ok {
+							return true
+						}
+						for i, e := range n.Args {
+							if i >= call.Params().Len() || call.Variadic() && i == call.Params().Len()-1 {
+								break // ...any takes anything: fmt.Println(p) prints <nil> fine
+							}
+							t := call.Params().At(i).Type()
+							if it, ok := t.Underlying().(*types.Interface); ok && it.NumMethods() > 0 {
+								if id := suspect(e); id != nil {
+									report(id, t, "passed")
+								}
+							}
+						}
+					}
+					return true
+				})
+			}
+			visit(obj.Type().(*types.Signature), fn.Body)
+		}
+	}
+	return findings
+}
+
+// maybeNil returns the local variables of body that are declared without a
+// value or set to nil, can hold nil, and are never compared with nil - a
+// comparison means the code already handles it. A variable whose address is
+// taken is left out too: errors.As(err, &target) and the like set it.
+func maybeNil(info *types.Info, body *ast.BlockStmt) map[types.Object]bool {
+	var (
+		vars    = map[types.Object]bool{}
+		handled = map[types.Object]bool{}
+	)
+	isNil := func(e ast.Expr) bool {
+		tv, ok := info.Types[e]
+		return ok && tv.IsNil()
+	}
+	mark := func(id *ast.Ident) {
+		obj := info.ObjectOf(id)
+		if v, ok := obj.(*types.Var); ok && nillable(v.Type()) {
+			vars[obj] = true
+		}
+	}
+	ast.Inspect(body, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.ValueSpec:
+			for i, id := range n.Names {
+				if len(n.Values) == 0 || i < len(n.Values) && isNil(n.Values[i]) {
+					mark(id)
+				}
+			}
+		case *ast.AssignStmt:
+			if len(n.Lhs) != len(n.Rhs) {
+				return true
+			}
+			for i, e := range n.Rhs {
+				if id, ok := n.Lhs[i].(*ast.Ident); ok && isNil(e) {
+					mark(id)
+				}
+			}
+		case *ast.UnaryExpr:
+			if id, ok := ast.Unparen(n.X).(*ast.Ident); ok && n.Op == token.AND {
+				handled[info.ObjectOf(id)] = true
+			}
+		case *ast.BinaryExpr:
+			if n.Op != token.EQL && n.Op != token.NEQ {
+				return true
+			}
+			for _, pair := range [][2]ast.Expr{{n.X, n.Y}, {n.Y, n.X}} {
+				if id, ok := ast.Unparen(pair[0]).(*ast.Ident); ok && isNil(pair[1]) {
+					handled[info.ObjectOf(id)] = true
+				}
+			}
+		}
+		return true
+	})
+	for obj := range handled {
+		delete(vars, obj)
+	}
+	return vars
+}
+
+// nillable reports whether t is concrete and can be nil: storing its nil in
+// an interface is what makes the interface non-nil.
+func nillable(t types.Type) bool {
+	switch t.Underlying().(type) {
+	case *types.Pointer, *types.Map, *types.Slice, *types.Signature, *types.Chan:
+		return true
+	}
+	return false
+}
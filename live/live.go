@@ -0,0 +1,212 @@
+// Package live streams domain events to browsers as they happen, over
+// websockets.
+//
+// Subscriber is an adapter between two worlds. To the event bus it is one
+// more events.Handler, subscribed like any projection; to each browser it is
+// a websocket feed. Nothing that raises events knows it exists (OCP), and it
+// knows nothing of who raised them.
+package live
+
+import (
+	"context"
+	"embed"
+	"encoding/json"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"go-solid/events"
+)
+
+// Message What a client receives for each event, as a JSON text frame
+type Message struct {
+	Event string       `json:"event"`
+	Data  events.Event `json:"data"`
+}
+
+// Subscriber Fans the events it handles out to every connected client.
+// Safe for concurrent use.
+type Subscriber struct {
+	buffer  int
+	timeout time.Duration
+	origins []string
+
+	mu      sync.Mutex
+	clients map[*client]struct{}
+	stopped bool
+}
+
+// client One connection, and the messages waiting to be written to it
+type client struct {
+	send   chan []byte
+	events []string // names it asked for; all when empty
+
+	// why the connection is being closed; set before send is closed
+	code   int
+	reason string
+}
+
+func (c *client) wants(name string) bool {
+	return len(c.events) == 0 || slices.Contains(c.events, name)
+}
+
+// Option customises a Subscriber created by NewSubscriber
+type Option func(*Subscriber)
+
+// WithBuffer sets how many messages may wait for a client; 64 by default.
+func WithBuffer(n int) Option { return func(s *Subscriber) { s.buffer = max(n, 1) } }
+
+// WithWriteTimeout sets how long one message may take to write; 5s by default.
+func WithWriteTimeout(d time.Duration) Option { return func(s *Subscriber) { s.timeout = d } }
+
+// WithOrigins lets pages from origins connect, e.g.
+// "https://hr.example.com", besides pages from the host serving the feed.
+func WithOrigins(origins ...string) Option {
+	return func(s *Subscriber) { s.origins = append(s.origins, origins...) }
+}
+
+func NewSubscriber(opts ...Option) *Subscriber {
+	s := &Subscriber{buffer: 64, timeout: 5 * time.Second, clients: map[*client]struct{}{}}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// Handle queues e for every client that wants it. It never waits for one:
+// the bus is synchronous, so a slow browser would otherwise slow down every
+// use case raising events. A client too far behind is disconnected instead,
+// and can reconnect.
+func (s *Subscriber) Handle(ctx context.Context, e events.Event) error {
+	msg, err := json.Marshal(Message{Event: e.EventName(), Data: e})
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for c := range s.clients {
+		if !c.wants(e.EventName()) {
+			continue
+		}
+		select {
+		case c.send <- msg:
+		default:
+			s.removeLocked(c, closeTryLater, "too slow, reconnect")
+		}
+	}
+	return nil
+}
+
+// ServeHTTP upgrades the request to a websocket and streams events to it
+// until either side closes. ?event= names the events wanted, and may be
+// repeated; without it the client gets every event. A page from an origin
+// it doesn't allow (WithOrigins) is refused with 403.
+func (s *Subscriber) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !s.allowed(r) {
+		http.Error(w, "origin not allowed", http.StatusForbidden)
+		return
+	}
+	ws, err := upgrade(w, r)
+	if err != nil {
+		w.Header().Set("Upgrade", "websocket")
+		http.Error(w, err.Error(), http.StatusUpgradeRequired)
+		return
+	}
+	c := &client{send: make(chan []byte, s.buffer), events: r.URL.Query()["event"]}
+	if !s.add(c) {
+		ws.close(closeGoingAway, "shutting down")
+		return
+	}
+	go func() {
+		_ = ws.readLoop()
+		s.remove(c, closeNormal, "")
+	}()
+	for msg := range c.send {
+		if err := ws.writeFrame(opText, msg, s.timeout); err != nil {
+			s.remove(c, closeTryLater, "write failed")
+			break
+		}
+	}
+	ws.close(c.code, c.reason)
+}
+
+// allowed reports whether r may connect. Browsers send the page's Origin
+// with every websocket handshake, and don't apply the same-origin policy to
+// websockets themselves, so without this check any site could open the
+// feed from a visitor's browser. A request without an Origin isn't from a
+// browser.
+func (s *Subscriber) allowed(r *http.Request) bool {
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		return true
+	}
+	if u, err := url.Parse(origin); err == nil && strings.EqualFold(u.Host, r.Host) {
+		return true
+	}
+	return slices.ContainsFunc(s.origins, func(o string) bool { return strings.EqualFold(o, origin) })
+}
+
+func (s *Subscriber) add(c *client) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.stopped {
+		return false
+	}
+	s.clients[c] = struct{}{}
+	return true
+}
+
+func (s *Subscriber) remove(c *client, code int, reason string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.removeLocked(c, code, reason)
+}
+
+// removeLocked ends c's write loop, which then closes the connection with
+// code. Removing a client twice does nothing.
+func (s *Subscriber) removeLocked(c *client, code int, reason string) {
+	if _, ok := s.clients[c]; !ok {
+		return
+	}
+	delete(s.clients, c)
+	c.code, c.reason = code, reason
+	close(c.send)
+}
+
+// Clients returns how many clients are connected.
+func (s *Subscriber) Clients() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.clients)
+}
+
+// Stop disconnects every client and turns new ones away. The HTTP server's
+// shutdown doesn't do it: upgraded connections are no longer its own.
+func (s *Subscriber) Stop(ctx context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.stopped = true
+	for c := range s.clients {
+		s.removeLocked(c, closeGoingAway, "shutting down")
+	}
+	return nil
+}
+
+//go:embed static
+var static embed.FS
+
+// Page serves a demo page that connects to the feed at "events", relative to
+// where the page is mounted, and lists what arrives:
+//
+//	mux.Handle("GET /live/", http.StripPrefix("/live", live.Page()))
+//	mux.Handle("GET /live/events", subscriber)
+func Page() http.Handler {
+	sub, _ := fs.Sub(static, "static")
+	return http.FileServerFS(sub)
+}
+
+var _ events.Handler = (*Subscriber)(nil)
@@ -0,0 +1,275 @@
+package live_test
+
+import (
+	"bufio"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"go-solid/live"
+)
+
+// hired A small event
+type hired struct {
+	Name string `json:"name"`
+}
+
+func (hired) EventName() string { return "employee.hired" }
+
+// payslip An event as large as asked, to fill a slow client's socket
+type payslip struct {
+	Lines string `json:"lines"`
+}
+
+func (payslip) EventName() string { return "payroll.paid" }
+
+// client A bare websocket client, speaking the frames by hand
+type client struct {
+	conn net.Conn
+	r    *bufio.Reader
+}
+
+// handshake sends an opening handshake for path with the extra header
+// lines, and returns the response and the connection.
+func handshake(t *testing.T, srv *httptest.Server, path string, extra ...string) (*http.Response, *client) {
+	t.Helper()
+	conn, err := net.Dial("tcp", srv.Listener.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	_ = conn.SetDeadline(time.Now().Add(10 * time.Second))
+	fmt.Fprintf(conn, "GET %s HTTP/1.1\r\nHost: %s\r\nUpgrade: websocket\r\nConnection: Upgrade\r\n"+
+		"Sec-WebSocket-Key: dGhlIHNhbXBsZSBub25jZQ==\r\nSec-WebSocket-Version: 13\r\n%s\r\n",
+		path, srv.Listener.Addr(), strings.Join(append(extra, ""), "\r\n"))
+	c := &client{conn: conn, r: bufio.NewReader(conn)}
+	resp, err := http.ReadResponse(c.r, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return resp, c
+}
+
+// dial connects to the feed at path, failing the test unless it upgrades.
+func dial(t *testing.T, srv *httptest.Server, path string) *client {
+	t.Helper()
+	resp, c := handshake(t, srv, path)
+	if resp.StatusCode != http.StatusSwitchingProtocols {
+		t.Fatalf("handshake = %s, want 101", resp.Status)
+	}
+	return c
+}
+
+// send writes one frame, masked unless mask is nil.
+func (c *client) send(t *testing.T, op byte, payload []byte, mask []byte) {
+	t.Helper()
+	frame := []byte{0x80 | op, byte(len(payload))}
+	body := append([]byte(nil), payload...)
+	if mask != nil {
+		frame[1] |= 0x80
+		frame = append(frame, mask...)
+		for i := range body {
+			body[i] ^= mask[i%4]
+		}
+	}
+	if _, err := c.conn.Write(append(frame, body...)); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// next reads one frame from the server.
+func (c *client) next(t *testing.T) (op byte, payload []byte) {
+	t.Helper()
+	var header [2]byte
+	if _, err := io.ReadFull(c.r, header[:]); err != nil {
+		t.Fatalf("read frame: %v", err)
+	}
+	length := uint64(header[1] & 0x7F)
+	switch length {
+	case 126:
+		var ext [2]byte
+		_, _ = io.ReadFull(c.r, ext[:])
+		length = uint64(binary.BigEndian.Uint16(ext[:]))
+	case 127:
+		var ext [8]byte
+		_, _ = io.ReadFull(c.r, ext[:])
+		length = binary.BigEndian.Uint64(ext[:])
+	}
+	payload = make([]byte, length)
+	if _, err := io.ReadFull(c.r, payload); err != nil {
+		t.Fatalf("read payload: %v", err)
+	}
+	return header[0] & 0x0F, payload
+}
+
+// closed reads frames until a close frame, and returns its code.
+func (c *client) closed(t *testing.T) int {
+	t.Helper()
+	for {
+		op, payload := c.next(t)
+		if op == 0x8 {
+			if len(payload) < 2 {
+				t.Fatalf("close frame of %d bytes, want a code", len(payload))
+			}
+			return int(binary.BigEndian.Uint16(payload))
+		}
+	}
+}
+
+// connected waits until s has n clients.
+func connected(t *testing.T, s *live.Subscriber, n int) {
+	t.Helper()
+	for deadline := time.Now().Add(5 * time.Second); s.Clients() != n; time.Sleep(time.Millisecond) {
+		if time.Now().After(deadline) {
+			t.Fatalf("Clients() = %d, want %d", s.Clients(), n)
+		}
+	}
+}
+
+var mask = []byte{0x37, 0xfa, 0x21, 0x3d}
+
+func TestSubscriber_Handshake(t *testing.T) {
+	s := live.NewSubscriber()
+	srv := httptest.NewServer(s)
+	defer srv.Close()
+
+	resp, c := handshake(t, srv, "/?event=employee.hired")
+	// the key and accept value of RFC 6455's own example
+	if resp.StatusCode != http.StatusSwitchingProtocols || resp.Header.Get("Sec-WebSocket-Accept") != "s3pPLMBiTxaQ9kYGzzhZRbK+xOo=" {
+		t.Fatalf("handshake = %s, Sec-WebSocket-Accept %q, want 101 and s3pPLMBiTxaQ9kYGzzhZRbK+xOo=", resp.Status, resp.Header.Get("Sec-WebSocket-Accept"))
+	}
+	connected(t, s, 1)
+	_ = s.Handle(t.Context(), payslip{Lines: "filtered out"})
+	_ = s.Handle(t.Context(), hired{Name: "Ali"})
+	op, payload := c.next(t)
+	var msg struct {
+		Event string `json:"event"`
+		Data  hired  `json:"data"`
+	}
+	if err := json.Unmarshal(payload, &msg); op != 0x1 || err != nil || msg.Event != "employee.hired" || msg.Data.Name != "Ali" {
+		t.Errorf("frame = op %d %s, want a text frame for Ali's hiring only", op, payload)
+	}
+
+	plain, err := http.Get(srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	plain.Body.Close()
+	if plain.StatusCode != http.StatusUpgradeRequired {
+		t.Errorf("GET without an upgrade = %s, want 426", plain.Status)
+	}
+}
+
+func TestSubscriber_Origins(t *testing.T) {
+	s := live.NewSubscriber(live.WithOrigins("https://hr.example.com"))
+	srv := httptest.NewServer(s)
+	defer srv.Close()
+	tests := []struct {
+		origin string
+		want   int
+	}{
+		{"", http.StatusSwitchingProtocols}, // not a browser
+		{"http://" + srv.Listener.Addr().String(), http.StatusSwitchingProtocols},
+		{"https://hr.example.com", http.StatusSwitchingProtocols},
+		{"https://evil.example", http.StatusForbidden},
+		{"https://hr.example.com.evil.example", http.StatusForbidden},
+	}
+	for _, tt := range tests {
+		var extra []string
+		if tt.origin != "" {
+			extra = append(extra, "Origin: "+tt.origin)
+		}
+		if resp, _ := handshake(t, srv, "/", extra...); resp.StatusCode != tt.want {
+			t.Errorf("handshake from %q = %s, want %d", tt.origin, resp.Status, tt.want)
+		}
+	}
+}
+
+func TestSubscriber_PingPong(t *testing.T) {
+	srv := httptest.NewServer(live.NewSubscriber())
+	defer srv.Close()
+	c := dial(t, srv, "/")
+	c.send(t, 0x9, []byte("are you there"), mask)
+	if op, payload := c.next(t); op != 0xA || string(payload) != "are you there" {
+		t.Errorf("reply to a ping = op %d %q, want a pong with its payload", op, payload)
+	}
+}
+
+func TestSubscriber_Close(t *testing.T) {
+	s := live.NewSubscriber()
+	srv := httptest.NewServer(s)
+	defer srv.Close()
+	c := dial(t, srv, "/")
+	connected(t, s, 1)
+	c.send(t, 0x8, binary.BigEndian.AppendUint16(nil, 1000), mask)
+	if code := c.closed(t); code != 1000 {
+		t.Errorf("reply to a close = %d, want 1000", code)
+	}
+	if _, err := c.r.ReadByte(); err != io.EOF {
+		t.Errorf("read after the close handshake error = %v, want EOF", err)
+	}
+	connected(t, s, 0)
+}
+
+func TestSubscriber_RejectsBadFrames(t *testing.T) {
+	tests := []struct {
+		name  string
+		frame []byte
+		want  int
+	}{
+		{"unmasked", []byte{0x89, 0x00}, 1008},
+		{"over 125 bytes", append([]byte{0x81, 0xFE, 0x01, 0x00}, mask...), 1009},
+		{"a length with the top bit set", append([]byte{0x82, 0xFF, 0x80, 0, 0, 0, 0, 0, 0, 0}, mask...), 1009},
+		{"a length of exabytes", append([]byte{0x82, 0xFF, 0x7F, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF}, mask...), 1009},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := live.NewSubscriber()
+			srv := httptest.NewServer(s)
+			defer srv.Close()
+			c := dial(t, srv, "/")
+			connected(t, s, 1)
+			if _, err := c.conn.Write(tt.frame); err != nil {
+				t.Fatal(err)
+			}
+			if code := c.closed(t); code != tt.want {
+				t.Errorf("close code = %d, want %d", code, tt.want)
+			}
+			connected(t, s, 0)
+		})
+	}
+}
+
+func TestSubscriber_DropsSlowClients(t *testing.T) {
+	s := live.NewSubscriber(live.WithBuffer(1), live.WithWriteTimeout(time.Minute))
+	srv := httptest.NewServer(s)
+	defer srv.Close()
+	slow := dial(t, srv, "/")
+	connected(t, s, 1)
+	// the client reads nothing: the socket fills, the write loop blocks and
+	// the buffer fills behind it, without Handle ever waiting
+	big := payslip{Lines: strings.Repeat("x", 1<<20)}
+	for i := 0; s.Clients() > 0; i++ {
+		if i == 200 {
+			t.Fatal("the slow client is still connected after 200MB")
+		}
+		start := time.Now()
+		if err := s.Handle(t.Context(), big); err != nil {
+			t.Fatal(err)
+		}
+		if d := time.Since(start); d > time.Second {
+			t.Fatalf("Handle() took %v, want it never to wait for a client", d)
+		}
+	}
+	_ = slow.conn.SetDeadline(time.Now().Add(time.Minute))
+	if code := slow.closed(t); code != 1013 {
+		t.Errorf("close code = %d, want 1013", code)
+	}
+}
@@ -0,0 +1,184 @@
+package live
+
+import (
+	"bufio"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// The server side of RFC 6455, as much as a one-way feed needs: the
+// handshake, unfragmented text frames out, and control frames both ways.
+// What clients send besides pings and closes is read and dropped.
+
+// Close codes sent to clients
+const (
+	closeNormal    = 1000
+	closeGoingAway = 1001
+	closePolicy    = 1008
+	closeTooBig    = 1009
+	closeTryLater  = 1013
+)
+
+const (
+	opText  = 0x1
+	opClose = 0x8
+	opPing  = 0x9
+	opPong  = 0xA
+
+	// maxClientFrame Clients only send control frames worth reading, whose
+	// payloads are 125 bytes at most; a larger frame closes the connection
+	// rather than have the server read whatever length it claims.
+	maxClientFrame = 125
+)
+
+var errNotWebSocket = errors.New("not a websocket handshake")
+
+// conn One upgraded connection. Writes are serialised, so the write loop and
+// the read loop's pongs and close replies can share it.
+type conn struct {
+	netConn net.Conn
+	r       *bufio.Reader
+
+	mu     sync.Mutex
+	closed bool
+}
+
+// upgrade completes the opening handshake and takes the connection over from
+// the HTTP server.
+func upgrade(w http.ResponseWriter, r *http.Request) (*conn, error) {
+	key := r.Header.Get("Sec-WebSocket-Key")
+	if r.Method != http.MethodGet || !headerHas(r.Header, "Connection", "upgrade") ||
+		!headerHas(r.Header, "Upgrade", "websocket") || key == "" {
+		return nil, errNotWebSocket
+	}
+	if r.Header.Get("Sec-WebSocket-Version") != "13" {
+		w.Header().Set("Sec-WebSocket-Version", "13")
+		return nil, fmt.Errorf("%w: unsupported version %q", errNotWebSocket, r.Header.Get("Sec-WebSocket-Version"))
+	}
+	nc, rw, err := http.NewResponseController(w).Hijack()
+	if err != nil {
+		return nil, err
+	}
+	sum := sha1.Sum([]byte(key + "258EAFA5-E914-47DA-95CA-C5AB0DC85B11"))
+	_, _ = rw.WriteString("HTTP/1.1 101 Switching Protocols\r\nUpgrade: websocket\r\nConnection: Upgrade\r\n" +
+		"Sec-WebSocket-Accept: " + base64.StdEncoding.EncodeToString(sum[:]) + "\r\n\r\n")
+	if err := rw.Flush(); err != nil {
+		nc.Close()
+		return nil, err
+	}
+	return &conn{netConn: nc, r: rw.Reader}, nil
+}
+
+func headerHas(h http.Header, name, token string) bool {
+	for _, v := range h.Values(name) {
+		for part := range strings.SplitSeq(v, ",") {
+			if strings.EqualFold(strings.TrimSpace(part), token) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// writeFrame sends one unmasked frame, as servers do.
+func (c *conn) writeFrame(op byte, payload []byte, timeout time.Duration) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.closed {
+		return net.ErrClosed
+	}
+	header := []byte{0x80 | op, 0, 0, 0, 0, 0, 0, 0, 0, 0}
+	n := 2
+	switch l := len(payload); {
+	case l < 126:
+		header[1] = byte(l)
+	case l <= 0xFFFF:
+		header[1] = 126
+		binary.BigEndian.PutUint16(header[2:], uint16(l))
+		n = 4
+	default:
+		header[1] = 127
+		binary.BigEndian.PutUint64(header[2:], uint64(l))
+		n = 10
+	}
+	_ = c.netConn.SetWriteDeadline(time.Now().Add(timeout))
+	_, err := (&net.Buffers{header[:n], payload}).WriteTo(c.netConn)
+	return err
+}
+
+// close sends a close frame with code and reason, and closes the connection.
+// Later calls do nothing.
+func (c *conn) close(code int, reason string) {
+	payload := binary.BigEndian.AppendUint16(nil, uint16(code))
+	_ = c.writeFrame(opClose, append(payload, reason...), time.Second)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if !c.closed {
+		c.closed = true
+		c.netConn.Close()
+	}
+}
+
+// readLoop reads what the client sends until it closes the connection or
+// breaks the protocol, answering pings. It returns why it stopped.
+func (c *conn) readLoop() error {
+	var header [2]byte
+	for {
+		if _, err := io.ReadFull(c.r, header[:]); err != nil {
+			return err
+		}
+		op, masked := header[0]&0x0F, header[1]&0x80 != 0
+		if !masked {
+			c.close(closePolicy, "client frames must be masked")
+			return errors.New("unmasked client frame")
+		}
+		length := uint64(header[1] & 0x7F)
+		switch length {
+		case 126:
+			var ext [2]byte
+			if _, err := io.ReadFull(c.r, ext[:]); err != nil {
+				return err
+			}
+			length = uint64(binary.BigEndian.Uint16(ext[:]))
+		case 127:
+			var ext [8]byte
+			if _, err := io.ReadFull(c.r, ext[:]); err != nil {
+				return err
+			}
+			length = binary.BigEndian.Uint64(ext[:])
+		}
+		if length > maxClientFrame {
+			c.close(closeTooBig, "frame too large")
+			return fmt.Errorf("client frame of %d bytes", length)
+		}
+		var mask [4]byte
+		if _, err := io.ReadFull(c.r, mask[:]); err != nil {
+			return err
+		}
+		payload := make([]byte, length)
+		if _, err := io.ReadFull(c.r, payload); err != nil {
+			return err
+		}
+		for i := range payload {
+			payload[i] ^= mask[i%4]
+		}
+		switch op {
+		case opClose:
+			c.close(closeNormal, "")
+			return io.EOF
+		case opPing:
+			if err := c.writeFrame(opPong, payload, time.Second); err != nil {
+				return err
+			}
+		}
+	}
+}
@@ -0,0 +1,114 @@
+package load
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"strings"
+	"time"
+)
+
+// subBuckets per doubling: bucket bounds grow by 2^(1/8), about 9%, which
+// bounds the error of every quantile
+const subBuckets = 8
+
+// Histogram Latencies in log-scaled buckets from a microsecond up. Not
+// safe for concurrent use.
+type Histogram struct {
+	counts []int
+	count  int
+	sum    time.Duration
+	max    time.Duration
+}
+
+func bucket(d time.Duration) int {
+	if d <= time.Microsecond {
+		return 0
+	}
+	return int(math.Ceil(math.Log2(float64(d)/float64(time.Microsecond)) * subBuckets))
+}
+
+// upper is the largest latency bucket i holds.
+func upper(i int) time.Duration {
+	return time.Duration(float64(time.Microsecond) * math.Exp2(float64(i)/subBuckets))
+}
+
+func (h *Histogram) Record(d time.Duration) {
+	i := bucket(d)
+	if i >= len(h.counts) {
+		h.counts = append(h.counts, make([]int, i+1-len(h.counts))...)
+	}
+	h.counts[i]++
+	h.count++
+	h.sum += d
+	h.max = max(h.max, d)
+}
+
+// Merge adds every latency recorded in o.
+func (h *Histogram) Merge(o *Histogram) {
+	if len(o.counts) > len(h.counts) {
+		h.counts = append(h.counts, make([]int, len(o.counts)-len(h.counts))...)
+	}
+	for i, n := range o.counts {
+		h.counts[i] += n
+	}
+	h.count += o.count
+	h.sum += o.sum
+	h.max = max(h.max, o.max)
+}
+
+func (h *Histogram) Count() int         { return h.count }
+func (h *Histogram) Max() time.Duration { return h.max }
+
+func (h *Histogram) Mean() time.Duration {
+	if h.count == 0 {
+		return 0
+	}
+	return h.sum / time.Duration(h.count)
+}
+
+// Quantile returns the latency q (0..1) of the requests were at most, to
+// within a bucket.
+func (h *Histogram) Quantile(q float64) time.Duration {
+	if h.count == 0 {
+		return 0
+	}
+	rank := int(math.Ceil(q * float64(h.count)))
+	seen := 0
+	for i, n := range h.counts {
+		seen += n
+		if seen >= max(rank, 1) {
+			return min(upper(i), h.max)
+		}
+	}
+	return h.max
+}
+
+// Write draws the histogram with one bar per doubling of latency, the
+// longest bar width characters wide.
+func (h *Histogram) Write(w io.Writer, width int) {
+	var rows []int
+	for i, n := range h.counts {
+		if i/subBuckets >= len(rows) {
+			rows = append(rows, make([]int, i/subBuckets+1-len(rows))...)
+		}
+		rows[i/subBuckets] += n
+	}
+	first, most := -1, 0
+	for i, n := range rows {
+		if n > 0 && first < 0 {
+			first = i
+		}
+		most = max(most, n)
+	}
+	if first < 0 {
+		return
+	}
+	for i := first; i < len(rows); i++ {
+		bar := rows[i] * width / most
+		if rows[i] > 0 {
+			bar = max(bar, 1)
+		}
+		fmt.Fprintf(w, "  ≤ %-9s %-*s %d\n", upper((i+1)*subBuckets).Round(time.Microsecond), width, strings.Repeat("█", bar), rows[i])
+	}
+}
@@ -0,0 +1,259 @@
+// Package load drives traffic at the employee API and reports latencies,
+// to see how the reference application - and the decorators wrapped around
+// it - behave under load.
+//
+// The generator is open-loop: requests are sent on schedule whether or not
+// earlier ones have answered, and latency is measured from when a request
+// was due. A slow server therefore shows up as latency, instead of quietly
+// lowering the rate the way a closed loop of waiting workers would.
+package load
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	"go-solid/fakes"
+	"go-solid/httpapi"
+)
+
+// Request One request to send; Name groups it in the report
+type Request struct {
+	Name   string
+	Method string
+	Path   string
+	Body   any // sent as JSON when not nil
+}
+
+// TrafficPattern Strategy - decides what the next request is. Next is
+// called from a single goroutine, so patterns may keep state unguarded.
+type TrafficPattern interface {
+	Next(rng *rand.Rand) Request
+}
+
+// Runner Sends RPS requests a second from Pattern to URL for Duration
+type Runner struct {
+	URL      string
+	Pattern  TrafficPattern
+	RPS      int
+	Duration time.Duration
+	// MaxInFlight bounds concurrent requests (default 256). A request due
+	// while all are busy is dropped and counted, rather than queued.
+	MaxInFlight int
+	Client      *http.Client
+	// Seed makes the sequence of requests repeatable
+	Seed uint64
+}
+
+var ErrNoRate = errors.New("rps must be positive")
+
+// Run sends requests until Duration has passed or ctx is done, then waits
+// for those in flight. An interrupted run still reports what it sent.
+func (r *Runner) Run(ctx context.Context) (*Report, error) {
+	if r.RPS <= 0 {
+		return nil, ErrNoRate
+	}
+	client := r.Client
+	if client == nil {
+		client = &http.Client{Timeout: 30 * time.Second}
+	}
+	rng := rand.New(rand.NewPCG(r.Seed, r.Seed))
+	inFlight := make(chan struct{}, cmp.Or(max(r.MaxInFlight, 0), 256))
+	report := &Report{Routes: map[string]*Route{}}
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+
+	interval := time.Second / time.Duration(r.RPS)
+	start := time.Now()
+	end := start.Add(r.Duration)
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+loop:
+	for i := 0; ; i++ {
+		due := start.Add(time.Duration(i) * interval)
+		if !due.Before(end) {
+			break
+		}
+		timer.Reset(time.Until(due))
+		select {
+		case <-ctx.Done():
+			break loop
+		case <-timer.C:
+		}
+
+		req := r.Pattern.Next(rng)
+		select {
+		case inFlight <- struct{}{}:
+		default:
+			mu.Lock()
+			report.route(req.Name).Dropped++
+			mu.Unlock()
+			continue
+		}
+		wg.Go(func() {
+			defer func() { <-inFlight }()
+			status, err := send(ctx, client, r.URL, req)
+			latency := time.Since(due)
+			mu.Lock()
+			defer mu.Unlock()
+			report.route(req.Name).record(status, err, latency)
+		})
+	}
+	wg.Wait()
+	report.Elapsed = time.Since(start)
+	for _, route := range report.Routes {
+		report.Latency.Merge(&route.Latency)
+	}
+	return report, nil
+}
+
+func send(ctx context.Context, client *http.Client, base string, r Request) (int, error) {
+	var body io.Reader
+	if r.Body != nil {
+		data, err := json.Marshal(r.Body)
+		if err != nil {
+			return 0, err
+		}
+		body = bytes.NewReader(data)
+	}
+	req, err := http.NewRequestWithContext(ctx, r.Method, strings.TrimSuffix(base, "/")+r.Path, body)
+	if err != nil {
+		return 0, err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+	return resp.StatusCode, nil
+}
+
+// Seed creates the n employees Get and ChangeSalary pick from, named by
+// Employee; titles, emails and salaries come from f.
+func Seed(ctx context.Context, client *http.Client, base string, f fakes.Faker, n int) error {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	for i := range n {
+		emp := f.Employee()
+		req := Request{Method: http.MethodPost, Path: "/employees", Body: httpapi.CreateRequest{
+			Name:   Employee(i),
+			Title:  emp.Title,
+			Email:  emp.Email,
+			Salary: emp.Salary,
+		}}
+		status, err := send(ctx, client, base, req)
+		if err != nil {
+			return err
+		}
+		if status != http.StatusCreated {
+			return fmt.Errorf("seeding %s: %d %s", Employee(i), status, http.StatusText(status))
+		}
+	}
+	return nil
+}
+
+// Route What happened to the requests of one name
+type Route struct {
+	Sent    int
+	Dropped int
+	// Errors counts requests that failed to send or got a 5xx
+	Errors  int
+	Status  map[int]int
+	Latency Histogram
+}
+
+func (r *Route) record(status int, err error, latency time.Duration) {
+	r.Sent++
+	if err != nil || status >= 500 {
+		r.Errors++
+	}
+	if status != 0 {
+		r.Status[status]++
+	}
+	r.Latency.Record(latency)
+}
+
+// Report The outcome of a run
+type Report struct {
+	Elapsed time.Duration
+	Routes  map[string]*Route
+	Latency Histogram // every route's
+}
+
+func (r *Report) route(name string) *Route {
+	route, ok := r.Routes[name]
+	if !ok {
+		route = &Route{Status: map[int]int{}}
+		r.Routes[name] = route
+	}
+	return route
+}
+
+// Write prints a row per route, the achieved rate and the histogram of
+// all latencies.
+func (r *Report) Write(w io.Writer) error {
+	names := make([]string, 0, len(r.Routes))
+	sent, dropped, errs := 0, 0, 0
+	for name, route := range r.Routes {
+		names = append(names, name)
+		sent += route.Sent
+		dropped += route.Dropped
+		errs += route.Errors
+	}
+	sort.Strings(names)
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "route\tsent\terrors\tdropped\tp50\tp90\tp99\tmax\tstatus")
+	for _, name := range names {
+		route := r.Routes[name]
+		fmt.Fprintf(tw, "%s\t%d\t%d\t%d\t%s\t%s\t%s\t%s\t%s\n", name, route.Sent, route.Errors, route.Dropped,
+			round(route.Latency.Quantile(0.5)), round(route.Latency.Quantile(0.9)), round(route.Latency.Quantile(0.99)),
+			round(route.Latency.Max()), statuses(route.Status))
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+	rate := 0.0
+	if r.Elapsed > 0 {
+		rate = float64(sent) / r.Elapsed.Seconds()
+	}
+	fmt.Fprintf(w, "\n%d sent in %s (%.0f/s), %d errors, %d dropped\n\n", sent, r.Elapsed.Round(time.Millisecond), rate, errs, dropped)
+	r.Latency.Write(w, 40)
+	return nil
+}
+
+func round(d time.Duration) time.Duration {
+	if d < time.Millisecond {
+		return d.Round(time.Microsecond)
+	}
+	return d.Round(100 * time.Microsecond)
+}
+
+func statuses(counts map[int]int) string {
+	codes := make([]int, 0, len(counts))
+	for code := range counts {
+		codes = append(codes, code)
+	}
+	sort.Ints(codes)
+	parts := make([]string, len(codes))
+	for i, code := range codes {
+		parts[i] = fmt.Sprintf("%d×%d", code, counts[code])
+	}
+	return strings.Join(parts, " ")
+}
@@ -0,0 +1,51 @@
+package load_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"go-solid/approve"
+	"go-solid/load"
+)
+
+func route(sent, errs, dropped int, status map[int]int, latencies ...time.Duration) *load.Route {
+	r := &load.Route{Sent: sent, Errors: errs, Dropped: dropped, Status: status}
+	for _, d := range latencies {
+		r.Latency.Record(d)
+	}
+	return r
+}
+
+func TestReport_Write(t *testing.T) {
+	var fast, slow []time.Duration
+	for i := range 90 {
+		fast = append(fast, time.Duration(200+i*10)*time.Microsecond)
+	}
+	for i := range 10 {
+		slow = append(slow, time.Duration(5+i*20)*time.Millisecond)
+	}
+	report := &load.Report{
+		Elapsed: 2 * time.Second,
+		Routes: map[string]*load.Route{
+			"get":    route(90, 0, 0, map[int]int{200: 85, 404: 5}, fast...),
+			"salary": route(10, 2, 3, map[int]int{200: 7, 409: 1, 503: 2}, slow...),
+		},
+	}
+	for _, r := range report.Routes {
+		report.Latency.Merge(&r.Latency)
+	}
+	var out strings.Builder
+	if err := report.Write(&out); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	approve.Verify(t, out.String())
+}
+
+func TestReport_WriteEmpty(t *testing.T) {
+	var out strings.Builder
+	if err := (&load.Report{Routes: map[string]*load.Route{}}).Write(&out); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	approve.Verify(t, out.String())
+}
@@ -0,0 +1,137 @@
+package load
+
+import (
+	"cmp"
+	"fmt"
+	"maps"
+	"math/rand/v2"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	"go-solid/httpapi"
+	"go-solid/money"
+)
+
+// Employee is the name of the i-th employee Seed creates.
+func Employee(i int) string { return "load-" + strconv.Itoa(i) }
+
+// Get Reads one seeded employee
+type Get struct {
+	Population int
+}
+
+func (p Get) Next(rng *rand.Rand) Request {
+	return Request{Name: "get", Method: http.MethodGet, Path: "/employees/" + Employee(rng.IntN(max(p.Population, 1)))}
+}
+
+// List Reads the first page of employees, sorted by salary
+type List struct {
+	Limit int
+}
+
+func (p List) Next(rng *rand.Rand) Request {
+	return Request{Name: "list", Method: http.MethodGet, Path: fmt.Sprintf("/employees?sort=salary&limit=%d", cmp.Or(p.Limit, 20))}
+}
+
+// Create Adds employees that weren't seeded, each with a new name
+type Create struct {
+	next int
+}
+
+func (p *Create) Next(rng *rand.Rand) Request {
+	p.next++
+	return Request{Name: "create", Method: http.MethodPost, Path: "/employees", Body: httpapi.CreateRequest{
+		Name:   fmt.Sprintf("load-new-%d", p.next),
+		Salary: money.Of(3000+rng.Int64N(4000), money.USD),
+	}}
+}
+
+// ChangeSalary Changes the salary of one seeded employee
+type ChangeSalary struct {
+	Population int
+}
+
+func (p ChangeSalary) Next(rng *rand.Rand) Request {
+	name := Employee(rng.IntN(max(p.Population, 1)))
+	return Request{Name: "salary", Method: http.MethodPut, Path: "/employees/" + name + "/salary", Body: httpapi.SalaryRequest{
+		Salary: money.Of(3000+rng.Int64N(4000), money.USD),
+	}}
+}
+
+// Weighted A pattern and its share of a Mix
+type Weighted struct {
+	Weight  int
+	Pattern TrafficPattern
+}
+
+// Mix Composite - picks one of its patterns per request, in proportion to
+// their weights
+type Mix []Weighted
+
+func (m Mix) Next(rng *rand.Rand) Request {
+	total := 0
+	for _, w := range m {
+		total += w.Weight
+	}
+	n := rng.IntN(total)
+	for _, w := range m {
+		if n < w.Weight {
+			return w.Pattern.Next(rng)
+		}
+		n -= w.Weight
+	}
+	panic("unreachable")
+}
+
+// Patterns Builds each named pattern for a seeded population. Adding a
+// pattern here makes it usable in every mix.
+var Patterns = map[string]func(population int) TrafficPattern{
+	"get":    func(n int) TrafficPattern { return Get{Population: n} },
+	"list":   func(n int) TrafficPattern { return List{} },
+	"create": func(n int) TrafficPattern { return &Create{} },
+	"salary": func(n int) TrafficPattern { return ChangeSalary{Population: n} },
+}
+
+// Mixes Named traffic mixes, as ParseMix specs
+var Mixes = map[string]string{
+	"read-heavy":  "get=8,list=1,salary=1",
+	"balanced":    "get=4,list=2,create=2,salary=2",
+	"write-heavy": "get=2,create=4,salary=4",
+}
+
+// ParseMix builds a Mix from a named mix or a spec like "get=8,create=2".
+func ParseMix(spec string, population int) (Mix, error) {
+	if named, ok := Mixes[spec]; ok {
+		spec = named
+	}
+	var mix Mix
+	for part := range strings.SplitSeq(spec, ",") {
+		name, weight, _ := strings.Cut(strings.TrimSpace(part), "=")
+		build, ok := Patterns[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown pattern %q; have %s", name, strings.Join(slices.Sorted(maps.Keys(Patterns)), ", "))
+		}
+		w := 1
+		if weight != "" {
+			var err error
+			if w, err = strconv.Atoi(weight); err != nil || w < 0 {
+				return nil, fmt.Errorf("pattern %s: weight %q must be a whole number", name, weight)
+			}
+		}
+		mix = append(mix, Weighted{Weight: w, Pattern: build(population)})
+	}
+	if slices.IndexFunc(mix, func(w Weighted) bool { return w.Weight > 0 }) < 0 {
+		return nil, fmt.Errorf("mix %q has no weight", spec)
+	}
+	return mix, nil
+}
+
+var (
+	_ TrafficPattern = Get{}
+	_ TrafficPattern = List{}
+	_ TrafficPattern = (*Create)(nil)
+	_ TrafficPattern = ChangeSalary{}
+	_ TrafficPattern = Mix{}
+)
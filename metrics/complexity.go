@@ -0,0 +1,291 @@
+// Package metrics measures code, so the benefit of a refactoring can be
+// shown as numbers rather than argued.
+//
+// Complexity is computed per function from the syntax tree alone - no type
+// checking - so it works on any directory of Go files, including the
+// embedded lesson checkpoints.
+package metrics
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"path"
+	"sort"
+	"strings"
+)
+
+// Function Complexity of one function or method
+type Function struct {
+	File string
+	Line int
+	Name string // "getSalary" or "(employee).getSalary"
+	// Cyclomatic counts independent paths: 1 + one per branch point
+	// (if, loop, case, && and ||)
+	Cyclomatic int
+	// Cognitive estimates how hard the code is to read: branches cost more
+	// the deeper they are nested, and a chain of && costs once
+	Cognitive int
+	Lines     int
+}
+
+// Analyze measures every function declared in file.
+func Analyze(fset *token.FileSet, file *ast.File) []Function {
+	var fns []Function
+	for _, decl := range file.Decls {
+		fd, ok := decl.(*ast.FuncDecl)
+		if !ok || fd.Body == nil {
+			continue
+		}
+		start, end := fset.Position(fd.Pos()), fset.Position(fd.End())
+		fns = append(fns, Function{
+			File:       start.Filename,
+			Line:       start.Line,
+			Name:       funcName(fd),
+			Cyclomatic: cyclomatic(fd.Body),
+			Cognitive:  cognitive(fd),
+			Lines:      end.Line - start.Line + 1,
+		})
+	}
+	return fns
+}
+
+// Dir measures the non-test Go files directly in dir of fsys.
+func Dir(fsys fs.FS, dir string) ([]Function, error) {
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return nil, err
+	}
+	files := map[string][]byte{}
+	for _, e := range entries {
+		if e.IsDir() {
+			continue
+		}
+		name := path.Join(dir, e.Name())
+		if !isSource(name) {
+			continue
+		}
+		if files[name], err = fs.ReadFile(fsys, name); err != nil {
+			return nil, err
+		}
+	}
+	return Files(files)
+}
+
+// Files measures the non-test Go files among files, by path - the shape of
+// a lesson checkpoint's tree.
+func Files(files map[string][]byte) ([]Function, error) {
+	fset := token.NewFileSet()
+	var fns []Function
+	for name, src := range files {
+		if !isSource(name) {
+			continue
+		}
+		file, err := parser.ParseFile(fset, name, src, parser.SkipObjectResolution)
+		if err != nil {
+			return nil, err
+		}
+		fns = append(fns, Analyze(fset, file)...)
+	}
+	sort.Slice(fns, func(i, j int) bool {
+		if fns[i].File != fns[j].File {
+			return fns[i].File < fns[j].File
+		}
+		return fns[i].Line < fns[j].Line
+	})
+	return fns, nil
+}
+
+func isSource(name string) bool {
+	return strings.HasSuffix(name, ".go") && !strings.HasSuffix(name, "_test.go")
+}
+
+func funcName(fd *ast.FuncDecl) string {
+	if fd.Recv == nil || len(fd.Recv.List) == 0 {
+		return fd.Name.Name
+	}
+	t := fd.Recv.List[0].Type
+	for {
+		switch x := t.(type) {
+		case *ast.StarExpr:
+			t = x.X
+			continue
+		case *ast.IndexExpr:
+			t = x.X
+			continue
+		case *ast.IndexListExpr:
+			t = x.X
+			continue
+		case *ast.Ident:
+			return fmt.Sprintf("(%s).%s", x.Name, fd.Name.Name)
+		}
+		return fd.Name.Name
+	}
+}
+
+func cyclomatic(body *ast.BlockStmt) int {
+	n := 1
+	ast.Inspect(body, func(node ast.Node) bool {
+		switch x := node.(type) {
+		case *ast.IfStmt, *ast.ForStmt, *ast.RangeStmt:
+			n++
+		case *ast.CaseClause:
+			if x.List != nil { // default is the path that's already counted
+				n++
+			}
+		case *ast.CommClause:
+			if x.Comm != nil {
+				n++
+			}
+		case *ast.BinaryExpr:
+			if x.Op == token.LAND || x.Op == token.LOR {
+				n++
+			}
+		}
+		return true
+	})
+	return n
+}
+
+// cognitive follows the cognitive complexity rules: +1 for each break in
+// the linear flow, plus the nesting depth for the structures that nest.
+func cognitive(fd *ast.FuncDecl) int {
+	c := &cognitiveCounter{name: fd.Name.Name}
+	c.block(fd.Body.List, 0)
+	return c.total
+}
+
+type cognitiveCounter struct {
+	name  string
+	total int
+}
+
+func (c *cognitiveCounter) block(stmts []ast.Stmt, nesting int) {
+	for _, s := range stmts {
+		c.stmt(s, nesting)
+	}
+}
+
+func (c *cognitiveCounter) stmt(s ast.Stmt, nesting int) {
+	switch x := s.(type) {
+	case *ast.IfStmt:
+		c.total += 1 + nesting
+		c.ifChain(x, nesting)
+	case *ast.ForStmt:
+		c.total += 1 + nesting
+		c.expr(x.Cond, nesting)
+		c.block(x.Body.List, nesting+1)
+	case *ast.RangeStmt:
+		c.total += 1 + nesting
+		c.expr(x.X, nesting)
+		c.block(x.Body.List, nesting+1)
+	case *ast.SwitchStmt:
+		c.total += 1 + nesting
+		c.expr(x.Tag, nesting)
+		c.clauses(x.Body, nesting+1)
+	case *ast.TypeSwitchStmt:
+		c.total += 1 + nesting
+		c.clauses(x.Body, nesting+1)
+	case *ast.SelectStmt:
+		c.total += 1 + nesting
+		c.clauses(x.Body, nesting+1)
+	case *ast.BranchStmt:
+		if x.Label != nil || x.Tok == token.GOTO {
+			c.total++ // a jump to a label breaks the flow wherever it is
+		}
+	case *ast.LabeledStmt:
+		c.stmt(x.Stmt, nesting)
+	case *ast.BlockStmt:
+		c.block(x.List, nesting)
+	default:
+		// plain statements: only their expressions (conditions, closures) count
+		ast.Inspect(s, func(n ast.Node) bool {
+			if e, ok := n.(ast.Expr); ok {
+				c.expr(e, nesting)
+				return false
+			}
+			return true
+		})
+	}
+}
+
+// ifChain scores the else branches of an if: each else if and else is +1,
+// without a nesting increment - they read as one flat decision.
+func (c *cognitiveCounter) ifChain(x *ast.IfStmt, nesting int) {
+	if x.Init != nil {
+		c.stmt(x.Init, nesting)
+	}
+	c.expr(x.Cond, nesting)
+	c.block(x.Body.List, nesting+1)
+	switch e := x.Else.(type) {
+	case *ast.IfStmt:
+		c.total++
+		c.ifChain(e, nesting)
+	case *ast.BlockStmt:
+		c.total++
+		c.block(e.List, nesting+1)
+	}
+}
+
+func (c *cognitiveCounter) clauses(body *ast.BlockStmt, nesting int) {
+	for _, cl := range body.List {
+		switch x := cl.(type) {
+		case *ast.CaseClause:
+			c.block(x.Body, nesting)
+		case *ast.CommClause:
+			c.block(x.Body, nesting)
+		}
+	}
+}
+
+// expr scores what hides inside expressions: sequences of logical operators,
+// closures (which nest) and recursive calls.
+func (c *cognitiveCounter) expr(e ast.Expr, nesting int) {
+	if e == nil {
+		return
+	}
+	ast.Inspect(e, func(n ast.Node) bool {
+		switch x := n.(type) {
+		case *ast.FuncLit:
+			c.block(x.Body.List, nesting+1)
+			return false
+		case *ast.BinaryExpr:
+			if x.Op == token.LAND || x.Op == token.LOR {
+				c.total += logicalSequences(x)
+				return false
+			}
+		case *ast.CallExpr:
+			if id, ok := x.Fun.(*ast.Ident); ok && id.Name == c.name {
+				c.total++ // recursion
+			}
+		}
+		return true
+	})
+}
+
+// logicalSequences counts the runs of like operators in a && / || chain:
+// a && b && c is 1, a && b || c is 2.
+func logicalSequences(e *ast.BinaryExpr) int {
+	var ops []token.Token
+	var walk func(ast.Expr)
+	walk = func(x ast.Expr) {
+		x = ast.Unparen(x)
+		b, ok := x.(*ast.BinaryExpr)
+		if !ok || (b.Op != token.LAND && b.Op != token.LOR) {
+			return
+		}
+		walk(b.X)
+		ops = append(ops, b.Op)
+		walk(b.Y)
+	}
+	walk(e)
+	n := 0
+	for i, op := range ops {
+		if i == 0 || op != ops[i-1] {
+			n++
+		}
+	}
+	return n
+}
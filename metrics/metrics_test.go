@@ -0,0 +1,100 @@
+package metrics_test
+
+import (
+	"strings"
+	"testing"
+
+	"go-solid/approve"
+	"go-solid/metrics"
+)
+
+// before A salary calculation with every rule nested in one function
+const before = `package payroll
+
+func salary(kind string, base, hours int, senior bool) int {
+	if kind == "manager" {
+		if senior && hours > 160 {
+			return base * 2
+		}
+		return base + base/2
+	} else if kind == "engineer" {
+		for i := 0; i < hours; i++ {
+			if i > 160 || senior {
+				base += 10
+			}
+		}
+		return base
+	}
+	switch kind {
+	case "intern":
+		return base / 2
+	case "contractor":
+		return hours * 50
+	}
+	return base
+}
+`
+
+// after The same rules, one type per kind of employee
+const after = `package payroll
+
+type kind interface{ salary(base, hours int, senior bool) int }
+
+type manager struct{}
+
+func (manager) salary(base, hours int, senior bool) int {
+	if senior && hours > 160 {
+		return base * 2
+	}
+	return base + base/2
+}
+
+type engineer struct{}
+
+func (engineer) salary(base, hours int, senior bool) int {
+	overtime := max(hours-160, 0)
+	if senior {
+		overtime = hours
+	}
+	return base + overtime*10
+}
+
+type intern struct{}
+
+func (intern) salary(base, _ int, _ bool) int { return base / 2 }
+
+type contractor struct{}
+
+func (contractor) salary(_, hours int, _ bool) int { return hours * 50 }
+`
+
+func measure(t *testing.T, src string) []metrics.Function {
+	t.Helper()
+	fns, err := metrics.Files(map[string][]byte{"payroll/payroll.go": []byte(src), "payroll/payroll_test.go": []byte("package payroll\n\nfunc helper() {}\n")})
+	if err != nil {
+		t.Fatalf("Files() error = %v", err)
+	}
+	return fns
+}
+
+func TestWriteFunctions(t *testing.T) {
+	for name, src := range map[string]string{"before": before, "after": after} {
+		var out strings.Builder
+		if err := metrics.WriteFunctions(&out, measure(t, src)); err != nil {
+			t.Fatalf("WriteFunctions() error = %v", err)
+		}
+		approve.Verify(t, out.String(), approve.WithName(name))
+	}
+}
+
+func TestWriteComparisons(t *testing.T) {
+	var out strings.Builder
+	err := metrics.WriteComparisons(&out, []metrics.Comparison{
+		{Name: "payroll", Before: metrics.Sum(measure(t, before)), After: metrics.Sum(measure(t, after))},
+		{Name: "unchanged", Before: metrics.Sum(measure(t, after)), After: metrics.Sum(measure(t, after))},
+	})
+	if err != nil {
+		t.Fatalf("WriteComparisons() error = %v", err)
+	}
+	approve.Verify(t, out.String())
+}
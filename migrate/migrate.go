@@ -0,0 +1,160 @@
+// Package migrate brings a backend's schema up to what the adapters expect,
+// and back down again.
+//
+// Each backend has its own Migrator: SQL backends run the numbered scripts
+// embedded under sql/<backend>, and a document store would create its
+// collections and indexes instead. solid migrate only talks to the Migrator
+// interface, so a new backend registers one (Register) without touching the
+// command - the same registry as storage.Register.
+package migrate
+
+import (
+	"context"
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"sync"
+
+	"go-solid/storage"
+)
+
+// Migration One numbered change to a schema. Up and Down are whatever the
+// backend's Migrator runs: SQL statements for the SQL backends.
+type Migration struct {
+	Version int
+	Name    string
+	Up      string
+	Down    string
+}
+
+func (m Migration) String() string { return fmt.Sprintf("%04d_%s", m.Version, m.Name) }
+
+// Migrator Abstraction - applies and reverts one backend's migrations.
+// Applied migrations are recorded in the backend itself, so running Up twice
+// does nothing the second time.
+type Migrator interface {
+	// Up applies every pending migration in version order and returns them.
+	Up(ctx context.Context) ([]Migration, error)
+	// Down reverts the last steps applied migrations, newest first, and returns them.
+	Down(ctx context.Context, steps int) ([]Migration, error)
+	Close() error
+}
+
+// Constructor builds a Migrator from a backend's storage configuration
+type Constructor func(cfg storage.Config) (Migrator, error)
+
+var (
+	mu       sync.RWMutex
+	backends = map[string]Constructor{}
+)
+
+// Register makes a backend's Migrator available to Open. It panics on
+// duplicates, like storage.Register.
+func Register(backend string, ctor Constructor) {
+	mu.Lock()
+	defer mu.Unlock()
+	if _, dup := backends[backend]; dup {
+		panic("migrate: Register called twice for backend " + backend)
+	}
+	backends[backend] = ctor
+}
+
+// Backends lists the backends with a registered Migrator.
+func Backends() []string {
+	mu.RLock()
+	defer mu.RUnlock()
+	names := make([]string, 0, len(backends))
+	for name := range backends {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Open returns the Migrator registered for cfg.Backend.
+func Open(cfg storage.Config) (Migrator, error) {
+	mu.RLock()
+	ctor, ok := backends[cfg.Backend]
+	mu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("migrate: no migrator for backend %q (registered: %v)", cfg.Backend, Backends())
+	}
+	return ctor(cfg)
+}
+
+// Nop Null Object Migrator for backends without a schema, like memory
+type Nop struct{}
+
+func (Nop) Up(context.Context) ([]Migration, error)        { return nil, nil }
+func (Nop) Down(context.Context, int) ([]Migration, error) { return nil, nil }
+func (Nop) Close() error                                   { return nil }
+
+func init() {
+	Register("memory", func(storage.Config) (Migrator, error) { return Nop{}, nil })
+}
+
+//go:embed sql
+var scripts embed.FS
+
+// SQL returns the migrations embedded for a SQL backend: mysql, postgres or sqlite.
+func SQL(backend string) ([]Migration, error) {
+	dir, err := fs.Sub(scripts, path.Join("sql", backend))
+	if err != nil {
+		return nil, err
+	}
+	return Load(dir)
+}
+
+// ErrInvalidMigration returned by Load for files it cannot make sense of
+var ErrInvalidMigration = errors.New("invalid migration")
+
+var scriptName = regexp.MustCompile(`^(\d+)_(\w+)\.(up|down)\.sql$`)
+
+// Load reads NNNN_name.up.sql and NNNN_name.down.sql pairs from the top of
+// fsys, sorted by version. Every migration needs an up script; a missing
+// down script makes it irreversible.
+func Load(fsys fs.FS) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, err
+	}
+	byVersion := map[int]*Migration{}
+	for _, e := range entries {
+		m := scriptName.FindStringSubmatch(e.Name())
+		if e.IsDir() || m == nil {
+			continue
+		}
+		version, _ := strconv.Atoi(m[1])
+		body, err := fs.ReadFile(fsys, e.Name())
+		if err != nil {
+			return nil, err
+		}
+		mig, ok := byVersion[version]
+		if !ok {
+			mig = &Migration{Version: version, Name: m[2]}
+			byVersion[version] = mig
+		}
+		if mig.Name != m[2] {
+			return nil, fmt.Errorf("migrate: %w: version %d is both %s and %s", ErrInvalidMigration, version, mig.Name, m[2])
+		}
+		if m[3] == "up" {
+			mig.Up = string(body)
+		} else {
+			mig.Down = string(body)
+		}
+	}
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, mig := range byVersion {
+		if mig.Up == "" {
+			return nil, fmt.Errorf("migrate: %w: %s has no up script", ErrInvalidMigration, mig)
+		}
+		migrations = append(migrations, *mig)
+	}
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+	return migrations, nil
+}
@@ -0,0 +1,173 @@
+package migrate
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"go-solid/sqldialect"
+	"go-solid/storage"
+)
+
+// ErrIrreversible returned by Down for a migration without a down script
+var ErrIrreversible = errors.New("migration has no down script")
+
+// SQLMigrator Migrator for database/sql backends. Applied versions are kept in
+// a schema_migrations table; each migration runs in a transaction with its
+// row there, so a failed one leaves nothing behind - except on MySQL, which
+// commits DDL as it goes.
+type SQLMigrator struct {
+	db         *sql.DB
+	dialect    sqldialect.Dialect
+	migrations []Migration
+}
+
+func NewSQL(db *sql.DB, dialect sqldialect.Dialect, migrations []Migration) *SQLMigrator {
+	return &SQLMigrator{db: db, dialect: dialect, migrations: migrations}
+}
+
+const versionTable = `CREATE TABLE IF NOT EXISTS schema_migrations (
+    version    INTEGER      NOT NULL PRIMARY KEY,
+    name       VARCHAR(255) NOT NULL,
+    applied_at TIMESTAMP    NOT NULL
+)`
+
+func (m *SQLMigrator) Up(ctx context.Context) ([]Migration, error) {
+	applied, err := m.applied(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var done []Migration
+	for _, mig := range m.migrations {
+		if applied[mig.Version] {
+			continue
+		}
+		err := m.run(ctx, mig, mig.Up, func(tx *sql.Tx) error {
+			_, err := tx.ExecContext(ctx, m.dialect.Rebind(`INSERT INTO schema_migrations (version, name, applied_at) VALUES (?, ?, ?)`),
+				mig.Version, mig.Name, time.Now().UTC())
+			return err
+		})
+		if err != nil {
+			return done, err
+		}
+		done = append(done, mig)
+	}
+	return done, nil
+}
+
+func (m *SQLMigrator) Down(ctx context.Context, steps int) ([]Migration, error) {
+	applied, err := m.applied(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var done []Migration
+	for i := len(m.migrations) - 1; i >= 0 && len(done) < steps; i-- {
+		mig := m.migrations[i]
+		if !applied[mig.Version] {
+			continue
+		}
+		if mig.Down == "" {
+			return done, fmt.Errorf("migrate: %s: %w", mig, ErrIrreversible)
+		}
+		err := m.run(ctx, mig, mig.Down, func(tx *sql.Tx) error {
+			_, err := tx.ExecContext(ctx, m.dialect.Rebind(`DELETE FROM schema_migrations WHERE version = ?`), mig.Version)
+			return err
+		})
+		if err != nil {
+			return done, err
+		}
+		done = append(done, mig)
+	}
+	return done, nil
+}
+
+func (m *SQLMigrator) Close() error { return m.db.Close() }
+
+// applied creates the version table if needed and reads the versions in it.
+func (m *SQLMigrator) applied(ctx context.Context) (map[int]bool, error) {
+	if _, err := m.db.ExecContext(ctx, versionTable); err != nil {
+		return nil, fmt.Errorf("migrate: create schema_migrations: %w", err)
+	}
+	rows, err := m.db.QueryContext(ctx, `SELECT version FROM schema_migrations`)
+	if err != nil {
+		return nil, fmt.Errorf("migrate: read schema_migrations: %w", err)
+	}
+	defer rows.Close()
+	applied := map[int]bool{}
+	for rows.Next() {
+		var v int
+		if err := rows.Scan(&v); err != nil {
+			return nil, fmt.Errorf("migrate: read schema_migrations: %w", err)
+		}
+		applied[v] = true
+	}
+	return applied, rows.Err()
+}
+
+// run executes script statement by statement, then record, in one transaction.
+func (m *SQLMigrator) run(ctx context.Context, mig Migration, script string, record func(*sql.Tx) error) error {
+	tx, err := m.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("migrate: begin: %w", err)
+	}
+	defer tx.Rollback()
+	for _, stmt := range statements(script) {
+		if _, err := tx.ExecContext(ctx, stmt); err != nil {
+			return fmt.Errorf("migrate: %s: %w", mig, err)
+		}
+	}
+	if err := record(tx); err != nil {
+		return fmt.Errorf("migrate: %s: %w", mig, err)
+	}
+	return tx.Commit()
+}
+
+// statements splits a script on the semicolons ending its lines. Not every
+// driver accepts several statements in one Exec; the embedded scripts keep
+// semicolons out of strings and comments so this split is safe.
+func statements(script string) []string {
+	var stmts []string
+	var cur strings.Builder
+	for line := range strings.Lines(script) {
+		cur.WriteString(line)
+		if strings.HasSuffix(strings.TrimSpace(line), ";") {
+			stmts = append(stmts, strings.TrimSuffix(strings.TrimSpace(cur.String()), ";"))
+			cur.Reset()
+		}
+	}
+	if rest := strings.TrimSpace(cur.String()); rest != "" {
+		stmts = append(stmts, rest)
+	}
+	return stmts
+}
+
+func init() {
+	Register("mysql", sqlBackend("mysql", sqldialect.MySQL{}))
+	Register("postgres", sqlBackend("postgres", sqldialect.Postgres{}))
+	Register("sqlite", sqlBackend("sqlite", sqldialect.SQLite{}))
+}
+
+// sqlBackend opens a database/sql connection for driver with the migrations
+// embedded for it. As with storage.Open, the driver must be linked into the
+// binary.
+func sqlBackend(driver string, dialect sqldialect.Dialect) Constructor {
+	return func(cfg storage.Config) (Migrator, error) {
+		migrations, err := SQL(driver)
+		if err != nil {
+			return nil, err
+		}
+		db, err := sql.Open(driver, cfg.DSN)
+		if err != nil {
+			return nil, fmt.Errorf("migrate: open %s: %w", driver, err)
+		}
+		return NewSQL(db, dialect, migrations), nil
+	}
+}
+
+var (
+	_ Migrator = Nop{}
+	_ Migrator = (*SQLMigrator)(nil)
+)
@@ -0,0 +1,2 @@
+DROP INDEX leave_requests_employee ON leave_requests;
+DROP TABLE leave_requests;
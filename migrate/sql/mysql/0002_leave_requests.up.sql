@@ -0,0 +1,9 @@
+CREATE TABLE leave_requests (
+    id           VARCHAR(64)  NOT NULL PRIMARY KEY,
+    employee     VARCHAR(255) NOT NULL,
+    days         INTEGER      NOT NULL,
+    status       VARCHAR(16)  NOT NULL,
+    requested_at DATETIME(6)  NOT NULL
+) ENGINE=InnoDB;
+
+CREATE INDEX leave_requests_employee ON leave_requests (employee);
@@ -0,0 +1,13 @@
+CREATE TABLE audit_log (
+    seq       BIGINT,
+    time      DATETIME(6) NOT NULL,
+    actor     TEXT,
+    action    TEXT NOT NULL,
+    entity    TEXT NOT NULL,
+    entity_id TEXT,
+    details   TEXT,
+    outcome   TEXT NOT NULL,
+    error     TEXT,
+    prev_hash TEXT,
+    hash      TEXT
+) ENGINE=InnoDB;
@@ -0,0 +1,2 @@
+DROP INDEX outbox_unpublished ON outbox;
+DROP TABLE outbox;
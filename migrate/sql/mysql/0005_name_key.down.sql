@@ -0,0 +1,2 @@
+DROP INDEX employees_name_key ON employees;
+ALTER TABLE employees DROP COLUMN name_key;
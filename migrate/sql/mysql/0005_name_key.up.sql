@@ -0,0 +1,6 @@
+-- name as compared: binary, so the database's collation can't make two keys
+-- equal that the normalizer kept apart. LOWER(TRIM()) approximates
+-- normalize.Name, and sqlrepo's Rekey computes the exact keys.
+ALTER TABLE employees ADD COLUMN name_key VARCHAR(255) CHARACTER SET utf8mb4 COLLATE utf8mb4_bin NOT NULL DEFAULT '' AFTER name;
+UPDATE employees SET name_key = LOWER(TRIM(name));
+CREATE UNIQUE INDEX employees_name_key ON employees (name_key);
@@ -0,0 +1 @@
+ALTER TABLE employees DROP PRIMARY KEY, ADD PRIMARY KEY (name);
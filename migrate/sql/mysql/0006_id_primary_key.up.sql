@@ -0,0 +1,4 @@
+-- Employees are keyed by ID, and names stay unique through name_key. Rows saved
+-- without an ID get one derived from their name.
+UPDATE employees SET id = CONCAT('legacy-', MD5(name_key)) WHERE id = '';
+ALTER TABLE employees DROP PRIMARY KEY, ADD PRIMARY KEY (id);
@@ -0,0 +1,2 @@
+DROP INDEX outbox_unpublished;
+DROP TABLE outbox;
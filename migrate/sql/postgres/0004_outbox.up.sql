@@ -0,0 +1,11 @@
+CREATE TABLE outbox (
+    id           VARCHAR(64)  NOT NULL PRIMARY KEY,
+    topic        VARCHAR(255) NOT NULL,
+    msg_key      VARCHAR(255) NOT NULL DEFAULT '',
+    payload      TEXT         NOT NULL,
+    created_at   TIMESTAMPTZ  NOT NULL,
+    seq          INTEGER      NOT NULL, -- insertion order; created_at can tie
+    published_at TIMESTAMPTZ  NULL
+);
+
+CREATE INDEX outbox_unpublished ON outbox (published_at, seq);
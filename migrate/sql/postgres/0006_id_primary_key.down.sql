@@ -0,0 +1,2 @@
+ALTER TABLE employees DROP CONSTRAINT employees_pkey;
+ALTER TABLE employees ADD PRIMARY KEY (name);
@@ -0,0 +1,5 @@
+-- Employees are keyed by ID, and names stay unique through name_key. Rows saved
+-- without an ID get one derived from their name.
+UPDATE employees SET id = 'legacy-' || md5(name_key) WHERE id = '';
+ALTER TABLE employees DROP CONSTRAINT employees_pkey;
+ALTER TABLE employees ADD PRIMARY KEY (id);
@@ -0,0 +1,11 @@
+CREATE TABLE employees (
+    id         VARCHAR(64)  NOT NULL,
+    name       VARCHAR(255) NOT NULL PRIMARY KEY,
+    title      VARCHAR(255) NOT NULL DEFAULT '',
+    email      VARCHAR(1024) NOT NULL DEFAULT '', -- or the salary and email sealed by package crypto
+    salary     BIGINT       NOT NULL, -- minor units (cents)
+    currency   CHAR(3)      NOT NULL,
+    hired_at   TIMESTAMP    NOT NULL,
+    version    INTEGER      NOT NULL,
+    deleted_at TIMESTAMP    NULL
+);
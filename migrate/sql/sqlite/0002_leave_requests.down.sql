@@ -0,0 +1,2 @@
+DROP INDEX leave_requests_employee;
+DROP TABLE leave_requests;
@@ -0,0 +1,5 @@
+-- name as compared. LOWER(TRIM()) approximates normalize.Name, and sqlrepo's
+-- Rekey computes the exact keys.
+ALTER TABLE employees ADD COLUMN name_key VARCHAR(255) NOT NULL DEFAULT '';
+UPDATE employees SET name_key = LOWER(TRIM(name));
+CREATE UNIQUE INDEX employees_name_key ON employees (name_key);
@@ -0,0 +1,18 @@
+CREATE TABLE employees_by_name (
+    id         VARCHAR(64)  NOT NULL,
+    name       VARCHAR(255) NOT NULL PRIMARY KEY,
+    name_key   VARCHAR(255) NOT NULL,
+    title      VARCHAR(255) NOT NULL DEFAULT '',
+    email      VARCHAR(1024) NOT NULL DEFAULT '', -- or the salary and email sealed by package crypto
+    salary     BIGINT       NOT NULL, -- minor units (cents)
+    currency   CHAR(3)      NOT NULL,
+    hired_at   TIMESTAMP    NOT NULL,
+    version    INTEGER      NOT NULL,
+    deleted_at TIMESTAMP    NULL
+);
+INSERT INTO employees_by_name (id, name, name_key, title, email, salary, currency, hired_at, version, deleted_at)
+    SELECT id, name, name_key, title, email, salary, currency, hired_at, version, deleted_at
+    FROM employees;
+DROP TABLE employees;
+ALTER TABLE employees_by_name RENAME TO employees;
+CREATE UNIQUE INDEX employees_name_key ON employees (name_key);
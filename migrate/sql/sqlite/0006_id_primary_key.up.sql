@@ -0,0 +1,22 @@
+-- Employees are keyed by ID, and names stay unique through name_key. SQLite
+-- can't change a primary key in place, so the table is copied. Rows saved
+-- without an ID get one from their rowid.
+CREATE TABLE employees_by_id (
+    id         VARCHAR(64)  NOT NULL PRIMARY KEY,
+    name       VARCHAR(255) NOT NULL,
+    name_key   VARCHAR(255) NOT NULL,
+    title      VARCHAR(255) NOT NULL DEFAULT '',
+    email      VARCHAR(1024) NOT NULL DEFAULT '', -- or the salary and email sealed by package crypto
+    salary     BIGINT       NOT NULL, -- minor units (cents)
+    currency   CHAR(3)      NOT NULL,
+    hired_at   TIMESTAMP    NOT NULL,
+    version    INTEGER      NOT NULL,
+    deleted_at TIMESTAMP    NULL
+);
+INSERT INTO employees_by_id (id, name, name_key, title, email, salary, currency, hired_at, version, deleted_at)
+    SELECT CASE id WHEN '' THEN 'legacy-' || rowid ELSE id END,
+           name, name_key, title, email, salary, currency, hired_at, version, deleted_at
+    FROM employees;
+DROP TABLE employees;
+ALTER TABLE employees_by_id RENAME TO employees;
+CREATE UNIQUE INDEX employees_name_key ON employees (name_key);
@@ -0,0 +1,3 @@
+-- Added, not required: rows from before it get the empty default, which
+-- evolve.Backfill or a default on read fills in later.
+ALTER TABLE employees ADD COLUMN department VARCHAR(255) NOT NULL DEFAULT '';
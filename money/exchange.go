@@ -0,0 +1,105 @@
+package money
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+// ExchangeRateProvider Abstraction - how many units of to one unit of from buys
+type ExchangeRateProvider interface {
+	Rate(ctx context.Context, from, to Currency) (*big.Rat, error)
+}
+
+// ErrNoRate returned when a provider has no rate for a currency pair
+var ErrNoRate = errors.New("no exchange rate")
+
+// Convert expresses m in currency to, rounding half away from zero to to's
+// minor unit. Rates are exact fractions, so only that final rounding loses
+// precision.
+func Convert(ctx context.Context, p ExchangeRateProvider, m Money, to Currency) (Money, error) {
+	if m.currency == to {
+		return m, nil
+	}
+	rate, err := p.Rate(ctx, m.currency, to)
+	if err != nil {
+		return Money{}, fmt.Errorf("convert %s to %s: %w", m, to, err)
+	}
+	// minor_to = minor_from / 10^d_from * rate * 10^d_to
+	v := new(big.Rat).SetInt64(m.minor)
+	v.Mul(v, rate)
+	v.Mul(v, new(big.Rat).SetFrac64(pow10(to.Digits()), pow10(m.currency.Digits())))
+	return Money{minor: round(v), currency: to}, nil
+}
+
+// Mul returns m scaled by factor (a rate, a percentage as a fraction...),
+// rounded half away from zero to the minor unit.
+func (m Money) Mul(factor *big.Rat) Money {
+	v := new(big.Rat).SetInt64(m.minor)
+	return Money{minor: round(v.Mul(v, factor)), currency: m.currency}
+}
+
+func round(r *big.Rat) int64 {
+	// |num|*2 + den over 2*den, truncated, is |r| rounded half up
+	num := new(big.Int).Abs(r.Num())
+	num.Add(num.Lsh(num, 1), r.Denom())
+	q := num.Quo(num, new(big.Int).Lsh(r.Denom(), 1)).Int64()
+	if r.Sign() < 0 {
+		return -q
+	}
+	return q
+}
+
+// Sum converts every amount to currency to and adds them up.
+func Sum(ctx context.Context, p ExchangeRateProvider, to Currency, amounts ...Money) (Money, error) {
+	total := Money{currency: to}
+	for _, m := range amounts {
+		c, err := Convert(ctx, p, m, to)
+		if err != nil {
+			return Money{}, err
+		}
+		if total, err = total.Add(c); err != nil {
+			return Money{}, err
+		}
+	}
+	return total, nil
+}
+
+// Rates Low-level module - a fixed table of rates against one base currency,
+// e.g. Base USD with EUR "0.92" meaning 1 USD = 0.92 EUR. Cross rates are
+// derived through the base.
+type Rates struct {
+	Base  Currency
+	Rates map[Currency]string
+}
+
+func (t Rates) Rate(ctx context.Context, from, to Currency) (*big.Rat, error) {
+	f, err := t.perBase(from)
+	if err != nil {
+		return nil, err
+	}
+	g, err := t.perBase(to)
+	if err != nil {
+		return nil, err
+	}
+	return new(big.Rat).Quo(g, f), nil
+}
+
+// perBase returns how many units of c one unit of the base buys.
+func (t Rates) perBase(c Currency) (*big.Rat, error) {
+	if c == t.Base {
+		return big.NewRat(1, 1), nil
+	}
+	s, ok := t.Rates[c]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s per %s", ErrNoRate, c, t.Base)
+	}
+	r, ok := new(big.Rat).SetString(s)
+	if !ok || r.Sign() <= 0 {
+		return nil, fmt.Errorf("%w: bad rate %q for %s", ErrNoRate, s, c)
+	}
+	return r, nil
+}
+
+var _ ExchangeRateProvider = Rates{}
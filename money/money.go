@@ -0,0 +1,235 @@
+// Package money is a value type for amounts of a currency.
+//
+// An int salary hides two facts - the unit (dollars? cents?) and the currency
+// - and lets 5000 EGP be added to 5000 USD without complaint. Money carries
+// both: amounts are whole minor units (cents), and arithmetic between
+// different currencies is an error rather than a silent bug. Converting
+// between currencies is someone else's job: an ExchangeRateProvider.
+package money
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Currency ISO 4217 code
+type Currency string
+
+const (
+	USD Currency = "USD"
+	EUR Currency = "EUR"
+	GBP Currency = "GBP"
+	EGP Currency = "EGP"
+	SAR Currency = "SAR"
+	JPY Currency = "JPY"
+)
+
+// Digits is the number of minor-unit digits: 2 for most currencies.
+func (c Currency) Digits() int {
+	switch c {
+	case JPY, "KRW", "CLP", "ISK":
+		return 0
+	case "KWD", "BHD", "OMR", "JOD", "TND":
+		return 3
+	}
+	return 2
+}
+
+func (c Currency) Valid() bool {
+	if len(c) != 3 {
+		return false
+	}
+	for _, r := range c {
+		if r < 'A' || r > 'Z' {
+			return false
+		}
+	}
+	return true
+}
+
+var (
+	ErrCurrencyMismatch = errors.New("currency mismatch")
+	ErrInvalidAmount    = errors.New("invalid amount")
+	ErrInvalidCurrency  = errors.New("invalid currency")
+	ErrOverflow         = errors.New("amount out of range")
+)
+
+// Money Immutable amount in minor units of one currency. The zero value means
+// "no amount" and has no currency.
+type Money struct {
+	minor    int64
+	currency Currency
+}
+
+// Of returns units whole units of c: Of(5000, USD) is $5000.00. It is meant
+// for amounts written in code, and panics when units of c don't fit in an
+// int64 of minor units; Parse reports that as ErrOverflow instead.
+func Of(units int64, c Currency) Money {
+	p := pow10(c.Digits())
+	if units > math.MaxInt64/p || units < math.MinInt64/p {
+		panic(fmt.Sprintf("money: Of(%d, %s): %v", units, c, ErrOverflow))
+	}
+	return Money{minor: units * p, currency: c}
+}
+
+// FromMinor returns minor units of c: FromMinor(1999, USD) is $19.99.
+func FromMinor(minor int64, c Currency) Money { return Money{minor: minor, currency: c} }
+
+func (m Money) Minor() int64       { return m.minor }
+func (m Money) Currency() Currency { return m.currency }
+func (m Money) IsZero() bool       { return m == Money{} }
+func (m Money) IsPositive() bool   { return m.minor > 0 }
+
+// Add returns m+o; both must be in the same currency, and the sum must fit
+// in an int64 of minor units.
+func (m Money) Add(o Money) (Money, error) {
+	if err := m.same(o); err != nil {
+		return Money{}, err
+	}
+	sum := m.minor + o.minor
+	if (o.minor > 0 && sum < m.minor) || (o.minor < 0 && sum > m.minor) {
+		return Money{}, fmt.Errorf("%w: %s + %s", ErrOverflow, m, o)
+	}
+	return Money{minor: sum, currency: m.currency}, nil
+}
+
+// Sub returns m-o; both must be in the same currency, and the difference must
+// fit in an int64 of minor units.
+func (m Money) Sub(o Money) (Money, error) {
+	if err := m.same(o); err != nil {
+		return Money{}, err
+	}
+	diff := m.minor - o.minor
+	if (o.minor < 0 && diff < m.minor) || (o.minor > 0 && diff > m.minor) {
+		return Money{}, fmt.Errorf("%w: %s - %s", ErrOverflow, m, o)
+	}
+	return Money{minor: diff, currency: m.currency}, nil
+}
+
+// Neg returns -m, as a deduction. The one amount without a negative, the
+// smallest int64 of minor units, panics.
+func (m Money) Neg() Money {
+	if m.minor == math.MinInt64 {
+		panic(fmt.Sprintf("money: -(%s): %v", m, ErrOverflow))
+	}
+	return Money{minor: -m.minor, currency: m.currency}
+}
+
+// Cmp returns -1, 0 or +1; both must be in the same currency.
+func (m Money) Cmp(o Money) (int, error) {
+	if err := m.same(o); err != nil {
+		return 0, err
+	}
+	switch {
+	case m.minor < o.minor:
+		return -1, nil
+	case m.minor > o.minor:
+		return 1, nil
+	}
+	return 0, nil
+}
+
+// Less orders by currency code and then amount - an arbitrary but total order
+// for sorting lists of mixed currencies. It says nothing about value.
+func (m Money) Less(o Money) bool {
+	if m.currency != o.currency {
+		return m.currency < o.currency
+	}
+	return m.minor < o.minor
+}
+
+func (m Money) same(o Money) error {
+	if m.currency != o.currency {
+		return fmt.Errorf("%w: %s and %s", ErrCurrencyMismatch, m.currency, o.currency)
+	}
+	return nil
+}
+
+// Amount renders the decimal amount without currency: "5000.00".
+func (m Money) Amount() string {
+	digits := m.currency.Digits()
+	sign, minor := "", abs(m.minor) // abs, as the smallest int64 has no int64 negative
+	if m.minor < 0 {
+		sign = "-"
+	}
+	if digits == 0 {
+		return sign + strconv.FormatUint(minor, 10)
+	}
+	p := uint64(pow10(digits))
+	return fmt.Sprintf("%s%d.%0*d", sign, minor/p, digits, minor%p)
+}
+
+// String renders "USD 5000.00".
+func (m Money) String() string {
+	if m.IsZero() {
+		return "0"
+	}
+	return string(m.currency) + " " + m.Amount()
+}
+
+// Parse reads a decimal amount such as "5000", "5000.5" or "-12.34" in c. More
+// decimals than the currency has are an error, not a rounding.
+func Parse(amount string, c Currency) (Money, error) {
+	if !c.Valid() {
+		return Money{}, fmt.Errorf("%w: %q", ErrInvalidCurrency, c)
+	}
+	s := strings.TrimSpace(amount)
+	neg := strings.HasPrefix(s, "-")
+	s = strings.TrimPrefix(s, "-")
+	whole, frac, _ := strings.Cut(s, ".")
+	digits := c.Digits()
+	if whole == "" || len(frac) > digits || strings.ContainsAny(whole+frac, "+-") {
+		return Money{}, fmt.Errorf("%w: %q", ErrInvalidAmount, amount)
+	}
+	frac += strings.Repeat("0", digits-len(frac))
+	if neg {
+		whole = "-" + whole
+	}
+	minor, err := strconv.ParseInt(whole+frac, 10, 64)
+	if errors.Is(err, strconv.ErrRange) {
+		return Money{}, fmt.Errorf("%w: %q", ErrOverflow, amount)
+	}
+	if err != nil {
+		return Money{}, fmt.Errorf("%w: %q", ErrInvalidAmount, amount)
+	}
+	return Money{minor: minor, currency: c}, nil
+}
+
+// wire is the JSON form. The amount is a string so no decoder turns it into a float.
+type wire struct {
+	Amount   string   `json:"amount"`
+	Currency Currency `json:"currency"`
+}
+
+func (m Money) MarshalJSON() ([]byte, error) {
+	return json.Marshal(wire{Amount: m.Amount(), Currency: m.currency})
+}
+
+func (m *Money) UnmarshalJSON(b []byte) error {
+	var w wire
+	if err := json.Unmarshal(b, &w); err != nil {
+		return err
+	}
+	if w == (wire{Amount: Money{}.Amount()}) { // the zero Money, as MarshalJSON writes it
+		*m = Money{}
+		return nil
+	}
+	parsed, err := Parse(w.Amount, w.Currency)
+	if err != nil {
+		return err
+	}
+	*m = parsed
+	return nil
+}
+
+func pow10(n int) int64 {
+	p := int64(1)
+	for range n {
+		p *= 10
+	}
+	return p
+}
@@ -0,0 +1,175 @@
+package money_test
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"testing"
+
+	"go-solid/money"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		amount   string
+		currency money.Currency
+		want     money.Money
+		wantErr  error
+	}{
+		{"5000", money.USD, money.FromMinor(500000, money.USD), nil},
+		{"5000.5", money.USD, money.FromMinor(500050, money.USD), nil},
+		{"-12.34", money.USD, money.FromMinor(-1234, money.USD), nil},
+		{" 7 ", money.USD, money.FromMinor(700, money.USD), nil},
+		{"1.", money.USD, money.FromMinor(100, money.USD), nil},
+		{"-0", money.USD, money.FromMinor(0, money.USD), nil},
+		{"0.001", "KWD", money.FromMinor(1, "KWD"), nil},
+		{"5000", money.JPY, money.FromMinor(5000, money.JPY), nil},
+		{"92233720368547758.07", money.USD, money.FromMinor(math.MaxInt64, money.USD), nil},
+		{"-92233720368547758.08", money.USD, money.FromMinor(math.MinInt64, money.USD), nil},
+		{"92233720368547758.08", money.USD, money.Money{}, money.ErrOverflow},
+		{"-92233720368547758.09", money.USD, money.Money{}, money.ErrOverflow},
+		{"", money.USD, money.Money{}, money.ErrInvalidAmount},
+		{"-", money.USD, money.Money{}, money.ErrInvalidAmount},
+		{".5", money.USD, money.Money{}, money.ErrInvalidAmount},
+		{"1.234", money.USD, money.Money{}, money.ErrInvalidAmount},
+		{"5.5", money.JPY, money.Money{}, money.ErrInvalidAmount},
+		{"1.2.3", "KWD", money.Money{}, money.ErrInvalidAmount},
+		{"+5", money.USD, money.Money{}, money.ErrInvalidAmount},
+		{"--5", money.USD, money.Money{}, money.ErrInvalidAmount},
+		{"1-2", money.USD, money.Money{}, money.ErrInvalidAmount},
+		{"1e3", money.USD, money.Money{}, money.ErrInvalidAmount},
+		{"1,000", money.USD, money.Money{}, money.ErrInvalidAmount},
+		{"1_000", money.USD, money.Money{}, money.ErrInvalidAmount},
+		{"5000", "usd", money.Money{}, money.ErrInvalidCurrency},
+		{"5000", "", money.Money{}, money.ErrInvalidCurrency},
+	}
+	for _, tt := range tests {
+		got, err := money.Parse(tt.amount, tt.currency)
+		if got != tt.want || !errors.Is(err, tt.wantErr) {
+			t.Errorf("Parse(%q, %s) = %v, %v, want %v, %v", tt.amount, tt.currency, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestMoney_Amount(t *testing.T) {
+	tests := []struct {
+		m    money.Money
+		want string
+	}{
+		{money.Money{}, "0.00"},
+		{money.Of(5000, money.USD), "5000.00"},
+		{money.FromMinor(-5, money.USD), "-0.05"},
+		{money.Of(5000, money.JPY), "5000"},
+		{money.FromMinor(-7, money.JPY), "-7"},
+		{money.FromMinor(1234, "KWD"), "1.234"},
+		{money.FromMinor(-1, "KWD"), "-0.001"},
+		{money.FromMinor(50, "KWD"), "0.050"},
+		{money.FromMinor(math.MinInt64, money.USD), "-92233720368547758.08"},
+		{money.FromMinor(math.MinInt64, money.JPY), "-9223372036854775808"},
+	}
+	for _, tt := range tests {
+		if got := tt.m.Amount(); got != tt.want {
+			t.Errorf("%#v.Amount() = %q, want %q", tt.m, got, tt.want)
+		}
+	}
+}
+
+func TestMoney_JSON(t *testing.T) {
+	for _, m := range []money.Money{
+		{},
+		money.FromMinor(0, money.USD),
+		money.Of(5000, money.USD),
+		money.FromMinor(-1234, money.EUR),
+		money.Of(5000, money.JPY),
+		money.FromMinor(1234, "KWD"),
+		money.FromMinor(math.MinInt64, money.USD),
+	} {
+		b, err := json.Marshal(m)
+		if err != nil {
+			t.Fatalf("Marshal(%v) error = %v", m, err)
+		}
+		var got money.Money
+		if err := json.Unmarshal(b, &got); err != nil || got != m {
+			t.Errorf("Unmarshal(%s) = %#v, %v, want %#v", b, got, err, m)
+		}
+	}
+	var m money.Money
+	for _, b := range []string{`{"amount":5000,"currency":"USD"}`, `{"amount":"50.001","currency":"USD"}`, `{"amount":"5000","currency":"usd"}`} {
+		if err := json.Unmarshal([]byte(b), &m); err == nil {
+			t.Errorf("Unmarshal(%s) = %v, want an error", b, m)
+		}
+	}
+}
+
+func TestMoney_Overflow(t *testing.T) {
+	maxUSD := money.FromMinor(math.MaxInt64, money.USD)
+	minUSD := money.FromMinor(math.MinInt64, money.USD)
+	cent, minusCent := money.FromMinor(1, money.USD), money.FromMinor(-1, money.USD)
+	tests := []struct {
+		name    string
+		op      func() (money.Money, error)
+		want    money.Money
+		wantErr error
+	}{
+		{"max + 1", func() (money.Money, error) { return maxUSD.Add(cent) }, money.Money{}, money.ErrOverflow},
+		{"min + -1", func() (money.Money, error) { return minUSD.Add(minusCent) }, money.Money{}, money.ErrOverflow},
+		{"max + -1", func() (money.Money, error) { return maxUSD.Add(minusCent) }, money.FromMinor(math.MaxInt64-1, money.USD), nil},
+		{"max + min", func() (money.Money, error) { return maxUSD.Add(minUSD) }, minusCent, nil},
+		{"min - 1", func() (money.Money, error) { return minUSD.Sub(cent) }, money.Money{}, money.ErrOverflow},
+		{"max - -1", func() (money.Money, error) { return maxUSD.Sub(minusCent) }, money.Money{}, money.ErrOverflow},
+		{"0 - min", func() (money.Money, error) { return money.FromMinor(0, money.USD).Sub(minUSD) }, money.Money{}, money.ErrOverflow},
+		{"-1 - min", func() (money.Money, error) { return minusCent.Sub(minUSD) }, maxUSD, nil},
+		{"different currencies", func() (money.Money, error) { return maxUSD.Add(money.Of(1, money.EUR)) }, money.Money{}, money.ErrCurrencyMismatch},
+	}
+	for _, tt := range tests {
+		got, err := tt.op()
+		if got != tt.want || !errors.Is(err, tt.wantErr) {
+			t.Errorf("%s = %v, %v, want %v, %v", tt.name, got, err, tt.want, tt.wantErr)
+		}
+	}
+
+	panics := map[string]func(){
+		"Of(max/100 + 1, USD)":  func() { money.Of(math.MaxInt64/100+1, money.USD) },
+		"Of(min/100 - 1, USD)":  func() { money.Of(math.MinInt64/100-1, money.USD) },
+		"Of(max/1000 + 1, KWD)": func() { money.Of(math.MaxInt64/1000+1, "KWD") },
+		"-min":                  func() { minUSD.Neg() },
+	}
+	for name, f := range panics {
+		t.Run(name, func(t *testing.T) {
+			defer func() {
+				if err, _ := recover().(string); err == "" {
+					t.Errorf("%s didn't panic", name)
+				}
+			}()
+			f()
+		})
+	}
+	if got := money.Of(math.MaxInt64, money.JPY); got.Minor() != math.MaxInt64 {
+		t.Errorf("Of(max, JPY) = %v, want all of it, JPY having no minor unit", got)
+	}
+	if got := maxUSD.Neg(); got.Minor() != -math.MaxInt64 {
+		t.Errorf("Neg() = %v, want %d", got, -math.MaxInt64)
+	}
+}
+
+func TestSum_Overflow(t *testing.T) {
+	rates := money.Rates{Base: money.USD}
+	_, err := money.Sum(t.Context(), rates, money.USD, money.FromMinor(math.MaxInt64, money.USD), money.FromMinor(1, money.USD))
+	if !errors.Is(err, money.ErrOverflow) {
+		t.Errorf("Sum() error = %v, want %v", err, money.ErrOverflow)
+	}
+}
+
+func TestCurrency_Known(t *testing.T) {
+	for _, c := range []money.Currency{money.USD, money.EUR, money.EGP, money.JPY, "KWD", "CHF"} {
+		if !c.Known() {
+			t.Errorf("%s.Known() = false, want an ISO 4217 code", c)
+		}
+	}
+	// the shape of a code, but no currency
+	for _, c := range []money.Currency{"CEO", "CTO", "VIP", "usd", "US", ""} {
+		if c.Known() {
+			t.Errorf("%q.Known() = true, want no currency", c)
+		}
+	}
+}
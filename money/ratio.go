@@ -0,0 +1,70 @@
+package money
+
+import (
+	"math"
+	"math/big"
+	"math/bits"
+)
+
+// Ratio A factor prepared once to scale many amounts, as a payroll rate
+// scales every payslip. Mul needs a big.Rat and allocates on every call;
+// MulRatio scales with 128-bit integer arithmetic and allocates nothing,
+// falling back to big.Rat only when the numbers don't fit. The zero Ratio
+// is zero.
+type Ratio struct {
+	num, den int64
+	rat      *big.Rat // set when num/den don't fit in an int64
+}
+
+// RatioOf prepares r; it is not modified or kept.
+func RatioOf(r *big.Rat) Ratio {
+	if r.Num().IsInt64() && r.Denom().IsInt64() {
+		return Ratio{num: r.Num().Int64(), den: r.Denom().Int64()}
+	}
+	return Ratio{rat: new(big.Rat).Set(r)}
+}
+
+// Rat returns the ratio as a big.Rat.
+func (r Ratio) Rat() *big.Rat {
+	switch {
+	case r.rat != nil:
+		return new(big.Rat).Set(r.rat)
+	case r.den == 0:
+		return new(big.Rat)
+	}
+	return big.NewRat(r.num, r.den)
+}
+
+// MulRatio returns m scaled by r, rounded half away from zero to the minor
+// unit - the same result as m.Mul(r.Rat()).
+func (m Money) MulRatio(r Ratio) Money {
+	switch {
+	case r.rat != nil:
+		return m.Mul(r.rat)
+	case r.den == 0:
+		return Money{currency: m.currency}
+	}
+	hi, lo := bits.Mul64(abs(m.minor), abs(r.num))
+	d := uint64(r.den)
+	if hi < d { // the quotient fits in 64 bits
+		q, rem := bits.Div64(hi, lo, d)
+		if rem >= d-rem {
+			q++
+		}
+		if q <= math.MaxInt64 {
+			minor := int64(q)
+			if (m.minor < 0) != (r.num < 0) {
+				minor = -minor
+			}
+			return Money{minor: minor, currency: m.currency}
+		}
+	}
+	return m.Mul(r.Rat())
+}
+
+func abs(n int64) uint64 {
+	if n < 0 {
+		return uint64(^n) + 1
+	}
+	return uint64(n)
+}
@@ -0,0 +1,89 @@
+package money
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"sync"
+	"time"
+
+	"go-solid/clock"
+)
+
+// RemoteRates Low-level module - fetches a rate table from an HTTP API and
+// caches it for TTL. The expected document is the common
+// {"base": "USD", "rates": {"EUR": 0.92, ...}} shape. When a refresh fails the
+// last good table keeps being used. This is a stub of a real rates provider:
+// no API keys, no historical rates.
+type RemoteRates struct {
+	URL    string
+	TTL    time.Duration
+	Client *http.Client
+	Clock  clock.Clock
+
+	mu        sync.Mutex
+	table     *Rates
+	fetchedAt time.Time
+}
+
+func (r *RemoteRates) Rate(ctx context.Context, from, to Currency) (*big.Rat, error) {
+	table, err := r.snapshot(ctx)
+	if table == nil {
+		return nil, err
+	}
+	return table.Rate(ctx, from, to)
+}
+
+func (r *RemoteRates) snapshot(ctx context.Context) (*Rates, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := r.now()
+	if r.table != nil && now.Sub(r.fetchedAt) < r.TTL {
+		return r.table, nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, r.URL, nil)
+	if err != nil {
+		return r.table, err
+	}
+	client := r.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return r.table, fmt.Errorf("money: fetch rates: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return r.table, fmt.Errorf("money: fetch rates: %s", resp.Status)
+	}
+
+	var doc struct {
+		Base  Currency                 `json:"base"`
+		Rates map[Currency]json.Number `json:"rates"`
+	}
+	dec := json.NewDecoder(resp.Body)
+	dec.UseNumber() // keep the rates' exact decimal digits
+	if err := dec.Decode(&doc); err != nil {
+		return r.table, fmt.Errorf("money: decode rates: %w", err)
+	}
+	table := &Rates{Base: doc.Base, Rates: make(map[Currency]string, len(doc.Rates))}
+	for c, n := range doc.Rates {
+		table.Rates[c] = n.String()
+	}
+	r.table, r.fetchedAt = table, now
+	return table, nil
+}
+
+func (r *RemoteRates) now() time.Time {
+	if r.Clock == nil {
+		return time.Now()
+	}
+	return r.Clock.Now()
+}
+
+var _ ExchangeRateProvider = (*RemoteRates)(nil)
@@ -0,0 +1,319 @@
+// Package mutate checks whether tests pin behaviour down: it makes small
+// changes to the code - mutants - and runs the tests against each. A test
+// suite that still passes with a flipped condition has a hole where that
+// condition is.
+//
+// Mutants never touch the source tree: each is handed to go test as an
+// overlay, so an interrupted run leaves nothing behind.
+package mutate
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Status What the tests made of a mutant
+type Status string
+
+const (
+	Killed   Status = "killed"   // a test failed: the change was noticed
+	Survived Status = "survived" // every test passed: nothing pins this down
+	Invalid  Status = "invalid"  // the mutant didn't compile, so it says nothing
+	TimedOut Status = "timeout"  // counted as killed: the change was noticed
+)
+
+// Tester Abstraction - runs a package's tests with some files replaced
+type Tester interface {
+	// Test runs the tests of the package in dir with overlay (absolute
+	// path -> content) in place of those files.
+	Test(ctx context.Context, dir string, overlay map[string][]byte) (Status, error)
+}
+
+// GoTest Tester using go test -overlay
+type GoTest struct{}
+
+func (GoTest) Test(ctx context.Context, dir string, overlay map[string][]byte) (Status, error) {
+	tmp, err := os.MkdirTemp("", "mutant-")
+	if err != nil {
+		return "", err
+	}
+	defer os.RemoveAll(tmp)
+	replace := map[string]string{}
+	for path, src := range overlay {
+		file := filepath.Join(tmp, fmt.Sprintf("%d.go", len(replace)))
+		if err := os.WriteFile(file, src, 0o644); err != nil {
+			return "", err
+		}
+		replace[path] = file
+	}
+	spec, err := json.Marshal(map[string]any{"Replace": replace})
+	if err != nil {
+		return "", err
+	}
+	specFile := filepath.Join(tmp, "overlay.json")
+	if err := os.WriteFile(specFile, spec, 0o644); err != nil {
+		return "", err
+	}
+
+	cmd := exec.CommandContext(ctx, "go", "test", "-count=1", "-vet=off", "-overlay", specFile, ".")
+	cmd.Dir = dir
+	out, err := cmd.CombinedOutput()
+	var exit *exec.ExitError
+	switch {
+	case err == nil:
+		return Survived, nil
+	case ctx.Err() != nil:
+		return TimedOut, nil
+	case !errors.As(err, &exit):
+		return "", fmt.Errorf("mutate: go test: %w", err)
+	case bytes.Contains(out, []byte("[build failed]")) || bytes.Contains(out, []byte("[setup failed]")):
+		return Invalid, nil
+	}
+	return Killed, nil
+}
+
+// Options How Run mutates
+type Options struct {
+	Dir      string    // where patterns are resolved; the current directory by default
+	Mutators []Mutator // All by default
+	Tester   Tester    // GoTest by default
+	Workers  int       // mutants tested at once; half the CPUs by default
+	// Progress, if set, is called as each mutant is decided
+	Progress func(Result)
+}
+
+// Result One mutant and its fate
+type Result struct {
+	Package     string
+	Position    token.Position
+	Mutator     string
+	Description string
+	Status      Status
+}
+
+func (r Result) String() string {
+	return fmt.Sprintf("%s:%d:%d: %s %s (%s)", r.Position.Filename, r.Position.Line, r.Position.Column, r.Status, r.Description, r.Mutator)
+}
+
+// Report Everything Run found
+type Report struct {
+	Results []Result
+	// Untested are packages without tests, which every mutant would survive
+	Untested []string
+}
+
+// Count returns how many results have status s.
+func (r Report) Count(s Status) int {
+	n := 0
+	for _, res := range r.Results {
+		if res.Status == s {
+			n++
+		}
+	}
+	return n
+}
+
+// Score is the share of valid mutants the tests killed.
+func (r Report) Score() float64 {
+	killed := r.Count(Killed) + r.Count(TimedOut)
+	if valid := killed + r.Count(Survived); valid > 0 {
+		return float64(killed) / float64(valid)
+	}
+	return 0
+}
+
+// ErrFailingTests returned when a package's tests fail before any mutation:
+// mutants of a broken suite prove nothing
+var ErrFailingTests = errors.New("tests fail without mutations")
+
+// Run mutates every package matching patterns (as go list takes them) that
+// has tests.
+func Run(ctx context.Context, opts Options, patterns ...string) (Report, error) {
+	if opts.Mutators == nil {
+		opts.Mutators = All
+	}
+	if opts.Tester == nil {
+		opts.Tester = GoTest{}
+	}
+	if opts.Workers <= 0 {
+		opts.Workers = max(runtime.NumCPU()/2, 1)
+	}
+	pkgs, err := list(ctx, opts.Dir, patterns)
+	if err != nil {
+		return Report{}, err
+	}
+	var report Report
+	for _, pkg := range pkgs {
+		if len(pkg.TestGoFiles)+len(pkg.XTestGoFiles) == 0 {
+			report.Untested = append(report.Untested, pkg.ImportPath)
+			continue
+		}
+		results, err := runPackage(ctx, opts, pkg)
+		if err != nil {
+			return report, err
+		}
+		report.Results = append(report.Results, results...)
+	}
+	return report, nil
+}
+
+type pkgInfo struct {
+	ImportPath   string
+	Dir          string
+	GoFiles      []string
+	TestGoFiles  []string
+	XTestGoFiles []string
+}
+
+func list(ctx context.Context, dir string, patterns []string) ([]pkgInfo, error) {
+	cmd := exec.CommandContext(ctx, "go", append([]string{"list", "-json"}, patterns...)...)
+	cmd.Dir = dir
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("mutate: go list: %w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+	var pkgs []pkgInfo
+	dec := json.NewDecoder(bytes.NewReader(out))
+	for dec.More() {
+		var p pkgInfo
+		if err := dec.Decode(&p); err != nil {
+			return nil, fmt.Errorf("mutate: go list: %w", err)
+		}
+		pkgs = append(pkgs, p)
+	}
+	return pkgs, nil
+}
+
+// mutant A mutated copy of one file, ready to test
+type mutant struct {
+	Result
+	path string
+	src  []byte
+}
+
+func runPackage(ctx context.Context, opts Options, pkg pkgInfo) ([]Result, error) {
+	// the baseline must pass, and its time bounds every mutant's: a
+	// mutation that turns a loop infinite is killed, not waited for
+	start := time.Now()
+	status, err := opts.Tester.Test(ctx, pkg.Dir, nil)
+	if err != nil {
+		return nil, err
+	}
+	if status != Survived {
+		return nil, fmt.Errorf("mutate: %s: %w", pkg.ImportPath, ErrFailingTests)
+	}
+	timeout := max(10*time.Since(start), 10*time.Second)
+
+	var mutants []mutant
+	for _, name := range pkg.GoFiles {
+		ms, err := generate(pkg, filepath.Join(pkg.Dir, name), opts.Mutators)
+		if err != nil {
+			return nil, err
+		}
+		mutants = append(mutants, ms...)
+	}
+
+	results := make([]Result, len(mutants))
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	var firstErr error
+	sem := make(chan struct{}, opts.Workers)
+	for i, m := range mutants {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func() {
+			defer func() { <-sem; wg.Done() }()
+			tctx, cancel := context.WithTimeout(ctx, timeout)
+			defer cancel()
+			status, err := opts.Tester.Test(tctx, pkg.Dir, map[string][]byte{m.path: m.src})
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				if firstErr == nil {
+					firstErr = err
+				}
+				return
+			}
+			m.Status = status
+			results[i] = m.Result
+			if opts.Progress != nil {
+				opts.Progress(m.Result)
+			}
+		}()
+	}
+	wg.Wait()
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	sort.SliceStable(results, func(i, j int) bool {
+		a, b := results[i].Position, results[j].Position
+		if a.Filename != b.Filename {
+			return a.Filename < b.Filename
+		}
+		return a.Offset < b.Offset
+	})
+	return results, nil
+}
+
+// generate makes every mutant of one file.
+func generate(pkg pkgInfo, path string, mutators []Mutator) ([]mutant, error) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, path, nil, parser.ParseComments)
+	if err != nil {
+		return nil, fmt.Errorf("mutate: %w", err)
+	}
+	rel := path
+	if wd, err := os.Getwd(); err == nil {
+		if r, err := filepath.Rel(wd, path); err == nil && !strings.HasPrefix(r, "..") {
+			rel = r
+		}
+	}
+	var mutants []mutant
+	var failed error
+	ast.Inspect(file, func(n ast.Node) bool {
+		if n == nil || failed != nil {
+			return false
+		}
+		for _, mut := range mutators {
+			for _, m := range mut.Mutations(n) {
+				m.Apply()
+				var buf bytes.Buffer
+				err := format.Node(&buf, fset, file)
+				m.Revert()
+				if err != nil {
+					failed = fmt.Errorf("mutate: %s: %w", path, err)
+					return false
+				}
+				pos := fset.Position(m.Pos)
+				pos.Filename = rel
+				mutants = append(mutants, mutant{
+					Result: Result{Package: pkg.ImportPath, Position: pos, Mutator: mut.Name(), Description: m.Description},
+					path:   path,
+					src:    buf.Bytes(),
+				})
+			}
+		}
+		return true
+	})
+	return mutants, failed
+}
@@ -0,0 +1,206 @@
+package mutate_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+
+	"go-solid/mutate"
+)
+
+const src = `package p
+
+func f(a, b int, ok bool) int {
+	if a == b && ok {
+		a++
+	}
+	x := 0
+	x = a
+	g(x)
+	return x
+}
+`
+
+func TestMutators(t *testing.T) {
+	tests := []struct {
+		mutator mutate.Mutator
+		want    []string // description, then the line changed
+	}{
+		{mutate.FlipCondition{}, []string{
+			"&& → || | +if a == b || ok {",
+			"== → != | +if a != b && ok {",
+		}},
+		{mutate.ChangeConstant{}, []string{
+			"0 → 1 | +x := 1",
+		}},
+		{mutate.DeleteStatement{}, []string{
+			"statement deleted | -x = a",
+			"statement deleted | -g(x)",
+			"statement deleted | -a++",
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.mutator.Name(), func(t *testing.T) {
+			fset := token.NewFileSet()
+			file, err := parser.ParseFile(fset, "p.go", src, 0)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got []string
+			ast.Inspect(file, func(n ast.Node) bool {
+				for _, m := range tt.mutator.Mutations(n) {
+					m.Apply()
+					mutated := render(t, fset, file)
+					m.Revert()
+					got = append(got, m.Description+" | "+changed(src, mutated))
+				}
+				return true
+			})
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("mutations = %q, want %q", got, tt.want)
+			}
+			if back := render(t, fset, file); back != src {
+				t.Errorf("after Revert() the file is\n%s\nwant it as parsed", back)
+			}
+		})
+	}
+}
+
+func render(t *testing.T, fset *token.FileSet, file *ast.File) string {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := format.Node(&buf, fset, file); err != nil {
+		t.Fatal(err)
+	}
+	return buf.String()
+}
+
+// changed returns the first line mutated adds to src, or else the first it
+// removes, trimmed.
+func changed(src, mutated string) string {
+	before, after := strings.Split(src, "\n"), strings.Split(mutated, "\n")
+	for _, l := range after {
+		if !slices.Contains(before, l) {
+			return "+" + strings.TrimSpace(l)
+		}
+	}
+	for _, l := range before {
+		if !slices.Contains(after, l) {
+			return "-" + strings.TrimSpace(l)
+		}
+	}
+	return ""
+}
+
+func TestRun(t *testing.T) {
+	dir := filepath.Join("testdata", "calc")
+	before := snapshot(t, dir)
+	var mu sync.Mutex
+	var progress int
+	report, err := mutate.Run(t.Context(), mutate.Options{Dir: dir, Workers: 2, Progress: func(mutate.Result) {
+		mu.Lock()
+		progress++
+		mu.Unlock()
+	}}, "./...")
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+
+	var got []string
+	for _, r := range report.Results {
+		got = append(got, filepath.Base(r.Position.Filename)+" "+r.Description+" "+string(r.Status))
+	}
+	want := []string{
+		"calc.go > → <= killed",
+		"calc.go < → >= killed",
+		"calc.go 0 → 1 survived",
+		"calc.go statement deleted invalid", // x declared and not used
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("Run() = %q, want %q", got, want)
+	}
+	if !slices.Equal(report.Untested, []string{"calc/untested"}) {
+		t.Errorf("Untested = %v, want [calc/untested]", report.Untested)
+	}
+	if progress != len(want) {
+		t.Errorf("Progress called %d times, want once per mutant", progress)
+	}
+	if got := snapshot(t, dir); !maps.Equal(before, got) {
+		t.Error("Run() changed the files it mutated")
+	}
+}
+
+func snapshot(t *testing.T, dir string) map[string]string {
+	t.Helper()
+	files := map[string]string{}
+	err := filepath.WalkDir(dir, func(path string, d os.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		b, err := os.ReadFile(path)
+		files[path] = string(b)
+		return err
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return files
+}
+
+// tester Answers with status for mutants and baseline for the unmutated run
+type tester struct {
+	baseline, status mutate.Status
+	err              error
+}
+
+func (f tester) Test(_ context.Context, _ string, overlay map[string][]byte) (mutate.Status, error) {
+	if overlay == nil {
+		return f.baseline, nil
+	}
+	return f.status, f.err
+}
+
+func TestRun_Errors(t *testing.T) {
+	dir := filepath.Join("testdata", "calc")
+	broken := errors.New("no go command")
+	tests := []struct {
+		name    string
+		tester  tester
+		wantErr error
+	}{
+		{"failing tests", tester{baseline: mutate.Killed}, mutate.ErrFailingTests},
+		{"tester error", tester{baseline: mutate.Survived, err: broken}, broken},
+	}
+	for _, tt := range tests {
+		_, err := mutate.Run(t.Context(), mutate.Options{Dir: dir, Tester: tt.tester}, ".")
+		if !errors.Is(err, tt.wantErr) {
+			t.Errorf("%s: Run() error = %v, want %v", tt.name, err, tt.wantErr)
+		}
+	}
+	if _, err := mutate.Run(t.Context(), mutate.Options{Dir: dir}, "./nowhere"); err == nil {
+		t.Error("Run() of a missing package error = nil")
+	}
+}
+
+func TestReport_Score(t *testing.T) {
+	report := mutate.Report{Results: []mutate.Result{
+		{Status: mutate.Killed}, {Status: mutate.TimedOut}, {Status: mutate.Survived}, {Status: mutate.Invalid},
+	}}
+	if got := report.Score(); got != 2.0/3 {
+		t.Errorf("Score() = %v, want 2/3: timeouts killed, invalid mutants left out", got)
+	}
+	if got := (mutate.Report{Results: []mutate.Result{{Status: mutate.Invalid}}}).Score(); got != 0 {
+		t.Errorf("Score() without valid mutants = %v, want 0", got)
+	}
+}
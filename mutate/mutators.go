@@ -0,0 +1,131 @@
+package mutate
+
+import (
+	"go/ast"
+	"go/token"
+	"strconv"
+)
+
+// Mutation One small change at one place. Apply edits the syntax tree in
+// place and Revert undoes it, so every mutant is made from the same tree.
+type Mutation struct {
+	Pos         token.Pos
+	Description string // e.g. "== → !="
+	Apply       func()
+	Revert      func()
+}
+
+// Mutator Strategy - finds the mutations it can make at one node. Inspect
+// calls it for every node of a file; a new kind of mutation is a new Mutator.
+type Mutator interface {
+	Name() string
+	Mutations(n ast.Node) []Mutation
+}
+
+// All The mutators solid mutate uses by default
+var All = []Mutator{FlipCondition{}, ChangeConstant{}, DeleteStatement{}}
+
+// FlipCondition Replaces a comparison or logical operator by its opposite:
+// == and !=, < and >=, > and <=, && and ||
+type FlipCondition struct{}
+
+var flips = map[token.Token]token.Token{
+	token.EQL: token.NEQ, token.NEQ: token.EQL,
+	token.LSS: token.GEQ, token.GEQ: token.LSS,
+	token.GTR: token.LEQ, token.LEQ: token.GTR,
+	token.LAND: token.LOR, token.LOR: token.LAND,
+}
+
+func (FlipCondition) Name() string { return "flip-condition" }
+
+func (FlipCondition) Mutations(n ast.Node) []Mutation {
+	b, ok := n.(*ast.BinaryExpr)
+	if !ok {
+		return nil
+	}
+	from := b.Op
+	to, ok := flips[from]
+	if !ok {
+		return nil
+	}
+	return []Mutation{{
+		Pos:         b.OpPos,
+		Description: from.String() + " → " + to.String(),
+		Apply:       func() { b.Op = to },
+		Revert:      func() { b.Op = from },
+	}}
+}
+
+// ChangeConstant Adds one to integer literals and swaps true and false
+type ChangeConstant struct{}
+
+func (ChangeConstant) Name() string { return "change-constant" }
+
+func (ChangeConstant) Mutations(n ast.Node) []Mutation {
+	switch lit := n.(type) {
+	case *ast.BasicLit:
+		v, err := strconv.ParseInt(lit.Value, 0, 64)
+		if lit.Kind != token.INT || err != nil {
+			return nil
+		}
+		from, to := lit.Value, strconv.FormatInt(v+1, 10)
+		return []Mutation{{
+			Pos:         lit.Pos(),
+			Description: from + " → " + to,
+			Apply:       func() { lit.Value = to },
+			Revert:      func() { lit.Value = from },
+		}}
+	case *ast.Ident:
+		to, ok := map[string]string{"true": "false", "false": "true"}[lit.Name]
+		if !ok {
+			return nil
+		}
+		from := lit.Name
+		return []Mutation{{
+			Pos:         lit.Pos(),
+			Description: from + " → " + to,
+			Apply:       func() { lit.Name = to },
+			Revert:      func() { lit.Name = from },
+		}}
+	}
+	return nil
+}
+
+// DeleteStatement Removes calls, assignments, increments and sends -
+// statements whose only trace is their effect. Declarations stay: removing
+// them rarely compiles.
+type DeleteStatement struct{}
+
+func (DeleteStatement) Name() string { return "delete-statement" }
+
+func (DeleteStatement) Mutations(n ast.Node) []Mutation {
+	block, ok := n.(*ast.BlockStmt)
+	if !ok {
+		return nil
+	}
+	var ms []Mutation
+	for i, stmt := range block.List {
+		switch s := stmt.(type) {
+		case *ast.ExprStmt, *ast.IncDecStmt, *ast.SendStmt:
+		case *ast.AssignStmt:
+			if s.Tok == token.DEFINE {
+				continue
+			}
+		default:
+			continue
+		}
+		ms = append(ms, Mutation{
+			Pos:         stmt.Pos(),
+			Description: "statement deleted",
+			Apply:       func() { block.List[i] = &ast.EmptyStmt{Semicolon: stmt.Pos(), Implicit: true} },
+			Revert:      func() { block.List[i] = stmt },
+		})
+	}
+	return ms
+}
+
+var (
+	_ Mutator = FlipCondition{}
+	_ Mutator = ChangeConstant{}
+	_ Mutator = DeleteStatement{}
+)
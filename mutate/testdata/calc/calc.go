@@ -0,0 +1,26 @@
+package calc
+
+// Max returns the larger of a and b.
+func Max(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+// Abs returns n without its sign.
+func Abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+// Sum adds xs up.
+func Sum(xs []int) int {
+	var total int
+	for _, x := range xs {
+		total += x
+	}
+	return total
+}
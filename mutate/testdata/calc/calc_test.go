@@ -0,0 +1,16 @@
+package calc
+
+import "testing"
+
+// The mutant n < 1 survives: with -0 == 0 it is Abs all the same.
+func TestCalc(t *testing.T) {
+	if Max(2, 1) != 2 || Max(1, 2) != 2 {
+		t.Error("Max")
+	}
+	if Abs(-3) != 3 || Abs(3) != 3 {
+		t.Error("Abs")
+	}
+	if Sum([]int{1, 2}) != 3 {
+		t.Error("Sum")
+	}
+}
@@ -0,0 +1,3 @@
+package untested
+
+func Double(n int) int { return n * 2 }
@@ -0,0 +1,164 @@
+// Package normalize turns names into the keys repositories store and look
+// them up under, so that "Mohamed", "mohamed" and " Mohamed " are the same
+// employee in every backend, whatever its collation.
+//
+// A Normalizer is one rule; Chain applies several in order. Name is the
+// chain the repositories use by default: Trim, NFC, then Fold. Every
+// Normalizer returns its input unchanged, without allocating, when there is
+// nothing to change - the common case for a lookup.
+package normalize
+
+import (
+	"slices"
+	"strings"
+	"unicode"
+)
+
+// Normalizer Abstraction - maps a string to its canonical form. Two strings
+// with the same canonical form are the same key. It must be idempotent:
+// normalizing a canonical form changes nothing.
+type Normalizer interface {
+	Normalize(s string) string
+}
+
+// Func Adapter turning a function into a Normalizer
+type Func func(s string) string
+
+func (f Func) Normalize(s string) string { return f(s) }
+
+// Chain Applies each Normalizer in turn
+type Chain []Normalizer
+
+func (c Chain) Normalize(s string) string {
+	for _, n := range c {
+		s = n.Normalize(s)
+	}
+	return s
+}
+
+var (
+	// Name is the key of an employee's name: trimmed, composed and case-folded.
+	Name Normalizer = Chain{Trim{}, NFC{}, Fold{}}
+	// Exact keeps names as written, for backends that compared them that way
+	// before normalization existed.
+	Exact Normalizer = Chain{}
+)
+
+// Equal reports whether a and b have the same canonical form under n.
+func Equal(n Normalizer, a, b string) bool {
+	return a == b || n.Normalize(a) == n.Normalize(b)
+}
+
+// Trim Drops leading and trailing white space and turns every run of white
+// space inside into one space
+type Trim struct{}
+
+func (Trim) Normalize(s string) string {
+	if trimmed(s) {
+		return s
+	}
+	return strings.Join(strings.Fields(s), " ")
+}
+
+// trimmed reports whether Trim would leave s as it is.
+func trimmed(s string) bool {
+	space := true // at the start, a space would be leading
+	for _, r := range s {
+		switch {
+		case r == ' ' && !space:
+			space = true
+		case unicode.IsSpace(r):
+			return false
+		default:
+			space = false
+		}
+	}
+	return !space || s == ""
+}
+
+// Fold Case folding: each letter becomes the lower case of its upper case,
+// which also brings together letters with more than two forms, as the
+// Kelvin sign with k and the three Greek sigmas. Folds that change the
+// length of a string, as ß to ss, are not made.
+type Fold struct{}
+
+func (Fold) Normalize(s string) string {
+	// strings.Map returns s itself when no rune changes
+	return strings.Map(func(r rune) rune { return unicode.ToLower(unicode.ToUpper(r)) }, s)
+}
+
+// NFC Unicode Normalization Form C for the Latin, Greek and Cyrillic
+// letters: a letter followed by combining marks becomes the precomposed
+// letter, as "e" and U+0301 become "é", whichever order marks of different
+// classes come in. Text without those marks is already in NFC and is
+// returned as is; marks outside the tables are kept where they are.
+type NFC struct{}
+
+func (NFC) Normalize(s string) string {
+	if !strings.ContainsFunc(s, func(r rune) bool { _, ok := mark[r]; return ok }) {
+		return s
+	}
+	// Decompose, put each run of marks in canonical order, then compose.
+	var runes []rune
+	for _, r := range s {
+		runes = decompose(runes, r)
+	}
+	for i := 0; i < len(runes); {
+		j := i
+		for j < len(runes) && mark[runes[j]] > 0 {
+			j++
+		}
+		if j > i {
+			slices.SortStableFunc(runes[i:j], func(a, b rune) int { return int(mark[a]) - int(mark[b]) })
+			i = j
+		} else {
+			i++
+		}
+	}
+	out := runes[:0]
+	starter, last := -1, uint8(0)
+	for _, r := range runes {
+		class := mark[r]
+		// r is blocked from the starter by anything between them of the
+		// same class or a starter
+		if starter >= 0 && (len(out)-1 == starter || last != 0 && last < class) {
+			if c, ok := compositions[[2]rune{out[starter], r}]; ok {
+				out[starter] = c
+				continue
+			}
+		}
+		if class == 0 {
+			starter = len(out)
+		}
+		out = append(out, r)
+		last = class
+	}
+	return string(out)
+}
+
+// decomposition Letter with a mark and the letter and mark it is made of,
+// the compositions the other way round
+var decomposition = func() map[rune][2]rune {
+	m := make(map[rune][2]rune, len(compositions))
+	for pair, c := range compositions {
+		m[c] = pair
+	}
+	return m
+}()
+
+// decompose appends r to runes with every mark on it taken apart.
+func decompose(runes []rune, r rune) []rune {
+	pair, ok := decomposition[r]
+	if !ok {
+		return append(runes, r)
+	}
+	return append(decompose(runes, pair[0]), pair[1])
+}
+
+var (
+	_ Normalizer = Func(nil)
+	_ Normalizer = Chain{}
+	_ Normalizer = Trim{}
+	_ Normalizer = Fold{}
+	_ Normalizer = NFC{}
+)
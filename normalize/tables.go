@@ -0,0 +1,217 @@
+package normalize
+
+// The tables below cover the letters of the Latin, Greek and Cyrillic blocks
+// that Unicode composes from a letter and one combining mark, taken from the
+// decomposition field of the Unicode Character Database. Letters composed
+// from a letter with a mark already on it (ệ is ẹ and a circumflex) compose
+// in steps. Compositions Unicode excludes from NFC are left out.
+
+// mark Canonical combining class of each mark the compositions use; marks of
+// different classes may come in either order and mean the same
+var mark = map[rune]uint8{
+	'\u0300': 230, '\u0301': 230, '\u0302': 230, '\u0303': 230, '\u0304': 230, '\u0306': 230,
+	'\u0307': 230, '\u0308': 230, '\u0309': 230, '\u030a': 230, '\u030b': 230, '\u030c': 230,
+	'\u030f': 230, '\u0311': 230, '\u0313': 230, '\u0314': 230, '\u031b': 216, '\u0323': 220,
+	'\u0324': 220, '\u0325': 220, '\u0326': 220, '\u0327': 202, '\u0328': 202, '\u032d': 220,
+	'\u032e': 220, '\u0330': 220, '\u0331': 220, '\u0342': 230, '\u0345': 240,
+}
+
+// compositions Letter and mark, and the letter they compose
+var compositions = map[[2]rune]rune{
+	{'A', '\u0300'}: 'À', {'A', '\u0301'}: 'Á', {'A', '\u0302'}: 'Â', {'A', '\u0303'}: 'Ã',
+	{'A', '\u0308'}: 'Ä', {'A', '\u030a'}: 'Å', {'C', '\u0327'}: 'Ç', {'E', '\u0300'}: 'È',
+	{'E', '\u0301'}: 'É', {'E', '\u0302'}: 'Ê', {'E', '\u0308'}: 'Ë', {'I', '\u0300'}: 'Ì',
+	{'I', '\u0301'}: 'Í', {'I', '\u0302'}: 'Î', {'I', '\u0308'}: 'Ï', {'N', '\u0303'}: 'Ñ',
+	{'O', '\u0300'}: 'Ò', {'O', '\u0301'}: 'Ó', {'O', '\u0302'}: 'Ô', {'O', '\u0303'}: 'Õ',
+	{'O', '\u0308'}: 'Ö', {'U', '\u0300'}: 'Ù', {'U', '\u0301'}: 'Ú', {'U', '\u0302'}: 'Û',
+	{'U', '\u0308'}: 'Ü', {'Y', '\u0301'}: 'Ý', {'a', '\u0300'}: 'à', {'a', '\u0301'}: 'á',
+	{'a', '\u0302'}: 'â', {'a', '\u0303'}: 'ã', {'a', '\u0308'}: 'ä', {'a', '\u030a'}: 'å',
+	{'c', '\u0327'}: 'ç', {'e', '\u0300'}: 'è', {'e', '\u0301'}: 'é', {'e', '\u0302'}: 'ê',
+	{'e', '\u0308'}: 'ë', {'i', '\u0300'}: 'ì', {'i', '\u0301'}: 'í', {'i', '\u0302'}: 'î',
+	{'i', '\u0308'}: 'ï', {'n', '\u0303'}: 'ñ', {'o', '\u0300'}: 'ò', {'o', '\u0301'}: 'ó',
+	{'o', '\u0302'}: 'ô', {'o', '\u0303'}: 'õ', {'o', '\u0308'}: 'ö', {'u', '\u0300'}: 'ù',
+	{'u', '\u0301'}: 'ú', {'u', '\u0302'}: 'û', {'u', '\u0308'}: 'ü', {'y', '\u0301'}: 'ý',
+	{'y', '\u0308'}: 'ÿ', {'A', '\u0304'}: 'Ā', {'a', '\u0304'}: 'ā', {'A', '\u0306'}: 'Ă',
+	{'a', '\u0306'}: 'ă', {'A', '\u0328'}: 'Ą', {'a', '\u0328'}: 'ą', {'C', '\u0301'}: 'Ć',
+	{'c', '\u0301'}: 'ć', {'C', '\u0302'}: 'Ĉ', {'c', '\u0302'}: 'ĉ', {'C', '\u0307'}: 'Ċ',
+	{'c', '\u0307'}: 'ċ', {'C', '\u030c'}: 'Č', {'c', '\u030c'}: 'č', {'D', '\u030c'}: 'Ď',
+	{'d', '\u030c'}: 'ď', {'E', '\u0304'}: 'Ē', {'e', '\u0304'}: 'ē', {'E', '\u0306'}: 'Ĕ',
+	{'e', '\u0306'}: 'ĕ', {'E', '\u0307'}: 'Ė', {'e', '\u0307'}: 'ė', {'E', '\u0328'}: 'Ę',
+	{'e', '\u0328'}: 'ę', {'E', '\u030c'}: 'Ě', {'e', '\u030c'}: 'ě', {'G', '\u0302'}: 'Ĝ',
+	{'g', '\u0302'}: 'ĝ', {'G', '\u0306'}: 'Ğ', {'g', '\u0306'}: 'ğ', {'G', '\u0307'}: 'Ġ',
+	{'g', '\u0307'}: 'ġ', {'G', '\u0327'}: 'Ģ', {'g', '\u0327'}: 'ģ', {'H', '\u0302'}: 'Ĥ',
+	{'h', '\u0302'}: 'ĥ', {'I', '\u0303'}: 'Ĩ', {'i', '\u0303'}: 'ĩ', {'I', '\u0304'}: 'Ī',
+	{'i', '\u0304'}: 'ī', {'I', '\u0306'}: 'Ĭ', {'i', '\u0306'}: 'ĭ', {'I', '\u0328'}: 'Į',
+	{'i', '\u0328'}: 'į', {'I', '\u0307'}: 'İ', {'J', '\u0302'}: 'Ĵ', {'j', '\u0302'}: 'ĵ',
+	{'K', '\u0327'}: 'Ķ', {'k', '\u0327'}: 'ķ', {'L', '\u0301'}: 'Ĺ', {'l', '\u0301'}: 'ĺ',
+	{'L', '\u0327'}: 'Ļ', {'l', '\u0327'}: 'ļ', {'L', '\u030c'}: 'Ľ', {'l', '\u030c'}: 'ľ',
+	{'N', '\u0301'}: 'Ń', {'n', '\u0301'}: 'ń', {'N', '\u0327'}: 'Ņ', {'n', '\u0327'}: 'ņ',
+	{'N', '\u030c'}: 'Ň', {'n', '\u030c'}: 'ň', {'O', '\u0304'}: 'Ō', {'o', '\u0304'}: 'ō',
+	{'O', '\u0306'}: 'Ŏ', {'o', '\u0306'}: 'ŏ', {'O', '\u030b'}: 'Ő', {'o', '\u030b'}: 'ő',
+	{'R', '\u0301'}: 'Ŕ', {'r', '\u0301'}: 'ŕ', {'R', '\u0327'}: 'Ŗ', {'r', '\u0327'}: 'ŗ',
+	{'R', '\u030c'}: 'Ř', {'r', '\u030c'}: 'ř', {'S', '\u0301'}: 'Ś', {'s', '\u0301'}: 'ś',
+	{'S', '\u0302'}: 'Ŝ', {'s', '\u0302'}: 'ŝ', {'S', '\u0327'}: 'Ş', {'s', '\u0327'}: 'ş',
+	{'S', '\u030c'}: 'Š', {'s', '\u030c'}: 'š', {'T', '\u0327'}: 'Ţ', {'t', '\u0327'}: 'ţ',
+	{'T', '\u030c'}: 'Ť', {'t', '\u030c'}: 'ť', {'U', '\u0303'}: 'Ũ', {'u', '\u0303'}: 'ũ',
+	{'U', '\u0304'}: 'Ū', {'u', '\u0304'}: 'ū', {'U', '\u0306'}: 'Ŭ', {'u', '\u0306'}: 'ŭ',
+	{'U', '\u030a'}: 'Ů', {'u', '\u030a'}: 'ů', {'U', '\u030b'}: 'Ű', {'u', '\u030b'}: 'ű',
+	{'U', '\u0328'}: 'Ų', {'u', '\u0328'}: 'ų', {'W', '\u0302'}: 'Ŵ', {'w', '\u0302'}: 'ŵ',
+	{'Y', '\u0302'}: 'Ŷ', {'y', '\u0302'}: 'ŷ', {'Y', '\u0308'}: 'Ÿ', {'Z', '\u0301'}: 'Ź',
+	{'z', '\u0301'}: 'ź', {'Z', '\u0307'}: 'Ż', {'z', '\u0307'}: 'ż', {'Z', '\u030c'}: 'Ž',
+	{'z', '\u030c'}: 'ž', {'O', '\u031b'}: 'Ơ', {'o', '\u031b'}: 'ơ', {'U', '\u031b'}: 'Ư',
+	{'u', '\u031b'}: 'ư', {'A', '\u030c'}: 'Ǎ', {'a', '\u030c'}: 'ǎ', {'I', '\u030c'}: 'Ǐ',
+	{'i', '\u030c'}: 'ǐ', {'O', '\u030c'}: 'Ǒ', {'o', '\u030c'}: 'ǒ', {'U', '\u030c'}: 'Ǔ',
+	{'u', '\u030c'}: 'ǔ', {'Ü', '\u0304'}: 'Ǖ', {'ü', '\u0304'}: 'ǖ', {'Ü', '\u0301'}: 'Ǘ',
+	{'ü', '\u0301'}: 'ǘ', {'Ü', '\u030c'}: 'Ǚ', {'ü', '\u030c'}: 'ǚ', {'Ü', '\u0300'}: 'Ǜ',
+	{'ü', '\u0300'}: 'ǜ', {'Ä', '\u0304'}: 'Ǟ', {'ä', '\u0304'}: 'ǟ', {'Ȧ', '\u0304'}: 'Ǡ',
+	{'ȧ', '\u0304'}: 'ǡ', {'Æ', '\u0304'}: 'Ǣ', {'æ', '\u0304'}: 'ǣ', {'G', '\u030c'}: 'Ǧ',
+	{'g', '\u030c'}: 'ǧ', {'K', '\u030c'}: 'Ǩ', {'k', '\u030c'}: 'ǩ', {'O', '\u0328'}: 'Ǫ',
+	{'o', '\u0328'}: 'ǫ', {'Ǫ', '\u0304'}: 'Ǭ', {'ǫ', '\u0304'}: 'ǭ', {'Ʒ', '\u030c'}: 'Ǯ',
+	{'ʒ', '\u030c'}: 'ǯ', {'j', '\u030c'}: 'ǰ', {'G', '\u0301'}: 'Ǵ', {'g', '\u0301'}: 'ǵ',
+	{'N', '\u0300'}: 'Ǹ', {'n', '\u0300'}: 'ǹ', {'Å', '\u0301'}: 'Ǻ', {'å', '\u0301'}: 'ǻ',
+	{'Æ', '\u0301'}: 'Ǽ', {'æ', '\u0301'}: 'ǽ', {'Ø', '\u0301'}: 'Ǿ', {'ø', '\u0301'}: 'ǿ',
+	{'A', '\u030f'}: 'Ȁ', {'a', '\u030f'}: 'ȁ', {'A', '\u0311'}: 'Ȃ', {'a', '\u0311'}: 'ȃ',
+	{'E', '\u030f'}: 'Ȅ', {'e', '\u030f'}: 'ȅ', {'E', '\u0311'}: 'Ȇ', {'e', '\u0311'}: 'ȇ',
+	{'I', '\u030f'}: 'Ȉ', {'i', '\u030f'}: 'ȉ', {'I', '\u0311'}: 'Ȋ', {'i', '\u0311'}: 'ȋ',
+	{'O', '\u030f'}: 'Ȍ', {'o', '\u030f'}: 'ȍ', {'O', '\u0311'}: 'Ȏ', {'o', '\u0311'}: 'ȏ',
+	{'R', '\u030f'}: 'Ȑ', {'r', '\u030f'}: 'ȑ', {'R', '\u0311'}: 'Ȓ', {'r', '\u0311'}: 'ȓ',
+	{'U', '\u030f'}: 'Ȕ', {'u', '\u030f'}: 'ȕ', {'U', '\u0311'}: 'Ȗ', {'u', '\u0311'}: 'ȗ',
+	{'S', '\u0326'}: 'Ș', {'s', '\u0326'}: 'ș', {'T', '\u0326'}: 'Ț', {'t', '\u0326'}: 'ț',
+	{'H', '\u030c'}: 'Ȟ', {'h', '\u030c'}: 'ȟ', {'A', '\u0307'}: 'Ȧ', {'a', '\u0307'}: 'ȧ',
+	{'E', '\u0327'}: 'Ȩ', {'e', '\u0327'}: 'ȩ', {'Ö', '\u0304'}: 'Ȫ', {'ö', '\u0304'}: 'ȫ',
+	{'Õ', '\u0304'}: 'Ȭ', {'õ', '\u0304'}: 'ȭ', {'O', '\u0307'}: 'Ȯ', {'o', '\u0307'}: 'ȯ',
+	{'Ȯ', '\u0304'}: 'Ȱ', {'ȯ', '\u0304'}: 'ȱ', {'Y', '\u0304'}: 'Ȳ', {'y', '\u0304'}: 'ȳ',
+	{'¨', '\u0301'}: '΅', {'Α', '\u0301'}: 'Ά', {'Ε', '\u0301'}: 'Έ', {'Η', '\u0301'}: 'Ή',
+	{'Ι', '\u0301'}: 'Ί', {'Ο', '\u0301'}: 'Ό', {'Υ', '\u0301'}: 'Ύ', {'Ω', '\u0301'}: 'Ώ',
+	{'ϊ', '\u0301'}: 'ΐ', {'Ι', '\u0308'}: 'Ϊ', {'Υ', '\u0308'}: 'Ϋ', {'α', '\u0301'}: 'ά',
+	{'ε', '\u0301'}: 'έ', {'η', '\u0301'}: 'ή', {'ι', '\u0301'}: 'ί', {'ϋ', '\u0301'}: 'ΰ',
+	{'ι', '\u0308'}: 'ϊ', {'υ', '\u0308'}: 'ϋ', {'ο', '\u0301'}: 'ό', {'υ', '\u0301'}: 'ύ',
+	{'ω', '\u0301'}: 'ώ', {'ϒ', '\u0301'}: 'ϓ', {'ϒ', '\u0308'}: 'ϔ', {'Е', '\u0300'}: 'Ѐ',
+	{'Е', '\u0308'}: 'Ё', {'Г', '\u0301'}: 'Ѓ', {'І', '\u0308'}: 'Ї', {'К', '\u0301'}: 'Ќ',
+	{'И', '\u0300'}: 'Ѝ', {'У', '\u0306'}: 'Ў', {'И', '\u0306'}: 'Й', {'и', '\u0306'}: 'й',
+	{'е', '\u0300'}: 'ѐ', {'е', '\u0308'}: 'ё', {'г', '\u0301'}: 'ѓ', {'і', '\u0308'}: 'ї',
+	{'к', '\u0301'}: 'ќ', {'и', '\u0300'}: 'ѝ', {'у', '\u0306'}: 'ў', {'Ѵ', '\u030f'}: 'Ѷ',
+	{'ѵ', '\u030f'}: 'ѷ', {'Ж', '\u0306'}: 'Ӂ', {'ж', '\u0306'}: 'ӂ', {'А', '\u0306'}: 'Ӑ',
+	{'а', '\u0306'}: 'ӑ', {'А', '\u0308'}: 'Ӓ', {'а', '\u0308'}: 'ӓ', {'Е', '\u0306'}: 'Ӗ',
+	{'е', '\u0306'}: 'ӗ', {'Ә', '\u0308'}: 'Ӛ', {'ә', '\u0308'}: 'ӛ', {'Ж', '\u0308'}: 'Ӝ',
+	{'ж', '\u0308'}: 'ӝ', {'З', '\u0308'}: 'Ӟ', {'з', '\u0308'}: 'ӟ', {'И', '\u0304'}: 'Ӣ',
+	{'и', '\u0304'}: 'ӣ', {'И', '\u0308'}: 'Ӥ', {'и', '\u0308'}: 'ӥ', {'О', '\u0308'}: 'Ӧ',
+	{'о', '\u0308'}: 'ӧ', {'Ө', '\u0308'}: 'Ӫ', {'ө', '\u0308'}: 'ӫ', {'Э', '\u0308'}: 'Ӭ',
+	{'э', '\u0308'}: 'ӭ', {'У', '\u0304'}: 'Ӯ', {'у', '\u0304'}: 'ӯ', {'У', '\u0308'}: 'Ӱ',
+	{'у', '\u0308'}: 'ӱ', {'У', '\u030b'}: 'Ӳ', {'у', '\u030b'}: 'ӳ', {'Ч', '\u0308'}: 'Ӵ',
+	{'ч', '\u0308'}: 'ӵ', {'Ы', '\u0308'}: 'Ӹ', {'ы', '\u0308'}: 'ӹ', {'A', '\u0325'}: 'Ḁ',
+	{'a', '\u0325'}: 'ḁ', {'B', '\u0307'}: 'Ḃ', {'b', '\u0307'}: 'ḃ', {'B', '\u0323'}: 'Ḅ',
+	{'b', '\u0323'}: 'ḅ', {'B', '\u0331'}: 'Ḇ', {'b', '\u0331'}: 'ḇ', {'Ç', '\u0301'}: 'Ḉ',
+	{'ç', '\u0301'}: 'ḉ', {'D', '\u0307'}: 'Ḋ', {'d', '\u0307'}: 'ḋ', {'D', '\u0323'}: 'Ḍ',
+	{'d', '\u0323'}: 'ḍ', {'D', '\u0331'}: 'Ḏ', {'d', '\u0331'}: 'ḏ', {'D', '\u0327'}: 'Ḑ',
+	{'d', '\u0327'}: 'ḑ', {'D', '\u032d'}: 'Ḓ', {'d', '\u032d'}: 'ḓ', {'Ē', '\u0300'}: 'Ḕ',
+	{'ē', '\u0300'}: 'ḕ', {'Ē', '\u0301'}: 'Ḗ', {'ē', '\u0301'}: 'ḗ', {'E', '\u032d'}: 'Ḙ',
+	{'e', '\u032d'}: 'ḙ', {'E', '\u0330'}: 'Ḛ', {'e', '\u0330'}: 'ḛ', {'Ȩ', '\u0306'}: 'Ḝ',
+	{'ȩ', '\u0306'}: 'ḝ', {'F', '\u0307'}: 'Ḟ', {'f', '\u0307'}: 'ḟ', {'G', '\u0304'}: 'Ḡ',
+	{'g', '\u0304'}: 'ḡ', {'H', '\u0307'}: 'Ḣ', {'h', '\u0307'}: 'ḣ', {'H', '\u0323'}: 'Ḥ',
+	{'h', '\u0323'}: 'ḥ', {'H', '\u0308'}: 'Ḧ', {'h', '\u0308'}: 'ḧ', {'H', '\u0327'}: 'Ḩ',
+	{'h', '\u0327'}: 'ḩ', {'H', '\u032e'}: 'Ḫ', {'h', '\u032e'}: 'ḫ', {'I', '\u0330'}: 'Ḭ',
+	{'i', '\u0330'}: 'ḭ', {'Ï', '\u0301'}: 'Ḯ', {'ï', '\u0301'}: 'ḯ', {'K', '\u0301'}: 'Ḱ',
+	{'k', '\u0301'}: 'ḱ', {'K', '\u0323'}: 'Ḳ', {'k', '\u0323'}: 'ḳ', {'K', '\u0331'}: 'Ḵ',
+	{'k', '\u0331'}: 'ḵ', {'L', '\u0323'}: 'Ḷ', {'l', '\u0323'}: 'ḷ', {'Ḷ', '\u0304'}: 'Ḹ',
+	{'ḷ', '\u0304'}: 'ḹ', {'L', '\u0331'}: 'Ḻ', {'l', '\u0331'}: 'ḻ', {'L', '\u032d'}: 'Ḽ',
+	{'l', '\u032d'}: 'ḽ', {'M', '\u0301'}: 'Ḿ', {'m', '\u0301'}: 'ḿ', {'M', '\u0307'}: 'Ṁ',
+	{'m', '\u0307'}: 'ṁ', {'M', '\u0323'}: 'Ṃ', {'m', '\u0323'}: 'ṃ', {'N', '\u0307'}: 'Ṅ',
+	{'n', '\u0307'}: 'ṅ', {'N', '\u0323'}: 'Ṇ', {'n', '\u0323'}: 'ṇ', {'N', '\u0331'}: 'Ṉ',
+	{'n', '\u0331'}: 'ṉ', {'N', '\u032d'}: 'Ṋ', {'n', '\u032d'}: 'ṋ', {'Õ', '\u0301'}: 'Ṍ',
+	{'õ', '\u0301'}: 'ṍ', {'Õ', '\u0308'}: 'Ṏ', {'õ', '\u0308'}: 'ṏ', {'Ō', '\u0300'}: 'Ṑ',
+	{'ō', '\u0300'}: 'ṑ', {'Ō', '\u0301'}: 'Ṓ', {'ō', '\u0301'}: 'ṓ', {'P', '\u0301'}: 'Ṕ',
+	{'p', '\u0301'}: 'ṕ', {'P', '\u0307'}: 'Ṗ', {'p', '\u0307'}: 'ṗ', {'R', '\u0307'}: 'Ṙ',
+	{'r', '\u0307'}: 'ṙ', {'R', '\u0323'}: 'Ṛ', {'r', '\u0323'}: 'ṛ', {'Ṛ', '\u0304'}: 'Ṝ',
+	{'ṛ', '\u0304'}: 'ṝ', {'R', '\u0331'}: 'Ṟ', {'r', '\u0331'}: 'ṟ', {'S', '\u0307'}: 'Ṡ',
+	{'s', '\u0307'}: 'ṡ', {'S', '\u0323'}: 'Ṣ', {'s', '\u0323'}: 'ṣ', {'Ś', '\u0307'}: 'Ṥ',
+	{'ś', '\u0307'}: 'ṥ', {'Š', '\u0307'}: 'Ṧ', {'š', '\u0307'}: 'ṧ', {'Ṣ', '\u0307'}: 'Ṩ',
+	{'ṣ', '\u0307'}: 'ṩ', {'T', '\u0307'}: 'Ṫ', {'t', '\u0307'}: 'ṫ', {'T', '\u0323'}: 'Ṭ',
+	{'t', '\u0323'}: 'ṭ', {'T', '\u0331'}: 'Ṯ', {'t', '\u0331'}: 'ṯ', {'T', '\u032d'}: 'Ṱ',
+	{'t', '\u032d'}: 'ṱ', {'U', '\u0324'}: 'Ṳ', {'u', '\u0324'}: 'ṳ', {'U', '\u0330'}: 'Ṵ',
+	{'u', '\u0330'}: 'ṵ', {'U', '\u032d'}: 'Ṷ', {'u', '\u032d'}: 'ṷ', {'Ũ', '\u0301'}: 'Ṹ',
+	{'ũ', '\u0301'}: 'ṹ', {'Ū', '\u0308'}: 'Ṻ', {'ū', '\u0308'}: 'ṻ', {'V', '\u0303'}: 'Ṽ',
+	{'v', '\u0303'}: 'ṽ', {'V', '\u0323'}: 'Ṿ', {'v', '\u0323'}: 'ṿ', {'W', '\u0300'}: 'Ẁ',
+	{'w', '\u0300'}: 'ẁ', {'W', '\u0301'}: 'Ẃ', {'w', '\u0301'}: 'ẃ', {'W', '\u0308'}: 'Ẅ',
+	{'w', '\u0308'}: 'ẅ', {'W', '\u0307'}: 'Ẇ', {'w', '\u0307'}: 'ẇ', {'W', '\u0323'}: 'Ẉ',
+	{'w', '\u0323'}: 'ẉ', {'X', '\u0307'}: 'Ẋ', {'x', '\u0307'}: 'ẋ', {'X', '\u0308'}: 'Ẍ',
+	{'x', '\u0308'}: 'ẍ', {'Y', '\u0307'}: 'Ẏ', {'y', '\u0307'}: 'ẏ', {'Z', '\u0302'}: 'Ẑ',
+	{'z', '\u0302'}: 'ẑ', {'Z', '\u0323'}: 'Ẓ', {'z', '\u0323'}: 'ẓ', {'Z', '\u0331'}: 'Ẕ',
+	{'z', '\u0331'}: 'ẕ', {'h', '\u0331'}: 'ẖ', {'t', '\u0308'}: 'ẗ', {'w', '\u030a'}: 'ẘ',
+	{'y', '\u030a'}: 'ẙ', {'ſ', '\u0307'}: 'ẛ', {'A', '\u0323'}: 'Ạ', {'a', '\u0323'}: 'ạ',
+	{'A', '\u0309'}: 'Ả', {'a', '\u0309'}: 'ả', {'Â', '\u0301'}: 'Ấ', {'â', '\u0301'}: 'ấ',
+	{'Â', '\u0300'}: 'Ầ', {'â', '\u0300'}: 'ầ', {'Â', '\u0309'}: 'Ẩ', {'â', '\u0309'}: 'ẩ',
+	{'Â', '\u0303'}: 'Ẫ', {'â', '\u0303'}: 'ẫ', {'Ạ', '\u0302'}: 'Ậ', {'ạ', '\u0302'}: 'ậ',
+	{'Ă', '\u0301'}: 'Ắ', {'ă', '\u0301'}: 'ắ', {'Ă', '\u0300'}: 'Ằ', {'ă', '\u0300'}: 'ằ',
+	{'Ă', '\u0309'}: 'Ẳ', {'ă', '\u0309'}: 'ẳ', {'Ă', '\u0303'}: 'Ẵ', {'ă', '\u0303'}: 'ẵ',
+	{'Ạ', '\u0306'}: 'Ặ', {'ạ', '\u0306'}: 'ặ', {'E', '\u0323'}: 'Ẹ', {'e', '\u0323'}: 'ẹ',
+	{'E', '\u0309'}: 'Ẻ', {'e', '\u0309'}: 'ẻ', {'E', '\u0303'}: 'Ẽ', {'e', '\u0303'}: 'ẽ',
+	{'Ê', '\u0301'}: 'Ế', {'ê', '\u0301'}: 'ế', {'Ê', '\u0300'}: 'Ề', {'ê', '\u0300'}: 'ề',
+	{'Ê', '\u0309'}: 'Ể', {'ê', '\u0309'}: 'ể', {'Ê', '\u0303'}: 'Ễ', {'ê', '\u0303'}: 'ễ',
+	{'Ẹ', '\u0302'}: 'Ệ', {'ẹ', '\u0302'}: 'ệ', {'I', '\u0309'}: 'Ỉ', {'i', '\u0309'}: 'ỉ',
+	{'I', '\u0323'}: 'Ị', {'i', '\u0323'}: 'ị', {'O', '\u0323'}: 'Ọ', {'o', '\u0323'}: 'ọ',
+	{'O', '\u0309'}: 'Ỏ', {'o', '\u0309'}: 'ỏ', {'Ô', '\u0301'}: 'Ố', {'ô', '\u0301'}: 'ố',
+	{'Ô', '\u0300'}: 'Ồ', {'ô', '\u0300'}: 'ồ', {'Ô', '\u0309'}: 'Ổ', {'ô', '\u0309'}: 'ổ',
+	{'Ô', '\u0303'}: 'Ỗ', {'ô', '\u0303'}: 'ỗ', {'Ọ', '\u0302'}: 'Ộ', {'ọ', '\u0302'}: 'ộ',
+	{'Ơ', '\u0301'}: 'Ớ', {'ơ', '\u0301'}: 'ớ', {'Ơ', '\u0300'}: 'Ờ', {'ơ', '\u0300'}: 'ờ',
+	{'Ơ', '\u0309'}: 'Ở', {'ơ', '\u0309'}: 'ở', {'Ơ', '\u0303'}: 'Ỡ', {'ơ', '\u0303'}: 'ỡ',
+	{'Ơ', '\u0323'}: 'Ợ', {'ơ', '\u0323'}: 'ợ', {'U', '\u0323'}: 'Ụ', {'u', '\u0323'}: 'ụ',
+	{'U', '\u0309'}: 'Ủ', {'u', '\u0309'}: 'ủ', {'Ư', '\u0301'}: 'Ứ', {'ư', '\u0301'}: 'ứ',
+	{'Ư', '\u0300'}: 'Ừ', {'ư', '\u0300'}: 'ừ', {'Ư', '\u0309'}: 'Ử', {'ư', '\u0309'}: 'ử',
+	{'Ư', '\u0303'}: 'Ữ', {'ư', '\u0303'}: 'ữ', {'Ư', '\u0323'}: 'Ự', {'ư', '\u0323'}: 'ự',
+	{'Y', '\u0300'}: 'Ỳ', {'y', '\u0300'}: 'ỳ', {'Y', '\u0323'}: 'Ỵ', {'y', '\u0323'}: 'ỵ',
+	{'Y', '\u0309'}: 'Ỷ', {'y', '\u0309'}: 'ỷ', {'Y', '\u0303'}: 'Ỹ', {'y', '\u0303'}: 'ỹ',
+	{'α', '\u0313'}: 'ἀ', {'α', '\u0314'}: 'ἁ', {'ἀ', '\u0300'}: 'ἂ', {'ἁ', '\u0300'}: 'ἃ',
+	{'ἀ', '\u0301'}: 'ἄ', {'ἁ', '\u0301'}: 'ἅ', {'ἀ', '\u0342'}: 'ἆ', {'ἁ', '\u0342'}: 'ἇ',
+	{'Α', '\u0313'}: 'Ἀ', {'Α', '\u0314'}: 'Ἁ', {'Ἀ', '\u0300'}: 'Ἂ', {'Ἁ', '\u0300'}: 'Ἃ',
+	{'Ἀ', '\u0301'}: 'Ἄ', {'Ἁ', '\u0301'}: 'Ἅ', {'Ἀ', '\u0342'}: 'Ἆ', {'Ἁ', '\u0342'}: 'Ἇ',
+	{'ε', '\u0313'}: 'ἐ', {'ε', '\u0314'}: 'ἑ', {'ἐ', '\u0300'}: 'ἒ', {'ἑ', '\u0300'}: 'ἓ',
+	{'ἐ', '\u0301'}: 'ἔ', {'ἑ', '\u0301'}: 'ἕ', {'Ε', '\u0313'}: 'Ἐ', {'Ε', '\u0314'}: 'Ἑ',
+	{'Ἐ', '\u0300'}: 'Ἒ', {'Ἑ', '\u0300'}: 'Ἓ', {'Ἐ', '\u0301'}: 'Ἔ', {'Ἑ', '\u0301'}: 'Ἕ',
+	{'η', '\u0313'}: 'ἠ', {'η', '\u0314'}: 'ἡ', {'ἠ', '\u0300'}: 'ἢ', {'ἡ', '\u0300'}: 'ἣ',
+	{'ἠ', '\u0301'}: 'ἤ', {'ἡ', '\u0301'}: 'ἥ', {'ἠ', '\u0342'}: 'ἦ', {'ἡ', '\u0342'}: 'ἧ',
+	{'Η', '\u0313'}: 'Ἠ', {'Η', '\u0314'}: 'Ἡ', {'Ἠ', '\u0300'}: 'Ἢ', {'Ἡ', '\u0300'}: 'Ἣ',
+	{'Ἠ', '\u0301'}: 'Ἤ', {'Ἡ', '\u0301'}: 'Ἥ', {'Ἠ', '\u0342'}: 'Ἦ', {'Ἡ', '\u0342'}: 'Ἧ',
+	{'ι', '\u0313'}: 'ἰ', {'ι', '\u0314'}: 'ἱ', {'ἰ', '\u0300'}: 'ἲ', {'ἱ', '\u0300'}: 'ἳ',
+	{'ἰ', '\u0301'}: 'ἴ', {'ἱ', '\u0301'}: 'ἵ', {'ἰ', '\u0342'}: 'ἶ', {'ἱ', '\u0342'}: 'ἷ',
+	{'Ι', '\u0313'}: 'Ἰ', {'Ι', '\u0314'}: 'Ἱ', {'Ἰ', '\u0300'}: 'Ἲ', {'Ἱ', '\u0300'}: 'Ἳ',
+	{'Ἰ', '\u0301'}: 'Ἴ', {'Ἱ', '\u0301'}: 'Ἵ', {'Ἰ', '\u0342'}: 'Ἶ', {'Ἱ', '\u0342'}: 'Ἷ',
+	{'ο', '\u0313'}: 'ὀ', {'ο', '\u0314'}: 'ὁ', {'ὀ', '\u0300'}: 'ὂ', {'ὁ', '\u0300'}: 'ὃ',
+	{'ὀ', '\u0301'}: 'ὄ', {'ὁ', '\u0301'}: 'ὅ', {'Ο', '\u0313'}: 'Ὀ', {'Ο', '\u0314'}: 'Ὁ',
+	{'Ὀ', '\u0300'}: 'Ὂ', {'Ὁ', '\u0300'}: 'Ὃ', {'Ὀ', '\u0301'}: 'Ὄ', {'Ὁ', '\u0301'}: 'Ὅ',
+	{'υ', '\u0313'}: 'ὐ', {'υ', '\u0314'}: 'ὑ', {'ὐ', '\u0300'}: 'ὒ', {'ὑ', '\u0300'}: 'ὓ',
+	{'ὐ', '\u0301'}: 'ὔ', {'ὑ', '\u0301'}: 'ὕ', {'ὐ', '\u0342'}: 'ὖ', {'ὑ', '\u0342'}: 'ὗ',
+	{'Υ', '\u0314'}: 'Ὑ', {'Ὑ', '\u0300'}: 'Ὓ', {'Ὑ', '\u0301'}: 'Ὕ', {'Ὑ', '\u0342'}: 'Ὗ',
+	{'ω', '\u0313'}: 'ὠ', {'ω', '\u0314'}: 'ὡ', {'ὠ', '\u0300'}: 'ὢ', {'ὡ', '\u0300'}: 'ὣ',
+	{'ὠ', '\u0301'}: 'ὤ', {'ὡ', '\u0301'}: 'ὥ', {'ὠ', '\u0342'}: 'ὦ', {'ὡ', '\u0342'}: 'ὧ',
+	{'Ω', '\u0313'}: 'Ὠ', {'Ω', '\u0314'}: 'Ὡ', {'Ὠ', '\u0300'}: 'Ὢ', {'Ὡ', '\u0300'}: 'Ὣ',
+	{'Ὠ', '\u0301'}: 'Ὤ', {'Ὡ', '\u0301'}: 'Ὥ', {'Ὠ', '\u0342'}: 'Ὦ', {'Ὡ', '\u0342'}: 'Ὧ',
+	{'α', '\u0300'}: 'ὰ', {'ε', '\u0300'}: 'ὲ', {'η', '\u0300'}: 'ὴ', {'ι', '\u0300'}: 'ὶ',
+	{'ο', '\u0300'}: 'ὸ', {'υ', '\u0300'}: 'ὺ', {'ω', '\u0300'}: 'ὼ', {'ἀ', '\u0345'}: 'ᾀ',
+	{'ἁ', '\u0345'}: 'ᾁ', {'ἂ', '\u0345'}: 'ᾂ', {'ἃ', '\u0345'}: 'ᾃ', {'ἄ', '\u0345'}: 'ᾄ',
+	{'ἅ', '\u0345'}: 'ᾅ', {'ἆ', '\u0345'}: 'ᾆ', {'ἇ', '\u0345'}: 'ᾇ', {'Ἀ', '\u0345'}: 'ᾈ',
+	{'Ἁ', '\u0345'}: 'ᾉ', {'Ἂ', '\u0345'}: 'ᾊ', {'Ἃ', '\u0345'}: 'ᾋ', {'Ἄ', '\u0345'}: 'ᾌ',
+	{'Ἅ', '\u0345'}: 'ᾍ', {'Ἆ', '\u0345'}: 'ᾎ', {'Ἇ', '\u0345'}: 'ᾏ', {'ἠ', '\u0345'}: 'ᾐ',
+	{'ἡ', '\u0345'}: 'ᾑ', {'ἢ', '\u0345'}: 'ᾒ', {'ἣ', '\u0345'}: 'ᾓ', {'ἤ', '\u0345'}: 'ᾔ',
+	{'ἥ', '\u0345'}: 'ᾕ', {'ἦ', '\u0345'}: 'ᾖ', {'ἧ', '\u0345'}: 'ᾗ', {'Ἠ', '\u0345'}: 'ᾘ',
+	{'Ἡ', '\u0345'}: 'ᾙ', {'Ἢ', '\u0345'}: 'ᾚ', {'Ἣ', '\u0345'}: 'ᾛ', {'Ἤ', '\u0345'}: 'ᾜ',
+	{'Ἥ', '\u0345'}: 'ᾝ', {'Ἦ', '\u0345'}: 'ᾞ', {'Ἧ', '\u0345'}: 'ᾟ', {'ὠ', '\u0345'}: 'ᾠ',
+	{'ὡ', '\u0345'}: 'ᾡ', {'ὢ', '\u0345'}: 'ᾢ', {'ὣ', '\u0345'}: 'ᾣ', {'ὤ', '\u0345'}: 'ᾤ',
+	{'ὥ', '\u0345'}: 'ᾥ', {'ὦ', '\u0345'}: 'ᾦ', {'ὧ', '\u0345'}: 'ᾧ', {'Ὠ', '\u0345'}: 'ᾨ',
+	{'Ὡ', '\u0345'}: 'ᾩ', {'Ὢ', '\u0345'}: 'ᾪ', {'Ὣ', '\u0345'}: 'ᾫ', {'Ὤ', '\u0345'}: 'ᾬ',
+	{'Ὥ', '\u0345'}: 'ᾭ', {'Ὦ', '\u0345'}: 'ᾮ', {'Ὧ', '\u0345'}: 'ᾯ', {'α', '\u0306'}: 'ᾰ',
+	{'α', '\u0304'}: 'ᾱ', {'ὰ', '\u0345'}: 'ᾲ', {'α', '\u0345'}: 'ᾳ', {'ά', '\u0345'}: 'ᾴ',
+	{'α', '\u0342'}: 'ᾶ', {'ᾶ', '\u0345'}: 'ᾷ', {'Α', '\u0306'}: 'Ᾰ', {'Α', '\u0304'}: 'Ᾱ',
+	{'Α', '\u0300'}: 'Ὰ', {'Α', '\u0345'}: 'ᾼ', {'¨', '\u0342'}: '῁', {'ὴ', '\u0345'}: 'ῂ',
+	{'η', '\u0345'}: 'ῃ', {'ή', '\u0345'}: 'ῄ', {'η', '\u0342'}: 'ῆ', {'ῆ', '\u0345'}: 'ῇ',
+	{'Ε', '\u0300'}: 'Ὲ', {'Η', '\u0300'}: 'Ὴ', {'Η', '\u0345'}: 'ῌ', {'᾿', '\u0300'}: '῍',
+	{'᾿', '\u0301'}: '῎', {'᾿', '\u0342'}: '῏', {'ι', '\u0306'}: 'ῐ', {'ι', '\u0304'}: 'ῑ',
+	{'ϊ', '\u0300'}: 'ῒ', {'ι', '\u0342'}: 'ῖ', {'ϊ', '\u0342'}: 'ῗ', {'Ι', '\u0306'}: 'Ῐ',
+	{'Ι', '\u0304'}: 'Ῑ', {'Ι', '\u0300'}: 'Ὶ', {'῾', '\u0300'}: '῝', {'῾', '\u0301'}: '῞',
+	{'῾', '\u0342'}: '῟', {'υ', '\u0306'}: 'ῠ', {'υ', '\u0304'}: 'ῡ', {'ϋ', '\u0300'}: 'ῢ',
+	{'ρ', '\u0313'}: 'ῤ', {'ρ', '\u0314'}: 'ῥ', {'υ', '\u0342'}: 'ῦ', {'ϋ', '\u0342'}: 'ῧ',
+	{'Υ', '\u0306'}: 'Ῠ', {'Υ', '\u0304'}: 'Ῡ', {'Υ', '\u0300'}: 'Ὺ', {'Ρ', '\u0314'}: 'Ῥ',
+	{'¨', '\u0300'}: '῭', {'ὼ', '\u0345'}: 'ῲ', {'ω', '\u0345'}: 'ῳ', {'ώ', '\u0345'}: 'ῴ',
+	{'ω', '\u0342'}: 'ῶ', {'ῶ', '\u0345'}: 'ῷ', {'Ο', '\u0300'}: 'Ὸ', {'Ω', '\u0300'}: 'Ὼ',
+	{'Ω', '\u0345'}: 'ῼ',
+}
@@ -0,0 +1,42 @@
+// Package notify sends messages to people - email, chat, SMS... The domain
+// only depends on the Notifier abstraction.
+package notify
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+)
+
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// Notifier Abstraction - delivers a message to a recipient
+type Notifier interface {
+	Notify(ctx context.Context, msg Message) error
+}
+
+// Console Low-level module - prints messages, handy for demos
+type Console struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+func NewConsole(w io.Writer) *Console {
+	if w == nil {
+		w = os.Stdout
+	}
+	return &Console{w: w}
+}
+
+func (c *Console) Notify(_ context.Context, msg Message) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	_, err := fmt.Fprintf(c.w, "✉️  to %s: %s - %s\n", msg.To, msg.Subject, msg.Body)
+	return err
+}
@@ -0,0 +1,40 @@
+// Package nullobj provides "do nothing" implementations of the project's
+// infrastructure abstractions.
+//
+// A Null Object is a legitimate substitute (LSP): it honours the contract of
+// its interface by successfully doing nothing. Constructors use them as safe
+// defaults, so optional collaborators never need nil checks.
+package nullobj
+
+import (
+	"context"
+	"log/slog"
+
+	"go-solid/audit"
+	"go-solid/events"
+	"go-solid/notify"
+)
+
+// NopAuditSink accepts and drops every audit record
+type NopAuditSink struct{}
+
+func (NopAuditSink) Write(context.Context, audit.Record) error { return nil }
+
+// NopDispatcher accepts and drops every domain event
+type NopDispatcher struct{}
+
+func (NopDispatcher) Dispatch(context.Context, ...events.Event) error { return nil }
+
+// NopNotifier accepts and drops every message
+type NopNotifier struct{}
+
+func (NopNotifier) Notify(context.Context, notify.Message) error { return nil }
+
+// NopLogger returns a logger that discards everything.
+func NopLogger() *slog.Logger { return slog.New(slog.DiscardHandler) }
+
+var (
+	_ audit.Sink        = NopAuditSink{}
+	_ events.Dispatcher = NopDispatcher{}
+	_ notify.Notifier   = NopNotifier{}
+)
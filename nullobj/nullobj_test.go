@@ -0,0 +1,125 @@
+package nullobj_test
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"sync"
+	"testing"
+	"time"
+
+	"go-solid/audit"
+	"go-solid/events"
+	"go-solid/notify"
+	"go-solid/nullobj"
+)
+
+// The contracts below are what callers of each abstraction rely on. Every
+// null object has to keep them like the real implementations it stands in
+// for, which it does trivially: succeeding at doing nothing.
+
+// sinkContract Any number of records, written concurrently, are accepted
+func sinkContract(t *testing.T, s audit.Sink) {
+	t.Helper()
+	var wg sync.WaitGroup
+	errs := make([]error, 20)
+	for i := range errs {
+		wg.Go(func() {
+			errs[i] = s.Write(t.Context(), audit.Record{Time: time.Now(), Action: "hire", Entity: "employee", EntityID: "emp-1", Outcome: audit.Success})
+		})
+	}
+	wg.Wait()
+	for i, err := range errs {
+		if err != nil {
+			t.Errorf("Write() #%d error = %v", i+1, err)
+		}
+	}
+}
+
+func TestAuditSink_Contract(t *testing.T) {
+	tests := []struct {
+		name string
+		sink audit.Sink
+	}{
+		{"audit.Memory", &audit.Memory{}},
+		{"audit.WriterSink", audit.NewWriterSink(io.Discard)},
+		{"nullobj.NopAuditSink", nullobj.NopAuditSink{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) { sinkContract(t, tt.sink) })
+	}
+}
+
+type hired struct{}
+
+func (hired) EventName() string { return "employee.hired" }
+
+// dispatcherContract Dispatching nothing, one event or several succeeds
+// when no handler fails
+func dispatcherContract(t *testing.T, d events.Dispatcher) {
+	t.Helper()
+	for _, evts := range [][]events.Event{nil, {hired{}}, {hired{}, hired{}}} {
+		if err := d.Dispatch(t.Context(), evts...); err != nil {
+			t.Errorf("Dispatch(%d events) error = %v", len(evts), err)
+		}
+	}
+}
+
+func TestDispatcher_Contract(t *testing.T) {
+	bus := events.NewBus()
+	bus.SubscribeAll(events.HandlerFunc(func(context.Context, events.Event) error { return nil }))
+	tests := []struct {
+		name       string
+		dispatcher events.Dispatcher
+	}{
+		{"events.Bus without handlers", events.NewBus()},
+		{"events.Bus with a handler", bus},
+		{"nullobj.NopDispatcher", nullobj.NopDispatcher{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) { dispatcherContract(t, tt.dispatcher) })
+	}
+}
+
+// notifierContract A message to a recipient is delivered without error,
+// from several goroutines at once
+func notifierContract(t *testing.T, n notify.Notifier) {
+	t.Helper()
+	var wg sync.WaitGroup
+	errs := make([]error, 10)
+	for i := range errs {
+		wg.Go(func() {
+			errs[i] = n.Notify(t.Context(), notify.Message{To: "ali@example.com", Subject: "Welcome", Body: "Glad to have you"})
+		})
+	}
+	wg.Wait()
+	for i, err := range errs {
+		if err != nil {
+			t.Errorf("Notify() #%d error = %v", i+1, err)
+		}
+	}
+}
+
+func TestNotifier_Contract(t *testing.T) {
+	tests := []struct {
+		name     string
+		notifier notify.Notifier
+	}{
+		{"notify.Console", notify.NewConsole(io.Discard)},
+		{"nullobj.NopNotifier", nullobj.NopNotifier{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) { notifierContract(t, tt.notifier) })
+	}
+}
+
+func TestNopLogger(t *testing.T) {
+	logger := nullobj.NopLogger()
+	for _, level := range []slog.Level{slog.LevelDebug, slog.LevelInfo, slog.LevelWarn, slog.LevelError} {
+		if logger.Enabled(t.Context(), level) {
+			t.Errorf("Enabled(%v) = true, want everything discarded", level)
+		}
+		logger.Log(t.Context(), level, "hired", "name", "Ali")
+	}
+	logger.With("request", 1).WithGroup("employee").Info("hired", "name", "Ali")
+}
@@ -0,0 +1,185 @@
+package org
+
+import (
+	"fmt"
+	"iter"
+	"maps"
+	"slices"
+)
+
+// Chart The hierarchy formed by a set of teams, built once and queried many
+// times. A Chart is a snapshot: it doesn't see teams saved after it was
+// built.
+type Chart struct {
+	teams    map[string]Team
+	subteams map[string][]string // by parent ID, "" for the top-level teams
+	memberOf map[string]string   // employee -> team ID
+	manages  map[string][]string // employee -> IDs of the teams they manage
+}
+
+// NewChart checks that teams form a hierarchy and builds its Chart: every
+// parent exists, no team is part of itself, nobody is on two teams or on a
+// team they manage, and nobody ends up managing themselves.
+func NewChart(teams []Team) (*Chart, error) {
+	c := &Chart{
+		teams:    map[string]Team{},
+		subteams: map[string][]string{},
+		memberOf: map[string]string{},
+		manages:  map[string][]string{},
+	}
+	for _, t := range teams {
+		c.teams[t.ID] = t
+	}
+	ids := slices.Sorted(maps.Keys(c.teams))
+	for _, id := range ids {
+		t := c.teams[id]
+		if _, ok := c.teams[t.Parent]; t.Parent != "" && !ok {
+			return nil, fmt.Errorf("team %s: parent %s: %w", t.ID, t.Parent, ErrTeamNotFound)
+		}
+		c.subteams[t.Parent] = append(c.subteams[t.Parent], t.ID)
+		if t.Manager != "" {
+			c.manages[t.Manager] = append(c.manages[t.Manager], t.ID)
+		}
+		for _, name := range t.Members {
+			if name == t.Manager {
+				return nil, fmt.Errorf("team %s: %s manages the team and is on it: %w", t.ID, name, ErrCycle)
+			}
+			if other, ok := c.memberOf[name]; ok {
+				return nil, fmt.Errorf("team %s: %s is on team %s: %w", t.ID, name, other, ErrOnAnotherTeam)
+			}
+			c.memberOf[name] = t.ID
+		}
+	}
+	for _, id := range ids {
+		seen := map[string]bool{}
+		for at := id; at != ""; at = c.teams[at].Parent {
+			if seen[at] {
+				return nil, fmt.Errorf("team %s is part of itself: %w", id, ErrCycle)
+			}
+			seen[at] = true
+		}
+	}
+	for name := range c.manages {
+		seen := map[string]bool{name: true}
+		for boss, ok := c.ManagerOf(name); ok; boss, ok = c.ManagerOf(boss) {
+			if seen[boss] {
+				return nil, fmt.Errorf("%s reports to themselves: %w", name, ErrCycle)
+			}
+			seen[boss] = true
+		}
+	}
+	return c, nil
+}
+
+// Team returns the team with the ID.
+func (c *Chart) Team(id string) (Team, bool) {
+	t, ok := c.teams[id]
+	return t, ok
+}
+
+// Subteams returns the teams directly part of the team with the ID, by ID;
+// "" returns the top-level teams.
+func (c *Chart) Subteams(id string) []Team {
+	var teams []Team
+	for _, sub := range c.subteams[id] {
+		teams = append(teams, c.teams[sub])
+	}
+	return teams
+}
+
+// Walk yields the team with the ID and every team part of it, depth first,
+// with their depth below it; "" walks the whole chart from the top-level
+// teams, at depth 0.
+func (c *Chart) Walk(id string) iter.Seq2[int, Team] {
+	return func(yield func(int, Team) bool) {
+		var walk func(id string, depth int) bool
+		walk = func(id string, depth int) bool {
+			if !yield(depth, c.teams[id]) {
+				return false
+			}
+			for _, sub := range c.subteams[id] {
+				if !walk(sub, depth+1) {
+					return false
+				}
+			}
+			return true
+		}
+		if id != "" {
+			if _, ok := c.teams[id]; ok {
+				walk(id, 0)
+			}
+			return
+		}
+		for _, top := range c.subteams[""] {
+			if !walk(top, 0) {
+				return
+			}
+		}
+	}
+}
+
+// TeamOf returns the team name is a member of.
+func (c *Chart) TeamOf(name string) (Team, bool) {
+	id, ok := c.memberOf[name]
+	if !ok {
+		return Team{}, false
+	}
+	return c.teams[id], true
+}
+
+// ManagerOf returns the manager name reports to: their team's manager or,
+// for a manager on no team, the manager of the team above the one they
+// manage.
+func (c *Chart) ManagerOf(name string) (string, bool) {
+	if t, ok := c.TeamOf(name); ok {
+		return t.Manager, t.Manager != ""
+	}
+	for _, id := range c.manages[name] {
+		if parent, ok := c.teams[c.teams[id].Parent]; ok && parent.Manager != "" {
+			return parent.Manager, true
+		}
+	}
+	return "", false
+}
+
+// ChainOfCommand returns the managers above name, nearest first.
+func (c *Chart) ChainOfCommand(name string) []string {
+	var chain []string
+	for boss, ok := c.ManagerOf(name); ok; boss, ok = c.ManagerOf(boss) {
+		chain = append(chain, boss)
+	}
+	return chain
+}
+
+// Reports returns everyone manager is above, directly or not, by name.
+func (c *Chart) Reports(manager string) []string {
+	seen := map[string]bool{}
+	for _, id := range c.manages[manager] {
+		for _, t := range c.Walk(id) {
+			if t.Manager != manager && t.Manager != "" {
+				seen[t.Manager] = true
+			}
+			for _, name := range t.Members {
+				seen[name] = true
+			}
+		}
+	}
+	delete(seen, manager)
+	return slices.Sorted(maps.Keys(seen))
+}
+
+// CommonManager returns the nearest employee both a and b report to,
+// directly or not, counting each as above themselves: a, when b reports
+// to a.
+func (c *Chart) CommonManager(a, b string) (string, bool) {
+	above := map[string]bool{b: true}
+	for _, boss := range c.ChainOfCommand(b) {
+		above[boss] = true
+	}
+	for _, boss := range append([]string{a}, c.ChainOfCommand(a)...) {
+		if above[boss] {
+			return boss, true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,159 @@
+package org
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+
+	"go-solid/employee"
+	"go-solid/id"
+)
+
+// People The one thing the Directory needs from employee storage: whether
+// an employee exists. Any employee.Repository will do.
+type People interface {
+	GetByName(ctx context.Context, name string) (employee.Employee, error)
+}
+
+// Directory Use cases that change the org chart. Every change is checked
+// against the whole chart before it is stored, so the teams in the
+// repository always form a hierarchy.
+type Directory struct {
+	teams  TeamRepository
+	people People
+	ids    id.Generator
+	mu     sync.Mutex // one change at a time, so checks see the latest chart
+}
+
+// DirectoryOption customises a Directory created by NewDirectory
+type DirectoryOption func(*Directory)
+
+func WithTeamIDs(g id.Generator) DirectoryOption { return func(d *Directory) { d.ids = g } }
+
+func NewDirectory(teams TeamRepository, people People, opts ...DirectoryOption) *Directory {
+	d := &Directory{teams: teams, people: people, ids: id.UUID{}}
+	for _, opt := range opts {
+		opt(d)
+	}
+	return d
+}
+
+// Chart builds the org chart from every stored team.
+func (d *Directory) Chart(ctx context.Context) (*Chart, error) {
+	teams, err := d.teams.All(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return NewChart(teams)
+}
+
+// CreateTeam creates a team run by manager, part of the team with the
+// parent ID ("" for a top-level team).
+func (d *Directory) CreateTeam(ctx context.Context, name, manager, parent string) (Team, error) {
+	t := Team{ID: d.ids.NewID(), Name: name, Manager: manager, Parent: parent}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if err := d.store(ctx, t, manager); err != nil {
+		return Team{}, fmt.Errorf("create team %q: %w", name, err)
+	}
+	return t, nil
+}
+
+// AddMember puts the employee on the team. An employee is on one team at a
+// time.
+func (d *Directory) AddMember(ctx context.Context, teamID, name string) (Team, error) {
+	t, err := d.edit(ctx, teamID, name, func(t *Team) error {
+		if !t.Has(name) {
+			t.Members = append(slices.Clone(t.Members), name)
+		}
+		return nil
+	})
+	if err != nil {
+		return Team{}, fmt.Errorf("add %s to team %s: %w", name, teamID, err)
+	}
+	return t, nil
+}
+
+// RemoveMember takes the employee off the team.
+func (d *Directory) RemoveMember(ctx context.Context, teamID, name string) (Team, error) {
+	t, err := d.edit(ctx, teamID, "", func(t *Team) error {
+		i := slices.Index(t.Members, name)
+		if i < 0 {
+			return ErrNotAMember
+		}
+		t.Members = slices.Delete(slices.Clone(t.Members), i, i+1)
+		return nil
+	})
+	if err != nil {
+		return Team{}, fmt.Errorf("remove %s from team %s: %w", name, teamID, err)
+	}
+	return t, nil
+}
+
+// AssignManager has the employee run the team instead of its manager.
+func (d *Directory) AssignManager(ctx context.Context, teamID, manager string) (Team, error) {
+	t, err := d.edit(ctx, teamID, manager, func(t *Team) error {
+		t.Manager = manager
+		return nil
+	})
+	if err != nil {
+		return Team{}, fmt.Errorf("assign %s to team %s: %w", manager, teamID, err)
+	}
+	return t, nil
+}
+
+// Move makes the team, with the teams part of it, part of the team with the
+// parent ID ("" for the top level).
+func (d *Directory) Move(ctx context.Context, teamID, parent string) (Team, error) {
+	t, err := d.edit(ctx, teamID, "", func(t *Team) error {
+		t.Parent = parent
+		return nil
+	})
+	if err != nil {
+		return Team{}, fmt.Errorf("move team %s: %w", teamID, err)
+	}
+	return t, nil
+}
+
+// edit loads the team, applies change and stores the result. person, if
+// any, is an employee the change brings in, who must exist.
+func (d *Directory) edit(ctx context.Context, teamID, person string, change func(t *Team) error) (Team, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	t, err := d.teams.Get(ctx, teamID)
+	if err != nil {
+		return Team{}, err
+	}
+	if err := change(&t); err != nil {
+		return Team{}, err
+	}
+	if err := d.store(ctx, t, person); err != nil {
+		return Team{}, err
+	}
+	return t, nil
+}
+
+// store checks that person exists and that the chart with t in it is still
+// a hierarchy, then saves t.
+func (d *Directory) store(ctx context.Context, t Team, person string) error {
+	if person != "" {
+		if _, err := d.people.GetByName(ctx, person); err != nil {
+			return err
+		}
+	}
+	teams, err := d.teams.All(ctx)
+	if err != nil {
+		return err
+	}
+	i := slices.IndexFunc(teams, func(other Team) bool { return other.ID == t.ID })
+	if i < 0 {
+		teams = append(teams, t)
+	} else {
+		teams[i] = t
+	}
+	if _, err := NewChart(teams); err != nil {
+		return err
+	}
+	return d.teams.Save(ctx, t)
+}
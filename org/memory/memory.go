@@ -0,0 +1,54 @@
+// Package memory is an in-process org.TeamRepository.
+package memory
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"sync"
+
+	"go-solid/org"
+)
+
+// Repository Low-level module - map-backed org.TeamRepository
+type Repository struct {
+	mu   sync.RWMutex
+	byID map[string]org.Team
+}
+
+func New() *Repository {
+	return &Repository{byID: make(map[string]org.Team)}
+}
+
+func (r *Repository) Save(ctx context.Context, team org.Team) error {
+	team.Members = slices.Clone(team.Members)
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.byID[team.ID] = team
+	return nil
+}
+
+func (r *Repository) Get(ctx context.Context, id string) (org.Team, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	team, ok := r.byID[id]
+	if !ok {
+		return org.Team{}, org.ErrTeamNotFound
+	}
+	team.Members = slices.Clone(team.Members)
+	return team, nil
+}
+
+func (r *Repository) All(ctx context.Context) ([]org.Team, error) {
+	r.mu.RLock()
+	teams := make([]org.Team, 0, len(r.byID))
+	for _, team := range r.byID {
+		team.Members = slices.Clone(team.Members)
+		teams = append(teams, team)
+	}
+	r.mu.RUnlock()
+	slices.SortFunc(teams, func(a, b org.Team) int { return cmp.Compare(a.ID, b.ID) })
+	return teams, nil
+}
+
+var _ org.TeamRepository = (*Repository)(nil)
@@ -0,0 +1,54 @@
+// Package org models how employees are organised: teams, the managers who
+// run them, and the org chart the teams form.
+//
+// A Team refers to employees by name rather than holding them, so an
+// employee.Repository stays the one owner of employees and a team is a
+// small aggregate of its own, stored through a TeamRepository. Questions
+// about the whole hierarchy - who reports to whom, the chain of command -
+// are answered by a Chart built from every team, not by the repositories:
+// each adapter only has to store teams, and every adapter gets the same
+// traversals (SRP, OCP).
+package org
+
+import (
+	"context"
+	"errors"
+	"slices"
+)
+
+// Team A manager and the employees on the team, by name. Teams nest: Parent
+// is the ID of the team this one is part of, "" for a top-level team. The
+// manager is not one of the team's Members - they are a member of the
+// parent team, if anything.
+type Team struct {
+	ID      string
+	Name    string
+	Manager string
+	Parent  string
+	Members []string
+}
+
+// Has reports whether name is one of the team's members.
+func (t Team) Has(name string) bool { return slices.Contains(t.Members, name) }
+
+var (
+	// ErrTeamNotFound returned when no team has the ID
+	ErrTeamNotFound = errors.New("team not found")
+	// ErrCycle returned for a change that would make a team part of itself,
+	// or an employee their own manager
+	ErrCycle = errors.New("org chart cycle")
+	// ErrOnAnotherTeam returned when adding an employee who is already a
+	// member of a team
+	ErrOnAnotherTeam = errors.New("employee is on another team")
+	// ErrNotAMember returned when removing an employee the team doesn't have
+	ErrNotAMember = errors.New("employee is not on the team")
+)
+
+// TeamRepository Abstraction over team storage. It stores teams and nothing
+// else: the hierarchy they form is the Chart's business.
+type TeamRepository interface {
+	Save(ctx context.Context, team Team) error
+	Get(ctx context.Context, id string) (Team, error)
+	// All returns every team, by ID.
+	All(ctx context.Context) ([]Team, error)
+}
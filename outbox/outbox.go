@@ -0,0 +1,156 @@
+// Package outbox implements the transactional outbox: a change and the
+// messages announcing it are stored in the same transaction, and a Relay
+// publishes the stored messages to a queue afterwards.
+//
+// Publishing straight after a commit loses the message when the process dies
+// in between; publishing before the commit announces changes that may be
+// rolled back. The outbox avoids both, at the price of at-least-once
+// delivery: a relay that dies after publishing but before marking a message
+// published sends it again. Consumers deduplicate on the message ID (see
+// Idempotent).
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"go-solid/clock"
+	"go-solid/events"
+	"go-solid/id"
+	"go-solid/nullobj"
+	"go-solid/queue"
+)
+
+// Message An event waiting to be published. Topic is the event name and the
+// queue it is published to.
+type Message struct {
+	ID        string
+	Topic     string
+	Key       string
+	Payload   []byte
+	CreatedAt time.Time
+}
+
+// Keyed Optional capability - events that know which aggregate they belong
+// to; the ID becomes the message key.
+type Keyed interface {
+	AggregateID() string
+}
+
+// Encode turns events into outbox messages, payloads as JSON.
+func Encode(ids id.Generator, now time.Time, evts ...events.Event) ([]Message, error) {
+	msgs := make([]Message, 0, len(evts))
+	for _, e := range evts {
+		payload, err := json.Marshal(e)
+		if err != nil {
+			return nil, fmt.Errorf("outbox: encode %s: %w", e.EventName(), err)
+		}
+		msg := Message{ID: ids.NewID(), Topic: e.EventName(), Payload: payload, CreatedAt: now}
+		if k, ok := e.(Keyed); ok {
+			msg.Key = k.AggregateID()
+		}
+		msgs = append(msgs, msg)
+	}
+	return msgs, nil
+}
+
+// Store The relay's view of an outbox. How messages get in is up to the
+// repository that owns the transaction.
+type Store interface {
+	// Pending returns up to limit unpublished messages, oldest first, and
+	// none when limit is zero or less.
+	Pending(ctx context.Context, limit int) ([]Message, error)
+	MarkPublished(ctx context.Context, ids ...string) error
+}
+
+// Relay Publishes pending outbox messages to a queue, in order
+type Relay struct {
+	Store    Store
+	Producer queue.Producer
+	// BatchSize messages are read per round; defaults to 100.
+	BatchSize int
+	// Interval between polls when the outbox is empty; defaults to 1s.
+	Interval time.Duration
+	Clock    clock.Clock
+	Logger   *slog.Logger
+}
+
+// Flush publishes everything pending and returns how many messages went out.
+// It stops at the first failure so messages are never published out of order.
+func (r *Relay) Flush(ctx context.Context) (int, error) {
+	size := r.BatchSize
+	if size <= 0 {
+		size = 100
+	}
+	published := 0
+	for {
+		pending, err := r.Store.Pending(ctx, size)
+		if err != nil {
+			return published, fmt.Errorf("outbox: pending: %w", err)
+		}
+		for _, msg := range pending {
+			qm := queue.Message{ID: msg.ID, Key: msg.Key, Body: msg.Payload, Headers: map[string]string{"event": msg.Topic}}
+			if err := r.Producer.Publish(ctx, msg.Topic, qm); err != nil {
+				return published, fmt.Errorf("outbox: publish %s: %w", msg.ID, err)
+			}
+			// a crash right here publishes msg again on the next run
+			if err := r.Store.MarkPublished(ctx, msg.ID); err != nil {
+				return published, fmt.Errorf("outbox: mark %s: %w", msg.ID, err)
+			}
+			published++
+		}
+		if len(pending) < size {
+			return published, nil
+		}
+	}
+}
+
+// Run flushes every Interval until ctx is done (lifecycle.RunFunc). Failures
+// are logged and retried on the next round.
+func (r *Relay) Run(ctx context.Context) error {
+	clk, logger, interval := r.Clock, r.Logger, r.Interval
+	if clk == nil {
+		clk = clock.Real{}
+	}
+	if logger == nil {
+		logger = nullobj.NopLogger()
+	}
+	if interval <= 0 {
+		interval = time.Second
+	}
+	for {
+		if n, err := r.Flush(ctx); err != nil {
+			logger.WarnContext(ctx, "outbox relay", "published", n, "err", err)
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-clk.After(interval):
+		}
+	}
+}
+
+// Idempotent Decorator remembering the IDs of handled messages, so a message
+// published twice is only handled once. The memory is in-process; a consumer
+// with side effects elsewhere should record the ID in the same transaction as
+// its own change. Messages are handled one at a time.
+func Idempotent(next queue.Handler) queue.Handler {
+	var mu sync.Mutex
+	seen := make(map[string]bool)
+	return queue.HandlerFunc(func(ctx context.Context, msg queue.Message) error {
+		mu.Lock()
+		defer mu.Unlock()
+		if seen[msg.ID] {
+			return nil
+		}
+		if err := next.Handle(ctx, msg); err != nil {
+			return err
+		}
+		seen[msg.ID] = true
+		return nil
+	})
+}
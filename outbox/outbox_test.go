@@ -0,0 +1,263 @@
+package outbox_test
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"path/filepath"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	_ "modernc.org/sqlite"
+
+	"go-solid/clock"
+	"go-solid/employee"
+	"go-solid/employee/sqlrepo"
+	"go-solid/id"
+	"go-solid/money"
+	"go-solid/outbox"
+	"go-solid/queue"
+	"go-solid/sqldialect"
+)
+
+var monday = time.Date(2026, time.March, 2, 9, 0, 0, 0, time.UTC)
+
+// store An in-memory outbox whose MarkPublished can fail
+type store struct {
+	mu       sync.Mutex
+	msgs     []outbox.Message
+	sent     map[string]bool
+	markFail error
+}
+
+func (s *store) add(ids ...string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, id := range ids {
+		s.msgs = append(s.msgs, outbox.Message{ID: id, Topic: "employee.hired", Key: "emp-" + id})
+	}
+}
+
+func (s *store) Pending(_ context.Context, limit int) ([]outbox.Message, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var pending []outbox.Message
+	for _, m := range s.msgs {
+		if !s.sent[m.ID] && len(pending) < limit {
+			pending = append(pending, m)
+		}
+	}
+	return pending, nil
+}
+
+func (s *store) MarkPublished(_ context.Context, ids ...string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.markFail != nil {
+		return s.markFail
+	}
+	if s.sent == nil {
+		s.sent = make(map[string]bool)
+	}
+	for _, id := range ids {
+		s.sent[id] = true
+	}
+	return nil
+}
+
+// producer Records what is published and refuses FailOn
+type producer struct {
+	mu     sync.Mutex
+	got    []string
+	sent   []string // IDs only
+	FailOn string
+}
+
+func (p *producer) Publish(_ context.Context, name string, msgs ...queue.Message) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for _, m := range msgs {
+		if m.ID == p.FailOn {
+			return errors.New("broker down")
+		}
+		p.got = append(p.got, name+"/"+m.Key+"/"+m.ID+"/"+m.Headers["event"])
+		p.sent = append(p.sent, m.ID)
+	}
+	return nil
+}
+
+func (p *producer) published() []string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return slices.Clone(p.got)
+}
+
+func (p *producer) ids() []string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return slices.Clone(p.sent)
+}
+
+func TestEncode(t *testing.T) {
+	hired := employee.Hired{EmployeeID: "emp-1", Name: "Ali", Salary: money.Of(5000, money.USD), At: monday}
+	msgs, err := outbox.Encode(id.NewSequence("msg-"), monday, hired, unkeyed{})
+	if err != nil {
+		t.Fatalf("Encode() error = %v", err)
+	}
+	if len(msgs) != 2 {
+		t.Fatalf("Encode() = %d messages, want 2", len(msgs))
+	}
+	if m := msgs[0]; m.ID != "msg-1" || m.Topic != "employee.hired" || m.Key != "emp-1" || !m.CreatedAt.Equal(monday) {
+		t.Errorf("Encode(Hired) = %+v, want msg-1 on employee.hired keyed by emp-1", m)
+	}
+	var back employee.Hired
+	if err := json.Unmarshal(msgs[0].Payload, &back); err != nil || back.Name != "Ali" || back.Salary != hired.Salary {
+		t.Errorf("payload %s decodes to %+v, %v; want the event", msgs[0].Payload, back, err)
+	}
+	if m := msgs[1]; m.ID != "msg-2" || m.Key != "" || string(m.Payload) != `{"Note":""}` {
+		t.Errorf("Encode(unkeyed) = %+v, want msg-2 without a key", m)
+	}
+	if _, err := outbox.Encode(id.NewSequence("msg-"), monday, unencodable{}); err == nil {
+		t.Error("Encode() of an event JSON can't encode: error = nil")
+	}
+}
+
+type unkeyed struct{ Note string }
+
+func (unkeyed) EventName() string { return "note" }
+
+type unencodable struct{ C chan int }
+
+func (unencodable) EventName() string { return "bad" }
+
+func TestRelay_Flush(t *testing.T) {
+	s, p := &store{}, &producer{}
+	s.add("1", "2", "3", "4", "5")
+	relay := &outbox.Relay{Store: s, Producer: p, BatchSize: 2}
+	n, err := relay.Flush(t.Context())
+	if n != 5 || err != nil {
+		t.Fatalf("Flush() = %d, %v, want all 5 over three batches", n, err)
+	}
+	if got := p.published(); len(got) != 5 || got[0] != "employee.hired/emp-1/1/employee.hired" {
+		t.Errorf("published %q, want every message on its topic, keyed, with its event header", got)
+	}
+	if n, err := relay.Flush(t.Context()); n != 0 || err != nil {
+		t.Errorf("Flush() again = %d, %v, want nothing left", n, err)
+	}
+}
+
+func TestRelay_StopsAtTheFirstFailure(t *testing.T) {
+	s, p := &store{}, &producer{FailOn: "2"}
+	s.add("1", "2", "3")
+	relay := &outbox.Relay{Store: s, Producer: p}
+	if n, err := relay.Flush(t.Context()); n != 1 || err == nil {
+		t.Fatalf("Flush() = %d, %v, want 1 and the broker's error", n, err)
+	}
+	p.FailOn = ""
+	if n, err := relay.Flush(t.Context()); n != 2 || err != nil {
+		t.Fatalf("Flush() after recovery = %d, %v, want the other 2", n, err)
+	}
+	if got := p.ids(); !slices.Equal(got, []string{"1", "2", "3"}) {
+		t.Errorf("published %v, want each once, in order", got)
+	}
+}
+
+// a relay that can't mark what it published sends it again: at least once
+func TestRelay_AtLeastOnce(t *testing.T) {
+	s, p := &store{markFail: errors.New("database gone")}, &producer{}
+	s.add("1")
+	relay := &outbox.Relay{Store: s, Producer: p}
+	if n, err := relay.Flush(t.Context()); n != 0 || err == nil {
+		t.Fatalf("Flush() = %d, %v, want the mark error", n, err)
+	}
+	s.markFail = nil
+	if n, err := relay.Flush(t.Context()); n != 1 || err != nil {
+		t.Fatalf("Flush() = %d, %v", n, err)
+	}
+	if got := p.ids(); !slices.Equal(got, []string{"1", "1"}) {
+		t.Errorf("published %v, want message 1 twice", got)
+	}
+}
+
+func TestRelay_Run(t *testing.T) {
+	s, p := &store{}, &producer{}
+	s.add("1")
+	clk := clock.NewFake(monday)
+	relay := &outbox.Relay{Store: s, Producer: p, Interval: time.Minute, Clock: clk}
+	ctx, cancel := context.WithCancel(t.Context())
+	done := make(chan error)
+	go func() { done <- relay.Run(ctx) }()
+
+	clk.BlockUntil(1) // flushed once, waiting for the next round
+	s.add("2")
+	if got := p.ids(); !slices.Equal(got, []string{"1"}) {
+		t.Errorf("published %v before the interval, want [1]", got)
+	}
+	clk.Advance(time.Minute)
+	clk.BlockUntil(1)
+	if got := p.ids(); !slices.Equal(got, []string{"1", "2"}) {
+		t.Errorf("published %v after the interval, want [1 2]", got)
+	}
+	cancel()
+	if err := <-done; err != nil {
+		t.Errorf("Run() = %v, want nil once ctx is done", err)
+	}
+}
+
+func TestIdempotent(t *testing.T) {
+	var handled []string
+	fail := true
+	h := outbox.Idempotent(queue.HandlerFunc(func(_ context.Context, msg queue.Message) error {
+		if msg.ID == "2" && fail {
+			fail = false
+			return errors.New("try again")
+		}
+		handled = append(handled, msg.ID)
+		return nil
+	}))
+	for _, id := range []string{"1", "1", "2", "2", "2", "1"} {
+		_ = h.Handle(t.Context(), queue.Message{ID: id})
+	}
+	if !slices.Equal(handled, []string{"1", "2"}) {
+		t.Errorf("handled %v, want each once, a failed message again", handled)
+	}
+}
+
+// the relay over the employee repository's outbox table
+func TestRelay_SQL(t *testing.T) {
+	db, err := sql.Open("sqlite", filepath.Join(t.TempDir(), "outbox.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	for _, ddl := range []string{sqlrepo.Schema, sqlrepo.OutboxSchema} {
+		if _, err := db.ExecContext(t.Context(), ddl); err != nil {
+			t.Fatal(err)
+		}
+	}
+	repo := sqlrepo.New(db, sqldialect.SQLite{})
+	ali := employee.Employee{Name: "Ali", Title: "Engineer", Salary: money.Of(5000, money.USD), HiredAt: monday}
+	hired := employee.Hired{EmployeeID: "emp-1", Name: "Ali", Salary: ali.Salary, At: monday}
+	msgs, err := outbox.Encode(id.NewSequence("msg-"), monday, hired, hired)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := repo.SaveWithOutbox(t.Context(), ali, msgs); err != nil {
+		t.Fatalf("SaveWithOutbox() error = %v", err)
+	}
+
+	p := &producer{}
+	relay := &outbox.Relay{Store: repo, Producer: p, BatchSize: 1}
+	if n, err := relay.Flush(t.Context()); n != 2 || err != nil {
+		t.Fatalf("Flush() = %d, %v, want both messages", n, err)
+	}
+	if want := []string{"employee.hired/emp-1/msg-1/employee.hired", "employee.hired/emp-1/msg-2/employee.hired"}; !slices.Equal(p.published(), want) {
+		t.Errorf("published %q, want %q", p.published(), want)
+	}
+	if pending, err := repo.Pending(t.Context(), 10); len(pending) != 0 || err != nil {
+		t.Errorf("Pending() = %v, %v, want everything marked published", pending, err)
+	}
+}
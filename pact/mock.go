@@ -0,0 +1,99 @@
+package pact
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"sync"
+)
+
+// MockProvider Stands in for the provider in a consumer's tests. Each request
+// is answered with the response of the first declared interaction it
+// matches - same method, path and, if one was declared, JSON body - and the
+// interaction is recorded as made. Anything else is answered 500 and
+// recorded as unexpected.
+type MockProvider struct {
+	consumer, provider string
+
+	mu         sync.Mutex
+	declared   []Interaction
+	made       []bool
+	unexpected []string
+}
+
+func NewMockProvider(consumer, provider string) *MockProvider {
+	return &MockProvider{consumer: consumer, provider: provider}
+}
+
+// Expect declares an interaction the consumer is about to make.
+func (m *MockProvider) Expect(i Interaction) *MockProvider {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.declared = append(m.declared, i)
+	m.made = append(m.made, false)
+	return m
+}
+
+func (m *MockProvider) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for i, x := range m.declared {
+		if x.Request.Method != r.Method || x.Request.Path != r.URL.RequestURI() || !sameJSON(x.Request.Body, body) {
+			continue
+		}
+		m.made[i] = true
+		if x.Response.ContentType != "" {
+			w.Header().Set("Content-Type", x.Response.ContentType)
+		}
+		w.WriteHeader(x.Response.Status)
+		_, _ = w.Write(x.Response.Body)
+		return
+	}
+	req := fmt.Sprintf("%s %s", r.Method, r.URL.RequestURI())
+	if len(body) > 0 {
+		req += " " + string(body)
+	}
+	m.unexpected = append(m.unexpected, req)
+	http.Error(w, "pact: no interaction declared for "+req, http.StatusInternalServerError)
+}
+
+// Contract returns what the consumer relied on. It fails if the consumer
+// made a request nobody declared, or declared an interaction it never made:
+// a contract must hold what the consumer does, no more and no less.
+func (m *MockProvider) Contract() (Contract, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var errs []error
+	for _, req := range m.unexpected {
+		errs = append(errs, fmt.Errorf("pact: %s made the undeclared request %s", m.consumer, req))
+	}
+	for i, x := range m.declared {
+		if !m.made[i] {
+			errs = append(errs, fmt.Errorf("pact: %s never made %q", m.consumer, x.Description))
+		}
+	}
+	if err := errors.Join(errs...); err != nil {
+		return Contract{}, err
+	}
+	return Contract{Consumer: m.consumer, Provider: m.provider, Interactions: append([]Interaction(nil), m.declared...)}, nil
+}
+
+// sameJSON reports whether got is the JSON declared, or anything when no
+// body was declared.
+func sameJSON(declared json.RawMessage, got []byte) bool {
+	if len(bytes.TrimSpace(declared)) == 0 {
+		return true
+	}
+	var want, have any
+	if json.Unmarshal(declared, &want) != nil || json.Unmarshal(got, &have) != nil {
+		return false
+	}
+	return reflect.DeepEqual(want, have)
+}
+
+var _ http.Handler = (*MockProvider)(nil)
@@ -0,0 +1,188 @@
+// Package pact checks an HTTP API against what its consumers expect of it,
+// as consumer-driven contracts.
+//
+// A consumer's tests talk to a MockProvider rather than the real API. It
+// answers with the responses the consumer declared, and records the
+// interactions the consumer actually made as a Contract, which is published
+// to a ContractStore. The provider's tests then replay every contract
+// published for it against the real handler:
+//
+//	pact.VerifyT(t, store, "employee-api", func(ctx context.Context, state string) (http.Handler, error) {
+//		return httpapi.New(seeded(state)), nil
+//	})
+//
+// A contract pins only what its consumer reads. A response may carry fields
+// no consumer asked for, so a provider is free to add them, but removing or
+// renaming one a consumer reads breaks that consumer's contract - and the
+// failures name who it breaks. This is the LSP at a service boundary: any
+// provider that keeps every published contract can stand in for the one the
+// consumers were written against.
+package pact
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// Request What the consumer sends
+type Request struct {
+	Method string `json:"method"`
+	// Path includes the query, if any
+	Path string          `json:"path"`
+	Body json.RawMessage `json:"body,omitempty"`
+}
+
+// Response What the consumer expects back. Only what is given is checked:
+// with no body, any body will do, and a body lists the fields the consumer
+// reads - others may come too.
+type Response struct {
+	Status      int             `json:"status"`
+	ContentType string          `json:"content_type,omitempty"`
+	Body        json.RawMessage `json:"body,omitempty"`
+}
+
+// Interaction One request a consumer makes and the response it relies on
+type Interaction struct {
+	Description string `json:"description"`
+	// Given is the provider state the interaction needs, e.g. "Mona is
+	// employed"; the provider is set up in it before the request is replayed
+	Given    string   `json:"given,omitempty"`
+	Request  Request  `json:"request"`
+	Response Response `json:"response"`
+}
+
+// Contract Everything one consumer relies on from one provider
+type Contract struct {
+	Consumer     string        `json:"consumer"`
+	Provider     string        `json:"provider"`
+	Interactions []Interaction `json:"interactions"`
+}
+
+// ContractStore Abstraction - where consumers publish their contracts and
+// providers find them, as a Pact broker would
+type ContractStore interface {
+	// Publish stores c, replacing what its consumer published for its
+	// provider before.
+	Publish(ctx context.Context, c Contract) error
+	// Contracts returns every contract published for provider, ordered by
+	// consumer.
+	Contracts(ctx context.Context, provider string) ([]Contract, error)
+}
+
+// validName keeps consumer and provider names usable as file names.
+func validName(name string) error {
+	if name == "" || !filepath.IsLocal(name) || strings.ContainsAny(name, `/\`) {
+		return fmt.Errorf("pact: invalid name %q", name)
+	}
+	return nil
+}
+
+func (c Contract) validate() error {
+	return errors.Join(validName(c.Consumer), validName(c.Provider))
+}
+
+// Memory In-process ContractStore
+type Memory struct {
+	mu        sync.Mutex
+	contracts map[string]map[string]Contract // provider, consumer
+}
+
+func NewMemory() *Memory { return &Memory{contracts: map[string]map[string]Contract{}} }
+
+func (m *Memory) Publish(ctx context.Context, c Contract) error {
+	if err := c.validate(); err != nil {
+		return err
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.contracts[c.Provider] == nil {
+		m.contracts[c.Provider] = map[string]Contract{}
+	}
+	m.contracts[c.Provider][c.Consumer] = c
+	return nil
+}
+
+func (m *Memory) Contracts(ctx context.Context, provider string) ([]Contract, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	byConsumer := m.contracts[provider]
+	contracts := make([]Contract, 0, len(byConsumer))
+	for _, consumer := range slices.Sorted(maps.Keys(byConsumer)) {
+		contracts = append(contracts, byConsumer[consumer])
+	}
+	return contracts, nil
+}
+
+// Dir ContractStore in a directory, one JSON file per contract at
+// <provider>/<consumer>.json, so contracts can be committed next to the code
+// that relies on them
+type Dir struct {
+	root string
+}
+
+func NewDir(root string) *Dir { return &Dir{root: root} }
+
+func (d *Dir) Publish(ctx context.Context, c Contract) error {
+	if err := c.validate(); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return fmt.Errorf("pact: %w", err)
+	}
+	dir := filepath.Join(d.root, c.Provider)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("pact: %w", err)
+	}
+	// write then rename, so a provider never reads half a contract
+	path := filepath.Join(dir, c.Consumer+".json")
+	if err := os.WriteFile(path+".tmp", append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("pact: %w", err)
+	}
+	return os.Rename(path+".tmp", path)
+}
+
+func (d *Dir) Contracts(ctx context.Context, provider string) ([]Contract, error) {
+	if err := validName(provider); err != nil {
+		return nil, err
+	}
+	dir := filepath.Join(d.root, provider)
+	entries, err := os.ReadDir(dir)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("pact: %w", err)
+	}
+	var contracts []Contract
+	for _, e := range entries { // ReadDir sorts by name, so by consumer
+		if e.IsDir() || filepath.Ext(e.Name()) != ".json" {
+			continue
+		}
+		path := filepath.Join(dir, e.Name())
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("pact: %w", err)
+		}
+		var c Contract
+		if err := json.Unmarshal(data, &c); err != nil {
+			return nil, fmt.Errorf("pact: %s: %w", path, err)
+		}
+		contracts = append(contracts, c)
+	}
+	return contracts, nil
+}
+
+var (
+	_ ContractStore = (*Memory)(nil)
+	_ ContractStore = (*Dir)(nil)
+)
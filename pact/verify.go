@@ -0,0 +1,164 @@
+package pact
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"mime"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// Provider Builds the provider under verification, fresh for every
+// interaction and set up in the state the interaction is given. It returns
+// an error for states it doesn't know.
+type Provider func(ctx context.Context, state string) (http.Handler, error)
+
+// Result What one interaction got from the provider
+type Result struct {
+	Consumer    string
+	Interaction Interaction
+	Status      int
+	Body        string
+	// Failure explains how the response broke the contract; empty when it kept it
+	Failure string
+}
+
+func (r Result) Passed() bool { return r.Failure == "" }
+
+func (r Result) String() string {
+	if r.Passed() {
+		return fmt.Sprintf("%s: %s", r.Consumer, r.Interaction.Description)
+	}
+	return fmt.Sprintf("%s: %s: %s", r.Consumer, r.Interaction.Description, r.Failure)
+}
+
+// Verify replays every interaction of c against a provider built for it, and
+// returns one result per interaction, in order.
+func Verify(ctx context.Context, provider Provider, c Contract) []Result {
+	results := make([]Result, 0, len(c.Interactions))
+	for _, x := range c.Interactions {
+		res := Result{Consumer: c.Consumer, Interaction: x}
+		h, err := provider(ctx, x.Given)
+		if err != nil {
+			res.Failure = fmt.Sprintf("provider state %q: %v", x.Given, err)
+			results = append(results, res)
+			continue
+		}
+		req := httptest.NewRequestWithContext(ctx, x.Request.Method, x.Request.Path, bytes.NewReader(x.Request.Body))
+		if len(x.Request.Body) > 0 {
+			req.Header.Set("Content-Type", "application/json")
+		}
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		res.Status, res.Body = rec.Code, strings.TrimSpace(rec.Body.String())
+		res.Failure = kept(x.Response, rec.Code, rec.Header().Get("Content-Type"), rec.Body.Bytes())
+		results = append(results, res)
+	}
+	return results
+}
+
+// VerifyAll verifies every contract store holds for the provider called
+// name, consumer by consumer.
+func VerifyAll(ctx context.Context, store ContractStore, name string, provider Provider) ([]Result, error) {
+	contracts, err := store.Contracts(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	var results []Result
+	for _, c := range contracts {
+		results = append(results, Verify(ctx, provider, c)...)
+	}
+	return results, nil
+}
+
+// VerifyT fails t for every interaction a consumer of name relies on that
+// the provider doesn't keep.
+func VerifyT(t testing.TB, store ContractStore, name string, provider Provider) {
+	t.Helper()
+	results, err := VerifyAll(t.Context(), store, name, provider)
+	if err != nil {
+		t.Fatalf("pact: contracts for %s: %v", name, err)
+	}
+	for _, r := range results {
+		if !r.Passed() {
+			t.Errorf("%s\n   got %d %s", r, r.Status, r.Body)
+		}
+	}
+}
+
+// kept explains how a response differs from what want relies on, or
+// returns "" when it doesn't.
+func kept(want Response, status int, contentType string, body []byte) string {
+	if status != want.Status {
+		return fmt.Sprintf("status %d, want %d", status, want.Status)
+	}
+	if want.ContentType != "" {
+		got, _, _ := mime.ParseMediaType(contentType)
+		if exp, _, _ := mime.ParseMediaType(want.ContentType); got != exp {
+			return fmt.Sprintf("content type %q, want %q", contentType, want.ContentType)
+		}
+	}
+	if len(bytes.TrimSpace(want.Body)) == 0 {
+		return ""
+	}
+	var expected, got any
+	if err := json.Unmarshal(want.Body, &expected); err != nil {
+		return fmt.Sprintf("contract body: %v", err)
+	}
+	if err := json.Unmarshal(body, &got); err != nil {
+		return fmt.Sprintf("body is not JSON: %v", err)
+	}
+	return missing("$", expected, got)
+}
+
+// missing finds the first value of want that got lacks or has otherwise.
+// Objects may have keys want doesn't; arrays must have the same length.
+func missing(path string, want, got any) string {
+	switch w := want.(type) {
+	case map[string]any:
+		g, ok := got.(map[string]any)
+		if !ok {
+			return fmt.Sprintf("%s is %s, want an object", path, describe(got))
+		}
+		for _, k := range slices.Sorted(maps.Keys(w)) {
+			v, ok := g[k]
+			if !ok {
+				return fmt.Sprintf("%s.%s is missing", path, k)
+			}
+			if diff := missing(path+"."+k, w[k], v); diff != "" {
+				return diff
+			}
+		}
+		return ""
+	case []any:
+		g, ok := got.([]any)
+		if !ok || len(g) != len(w) {
+			return fmt.Sprintf("%s is %s, want %d items", path, describe(got), len(w))
+		}
+		for i := range w {
+			if diff := missing(fmt.Sprintf("%s[%d]", path, i), w[i], g[i]); diff != "" {
+				return diff
+			}
+		}
+		return ""
+	}
+	if !reflect.DeepEqual(want, got) {
+		return fmt.Sprintf("%s is %s, want %s", path, describe(got), describe(want))
+	}
+	return ""
+}
+
+func describe(v any) string {
+	if a, ok := v.([]any); ok {
+		return fmt.Sprintf("%d items", len(a))
+	}
+	b, _ := json.Marshal(v)
+	return string(b)
+}
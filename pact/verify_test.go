@@ -0,0 +1,58 @@
+package pact_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"go-solid/pact"
+)
+
+// directory A contract pinning one field of one response
+var directory = pact.Contract{Consumer: "directory", Provider: "employee-api", Interactions: []pact.Interaction{{
+	Description: "reads a title",
+	Request:     pact.Request{Method: "GET", Path: "/employees/Mona"},
+	Response:    pact.Response{Status: 200, ContentType: "application/json", Body: json.RawMessage(`{"title":"Engineer"}`)},
+}}}
+
+// answering A provider answering every request with status and body
+func answering(status int, body string) pact.Provider {
+	return func(ctx context.Context, state string) (http.Handler, error) {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(status)
+			_, _ = w.Write([]byte(body))
+		}), nil
+	}
+}
+
+func TestVerify(t *testing.T) {
+	tests := []struct {
+		name     string
+		provider pact.Provider
+		wantFail string // "" when the contract is kept
+	}{
+		{"kept", answering(200, `{"name":"Mona","title":"Engineer"}`), ""},
+		{"extra fields are fine", answering(200, `{"name":"Mona","title":"Engineer","department":"Platform"}`), ""},
+		{"field renamed", answering(200, `{"name":"Mona","job_title":"Engineer"}`), "title"},
+		{"field changed", answering(200, `{"name":"Mona","title":"Lead"}`), "title"},
+		{"status changed", answering(404, `{}`), "status 404"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results := pact.Verify(t.Context(), tt.provider, directory)
+			if len(results) != 1 {
+				t.Fatalf("Verify() = %d results, want 1", len(results))
+			}
+			r := results[0]
+			if tt.wantFail == "" && !r.Passed() {
+				t.Errorf("Verify() failed: %s", r)
+			}
+			if tt.wantFail != "" && (r.Passed() || !strings.Contains(r.Failure, tt.wantFail) || !strings.Contains(r.String(), "directory")) {
+				t.Errorf("Verify() = %s, want a failure of directory's contract about %q", r, tt.wantFail)
+			}
+		})
+	}
+}
@@ -0,0 +1,160 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+)
+
+//////////--------------------Bad Practice--------------------/////////////////////////
+
+// ❌ One giant switch per action: every new state means editing every method
+//type employee struct {
+//	name   string
+//	status string // "candidate", "hired", "on-leave", "terminated"
+//}
+//
+//func (e *employee) startLeave(days int) error {
+//	switch e.status {
+//	case "hired":
+//		e.status = "on-leave"
+//		return nil
+//	case "candidate":
+//		return fmt.Errorf("candidate cannot take leave")
+//	case "on-leave":
+//		return fmt.Errorf("already on leave")
+//	case "terminated":
+//		return fmt.Errorf("terminated employee cannot take leave")
+//	}
+//	return fmt.Errorf("unknown status %q", e.status) // ❌ typos compile fine
+//}
+//
+//func (e *employee) terminate() error {
+//	switch e.status { // ❌ ...and the same switch again, and again
+//	case "hired", "on-leave":
+//		e.status = "terminated"
+//		return nil
+//	}
+//	return fmt.Errorf("cannot terminate from %q", e.status)
+//}
+
+//////////////-----------------------------Good Practice-------------------/////////////////////////////////////////////////////////
+
+var ErrIllegalTransition = errors.New("illegal transition")
+
+// State Each lifecycle state decides for itself which actions it allows
+type State interface {
+	Name() string
+	Hire(e *Employee) error
+	StartLeave(e *Employee, days int) error
+	ReturnFromLeave(e *Employee) error
+	Terminate(e *Employee, reason string) error
+}
+
+// noTransitions Embedded by every state: anything not explicitly allowed is illegal
+type noTransitions struct{ name string }
+
+func (s noTransitions) Name() string { return s.name }
+
+func (s noTransitions) illegal(action string) error {
+	return fmt.Errorf("%w: cannot %s while %s", ErrIllegalTransition, action, s.name)
+}
+
+func (s noTransitions) Hire(*Employee) error              { return s.illegal("hire") }
+func (s noTransitions) StartLeave(*Employee, int) error   { return s.illegal("start leave") }
+func (s noTransitions) ReturnFromLeave(*Employee) error   { return s.illegal("return from leave") }
+func (s noTransitions) Terminate(*Employee, string) error { return s.illegal("terminate") }
+
+type candidate struct{ noTransitions }
+
+// Hire guard: the candidate must have accepted the offer
+func (candidate) Hire(e *Employee) error {
+	if !e.OfferAccepted {
+		return fmt.Errorf("%w: %s has not accepted the offer yet", ErrIllegalTransition, e.Name)
+	}
+	e.setState(hired{noTransitions{"hired"}})
+	return nil
+}
+
+type hired struct{ noTransitions }
+
+// StartLeave guard: enough leave balance left
+func (hired) StartLeave(e *Employee, days int) error {
+	if days <= 0 || days > e.LeaveBalance {
+		return fmt.Errorf("%w: %d days requested, %d available", ErrIllegalTransition, days, e.LeaveBalance)
+	}
+	e.LeaveBalance -= days
+	e.setState(onLeave{noTransitions{"on-leave"}})
+	return nil
+}
+
+func (hired) Terminate(e *Employee, reason string) error {
+	e.setState(terminated{noTransitions{"terminated"}})
+	e.log("reason: " + reason)
+	return nil
+}
+
+type onLeave struct{ noTransitions }
+
+func (onLeave) ReturnFromLeave(e *Employee) error {
+	e.setState(hired{noTransitions{"hired"}})
+	return nil
+}
+
+// terminated Final state - the embedded defaults reject every action
+type terminated struct{ noTransitions }
+
+// Employee Context - delegates every action to its current state
+type Employee struct {
+	Name          string
+	OfferAccepted bool
+	LeaveBalance  int
+	state         State
+	history       []string
+}
+
+func NewCandidate(name string, leaveBalance int) *Employee {
+	return &Employee{Name: name, LeaveBalance: leaveBalance, state: candidate{noTransitions{"candidate"}}}
+}
+
+func (e *Employee) State() string { return e.state.Name() }
+
+func (e *Employee) Hire() error                   { return e.state.Hire(e) }
+func (e *Employee) StartLeave(days int) error     { return e.state.StartLeave(e, days) }
+func (e *Employee) ReturnFromLeave() error        { return e.state.ReturnFromLeave(e) }
+func (e *Employee) Terminate(reason string) error { return e.state.Terminate(e, reason) }
+
+func (e *Employee) setState(s State) {
+	e.log(e.state.Name() + " -> " + s.Name())
+	e.state = s
+}
+
+func (e *Employee) log(entry string) { e.history = append(e.history, entry) }
+
+func main() {
+	alice := NewCandidate("Alice", 20)
+
+	step := func(action string, err error) {
+		if err != nil {
+			fmt.Printf("❌ %-22s %v\n", action, err)
+			return
+		}
+		fmt.Printf("✅ %-22s now %s\n", action, alice.State())
+	}
+
+	step("hire", alice.Hire()) // guard: offer not accepted yet
+	alice.OfferAccepted = true
+	step("hire", alice.Hire())
+	step("start leave (30 days)", alice.StartLeave(30)) // guard: not enough balance
+	step("start leave (10 days)", alice.StartLeave(10))
+	step("terminate", alice.Terminate("restructuring")) // illegal while on leave
+	step("return from leave", alice.ReturnFromLeave())
+	step("terminate", alice.Terminate("restructuring"))
+	step("hire", alice.Hire()) // terminated is final
+
+	fmt.Println("\n📜 History:")
+	for _, h := range alice.history {
+		fmt.Println("  ", h)
+	}
+
+	// Adding a state (e.g. "probation") means adding one type - existing states stay closed for modification.
+}
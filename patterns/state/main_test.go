@@ -0,0 +1,91 @@
+package main
+
+import (
+	"errors"
+	"maps"
+	"slices"
+	"testing"
+)
+
+// in returns an employee in state, reached through legal transitions.
+func in(t *testing.T, state string) *Employee {
+	t.Helper()
+	e := NewCandidate("Alice", 20)
+	e.OfferAccepted = true
+	path := map[string][]func() error{
+		"candidate":  nil,
+		"hired":      {e.Hire},
+		"on-leave":   {e.Hire, func() error { return e.StartLeave(5) }},
+		"terminated": {e.Hire, func() error { return e.Terminate("restructuring") }},
+	}[state]
+	for _, step := range path {
+		if err := step(); err != nil {
+			t.Fatalf("reaching %s: %v", state, err)
+		}
+	}
+	if e.State() != state {
+		t.Fatalf("State() = %s, want %s", e.State(), state)
+	}
+	return e
+}
+
+func TestEmployee_Transitions(t *testing.T) {
+	actions := map[string]func(e *Employee) error{
+		"hire":              (*Employee).Hire,
+		"start leave":       func(e *Employee) error { return e.StartLeave(5) },
+		"return from leave": (*Employee).ReturnFromLeave,
+		"terminate":         func(e *Employee) error { return e.Terminate("restructuring") },
+	}
+	// legal: from state, action -> to state; every other pair is illegal
+	legal := map[[2]string]string{
+		{"candidate", "hire"}:             "hired",
+		{"hired", "start leave"}:          "on-leave",
+		{"hired", "terminate"}:            "terminated",
+		{"on-leave", "return from leave"}: "hired",
+	}
+	for _, from := range []string{"candidate", "hired", "on-leave", "terminated"} {
+		for _, action := range slices.Sorted(maps.Keys(actions)) {
+			t.Run(from+"/"+action, func(t *testing.T) {
+				e := in(t, from)
+				history := len(e.history)
+				err := actions[action](e)
+				if to, ok := legal[[2]string{from, action}]; ok {
+					if err != nil || e.State() != to {
+						t.Errorf("%s while %s = %v, now %s; want %s", action, from, err, e.State(), to)
+					}
+					return
+				}
+				if !errors.Is(err, ErrIllegalTransition) {
+					t.Errorf("%s while %s error = %v, want %v", action, from, err, ErrIllegalTransition)
+				}
+				if e.State() != from || len(e.history) != history {
+					t.Errorf("after an illegal %s the employee is %s with history %v, want it untouched in %s", action, e.State(), e.history, from)
+				}
+			})
+		}
+	}
+}
+
+func TestEmployee_Guards(t *testing.T) {
+	tests := []struct {
+		name   string
+		from   string
+		action func(e *Employee) error
+	}{
+		{"hire without an accepted offer", "candidate", func(e *Employee) error { e.OfferAccepted = false; return e.Hire() }},
+		{"leave beyond the balance", "hired", func(e *Employee) error { return e.StartLeave(21) }},
+		{"leave of no days", "hired", func(e *Employee) error { return e.StartLeave(0) }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := in(t, tt.from)
+			balance := e.LeaveBalance
+			if err := tt.action(e); !errors.Is(err, ErrIllegalTransition) {
+				t.Errorf("error = %v, want %v", err, ErrIllegalTransition)
+			}
+			if e.State() != tt.from || e.LeaveBalance != balance {
+				t.Errorf("employee is %s with %d days left, want %s with %d", e.State(), e.LeaveBalance, tt.from, balance)
+			}
+		})
+	}
+}
@@ -0,0 +1,126 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+//////////--------------------Bad Practice--------------------/////////////////////////
+
+// ❌ Every operation re-discovers the concrete type with a type switch
+//func totalPayroll(staff []any) float64 {
+//	total := 0.0
+//	for _, s := range staff {
+//		switch e := s.(type) {
+//		case FullTime:
+//			total += e.MonthlySalary
+//		case Contractor:
+//			total += e.HourlyRate * e.Hours
+//		case Intern:
+//			total += e.Stipend
+//		// ❌ forget a case here and the compiler won't tell you
+//		}
+//	}
+//	return total
+//}
+//
+//func headcount(staff []any) map[string]int { /* ...the same switch again... */ }
+//func exportCSV(staff []any) string         { /* ...and again... */ }
+
+//////////////-----------------------------Good Practice-------------------/////////////////////////////////////////////////////////
+
+// Visitor One method per element type - the compiler forces every operation to handle every type
+type Visitor interface {
+	VisitFullTime(e FullTime)
+	VisitContractor(e Contractor)
+	VisitIntern(e Intern)
+}
+
+// Element Anything that can be visited
+type Element interface {
+	Accept(v Visitor)
+}
+
+type FullTime struct {
+	Name          string
+	MonthlySalary float64
+}
+
+type Contractor struct {
+	Name       string
+	HourlyRate float64
+	Hours      float64
+}
+
+type Intern struct {
+	Name    string
+	Stipend float64
+}
+
+func (e FullTime) Accept(v Visitor)   { v.VisitFullTime(e) }
+func (e Contractor) Accept(v Visitor) { v.VisitContractor(e) }
+func (e Intern) Accept(v Visitor)     { v.VisitIntern(e) }
+
+// payrollVisitor Operation #1 - total monthly cost
+type payrollVisitor struct{ total float64 }
+
+func (p *payrollVisitor) VisitFullTime(e FullTime)     { p.total += e.MonthlySalary }
+func (p *payrollVisitor) VisitContractor(e Contractor) { p.total += e.HourlyRate * e.Hours }
+func (p *payrollVisitor) VisitIntern(e Intern)         { p.total += e.Stipend }
+
+// headcountVisitor Operation #2 - people per kind
+type headcountVisitor struct{ counts map[string]int }
+
+func (h *headcountVisitor) VisitFullTime(FullTime)     { h.counts["full-time"]++ }
+func (h *headcountVisitor) VisitContractor(Contractor) { h.counts["contractor"]++ }
+func (h *headcountVisitor) VisitIntern(Intern)         { h.counts["intern"]++ }
+
+// csvVisitor Operation #3 - report export
+type csvVisitor struct{ b strings.Builder }
+
+func (c *csvVisitor) VisitFullTime(e FullTime) {
+	fmt.Fprintf(&c.b, "%s,full-time,%.2f\n", e.Name, e.MonthlySalary)
+}
+
+func (c *csvVisitor) VisitContractor(e Contractor) {
+	fmt.Fprintf(&c.b, "%s,contractor,%.2f\n", e.Name, e.HourlyRate*e.Hours)
+}
+
+func (c *csvVisitor) VisitIntern(e Intern) {
+	fmt.Fprintf(&c.b, "%s,intern,%.2f\n", e.Name, e.Stipend)
+}
+
+func visitAll(staff []Element, v Visitor) {
+	for _, e := range staff {
+		e.Accept(v)
+	}
+}
+
+func main() {
+	staff := []Element{
+		FullTime{Name: "Mohamed", MonthlySalary: 5000},
+		FullTime{Name: "Ahmed", MonthlySalary: 6000},
+		Contractor{Name: "Ali", HourlyRate: 120, Hours: 40},
+		Intern{Name: "Charlie", Stipend: 800},
+	}
+
+	payroll := &payrollVisitor{}
+	visitAll(staff, payroll)
+	fmt.Printf("💰 Payroll total: %.2f EUR\n", payroll.total)
+
+	headcount := &headcountVisitor{counts: map[string]int{}}
+	visitAll(staff, headcount)
+	fmt.Printf("👥 Headcount: %d full-time, %d contractors, %d interns\n",
+		headcount.counts["full-time"], headcount.counts["contractor"], headcount.counts["intern"])
+
+	report := &csvVisitor{}
+	report.b.WriteString("name,kind,monthly_cost\n")
+	visitAll(staff, report)
+	fmt.Print("📄 Report:\n" + report.b.String())
+
+	// ⚖️ Visitor and OCP pull in opposite directions:
+	//   ✅ new *operations* (tax report, benefits audit...) are new Visitor types - nothing existing changes
+	//   ❌ a new *element* (e.g. PartTime) adds a method to Visitor and breaks every existing visitor
+	// Use it when the set of types is stable and operations keep growing; prefer plain interface
+	// methods (like 2.OCP's role.getSalary) when types keep growing instead.
+}
@@ -0,0 +1,85 @@
+package payroll
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"sync"
+)
+
+// Runner Runs the payroll of a period. Engine does it one employee at a time;
+// Concurrent computes several payslips at once and returns the same Run.
+type Runner interface {
+	Run(ctx context.Context, period Period, roster Roster) (Run, error)
+}
+
+// Concurrent Runner fanning the payslips of a run out to a bounded number of
+// goroutines. Steps that wait - an exchange rate, a tax service - then wait
+// side by side. The Run is the one the Engine would produce: payslips and
+// errors in roster order, one employee's failure never stopping the others.
+type Concurrent struct {
+	engine  *Engine
+	workers int
+}
+
+// NewConcurrent runs e's pipelines on at most workers goroutines; 0 or less
+// means one per CPU. Steps must be safe to call concurrently.
+func NewConcurrent(e *Engine, workers int) *Concurrent {
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	return &Concurrent{engine: e, workers: workers}
+}
+
+// Run produces the payslips for period. Employees are read from the roster
+// only as fast as workers free up. A roster error stops the reading; payslips
+// already under way are finished and returned with it. Employees are
+// reported to the ProgressReporter in ctx as their payslips complete, which
+// is not roster order.
+func (c *Concurrent) Run(ctx context.Context, period Period, roster Roster) (Run, error) {
+	type job struct {
+		emp  PaidEmployee
+		slip Payslip
+		err  error
+	}
+	var (
+		jobs      []*job // in roster order; each worker fills in its own
+		wg        sync.WaitGroup
+		slots     = make(chan struct{}, c.workers)
+		rosterErr error
+		progress  = track(ctx, period, roster)
+	)
+	for emp, err := range roster.PaidEmployees(ctx) {
+		if err != nil {
+			rosterErr = err
+			break
+		}
+		j := &job{emp: emp}
+		jobs = append(jobs, j)
+		slots <- struct{}{}
+		wg.Go(func() {
+			defer func() { <-slots }()
+			j.slip, j.err = c.engine.Payslip(ctx, period, j.emp)
+			progress.done(j.emp, j.err)
+		})
+	}
+	wg.Wait()
+
+	run := Run{Period: period}
+	for _, j := range jobs {
+		if j.err != nil {
+			run.Errors = append(run.Errors, EmployeeError{EmployeeID: j.emp.EmployeeID(), Name: j.emp.EmployeeName(), Err: j.err})
+			continue
+		}
+		run.Payslips = append(run.Payslips, j.slip)
+	}
+	if rosterErr != nil {
+		return run, fmt.Errorf("payroll %s: %w", period, rosterErr)
+	}
+	return run, nil
+}
+
+var (
+	_ Runner = (*Engine)(nil)
+	_ Runner = (*Concurrent)(nil)
+)
@@ -0,0 +1,59 @@
+package payroll
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go-solid/clock"
+	"go-solid/events"
+	"go-solid/money"
+)
+
+// Paid Domain event raised for every payslip of a run
+type Paid struct {
+	EmployeeID string
+	Name       string
+	Period     Period
+	Gross      money.Money
+	Net        money.Money
+	At         time.Time
+}
+
+func (Paid) EventName() string { return "payroll.paid" }
+
+// AggregateID keys the event by employee (outbox.Keyed).
+func (e Paid) AggregateID() string { return e.EmployeeID }
+
+// Publisher Runner decorator raising a Paid event for each payslip of a run,
+// once the run is over. Neither the Engine nor Concurrent knows events exist;
+// either can be decorated.
+type Publisher struct {
+	next   Runner
+	events events.Dispatcher
+	clock  clock.Clock
+}
+
+func NewPublisher(next Runner, d events.Dispatcher, clock clock.Clock) *Publisher {
+	return &Publisher{next: next, events: d, clock: clock}
+}
+
+// Run runs next and publishes what it paid. Employees left unpaid raise no
+// event. A failed delivery is reported with the Run, which is complete.
+func (p *Publisher) Run(ctx context.Context, period Period, roster Roster) (Run, error) {
+	run, err := p.next.Run(ctx, period, roster)
+	now := p.clock.Now()
+	evts := make([]events.Event, 0, len(run.Payslips))
+	for _, slip := range run.Payslips {
+		evts = append(evts, Paid{EmployeeID: slip.EmployeeID, Name: slip.Name, Period: period, Gross: slip.Gross(), Net: slip.Net(), At: now})
+	}
+	if derr := p.events.Dispatch(ctx, evts...); derr != nil && err == nil {
+		err = fmt.Errorf("payroll %s: paid, but event delivery failed: %w", period, derr)
+	}
+	return run, err
+}
+
+// Wrapped returns the decorated Runner.
+func (p *Publisher) Wrapped() any { return p.next }
+
+var _ Runner = (*Publisher)(nil)
@@ -0,0 +1,292 @@
+// Package payroll runs the monthly payroll: every paid employee goes through
+// an ordered pipeline of Steps (bonus, pension, tax, garnishment...) chosen
+// by country.
+//
+// The engine knows nothing about any particular deduction. A new country is a
+// new pipeline in the configuration and a new rule is a new Step - neither
+// edits the engine (OCP). The engine only asks for what it needs to know
+// about a person, PaidEmployee (ISP, as in 4.ISP).
+package payroll
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"iter"
+	"sync"
+	"time"
+
+	"go-solid/money"
+)
+
+// PaidEmployee What payroll needs from someone it pays - nothing about titles,
+// leave or history
+type PaidEmployee interface {
+	EmployeeID() string
+	EmployeeName() string
+	Country() string
+	MonthlyPay() money.Money
+}
+
+// Roster Source of the people to pay in a run
+type Roster interface {
+	PaidEmployees(ctx context.Context) iter.Seq2[PaidEmployee, error]
+}
+
+// Period A payroll month
+type Period struct {
+	Year  int
+	Month time.Month
+}
+
+func (p Period) String() string { return fmt.Sprintf("%04d-%02d", p.Year, p.Month) }
+
+// Kind says how a line affects the payslip totals.
+type Kind int
+
+const (
+	Earning Kind = iota // adds to taxable pay
+	PreTax              // deducted before tax (pension contributions)
+	Tax
+	PostTax // deducted from what is left (garnishments)
+)
+
+// Line One entry on a payslip. Deductions have negative amounts.
+type Line struct {
+	Step   string
+	Label  string
+	Kind   Kind
+	Amount money.Money
+}
+
+// Payslip Built up by the pipeline, one step at a time
+type Payslip struct {
+	EmployeeID string
+	Name       string
+	Country    string
+	Period     Period
+	Base       money.Money
+	Lines      []Line
+}
+
+// Add appends a line; amounts must be in the payslip's currency, and every
+// total must still fit in a money.Money with the line added.
+func (p *Payslip) Add(step, label string, kind Kind, amount money.Money) error {
+	if amount.Currency() != p.Base.Currency() {
+		return fmt.Errorf("%s: %w: %s line on a %s payslip", step, money.ErrCurrencyMismatch, amount.Currency(), p.Base.Currency())
+	}
+	p.Lines = append(p.Lines, Line{Step: step, Label: label, Kind: kind, Amount: amount})
+	if err := p.check(); err != nil {
+		p.Lines = p.Lines[:len(p.Lines)-1]
+		return fmt.Errorf("%s: %s line: %w", step, label, err)
+	}
+	return nil
+}
+
+// Gross is base pay plus earnings.
+func (p *Payslip) Gross() money.Money { return p.must(p.total(Earning)) }
+
+// Taxable is gross pay less pre-tax deductions.
+func (p *Payslip) Taxable() money.Money { return p.must(p.total(Earning, PreTax)) }
+
+// Net is what is paid out.
+func (p *Payslip) Net() money.Money { return p.must(p.total(Earning, PreTax, Tax, PostTax)) }
+
+// check sums every total. Add checks each line as it goes in and the Engine
+// checks the finished payslip, catching lines a step appended itself, so the
+// totals of a payslip either built can always be read.
+func (p *Payslip) check() error {
+	if _, err := p.total(Earning); err != nil {
+		return fmt.Errorf("gross: %w", err)
+	}
+	if _, err := p.total(Earning, PreTax); err != nil {
+		return fmt.Errorf("taxable: %w", err)
+	}
+	if _, err := p.total(Earning, PreTax, Tax, PostTax); err != nil {
+		return fmt.Errorf("net: %w", err)
+	}
+	return nil
+}
+
+// must is a total that check has passed; one it hasn't is a payslip built
+// around Add and the Engine, and panics rather than pay out a wrong amount.
+func (p *Payslip) must(sum money.Money, err error) money.Money {
+	if err != nil {
+		panic(fmt.Sprintf("payroll: payslip of %s: %v", p.Name, err))
+	}
+	return sum
+}
+
+func (p *Payslip) total(kinds ...Kind) (money.Money, error) {
+	sum := p.Base
+	for _, l := range p.Lines {
+		for _, k := range kinds {
+			if l.Kind == k {
+				var err error
+				if sum, err = sum.Add(l.Amount); err != nil {
+					return money.Money{}, err
+				}
+			}
+		}
+	}
+	return sum, nil
+}
+
+// Step One rule of the pipeline
+type Step interface {
+	Name() string
+	Apply(ctx context.Context, emp PaidEmployee, slip *Payslip) error
+}
+
+// StepFunc What a step does for one employee
+type StepFunc func(ctx context.Context, emp PaidEmployee, slip *Payslip) error
+
+// Binder Optional capability - a step that can do its set-up once, such as
+// parsing its rates, and return a StepFunc with the result bound in. The
+// Engine binds every step that can when it is created, so the per-employee
+// loop neither parses nor allocates for it. Steps without the capability
+// are applied through Apply as before.
+type Binder interface {
+	Bind() (StepFunc, error)
+}
+
+// Pipeline Steps applied in order
+type Pipeline []Step
+
+// Config Pipelines by country code
+type Config map[string]Pipeline
+
+// ErrNoPipeline returned for employees in a country the config doesn't cover
+var ErrNoPipeline = errors.New("no payroll pipeline for country")
+
+// EmployeeError A payslip that could not be produced
+type EmployeeError struct {
+	EmployeeID string
+	Name       string
+	Err        error
+}
+
+func (e EmployeeError) Error() string { return fmt.Sprintf("%s (%s): %v", e.Name, e.EmployeeID, e.Err) }
+func (e EmployeeError) Unwrap() error { return e.Err }
+
+// Run Outcome of a payroll run: every employee has either a payslip or an error
+type Run struct {
+	Period   Period
+	Payslips []Payslip
+	Errors   []EmployeeError
+}
+
+// Err joins the errors of every employee left unpaid, nil if everyone was
+// paid. errors.Is and errors.As look through all of them.
+func (r Run) Err() error {
+	errs := make([]error, len(r.Errors))
+	for i, e := range r.Errors {
+		errs[i] = e
+	}
+	return errors.Join(errs...)
+}
+
+// Totals sums gross and net pay across the run in one currency; payslips in
+// other currencies are converted with p.
+func (r Run) Totals(ctx context.Context, p money.ExchangeRateProvider, to money.Currency) (gross, net money.Money, err error) {
+	var grosses, nets []money.Money
+	for _, slip := range r.Payslips {
+		grosses = append(grosses, slip.Gross())
+		nets = append(nets, slip.Net())
+	}
+	if gross, err = money.Sum(ctx, p, to, grosses...); err != nil {
+		return money.Money{}, money.Money{}, err
+	}
+	if net, err = money.Sum(ctx, p, to, nets...); err != nil {
+		return money.Money{}, money.Money{}, err
+	}
+	return gross, net, nil
+}
+
+// Engine Applies the configured pipeline to every employee of a roster
+type Engine struct {
+	pipelines map[string][]bound
+}
+
+// bound A step ready to apply: bound once, or its Apply
+type bound struct {
+	name  string
+	apply StepFunc
+}
+
+// New binds every step of cfg that is a Binder. A step that fails to bind
+// is applied through Apply, so its error is reported per employee, as it
+// would have been without binding.
+func New(cfg Config) *Engine {
+	e := &Engine{pipelines: make(map[string][]bound, len(cfg))}
+	for country, pipeline := range cfg {
+		steps := make([]bound, len(pipeline))
+		for i, step := range pipeline {
+			steps[i] = bound{name: step.Name(), apply: step.Apply}
+			if b, ok := step.(Binder); ok {
+				if fn, err := b.Bind(); err == nil {
+					steps[i].apply = fn
+				}
+			}
+		}
+		e.pipelines[country] = steps
+	}
+	return e
+}
+
+// Run produces the payslips for period. One employee's failure doesn't stop
+// the run; the error is non-nil only if the roster itself failed. Progress
+// goes to the ProgressReporter in ctx, if there is one.
+func (e *Engine) Run(ctx context.Context, period Period, roster Roster) (Run, error) {
+	run := Run{Period: period}
+	progress := track(ctx, period, roster)
+	for emp, err := range roster.PaidEmployees(ctx) {
+		if err != nil {
+			return run, fmt.Errorf("payroll %s: %w", period, err)
+		}
+		slip, err := e.Payslip(ctx, period, emp)
+		progress.done(emp, err)
+		if err != nil {
+			run.Errors = append(run.Errors, EmployeeError{EmployeeID: emp.EmployeeID(), Name: emp.EmployeeName(), Err: err})
+			continue
+		}
+		run.Payslips = append(run.Payslips, slip)
+	}
+	return run, nil
+}
+
+// Payslip runs one employee through their country's pipeline, for callers
+// that distribute the work themselves (see examples/asyncpayroll).
+func (e *Engine) Payslip(ctx context.Context, period Period, emp PaidEmployee) (Payslip, error) {
+	pipeline, ok := e.pipelines[emp.Country()]
+	if !ok {
+		return Payslip{}, fmt.Errorf("%w %q", ErrNoPipeline, emp.Country())
+	}
+	// Steps get a pointer they could keep, so the payslip being built would
+	// escape to the heap for every employee; a pooled one is reused instead
+	// and copied out. Its Lines go with the copy.
+	slip := scratch.Get().(*Payslip)
+	defer func() {
+		*slip = Payslip{}
+		scratch.Put(slip)
+	}()
+	*slip = Payslip{
+		EmployeeID: emp.EmployeeID(),
+		Name:       emp.EmployeeName(),
+		Country:    emp.Country(),
+		Period:     period,
+		Base:       emp.MonthlyPay(),
+		Lines:      make([]Line, 0, len(pipeline)), // most steps add one line
+	}
+	for _, step := range pipeline {
+		if err := step.apply(ctx, emp, slip); err != nil {
+			return Payslip{}, fmt.Errorf("step %s: %w", step.name, err)
+		}
+	}
+	if err := slip.check(); err != nil {
+		return Payslip{}, err
+	}
+	return *slip, nil
+}
+
+var scratch = sync.Pool{New: func() any { return new(Payslip) }}
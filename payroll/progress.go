@@ -0,0 +1,111 @@
+package payroll
+
+import (
+	"context"
+	"iter"
+	"sync"
+)
+
+// Progress How far a run has got
+type Progress struct {
+	Period Period
+	Done   int // employees paid or left unpaid so far
+	Total  int // -1 when the roster can't tell in advance
+	Name   string
+	Err    error // why Name was left unpaid; nil if they were paid
+}
+
+// ProgressReporter Told how a run is going: once before the first employee,
+// with Done 0, then after each one. Concurrent reports from its workers, one
+// call at a time, so Done only ever grows.
+//
+// The runners don't know where the reports go - a browser, a progress bar on
+// a terminal, a log. Each is one more implementation.
+type ProgressReporter interface {
+	Report(p Progress)
+}
+
+// ReportFunc Lets an ordinary function be a ProgressReporter
+type ReportFunc func(p Progress)
+
+func (f ReportFunc) Report(p Progress) { f(p) }
+
+type progressKey struct{}
+
+// WithProgress attaches r to ctx, for the runs given ctx to report to. It
+// travels with the run rather than with the runner, so one runner can serve
+// many callers, each watching their own run, and decorators such as
+// Publisher pass it on without knowing it is there.
+func WithProgress(ctx context.Context, r ProgressReporter) context.Context {
+	return context.WithValue(ctx, progressKey{}, r)
+}
+
+// ProgressFrom returns the reporter attached by WithProgress, or nil.
+func ProgressFrom(ctx context.Context) ProgressReporter {
+	r, _ := ctx.Value(progressKey{}).(ProgressReporter)
+	return r
+}
+
+// Employees A roster already in memory. Unlike one that reads as it goes, it
+// knows its length, so progress can be reported against a total.
+type Employees []PaidEmployee
+
+func (e Employees) PaidEmployees(context.Context) iter.Seq2[PaidEmployee, error] {
+	return func(yield func(PaidEmployee, error) bool) {
+		for _, emp := range e {
+			if !yield(emp, nil) {
+				return
+			}
+		}
+	}
+}
+
+// Len returns how many employees there are to pay.
+func (e Employees) Len() int { return len(e) }
+
+// Collect reads the whole roster into memory.
+func Collect(ctx context.Context, roster Roster) (Employees, error) {
+	var all Employees
+	for emp, err := range roster.PaidEmployees(ctx) {
+		if err != nil {
+			return all, err
+		}
+		all = append(all, emp)
+	}
+	return all, nil
+}
+
+// tracker Counts a run's employees for the reporter in its context, if any
+type tracker struct {
+	mu       sync.Mutex
+	reporter ProgressReporter
+	progress Progress
+}
+
+func track(ctx context.Context, period Period, roster Roster) *tracker {
+	t := &tracker{reporter: ProgressFrom(ctx), progress: Progress{Period: period, Total: -1}}
+	if t.reporter == nil {
+		return t
+	}
+	if sized, ok := roster.(interface{ Len() int }); ok {
+		t.progress.Total = sized.Len()
+	}
+	t.reporter.Report(t.progress)
+	return t
+}
+
+func (t *tracker) done(emp PaidEmployee, err error) {
+	if t.reporter == nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.progress.Done++
+	t.progress.Name, t.progress.Err = emp.EmployeeName(), err
+	t.reporter.Report(t.progress)
+}
+
+var (
+	_ Roster           = Employees(nil)
+	_ ProgressReporter = ReportFunc(nil)
+)
@@ -0,0 +1,54 @@
+package payroll
+
+import (
+	"context"
+	"iter"
+
+	"go-solid/employee"
+	"go-solid/money"
+)
+
+// Staff Adapts an employee repository to a Roster. Employees carry no country,
+// so CountryOf decides which pipeline each one goes through.
+type Staff struct {
+	Repo      employee.Repository
+	CountryOf func(employee.Employee) string
+}
+
+// ByCurrency A CountryOf for companies that pay each country in its own currency
+func ByCurrency(countries map[money.Currency]string) func(employee.Employee) string {
+	return func(emp employee.Employee) string { return countries[emp.Salary.Currency()] }
+}
+
+// PaidEmployees streams the repository by name through employee.All; it
+// needs the employee.Iterable or employee.QueryRepository capability.
+func (s Staff) PaidEmployees(ctx context.Context) iter.Seq2[PaidEmployee, error] {
+	return func(yield func(PaidEmployee, error) bool) {
+		for emp, err := range employee.All(ctx, s.Repo) {
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+			if !yield(s.Member(emp), nil) {
+				return
+			}
+		}
+	}
+}
+
+// Member adapts a single employee, for callers that load employees themselves.
+func (s Staff) Member(emp employee.Employee) PaidEmployee {
+	return staffMember{emp: emp, country: s.CountryOf(emp)}
+}
+
+type staffMember struct {
+	emp     employee.Employee
+	country string
+}
+
+func (m staffMember) EmployeeID() string      { return string(m.emp.ID) }
+func (m staffMember) EmployeeName() string    { return m.emp.Name }
+func (m staffMember) Country() string         { return m.country }
+func (m staffMember) MonthlyPay() money.Money { return m.emp.Salary }
+
+var _ Roster = Staff{}
@@ -0,0 +1,257 @@
+package payroll
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"go-solid/money"
+)
+
+// Rates and percentages are written as decimal fractions ("0.10" is 10%) and
+// kept as strings so a Config can be written - or loaded - without floats.
+
+func parseRate(s string) (*big.Rat, error) {
+	r, ok := new(big.Rat).SetString(s)
+	if !ok || r.Sign() < 0 {
+		return nil, fmt.Errorf("invalid rate %q", s)
+	}
+	return r, nil
+}
+
+// Bonus Adds Rate of base pay for employees that Eligible accepts (all when nil)
+type Bonus struct {
+	Label    string
+	Rate     string
+	Eligible func(PaidEmployee) bool
+}
+
+func (Bonus) Name() string { return "bonus" }
+
+func (b Bonus) Apply(ctx context.Context, emp PaidEmployee, slip *Payslip) error {
+	if b.Eligible != nil && !b.Eligible(emp) {
+		return nil
+	}
+	apply, err := b.Bind()
+	if err != nil {
+		return err
+	}
+	return apply(ctx, emp, slip)
+}
+
+// Bind parses Rate once.
+func (b Bonus) Bind() (StepFunc, error) {
+	rate, err := parseRate(b.Rate)
+	if err != nil {
+		return nil, err
+	}
+	r := money.RatioOf(rate)
+	return func(_ context.Context, emp PaidEmployee, slip *Payslip) error {
+		if b.Eligible != nil && !b.Eligible(emp) {
+			return nil
+		}
+		return slip.Add(b.Name(), b.Label, Earning, slip.Base.MulRatio(r))
+	}, nil
+}
+
+// Pension Employee contribution of Rate of gross pay, deducted before tax and
+// capped at Cap when set
+type Pension struct {
+	Rate string
+	Cap  money.Money
+}
+
+func (Pension) Name() string { return "pension" }
+
+func (p Pension) Apply(ctx context.Context, emp PaidEmployee, slip *Payslip) error {
+	apply, err := p.Bind()
+	if err != nil {
+		return err
+	}
+	return apply(ctx, emp, slip)
+}
+
+// Bind parses Rate once.
+func (p Pension) Bind() (StepFunc, error) {
+	rate, err := parseRate(p.Rate)
+	if err != nil {
+		return nil, err
+	}
+	r := money.RatioOf(rate)
+	return func(_ context.Context, _ PaidEmployee, slip *Payslip) error {
+		contribution := slip.Gross().MulRatio(r)
+		if !p.Cap.IsZero() {
+			if c, err := contribution.Cmp(p.Cap); err != nil {
+				return err
+			} else if c > 0 {
+				contribution = p.Cap
+			}
+		}
+		return slip.Add(p.Name(), "employee contribution", PreTax, contribution.Neg())
+	}, nil
+}
+
+// Bracket Income up to UpTo is taxed at Rate; a zero UpTo means "and above"
+type Bracket struct {
+	UpTo money.Money
+	Rate string
+}
+
+// IncomeTax Progressive monthly tax on taxable pay. Brackets are in ascending
+// order and in the currency of the country's salaries.
+type IncomeTax struct {
+	Brackets []Bracket
+}
+
+func (IncomeTax) Name() string { return "income-tax" }
+
+func (t IncomeTax) Apply(ctx context.Context, emp PaidEmployee, slip *Payslip) error {
+	apply, err := t.Bind()
+	if err != nil {
+		return err
+	}
+	return apply(ctx, emp, slip)
+}
+
+// Bind parses the rate of every bracket once.
+func (t IncomeTax) Bind() (StepFunc, error) {
+	rates := make([]money.Ratio, len(t.Brackets))
+	for i, b := range t.Brackets {
+		rate, err := parseRate(b.Rate)
+		if err != nil {
+			return nil, err
+		}
+		rates[i] = money.RatioOf(rate)
+	}
+	return func(_ context.Context, _ PaidEmployee, slip *Payslip) error {
+		taxable := slip.Taxable()
+		tax := money.FromMinor(0, taxable.Currency())
+		lower := money.FromMinor(0, taxable.Currency())
+		for i, b := range t.Brackets {
+			upper := taxable
+			if !b.UpTo.IsZero() {
+				c, err := b.UpTo.Cmp(taxable)
+				if err != nil {
+					return err
+				}
+				if c < 0 {
+					upper = b.UpTo
+				}
+			}
+			band, err := upper.Sub(lower)
+			if err != nil {
+				return err
+			}
+			if band.IsPositive() {
+				if tax, err = tax.Add(band.MulRatio(rates[i])); err != nil {
+					return err
+				}
+			}
+			if b.UpTo.IsZero() || upper == taxable {
+				break
+			}
+			lower = b.UpTo
+		}
+		return slip.Add(t.Name(), "income tax", Tax, tax.Neg())
+	}, nil
+}
+
+// Order A court or agency order against an employee's pay: a fixed Amount
+// per month, or Rate of net pay when Amount is zero
+type Order struct {
+	Label  string
+	Amount money.Money
+	Rate   string
+}
+
+// Garnishment Deducts the orders against an employee from net pay, never
+// leaving less than Protected
+type Garnishment struct {
+	Orders    map[string][]Order // by employee ID
+	Protected money.Money
+}
+
+func (Garnishment) Name() string { return "garnishment" }
+
+// Apply parses the rate of each order as it comes to it, so a bad rate only
+// fails the payslips of the employee it is against.
+func (g Garnishment) Apply(ctx context.Context, emp PaidEmployee, slip *Payslip) error {
+	for _, o := range g.Orders[emp.EmployeeID()] {
+		var r money.Ratio
+		if o.Amount.IsZero() {
+			rate, err := parseRate(o.Rate)
+			if err != nil {
+				return err
+			}
+			r = money.RatioOf(rate)
+		}
+		if err := g.deduct(slip, o, r); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Bind parses the rate of every order once; if one is bad, the Engine falls
+// back to Apply.
+func (g Garnishment) Bind() (StepFunc, error) {
+	rates := make(map[string][]money.Ratio, len(g.Orders))
+	for id, orders := range g.Orders {
+		rates[id] = make([]money.Ratio, len(orders))
+		for i, o := range orders {
+			if !o.Amount.IsZero() {
+				continue
+			}
+			rate, err := parseRate(o.Rate)
+			if err != nil {
+				return nil, err
+			}
+			rates[id][i] = money.RatioOf(rate)
+		}
+	}
+	return func(_ context.Context, emp PaidEmployee, slip *Payslip) error {
+		id := emp.EmployeeID()
+		for i, o := range g.Orders[id] {
+			if err := g.deduct(slip, o, rates[id][i]); err != nil {
+				return err
+			}
+		}
+		return nil
+	}, nil
+}
+
+// deduct applies one order, at rate r of net pay when it has no Amount.
+func (g Garnishment) deduct(slip *Payslip, o Order, r money.Ratio) error {
+	amount := o.Amount
+	if amount.IsZero() {
+		amount = slip.Net().MulRatio(r)
+	}
+	available := slip.Net()
+	if !g.Protected.IsZero() {
+		var err error
+		if available, err = available.Sub(g.Protected); err != nil {
+			return err
+		}
+	}
+	if c, err := amount.Cmp(available); err != nil {
+		return err
+	} else if c > 0 {
+		amount = available
+	}
+	if !amount.IsPositive() {
+		return nil // nothing left above the protected amount
+	}
+	return slip.Add(g.Name(), o.Label, PostTax, amount.Neg())
+}
+
+var (
+	_ Step = Bonus{}
+	_ Step = Pension{}
+	_ Step = IncomeTax{}
+	_ Step = Garnishment{}
+
+	_ Binder = Bonus{}
+	_ Binder = Pension{}
+	_ Binder = IncomeTax{}
+	_ Binder = Garnishment{}
+)
@@ -0,0 +1,207 @@
+package payroll_test
+
+import (
+	"context"
+	"errors"
+	"iter"
+	"math"
+	"slices"
+	"testing"
+	"time"
+
+	"go-solid/money"
+	"go-solid/payroll"
+)
+
+type member struct {
+	id, country string
+	pay         money.Money
+}
+
+func (m member) EmployeeID() string      { return m.id }
+func (m member) EmployeeName() string    { return m.id }
+func (m member) Country() string         { return m.country }
+func (m member) MonthlyPay() money.Money { return m.pay }
+
+func usd(amount string) money.Money {
+	m, err := money.Parse(amount, money.USD)
+	if err != nil {
+		panic(err)
+	}
+	return m
+}
+
+var march = payroll.Period{Year: 2026, Month: time.March}
+
+// payslip runs emp through steps twice, bound by the Engine and through
+// Apply alone, and fails unless both give the same payslip.
+func payslip(t *testing.T, emp member, steps ...payroll.Step) (payroll.Payslip, error) {
+	t.Helper()
+	bound, err := payroll.New(payroll.Config{"US": steps}).Payslip(t.Context(), march, emp)
+	var applied []payroll.Step
+	for _, s := range steps {
+		applied = append(applied, applyOnly{s})
+	}
+	slip, err2 := payroll.New(payroll.Config{"US": applied}).Payslip(t.Context(), march, emp)
+	if !slices.Equal(bound.Lines, slip.Lines) || (err == nil) != (err2 == nil) {
+		t.Fatalf("bound steps gave %v, %v; Apply gave %v, %v", bound.Lines, err, slip.Lines, err2)
+	}
+	return bound, err
+}
+
+// applyOnly hides a step's Bind.
+type applyOnly struct{ payroll.Step }
+
+func amounts(slip payroll.Payslip) []string {
+	var got []string
+	for _, l := range slip.Lines {
+		got = append(got, l.Step+" "+l.Amount.Amount())
+	}
+	return got
+}
+
+func TestSteps(t *testing.T) {
+	ali := member{id: "emp-1", country: "US", pay: usd("5000")}
+	brackets := payroll.IncomeTax{Brackets: []payroll.Bracket{
+		{UpTo: usd("1000"), Rate: "0"},
+		{UpTo: usd("4000"), Rate: "0.10"},
+		{Rate: "0.20"},
+	}}
+	tests := []struct {
+		name    string
+		emp     member
+		steps   []payroll.Step
+		want    []string
+		wantNet string
+	}{
+		{"bonus", ali, []payroll.Step{payroll.Bonus{Rate: "0.10"}}, []string{"bonus 500.00"}, "5500.00"},
+		{"bonus, not eligible", ali, []payroll.Step{payroll.Bonus{Rate: "0.10", Eligible: func(payroll.PaidEmployee) bool { return false }}}, nil, "5000.00"},
+		{"pension on gross", ali, []payroll.Step{payroll.Bonus{Rate: "0.10"}, payroll.Pension{Rate: "0.05"}}, []string{"bonus 500.00", "pension -275.00"}, "5225.00"},
+		{"pension capped", ali, []payroll.Step{payroll.Pension{Rate: "0.05", Cap: usd("200")}}, []string{"pension -200.00"}, "4800.00"},
+		// 0 on the first 1000, 10% of the next 3000, 20% of the last 1000
+		{"tax over every bracket", ali, []payroll.Step{brackets}, []string{"income-tax -500.00"}, "4500.00"},
+		{"tax at a bracket's top", member{id: "emp-2", country: "US", pay: usd("4000")}, []payroll.Step{brackets}, []string{"income-tax -300.00"}, "3700.00"},
+		{"tax in the first bracket", member{id: "emp-3", country: "US", pay: usd("800")}, []payroll.Step{brackets}, []string{"income-tax 0.00"}, "800.00"},
+		// 10% of 0.05 is half a cent, rounded away from zero
+		{"tax rounded", member{id: "emp-4", country: "US", pay: usd("1000.05")}, []payroll.Step{brackets}, []string{"income-tax -0.01"}, "1000.04"},
+		{"tax after pension", ali, []payroll.Step{payroll.Pension{Rate: "0.10"}, brackets}, []string{"pension -500.00", "income-tax -400.00"}, "4100.00"},
+		{"tax in one bracket", ali, []payroll.Step{payroll.IncomeTax{Brackets: []payroll.Bracket{{Rate: "0.25"}}}}, []string{"income-tax -1250.00"}, "3750.00"},
+		{"garnishment", ali, []payroll.Step{payroll.Garnishment{Orders: map[string][]payroll.Order{
+			"emp-1": {{Label: "court", Amount: usd("300")}, {Label: "agency", Rate: "0.10"}},
+			"emp-2": {{Label: "court", Amount: usd("1000")}},
+		}}}, []string{"garnishment -300.00", "garnishment -470.00"}, "4230.00"},
+		{"garnishment above the protected pay", ali, []payroll.Step{payroll.Garnishment{
+			Orders:    map[string][]payroll.Order{"emp-1": {{Amount: usd("300")}, {Amount: usd("300")}}},
+			Protected: usd("4500"),
+		}}, []string{"garnishment -300.00", "garnishment -200.00"}, "4500.00"},
+		{"garnishment under the protected pay", ali, []payroll.Step{payroll.Garnishment{
+			Orders:    map[string][]payroll.Order{"emp-1": {{Amount: usd("300")}}},
+			Protected: usd("6000"),
+		}}, nil, "5000.00"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			slip, err := payslip(t, tt.emp, tt.steps...)
+			if err != nil {
+				t.Fatalf("Payslip() error = %v", err)
+			}
+			if got := amounts(slip); !slices.Equal(got, tt.want) {
+				t.Errorf("Payslip() lines = %q, want %q", got, tt.want)
+			}
+			if got := slip.Net().Amount(); got != tt.wantNet {
+				t.Errorf("Net() = %s, want %s", got, tt.wantNet)
+			}
+		})
+	}
+}
+
+func TestSteps_Errors(t *testing.T) {
+	ali := member{id: "emp-1", country: "US", pay: usd("5000")}
+	tests := []struct {
+		name    string
+		step    payroll.Step
+		wantErr error
+	}{
+		{"negative rate", payroll.Bonus{Rate: "-0.10"}, nil},
+		{"rate that isn't a number", payroll.Pension{Rate: "ten percent"}, nil},
+		{"bad bracket rate", payroll.IncomeTax{Brackets: []payroll.Bracket{{Rate: "0.1.0"}}}, nil},
+		{"bad order rate", payroll.Garnishment{Orders: map[string][]payroll.Order{"emp-1": {{Rate: "x"}}}}, nil},
+		{"bracket in another currency", payroll.IncomeTax{Brackets: []payroll.Bracket{{UpTo: money.Of(1000, money.EUR), Rate: "0"}, {Rate: "0.2"}}}, money.ErrCurrencyMismatch},
+		{"cap in another currency", payroll.Pension{Rate: "0.05", Cap: money.Of(100, money.EUR)}, money.ErrCurrencyMismatch},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := payslip(t, ali, tt.step)
+			if err == nil || (tt.wantErr != nil && !errors.Is(err, tt.wantErr)) {
+				t.Errorf("Payslip() error = %v, want one (%v)", err, tt.wantErr)
+			}
+		})
+	}
+	if _, err := payroll.New(payroll.Config{}).Payslip(t.Context(), march, ali); !errors.Is(err, payroll.ErrNoPipeline) {
+		t.Errorf("Payslip() in a country without a pipeline error = %v, want %v", err, payroll.ErrNoPipeline)
+	}
+}
+
+// a bad order rate only fails the payslips of the employee it is against
+func TestGarnishment_BadRateFailsItsEmployeeOnly(t *testing.T) {
+	engine := payroll.New(payroll.Config{"US": {payroll.Garnishment{Orders: map[string][]payroll.Order{"emp-1": {{Rate: "x"}}}}}})
+	roster := roster{{id: "emp-1", country: "US", pay: usd("5000")}, {id: "emp-2", country: "US", pay: usd("5000")}}
+	run, err := engine.Run(t.Context(), march, roster)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(run.Payslips) != 1 || run.Payslips[0].EmployeeID != "emp-2" || len(run.Errors) != 1 || run.Errors[0].EmployeeID != "emp-1" {
+		t.Errorf("Run() = %d payslips, errors %v, want emp-2 paid and emp-1 failed", len(run.Payslips), run.Errors)
+	}
+}
+
+type roster []member
+
+func (r roster) PaidEmployees(context.Context) iter.Seq2[payroll.PaidEmployee, error] {
+	return func(yield func(payroll.PaidEmployee, error) bool) {
+		for _, m := range r {
+			if !yield(m, nil) {
+				return
+			}
+		}
+	}
+}
+
+// a payslip whose totals don't fit in a money.Money fails its employee
+// instead of paying out a wrong amount
+func TestPayslip_Overflow(t *testing.T) {
+	huge := money.FromMinor(math.MaxInt64, money.USD)
+	adds := payroll.StepFunc(func(_ context.Context, _ payroll.PaidEmployee, slip *payroll.Payslip) error {
+		return slip.Add("windfall", "windfall", payroll.Earning, huge)
+	})
+	appends := payroll.StepFunc(func(_ context.Context, _ payroll.PaidEmployee, slip *payroll.Payslip) error {
+		slip.Lines = append(slip.Lines, payroll.Line{Step: "windfall", Kind: payroll.PostTax, Amount: huge})
+		return nil
+	})
+	for name, step := range map[string]payroll.StepFunc{"Add": adds, "appended": appends} {
+		engine := payroll.New(payroll.Config{"US": {named{"windfall", step}}})
+		roster := roster{{id: "emp-1", country: "US", pay: usd("5000")}, {id: "emp-2", country: "US", pay: usd("-1")}}
+		run, err := engine.Run(t.Context(), march, roster)
+		if err != nil {
+			t.Fatal(err)
+		}
+		// -1 + MaxInt64 still fits, so emp-2 is paid
+		if len(run.Errors) != 1 || run.Errors[0].EmployeeID != "emp-1" || !errors.Is(run.Err(), money.ErrOverflow) {
+			t.Errorf("%s: Run() errors %v, want emp-1 failed with %v", name, run.Errors, money.ErrOverflow)
+		}
+		if len(run.Payslips) != 1 || run.Payslips[0].Net() != money.FromMinor(math.MaxInt64-100, money.USD) {
+			t.Errorf("%s: Run() payslips %v, want emp-2's alone", name, run.Payslips)
+		}
+	}
+}
+
+// named A StepFunc given a name
+type named struct {
+	name string
+	payroll.StepFunc
+}
+
+func (n named) Name() string { return n.name }
+func (n named) Apply(ctx context.Context, emp payroll.PaidEmployee, slip *payroll.Payslip) error {
+	return n.StepFunc(ctx, emp, slip)
+}
@@ -0,0 +1,276 @@
+// Package payrollapi runs payrolls over HTTP and streams how each run is
+// going to the browser as server-sent events.
+//
+// A run reports its progress to a payroll.ProgressReporter. Here the reporter
+// is the run's event stream; in employee-cli it is a progress bar. Neither
+// the engine nor the reporters know about the other side (DIP), so a new
+// transport is a new reporter.
+package payrollapi
+
+import (
+	"context"
+	"embed"
+	"encoding/json"
+	"io/fs"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"go-solid/id"
+	"go-solid/payroll"
+)
+
+// Handler Starts payroll runs in the background and streams their progress:
+//
+//	POST /payroll/runs                  {"month": "2026-03"}, answers 202 and where to follow the run
+//	GET  /payroll/runs/{id}/progress    text/event-stream of "progress" events, then one "done"
+//	GET  /payroll/                      a page that does both
+//
+// Runs are kept in memory, finished or not, until the process exits.
+type Handler struct {
+	runner payroll.Runner
+	roster payroll.Roster
+	ids    id.Generator
+	mux    *http.ServeMux
+
+	ctx    context.Context // cancelled by Stop; runs outlive the request that started them
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+
+	mu   sync.Mutex
+	runs map[string]*run
+}
+
+// Option customises a Handler created by New
+type Option func(*Handler)
+
+// WithIDs sets how runs are named; random UUIDs by default.
+func WithIDs(g id.Generator) Option { return func(h *Handler) { h.ids = g } }
+
+func New(runner payroll.Runner, roster payroll.Roster, opts ...Option) *Handler {
+	ctx, cancel := context.WithCancel(context.Background())
+	h := &Handler{runner: runner, roster: roster, ids: id.UUID{}, mux: http.NewServeMux(), ctx: ctx, cancel: cancel, runs: map[string]*run{}}
+	for _, opt := range opts {
+		opt(h)
+	}
+	h.mux.HandleFunc("POST /payroll/runs", h.start)
+	h.mux.HandleFunc("GET /payroll/runs/{id}/progress", h.progress)
+	sub, _ := fs.Sub(static, "static")
+	h.mux.Handle("GET /payroll/", http.StripPrefix("/payroll", http.FileServerFS(sub)))
+	return h
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) { h.mux.ServeHTTP(w, r) }
+
+// StartRequest Body of POST /payroll/runs
+type StartRequest struct {
+	Month string `json:"month"` // YYYY-MM
+}
+
+// StartResponse Where to follow a run that has been started
+type StartResponse struct {
+	ID       string `json:"id"`
+	Period   string `json:"period"`
+	Progress string `json:"progress"`
+}
+
+// ProgressEvent Data of a "progress" event
+type ProgressEvent struct {
+	Done  int    `json:"done"`
+	Total int    `json:"total"` // -1 when unknown
+	Name  string `json:"name,omitempty"`
+	Error string `json:"error,omitempty"`
+}
+
+// DoneEvent Data of the "done" event ending a stream
+type DoneEvent struct {
+	Paid   int      `json:"paid"`
+	Failed []string `json:"failed"` // one line per employee left unpaid
+	Error  string   `json:"error,omitempty"`
+}
+
+func (h *Handler) start(w http.ResponseWriter, r *http.Request) {
+	var req StartRequest
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, 1<<10)).Decode(&req); err != nil {
+		writeJSON(w, http.StatusBadRequest, errorBody{"invalid JSON body: " + err.Error()})
+		return
+	}
+	t, err := time.Parse("2006-01", req.Month)
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, errorBody{"month must be YYYY-MM"})
+		return
+	}
+	period := payroll.Period{Year: t.Year(), Month: t.Month()}
+
+	h.mu.Lock()
+	if h.ctx.Err() != nil {
+		h.mu.Unlock()
+		writeJSON(w, http.StatusServiceUnavailable, errorBody{"shutting down"})
+		return
+	}
+	rn := newRun(h.ids.NewID())
+	h.runs[rn.id] = rn
+	h.wg.Add(1)
+	h.mu.Unlock()
+
+	go func() {
+		defer h.wg.Done()
+		rn.finish(h.pay(rn, period))
+	}()
+
+	resp := StartResponse{ID: rn.id, Period: period.String(), Progress: "/payroll/runs/" + rn.id + "/progress"}
+	w.Header().Set("Location", resp.Progress)
+	writeJSON(w, http.StatusAccepted, resp)
+}
+
+// pay reads the roster first, so that the run can report against a total.
+func (h *Handler) pay(rn *run, period payroll.Period) (payroll.Run, error) {
+	employees, err := payroll.Collect(h.ctx, h.roster)
+	if err != nil {
+		return payroll.Run{Period: period}, err
+	}
+	return h.runner.Run(payroll.WithProgress(h.ctx, rn), period, employees)
+}
+
+// progress streams a run's events, from the start or from after the
+// Last-Event-ID the browser sends when it reconnects.
+func (h *Handler) progress(w http.ResponseWriter, r *http.Request) {
+	h.mu.Lock()
+	rn, ok := h.runs[r.PathValue("id")]
+	h.mu.Unlock()
+	if !ok {
+		writeJSON(w, http.StatusNotFound, errorBody{"no such run"})
+		return
+	}
+	next, _ := strconv.Atoi(r.Header.Get("Last-Event-ID"))
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-store")
+	rc := http.NewResponseController(w)
+	for {
+		var (
+			events  []event
+			changed <-chan struct{}
+		)
+		next, events, changed = rn.since(next)
+		for _, e := range events {
+			next++
+			if err := writeEvent(w, next, e.name, e.data); err != nil {
+				return
+			}
+		}
+		if err := rc.Flush(); err != nil {
+			return
+		}
+		if changed == nil {
+			return // the run is over and the client has every event
+		}
+		select {
+		case <-changed:
+		case <-r.Context().Done():
+			return
+		}
+	}
+}
+
+// Stop cancels the runs still going and waits for them to end. Streams end
+// with them, with a "done" event carrying the cancellation.
+func (h *Handler) Stop(ctx context.Context) error {
+	h.mu.Lock()
+	h.cancel()
+	h.mu.Unlock()
+	finished := make(chan struct{})
+	go func() {
+		h.wg.Wait()
+		close(finished)
+	}()
+	select {
+	case <-finished:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// run One payroll run and every event it has produced, so that a browser
+// arriving late, or reconnecting, misses nothing.
+type run struct {
+	id string
+
+	mu      sync.Mutex
+	events  []event
+	changed chan struct{} // closed when events grow; nil once the run is over
+}
+
+type event struct {
+	name string
+	data []byte
+}
+
+func newRun(id string) *run { return &run{id: id, changed: make(chan struct{})} }
+
+// Report makes the run its own ProgressReporter: each report is one more event.
+func (rn *run) Report(p payroll.Progress) {
+	e := ProgressEvent{Done: p.Done, Total: p.Total, Name: p.Name}
+	if p.Err != nil {
+		e.Error = p.Err.Error()
+	}
+	rn.add("progress", e, false)
+}
+
+func (rn *run) finish(result payroll.Run, err error) {
+	e := DoneEvent{Paid: len(result.Payslips), Failed: []string{}}
+	for _, ee := range result.Errors {
+		e.Failed = append(e.Failed, ee.Error())
+	}
+	if err != nil {
+		e.Error = err.Error()
+	}
+	rn.add("done", e, true)
+}
+
+func (rn *run) add(name string, v any, last bool) {
+	data, _ := json.Marshal(v)
+	rn.mu.Lock()
+	defer rn.mu.Unlock()
+	rn.events = append(rn.events, event{name: name, data: data})
+	close(rn.changed)
+	rn.changed = nil
+	if !last {
+		rn.changed = make(chan struct{})
+	}
+}
+
+// since returns the events after the first n, and a channel closed when there
+// are more; nil when there will be none. n is clamped to the events there
+// are, and returned, so that the caller numbers the events from there: a
+// Last-Event-ID the run never sent mustn't replay or skip any.
+func (rn *run) since(n int) (int, []event, <-chan struct{}) {
+	rn.mu.Lock()
+	defer rn.mu.Unlock()
+	n = min(max(n, 0), len(rn.events))
+	events := rn.events[n:len(rn.events):len(rn.events)]
+	if rn.changed == nil {
+		return n, events, nil
+	}
+	return n, events, rn.changed
+}
+
+type errorBody struct {
+	Error string `json:"error"`
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+//go:embed static
+var static embed.FS
+
+var (
+	_ http.Handler             = (*Handler)(nil)
+	_ payroll.ProgressReporter = (*run)(nil)
+)
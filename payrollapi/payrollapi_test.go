@@ -0,0 +1,278 @@
+package payrollapi_test
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"go-solid/id"
+	"go-solid/money"
+	"go-solid/payroll"
+	"go-solid/payrollapi"
+)
+
+// paid Someone to pay, by name only
+type paid string
+
+func (p paid) EmployeeID() string    { return "emp-" + strings.ToLower(string(p)) }
+func (p paid) EmployeeName() string  { return string(p) }
+func (paid) Country() string         { return "US" }
+func (paid) MonthlyPay() money.Money { return money.Of(4000, money.USD) }
+
+// stepper A runner that pays one employee each time the test says so, and
+// fails Bob
+type stepper struct{ next chan struct{} }
+
+func (s stepper) Run(ctx context.Context, period payroll.Period, roster payroll.Roster) (payroll.Run, error) {
+	employees := roster.(payroll.Employees)
+	reporter := payroll.ProgressFrom(ctx)
+	reporter.Report(payroll.Progress{Period: period, Total: len(employees)})
+	result := payroll.Run{Period: period}
+	for i, emp := range employees {
+		select {
+		case <-s.next:
+		case <-ctx.Done():
+			return result, ctx.Err()
+		}
+		var err error
+		if emp.EmployeeName() == "Bob" {
+			err = errors.New("no bank account")
+			result.Errors = append(result.Errors, payroll.EmployeeError{EmployeeID: emp.EmployeeID(), Name: emp.EmployeeName(), Err: err})
+		} else {
+			result.Payslips = append(result.Payslips, payroll.Payslip{EmployeeID: emp.EmployeeID(), Name: emp.EmployeeName(), Period: period})
+		}
+		reporter.Report(payroll.Progress{Period: period, Done: i + 1, Total: len(employees), Name: emp.EmployeeName(), Err: err})
+	}
+	return result, nil
+}
+
+// step lets the runner pay n more employees.
+func (s stepper) step(n int) {
+	for range n {
+		s.next <- struct{}{}
+	}
+}
+
+var staff = payroll.Employees{paid("Alice"), paid("Bob"), paid("Carol")}
+
+// sse One server-sent event as read off the wire
+type sse struct {
+	id, name, data string
+}
+
+// stream A progress stream being read
+type stream struct{ r *bufio.Reader }
+
+// follow opens the progress stream at path, sending lastID unless it is "".
+func follow(t *testing.T, srv *httptest.Server, path, lastID string) *stream {
+	t.Helper()
+	req, _ := http.NewRequestWithContext(t.Context(), http.MethodGet, srv.URL+path, nil)
+	if lastID != "" {
+		req.Header.Set("Last-Event-ID", lastID)
+	}
+	resp, err := srv.Client().Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { resp.Body.Close() })
+	if resp.StatusCode != http.StatusOK || resp.Header.Get("Content-Type") != "text/event-stream" {
+		t.Fatalf("GET %s = %s %s, want 200 text/event-stream", path, resp.Status, resp.Header.Get("Content-Type"))
+	}
+	return &stream{r: bufio.NewReader(resp.Body)}
+}
+
+// next reads the next event; ok is false when the stream has ended.
+func (s *stream) next(t *testing.T) (e sse, ok bool) {
+	t.Helper()
+	for {
+		line, err := s.r.ReadString('\n')
+		if err != nil {
+			if line != "" || e != (sse{}) {
+				t.Fatalf("stream ended mid-event: %q", line)
+			}
+			return e, false
+		}
+		line = strings.TrimSuffix(line, "\n")
+		if line == "" {
+			return e, true
+		}
+		field, value, _ := strings.Cut(line, ": ")
+		switch field {
+		case "id":
+			e.id = value
+		case "event":
+			e.name = value
+		case "data":
+			e.data += value
+		}
+	}
+}
+
+// rest reads the events left until the stream ends.
+func (s *stream) rest(t *testing.T) []sse {
+	t.Helper()
+	var events []sse
+	for {
+		e, ok := s.next(t)
+		if !ok {
+			return events
+		}
+		events = append(events, e)
+	}
+}
+
+// start starts the run of March 2026 and returns where to follow it.
+func start(t *testing.T, srv *httptest.Server) string {
+	t.Helper()
+	resp, err := srv.Client().Post(srv.URL+"/payroll/runs", "application/json", strings.NewReader(`{"month": "2026-03"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	var body payrollapi.StartResponse
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		t.Fatal(err)
+	}
+	if resp.StatusCode != http.StatusAccepted || body.Period != "2026-03" || resp.Header.Get("Location") != body.Progress {
+		t.Fatalf("POST /payroll/runs = %s %+v, Location %q, want 202 for 2026-03 and its progress", resp.Status, body, resp.Header.Get("Location"))
+	}
+	return body.Progress
+}
+
+// newServer serves a Handler paying staff with a stepper.
+func newServer(t *testing.T) (*httptest.Server, stepper) {
+	t.Helper()
+	runner := stepper{next: make(chan struct{})}
+	h := payrollapi.New(runner, staff, payrollapi.WithIDs(id.NewSequence("run-")))
+	srv := httptest.NewServer(h)
+	t.Cleanup(func() {
+		srv.Close()
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := h.Stop(ctx); err != nil {
+			t.Errorf("Stop() error = %v", err)
+		}
+	})
+	return srv, runner
+}
+
+// ids returns the id and name of each event, e.g. "1 progress".
+func ids(events []sse) []string {
+	var got []string
+	for _, e := range events {
+		got = append(got, e.id+" "+e.name)
+	}
+	return got
+}
+
+var whole = []string{"1 progress", "2 progress", "3 progress", "4 progress", "5 done"}
+
+func TestHandler_Streams(t *testing.T) {
+	srv, runner := newServer(t)
+	s := follow(t, srv, start(t, srv), "")
+
+	first, _ := s.next(t)
+	var p payrollapi.ProgressEvent
+	if err := json.Unmarshal([]byte(first.data), &p); err != nil || first.id != "1" || first.name != "progress" || p.Done != 0 || p.Total != 3 {
+		t.Fatalf("first event = %+v, want progress 1 with 0 done of 3", first)
+	}
+	runner.step(len(staff))
+	events := append([]sse{first}, s.rest(t)...)
+	if got := ids(events); fmt.Sprint(got) != fmt.Sprint(whole) {
+		t.Fatalf("events = %v, want %v", got, whole)
+	}
+	if err := json.Unmarshal([]byte(events[2].data), &p); err != nil || p.Done != 2 || p.Name != "Bob" || p.Error != "no bank account" {
+		t.Errorf("Bob's event = %s, want him left unpaid", events[2].data)
+	}
+
+	var done payrollapi.DoneEvent
+	if err := json.Unmarshal([]byte(events[4].data), &done); err != nil {
+		t.Fatal(err)
+	}
+	if done.Paid != 2 || len(done.Failed) != 1 || !strings.Contains(done.Failed[0], "Bob") || done.Error != "" {
+		t.Errorf("done = %+v, want 2 paid and Bob failed", done)
+	}
+}
+
+func TestHandler_Reconnect(t *testing.T) {
+	srv, runner := newServer(t)
+	progress := start(t, srv)
+	runner.step(len(staff))
+	if got := ids(follow(t, srv, progress, "").rest(t)); len(got) != len(whole) {
+		t.Fatalf("events = %v, want %v", got, whole)
+	}
+
+	tests := []struct {
+		lastID string
+		want   []string
+	}{
+		{"", whole},
+		{"3", whole[3:]},
+		{"5", nil},
+		{"not a number", whole},
+		{"-5", whole}, // once, numbered from 1
+		{"999", nil},  // the run is over: nothing more will come
+	}
+	for _, tt := range tests {
+		if got := ids(follow(t, srv, progress, tt.lastID).rest(t)); fmt.Sprint(got) != fmt.Sprint(tt.want) {
+			t.Errorf("Last-Event-ID %q: events = %v, want %v", tt.lastID, got, tt.want)
+		}
+	}
+}
+
+func TestHandler_ReconnectWhileRunning(t *testing.T) {
+	tests := []struct {
+		lastID string
+		want   []string
+	}{
+		{"1", whole[1:]},
+		{"-5", whole},
+		{"999", whole[1:]}, // after the one event there is, as if it had said 1
+	}
+	for _, tt := range tests {
+		t.Run(tt.lastID, func(t *testing.T) {
+			srv, runner := newServer(t)
+			progress := start(t, srv)
+			if e, _ := follow(t, srv, progress, "").next(t); e.id != "1" {
+				t.Fatalf("first event = %+v, want id 1", e)
+			}
+			// the headers come after the handler has read the events so far
+			s := follow(t, srv, progress, tt.lastID)
+			runner.step(len(staff))
+			got := s.rest(t)
+			if fmt.Sprint(ids(got)) != fmt.Sprint(tt.want) {
+				t.Errorf("events = %v, want %v", ids(got), tt.want)
+			}
+		})
+	}
+}
+
+func TestHandler_Errors(t *testing.T) {
+	srv, _ := newServer(t)
+	tests := []struct {
+		method, path, body string
+		want               int
+	}{
+		{http.MethodPost, "/payroll/runs", `{"month": "March"}`, http.StatusBadRequest},
+		{http.MethodPost, "/payroll/runs", `{`, http.StatusBadRequest},
+		{http.MethodGet, "/payroll/runs/run-404/progress", "", http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		req, _ := http.NewRequest(tt.method, srv.URL+tt.path, strings.NewReader(tt.body))
+		resp, err := srv.Client().Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != tt.want {
+			t.Errorf("%s %s %s = %s, want %d", tt.method, tt.path, tt.body, resp.Status, tt.want)
+		}
+	}
+}
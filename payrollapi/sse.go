@@ -0,0 +1,20 @@
+package payrollapi
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+)
+
+// writeEvent writes one server-sent event. id lets a reconnecting browser
+// say what it last received, in the Last-Event-ID header.
+func writeEvent(w io.Writer, id int, name string, data []byte) error {
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "id: %d\nevent: %s\n", id, name)
+	for line := range bytes.Lines(data) {
+		fmt.Fprintf(&b, "data: %s\n", bytes.TrimSuffix(line, []byte("\n")))
+	}
+	b.WriteByte('\n')
+	_, err := w.Write(b.Bytes())
+	return err
+}
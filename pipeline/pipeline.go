@@ -0,0 +1,211 @@
+// Package pipeline streams values through stages connected by channels: a
+// Source produces them, Transforms change them one at a time, and a Sink
+// consumes them.
+//
+// Each stage is a small interface, so a stage is written once and reused in
+// any pipeline whose types fit, and a new step is a new stage rather than an
+// edit to an existing one (OCP). Channels between stages are bounded: a slow
+// sink blocks the transforms, which block the source, so memory stays flat
+// however many values pass through (backpressure). The first error, or the
+// end of the context, stops every stage.
+//
+//	src := pipeline.Through(employees, raise, pipeline.WithWorkers(4))
+//	err := pipeline.Run(ctx, src, report)
+package pipeline
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// Source Stage producing values. Emit sends them to out - with Send, so it
+// stops when ctx ends - and returns when there are no more; out is closed for it.
+type Source[T any] interface {
+	Emit(ctx context.Context, out chan<- T) error
+}
+
+// Transform Stage turning one value into another. Returning Skip drops the
+// value; any other error stops the pipeline.
+type Transform[In, Out any] interface {
+	Apply(ctx context.Context, in In) (Out, error)
+}
+
+// Sink Stage consuming values, one Write per value
+type Sink[T any] interface {
+	Write(ctx context.Context, v T) error
+}
+
+// Flusher Optional capability - sinks that buffer, or report totals, once the
+// stream has ended. Flush is only called when every value was written.
+type Flusher interface {
+	Flush(ctx context.Context) error
+}
+
+// SourceFunc, TransformFunc and SinkFunc adapt plain functions to the stage
+// interfaces, as http.HandlerFunc does for http.Handler.
+type (
+	SourceFunc[T any]          func(ctx context.Context, out chan<- T) error
+	TransformFunc[In, Out any] func(ctx context.Context, in In) (Out, error)
+	SinkFunc[T any]            func(ctx context.Context, v T) error
+)
+
+func (f SourceFunc[T]) Emit(ctx context.Context, out chan<- T) error { return f(ctx, out) }
+
+func (f TransformFunc[In, Out]) Apply(ctx context.Context, in In) (Out, error) { return f(ctx, in) }
+
+func (f SinkFunc[T]) Write(ctx context.Context, v T) error { return f(ctx, v) }
+
+// Skip returned by a Transform to drop a value without stopping the pipeline
+var Skip = errors.New("pipeline: skip value")
+
+// Send sends v to out, unless ctx ends first. Sources and stages use it so a
+// stopped pipeline never leaves them blocked on a channel nobody reads.
+func Send[T any](ctx context.Context, out chan<- T, v T) error {
+	select {
+	case out <- v:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Slice is a Source emitting vs in order.
+func Slice[T any](vs ...T) Source[T] {
+	return SourceFunc[T](func(ctx context.Context, out chan<- T) error {
+		for _, v := range vs {
+			if err := Send(ctx, out, v); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+type options struct {
+	buffer  int
+	workers int
+}
+
+// Option customises how a stage is connected
+type Option func(*options)
+
+// WithBuffer lets n values wait between the stages before the producing one
+// blocks. 0, unbuffered, by default: every value is handed over directly.
+func WithBuffer(n int) Option { return func(o *options) { o.buffer = max(n, 0) } }
+
+// WithWorkers applies a Transform on n goroutines. Values then leave in the
+// order they are finished, not the order they arrived. 1 by default.
+func WithWorkers(n int) Option { return func(o *options) { o.workers = max(n, 1) } }
+
+func apply(opts []Option) options {
+	o := options{workers: 1}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// Through returns the Source of src's values after t. Nothing runs until the
+// returned Source is emitted from, usually by Run.
+func Through[In, Out any](src Source[In], t Transform[In, Out], opts ...Option) Source[Out] {
+	o := apply(opts)
+	return SourceFunc[Out](func(ctx context.Context, out chan<- Out) error {
+		return stream(ctx, src, o, func(ctx context.Context, in <-chan In) error {
+			// the first worker to fail stops the others, not just itself
+			ctx, cancel := context.WithCancelCause(ctx)
+			defer cancel(nil)
+			var wg sync.WaitGroup
+			for range o.workers {
+				wg.Go(func() {
+					if err := transform(ctx, in, t, out); err != nil {
+						cancel(err)
+					}
+				})
+			}
+			wg.Wait()
+			return context.Cause(ctx)
+		})
+	})
+}
+
+func transform[In, Out any](ctx context.Context, in <-chan In, t Transform[In, Out], out chan<- Out) error {
+	for {
+		var v In
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case next, ok := <-in:
+			if !ok {
+				return nil
+			}
+			v = next
+		}
+		res, err := t.Apply(ctx, v)
+		if errors.Is(err, Skip) {
+			continue
+		}
+		if err == nil {
+			err = Send(ctx, out, res)
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// Run streams every value of src into sink, then flushes sink if it is a
+// Flusher. It returns the first error of any stage; on an error, or when ctx
+// ends, every stage is stopped before Run returns.
+func Run[T any](ctx context.Context, src Source[T], sink Sink[T], opts ...Option) error {
+	return stream(ctx, src, apply(opts), func(ctx context.Context, in <-chan T) error {
+		for v := range in {
+			if err := sink.Write(ctx, v); err != nil {
+				return err
+			}
+		}
+		// the channel also closes when the source gave up; only flush a complete stream
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if f, ok := sink.(Flusher); ok {
+			return f.Flush(ctx)
+		}
+		return nil
+	})
+}
+
+// stream runs src on its own goroutine, feeding consume through a channel of
+// o.buffer values. Whichever side fails first cancels the other; its error is
+// the one returned.
+func stream[T any](ctx context.Context, src Source[T], o options, consume func(context.Context, <-chan T) error) error {
+	ctx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+	ch := make(chan T, o.buffer)
+	emitted := make(chan error, 1)
+	go func() {
+		defer close(ch)
+		err := src.Emit(ctx, ch)
+		if err != nil {
+			cancel(err)
+		}
+		emitted <- err
+	}()
+
+	err := consume(ctx, ch)
+	if err != nil {
+		cancel(err)
+	}
+	for range ch { // a source that ignores ctx still gets to finish
+	}
+	return first(context.Cause(ctx), <-emitted, err)
+}
+
+func first(errs ...error) error {
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
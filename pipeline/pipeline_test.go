@@ -0,0 +1,172 @@
+package pipeline_test
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"go-solid/pipeline"
+)
+
+// endless A Source counting up until the pipeline stops it
+var endless = pipeline.SourceFunc[int](func(ctx context.Context, out chan<- int) error {
+	for i := 0; ; i++ {
+		if err := pipeline.Send(ctx, out, i); err != nil {
+			return err
+		}
+	}
+})
+
+var double = pipeline.TransformFunc[int, int](func(_ context.Context, v int) (int, error) { return 2 * v, nil })
+
+// collector A Sink keeping what it was given, and whether it was flushed
+type collector struct {
+	mu      sync.Mutex
+	got     []int
+	flushed bool
+}
+
+func (c *collector) Write(_ context.Context, v int) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.got = append(c.got, v)
+	return nil
+}
+
+func (c *collector) Flush(context.Context) error {
+	c.flushed = true
+	return nil
+}
+
+// within runs f and fails the test if it hasn't returned after 5s: a stage
+// left running would otherwise hang the test binary.
+func within(t *testing.T, f func() error) error {
+	t.Helper()
+	done := make(chan error, 1)
+	go func() { done <- f() }()
+	select {
+	case err := <-done:
+		return err
+	case <-time.After(5 * time.Second):
+		t.Fatal("Run() still running 5s later, want every stage stopped")
+		return nil
+	}
+}
+
+func TestRun_InOrder(t *testing.T) {
+	sink := &collector{}
+	if err := pipeline.Run(t.Context(), pipeline.Through(pipeline.Slice(1, 2, 3), double), sink); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if want := []int{2, 4, 6}; !slices.Equal(sink.got, want) || !sink.flushed {
+		t.Errorf("sink = %v, flushed %t, want %v flushed", sink.got, sink.flushed, want)
+	}
+}
+
+func TestRun_Workers(t *testing.T) {
+	sink := &collector{}
+	src := pipeline.Through(pipeline.Slice(1, 2, 3, 4, 5, 6, 7, 8), double, pipeline.WithWorkers(4), pipeline.WithBuffer(2))
+	if err := pipeline.Run(t.Context(), src, sink); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	// the order they finished in, so compared sorted
+	if want := []int{2, 4, 6, 8, 10, 12, 14, 16}; !slices.Equal(slices.Sorted(slices.Values(sink.got)), want) {
+		t.Errorf("sink = %v, want %v in any order", sink.got, want)
+	}
+}
+
+func TestRun_Skip(t *testing.T) {
+	odd := pipeline.TransformFunc[int, int](func(_ context.Context, v int) (int, error) {
+		if v%2 == 0 {
+			return 0, pipeline.Skip
+		}
+		return v, nil
+	})
+	sink := &collector{}
+	if err := pipeline.Run(t.Context(), pipeline.Through(pipeline.Slice(1, 2, 3, 4, 5), odd), sink); err != nil {
+		t.Fatalf("Run() error = %v, want skipped values not to stop it", err)
+	}
+	if want := []int{1, 3, 5}; !slices.Equal(sink.got, want) || !sink.flushed {
+		t.Errorf("sink = %v, flushed %t, want %v flushed", sink.got, sink.flushed, want)
+	}
+}
+
+func TestRun_TransformError(t *testing.T) {
+	failed := errors.New("no salary")
+	for _, workers := range []int{1, 2, 8} {
+		var applied atomic.Int64
+		failing := pipeline.TransformFunc[int, int](func(_ context.Context, v int) (int, error) {
+			applied.Add(1)
+			if v == 10 {
+				return 0, failed
+			}
+			return v, nil
+		})
+		sink := &collector{}
+		err := within(t, func() error {
+			return pipeline.Run(t.Context(), pipeline.Through(endless, failing, pipeline.WithWorkers(workers)), sink)
+		})
+		if !errors.Is(err, failed) {
+			t.Errorf("%d workers: Run() error = %v, want %v", workers, err, failed)
+		}
+		// the other workers may each finish the value they hold, no more
+		if n := applied.Load(); n > int64(11+workers) {
+			t.Errorf("%d workers: %d values transformed, want the source stopped after 10", workers, n)
+		}
+		if sink.flushed {
+			t.Errorf("%d workers: sink flushed after an error, want only complete streams flushed", workers)
+		}
+	}
+}
+
+func TestRun_SinkError(t *testing.T) {
+	full := errors.New("disk full")
+	var written int
+	sink := pipeline.SinkFunc[int](func(context.Context, int) error {
+		if written++; written == 3 {
+			return full
+		}
+		return nil
+	})
+	err := within(t, func() error {
+		return pipeline.Run(t.Context(), pipeline.Through(endless, double, pipeline.WithWorkers(2)), sink)
+	})
+	if !errors.Is(err, full) || written != 3 {
+		t.Errorf("Run() error = %v after %d writes, want %v after 3", err, written, full)
+	}
+}
+
+func TestRun_SourceError(t *testing.T) {
+	down := errors.New("database down")
+	src := pipeline.SourceFunc[int](func(ctx context.Context, out chan<- int) error {
+		if err := pipeline.Send(ctx, out, 1); err != nil {
+			return err
+		}
+		return down
+	})
+	sink := &collector{}
+	err := within(t, func() error { return pipeline.Run(t.Context(), pipeline.Through(src, double), sink) })
+	if !errors.Is(err, down) || sink.flushed {
+		t.Errorf("Run() error = %v, flushed %t, want %v and no flush", err, sink.flushed, down)
+	}
+}
+
+func TestRun_Cancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(t.Context())
+	sink := pipeline.SinkFunc[int](func(_ context.Context, v int) error {
+		if v == 20 {
+			cancel()
+		}
+		return nil
+	})
+	err := within(t, func() error {
+		return pipeline.Run(ctx, pipeline.Through(endless, double, pipeline.WithWorkers(4)), sink)
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Run() error = %v, want %v", err, context.Canceled)
+	}
+}
@@ -0,0 +1,104 @@
+// Package policy decides how long an operation may take before it is given up.
+//
+// A timeout is an operational decision, and it changes: a fixed budget tuned
+// for one database is wrong for the next, and wrong again under load. Callers
+// depend on the TimeoutPolicy abstraction, so a fixed budget can be swapped
+// for one that follows the latency it observes - a new policy is a new type,
+// and the decorator applying it doesn't change (OCP).
+package policy
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"math"
+	"slices"
+	"sync"
+	"time"
+)
+
+// ErrTimeout returned when a call took longer than its policy allowed. It
+// matches context.DeadlineExceeded too, for callers that only check that.
+var ErrTimeout = fmt.Errorf("policy: timed out: %w", context.DeadlineExceeded)
+
+// TimeoutPolicy Abstraction - the time budget of one call of an operation
+type TimeoutPolicy interface {
+	Timeout(op string) time.Duration
+}
+
+// Observer Optional capability - policies that learn from how long calls took.
+// timedOut is set when the call was stopped by its timeout, so took is only a
+// lower bound.
+type Observer interface {
+	Observe(op string, took time.Duration, timedOut bool)
+}
+
+// Fixed TimeoutPolicy giving every operation the same budget
+type Fixed time.Duration
+
+func (f Fixed) Timeout(string) time.Duration { return time.Duration(f) }
+
+// Adaptive TimeoutPolicy following the latency of each operation: Multiplier
+// times the Percentile of its last Window calls, kept within [Min, Max]. Until
+// an operation has MinSamples calls, or Window if that is fewer, it gets
+// Initial. The zero value is ready to use; it must not be copied after first
+// use.
+type Adaptive struct {
+	Initial    time.Duration // 1s by default
+	Percentile float64       // 0.99 by default
+	Multiplier float64       // 2 by default
+	Window     int           // 100 by default
+	MinSamples int           // 20 by default
+	Min, Max   time.Duration // 10ms and 30s by default
+
+	mu      sync.Mutex
+	samples map[string]*window
+}
+
+// window The last calls of one operation, oldest overwritten first
+type window struct {
+	took []time.Duration
+	next int
+}
+
+func (a *Adaptive) Timeout(op string) time.Duration {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	w := a.samples[op]
+	// a window never holds more than Window calls, so more are never needed
+	if w == nil || len(w.took) < min(cmp.Or(a.MinSamples, 20), cmp.Or(a.Window, 100)) {
+		return cmp.Or(a.Initial, time.Second)
+	}
+	sorted := slices.Sorted(slices.Values(w.took))
+	p := cmp.Or(a.Percentile, 0.99)
+	rank := int(math.Ceil(p*float64(len(sorted)))) - 1
+	d := time.Duration(float64(sorted[min(max(rank, 0), len(sorted)-1)]) * cmp.Or(a.Multiplier, 2))
+	return min(max(d, cmp.Or(a.Min, 10*time.Millisecond)), cmp.Or(a.Max, 30*time.Second))
+}
+
+// Observe records a call. Timed-out calls are recorded too: they took at
+// least that long, and leaving them out would keep the timeout too short.
+func (a *Adaptive) Observe(op string, took time.Duration, timedOut bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.samples == nil {
+		a.samples = map[string]*window{}
+	}
+	w := a.samples[op]
+	if w == nil {
+		w = &window{}
+		a.samples[op] = w
+	}
+	if size := cmp.Or(a.Window, 100); len(w.took) < size {
+		w.took = append(w.took, took)
+		return
+	}
+	w.took[w.next] = took
+	w.next = (w.next + 1) % len(w.took)
+}
+
+var (
+	_ TimeoutPolicy = Fixed(0)
+	_ TimeoutPolicy = (*Adaptive)(nil)
+	_ Observer      = (*Adaptive)(nil)
+)
@@ -0,0 +1,74 @@
+package policy_test
+
+import (
+	"testing"
+	"time"
+
+	"go-solid/policy"
+)
+
+func TestFixed(t *testing.T) {
+	p := policy.Fixed(100 * time.Millisecond)
+	for _, op := range []string{"Save", "GetByName"} {
+		if got := p.Timeout(op); got != 100*time.Millisecond {
+			t.Errorf("Timeout(%s) = %s, want 100ms", op, got)
+		}
+	}
+}
+
+// observe records n calls of op taking took each.
+func observe(a *policy.Adaptive, op string, n int, took time.Duration) {
+	for range n {
+		a.Observe(op, took, false)
+	}
+}
+
+func TestAdaptive_Timeout(t *testing.T) {
+	tests := []struct {
+		name    string
+		policy  *policy.Adaptive
+		observe func(a *policy.Adaptive)
+		want    time.Duration
+	}{
+		{"defaults before any call", &policy.Adaptive{}, func(*policy.Adaptive) {}, time.Second},
+		{"initial until MinSamples", &policy.Adaptive{Initial: 300 * time.Millisecond, MinSamples: 10}, func(a *policy.Adaptive) {
+			observe(a, "GetByName", 9, 20*time.Millisecond)
+		}, 300 * time.Millisecond},
+		{"multiplier times the percentile", &policy.Adaptive{MinSamples: 10}, func(a *policy.Adaptive) {
+			observe(a, "GetByName", 9, 20*time.Millisecond)
+			observe(a, "GetByName", 1, 40*time.Millisecond)
+		}, 80 * time.Millisecond},
+		{"a lower percentile ignores the spike", &policy.Adaptive{MinSamples: 10, Percentile: 0.5, Multiplier: 3}, func(a *policy.Adaptive) {
+			observe(a, "GetByName", 9, 20*time.Millisecond)
+			observe(a, "GetByName", 1, 400*time.Millisecond)
+		}, 60 * time.Millisecond},
+		{"no lower than Min", &policy.Adaptive{MinSamples: 1, Min: 50 * time.Millisecond}, func(a *policy.Adaptive) {
+			observe(a, "GetByName", 5, time.Millisecond)
+		}, 50 * time.Millisecond},
+		{"no higher than Max", &policy.Adaptive{MinSamples: 1, Max: time.Second}, func(a *policy.Adaptive) {
+			observe(a, "GetByName", 5, 3*time.Second)
+		}, time.Second},
+		{"only the last Window calls count", &policy.Adaptive{MinSamples: 5, Window: 5}, func(a *policy.Adaptive) {
+			observe(a, "GetByName", 5, time.Second)
+			observe(a, "GetByName", 5, 30*time.Millisecond)
+		}, 60 * time.Millisecond},
+		{"a Window smaller than MinSamples fills up", &policy.Adaptive{Initial: time.Second, MinSamples: 20, Window: 5}, func(a *policy.Adaptive) {
+			observe(a, "GetByName", 50, 30*time.Millisecond)
+		}, 60 * time.Millisecond},
+		{"timed-out calls count", &policy.Adaptive{MinSamples: 2}, func(a *policy.Adaptive) {
+			a.Observe("GetByName", 20*time.Millisecond, false)
+			a.Observe("GetByName", 500*time.Millisecond, true)
+		}, time.Second},
+		{"each operation on its own", &policy.Adaptive{Initial: 300 * time.Millisecond, MinSamples: 1}, func(a *policy.Adaptive) {
+			observe(a, "Save", 5, 20*time.Millisecond)
+		}, 300 * time.Millisecond},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.observe(tt.policy)
+			if got := tt.policy.Timeout("GetByName"); got != tt.want {
+				t.Errorf("Timeout() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
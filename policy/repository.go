@@ -0,0 +1,161 @@
+package policy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"iter"
+
+	"go-solid/clock"
+	"go-solid/employee"
+	"go-solid/outbox"
+	"go-solid/spec"
+)
+
+// Repository Decorator giving every call to an employee.Repository the time
+// its TimeoutPolicy allows, and telling the policy how long the call took if
+// it is an Observer. Optional capabilities are forwarded with a timeout of
+// their own.
+type Repository struct {
+	next   employee.Repository
+	policy TimeoutPolicy
+	clock  clock.Clock
+}
+
+// NewRepository applies p to every call to next. Time is read from c, so a
+// clock.Fake drives both the timeouts and what the policy observes.
+func NewRepository(next employee.Repository, p TimeoutPolicy, c clock.Clock) *Repository {
+	return &Repository{next: next, policy: p, clock: c}
+}
+
+// Wrapped returns the repository the timeouts are applied to.
+func (r *Repository) Wrapped() any { return r.next }
+
+func (r *Repository) Save(ctx context.Context, emp employee.Employee) error {
+	return exec(ctx, r, "Save", func(ctx context.Context) error { return r.next.Save(ctx, emp) })
+}
+
+func (r *Repository) GetByName(ctx context.Context, name string) (employee.Employee, error) {
+	return call(ctx, r, "GetByName", func(ctx context.Context) (employee.Employee, error) { return r.next.GetByName(ctx, name) })
+}
+
+func (r *Repository) GetByID(ctx context.Context, id employee.ID) (employee.Employee, error) {
+	return call(ctx, r, "GetByID", func(ctx context.Context) (employee.Employee, error) { return employee.GetByID(ctx, r.next, id) })
+}
+
+// All is not timed: how long the loop runs is up to the caller, not the
+// backend.
+func (r *Repository) All(ctx context.Context) iter.Seq2[employee.Employee, error] {
+	return employee.All(ctx, r.next)
+}
+
+// SaveAll gives the whole batch one budget, as one round trip would get.
+func (r *Repository) SaveAll(ctx context.Context, emps iter.Seq[employee.Employee]) error {
+	return exec(ctx, r, "SaveAll", func(ctx context.Context) error { return employee.SaveAll(ctx, r.next, emps) })
+}
+
+func (r *Repository) Update(ctx context.Context, emp employee.Employee, expectedVersion int) (employee.Employee, error) {
+	u, ok := r.next.(employee.Updater)
+	if !ok {
+		return employee.Employee{}, errors.ErrUnsupported
+	}
+	return call(ctx, r, "Update", func(ctx context.Context) (employee.Employee, error) { return u.Update(ctx, emp, expectedVersion) })
+}
+
+func (r *Repository) SoftDelete(ctx context.Context, name string) error {
+	d, ok := r.next.(employee.SoftDeleter)
+	if !ok {
+		return errors.ErrUnsupported
+	}
+	return exec(ctx, r, "SoftDelete", func(ctx context.Context) error { return d.SoftDelete(ctx, name) })
+}
+
+func (r *Repository) Restore(ctx context.Context, name string) error {
+	d, ok := r.next.(employee.SoftDeleter)
+	if !ok {
+		return errors.ErrUnsupported
+	}
+	return exec(ctx, r, "Restore", func(ctx context.Context) error { return d.Restore(ctx, name) })
+}
+
+func (r *Repository) History(ctx context.Context, name string) ([]employee.Employee, error) {
+	v, ok := r.next.(employee.Versioned)
+	if !ok {
+		return nil, errors.ErrUnsupported
+	}
+	return call(ctx, r, "History", func(ctx context.Context) ([]employee.Employee, error) { return v.History(ctx, name) })
+}
+
+func (r *Repository) List(ctx context.Context, filter employee.Filter, page employee.Page) (employee.PageResult, error) {
+	q, ok := r.next.(employee.QueryRepository)
+	if !ok {
+		return employee.PageResult{}, errors.ErrUnsupported
+	}
+	return call(ctx, r, "List", func(ctx context.Context) (employee.PageResult, error) { return q.List(ctx, filter, page) })
+}
+
+func (r *Repository) Matching(ctx context.Context, s spec.Specification[employee.Employee]) ([]employee.Employee, error) {
+	m, ok := r.next.(employee.SpecificationRepository)
+	if !ok {
+		return nil, errors.ErrUnsupported
+	}
+	return call(ctx, r, "Matching", func(ctx context.Context) ([]employee.Employee, error) { return m.Matching(ctx, s) })
+}
+
+func (r *Repository) SaveWithOutbox(ctx context.Context, emp employee.Employee, msgs []outbox.Message) error {
+	o, ok := r.next.(employee.OutboxRepository)
+	if !ok {
+		return errors.ErrUnsupported
+	}
+	return exec(ctx, r, "SaveWithOutbox", func(ctx context.Context) error { return o.SaveWithOutbox(ctx, emp, msgs) })
+}
+
+func exec(ctx context.Context, r *Repository, op string, f func(context.Context) error) error {
+	_, err := call(ctx, r, op, func(ctx context.Context) (struct{}, error) { return struct{}{}, f(ctx) })
+	return err
+}
+
+// call runs f with a context cancelled once op's budget has passed on r's
+// clock. A call returning after its budget is reported as timed out even if
+// it succeeded: a remote caller would have stopped waiting for it too.
+func call[T any](ctx context.Context, r *Repository, op string, f func(context.Context) (T, error)) (T, error) {
+	budget := r.policy.Timeout(op)
+	timeout := fmt.Errorf("%s after %s: %w", op, budget, ErrTimeout)
+	ctx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+	stop := make(chan struct{})
+	defer close(stop)
+	go func() {
+		select {
+		case <-r.clock.After(budget):
+			cancel(timeout)
+		case <-stop:
+		}
+	}()
+
+	start := r.clock.Now()
+	v, err := f(ctx)
+	took := r.clock.Now().Sub(start)
+	timedOut := took >= budget || errors.Is(context.Cause(ctx), ErrTimeout)
+	if o, ok := r.policy.(Observer); ok {
+		o.Observe(op, took, timedOut)
+	}
+	if timedOut {
+		var zero T
+		return zero, timeout
+	}
+	return v, err
+}
+
+var (
+	_ employee.Repository              = (*Repository)(nil)
+	_ employee.BulkSaver               = (*Repository)(nil)
+	_ employee.SoftDeleter             = (*Repository)(nil)
+	_ employee.Updater                 = (*Repository)(nil)
+	_ employee.Iterable                = (*Repository)(nil)
+	_ employee.IDRepository            = (*Repository)(nil)
+	_ employee.Versioned               = (*Repository)(nil)
+	_ employee.QueryRepository         = (*Repository)(nil)
+	_ employee.SpecificationRepository = (*Repository)(nil)
+	_ employee.OutboxRepository        = (*Repository)(nil)
+)
@@ -0,0 +1,149 @@
+package policy_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"go-solid/clock"
+	"go-solid/employee"
+	"go-solid/employee/employeetest"
+	"go-solid/employee/memory"
+	"go-solid/policy"
+)
+
+var start = time.Date(2026, time.March, 2, 9, 0, 0, 0, time.UTC)
+
+// slow Takes latency to answer on a fake clock, or until its context is
+// cancelled if blocked
+type slow struct {
+	*memory.Repository
+	clock   *clock.Fake
+	latency time.Duration
+	blocked bool
+}
+
+func (s *slow) GetByName(ctx context.Context, name string) (employee.Employee, error) {
+	if s.blocked {
+		<-ctx.Done()
+		return employee.Employee{}, ctx.Err()
+	}
+	s.clock.Advance(s.latency)
+	return s.Repository.GetByName(ctx, name)
+}
+
+// recorder A Fixed policy remembering what it observed
+type recorder struct {
+	policy.Fixed
+	took     []time.Duration
+	timedOut []bool
+}
+
+func (r *recorder) Observe(_ string, took time.Duration, timedOut bool) {
+	r.took = append(r.took, took)
+	r.timedOut = append(r.timedOut, timedOut)
+}
+
+func setup(t *testing.T, p policy.TimeoutPolicy) (*policy.Repository, *slow) {
+	t.Helper()
+	backend := &slow{Repository: memory.New(), clock: clock.NewFake(start)}
+	if err := backend.Save(t.Context(), employee.Employee{Name: "Ali"}); err != nil {
+		t.Fatal(err)
+	}
+	return policy.NewRepository(backend, p, backend.clock), backend
+}
+
+func TestRepository_WithinBudget(t *testing.T) {
+	p := &recorder{Fixed: policy.Fixed(100 * time.Millisecond)}
+	repo, backend := setup(t, p)
+	backend.latency = 40 * time.Millisecond
+	if _, err := repo.GetByName(t.Context(), "Ali"); err != nil {
+		t.Fatalf("GetByName() error = %v", err)
+	}
+	if len(p.took) != 1 || p.took[0] != 40*time.Millisecond || p.timedOut[0] {
+		t.Errorf("observed %v timed out %v, want one call of 40ms in time", p.took, p.timedOut)
+	}
+}
+
+func TestRepository_AnsweredTooLate(t *testing.T) {
+	p := &recorder{Fixed: policy.Fixed(100 * time.Millisecond)}
+	repo, backend := setup(t, p)
+	backend.latency = 150 * time.Millisecond
+	_, err := repo.GetByName(t.Context(), "Ali")
+	if !errors.Is(err, policy.ErrTimeout) || !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("GetByName() error = %v, want %v matching context.DeadlineExceeded", err, policy.ErrTimeout)
+	}
+	if len(p.took) != 1 || p.took[0] != 150*time.Millisecond || !p.timedOut[0] {
+		t.Errorf("observed %v timed out %v, want one call of 150ms that timed out", p.took, p.timedOut)
+	}
+}
+
+func TestRepository_CancelsWhenTheBudgetRunsOut(t *testing.T) {
+	p := &recorder{Fixed: policy.Fixed(100 * time.Millisecond)}
+	repo, backend := setup(t, p)
+	backend.blocked = true
+	errc := make(chan error, 1)
+	go func() {
+		_, err := repo.GetByName(t.Context(), "Ali")
+		errc <- err
+	}()
+	backend.clock.BlockUntil(1)
+	backend.clock.Advance(99 * time.Millisecond)
+	select {
+	case err := <-errc:
+		t.Fatalf("GetByName() returned %v before its budget ran out", err)
+	default:
+	}
+	backend.clock.Advance(time.Millisecond)
+	if err := <-errc; !errors.Is(err, policy.ErrTimeout) {
+		t.Errorf("GetByName() error = %v, want %v", err, policy.ErrTimeout)
+	}
+	if len(p.timedOut) != 1 || !p.timedOut[0] {
+		t.Errorf("observed timed out %v, want the call reported as timed out", p.timedOut)
+	}
+}
+
+func TestRepository_AdaptsToASlowdown(t *testing.T) {
+	p := &policy.Adaptive{Initial: 100 * time.Millisecond, Window: 20, MinSamples: 10, Min: 50 * time.Millisecond}
+	repo, backend := setup(t, p)
+	timeouts := func(n int, latency time.Duration) int {
+		backend.latency = latency
+		count := 0
+		for range n {
+			if _, err := repo.GetByName(t.Context(), "Ali"); errors.Is(err, policy.ErrTimeout) {
+				count++
+			}
+		}
+		return count
+	}
+	if got := timeouts(20, 30*time.Millisecond); got != 0 {
+		t.Errorf("%d calls timed out while the database was fast, want none", got)
+	}
+	if got := timeouts(40, 150*time.Millisecond); got == 0 || got > 10 {
+		t.Errorf("%d of 40 calls timed out through a slowdown, want some until the budget grew", got)
+	}
+	if got := p.Timeout("GetByName"); got != 300*time.Millisecond {
+		t.Errorf("Timeout() after the slowdown = %s, want 300ms", got)
+	}
+	timeouts(20, 30*time.Millisecond)
+	if got := p.Timeout("GetByName"); got != 60*time.Millisecond {
+		t.Errorf("Timeout() after recovering = %s, want back down to 60ms", got)
+	}
+}
+
+func TestRepository_Unsupported(t *testing.T) {
+	repo := policy.NewRepository(employee.NopRepository{}, policy.Fixed(time.Second), clock.NewFake(start))
+	if _, err := repo.History(t.Context(), "Ali"); !errors.Is(err, errors.ErrUnsupported) {
+		t.Errorf("History() error = %v, want %v", err, errors.ErrUnsupported)
+	}
+	if err := repo.SoftDelete(t.Context(), "Ali"); !errors.Is(err, errors.ErrUnsupported) {
+		t.Errorf("SoftDelete() error = %v, want %v", err, errors.ErrUnsupported)
+	}
+}
+
+func TestRepository(t *testing.T) {
+	employeetest.TestRepository(t, func(*testing.T) employee.Repository {
+		return policy.NewRepository(memory.New(), policy.Fixed(time.Minute), clock.Real{})
+	})
+}
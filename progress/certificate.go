@@ -0,0 +1,158 @@
+package progress
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"time"
+)
+
+var (
+	// ErrNothingCompleted returned when there is nothing to certify
+	ErrNothingCompleted = errors.New("progress: nothing completed yet")
+	// ErrBadSignature returned when a certificate was altered or signed by another key
+	ErrBadSignature = errors.New("progress: certificate signature does not verify")
+)
+
+// Certificate Signed record of what a learner completed. The signature
+// covers every other field, so it survives being mailed around as JSON;
+// anyone holding the issuer's public key can check it.
+type Certificate struct {
+	Learner   string    `json:"learner"`
+	Lessons   []string  `json:"lessons"`
+	Entries   []Entry   `json:"entries"`
+	IssuedAt  time.Time `json:"issued_at"`
+	KeyID     string    `json:"key_id"`
+	Signature []byte    `json:"signature,omitempty"`
+}
+
+// Issue certifies entries for learner, signed with key.
+func Issue(learner string, entries []Entry, key ed25519.PrivateKey, now time.Time) (Certificate, error) {
+	if len(entries) == 0 {
+		return Certificate{}, ErrNothingCompleted
+	}
+	c := Certificate{
+		Learner:  learner,
+		Entries:  slices.Clone(entries),
+		IssuedAt: now.UTC().Truncate(time.Second),
+		KeyID:    KeyID(key.Public().(ed25519.PublicKey)),
+	}
+	for _, e := range entries {
+		if e.Kind == Lesson {
+			c.Lessons = append(c.Lessons, e.ID)
+		}
+	}
+	msg, err := c.signed()
+	if err != nil {
+		return Certificate{}, err
+	}
+	c.Signature = ed25519.Sign(key, msg)
+	return c, nil
+}
+
+// Verify checks c was signed by pub and not altered since.
+func Verify(c Certificate, pub ed25519.PublicKey) error {
+	if c.KeyID != KeyID(pub) {
+		return fmt.Errorf("%w: signed by key %s, not %s", ErrBadSignature, c.KeyID, KeyID(pub))
+	}
+	msg, err := c.signed()
+	if err != nil {
+		return err
+	}
+	if !ed25519.Verify(pub, msg, c.Signature) {
+		return ErrBadSignature
+	}
+	return nil
+}
+
+// signed is the message the signature covers: c as JSON, without the signature.
+func (c Certificate) signed() ([]byte, error) {
+	c.Signature = nil
+	return json.Marshal(c)
+}
+
+// KeyID A short fingerprint of a public key, printed on certificates
+func KeyID(pub ed25519.PublicKey) string {
+	sum := sha256.Sum256(pub)
+	return hex.EncodeToString(sum[:8])
+}
+
+// Keys
+
+// GenerateKey writes a new signing key pair as PEM: the private key to
+// path, the public key to path + ".pub".
+func GenerateKey(path string) (ed25519.PublicKey, error) {
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	der, err := x509.MarshalPKCS8PrivateKey(priv)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), 0o600); err != nil {
+		return nil, err
+	}
+	der, err = x509.MarshalPKIXPublicKey(pub)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.WriteFile(path+".pub", pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}), 0o644); err != nil {
+		return nil, err
+	}
+	return pub, nil
+}
+
+// LoadPrivateKey reads a key written by GenerateKey.
+func LoadPrivateKey(path string) (ed25519.PrivateKey, error) {
+	der, err := readPEM(path, "PRIVATE KEY")
+	if err != nil {
+		return nil, err
+	}
+	key, err := x509.ParsePKCS8PrivateKey(der)
+	if err != nil {
+		return nil, fmt.Errorf("progress: %s: %w", path, err)
+	}
+	priv, ok := key.(ed25519.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("progress: %s: not an ed25519 key", path)
+	}
+	return priv, nil
+}
+
+// LoadPublicKey reads the ".pub" half written by GenerateKey.
+func LoadPublicKey(path string) (ed25519.PublicKey, error) {
+	der, err := readPEM(path, "PUBLIC KEY")
+	if err != nil {
+		return nil, err
+	}
+	key, err := x509.ParsePKIXPublicKey(der)
+	if err != nil {
+		return nil, fmt.Errorf("progress: %s: %w", path, err)
+	}
+	pub, ok := key.(ed25519.PublicKey)
+	if !ok {
+		return nil, fmt.Errorf("progress: %s: not an ed25519 key", path)
+	}
+	return pub, nil
+}
+
+func readPEM(path, typ string) ([]byte, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("progress: %w", err)
+	}
+	block, _ := pem.Decode(data)
+	if block == nil || block.Type != typ {
+		return nil, fmt.Errorf("progress: %s: no %s block", path, typ)
+	}
+	return block.Bytes, nil
+}
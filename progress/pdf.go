@@ -0,0 +1,93 @@
+package progress
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// WritePDF renders c as a one-page PDF. The page is for printing and
+// framing: the signature is shown but a PDF reader won't check it - the
+// JSON certificate is what `solid progress verify` accepts.
+//
+// The PDF is written by hand (one page, built-in Helvetica), keeping the
+// module free of dependencies.
+func WritePDF(w io.Writer, c Certificate) error {
+	var page bytes.Buffer
+	y := 720
+	text := func(size int, s string) {
+		fmt.Fprintf(&page, "BT /F1 %d Tf 72 %d Td (%s) Tj ET\n", size, y, pdfString(s))
+		y -= size + size/2
+	}
+	text(28, "Certificate of Completion")
+	y -= 20
+	text(14, "This certifies that")
+	text(22, c.Learner)
+	text(14, "has completed the go-solid course material:")
+	y -= 6
+	for _, id := range c.Lessons {
+		text(12, "    lesson "+id)
+	}
+	others := map[Kind]int{}
+	for _, e := range c.Entries {
+		if e.Kind != Lesson {
+			others[e.Kind]++
+		}
+	}
+	for _, k := range []Kind{Checkpoint, Exercise, Quiz} {
+		if others[k] > 0 {
+			text(12, fmt.Sprintf("    %d %s(s)", others[k], k))
+		}
+	}
+	y -= 20
+	text(10, "Issued "+c.IssuedAt.Format("2 January 2006")+" - key "+c.KeyID)
+	sig := base64.StdEncoding.EncodeToString(c.Signature)
+	for len(sig) > 64 {
+		text(8, sig[:64])
+		sig = sig[64:]
+	}
+	text(8, sig)
+
+	objects := []string{
+		"<< /Type /Catalog /Pages 2 0 R >>",
+		"<< /Type /Pages /Kids [3 0 R] /Count 1 >>",
+		"<< /Type /Page /Parent 2 0 R /MediaBox [0 0 612 792] /Resources << /Font << /F1 4 0 R >> >> /Contents 5 0 R >>",
+		"<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>",
+		fmt.Sprintf("<< /Length %d >>\nstream\n%sendstream", page.Len(), page.String()),
+	}
+	var out bytes.Buffer
+	out.WriteString("%PDF-1.4\n")
+	offsets := make([]int, len(objects))
+	for i, obj := range objects {
+		offsets[i] = out.Len()
+		fmt.Fprintf(&out, "%d 0 obj\n%s\nendobj\n", i+1, obj)
+	}
+	xref := out.Len()
+	fmt.Fprintf(&out, "xref\n0 %d\n0000000000 65535 f \n", len(objects)+1)
+	for _, off := range offsets {
+		fmt.Fprintf(&out, "%010d 00000 n \n", off)
+	}
+	fmt.Fprintf(&out, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(objects)+1, xref)
+	_, err := w.Write(out.Bytes())
+	return err
+}
+
+// pdfString escapes s for a PDF literal string; characters outside
+// printable ASCII become '?', as the built-in font can't be relied on for them.
+func pdfString(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		switch {
+		case r == '(' || r == ')' || r == '\\':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case r < 0x20 || r > 0x7e:
+			b.WriteByte('?')
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,199 @@
+// Package progress records what a learner has completed and issues signed
+// completion certificates.
+//
+// An Entry is anything completable - a lesson, one of its checkpoints, an
+// exercise, a quiz - identified by kind and ID, so new kinds of activity
+// need no change here. A Store keeps the entries; File keeps them in the
+// learner's config directory.
+package progress
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"time"
+)
+
+// Kind What sort of activity was completed
+type Kind string
+
+const (
+	Lesson     Kind = "lesson"
+	Checkpoint Kind = "checkpoint"
+	Exercise   Kind = "exercise"
+	Quiz       Kind = "quiz"
+)
+
+// Entry One completed activity. Score is optional (quizzes, graded exercises).
+type Entry struct {
+	Kind        Kind      `json:"kind"`
+	ID          string    `json:"id"` // e.g. "ocp" or "ocp/01-switch-on-role"
+	Score       *float64  `json:"score,omitempty"`
+	CompletedAt time.Time `json:"completed_at"`
+}
+
+// Store Abstraction - where completions are kept. Recording an activity
+// again keeps the first completion and the best score. Hints are counted
+// per exercise as the highest level revealed.
+type Store interface {
+	Record(ctx context.Context, e Entry) error
+	Entries(ctx context.Context) ([]Entry, error)
+	RecordHint(ctx context.Context, exercise string, level int) error
+	Hints(ctx context.Context) (map[string]int, error)
+}
+
+// Completed reports whether entries include kind/id.
+func Completed(entries []Entry, kind Kind, id string) bool {
+	return slices.ContainsFunc(entries, func(e Entry) bool { return e.Kind == kind && e.ID == id })
+}
+
+// state Everything a Store keeps
+type state struct {
+	Entries []Entry        `json:"entries"`
+	Hints   map[string]int `json:"hints,omitempty"`
+}
+
+// record adds e under the Store rules.
+func (s *state) record(e Entry) {
+	i := slices.IndexFunc(s.Entries, func(x Entry) bool { return x.Kind == e.Kind && x.ID == e.ID })
+	if i < 0 {
+		s.Entries = append(s.Entries, e)
+		return
+	}
+	if e.Score != nil && (s.Entries[i].Score == nil || *e.Score > *s.Entries[i].Score) {
+		s.Entries[i].Score = e.Score
+	}
+}
+
+func (s *state) recordHint(exercise string, level int) {
+	if s.Hints == nil {
+		s.Hints = map[string]int{}
+	}
+	s.Hints[exercise] = max(s.Hints[exercise], level)
+}
+
+// Memory In-process Store
+type Memory struct {
+	mu    sync.Mutex
+	state state
+}
+
+func (m *Memory) Record(ctx context.Context, e Entry) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.state.record(e)
+	return nil
+}
+
+func (m *Memory) Entries(ctx context.Context) ([]Entry, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return slices.Clone(m.state.Entries), nil
+}
+
+func (m *Memory) RecordHint(ctx context.Context, exercise string, level int) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.state.recordHint(exercise, level)
+	return nil
+}
+
+func (m *Memory) Hints(ctx context.Context) (map[string]int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return maps.Clone(m.state.Hints), nil
+}
+
+// File Store in a JSON file, rewritten on every change
+type File struct {
+	Path string
+	mu   sync.Mutex
+}
+
+// DefaultPath is progress.json under the user's config directory.
+func DefaultPath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("progress: %w", err)
+	}
+	return filepath.Join(dir, "solid", "progress.json"), nil
+}
+
+func (f *File) Record(ctx context.Context, e Entry) error {
+	return f.update(func(s *state) { s.record(e) })
+}
+
+func (f *File) Entries(ctx context.Context) ([]Entry, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	s, err := f.read()
+	return s.Entries, err
+}
+
+func (f *File) RecordHint(ctx context.Context, exercise string, level int) error {
+	return f.update(func(s *state) { s.recordHint(exercise, level) })
+}
+
+func (f *File) Hints(ctx context.Context) (map[string]int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	s, err := f.read()
+	return s.Hints, err
+}
+
+func (f *File) update(change func(*state)) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	s, err := f.read()
+	if err != nil {
+		return err
+	}
+	change(&s)
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(f.Path), 0o755); err != nil {
+		return fmt.Errorf("progress: %w", err)
+	}
+	// write then rename, so a crash never leaves half a file
+	tmp := f.Path + ".tmp"
+	if err := os.WriteFile(tmp, append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("progress: %w", err)
+	}
+	return os.Rename(tmp, f.Path)
+}
+
+func (f *File) read() (state, error) {
+	data, err := os.ReadFile(f.Path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return state{}, nil
+	}
+	if err != nil {
+		return state{}, fmt.Errorf("progress: %w", err)
+	}
+	var s state
+	// the first files held only the entries, as a bare array
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] == '[' {
+		err = json.Unmarshal(data, &s.Entries)
+	} else {
+		err = json.Unmarshal(data, &s)
+	}
+	if err != nil {
+		return state{}, fmt.Errorf("progress: %s: %w", f.Path, err)
+	}
+	return s, nil
+}
+
+var (
+	_ Store = (*Memory)(nil)
+	_ Store = (*File)(nil)
+)
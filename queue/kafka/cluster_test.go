@@ -0,0 +1,29 @@
+//go:build kafka
+
+package kafka_test
+
+import (
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"go-solid/queue/kafka"
+	"go-solid/queue/queuetest"
+)
+
+// TestBroker_Cluster runs the contract against the cluster KAFKA_BROKERS
+// lists, comma-separated. Every case uses topics of its own, created on
+// first use and left on the cluster.
+func TestBroker_Cluster(t *testing.T) {
+	seeds := os.Getenv("KAFKA_BROKERS")
+	if seeds == "" {
+		t.Skip("KAFKA_BROKERS is not set")
+	}
+	queuetest.TestBroker(t, func(t *testing.T) queuetest.Broker {
+		b := kafka.New(strings.Split(seeds, ","))
+		b.MaxAttempts, b.Backoff = queuetest.MaxAttempts, time.Millisecond
+		t.Cleanup(b.Close)
+		return b
+	})
+}
@@ -0,0 +1,256 @@
+// Package kafka is a queue broker backed by Apache Kafka, spoken to with
+// franz-go. A queue is a topic; the consumers of a queue form a consumer
+// group, so they compete for its partitions and each message goes to one of
+// them.
+//
+// It is a module of its own, so only a build that uses it downloads the
+// client and go-solid's own packages import nothing beyond the standard
+// library:
+//
+//	cd queue/kafka && go test ./...
+package kafka
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/twmb/franz-go/pkg/kgo"
+
+	"go-solid/clock"
+	"go-solid/id"
+	"go-solid/queue"
+)
+
+// Headers the broker keeps its own bookkeeping in, next to the message's
+const (
+	headerID          = "x-message-id"
+	headerPublishedAt = "x-published-at"
+	// headerRetries counts the failed attempts before this delivery
+	headerRetries = "x-retries"
+)
+
+// Broker Low-level module - Kafka topics as queues. Implements both
+// queue.Producer and queue.Consumer.
+//
+// Kafka can't hand one message back: a consumer only moves its offset
+// forward. So a failed message is published again at the end of its topic,
+// with its attempt counted, after the backoff; only then is its offset
+// committed. A consumer waiting out a backoff takes nothing else from its
+// partitions meanwhile.
+type Broker struct {
+	// MaxAttempts before a message is dead-lettered; defaults to 3.
+	MaxAttempts int
+	// Backoff before the first redelivery, doubled on each further one; defaults to 100ms.
+	Backoff time.Duration
+	Clock   clock.Clock
+	// Group prefixes the consumer group of each queue's consumers; "go-solid" by default.
+	Group string
+
+	opts []kgo.Opt
+	ids  id.Generator
+
+	mu       sync.Mutex
+	producer *kgo.Client
+}
+
+// New creates a broker for the cluster seeds are brokers of. opts are
+// passed to every client it creates, e.g. for TLS or SASL. Topics are
+// created when first used, if the cluster allows it.
+func New(seeds []string, opts ...kgo.Opt) *Broker {
+	return &Broker{
+		opts: append([]kgo.Opt{kgo.SeedBrokers(seeds...), kgo.AllowAutoTopicCreation()}, opts...),
+		ids:  id.UUIDv7{},
+	}
+}
+
+func (b *Broker) clock() clock.Clock {
+	if b.Clock == nil {
+		return clock.Real{}
+	}
+	return b.Clock
+}
+
+// client returns the client Publish shares, creating it on first use.
+func (b *Broker) client() (*kgo.Client, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.producer == nil {
+		cl, err := kgo.NewClient(b.opts...)
+		if err != nil {
+			return nil, fmt.Errorf("kafka: %w", err)
+		}
+		b.producer = cl
+	}
+	return b.producer, nil
+}
+
+// Publish waits until every message is written to the topic name.
+func (b *Broker) Publish(ctx context.Context, name string, msgs ...queue.Message) error {
+	cl, err := b.client()
+	if err != nil {
+		return err
+	}
+	records := make([]*kgo.Record, len(msgs))
+	for i, msg := range msgs {
+		if msg.ID == "" {
+			msg.ID = b.ids.NewID()
+		}
+		msg.PublishedAt = b.clock().Now()
+		records[i] = record(name, msg, 0)
+	}
+	return produce(ctx, cl, name, records...)
+}
+
+func produce(ctx context.Context, cl *kgo.Client, name string, records ...*kgo.Record) error {
+	if err := cl.ProduceSync(ctx, records...).FirstErr(); err != nil {
+		return fmt.Errorf("kafka: publish to %s: %w", name, err)
+	}
+	return nil
+}
+
+// record encodes msg for topic, after retries failed attempts.
+func record(topic string, msg queue.Message, retries int) *kgo.Record {
+	r := &kgo.Record{Topic: topic, Value: msg.Body}
+	if msg.Key != "" {
+		r.Key = []byte(msg.Key)
+	}
+	for k, v := range msg.Headers {
+		r.Headers = append(r.Headers, kgo.RecordHeader{Key: k, Value: []byte(v)})
+	}
+	r.Headers = append(r.Headers,
+		kgo.RecordHeader{Key: headerID, Value: []byte(msg.ID)},
+		kgo.RecordHeader{Key: headerPublishedAt, Value: []byte(msg.PublishedAt.Format(time.RFC3339Nano))})
+	if retries > 0 {
+		r.Headers = append(r.Headers, kgo.RecordHeader{Key: headerRetries, Value: []byte(strconv.Itoa(retries))})
+	}
+	return r
+}
+
+// message decodes r, delivered for the attempt after the ones it records.
+func message(r *kgo.Record) queue.Message {
+	msg := queue.Message{Key: string(r.Key), Body: r.Value, Attempt: 1}
+	for _, h := range r.Headers {
+		switch h.Key {
+		case headerID:
+			msg.ID = string(h.Value)
+		case headerPublishedAt:
+			msg.PublishedAt, _ = time.Parse(time.RFC3339Nano, string(h.Value))
+		case headerRetries:
+			retries, _ := strconv.Atoi(string(h.Value))
+			msg.Attempt += retries
+		default:
+			if msg.Headers == nil {
+				msg.Headers = make(map[string]string)
+			}
+			msg.Headers[h.Key] = string(h.Value)
+		}
+	}
+	return msg
+}
+
+// Consume handles messages until ctx is done, then returns nil. Offsets are
+// committed once the messages of a poll are handled, so messages a consumer
+// took but didn't finish go to another one.
+func (b *Broker) Consume(ctx context.Context, name string, h queue.Handler) error {
+	cl, err := kgo.NewClient(slices.Concat(b.opts, []kgo.Opt{
+		kgo.ConsumerGroup(b.group(name)),
+		kgo.ConsumeTopics(name),
+		kgo.DisableAutoCommit(),
+		// no rebalance between handling a poll and committing it, which
+		// would hand the same messages to another consumer
+		kgo.BlockRebalanceOnPoll(),
+	})...)
+	if err != nil {
+		return fmt.Errorf("kafka: %w", err)
+	}
+	defer cl.CloseAllowingRebalance()
+	for {
+		fetches := cl.PollFetches(ctx)
+		if ctx.Err() != nil || fetches.IsClientClosed() {
+			return nil
+		}
+		if err := fetches.Err(); err != nil {
+			return fmt.Errorf("kafka: consume %s: %w", name, err)
+		}
+		records := fetches.Records()
+		for _, r := range records {
+			msg := message(r)
+			if err := h.Handle(ctx, msg); err != nil {
+				if err := b.failed(ctx, cl, name, msg, err); err != nil {
+					return ignoreDone(ctx, err)
+				}
+			}
+		}
+		if err := cl.CommitRecords(ctx, records...); err != nil {
+			return ignoreDone(ctx, fmt.Errorf("kafka: commit %s: %w", name, err))
+		}
+		cl.AllowRebalance()
+	}
+}
+
+func (b *Broker) group(name string) string {
+	if b.Group == "" {
+		return "go-solid." + name
+	}
+	return b.Group + "." + name
+}
+
+// ignoreDone drops err when ctx is done: the consumer was stopped, and what
+// it didn't commit is delivered again.
+func ignoreDone(ctx context.Context, err error) error {
+	if ctx.Err() != nil {
+		return nil
+	}
+	return err
+}
+
+// failed publishes msg again, after the backoff, or to the dead-letter
+// topic after MaxAttempts or on a queue.ErrPermanent failure.
+func (b *Broker) failed(ctx context.Context, cl *kgo.Client, name string, msg queue.Message, err error) error {
+	maxAttempts := b.MaxAttempts
+	if maxAttempts <= 0 {
+		maxAttempts = 3
+	}
+	if errors.Is(err, queue.ErrPermanent) || msg.Attempt >= maxAttempts {
+		msg.Headers = maps.Clone(msg.Headers)
+		if msg.Headers == nil {
+			msg.Headers = make(map[string]string)
+		}
+		msg.Headers["x-error"] = err.Error()
+		msg.Headers["x-attempts"] = fmt.Sprint(msg.Attempt)
+		dead := queue.DeadLetter(name)
+		return produce(ctx, cl, dead, record(dead, msg, 0))
+	}
+	backoff := b.Backoff
+	if backoff <= 0 {
+		backoff = 100 * time.Millisecond
+	}
+	select {
+	case <-b.clock().After(backoff << (msg.Attempt - 1)):
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	return produce(ctx, cl, name, record(name, msg, msg.Attempt))
+}
+
+// Close closes the client Publish shares. Consumers close their own when
+// their context is done.
+func (b *Broker) Close() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.producer != nil {
+		b.producer.Close()
+		b.producer = nil
+	}
+}
+
+var (
+	_ queue.Producer = (*Broker)(nil)
+	_ queue.Consumer = (*Broker)(nil)
+)
@@ -0,0 +1,27 @@
+package kafka_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/twmb/franz-go/pkg/kfake"
+
+	"go-solid/queue/kafka"
+	"go-solid/queue/queuetest"
+)
+
+// TestBroker runs the contract against kfake, a Kafka cluster in the test
+// process, with topics of three partitions for the consumers to share.
+func TestBroker(t *testing.T) {
+	cluster, err := kfake.NewCluster(kfake.NumBrokers(1), kfake.AllowAutoTopicCreation(), kfake.DefaultNumPartitions(3))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(cluster.Close)
+	queuetest.TestBroker(t, func(t *testing.T) queuetest.Broker {
+		b := kafka.New(cluster.ListenAddrs())
+		b.MaxAttempts, b.Backoff = queuetest.MaxAttempts, time.Millisecond
+		t.Cleanup(b.Close)
+		return b
+	})
+}
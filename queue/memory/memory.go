@@ -0,0 +1,128 @@
+// Package memory is a channel-backed queue broker, for tests and demos. It
+// keeps nothing across restarts.
+package memory
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"sync"
+	"time"
+
+	"go-solid/clock"
+	"go-solid/queue"
+)
+
+// Broker Low-level module - one buffered channel per queue. Implements both
+// queue.Producer and queue.Consumer.
+type Broker struct {
+	// MaxAttempts before a message is dead-lettered; defaults to 3.
+	MaxAttempts int
+	// Backoff before the first redelivery, doubled on each further one; defaults to 100ms.
+	Backoff time.Duration
+	Clock   clock.Clock
+
+	buffer int
+	mu     sync.Mutex
+	queues map[string]chan queue.Message
+	seq    int
+}
+
+// New creates a broker whose queues hold up to buffer messages; Publish
+// blocks while a queue is full.
+func New(buffer int) *Broker {
+	return &Broker{buffer: buffer, queues: make(map[string]chan queue.Message)}
+}
+
+func (b *Broker) channel(name string) chan queue.Message {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	ch, ok := b.queues[name]
+	if !ok {
+		ch = make(chan queue.Message, b.buffer)
+		b.queues[name] = ch
+	}
+	return ch
+}
+
+func (b *Broker) clock() clock.Clock {
+	if b.Clock == nil {
+		return clock.Real{}
+	}
+	return b.Clock
+}
+
+func (b *Broker) Publish(ctx context.Context, name string, msgs ...queue.Message) error {
+	ch := b.channel(name)
+	for _, msg := range msgs {
+		b.mu.Lock()
+		b.seq++
+		if msg.ID == "" {
+			msg.ID = fmt.Sprintf("msg-%d", b.seq)
+		}
+		b.mu.Unlock()
+		msg.Attempt, msg.PublishedAt = 0, b.clock().Now()
+		select {
+		case ch <- msg:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return nil
+}
+
+// Consume handles messages until ctx is done, then returns nil. A message
+// whose handler fails is redelivered after a backoff, and dead-lettered
+// after MaxAttempts or on a queue.ErrPermanent failure.
+func (b *Broker) Consume(ctx context.Context, name string, h queue.Handler) error {
+	ch := b.channel(name)
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case msg := <-ch:
+			msg.Attempt++
+			if err := h.Handle(ctx, msg); err != nil {
+				b.failed(name, msg, err)
+			}
+		}
+	}
+}
+
+func (b *Broker) failed(name string, msg queue.Message, err error) {
+	maxAttempts := b.MaxAttempts
+	if maxAttempts <= 0 {
+		maxAttempts = 3
+	}
+	if errors.Is(err, queue.ErrPermanent) || msg.Attempt >= maxAttempts {
+		msg.Headers = maps.Clone(msg.Headers)
+		if msg.Headers == nil {
+			msg.Headers = make(map[string]string)
+		}
+		msg.Headers["x-error"] = err.Error()
+		msg.Headers["x-attempts"] = fmt.Sprint(msg.Attempt)
+		msg.Attempt = 0
+		// a goroutine, so a full dead-letter queue never stalls the consumer
+		go func() { b.channel(queue.DeadLetter(name)) <- msg }()
+		return
+	}
+	backoff := b.Backoff
+	if backoff <= 0 {
+		backoff = 100 * time.Millisecond
+	}
+	wait := backoff << (msg.Attempt - 1)
+	go func() {
+		<-b.clock().After(wait)
+		b.channel(name) <- msg
+	}()
+}
+
+// Len returns the number of messages waiting in a queue, not counting
+// messages being handled or waiting to be redelivered.
+func (b *Broker) Len(name string) int { return len(b.channel(name)) }
+
+var (
+	_ queue.Producer = (*Broker)(nil)
+	_ queue.Consumer = (*Broker)(nil)
+)
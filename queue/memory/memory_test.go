@@ -0,0 +1,178 @@
+package memory_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"go-solid/clock"
+	"go-solid/queue"
+	"go-solid/queue/memory"
+	"go-solid/queue/queuetest"
+)
+
+var start = time.Date(2026, time.March, 2, 9, 0, 0, 0, time.UTC)
+
+func TestBroker(t *testing.T) {
+	queuetest.TestBroker(t, func(t *testing.T) queuetest.Broker {
+		b := memory.New(100)
+		b.MaxAttempts, b.Backoff = queuetest.MaxAttempts, time.Millisecond
+		return b
+	})
+}
+
+// consume runs h on name's messages until the test ends.
+func consume(t *testing.T, b *memory.Broker, name string, h queue.HandlerFunc) {
+	t.Helper()
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- b.Consume(ctx, name, h) }()
+	t.Cleanup(func() {
+		cancel()
+		if err := <-done; err != nil {
+			t.Errorf("Consume() error = %v, want nil once the context is done", err)
+		}
+	})
+}
+
+// receive waits for the next message on ch.
+func receive(t *testing.T, ch <-chan queue.Message) queue.Message {
+	t.Helper()
+	select {
+	case msg := <-ch:
+		return msg
+	case <-time.After(5 * time.Second):
+		t.Fatal("no message delivered")
+		return queue.Message{}
+	}
+}
+
+func TestBroker_CompetingConsumersHandleEachMessageOnce(t *testing.T) {
+	b := memory.New(10)
+	var (
+		mu      sync.Mutex
+		handled = make(map[string]int)
+		all     = make(chan struct{})
+	)
+	for range 3 {
+		consume(t, b, "jobs", func(ctx context.Context, msg queue.Message) error {
+			mu.Lock()
+			defer mu.Unlock()
+			handled[string(msg.Body)]++
+			if len(handled) == 100 {
+				close(all)
+			}
+			return nil
+		})
+	}
+	for i := range 100 {
+		if err := b.Publish(t.Context(), "jobs", queue.Message{Body: fmt.Appendf(nil, "job %d", i)}); err != nil {
+			t.Fatalf("Publish() error = %v", err)
+		}
+	}
+	select {
+	case <-all:
+	case <-time.After(5 * time.Second):
+		t.Fatal("not every message was handled")
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	for body, n := range handled {
+		if n != 1 {
+			t.Errorf("%s handled %d times, want once", body, n)
+		}
+	}
+}
+
+func TestBroker_RedeliversWithBackoffUntilHandled(t *testing.T) {
+	fake := clock.NewFake(start)
+	b := memory.New(10)
+	b.Clock, b.Backoff, b.MaxAttempts = fake, time.Second, 5
+	deliveries := make(chan queue.Message)
+	consume(t, b, "jobs", func(ctx context.Context, msg queue.Message) error {
+		msg.PublishedAt = fake.Now() // when it was delivered
+		deliveries <- msg
+		if msg.Attempt < 3 {
+			return errors.New("not yet")
+		}
+		return nil
+	})
+	if err := b.Publish(t.Context(), "jobs", queue.Message{ID: "job-1", Body: []byte("pay Ali")}); err != nil {
+		t.Fatalf("Publish() error = %v", err)
+	}
+
+	var got []queue.Message
+	got = append(got, receive(t, deliveries))
+	fake.BlockUntil(1)
+	fake.Advance(time.Second)
+	got = append(got, receive(t, deliveries))
+	fake.BlockUntil(1)
+	fake.Advance(time.Second) // the second backoff is two seconds
+	fake.Advance(time.Second)
+	got = append(got, receive(t, deliveries))
+
+	for i, msg := range got {
+		want := start.Add([]time.Duration{0, time.Second, 3 * time.Second}[i])
+		if msg.ID != "job-1" || string(msg.Body) != "pay Ali" || msg.Attempt != i+1 || !msg.PublishedAt.Equal(want) {
+			t.Errorf("delivery %d = %s %q attempt %d at %v, want job-1 attempt %d at %v", i+1, msg.ID, msg.Body, msg.Attempt, msg.PublishedAt, i+1, want)
+		}
+	}
+	if n := b.Len(queue.DeadLetter("jobs")); n != 0 {
+		t.Errorf("Len(dead letters) = %d, want 0 once handled", n)
+	}
+}
+
+func TestBroker_DeadLetters(t *testing.T) {
+	tests := []struct {
+		name         string
+		err          error
+		wantAttempts int
+	}{
+		{name: "after MaxAttempts", err: errors.New("ledger down"), wantAttempts: 2},
+		{name: "at once when permanent", err: queue.Permanent(errors.New("no such employee")), wantAttempts: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := memory.New(10)
+			b.Backoff, b.MaxAttempts = time.Millisecond, 2
+			consume(t, b, "jobs", func(ctx context.Context, msg queue.Message) error { return tt.err })
+			dead := make(chan queue.Message, 1)
+			consume(t, b, queue.DeadLetter("jobs"), func(ctx context.Context, msg queue.Message) error {
+				dead <- msg
+				return nil
+			})
+			msg := queue.Message{ID: "job-1", Headers: map[string]string{"run": "2026-03"}}
+			if err := b.Publish(t.Context(), "jobs", msg); err != nil {
+				t.Fatalf("Publish() error = %v", err)
+			}
+			got := receive(t, dead)
+			if got.ID != "job-1" || got.Attempt != 1 || got.Headers["run"] != "2026-03" {
+				t.Errorf("dead letter = %s attempt %d headers %v, want job-1 as a first delivery with its headers", got.ID, got.Attempt, got.Headers)
+			}
+			if got.Headers["x-error"] != tt.err.Error() || got.Headers["x-attempts"] != fmt.Sprint(tt.wantAttempts) {
+				t.Errorf("dead letter headers = %v, want x-error %q after %d attempts", got.Headers, tt.err, tt.wantAttempts)
+			}
+			if msg.Headers["x-error"] != "" {
+				t.Error("dead-lettering changed the published message's headers")
+			}
+		})
+	}
+}
+
+func TestBroker_PublishBlocksWhileFull(t *testing.T) {
+	b := memory.New(1)
+	if err := b.Publish(t.Context(), "jobs", queue.Message{}); err != nil {
+		t.Fatalf("Publish() error = %v", err)
+	}
+	ctx, cancel := context.WithTimeout(t.Context(), 10*time.Millisecond)
+	defer cancel()
+	if err := b.Publish(ctx, "jobs", queue.Message{}); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Publish() to a full queue error = %v, want %v", err, context.DeadlineExceeded)
+	}
+	if n := b.Len("jobs"); n != 1 {
+		t.Errorf("Len() = %d, want 1", n)
+	}
+}
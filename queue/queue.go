@@ -0,0 +1,69 @@
+// Package queue moves work between processes: a Producer publishes messages
+// to a named queue and Consumers take them off and handle them.
+//
+// Delivery is at-least-once. A message is acknowledged only after its handler
+// returns nil; when the handler fails, or the consumer dies mid-message, the
+// message is delivered again - possibly to another consumer and possibly out of
+// order. Handlers must therefore be idempotent: handling the same message
+// twice must have the same effect as handling it once. Messages that keep
+// failing are moved to the queue's dead-letter queue (DeadLetter(name)).
+//
+// queue/memory is a channel-backed broker for tests and demos. queue/kafka
+// and queue/rabbitmq, modules of their own, implement the same two
+// interfaces, and queue/queuetest is the contract all three keep.
+package queue
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// Message The unit of work. Key groups related messages (one employee, one
+// order) for brokers that partition by it.
+type Message struct {
+	ID      string
+	Key     string
+	Body    []byte
+	Headers map[string]string
+	// Attempt is set by the broker: 1 on first delivery, +1 on every redelivery
+	Attempt     int
+	PublishedAt time.Time
+}
+
+// Producer Publishes messages to a queue
+type Producer interface {
+	Publish(ctx context.Context, queue string, msgs ...Message) error
+}
+
+// Handler Processes one message; returning nil acknowledges it
+type Handler interface {
+	Handle(ctx context.Context, msg Message) error
+}
+
+// HandlerFunc Adapter so plain functions can be used as handlers
+type HandlerFunc func(ctx context.Context, msg Message) error
+
+func (f HandlerFunc) Handle(ctx context.Context, msg Message) error { return f(ctx, msg) }
+
+// Consumer Delivers a queue's messages to h until ctx is done. Several
+// consumers of the same queue compete: each message goes to one of them.
+type Consumer interface {
+	Consume(ctx context.Context, queue string, h Handler) error
+}
+
+// DeadLetter names the queue that receives name's messages once they have
+// failed too often.
+func DeadLetter(name string) string { return name + ".dead" }
+
+// ErrPermanent marks failures that retrying can't fix: the message goes
+// straight to the dead-letter queue.
+var ErrPermanent = errors.New("permanent failure")
+
+type permanent struct{ err error }
+
+func (p permanent) Error() string   { return p.err.Error() }
+func (p permanent) Unwrap() []error { return []error{p.err, ErrPermanent} }
+
+// Permanent wraps err so brokers skip the remaining attempts.
+func Permanent(err error) error { return permanent{err} }
@@ -0,0 +1,207 @@
+// Package queuetest is the contract every queue broker keeps, as a test
+// suite any adapter runs against itself:
+//
+//	func TestBroker(t *testing.T) {
+//		queuetest.TestBroker(t, func(t *testing.T) queuetest.Broker {
+//			b := memory.New(100)
+//			b.MaxAttempts, b.Backoff = queuetest.MaxAttempts, time.Millisecond
+//			return b
+//		})
+//	}
+//
+// It checks the delivery rules of package queue: what a delivered message
+// carries, that every message reaches one of the competing consumers, that
+// a failed message comes back with its attempt counted, and that it is
+// dead-lettered after MaxAttempts, or at once when permanent. Delivery is
+// at-least-once, so a message handled twice only fails the case where a
+// handler succeeded the first time. open must return a broker that gives up
+// after MaxAttempts, with a backoff of milliseconds. Each case uses queues
+// of its own, so one broker can serve them all.
+package queuetest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"go-solid/queue"
+)
+
+// Broker Both halves of a broker
+type Broker interface {
+	queue.Producer
+	queue.Consumer
+}
+
+// MaxAttempts The attempts the brokers open returns make before they
+// dead-letter a message
+const MaxAttempts = 3
+
+// wait bounds how long a case waits for a delivery; generous, for brokers
+// in containers.
+const wait = 30 * time.Second
+
+// TestBroker runs the contract against the brokers open returns.
+func TestBroker(t *testing.T, open func(t *testing.T) Broker) {
+	t.Run("delivers what was published", func(t *testing.T) {
+		b, name := open(t), queueName(t)
+		got := make(chan queue.Message, 1)
+		consume(t, b, name, func(ctx context.Context, msg queue.Message) error {
+			got <- msg
+			return nil
+		})
+		sent := queue.Message{ID: "job-1", Key: "emp-1", Body: []byte("pay Ali"), Headers: map[string]string{"run": "2026-03"}}
+		publish(t, b, name, sent)
+		msg := receive(t, got)
+		if msg.ID != sent.ID || msg.Key != sent.Key || string(msg.Body) != string(sent.Body) || msg.Attempt != 1 {
+			t.Errorf("delivered %s key %q %q attempt %d, want job-1 key emp-1 %q attempt 1", msg.ID, msg.Key, msg.Body, msg.Attempt, sent.Body)
+		}
+		if len(msg.Headers) != 1 || msg.Headers["run"] != "2026-03" {
+			t.Errorf("delivered headers %v, want %v", msg.Headers, sent.Headers)
+		}
+		if msg.PublishedAt.IsZero() {
+			t.Error("delivered without PublishedAt")
+		}
+	})
+
+	t.Run("gives every message an ID", func(t *testing.T) {
+		b, name := open(t), queueName(t)
+		got := make(chan queue.Message, 2)
+		consume(t, b, name, func(ctx context.Context, msg queue.Message) error {
+			got <- msg
+			return nil
+		})
+		publish(t, b, name, queue.Message{Body: []byte("a")}, queue.Message{Body: []byte("b")})
+		first, second := receive(t, got), receive(t, got)
+		if first.ID == "" || first.ID == second.ID {
+			t.Errorf("IDs %q and %q, want two different ones", first.ID, second.ID)
+		}
+	})
+
+	t.Run("competing consumers", func(t *testing.T) {
+		b, name := open(t), queueName(t)
+		const n = 30
+		var (
+			mu      sync.Mutex
+			handled = make(map[string]int)
+			all     = make(chan struct{})
+		)
+		for range 3 {
+			consume(t, b, name, func(ctx context.Context, msg queue.Message) error {
+				mu.Lock()
+				defer mu.Unlock()
+				handled[string(msg.Body)]++
+				if len(handled) == n {
+					close(all)
+				}
+				return nil
+			})
+		}
+		for i := range n {
+			publish(t, b, name, queue.Message{Key: fmt.Sprint(i % 5), Body: fmt.Appendf(nil, "job %d", i)})
+		}
+		select {
+		case <-all:
+		case <-time.After(wait):
+			mu.Lock()
+			defer mu.Unlock()
+			t.Fatalf("%d of %d messages handled", len(handled), n)
+		}
+	})
+
+	t.Run("redelivers until handled", func(t *testing.T) {
+		b, name := open(t), queueName(t)
+		deliveries := make(chan queue.Message, MaxAttempts)
+		consume(t, b, name, func(ctx context.Context, msg queue.Message) error {
+			deliveries <- msg
+			if msg.Attempt < MaxAttempts {
+				return errors.New("not yet")
+			}
+			return nil
+		})
+		publish(t, b, name, queue.Message{ID: "job-1", Body: []byte("pay Ali"), Headers: map[string]string{"run": "2026-03"}})
+		for attempt := 1; attempt <= MaxAttempts; attempt++ {
+			msg := receive(t, deliveries)
+			if msg.ID != "job-1" || string(msg.Body) != "pay Ali" || msg.Headers["run"] != "2026-03" || msg.Attempt != attempt {
+				t.Errorf("delivery %d = %s %q %v attempt %d, want job-1 with its body and headers at attempt %d", attempt, msg.ID, msg.Body, msg.Headers, msg.Attempt, attempt)
+			}
+		}
+		select {
+		case msg := <-deliveries:
+			t.Errorf("delivered again at attempt %d after it was handled", msg.Attempt)
+		case <-time.After(100 * time.Millisecond):
+		}
+	})
+
+	deadLetters := []struct {
+		name         string
+		err          error
+		wantAttempts int
+	}{
+		{name: "dead letters after MaxAttempts", err: errors.New("ledger down"), wantAttempts: MaxAttempts},
+		{name: "dead letters permanent failures at once", err: queue.Permanent(errors.New("no such employee")), wantAttempts: 1},
+	}
+	for _, tt := range deadLetters {
+		t.Run(tt.name, func(t *testing.T) {
+			b, name := open(t), queueName(t)
+			consume(t, b, name, func(ctx context.Context, msg queue.Message) error { return tt.err })
+			dead := make(chan queue.Message, 1)
+			consume(t, b, queue.DeadLetter(name), func(ctx context.Context, msg queue.Message) error {
+				dead <- msg
+				return nil
+			})
+			publish(t, b, name, queue.Message{ID: "job-1", Body: []byte("pay Ali"), Headers: map[string]string{"run": "2026-03"}})
+			got := receive(t, dead)
+			if got.ID != "job-1" || string(got.Body) != "pay Ali" || got.Attempt != 1 || got.Headers["run"] != "2026-03" {
+				t.Errorf("dead letter = %s %q attempt %d headers %v, want job-1 as a first delivery with its body and headers", got.ID, got.Body, got.Attempt, got.Headers)
+			}
+			if got.Headers["x-error"] != tt.err.Error() || got.Headers["x-attempts"] != fmt.Sprint(tt.wantAttempts) {
+				t.Errorf("dead letter headers = %v, want x-error %q after %d attempts", got.Headers, tt.err, tt.wantAttempts)
+			}
+		})
+	}
+}
+
+// queueName returns a queue name no other case uses, even on a broker
+// shared between test runs.
+func queueName(t *testing.T) string {
+	_, name, _ := strings.Cut(t.Name(), "/")
+	return fmt.Sprintf("queuetest.%s.%d", strings.ReplaceAll(name, "/", "."), time.Now().UnixNano())
+}
+
+// consume runs h on name's messages until the case ends.
+func consume(t *testing.T, b Broker, name string, h queue.HandlerFunc) {
+	t.Helper()
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- b.Consume(ctx, name, h) }()
+	t.Cleanup(func() {
+		cancel()
+		if err := <-done; err != nil {
+			t.Errorf("Consume() error = %v, want nil once the context is done", err)
+		}
+	})
+}
+
+func publish(t *testing.T, b Broker, name string, msgs ...queue.Message) {
+	t.Helper()
+	if err := b.Publish(t.Context(), name, msgs...); err != nil {
+		t.Fatalf("Publish() error = %v", err)
+	}
+}
+
+// receive waits for the next message on ch.
+func receive(t *testing.T, ch <-chan queue.Message) queue.Message {
+	t.Helper()
+	select {
+	case msg := <-ch:
+		return msg
+	case <-time.After(wait):
+		t.Fatal("no message delivered")
+		return queue.Message{}
+	}
+}
@@ -0,0 +1,9 @@
+module go-solid/queue/rabbitmq
+
+go 1.25.0
+
+require go-solid v0.0.0
+
+require github.com/rabbitmq/amqp091-go v1.15.0
+
+replace go-solid => ../..
@@ -0,0 +1,27 @@
+//go:build rabbitmq
+
+package rabbitmq_test
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"go-solid/queue/queuetest"
+	"go-solid/queue/rabbitmq"
+)
+
+// TestBroker runs the contract against the server RABBITMQ_URL points at.
+// Every case declares queues of its own, left on the server.
+func TestBroker(t *testing.T) {
+	url := os.Getenv("RABBITMQ_URL")
+	if url == "" {
+		t.Skip("RABBITMQ_URL is not set")
+	}
+	queuetest.TestBroker(t, func(t *testing.T) queuetest.Broker {
+		b := rabbitmq.New(url)
+		b.MaxAttempts, b.Backoff = queuetest.MaxAttempts, time.Millisecond
+		t.Cleanup(func() { _ = b.Close() })
+		return b
+	})
+}
@@ -0,0 +1,75 @@
+// Package quiz runs a lesson's quiz: it asks the questions of a bank in
+// turn, checks the answers and keeps the score.
+//
+// A Session knows nothing of terminals or browsers. solid quiz reads answers
+// from stdin; another front end would call the same three methods. The
+// questions come from a content.Provider, so the engine carries none.
+package quiz
+
+import (
+	"errors"
+	"fmt"
+
+	"go-solid/content"
+)
+
+var (
+	ErrEmpty    = errors.New("quiz has no questions")
+	ErrFinished = errors.New("quiz is finished")
+	ErrChoice   = errors.New("no such choice")
+)
+
+// Feedback What the learner is told after answering
+type Feedback struct {
+	Correct     bool
+	Answer      int // the right choice, to show when the learner's wasn't
+	Explanation string
+}
+
+// Session One attempt at a quiz. Not safe for concurrent use.
+type Session struct {
+	questions []content.Question
+	next      int
+	correct   int
+}
+
+func New(questions []content.Question) (*Session, error) {
+	if len(questions) == 0 {
+		return nil, ErrEmpty
+	}
+	return &Session{questions: questions}, nil
+}
+
+// Question returns the question waiting for an answer, and its number from
+// 1; ok is false once every question has been answered.
+func (s *Session) Question() (q content.Question, number int, ok bool) {
+	if s.next == len(s.questions) {
+		return content.Question{}, 0, false
+	}
+	return s.questions[s.next], s.next + 1, true
+}
+
+// Answer answers the current question with choice, an index into its
+// Choices, and moves on. A choice out of range is refused and the question
+// stays current.
+func (s *Session) Answer(choice int) (Feedback, error) {
+	q, _, ok := s.Question()
+	if !ok {
+		return Feedback{}, ErrFinished
+	}
+	if choice < 0 || choice >= len(q.Choices) {
+		return Feedback{}, fmt.Errorf("%w %d: pick 1 to %d", ErrChoice, choice+1, len(q.Choices))
+	}
+	s.next++
+	if choice == q.Answer {
+		s.correct++
+	}
+	return Feedback{Correct: choice == q.Answer, Answer: q.Answer, Explanation: q.Explanation}, nil
+}
+
+// Score returns the share of questions answered correctly so far, from 0
+// to 1, as progress entries record it.
+func (s *Session) Score() float64 { return float64(s.correct) / float64(len(s.questions)) }
+
+// Correct returns how many answers were right, and out of how many questions.
+func (s *Session) Correct() (right, of int) { return s.correct, len(s.questions) }
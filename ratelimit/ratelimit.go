@@ -0,0 +1,136 @@
+// Package ratelimit throttles callers behind a single Limiter abstraction.
+//
+// TokenBucket and SlidingWindow both honour the same contract - a sustained
+// rate of N operations per period - so either can be substituted for the other
+// (LSP). What they don't share is timing: a token bucket lets an idle caller
+// burst and then trickles, a sliding window admits a full batch and then
+// nothing until the oldest admission ages out. Callers must rely only on the
+// contract, never on the shape.
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"go-solid/clock"
+)
+
+// Limiter Decides whether an operation may proceed now
+type Limiter interface {
+	// Allow reports whether one operation may proceed immediately.
+	Allow() bool
+	// Wait blocks until one operation may proceed or ctx is done.
+	Wait(ctx context.Context) error
+}
+
+// algorithm is the part that differs between implementations: try to admit one
+// operation at now, or say how long until one could be admitted.
+type algorithm interface {
+	take(now time.Time) (ok bool, retryIn time.Duration)
+}
+
+// limiter Shares Allow/Wait between the algorithms
+type limiter struct {
+	mu    sync.Mutex
+	clock clock.Clock
+	alg   algorithm
+}
+
+func (l *limiter) Allow() bool {
+	ok, _ := l.try()
+	return ok
+}
+
+func (l *limiter) Wait(ctx context.Context) error {
+	for {
+		ok, retryIn := l.try()
+		if ok {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-l.clock.After(retryIn):
+		}
+	}
+}
+
+func (l *limiter) try() (bool, time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.alg.take(l.clock.Now())
+}
+
+// TokenBucket Refills one token every interval up to burst; each operation spends one
+type TokenBucket struct{ limiter }
+
+// NewTokenBucket starts with a full bucket. It panics unless every and burst
+// are positive: a bucket that never refills, or holds no token, would never
+// admit anything.
+func NewTokenBucket(every time.Duration, burst int, c clock.Clock) *TokenBucket {
+	if every <= 0 || burst <= 0 {
+		panic(fmt.Sprintf("ratelimit: token bucket of %d refilled every %v", burst, every))
+	}
+	b := &bucket{every: every, burst: float64(burst), tokens: float64(burst), last: c.Now()}
+	return &TokenBucket{limiter{clock: c, alg: b}}
+}
+
+type bucket struct {
+	every  time.Duration
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+func (b *bucket) take(now time.Time) (bool, time.Duration) {
+	if elapsed := now.Sub(b.last); elapsed > 0 {
+		b.tokens = min(b.burst, b.tokens+float64(elapsed)/float64(b.every))
+		b.last = now
+	}
+	if b.tokens >= 1 {
+		b.tokens--
+		return true, 0
+	}
+	return false, time.Duration((1 - b.tokens) * float64(b.every))
+}
+
+// SlidingWindow Admits at most limit operations in any window-long span
+type SlidingWindow struct{ limiter }
+
+// NewSlidingWindow panics unless limit and window are positive.
+func NewSlidingWindow(limit int, window time.Duration, c clock.Clock) *SlidingWindow {
+	if limit <= 0 || window <= 0 {
+		panic(fmt.Sprintf("ratelimit: sliding window of %d per %v", limit, window))
+	}
+	w := &slidingLog{limit: limit, window: window}
+	return &SlidingWindow{limiter{clock: c, alg: w}}
+}
+
+// slidingLog keeps the admission times still inside the window, oldest first.
+type slidingLog struct {
+	limit  int
+	window time.Duration
+	log    []time.Time
+}
+
+func (w *slidingLog) take(now time.Time) (bool, time.Duration) {
+	cutoff := now.Add(-w.window)
+	expired := 0
+	for expired < len(w.log) && !w.log[expired].After(cutoff) {
+		expired++
+	}
+	w.log = w.log[expired:]
+
+	if len(w.log) < w.limit {
+		w.log = append(w.log, now)
+		return true, 0
+	}
+	return false, w.log[0].Sub(cutoff)
+}
+
+var (
+	_ Limiter = (*TokenBucket)(nil)
+	_ Limiter = (*SlidingWindow)(nil)
+)
@@ -0,0 +1,64 @@
+package ratelimit_test
+
+import (
+	"testing"
+	"time"
+
+	"go-solid/clock"
+	"go-solid/ratelimit"
+)
+
+var start = time.Date(2026, time.March, 2, 9, 0, 0, 0, time.UTC)
+
+func TestNew_RefusesLimitsThatAdmitNothing(t *testing.T) {
+	tests := []struct {
+		name string
+		new  func()
+	}{
+		{"token bucket without burst", func() { ratelimit.NewTokenBucket(time.Second, 0, clock.Real{}) }},
+		{"token bucket with negative burst", func() { ratelimit.NewTokenBucket(time.Second, -1, clock.Real{}) }},
+		{"token bucket never refilled", func() { ratelimit.NewTokenBucket(0, 5, clock.Real{}) }},
+		{"sliding window without limit", func() { ratelimit.NewSlidingWindow(0, time.Second, clock.Real{}) }},
+		{"sliding window without window", func() { ratelimit.NewSlidingWindow(5, 0, clock.Real{}) }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Error("constructor didn't panic")
+				}
+			}()
+			tt.new()
+		})
+	}
+}
+
+// TestLimiter_Contract checks what both limiters promise: n operations per
+// period, and no more.
+func TestLimiter_Contract(t *testing.T) {
+	tests := []struct {
+		name string
+		new  func(clock.Clock) ratelimit.Limiter
+	}{
+		{"token bucket", func(c clock.Clock) ratelimit.Limiter { return ratelimit.NewTokenBucket(200*time.Millisecond, 5, c) }},
+		{"sliding window", func(c clock.Clock) ratelimit.Limiter { return ratelimit.NewSlidingWindow(5, time.Second, c) }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := clock.NewFake(start)
+			l := tt.new(c)
+			for i := range 5 {
+				if !l.Allow() {
+					t.Fatalf("Allow() #%d = false, want 5 admitted at once", i+1)
+				}
+			}
+			if l.Allow() {
+				t.Fatal("Allow() #6 = true, want the limit reached")
+			}
+			c.Advance(time.Second)
+			if !l.Allow() {
+				t.Error("Allow() a period later = false, want room again")
+			}
+		})
+	}
+}
@@ -0,0 +1,151 @@
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"iter"
+
+	"go-solid/employee"
+	"go-solid/outbox"
+	"go-solid/spec"
+)
+
+// Repository Decorator throttling writes to an employee.Repository; reads pass
+// straight through. Optional capabilities are forwarded so wrapping doesn't
+// hide them (see examples/capabilities).
+type Repository struct {
+	next    employee.Repository
+	limiter Limiter
+}
+
+func NewRepository(next employee.Repository, l Limiter) *Repository {
+	return &Repository{next: next, limiter: l}
+}
+
+func (r *Repository) Save(ctx context.Context, emp employee.Employee) error {
+	if err := r.limiter.Wait(ctx); err != nil {
+		return err
+	}
+	return r.next.Save(ctx, emp)
+}
+
+// SaveAll keeps the wrapped backend's bulk path but still spends one permit
+// per employee: the sequence is throttled before the backend sees it.
+func (r *Repository) SaveAll(ctx context.Context, emps iter.Seq[employee.Employee]) error {
+	var stopped error
+	yielded := 0
+	throttled := func(yield func(employee.Employee) bool) {
+		for emp := range emps {
+			if stopped = r.limiter.Wait(ctx); stopped != nil || !yield(emp) {
+				return
+			}
+			yielded++
+		}
+	}
+	err := employee.SaveAll(ctx, r.next, throttled)
+	if stopped == nil {
+		return err
+	}
+	// The backend saw a sequence that simply ended; report why it ended.
+	var report *employee.BulkError
+	if !errors.As(err, &report) {
+		report = &employee.BulkError{Saved: yielded}
+	}
+	if report.Stopped == nil {
+		report.Stopped = stopped
+	}
+	return report
+}
+
+func (r *Repository) SaveWithOutbox(ctx context.Context, emp employee.Employee, msgs []outbox.Message) error {
+	o, ok := r.next.(employee.OutboxRepository)
+	if !ok {
+		return errors.ErrUnsupported
+	}
+	if err := r.limiter.Wait(ctx); err != nil {
+		return err
+	}
+	return o.SaveWithOutbox(ctx, emp, msgs)
+}
+
+func (r *Repository) Update(ctx context.Context, emp employee.Employee, expectedVersion int) (employee.Employee, error) {
+	u, ok := r.next.(employee.Updater)
+	if !ok {
+		return employee.Employee{}, errors.ErrUnsupported
+	}
+	if err := r.limiter.Wait(ctx); err != nil {
+		return employee.Employee{}, err
+	}
+	return u.Update(ctx, emp, expectedVersion)
+}
+
+func (r *Repository) GetByName(ctx context.Context, name string) (employee.Employee, error) {
+	return r.next.GetByName(ctx, name)
+}
+
+func (r *Repository) GetByID(ctx context.Context, id employee.ID) (employee.Employee, error) {
+	return employee.GetByID(ctx, r.next, id)
+}
+
+func (r *Repository) All(ctx context.Context) iter.Seq2[employee.Employee, error] {
+	return employee.All(ctx, r.next)
+}
+
+func (r *Repository) SoftDelete(ctx context.Context, name string) error {
+	d, ok := r.next.(employee.SoftDeleter)
+	if !ok {
+		return errors.ErrUnsupported
+	}
+	if err := r.limiter.Wait(ctx); err != nil {
+		return err
+	}
+	return d.SoftDelete(ctx, name)
+}
+
+func (r *Repository) Restore(ctx context.Context, name string) error {
+	d, ok := r.next.(employee.SoftDeleter)
+	if !ok {
+		return errors.ErrUnsupported
+	}
+	if err := r.limiter.Wait(ctx); err != nil {
+		return err
+	}
+	return d.Restore(ctx, name)
+}
+
+func (r *Repository) History(ctx context.Context, name string) ([]employee.Employee, error) {
+	v, ok := r.next.(employee.Versioned)
+	if !ok {
+		return nil, errors.ErrUnsupported
+	}
+	return v.History(ctx, name)
+}
+
+func (r *Repository) List(ctx context.Context, filter employee.Filter, page employee.Page) (employee.PageResult, error) {
+	q, ok := r.next.(employee.QueryRepository)
+	if !ok {
+		return employee.PageResult{}, errors.ErrUnsupported
+	}
+	return q.List(ctx, filter, page)
+}
+
+func (r *Repository) Matching(ctx context.Context, s spec.Specification[employee.Employee]) ([]employee.Employee, error) {
+	m, ok := r.next.(employee.SpecificationRepository)
+	if !ok {
+		return nil, errors.ErrUnsupported
+	}
+	return m.Matching(ctx, s)
+}
+
+var (
+	_ employee.Repository              = (*Repository)(nil)
+	_ employee.SoftDeleter             = (*Repository)(nil)
+	_ employee.Updater                 = (*Repository)(nil)
+	_ employee.Iterable                = (*Repository)(nil)
+	_ employee.IDRepository            = (*Repository)(nil)
+	_ employee.Versioned               = (*Repository)(nil)
+	_ employee.QueryRepository         = (*Repository)(nil)
+	_ employee.SpecificationRepository = (*Repository)(nil)
+	_ employee.BulkSaver               = (*Repository)(nil)
+	_ employee.OutboxRepository        = (*Repository)(nil)
+)
@@ -0,0 +1,18 @@
+package ratelimit_test
+
+import (
+	"testing"
+	"time"
+
+	"go-solid/clock"
+	"go-solid/employee"
+	"go-solid/employee/employeetest"
+	"go-solid/employee/memory"
+	"go-solid/ratelimit"
+)
+
+func TestRepository(t *testing.T) {
+	employeetest.TestRepository(t, func(*testing.T) employee.Repository {
+		return ratelimit.NewRepository(memory.New(), ratelimit.NewTokenBucket(time.Nanosecond, 1000, clock.Real{}))
+	})
+}
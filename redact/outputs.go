@@ -0,0 +1,120 @@
+package redact
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"maps"
+
+	"go-solid/audit"
+	"go-solid/codec"
+)
+
+// ReplaceAttr masks log attributes named in fields. Plug it into any standard
+// handler:
+//
+//	strict, err := redact.Strict(key)
+//	...
+//	slog.NewJSONHandler(os.Stderr, &slog.HandlerOptions{ReplaceAttr: redact.ReplaceAttr(strict, redact.EmployeeFields)})
+func ReplaceAttr(p Policy, fields Fields) func(groups []string, a slog.Attr) slog.Attr {
+	return func(groups []string, a slog.Attr) slog.Attr {
+		class, ok := fields[a.Key]
+		if !ok || a.Value.Kind() == slog.KindGroup {
+			return a
+		}
+		return slog.String(a.Key, p.Redact(class, a.Value.Resolve().String()))
+	}
+}
+
+// Sink Decorator masking the details of audit records before next stores them.
+// Put it outside an audit.Chain, so the chain hashes what is actually kept.
+type Sink struct {
+	next   audit.Sink
+	policy Policy
+	fields Fields
+}
+
+func NewSink(next audit.Sink, p Policy, fields Fields) *Sink {
+	return &Sink{next: next, policy: p, fields: fields}
+}
+
+func (s *Sink) Write(ctx context.Context, rec audit.Record) error {
+	if len(rec.Details) > 0 {
+		details := maps.Clone(rec.Details)
+		for key, v := range details {
+			if class, ok := s.fields[key]; ok {
+				details[key] = s.policy.Redact(class, fmt.Sprint(v))
+			}
+		}
+		rec.Details = details
+	}
+	return s.next.Write(ctx, rec)
+}
+
+// NewCodec decorates c so tagged fields of every encoded value are masked -
+// the export is the same report, with less in it. The result can still be
+// resumed when c can.
+func NewCodec(c codec.Codec, p Policy) codec.Codec {
+	if _, ok := c.(codec.Continuer); ok {
+		return continuingCodec{redactingCodec{c, p}}
+	}
+	return redactingCodec{c, p}
+}
+
+type redactingCodec struct {
+	codec.Codec
+	policy Policy
+}
+
+func (r redactingCodec) NewEncoder(w io.Writer) codec.Encoder {
+	return encoder{r.Codec.NewEncoder(w), r.policy}
+}
+
+type continuingCodec struct{ redactingCodec }
+
+func (r continuingCodec) ContinueEncoder(w io.Writer) codec.Encoder {
+	return encoder{r.Codec.(codec.Continuer).ContinueEncoder(w), r.policy}
+}
+
+type encoder struct {
+	codec.Encoder
+	policy Policy
+}
+
+func (e encoder) Encode(v any) error {
+	m, err := Map(e.policy, v)
+	if err != nil {
+		return err
+	}
+	rec, ok := v.(codec.CSVRecord)
+	if !ok {
+		return e.Encoder.Encode(m)
+	}
+	r := maskedRecord{m: m, header: rec.CSVHeader(), record: rec.CSVRecord()}
+	fields := Tagged(v)
+	for i, col := range r.header {
+		if class, ok := fields[col]; ok && i < len(r.record) {
+			r.record[i] = e.policy.Redact(class, r.record[i])
+		}
+	}
+	return e.Encoder.Encode(r)
+}
+
+// maskedRecord A redacted value the CSV codec can still write; other codecs
+// see the masked map
+type maskedRecord struct {
+	m      map[string]any
+	header []string
+	record []string
+}
+
+func (r maskedRecord) MarshalJSON() ([]byte, error) { return json.Marshal(r.m) }
+func (r maskedRecord) CSVHeader() []string          { return r.header }
+func (r maskedRecord) CSVRecord() []string          { return r.record }
+
+var (
+	_ audit.Sink      = (*Sink)(nil)
+	_ codec.Continuer = continuingCodec{}
+)
@@ -0,0 +1,141 @@
+// Package redact masks personal data before it leaves the system in logs,
+// audit records and reports.
+//
+// Two questions are kept apart (SRP). Which data is sensitive is declared
+// once, next to the data: a `redact:"financial"` struct tag, or a Fields map
+// for key/value data such as log attributes. How a reader may see it is a
+// Policy, chosen where the output is wired up. A stricter policy or a new
+// output is added without touching either side (OCP).
+package redact
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"unicode/utf8"
+)
+
+// Class Kind of sensitive data
+type Class string
+
+const (
+	PII       Class = "pii"       // identifies a person: names
+	Contact   Class = "contact"   // reaches a person: email addresses
+	Financial Class = "financial" // salaries, raises, pay
+)
+
+// Policy Strategy - what a reader may see of a value of class
+type Policy interface {
+	Redact(class Class, value string) string
+}
+
+// Masker Strategy - how one value is masked
+type Masker interface {
+	Mask(value string) string
+}
+
+// Rules Policy masking each listed class with its Masker; other classes are
+// shown as they are
+type Rules map[Class]Masker
+
+func (r Rules) Redact(class Class, value string) string {
+	m, ok := r[class]
+	if !ok {
+		return value
+	}
+	return m.Mask(value)
+}
+
+// Strict is the policy for output read outside HR: names are pseudonymised
+// under key, emails partly hidden and pay removed. The key is required, as
+// it is for NewHash.
+func Strict(key []byte) (Rules, error) {
+	h, err := NewHash(key)
+	if err != nil {
+		return nil, err
+	}
+	return Rules{PII: h, Contact: Email{}, Financial: Full{}}, nil
+}
+
+// Full Replaces the whole value
+type Full struct{}
+
+func (Full) Mask(string) string { return "[REDACTED]" }
+
+// Last Keeps the last n characters: Last(4) turns "DE89370400440532013000" into "******************3000"
+type Last int
+
+func (n Last) Mask(value string) string {
+	count := utf8.RuneCountInString(value)
+	if count <= int(n) {
+		return strings.Repeat("*", count)
+	}
+	cut := len(value)
+	for range int(n) {
+		_, size := utf8.DecodeLastRuneInString(value[:cut])
+		cut -= size
+	}
+	return strings.Repeat("*", count-int(n)) + value[cut:]
+}
+
+// Email Keeps the first letter and the domain: m***@example.com
+type Email struct{}
+
+func (Email) Mask(value string) string {
+	local, domain, ok := strings.Cut(value, "@")
+	if !ok || local == "" {
+		return Full{}.Mask(value)
+	}
+	first, _ := utf8.DecodeRuneInString(local)
+	return string(first) + "***@" + domain
+}
+
+// ErrShortKey returned by NewHash for a key under 16 bytes
+var ErrShortKey = errors.New("redact: hash key shorter than 16 bytes")
+
+// Hash Replaces the value with a short stable digest, an HMAC-SHA256 under a
+// secret key, so lines about the same person can still be correlated. Without
+// the key the digests can't be matched against a list of names. The zero
+// Hash has no key and hides the value completely instead.
+type Hash struct {
+	key []byte
+}
+
+// NewHash keys the digests with key, which must be at least 16 bytes
+// (crypto.NewKey makes one) and kept as secret as the names themselves.
+func NewHash(key []byte) (Hash, error) {
+	if len(key) < 16 {
+		return Hash{}, ErrShortKey
+	}
+	return Hash{key: key}, nil
+}
+
+func (h Hash) Mask(value string) string {
+	if h.key == nil {
+		return Full{}.Mask(value)
+	}
+	mac := hmac.New(sha256.New, h.key)
+	mac.Write([]byte(value))
+	return "#" + hex.EncodeToString(mac.Sum(nil)[:4])
+}
+
+// Fields Classifies key/value data - log attributes, audit details - by key
+type Fields map[string]Class
+
+// EmployeeFields The keys the employee package uses in logs and audit details
+var EmployeeFields = Fields{
+	"name":   PII,
+	"email":  Contact,
+	"salary": Financial,
+	"raise":  Financial,
+}
+
+var (
+	_ Policy = Rules{}
+	_ Masker = Full{}
+	_ Masker = Last(0)
+	_ Masker = Email{}
+	_ Masker = Hash{}
+)
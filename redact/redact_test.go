@@ -0,0 +1,152 @@
+package redact_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"go-solid/money"
+	"go-solid/redact"
+)
+
+var key = []byte("0123456789abcdef")
+
+func TestMaskers(t *testing.T) {
+	tests := []struct {
+		masker redact.Masker
+		value  string
+		want   string
+	}{
+		{redact.Full{}, "USD 5000.00", "[REDACTED]"},
+		{redact.Full{}, "", "[REDACTED]"},
+		{redact.Last(4), "DE89370400440532013000", "******************3000"},
+		{redact.Last(4), "3000", "****"},
+		{redact.Last(4), "300", "***"},
+		{redact.Last(2), "Zoë", "*oë"},
+		{redact.Last(0), "secret", "******"},
+		{redact.Email{}, "mohamed@example.com", "m***@example.com"},
+		{redact.Email{}, "élodie@example.fr", "é***@example.fr"},
+		{redact.Email{}, "@example.com", "[REDACTED]"},
+		{redact.Email{}, "not an email", "[REDACTED]"},
+		{redact.Hash{}, "Mohamed", "[REDACTED]"},
+	}
+	for _, tt := range tests {
+		if got := tt.masker.Mask(tt.value); got != tt.want {
+			t.Errorf("%T(%v).Mask(%q) = %q, want %q", tt.masker, tt.masker, tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestHash(t *testing.T) {
+	h, err := redact.NewHash(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	digest := h.Mask("Mohamed")
+	if len(digest) != 9 || !strings.HasPrefix(digest, "#") || strings.Contains(digest, "Mohamed") {
+		t.Errorf("Mask(Mohamed) = %q, want # and 8 hex digits", digest)
+	}
+	if again := h.Mask("Mohamed"); again != digest {
+		t.Errorf("Mask(Mohamed) = %q then %q, want the same digest to correlate lines", digest, again)
+	}
+	if other := h.Mask("Ahmed"); other == digest {
+		t.Errorf("Mask(Ahmed) = Mask(Mohamed) = %q, want different people apart", other)
+	}
+	// the same name under another key can't be matched up
+	other, _ := redact.NewHash([]byte("fedcba9876543210"))
+	if got := other.Mask("Mohamed"); got == digest {
+		t.Errorf("Mask(Mohamed) = %q under both keys, want the digest to depend on the key", got)
+	}
+}
+
+func TestNewHash_ShortKey(t *testing.T) {
+	for _, k := range [][]byte{nil, {}, []byte("fifteen bytes!!")} {
+		if _, err := redact.NewHash(k); !errors.Is(err, redact.ErrShortKey) {
+			t.Errorf("NewHash(%d bytes) error = %v, want %v", len(k), err, redact.ErrShortKey)
+		}
+	}
+	if _, err := redact.Strict(nil); !errors.Is(err, redact.ErrShortKey) {
+		t.Errorf("Strict(nil) error = %v, want %v", err, redact.ErrShortKey)
+	}
+}
+
+func TestStrict(t *testing.T) {
+	strict, err := redact.Strict(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	h, _ := redact.NewHash(key)
+	tests := []struct {
+		class redact.Class
+		value string
+		want  string
+	}{
+		{redact.PII, "Mohamed", h.Mask("Mohamed")},
+		{redact.Contact, "mohamed@example.com", "m***@example.com"},
+		{redact.Financial, "USD 5000.00", "[REDACTED]"},
+		{redact.Class("title"), "Engineer", "Engineer"},
+	}
+	for _, tt := range tests {
+		if got := strict.Redact(tt.class, tt.value); got != tt.want {
+			t.Errorf("Redact(%s, %q) = %q, want %q", tt.class, tt.value, got, tt.want)
+		}
+	}
+}
+
+// row Tags the way export.Row and review.Result do
+type row struct {
+	Name     string      `json:"name" redact:"pii"`
+	Email    string      `json:"email,omitempty" redact:"contact"`
+	Salary   money.Money `json:"salary" redact:"financial"`
+	Title    string      `json:"title"`
+	Note     string      `redact:"pii"`
+	Internal string      `json:"-" redact:"financial"`
+	secret   string      `redact:"financial"`
+}
+
+func TestTagged(t *testing.T) {
+	want := redact.Fields{"name": redact.PII, "email": redact.Contact, "salary": redact.Financial, "Note": redact.PII, "Internal": redact.Financial}
+	for _, v := range []any{row{}, &row{}, (*row)(nil)} {
+		got := redact.Tagged(v)
+		if len(got) != len(want) {
+			t.Errorf("Tagged(%T) = %v, want %v", v, got, want)
+			continue
+		}
+		for k, class := range want {
+			if got[k] != class {
+				t.Errorf("Tagged(%T)[%q] = %q, want %q", v, k, got[k], class)
+			}
+		}
+	}
+	for _, v := range []any{nil, "not a struct"} {
+		if got := redact.Tagged(v); len(got) != 0 {
+			t.Errorf("Tagged(%#v) = %v, want no fields", v, got)
+		}
+	}
+}
+
+func TestMap(t *testing.T) {
+	strict, _ := redact.Strict(key)
+	r := row{Name: "Mohamed", Salary: money.Of(5000, money.USD), Title: "Engineer", Note: "on leave", Internal: "x", secret: "y"}
+	got, err := redact.Map(strict, &r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	h, _ := redact.NewHash(key)
+	want := map[string]any{"name": h.Mask("Mohamed"), "salary": "[REDACTED]", "title": "Engineer", "Note": h.Mask("on leave")}
+	if len(got) != len(want) {
+		t.Errorf("Map() = %v, want %v", got, want)
+	}
+	for k, v := range want {
+		if got[k] != v {
+			t.Errorf("Map()[%q] = %v, want %v", k, got[k], v)
+		}
+	}
+	// an omitted field stays omitted rather than showing up masked
+	if _, ok := got["email"]; ok {
+		t.Errorf("Map() has email = %v, want it left out as the JSON leaves it", got["email"])
+	}
+	if _, err := redact.Map(strict, []string{"Mohamed"}); err == nil {
+		t.Error("Map() of a slice error = nil, want it refused as not a JSON object")
+	}
+}
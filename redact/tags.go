@@ -0,0 +1,68 @@
+package redact
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Tagged classifies the fields of struct v by their `redact` tags. Fields are
+// keyed by their JSON name, which reports also use as column names:
+//
+//	Salary money.Money `json:"salary" redact:"financial"`
+func Tagged(v any) Fields {
+	t := reflect.TypeOf(v)
+	for t != nil && t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	fields := Fields{}
+	if t == nil || t.Kind() != reflect.Struct {
+		return fields
+	}
+	for i := range t.NumField() {
+		f := t.Field(i)
+		class, ok := f.Tag.Lookup("redact")
+		if !ok || !f.IsExported() {
+			continue
+		}
+		fields[jsonName(f)] = Class(class)
+	}
+	return fields
+}
+
+func jsonName(f reflect.StructField) string {
+	name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+	if name == "" || name == "-" {
+		return f.Name
+	}
+	return name
+}
+
+// Map renders struct v as its JSON object, with every tagged field replaced by
+// its masked text (the field's fmt representation, e.g. "USD 5000.00").
+func Map(p Policy, v any) (map[string]any, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var m map[string]any
+	if err := json.Unmarshal(b, &m); err != nil {
+		return nil, fmt.Errorf("redact: %T is not a JSON object: %w", v, err)
+	}
+	rv := reflect.Indirect(reflect.ValueOf(v))
+	if rv.Kind() != reflect.Struct {
+		return m, nil
+	}
+	for i := range rv.NumField() {
+		f := rv.Type().Field(i)
+		class, ok := f.Tag.Lookup("redact")
+		if !ok || !f.IsExported() {
+			continue
+		}
+		if _, present := m[jsonName(f)]; present {
+			m[jsonName(f)] = p.Redact(Class(class), fmt.Sprint(rv.Field(i).Interface()))
+		}
+	}
+	return m, nil
+}
@@ -0,0 +1,107 @@
+package replica
+
+import (
+	"cmp"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Policy Abstraction - chooses the replica a read goes to. candidates is
+// never empty, and holds only replicas fresh enough for the read.
+type Policy interface {
+	Pick(candidates []Replica) int
+}
+
+// Observer Optional capability - policies that learn from how reads went.
+// failed is set when the replica errored; not finding the employee is an
+// answer, not a failure.
+type Observer interface {
+	Observe(replica string, took time.Duration, failed bool)
+}
+
+// RoundRobin Policy spreading reads evenly over the candidates, whatever
+// their latency. The zero value is ready to use.
+type RoundRobin struct{ next atomic.Uint64 }
+
+func (r *RoundRobin) Pick(candidates []Replica) int {
+	return int((r.next.Add(1) - 1) % uint64(len(candidates)))
+}
+
+// LatencyAware Policy sending reads to the replica that answered fastest
+// lately: the lowest moving average of its latencies, a failed read counting
+// as Penalty. A replica never tried is tried first, and every Explore-th read
+// goes to the candidate tried least recently, so a replica that was slow gets
+// to show it recovered. One that answers after failing starts a new average:
+// the old one described a replica that was down. The zero value is ready to
+// use; it must not be copied after first use.
+type LatencyAware struct {
+	Alpha   float64       // weight of the newest read in the average; 0.2 by default
+	Penalty time.Duration // 1s by default
+	Explore int           // 10 by default
+
+	mu      sync.Mutex
+	avg     map[string]time.Duration
+	failing map[string]bool
+	tried   map[string]int // pick number of the latest read sent to the replica
+	picks   int
+}
+
+func (l *LatencyAware) Pick(candidates []Replica) int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.tried == nil {
+		l.tried = map[string]int{}
+	}
+	l.picks++
+	best := 0
+	explore := l.picks%cmp.Or(l.Explore, 10) == 0
+	for i, c := range candidates {
+		avg, seen := l.avg[c.Name]
+		if !seen {
+			best = i
+			break
+		}
+		b := candidates[best].Name
+		if explore && l.tried[c.Name] < l.tried[b] || !explore && avg < l.avg[b] {
+			best = i
+		}
+	}
+	l.tried[candidates[best].Name] = l.picks
+	return best
+}
+
+func (l *LatencyAware) Observe(replica string, took time.Duration, failed bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.avg == nil {
+		l.avg, l.failing = map[string]time.Duration{}, map[string]bool{}
+	}
+	if failed {
+		took = max(took, cmp.Or(l.Penalty, time.Second))
+	}
+	avg, seen := l.avg[replica]
+	recovered := !failed && l.failing[replica]
+	l.failing[replica] = failed
+	if !seen || recovered {
+		l.avg[replica] = took
+		return
+	}
+	alpha := cmp.Or(l.Alpha, 0.2)
+	l.avg[replica] = time.Duration(alpha*float64(took) + (1-alpha)*float64(avg))
+}
+
+// Latency returns the moving average of replica's reads, and false before
+// its first read.
+func (l *LatencyAware) Latency(replica string) (time.Duration, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	d, ok := l.avg[replica]
+	return d, ok
+}
+
+var (
+	_ Policy   = (*RoundRobin)(nil)
+	_ Policy   = (*LatencyAware)(nil)
+	_ Observer = (*LatencyAware)(nil)
+)
@@ -0,0 +1,371 @@
+// Package replica scales reads out. Its ReadWriteSplitter decorator sends
+// writes to the primary and reads to read replicas, which a database keeps
+// in sync with the primary a little behind it.
+//
+// The splitter is an employee.Repository like any other: the Manager does
+// not know it is there, and neither do the backends it routes to (OCP). Which
+// replica serves a read is a Policy, and a new policy is a new type. What
+// the application gives up is freshness - a replica answers with what it
+// has - so the splitter bounds it: replicas further behind than the
+// tolerance are skipped, and the writer's own reads can be kept on the
+// primary for a while after each write.
+package replica
+
+import (
+	"context"
+	"errors"
+	"iter"
+	"maps"
+	"sync"
+	"time"
+
+	"go-solid/clock"
+	"go-solid/employee"
+	"go-solid/normalize"
+	"go-solid/outbox"
+	"go-solid/spec"
+)
+
+// Replica One read replica, by the name the policy and the stats know it by
+type Replica struct {
+	Name string
+	Repo employee.Repository
+}
+
+// LagReporter Optional capability - replicas that can tell how far behind
+// the primary they are, e.g. from a heartbeat row. It is asked on every read
+// under a staleness tolerance, so it should be cheap.
+type LagReporter interface {
+	Lag(ctx context.Context) (time.Duration, error)
+}
+
+// Stats Where reads went
+type Stats struct {
+	Primary  int            // reads served by the primary
+	Replicas map[string]int // reads served by each replica
+	// Fallbacks counts reads a replica failed and the primary served again;
+	// they are in Primary too
+	Fallbacks int
+	// Stale counts reads sent to the primary because no replica was fresh
+	// enough; they are in Primary too
+	Stale int
+}
+
+// ReadWriteSplitter Decorator sending writes to a primary and reads to
+// replicas. Optional capabilities are forwarded: writes to the primary, reads
+// to a replica that has them.
+type ReadWriteSplitter struct {
+	primary  employee.Repository
+	replicas []Replica
+	policy   Policy
+	maxLag   time.Duration
+	ryw      time.Duration
+	clock    clock.Clock
+	names    normalize.Normalizer
+
+	mu      sync.Mutex
+	written map[string]time.Time // names written less than ryw ago, by their key under names
+	stats   Stats
+}
+
+// Option customises a ReadWriteSplitter created by New
+type Option func(*ReadWriteSplitter)
+
+// WithPolicy chooses replicas with p; a RoundRobin by default.
+func WithPolicy(p Policy) Option { return func(s *ReadWriteSplitter) { s.policy = p } }
+
+// WithMaxStaleness skips replicas more than d behind the primary, and those
+// that can't tell (no LagReporter, or an error). With none left, the primary
+// serves the read. By default any lag is tolerated.
+func WithMaxStaleness(d time.Duration) Option {
+	return func(s *ReadWriteSplitter) { s.maxLag = d }
+}
+
+// WithReadYourWrites reads an employee from the primary for d after it was
+// written through the splitter, so a caller sees its own write even though
+// the replicas have yet to. Listings are not covered: they read from a
+// replica and may miss it.
+func WithReadYourWrites(d time.Duration) Option {
+	return func(s *ReadWriteSplitter) { s.ryw = d }
+}
+
+func WithClock(c clock.Clock) Option { return func(s *ReadWriteSplitter) { s.clock = c } }
+
+// WithNormalizer keeps a name written within WithReadYourWrites on the
+// primary under every spelling the repositories would find it by;
+// normalize.Name by default, as in the memory and SQL repositories.
+func WithNormalizer(n normalize.Normalizer) Option {
+	return func(s *ReadWriteSplitter) { s.names = n }
+}
+
+// New routes writes to primary and reads to replicas. Without replicas,
+// everything goes to the primary.
+func New(primary employee.Repository, replicas []Replica, opts ...Option) *ReadWriteSplitter {
+	s := &ReadWriteSplitter{
+		primary:  primary,
+		replicas: replicas,
+		policy:   &RoundRobin{},
+		clock:    clock.Real{},
+		names:    normalize.Name,
+		written:  map[string]time.Time{},
+		stats:    Stats{Replicas: map[string]int{}},
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// Wrapped returns the primary.
+func (s *ReadWriteSplitter) Wrapped() any { return s.primary }
+
+// Stats returns where reads went so far.
+func (s *ReadWriteSplitter) Stats() Stats {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	st := s.stats
+	st.Replicas = maps.Clone(s.stats.Replicas)
+	return st
+}
+
+// route returns the replica a read of name ("" for a listing) goes to, or
+// false for the primary.
+func (s *ReadWriteSplitter) route(ctx context.Context, name string) (Replica, bool) {
+	if name != "" && s.ryw > 0 {
+		s.mu.Lock()
+		at, ok := s.written[s.names.Normalize(name)]
+		s.mu.Unlock()
+		if ok && s.clock.Now().Sub(at) < s.ryw {
+			return Replica{}, false
+		}
+	}
+	candidates := s.replicas
+	if s.maxLag > 0 {
+		candidates = nil
+		for _, r := range s.replicas {
+			l, ok := r.Repo.(LagReporter)
+			if !ok {
+				continue
+			}
+			if lag, err := l.Lag(ctx); err == nil && lag <= s.maxLag {
+				candidates = append(candidates, r)
+			}
+		}
+		if len(candidates) == 0 && len(s.replicas) > 0 {
+			s.mu.Lock()
+			s.stats.Stale++
+			s.mu.Unlock()
+		}
+	}
+	if len(candidates) == 0 {
+		return Replica{}, false
+	}
+	return candidates[s.policy.Pick(candidates)], true
+}
+
+// read runs op on the replica route chooses, and again on the primary when
+// the replica fails. Not finding the employee, an unsupported capability and
+// the caller giving up are answers, not failures.
+func read[T any](ctx context.Context, s *ReadWriteSplitter, name string, op func(employee.Repository) (T, error)) (T, error) {
+	r, ok := s.route(ctx, name)
+	if ok {
+		start := s.clock.Now()
+		v, err := op(r.Repo)
+		failed := err != nil && ctx.Err() == nil &&
+			!errors.Is(err, employee.ErrNotFound) && !errors.Is(err, errors.ErrUnsupported)
+		if o, ok := s.policy.(Observer); ok {
+			o.Observe(r.Name, s.clock.Now().Sub(start), failed)
+		}
+		s.mu.Lock()
+		if !failed {
+			s.stats.Replicas[r.Name]++
+		} else {
+			s.stats.Fallbacks++
+		}
+		s.mu.Unlock()
+		if !failed {
+			return v, err
+		}
+	}
+	s.mu.Lock()
+	s.stats.Primary++
+	s.mu.Unlock()
+	return op(s.primary)
+}
+
+// wrote records writes of names for WithReadYourWrites, and forgets those
+// that no longer need the primary.
+func (s *ReadWriteSplitter) wrote(names ...string) {
+	if s.ryw <= 0 {
+		return
+	}
+	now := s.clock.Now()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for name, at := range s.written {
+		if now.Sub(at) >= s.ryw {
+			delete(s.written, name)
+		}
+	}
+	for _, name := range names {
+		s.written[s.names.Normalize(name)] = now
+	}
+}
+
+// wroteRecently reports whether any write is still within
+// WithReadYourWrites.
+func (s *ReadWriteSplitter) wroteRecently() bool {
+	if s.ryw <= 0 {
+		return false
+	}
+	now := s.clock.Now()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, at := range s.written {
+		if now.Sub(at) < s.ryw {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *ReadWriteSplitter) Save(ctx context.Context, emp employee.Employee) error {
+	err := s.primary.Save(ctx, emp)
+	s.wrote(emp.Name) // even on error: the write may have happened
+	return err
+}
+
+func (s *ReadWriteSplitter) Update(ctx context.Context, emp employee.Employee, expectedVersion int) (employee.Employee, error) {
+	u, ok := s.primary.(employee.Updater)
+	if !ok {
+		return employee.Employee{}, errors.ErrUnsupported
+	}
+	stored, err := u.Update(ctx, emp, expectedVersion)
+	s.wrote(emp.Name)
+	return stored, err
+}
+
+func (s *ReadWriteSplitter) GetByName(ctx context.Context, name string) (employee.Employee, error) {
+	return read(ctx, s, name, func(repo employee.Repository) (employee.Employee, error) {
+		return repo.GetByName(ctx, name)
+	})
+}
+
+// GetByID reads from a replica unless anything was written within
+// WithReadYourWrites: the name, which that window is kept by, isn't known
+// until the employee is found.
+func (s *ReadWriteSplitter) GetByID(ctx context.Context, id employee.ID) (employee.Employee, error) {
+	if s.wroteRecently() {
+		s.mu.Lock()
+		s.stats.Primary++
+		s.mu.Unlock()
+		return employee.GetByID(ctx, s.primary, id)
+	}
+	return read(ctx, s, "", func(repo employee.Repository) (employee.Employee, error) {
+		return employee.GetByID(ctx, repo, id)
+	})
+}
+
+func (s *ReadWriteSplitter) SaveAll(ctx context.Context, emps iter.Seq[employee.Employee]) error {
+	var names []string
+	err := employee.SaveAll(ctx, s.primary, func(yield func(employee.Employee) bool) {
+		for emp := range emps {
+			names = append(names, emp.Name)
+			if !yield(emp) {
+				return
+			}
+		}
+	})
+	s.wrote(names...)
+	return err
+}
+
+func (s *ReadWriteSplitter) SoftDelete(ctx context.Context, name string) error {
+	d, ok := s.primary.(employee.SoftDeleter)
+	if !ok {
+		return errors.ErrUnsupported
+	}
+	err := d.SoftDelete(ctx, name)
+	s.wrote(name)
+	return err
+}
+
+func (s *ReadWriteSplitter) Restore(ctx context.Context, name string) error {
+	d, ok := s.primary.(employee.SoftDeleter)
+	if !ok {
+		return errors.ErrUnsupported
+	}
+	err := d.Restore(ctx, name)
+	s.wrote(name)
+	return err
+}
+
+func (s *ReadWriteSplitter) SaveWithOutbox(ctx context.Context, emp employee.Employee, msgs []outbox.Message) error {
+	o, ok := s.primary.(employee.OutboxRepository)
+	if !ok {
+		return errors.ErrUnsupported
+	}
+	err := o.SaveWithOutbox(ctx, emp, msgs)
+	s.wrote(emp.Name)
+	return err
+}
+
+func (s *ReadWriteSplitter) History(ctx context.Context, name string) ([]employee.Employee, error) {
+	return read(ctx, s, name, func(repo employee.Repository) ([]employee.Employee, error) {
+		v, ok := repo.(employee.Versioned)
+		if !ok {
+			return nil, errors.ErrUnsupported
+		}
+		return v.History(ctx, name)
+	})
+}
+
+func (s *ReadWriteSplitter) List(ctx context.Context, filter employee.Filter, page employee.Page) (employee.PageResult, error) {
+	return read(ctx, s, "", func(repo employee.Repository) (employee.PageResult, error) {
+		q, ok := repo.(employee.QueryRepository)
+		if !ok {
+			return employee.PageResult{}, errors.ErrUnsupported
+		}
+		return q.List(ctx, filter, page)
+	})
+}
+
+func (s *ReadWriteSplitter) Matching(ctx context.Context, sp spec.Specification[employee.Employee]) ([]employee.Employee, error) {
+	return read(ctx, s, "", func(repo employee.Repository) ([]employee.Employee, error) {
+		m, ok := repo.(employee.SpecificationRepository)
+		if !ok {
+			return nil, errors.ErrUnsupported
+		}
+		return m.Matching(ctx, sp)
+	})
+}
+
+// All streams from one replica. A stream that fails partway is not resumed
+// on the primary: the caller has seen part of it.
+func (s *ReadWriteSplitter) All(ctx context.Context) iter.Seq2[employee.Employee, error] {
+	r, ok := s.route(ctx, "")
+	s.mu.Lock()
+	if ok {
+		s.stats.Replicas[r.Name]++
+	} else {
+		s.stats.Primary++
+	}
+	s.mu.Unlock()
+	if !ok {
+		return employee.All(ctx, s.primary)
+	}
+	return employee.All(ctx, r.Repo)
+}
+
+var (
+	_ employee.Repository              = (*ReadWriteSplitter)(nil)
+	_ employee.BulkSaver               = (*ReadWriteSplitter)(nil)
+	_ employee.SoftDeleter             = (*ReadWriteSplitter)(nil)
+	_ employee.Updater                 = (*ReadWriteSplitter)(nil)
+	_ employee.IDRepository            = (*ReadWriteSplitter)(nil)
+	_ employee.Versioned               = (*ReadWriteSplitter)(nil)
+	_ employee.QueryRepository         = (*ReadWriteSplitter)(nil)
+	_ employee.SpecificationRepository = (*ReadWriteSplitter)(nil)
+	_ employee.OutboxRepository        = (*ReadWriteSplitter)(nil)
+	_ employee.Iterable                = (*ReadWriteSplitter)(nil)
+)
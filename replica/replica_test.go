@@ -0,0 +1,126 @@
+package replica_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"go-solid/clock"
+	"go-solid/employee"
+	"go-solid/employee/employeetest"
+	"go-solid/employee/memory"
+	"go-solid/replica"
+)
+
+func TestRepository(t *testing.T) {
+	// replicas that are the primary never lag, so the splitter must keep the
+	// contract as the primary does whichever of them serves a read
+	t.Run("replicas in sync", func(t *testing.T) {
+		employeetest.TestRepository(t, func(t *testing.T) employee.Repository {
+			primary := memory.New()
+			return replica.New(primary, []replica.Replica{{Name: "a", Repo: primary}, {Name: "b", Repo: primary}})
+		})
+	})
+	t.Run("read your writes", func(t *testing.T) {
+		employeetest.TestRepository(t, func(t *testing.T) employee.Repository {
+			primary := memory.New()
+			return replica.New(primary, []replica.Replica{{Name: "a", Repo: primary}}, replica.WithReadYourWrites(time.Minute))
+		})
+	})
+}
+
+// lagging A replica that applies nothing by itself: the test copies the
+// primary into it, and says how far behind it is
+type lagging struct {
+	*memory.Repository
+	lag  time.Duration
+	down bool
+}
+
+func (l *lagging) Lag(context.Context) (time.Duration, error) { return l.lag, nil }
+
+func (l *lagging) GetByName(ctx context.Context, name string) (employee.Employee, error) {
+	if l.down {
+		return employee.Employee{}, errors.New("connection refused")
+	}
+	return l.Repository.GetByName(ctx, name)
+}
+
+// mute A replica that can't tell its lag
+type mute struct{ *memory.Repository }
+
+func TestReadWriteSplitter_MaxStaleness(t *testing.T) {
+	primary := memory.New()
+	_ = primary.Save(t.Context(), employee.Employee{Name: "Ali"})
+	fresh := &lagging{Repository: memory.New(), lag: 500 * time.Millisecond}
+	_ = fresh.Save(t.Context(), employee.Employee{Name: "Ali"})
+	behind := &lagging{Repository: memory.New(), lag: 2 * time.Second}
+	rw := replica.New(primary, []replica.Replica{{Name: "fresh", Repo: fresh}, {Name: "behind", Repo: behind}, {Name: "mute", Repo: mute{memory.New()}}},
+		replica.WithMaxStaleness(time.Second))
+
+	for range 6 {
+		if _, err := rw.GetByName(t.Context(), "Ali"); err != nil {
+			t.Fatalf("GetByName(Ali) error = %v, want it from fresh", err)
+		}
+	}
+	if st := rw.Stats(); st.Replicas["fresh"] != 6 || st.Primary != 0 || st.Stale != 0 {
+		t.Errorf("Stats() = %+v, want all 6 reads on fresh: behind lags 2s, mute can't tell", st)
+	}
+
+	fresh.lag = 5 * time.Second
+	if _, err := rw.GetByName(t.Context(), "Ali"); err != nil {
+		t.Fatalf("GetByName(Ali) error = %v, want it from the primary", err)
+	}
+	if st := rw.Stats(); st.Primary != 1 || st.Stale != 1 {
+		t.Errorf("Stats() = primary %d, stale %d, want the read on the primary as no replica is fresh", st.Primary, st.Stale)
+	}
+}
+
+func TestReadWriteSplitter_ReadYourWrites(t *testing.T) {
+	clk := clock.NewFake(time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC))
+	primary, behind := memory.New(), &lagging{Repository: memory.New(), lag: time.Hour}
+	rw := replica.New(primary, []replica.Replica{{Name: "behind", Repo: behind}},
+		replica.WithReadYourWrites(5*time.Second), replica.WithClock(clk))
+	if err := rw.Save(t.Context(), employee.Employee{Name: "Ali"}); err != nil {
+		t.Fatal(err)
+	}
+	ali, err := rw.GetByName(t.Context(), "Ali")
+	if err != nil {
+		t.Fatalf("GetByName(Ali) just after saving error = %v, want it from the primary", err)
+	}
+	if _, err := rw.GetByID(t.Context(), ali.ID); err != nil {
+		t.Errorf("GetByID() just after saving error = %v, want it from the primary", err)
+	}
+	if _, err := rw.GetByName(t.Context(), "Sara"); !errors.Is(err, employee.ErrNotFound) {
+		t.Errorf("GetByName(Sara) error = %v, want %v from the replica", err, employee.ErrNotFound)
+	}
+	if st := rw.Stats(); st.Primary != 2 || st.Replicas["behind"] != 1 {
+		t.Errorf("Stats() = %+v, want Ali's reads on the primary and Sara's, never written, on the replica", st)
+	}
+
+	clk.Advance(5 * time.Second)
+	if _, err := rw.GetByName(t.Context(), "Ali"); !errors.Is(err, employee.ErrNotFound) {
+		t.Errorf("GetByName(Ali) 5s later error = %v, want the replica's stale %v", err, employee.ErrNotFound)
+	}
+}
+
+func TestReadWriteSplitter_FallsBackToThePrimary(t *testing.T) {
+	primary, down := memory.New(), &lagging{Repository: memory.New(), down: true}
+	_ = primary.Save(t.Context(), employee.Employee{Name: "Ali"})
+	rw := replica.New(primary, []replica.Replica{{Name: "down", Repo: down}})
+	if _, err := rw.GetByName(t.Context(), "Ali"); err != nil {
+		t.Fatalf("GetByName(Ali) error = %v, want it from the primary", err)
+	}
+	if st := rw.Stats(); st.Fallbacks != 1 || st.Primary != 1 || st.Replicas["down"] != 0 {
+		t.Errorf("Stats() = %+v, want one fallback to the primary", st)
+	}
+
+	down.down = false
+	if _, err := rw.GetByName(t.Context(), "Ali"); !errors.Is(err, employee.ErrNotFound) {
+		t.Errorf("GetByName(Ali) error = %v, want the replica's %v: not finding is an answer", err, employee.ErrNotFound)
+	}
+	if st := rw.Stats(); st.Fallbacks != 1 || st.Replicas["down"] != 1 {
+		t.Errorf("Stats() = %+v, want the read on the replica, not a fallback", st)
+	}
+}
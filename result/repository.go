@@ -0,0 +1,41 @@
+package result
+
+import (
+	"context"
+	"errors"
+
+	"go-solid/employee"
+)
+
+// Repository employee.Repository in Result style: a missing employee is
+// Ok(None), and only a failure of the backend is an error
+type Repository interface {
+	Save(ctx context.Context, emp employee.Employee) error
+	GetByName(ctx context.Context, name string) Result[Option[employee.Employee]]
+}
+
+// Adapter Repository over any employee.Repository - the boundary where
+// ErrNotFound turns into None
+type Adapter struct {
+	repo employee.Repository
+}
+
+var _ Repository = (*Adapter)(nil)
+
+// Adapt returns repo as a Repository.
+func Adapt(repo employee.Repository) *Adapter { return &Adapter{repo: repo} }
+
+func (a *Adapter) Save(ctx context.Context, emp employee.Employee) error {
+	return a.repo.Save(ctx, emp)
+}
+
+func (a *Adapter) GetByName(ctx context.Context, name string) Result[Option[employee.Employee]] {
+	emp, err := a.repo.GetByName(ctx, name)
+	switch {
+	case errors.Is(err, employee.ErrNotFound):
+		return Ok(None[employee.Employee]())
+	case err != nil:
+		return Fail[Option[employee.Employee]](err)
+	}
+	return Ok(Some(emp))
+}
@@ -0,0 +1,134 @@
+// Package result is an experiment: Result and Option, the way Rust and the
+// functional languages return failure and absence, written with generics. It
+// exists to be compared with Go's (T, error), not to replace it.
+//
+// What it buys:
+//   - Absence and failure are different types. GetByName returning
+//     Result[Option[Employee]] says in its signature that "no such employee"
+//     is an answer, not an error; (Employee, error) needs ErrNotFound and a
+//     doc comment to say the same.
+//   - A value is read through Get, Or or Must, each a decision about the
+//     error: there is no zero Employee to use by mistake next to a non-nil err.
+//   - Steps chain with Then and Map instead of an if err != nil per step.
+//
+// What it costs:
+//   - Go has no methods with type parameters, so Map and Then are functions,
+//     and a chain reads inside out: Map(Then(r, f), g).
+//   - No ? operator: every Result is unwrapped with Get back into (T, error)
+//     at the first if, so most code ends up written in both styles.
+//   - Every library - database/sql, net/http, the rest of this module - speaks
+//     (T, error). Each boundary converts with Of and Get.
+//   - Save has nothing to return. Result[struct{}] is noise, so Repository
+//     keeps error there, and the style is mixed even inside one interface.
+//   - Readers expect (T, error); errcheck, vet and every Go programmer know it.
+//
+// examples/result puts the two side by side. The verdict the README draws:
+// Option is worth a look where absence is common and meaningful; Result
+// mostly restates what (T, error) already says.
+package result
+
+import "errors"
+
+// ErrNone The error of a Result failed without one
+var ErrNone = errors.New("result: no value")
+
+// Result Either a value or the error that prevented it
+type Result[T any] struct {
+	value T
+	err   error
+}
+
+// Ok returns a successful Result holding v.
+func Ok[T any](v T) Result[T] { return Result[T]{value: v} }
+
+// Fail returns a failed Result. A nil err would make it a success holding
+// nothing, so it fails with ErrNone instead.
+func Fail[T any](err error) Result[T] {
+	if err == nil {
+		err = ErrNone
+	}
+	return Result[T]{err: err}
+}
+
+// Of converts the (T, error) every Go function returns.
+func Of[T any](v T, err error) Result[T] {
+	if err != nil {
+		return Fail[T](err)
+	}
+	return Ok(v)
+}
+
+// Get converts back to (T, error), for an if err != nil or a caller that
+// doesn't know about Result.
+func (r Result[T]) Get() (T, error) { return r.value, r.err }
+
+func (r Result[T]) IsOk() bool { return r.err == nil }
+
+// Err returns the error, nil for a success.
+func (r Result[T]) Err() error { return r.err }
+
+// Or returns the value, or fallback when r failed.
+func (r Result[T]) Or(fallback T) T {
+	if r.err != nil {
+		return fallback
+	}
+	return r.value
+}
+
+// Must returns the value and panics when r failed - for tests and main.
+func (r Result[T]) Must() T {
+	if r.err != nil {
+		panic(r.err)
+	}
+	return r.value
+}
+
+// Map applies f to the value of a successful r.
+func Map[T, U any](r Result[T], f func(T) U) Result[U] {
+	if r.err != nil {
+		return Fail[U](r.err)
+	}
+	return Ok(f(r.value))
+}
+
+// Then runs the next step, which may fail, on the value of a successful r.
+// The first failure skips every later step.
+func Then[T, U any](r Result[T], f func(T) Result[U]) Result[U] {
+	if r.err != nil {
+		return Fail[U](r.err)
+	}
+	return f(r.value)
+}
+
+// Option Either a value or nothing, with no error involved
+type Option[T any] struct {
+	value T
+	ok    bool
+}
+
+// Some returns an Option holding v.
+func Some[T any](v T) Option[T] { return Option[T]{value: v, ok: true} }
+
+// None returns an empty Option.
+func None[T any]() Option[T] { return Option[T]{} }
+
+// Get returns the value and whether there is one, like a map lookup.
+func (o Option[T]) Get() (T, bool) { return o.value, o.ok }
+
+func (o Option[T]) IsSome() bool { return o.ok }
+
+// Or returns the value, or fallback when there is none.
+func (o Option[T]) Or(fallback T) T {
+	if !o.ok {
+		return fallback
+	}
+	return o.value
+}
+
+// OkOr turns absence into err: Ok(v) for Some(v), Fail(err) for None.
+func OkOr[T any](o Option[T], err error) Result[T] {
+	if !o.ok {
+		return Fail[T](err)
+	}
+	return Ok(o.value)
+}
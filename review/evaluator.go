@@ -0,0 +1,78 @@
+package review
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math"
+
+	"go-solid/codec"
+)
+
+// Evaluator Runs the reviews of a cycle through one Scorer and bands the
+// scores
+type Evaluator struct {
+	scorer Scorer
+	bands  Bands
+}
+
+// Option customises an Evaluator created by NewEvaluator
+type Option func(*Evaluator)
+
+// WithBands reports scores on b instead of DefaultBands.
+func WithBands(b Bands) Option { return func(e *Evaluator) { e.bands = b } }
+
+func NewEvaluator(scorer Scorer, opts ...Option) *Evaluator {
+	e := &Evaluator{scorer: scorer, bands: DefaultBands}
+	for _, opt := range opts {
+		opt(e)
+	}
+	return e
+}
+
+// Evaluate scores one review. Scores are rounded to two decimals.
+func (e *Evaluator) Evaluate(r Review) (Result, error) {
+	s, err := e.scorer.Score(r.Evidence)
+	if err != nil {
+		return Result{}, fmt.Errorf("review of %s: %w", r.Employee, err)
+	}
+	value := math.Round(100*s.Value) / 100
+	return Result{
+		Employee: r.Employee,
+		Cycle:    r.Cycle,
+		Method:   e.scorer.Name(),
+		Score:    value,
+		Band:     e.bands.Of(value),
+		Notes:    s.Notes,
+	}, nil
+}
+
+// EvaluateAll scores every review it can, in order, and returns the errors
+// of the others joined.
+func (e *Evaluator) EvaluateAll(reviews []Review) ([]Result, error) {
+	var (
+		results []Result
+		errs    []error
+	)
+	for _, r := range reviews {
+		res, err := e.Evaluate(r)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		results = append(results, res)
+	}
+	return results, errors.Join(errs...)
+}
+
+// WriteReport writes results to w in c's format - any registered codec, or
+// one wrapped by redact.NewCodec.
+func WriteReport(w io.Writer, c codec.Codec, results []Result) error {
+	enc := c.NewEncoder(w)
+	for _, r := range results {
+		if err := enc.Encode(r); err != nil {
+			return fmt.Errorf("review report: %w", err)
+		}
+	}
+	return enc.Close()
+}
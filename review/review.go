@@ -0,0 +1,131 @@
+// Package review scores performance reviews.
+//
+// How a review becomes a score is a Scorer: a manager's rating, progress
+// on OKRs, 360-degree feedback, or a blend of them. The Evaluator that runs
+// a review cycle only knows the interface, so a new method is a new type
+// (OCP). Turning a score into a band and writing the results out are jobs
+// of their own - Bands and WriteReport - so changing the scale or the
+// output format touches neither the scorers nor the Evaluator (SRP).
+package review
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+)
+
+// Evidence What a review has to go on. Each Scorer reads the part it needs.
+type Evidence struct {
+	Rating     int // the manager's rating, 1 to 5
+	Objectives []Objective
+	Feedback   []Feedback
+}
+
+// Objective An OKR objective: Weight relative to the other objectives
+type Objective struct {
+	Title      string
+	Weight     float64
+	KeyResults []KeyResult
+}
+
+// KeyResult Progress towards it, from 0 to 1
+type KeyResult struct {
+	Title    string
+	Progress float64
+}
+
+// Relation Who gave feedback, relative to the person reviewed
+type Relation string
+
+const (
+	Manager Relation = "manager"
+	Peer    Relation = "peer"
+	Report  Relation = "report" // someone they manage
+	Self    Relation = "self"
+)
+
+// Feedback One rating, 1 to 5, in a 360-degree review
+type Feedback struct {
+	From     string
+	Relation Relation
+	Rating   int
+}
+
+// Review One employee's review in a cycle
+type Review struct {
+	Employee string
+	Cycle    string // e.g. "2025-H1"
+	Evidence Evidence
+}
+
+// Score A review's score, from 1 to 5, and how it was reached
+type Score struct {
+	Value float64
+	Notes string
+}
+
+// Scorer Strategy turning evidence into a score
+type Scorer interface {
+	Name() string
+	Score(ev Evidence) (Score, error)
+}
+
+var (
+	// ErrNoEvidence returned by a Scorer when the evidence lacks what it
+	// scores
+	ErrNoEvidence = errors.New("no evidence to score")
+	// ErrInvalidEvidence returned for ratings, progress or weights out of
+	// range
+	ErrInvalidEvidence = errors.New("invalid evidence")
+)
+
+// Band A label for scores of at least Min
+type Band struct {
+	Min   float64
+	Label string
+}
+
+// Bands The scale scores are reported on, highest band first
+type Bands []Band
+
+// DefaultBands A five-point scale
+var DefaultBands = Bands{
+	{4.5, "exceptional"},
+	{3.5, "exceeds"},
+	{2.5, "meets"},
+	{1.5, "developing"},
+	{0, "below"},
+}
+
+// Of returns the label of the band score falls in.
+func (b Bands) Of(score float64) string {
+	for _, band := range b {
+		if score >= band.Min {
+			return band.Label
+		}
+	}
+	return ""
+}
+
+// Result Wire format of a scored review. The redact tags let
+// redact.NewCodec mask who got which score.
+type Result struct {
+	Employee string  `json:"employee" redact:"pii"`
+	Cycle    string  `json:"cycle"`
+	Method   string  `json:"method"`
+	Score    float64 `json:"score"`
+	Band     string  `json:"band"`
+	Notes    string  `json:"notes,omitempty"`
+}
+
+func (Result) CSVHeader() []string {
+	return []string{"employee", "cycle", "method", "score", "band", "notes"}
+}
+
+func (r Result) CSVRecord() []string {
+	return []string{r.Employee, r.Cycle, r.Method, strconv.FormatFloat(r.Score, 'f', 2, 64), r.Band, r.Notes}
+}
+
+func (r Result) String() string {
+	return fmt.Sprintf("%s %.2f (%s)", r.Employee, r.Score, r.Band)
+}
@@ -0,0 +1,170 @@
+package review
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+func validRating(r int) bool { return r >= 1 && r <= 5 }
+
+// SimpleRating Scorer taking the manager's rating as it is
+type SimpleRating struct{}
+
+func (SimpleRating) Name() string { return "rating" }
+
+func (SimpleRating) Score(ev Evidence) (Score, error) {
+	switch {
+	case ev.Rating == 0:
+		return Score{}, fmt.Errorf("no rating: %w", ErrNoEvidence)
+	case !validRating(ev.Rating):
+		return Score{}, fmt.Errorf("rating %d: %w", ev.Rating, ErrInvalidEvidence)
+	}
+	return Score{Value: float64(ev.Rating), Notes: fmt.Sprintf("rated %d", ev.Rating)}, nil
+}
+
+// OKR Scorer averaging key-result progress per objective, weighting the
+// objectives, and mapping progress onto the scale so that Target progress
+// scores 4 and full progress 5 - ambitious OKRs aren't meant to be met in
+// full. Target is 0.7 when zero.
+type OKR struct {
+	Target float64
+}
+
+func (OKR) Name() string { return "okr" }
+
+func (o OKR) Score(ev Evidence) (Score, error) {
+	if len(ev.Objectives) == 0 {
+		return Score{}, fmt.Errorf("no objectives: %w", ErrNoEvidence)
+	}
+	target := o.Target
+	if target == 0 {
+		target = 0.7
+	}
+	var sum, weights float64
+	for _, obj := range ev.Objectives {
+		if obj.Weight <= 0 || len(obj.KeyResults) == 0 {
+			return Score{}, fmt.Errorf("objective %q needs a weight and key results: %w", obj.Title, ErrInvalidEvidence)
+		}
+		var progress float64
+		for _, kr := range obj.KeyResults {
+			if kr.Progress < 0 || kr.Progress > 1 {
+				return Score{}, fmt.Errorf("key result %q: progress %v: %w", kr.Title, kr.Progress, ErrInvalidEvidence)
+			}
+			progress += kr.Progress
+		}
+		sum += obj.Weight * progress / float64(len(obj.KeyResults))
+		weights += obj.Weight
+	}
+	progress := sum / weights
+	value := 1 + 3*progress/target // 0 -> 1, target -> 4
+	if progress > target {
+		value = 4 + (progress-target)/(1-target)
+	}
+	return Score{Value: value, Notes: fmt.Sprintf("%.0f%% of key results, target %.0f%%", 100*progress, 100*target)}, nil
+}
+
+// ThreeSixty Scorer averaging feedback per relation, then weighting the
+// relations. Relations nobody rated from are left out and the others
+// weigh more; so are groups too small to keep their raters anonymous.
+type ThreeSixty struct {
+	// Weights by relation; DefaultWeights when nil. A relation missing from
+	// it doesn't count.
+	Weights map[Relation]float64
+	// MinRaters is the fewest peers or reports whose average is used, so no
+	// single rating can be traced back; 2 when zero. Managers and self
+	// ratings aren't anonymous and always count.
+	MinRaters int
+}
+
+// DefaultWeights How much each relation counts in a 360
+var DefaultWeights = map[Relation]float64{Manager: 0.4, Peer: 0.3, Report: 0.2, Self: 0.1}
+
+func (ThreeSixty) Name() string { return "360" }
+
+func (t ThreeSixty) Score(ev Evidence) (Score, error) {
+	weights := t.Weights
+	if weights == nil {
+		weights = DefaultWeights
+	}
+	minRaters := t.MinRaters
+	if minRaters == 0 {
+		minRaters = 2
+	}
+	sums, counts := map[Relation]float64{}, map[Relation]int{}
+	for _, f := range ev.Feedback {
+		if !validRating(f.Rating) {
+			return Score{}, fmt.Errorf("rating %d from %s: %w", f.Rating, f.From, ErrInvalidEvidence)
+		}
+		sums[f.Relation] += float64(f.Rating)
+		counts[f.Relation]++
+	}
+	var sum, total float64
+	var notes []string
+	for _, rel := range []Relation{Manager, Peer, Report, Self} {
+		n := counts[rel]
+		anonymous := rel == Peer || rel == Report
+		switch {
+		case n == 0 || weights[rel] == 0:
+			continue
+		case anonymous && n < minRaters:
+			notes = append(notes, fmt.Sprintf("%s left out: %d of %d raters", rel, n, minRaters))
+			continue
+		}
+		avg := sums[rel] / float64(n)
+		sum += weights[rel] * avg
+		total += weights[rel]
+		notes = append(notes, fmt.Sprintf("%s %.1f", rel, avg))
+	}
+	if total == 0 {
+		return Score{}, fmt.Errorf("no usable feedback: %w", ErrNoEvidence)
+	}
+	return Score{Value: sum / total, Notes: strings.Join(notes, ", ")}, nil
+}
+
+// Weighted A Scorer and how much it counts in a Blend
+type Weighted struct {
+	Scorer Scorer
+	Weight float64
+}
+
+// Blend Composite Scorer weighting the scores of others. A part without
+// evidence is left out and the others weigh more; any other error fails
+// the blend.
+type Blend []Weighted
+
+func (b Blend) Name() string {
+	names := make([]string, len(b))
+	for i, part := range b {
+		names[i] = part.Scorer.Name()
+	}
+	return strings.Join(names, "+")
+}
+
+func (b Blend) Score(ev Evidence) (Score, error) {
+	var sum, total float64
+	var notes []string
+	for _, part := range b {
+		s, err := part.Scorer.Score(ev)
+		switch {
+		case errors.Is(err, ErrNoEvidence):
+			continue
+		case err != nil:
+			return Score{}, fmt.Errorf("%s: %w", part.Scorer.Name(), err)
+		}
+		sum += part.Weight * s.Value
+		total += part.Weight
+		notes = append(notes, fmt.Sprintf("%s %.2f", part.Scorer.Name(), s.Value))
+	}
+	if total == 0 {
+		return Score{}, fmt.Errorf("%s: %w", b.Name(), ErrNoEvidence)
+	}
+	return Score{Value: sum / total, Notes: strings.Join(notes, ", ")}, nil
+}
+
+var (
+	_ Scorer = SimpleRating{}
+	_ Scorer = OKR{}
+	_ Scorer = ThreeSixty{}
+	_ Scorer = Blend{}
+)
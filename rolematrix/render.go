@@ -0,0 +1,114 @@
+package rolematrix
+
+import (
+	"fmt"
+	"html/template"
+	"io"
+	"slices"
+	"strings"
+)
+
+// Renderer Strategy - writes a Matrix in some document format
+type Renderer interface {
+	Render(w io.Writer, m Matrix) error
+}
+
+// cell marks a role a player plays; † when only its pointer does.
+func cell(p Player, i int) string {
+	switch {
+	case !p.Plays[i]:
+		return ""
+	case p.Pointer[i]:
+		return "✅†"
+	}
+	return "✅"
+}
+
+func pointerOnly(m Matrix) bool {
+	for _, p := range m.Players {
+		if slices.Contains(p.Pointer, true) {
+			return true
+		}
+	}
+	return false
+}
+
+// Markdown A GitHub-flavoured table followed by the methods of each role
+type Markdown struct{}
+
+func (Markdown) Render(w io.Writer, m Matrix) error {
+	var b strings.Builder
+	b.WriteString("| Type |")
+	for _, r := range m.Roles {
+		fmt.Fprintf(&b, " %s |", r.Name)
+	}
+	b.WriteString("\n|------|")
+	for range m.Roles {
+		b.WriteString(":-:|")
+	}
+	b.WriteString("\n")
+	for _, p := range m.Players {
+		fmt.Fprintf(&b, "| %s |", p.Name)
+		for i := range m.Roles {
+			fmt.Fprintf(&b, " %s |", cell(p, i))
+		}
+		b.WriteString("\n")
+	}
+	b.WriteString("\n")
+	if pointerOnly(m) {
+		b.WriteString("† implemented by the pointer type only\n\n")
+	}
+	for _, r := range m.Roles {
+		fmt.Fprintf(&b, "- **%s**: %s\n", r.Name, strings.Join(r.Methods, ", "))
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// HTML A standalone page; hovering a column header lists the role's methods
+type HTML struct {
+	Title string
+}
+
+var page = template.Must(template.New("matrix").Funcs(template.FuncMap{
+	"cell":        cell,
+	"pointerOnly": pointerOnly,
+	"join":        strings.Join,
+}).Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>{{.Title}}</title>
+<style>
+table { border-collapse: collapse; font-family: sans-serif; }
+th, td { border: 1px solid #ccc; padding: 4px 8px; }
+td { text-align: center; }
+td:first-child { text-align: left; font-family: monospace; }
+</style>
+</head>
+<body>
+<h1>{{.Title}}</h1>
+<table>
+<tr><th>Type</th>{{range .Roles}}<th title="{{join .Methods ", "}}">{{.Name}}</th>{{end}}</tr>
+{{range $p := .Players}}<tr><td>{{$p.Name}}</td>{{range $i, $r := $.Roles}}<td>{{cell $p $i}}</td>{{end}}</tr>
+{{end}}</table>
+{{if pointerOnly .Matrix}}<p>† implemented by the pointer type only</p>{{end}}
+</body>
+</html>
+`))
+
+func (h HTML) Render(w io.Writer, m Matrix) error {
+	title := h.Title
+	if title == "" {
+		title = "Role matrix"
+	}
+	return page.Execute(w, struct {
+		Title string
+		Matrix
+	}{title, m})
+}
+
+var (
+	_ Renderer = Markdown{}
+	_ Renderer = HTML{}
+)
@@ -0,0 +1,123 @@
+// Package rolematrix documents which concrete types play which roles.
+//
+// With small role interfaces (ISP) the answer to "what can a Manager do?" is
+// spread over many one-method interfaces and the types that happen to
+// satisfy them. Build type-checks packages, tests every concrete type against
+// every interface, and a Renderer turns the result into a table.
+package rolematrix
+
+import (
+	"context"
+	"fmt"
+	"go/types"
+	"slices"
+
+	"go-solid/typeload"
+)
+
+// Role An interface, with the methods it asks for
+type Role struct {
+	Name    string // qualified with its package when several are loaded
+	Methods []string
+}
+
+// Player A concrete type and, per role, whether it implements it
+type Player struct {
+	Name  string
+	Plays []bool // indexed like Matrix.Roles
+	// Pointer reports, per role, that only the pointer type implements it
+	Pointer []bool
+}
+
+// Matrix Roles as columns, players as rows
+type Matrix struct {
+	Roles   []Role
+	Players []Player
+}
+
+// Load type-checks the packages matching patterns, as go list takes them
+// ("./employee", "./...", "go-solid/payroll"), from dir.
+func Load(dir string, patterns ...string) ([]*types.Package, error) {
+	loaded, err := typeload.Load(context.Background(), dir, patterns...)
+	if err != nil {
+		return nil, err
+	}
+	pkgs := make([]*types.Package, len(loaded))
+	for i, p := range loaded {
+		if len(p.Errors) > 0 {
+			return nil, fmt.Errorf("rolematrix: %w", p.Errors[0])
+		}
+		pkgs[i] = p.Types
+	}
+	return pkgs, nil
+}
+
+// Build tests every named concrete type in pkgs against every exported
+// interface in pkgs. roles, when given, keeps only the listed interfaces,
+// written as "Name", "pkg.Name" or "pkg.*". Types playing no role are left out.
+func Build(pkgs []*types.Package, roles ...string) Matrix {
+	qualify := len(pkgs) > 1
+	name := func(obj types.Object) string {
+		if qualify {
+			return obj.Pkg().Name() + "." + obj.Name()
+		}
+		return obj.Name()
+	}
+
+	var m Matrix
+	var ifaces []*types.Interface
+	var concrete []*types.Named
+	for _, pkg := range pkgs {
+		scope := pkg.Scope()
+		for _, n := range scope.Names() {
+			tn, ok := scope.Lookup(n).(*types.TypeName)
+			if !ok || tn.IsAlias() {
+				continue
+			}
+			named, ok := tn.Type().(*types.Named)
+			if !ok || named.TypeParams().Len() > 0 {
+				continue // generic types need instantiating first
+			}
+			iface, isIface := named.Underlying().(*types.Interface)
+			switch {
+			case !isIface:
+				concrete = append(concrete, named)
+			case tn.Exported() && iface.NumMethods() > 0 && iface.IsMethodSet() && selected(tn, roles):
+				var methods []string
+				for i := range iface.NumMethods() {
+					methods = append(methods, iface.Method(i).Name())
+				}
+				m.Roles = append(m.Roles, Role{Name: name(tn), Methods: methods})
+				ifaces = append(ifaces, iface)
+			}
+		}
+	}
+
+	for _, named := range concrete {
+		p := Player{Name: name(named.Obj()), Plays: make([]bool, len(ifaces)), Pointer: make([]bool, len(ifaces))}
+		plays := false
+		for i, iface := range ifaces {
+			switch {
+			case types.Implements(named, iface):
+				p.Plays[i] = true
+			case types.Implements(types.NewPointer(named), iface):
+				p.Plays[i], p.Pointer[i] = true, true
+			}
+			plays = plays || p.Plays[i]
+		}
+		if plays {
+			m.Players = append(m.Players, p)
+		}
+	}
+	return m
+}
+
+func selected(tn *types.TypeName, roles []string) bool {
+	if len(roles) == 0 {
+		return true
+	}
+	pkg := tn.Pkg().Name()
+	return slices.ContainsFunc(roles, func(r string) bool {
+		return r == tn.Name() || r == pkg+"."+tn.Name() || r == pkg+".*"
+	})
+}
@@ -0,0 +1,78 @@
+package rolematrix_test
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"strings"
+	"testing"
+
+	"go-solid/approve"
+	"go-solid/rolematrix"
+)
+
+// staff Small roles, and the types that happen to play them
+const staff = `package staff
+
+type Worker interface{ Work() string }
+type Approver interface{ Approve(amount int) bool }
+type Reporter interface{ Report() string }
+
+type Engineer struct{}
+
+func (Engineer) Work() string { return "code" }
+
+type Manager struct{ limit int }
+
+func (Manager) Work() string                  { return "meetings" }
+func (m *Manager) Approve(amount int) bool    { return amount <= m.limit }
+func (m *Manager) Report() string             { return "weekly" }
+
+type Intern struct{}
+
+type salary int
+`
+
+func check(t *testing.T, src string) *types.Package {
+	t.Helper()
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, "staff.go", src, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pkg, err := new(types.Config).Check("staff", fset, []*ast.File{f}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return pkg
+}
+
+func TestRenderer(t *testing.T) {
+	m := rolematrix.Build([]*types.Package{check(t, staff)})
+	tests := []struct {
+		name string
+		r    rolematrix.Renderer
+	}{
+		{"markdown", rolematrix.Markdown{}},
+		{"html", rolematrix.HTML{Title: "Staff roles"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out strings.Builder
+			if err := tt.r.Render(&out, m); err != nil {
+				t.Fatalf("Render() error = %v", err)
+			}
+			approve.Verify(t, out.String())
+		})
+	}
+}
+
+func TestBuild_Roles(t *testing.T) {
+	m := rolematrix.Build([]*types.Package{check(t, staff)}, "Approver", "staff.Reporter")
+	var out strings.Builder
+	if err := (rolematrix.Markdown{}).Render(&out, m); err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	approve.Verify(t, out.String())
+}
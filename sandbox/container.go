@@ -0,0 +1,78 @@
+package sandbox
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strconv"
+)
+
+// Container Low-level module - runs the job in a throwaway container with
+// docker or podman. The kernel stays shared unless Runtime is "runsc"
+// (gVisor), which is the choice for code from strangers.
+type Container struct {
+	Engine  string // "docker" (default) or "podman"
+	Image   string // must contain the Go toolchain, e.g. golang:1.25
+	Runtime string // OCI runtime, e.g. runsc; empty is the engine's default
+}
+
+func (c Container) Run(ctx context.Context, job Job) (Outcome, error) {
+	if len(job.Command) == 0 {
+		return Outcome{}, ErrNoCommand
+	}
+	if c.Image == "" {
+		return Outcome{}, errors.New("sandbox: container needs an image")
+	}
+	engine := c.Engine
+	if engine == "" {
+		engine = "docker"
+	}
+	root, err := os.MkdirTemp("", "sandbox-")
+	if err != nil {
+		return Outcome{}, fmt.Errorf("sandbox: %w", err)
+	}
+	defer os.RemoveAll(root)
+	src, err := prepare(root, job.Dir)
+	if err != nil {
+		return Outcome{}, err
+	}
+	name := "sandbox-" + rand.Text()[:12]
+
+	args := []string{"run", "--rm", "--name", name,
+		"--network", "none",
+		"--pids-limit", "256",
+		"--cap-drop", "ALL",
+		"--security-opt", "no-new-privileges",
+		"--volume", src + ":/src",
+		"--workdir", "/src",
+		"--env", "GOPROXY=off", "--env", "GOFLAGS=-mod=mod", "--env", "GOTOOLCHAIN=local", "--env", "CGO_ENABLED=0",
+	}
+	if c.Runtime != "" {
+		args = append(args, "--runtime", c.Runtime)
+	}
+	if l := job.Limits; l.Memory > 0 {
+		args = append(args, "--memory", strconv.FormatInt(l.Memory, 10))
+	}
+	if l := job.Limits; l.CPU > 0 {
+		args = append(args, "--ulimit", "cpu="+strconv.Itoa(max(int(l.CPU.Seconds()), 1)))
+	}
+	if l := job.Limits; l.FileSize > 0 {
+		args = append(args, "--ulimit", "fsize="+strconv.FormatInt(l.FileSize, 10))
+	}
+	args = append(append(args, c.Image), job.Command...)
+
+	return run(ctx, job, func(ctx context.Context) (*exec.Cmd, error) {
+		cmd := exec.CommandContext(ctx, engine, args...)
+		cmd.Cancel = func() error {
+			// killing the client would leave the container running
+			_ = exec.Command(engine, "kill", name).Run()
+			return cmd.Process.Kill()
+		}
+		return cmd, nil
+	})
+}
+
+var _ Sandbox = Container{}
@@ -0,0 +1,112 @@
+package sandbox
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"time"
+)
+
+// Report What go test said about a submission
+type Report struct {
+	// Tests are the top-level tests, plus an entry for each package that
+	// failed without a failing test (it didn't build) and one for a run
+	// that was killed
+	Tests []TestResult
+	// BuildOutput is the compiler's complaints, when there were any
+	BuildOutput string
+	Outcome     Outcome
+}
+
+// TestResult One test's verdict
+type TestResult struct {
+	Package string
+	Name    string
+	Passed  bool
+	Elapsed time.Duration
+	Output  string // what a failing test printed
+}
+
+// Passed names the tests that passed.
+func (r Report) Passed() []string { return r.names(true) }
+
+// Failed names the tests that failed.
+func (r Report) Failed() []string { return r.names(false) }
+
+func (r Report) names(passed bool) []string {
+	var names []string
+	for _, t := range r.Tests {
+		if t.Passed == passed {
+			names = append(names, t.Name)
+		}
+	}
+	return names
+}
+
+// Score is the fraction of tests passed. A submission that timed out or
+// failed to build scores 0 rather than the share of what did run.
+func (r Report) Score() float64 {
+	if r.Outcome.TimedOut || len(r.Tests) == 0 {
+		return 0
+	}
+	return float64(len(r.Passed())) / float64(len(r.Tests))
+}
+
+// OK reports whether every test passed.
+func (r Report) OK() bool { return r.Outcome.Passed() && len(r.Tests) > 0 && len(r.Failed()) == 0 }
+
+// GoTest is the command Grade runs when the job has none.
+var GoTest = []string{"go", "test", "-json", "./..."}
+
+// Grade runs the job's tests in sb and reads the results from go test's
+// JSON output, so the command must print it (GoTest does).
+func Grade(ctx context.Context, sb Sandbox, job Job) (Report, error) {
+	if len(job.Command) == 0 {
+		job.Command = GoTest
+	}
+	out, err := sb.Run(ctx, job)
+	if err != nil {
+		return Report{}, err
+	}
+	r := Report{Outcome: out}
+	testsFailed := map[string]bool{}
+	output := map[string]string{} // by package and top-level test
+	sc := bufio.NewScanner(bytes.NewReader(out.Stdout))
+	for sc.Scan() {
+		var ev struct {
+			Action  string
+			Package string
+			Test    string
+			Elapsed float64
+			Output  string
+		}
+		if json.Unmarshal(sc.Bytes(), &ev) != nil {
+			continue // build output interleaved with the events
+		}
+		top, _, _ := strings.Cut(ev.Test, "/")
+		key := ev.Package + " " + top
+		switch {
+		case ev.Action == "build-output":
+			r.BuildOutput += ev.Output
+		case ev.Action == "output" && top != "":
+			output[key] += ev.Output
+		case ev.Test == "" && ev.Action == "fail" && !testsFailed[ev.Package]:
+			r.Tests = append(r.Tests, TestResult{Package: ev.Package, Name: ev.Package, Output: r.BuildOutput})
+		case ev.Test == "" || top != ev.Test:
+		case ev.Action == "pass" || ev.Action == "fail":
+			t := TestResult{Package: ev.Package, Name: ev.Test, Passed: ev.Action == "pass", Elapsed: time.Duration(ev.Elapsed * float64(time.Second))}
+			if !t.Passed {
+				t.Output = output[key]
+				testsFailed[ev.Package] = true
+			}
+			r.Tests = append(r.Tests, t)
+		}
+	}
+	// a run killed mid-way, or whose output was cut short, failed whatever it printed
+	if out.TimedOut || out.Truncated {
+		r.Tests = append(r.Tests, TestResult{Name: "(incomplete run)", Elapsed: out.Duration})
+	}
+	return r, nil
+}
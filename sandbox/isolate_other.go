@@ -0,0 +1,18 @@
+//go:build !linux
+
+package sandbox
+
+import (
+	"errors"
+	"fmt"
+	"os/exec"
+)
+
+// isolate can't take the network away outside Linux; use a Container there,
+// or set Process.AllowNetwork knowingly.
+func isolate(cmd *exec.Cmd, network bool) error {
+	if !network {
+		return fmt.Errorf("sandbox: network isolation needs Linux namespaces: %w", errors.ErrUnsupported)
+	}
+	return nil
+}
@@ -0,0 +1,220 @@
+// Package sandbox runs untrusted code - a learner's submission - with a
+// deadline, resource limits and no network.
+//
+// Sandbox is the abstraction graders depend on. Process runs the job as a
+// local process; Container hands it to docker or podman, optionally under
+// gVisor. A grader is written once and the isolation is chosen per
+// deployment.
+package sandbox
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Job What to run and how much it may use
+type Job struct {
+	// Dir is the submission. It is copied into the sandbox and never modified.
+	Dir     string
+	Command []string
+	// Timeout is wall-clock time for the whole job; 0 means DefaultTimeout.
+	Timeout time.Duration
+	Limits  Limits
+}
+
+// Limits Resources a job may use; zero leaves a resource unlimited, except
+// Output, which defaults to DefaultOutput
+type Limits struct {
+	CPU      time.Duration // CPU time of each process
+	Memory   int64         // bytes of address space of each process
+	FileSize int64         // bytes of the largest file written
+	Output   int64         // bytes kept of stdout and of stderr
+}
+
+const (
+	DefaultTimeout = time.Minute
+	DefaultOutput  = 1 << 20
+)
+
+// Outcome What the job did. A job that fails, crashes or times out is an
+// Outcome, not an error - errors are reserved for the sandbox itself failing.
+type Outcome struct {
+	ExitCode  int
+	Stdout    []byte
+	Stderr    []byte
+	Duration  time.Duration
+	TimedOut  bool
+	Truncated bool // output went over Limits.Output and the job was killed
+}
+
+// Passed reports whether the job finished in time with exit code 0.
+func (o Outcome) Passed() bool { return o.ExitCode == 0 && !o.TimedOut }
+
+// Sandbox Abstraction - runs a Job in isolation
+type Sandbox interface {
+	Run(ctx context.Context, job Job) (Outcome, error)
+}
+
+// ErrNoCommand returned for a Job without a command
+var ErrNoCommand = errors.New("sandbox: job has no command")
+
+// Process Low-level module - runs the job as a local process in a fresh
+// temporary tree: its own HOME, GOPATH and module cache, no module proxy,
+// and on Linux its own empty network namespace. Limits are applied with
+// ulimit, so they hold for every process the job starts.
+type Process struct {
+	// GoCache is a build cache shared between jobs, so the standard library
+	// is compiled once rather than per submission. Jobs can write to it;
+	// give the sandbox a cache of its own, never the user's. Empty means a
+	// fresh cache per job.
+	GoCache string
+	// AllowNetwork skips the network namespace, for platforms without one.
+	AllowNetwork bool
+}
+
+func (p Process) Run(ctx context.Context, job Job) (Outcome, error) {
+	if len(job.Command) == 0 {
+		return Outcome{}, ErrNoCommand
+	}
+	root, err := os.MkdirTemp("", "sandbox-")
+	if err != nil {
+		return Outcome{}, fmt.Errorf("sandbox: %w", err)
+	}
+	defer os.RemoveAll(root)
+	src, err := prepare(root, job.Dir)
+	if err != nil {
+		return Outcome{}, err
+	}
+	goCache := p.GoCache
+	if goCache == "" {
+		goCache = filepath.Join(root, "gocache")
+	}
+
+	return run(ctx, job, func(ctx context.Context) (*exec.Cmd, error) {
+		// ulimit before exec, so no process of the job ever runs unlimited
+		script := ulimits(job.Limits) + `exec "$@"`
+		cmd := exec.CommandContext(ctx, "/bin/sh", append([]string{"-c", script, "sandbox"}, job.Command...)...)
+		cmd.Dir = src
+		cmd.Env = []string{
+			"PATH=" + os.Getenv("PATH"),
+			"HOME=" + filepath.Join(root, "home"),
+			"TMPDIR=" + filepath.Join(root, "tmp"),
+			"GOPATH=" + filepath.Join(root, "gopath"),
+			"GOCACHE=" + goCache,
+			"GOPROXY=off",
+			"GOFLAGS=-mod=mod",
+			"GOTOOLCHAIN=local",
+			"GOENV=off",
+			"CGO_ENABLED=0",
+		}
+		return cmd, isolate(cmd, p.AllowNetwork)
+	})
+}
+
+// prepare copies the submission to root/src and creates the directories
+// the environment points at.
+func prepare(root, dir string) (string, error) {
+	src := filepath.Join(root, "src")
+	if err := os.CopyFS(src, os.DirFS(dir)); err != nil {
+		return "", fmt.Errorf("sandbox: copy %s: %w", dir, err)
+	}
+	for _, d := range []string{"home", "tmp", "gopath"} {
+		if err := os.Mkdir(filepath.Join(root, d), 0o755); err != nil {
+			return "", fmt.Errorf("sandbox: %w", err)
+		}
+	}
+	return src, nil
+}
+
+// ulimits renders l as shell ulimit commands. POSIX sh counts -f in
+// 512-byte blocks and -v in kilobytes.
+func ulimits(l Limits) string {
+	var b strings.Builder
+	if l.CPU > 0 {
+		fmt.Fprintf(&b, "ulimit -t %d || exit 125; ", max(int64(l.CPU/time.Second), 1))
+	}
+	if l.Memory > 0 {
+		fmt.Fprintf(&b, "ulimit -v %d || exit 125; ", max(l.Memory/1024, 1))
+	}
+	if l.FileSize > 0 {
+		fmt.Fprintf(&b, "ulimit -f %d || exit 125; ", max(l.FileSize/512, 1))
+	}
+	return b.String()
+}
+
+// run builds the command under job's timeout and collects its Outcome.
+// build gets the deadline's context for exec.CommandContext, so a
+// cmd.Cancel it sets is how the whole job is killed.
+func run(ctx context.Context, job Job, build func(ctx context.Context) (*exec.Cmd, error)) (Outcome, error) {
+	timeout := job.Timeout
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	cmd, err := build(ctx)
+	if err != nil {
+		return Outcome{}, err
+	}
+	limit := job.Limits.Output
+	if limit <= 0 {
+		limit = DefaultOutput
+	}
+	// a job that floods its output is stopped rather than left to run
+	stdout, stderr := &capped{limit: limit, full: cancel}, &capped{limit: limit, full: cancel}
+	cmd.Stdout, cmd.Stderr = stdout, stderr
+	cmd.WaitDelay = time.Second
+
+	start := time.Now()
+	err = cmd.Run()
+	out := Outcome{
+		Stdout:    stdout.buf.Bytes(),
+		Stderr:    stderr.buf.Bytes(),
+		Duration:  time.Since(start),
+		TimedOut:  errors.Is(ctx.Err(), context.DeadlineExceeded),
+		Truncated: stdout.truncated || stderr.truncated,
+	}
+	var exit *exec.ExitError
+	switch {
+	case err == nil:
+	case errors.As(err, &exit):
+		out.ExitCode = exit.ExitCode()
+	case out.TimedOut || out.Truncated:
+		out.ExitCode = -1
+	default:
+		return Outcome{}, fmt.Errorf("sandbox: %s: %w", strconv.Quote(strings.Join(job.Command, " ")), err)
+	}
+	return out, nil
+}
+
+// capped Writer that keeps the first limit bytes, discards the rest and
+// calls full once it starts discarding
+type capped struct {
+	buf       bytes.Buffer
+	limit     int64
+	truncated bool
+	full      func()
+}
+
+func (c *capped) Write(p []byte) (int, error) {
+	room := c.limit - int64(c.buf.Len())
+	if int64(len(p)) > room {
+		if !c.truncated {
+			c.truncated = true
+			c.full()
+		}
+		c.buf.Write(p[:max(room, 0)])
+		return len(p), nil
+	}
+	return c.buf.Write(p)
+}
+
+var _ Sandbox = Process{}
@@ -0,0 +1,159 @@
+package sandbox_test
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	"go-solid/sandbox"
+)
+
+// TestHelperProcess is not a test: jobs run this binary with arguments after
+// "--" to do what sh can't.
+func TestHelperProcess(t *testing.T) {
+	i := slices.Index(os.Args, "--")
+	if i < 0 {
+		return
+	}
+	args := os.Args[i+1:]
+	switch args[0] {
+	case "dial":
+		conn, err := net.DialTimeout("tcp", args[1], time.Second)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(3)
+		}
+		conn.Close()
+	}
+	os.Exit(0)
+}
+
+func helper(args ...string) []string {
+	return append([]string{os.Args[0], "-test.run=^TestHelperProcess$", "--"}, args...)
+}
+
+func TestProcess_NoNetwork(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+	go func() {
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+			conn.Close()
+		}
+	}()
+
+	job := sandbox.Job{Dir: t.TempDir(), Command: helper("dial", l.Addr().String()), Timeout: 30 * time.Second}
+	out, err := sandbox.Process{}.Run(t.Context(), job)
+	if errors.Is(err, syscall.EPERM) || errors.Is(err, syscall.EINVAL) || errors.Is(err, syscall.ENOSPC) {
+		t.Skipf("user namespaces are disabled here: %v", err)
+	}
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if out.ExitCode != 3 {
+		t.Errorf("dialing the host from the sandbox: exit %d, %s; want it unreachable", out.ExitCode, out.Stdout)
+	}
+	out, err = sandbox.Process{}.Run(t.Context(), sandbox.Job{Dir: t.TempDir(), Command: []string{"cat", "/proc/net/dev"}})
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	for _, line := range strings.Split(string(out.Stdout), "\n") {
+		if name, _, ok := strings.Cut(line, ":"); ok && strings.TrimSpace(name) != "lo" {
+			t.Errorf("the sandbox has interface %s, want only the loopback", strings.TrimSpace(name))
+		}
+	}
+
+	out, err = sandbox.Process{AllowNetwork: true}.Run(t.Context(), job)
+	if err != nil || !out.Passed() {
+		t.Errorf("dialing the host with AllowNetwork = exit %d, %s, %v; want it reached", out.ExitCode, out.Stdout, err)
+	}
+}
+
+func TestProcess_LimitsHoldForChildren(t *testing.T) {
+	// the grandchild reports its limits and writes past FileSize
+	script := `/bin/sh -c 'ulimit -t; ulimit -v; ulimit -f; head -c 100000 /dev/zero > big'; wc -c < big`
+	job := sandbox.Job{
+		Dir:     t.TempDir(),
+		Command: []string{"/bin/sh", "-c", script},
+		Limits:  sandbox.Limits{CPU: 3 * time.Second, Memory: 512 << 20, FileSize: 4096},
+	}
+	out, err := sandbox.Process{AllowNetwork: true}.Run(t.Context(), job)
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if got, want := strings.Fields(string(out.Stdout)), []string{"3", "524288", "8", "4096"}; !slices.Equal(got, want) {
+		t.Errorf("the grandchild saw limits and wrote %q, want %q", got, want)
+	}
+}
+
+func TestProcess_TimeoutKillsTheGroup(t *testing.T) {
+	job := sandbox.Job{
+		Dir:     t.TempDir(),
+		Command: []string{"/bin/sh", "-c", "sleep 60 & echo $!; wait"},
+		Timeout: 500 * time.Millisecond,
+	}
+	out, err := sandbox.Process{AllowNetwork: true}.Run(t.Context(), job)
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if !out.TimedOut || out.Passed() || out.Duration > 5*time.Second {
+		t.Errorf("Run() = timed out %v after %v, want it stopped at the deadline", out.TimedOut, out.Duration)
+	}
+	pid, err := strconv.Atoi(strings.TrimSpace(string(out.Stdout)))
+	if err != nil {
+		t.Fatalf("the job printed %q, want the pid of its sleep", out.Stdout)
+	}
+	deadline := time.Now().Add(5 * time.Second)
+	for alive(pid) {
+		if time.Now().After(deadline) {
+			t.Fatalf("sleep (pid %d) outlived the job", pid)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+// alive reports whether pid is running: a zombie waiting to be reaped has
+// already been killed.
+func alive(pid int) bool {
+	stat, err := os.ReadFile(fmt.Sprintf("/proc/%d/stat", pid))
+	if err != nil {
+		return false
+	}
+	_, fields, _ := strings.Cut(string(stat), ") ")
+	return !strings.HasPrefix(fields, "Z") && !strings.HasPrefix(fields, "X")
+}
+
+func TestProcess_OutputCapKillsTheJob(t *testing.T) {
+	for _, stream := range []string{"yes", "yes >&2"} {
+		t.Run(stream, func(t *testing.T) {
+			job := sandbox.Job{
+				Dir:     t.TempDir(),
+				Command: []string{"/bin/sh", "-c", stream},
+				Limits:  sandbox.Limits{Output: 1024},
+			}
+			out, err := sandbox.Process{AllowNetwork: true}.Run(t.Context(), job)
+			if err != nil {
+				t.Fatalf("Run() error = %v", err)
+			}
+			if !out.Truncated || out.TimedOut || out.Passed() || out.Duration > 10*time.Second {
+				t.Errorf("Run() = truncated %v, timed out %v, after %v; want it killed once over the cap", out.Truncated, out.TimedOut, out.Duration)
+			}
+			if n := len(out.Stdout) + len(out.Stderr); n != 1024 {
+				t.Errorf("Run() kept %d bytes, want the first 1024", n)
+			}
+		})
+	}
+}
@@ -0,0 +1,240 @@
+// Package satisfy explains why a type does or doesn't implement an interface.
+//
+// The compiler says "T does not implement I (missing method M)" and stops at
+// the first method. A learner usually has several things wrong at once: a
+// method missing, one named Getname instead of GetName, one with a pointer
+// receiver used through a value. Explain lists all of them. Implementers and
+// Satisfied run it across packages, for "who implements this?" and "what
+// does this type implement?", near misses included.
+package satisfy
+
+import (
+	"cmp"
+	"fmt"
+	"go/types"
+	"slices"
+	"strings"
+)
+
+// Kind Why a method of the interface doesn't count
+type Kind int
+
+const (
+	// Missing The type has no method of that name
+	Missing Kind = iota
+	// Misnamed The type has the method under another case, e.g. Getname
+	Misnamed
+	// WrongSignature The type has the method, with other parameters or results
+	WrongSignature
+	// PointerReceiver The method has a pointer receiver, so only *T has it
+	PointerReceiver
+	// Field The name is a field of the type, not a method
+	Field
+)
+
+// Problem One method of the interface the type doesn't provide as asked
+type Problem struct {
+	Kind   Kind
+	Method string
+	Want   string // the interface's signature
+	Have   string // the type's, for Misnamed and WrongSignature
+}
+
+func (p Problem) String() string {
+	switch p.Kind {
+	case Misnamed:
+		return fmt.Sprintf("has %s, the interface asks for %s - names are case-sensitive", p.Have, p.Want)
+	case WrongSignature:
+		return fmt.Sprintf("has %s, the interface asks for %s", p.Have, p.Want)
+	case PointerReceiver:
+		return fmt.Sprintf("%s has a pointer receiver: a pointer has it, a value doesn't", p.Method)
+	case Field:
+		return fmt.Sprintf("%s is a field, the interface asks for a method %s", p.Method, p.Want)
+	}
+	return "missing " + p.Want
+}
+
+// Match One type measured against one interface
+type Match struct {
+	Type      *types.TypeName
+	Interface *types.TypeName
+	Value     bool // T implements it
+	Pointer   bool // *T implements it, true whenever Value is
+	Problems  []Problem
+}
+
+// Implements reports whether T or *T implements the interface.
+func (m Match) Implements() bool { return m.Pointer }
+
+// Explain measures t against iface, method by method.
+func Explain(t, iface *types.Named) Match {
+	m := Match{Type: t.Obj(), Interface: iface.Obj()}
+	_, isIface := t.Underlying().(*types.Interface)
+	var ptr types.Type = types.NewPointer(t)
+	if isIface {
+		ptr = t // an interface's method set is the same through a pointer, and nobody wants one
+	}
+	values, pointers := types.NewMethodSet(t), types.NewMethodSet(ptr)
+	qualifier := types.RelativeTo(iface.Obj().Pkg())
+
+	it := iface.Underlying().(*types.Interface)
+	for i := range it.NumMethods() {
+		want := it.Method(i)
+		p := Problem{Method: want.Name(), Want: signature(want, qualifier)}
+		if sel := pointers.Lookup(want.Pkg(), want.Name()); sel != nil {
+			have := sel.Obj().(*types.Func)
+			switch {
+			case !types.Identical(have.Type(), want.Type()):
+				p.Kind, p.Have = WrongSignature, signature(have, qualifier)
+			case values.Lookup(want.Pkg(), want.Name()) == nil:
+				p.Kind = PointerReceiver
+			default:
+				continue
+			}
+		} else if obj, _, _ := types.LookupFieldOrMethod(ptr, false, want.Pkg(), want.Name()); obj != nil {
+			p.Kind = Field
+		} else if have := misnamed(pointers, want.Name()); have != nil {
+			p.Kind, p.Have = Misnamed, signature(have, qualifier)
+		}
+		m.Problems = append(m.Problems, p)
+	}
+	m.Value = len(m.Problems) == 0
+	m.Pointer = !slices.ContainsFunc(m.Problems, func(p Problem) bool { return p.Kind != PointerReceiver })
+	return m
+}
+
+// near reports whether m is worth showing though the type doesn't implement
+// the interface: it has at least one of the methods, maybe misspelt.
+func (m Match) near() bool {
+	return m.Implements() || slices.ContainsFunc(m.Problems, func(p Problem) bool { return p.Kind != Missing }) ||
+		len(m.Problems) < m.Interface.Type().Underlying().(*types.Interface).NumMethods()
+}
+
+func misnamed(ms *types.MethodSet, name string) *types.Func {
+	for sel := range ms.Methods() {
+		if strings.EqualFold(sel.Obj().Name(), name) {
+			return sel.Obj().(*types.Func)
+		}
+	}
+	return nil
+}
+
+// signature writes a method as Name(params) results.
+func signature(f *types.Func, q types.Qualifier) string {
+	return f.Name() + strings.TrimPrefix(types.TypeString(f.Type(), q), "func")
+}
+
+// Find returns the types called name in pkgs: "Repository", qualified with
+// a package name ("employee.Repository") or an import path
+// ("go-solid/5.DIP.EmployeeRepository") when it is ambiguous.
+func Find(pkgs []*types.Package, name string) []*types.Named {
+	qual, typ := "", name
+	if i := strings.LastIndex(name, "."); i >= 0 {
+		qual, typ = name[:i], name[i+1:]
+	}
+	var found []*types.Named
+	for _, pkg := range pkgs {
+		if qual != "" && qual != pkg.Name() && qual != pkg.Path() {
+			continue
+		}
+		if tn, ok := pkg.Scope().Lookup(typ).(*types.TypeName); ok {
+			if n, ok := types.Unalias(tn.Type()).(*types.Named); ok {
+				found = append(found, n)
+			}
+		}
+	}
+	return found
+}
+
+// Implementers measures every concrete type of pkgs against iface and
+// returns those implementing it, then the near misses, the closest first.
+// Only types of packages that could meet are measured: iface's own, and
+// those importing it or imported by it.
+func Implementers(pkgs []*types.Package, iface *types.Named) []Match {
+	var matches []Match
+	for _, t := range named(pkgs, iface.Obj().Pkg()) {
+		if _, ok := t.Underlying().(*types.Interface); ok || t.TypeParams().Len() > 0 {
+			continue
+		}
+		if m := Explain(t, iface); m.near() {
+			matches = append(matches, m)
+		}
+	}
+	sortMatches(matches)
+	return matches
+}
+
+// Satisfied measures t against every interface of pkgs that has methods, in
+// the packages that could meet, and returns those it implements, then the
+// near misses.
+func Satisfied(pkgs []*types.Package, t *types.Named) []Match {
+	var matches []Match
+	for _, iface := range named(pkgs, t.Obj().Pkg()) {
+		it, ok := iface.Underlying().(*types.Interface)
+		if !ok || it.NumMethods() == 0 || iface.TypeParams().Len() > 0 || iface == t {
+			continue
+		}
+		if m := Explain(t, iface); m.near() {
+			matches = append(matches, m)
+		}
+	}
+	sortMatches(matches)
+	return matches
+}
+
+func sortMatches(matches []Match) {
+	slices.SortStableFunc(matches, func(a, b Match) int {
+		return cmp.Or(
+			cmp.Compare(rank(a), rank(b)),
+			cmp.Compare(len(a.Problems), len(b.Problems)),
+		)
+	})
+}
+
+func rank(m Match) int {
+	switch {
+	case m.Value:
+		return 0
+	case m.Pointer:
+		return 1
+	}
+	return 2
+}
+
+// named returns the named types declared in those of pkgs related to home.
+func named(pkgs []*types.Package, home *types.Package) []*types.Named {
+	var all []*types.Named
+	for _, pkg := range pkgs {
+		if !related(pkg, home) {
+			continue
+		}
+		scope := pkg.Scope()
+		for _, n := range scope.Names() {
+			if tn, ok := scope.Lookup(n).(*types.TypeName); ok && !tn.IsAlias() {
+				if t, ok := tn.Type().(*types.Named); ok {
+					all = append(all, t)
+				}
+			}
+		}
+	}
+	return all
+}
+
+// related reports whether a and b are the same package or one imports the
+// other, directly or not: only then can a value of one meet the other.
+func related(a, b *types.Package) bool {
+	return a == b || imports(a, b, map[*types.Package]bool{}) || imports(b, a, map[*types.Package]bool{})
+}
+
+func imports(from, to *types.Package, seen map[*types.Package]bool) bool {
+	if seen[from] {
+		return false
+	}
+	seen[from] = true
+	for _, p := range from.Imports() {
+		if p == to || imports(p, to, seen) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,111 @@
+// Package savehook plugs existing subsystems into the employee.Manager's save
+// hooks: search indexing, notifications and auditing react to every save, and
+// validation rules can veto one. The Manager knows none of them (OCP), and
+// none of them knows the Manager - only the employee.Hook interfaces.
+package savehook
+
+import (
+	"context"
+	"fmt"
+	"net/mail"
+
+	"go-solid/audit"
+	"go-solid/clock"
+	"go-solid/employee"
+	"go-solid/money"
+	"go-solid/notify"
+	"go-solid/search"
+)
+
+// Index AfterSaver keeping a search index up to date with every save
+type Index struct {
+	Searcher search.EmployeeSearcher
+}
+
+func (Index) Name() string { return "search-index" }
+
+func (i Index) AfterSave(ctx context.Context, emp employee.Employee) error {
+	return i.Searcher.Index(ctx, emp)
+}
+
+// Notify BeforeSaver and AfterSaver: it rejects an email address it could not
+// deliver to, and tells the employee once their record is saved. Employees
+// without an address are saved and not told.
+type Notify struct {
+	Notifier notify.Notifier
+}
+
+func (Notify) Name() string { return "notify" }
+
+func (Notify) BeforeSave(_ context.Context, emp employee.Employee) error {
+	if emp.Email == "" {
+		return nil
+	}
+	if _, err := mail.ParseAddress(emp.Email); err != nil {
+		return fmt.Errorf("email %q: %w", emp.Email, err)
+	}
+	return nil
+}
+
+func (n Notify) AfterSave(ctx context.Context, emp employee.Employee) error {
+	if emp.Email == "" {
+		return nil
+	}
+	return n.Notifier.Notify(ctx, notify.Message{
+		To:      emp.Email,
+		Subject: "Your employee record was updated",
+		Body:    fmt.Sprintf("%s, %s, paid %v", emp.Name, emp.Title, emp.Salary),
+	})
+}
+
+// Audit AfterSaver recording every stored revision, whichever use case stored
+// it. The Manager's own records say what was asked; these say what was
+// written.
+type Audit struct {
+	Sink  audit.Sink
+	Clock clock.Clock // clock.Real when nil
+}
+
+func (Audit) Name() string { return "audit" }
+
+func (a Audit) AfterSave(ctx context.Context, emp employee.Employee) error {
+	c := a.Clock
+	if c == nil {
+		c = clock.Real{}
+	}
+	return a.Sink.Write(ctx, audit.Record{
+		Time:     c.Now(),
+		Action:   "employee.stored",
+		Entity:   "employee",
+		EntityID: string(emp.ID),
+		Actor:    audit.ActorFrom(ctx),
+		Details:  map[string]any{"name": emp.Name, "title": emp.Title, "salary": emp.Salary},
+		Outcome:  audit.Success,
+	})
+}
+
+// SalaryBand BeforeSaver rejecting salaries outside [Min, Max], in their
+// currency. Salaries in another currency are left to another band.
+type SalaryBand struct {
+	Min, Max money.Money
+}
+
+func (SalaryBand) Name() string { return "salary-band" }
+
+func (b SalaryBand) BeforeSave(_ context.Context, emp employee.Employee) error {
+	if emp.Salary.Currency() != b.Min.Currency() {
+		return nil
+	}
+	if emp.Salary.Less(b.Min) || b.Max.Less(emp.Salary) {
+		return fmt.Errorf("salary %v outside the band %v-%v", emp.Salary, b.Min, b.Max)
+	}
+	return nil
+}
+
+var (
+	_ employee.AfterSaver  = Index{}
+	_ employee.BeforeSaver = Notify{}
+	_ employee.AfterSaver  = Notify{}
+	_ employee.AfterSaver  = Audit{}
+	_ employee.BeforeSaver = SalaryBand{}
+)
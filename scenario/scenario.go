@@ -0,0 +1,113 @@
+// Package scenario runs scripted demos and checks what they print.
+//
+// A Scenario is a list of commands with the output each one is expected to
+// produce. The runner knows nothing about employees: it hands every command to
+// an Executor (the solid REPL, in cmd/solid) and compares what comes back, so
+// the same scenario files double as regression tests for the demos.
+package scenario
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Scenario A named script, run against a fresh backend
+type Scenario struct {
+	Name string `json:"name"`
+	// Backend the script starts on; empty means the executor's default
+	Backend string `json:"backend,omitempty"`
+	Steps   []Step `json:"steps"`
+}
+
+// Step One command and what it must produce. With no expectation at all the
+// step only has to succeed.
+type Step struct {
+	Run string `json:"run"`
+	// Expect is the whole output, compared with surrounding space trimmed
+	Expect string `json:"expect,omitempty"`
+	// Contains are substrings the output must include
+	Contains []string `json:"contains,omitempty"`
+	// Error is a substring of the error the command must fail with
+	Error string `json:"error,omitempty"`
+}
+
+// Executor Abstraction - whatever runs the commands
+type Executor interface {
+	Exec(ctx context.Context, command string) (output string, err error)
+}
+
+// Load reads a scenario file. Only JSON is supported: YAML would need a
+// third-party parser, and this module's packages import the standard
+// library only.
+func Load(path string) (Scenario, error) {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		return Scenario{}, fmt.Errorf("scenario %s: YAML is not supported, use JSON: %w", path, errors.ErrUnsupported)
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return Scenario{}, fmt.Errorf("scenario: %w", err)
+	}
+	defer f.Close()
+	var sc Scenario
+	dec := json.NewDecoder(f)
+	dec.DisallowUnknownFields() // a misspelt "expect" must not pass silently
+	if err := dec.Decode(&sc); err != nil {
+		return Scenario{}, fmt.Errorf("scenario %s: %w", path, err)
+	}
+	if sc.Name == "" {
+		sc.Name = strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	}
+	return sc, nil
+}
+
+// Result What one step did
+type Result struct {
+	Step   Step
+	Output string
+	Err    error
+	// Failure explains why the step did not meet its expectations; empty when it did
+	Failure string
+}
+
+func (r Result) Passed() bool { return r.Failure == "" }
+
+// Run executes every step, even after a failure, so one report shows all
+// the differences.
+func Run(ctx context.Context, sc Scenario, exec Executor) []Result {
+	results := make([]Result, 0, len(sc.Steps))
+	for _, step := range sc.Steps {
+		out, err := exec.Exec(ctx, step.Run)
+		results = append(results, Result{Step: step, Output: out, Err: err, Failure: check(step, out, err)})
+	}
+	return results
+}
+
+func check(step Step, out string, err error) string {
+	if step.Error != "" {
+		if err == nil {
+			return fmt.Sprintf("expected an error containing %q, but the command succeeded", step.Error)
+		}
+		if !strings.Contains(err.Error(), step.Error) {
+			return fmt.Sprintf("expected an error containing %q, got %q", step.Error, err)
+		}
+		return ""
+	}
+	if err != nil {
+		return fmt.Sprintf("unexpected error: %v", err)
+	}
+	if step.Expect != "" && strings.TrimSpace(out) != strings.TrimSpace(step.Expect) {
+		return fmt.Sprintf("expected output %q, got %q", strings.TrimSpace(step.Expect), strings.TrimSpace(out))
+	}
+	for _, want := range step.Contains {
+		if !strings.Contains(out, want) {
+			return fmt.Sprintf("output does not contain %q: %q", want, strings.TrimSpace(out))
+		}
+	}
+	return ""
+}
@@ -0,0 +1,80 @@
+package schedule_test
+
+import (
+	"testing"
+	"time"
+
+	"go-solid/schedule"
+)
+
+// monday 2026-03-02 was a Monday; the 13th of March a Friday.
+var monday = time.Date(2026, time.March, 2, 9, 0, 0, 0, time.UTC)
+
+func at(year int, month time.Month, day, hour, min int) time.Time {
+	return time.Date(year, month, day, hour, min, 0, 0, time.UTC)
+}
+
+func TestCron_Next(t *testing.T) {
+	tests := []struct {
+		name  string
+		expr  string
+		after time.Time
+		want  time.Time
+	}{
+		{"every minute", "* * * * *", monday, at(2026, time.March, 2, 9, 1)},
+		{"seconds dropped", "1 9 * * *", monday.Add(30 * time.Second), at(2026, time.March, 2, 9, 1)},
+		{"step", "*/15 * * * *", monday, at(2026, time.March, 2, 9, 15)},
+		{"step over a range", "0-30/10 * * * *", monday.Add(25 * time.Minute), at(2026, time.March, 2, 9, 30)},
+		{"step from a value", "5 10/4 * * *", monday, at(2026, time.March, 2, 10, 5)},
+		{"range of hours, stepped", "5 9-17/4 * * *", monday.Add(10 * time.Minute), at(2026, time.March, 2, 13, 5)},
+		{"lists", "0,30 8,18 * * *", monday, at(2026, time.March, 2, 18, 0)},
+		{"weekdays", "0 9 * * 1-5", monday, at(2026, time.March, 3, 9, 0)},
+		{"7 is Sunday", "0 9 * * 7", monday, at(2026, time.March, 8, 9, 0)},
+		{"0 is Sunday", "0 9 * * 0", monday, at(2026, time.March, 8, 9, 0)},
+		{"Friday to Sunday through 7", "0 9 * * 5-7", monday, at(2026, time.March, 6, 9, 0)},
+		{"day of month", "0 9 13 * *", monday, at(2026, time.March, 13, 9, 0)},
+		{"day of month or day of week", "0 9 13 * 5", monday, at(2026, time.March, 6, 9, 0)},
+		{"day of week or day of month", "0 9 3 * 5", monday, at(2026, time.March, 3, 9, 0)},
+		// a stepped "*" is unrestricted, so both day fields must match: the
+		// first 13th on an even weekday is Saturday 13 June
+		{"stepped day of week and a day of month", "0 9 13 * */2", monday, at(2026, time.June, 13, 9, 0)},
+		{"stepped day of month and a day of week", "0 9 */1 * 1", monday, at(2026, time.March, 9, 9, 0)},
+		{"month", "0 0 1 1 *", monday, at(2027, time.January, 1, 0, 0)},
+		{"new year", "* * * * *", at(2026, time.December, 31, 23, 59), at(2027, time.January, 1, 0, 0)},
+		{"leap day", "0 0 29 2 *", monday, at(2028, time.February, 29, 0, 0)},
+		{"30 February", "0 0 30 2 *", monday, time.Time{}},
+		{"31 April", "0 0 31 4 *", monday, time.Time{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := schedule.ParseCron(tt.expr)
+			if err != nil {
+				t.Fatalf("ParseCron(%q) error = %v", tt.expr, err)
+			}
+			if got := c.Next(tt.after); !got.Equal(tt.want) {
+				t.Errorf("Next(%v) = %v, want %v", tt.after, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseCron_Invalid(t *testing.T) {
+	for _, expr := range []string{
+		"* * * *",
+		"* * * * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * * 13 *",
+		"* * * * 8",
+		"5-1 * * * *",
+		"*/0 * * * *",
+		"*/x * * * *",
+		"a * * * *",
+		"1-x * * * *",
+	} {
+		if _, err := schedule.ParseCron(expr); err == nil {
+			t.Errorf("ParseCron(%q) error = nil, want one", expr)
+		}
+	}
+}
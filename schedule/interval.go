@@ -0,0 +1,26 @@
+package schedule
+
+import "time"
+
+// Interval Fixed-period schedule, optionally aligned to an anchor time
+type Interval struct {
+	Every  time.Duration
+	Anchor time.Time
+}
+
+// Every returns an Interval firing every d, counted from the moment it is asked.
+func Every(d time.Duration) Interval { return Interval{Every: d} }
+
+func (i Interval) Next(after time.Time) time.Time {
+	if i.Every <= 0 {
+		return time.Time{}
+	}
+	if i.Anchor.IsZero() {
+		return after.Add(i.Every)
+	}
+	if after.Before(i.Anchor) {
+		return i.Anchor
+	}
+	periods := after.Sub(i.Anchor)/i.Every + 1
+	return i.Anchor.Add(periods * i.Every)
+}
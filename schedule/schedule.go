@@ -0,0 +1,106 @@
+// Package schedule runs jobs on a timetable.
+//
+// The runner only knows about the Scheduler abstraction (when should the job
+// run next?) and the Clock abstraction (what time is it?). Cron expressions,
+// fixed intervals and fake clocks are all low-level details plugged in from the
+// outside - the Dependency Inversion Principle applied to time.
+package schedule
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"go-solid/clock"
+)
+
+// Scheduler Abstraction - decides when a job should run next
+type Scheduler interface {
+	// Next returns the first activation time strictly after the given time.
+	// A zero time means the schedule is exhausted.
+	Next(after time.Time) time.Time
+}
+
+// Job Unit of work triggered by the Runner
+type Job interface {
+	Run(ctx context.Context, at time.Time) error
+}
+
+// JobFunc Adapter so plain functions can be used as jobs
+type JobFunc func(ctx context.Context, at time.Time) error
+
+func (f JobFunc) Run(ctx context.Context, at time.Time) error { return f(ctx, at) }
+
+type entry struct {
+	name      string
+	scheduler Scheduler
+	job       Job
+	next      time.Time
+}
+
+// Runner High-level module - triggers jobs, depends only on Scheduler and Clock
+type Runner struct {
+	clock   clock.Clock
+	onError func(job string, err error)
+	entries []*entry
+}
+
+// NewRunner creates a Runner driven by the given clock. onError is called
+// whenever a job fails; it may be nil.
+func NewRunner(clock clock.Clock, onError func(job string, err error)) *Runner {
+	return &Runner{clock: clock, onError: onError}
+}
+
+// Add registers a job under a name. It must be called before Run.
+func (r *Runner) Add(name string, scheduler Scheduler, job Job) {
+	r.entries = append(r.entries, &entry{name: name, scheduler: scheduler, job: job})
+}
+
+// Run blocks, triggering jobs as they become due, until ctx is cancelled or
+// every schedule is exhausted.
+func (r *Runner) Run(ctx context.Context) error {
+	now := r.clock.Now()
+	for _, e := range r.entries {
+		e.next = e.scheduler.Next(now)
+	}
+
+	for {
+		due := r.pending()
+		if len(due) == 0 {
+			return nil
+		}
+
+		wait := due[0].next.Sub(r.clock.Now())
+		if wait > 0 {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-r.clock.After(wait):
+			}
+		}
+
+		now = r.clock.Now()
+		for _, e := range due {
+			if e.next.After(now) {
+				continue
+			}
+			if err := e.job.Run(ctx, e.next); err != nil && r.onError != nil {
+				r.onError(e.name, err)
+			}
+			// schedule from "now" so a late wake-up skips missed runs instead of replaying them
+			e.next = e.scheduler.Next(now)
+		}
+	}
+}
+
+// pending returns the active entries ordered by their next activation time.
+func (r *Runner) pending() []*entry {
+	var active []*entry
+	for _, e := range r.entries {
+		if !e.next.IsZero() {
+			active = append(active, e)
+		}
+	}
+	sort.SliceStable(active, func(i, j int) bool { return active[i].next.Before(active[j].next) })
+	return active
+}
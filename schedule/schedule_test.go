@@ -0,0 +1,160 @@
+package schedule_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"go-solid/clock"
+	"go-solid/schedule"
+)
+
+func TestInterval_Next(t *testing.T) {
+	anchor := at(2026, time.March, 2, 0, 0)
+	tests := []struct {
+		name     string
+		interval schedule.Interval
+		after    time.Time
+		want     time.Time
+	}{
+		{"from the moment asked", schedule.Every(time.Hour), monday.Add(17 * time.Second), monday.Add(time.Hour + 17*time.Second)},
+		{"before the anchor", schedule.Interval{Every: time.Hour, Anchor: anchor}, anchor.Add(-time.Minute), anchor},
+		{"on the anchor", schedule.Interval{Every: time.Hour, Anchor: anchor}, anchor, anchor.Add(time.Hour)},
+		{"between two periods", schedule.Interval{Every: time.Hour, Anchor: anchor}, monday.Add(10 * time.Minute), at(2026, time.March, 2, 10, 0)},
+		{"on a period", schedule.Interval{Every: 15 * time.Minute, Anchor: anchor}, monday, monday.Add(15 * time.Minute)},
+		{"no period", schedule.Interval{}, monday, time.Time{}},
+		{"a negative period", schedule.Every(-time.Second), monday, time.Time{}},
+	}
+	for _, tt := range tests {
+		if got := tt.interval.Next(tt.after); !got.Equal(tt.want) {
+			t.Errorf("%s: Next(%s) = %s, want %s", tt.name, tt.after, got, tt.want)
+		}
+	}
+}
+
+// times A schedule of a fixed list of times, exhausted after the last
+type times []time.Time
+
+func (ts times) Next(after time.Time) time.Time {
+	for _, t := range ts {
+		if t.After(after) {
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+// started runs r on its own goroutine and returns what Run returned, once
+// it has.
+func started(ctx context.Context, r *schedule.Runner) <-chan error {
+	done := make(chan error, 1)
+	go func() { done <- r.Run(ctx) }()
+	return done
+}
+
+// recorder A Job sending the time of each run it is given
+func recorder(runs chan<- time.Time) schedule.Job {
+	return schedule.JobFunc(func(_ context.Context, at time.Time) error {
+		runs <- at
+		return nil
+	})
+}
+
+func TestRunner_FiresOnSchedule(t *testing.T) {
+	clk := clock.NewFake(monday)
+	runs := make(chan time.Time, 10)
+	r := schedule.NewRunner(clk, nil)
+	r.Add("tick", schedule.Every(time.Minute), recorder(runs))
+	ctx, cancel := context.WithCancel(t.Context())
+	defer cancel()
+	started(ctx, r)
+
+	for i := 1; i <= 3; i++ {
+		clk.BlockUntil(1)
+		if len(runs) > 0 {
+			t.Fatalf("run %d fired before its time", i)
+		}
+		clk.Advance(time.Minute)
+		if got, want := <-runs, monday.Add(time.Duration(i)*time.Minute); !got.Equal(want) {
+			t.Errorf("run %d at %s, want %s", i, got, want)
+		}
+	}
+}
+
+func TestRunner_SkipsMissedRuns(t *testing.T) {
+	clk := clock.NewFake(monday)
+	runs := make(chan time.Time, 10)
+	r := schedule.NewRunner(clk, nil)
+	r.Add("tick", schedule.Every(time.Minute), recorder(runs))
+	ctx, cancel := context.WithCancel(t.Context())
+	defer cancel()
+	started(ctx, r)
+
+	// a wake-up five minutes late: one run, for the time it was due
+	clk.BlockUntil(1)
+	clk.Advance(5 * time.Minute)
+	if got := <-runs; !got.Equal(monday.Add(time.Minute)) {
+		t.Errorf("late run at %s, want %s", got, monday.Add(time.Minute))
+	}
+	clk.BlockUntil(1)
+	if len(runs) > 0 {
+		t.Errorf("%d missed runs replayed, want them skipped", len(runs))
+	}
+	// and the next a minute after the wake-up, not after the missed run
+	clk.Advance(time.Minute)
+	if got := <-runs; !got.Equal(monday.Add(6 * time.Minute)) {
+		t.Errorf("next run at %s, want %s", got, monday.Add(6*time.Minute))
+	}
+}
+
+func TestRunner_StopsWhenExhausted(t *testing.T) {
+	clk := clock.NewFake(monday)
+	runs := make(chan time.Time, 10)
+	failed := errors.New("report generator down")
+	var errs []string
+	r := schedule.NewRunner(clk, func(job string, err error) {
+		if errors.Is(err, failed) {
+			errs = append(errs, job)
+		}
+	})
+	r.Add("twice", times{monday.Add(time.Minute), monday.Add(2 * time.Minute)}, recorder(runs))
+	r.Add("failing", times{monday.Add(time.Minute)}, schedule.JobFunc(func(context.Context, time.Time) error { return failed }))
+	r.Add("never", schedule.Interval{}, recorder(runs))
+	done := started(t.Context(), r)
+
+	for range 2 {
+		clk.BlockUntil(1)
+		clk.Advance(time.Minute)
+	}
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("Run() error = %v, want nil once every schedule is exhausted", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Run() still running 5s after the last run")
+	}
+	if len(runs) != 2 || len(errs) != 1 || errs[0] != "failing" {
+		t.Errorf("%d runs, errors of %v, want 2 runs and failing reported once", len(runs), errs)
+	}
+}
+
+func TestRunner_Cancelled(t *testing.T) {
+	clk := clock.NewFake(monday)
+	r := schedule.NewRunner(clk, nil)
+	r.Add("hourly", schedule.Every(time.Hour), recorder(make(chan time.Time, 1)))
+	ctx, cancel := context.WithCancel(t.Context())
+	done := started(ctx, r)
+
+	clk.BlockUntil(1)
+	cancel()
+	select {
+	case err := <-done:
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("Run() error = %v, want %v", err, context.Canceled)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Run() still waiting 5s after its context was cancelled")
+	}
+}
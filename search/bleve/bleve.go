@@ -0,0 +1,159 @@
+// Package bleve is a search.EmployeeSearcher backed by a Bleve index, in
+// memory or in a directory on disk.
+//
+// It is a module of its own, so only a build that uses it downloads Bleve
+// and go-solid's own packages import nothing beyond the standard library:
+//
+//	cd search/bleve && go test ./...
+package bleve
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	blevesearch "github.com/blevesearch/bleve/v2"
+	"github.com/blevesearch/bleve/v2/analysis/analyzer/custom"
+	"github.com/blevesearch/bleve/v2/analysis/tokenizer/whitespace"
+	"github.com/blevesearch/bleve/v2/mapping"
+	"github.com/blevesearch/bleve/v2/search/query"
+
+	"go-solid/employee"
+	"go-solid/search"
+)
+
+// Searcher Low-level module - one Bleve index of employees, one document
+// per employee with the name as document ID
+type Searcher struct {
+	index blevesearch.Index
+}
+
+// document Name and title are stored for the hits; the words are what is
+// searched, split by search.Terms so the index agrees with every other
+// adapter on what a word is.
+type document struct {
+	ID         string `json:"id"`
+	Name       string `json:"name"`
+	Title      string `json:"title"`
+	NameWords  string `json:"name_words"`
+	TitleWords string `json:"title_words"`
+}
+
+// words splits on spaces only: the text is search.Terms joined, already
+// lower case.
+const words = "words"
+
+// NewMemory returns a Searcher over an index that lives as long as it does.
+func NewMemory() (*Searcher, error) {
+	m, err := newMapping()
+	if err != nil {
+		return nil, err
+	}
+	index, err := blevesearch.NewMemOnly(m)
+	if err != nil {
+		return nil, err
+	}
+	return &Searcher{index: index}, nil
+}
+
+// Open opens the index in dir, creating it if there is none yet.
+func Open(dir string) (*Searcher, error) {
+	index, err := blevesearch.Open(dir)
+	if errors.Is(err, blevesearch.ErrorIndexPathDoesNotExist) {
+		var m mapping.IndexMapping
+		if m, err = newMapping(); err != nil {
+			return nil, err
+		}
+		index, err = blevesearch.New(dir, m)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &Searcher{index: index}, nil
+}
+
+func newMapping() (mapping.IndexMapping, error) {
+	m := blevesearch.NewIndexMapping()
+	if err := m.AddCustomAnalyzer(words, map[string]any{"type": custom.Name, "tokenizer": whitespace.Name}); err != nil {
+		return nil, err
+	}
+
+	stored := blevesearch.NewKeywordFieldMapping()
+	stored.Index = false
+	searched := blevesearch.NewTextFieldMapping()
+	searched.Analyzer = words
+	searched.Store = false
+
+	doc := blevesearch.NewDocumentStaticMapping()
+	for _, f := range []string{"id", "name", "title"} {
+		doc.AddFieldMappingsAt(f, stored)
+	}
+	doc.AddFieldMappingsAt("name_words", searched)
+	doc.AddFieldMappingsAt("title_words", searched)
+	m.DefaultMapping = doc
+	return m, nil
+}
+
+func (s *Searcher) Index(ctx context.Context, emps ...employee.Employee) error {
+	b := s.index.NewBatch()
+	for _, emp := range emps {
+		err := b.Index(emp.Name, document{
+			ID:         string(emp.ID),
+			Name:       emp.Name,
+			Title:      emp.Title,
+			NameWords:  strings.Join(search.Terms(emp.Name), " "),
+			TitleWords: strings.Join(search.Terms(emp.Title), " "),
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return s.index.Batch(b)
+}
+
+func (s *Searcher) Remove(ctx context.Context, names ...string) error {
+	b := s.index.NewBatch()
+	for _, name := range names {
+		b.Delete(name)
+	}
+	return s.index.Batch(b)
+}
+
+// Search needs every term to match the start of a word in the name
+// (boosted) or the title.
+func (s *Searcher) Search(ctx context.Context, q search.Query) ([]search.Hit, error) {
+	terms := search.Terms(q.Text)
+	if len(terms) == 0 {
+		return nil, nil
+	}
+	each := make([]query.Query, 0, len(terms))
+	for _, term := range terms {
+		name := blevesearch.NewPrefixQuery(term)
+		name.SetField("name_words")
+		name.SetBoost(2)
+		title := blevesearch.NewPrefixQuery(term)
+		title.SetField("title_words")
+		each = append(each, blevesearch.NewDisjunctionQuery(name, title))
+	}
+	req := blevesearch.NewSearchRequestOptions(blevesearch.NewConjunctionQuery(each...), q.Size(), 0, false)
+	req.Fields = []string{"id", "name", "title"}
+	res, err := s.index.SearchInContext(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	hits := make([]search.Hit, 0, len(res.Hits))
+	for _, h := range res.Hits {
+		id, _ := h.Fields["id"].(string)
+		name, _ := h.Fields["name"].(string)
+		title, _ := h.Fields["title"].(string)
+		hits = append(hits, search.Hit{ID: id, Name: name, Title: title, Score: h.Score})
+	}
+	return hits, nil
+}
+
+// Close releases the index; an index on disk keeps what was indexed.
+func (s *Searcher) Close() error {
+	return s.index.Close()
+}
+
+var _ search.EmployeeSearcher = (*Searcher)(nil)
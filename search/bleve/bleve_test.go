@@ -0,0 +1,43 @@
+package bleve_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"go-solid/employee"
+	"go-solid/search"
+	"go-solid/search/bleve"
+	"go-solid/search/searchtest"
+)
+
+func TestSearcher(t *testing.T) {
+	searchtest.TestSearcher(t, func(t *testing.T) search.EmployeeSearcher {
+		s, err := bleve.NewMemory()
+		if err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(func() { _ = s.Close() })
+		return s
+	})
+}
+
+func TestOpen_KeepsTheIndex(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "employees.bleve")
+	s, err := bleve.Open(dir)
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	if err := s.Index(t.Context(), employee.Employee{ID: "emp-3", Name: "Omar Haddad", Title: "Sales Manager"}); err != nil {
+		t.Fatalf("Index() error = %v", err)
+	}
+	if err := s.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	if s, err = bleve.Open(dir); err != nil {
+		t.Fatalf("Open() again error = %v", err)
+	}
+	defer s.Close()
+	if hits, err := s.Search(t.Context(), search.Query{Text: "omar"}); err != nil || len(hits) != 1 || hits[0].Name != "Omar Haddad" {
+		t.Errorf("Search(omar) after reopening = %+v, %v, want Omar Haddad", hits, err)
+	}
+}
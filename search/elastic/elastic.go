@@ -0,0 +1,188 @@
+//go:build elasticsearch
+
+// Package elastic is a search.EmployeeSearcher backed by Elasticsearch (or
+// OpenSearch), spoken to over its REST API with net/http - no client library.
+//
+// It is behind the "elasticsearch" build tag so binaries that don't need it
+// don't carry it:
+//
+//	go build -tags elasticsearch ./...
+package elastic
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"go-solid/employee"
+	"go-solid/search"
+)
+
+// Searcher Low-level module - one Elasticsearch index of employees, one
+// document per employee with the name as document ID
+type Searcher struct {
+	URL       string // e.g. http://localhost:9200
+	IndexName string
+	Client    *http.Client
+}
+
+// document Name and title are kept for the hits; the words are what is
+// searched, split by search.Terms so the index agrees with every other
+// adapter on what a word is ("O'Brien" is two).
+type document struct {
+	ID         string `json:"id"`
+	Name       string `json:"name"`
+	Title      string `json:"title"`
+	NameWords  string `json:"name_words"`
+	TitleWords string `json:"title_words"`
+}
+
+func newDocument(emp employee.Employee) document {
+	return document{
+		ID:         string(emp.ID),
+		Name:       emp.Name,
+		Title:      emp.Title,
+		NameWords:  strings.Join(search.Terms(emp.Name), " "),
+		TitleWords: strings.Join(search.Terms(emp.Title), " "),
+	}
+}
+
+// Index writes the employees with one _bulk request and waits for them to be
+// searchable, so a search right after Index sees them like the memory index does.
+func (s *Searcher) Index(ctx context.Context, emps ...employee.Employee) error {
+	if len(emps) == 0 {
+		return nil
+	}
+	var body bytes.Buffer
+	enc := json.NewEncoder(&body)
+	for _, emp := range emps {
+		_ = enc.Encode(map[string]any{"index": map[string]string{"_index": s.IndexName, "_id": emp.Name}})
+		_ = enc.Encode(newDocument(emp))
+	}
+	return s.bulk(ctx, &body)
+}
+
+func (s *Searcher) Remove(ctx context.Context, names ...string) error {
+	if len(names) == 0 {
+		return nil
+	}
+	var body bytes.Buffer
+	enc := json.NewEncoder(&body)
+	for _, name := range names {
+		_ = enc.Encode(map[string]any{"delete": map[string]string{"_index": s.IndexName, "_id": name}})
+	}
+	return s.bulk(ctx, &body)
+}
+
+func (s *Searcher) bulk(ctx context.Context, body io.Reader) error {
+	var res struct {
+		Errors bool `json:"errors"`
+		Items  []map[string]struct {
+			ID     string `json:"_id"`
+			Status int    `json:"status"`
+			Error  *struct {
+				Reason string `json:"reason"`
+			} `json:"error"`
+		} `json:"items"`
+	}
+	if err := s.do(ctx, http.MethodPost, "/_bulk?refresh=wait_for", "application/x-ndjson", body, &res); err != nil {
+		return err
+	}
+	if !res.Errors {
+		return nil
+	}
+	var errs []error
+	for _, item := range res.Items {
+		for action, r := range item {
+			// deleting a document that isn't there is not an error (see search.EmployeeSearcher)
+			if r.Error != nil && !(action == "delete" && r.Status == http.StatusNotFound) {
+				errs = append(errs, fmt.Errorf("elastic: %s %q: %s", action, r.ID, r.Error.Reason))
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Search needs every term to match the start of a word in the name
+// (boosted) or the title. A bool_prefix multi_match would only treat the
+// last term as a prefix, so each term gets prefix queries of its own.
+func (s *Searcher) Search(ctx context.Context, q search.Query) ([]search.Hit, error) {
+	terms := search.Terms(q.Text)
+	if len(terms) == 0 {
+		return nil, nil
+	}
+	must := make([]any, 0, len(terms))
+	for _, term := range terms {
+		must = append(must, map[string]any{"bool": map[string]any{"should": []any{
+			map[string]any{"prefix": map[string]any{"name_words": map[string]any{"value": term, "boost": 2}}},
+			map[string]any{"prefix": map[string]any{"title_words": map[string]any{"value": term}}},
+		}}})
+	}
+	query, _ := json.Marshal(map[string]any{
+		"size":  q.Size(),
+		"query": map[string]any{"bool": map[string]any{"must": must}},
+	})
+	var res struct {
+		Hits struct {
+			Hits []struct {
+				Score  float64  `json:"_score"`
+				Source document `json:"_source"`
+			} `json:"hits"`
+		} `json:"hits"`
+	}
+	if err := s.do(ctx, http.MethodPost, "/"+url.PathEscape(s.IndexName)+"/_search", "application/json", bytes.NewReader(query), &res); err != nil {
+		return nil, err
+	}
+	hits := make([]search.Hit, 0, len(res.Hits.Hits))
+	for _, h := range res.Hits.Hits {
+		hits = append(hits, search.Hit{ID: h.Source.ID, Name: h.Source.Name, Title: h.Source.Title, Score: h.Score})
+	}
+	return hits, nil
+}
+
+func (s *Searcher) do(ctx context.Context, method, path, contentType string, body io.Reader, out any) error {
+	req, err := http.NewRequestWithContext(ctx, method, strings.TrimSuffix(s.URL, "/")+path, body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", contentType)
+	client := s.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("elastic: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("elastic: %s %s: %s: %s", method, path, resp.Status, bytes.TrimSpace(msg))
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("elastic: decode %s: %w", path, err)
+	}
+	return nil
+}
+
+// CheckHealth asks the cluster for its health (health.Checker).
+func (s *Searcher) CheckHealth(ctx context.Context) error {
+	var res struct {
+		Status string `json:"status"`
+	}
+	if err := s.do(ctx, http.MethodGet, "/_cluster/health", "application/json", nil, &res); err != nil {
+		return err
+	}
+	if res.Status == "red" {
+		return errors.New("elastic: cluster status red")
+	}
+	return nil
+}
+
+var _ search.EmployeeSearcher = (*Searcher)(nil)
@@ -0,0 +1,34 @@
+//go:build elasticsearch
+
+package elastic_test
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"testing"
+	"time"
+
+	"go-solid/search"
+	"go-solid/search/elastic"
+	"go-solid/search/searchtest"
+)
+
+// TestSearcher runs the contract against the cluster ELASTICSEARCH_URL
+// points at. Every case gets an index of its own, deleted afterwards.
+func TestSearcher(t *testing.T) {
+	url := os.Getenv("ELASTICSEARCH_URL")
+	if url == "" {
+		t.Skip("ELASTICSEARCH_URL is not set")
+	}
+	searchtest.TestSearcher(t, func(t *testing.T) search.EmployeeSearcher {
+		name := fmt.Sprintf("employees-test-%d", time.Now().UnixNano())
+		t.Cleanup(func() {
+			req, _ := http.NewRequest(http.MethodDelete, url+"/"+name, nil)
+			if resp, err := http.DefaultClient.Do(req); err == nil {
+				resp.Body.Close()
+			}
+		})
+		return &elastic.Searcher{URL: url, IndexName: name}
+	})
+}
@@ -0,0 +1,140 @@
+// Package memory is an in-process search.EmployeeSearcher.
+package memory
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+
+	"go-solid/employee"
+	"go-solid/search"
+)
+
+// Index Low-level module - an inverted index from words to employees. Names
+// weigh more than titles and whole words more than prefixes.
+type Index struct {
+	mu    sync.RWMutex
+	docs  map[string]doc            // by employee name
+	words map[string]map[string]int // word -> employee name -> weight
+}
+
+type doc struct {
+	id, name, title string
+	words           []string
+}
+
+const (
+	nameWeight  = 2
+	titleWeight = 1
+)
+
+func New() *Index {
+	return &Index{docs: make(map[string]doc), words: make(map[string]map[string]int)}
+}
+
+func (x *Index) Index(ctx context.Context, emps ...employee.Employee) error {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	for _, emp := range emps {
+		x.remove(emp.Name)
+		d := doc{id: string(emp.ID), name: emp.Name, title: emp.Title}
+		weights := make(map[string]int)
+		for _, w := range search.Terms(emp.Name) {
+			weights[w] += nameWeight
+		}
+		for _, w := range search.Terms(emp.Title) {
+			weights[w] += titleWeight
+		}
+		for w, weight := range weights {
+			if x.words[w] == nil {
+				x.words[w] = make(map[string]int)
+			}
+			x.words[w][emp.Name] = weight
+			d.words = append(d.words, w)
+		}
+		x.docs[emp.Name] = d
+	}
+	return nil
+}
+
+func (x *Index) Remove(ctx context.Context, names ...string) error {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	for _, name := range names {
+		x.remove(name)
+	}
+	return nil
+}
+
+func (x *Index) remove(name string) {
+	d, ok := x.docs[name]
+	if !ok {
+		return
+	}
+	for _, w := range d.words {
+		delete(x.words[w], name)
+		if len(x.words[w]) == 0 {
+			delete(x.words, w)
+		}
+	}
+	delete(x.docs, name)
+}
+
+// Search scans the vocabulary once per term; fine for the thousands of words
+// a company's names and titles contain.
+func (x *Index) Search(ctx context.Context, q search.Query) ([]search.Hit, error) {
+	terms := search.Terms(q.Text)
+	if len(terms) == 0 {
+		return nil, nil
+	}
+	x.mu.RLock()
+	defer x.mu.RUnlock()
+
+	var scores map[string]float64
+	for _, term := range terms {
+		matched := make(map[string]float64)
+		for w, postings := range x.words {
+			if !strings.HasPrefix(w, term) {
+				continue
+			}
+			boost := 0.5 // prefix
+			if w == term {
+				boost = 1
+			}
+			for name, weight := range postings {
+				matched[name] = max(matched[name], float64(weight)*boost)
+			}
+		}
+		// every term must match: keep the intersection
+		if scores == nil {
+			scores = matched
+			continue
+		}
+		for name := range scores {
+			if s, ok := matched[name]; ok {
+				scores[name] += s
+			} else {
+				delete(scores, name)
+			}
+		}
+	}
+
+	hits := make([]search.Hit, 0, len(scores))
+	for name, score := range scores {
+		d := x.docs[name]
+		hits = append(hits, search.Hit{ID: d.id, Name: d.name, Title: d.title, Score: score})
+	}
+	sort.Slice(hits, func(i, j int) bool {
+		if hits[i].Score != hits[j].Score {
+			return hits[i].Score > hits[j].Score
+		}
+		return hits[i].Name < hits[j].Name
+	})
+	if len(hits) > q.Size() {
+		hits = hits[:q.Size()]
+	}
+	return hits, nil
+}
+
+var _ search.EmployeeSearcher = (*Index)(nil)
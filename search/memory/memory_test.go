@@ -0,0 +1,13 @@
+package memory_test
+
+import (
+	"testing"
+
+	"go-solid/search"
+	"go-solid/search/memory"
+	"go-solid/search/searchtest"
+)
+
+func TestIndex(t *testing.T) {
+	searchtest.TestSearcher(t, func(t *testing.T) search.EmployeeSearcher { return memory.New() })
+}
@@ -0,0 +1,89 @@
+// Package search is full-text search over employees' names and titles.
+//
+// Callers depend on EmployeeSearcher; the index behind it is a detail. An
+// in-process index (search/memory), an Elasticsearch adapter
+// (search/elastic, behind the "elasticsearch" build tag) and a Bleve
+// adapter (search/bleve, a module of its own) are provided. Other engines -
+// OpenSearch, a database's full-text index - plug in by implementing the
+// same three methods, and search/searchtest checks that they match what
+// the others match.
+package search
+
+import (
+	"context"
+	"strings"
+	"unicode"
+
+	"go-solid/employee"
+)
+
+// Query A free-text search. Every term must match the start of a word in the
+// name or title ("eng man" finds "Engineering Manager"); case is ignored.
+type Query struct {
+	Text  string
+	Limit int
+}
+
+const (
+	DefaultLimit = 20
+	MaxLimit     = 100
+)
+
+// Size returns the effective number of hits to return.
+func (q Query) Size() int {
+	switch {
+	case q.Limit <= 0:
+		return DefaultLimit
+	case q.Limit > MaxLimit:
+		return MaxLimit
+	}
+	return q.Limit
+}
+
+// Hit One matching employee. Scores only order the hits of one search and
+// mean nothing across adapters.
+type Hit struct {
+	ID    string
+	Name  string
+	Title string
+	Score float64
+}
+
+// EmployeeSearcher Abstraction over a search index. Implementations agree on
+// which employees match a query, not on how they are ranked: hits come best
+// first, but "best" is the adapter's call.
+type EmployeeSearcher interface {
+	// Index adds employees or replaces them, keyed by name.
+	Index(ctx context.Context, emps ...employee.Employee) error
+	// Remove drops employees from the index; unknown names are ignored.
+	Remove(ctx context.Context, names ...string) error
+	Search(ctx context.Context, q Query) ([]Hit, error)
+}
+
+// Terms splits text into lower-case words, the unit both queries and
+// documents are matched on.
+func Terms(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// Reindex loads every employee of repo into s, a page at a time. repo must
+// have the employee.QueryRepository capability.
+func Reindex(ctx context.Context, repo employee.QueryRepository, s EmployeeSearcher) (int, error) {
+	indexed, cursor := 0, ""
+	for {
+		res, err := repo.List(ctx, employee.Filter{}, employee.Page{Cursor: cursor, Limit: employee.MaxPageSize})
+		if err != nil {
+			return indexed, err
+		}
+		if err := s.Index(ctx, res.Items...); err != nil {
+			return indexed, err
+		}
+		indexed += len(res.Items)
+		if res.NextCursor == "" {
+			return indexed, nil
+		}
+		cursor = res.NextCursor
+	}
+}
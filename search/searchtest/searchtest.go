@@ -0,0 +1,156 @@
+// Package searchtest is the contract every search.EmployeeSearcher keeps, as
+// a test suite any adapter runs against itself:
+//
+//	func TestSearcher(t *testing.T) {
+//		searchtest.TestSearcher(t, func(t *testing.T) search.EmployeeSearcher { return memory.New() })
+//	}
+//
+// It checks what adapters agree on and nothing they don't: which employees
+// match a query, what a hit carries, that hits come best first by their own
+// scores, and the query's limit. Which of two matches ranks higher is the
+// engine's call, so results are compared as sets. open is called once per
+// case and must return an empty index.
+package searchtest
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"testing"
+
+	"go-solid/employee"
+	"go-solid/search"
+)
+
+// staff The employees every case but "limit" starts with
+var staff = []employee.Employee{
+	{ID: "emp-1", Name: "Ali Hassan", Title: "Engineering Manager"},
+	{ID: "emp-2", Name: "Sara Engel", Title: "Software Engineer"},
+	{ID: "emp-3", Name: "Omar Haddad", Title: "Sales Manager"},
+	{ID: "emp-4", Name: "Mei O'Brien", Title: "Recruiter"},
+}
+
+// TestSearcher runs the contract against the searchers open returns.
+func TestSearcher(t *testing.T, open func(t *testing.T) search.EmployeeSearcher) {
+	t.Run("matches", func(t *testing.T) {
+		s := indexed(t, open(t), staff...)
+		tests := []struct {
+			text string
+			want []string
+		}{
+			{"eng", []string{"Ali Hassan", "Sara Engel"}},
+			{"man", []string{"Ali Hassan", "Omar Haddad"}},
+			{"eng man", []string{"Ali Hassan"}},
+			{"ENG MAN", []string{"Ali Hassan"}},
+			{"engineer", []string{"Ali Hassan", "Sara Engel"}}, // a whole word is a prefix of a longer one
+			{"software eng", []string{"Sara Engel"}},
+			{"sara eng", []string{"Sara Engel"}}, // one term in the name, one in the title
+			{"gineer", nil},                      // the start of a word, not the middle
+			{"eng nobody", nil},                  // every term must match
+			{"brien", []string{"Mei O'Brien"}},   // punctuation splits words
+			{"  Sales,  manager!", []string{"Omar Haddad"}},
+		}
+		for _, tt := range tests {
+			if got := names(find(t, s, search.Query{Text: tt.text})); !slices.Equal(got, tt.want) {
+				t.Errorf("Search(%q) = %v, want %v", tt.text, got, tt.want)
+			}
+		}
+	})
+
+	t.Run("no terms", func(t *testing.T) {
+		s := indexed(t, open(t), staff...)
+		for _, text := range []string{"", "   ", "--"} {
+			if hits := find(t, s, search.Query{Text: text}); len(hits) != 0 {
+				t.Errorf("Search(%q) = %v, want no hits", text, names(hits))
+			}
+		}
+	})
+
+	t.Run("hit", func(t *testing.T) {
+		s := indexed(t, open(t), staff...)
+		hits := find(t, s, search.Query{Text: "omar"})
+		if len(hits) != 1 {
+			t.Fatalf("Search(omar) = %v, want Omar Haddad", names(hits))
+		}
+		if h := hits[0]; h.ID != "emp-3" || h.Name != "Omar Haddad" || h.Title != "Sales Manager" {
+			t.Errorf("Search(omar) = %+v, want Omar's ID, name and title", h)
+		}
+	})
+
+	t.Run("best first", func(t *testing.T) {
+		s := indexed(t, open(t), staff...)
+		hits := find(t, s, search.Query{Text: "eng"})
+		if !slices.IsSortedFunc(hits, func(a, b search.Hit) int { return cmp.Compare(b.Score, a.Score) }) {
+			t.Errorf("Search(eng) = %+v, want the scores in descending order", hits)
+		}
+	})
+
+	t.Run("replace", func(t *testing.T) {
+		s := indexed(t, open(t), staff...)
+		sara := staff[1]
+		sara.Title = "Sales Lead"
+		indexed(t, s, sara)
+		if hits := find(t, s, search.Query{Text: "sara"}); len(hits) != 1 || hits[0].Title != "Sales Lead" {
+			t.Errorf("Search(sara) = %+v, want one Sara, a Sales Lead", hits)
+		}
+		if got := names(find(t, s, search.Query{Text: "software"})); len(got) != 0 {
+			t.Errorf("Search(software) = %v, want the old title gone", got)
+		}
+		if got := names(find(t, s, search.Query{Text: "sales"})); !slices.Equal(got, []string{"Omar Haddad", "Sara Engel"}) {
+			t.Errorf("Search(sales) = %v, want Omar and Sara", got)
+		}
+	})
+
+	t.Run("remove", func(t *testing.T) {
+		s := indexed(t, open(t), staff...)
+		if err := s.Remove(t.Context(), "Omar Haddad", "Nobody"); err != nil {
+			t.Fatalf("Remove() error = %v, want an unknown name ignored", err)
+		}
+		if got := names(find(t, s, search.Query{Text: "man"})); !slices.Equal(got, []string{"Ali Hassan"}) {
+			t.Errorf("Search(man) = %v, want Omar gone", got)
+		}
+		if err := s.Remove(t.Context()); err != nil {
+			t.Errorf("Remove() of nothing error = %v", err)
+		}
+	})
+
+	t.Run("limit", func(t *testing.T) {
+		emps := make([]employee.Employee, search.MaxLimit+10)
+		for i := range emps {
+			emps[i] = employee.Employee{ID: employee.ID(fmt.Sprintf("emp-%d", i)), Name: fmt.Sprintf("Engineer %03d", i), Title: "Engineer"}
+		}
+		s := indexed(t, open(t), emps...)
+		for _, tt := range []struct{ limit, want int }{{0, search.DefaultLimit}, {5, 5}, {1000, search.MaxLimit}} {
+			if got := len(find(t, s, search.Query{Text: "engineer", Limit: tt.limit})); got != tt.want {
+				t.Errorf("Search() with limit %d = %d hits, want %d", tt.limit, got, tt.want)
+			}
+		}
+	})
+}
+
+func indexed(t *testing.T, s search.EmployeeSearcher, emps ...employee.Employee) search.EmployeeSearcher {
+	t.Helper()
+	if err := s.Index(t.Context(), emps...); err != nil {
+		t.Fatalf("Index() error = %v", err)
+	}
+	return s
+}
+
+func find(t *testing.T, s search.EmployeeSearcher, q search.Query) []search.Hit {
+	t.Helper()
+	hits, err := s.Search(t.Context(), q)
+	if err != nil {
+		t.Fatalf("Search(%q) error = %v", q.Text, err)
+	}
+	return hits
+}
+
+// names returns the hits' names sorted, since ranking is not the contract.
+func names(hits []search.Hit) []string {
+	var names []string
+	for _, h := range hits {
+		names = append(names, h.Name)
+	}
+	slices.Sort(names)
+	return names
+}
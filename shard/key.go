@@ -0,0 +1,40 @@
+package shard
+
+import "hash/fnv"
+
+// ShardKeyFunc Decides the shard of an employee by name: an index in
+// [0, shards). It must be deterministic - the same name, the same number of
+// shards, the same shard - or reads miss what was written.
+type ShardKeyFunc func(name string, shards int) int
+
+// Modulo hashes the name and takes it modulo the number of shards. It
+// spreads evenly, but changing the number of shards moves almost every
+// employee: from n to n+1, all but about 1 in n+1.
+func Modulo(name string, shards int) int {
+	return int(hash(name) % uint64(shards))
+}
+
+// Jump is Lamping and Veach's jump consistent hash: as even as Modulo, and
+// growing from n to n+1 shards moves only the employees the new shard takes,
+// about 1 in n+1. Shards can only be added or removed at the end.
+func Jump(name string, shards int) int {
+	key := hash(name)
+	b, j := int64(-1), int64(0)
+	for j < int64(shards) {
+		b = j
+		key = key*2862933555777941757 + 1
+		j = int64(float64(b+1) * (float64(int64(1)<<31) / float64((key>>33)+1)))
+	}
+	return int(b)
+}
+
+func hash(name string) uint64 {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(name))
+	return h.Sum64()
+}
+
+var (
+	_ ShardKeyFunc = Modulo
+	_ ShardKeyFunc = Jump
+)
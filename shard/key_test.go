@@ -0,0 +1,113 @@
+package shard_test
+
+import (
+	"fmt"
+	"testing"
+
+	"go-solid/shard"
+)
+
+var keys = []struct {
+	name string
+	key  shard.ShardKeyFunc
+}{
+	{"Modulo", shard.Modulo},
+	{"Jump", shard.Jump},
+}
+
+func names(n int) []string {
+	out := make([]string, n)
+	for i := range out {
+		out[i] = fmt.Sprintf("employee %05d", i)
+	}
+	return out
+}
+
+// TestKey_Pinned fails when a key function routes a name differently than it
+// used to: every employee already stored would be looked for on the wrong
+// shard.
+func TestKey_Pinned(t *testing.T) {
+	tests := []struct {
+		key    string
+		name   string
+		shards int
+		want   int
+	}{
+		{"Modulo", "ali", 10, 9},
+		{"Modulo", "sara", 10, 8},
+		{"Modulo", "omar", 10, 2},
+		{"Modulo", "lina", 10, 5},
+		{"Jump", "ali", 10, 9},
+		{"Jump", "sara", 10, 7},
+		{"Jump", "omar", 10, 1},
+		{"Jump", "lina", 10, 9},
+		{"Jump", "ali", 3, 2},
+		{"Jump", "sara", 3, 1},
+	}
+	funcs := map[string]shard.ShardKeyFunc{"Modulo": shard.Modulo, "Jump": shard.Jump}
+	for _, tt := range tests {
+		if got := funcs[tt.key](tt.name, tt.shards); got != tt.want {
+			t.Errorf("%s(%q, %d) = %d, want %d", tt.key, tt.name, tt.shards, got, tt.want)
+		}
+	}
+}
+
+func TestKey_DeterministicEvenAndInRange(t *testing.T) {
+	all := names(10000)
+	for _, k := range keys {
+		for _, shards := range []int{1, 2, 3, 7, 16} {
+			t.Run(fmt.Sprintf("%s over %d", k.name, shards), func(t *testing.T) {
+				counts := make([]int, shards)
+				for _, name := range all {
+					i := k.key(name, shards)
+					if i < 0 || i >= shards {
+						t.Fatalf("%s(%q, %d) = %d, out of range", k.name, name, shards, i)
+					}
+					if again := k.key(name, shards); again != i {
+						t.Fatalf("%s(%q, %d) = %d then %d", k.name, name, shards, i, again)
+					}
+					counts[i]++
+				}
+				fair := len(all) / shards
+				for i, c := range counts {
+					if c < fair*8/10 || c > fair*12/10 {
+						t.Errorf("shard %d has %d of %d names, want about %d", i, c, len(all), fair)
+					}
+				}
+			})
+		}
+	}
+}
+
+func TestJump_GrowingMovesOnlyToTheNewShard(t *testing.T) {
+	all := names(10000)
+	for n := 1; n < 16; n++ {
+		moved := 0
+		for _, name := range all {
+			before, after := shard.Jump(name, n), shard.Jump(name, n+1)
+			if before == after {
+				continue
+			}
+			if after != n {
+				t.Fatalf("Jump(%q) went from shard %d to %d growing to %d shards, want only moves to the new one", name, before, after, n+1)
+			}
+			moved++
+		}
+		if want := len(all) / (n + 1); moved < want*8/10 || moved > want*12/10 {
+			t.Errorf("growing from %d to %d shards moved %d names, want about %d", n, n+1, moved, want)
+		}
+	}
+}
+
+func TestModulo_GrowingMovesMost(t *testing.T) {
+	all := names(10000)
+	moved := 0
+	for _, name := range all {
+		if shard.Modulo(name, 4) != shard.Modulo(name, 5) {
+			moved++
+		}
+	}
+	if moved < len(all)*7/10 {
+		t.Errorf("growing Modulo from 4 to 5 shards moved %d of %d names, want about 4 in 5", moved, len(all))
+	}
+}
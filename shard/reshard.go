@@ -0,0 +1,90 @@
+package shard
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"go-solid/employee"
+)
+
+// ReshardReport What a Reshard did
+type ReshardReport struct {
+	Kept  int // employees already on their new shard
+	Moved int // employees copied to another shard
+}
+
+// Reshard moves the employees to a new layout: shards, routed by key (the
+// current key when nil). A repository in both layouts keeps what still
+// routes to it, so growing from n to n+1 shards with Jump copies only about
+// 1 in n+1 employees. Shards left out of the new layout are left as they
+// are, for the caller to drop.
+//
+// It runs in two phases. Employees are copied to their new shard first;
+// if that fails, the old layout stays in use and Reshard can be run again.
+// Then the layout switches, and the copies left behind on shards of both
+// layouts are soft-deleted, so those shards must be employee.SoftDeleters.
+//
+// Every other call waits while Reshard runs. Soft-deleted employees are not
+// moved: restore them first. Moved employees start a new version history on
+// their new shard.
+func (s *ShardedRepository) Reshard(ctx context.Context, shards []employee.Repository, key ShardKeyFunc) (ReshardReport, error) {
+	if len(shards) == 0 {
+		return ReshardReport{}, errors.New("shard: reshard to no shards")
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if key == nil {
+		key = s.key
+	}
+	for _, old := range s.shards {
+		if _, ok := old.(employee.SoftDeleter); !ok && slices.Contains(shards, old) {
+			return ReshardReport{}, fmt.Errorf("shard: reshard: %T stays a shard but cannot soft-delete what moves off it: %w", old, errors.ErrUnsupported)
+		}
+	}
+
+	type move struct {
+		from employee.Repository
+		name string
+	}
+	var (
+		report ReshardReport
+		moves  []move
+		copied = map[string]bool{} // by normalized name: a copy may land on a shard not read yet
+	)
+	for n, old := range s.shards {
+		for emp, err := range employee.All(ctx, old) {
+			if err != nil {
+				return report, fmt.Errorf("shard: reshard: read shard %d: %w", n, err)
+			}
+			name := s.names.Normalize(emp.Name)
+			if copied[name] {
+				continue
+			}
+			to := shards[key(name, len(shards))]
+			if to == old {
+				report.Kept++
+				continue
+			}
+			if err := to.Save(ctx, emp); err != nil {
+				return report, fmt.Errorf("shard: reshard: copy %q: %w", emp.Name, err)
+			}
+			report.Moved++
+			copied[name] = true
+			moves = append(moves, move{old, emp.Name})
+		}
+	}
+
+	s.shards, s.key = slices.Clone(shards), key
+	var errs []error
+	for _, m := range moves {
+		if !slices.Contains(shards, m.from) {
+			continue
+		}
+		if err := m.from.(employee.SoftDeleter).SoftDelete(ctx, m.name); err != nil {
+			errs = append(errs, fmt.Errorf("shard: reshard: remove %q from its old shard: %w", m.name, err))
+		}
+	}
+	return report, errors.Join(errs...)
+}
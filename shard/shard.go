@@ -0,0 +1,435 @@
+// Package shard partitions employees across several repositories.
+//
+// ShardedRepository is a Composite: it holds N employee.Repository values
+// and is one itself. A call about one employee goes to the shard its
+// ShardKeyFunc names; a call about many - a listing, a specification, a
+// stream - goes to every shard and the answers are merged. The Manager can't
+// tell a sharded store from a single one (LSP), and a shard can be any
+// backend, even another ShardedRepository. Renaming an employee to a name
+// routed to another shard moves it there.
+package shard
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"iter"
+	"slices"
+	"sync"
+
+	"go-solid/employee"
+	"go-solid/normalize"
+	"go-solid/outbox"
+	"go-solid/spec"
+)
+
+// ShardedRepository Composite routing each employee to one of its shards
+type ShardedRepository struct {
+	mu     sync.RWMutex // held for writing while resharding
+	shards []employee.Repository
+	key    ShardKeyFunc
+	names  normalize.Normalizer
+}
+
+// Option customises a ShardedRepository created by New
+type Option func(*ShardedRepository)
+
+// WithKey routes employees with key; Jump by default.
+func WithKey(key ShardKeyFunc) Option { return func(s *ShardedRepository) { s.key = key } }
+
+// WithNormalizer routes each name by its key under n, so names its shards
+// would find as the same employee land on the same shard; normalize.Name by
+// default, as in the memory and SQL repositories.
+func WithNormalizer(n normalize.Normalizer) Option {
+	return func(s *ShardedRepository) { s.names = n }
+}
+
+// New partitions employees across shards. It panics without shards, as
+// there is nowhere to put an employee.
+func New(shards []employee.Repository, opts ...Option) *ShardedRepository {
+	if len(shards) == 0 {
+		panic("shard: no shards")
+	}
+	s := &ShardedRepository{shards: slices.Clone(shards), key: Jump, names: normalize.Name}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// Shards returns the current shards, in routing order.
+func (s *ShardedRepository) Shards() []employee.Repository {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return slices.Clone(s.shards)
+}
+
+// ShardOf returns the index of the shard name is routed to.
+func (s *ShardedRepository) ShardOf(name string) int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.shardOf(name)
+}
+
+func (s *ShardedRepository) shardOf(name string) int {
+	i := s.key(s.names.Normalize(name), len(s.shards))
+	if i < 0 || i >= len(s.shards) {
+		panic(fmt.Sprintf("shard: key function returned %d for %d shards", i, len(s.shards)))
+	}
+	return i
+}
+
+// Save stores emp on the shard its name routes to. Under a new name that
+// routes elsewhere, the employee moves there: see move.
+func (s *ShardedRepository) Save(ctx context.Context, emp employee.Employee) error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	to := s.shardOf(emp.Name)
+	from, _, err := s.home(ctx, emp.ID, to)
+	if err != nil {
+		return err
+	}
+	if from < 0 || from == to {
+		return s.shards[to].Save(ctx, emp)
+	}
+	return s.move(ctx, from, emp, func() error { return s.shards[to].Save(ctx, emp) })
+}
+
+// Update checks expectedVersion on the shard holding the employee, which is
+// another one when the update renames it across shards.
+func (s *ShardedRepository) Update(ctx context.Context, emp employee.Employee, expectedVersion int) (employee.Employee, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	to := s.shardOf(emp.Name)
+	u, ok := s.shards[to].(employee.Updater)
+	if !ok {
+		return employee.Employee{}, errors.ErrUnsupported
+	}
+	from, current, err := s.home(ctx, emp.ID, to)
+	if err != nil {
+		return employee.Employee{}, err
+	}
+	if from < 0 || from == to {
+		return u.Update(ctx, emp, expectedVersion)
+	}
+	if current.Version != expectedVersion {
+		return employee.Employee{}, &employee.ConflictError{Name: emp.Name, Expected: expectedVersion, Actual: current.Version}
+	}
+	var saved employee.Employee
+	err = s.move(ctx, from, emp, func() (err error) {
+		saved, err = u.Update(ctx, emp, 0)
+		return err
+	})
+	return saved, err
+}
+
+// home returns the index of the shard holding the employee with id, and
+// the employee, or -1 when id is empty or no shard has it. The shard at
+// prefer, where the employee's name routes, is asked first: it is the one
+// holding it unless the save renames it across shards.
+func (s *ShardedRepository) home(ctx context.Context, id employee.ID, prefer int) (int, employee.Employee, error) {
+	if id == "" {
+		return -1, employee.Employee{}, nil
+	}
+	for i := range s.shards {
+		n := (prefer + i) % len(s.shards)
+		emp, err := employee.GetByID(ctx, s.shards[n], id)
+		if err == nil {
+			return n, emp, nil
+		}
+		if !errors.Is(err, employee.ErrNotFound) {
+			return -1, employee.Employee{}, fmt.Errorf("shard %d: %w", n, err)
+		}
+	}
+	return -1, employee.Employee{}, nil
+}
+
+// move renames emp across shards: save stores it on the shard its new name
+// routes to, then the row left on shard from is retired - renamed to a name
+// only it has, freeing its old name there, and soft-deleted - so that shard
+// must be an employee.SoftDeleter. Renaming the employee back to that shard
+// later finds the retired row by ID and brings it back. As after a Reshard,
+// the employee starts a new version history on its new shard. The two
+// shards are written one after the other, not atomically: if retiring
+// fails, the error says so and the employee is on both until saved again.
+func (s *ShardedRepository) move(ctx context.Context, from int, emp employee.Employee, save func() error) error {
+	d, ok := s.shards[from].(employee.SoftDeleter)
+	if !ok {
+		return fmt.Errorf("shard: rename %q across shards: shard %d cannot soft-delete it: %w", emp.Name, from, errors.ErrUnsupported)
+	}
+	if err := save(); err != nil {
+		return err
+	}
+	retired := emp
+	retired.Name = retiredName(emp.ID)
+	if err := s.shards[from].Save(ctx, retired); err != nil {
+		return fmt.Errorf("shard: rename %q: retire it from shard %d: %w", emp.Name, from, err)
+	}
+	if err := d.SoftDelete(ctx, retired.Name); err != nil {
+		return fmt.Errorf("shard: rename %q: retire it from shard %d: %w", emp.Name, from, err)
+	}
+	return nil
+}
+
+// retiredName The name an employee's row is kept under on a shard it moved
+// off: unique, as IDs are, and unlike any name a person has.
+func retiredName(id employee.ID) string { return "(moved) " + string(id) }
+
+func (s *ShardedRepository) GetByName(ctx context.Context, name string) (employee.Employee, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.shards[s.shardOf(name)].GetByName(ctx, name)
+}
+
+// GetByID asks every shard in turn: names route, IDs don't.
+func (s *ShardedRepository) GetByID(ctx context.Context, id employee.ID) (employee.Employee, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	for n, shard := range s.shards {
+		emp, err := employee.GetByID(ctx, shard, id)
+		if err == nil {
+			return emp, nil
+		}
+		if !errors.Is(err, employee.ErrNotFound) {
+			return employee.Employee{}, fmt.Errorf("shard %d: %w", n, err)
+		}
+	}
+	return employee.Employee{}, employee.ErrNotFound
+}
+
+// SaveAll splits each batch of emps by shard and saves every part with
+// employee.SaveAll; an employee renamed across shards is moved on its own.
+// Failures are reported at their index in emps.
+func (s *ShardedRepository) SaveAll(ctx context.Context, emps iter.Seq[employee.Employee]) error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	report := &employee.BulkError{}
+	offset := 0
+	for batch := range employee.Batches(emps, 256) {
+		if err := ctx.Err(); err != nil {
+			report.Stopped = err
+			break
+		}
+		parts := make([][]int, len(s.shards)) // indexes in batch, by shard
+		for i, emp := range batch {
+			n := s.shardOf(emp.Name)
+			from, _, err := s.home(ctx, emp.ID, n)
+			switch {
+			case err != nil:
+				report.Add(offset+i, emp, err)
+			case from >= 0 && from != n:
+				report.Add(offset+i, emp, s.move(ctx, from, emp, func() error { return s.shards[n].Save(ctx, emp) }))
+			default:
+				parts[n] = append(parts[n], i)
+			}
+		}
+		for n, part := range parts {
+			if len(part) == 0 {
+				continue
+			}
+			err := employee.SaveAll(ctx, s.shards[n], func(yield func(employee.Employee) bool) {
+				for _, i := range part {
+					if !yield(batch[i]) {
+						return
+					}
+				}
+			})
+			merge(report, err, part, batch, offset)
+		}
+		offset += len(batch)
+	}
+	return report.Err()
+}
+
+// merge adds the result of saving part of batch to report. part holds
+// indexes in batch, batch starts at offset in the input.
+func merge(report *employee.BulkError, err error, part []int, batch []employee.Employee, offset int) {
+	var bulk *employee.BulkError
+	switch {
+	case err == nil:
+		report.Saved += len(part)
+		return
+	case !errors.As(err, &bulk):
+		for _, i := range part {
+			report.Add(offset+i, batch[i], err)
+		}
+		return
+	}
+	report.Saved += bulk.Saved
+	for _, f := range bulk.Failed {
+		i := part[f.Index]
+		report.Add(offset+i, batch[i], f.Err)
+	}
+	report.Stopped = cmp.Or(report.Stopped, bulk.Stopped)
+}
+
+func (s *ShardedRepository) SoftDelete(ctx context.Context, name string) error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	d, ok := s.shards[s.shardOf(name)].(employee.SoftDeleter)
+	if !ok {
+		return errors.ErrUnsupported
+	}
+	return d.SoftDelete(ctx, name)
+}
+
+func (s *ShardedRepository) Restore(ctx context.Context, name string) error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	d, ok := s.shards[s.shardOf(name)].(employee.SoftDeleter)
+	if !ok {
+		return errors.ErrUnsupported
+	}
+	return d.Restore(ctx, name)
+}
+
+func (s *ShardedRepository) History(ctx context.Context, name string) ([]employee.Employee, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	v, ok := s.shards[s.shardOf(name)].(employee.Versioned)
+	if !ok {
+		return nil, errors.ErrUnsupported
+	}
+	return v.History(ctx, name)
+}
+
+// SaveWithOutbox stores emp and msgs on emp's shard, whose outbox the relay
+// for that shard drains; under a rename across shards, the new one.
+func (s *ShardedRepository) SaveWithOutbox(ctx context.Context, emp employee.Employee, msgs []outbox.Message) error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	to := s.shardOf(emp.Name)
+	o, ok := s.shards[to].(employee.OutboxRepository)
+	if !ok {
+		return errors.ErrUnsupported
+	}
+	from, _, err := s.home(ctx, emp.ID, to)
+	if err != nil {
+		return err
+	}
+	if from < 0 || from == to {
+		return o.SaveWithOutbox(ctx, emp, msgs)
+	}
+	return s.move(ctx, from, emp, func() error { return o.SaveWithOutbox(ctx, emp, msgs) })
+}
+
+// List asks every shard for the same page and merges them: the first
+// page.Size() items, in the filter's order, are the page. Cursors are keyset
+// cursors, so the one after the last item is valid on every shard.
+func (s *ShardedRepository) List(ctx context.Context, filter employee.Filter, page employee.Page) (employee.PageResult, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	var (
+		items []employee.Employee
+		more  bool
+	)
+	for n, shard := range s.shards {
+		q, ok := shard.(employee.QueryRepository)
+		if !ok {
+			return employee.PageResult{}, errors.ErrUnsupported
+		}
+		res, err := q.List(ctx, filter, page)
+		if err != nil {
+			return employee.PageResult{}, fmt.Errorf("shard %d: %w", n, err)
+		}
+		items = append(items, res.Items...)
+		more = more || res.NextCursor != ""
+	}
+	slices.SortFunc(items, func(a, b employee.Employee) int {
+		if filter.Before(a, b) {
+			return -1
+		}
+		if filter.Before(b, a) {
+			return 1
+		}
+		return 0
+	})
+	res := employee.PageResult{Items: items}
+	if size := page.Size(); len(items) > size {
+		res.Items, more = items[:size], true
+	}
+	if more && len(res.Items) > 0 {
+		res.NextCursor = employee.CursorAfter(filter, res.Items[len(res.Items)-1])
+	}
+	return res, nil
+}
+
+// Matching asks every shard and returns the employees by name.
+func (s *ShardedRepository) Matching(ctx context.Context, sp spec.Specification[employee.Employee]) ([]employee.Employee, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	var all []employee.Employee
+	for n, shard := range s.shards {
+		m, ok := shard.(employee.SpecificationRepository)
+		if !ok {
+			return nil, errors.ErrUnsupported
+		}
+		emps, err := m.Matching(ctx, sp)
+		if err != nil {
+			return nil, fmt.Errorf("shard %d: %w", n, err)
+		}
+		all = append(all, emps...)
+	}
+	slices.SortFunc(all, func(a, b employee.Employee) int { return cmp.Compare(a.Name, b.Name) })
+	return all, nil
+}
+
+// All merges the shards' streams, each already by name, into one by name.
+// The shards are those of when the loop starts.
+func (s *ShardedRepository) All(ctx context.Context) iter.Seq2[employee.Employee, error] {
+	shards := s.Shards()
+	return func(yield func(employee.Employee, error) bool) {
+		type head struct {
+			emp  employee.Employee
+			next func() (employee.Employee, error, bool)
+		}
+		var heads []head
+		for _, shard := range shards {
+			next, stop := iter.Pull2(employee.All(ctx, shard))
+			defer stop()
+			emp, err, ok := next()
+			if err != nil {
+				yield(employee.Employee{}, err)
+				return
+			}
+			if ok {
+				heads = append(heads, head{emp, next})
+			}
+		}
+		for len(heads) > 0 {
+			i := 0
+			for j := range heads {
+				if heads[j].emp.Name < heads[i].emp.Name {
+					i = j
+				}
+			}
+			if !yield(heads[i].emp, nil) {
+				return
+			}
+			emp, err, ok := heads[i].next()
+			switch {
+			case err != nil:
+				yield(employee.Employee{}, err)
+				return
+			case ok:
+				heads[i].emp = emp
+			default:
+				heads = slices.Delete(heads, i, i+1)
+			}
+		}
+	}
+}
+
+var (
+	_ employee.Repository              = (*ShardedRepository)(nil)
+	_ employee.BulkSaver               = (*ShardedRepository)(nil)
+	_ employee.SoftDeleter             = (*ShardedRepository)(nil)
+	_ employee.Updater                 = (*ShardedRepository)(nil)
+	_ employee.IDRepository            = (*ShardedRepository)(nil)
+	_ employee.Versioned               = (*ShardedRepository)(nil)
+	_ employee.QueryRepository         = (*ShardedRepository)(nil)
+	_ employee.SpecificationRepository = (*ShardedRepository)(nil)
+	_ employee.OutboxRepository        = (*ShardedRepository)(nil)
+	_ employee.Iterable                = (*ShardedRepository)(nil)
+)
@@ -0,0 +1,256 @@
+package shard_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"iter"
+	"slices"
+	"testing"
+
+	"go-solid/employee"
+	"go-solid/employee/employeetest"
+	"go-solid/employee/memory"
+	"go-solid/shard"
+)
+
+func TestRepository(t *testing.T) {
+	employeetest.TestRepository(t, func(t *testing.T) employee.Repository {
+		return shard.New([]employee.Repository{memory.New(), memory.New(), memory.New()})
+	})
+}
+
+var errDown = errors.New("shard down")
+
+// down A shard whose bulk saves fail as a whole, without a report
+type down struct{ *memory.Repository }
+
+func (down) SaveAll(context.Context, iter.Seq[employee.Employee]) error { return errDown }
+
+func TestRepository_SaveAllReportsInputIndexes(t *testing.T) {
+	s := shard.New([]employee.Repository{down{memory.New()}, memory.New(), memory.New()})
+	emps := make([]employee.Employee, 600) // more than one batch
+	for i := range emps {
+		emps[i] = employee.Employee{Name: fmt.Sprintf("Staff %03d", i)}
+	}
+	err := s.SaveAll(t.Context(), slices.Values(emps))
+	var bulk *employee.BulkError
+	if !errors.As(err, &bulk) {
+		t.Fatalf("SaveAll() error = %v, want a *BulkError", err)
+	}
+	var want []int
+	for i, emp := range emps {
+		if s.ShardOf(emp.Name) == 0 {
+			want = append(want, i)
+		}
+	}
+	var got []int
+	for _, f := range bulk.Failed {
+		if f.Name != emps[f.Index].Name || !errors.Is(f.Err, errDown) {
+			t.Errorf("Failed #%d = %q %v, want %q %v", f.Index, f.Name, f.Err, emps[f.Index].Name, errDown)
+		}
+		got = append(got, f.Index)
+	}
+	slices.Sort(got)
+	if len(want) == 0 || !slices.Equal(got, want) {
+		t.Errorf("failed indexes = %v, want those on the shard that is down: %v", got, want)
+	}
+	if bulk.Saved != len(emps)-len(want) {
+		t.Errorf("Saved = %d, want %d", bulk.Saved, len(emps)-len(want))
+	}
+}
+
+// layout n memory shards, as Repositories
+func layout(n int) []employee.Repository {
+	shards := make([]employee.Repository, n)
+	for i := range shards {
+		shards[i] = memory.New()
+	}
+	return shards
+}
+
+// hire saves n employees through s and returns their names.
+func hire(t *testing.T, s *shard.ShardedRepository, n int) []string {
+	t.Helper()
+	hired := names(n)
+	if err := s.SaveAll(t.Context(), func(yield func(employee.Employee) bool) {
+		for _, name := range hired {
+			if !yield(employee.Employee{Name: name}) {
+				return
+			}
+		}
+	}); err != nil {
+		t.Fatalf("SaveAll() error = %v", err)
+	}
+	return hired
+}
+
+func TestRepository_RoutesEachEmployeeToOneShard(t *testing.T) {
+	shards := layout(4)
+	s := shard.New(shards)
+	for _, name := range hire(t, s, 200) {
+		for i, repo := range shards {
+			_, err := repo.GetByName(t.Context(), name)
+			if found := err == nil; found != (i == s.ShardOf(name)) {
+				t.Errorf("%q found on shard %d: %v, want it on shard %d only", name, i, found, s.ShardOf(name))
+			}
+		}
+	}
+	// names the shards find as the same employee route the same way
+	if a, b := s.ShardOf("Ali Hassan"), s.ShardOf("  ali   HASSAN "); a != b {
+		t.Errorf("ShardOf() = %d and %d for the same name spelled twice", a, b)
+	}
+	if err := s.Save(t.Context(), employee.Employee{Name: "Ali Hassan"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.GetByName(t.Context(), "  ali   HASSAN "); err != nil {
+		t.Errorf("GetByName() spelled differently error = %v", err)
+	}
+}
+
+// apart returns two names s routes to different shards.
+func apart(t *testing.T, s *shard.ShardedRepository) (string, string) {
+	t.Helper()
+	first := names(1)[0]
+	for _, name := range names(100) {
+		if s.ShardOf(name) != s.ShardOf(first) {
+			return first, name
+		}
+	}
+	t.Fatal("every name routes to one shard")
+	return "", ""
+}
+
+func TestRepository_RenameAcrossShards(t *testing.T) {
+	shards := layout(3)
+	s := shard.New(shards)
+	from, to := apart(t, s)
+	if err := s.Save(t.Context(), employee.Employee{Name: from, Title: "Engineer"}); err != nil {
+		t.Fatal(err)
+	}
+	emp, err := s.GetByName(t.Context(), from)
+	if err != nil {
+		t.Fatal(err)
+	}
+	emp.Name = to
+	if err := s.Save(t.Context(), emp); err != nil {
+		t.Fatalf("Save() under a name on another shard error = %v", err)
+	}
+	if _, err := s.GetByName(t.Context(), from); !errors.Is(err, employee.ErrNotFound) {
+		t.Errorf("GetByName(old name) error = %v, want %v", err, employee.ErrNotFound)
+	}
+	if got, err := s.GetByID(t.Context(), emp.ID); err != nil || got.Name != to {
+		t.Errorf("GetByID() = %q, %v, want %q", got.Name, err, to)
+	}
+	for i, repo := range shards {
+		if _, err := employee.GetByID(t.Context(), repo, emp.ID); (err == nil) != (i == s.ShardOf(to)) {
+			t.Errorf("found on shard %d: %v, want it on shard %d only", i, err == nil, s.ShardOf(to))
+		}
+	}
+
+	// the old name is free for someone else, and the move back works by Update
+	if err := s.Save(t.Context(), employee.Employee{Name: from}); err != nil {
+		t.Fatal(err)
+	}
+	if other, _ := s.GetByName(t.Context(), from); other.ID == emp.ID {
+		t.Errorf("GetByName(old name) ID = %q, want a new employee", other.ID)
+	}
+	emp, _ = s.GetByName(t.Context(), to)
+	back := emp
+	back.Name = from + " again"
+	for s.ShardOf(back.Name) == s.ShardOf(to) {
+		back.Name += "!"
+	}
+	if _, err := s.Update(t.Context(), back, emp.Version+1); !errors.Is(err, employee.ErrConflict) {
+		t.Errorf("Update() across shards at a stale version error = %v, want %v", err, employee.ErrConflict)
+	}
+	stored, err := s.Update(t.Context(), back, emp.Version)
+	if err != nil || stored.ID != emp.ID || stored.Name != back.Name {
+		t.Fatalf("Update() across shards = %q %q, %v, want %q %q", stored.ID, stored.Name, err, emp.ID, back.Name)
+	}
+	var seen []employee.ID
+	for emp, err := range s.All(t.Context()) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		seen = append(seen, emp.ID)
+	}
+	if slices.Sort(seen); len(seen) != 2 || seen[0] == seen[1] {
+		t.Errorf("All() = %v, want two employees, each once", seen)
+	}
+}
+
+func TestReshard(t *testing.T) {
+	tests := []struct {
+		name     string
+		grow     func(old []employee.Repository) []employee.Repository
+		key      shard.ShardKeyFunc
+		maxMoved int
+	}{
+		{"jump, one more shard", func(old []employee.Repository) []employee.Repository {
+			return append(old, memory.New())
+		}, nil, 300 * 12 / 10 / 4},
+		{"modulo, one more shard", func(old []employee.Repository) []employee.Repository {
+			return append(old, memory.New())
+		}, shard.Modulo, 300},
+		{"jump, new shards", func([]employee.Repository) []employee.Repository { return layout(5) }, nil, 300},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			old := layout(3)
+			s := shard.New(old)
+			hired := hire(t, s, 300)
+			shards := tt.grow(old)
+			report, err := s.Reshard(t.Context(), shards, tt.key)
+			if err != nil {
+				t.Fatalf("Reshard() error = %v", err)
+			}
+			if report.Kept+report.Moved != len(hired) || report.Moved > tt.maxMoved {
+				t.Errorf("Reshard() = %+v, want %d in all and at most %d moved", report, len(hired), tt.maxMoved)
+			}
+			for _, name := range hired {
+				if _, err := s.GetByName(t.Context(), name); err != nil {
+					t.Errorf("GetByName(%q) after resharding error = %v", name, err)
+				}
+				want := s.ShardOf(name)
+				for i, repo := range shards {
+					if _, err := repo.GetByName(t.Context(), name); (err == nil) != (i == want) {
+						t.Errorf("%q found on shard %d: %v, want it on shard %d only", name, i, err == nil, want)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestReshard_JumpKeepsWhatStays(t *testing.T) {
+	s := shard.New(layout(3))
+	hired := hire(t, s, 300)
+	before := make(map[string]int, len(hired))
+	for _, name := range hired {
+		before[name] = s.ShardOf(name)
+	}
+	if _, err := s.Reshard(t.Context(), append(s.Shards(), memory.New()), nil); err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range hired {
+		if after := s.ShardOf(name); after != before[name] && after != 3 {
+			t.Errorf("%q moved from shard %d to %d, want it kept or on the new shard", name, before[name], after)
+		}
+	}
+}
+
+// plain A shard without optional capabilities
+type plain struct{ employee.Repository }
+
+func TestReshard_NeedsSoftDeletesOnShardsThatStay(t *testing.T) {
+	old := []employee.Repository{plain{memory.New()}, memory.New()}
+	s := shard.New(old)
+	hire(t, s, 10)
+	if _, err := s.Reshard(t.Context(), append(old, memory.New()), nil); !errors.Is(err, errors.ErrUnsupported) {
+		t.Fatalf("Reshard() error = %v, want %v", err, errors.ErrUnsupported)
+	}
+	if len(s.Shards()) != 2 {
+		t.Errorf("Shards() = %d, want the old layout kept", len(s.Shards()))
+	}
+}
@@ -0,0 +1,223 @@
+// Package simulate runs a whole organisation's payroll, month after month,
+// on made-up data: an org of teams from the fakes package, hired into an
+// employee repository, with people leaving, being replaced and getting
+// raises on their anniversaries, and every month's payroll run through a
+// payroll.Runner.
+//
+// Everything random is drawn from the seed, so the same seed gives the same
+// months and the same payslips. The report carries a fingerprint of those
+// payslips - a change that alters any of them changes it - and how long
+// each run took, so the simulation doubles as a regression harness for both
+// results and speed.
+package simulate
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"io"
+	"math/big"
+	"math/rand/v2"
+	"text/tabwriter"
+	"time"
+
+	"go-solid/clock"
+	"go-solid/employee"
+	"go-solid/employee/memory"
+	"go-solid/fakes"
+	"go-solid/id"
+	"go-solid/money"
+	"go-solid/payroll"
+)
+
+// attrition Share of the staff leaving each month; each is replaced by a hire
+const attrition = 0.01
+
+var (
+	ErrNoEmployees = errors.New("employees must be positive")
+	ErrNoMonths    = errors.New("months must be positive")
+)
+
+// Simulation An org of Employees paid for Months, starting at Start
+type Simulation struct {
+	Employees int
+	Months    int
+	Seed      uint64
+	// Start is the first month paid (default January 2026, when the fakes'
+	// data is dated from)
+	Start payroll.Period
+	// Currency everyone is paid in (default USD)
+	Currency  money.Currency
+	Payroll   payroll.Runner
+	CountryOf func(employee.Employee) string
+}
+
+// Month What happened in one month, and its payroll run
+type Month struct {
+	Period    payroll.Period
+	Headcount int
+	Hires     int
+	Leavers   int
+	Raises    int
+	Unpaid    int // employees the run failed for
+	Gross     money.Money
+	Net       money.Money
+	Elapsed   time.Duration // the payroll run alone
+}
+
+// Report The months of a simulation, and how long it took
+type Report struct {
+	Seed   uint64
+	Teams  int
+	Months []Month
+	// Setup is the time taken to make up and store the org
+	Setup   time.Duration
+	Elapsed time.Duration
+	// Fingerprint hashes every payslip's employee, gross and net pay: the
+	// same seed must give the same fingerprint
+	Fingerprint uint64
+}
+
+// Run makes up the org and pays it for each month. An interrupted run
+// reports the months already paid, with ctx's error.
+func (s *Simulation) Run(ctx context.Context) (*Report, error) {
+	if s.Employees <= 0 {
+		return nil, ErrNoEmployees
+	}
+	if s.Months <= 0 {
+		return nil, ErrNoMonths
+	}
+	start := s.Start
+	if start == (payroll.Period{}) {
+		start = payroll.Period{Year: 2026, Month: time.January}
+	}
+	currency := cmp.Or(s.Currency, money.USD)
+	began := time.Now()
+	first := time.Date(start.Year, start.Month, 1, 0, 0, 0, 0, time.UTC)
+	faker := fakes.New(s.Seed, fakes.WithNow(first), fakes.WithCurrency(currency))
+	rng := rand.New(rand.NewPCG(s.Seed, ^s.Seed))
+	clk := clock.NewFake(first)
+	repo := memory.New()
+	manager := employee.NewManager(repo, employee.WithClock(clk), employee.WithIDs(id.NewSequence("sim-")))
+
+	report := &Report{Seed: s.Seed}
+	var err error
+	if report.Teams, err = s.hire(ctx, repo, faker, rng); err != nil {
+		return nil, err
+	}
+	report.Setup = time.Since(began)
+
+	digest := fnv.New64a()
+	staff := payroll.Staff{Repo: repo, CountryOf: s.CountryOf}
+	for i := range s.Months {
+		t := first.AddDate(0, i, 0)
+		clk.Advance(t.Sub(clk.Now()))
+		m := Month{Period: payroll.Period{Year: t.Year(), Month: t.Month()}}
+		if err := s.turnover(ctx, manager, repo, faker, rng, &m); err != nil {
+			return report, fmt.Errorf("simulate %s: %w", m.Period, err)
+		}
+		ran := time.Now()
+		run, err := s.Payroll.Run(ctx, m.Period, staff)
+		m.Elapsed = time.Since(ran)
+		if err != nil {
+			return report, fmt.Errorf("simulate %s: %w", m.Period, err)
+		}
+		m.Headcount = len(run.Payslips) + len(run.Errors)
+		m.Unpaid = len(run.Errors)
+		if m.Gross, m.Net, err = run.Totals(ctx, money.Rates{Base: currency}, currency); err != nil {
+			return report, fmt.Errorf("simulate %s: %w", m.Period, err)
+		}
+		for _, slip := range run.Payslips {
+			fmt.Fprintf(digest, "%s|%s|%d|%d\n", m.Period, slip.EmployeeID, slip.Gross().Minor(), slip.Net().Minor())
+		}
+		report.Months = append(report.Months, m)
+	}
+	report.Fingerprint = digest.Sum64()
+	report.Elapsed = time.Since(began)
+	return report, nil
+}
+
+// hire stores the org: teams of 4 to 10 people and their manager, until
+// there are Employees in all. It returns the number of teams.
+func (s *Simulation) hire(ctx context.Context, repo employee.Repository, faker fakes.Faker, rng *rand.Rand) (int, error) {
+	hired, teams := 0, 0
+	for hired < s.Employees {
+		team := faker.Team(min(4+rng.IntN(7), s.Employees-hired-1))
+		teams++
+		for _, emp := range append([]employee.Employee{team.Manager}, team.Members...) {
+			if err := repo.Save(ctx, emp); err != nil {
+				return teams, fmt.Errorf("hiring %s: %w", emp.Name, err)
+			}
+			hired++
+		}
+	}
+	return teams, nil
+}
+
+// turnover applies the month's changes before it is paid: leavers, raises
+// for those whose anniversary it is, and a hire for each leaver.
+func (s *Simulation) turnover(ctx context.Context, manager *employee.Manager, repo employee.Repository, faker fakes.Faker, rng *rand.Rand, m *Month) error {
+	var staff []employee.Employee
+	for emp, err := range employee.All(ctx, repo) {
+		if err != nil {
+			return err
+		}
+		staff = append(staff, emp)
+	}
+	for _, emp := range staff {
+		switch {
+		case rng.Float64() < attrition:
+			if err := manager.RemoveEmployee(ctx, emp.Name); err != nil {
+				return err
+			}
+			m.Leavers++
+		case emp.HiredAt.Month() == m.Period.Month && emp.HiredAt.Year() < m.Period.Year:
+			raise := big.NewRat(int64(103+rng.IntN(8)), 100)
+			if _, err := manager.ChangeSalary(ctx, emp.Name, emp.Salary.Mul(raise)); err != nil {
+				return err
+			}
+			m.Raises++
+		}
+	}
+	for range m.Leavers {
+		if _, err := manager.AddEmployee(ctx, faker.Employee()); err != nil {
+			return err
+		}
+		m.Hires++
+	}
+	return nil
+}
+
+// Payslips returns how many payslips the simulation produced.
+func (r *Report) Payslips() int {
+	n := 0
+	for _, m := range r.Months {
+		n += m.Headcount - m.Unpaid
+	}
+	return n
+}
+
+// Write prints a line per month, then the totals, the timing and the
+// fingerprint.
+func (r *Report) Write(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(tw, "month\theadcount\thires\tleavers\traises\tunpaid\tgross\tnet\ttime\t")
+	var paid time.Duration
+	for _, m := range r.Months {
+		fmt.Fprintf(tw, "%s\t%d\t%d\t%d\t%d\t%d\t%v\t%v\t%s\t\n", m.Period, m.Headcount, m.Hires, m.Leavers, m.Raises, m.Unpaid,
+			m.Gross, m.Net, m.Elapsed.Round(time.Millisecond))
+		paid += m.Elapsed
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+	rate := 0.0
+	if paid > 0 {
+		rate = float64(r.Payslips()) / paid.Seconds()
+	}
+	_, err := fmt.Fprintf(w, "\n%d teams; %d payslips in %s of payroll (%.0f/s); %s in all, %s of it making up the org\nfingerprint %016x (seed %d)\n",
+		r.Teams, r.Payslips(), paid.Round(time.Millisecond), rate, r.Elapsed.Round(time.Millisecond), r.Setup.Round(time.Millisecond), r.Fingerprint, r.Seed)
+	return err
+}
@@ -0,0 +1,97 @@
+// Package slides turns a lesson into a slide deck for workshops.
+//
+// The deck is the lesson's own text, cut at its "---" lines, with the code
+// excerpts filled in from the checkpoints. Nothing is copied by hand, so a
+// slide can't show code the lesson no longer has. How the deck is written
+// out is a Renderer, chosen by name.
+package slides
+
+import (
+	_ "embed"
+	"fmt"
+	"html/template"
+	"io"
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
+
+	"go-solid/content"
+)
+
+// Slide One slide, in markdown
+type Slide struct {
+	Markdown string
+}
+
+// Deck A lesson's slides, the first one being its title slide
+type Deck struct {
+	Lesson string
+	Title  string
+	Slides []Slide
+}
+
+var separator = regexp.MustCompile(`(?m)^---[ \t]*$`)
+
+// Build makes the deck of lesson from what p provides.
+func Build(p content.Provider, lesson string) (Deck, error) {
+	l, err := p.Lesson(lesson)
+	if err != nil {
+		return Deck{}, err
+	}
+	text, err := content.Expand(p, lesson, l.Text)
+	if err != nil {
+		return Deck{}, err
+	}
+	deck := Deck{Lesson: l.Name, Title: l.Title}
+	for part := range strings.SplitSeq(separator.ReplaceAllString(text, "\x00"), "\x00") {
+		if part = strings.TrimSpace(part); part != "" {
+			deck.Slides = append(deck.Slides, Slide{Markdown: part})
+		}
+	}
+	return deck, nil
+}
+
+// Renderer Writes a deck in one format
+type Renderer interface {
+	Render(w io.Writer, d Deck) error
+}
+
+// Formats The renderers solid slides can pick by name
+var Formats = map[string]Renderer{
+	"revealjs": RevealJS{},
+	"markdown": Markdown{},
+}
+
+// FormatNames returns the names of Formats, sorted.
+func FormatNames() []string { return slices.Sorted(maps.Keys(Formats)) }
+
+// Markdown Writes the deck as one markdown file with "---" between slides,
+// which reveal.js, Marp and most slide tools read
+type Markdown struct{}
+
+func (Markdown) Render(w io.Writer, d Deck) error {
+	for i, s := range d.Slides {
+		if i > 0 {
+			if _, err := io.WriteString(w, "\n---\n\n"); err != nil {
+				return err
+			}
+		}
+		if _, err := fmt.Fprintln(w, s.Markdown); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+//go:embed reveal.html
+var revealPage string
+
+var revealTemplate = template.Must(template.New("reveal").Parse(revealPage))
+
+// RevealJS Writes a single HTML page presenting the deck with reveal.js. Its
+// scripts and styles are loaded from a CDN, so presenting needs internet
+// access.
+type RevealJS struct{}
+
+func (RevealJS) Render(w io.Writer, d Deck) error { return revealTemplate.Execute(w, d) }
@@ -0,0 +1,279 @@
+// Package snippets extracts named pieces of Go source, for lessons, slides
+// and anything else that quotes code instead of copying it.
+//
+// A piece is picked three ways: a region between markers,
+//
+//	// snippet:begin special-case
+//	if _, ok := em.(contractorEmployee); ok { ... }
+//	// snippet:end special-case
+//
+// a top-level declaration found with go/ast, or a line range. Regions may
+// nest and overlap; marker lines never show in what is extracted. A marker
+// left unclosed, or closing a region never opened, is an error - Scan finds
+// them all, which is what solid snippets check runs.
+package snippets
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"maps"
+	"path"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+var (
+	ErrMarker   = errors.New("bad snippet marker")
+	ErrNotFound = errors.New("no such snippet")
+)
+
+// Region A named piece of a file, between its markers
+type Region struct {
+	Name string
+	File string // set by Scan
+	Line int    // of the begin marker
+	Code string
+}
+
+var marker = regexp.MustCompile(`^\s*// snippet:(begin|end)(?:\s+(\S+))?\s*$`)
+
+// Regions returns every region of src, in the order they begin.
+func Regions(src []byte) ([]Region, error) {
+	lines := strings.Split(strings.TrimSuffix(string(src), "\n"), "\n")
+	type open struct {
+		line int
+		code []string
+	}
+	var (
+		regions []Region
+		opened  = map[string]*open{}
+		errs    []error
+	)
+	for i, line := range lines {
+		m := marker.FindStringSubmatch(line)
+		if m == nil {
+			for _, o := range opened {
+				o.code = append(o.code, line)
+			}
+			continue
+		}
+		name := m[2]
+		switch {
+		case name == "":
+			errs = append(errs, fmt.Errorf("%w on line %d: %s without a name", ErrMarker, i+1, m[1]))
+		case m[1] == "begin" && opened[name] != nil:
+			errs = append(errs, fmt.Errorf("%w on line %d: %q begins again before it ends", ErrMarker, i+1, name))
+		case m[1] == "begin" && slices.ContainsFunc(regions, func(r Region) bool { return r.Name == name }):
+			errs = append(errs, fmt.Errorf("%w on line %d: %q is used twice", ErrMarker, i+1, name))
+		case m[1] == "begin":
+			opened[name] = &open{line: i + 1}
+		case opened[name] == nil:
+			errs = append(errs, fmt.Errorf("%w on line %d: %q ends without beginning", ErrMarker, i+1, name))
+		default:
+			regions = append(regions, Region{Name: name, Line: opened[name].line, Code: dedent(opened[name].code)})
+			delete(opened, name)
+		}
+	}
+	for _, name := range slices.Sorted(maps.Keys(opened)) {
+		errs = append(errs, fmt.Errorf("%w on line %d: %q never ends", ErrMarker, opened[name].line, name))
+	}
+	slices.SortFunc(regions, func(a, b Region) int { return a.Line - b.Line })
+	return regions, errors.Join(errs...)
+}
+
+// Select returns the pieces of src named by selector, a comma-separated
+// list, joined by blank lines. Each item is one of
+//
+//	snippet:special-case     a region
+//	employee.getSalary       a declaration, methods as type.method
+//	L11-18                   lines 11 to 18, or L11 alone
+func Select(src []byte, selector string) (string, error) {
+	var parts []string
+	for item := range strings.SplitSeq(selector, ",") {
+		var (
+			code string
+			err  error
+		)
+		if name, ok := strings.CutPrefix(item, "snippet:"); ok {
+			code, err = region(src, name)
+		} else if r, ok := strings.CutPrefix(item, "L"); ok && r != "" && strings.Trim(r, "0123456789-") == "" {
+			code, err = Lines(src, r)
+		} else {
+			code, err = Decl(src, item)
+		}
+		if err != nil {
+			return "", err
+		}
+		parts = append(parts, code)
+	}
+	return strings.Join(parts, "\n\n"), nil
+}
+
+func region(src []byte, name string) (string, error) {
+	regions, err := Regions(src)
+	if err != nil {
+		return "", err
+	}
+	for _, r := range regions {
+		if r.Name == name {
+			return r.Code, nil
+		}
+	}
+	return "", fmt.Errorf("%w: no region %q", ErrNotFound, name)
+}
+
+// Decl returns the top-level declaration called name, with its doc comment.
+func Decl(src []byte, name string) (string, error) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "", src, parser.ParseComments)
+	if err != nil {
+		return "", err
+	}
+	node := findDecl(file, name)
+	if node == nil {
+		return "", fmt.Errorf("%w: no declaration %q", ErrNotFound, name)
+	}
+	start, end := node.Pos(), node.End()
+	if doc := docOf(node); doc != nil {
+		start = doc.Pos()
+	}
+	return Strip(string(src[fset.Position(start).Offset:fset.Position(end).Offset])), nil
+}
+
+// findDecl returns the top-level declaration called name, a method being
+// type.method.
+func findDecl(file *ast.File, name string) ast.Node {
+	recv, method, isMethod := strings.Cut(name, ".")
+	for _, decl := range file.Decls {
+		switch d := decl.(type) {
+		case *ast.FuncDecl:
+			if !isMethod && d.Recv == nil && d.Name.Name == name {
+				return d
+			}
+			if isMethod && d.Recv != nil && d.Name.Name == method && receiverName(d.Recv.List[0].Type) == recv {
+				return d
+			}
+		case *ast.GenDecl:
+			for _, spec := range d.Specs {
+				if ts, ok := spec.(*ast.TypeSpec); ok && !isMethod && ts.Name.Name == name {
+					if len(d.Specs) == 1 {
+						return d // keep the "type" keyword and the doc comment above it
+					}
+					return ts
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func receiverName(expr ast.Expr) string {
+	if star, ok := expr.(*ast.StarExpr); ok {
+		expr = star.X
+	}
+	if id, ok := expr.(*ast.Ident); ok {
+		return id.Name
+	}
+	return ""
+}
+
+func docOf(node ast.Node) *ast.CommentGroup {
+	switch n := node.(type) {
+	case *ast.FuncDecl:
+		return n.Doc
+	case *ast.GenDecl:
+		return n.Doc
+	case *ast.TypeSpec:
+		return n.Doc
+	}
+	return nil
+}
+
+// Lines returns lines "11-18" of src, or a single line "11", without
+// markers.
+func Lines(src []byte, r string) (string, error) {
+	from, to, ok := strings.Cut(r, "-")
+	if !ok {
+		to = from
+	}
+	first, err1 := strconv.Atoi(from)
+	last, err2 := strconv.Atoi(to)
+	lines := bytes.Split(bytes.TrimSuffix(src, []byte("\n")), []byte("\n"))
+	if err1 != nil || err2 != nil || first < 1 || last < first || last > len(lines) {
+		return "", fmt.Errorf("%w: line range L%s of a %d-line file", ErrNotFound, r, len(lines))
+	}
+	return Strip(string(bytes.Join(lines[first-1:last], []byte("\n")))), nil
+}
+
+// Strip removes marker lines from code.
+func Strip(code string) string {
+	lines := strings.Split(code, "\n")
+	return strings.Join(slices.DeleteFunc(lines, marker.MatchString), "\n")
+}
+
+// Scan returns the regions of every .go file under fsys, and every bad
+// marker it met on the way. Like the go tool, it skips testdata and
+// directories whose names start with "." or "_".
+func Scan(fsys fs.FS) ([]Region, error) {
+	var (
+		all  []Region
+		errs []error
+	)
+	err := fs.WalkDir(fsys, ".", func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() && p != "." && (d.Name() == "testdata" || strings.ContainsAny(d.Name()[:1], "._")) {
+			return fs.SkipDir
+		}
+		if d.IsDir() || path.Ext(p) != ".go" {
+			return nil
+		}
+		src, err := fs.ReadFile(fsys, p)
+		if err != nil {
+			return err
+		}
+		regions, err := Regions(src)
+		if err != nil {
+			for _, err := range err.(interface{ Unwrap() []error }).Unwrap() {
+				errs = append(errs, fmt.Errorf("%s: %w", p, err))
+			}
+		}
+		for _, r := range regions {
+			r.File = p
+			all = append(all, r)
+		}
+		return nil
+	})
+	return all, errors.Join(append(errs, err)...)
+}
+
+// dedent removes the indentation every non-blank line shares.
+func dedent(lines []string) string {
+	prefix := ""
+	first := true
+	for _, l := range lines {
+		if strings.TrimSpace(l) == "" {
+			continue
+		}
+		indent := l[:len(l)-len(strings.TrimLeft(l, " \t"))]
+		if first {
+			prefix, first = indent, false
+			continue
+		}
+		for !strings.HasPrefix(indent, prefix) {
+			prefix = prefix[:len(prefix)-1]
+		}
+	}
+	for i, l := range lines {
+		lines[i] = strings.TrimPrefix(l, prefix)
+	}
+	return strings.Join(lines, "\n")
+}
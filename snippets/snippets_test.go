@@ -0,0 +1,142 @@
+package snippets_test
+
+import (
+	"errors"
+	"os"
+	"slices"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"go-solid/content"
+	"go-solid/snippets"
+)
+
+// src is read from testdata, which Scan skips, so its markers don't show in
+// solid snippets list.
+var src = func() []byte {
+	b, err := os.ReadFile("testdata/employee.go")
+	if err != nil {
+		panic(err)
+	}
+	return b
+}()
+
+func TestRegions(t *testing.T) {
+	regions, err := snippets.Regions(src)
+	if err != nil {
+		t.Fatalf("Regions() error = %v", err)
+	}
+	want := []snippets.Region{
+		{Name: "all", Line: 3, Code: "type employee struct {\n\tname string\n}\n\nfunc (e employee) getSalary() int {\n\treturn 5000\n}"},
+		{Name: "salary", Line: 8, Code: "func (e employee) getSalary() int {\n\treturn 5000\n}"},
+		{Name: "body", Line: 10, Code: "return 5000"},
+	}
+	if !slices.Equal(regions, want) {
+		t.Errorf("Regions() = %q, want %q", regions, want)
+	}
+}
+
+func TestRegions_Overlapping(t *testing.T) {
+	src := "// snippet:begin a\none\n// snippet:begin b\ntwo\n// snippet:end a\nthree\n// snippet:end b\n"
+	regions, err := snippets.Regions([]byte(src))
+	if err != nil {
+		t.Fatalf("Regions() error = %v", err)
+	}
+	if len(regions) != 2 || regions[0].Code != "one\ntwo" || regions[1].Code != "two\nthree" {
+		t.Errorf("Regions() = %q, want a as one-two and b as two-three", regions)
+	}
+}
+
+func TestRegions_BadMarkers(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		want []string // in the error, in order
+	}{
+		{"never ends", "// snippet:begin a\ncode\n", []string{`line 1: "a" never ends`}},
+		{"ends without beginning", "code\n// snippet:end a\n", []string{`line 2: "a" ends without beginning`}},
+		{"begins again", "// snippet:begin a\n// snippet:begin a\n// snippet:end a\n", []string{`line 2: "a" begins again`}},
+		{"used twice", "// snippet:begin a\n// snippet:end a\n// snippet:begin a\n// snippet:end a\n", []string{`line 3: "a" is used twice`}},
+		{"no name", "// snippet:begin\n", []string{"line 1: begin without a name"}},
+		{"every problem", "// snippet:begin b\n// snippet:end a\n// snippet:begin c\n", []string{
+			`line 2: "a" ends without beginning`, `line 1: "b" never ends`, `line 3: "c" never ends`,
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := snippets.Regions([]byte(tt.src))
+			if !errors.Is(err, snippets.ErrMarker) {
+				t.Fatalf("Regions() error = %v, want %v", err, snippets.ErrMarker)
+			}
+			msg := err.Error()
+			for _, want := range tt.want {
+				i := strings.Index(msg, want)
+				if i < 0 {
+					t.Fatalf("Regions() error = %q, want it to report %q, in order", err, want)
+				}
+				msg = msg[i+len(want):]
+			}
+		})
+	}
+}
+
+func TestSelect(t *testing.T) {
+	tests := []struct {
+		selector string
+		want     string
+		wantErr  error
+	}{
+		{"snippet:body", "return 5000", nil},
+		{"employee", "type employee struct {\n\tname string\n}", nil},
+		{"employee.getSalary", "func (e employee) getSalary() int {\n\treturn 5000\n}", nil},
+		{"L4-6", "type employee struct {\n\tname string\n}", nil},
+		{"L11", "\treturn 5000", nil},
+		{"snippet:body,L4", "return 5000\n\ntype employee struct {", nil},
+		{"snippet:missing", "", snippets.ErrNotFound},
+		{"employee.missing", "", snippets.ErrNotFound},
+		{"L15-99", "", snippets.ErrNotFound},
+	}
+	for _, tt := range tests {
+		got, err := snippets.Select(src, tt.selector)
+		if got != tt.want || !errors.Is(err, tt.wantErr) {
+			t.Errorf("Select(%s) = %q, %v, want %q, %v", tt.selector, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestScan(t *testing.T) {
+	fsys := fstest.MapFS{
+		"a/main.go":          {Data: src},
+		"b/main.go":          {Data: []byte("// snippet:begin open\n")},
+		"b/notes.txt":        {Data: []byte("// snippet:begin ignored\n")},
+		"_skip/main.go":      {Data: []byte("// snippet:begin skipped\n")},
+		".hidden/main.go":    {Data: []byte("// snippet:begin skipped\n")},
+		"b/testdata/main.go": {Data: []byte("// snippet:begin skipped\n")},
+	}
+	regions, err := snippets.Scan(fsys)
+	if !errors.Is(err, snippets.ErrMarker) || !strings.Contains(err.Error(), `b/main.go: bad snippet marker on line 1: "open" never ends`) {
+		t.Errorf("Scan() error = %v, want the open marker of b/main.go", err)
+	}
+	if len(regions) != 3 || regions[0].File != "a/main.go" || strings.Contains(err.Error(), "skipped") {
+		t.Errorf("Scan() = %q, want the 3 regions of a/main.go only", regions)
+	}
+}
+
+// TestCourse_NoDrift is solid snippets check over this repository: every
+// marker pairs up and every lesson's excerpts still find their code.
+func TestCourse_NoDrift(t *testing.T) {
+	if _, err := snippets.Scan(os.DirFS("..")); err != nil {
+		t.Errorf("Scan() error = %v", err)
+	}
+	provider := content.Embedded()
+	lessons, err := provider.Lessons()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, l := range lessons {
+		if _, err := content.Expand(provider, l.Name, l.Text); err != nil {
+			t.Errorf("Expand(%s) error = %v", l.Name, err)
+		}
+	}
+}
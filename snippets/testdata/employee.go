@@ -0,0 +1,15 @@
+package main
+
+// snippet:begin all
+type employee struct {
+	name string
+}
+
+// snippet:begin salary
+func (e employee) getSalary() int {
+	// snippet:begin body
+	return 5000
+	// snippet:end body
+}
+// snippet:end salary
+// snippet:end all
@@ -0,0 +1,133 @@
+// Package spec implements the Specification pattern: business rules as small
+// composable values instead of ever-growing query methods.
+//
+// New rules are added by writing a new Specification and combining it with
+// And/Or/Not - existing repositories and rules stay untouched (OCP).
+package spec
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Specification Abstraction - a rule a candidate either satisfies or not
+type Specification[T any] interface {
+	IsSatisfiedBy(candidate T) bool
+}
+
+// SQLer Optional capability - specifications that can render themselves as a
+// WHERE fragment using "?" placeholders
+type SQLer interface {
+	SQL() (string, []any, error)
+}
+
+// ErrNotTranslatable returned when a specification has no SQL form
+var ErrNotTranslatable = errors.New("specification cannot be translated to SQL")
+
+// ToSQL renders s as a WHERE fragment, or returns ErrNotTranslatable.
+func ToSQL[T any](s Specification[T]) (string, []any, error) {
+	sqler, ok := s.(SQLer)
+	if !ok {
+		return "", nil, fmt.Errorf("%T: %w", s, ErrNotTranslatable)
+	}
+	return sqler.SQL()
+}
+
+// Func Adapter so plain predicates can be used as specifications (in memory only)
+type Func[T any] func(candidate T) bool
+
+func (f Func[T]) IsSatisfiedBy(candidate T) bool { return f(candidate) }
+
+type and[T any] []Specification[T]
+
+// And is satisfied when every spec is satisfied.
+func And[T any](specs ...Specification[T]) Specification[T] { return and[T](specs) }
+
+func (a and[T]) IsSatisfiedBy(candidate T) bool {
+	for _, s := range a {
+		if !s.IsSatisfiedBy(candidate) {
+			return false
+		}
+	}
+	return true
+}
+
+func (a and[T]) SQL() (string, []any, error) { return joinSQL(a, " AND ", "1=1") }
+
+type or[T any] []Specification[T]
+
+// Or is satisfied when at least one spec is satisfied.
+func Or[T any](specs ...Specification[T]) Specification[T] { return or[T](specs) }
+
+func (o or[T]) IsSatisfiedBy(candidate T) bool {
+	for _, s := range o {
+		if s.IsSatisfiedBy(candidate) {
+			return true
+		}
+	}
+	return false
+}
+
+func (o or[T]) SQL() (string, []any, error) { return joinSQL(o, " OR ", "1=0") }
+
+type not[T any] struct{ inner Specification[T] }
+
+// Not is satisfied when s is not.
+func Not[T any](s Specification[T]) Specification[T] { return not[T]{s} }
+
+func (n not[T]) IsSatisfiedBy(candidate T) bool { return !n.inner.IsSatisfiedBy(candidate) }
+
+func (n not[T]) SQL() (string, []any, error) {
+	where, args, err := ToSQL(n.inner)
+	if err != nil {
+		return "", nil, err
+	}
+	return "NOT (" + where + ")", args, nil
+}
+
+// Map rebuilds s with f applied to each rule inside it, however deeply
+// combined with And, Or and Not; a rule that is not a combination is passed
+// to f itself. Repositories use it to bind rules to their own settings.
+func Map[T any](s Specification[T], f func(Specification[T]) Specification[T]) Specification[T] {
+	switch s := s.(type) {
+	case and[T]:
+		return and[T](mapAll(s, f))
+	case or[T]:
+		return or[T](mapAll(s, f))
+	case not[T]:
+		return not[T]{Map(s.inner, f)}
+	}
+	return f(s)
+}
+
+func mapAll[T any](specs []Specification[T], f func(Specification[T]) Specification[T]) []Specification[T] {
+	out := make([]Specification[T], len(specs))
+	for i, s := range specs {
+		out[i] = Map(s, f)
+	}
+	return out
+}
+
+// LikePrefix is the argument for a "LIKE ? ESCAPE '!'" fragment matching
+// values that begin with prefix: its LIKE wildcards are escaped with '!'.
+func LikePrefix(prefix string) string {
+	return strings.NewReplacer("!", "!!", "%", "!%", "_", "!_").Replace(prefix) + "%"
+}
+
+func joinSQL[T any](specs []Specification[T], sep, empty string) (string, []any, error) {
+	if len(specs) == 0 {
+		return empty, nil, nil
+	}
+	parts := make([]string, 0, len(specs))
+	var args []any
+	for _, s := range specs {
+		where, a, err := ToSQL(s)
+		if err != nil {
+			return "", nil, err
+		}
+		parts = append(parts, "("+where+")")
+		args = append(args, a...)
+	}
+	return strings.Join(parts, sep), args, nil
+}
@@ -0,0 +1,126 @@
+package spec_test
+
+import (
+	"database/sql"
+	"errors"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"go-solid/spec"
+
+	_ "modernc.org/sqlite"
+)
+
+// over matches numbers greater than n, in memory and in SQL
+type over int
+
+func (o over) IsSatisfiedBy(n int) bool    { return n > int(o) }
+func (o over) SQL() (string, []any, error) { return "n > ?", []any{int(o)}, nil }
+
+// even matches even numbers, in memory and in SQL
+type even struct{}
+
+func (even) IsSatisfiedBy(n int) bool    { return n%2 == 0 }
+func (even) SQL() (string, []any, error) { return "n % 2 = 0", nil, nil }
+
+// numbers is a table of n = 1..10 to run the SQL forms against.
+func numbers(t *testing.T) *sql.DB {
+	t.Helper()
+	db, err := sql.Open("sqlite", filepath.Join(t.TempDir(), "spec.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+	if _, err := db.ExecContext(t.Context(), "CREATE TABLE numbers (n INTEGER)"); err != nil {
+		t.Fatal(err)
+	}
+	for n := 1; n <= 10; n++ {
+		if _, err := db.ExecContext(t.Context(), "INSERT INTO numbers (n) VALUES (?)", n); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return db
+}
+
+func TestToSQL_AgreesWithIsSatisfiedBy(t *testing.T) {
+	db := numbers(t)
+	tests := []struct {
+		name string
+		spec spec.Specification[int]
+		want []int
+	}{
+		{"rule", over(7), []int{8, 9, 10}},
+		{"and", spec.And[int](over(4), even{}), []int{6, 8, 10}},
+		{"or", spec.Or[int](over(8), even{}), []int{2, 4, 6, 8, 9, 10}},
+		{"not", spec.Not[int](even{}), []int{1, 3, 5, 7, 9}},
+		{"empty and", spec.And[int](), []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}},
+		{"empty or", spec.Or[int](), nil},
+		{"not an empty or", spec.Not(spec.Or[int]()), []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}},
+		{"nested", spec.Or(spec.And[int](over(2), spec.Not[int](over(4))), spec.Not(spec.Or[int](even{}, over(1)))), []int{1, 3, 4}},
+	}
+	for _, tt := range tests {
+		var inMemory []int
+		for n := 1; n <= 10; n++ {
+			if tt.spec.IsSatisfiedBy(n) {
+				inMemory = append(inMemory, n)
+			}
+		}
+		where, args, err := spec.ToSQL(tt.spec)
+		if err != nil {
+			t.Fatalf("%s: ToSQL() error = %v", tt.name, err)
+		}
+		rows, err := db.QueryContext(t.Context(), "SELECT n FROM numbers WHERE "+where+" ORDER BY n", args...)
+		if err != nil {
+			t.Fatalf("%s: WHERE %s: %v", tt.name, where, err)
+		}
+		var inSQL []int
+		for rows.Next() {
+			var n int
+			if err := rows.Scan(&n); err != nil {
+				t.Fatal(err)
+			}
+			inSQL = append(inSQL, n)
+		}
+		rows.Close()
+		if !slices.Equal(inMemory, tt.want) || !slices.Equal(inSQL, tt.want) {
+			t.Errorf("%s: IsSatisfiedBy matched %v, WHERE %s matched %v; want %v", tt.name, inMemory, where, inSQL, tt.want)
+		}
+	}
+}
+
+func TestToSQL_NotTranslatable(t *testing.T) {
+	odd := spec.Func[int](func(n int) bool { return n%2 == 1 })
+	for _, s := range []spec.Specification[int]{odd, spec.Not[int](odd), spec.And[int](over(1), odd), spec.Or[int](even{}, spec.Not[int](odd))} {
+		if _, _, err := spec.ToSQL(s); !errors.Is(err, spec.ErrNotTranslatable) {
+			t.Errorf("ToSQL(%T) error = %v, want %v", s, err, spec.ErrNotTranslatable)
+		}
+	}
+}
+
+func TestMap(t *testing.T) {
+	// raise every threshold by 5, leaving the combinations as they were
+	raised := spec.Map(spec.Or(spec.And[int](over(1), even{}), spec.Not[int](over(3))), func(s spec.Specification[int]) spec.Specification[int] {
+		if o, ok := s.(over); ok {
+			return o + 5
+		}
+		return s
+	})
+	var got []int
+	for n := 1; n <= 10; n++ {
+		if raised.IsSatisfiedBy(n) {
+			got = append(got, n)
+		}
+	}
+	if want := []int{1, 2, 3, 4, 5, 6, 7, 8, 10}; !slices.Equal(got, want) {
+		t.Errorf("mapped spec matched %v, want %v", got, want)
+	}
+}
+
+func TestLikePrefix(t *testing.T) {
+	for prefix, want := range map[string]string{"am": "am%", "a_": "a!_%", "100%": "100!%%", "hi!": "hi!!%", "": "%"} {
+		if got := spec.LikePrefix(prefix); got != want {
+			t.Errorf("LikePrefix(%q) = %q, want %q", prefix, got, want)
+		}
+	}
+}
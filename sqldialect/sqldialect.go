@@ -0,0 +1,47 @@
+// Package sqldialect hides the small syntax differences between SQL databases
+// so adapters can be written once against database/sql.
+package sqldialect
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Dialect Abstraction over database-specific SQL syntax
+type Dialect interface {
+	Name() string
+	// Rebind rewrites a query written with "?" placeholders into the dialect's style.
+	Rebind(query string) string
+}
+
+// MySQL uses "?" placeholders, so queries pass through unchanged
+type MySQL struct{}
+
+func (MySQL) Name() string               { return "mysql" }
+func (MySQL) Rebind(query string) string { return query }
+
+// SQLite shares MySQL's placeholder style
+type SQLite struct{}
+
+func (SQLite) Name() string               { return "sqlite" }
+func (SQLite) Rebind(query string) string { return query }
+
+// Postgres uses numbered "$1, $2, ..." placeholders
+type Postgres struct{}
+
+func (Postgres) Name() string { return "postgres" }
+
+func (Postgres) Rebind(query string) string {
+	var b strings.Builder
+	n := 0
+	for _, r := range query {
+		if r == '?' {
+			n++
+			b.WriteByte('$')
+			b.WriteString(strconv.Itoa(n))
+			continue
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
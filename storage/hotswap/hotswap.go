@@ -0,0 +1,159 @@
+// Package hotswap lets a running application switch storage backends.
+//
+// Factory is itself a storage.RepositoryFactory (a decorator), so the rest of
+// the application never notices the switch: it keeps calling the same
+// repositories, and each call is routed to whichever backend is active at
+// that moment. The active backend sits behind an atomic pointer; a swap waits
+// for in-flight calls on the old backend to finish (connection draining)
+// before closing it.
+package hotswap
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+
+	"go-solid/audit"
+	"go-solid/employee"
+	"go-solid/leave"
+	"go-solid/storage"
+)
+
+// generation One backend plus the bookkeeping needed to drain it
+type generation struct {
+	name    string
+	factory storage.RepositoryFactory
+
+	mu       sync.Mutex
+	inflight int
+	draining bool
+	idle     chan struct{}
+}
+
+func (g *generation) tryAcquire() bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.draining {
+		return false
+	}
+	g.inflight++
+	return true
+}
+
+func (g *generation) release() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.inflight--
+	if g.draining && g.inflight == 0 {
+		close(g.idle)
+	}
+}
+
+// drain stops new calls and waits for running ones, or until ctx is done.
+func (g *generation) drain(ctx context.Context) error {
+	g.mu.Lock()
+	if !g.draining {
+		g.draining = true
+		if g.inflight == 0 {
+			close(g.idle)
+		}
+	}
+	g.mu.Unlock()
+
+	select {
+	case <-g.idle:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// ErrClosed returned for calls made, and swaps asked for, after Close
+var ErrClosed = errors.New("hotswap: factory closed")
+
+// Factory Decorator - a RepositoryFactory whose backend can be replaced at runtime
+type Factory struct {
+	current atomic.Pointer[generation]
+	swapMu  sync.Mutex
+	closed  atomic.Bool
+}
+
+// New starts with the given backend; name is only used for reporting.
+func New(name string, initial storage.RepositoryFactory) *Factory {
+	f := &Factory{}
+	f.current.Store(&generation{name: name, factory: initial, idle: make(chan struct{})})
+	return f
+}
+
+// Active returns the name of the backend currently serving calls.
+func (f *Factory) Active() string { return f.current.Load().name }
+
+// Wrapped returns the active backend.
+func (f *Factory) Wrapped() any { return f.current.Load().factory }
+
+// Swap routes all new calls to next, then drains and closes the previous
+// backend. If ctx expires before the old backend is idle it is closed anyway
+// and the context error is returned. After Close it returns ErrClosed and
+// leaves next alone.
+func (f *Factory) Swap(ctx context.Context, name string, next storage.RepositoryFactory) error {
+	f.swapMu.Lock()
+	defer f.swapMu.Unlock()
+	if f.closed.Load() {
+		return ErrClosed
+	}
+
+	old := f.current.Swap(&generation{name: name, factory: next, idle: make(chan struct{})})
+	drainErr := old.drain(ctx)
+	return errors.Join(drainErr, old.factory.Close())
+}
+
+// Close drains and closes the active backend. Calls made after it fail with
+// ErrClosed; closing again does nothing.
+func (f *Factory) Close() error {
+	f.swapMu.Lock()
+	defer f.swapMu.Unlock()
+	if f.closed.Swap(true) {
+		return nil
+	}
+	g := f.current.Load()
+	return errors.Join(g.drain(context.Background()), g.factory.Close())
+}
+
+// CheckHealth forwards to the active backend if it can be checked. The
+// interface is declared inline to keep storage free of a health dependency.
+func (f *Factory) CheckHealth(ctx context.Context) error {
+	g, err := f.acquire()
+	if err != nil {
+		return err
+	}
+	defer g.release()
+	if c, ok := g.factory.(interface{ CheckHealth(context.Context) error }); ok {
+		if err := c.CheckHealth(ctx); err != nil {
+			return fmt.Errorf("%s backend: %w", g.name, err)
+		}
+	}
+	return nil
+}
+
+// acquire pins the active generation for the duration of one call, or
+// fails with ErrClosed once Close has begun.
+func (f *Factory) acquire() (*generation, error) {
+	for {
+		// checked first: the drained generation stays published after Close
+		if f.closed.Load() {
+			return nil, ErrClosed
+		}
+		if g := f.current.Load(); g.tryAcquire() {
+			return g, nil
+		}
+		// lost a race with Swap: the new generation is already published, retry
+	}
+}
+
+func (f *Factory) Employees() employee.Repository { return employees{f} }
+func (f *Factory) Leaves() leave.Repository       { return leaves{f} }
+func (f *Factory) Audit() audit.Sink              { return sink{f} }
+
+var _ storage.RepositoryFactory = (*Factory)(nil)
@@ -0,0 +1,124 @@
+package hotswap_test
+
+import (
+	"context"
+	"errors"
+	"iter"
+	"testing"
+	"time"
+
+	"go-solid/audit"
+	"go-solid/chaos"
+	"go-solid/employee"
+	"go-solid/employee/employeetest"
+	"go-solid/employee/memory"
+	"go-solid/money"
+	"go-solid/storage"
+	"go-solid/storage/hotswap"
+)
+
+func TestEmployees(t *testing.T) {
+	employeetest.TestRepository(t, func(*testing.T) employee.Repository {
+		return hotswap.New("memory", storage.NewMemory()).Employees()
+	})
+}
+
+// TestEmployees_ConflictUnderChaos wires the Manager as employee-api does -
+// chaos over hotswap over a backend - and has a stale update refused.
+func TestEmployees_ConflictUnderChaos(t *testing.T) {
+	inj, err := chaos.New(chaos.Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	repo := chaos.NewRepository(hotswap.New("memory", storage.NewMemory()).Employees(), inj)
+	m := employee.NewManager(repo, employee.WithConflictRetries(1))
+	if _, err := m.AddEmployee(t.Context(), employee.Employee{Name: "Ali", Title: "Engineer", Salary: money.Of(5000, money.USD)}); err != nil {
+		t.Fatalf("AddEmployee() error = %v", err)
+	}
+	_, err = m.UpdateEmployee(t.Context(), "Ali", func(emp *employee.Employee) error {
+		if _, err := m.ChangeSalary(t.Context(), "Ali", money.Of(5500, money.USD)); err != nil {
+			t.Fatalf("ChangeSalary() error = %v", err)
+		}
+		emp.Title = "Lead"
+		return nil
+	})
+	if !errors.Is(err, employee.ErrConflict) {
+		t.Errorf("UpdateEmployee() error = %v, want %v", err, employee.ErrConflict)
+	}
+	if emp, _ := m.FindEmployee(t.Context(), "Ali"); emp.Title != "Engineer" || emp.Salary != money.Of(5500, money.USD) {
+		t.Errorf("FindEmployee() = %s at %v, want the raise kept and the stale title change dropped", emp.Title, emp.Salary)
+	}
+}
+
+// unlisted A backend that fails to list or stream, so a lookup that falls
+// back to looking through every employee fails with it
+type unlisted struct{ *memory.Repository }
+
+var errListed = errors.New("looked through every employee")
+
+func (unlisted) List(context.Context, employee.Filter, employee.Page) (employee.PageResult, error) {
+	return employee.PageResult{}, errListed
+}
+
+func (unlisted) All(context.Context) iter.Seq2[employee.Employee, error] {
+	return func(yield func(employee.Employee, error) bool) { yield(employee.Employee{}, errListed) }
+}
+
+// TestEmployees_GetByIDThroughChaos looks an employee up by ID through the
+// employee-api stack, which must reach the backend's own GetByID.
+func TestEmployees_GetByIDThroughChaos(t *testing.T) {
+	backend := unlisted{memory.New()}
+	if err := backend.Save(t.Context(), employee.Employee{ID: "emp-1", Name: "Ali"}); err != nil {
+		t.Fatal(err)
+	}
+	inj, err := chaos.New(chaos.Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	repo := chaos.NewRepository(hotswap.New("memory", factory{storage.NewMemory(), backend}).Employees(), inj)
+	if emp, err := employee.GetByID(t.Context(), repo, "emp-1"); err != nil || emp.Name != "Ali" {
+		t.Errorf("GetByID() = %q, %v, want Ali without listing anyone", emp.Name, err)
+	}
+}
+
+// factory The memory backend with employees of the test's choosing
+type factory struct {
+	*storage.Memory
+	employees employee.Repository
+}
+
+func (f factory) Employees() employee.Repository { return f.employees }
+
+func TestFactory_AfterClose(t *testing.T) {
+	f := hotswap.New("memory", storage.NewMemory())
+	repo := f.Employees()
+	if err := repo.Save(t.Context(), employee.Employee{Name: "Ali"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := repo.GetByName(t.Context(), "Ali")
+		done <- err
+	}()
+	select {
+	case err := <-done:
+		if !errors.Is(err, hotswap.ErrClosed) {
+			t.Errorf("GetByName() after Close error = %v, want %v", err, hotswap.ErrClosed)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("GetByName() after Close still hasn't returned after 5s")
+	}
+	if err := f.Audit().Write(t.Context(), audit.Record{}); !errors.Is(err, hotswap.ErrClosed) {
+		t.Errorf("Audit().Write() after Close error = %v, want %v", err, hotswap.ErrClosed)
+	}
+	if err := f.Swap(t.Context(), "memory", storage.NewMemory()); !errors.Is(err, hotswap.ErrClosed) {
+		t.Errorf("Swap() after Close error = %v, want %v", err, hotswap.ErrClosed)
+	}
+	if err := f.Close(); err != nil {
+		t.Errorf("Close() again error = %v, want nil", err)
+	}
+}
@@ -0,0 +1,259 @@
+package hotswap
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"iter"
+
+	"go-solid/audit"
+	"go-solid/employee"
+	"go-solid/leave"
+	"go-solid/outbox"
+	"go-solid/spec"
+)
+
+// employees Proxy routing every call to the active backend. It forwards the
+// optional capabilities too - a decorator that only embedded
+// employee.Repository would silently hide them (see examples/capabilities).
+type employees struct{ f *Factory }
+
+func (p employees) Save(ctx context.Context, emp employee.Employee) error {
+	g, err := p.f.acquire()
+	if err != nil {
+		return err
+	}
+	defer g.release()
+	return g.factory.Employees().Save(ctx, emp)
+}
+
+func (p employees) GetByName(ctx context.Context, name string) (employee.Employee, error) {
+	g, err := p.f.acquire()
+	if err != nil {
+		return employee.Employee{}, err
+	}
+	defer g.release()
+	return g.factory.Employees().GetByName(ctx, name)
+}
+
+func (p employees) GetByID(ctx context.Context, id employee.ID) (employee.Employee, error) {
+	g, err := p.f.acquire()
+	if err != nil {
+		return employee.Employee{}, err
+	}
+	defer g.release()
+	return employee.GetByID(ctx, g.factory.Employees(), id)
+}
+
+// All pins one backend while the loop runs: a swap waits for it, as it would
+// for a cursor still open on the old database.
+func (p employees) All(ctx context.Context) iter.Seq2[employee.Employee, error] {
+	return func(yield func(employee.Employee, error) bool) {
+		g, err := p.f.acquire()
+		if err != nil {
+			yield(employee.Employee{}, err)
+			return
+		}
+		defer g.release()
+		for emp, err := range employee.All(ctx, g.factory.Employees()) {
+			if !yield(emp, err) {
+				return
+			}
+		}
+	}
+}
+
+// SaveAll pins one backend for the whole sequence, so a swap mid-import
+// can't split it across two databases.
+func (p employees) SaveAll(ctx context.Context, emps iter.Seq[employee.Employee]) error {
+	g, err := p.f.acquire()
+	if err != nil {
+		return err
+	}
+	defer g.release()
+	return employee.SaveAll(ctx, g.factory.Employees(), emps)
+}
+
+func (p employees) Update(ctx context.Context, emp employee.Employee, expectedVersion int) (employee.Employee, error) {
+	g, err := p.f.acquire()
+	if err != nil {
+		return employee.Employee{}, err
+	}
+	defer g.release()
+	u, ok := g.factory.Employees().(employee.Updater)
+	if !ok {
+		return employee.Employee{}, unsupported(g)
+	}
+	return u.Update(ctx, emp, expectedVersion)
+}
+
+func (p employees) SoftDelete(ctx context.Context, name string) error {
+	g, err := p.f.acquire()
+	if err != nil {
+		return err
+	}
+	defer g.release()
+	d, ok := g.factory.Employees().(employee.SoftDeleter)
+	if !ok {
+		return unsupported(g)
+	}
+	return d.SoftDelete(ctx, name)
+}
+
+func (p employees) Restore(ctx context.Context, name string) error {
+	g, err := p.f.acquire()
+	if err != nil {
+		return err
+	}
+	defer g.release()
+	d, ok := g.factory.Employees().(employee.SoftDeleter)
+	if !ok {
+		return unsupported(g)
+	}
+	return d.Restore(ctx, name)
+}
+
+func (p employees) History(ctx context.Context, name string) ([]employee.Employee, error) {
+	g, err := p.f.acquire()
+	if err != nil {
+		return nil, err
+	}
+	defer g.release()
+	v, ok := g.factory.Employees().(employee.Versioned)
+	if !ok {
+		return nil, unsupported(g)
+	}
+	return v.History(ctx, name)
+}
+
+func (p employees) List(ctx context.Context, filter employee.Filter, page employee.Page) (employee.PageResult, error) {
+	g, err := p.f.acquire()
+	if err != nil {
+		return employee.PageResult{}, err
+	}
+	defer g.release()
+	q, ok := g.factory.Employees().(employee.QueryRepository)
+	if !ok {
+		return employee.PageResult{}, unsupported(g)
+	}
+	return q.List(ctx, filter, page)
+}
+
+func (p employees) Matching(ctx context.Context, s spec.Specification[employee.Employee]) ([]employee.Employee, error) {
+	g, err := p.f.acquire()
+	if err != nil {
+		return nil, err
+	}
+	defer g.release()
+	m, ok := g.factory.Employees().(employee.SpecificationRepository)
+	if !ok {
+		return nil, unsupported(g)
+	}
+	return m.Matching(ctx, s)
+}
+
+func (p employees) SaveWithOutbox(ctx context.Context, emp employee.Employee, msgs []outbox.Message) error {
+	g, err := p.f.acquire()
+	if err != nil {
+		return err
+	}
+	defer g.release()
+	o, ok := g.factory.Employees().(employee.OutboxRepository)
+	if !ok {
+		return unsupported(g)
+	}
+	return o.SaveWithOutbox(ctx, emp, msgs)
+}
+
+// Pending and MarkPublished read the active backend's outbox. Messages left
+// in a backend that was swapped out stay there until it is relayed directly.
+func (p employees) Pending(ctx context.Context, limit int) ([]outbox.Message, error) {
+	g, err := p.f.acquire()
+	if err != nil {
+		return nil, err
+	}
+	defer g.release()
+	s, ok := g.factory.Employees().(outbox.Store)
+	if !ok {
+		return nil, unsupported(g)
+	}
+	return s.Pending(ctx, limit)
+}
+
+func (p employees) MarkPublished(ctx context.Context, ids ...string) error {
+	g, err := p.f.acquire()
+	if err != nil {
+		return err
+	}
+	defer g.release()
+	s, ok := g.factory.Employees().(outbox.Store)
+	if !ok {
+		return unsupported(g)
+	}
+	return s.MarkPublished(ctx, ids...)
+}
+
+func unsupported(g *generation) error {
+	return fmt.Errorf("%s backend: %w", g.name, errors.ErrUnsupported)
+}
+
+type leaves struct{ f *Factory }
+
+func (p leaves) Save(ctx context.Context, req leave.Request) error {
+	g, err := p.f.acquire()
+	if err != nil {
+		return err
+	}
+	defer g.release()
+	return g.factory.Leaves().Save(ctx, req)
+}
+
+func (p leaves) Get(ctx context.Context, id string) (leave.Request, error) {
+	g, err := p.f.acquire()
+	if err != nil {
+		return leave.Request{}, err
+	}
+	defer g.release()
+	return g.factory.Leaves().Get(ctx, id)
+}
+
+func (p leaves) ListByEmployee(ctx context.Context, name string) ([]leave.Request, error) {
+	g, err := p.f.acquire()
+	if err != nil {
+		return nil, err
+	}
+	defer g.release()
+	return g.factory.Leaves().ListByEmployee(ctx, name)
+}
+
+type sink struct{ f *Factory }
+
+func (p sink) Write(ctx context.Context, rec audit.Record) error {
+	g, err := p.f.acquire()
+	if err != nil {
+		return err
+	}
+	defer g.release()
+	return g.factory.Audit().Write(ctx, rec)
+}
+
+// Wrapped name the backend calls go to right now, for the admin endpoint.
+func (p employees) Wrapped() any { return p.f.current.Load().factory.Employees() }
+func (p leaves) Wrapped() any    { return p.f.current.Load().factory.Leaves() }
+func (p sink) Wrapped() any      { return p.f.current.Load().factory.Audit() }
+
+var (
+	_ employee.Repository              = employees{}
+	_ employee.SoftDeleter             = employees{}
+	_ employee.Updater                 = employees{}
+	_ employee.Iterable                = employees{}
+	_ employee.IDRepository            = employees{}
+	_ employee.Versioned               = employees{}
+	_ employee.QueryRepository         = employees{}
+	_ employee.SpecificationRepository = employees{}
+	_ employee.BulkSaver               = employees{}
+	_ employee.OutboxRepository        = employees{}
+	_ outbox.Store                     = employees{}
+	_ leave.Repository                 = leaves{}
+	_ audit.Sink                       = sink{}
+)
@@ -0,0 +1,41 @@
+//go:build integration
+
+package storage_test
+
+import (
+	"database/sql"
+	"os"
+	"testing"
+
+	_ "github.com/go-sql-driver/mysql"
+	_ "github.com/lib/pq"
+
+	"go-solid/employee"
+	"go-solid/employee/employeetest"
+	"go-solid/testenv"
+)
+
+func TestMain(m *testing.M) { os.Exit(testenv.Main(m)) }
+
+// TestEmployees_Containers runs the employee contract against databases
+// started by testenv, or the ones SOLID_<BACKEND>_DSN points at. They are
+// shared by every case, so each case starts by emptying the table.
+func TestEmployees_Containers(t *testing.T) {
+	for _, s := range []testenv.Service{testenv.MySQL, testenv.Postgres} {
+		t.Run(s.Name, func(t *testing.T) {
+			cfg := testenv.Require(t, s)
+			repos := migrated(t, cfg)
+			db, err := sql.Open(cfg.Backend, cfg.DSN)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer db.Close()
+			employeetest.TestRepository(t, func(t *testing.T) employee.Repository {
+				if _, err := db.ExecContext(t.Context(), "DELETE FROM employees"); err != nil {
+					t.Fatal(err)
+				}
+				return repos.Employees()
+			})
+		})
+	}
+}
@@ -0,0 +1,153 @@
+// Package storage creates whole families of repositories for one backend.
+//
+// Mixing backends by accident (employees in Postgres, leave requests in
+// memory) is a classic wiring bug. A RepositoryFactory hands out a matching
+// set, so switching backend means swapping one factory - an Abstract Factory.
+// Open picks the factory by name from a registry - a Factory Method that new
+// backends extend by calling Register, without editing this package (OCP).
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+
+	"go-solid/audit"
+	"go-solid/employee"
+	employeemem "go-solid/employee/memory"
+	employeesql "go-solid/employee/sqlrepo"
+	"go-solid/leave"
+	leavemem "go-solid/leave/memory"
+	leavesql "go-solid/leave/sqlrepo"
+	"go-solid/sqldialect"
+)
+
+// RepositoryFactory Abstract Factory - produces repositories that belong together
+type RepositoryFactory interface {
+	Employees() employee.Repository
+	Leaves() leave.Repository
+	Audit() audit.Sink
+	Close() error
+}
+
+// Config selects and configures a backend
+type Config struct {
+	Backend string `json:"backend"` // "memory", "mysql", "postgres", "sqlite", or anything registered
+	DSN     string `json:"dsn,omitempty"`
+	// Pool and StatementCache tune the SQL backends; memory ignores them
+	Pool           employeesql.Pool `json:"pool,omitzero"`
+	StatementCache int              `json:"statement_cache,omitempty"` // prepared employee statements kept; 0 prepares none
+}
+
+// Constructor builds a factory from configuration
+type Constructor func(cfg Config) (RepositoryFactory, error)
+
+var (
+	mu       sync.RWMutex
+	backends = map[string]Constructor{}
+)
+
+// Register makes a backend available to Open. It panics on duplicates, like database/sql.Register.
+func Register(name string, ctor Constructor) {
+	mu.Lock()
+	defer mu.Unlock()
+	if _, dup := backends[name]; dup {
+		panic("storage: Register called twice for backend " + name)
+	}
+	backends[name] = ctor
+}
+
+// Backends lists the registered backend names.
+func Backends() []string {
+	mu.RLock()
+	defer mu.RUnlock()
+	names := make([]string, 0, len(backends))
+	for name := range backends {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Open Factory Method - returns the factory registered for cfg.Backend.
+func Open(cfg Config) (RepositoryFactory, error) {
+	mu.RLock()
+	ctor, ok := backends[cfg.Backend]
+	mu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("storage: unknown backend %q (registered: %v)", cfg.Backend, Backends())
+	}
+	return ctor(cfg)
+}
+
+func init() {
+	Register("memory", func(Config) (RepositoryFactory, error) { return NewMemory(), nil })
+	Register("mysql", sqlBackend("mysql", sqldialect.MySQL{}))
+	Register("postgres", sqlBackend("postgres", sqldialect.Postgres{}))
+	Register("sqlite", sqlBackend("sqlite", sqldialect.SQLite{}))
+}
+
+// Memory Concrete factory - everything lives in process memory
+type Memory struct {
+	employees *employeemem.Repository
+	leaves    *leavemem.Repository
+	audit     *audit.Memory
+}
+
+func NewMemory() *Memory {
+	return &Memory{employees: employeemem.New(), leaves: leavemem.New(), audit: &audit.Memory{}}
+}
+
+func (m *Memory) Employees() employee.Repository { return m.employees }
+func (m *Memory) Leaves() leave.Repository       { return m.leaves }
+func (m *Memory) Audit() audit.Sink              { return m.audit }
+func (m *Memory) Close() error                   { return nil }
+
+// SQL Concrete factory - every repository shares one *sql.DB and dialect
+type SQL struct {
+	db        *sql.DB
+	dialect   sqldialect.Dialect
+	employees *employeesql.Repository
+}
+
+// NewSQL creates the factory; opts configure its employee repository, e.g.
+// employeesql.WithStatementCache.
+func NewSQL(db *sql.DB, dialect sqldialect.Dialect, opts ...employeesql.Option) *SQL {
+	return &SQL{db: db, dialect: dialect, employees: employeesql.New(db, dialect, opts...)}
+}
+
+func (s *SQL) Employees() employee.Repository { return s.employees }
+func (s *SQL) Leaves() leave.Repository       { return leavesql.New(s.db, s.dialect) }
+func (s *SQL) Audit() audit.Sink              { return audit.NewSQLSink(s.db, s.dialect, "audit_log") }
+
+// Close releases the employee repository's statements, then the database.
+func (s *SQL) Close() error { return errors.Join(s.employees.Close(), s.db.Close()) }
+
+// CheckHealth pings the shared database (health.Checker). Memory has nothing
+// to check, so it doesn't implement the capability.
+func (s *SQL) CheckHealth(ctx context.Context) error { return s.db.PingContext(ctx) }
+
+// sqlBackend opens a database/sql connection for driver. The driver itself
+// must be linked into the binary (e.g. a blank import), as usual with database/sql.
+func sqlBackend(driver string, dialect sqldialect.Dialect) Constructor {
+	return func(cfg Config) (RepositoryFactory, error) {
+		db, err := sql.Open(driver, cfg.DSN)
+		if err != nil {
+			return nil, fmt.Errorf("storage: open %s: %w", driver, err)
+		}
+		cfg.Pool.Apply(db)
+		var opts []employeesql.Option
+		if cfg.StatementCache > 0 {
+			opts = append(opts, employeesql.WithStatementCache(cfg.StatementCache))
+		}
+		return NewSQL(db, dialect, opts...), nil
+	}
+}
+
+var (
+	_ RepositoryFactory = (*Memory)(nil)
+	_ RepositoryFactory = (*SQL)(nil)
+)
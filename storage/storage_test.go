@@ -0,0 +1,69 @@
+package storage_test
+
+import (
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	_ "modernc.org/sqlite"
+
+	"go-solid/employee"
+	"go-solid/employee/employeetest"
+	"go-solid/migrate"
+	"go-solid/storage"
+)
+
+// migrated brings cfg's schema up to date and opens its backend.
+func migrated(t *testing.T, cfg storage.Config) storage.RepositoryFactory {
+	t.Helper()
+	m, err := migrate.Open(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer m.Close()
+	if _, err := m.Up(t.Context()); err != nil {
+		t.Fatalf("migrate %s: %v", cfg.Backend, err)
+	}
+	repos, err := storage.Open(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = repos.Close() })
+	return repos
+}
+
+// TestEmployees_SQLite needs no container, so it runs without the
+// integration tag: every case gets a new file.
+func TestEmployees_SQLite(t *testing.T) {
+	employeetest.TestRepository(t, func(t *testing.T) employee.Repository {
+		cfg := storage.Config{Backend: "sqlite", DSN: filepath.Join(t.TempDir(), "solid.db")}
+		return migrated(t, cfg).Employees()
+	})
+}
+
+// TestDrivers_OnlyCommandsImportThem keeps the library free of go.mod's
+// requirements: no package outside cmd/ depends on anything beyond the
+// standard library. The commands under cmd/ link the SQL drivers, so the
+// backends they open by name are there to open.
+func TestDrivers_OnlyCommandsImportThem(t *testing.T) {
+	out, err := exec.Command("go", "list", "go-solid/...").Output()
+	if err != nil {
+		t.Fatalf("go list: %v", err)
+	}
+	var library []string
+	for _, path := range strings.Fields(string(out)) {
+		if !strings.HasPrefix(path, "go-solid/cmd/") {
+			library = append(library, path)
+		}
+	}
+	out, err = exec.Command("go", append([]string{"list", "-deps", "-f", "{{if not .Standard}}{{.ImportPath}}{{end}}"}, library...)...).Output()
+	if err != nil {
+		t.Fatalf("go list -deps: %v", err)
+	}
+	for _, path := range strings.Fields(string(out)) {
+		if path != "go-solid" && !strings.HasPrefix(path, "go-solid/") {
+			t.Errorf("%s is imported by a package outside cmd/, want only the standard library", path)
+		}
+	}
+}
@@ -0,0 +1,136 @@
+// Package stub is the runtime half of the stubs `solid gen stub` writes: a
+// Recorder every generated stub embeds, and assertions on what it recorded.
+//
+// A generated stub has one function field per method. A test sets the fields
+// it cares about and leaves the rest returning zero values - no expectations
+// to declare up front, and nothing to learn beyond plain Go functions:
+//
+//	n := &stubs.NotifierStub{NotifyFunc: func(ctx context.Context, msg notify.Message) error {
+//		return errors.New("smtp down")
+//	}}
+//	// ... exercise the code under test with n ...
+//	stub.AssertCalled(t, &n.Recorder, "Notify", 1)
+package stub
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// Call One recorded invocation
+type Call struct {
+	Method string
+	Args   []any
+}
+
+func (c Call) String() string {
+	args := make([]string, len(c.Args))
+	for i, a := range c.Args {
+		args[i] = fmt.Sprintf("%#v", a)
+	}
+	return c.Method + "(" + strings.Join(args, ", ") + ")"
+}
+
+// Recorder Records calls in order; safe for concurrent use
+type Recorder struct {
+	mu    sync.Mutex
+	calls []Call
+}
+
+// Record is called by the generated methods.
+func (r *Recorder) Record(method string, args ...any) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.calls = append(r.calls, Call{Method: method, Args: args})
+}
+
+// Calls returns the calls to method, or every call when method is empty.
+func (r *Recorder) Calls(method string) []Call {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if method == "" {
+		return slices.Clone(r.calls)
+	}
+	var calls []Call
+	for _, c := range r.calls {
+		if c.Method == method {
+			calls = append(calls, c)
+		}
+	}
+	return calls
+}
+
+func (r *Recorder) Count(method string) int { return len(r.Calls(method)) }
+
+func (r *Recorder) Reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.calls = nil
+}
+
+// AssertCalled fails t unless method was called exactly times times.
+func AssertCalled(t testing.TB, r *Recorder, method string, times int) {
+	t.Helper()
+	if n := r.Count(method); n != times {
+		t.Errorf("%s called %d times, want %d; calls: %v", method, n, times, r.Calls(""))
+	}
+}
+
+func AssertNotCalled(t testing.TB, r *Recorder, method string) {
+	t.Helper()
+	AssertCalled(t, r, method, 0)
+}
+
+// AssertCalledWith fails t unless some call to method had args, compared
+// with reflect.DeepEqual. Pass Any for an argument that doesn't matter,
+// like a context.
+func AssertCalledWith(t testing.TB, r *Recorder, method string, args ...any) {
+	t.Helper()
+	calls := r.Calls(method)
+	for _, c := range calls {
+		if matches(c.Args, args) {
+			return
+		}
+	}
+	want := Call{Method: method, Args: args}
+	t.Errorf("no call %v; calls to %s: %v", want, method, calls)
+}
+
+// AssertOrder fails t unless methods were called in this order; other calls
+// may come in between.
+func AssertOrder(t testing.TB, r *Recorder, methods ...string) {
+	t.Helper()
+	calls := r.Calls("")
+	next := 0
+	for _, c := range calls {
+		if next < len(methods) && c.Method == methods[next] {
+			next++
+		}
+	}
+	if next < len(methods) {
+		t.Errorf("calls %v, want %s in that order", calls, strings.Join(methods, ", "))
+	}
+}
+
+type anything struct{}
+
+func (anything) GoString() string { return "stub.Any" }
+
+// Any matches every argument in AssertCalledWith
+var Any any = anything{}
+
+func matches(got, want []any) bool {
+	if len(got) != len(want) {
+		return false
+	}
+	for i := range got {
+		if want[i] != Any && !reflect.DeepEqual(got[i], want[i]) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,55 @@
+// Package memory is an in-process tasks.Repository.
+package memory
+
+import (
+	"context"
+	"slices"
+	"sync"
+
+	"go-solid/tasks"
+)
+
+// Repository Low-level module - map-backed tasks.Repository
+type Repository struct {
+	mu   sync.RWMutex
+	byID map[string]tasks.Task
+}
+
+func New() *Repository {
+	return &Repository{byID: make(map[string]tasks.Task)}
+}
+
+func (r *Repository) Save(ctx context.Context, task tasks.Task) error {
+	task.Skills = slices.Clone(task.Skills)
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.byID[task.ID] = task
+	return nil
+}
+
+func (r *Repository) Get(ctx context.Context, id string) (tasks.Task, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	task, ok := r.byID[id]
+	if !ok {
+		return tasks.Task{}, tasks.ErrNotFound
+	}
+	task.Skills = slices.Clone(task.Skills)
+	return task, nil
+}
+
+func (r *Repository) ListByAssignee(ctx context.Context, name string) ([]tasks.Task, error) {
+	r.mu.RLock()
+	var list []tasks.Task
+	for _, task := range r.byID {
+		if task.Assignee == name {
+			task.Skills = slices.Clone(task.Skills)
+			list = append(list, task)
+		}
+	}
+	r.mu.RUnlock()
+	slices.SortFunc(list, func(a, b tasks.Task) int { return a.Created.Compare(b.Created) })
+	return list, nil
+}
+
+var _ tasks.Repository = (*Repository)(nil)
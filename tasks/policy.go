@@ -0,0 +1,92 @@
+package tasks
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// AssignmentPolicy Strategy choosing who gets a task - the extension point
+// for new ways of sharing out work
+type AssignmentPolicy interface {
+	// Choose returns the index of the candidate to assign task to.
+	// candidates is never empty and is ordered by name.
+	Choose(task Task, candidates []Candidate) (int, error)
+}
+
+// RoundRobin AssignmentPolicy taking turns within each team: a task goes to
+// the first member, by name, after the one who got the team's last task.
+// Members joining or leaving don't reset the turn.
+type RoundRobin struct {
+	mu   sync.Mutex
+	last map[string]string // team -> name
+}
+
+func (r *RoundRobin) Choose(task Task, candidates []Candidate) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.last == nil {
+		r.last = map[string]string{}
+	}
+	i := 0
+	for j, c := range candidates {
+		if c.Name > r.last[task.Team] {
+			i = j
+			break
+		}
+	}
+	r.last[task.Team] = candidates[i].Name
+	return i, nil
+}
+
+// LeastLoaded AssignmentPolicy giving the task to the candidate with the
+// fewest active tasks, the first by name on a tie
+type LeastLoaded struct{}
+
+func (LeastLoaded) Choose(_ Task, candidates []Candidate) (int, error) {
+	best := 0
+	for i, c := range candidates {
+		if c.Load < candidates[best].Load {
+			best = i
+		}
+	}
+	return best, nil
+}
+
+// SkillBased AssignmentPolicy decorator keeping only the candidates with
+// every skill the task needs, and letting Then choose among them -
+// LeastLoaded when nil
+type SkillBased struct {
+	Then AssignmentPolicy
+}
+
+func (s SkillBased) Choose(task Task, candidates []Candidate) (int, error) {
+	var (
+		skilled []Candidate
+		index   []int // of each skilled candidate in candidates
+	)
+	for i, c := range candidates {
+		if c.Has(task.Skills...) {
+			skilled = append(skilled, c)
+			index = append(index, i)
+		}
+	}
+	if len(skilled) == 0 {
+		return 0, fmt.Errorf("nobody has %s: %w", strings.Join(task.Skills, ", "), ErrNoCandidate)
+	}
+	then := s.Then
+	if then == nil {
+		then = LeastLoaded{}
+	}
+	i, err := then.Choose(task, skilled)
+	if err != nil {
+		return 0, err
+	}
+	return index[i], nil
+}
+
+var (
+	_ AssignmentPolicy = (*RoundRobin)(nil)
+	_ AssignmentPolicy = LeastLoaded{}
+	_ AssignmentPolicy = SkillBased{}
+)
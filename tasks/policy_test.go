@@ -0,0 +1,111 @@
+package tasks_test
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"go-solid/tasks"
+)
+
+// team Candidates by name, as the Service hands them to a policy
+var team = []tasks.Candidate{
+	{Name: "Dan", Skills: []string{"go", "sql"}, Load: 2},
+	{Name: "Erin", Skills: []string{"go", "kubernetes"}, Load: 1},
+	{Name: "Ivan", Skills: []string{"sql"}, Load: 1},
+}
+
+// choices runs p over tasks and returns who got each.
+func choices(t *testing.T, p tasks.AssignmentPolicy, candidates []tasks.Candidate, ts ...tasks.Task) []string {
+	t.Helper()
+	var names []string
+	for _, task := range ts {
+		i, err := p.Choose(task, candidates)
+		if err != nil {
+			t.Fatalf("Choose(%+v) error = %v", task, err)
+		}
+		names = append(names, candidates[i].Name)
+	}
+	return names
+}
+
+func TestRoundRobin(t *testing.T) {
+	p := &tasks.RoundRobin{}
+	platform, data := tasks.Task{Team: "platform"}, tasks.Task{Team: "data"}
+	if got := choices(t, p, team, platform, platform, platform, platform); !slices.Equal(got, []string{"Dan", "Erin", "Ivan", "Dan"}) {
+		t.Errorf("turns = %v, want Dan, Erin, Ivan, then Dan again", got)
+	}
+	if got := choices(t, p, team, data); !slices.Equal(got, []string{"Dan"}) {
+		t.Errorf("another team's first task went to %v, want Dan: each team takes its own turns", got)
+	}
+	// Dan got platform's last task; Erin leaves, and the turn carries on
+	without := []tasks.Candidate{team[0], team[2]}
+	if got := choices(t, p, without, platform, platform); !slices.Equal(got, []string{"Ivan", "Dan"}) {
+		t.Errorf("turns after Erin left = %v, want Ivan then Dan", got)
+	}
+	joined := append([]tasks.Candidate{{Name: "Amy"}}, team...)
+	if got := choices(t, p, joined, platform); !slices.Equal(got, []string{"Erin"}) {
+		t.Errorf("after Amy joined the turn went to %v, want Erin, next after Dan", got)
+	}
+}
+
+func TestLeastLoaded(t *testing.T) {
+	tests := []struct {
+		name       string
+		candidates []tasks.Candidate
+		want       string
+	}{
+		{"fewest active tasks", team, "Erin"},
+		{"first by name on a tie", []tasks.Candidate{{Name: "Dan", Load: 1}, {Name: "Erin", Load: 1}}, "Dan"},
+		{"one candidate", []tasks.Candidate{{Name: "Ivan", Load: 9}}, "Ivan"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := choices(t, tasks.LeastLoaded{}, tt.candidates, tasks.Task{}); got[0] != tt.want {
+				t.Errorf("Choose() = %s, want %s", got[0], tt.want)
+			}
+		})
+	}
+}
+
+// last AssignmentPolicy choosing the last candidate, to see what SkillBased
+// hands on
+type last struct{ seen []tasks.Candidate }
+
+func (l *last) Choose(_ tasks.Task, candidates []tasks.Candidate) (int, error) {
+	l.seen = candidates
+	return len(candidates) - 1, nil
+}
+
+func TestSkillBased(t *testing.T) {
+	tests := []struct {
+		name   string
+		skills []string
+		want   string
+	}{
+		{"the only one with the skill", []string{"kubernetes"}, "Erin"},
+		{"every skill needed", []string{"go", "sql"}, "Dan"},
+		{"the least loaded of the skilled", []string{"sql"}, "Ivan"},
+		{"no skill needed", nil, "Erin"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := choices(t, tasks.SkillBased{}, team, tasks.Task{Skills: tt.skills}); got[0] != tt.want {
+				t.Errorf("Choose() = %s, want %s", got[0], tt.want)
+			}
+		})
+	}
+
+	if _, err := (tasks.SkillBased{}).Choose(tasks.Task{Skills: []string{"rust"}}, team); !errors.Is(err, tasks.ErrNoCandidate) {
+		t.Errorf("Choose() with nobody skilled error = %v, want %v", err, tasks.ErrNoCandidate)
+	}
+
+	then := &last{}
+	i, err := tasks.SkillBased{Then: then}.Choose(tasks.Task{Skills: []string{"go"}}, team)
+	if err != nil || team[i].Name != "Erin" {
+		t.Errorf("Choose() = %d, %v; want Erin's index in the full list", i, err)
+	}
+	if len(then.seen) != 2 || then.seen[0].Name != "Dan" || then.seen[1].Name != "Erin" {
+		t.Errorf("Then chose among %v, want Dan and Erin only", then.seen)
+	}
+}
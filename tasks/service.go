@@ -0,0 +1,202 @@
+package tasks
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+
+	"go-solid/clock"
+	"go-solid/id"
+	"go-solid/org"
+)
+
+// Roster Where a team's members come from
+type Roster interface {
+	Members(ctx context.Context, team string) ([]string, error)
+}
+
+// SkillDirectory Where a member's skills come from
+type SkillDirectory interface {
+	Skills(ctx context.Context, name string) ([]string, error)
+}
+
+// SkillMap SkillDirectory held in memory, by name
+type SkillMap map[string][]string
+
+func (m SkillMap) Skills(_ context.Context, name string) ([]string, error) { return m[name], nil }
+
+// OrgRoster Roster of the teams in an org.Directory. A team's manager
+// hands out its tasks and isn't a candidate for them.
+type OrgRoster struct {
+	Directory *org.Directory
+}
+
+func (r OrgRoster) Members(ctx context.Context, team string) ([]string, error) {
+	chart, err := r.Directory.Chart(ctx)
+	if err != nil {
+		return nil, err
+	}
+	t, ok := chart.Team(team)
+	if !ok {
+		return nil, fmt.Errorf("team %s: %w", team, org.ErrTeamNotFound)
+	}
+	return t.Members, nil
+}
+
+// Service Task use cases: creating tasks, assigning them by policy or by
+// hand, and moving them along
+type Service struct {
+	repo   Repository
+	roster Roster
+	skills SkillDirectory
+	policy AssignmentPolicy
+	ids    id.Generator
+	clock  clock.Clock
+	mu     sync.Mutex // one assignment at a time, so loads are current
+}
+
+// Option customises a Service created by NewService
+type Option func(*Service)
+
+// WithPolicy assigns tasks with p; LeastLoaded by default.
+func WithPolicy(p AssignmentPolicy) Option { return func(s *Service) { s.policy = p } }
+
+// WithSkills looks members' skills up in d. Without it nobody has any, so
+// only tasks needing none can be assigned by SkillBased.
+func WithSkills(d SkillDirectory) Option { return func(s *Service) { s.skills = d } }
+
+func WithIDs(g id.Generator) Option  { return func(s *Service) { s.ids = g } }
+func WithClock(c clock.Clock) Option { return func(s *Service) { s.clock = c } }
+
+func NewService(repo Repository, roster Roster, opts ...Option) *Service {
+	s := &Service{
+		repo:   repo,
+		roster: roster,
+		skills: SkillMap(nil),
+		policy: LeastLoaded{},
+		ids:    id.UUID{},
+		clock:  clock.Real{},
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// Create adds an open task to the team, for someone with skills.
+func (s *Service) Create(ctx context.Context, title, team string, skills ...string) (Task, error) {
+	t := Task{ID: s.ids.NewID(), Title: title, Team: team, Skills: skills, Status: Open, Created: s.clock.Now()}
+	if _, err := s.roster.Members(ctx, team); err != nil {
+		return Task{}, fmt.Errorf("create task %q: %w", title, err)
+	}
+	if err := s.repo.Save(ctx, t); err != nil {
+		return Task{}, fmt.Errorf("create task %q: %w", title, err)
+	}
+	return t, nil
+}
+
+// Candidates returns the team's members with their skills and load, by
+// name.
+func (s *Service) Candidates(ctx context.Context, team string) ([]Candidate, error) {
+	names, err := s.roster.Members(ctx, team)
+	if err != nil {
+		return nil, err
+	}
+	names = slices.Sorted(slices.Values(names))
+	candidates := make([]Candidate, 0, len(names))
+	for _, name := range names {
+		skills, err := s.skills.Skills(ctx, name)
+		if err != nil {
+			return nil, err
+		}
+		assigned, err := s.repo.ListByAssignee(ctx, name)
+		if err != nil {
+			return nil, err
+		}
+		load := 0
+		for _, t := range assigned {
+			if t.Active() {
+				load++
+			}
+		}
+		candidates = append(candidates, Candidate{Name: name, Skills: skills, Load: load})
+	}
+	return candidates, nil
+}
+
+// Assign gives the task to the member of its team the policy chooses.
+func (s *Service) Assign(ctx context.Context, id string) (Task, error) {
+	return s.assign(ctx, id, func(t Task, candidates []Candidate) (int, error) {
+		if len(candidates) == 0 {
+			return 0, fmt.Errorf("team %s has no members: %w", t.Team, ErrNoCandidate)
+		}
+		i, err := s.policy.Choose(t, candidates)
+		if err == nil && (i < 0 || i >= len(candidates)) {
+			err = fmt.Errorf("policy chose candidate %d of %d", i, len(candidates))
+		}
+		return i, err
+	})
+}
+
+// AssignTo gives the task to name, who must be on its team. Skills are not
+// checked: whoever assigns by hand knows better.
+func (s *Service) AssignTo(ctx context.Context, id, name string) (Task, error) {
+	return s.assign(ctx, id, func(_ Task, candidates []Candidate) (int, error) {
+		i := slices.IndexFunc(candidates, func(c Candidate) bool { return c.Name == name })
+		if i < 0 {
+			return 0, fmt.Errorf("%s: %w", name, ErrNotOnTeam)
+		}
+		return i, nil
+	})
+}
+
+func (s *Service) assign(ctx context.Context, id string, choose func(Task, []Candidate) (int, error)) (Task, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	t, err := s.repo.Get(ctx, id)
+	if err != nil {
+		return Task{}, fmt.Errorf("assign task %s: %w", id, err)
+	}
+	candidates, err := s.Candidates(ctx, t.Team)
+	if err != nil {
+		return Task{}, fmt.Errorf("assign task %s: %w", id, err)
+	}
+	i, err := choose(t, candidates)
+	if err != nil {
+		return Task{}, fmt.Errorf("assign task %s: %w", id, err)
+	}
+	if err := t.Assign(candidates[i].Name); err != nil {
+		return Task{}, fmt.Errorf("assign task %s: %w", id, err)
+	}
+	if err := s.repo.Save(ctx, t); err != nil {
+		return Task{}, fmt.Errorf("assign task %s: %w", id, err)
+	}
+	return t, nil
+}
+
+// Start marks the task as being worked on.
+func (s *Service) Start(ctx context.Context, id string) (Task, error) {
+	return s.change(ctx, id, "start", (*Task).Start)
+}
+
+// Complete marks the task as done, which takes it off its assignee's load.
+func (s *Service) Complete(ctx context.Context, id string) (Task, error) {
+	return s.change(ctx, id, "complete", (*Task).Complete)
+}
+
+func (s *Service) change(ctx context.Context, id, verb string, change func(*Task) error) (Task, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	t, err := s.repo.Get(ctx, id)
+	if err == nil {
+		err = change(&t)
+	}
+	if err == nil {
+		err = s.repo.Save(ctx, t)
+	}
+	if err != nil {
+		return Task{}, fmt.Errorf("%s task %s: %w", verb, id, err)
+	}
+	return t, nil
+}
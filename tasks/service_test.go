@@ -0,0 +1,154 @@
+package tasks_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"go-solid/clock"
+	"go-solid/id"
+	"go-solid/tasks"
+	"go-solid/tasks/memory"
+)
+
+// roster Roster of fixed teams
+type roster map[string][]string
+
+var errNoTeam = errors.New("no such team")
+
+func (r roster) Members(_ context.Context, team string) ([]string, error) {
+	members, ok := r[team]
+	if !ok {
+		return nil, errNoTeam
+	}
+	return members, nil
+}
+
+var skills = tasks.SkillMap{"Dan": {"go", "sql"}, "Erin": {"go", "kubernetes"}, "Ivan": {"sql"}}
+
+func service(p tasks.AssignmentPolicy) *tasks.Service {
+	teams := roster{"platform": {"Ivan", "Dan", "Erin"}, "empty": nil}
+	return tasks.NewService(memory.New(), teams, tasks.WithPolicy(p), tasks.WithSkills(skills),
+		tasks.WithIDs(id.NewSequence("task-")), tasks.WithClock(clock.NewFake(time.Date(2026, time.March, 2, 9, 0, 0, 0, time.UTC))))
+}
+
+// assign creates a task needing skills in team and assigns it by policy.
+func assign(t *testing.T, svc *tasks.Service, team string, skills ...string) (tasks.Task, error) {
+	t.Helper()
+	task, err := svc.Create(t.Context(), "work", team, skills...)
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	return svc.Assign(t.Context(), task.ID)
+}
+
+func TestService_AssignByPolicy(t *testing.T) {
+	tests := []struct {
+		name   string
+		policy tasks.AssignmentPolicy
+		skills [][]string
+		want   []string
+	}{
+		{"round robin", &tasks.RoundRobin{}, [][]string{nil, nil, nil, nil}, []string{"Dan", "Erin", "Ivan", "Dan"}},
+		{"least loaded evens out", tasks.LeastLoaded{}, [][]string{nil, nil, nil, nil}, []string{"Dan", "Erin", "Ivan", "Dan"}},
+		{"skill based", tasks.SkillBased{}, [][]string{{"kubernetes"}, {"go", "sql"}, {"sql"}, {"go"}}, []string{"Erin", "Dan", "Ivan", "Dan"}},
+		{"skill based then round robin", tasks.SkillBased{Then: &tasks.RoundRobin{}}, [][]string{{"sql"}, {"sql"}, {"sql"}}, []string{"Dan", "Ivan", "Dan"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := service(tt.policy)
+			for i, skills := range tt.skills {
+				task, err := assign(t, svc, "platform", skills...)
+				if err != nil || task.Assignee != tt.want[i] || task.Status != tasks.Assigned {
+					t.Errorf("Assign() #%d = %s %s, %v; want assigned to %s", i+1, task.Assignee, task.Status, err, tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestService_Candidates(t *testing.T) {
+	svc := service(tasks.LeastLoaded{})
+	done, _ := assign(t, svc, "platform")
+	if _, err := svc.Start(t.Context(), done.ID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := svc.Complete(t.Context(), done.ID); err != nil {
+		t.Fatal(err)
+	}
+	assign(t, svc, "platform") // Dan's done task no longer counts, so Dan gets this one
+	candidates, err := svc.Candidates(t.Context(), "platform")
+	if err != nil {
+		t.Fatalf("Candidates() error = %v", err)
+	}
+	want := []tasks.Candidate{{Name: "Dan", Load: 1}, {Name: "Erin", Load: 0}, {Name: "Ivan", Load: 0}}
+	for i, c := range candidates {
+		if c.Name != want[i].Name || c.Load != want[i].Load || !c.Has(skills[c.Name]...) {
+			t.Errorf("Candidates()[%d] = %+v, want %s with load %d and their skills", i, c, want[i].Name, want[i].Load)
+		}
+	}
+}
+
+type broken struct{ choice int }
+
+func (b broken) Choose(tasks.Task, []tasks.Candidate) (int, error) { return b.choice, nil }
+
+func TestService_AssignFails(t *testing.T) {
+	tests := []struct {
+		name    string
+		policy  tasks.AssignmentPolicy
+		team    string
+		skills  []string
+		wantErr error
+	}{
+		{"nobody on the team", tasks.LeastLoaded{}, "empty", nil, tasks.ErrNoCandidate},
+		{"nobody with the skill", tasks.SkillBased{}, "platform", []string{"rust"}, tasks.ErrNoCandidate},
+		{"a policy choosing nobody", broken{choice: 3}, "platform", nil, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			task, err := assign(t, service(tt.policy), tt.team, tt.skills...)
+			if err == nil || tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Errorf("Assign() = %+v, %v; want error %v", task, err, tt.wantErr)
+			}
+		})
+	}
+	if _, err := service(tasks.LeastLoaded{}).Create(t.Context(), "work", "marketing"); !errors.Is(err, errNoTeam) {
+		t.Errorf("Create() for a missing team error = %v, want %v", err, errNoTeam)
+	}
+}
+
+func TestService_Lifecycle(t *testing.T) {
+	ctx := t.Context()
+	svc := service(tasks.LeastLoaded{})
+	task, _ := svc.Create(ctx, "Rotate the certificates", "platform", "kubernetes")
+	if _, err := svc.AssignTo(ctx, task.ID, "Grace"); !errors.Is(err, tasks.ErrNotOnTeam) {
+		t.Errorf("AssignTo(Grace) error = %v, want %v", err, tasks.ErrNotOnTeam)
+	}
+	if _, err := svc.Start(ctx, task.ID); !errors.Is(err, tasks.ErrTransition) {
+		t.Errorf("Start() before assigning error = %v, want %v", err, tasks.ErrTransition)
+	}
+	if _, err := svc.AssignTo(ctx, task.ID, "Erin"); err != nil {
+		t.Fatal(err)
+	}
+	task, err := svc.AssignTo(ctx, task.ID, "Ivan") // skills aren't checked by hand
+	if err != nil || task.Assignee != "Ivan" {
+		t.Fatalf("AssignTo(Ivan) = %s, %v; want the task to change hands", task.Assignee, err)
+	}
+	if _, err := svc.Start(ctx, task.ID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := svc.AssignTo(ctx, task.ID, "Erin"); !errors.Is(err, tasks.ErrTransition) {
+		t.Errorf("AssignTo() once started error = %v, want %v", err, tasks.ErrTransition)
+	}
+	if task, err = svc.Complete(ctx, task.ID); err != nil || task.Status != tasks.Done {
+		t.Fatalf("Complete() = %s, %v; want done", task.Status, err)
+	}
+	if _, err := svc.Complete(ctx, task.ID); !errors.Is(err, tasks.ErrTransition) {
+		t.Errorf("Complete() again error = %v, want %v", err, tasks.ErrTransition)
+	}
+	if _, err := svc.Start(ctx, "task-99"); !errors.Is(err, tasks.ErrNotFound) {
+		t.Errorf("Start() of a missing task error = %v, want %v", err, tasks.ErrNotFound)
+	}
+}
@@ -0,0 +1,113 @@
+// Package sqlrepo is a tasks.Repository on top of database/sql.
+package sqlrepo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"go-solid/sqldialect"
+	"go-solid/tasks"
+)
+
+// Schema Table layout expected by the repository. Skills are stored
+// comma-separated.
+const Schema = `CREATE TABLE tasks (
+    id         VARCHAR(64)  NOT NULL PRIMARY KEY,
+    title      VARCHAR(255) NOT NULL,
+    team       VARCHAR(64)  NOT NULL,
+    skills     VARCHAR(255) NOT NULL,
+    assignee   VARCHAR(255) NOT NULL,
+    status     VARCHAR(16)  NOT NULL,
+    created_at TIMESTAMP    NOT NULL
+)`
+
+// Repository Low-level module - SQL-backed tasks.Repository
+type Repository struct {
+	db      *sql.DB
+	dialect sqldialect.Dialect
+}
+
+func New(db *sql.DB, dialect sqldialect.Dialect) *Repository {
+	return &Repository{db: db, dialect: dialect}
+}
+
+const columns = `id, title, team, skills, assignee, status, created_at`
+
+func (r *Repository) Save(ctx context.Context, task tasks.Task) error {
+	skills := strings.Join(task.Skills, ",")
+	res, err := r.db.ExecContext(ctx, r.dialect.Rebind(`UPDATE tasks
+		SET title = ?, team = ?, skills = ?, assignee = ?, status = ?, created_at = ? WHERE id = ?`),
+		task.Title, task.Team, skills, task.Assignee, string(task.Status), task.Created, task.ID)
+	if err != nil {
+		return fmt.Errorf("sqlrepo: update task %q: %w", task.ID, err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("sqlrepo: update task %q: %w", task.ID, err)
+	}
+	if n > 0 {
+		return nil
+	}
+	_, err = r.db.ExecContext(ctx, r.dialect.Rebind(`INSERT INTO tasks (`+columns+`)
+		VALUES (?, ?, ?, ?, ?, ?, ?)`),
+		task.ID, task.Title, task.Team, skills, task.Assignee, string(task.Status), task.Created)
+	if err != nil {
+		return fmt.Errorf("sqlrepo: insert task %q: %w", task.ID, err)
+	}
+	return nil
+}
+
+func (r *Repository) Get(ctx context.Context, id string) (tasks.Task, error) {
+	task, err := scan(r.db.QueryRowContext(ctx, r.dialect.Rebind(`SELECT `+columns+` FROM tasks WHERE id = ?`), id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return tasks.Task{}, tasks.ErrNotFound
+	}
+	if err != nil {
+		return tasks.Task{}, fmt.Errorf("sqlrepo: get task %q: %w", id, err)
+	}
+	return task, nil
+}
+
+func (r *Repository) ListByAssignee(ctx context.Context, name string) ([]tasks.Task, error) {
+	rows, err := r.db.QueryContext(ctx, r.dialect.Rebind(`SELECT `+columns+`
+		FROM tasks WHERE assignee = ? ORDER BY created_at`), name)
+	if err != nil {
+		return nil, fmt.Errorf("sqlrepo: list tasks of %q: %w", name, err)
+	}
+	defer rows.Close()
+
+	var list []tasks.Task
+	for rows.Next() {
+		task, err := scan(rows)
+		if err != nil {
+			return nil, fmt.Errorf("sqlrepo: list tasks of %q: %w", name, err)
+		}
+		list = append(list, task)
+	}
+	return list, rows.Err()
+}
+
+func scan(row interface{ Scan(dest ...any) error }) (tasks.Task, error) {
+	var (
+		task           tasks.Task
+		skills, status string
+	)
+	if err := row.Scan(&task.ID, &task.Title, &task.Team, &skills, &task.Assignee, &status, &task.Created); err != nil {
+		return tasks.Task{}, err
+	}
+	if skills != "" {
+		task.Skills = strings.Split(skills, ",")
+	}
+	task.Status = tasks.Status(status)
+	return task, nil
+}
+
+// CheckHealth pings the database (health.Checker).
+func (r *Repository) CheckHealth(ctx context.Context) error {
+	return r.db.PingContext(ctx)
+}
+
+var _ tasks.Repository = (*Repository)(nil)
@@ -0,0 +1,104 @@
+// Package tasks assigns work to the members of a team.
+//
+// Who gets a task is an AssignmentPolicy - round-robin, least loaded, by
+// skill, or anything else that implements Choose. The Service never changes
+// for a new policy (OCP): it gathers the candidates - the team's members,
+// their skills and how much open work they have - and lets the policy pick.
+// The ISP lesson's TaskAssigner is the role; this is the work behind it.
+package tasks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+)
+
+type Status string
+
+const (
+	Open       Status = "open" // nobody assigned yet
+	Assigned   Status = "assigned"
+	InProgress Status = "in_progress"
+	Done       Status = "done"
+)
+
+// Task A piece of work for one member of a team
+type Task struct {
+	ID       string
+	Title    string
+	Team     string   // the org.Team the task belongs to
+	Skills   []string // what the assignee needs
+	Assignee string
+	Status   Status
+	Created  time.Time
+}
+
+var (
+	// ErrNotFound returned by repositories when no task matches
+	ErrNotFound = errors.New("task not found")
+	// ErrTransition returned for a change the task's status doesn't allow
+	ErrTransition = errors.New("invalid task transition")
+	// ErrNoCandidate returned when nobody on the team can take the task
+	ErrNoCandidate = errors.New("no candidate for the task")
+	// ErrNotOnTeam returned when assigning a task to someone outside its team
+	ErrNotOnTeam = errors.New("not on the task's team")
+)
+
+// Active reports whether the task is assigned and not done yet: the work
+// that counts towards its assignee's load.
+func (t Task) Active() bool { return t.Status == Assigned || t.Status == InProgress }
+
+// Assign gives the task to name. A task can change hands until it is
+// started.
+func (t *Task) Assign(name string) error {
+	if t.Status != Open && t.Status != Assigned {
+		return fmt.Errorf("assign a task that is %s: %w", t.Status, ErrTransition)
+	}
+	t.Assignee, t.Status = name, Assigned
+	return nil
+}
+
+// Start marks an assigned task as being worked on.
+func (t *Task) Start() error {
+	if t.Status != Assigned {
+		return fmt.Errorf("start a task that is %s: %w", t.Status, ErrTransition)
+	}
+	t.Status = InProgress
+	return nil
+}
+
+// Complete marks a task in progress as done.
+func (t *Task) Complete() error {
+	if t.Status != InProgress {
+		return fmt.Errorf("complete a task that is %s: %w", t.Status, ErrTransition)
+	}
+	t.Status = Done
+	return nil
+}
+
+// Repository Abstraction over task storage
+type Repository interface {
+	Save(ctx context.Context, task Task) error
+	Get(ctx context.Context, id string) (Task, error)
+	// ListByAssignee returns the tasks assigned to name, oldest first.
+	ListByAssignee(ctx context.Context, name string) ([]Task, error)
+}
+
+// Candidate A team member a task could go to
+type Candidate struct {
+	Name   string
+	Skills []string
+	Load   int // active tasks assigned to them
+}
+
+// Has reports whether the candidate has every one of skills.
+func (c Candidate) Has(skills ...string) bool {
+	for _, s := range skills {
+		if !slices.Contains(c.Skills, s) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,123 @@
+package telemetry
+
+import (
+	"cmp"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+)
+
+// Collector HTTP endpoint an instructor runs for a workshop:
+//
+//	POST /events   one event, as an HTTPSink sends it
+//	GET  /summary  how often each event happened, and to how many learners
+//
+// Events are checked against the registry again on arrival, so a CLI older
+// or newer than the collector can't slip in a field it doesn't know.
+type Collector struct {
+	registry *Registry
+	events   *Memory
+	sink     Sink
+	mux      *http.ServeMux
+}
+
+// NewCollector keeps the events it accepts, and passes each to sink as well
+// (a FileSink, to keep them past a restart) when sink isn't nil.
+func NewCollector(registry *Registry, sink Sink) *Collector {
+	if sink == nil {
+		sink = Nop{}
+	}
+	c := &Collector{registry: registry, events: &Memory{}, sink: sink, mux: http.NewServeMux()}
+	c.mux.HandleFunc("POST /events", c.collect)
+	c.mux.HandleFunc("GET /summary", c.summary)
+	return c
+}
+
+func (c *Collector) ServeHTTP(w http.ResponseWriter, r *http.Request) { c.mux.ServeHTTP(w, r) }
+
+// Events returns every event accepted so far.
+func (c *Collector) Events() []Event { return c.events.Events() }
+
+func (c *Collector) collect(w http.ResponseWriter, r *http.Request) {
+	var e Event
+	dec := json.NewDecoder(http.MaxBytesReader(w, r.Body, 1<<16))
+	dec.DisallowUnknownFields()
+	dec.UseNumber()
+	if err := dec.Decode(&e); err != nil {
+		writeJSON(w, http.StatusBadRequest, errorBody{"invalid JSON body: " + err.Error()})
+		return
+	}
+	if err := c.registry.Validate(e); err != nil {
+		status := http.StatusUnprocessableEntity
+		if errors.Is(err, ErrUnknownEvent) {
+			status = http.StatusNotFound
+		}
+		writeJSON(w, status, errorBody{err.Error()})
+		return
+	}
+	if err := c.sink.Emit(r.Context(), e); err != nil {
+		writeJSON(w, http.StatusInternalServerError, errorBody{err.Error()})
+		return
+	}
+	_ = c.events.Emit(r.Context(), e)
+	w.WriteHeader(http.StatusAccepted)
+}
+
+func (c *Collector) summary(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, Summarize(c.registry, c.Events()))
+}
+
+// Count How often one event happened for one value of its schema's key
+type Count struct {
+	Event    string `json:"event"`
+	Key      string `json:"key"`
+	Events   int    `json:"events"`
+	Sessions int    `json:"sessions"` // learners, as far as anyone can tell
+}
+
+func (c Count) String() string {
+	return fmt.Sprintf("%s %s: %d time(s), %d learner(s)", c.Event, c.Key, c.Events, c.Sessions)
+}
+
+// Summarize counts events by name and the value of their schema's key, in
+// that order. Versions of an event count together.
+func Summarize(registry *Registry, events []Event) []Count {
+	type group struct{ event, key string }
+	counts := map[group]*Count{}
+	sessions := map[group]map[string]bool{}
+	for _, e := range events {
+		s, ok := registry.Lookup(e.Name, e.Version)
+		if !ok {
+			continue
+		}
+		g := group{e.Name, fmt.Sprint(e.Attrs[s.Key])}
+		if counts[g] == nil {
+			counts[g] = &Count{Event: g.event, Key: g.key}
+			sessions[g] = map[string]bool{}
+		}
+		counts[g].Events++
+		sessions[g][e.Session] = true
+	}
+	out := make([]Count, 0, len(counts))
+	for g, c := range counts {
+		c.Sessions = len(sessions[g])
+		out = append(out, *c)
+	}
+	slices.SortFunc(out, func(a, b Count) int {
+		return cmp.Or(strings.Compare(a.Event, b.Event), strings.Compare(a.Key, b.Key))
+	})
+	return out
+}
+
+type errorBody struct {
+	Error string `json:"error"`
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,219 @@
+package telemetry
+
+import (
+	"cmp"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"math"
+	"slices"
+	"strings"
+	"sync"
+)
+
+var (
+	// ErrUnknownEvent returned for an event no schema in the registry declares
+	ErrUnknownEvent = errors.New("unknown event")
+	// ErrInvalidEvent returned for an event that doesn't match its schema
+	ErrInvalidEvent = errors.New("invalid event")
+)
+
+// Type What a field's value must be
+type Type string
+
+const (
+	String Type = "string"
+	Int    Type = "int"
+	Bool   Type = "bool"
+)
+
+// Field One attribute an event may carry
+type Field struct {
+	Name string `json:"name"`
+	Type Type   `json:"type"`
+	Doc  string `json:"doc"`
+}
+
+// Schema Declares one version of one event: every field it carries, and
+// nothing else. Key names the field a summary groups the event by.
+type Schema struct {
+	Name    string  `json:"name"`
+	Version int     `json:"version"`
+	Doc     string  `json:"doc"`
+	Key     string  `json:"key"`
+	Fields  []Field `json:"fields"`
+}
+
+func (s Schema) String() string { return fmt.Sprintf("%s/v%d", s.Name, s.Version) }
+
+func (s Schema) field(name string) (Field, bool) {
+	i := slices.IndexFunc(s.Fields, func(f Field) bool { return f.Name == name })
+	if i < 0 {
+		return Field{}, false
+	}
+	return s.Fields[i], true
+}
+
+// Registry The schemas events are checked against. Adding an event is
+// registering its schema; sinks and the collector never change (OCP).
+type Registry struct {
+	mu      sync.RWMutex
+	schemas map[string]Schema
+}
+
+// NewRegistry returns a registry holding schemas.
+func NewRegistry(schemas ...Schema) (*Registry, error) {
+	r := &Registry{schemas: map[string]Schema{}}
+	for _, s := range schemas {
+		if err := r.Register(s); err != nil {
+			return nil, err
+		}
+	}
+	return r, nil
+}
+
+// Register adds s. A new version of an event is a new schema; a registered
+// one is never replaced, since events already sent were checked against it.
+func (r *Registry) Register(s Schema) error {
+	if s.Name == "" || s.Version < 1 {
+		return fmt.Errorf("telemetry: schema %q needs a name and a version from 1", s)
+	}
+	if _, ok := s.field(s.Key); !ok {
+		return fmt.Errorf("telemetry: %s is keyed by %q, which it doesn't declare", s, s.Key)
+	}
+	for _, f := range s.Fields {
+		if f.Type != String && f.Type != Int && f.Type != Bool {
+			return fmt.Errorf("telemetry: %s.%s has unknown type %q", s, f.Name, f.Type)
+		}
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, dup := r.schemas[s.String()]; dup {
+		return fmt.Errorf("telemetry: %s is already registered", s)
+	}
+	r.schemas[s.String()] = s
+	return nil
+}
+
+// Lookup returns the schema for version of the event called name.
+func (r *Registry) Lookup(name string, version int) (Schema, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	s, ok := r.schemas[Schema{Name: name, Version: version}.String()]
+	return s, ok
+}
+
+// Schemas returns every registered schema, by name then version.
+func (r *Registry) Schemas() []Schema {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return slices.SortedFunc(maps.Values(r.schemas), func(a, b Schema) int {
+		return cmp.Or(strings.Compare(a.Name, b.Name), cmp.Compare(a.Version, b.Version))
+	})
+}
+
+// Validate checks that e is a registered event carrying every field its
+// schema declares, of the declared type, and no other.
+func (r *Registry) Validate(e Event) error {
+	s, ok := r.Lookup(e.Name, e.Version)
+	if !ok {
+		return fmt.Errorf("%w: %s/v%d", ErrUnknownEvent, e.Name, e.Version)
+	}
+	for _, name := range slices.Sorted(maps.Keys(e.Attrs)) {
+		f, ok := s.field(name)
+		if !ok {
+			return fmt.Errorf("%w: %s declares no field %q", ErrInvalidEvent, s, name)
+		}
+		if !conforms(f.Type, e.Attrs[name]) {
+			return fmt.Errorf("%w: %s.%s is %T, want %s", ErrInvalidEvent, s, name, e.Attrs[name], f.Type)
+		}
+	}
+	for _, f := range s.Fields {
+		if _, ok := e.Attrs[f.Name]; !ok {
+			return fmt.Errorf("%w: %s is missing %s", ErrInvalidEvent, s, f.Name)
+		}
+	}
+	return nil
+}
+
+// conforms reports whether v is of type t, as built in Go or as decoded
+// from JSON.
+func conforms(t Type, v any) bool {
+	switch t {
+	case String:
+		_, ok := v.(string)
+		return ok
+	case Bool:
+		_, ok := v.(bool)
+		return ok
+	case Int:
+		switch n := v.(type) {
+		case int, int64:
+			return true
+		case float64:
+			return n == math.Trunc(n)
+		case json.Number:
+			_, err := n.Int64()
+			return err == nil
+		}
+	}
+	return false
+}
+
+// The events the CLI sends.
+var (
+	LessonCompletedSchema = Schema{Name: "lesson_completed", Version: 1, Key: "lesson",
+		Doc: "solid lesson next reached a lesson's last checkpoint",
+		Fields: []Field{
+			{Name: "lesson", Type: String, Doc: "the lesson, e.g. srp"},
+			{Name: "checkpoints", Type: Int, Doc: "how many checkpoints it has"},
+		}}
+	ExerciseFailedSchema = Schema{Name: "exercise_failed", Version: 1, Key: "exercise",
+		Doc: "solid grade -exercise found failing tests, or none that passed",
+		Fields: []Field{
+			{Name: "exercise", Type: String, Doc: "the exercise, e.g. srp-1"},
+			{Name: "failed", Type: Int, Doc: "tests that failed"},
+			{Name: "tests", Type: Int, Doc: "tests run"},
+			{Name: "built", Type: Bool, Doc: "whether the submission compiled"},
+			{Name: "timed_out", Type: Bool, Doc: "whether the run was stopped"},
+		}}
+	DemoRunSchema = Schema{Name: "demo_run", Version: 1, Key: "demo",
+		Doc: "solid scenario run played a scenario",
+		Fields: []Field{
+			{Name: "demo", Type: String, Doc: "the scenario's name"},
+			{Name: "steps", Type: Int, Doc: "steps it has"},
+			{Name: "passed", Type: Int, Doc: "steps whose output was as expected"},
+		}}
+)
+
+// Default The registry the CLI's events are checked against
+var Default = must(NewRegistry(LessonCompletedSchema, ExerciseFailedSchema, DemoRunSchema))
+
+func must(r *Registry, err error) *Registry {
+	if err != nil {
+		panic(err)
+	}
+	return r
+}
+
+// LessonCompleted is the event for a learner finishing lesson, which has
+// so many checkpoints.
+func LessonCompleted(lesson string, checkpoints int) Event {
+	return Event{Name: LessonCompletedSchema.Name, Version: LessonCompletedSchema.Version,
+		Attrs: map[string]any{"lesson": lesson, "checkpoints": checkpoints}}
+}
+
+// ExerciseFailed is the event for a submission to exercise that failed
+// some of its tests, or didn't get as far as running them.
+func ExerciseFailed(exercise string, failed, tests int, built, timedOut bool) Event {
+	return Event{Name: ExerciseFailedSchema.Name, Version: ExerciseFailedSchema.Version,
+		Attrs: map[string]any{"exercise": exercise, "failed": failed, "tests": tests, "built": built, "timed_out": timedOut}}
+}
+
+// DemoRun is the event for a learner running demo, passed of whose steps
+// went as expected.
+func DemoRun(demo string, steps, passed int) Event {
+	return Event{Name: DemoRunSchema.Name, Version: DemoRunSchema.Version,
+		Attrs: map[string]any{"demo": demo, "steps": steps, "passed": passed}}
+}
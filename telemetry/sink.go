@@ -0,0 +1,102 @@
+package telemetry
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"time"
+
+	"go-solid/jsonl"
+)
+
+// WriterSink Low-level module - writes events as JSON lines to any io.Writer
+type WriterSink struct {
+	w *jsonl.Writer
+}
+
+func NewWriterSink(w io.Writer) *WriterSink { return &WriterSink{w: jsonl.NewWriter(w)} }
+
+// NewStdoutSink writes JSON lines to standard output.
+func NewStdoutSink() *WriterSink { return NewWriterSink(os.Stdout) }
+
+func (s *WriterSink) Emit(_ context.Context, e Event) error { return s.w.Write(e) }
+
+// FileSink Low-level module - appends JSON lines to a file
+type FileSink struct {
+	f *jsonl.File
+}
+
+// NewFileSink opens (or creates) path for appending.
+func NewFileSink(path string) (*FileSink, error) {
+	f, err := jsonl.Append(path)
+	if err != nil {
+		return nil, fmt.Errorf("telemetry: %w", err)
+	}
+	return &FileSink{f: f}, nil
+}
+
+func (s *FileSink) Emit(_ context.Context, e Event) error { return s.f.Write(e) }
+
+func (s *FileSink) Close() error { return s.f.Close() }
+
+// HTTPSink Low-level module - posts each event to a Collector
+type HTTPSink struct {
+	URL  string       // e.g. http://teacher.local:8091/events
+	HTTP *http.Client // one giving up after 5s when nil
+}
+
+func (s *HTTPSink) Emit(ctx context.Context, e Event) error {
+	body, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	hc := s.HTTP
+	if hc == nil {
+		// a learner's command shouldn't wait on an instructor's laptop
+		hc = &http.Client{Timeout: 5 * time.Second}
+	}
+	resp, err := hc.Do(req)
+	if err != nil {
+		return fmt.Errorf("telemetry: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		var e errorBody
+		_ = json.NewDecoder(io.LimitReader(resp.Body, 1<<16)).Decode(&e)
+		return fmt.Errorf("telemetry: %s: %s", resp.Status, e.Error)
+	}
+	return nil
+}
+
+// Open resolves a destination into a sink: "" for Nop, "stdout",
+// file:///path/events.jsonl or the URL of a collector's /events. A sink
+// that holds a file is an io.Closer.
+func Open(dest string) (Sink, error) {
+	switch dest {
+	case "", "off":
+		return Nop{}, nil
+	case "stdout":
+		return NewStdoutSink(), nil
+	}
+	u, err := url.Parse(dest)
+	if err != nil {
+		return nil, fmt.Errorf("telemetry: %w", err)
+	}
+	switch u.Scheme {
+	case "file":
+		return NewFileSink(u.Path)
+	case "http", "https":
+		return &HTTPSink{URL: dest}, nil
+	}
+	return nil, fmt.Errorf("telemetry: unknown destination %q: stdout, file:///path or an http(s) URL", dest)
+}
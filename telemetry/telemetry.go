@@ -0,0 +1,135 @@
+// Package telemetry records anonymous usage events from the learner's CLI,
+// so an instructor can see how a workshop is going: which lessons get
+// finished, which exercises keep failing, which demos get run.
+//
+// Every event is declared up front by a Schema in a Registry, and an event
+// carrying a field its schema doesn't declare is refused before it leaves
+// the machine. That is what keeps it anonymous: there is no field for a
+// name, and no way to add one by accident. Where events go - nowhere,
+// stdout, a file, an instructor's collector - is a Sink chosen by whoever
+// runs the CLI (DIP), and nothing is sent unless they opt in.
+package telemetry
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"go-solid/clock"
+)
+
+// Event One thing that happened, with the attributes its schema declares
+type Event struct {
+	Name    string         `json:"name"`
+	Version int            `json:"version"`
+	Time    time.Time      `json:"time"`
+	Session string         `json:"session,omitempty"`
+	Attrs   map[string]any `json:"attrs,omitempty"`
+}
+
+func (e Event) String() string { return fmt.Sprintf("%s/v%d %v", e.Name, e.Version, e.Attrs) }
+
+// Sink Abstraction - where events go
+type Sink interface {
+	Emit(ctx context.Context, e Event) error
+}
+
+// Nop Sink for learners who haven't opted in: events are checked, then
+// dropped
+type Nop struct{}
+
+func (Nop) Emit(context.Context, Event) error { return nil }
+
+// Memory Sink that keeps events in memory, useful in tests and demos
+type Memory struct {
+	mu     sync.Mutex
+	events []Event
+}
+
+func (m *Memory) Emit(_ context.Context, e Event) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.events = append(m.events, e)
+	return nil
+}
+
+// Events returns a copy of everything emitted so far.
+func (m *Memory) Events() []Event {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]Event(nil), m.events...)
+}
+
+// Emitter Checks events against a registry, stamps them and hands them to a
+// sink
+type Emitter struct {
+	sink     Sink
+	registry *Registry
+	clock    clock.Clock
+	session  string
+}
+
+// Option customises an Emitter created by NewEmitter
+type Option func(*Emitter)
+
+// WithRegistry checks events against r instead of Default.
+func WithRegistry(r *Registry) Option { return func(e *Emitter) { e.registry = r } }
+
+// WithClock stamps events with c instead of the real time.
+func WithClock(c clock.Clock) Option { return func(e *Emitter) { e.clock = c } }
+
+// WithSession stamps events with session instead of a new random one.
+func WithSession(session string) Option { return func(e *Emitter) { e.session = session } }
+
+func NewEmitter(sink Sink, opts ...Option) *Emitter {
+	e := &Emitter{sink: sink, registry: Default, clock: clock.Real{}}
+	for _, opt := range opts {
+		opt(e)
+	}
+	if e.session == "" {
+		e.session = NewSession()
+	}
+	return e
+}
+
+// Emit sends ev, stamped with the time and the session, unless its schema
+// refuses it.
+func (e *Emitter) Emit(ctx context.Context, ev Event) error {
+	if err := e.registry.Validate(ev); err != nil {
+		return err
+	}
+	ev.Time, ev.Session = e.clock.Now().UTC(), e.session
+	return e.sink.Emit(ctx, ev)
+}
+
+// NewSession is a random ID that tells one learner's events apart from
+// another's without saying who either is.
+func NewSession() string {
+	return strings.ToLower(rand.Text()[:16])
+}
+
+// LoadSession reads the session kept at path, creating one the first time,
+// so every command a learner runs counts as the same learner.
+func LoadSession(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err == nil && len(strings.TrimSpace(string(data))) > 0 {
+		return strings.TrimSpace(string(data)), nil
+	}
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return "", fmt.Errorf("telemetry: %w", err)
+	}
+	session := NewSession()
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return "", fmt.Errorf("telemetry: %w", err)
+	}
+	if err := os.WriteFile(path, []byte(session+"\n"), 0o644); err != nil {
+		return "", fmt.Errorf("telemetry: %w", err)
+	}
+	return session, nil
+}
@@ -0,0 +1,36 @@
+package tenant
+
+import (
+	"context"
+	"sync"
+)
+
+// partitions Opens one value per tenant on first use and keeps it
+type partitions[T any] struct {
+	open func(ID) (T, error)
+
+	mu     sync.Mutex
+	opened map[ID]T
+}
+
+func (p *partitions[T]) get(ctx context.Context) (T, error) {
+	var zero T
+	id, err := FromContext(ctx)
+	if err != nil {
+		return zero, err
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if v, ok := p.opened[id]; ok {
+		return v, nil
+	}
+	v, err := p.open(id)
+	if err != nil {
+		return zero, err
+	}
+	if p.opened == nil {
+		p.opened = make(map[ID]T)
+	}
+	p.opened[id] = v
+	return v, nil
+}
@@ -0,0 +1,182 @@
+package tenant
+
+import (
+	"context"
+	"errors"
+	"iter"
+
+	"go-solid/employee"
+	"go-solid/leave"
+	"go-solid/outbox"
+	"go-solid/spec"
+)
+
+// Employees Decorator routing every call to the context tenant's own
+// employee.Repository. Optional capabilities are forwarded, so wrapping
+// doesn't hide them (see examples/capabilities).
+type Employees struct {
+	p *partitions[employee.Repository]
+}
+
+// NewEmployees opens a tenant's repository the first time the tenant is
+// seen, e.g. memory.New() or sqlrepo.New(db, d, sqlrepo.WithTable(Table(id, "employees"))).
+func NewEmployees(open func(ID) (employee.Repository, error)) *Employees {
+	return &Employees{p: &partitions[employee.Repository]{open: open}}
+}
+
+func (e *Employees) Save(ctx context.Context, emp employee.Employee) error {
+	repo, err := e.p.get(ctx)
+	if err != nil {
+		return err
+	}
+	return repo.Save(ctx, emp)
+}
+
+func (e *Employees) GetByName(ctx context.Context, name string) (employee.Employee, error) {
+	repo, err := e.p.get(ctx)
+	if err != nil {
+		return employee.Employee{}, err
+	}
+	return repo.GetByName(ctx, name)
+}
+
+func (e *Employees) GetByID(ctx context.Context, id employee.ID) (employee.Employee, error) {
+	repo, err := e.p.get(ctx)
+	if err != nil {
+		return employee.Employee{}, err
+	}
+	return employee.GetByID(ctx, repo, id)
+}
+
+func (e *Employees) All(ctx context.Context) iter.Seq2[employee.Employee, error] {
+	repo, err := e.p.get(ctx)
+	if err != nil {
+		return func(yield func(employee.Employee, error) bool) { yield(employee.Employee{}, err) }
+	}
+	return employee.All(ctx, repo)
+}
+
+func (e *Employees) SaveAll(ctx context.Context, emps iter.Seq[employee.Employee]) error {
+	repo, err := e.p.get(ctx)
+	if err != nil {
+		return err
+	}
+	return employee.SaveAll(ctx, repo, emps)
+}
+
+func (e *Employees) Update(ctx context.Context, emp employee.Employee, expectedVersion int) (employee.Employee, error) {
+	u, err := capability[employee.Updater](ctx, e)
+	if err != nil {
+		return employee.Employee{}, err
+	}
+	return u.Update(ctx, emp, expectedVersion)
+}
+
+func (e *Employees) SoftDelete(ctx context.Context, name string) error {
+	d, err := capability[employee.SoftDeleter](ctx, e)
+	if err != nil {
+		return err
+	}
+	return d.SoftDelete(ctx, name)
+}
+
+func (e *Employees) Restore(ctx context.Context, name string) error {
+	d, err := capability[employee.SoftDeleter](ctx, e)
+	if err != nil {
+		return err
+	}
+	return d.Restore(ctx, name)
+}
+
+func (e *Employees) History(ctx context.Context, name string) ([]employee.Employee, error) {
+	v, err := capability[employee.Versioned](ctx, e)
+	if err != nil {
+		return nil, err
+	}
+	return v.History(ctx, name)
+}
+
+func (e *Employees) List(ctx context.Context, filter employee.Filter, page employee.Page) (employee.PageResult, error) {
+	q, err := capability[employee.QueryRepository](ctx, e)
+	if err != nil {
+		return employee.PageResult{}, err
+	}
+	return q.List(ctx, filter, page)
+}
+
+func (e *Employees) Matching(ctx context.Context, s spec.Specification[employee.Employee]) ([]employee.Employee, error) {
+	m, err := capability[employee.SpecificationRepository](ctx, e)
+	if err != nil {
+		return nil, err
+	}
+	return m.Matching(ctx, s)
+}
+
+func (e *Employees) SaveWithOutbox(ctx context.Context, emp employee.Employee, msgs []outbox.Message) error {
+	o, err := capability[employee.OutboxRepository](ctx, e)
+	if err != nil {
+		return err
+	}
+	return o.SaveWithOutbox(ctx, emp, msgs)
+}
+
+// capability returns the tenant's repository as C, or errors.ErrUnsupported.
+func capability[C any](ctx context.Context, e *Employees) (C, error) {
+	var zero C
+	repo, err := e.p.get(ctx)
+	if err != nil {
+		return zero, err
+	}
+	c, ok := repo.(C)
+	if !ok {
+		return zero, errors.ErrUnsupported
+	}
+	return c, nil
+}
+
+// Leaves Decorator routing leave requests to the context tenant's own leave.Repository
+type Leaves struct {
+	p *partitions[leave.Repository]
+}
+
+func NewLeaves(open func(ID) (leave.Repository, error)) *Leaves {
+	return &Leaves{p: &partitions[leave.Repository]{open: open}}
+}
+
+func (l *Leaves) Save(ctx context.Context, req leave.Request) error {
+	repo, err := l.p.get(ctx)
+	if err != nil {
+		return err
+	}
+	return repo.Save(ctx, req)
+}
+
+func (l *Leaves) Get(ctx context.Context, id string) (leave.Request, error) {
+	repo, err := l.p.get(ctx)
+	if err != nil {
+		return leave.Request{}, err
+	}
+	return repo.Get(ctx, id)
+}
+
+func (l *Leaves) ListByEmployee(ctx context.Context, name string) ([]leave.Request, error) {
+	repo, err := l.p.get(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return repo.ListByEmployee(ctx, name)
+}
+
+var (
+	_ employee.Repository              = (*Employees)(nil)
+	_ employee.SoftDeleter             = (*Employees)(nil)
+	_ employee.Updater                 = (*Employees)(nil)
+	_ employee.Iterable                = (*Employees)(nil)
+	_ employee.IDRepository            = (*Employees)(nil)
+	_ employee.Versioned               = (*Employees)(nil)
+	_ employee.QueryRepository         = (*Employees)(nil)
+	_ employee.SpecificationRepository = (*Employees)(nil)
+	_ employee.BulkSaver               = (*Employees)(nil)
+	_ employee.OutboxRepository        = (*Employees)(nil)
+	_ leave.Repository                 = (*Leaves)(nil)
+)
@@ -0,0 +1,132 @@
+// Package tenant isolates customers sharing one deployment.
+//
+// The tenant travels in the request context: a Resolver finds it in the HTTP
+// request, Middleware puts it in the context, and repository decorators route
+// every call to that tenant's own partition - its own memory repository, or
+// its own SQL tables. Use cases and HTTP handlers never see a tenant ID, so
+// no business code can forget to filter by it; a call without a tenant fails
+// instead of seeing everyone's data.
+package tenant
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// ID Identifies a tenant. Lower-case letters, digits and underscores only, so
+// it can safely become part of a table name.
+type ID string
+
+var valid = regexp.MustCompile(`^[a-z0-9_]{1,32}$`)
+
+var (
+	ErrNoTenant      = errors.New("no tenant in context")
+	ErrInvalidTenant = errors.New("invalid tenant ID")
+	ErrUnknownTenant = errors.New("unknown tenant")
+)
+
+// Parse validates s as a tenant ID.
+func Parse(s string) (ID, error) {
+	if !valid.MatchString(s) {
+		return "", fmt.Errorf("%w %q", ErrInvalidTenant, s)
+	}
+	return ID(s), nil
+}
+
+type ctxKey struct{}
+
+// WithTenant returns a context carrying id.
+func WithTenant(ctx context.Context, id ID) context.Context {
+	return context.WithValue(ctx, ctxKey{}, id)
+}
+
+// FromContext returns the context's tenant, or ErrNoTenant.
+func FromContext(ctx context.Context) (ID, error) {
+	id, ok := ctx.Value(ctxKey{}).(ID)
+	if !ok {
+		return "", ErrNoTenant
+	}
+	return id, nil
+}
+
+// Resolver Strategy - finds the tenant an HTTP request belongs to
+type Resolver interface {
+	Resolve(r *http.Request) (ID, error)
+}
+
+// Header Tenant named in a request header, e.g. set by an API gateway
+// after authentication
+type Header string
+
+func (h Header) Resolve(r *http.Request) (ID, error) {
+	v := r.Header.Get(string(h))
+	if v == "" {
+		return "", ErrNoTenant
+	}
+	return Parse(v)
+}
+
+// Subdomain Tenant taken from the host: acme.hr.example.com -> acme
+type Subdomain struct {
+	Domain string // hr.example.com
+}
+
+func (s Subdomain) Resolve(r *http.Request) (ID, error) {
+	host, _, _ := strings.Cut(r.Host, ":")
+	sub, ok := strings.CutSuffix(host, "."+s.Domain)
+	if !ok || sub == "" {
+		return "", ErrNoTenant
+	}
+	return Parse(sub)
+}
+
+// Known Decorator accepting only the listed tenants
+type Known struct {
+	Resolver Resolver
+	Tenants  []ID
+}
+
+func (k Known) Resolve(r *http.Request) (ID, error) {
+	id, err := k.Resolver.Resolve(r)
+	if err != nil {
+		return "", err
+	}
+	if !slices.Contains(k.Tenants, id) {
+		return "", fmt.Errorf("%w %q", ErrUnknownTenant, id)
+	}
+	return id, nil
+}
+
+// Table names a tenant's copy of a table for the SQL repositories' WithTable
+// options: Table("acme", "employees") is "acme_employees".
+func Table(id ID, base string) string { return string(id) + "_" + base }
+
+// Middleware puts the resolved tenant in the request context; requests
+// without a valid tenant are refused before reaching next.
+func Middleware(resolver Resolver) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			id, err := resolver.Resolve(r)
+			if errors.Is(err, ErrUnknownTenant) {
+				http.Error(w, err.Error(), http.StatusNotFound)
+				return
+			}
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			next.ServeHTTP(w, r.WithContext(WithTenant(r.Context(), id)))
+		})
+	}
+}
+
+var (
+	_ Resolver = Header("")
+	_ Resolver = Subdomain{}
+	_ Resolver = Known{}
+)
@@ -0,0 +1,304 @@
+package tenant_test
+
+import (
+	"cmp"
+	"context"
+	"database/sql"
+	"errors"
+	"iter"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	_ "modernc.org/sqlite"
+
+	"go-solid/employee"
+	"go-solid/employee/employeetest"
+	"go-solid/employee/memory"
+	"go-solid/employee/sqlrepo"
+	"go-solid/httpapi"
+	"go-solid/leave"
+	leavememory "go-solid/leave/memory"
+	"go-solid/money"
+	"go-solid/outbox"
+	"go-solid/spec"
+	"go-solid/sqldialect"
+	"go-solid/tenant"
+)
+
+func employees() *tenant.Employees {
+	return tenant.NewEmployees(func(tenant.ID) (employee.Repository, error) { return memory.New(), nil })
+}
+
+// in Calls a tenant.Employees in one tenant's context, as the middleware
+// would, so the contract can run through it
+type in struct {
+	e  *tenant.Employees
+	id tenant.ID
+}
+
+func (r in) ctx(ctx context.Context) context.Context { return tenant.WithTenant(ctx, r.id) }
+
+func (r in) Save(ctx context.Context, emp employee.Employee) error { return r.e.Save(r.ctx(ctx), emp) }
+func (r in) GetByName(ctx context.Context, name string) (employee.Employee, error) {
+	return r.e.GetByName(r.ctx(ctx), name)
+}
+func (r in) GetByID(ctx context.Context, id employee.ID) (employee.Employee, error) {
+	return r.e.GetByID(r.ctx(ctx), id)
+}
+func (r in) All(ctx context.Context) iter.Seq2[employee.Employee, error] { return r.e.All(r.ctx(ctx)) }
+func (r in) SaveAll(ctx context.Context, emps iter.Seq[employee.Employee]) error {
+	return r.e.SaveAll(r.ctx(ctx), emps)
+}
+func (r in) Update(ctx context.Context, emp employee.Employee, expectedVersion int) (employee.Employee, error) {
+	return r.e.Update(r.ctx(ctx), emp, expectedVersion)
+}
+func (r in) SoftDelete(ctx context.Context, name string) error {
+	return r.e.SoftDelete(r.ctx(ctx), name)
+}
+func (r in) Restore(ctx context.Context, name string) error { return r.e.Restore(r.ctx(ctx), name) }
+func (r in) History(ctx context.Context, name string) ([]employee.Employee, error) {
+	return r.e.History(r.ctx(ctx), name)
+}
+func (r in) List(ctx context.Context, filter employee.Filter, page employee.Page) (employee.PageResult, error) {
+	return r.e.List(r.ctx(ctx), filter, page)
+}
+func (r in) Matching(ctx context.Context, s spec.Specification[employee.Employee]) ([]employee.Employee, error) {
+	return r.e.Matching(r.ctx(ctx), s)
+}
+func (r in) SaveWithOutbox(ctx context.Context, emp employee.Employee, msgs []outbox.Message) error {
+	return r.e.SaveWithOutbox(r.ctx(ctx), emp, msgs)
+}
+
+func TestEmployees(t *testing.T) {
+	employeetest.TestRepository(t, func(t *testing.T) employee.Repository {
+		repo := employees()
+		// another tenant's employees, under names the contract expects to
+		// be free, must not show through
+		globex := tenant.WithTenant(t.Context(), "globex")
+		for _, name := range []string{"Ali", "Nobody", "Staff 000"} {
+			if err := repo.Save(globex, employee.Employee{ID: employee.ID("globex-" + name), Name: name, Salary: money.Of(1000, money.USD)}); err != nil {
+				t.Fatal(err)
+			}
+		}
+		return in{e: repo, id: "acme"}
+	})
+}
+
+func TestEmployees_CrossTenantReadsFail(t *testing.T) {
+	repo := employees()
+	acme := tenant.WithTenant(t.Context(), "acme")
+	globex := tenant.WithTenant(t.Context(), "globex")
+	if err := repo.Save(acme, employee.Employee{Name: "Ali", Title: "Engineer", Salary: money.Of(5000, money.USD)}); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	if _, err := repo.GetByName(acme, "Ali"); err != nil {
+		t.Fatalf("GetByName() in acme error = %v", err)
+	}
+
+	// each read fails with ErrNotFound when it finds nothing of acme's
+	tests := []struct {
+		name string
+		read func(ctx context.Context) error
+	}{
+		{"GetByName", func(ctx context.Context) error {
+			_, err := repo.GetByName(ctx, "Ali")
+			return err
+		}},
+		{"History", func(ctx context.Context) error {
+			_, err := repo.History(ctx, "Ali")
+			return err
+		}},
+		{"SoftDelete", func(ctx context.Context) error { return repo.SoftDelete(ctx, "Ali") }},
+		{"List", func(ctx context.Context) error {
+			res, err := repo.List(ctx, employee.Filter{}, employee.Page{Limit: 10})
+			if err == nil && len(res.Items) > 0 {
+				return errors.New("listed acme's employees")
+			}
+			return cmp.Or(err, employee.ErrNotFound)
+		}},
+		{"All", func(ctx context.Context) error {
+			for _, err := range employee.All(ctx, repo) {
+				if err != nil {
+					return err
+				}
+				return errors.New("listed acme's employees")
+			}
+			return employee.ErrNotFound
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.read(globex); !errors.Is(err, employee.ErrNotFound) {
+				t.Errorf("%s in globex error = %v, want %v", tt.name, err, employee.ErrNotFound)
+			}
+			if err := tt.read(t.Context()); !errors.Is(err, tenant.ErrNoTenant) {
+				t.Errorf("%s without a tenant error = %v, want %v", tt.name, err, tenant.ErrNoTenant)
+			}
+		})
+	}
+	if _, err := repo.GetByName(acme, "Ali"); err != nil {
+		t.Errorf("GetByName() in acme after globex's attempts error = %v, want Ali untouched", err)
+	}
+}
+
+func TestEmployees_SameNameInTwoTenants(t *testing.T) {
+	repo := employees()
+	for _, id := range []tenant.ID{"acme", "globex"} {
+		ctx := tenant.WithTenant(t.Context(), id)
+		if err := repo.Save(ctx, employee.Employee{Name: "Ali", Title: string(id)}); err != nil {
+			t.Fatalf("Save() in %s error = %v, want names scoped to a tenant", id, err)
+		}
+	}
+	for _, id := range []tenant.ID{"acme", "globex"} {
+		if emp, err := repo.GetByName(tenant.WithTenant(t.Context(), id), "Ali"); err != nil || emp.Title != string(id) {
+			t.Errorf("GetByName() in %s = %q, %v; want its own Ali", id, emp.Title, err)
+		}
+	}
+}
+
+func TestLeaves_CrossTenantReadsFail(t *testing.T) {
+	repo := tenant.NewLeaves(func(tenant.ID) (leave.Repository, error) { return leavememory.New(), nil })
+	acme := tenant.WithTenant(t.Context(), "acme")
+	globex := tenant.WithTenant(t.Context(), "globex")
+	if err := repo.Save(acme, leave.Request{ID: "leave-1", Employee: "Ali", Days: 3}); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	if _, err := repo.Get(globex, "leave-1"); !errors.Is(err, leave.ErrNotFound) {
+		t.Errorf("Get() in globex error = %v, want %v", err, leave.ErrNotFound)
+	}
+	if reqs, err := repo.ListByEmployee(globex, "Ali"); err != nil || len(reqs) != 0 {
+		t.Errorf("ListByEmployee() in globex = %v, %v; want none", reqs, err)
+	}
+	if _, err := repo.Get(t.Context(), "leave-1"); !errors.Is(err, tenant.ErrNoTenant) {
+		t.Errorf("Get() without a tenant error = %v, want %v", err, tenant.ErrNoTenant)
+	}
+}
+
+func TestMiddleware(t *testing.T) {
+	manager := employee.NewManager(employees())
+	if _, err := manager.AddEmployee(tenant.WithTenant(t.Context(), "acme"), employee.Employee{Name: "Ali", Title: "Engineer", Salary: money.Of(5000, money.USD)}); err != nil {
+		t.Fatal(err)
+	}
+	resolver := tenant.Known{Resolver: tenant.Header("X-Tenant-ID"), Tenants: []tenant.ID{"acme", "globex"}}
+	api := tenant.Middleware(resolver)(httpapi.New(manager))
+
+	tests := []struct {
+		tenant string
+		want   int
+	}{
+		{"acme", http.StatusOK},
+		{"globex", http.StatusNotFound},
+		{"initech", http.StatusNotFound},
+		{"", http.StatusBadRequest},
+		{"acme'; DROP TABLE employees", http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.tenant, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/employees/Ali", nil)
+			if tt.tenant != "" {
+				req.Header.Set("X-Tenant-ID", tt.tenant)
+			}
+			rec := httptest.NewRecorder()
+			api.ServeHTTP(rec, req)
+			if rec.Code != tt.want {
+				t.Errorf("GET /employees/Ali as %q = %d, want %d", tt.tenant, rec.Code, tt.want)
+			}
+		})
+	}
+}
+
+func TestResolvers(t *testing.T) {
+	tests := []struct {
+		name     string
+		resolver tenant.Resolver
+		host     string
+		header   string
+		want     tenant.ID
+		wantErr  error
+	}{
+		{"header", tenant.Header("X-Tenant-ID"), "hr.example.com", "acme", "acme", nil},
+		{"header missing", tenant.Header("X-Tenant-ID"), "hr.example.com", "", "", tenant.ErrNoTenant},
+		{"header invalid", tenant.Header("X-Tenant-ID"), "hr.example.com", "Acme", "", tenant.ErrInvalidTenant},
+		{"subdomain", tenant.Subdomain{Domain: "hr.example.com"}, "acme.hr.example.com:8080", "", "acme", nil},
+		{"bare domain", tenant.Subdomain{Domain: "hr.example.com"}, "hr.example.com", "", "", tenant.ErrNoTenant},
+		{"other domain", tenant.Subdomain{Domain: "hr.example.com"}, "acme.evil.com", "", "", tenant.ErrNoTenant},
+		{"known", tenant.Known{Resolver: tenant.Header("X-Tenant-ID"), Tenants: []tenant.ID{"acme"}}, "", "acme", "acme", nil},
+		{"unknown", tenant.Known{Resolver: tenant.Header("X-Tenant-ID"), Tenants: []tenant.ID{"acme"}}, "", "globex", "", tenant.ErrUnknownTenant},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.Host = tt.host
+			if tt.header != "" {
+				req.Header.Set("X-Tenant-ID", tt.header)
+			}
+			got, err := tt.resolver.Resolve(req)
+			if !errors.Is(err, tt.wantErr) || got != tt.want {
+				t.Errorf("Resolve() = %q, %v; want %q, %v", got, err, tt.want, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestTable(t *testing.T) {
+	if got := tenant.Table("acme", "employees"); got != "acme_employees" {
+		t.Errorf("Table() = %q, want acme_employees", got)
+	}
+}
+
+func TestEmployees_UpdateConflictsWithinATenant(t *testing.T) {
+	acme := tenant.WithTenant(t.Context(), "acme")
+	m := employee.NewManager(employees(), employee.WithConflictRetries(1))
+	if _, err := m.AddEmployee(acme, employee.Employee{Name: "Ali", Title: "Engineer", Salary: money.Of(5000, money.USD)}); err != nil {
+		t.Fatalf("AddEmployee() error = %v", err)
+	}
+	_, err := m.UpdateEmployee(acme, "Ali", func(emp *employee.Employee) error {
+		if _, err := m.ChangeSalary(acme, "Ali", money.Of(5500, money.USD)); err != nil {
+			t.Fatalf("ChangeSalary() error = %v", err)
+		}
+		emp.Title = "Lead"
+		return nil
+	})
+	if !errors.Is(err, employee.ErrConflict) {
+		t.Errorf("UpdateEmployee() error = %v, want %v", err, employee.ErrConflict)
+	}
+}
+
+func TestEmployees_OutboxPerTenant(t *testing.T) {
+	db, err := sql.Open("sqlite", filepath.Join(t.TempDir(), "tenants.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+	repos := map[tenant.ID]*sqlrepo.Repository{}
+	for _, id := range []tenant.ID{"acme", "globex"} {
+		table := tenant.Table(id, "employees")
+		for _, schema := range []string{sqlrepo.TableSchema(table), sqlrepo.OutboxTableSchema(table)} {
+			if _, err := db.Exec(schema); err != nil {
+				t.Fatalf("creating %s's tables: %v", id, err)
+			}
+		}
+		repos[id] = sqlrepo.New(db, sqldialect.SQLite{}, sqlrepo.WithTable(table))
+	}
+	m := employee.NewManager(tenant.NewEmployees(func(id tenant.ID) (employee.Repository, error) { return repos[id], nil }), employee.WithOutbox())
+
+	acme := tenant.WithTenant(t.Context(), "acme")
+	if _, err := m.AddEmployee(acme, employee.Employee{Name: "Ali", Title: "Engineer", Salary: money.Of(5000, money.USD)}); err != nil {
+		t.Fatalf("AddEmployee() in acme error = %v", err)
+	}
+	if msgs, err := repos["globex"].Pending(t.Context(), 10); err != nil || len(msgs) != 0 {
+		t.Errorf("Pending() in globex = %d messages, %v, want none of acme's", len(msgs), err)
+	}
+	msgs, err := repos["acme"].Pending(t.Context(), 10)
+	if err != nil || len(msgs) == 0 {
+		t.Fatalf("Pending() in acme = %d messages, %v, want the hire", len(msgs), err)
+	}
+	if err := repos["globex"].MarkPublished(t.Context(), msgs[0].ID); err != nil {
+		t.Fatalf("MarkPublished() in globex error = %v", err)
+	}
+	if again, err := repos["acme"].Pending(t.Context(), 10); err != nil || len(again) != len(msgs) {
+		t.Errorf("Pending() in acme after globex marked its message = %d, %v, want %d still pending", len(again), err, len(msgs))
+	}
+}
@@ -0,0 +1,201 @@
+// Package testenv starts the databases integration tests run against -
+// MySQL and PostgreSQL - in throwaway containers, and hands the tests their
+// connection settings.
+//
+// A package's integration tests (build tag integration) call Main from
+// TestMain and Require from each test:
+//
+//	func TestMain(m *testing.M) { os.Exit(testenv.Main(m)) }
+//
+//	func TestPostgres(t *testing.T) {
+//		cfg := testenv.Require(t, testenv.Postgres)
+//		repos, err := storage.Open(cfg)
+//		...
+//	}
+//
+// so go test -tags=integration ./... is the whole setup. Containers are
+// started with the docker (or podman) CLI, once per test binary, and removed
+// when it exits. Setting SOLID_<SERVICE>_DSN uses an existing database
+// instead, e.g. one provided by CI; without either, the tests skip.
+package testenv
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"go-solid/storage"
+)
+
+// Service A database and how to run it in a container
+type Service struct {
+	Name  string // the storage backend name: mysql, postgres
+	Image string
+	Port  string // inside the container, e.g. "5432/tcp"
+	Env   []string
+	// Ready runs inside the container and exits 0 once the service accepts
+	// connections over TCP
+	Ready []string
+	// DSN builds the connection string for the published host:port
+	DSN func(addr string) string
+}
+
+var (
+	MySQL = Service{
+		Name:  "mysql",
+		Image: "mysql:8.4",
+		Port:  "3306/tcp",
+		Env:   []string{"MYSQL_ROOT_PASSWORD=solid", "MYSQL_DATABASE=solid"},
+		// over TCP: the server the entrypoint starts for initialisation only listens on the socket
+		Ready: []string{"mysqladmin", "ping", "--host=127.0.0.1", "--user=root", "--password=solid", "--silent"},
+		DSN:   func(addr string) string { return "root:solid@tcp(" + addr + ")/solid?parseTime=true" },
+	}
+	Postgres = Service{
+		Name:  "postgres",
+		Image: "postgres:17-alpine",
+		Port:  "5432/tcp",
+		Env:   []string{"POSTGRES_USER=solid", "POSTGRES_PASSWORD=solid", "POSTGRES_DB=solid"},
+		Ready: []string{"pg_isready", "--host=127.0.0.1", "--username=solid"},
+		DSN:   func(addr string) string { return "postgres://solid:solid@" + addr + "/solid?sslmode=disable" },
+	}
+)
+
+// ErrNoEngine returned when neither a DSN nor a container engine is available
+var ErrNoEngine = errors.New("testenv: no container engine")
+
+// Env The containers started for one test run
+type Env struct {
+	Engine string // "docker" (default) or "podman"
+	// Timeout bounds how long a service may take to become ready; a minute by default
+	Timeout time.Duration
+
+	mu      sync.Mutex
+	started map[string]started
+}
+
+type started struct {
+	id  string
+	cfg storage.Config
+}
+
+// Start returns the storage configuration for s, starting its container
+// unless SOLID_<NAME>_DSN is set or it is already running.
+func (e *Env) Start(ctx context.Context, s Service) (storage.Config, error) {
+	if dsn := os.Getenv("SOLID_" + strings.ToUpper(s.Name) + "_DSN"); dsn != "" {
+		return storage.Config{Backend: s.Name, DSN: dsn}, nil
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if c, ok := e.started[s.Name]; ok {
+		return c.cfg, nil
+	}
+	if _, err := exec.LookPath(e.engine()); err != nil {
+		return storage.Config{}, fmt.Errorf("%w: %v", ErrNoEngine, err)
+	}
+
+	args := []string{"run", "--detach", "--rm", "--publish", "127.0.0.1::" + s.Port}
+	for _, env := range s.Env {
+		args = append(args, "--env", env)
+	}
+	out, err := e.run(ctx, append(args, s.Image)...)
+	if err != nil {
+		return storage.Config{}, fmt.Errorf("testenv: start %s: %w", s.Name, err)
+	}
+	id := strings.TrimSpace(out)
+	cfg, err := e.await(ctx, s, id)
+	if err != nil {
+		_, _ = e.run(context.Background(), "rm", "--force", id)
+		return storage.Config{}, err
+	}
+	if e.started == nil {
+		e.started = map[string]started{}
+	}
+	e.started[s.Name] = started{id: id, cfg: cfg}
+	return cfg, nil
+}
+
+// await waits for the container's service to be ready and reads the host
+// port it was published on.
+func (e *Env) await(ctx context.Context, s Service, id string) (storage.Config, error) {
+	ctx, cancel := context.WithTimeout(ctx, cmp.Or(e.Timeout, time.Minute))
+	defer cancel()
+	for {
+		_, err := e.run(ctx, append([]string{"exec", id}, s.Ready...)...)
+		if err == nil {
+			break
+		}
+		select {
+		case <-ctx.Done():
+			return storage.Config{}, fmt.Errorf("testenv: %s not ready: %w (last check: %v)", s.Name, ctx.Err(), err)
+		case <-time.After(500 * time.Millisecond):
+		}
+	}
+	out, err := e.run(ctx, "port", id, s.Port)
+	if err != nil {
+		return storage.Config{}, fmt.Errorf("testenv: %s port: %w", s.Name, err)
+	}
+	// one line per address family; the first is the 127.0.0.1 one asked for
+	addr, _, _ := strings.Cut(strings.TrimSpace(out), "\n")
+	return storage.Config{Backend: s.Name, DSN: s.DSN(addr)}, nil
+}
+
+// Stop removes every container Start started.
+func (e *Env) Stop(ctx context.Context) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	var errs []error
+	for name, c := range e.started {
+		if _, err := e.run(ctx, "rm", "--force", c.id); err != nil {
+			errs = append(errs, fmt.Errorf("testenv: stop %s: %w", name, err))
+		}
+		delete(e.started, name)
+	}
+	return errors.Join(errs...)
+}
+
+func (e *Env) engine() string { return cmp.Or(e.Engine, "docker") }
+
+func (e *Env) run(ctx context.Context, args ...string) (string, error) {
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, e.engine(), args...)
+	cmd.Stdout, cmd.Stderr = &stdout, &stderr
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("%s %s: %w: %s", e.engine(), args[0], err, strings.TrimSpace(stderr.String()))
+	}
+	return stdout.String(), nil
+}
+
+// Default The Env used by Require and Main
+var Default = &Env{Engine: os.Getenv("SOLID_CONTAINER_ENGINE")}
+
+// Require returns the storage configuration for s from Default, skipping the
+// test when there is neither a DSN nor a container engine to start it with.
+func Require(t testing.TB, s Service) storage.Config {
+	t.Helper()
+	cfg, err := Default.Start(t.Context(), s)
+	if errors.Is(err, ErrNoEngine) {
+		t.Skipf("%s unavailable: set SOLID_%s_DSN or install docker", s.Name, strings.ToUpper(s.Name))
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cfg
+}
+
+// Main runs the tests and then removes the containers they started; call
+// it from TestMain.
+func Main(m *testing.M) int {
+	code := m.Run()
+	if err := Default.Stop(context.Background()); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+	}
+	return code
+}
@@ -0,0 +1,130 @@
+// Package textdiff compares texts line by line and writes the differences
+// the way diff -u does.
+package textdiff
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// context lines around each change, as in diff -u
+const contextLines = 3
+
+type op struct {
+	kind byte // ' ', '-' or '+'
+	line string
+}
+
+// Unified writes a diff -u style comparison of a and b; nothing when equal.
+func Unified(w io.Writer, nameA, nameB, a, b string) error {
+	if a == b {
+		return nil
+	}
+	ops := diffLines(lines(a), lines(b))
+	if _, err := fmt.Fprintf(w, "--- %s\n+++ %s\n", nameA, nameB); err != nil {
+		return err
+	}
+	for start := 0; start < len(ops); {
+		// find the next change and the run of ops belonging to its hunk
+		first := start
+		for first < len(ops) && ops[first].kind == ' ' {
+			first++
+		}
+		if first == len(ops) {
+			break
+		}
+		from := max(first-contextLines, start)
+		end, quiet := first, 0
+		for end < len(ops) && quiet <= 2*contextLines {
+			if ops[end].kind == ' ' {
+				quiet++
+			} else {
+				quiet = 0
+			}
+			end++
+		}
+		end -= max(quiet-contextLines, 0)
+		if err := hunk(w, ops, from, end); err != nil {
+			return err
+		}
+		start = end
+	}
+	return nil
+}
+
+func hunk(w io.Writer, ops []op, from, end int) error {
+	lineA, lineB := 1, 1
+	for _, o := range ops[:from] {
+		if o.kind != '+' {
+			lineA++
+		}
+		if o.kind != '-' {
+			lineB++
+		}
+	}
+	var countA, countB int
+	var body strings.Builder
+	for _, o := range ops[from:end] {
+		if o.kind != '+' {
+			countA++
+		}
+		if o.kind != '-' {
+			countB++
+		}
+		body.WriteByte(o.kind)
+		body.WriteString(o.line)
+		body.WriteByte('\n')
+	}
+	_, err := fmt.Fprintf(w, "@@ -%d,%d +%d,%d @@\n%s", lineA, countA, lineB, countB, body.String())
+	return err
+}
+
+// diffLines aligns a and b on their longest common subsequence. The texts
+// compared are small - lesson files, test output - so the quadratic table
+// is fine.
+func diffLines(a, b []string) []op {
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+	var ops []op
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] == b[j]:
+			ops = append(ops, op{' ', a[i]})
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			ops = append(ops, op{'-', a[i]})
+			i++
+		default:
+			ops = append(ops, op{'+', b[j]})
+			j++
+		}
+	}
+	for ; i < len(a); i++ {
+		ops = append(ops, op{'-', a[i]})
+	}
+	for ; j < len(b); j++ {
+		ops = append(ops, op{'+', b[j]})
+	}
+	return ops
+}
+
+func lines(s string) []string {
+	if s == "" {
+		return nil
+	}
+	return strings.Split(strings.TrimSuffix(strings.ReplaceAll(s, "\r\n", "\n"), "\n"), "\n")
+}
@@ -0,0 +1,49 @@
+package timesheet
+
+import (
+	"context"
+	"time"
+
+	"go-solid/workflow"
+)
+
+// SubjectKind identifies timesheets among workflow subjects
+const SubjectKind = "timesheet"
+
+// Subject describes the timesheet to a workflow.Engine; its total is the
+// "hours" attribute.
+func (t Timesheet) Subject() workflow.Subject {
+	return workflow.Subject{
+		Kind:      SubjectKind,
+		ID:        t.ID,
+		Requester: t.Contractor,
+		Attrs:     map[string]string{"hours": t.Total().String(), "period": t.Period.String()},
+	}
+}
+
+// Hours reads the "hours" attribute back from a timesheet subject.
+func Hours(subj workflow.Subject) time.Duration {
+	hours, _ := time.ParseDuration(subj.Attrs["hours"])
+	return hours
+}
+
+// StatusSync workflow.Listener that writes the workflow's decision back to
+// the timesheet. A rejected timesheet can then be corrected and submitted
+// again.
+type StatusSync struct {
+	Repo Repository
+}
+
+func (s StatusSync) OnEntry(ctx context.Context, inst workflow.Instance, e workflow.Entry) error {
+	if e.Action != workflow.Decided || inst.Subject.Kind != SubjectKind {
+		return nil
+	}
+	sheet, err := s.Repo.Get(ctx, inst.Subject.ID)
+	if err != nil {
+		return err
+	}
+	sheet.Status = Status(inst.Status)
+	return s.Repo.Save(ctx, sheet)
+}
+
+var _ workflow.Listener = StatusSync{}
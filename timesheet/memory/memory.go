@@ -0,0 +1,41 @@
+// Package memory is an in-process timesheet.Repository.
+package memory
+
+import (
+	"context"
+	"slices"
+	"sync"
+
+	"go-solid/timesheet"
+)
+
+// Repository Low-level module - map-backed timesheet.Repository
+type Repository struct {
+	mu   sync.RWMutex
+	byID map[string]timesheet.Timesheet
+}
+
+func New() *Repository {
+	return &Repository{byID: make(map[string]timesheet.Timesheet)}
+}
+
+func (r *Repository) Save(ctx context.Context, sheet timesheet.Timesheet) error {
+	sheet.Entries = slices.Clone(sheet.Entries)
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.byID[sheet.ID] = sheet
+	return nil
+}
+
+func (r *Repository) Get(ctx context.Context, id string) (timesheet.Timesheet, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	sheet, ok := r.byID[id]
+	if !ok {
+		return timesheet.Timesheet{}, timesheet.ErrNotFound
+	}
+	sheet.Entries = slices.Clone(sheet.Entries)
+	return sheet, nil
+}
+
+var _ timesheet.Repository = (*Repository)(nil)
@@ -0,0 +1,66 @@
+package timesheet
+
+import (
+	"context"
+	"errors"
+	"iter"
+	"math/big"
+	"time"
+
+	"go-solid/money"
+	"go-solid/payroll"
+)
+
+// Contractor Someone paid by the hour
+type Contractor struct {
+	ID      string
+	Name    string
+	Country string
+	Rate    money.Money // per hour
+}
+
+// Pay returns what hours earn at the contractor's rate, to the minor unit.
+func (c Contractor) Pay(hours time.Duration) money.Money {
+	return c.Rate.Mul(big.NewRat(int64(hours/time.Minute), 60))
+}
+
+// Roster payroll.Roster of contractors for one period. Each is paid for the
+// hours approved on their timesheet; hours not approved yet are paid in the
+// period they are approved for.
+type Roster struct {
+	Sheets      Repository
+	Period      payroll.Period
+	Contractors []Contractor
+}
+
+func (r Roster) PaidEmployees(ctx context.Context) iter.Seq2[payroll.PaidEmployee, error] {
+	return func(yield func(payroll.PaidEmployee, error) bool) {
+		for _, c := range r.Contractors {
+			var hours time.Duration
+			sheet, err := r.Sheets.Get(ctx, SheetID(c.Name, r.Period))
+			switch {
+			case errors.Is(err, ErrNotFound):
+			case err != nil:
+				yield(nil, err)
+				return
+			case sheet.Status == Approved:
+				hours = sheet.Total()
+			}
+			if !yield(paidContractor{c, hours}, nil) {
+				return
+			}
+		}
+	}
+}
+
+type paidContractor struct {
+	Contractor
+	hours time.Duration
+}
+
+func (p paidContractor) EmployeeID() string      { return p.ID }
+func (p paidContractor) EmployeeName() string    { return p.Name }
+func (p paidContractor) Country() string         { return p.Contractor.Country }
+func (p paidContractor) MonthlyPay() money.Money { return p.Pay(p.hours) }
+
+var _ payroll.Roster = Roster{}
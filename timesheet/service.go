@@ -0,0 +1,108 @@
+package timesheet
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"go-solid/payroll"
+	"go-solid/workflow"
+)
+
+// Approvals Where submitted timesheets go to be approved; a
+// *workflow.Engine with the workflow defined
+type Approvals interface {
+	Start(ctx context.Context, workflow string, subj workflow.Subject) (workflow.Instance, error)
+}
+
+// Service Timesheet use cases: recording hours and submitting them for
+// approval
+type Service struct {
+	repo      Repository
+	approvals Approvals
+	workflow  string
+	mu        sync.Mutex // one change at a time, so a day's total is current
+}
+
+// Option customises a Service created by NewService
+type Option func(*Service)
+
+// WithWorkflow submits timesheets to the named workflow; "timesheet" by
+// default.
+func WithWorkflow(name string) Option { return func(s *Service) { s.workflow = name } }
+
+func NewService(repo Repository, approvals Approvals, opts ...Option) *Service {
+	s := &Service{repo: repo, approvals: approvals, workflow: SubjectKind}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// Get returns the contractor's timesheet for period, a draft with no hours
+// if nothing was recorded.
+func (s *Service) Get(ctx context.Context, contractor string, period payroll.Period) (Timesheet, error) {
+	sheet, err := s.repo.Get(ctx, SheetID(contractor, period))
+	if errors.Is(err, ErrNotFound) {
+		return Timesheet{ID: SheetID(contractor, period), Contractor: contractor, Period: period, Status: Draft}, nil
+	}
+	return sheet, err
+}
+
+// Record adds hours worked on day to the contractor's timesheet for the
+// day's month. A rejected timesheet goes back to draft, to be corrected and
+// submitted again.
+func (s *Service) Record(ctx context.Context, contractor string, day time.Time, hours time.Duration, note string) (Timesheet, error) {
+	day = time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, time.UTC)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	sheet, err := s.Get(ctx, contractor, payroll.Period{Year: day.Year(), Month: day.Month()})
+	if err != nil {
+		return Timesheet{}, fmt.Errorf("record hours of %s: %w", contractor, err)
+	}
+	switch {
+	case sheet.Status == Submitted || sheet.Status == Approved:
+		return Timesheet{}, fmt.Errorf("record hours of %s: %s is %s: %w", contractor, sheet.Period, sheet.Status, ErrLocked)
+	case hours <= 0 || hours%time.Minute != 0:
+		return Timesheet{}, fmt.Errorf("record hours of %s: %v: %w", contractor, hours, ErrInvalidHours)
+	case sheet.On(day)+hours > 24*time.Hour:
+		return Timesheet{}, fmt.Errorf("record hours of %s: %v more on %s: %w", contractor, hours, day.Format(time.DateOnly), ErrInvalidHours)
+	}
+	sheet.Entries = append(sheet.Entries, Entry{Day: day, Hours: hours, Note: note})
+	sheet.Status = Draft
+	if err := s.repo.Save(ctx, sheet); err != nil {
+		return Timesheet{}, fmt.Errorf("record hours of %s: %w", contractor, err)
+	}
+	return sheet, nil
+}
+
+// Submit locks the contractor's timesheet for period and starts its
+// approval.
+func (s *Service) Submit(ctx context.Context, contractor string, period payroll.Period) (workflow.Instance, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	sheet, err := s.Get(ctx, contractor, period)
+	switch {
+	case err != nil:
+	case sheet.Status == Submitted || sheet.Status == Approved:
+		err = fmt.Errorf("%s: %w", sheet.Status, ErrLocked)
+	case len(sheet.Entries) == 0:
+		err = ErrEmpty
+	}
+	if err != nil {
+		return workflow.Instance{}, fmt.Errorf("submit timesheet %s: %w", SheetID(contractor, period), err)
+	}
+	sheet.Status = Submitted
+	if err := s.repo.Save(ctx, sheet); err != nil {
+		return workflow.Instance{}, fmt.Errorf("submit timesheet %s: %w", sheet.ID, err)
+	}
+	inst, err := s.approvals.Start(ctx, s.workflow, sheet.Subject())
+	if err != nil {
+		return workflow.Instance{}, fmt.Errorf("submit timesheet %s: %w", sheet.ID, err)
+	}
+	return inst, nil
+}
+
+var _ TimesheetRecorder = (*Service)(nil)
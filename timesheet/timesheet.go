@@ -0,0 +1,97 @@
+// Package timesheet records the hours contractors work and turns the
+// approved ones into pay.
+//
+// It joins three modules without changing any of them. A contractor logs
+// hours through a TimesheetRecorder and nothing else (ISP). A submitted
+// timesheet is approved by the workflow engine, like a leave request, and
+// StatusSync writes the decision back. Payroll pays contractors through a
+// Roster whose members are payroll.PaidEmployees like everyone else: their
+// monthly pay is their rate times their approved hours, and zero - never a
+// sentinel - when none are approved, so the engine can't tell them apart
+// from salaried staff (LSP, as in 3.LSP).
+package timesheet
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go-solid/payroll"
+)
+
+type Status string
+
+const (
+	Draft     Status = "draft"
+	Submitted Status = "submitted"
+	Approved  Status = "approved"
+	Rejected  Status = "rejected"
+)
+
+// Entry Hours worked on one day
+type Entry struct {
+	Day   time.Time // midnight UTC
+	Hours time.Duration
+	Note  string
+}
+
+// Timesheet A contractor's hours for one payroll period
+type Timesheet struct {
+	ID         string // see SheetID
+	Contractor string
+	Period     payroll.Period
+	Entries    []Entry // by day, in the order recorded
+	Status     Status
+}
+
+// SheetID returns the ID of the contractor's timesheet for period.
+//
+//lint:ignore acceptinterfaces timesheets are kept per payroll period, not per anything with a String method
+func SheetID(contractor string, period payroll.Period) string {
+	return contractor + "/" + period.String()
+}
+
+// Total returns the hours on the timesheet.
+func (t Timesheet) Total() time.Duration {
+	var total time.Duration
+	for _, e := range t.Entries {
+		total += e.Hours
+	}
+	return total
+}
+
+// On returns the hours recorded for day.
+func (t Timesheet) On(day time.Time) time.Duration {
+	var total time.Duration
+	for _, e := range t.Entries {
+		if e.Day.Equal(day) {
+			total += e.Hours
+		}
+	}
+	return total
+}
+
+var (
+	// ErrNotFound returned by repositories when no timesheet matches
+	ErrNotFound = errors.New("timesheet not found")
+	// ErrInvalidHours returned for hours that aren't whole minutes above
+	// zero, or would make a day longer than 24 hours
+	ErrInvalidHours = errors.New("invalid hours")
+	// ErrLocked returned when recording hours on a timesheet that is
+	// submitted or approved
+	ErrLocked = errors.New("timesheet is locked")
+	// ErrEmpty returned when submitting a timesheet with no hours
+	ErrEmpty = errors.New("timesheet has no hours")
+)
+
+// TimesheetRecorder What a contractor is given: logging hours, and nothing
+// about approving or paying them
+type TimesheetRecorder interface {
+	Record(ctx context.Context, contractor string, day time.Time, hours time.Duration, note string) (Timesheet, error)
+}
+
+// Repository Abstraction over timesheet storage
+type Repository interface {
+	Save(ctx context.Context, sheet Timesheet) error
+	Get(ctx context.Context, id string) (Timesheet, error)
+}
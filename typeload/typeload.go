@@ -0,0 +1,131 @@
+// Package typeload type-checks packages of a module for the tools that read
+// its code: wirecheck, rolematrix, solid diagram, solid implements.
+//
+// It does what go/packages does, minus the dependency: go list -export
+// compiles the dependencies and reports where their export data is, and only
+// the packages asked for are checked from source. A package asked for that
+// doesn't type-check is still returned, with its errors - explaining why a
+// type doesn't satisfy an interface is most useful when it doesn't compile.
+package typeload
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// Package A type-checked package and what came with it
+type Package struct {
+	Types  *types.Package
+	Files  []*ast.File
+	Info   *types.Info
+	Fset   *token.FileSet
+	Module string  // path of the module it belongs to, "" for the standard library
+	Errors []error // type errors; the package is checked as far as it goes
+}
+
+// listed One package as go list -export describes it
+type listed struct {
+	ImportPath string
+	Name       string
+	Dir        string
+	GoFiles    []string
+	Export     string // compiled export data, for importing it
+	DepOnly    bool
+	Module     *struct{ Path string }
+	Error      *struct{ Err string }
+}
+
+// Load type-checks the packages matching patterns, as go list takes them
+// ("./...", "./employee", "go-solid/payroll"), from dir. A dependency that
+// doesn't build shows up in the Errors of the packages importing it.
+func Load(ctx context.Context, dir string, patterns ...string) ([]Package, error) {
+	cmd := exec.CommandContext(ctx, "go", append([]string{"list", "-e", "-export", "-deps", "-json"}, patterns...)...)
+	cmd.Dir = dir
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("typeload: go list: %w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+	var targets []listed
+	exports := map[string]string{}
+	dec := json.NewDecoder(bytes.NewReader(out))
+	for dec.More() {
+		var p listed
+		if err := dec.Decode(&p); err != nil {
+			return nil, fmt.Errorf("typeload: go list: %w", err)
+		}
+		exports[p.ImportPath] = p.Export
+		if !p.DepOnly {
+			targets = append(targets, p)
+		}
+	}
+
+	// go list -deps lists a package after its dependencies, so a package
+	// asked for is checked before those importing it, which then get the
+	// same *types.Package. Types from two copies of a package are never
+	// identical, and nothing would implement anything across packages.
+	fset := token.NewFileSet()
+	checked := map[string]*types.Package{}
+	exported := importer.ForCompiler(fset, "gc", func(path string) (io.ReadCloser, error) {
+		file, ok := exports[path]
+		if !ok || file == "" {
+			return nil, fmt.Errorf("no export data for %q", path)
+		}
+		return os.Open(file)
+	})
+	imp := importerFunc(func(path string) (*types.Package, error) {
+		if pkg, ok := checked[path]; ok {
+			return pkg, nil
+		}
+		return exported.Import(path)
+	})
+	var pkgs []Package
+	for _, t := range targets {
+		if len(t.GoFiles) == 0 && t.Error != nil {
+			return nil, fmt.Errorf("typeload: %s: %s", t.ImportPath, t.Error.Err)
+		}
+		var files []*ast.File
+		for _, name := range t.GoFiles {
+			f, err := parser.ParseFile(fset, filepath.Join(t.Dir, name), nil, parser.ParseComments|parser.SkipObjectResolution)
+			if err != nil {
+				return nil, err
+			}
+			files = append(files, f)
+		}
+		p := Package{
+			Info: &types.Info{
+				Types:      map[ast.Expr]types.TypeAndValue{},
+				Defs:       map[*ast.Ident]types.Object{},
+				Uses:       map[*ast.Ident]types.Object{},
+				Selections: map[*ast.SelectorExpr]*types.Selection{},
+			},
+			Files: files,
+			Fset:  fset,
+		}
+		conf := types.Config{Importer: imp, Error: func(err error) { p.Errors = append(p.Errors, err) }}
+		p.Types, _ = conf.Check(t.ImportPath, fset, files, p.Info)
+		checked[t.ImportPath] = p.Types
+		if t.Module != nil {
+			p.Module = t.Module.Path
+		}
+		pkgs = append(pkgs, p)
+	}
+	return pkgs, nil
+}
+
+type importerFunc func(path string) (*types.Package, error)
+
+func (f importerFunc) Import(path string) (*types.Package, error) { return f(path) }
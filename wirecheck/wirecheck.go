@@ -0,0 +1,334 @@
+// Package wirecheck verifies, before the application runs, the wiring main
+// records with admin.Wiring.Bind.
+//
+// There is no DI container to fail late: the application is wired by hand,
+// and the compiler already checks that every dependency is provided. What
+// it can't check is the record of that wiring - the interface names are
+// strings, and nothing forces main to record every dependency or to keep
+// the record up to date. Check compares the record with the code:
+//
+//   - Missing: main passes a value for an interface of this module to a
+//     constructor (New...), an option (With...) or a struct field, and
+//     never binds that interface.
+//   - Ambiguous: an interface is bound more than once, so the admin
+//     endpoint can't say which implementation is in use.
+//   - Unused: an interface is bound but nothing in the package consumes it.
+//   - Invalid: the name is not a constant, names no interface, or names an
+//     interface the bound value doesn't implement.
+package wirecheck
+
+import (
+	"context"
+	"fmt"
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"go/types"
+	"sort"
+	"strings"
+
+	"go-solid/typeload"
+)
+
+// BindMethod The registration call Check looks for
+const BindMethod = "(*go-solid/admin.Wiring).Bind"
+
+type Kind string
+
+const (
+	Missing   Kind = "missing"
+	Ambiguous Kind = "ambiguous"
+	Unused    Kind = "unused"
+	Invalid   Kind = "invalid"
+)
+
+// Problem A difference between the recorded wiring and the code
+type Problem struct {
+	Pos     token.Position
+	Kind    Kind
+	Message string
+}
+
+func (p Problem) String() string { return fmt.Sprintf("%s: %s: %s", p.Pos, p.Kind, p.Message) }
+
+// Binding One Bind call
+type Binding struct {
+	Pos            token.Position
+	Interface      string
+	Implementation string // the static type of the bound value
+}
+
+// Report The wiring of one package
+type Report struct {
+	Package  string
+	Bindings []Binding
+	Problems []Problem
+}
+
+// Check loads the packages matching patterns, as go list takes them, from
+// dir and checks the wiring of each one that binds anything.
+func Check(ctx context.Context, dir string, patterns ...string) ([]Report, error) {
+	pkgs, err := typeload.Load(ctx, dir, patterns...)
+	if err != nil {
+		return nil, err
+	}
+	var reports []Report
+	for _, p := range pkgs {
+		if len(p.Errors) > 0 {
+			return nil, fmt.Errorf("wirecheck: %w", p.Errors[0])
+		}
+		if r := check(p); len(r.Bindings) > 0 {
+			reports = append(reports, r)
+		}
+	}
+	return reports, nil
+}
+
+// bind A Bind call, resolved
+type bind struct {
+	Binding
+	iface *types.Named // nil unless the binding is valid
+}
+
+// use A value passed where an interface is expected
+type use struct {
+	pos   token.Position
+	iface *types.Named
+	where string
+}
+
+func check(p typeload.Package) Report {
+	r := Report{Package: p.Types.Path()}
+	index := interfaces(p.Types)
+	var binds []bind
+	var uses []use
+	for _, f := range p.Files {
+		ast.Inspect(f, func(n ast.Node) bool {
+			switch n := n.(type) {
+			case *ast.CallExpr:
+				if isBind(p.Info, n) {
+					b, problem := resolveBind(p, index, n)
+					if problem != nil {
+						r.Problems = append(r.Problems, *problem)
+					}
+					binds = append(binds, b)
+					r.Bindings = append(r.Bindings, b.Binding)
+					return true
+				}
+				uses = append(uses, callUses(p, n)...)
+			case *ast.CompositeLit:
+				uses = append(uses, fieldUses(p, n)...)
+			}
+			return true
+		})
+	}
+
+	bound := map[*types.Named][]bind{}
+	for _, b := range binds {
+		if b.iface != nil {
+			bound[b.iface] = append(bound[b.iface], b)
+		}
+	}
+	used := map[*types.Named]bool{}
+	for _, u := range uses {
+		used[u.iface] = true
+	}
+	reported := map[*types.Named]bool{}
+	for _, u := range uses {
+		if len(bound[u.iface]) > 0 || reported[u.iface] || !inModule(u.iface, p.Module) {
+			continue
+		}
+		reported[u.iface] = true
+		r.Problems = append(r.Problems, Problem{u.pos, Missing, fmt.Sprintf("%s is passed to %s but never bound", qualified(u.iface), u.where)})
+	}
+	for iface, bs := range bound {
+		if len(bs) > 1 {
+			r.Problems = append(r.Problems, Problem{bs[1].Pos, Ambiguous, fmt.Sprintf("%s is bound %d times, first at line %d", qualified(iface), len(bs), bs[0].Pos.Line)})
+		}
+		if !used[iface] {
+			r.Problems = append(r.Problems, Problem{bs[0].Pos, Unused, fmt.Sprintf("%s is bound but nothing here consumes it", qualified(iface))})
+		}
+	}
+	sort.Slice(r.Problems, func(i, j int) bool {
+		a, b := r.Problems[i].Pos, r.Problems[j].Pos
+		if a.Filename != b.Filename {
+			return a.Filename < b.Filename
+		}
+		return a.Line < b.Line
+	})
+	return r
+}
+
+func isBind(info *types.Info, call *ast.CallExpr) bool {
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok {
+		return false
+	}
+	fn, ok := info.Uses[sel.Sel].(*types.Func)
+	return ok && fn.FullName() == BindMethod
+}
+
+func resolveBind(p typeload.Package, index map[string][]*types.Named, call *ast.CallExpr) (bind, *Problem) {
+	pos := p.Fset.Position(call.Pos())
+	b := bind{Binding: Binding{Pos: pos}}
+	if len(call.Args) != 2 {
+		return b, &Problem{pos, Invalid, "Bind takes an interface name and a value"}
+	}
+	b.Implementation = types.TypeString(p.Info.Types[call.Args[1]].Type, byName)
+	name := p.Info.Types[call.Args[0]].Value
+	if name == nil || name.Kind() != constant.String {
+		return b, &Problem{pos, Invalid, "the interface name must be a constant string"}
+	}
+	b.Interface = constant.StringVal(name)
+	candidates := index[b.Interface]
+	switch {
+	case len(candidates) == 0:
+		return b, &Problem{pos, Invalid, fmt.Sprintf("no interface %s in %s or its dependencies", b.Interface, p.Types.Path())}
+	case len(candidates) > 1:
+		var paths []string
+		for _, c := range candidates {
+			paths = append(paths, c.Obj().Pkg().Path())
+		}
+		return b, &Problem{pos, Invalid, fmt.Sprintf("%s could be in any of %s", b.Interface, strings.Join(paths, ", "))}
+	}
+	if impl := p.Info.Types[call.Args[1]].Type; !types.Implements(impl, candidates[0].Underlying().(*types.Interface)) {
+		return b, &Problem{pos, Invalid, fmt.Sprintf("%s does not implement %s", b.Implementation, b.Interface)}
+	}
+	b.iface = candidates[0]
+	return b, nil
+}
+
+// callUses finds the interfaces a constructor or option call is given
+// values for.
+func callUses(p typeload.Package, call *ast.CallExpr) []use {
+	fn := callee(p.Info, call)
+	if fn == nil || !(strings.HasPrefix(fn.Name(), "New") || strings.HasPrefix(fn.Name(), "With")) {
+		return nil
+	}
+	sig := fn.Type().(*types.Signature)
+	var uses []use
+	for i, arg := range call.Args {
+		var param types.Type
+		switch {
+		case sig.Variadic() && i >= sig.Params().Len()-1:
+			param = sig.Params().At(sig.Params().Len() - 1).Type().(*types.Slice).Elem()
+		case i < sig.Params().Len():
+			param = sig.Params().At(i).Type()
+		}
+		if iface := namedInterface(param); iface != nil {
+			uses = append(uses, use{p.Fset.Position(arg.Pos()), iface, qualifiedFunc(fn)})
+		}
+	}
+	return uses
+}
+
+// fieldUses finds the interface-typed fields a struct literal sets.
+func fieldUses(p typeload.Package, lit *ast.CompositeLit) []use {
+	t := p.Info.Types[lit].Type
+	if t == nil {
+		return nil
+	}
+	st, ok := t.Underlying().(*types.Struct)
+	if !ok {
+		return nil
+	}
+	var uses []use
+	for _, elt := range lit.Elts {
+		kv, ok := elt.(*ast.KeyValueExpr)
+		if !ok {
+			continue
+		}
+		key, ok := kv.Key.(*ast.Ident)
+		if !ok {
+			continue
+		}
+		for i := range st.NumFields() {
+			if f := st.Field(i); f.Name() == key.Name {
+				if iface := namedInterface(f.Type()); iface != nil {
+					uses = append(uses, use{p.Fset.Position(kv.Value.Pos()), iface, types.TypeString(t, byName) + "." + f.Name()})
+				}
+			}
+		}
+	}
+	return uses
+}
+
+func callee(info *types.Info, call *ast.CallExpr) *types.Func {
+	var id *ast.Ident
+	switch fun := ast.Unparen(call.Fun).(type) {
+	case *ast.Ident:
+		id = fun
+	case *ast.SelectorExpr:
+		id = fun.Sel
+	case *ast.IndexExpr: // generic function with explicit type arguments
+		if sel, ok := fun.X.(*ast.SelectorExpr); ok {
+			id = sel.Sel
+		}
+	}
+	if id == nil {
+		return nil
+	}
+	fn, _ := info.Uses[id].(*types.Func)
+	return fn
+}
+
+// namedInterface returns t if it is a named interface with methods; any
+// and other empty interfaces accept everything and say nothing.
+func namedInterface(t types.Type) *types.Named {
+	named, ok := types.Unalias(t).(*types.Named)
+	if !ok || named.Obj().Pkg() == nil {
+		return nil
+	}
+	iface, ok := named.Underlying().(*types.Interface)
+	if !ok || iface.NumMethods() == 0 {
+		return nil
+	}
+	return named
+}
+
+// interfaces indexes every named interface reachable from pkg by the name
+// Bind uses for it, e.g. "employee.Repository".
+func interfaces(pkg *types.Package) map[string][]*types.Named {
+	index := map[string][]*types.Named{}
+	seen := map[*types.Package]bool{}
+	var walk func(*types.Package)
+	walk = func(p *types.Package) {
+		if seen[p] {
+			return
+		}
+		seen[p] = true
+		scope := p.Scope()
+		for _, name := range scope.Names() {
+			tn, ok := scope.Lookup(name).(*types.TypeName)
+			if !ok || !tn.Exported() {
+				continue
+			}
+			if named := namedInterface(tn.Type()); named != nil {
+				key := p.Name() + "." + name
+				index[key] = append(index[key], named)
+			}
+		}
+		for _, imp := range p.Imports() {
+			walk(imp)
+		}
+	}
+	walk(pkg)
+	return index
+}
+
+// byName qualifies types by package name, as Bind names interfaces.
+func byName(p *types.Package) string { return p.Name() }
+
+func inModule(iface *types.Named, module string) bool {
+	path := iface.Obj().Pkg().Path()
+	return module != "" && (path == module || strings.HasPrefix(path, module+"/"))
+}
+
+func qualified(t *types.Named) string { return t.Obj().Pkg().Name() + "." + t.Obj().Name() }
+
+func qualifiedFunc(fn *types.Func) string {
+	if sig := fn.Type().(*types.Signature); sig.Recv() != nil {
+		return fn.Name()
+	}
+	return fn.Pkg().Name() + "." + fn.Name()
+}
@@ -0,0 +1,69 @@
+// Package memory is an in-process workflow.Store.
+package memory
+
+import (
+	"context"
+	"maps"
+	"slices"
+	"sort"
+	"sync"
+
+	"go-solid/workflow"
+)
+
+// Store Low-level module - map-backed workflow.Store
+type Store struct {
+	mu   sync.RWMutex
+	byID map[string]workflow.Instance
+}
+
+func New() *Store {
+	return &Store{byID: make(map[string]workflow.Instance)}
+}
+
+func (s *Store) Save(ctx context.Context, inst workflow.Instance) (workflow.Instance, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.byID[inst.ID].Version != inst.Version {
+		return workflow.Instance{}, workflow.ErrConflict
+	}
+	inst.Version++
+	s.byID[inst.ID] = clone(inst)
+	return clone(inst), nil
+}
+
+func (s *Store) Get(ctx context.Context, id string) (workflow.Instance, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	inst, ok := s.byID[id]
+	if !ok {
+		return workflow.Instance{}, workflow.ErrNotFound
+	}
+	return clone(inst), nil
+}
+
+// Pending returns undecided instances, oldest first.
+func (s *Store) Pending(ctx context.Context) ([]workflow.Instance, error) {
+	s.mu.RLock()
+	var pending []workflow.Instance
+	for _, inst := range s.byID {
+		if inst.Status == workflow.Pending {
+			pending = append(pending, clone(inst))
+		}
+	}
+	s.mu.RUnlock()
+	sort.Slice(pending, func(i, j int) bool { return pending[i].History[0].At.Before(pending[j].History[0].At) })
+	return pending, nil
+}
+
+// clone copies the slices and map so callers can't change stored instances
+// behind the store's back.
+func clone(inst workflow.Instance) workflow.Instance {
+	inst.Subject.Attrs = maps.Clone(inst.Subject.Attrs)
+	inst.Approvers = slices.Clone(inst.Approvers)
+	inst.Votes = slices.Clone(inst.Votes)
+	inst.History = slices.Clone(inst.History)
+	return inst
+}
+
+var _ workflow.Store = (*Store)(nil)
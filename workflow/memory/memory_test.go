@@ -0,0 +1,13 @@
+package memory_test
+
+import (
+	"testing"
+
+	"go-solid/workflow"
+	"go-solid/workflow/memory"
+	"go-solid/workflow/workflowtest"
+)
+
+func TestStore(t *testing.T) {
+	workflowtest.TestStore(t, func(*testing.T) workflow.Store { return memory.New() })
+}
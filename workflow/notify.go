@@ -0,0 +1,47 @@
+package workflow
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"go-solid/notify"
+)
+
+// Notifications Listener that tells approvers when they are asked to vote
+// and requesters when their subject is decided
+type Notifications struct {
+	Notifier notify.Notifier
+}
+
+func (n Notifications) OnEntry(ctx context.Context, inst Instance, e Entry) error {
+	var to []string
+	var msg notify.Message
+	switch e.Action {
+	case Assigned, Escalated:
+		if inst.Status != Pending {
+			return nil
+		}
+		to = e.Assignees
+		msg = notify.Message{
+			Subject: fmt.Sprintf("Approval needed: %s %s", inst.Subject.Kind, inst.Subject.ID),
+			Body:    fmt.Sprintf("%s is waiting on step %q", inst.Subject.Requester, e.Step),
+		}
+	case Decided:
+		to = []string{inst.Subject.Requester}
+		msg = notify.Message{
+			Subject: fmt.Sprintf("Your %s %s", inst.Subject.Kind, inst.Subject.ID),
+			Body:    e.Note,
+		}
+	default:
+		return nil
+	}
+	var errs []error
+	for _, who := range to {
+		msg.To = who
+		errs = append(errs, n.Notifier.Notify(ctx, msg))
+	}
+	return errors.Join(errs...)
+}
+
+var _ Listener = Notifications{}
@@ -0,0 +1,121 @@
+// Package sqlstore is a workflow.Store on top of database/sql.
+//
+// The instance is stored as a JSON document next to the few columns that are
+// queried (status, version), so adding a field to workflow.Instance needs no
+// migration.
+package sqlstore
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"go-solid/sqldialect"
+	"go-solid/workflow"
+)
+
+// Schema Table layout expected by the store
+const Schema = `CREATE TABLE workflow_instances (
+    id         VARCHAR(64)  NOT NULL PRIMARY KEY,
+    workflow   VARCHAR(255) NOT NULL,
+    status     VARCHAR(16)  NOT NULL,
+    version    INTEGER      NOT NULL,
+    started_at TIMESTAMP    NOT NULL,
+    document   TEXT         NOT NULL
+)`
+
+// Store Low-level module - SQL-backed workflow.Store with optimistic locking
+// on the version column
+type Store struct {
+	db      *sql.DB
+	dialect sqldialect.Dialect
+}
+
+func New(db *sql.DB, dialect sqldialect.Dialect) *Store {
+	return &Store{db: db, dialect: dialect}
+}
+
+func (s *Store) Save(ctx context.Context, inst workflow.Instance) (workflow.Instance, error) {
+	expected := inst.Version
+	inst.Version++
+	doc, err := json.Marshal(inst)
+	if err != nil {
+		return workflow.Instance{}, fmt.Errorf("sqlstore: encode %q: %w", inst.ID, err)
+	}
+
+	if expected == 0 {
+		_, err = s.db.ExecContext(ctx, s.dialect.Rebind(`INSERT INTO workflow_instances
+			(id, workflow, status, version, started_at, document) VALUES (?, ?, ?, ?, ?, ?)`),
+			inst.ID, inst.Workflow, string(inst.Status), inst.Version, inst.History[0].At, string(doc))
+		if err != nil {
+			return workflow.Instance{}, fmt.Errorf("sqlstore: insert %q: %w", inst.ID, err)
+		}
+		return inst, nil
+	}
+
+	res, err := s.db.ExecContext(ctx, s.dialect.Rebind(`UPDATE workflow_instances
+		SET status = ?, version = ?, document = ? WHERE id = ? AND version = ?`),
+		string(inst.Status), inst.Version, string(doc), inst.ID, expected)
+	if err != nil {
+		return workflow.Instance{}, fmt.Errorf("sqlstore: update %q: %w", inst.ID, err)
+	}
+	if n, err := res.RowsAffected(); err != nil {
+		return workflow.Instance{}, fmt.Errorf("sqlstore: update %q: %w", inst.ID, err)
+	} else if n == 0 {
+		return workflow.Instance{}, workflow.ErrConflict
+	}
+	return inst, nil
+}
+
+func (s *Store) Get(ctx context.Context, id string) (workflow.Instance, error) {
+	var doc string
+	err := s.db.QueryRowContext(ctx, s.dialect.Rebind(`SELECT document
+		FROM workflow_instances WHERE id = ?`), id).Scan(&doc)
+	if errors.Is(err, sql.ErrNoRows) {
+		return workflow.Instance{}, workflow.ErrNotFound
+	}
+	if err != nil {
+		return workflow.Instance{}, fmt.Errorf("sqlstore: get %q: %w", id, err)
+	}
+	return decode(doc)
+}
+
+func (s *Store) Pending(ctx context.Context) ([]workflow.Instance, error) {
+	rows, err := s.db.QueryContext(ctx, s.dialect.Rebind(`SELECT document
+		FROM workflow_instances WHERE status = ? ORDER BY started_at`), string(workflow.Pending))
+	if err != nil {
+		return nil, fmt.Errorf("sqlstore: pending: %w", err)
+	}
+	defer rows.Close()
+
+	var pending []workflow.Instance
+	for rows.Next() {
+		var doc string
+		if err := rows.Scan(&doc); err != nil {
+			return nil, fmt.Errorf("sqlstore: pending: %w", err)
+		}
+		inst, err := decode(doc)
+		if err != nil {
+			return nil, err
+		}
+		pending = append(pending, inst)
+	}
+	return pending, rows.Err()
+}
+
+func decode(doc string) (workflow.Instance, error) {
+	var inst workflow.Instance
+	if err := json.Unmarshal([]byte(doc), &inst); err != nil {
+		return workflow.Instance{}, fmt.Errorf("sqlstore: decode: %w", err)
+	}
+	return inst, nil
+}
+
+// CheckHealth pings the database (health.Checker).
+func (s *Store) CheckHealth(ctx context.Context) error {
+	return s.db.PingContext(ctx)
+}
+
+var _ workflow.Store = (*Store)(nil)
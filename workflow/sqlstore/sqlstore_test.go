@@ -0,0 +1,28 @@
+package sqlstore_test
+
+import (
+	"database/sql"
+	"path/filepath"
+	"testing"
+
+	_ "modernc.org/sqlite"
+
+	"go-solid/sqldialect"
+	"go-solid/workflow"
+	"go-solid/workflow/sqlstore"
+	"go-solid/workflow/workflowtest"
+)
+
+func TestStore(t *testing.T) {
+	workflowtest.TestStore(t, func(t *testing.T) workflow.Store {
+		db, err := sql.Open("sqlite", filepath.Join(t.TempDir(), "workflow.db"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(func() { db.Close() })
+		if _, err := db.ExecContext(t.Context(), sqlstore.Schema); err != nil {
+			t.Fatal(err)
+		}
+		return sqlstore.New(db, sqldialect.SQLite{})
+	})
+}
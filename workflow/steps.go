@@ -0,0 +1,262 @@
+package workflow
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+)
+
+// ApproverSelector Strategy - decides who votes on a subject
+type ApproverSelector interface {
+	Select(ctx context.Context, subj Subject) ([]string, error)
+}
+
+// VotingRule Strategy - turns the votes cast so far into a decision
+type VotingRule interface {
+	Decide(approvers []string, votes []Vote) Status
+}
+
+// EscalationPolicy Strategy - what to do when a step has waited too long
+type EscalationPolicy interface {
+	Escalate(ctx context.Context, inst *Instance, now time.Time) (Status, error)
+}
+
+// ErrNoApprovers returned when a selector finds nobody to vote
+var ErrNoApprovers = errors.New("no approvers selected")
+
+// Approval A step decided by votes. SkipWhen lets a subject through without
+// asking anyone (short leave, small expenses); Escalation is optional.
+type Approval struct {
+	Label      string
+	Approvers  ApproverSelector
+	Rule       VotingRule
+	Escalation EscalationPolicy
+	SkipWhen   func(Subject) bool
+}
+
+func (a Approval) Name() string { return a.Label }
+
+func (a Approval) Enter(ctx context.Context, inst *Instance) (Status, error) {
+	if a.SkipWhen != nil && a.SkipWhen(inst.Subject) {
+		return Approved, nil
+	}
+	approvers, err := a.Approvers.Select(ctx, inst.Subject)
+	if err != nil {
+		return "", err
+	}
+	// requesters never approve their own subject
+	approvers = slices.DeleteFunc(approvers, func(s string) bool { return s == inst.Subject.Requester })
+	if len(approvers) == 0 {
+		return "", ErrNoApprovers
+	}
+	inst.Approvers = approvers
+	return Pending, nil
+}
+
+func (a Approval) Decide(inst *Instance) Status { return a.Rule.Decide(inst.Approvers, inst.Votes) }
+
+// Escalate makes Approval an Escalator; without a policy nothing happens.
+func (a Approval) Escalate(ctx context.Context, inst *Instance, now time.Time) (Status, error) {
+	if a.Escalation == nil {
+		return Pending, nil
+	}
+	return a.Escalation.Escalate(ctx, inst, now)
+}
+
+// Check An automatic step: rejects the subject when Allow returns an error
+type Check struct {
+	Label string
+	Allow func(ctx context.Context, subj Subject) error
+}
+
+func (c Check) Name() string { return c.Label }
+
+func (c Check) Enter(ctx context.Context, inst *Instance) (Status, error) {
+	if err := c.Allow(ctx, inst.Subject); err != nil {
+		inst.Reason = err.Error()
+		return Rejected, nil
+	}
+	return Approved, nil
+}
+
+// Decide is never reached: a Check has no approvers to vote.
+func (c Check) Decide(inst *Instance) Status { return Pending }
+
+// Approver selection
+
+// Named Always the same people
+type Named []string
+
+func (n Named) Select(ctx context.Context, subj Subject) ([]string, error) {
+	return slices.Clone(n), nil
+}
+
+// OrgChart Abstraction over reporting lines
+type OrgChart interface {
+	ManagerOf(ctx context.Context, employee string) (string, error)
+}
+
+// Reports Map-backed OrgChart: employee -> manager
+type Reports map[string]string
+
+func (r Reports) ManagerOf(ctx context.Context, employee string) (string, error) {
+	m, ok := r[employee]
+	if !ok {
+		return "", fmt.Errorf("no manager for %q", employee)
+	}
+	return m, nil
+}
+
+// Manager The requester's manager, or the manager Levels up the chart
+type Manager struct {
+	Org    OrgChart
+	Levels int
+}
+
+func (m Manager) Select(ctx context.Context, subj Subject) ([]string, error) {
+	who := subj.Requester
+	for range max(m.Levels, 1) {
+		next, err := m.Org.ManagerOf(ctx, who)
+		if err != nil {
+			return nil, err
+		}
+		who = next
+	}
+	return []string{who}, nil
+}
+
+// RoundRobin Spreads subjects over a pool, one approver each
+type RoundRobin struct {
+	mu   sync.Mutex
+	pool []string
+	next int
+}
+
+func NewRoundRobin(pool ...string) *RoundRobin { return &RoundRobin{pool: pool} }
+
+func (r *RoundRobin) Select(ctx context.Context, subj Subject) ([]string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if len(r.pool) == 0 {
+		return nil, ErrNoApprovers
+	}
+	for range len(r.pool) {
+		who := r.pool[r.next%len(r.pool)]
+		r.next++
+		if who != subj.Requester {
+			return []string{who}, nil
+		}
+	}
+	return nil, ErrNoApprovers
+}
+
+// Voting rules
+
+// AnyOne The first approval decides; rejected only once everyone has rejected
+type AnyOne struct{}
+
+func (AnyOne) Decide(approvers []string, votes []Vote) Status {
+	for _, v := range votes {
+		if v.Approve {
+			return Approved
+		}
+	}
+	if len(votes) == len(approvers) {
+		return Rejected
+	}
+	return Pending
+}
+
+// Unanimous Every approver must approve; one rejection decides
+type Unanimous struct{}
+
+func (Unanimous) Decide(approvers []string, votes []Vote) Status {
+	for _, v := range votes {
+		if !v.Approve {
+			return Rejected
+		}
+	}
+	if len(votes) == len(approvers) {
+		return Approved
+	}
+	return Pending
+}
+
+// Majority Decided as soon as more than half of the approvers agree either way
+type Majority struct{}
+
+func (Majority) Decide(approvers []string, votes []Vote) Status {
+	yes := 0
+	for _, v := range votes {
+		if v.Approve {
+			yes++
+		}
+	}
+	no := len(votes) - yes
+	switch {
+	case yes*2 > len(approvers):
+		return Approved
+	case no*2 >= len(approvers):
+		return Rejected // a tie can no longer become a majority
+	}
+	return Pending
+}
+
+// Escalation policies
+
+// EscalateTo Adds approvers each time the step has waited another After
+type EscalateTo struct {
+	After     time.Duration
+	Approvers ApproverSelector
+	// Max escalations per step; 0 means one
+	Max int
+}
+
+func (p EscalateTo) Escalate(ctx context.Context, inst *Instance, now time.Time) (Status, error) {
+	due := inst.StepStarted.Add(p.After * time.Duration(inst.Escalations+1))
+	if inst.Escalations >= max(p.Max, 1) || now.Before(due) {
+		return Pending, nil
+	}
+	extra, err := p.Approvers.Select(ctx, inst.Subject)
+	if err != nil {
+		return "", err
+	}
+	for _, who := range extra {
+		if who != inst.Subject.Requester && !slices.Contains(inst.Approvers, who) {
+			inst.Approvers = append(inst.Approvers, who)
+		}
+	}
+	return Pending, nil
+}
+
+// DecideAfter Settles the step with Outcome once it has waited After - e.g.
+// leave that nobody objected to within a week is approved
+type DecideAfter struct {
+	After   time.Duration
+	Outcome Status
+}
+
+func (p DecideAfter) Escalate(ctx context.Context, inst *Instance, now time.Time) (Status, error) {
+	if now.Before(inst.StepStarted.Add(p.After)) {
+		return Pending, nil
+	}
+	return p.Outcome, nil
+}
+
+var (
+	_ Step             = Approval{}
+	_ Step             = Check{}
+	_ Escalator        = Approval{}
+	_ ApproverSelector = Named{}
+	_ ApproverSelector = Manager{}
+	_ ApproverSelector = (*RoundRobin)(nil)
+	_ OrgChart         = Reports{}
+	_ VotingRule       = AnyOne{}
+	_ VotingRule       = Unanimous{}
+	_ VotingRule       = Majority{}
+	_ EscalationPolicy = EscalateTo{}
+	_ EscalationPolicy = DecideAfter{}
+)
@@ -0,0 +1,380 @@
+// Package workflow runs approval processes: a request goes through an ordered
+// list of Steps, each deciding on its own or collecting votes from approvers.
+//
+// Every moving part is an abstraction. What a step does (Step), who votes
+// (ApproverSelector), how votes add up (VotingRule), what happens when nobody
+// answers (EscalationPolicy), where instances are kept (Store) and who hears
+// about it (Listener) are all chosen per workflow, so leave approval, expense
+// approval or a promotion sign-off are configurations of the same Engine (OCP, DIP).
+package workflow
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"go-solid/clock"
+	"go-solid/id"
+)
+
+// Subject The thing being approved, described generically so the engine needs
+// no knowledge of leave requests, expenses...
+type Subject struct {
+	Kind      string
+	ID        string
+	Requester string
+	Attrs     map[string]string
+}
+
+type Status string
+
+const (
+	Pending  Status = "pending"
+	Approved Status = "approved"
+	Rejected Status = "rejected"
+)
+
+// Vote One approver's answer on the current step
+type Vote struct {
+	Approver string
+	Approve  bool
+	Comment  string
+	At       time.Time
+}
+
+type Action string
+
+const (
+	Started   Action = "started"
+	Assigned  Action = "assigned"
+	Voted     Action = "voted"
+	Passed    Action = "passed" // decided by the step itself, no votes needed
+	Escalated Action = "escalated"
+	Decided   Action = "decided"
+)
+
+// Entry One line of an instance's history
+type Entry struct {
+	At     time.Time
+	Step   string
+	Actor  string
+	Action Action
+	Note   string
+	// Assignees are the approvers added by Assigned and Escalated entries
+	Assignees []string `json:",omitempty"`
+}
+
+// Instance A subject's progress through a workflow
+type Instance struct {
+	ID       string
+	Workflow string
+	Subject  Subject
+	Status   Status
+	// Step is the index of the current step; Approvers and Votes belong to it
+	Step        int
+	Approvers   []string
+	Votes       []Vote
+	StepStarted time.Time
+	Escalations int
+	// Reason is set by steps that reject on their own
+	Reason  string
+	History []Entry
+	// Version is maintained by the Store: 1 on first save, +1 on every update
+	Version int
+}
+
+// Step One stage of a workflow
+type Step interface {
+	Name() string
+	// Enter is called when an instance reaches the step. A step that needs
+	// votes sets inst.Approvers and returns Pending; any other status decides
+	// the step on the spot.
+	Enter(ctx context.Context, inst *Instance) (Status, error)
+	// Decide is called after every vote on the step.
+	Decide(inst *Instance) Status
+}
+
+// Escalator Optional capability - steps that act when an instance has been
+// waiting on them too long
+type Escalator interface {
+	Escalate(ctx context.Context, inst *Instance, now time.Time) (Status, error)
+}
+
+// Definition A named, ordered list of steps
+type Definition struct {
+	Name  string
+	Steps []Step
+}
+
+// Store Abstraction over instance persistence. Save fails with ErrConflict
+// when inst.Version is not the stored version, so two approvers voting at
+// once can't overwrite each other.
+type Store interface {
+	Save(ctx context.Context, inst Instance) (Instance, error)
+	Get(ctx context.Context, id string) (Instance, error)
+	Pending(ctx context.Context) ([]Instance, error)
+}
+
+// Listener Reacts to history entries: assignments, votes, escalations and decisions
+type Listener interface {
+	OnEntry(ctx context.Context, inst Instance, e Entry) error
+}
+
+// ListenerFunc Adapter so plain functions can be used as listeners
+type ListenerFunc func(ctx context.Context, inst Instance, e Entry) error
+
+func (f ListenerFunc) OnEntry(ctx context.Context, inst Instance, e Entry) error {
+	return f(ctx, inst, e)
+}
+
+var (
+	ErrNotFound        = errors.New("workflow instance not found")
+	ErrConflict        = errors.New("workflow instance was modified concurrently")
+	ErrUnknownWorkflow = errors.New("unknown workflow")
+	ErrClosed          = errors.New("workflow instance already decided")
+	ErrNotApprover     = errors.New("not an approver on the current step")
+	ErrAlreadyVoted    = errors.New("already voted on the current step")
+)
+
+// Engine High-level module - drives instances through their definitions
+type Engine struct {
+	store     Store
+	ids       id.Generator
+	clock     clock.Clock
+	listeners []Listener
+
+	mu          sync.RWMutex
+	definitions map[string]Definition
+}
+
+// Option customises an Engine created by NewEngine
+type Option func(*Engine)
+
+func WithIDs(g id.Generator) Option  { return func(e *Engine) { e.ids = g } }
+func WithClock(c clock.Clock) Option { return func(e *Engine) { e.clock = c } }
+func WithListener(l Listener) Option { return func(e *Engine) { e.listeners = append(e.listeners, l) } }
+
+// NewEngine creates an Engine on top of store, with random UUIDs and the real
+// clock unless options say otherwise.
+func NewEngine(store Store, opts ...Option) *Engine {
+	e := &Engine{store: store, ids: id.UUID{}, clock: clock.Real{}, definitions: make(map[string]Definition)}
+	for _, opt := range opts {
+		opt(e)
+	}
+	return e
+}
+
+// Define registers a workflow. Defining the same name twice panics.
+func (e *Engine) Define(def Definition) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if _, dup := e.definitions[def.Name]; dup {
+		panic("workflow: Define called twice for " + def.Name)
+	}
+	e.definitions[def.Name] = def
+}
+
+func (e *Engine) definition(name string) (Definition, error) {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	def, ok := e.definitions[name]
+	if !ok {
+		return Definition{}, fmt.Errorf("%w %q", ErrUnknownWorkflow, name)
+	}
+	return def, nil
+}
+
+// Start opens an instance of the named workflow and runs it as far as it goes
+// without votes.
+func (e *Engine) Start(ctx context.Context, workflow string, subj Subject) (Instance, error) {
+	def, err := e.definition(workflow)
+	if err != nil {
+		return Instance{}, err
+	}
+	inst := &Instance{ID: e.ids.NewID(), Workflow: workflow, Subject: subj, Status: Pending}
+	var entries []Entry
+	record := e.recorder(inst, &entries)
+	record("", subj.Requester, Started, subj.Kind+" "+subj.ID)
+
+	if err := e.enter(ctx, def, inst, 0, record); err != nil {
+		return Instance{}, err
+	}
+	return e.save(ctx, inst, entries)
+}
+
+// Vote records an approver's answer and advances the instance when the step is decided.
+func (e *Engine) Vote(ctx context.Context, instanceID, approver string, approve bool, comment string) (Instance, error) {
+	inst, def, err := e.load(ctx, instanceID)
+	if err != nil {
+		return Instance{}, err
+	}
+	if !slices.Contains(inst.Approvers, approver) {
+		return Instance{}, fmt.Errorf("%q on %s: %w", approver, instanceID, ErrNotApprover)
+	}
+	if slices.ContainsFunc(inst.Votes, func(v Vote) bool { return v.Approver == approver }) {
+		return Instance{}, fmt.Errorf("%q on %s: %w", approver, instanceID, ErrAlreadyVoted)
+	}
+
+	var entries []Entry
+	record := e.recorder(&inst, &entries)
+	step := def.Steps[inst.Step]
+	inst.Votes = append(inst.Votes, Vote{Approver: approver, Approve: approve, Comment: comment, At: e.clock.Now()})
+	note := "rejected"
+	if approve {
+		note = "approved"
+	}
+	if comment != "" {
+		note += ": " + comment
+	}
+	record(step.Name(), approver, Voted, note)
+
+	if err := e.settle(ctx, def, &inst, step.Decide(&inst), record); err != nil {
+		return Instance{}, err
+	}
+	return e.save(ctx, &inst, entries)
+}
+
+// Escalate gives every pending instance whose current step is an Escalator
+// the chance to act, and returns how many instances changed. Call it
+// periodically, e.g. from a schedule.Scheduler.
+func (e *Engine) Escalate(ctx context.Context) (int, error) {
+	pending, err := e.store.Pending(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("workflow: pending: %w", err)
+	}
+	changed := 0
+	var errs []error
+	for _, inst := range pending {
+		def, err := e.definition(inst.Workflow)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		esc, ok := def.Steps[inst.Step].(Escalator)
+		if !ok {
+			continue
+		}
+		var entries []Entry
+		record := e.recorder(&inst, &entries)
+		before := len(inst.Approvers)
+		status, err := esc.Escalate(ctx, &inst, e.clock.Now())
+		if err != nil {
+			errs = append(errs, fmt.Errorf("escalate %s: %w", inst.ID, err))
+			continue
+		}
+		step := def.Steps[inst.Step].Name()
+		switch {
+		case status != Pending:
+			record(step, "", Escalated, "timed out, "+string(status))
+		case len(inst.Approvers) > before:
+			inst.Escalations++
+			record(step, "", Escalated, fmt.Sprintf("added %v", inst.Approvers[before:]), inst.Approvers[before:]...)
+		default:
+			continue
+		}
+		if err := e.settle(ctx, def, &inst, status, record); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if _, err := e.save(ctx, &inst, entries); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		changed++
+	}
+	return changed, errors.Join(errs...)
+}
+
+// Get returns an instance as stored.
+func (e *Engine) Get(ctx context.Context, instanceID string) (Instance, error) {
+	return e.store.Get(ctx, instanceID)
+}
+
+func (e *Engine) load(ctx context.Context, instanceID string) (Instance, Definition, error) {
+	inst, err := e.store.Get(ctx, instanceID)
+	if err != nil {
+		return Instance{}, Definition{}, err
+	}
+	if inst.Status != Pending {
+		return Instance{}, Definition{}, fmt.Errorf("%s: %w (%s)", instanceID, ErrClosed, inst.Status)
+	}
+	def, err := e.definition(inst.Workflow)
+	if err != nil {
+		return Instance{}, Definition{}, err
+	}
+	return inst, def, nil
+}
+
+// settle moves on from the current step once it is decided.
+func (e *Engine) settle(ctx context.Context, def Definition, inst *Instance, status Status, record recordFunc) error {
+	switch status {
+	case Pending:
+		return nil
+	case Rejected:
+		inst.Status = Rejected
+		record(def.Steps[inst.Step].Name(), "", Decided, string(Rejected))
+		return nil
+	}
+	return e.enter(ctx, def, inst, inst.Step+1, record)
+}
+
+// enter runs steps from index i until one waits for votes or the workflow is decided.
+func (e *Engine) enter(ctx context.Context, def Definition, inst *Instance, i int, record recordFunc) error {
+	for ; i < len(def.Steps); i++ {
+		step := def.Steps[i]
+		inst.Step, inst.Approvers, inst.Votes = i, nil, nil
+		inst.StepStarted, inst.Escalations = e.clock.Now(), 0
+
+		status, err := step.Enter(ctx, inst)
+		if err != nil {
+			return fmt.Errorf("workflow %s: step %s: %w", def.Name, step.Name(), err)
+		}
+		switch status {
+		case Pending:
+			record(step.Name(), "", Assigned, fmt.Sprintf("%v", inst.Approvers), inst.Approvers...)
+			return nil
+		case Rejected:
+			inst.Status = Rejected
+			record(step.Name(), "", Decided, strings.TrimSuffix(string(Rejected)+": "+inst.Reason, ": "))
+			return nil
+		}
+		record(step.Name(), "", Passed, "")
+	}
+	inst.Status = Approved
+	record("", "", Decided, string(Approved))
+	return nil
+}
+
+type recordFunc func(step, actor string, a Action, note string, assignees ...string)
+
+// recorder returns a function appending history entries to inst and to the
+// batch listeners will hear about once the instance is saved.
+func (e *Engine) recorder(inst *Instance, batch *[]Entry) recordFunc {
+	return func(step, actor string, a Action, note string, assignees ...string) {
+		entry := Entry{At: e.clock.Now(), Step: step, Actor: actor, Action: a, Note: note, Assignees: slices.Clone(assignees)}
+		inst.History = append(inst.History, entry)
+		*batch = append(*batch, entry)
+	}
+}
+
+// save persists inst and then tells the listeners. The saved instance is the
+// truth: listener errors are returned joined but don't undo anything.
+func (e *Engine) save(ctx context.Context, inst *Instance, entries []Entry) (Instance, error) {
+	saved, err := e.store.Save(ctx, *inst)
+	if err != nil {
+		return Instance{}, fmt.Errorf("workflow: save %s: %w", inst.ID, err)
+	}
+	var errs []error
+	for _, entry := range entries {
+		for _, l := range e.listeners {
+			if err := l.OnEntry(ctx, saved, entry); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+	return saved, errors.Join(errs...)
+}
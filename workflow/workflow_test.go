@@ -0,0 +1,331 @@
+package workflow_test
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"go-solid/clock"
+	"go-solid/id"
+	"go-solid/notify"
+	"go-solid/workflow"
+	"go-solid/workflow/memory"
+)
+
+var monday = time.Date(2026, time.March, 2, 9, 0, 0, 0, time.UTC)
+
+var leave = workflow.Subject{Kind: "leave", ID: "lv-1", Requester: "ali", Attrs: map[string]string{"days": "3"}}
+
+func newEngine(t *testing.T, defs ...workflow.Definition) (*workflow.Engine, *clock.Fake) {
+	t.Helper()
+	clk := clock.NewFake(monday)
+	e := workflow.NewEngine(memory.New(), workflow.WithIDs(id.NewSequence("wf-")), workflow.WithClock(clk))
+	for _, def := range defs {
+		e.Define(def)
+	}
+	return e, clk
+}
+
+func start(t *testing.T, e *workflow.Engine, name string) workflow.Instance {
+	t.Helper()
+	inst, err := e.Start(t.Context(), name, leave)
+	if err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+	return inst
+}
+
+func actions(inst workflow.Instance) []string {
+	var got []string
+	for _, e := range inst.History {
+		got = append(got, e.Step+" "+string(e.Action))
+	}
+	return got
+}
+
+type vote struct {
+	approver string
+	approve  bool
+}
+
+func TestEngine_VotingRules(t *testing.T) {
+	three := workflow.Named{"bea", "cal", "dan"}
+	tests := []struct {
+		name      string
+		rule      workflow.VotingRule
+		approvers workflow.Named
+		votes     []vote
+		want      workflow.Status
+	}{
+		{"any one, first yes", workflow.AnyOne{}, three, []vote{{"cal", true}}, workflow.Approved},
+		{"any one, some no", workflow.AnyOne{}, three, []vote{{"bea", false}, {"cal", false}}, workflow.Pending},
+		{"any one, all no", workflow.AnyOne{}, three, []vote{{"bea", false}, {"cal", false}, {"dan", false}}, workflow.Rejected},
+		{"unanimous, some yes", workflow.Unanimous{}, three, []vote{{"bea", true}, {"cal", true}}, workflow.Pending},
+		{"unanimous, all yes", workflow.Unanimous{}, three, []vote{{"bea", true}, {"cal", true}, {"dan", true}}, workflow.Approved},
+		{"unanimous, one no", workflow.Unanimous{}, three, []vote{{"bea", true}, {"cal", false}}, workflow.Rejected},
+		{"majority yes", workflow.Majority{}, three, []vote{{"bea", true}, {"dan", true}}, workflow.Approved},
+		{"majority no", workflow.Majority{}, three, []vote{{"bea", false}, {"dan", false}}, workflow.Rejected},
+		{"majority undecided", workflow.Majority{}, three, []vote{{"bea", true}, {"dan", false}}, workflow.Pending},
+		{"majority tied", workflow.Majority{}, workflow.Named{"bea", "cal", "dan", "eve"}, []vote{{"bea", true}, {"cal", false}, {"dan", true}, {"eve", false}}, workflow.Rejected},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e, _ := newEngine(t, workflow.Definition{Name: "leave", Steps: []workflow.Step{
+				workflow.Approval{Label: "team", Approvers: tt.approvers, Rule: tt.rule},
+			}})
+			inst := start(t, e, "leave")
+			var err error
+			for _, v := range tt.votes {
+				if inst, err = e.Vote(t.Context(), inst.ID, v.approver, v.approve, ""); err != nil {
+					t.Fatalf("Vote(%s) error = %v", v.approver, err)
+				}
+			}
+			if inst.Status != tt.want {
+				t.Errorf("after %v: Status = %s, want %s", tt.votes, inst.Status, tt.want)
+			}
+		})
+	}
+}
+
+func TestEngine_Steps(t *testing.T) {
+	e, _ := newEngine(t, workflow.Definition{Name: "expense", Steps: []workflow.Step{
+		workflow.Check{Label: "budget", Allow: func(context.Context, workflow.Subject) error { return nil }},
+		workflow.Approval{Label: "manager", Approvers: workflow.Manager{Org: workflow.Reports{"ali": "bea"}}, Rule: workflow.AnyOne{}},
+		workflow.Approval{Label: "finance", Approvers: workflow.Named{"ali", "fay"}, Rule: workflow.Unanimous{}},
+	}})
+	inst := start(t, e, "expense")
+	if !slices.Equal(inst.Approvers, []string{"bea"}) || inst.Step != 1 || inst.Version != 1 {
+		t.Fatalf("Start() = step %d, approvers %v, version %d; want the manager asked at version 1", inst.Step, inst.Approvers, inst.Version)
+	}
+	inst, err := e.Vote(t.Context(), inst.ID, "bea", true, "fine")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the requester is never asked to approve their own expense
+	if !slices.Equal(inst.Approvers, []string{"fay"}) {
+		t.Errorf("finance approvers = %v, want [fay]", inst.Approvers)
+	}
+	if inst, err = e.Vote(t.Context(), inst.ID, "fay", true, ""); err != nil {
+		t.Fatal(err)
+	}
+	want := []string{" started", "budget passed", "manager assigned", "manager voted", "finance assigned", "finance voted", " decided"}
+	if got := actions(inst); inst.Status != workflow.Approved || !slices.Equal(got, want) {
+		t.Errorf("Status = %s, history %q; want approved after %q", inst.Status, got, want)
+	}
+	if note := inst.History[3].Note; note != "approved: fine" {
+		t.Errorf("vote note = %q, want the answer and comment", note)
+	}
+	if stored, err := e.Get(t.Context(), inst.ID); err != nil || stored.Version != 3 || stored.Status != workflow.Approved {
+		t.Errorf("Get() = version %d, %s, %v; want the decided instance at version 3", stored.Version, stored.Status, err)
+	}
+}
+
+func TestEngine_DecidedWithoutVotes(t *testing.T) {
+	overBudget := errors.New("over budget")
+	e, _ := newEngine(t,
+		workflow.Definition{Name: "expense", Steps: []workflow.Step{
+			workflow.Check{Label: "budget", Allow: func(context.Context, workflow.Subject) error { return overBudget }},
+			workflow.Approval{Label: "manager", Approvers: workflow.Named{"bea"}, Rule: workflow.AnyOne{}},
+		}},
+		workflow.Definition{Name: "short-leave", Steps: []workflow.Step{
+			workflow.Approval{Label: "manager", Approvers: workflow.Named{"bea"}, Rule: workflow.AnyOne{},
+				SkipWhen: func(s workflow.Subject) bool { days, _ := strconv.Atoi(s.Attrs["days"]); return days < 5 }},
+		}},
+	)
+
+	inst := start(t, e, "expense")
+	if inst.Status != workflow.Rejected || inst.Reason != "over budget" || inst.History[len(inst.History)-1].Note != "rejected: over budget" {
+		t.Errorf("Start() = %s, reason %q, history %q; want rejected by the check", inst.Status, inst.Reason, actions(inst))
+	}
+	if _, err := e.Vote(t.Context(), inst.ID, "bea", true, ""); !errors.Is(err, workflow.ErrClosed) {
+		t.Errorf("Vote() on a rejected instance error = %v, want %v", err, workflow.ErrClosed)
+	}
+
+	inst = start(t, e, "short-leave")
+	if want := []string{" started", "manager passed", " decided"}; inst.Status != workflow.Approved || !slices.Equal(actions(inst), want) {
+		t.Errorf("Start() = %s, history %q; want approved, skipping %q", inst.Status, actions(inst), want)
+	}
+}
+
+func TestEngine_Errors(t *testing.T) {
+	e, _ := newEngine(t,
+		workflow.Definition{Name: "leave", Steps: []workflow.Step{
+			workflow.Approval{Label: "team", Approvers: workflow.Named{"bea", "cal"}, Rule: workflow.Unanimous{}},
+		}},
+		workflow.Definition{Name: "self", Steps: []workflow.Step{
+			workflow.Approval{Label: "self", Approvers: workflow.Named{"ali"}, Rule: workflow.AnyOne{}},
+		}},
+	)
+	inst := start(t, e, "leave")
+	if _, err := e.Vote(t.Context(), inst.ID, "bea", true, ""); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		err     error
+		wantErr error
+	}{
+		{"unknown workflow", errOf(e.Start(t.Context(), "travel", leave)), workflow.ErrUnknownWorkflow},
+		{"requester is the only approver", errOf(e.Start(t.Context(), "self", leave)), workflow.ErrNoApprovers},
+		{"unknown instance", errOf(e.Vote(t.Context(), "wf-99", "bea", true, "")), workflow.ErrNotFound},
+		{"not an approver", errOf(e.Vote(t.Context(), inst.ID, "ali", true, "")), workflow.ErrNotApprover},
+		{"voted twice", errOf(e.Vote(t.Context(), inst.ID, "bea", false, "")), workflow.ErrAlreadyVoted},
+	}
+	for _, tt := range tests {
+		if !errors.Is(tt.err, tt.wantErr) {
+			t.Errorf("%s: error = %v, want %v", tt.name, tt.err, tt.wantErr)
+		}
+	}
+	if got, _ := e.Get(t.Context(), inst.ID); len(got.Votes) != 1 || got.Version != 2 {
+		t.Errorf("Get() = %d votes at version %d, want the refused votes not saved", len(got.Votes), got.Version)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("Define() twice didn't panic")
+		}
+	}()
+	e.Define(workflow.Definition{Name: "leave"})
+}
+
+func errOf(_ workflow.Instance, err error) error { return err }
+
+func TestSelectors(t *testing.T) {
+	org := workflow.Reports{"ali": "bea", "bea": "cal"}
+	tests := []struct {
+		name     string
+		selector workflow.ApproverSelector
+		want     []string
+		wantErr  bool
+	}{
+		{"named", workflow.Named{"bea", "cal"}, []string{"bea", "cal"}, false},
+		{"manager", workflow.Manager{Org: org}, []string{"bea"}, false},
+		{"manager's manager", workflow.Manager{Org: org, Levels: 2}, []string{"cal"}, false},
+		{"past the top", workflow.Manager{Org: org, Levels: 3}, nil, true},
+	}
+	for _, tt := range tests {
+		got, err := tt.selector.Select(t.Context(), leave)
+		if !slices.Equal(got, tt.want) || (err != nil) != tt.wantErr {
+			t.Errorf("%s: Select() = %v, %v, want %v", tt.name, got, err, tt.want)
+		}
+	}
+
+	rr := workflow.NewRoundRobin("bea", "ali", "cal")
+	var got []string
+	for range 4 {
+		who, err := rr.Select(t.Context(), leave)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got = append(got, who...)
+	}
+	if want := []string{"bea", "cal", "bea", "cal"}; !slices.Equal(got, want) {
+		t.Errorf("RoundRobin gave %v, want %v, skipping the requester", got, want)
+	}
+	for _, pool := range [][]string{nil, {"ali"}} {
+		if _, err := workflow.NewRoundRobin(pool...).Select(t.Context(), leave); !errors.Is(err, workflow.ErrNoApprovers) {
+			t.Errorf("RoundRobin(%v) error = %v, want %v", pool, err, workflow.ErrNoApprovers)
+		}
+	}
+}
+
+func TestEngine_Escalate(t *testing.T) {
+	e, clk := newEngine(t,
+		workflow.Definition{Name: "leave", Steps: []workflow.Step{
+			workflow.Approval{Label: "team", Approvers: workflow.Named{"bea"}, Rule: workflow.AnyOne{},
+				Escalation: workflow.EscalateTo{After: 24 * time.Hour, Approvers: workflow.Named{"bea", "ali", "cal", "dan"}, Max: 2}},
+		}},
+		workflow.Definition{Name: "quiet", Steps: []workflow.Step{
+			workflow.Approval{Label: "team", Approvers: workflow.Named{"bea"}, Rule: workflow.AnyOne{},
+				Escalation: workflow.DecideAfter{After: 7 * 24 * time.Hour, Outcome: workflow.Approved}},
+		}},
+		workflow.Definition{Name: "patient", Steps: []workflow.Step{
+			workflow.Approval{Label: "team", Approvers: workflow.Named{"bea"}, Rule: workflow.AnyOne{}},
+		}},
+	)
+	escalated, quiet, patient := start(t, e, "leave"), start(t, e, "quiet"), start(t, e, "patient")
+
+	escalate := func(want int) {
+		t.Helper()
+		if n, err := e.Escalate(t.Context()); n != want || err != nil {
+			t.Fatalf("Escalate() at %v = %d, %v, want %d changed", clk.Now().Sub(monday), n, err, want)
+		}
+	}
+	escalate(0)
+	clk.Advance(24 * time.Hour)
+	escalate(1)
+	escalate(0)
+	got, _ := e.Get(t.Context(), escalated.ID)
+	// bea is already asked and ali is the requester
+	if !slices.Equal(got.Approvers, []string{"bea", "cal", "dan"}) || got.Escalations != 1 {
+		t.Errorf("after a day: approvers %v, escalations %d; want cal and dan added once", got.Approvers, got.Escalations)
+	}
+	if last := got.History[len(got.History)-1]; last.Action != workflow.Escalated || !slices.Equal(last.Assignees, []string{"cal", "dan"}) {
+		t.Errorf("last entry = %+v, want the escalation to cal and dan", last)
+	}
+
+	clk.Advance(24 * time.Hour)
+	escalate(0) // nobody left to add
+	clk.Advance(6 * 24 * time.Hour)
+	escalate(1)
+	if got, _ := e.Get(t.Context(), quiet.ID); got.Status != workflow.Approved {
+		t.Errorf("after a week: %s, want approved by DecideAfter", got.Status)
+	}
+	if got, _ := e.Get(t.Context(), patient.ID); got.Status != workflow.Pending || got.Version != 1 {
+		t.Errorf("a step without escalation = %s at version %d, want it left alone", got.Status, got.Version)
+	}
+	if _, err := e.Vote(t.Context(), escalated.ID, "dan", true, ""); err != nil {
+		t.Errorf("Vote() by an escalated approver error = %v", err)
+	}
+}
+
+// inbox records what it is asked to send, and fails for Fails.
+type inbox struct {
+	mu    sync.Mutex
+	sent  []string
+	Fails string
+}
+
+func (b *inbox) Notify(_ context.Context, msg notify.Message) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if msg.To == b.Fails {
+		return errors.New("mailbox full")
+	}
+	b.sent = append(b.sent, msg.To+": "+msg.Subject)
+	return nil
+}
+
+func TestNotifications(t *testing.T) {
+	box := &inbox{Fails: "cal"}
+	clk := clock.NewFake(monday)
+	e := workflow.NewEngine(memory.New(), workflow.WithClock(clk), workflow.WithListener(workflow.Notifications{Notifier: box}))
+	e.Define(workflow.Definition{Name: "leave", Steps: []workflow.Step{
+		workflow.Approval{Label: "team", Approvers: workflow.Named{"bea"}, Rule: workflow.Unanimous{},
+			Escalation: workflow.EscalateTo{After: time.Hour, Approvers: workflow.Named{"cal", "dan"}}},
+	}})
+	inst := start(t, e, "leave")
+	clk.Advance(time.Hour)
+	if _, err := e.Escalate(t.Context()); err == nil {
+		t.Error("Escalate() error = nil, want cal's mailbox error")
+	}
+	if got, _ := e.Get(t.Context(), inst.ID); !slices.Contains(got.Approvers, "cal") {
+		t.Errorf("approvers = %v, want cal saved though their notification failed", got.Approvers)
+	}
+	if _, err := e.Vote(t.Context(), inst.ID, "dan", false, "short-staffed"); err != nil {
+		t.Fatal(err)
+	}
+	want := []string{
+		"bea: Approval needed: leave lv-1",
+		"dan: Approval needed: leave lv-1",
+		"ali: Your leave lv-1",
+	}
+	if !slices.Equal(box.sent, want) {
+		t.Errorf("sent %q, want %q", box.sent, want)
+	}
+}
@@ -0,0 +1,125 @@
+// Package workflowtest is the contract every workflow.Store keeps, as a test
+// suite any backend runs against itself:
+//
+//	func TestStore(t *testing.T) {
+//		workflowtest.TestStore(t, func(t *testing.T) workflow.Store { return memory.New() })
+//	}
+//
+// open is called once per case and must return an empty store.
+package workflowtest
+
+import (
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"go-solid/workflow"
+)
+
+// started A fixed time in the precision databases keep
+var started = time.Date(2026, time.March, 2, 9, 0, 0, 0, time.UTC)
+
+func instance(id string, at time.Time) workflow.Instance {
+	return workflow.Instance{
+		ID:        id,
+		Workflow:  "leave",
+		Subject:   workflow.Subject{Kind: "leave", ID: "lv-" + id, Requester: "ali", Attrs: map[string]string{"days": "3"}},
+		Status:    workflow.Pending,
+		Approvers: []string{"bea", "cal"},
+		Votes:     []workflow.Vote{{Approver: "bea", Approve: true, Comment: "fine", At: at}},
+		History: []workflow.Entry{
+			{At: at, Actor: "ali", Action: workflow.Started, Note: "leave lv-" + id},
+			{At: at, Step: "team", Action: workflow.Assigned, Assignees: []string{"bea", "cal"}},
+		},
+		StepStarted: at,
+	}
+}
+
+// TestStore runs the contract against the stores open returns.
+func TestStore(t *testing.T, open func(t *testing.T) workflow.Store) {
+	t.Run("save then get", func(t *testing.T) {
+		store := open(t)
+		want := instance("wf-1", started)
+		saved, err := store.Save(t.Context(), want)
+		if err != nil || saved.Version != 1 {
+			t.Fatalf("Save() = version %d, %v, want version 1", saved.Version, err)
+		}
+		got, err := store.Get(t.Context(), want.ID)
+		if err != nil {
+			t.Fatalf("Get() error = %v", err)
+		}
+		if got.Version != 1 || got.Subject.Attrs["days"] != "3" || !slices.Equal(got.Approvers, want.Approvers) ||
+			len(got.Votes) != 1 || got.Votes[0].Comment != "fine" || !got.StepStarted.Equal(started) ||
+			len(got.History) != 2 || !slices.Equal(got.History[1].Assignees, want.History[1].Assignees) {
+			t.Errorf("Get() = %+v, want what was saved: %+v", got, want)
+		}
+	})
+
+	t.Run("missing", func(t *testing.T) {
+		if _, err := open(t).Get(t.Context(), "wf-404"); !errors.Is(err, workflow.ErrNotFound) {
+			t.Errorf("Get() error = %v, want %v", err, workflow.ErrNotFound)
+		}
+	})
+
+	t.Run("stale version", func(t *testing.T) {
+		store := open(t)
+		first, err := store.Save(t.Context(), instance("wf-1", started))
+		if err != nil {
+			t.Fatal(err)
+		}
+		first.Votes = nil
+		if _, err := store.Save(t.Context(), first); err != nil {
+			t.Fatalf("Save() at the stored version error = %v", err)
+		}
+		first.Status = workflow.Approved
+		if _, err := store.Save(t.Context(), first); !errors.Is(err, workflow.ErrConflict) {
+			t.Errorf("Save() at an old version error = %v, want %v", err, workflow.ErrConflict)
+		}
+		if got, _ := store.Get(t.Context(), first.ID); got.Version != 2 || got.Status != workflow.Pending || len(got.Votes) != 0 {
+			t.Errorf("Get() = version %d, %s, %d votes; want the second save kept", got.Version, got.Status, len(got.Votes))
+		}
+	})
+
+	t.Run("saved copies", func(t *testing.T) {
+		store := open(t)
+		inst := instance("wf-1", started)
+		if _, err := store.Save(t.Context(), inst); err != nil {
+			t.Fatal(err)
+		}
+		inst.Approvers[0] = "eve"
+		inst.Subject.Attrs["days"] = "30"
+		got, _ := store.Get(t.Context(), inst.ID)
+		got.History[0].Note = "changed"
+		if got.Approvers[0] != "bea" || got.Subject.Attrs["days"] != "3" {
+			t.Errorf("Get() = %v, %v; want the store unaffected by the caller's instance", got.Approvers, got.Subject.Attrs)
+		}
+		if again, _ := store.Get(t.Context(), inst.ID); again.History[0].Note == "changed" {
+			t.Error("Get() shares its history with an earlier Get()")
+		}
+	})
+
+	t.Run("pending", func(t *testing.T) {
+		store := open(t)
+		for i, id := range []string{"wf-2", "wf-3", "wf-1"} {
+			inst := instance(id, started.Add(time.Duration(i)*time.Hour))
+			if id == "wf-3" {
+				inst.Status = workflow.Rejected
+			}
+			if _, err := store.Save(t.Context(), inst); err != nil {
+				t.Fatal(err)
+			}
+		}
+		pending, err := store.Pending(t.Context())
+		if err != nil {
+			t.Fatalf("Pending() error = %v", err)
+		}
+		var got []string
+		for _, inst := range pending {
+			got = append(got, inst.ID)
+		}
+		if want := []string{"wf-2", "wf-1"}; !slices.Equal(got, want) {
+			t.Errorf("Pending() = %v, want the undecided instances oldest first: %v", got, want)
+		}
+	})
+}